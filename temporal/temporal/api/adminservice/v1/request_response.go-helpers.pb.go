@@ -3445,3 +3445,891 @@ func (this *MigrateScheduleResponse) Equal(that interface{}) bool {
 
 	return proto.Equal(this, that1)
 }
+
+// Marshal an object of type FaultInjectionRule to the protobuf v3 wire format
+func (val *FaultInjectionRule) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type FaultInjectionRule from the protobuf v3 wire format
+func (val *FaultInjectionRule) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *FaultInjectionRule) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two FaultInjectionRule values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *FaultInjectionRule) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *FaultInjectionRule
+	switch t := that.(type) {
+	case *FaultInjectionRule:
+		that1 = t
+	case FaultInjectionRule:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
+
+// Marshal an object of type FaultInjectionLatency to the protobuf v3 wire format
+func (val *FaultInjectionLatency) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type FaultInjectionLatency from the protobuf v3 wire format
+func (val *FaultInjectionLatency) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *FaultInjectionLatency) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two FaultInjectionLatency values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *FaultInjectionLatency) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *FaultInjectionLatency
+	switch t := that.(type) {
+	case *FaultInjectionLatency:
+		that1 = t
+	case FaultInjectionLatency:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
+
+// Marshal an object of type FaultInjectionWindow to the protobuf v3 wire format
+func (val *FaultInjectionWindow) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type FaultInjectionWindow from the protobuf v3 wire format
+func (val *FaultInjectionWindow) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *FaultInjectionWindow) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two FaultInjectionWindow values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *FaultInjectionWindow) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *FaultInjectionWindow
+	switch t := that.(type) {
+	case *FaultInjectionWindow:
+		that1 = t
+	case FaultInjectionWindow:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
+
+// Marshal an object of type ListFaultInjectionRulesRequest to the protobuf v3 wire format
+func (val *ListFaultInjectionRulesRequest) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type ListFaultInjectionRulesRequest from the protobuf v3 wire format
+func (val *ListFaultInjectionRulesRequest) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *ListFaultInjectionRulesRequest) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two ListFaultInjectionRulesRequest values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *ListFaultInjectionRulesRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *ListFaultInjectionRulesRequest
+	switch t := that.(type) {
+	case *ListFaultInjectionRulesRequest:
+		that1 = t
+	case ListFaultInjectionRulesRequest:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
+
+// Marshal an object of type ListFaultInjectionRulesResponse to the protobuf v3 wire format
+func (val *ListFaultInjectionRulesResponse) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type ListFaultInjectionRulesResponse from the protobuf v3 wire format
+func (val *ListFaultInjectionRulesResponse) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *ListFaultInjectionRulesResponse) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two ListFaultInjectionRulesResponse values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *ListFaultInjectionRulesResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *ListFaultInjectionRulesResponse
+	switch t := that.(type) {
+	case *ListFaultInjectionRulesResponse:
+		that1 = t
+	case ListFaultInjectionRulesResponse:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
+
+// Marshal an object of type AddFaultInjectionRuleRequest to the protobuf v3 wire format
+func (val *AddFaultInjectionRuleRequest) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type AddFaultInjectionRuleRequest from the protobuf v3 wire format
+func (val *AddFaultInjectionRuleRequest) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *AddFaultInjectionRuleRequest) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two AddFaultInjectionRuleRequest values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *AddFaultInjectionRuleRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *AddFaultInjectionRuleRequest
+	switch t := that.(type) {
+	case *AddFaultInjectionRuleRequest:
+		that1 = t
+	case AddFaultInjectionRuleRequest:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
+
+// Marshal an object of type AddFaultInjectionRuleResponse to the protobuf v3 wire format
+func (val *AddFaultInjectionRuleResponse) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type AddFaultInjectionRuleResponse from the protobuf v3 wire format
+func (val *AddFaultInjectionRuleResponse) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *AddFaultInjectionRuleResponse) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two AddFaultInjectionRuleResponse values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *AddFaultInjectionRuleResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *AddFaultInjectionRuleResponse
+	switch t := that.(type) {
+	case *AddFaultInjectionRuleResponse:
+		that1 = t
+	case AddFaultInjectionRuleResponse:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
+
+// Marshal an object of type ClearFaultInjectionRulesRequest to the protobuf v3 wire format
+func (val *ClearFaultInjectionRulesRequest) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type ClearFaultInjectionRulesRequest from the protobuf v3 wire format
+func (val *ClearFaultInjectionRulesRequest) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *ClearFaultInjectionRulesRequest) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two ClearFaultInjectionRulesRequest values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *ClearFaultInjectionRulesRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *ClearFaultInjectionRulesRequest
+	switch t := that.(type) {
+	case *ClearFaultInjectionRulesRequest:
+		that1 = t
+	case ClearFaultInjectionRulesRequest:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
+
+// Marshal an object of type ClearFaultInjectionRulesResponse to the protobuf v3 wire format
+func (val *ClearFaultInjectionRulesResponse) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type ClearFaultInjectionRulesResponse from the protobuf v3 wire format
+func (val *ClearFaultInjectionRulesResponse) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *ClearFaultInjectionRulesResponse) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two ClearFaultInjectionRulesResponse values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *ClearFaultInjectionRulesResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *ClearFaultInjectionRulesResponse
+	switch t := that.(type) {
+	case *ClearFaultInjectionRulesResponse:
+		that1 = t
+	case ClearFaultInjectionRulesResponse:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
+
+// Marshal an object of type AggregateWorkflowExecutionsRequest to the protobuf v3 wire format
+func (val *AggregateWorkflowExecutionsRequest) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type AggregateWorkflowExecutionsRequest from the protobuf v3 wire format
+func (val *AggregateWorkflowExecutionsRequest) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *AggregateWorkflowExecutionsRequest) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two AggregateWorkflowExecutionsRequest values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *AggregateWorkflowExecutionsRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *AggregateWorkflowExecutionsRequest
+	switch t := that.(type) {
+	case *AggregateWorkflowExecutionsRequest:
+		that1 = t
+	case AggregateWorkflowExecutionsRequest:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
+
+// Marshal an object of type AggregateWorkflowExecutionsResponse to the protobuf v3 wire format
+func (val *AggregateWorkflowExecutionsResponse) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type AggregateWorkflowExecutionsResponse from the protobuf v3 wire format
+func (val *AggregateWorkflowExecutionsResponse) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *AggregateWorkflowExecutionsResponse) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two AggregateWorkflowExecutionsResponse values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *AggregateWorkflowExecutionsResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *AggregateWorkflowExecutionsResponse
+	switch t := that.(type) {
+	case *AggregateWorkflowExecutionsResponse:
+		that1 = t
+	case AggregateWorkflowExecutionsResponse:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
+
+// Marshal an object of type AggregationGroup to the protobuf v3 wire format
+func (val *AggregationGroup) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type AggregationGroup from the protobuf v3 wire format
+func (val *AggregationGroup) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *AggregationGroup) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two AggregationGroup values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *AggregationGroup) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *AggregationGroup
+	switch t := that.(type) {
+	case *AggregationGroup:
+		that1 = t
+	case AggregationGroup:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
+
+// Marshal an object of type ExplainVisibilityQueryRequest to the protobuf v3 wire format
+func (val *ExplainVisibilityQueryRequest) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type ExplainVisibilityQueryRequest from the protobuf v3 wire format
+func (val *ExplainVisibilityQueryRequest) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *ExplainVisibilityQueryRequest) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two ExplainVisibilityQueryRequest values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *ExplainVisibilityQueryRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *ExplainVisibilityQueryRequest
+	switch t := that.(type) {
+	case *ExplainVisibilityQueryRequest:
+		that1 = t
+	case ExplainVisibilityQueryRequest:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
+
+// Marshal an object of type ExplainVisibilityQueryResponse to the protobuf v3 wire format
+func (val *ExplainVisibilityQueryResponse) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type ExplainVisibilityQueryResponse from the protobuf v3 wire format
+func (val *ExplainVisibilityQueryResponse) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *ExplainVisibilityQueryResponse) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two ExplainVisibilityQueryResponse values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *ExplainVisibilityQueryResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *ExplainVisibilityQueryResponse
+	switch t := that.(type) {
+	case *ExplainVisibilityQueryResponse:
+		that1 = t
+	case ExplainVisibilityQueryResponse:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
+
+// Marshal an object of type ExplainedSearchAttribute to the protobuf v3 wire format
+func (val *ExplainedSearchAttribute) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type ExplainedSearchAttribute from the protobuf v3 wire format
+func (val *ExplainedSearchAttribute) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *ExplainedSearchAttribute) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two ExplainedSearchAttribute values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *ExplainedSearchAttribute) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *ExplainedSearchAttribute
+	switch t := that.(type) {
+	case *ExplainedSearchAttribute:
+		that1 = t
+	case ExplainedSearchAttribute:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
+
+// Marshal an object of type RehydrateWorkflowExecutionRequest to the protobuf v3 wire format
+func (val *RehydrateWorkflowExecutionRequest) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type RehydrateWorkflowExecutionRequest from the protobuf v3 wire format
+func (val *RehydrateWorkflowExecutionRequest) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *RehydrateWorkflowExecutionRequest) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two RehydrateWorkflowExecutionRequest values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *RehydrateWorkflowExecutionRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *RehydrateWorkflowExecutionRequest
+	switch t := that.(type) {
+	case *RehydrateWorkflowExecutionRequest:
+		that1 = t
+	case RehydrateWorkflowExecutionRequest:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
+
+// Marshal an object of type RehydrateWorkflowExecutionResponse to the protobuf v3 wire format
+func (val *RehydrateWorkflowExecutionResponse) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type RehydrateWorkflowExecutionResponse from the protobuf v3 wire format
+func (val *RehydrateWorkflowExecutionResponse) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *RehydrateWorkflowExecutionResponse) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two RehydrateWorkflowExecutionResponse values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *RehydrateWorkflowExecutionResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *RehydrateWorkflowExecutionResponse
+	switch t := that.(type) {
+	case *RehydrateWorkflowExecutionResponse:
+		that1 = t
+	case RehydrateWorkflowExecutionResponse:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
+
+// Marshal an object of type VerifyArchivedHistoryRequest to the protobuf v3 wire format
+func (val *VerifyArchivedHistoryRequest) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type VerifyArchivedHistoryRequest from the protobuf v3 wire format
+func (val *VerifyArchivedHistoryRequest) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *VerifyArchivedHistoryRequest) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two VerifyArchivedHistoryRequest values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *VerifyArchivedHistoryRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *VerifyArchivedHistoryRequest
+	switch t := that.(type) {
+	case *VerifyArchivedHistoryRequest:
+		that1 = t
+	case VerifyArchivedHistoryRequest:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
+
+// Marshal an object of type VerifyArchivedHistoryResponse to the protobuf v3 wire format
+func (val *VerifyArchivedHistoryResponse) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type VerifyArchivedHistoryResponse from the protobuf v3 wire format
+func (val *VerifyArchivedHistoryResponse) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *VerifyArchivedHistoryResponse) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two VerifyArchivedHistoryResponse values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *VerifyArchivedHistoryResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *VerifyArchivedHistoryResponse
+	switch t := that.(type) {
+	case *VerifyArchivedHistoryResponse:
+		that1 = t
+	case VerifyArchivedHistoryResponse:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
+
+// Marshal an object of type ArchivedHistoryVerification to the protobuf v3 wire format
+func (val *ArchivedHistoryVerification) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type ArchivedHistoryVerification from the protobuf v3 wire format
+func (val *ArchivedHistoryVerification) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *ArchivedHistoryVerification) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two ArchivedHistoryVerification values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *ArchivedHistoryVerification) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *ArchivedHistoryVerification
+	switch t := that.(type) {
+	case *ArchivedHistoryVerification:
+		that1 = t
+	case ArchivedHistoryVerification:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
+
+// Marshal an object of type GetArchivedChasmExecutionRequest to the protobuf v3 wire format
+func (val *GetArchivedChasmExecutionRequest) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type GetArchivedChasmExecutionRequest from the protobuf v3 wire format
+func (val *GetArchivedChasmExecutionRequest) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *GetArchivedChasmExecutionRequest) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two GetArchivedChasmExecutionRequest values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *GetArchivedChasmExecutionRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *GetArchivedChasmExecutionRequest
+	switch t := that.(type) {
+	case *GetArchivedChasmExecutionRequest:
+		that1 = t
+	case GetArchivedChasmExecutionRequest:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
+
+// Marshal an object of type GetArchivedChasmExecutionResponse to the protobuf v3 wire format
+func (val *GetArchivedChasmExecutionResponse) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type GetArchivedChasmExecutionResponse from the protobuf v3 wire format
+func (val *GetArchivedChasmExecutionResponse) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *GetArchivedChasmExecutionResponse) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two GetArchivedChasmExecutionResponse values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *GetArchivedChasmExecutionResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *GetArchivedChasmExecutionResponse
+	switch t := that.(type) {
+	case *GetArchivedChasmExecutionResponse:
+		that1 = t
+	case GetArchivedChasmExecutionResponse:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
+
+// Marshal an object of type AdvanceNamespaceTimeRequest to the protobuf v3 wire format
+func (val *AdvanceNamespaceTimeRequest) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type AdvanceNamespaceTimeRequest from the protobuf v3 wire format
+func (val *AdvanceNamespaceTimeRequest) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *AdvanceNamespaceTimeRequest) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two AdvanceNamespaceTimeRequest values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *AdvanceNamespaceTimeRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *AdvanceNamespaceTimeRequest
+	switch t := that.(type) {
+	case *AdvanceNamespaceTimeRequest:
+		that1 = t
+	case AdvanceNamespaceTimeRequest:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
+
+// Marshal an object of type AdvanceNamespaceTimeResponse to the protobuf v3 wire format
+func (val *AdvanceNamespaceTimeResponse) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type AdvanceNamespaceTimeResponse from the protobuf v3 wire format
+func (val *AdvanceNamespaceTimeResponse) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *AdvanceNamespaceTimeResponse) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two AdvanceNamespaceTimeResponse values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *AdvanceNamespaceTimeResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *AdvanceNamespaceTimeResponse
+	switch t := that.(type) {
+	case *AdvanceNamespaceTimeResponse:
+		that1 = t
+	case AdvanceNamespaceTimeResponse:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
@@ -65,6 +65,15 @@ const (
 	AdminService_DescribeTaskQueuePartition_FullMethodName          = "/temporal.server.api.adminservice.v1.AdminService/DescribeTaskQueuePartition"
 	AdminService_ForceUnloadTaskQueuePartition_FullMethodName       = "/temporal.server.api.adminservice.v1.AdminService/ForceUnloadTaskQueuePartition"
 	AdminService_MigrateSchedule_FullMethodName                     = "/temporal.server.api.adminservice.v1.AdminService/MigrateSchedule"
+	AdminService_ListFaultInjectionRules_FullMethodName             = "/temporal.server.api.adminservice.v1.AdminService/ListFaultInjectionRules"
+	AdminService_AddFaultInjectionRule_FullMethodName               = "/temporal.server.api.adminservice.v1.AdminService/AddFaultInjectionRule"
+	AdminService_ClearFaultInjectionRules_FullMethodName            = "/temporal.server.api.adminservice.v1.AdminService/ClearFaultInjectionRules"
+	AdminService_AggregateWorkflowExecutions_FullMethodName         = "/temporal.server.api.adminservice.v1.AdminService/AggregateWorkflowExecutions"
+	AdminService_ExplainVisibilityQuery_FullMethodName              = "/temporal.server.api.adminservice.v1.AdminService/ExplainVisibilityQuery"
+	AdminService_RehydrateWorkflowExecution_FullMethodName          = "/temporal.server.api.adminservice.v1.AdminService/RehydrateWorkflowExecution"
+	AdminService_VerifyArchivedHistory_FullMethodName               = "/temporal.server.api.adminservice.v1.AdminService/VerifyArchivedHistory"
+	AdminService_GetArchivedChasmExecution_FullMethodName           = "/temporal.server.api.adminservice.v1.AdminService/GetArchivedChasmExecution"
+	AdminService_AdvanceNamespaceTime_FullMethodName                = "/temporal.server.api.adminservice.v1.AdminService/AdvanceNamespaceTime"
 )
 
 // AdminServiceClient is the client API for AdminService service.
@@ -123,9 +132,7 @@ type AdminServiceClient interface {
 	// GetDLQMessages returns messages from DLQ.
 	GetDLQMessages(ctx context.Context, in *GetDLQMessagesRequest, opts ...grpc.CallOption) (*GetDLQMessagesResponse, error)
 	// (-- api-linter: core::0165::response-message-name=disabled
-	//
-	//	aip.dev/not-precedent:  --)
-	//
+	//     aip.dev/not-precedent:  --)
 	// PurgeDLQMessages purges messages from DLQ.
 	PurgeDLQMessages(ctx context.Context, in *PurgeDLQMessagesRequest, opts ...grpc.CallOption) (*PurgeDLQMessagesResponse, error)
 	// MergeDLQMessages merges messages from DLQ.
@@ -144,8 +151,7 @@ type AdminServiceClient interface {
 	GetNamespace(ctx context.Context, in *GetNamespaceRequest, opts ...grpc.CallOption) (*GetNamespaceResponse, error)
 	GetDLQTasks(ctx context.Context, in *GetDLQTasksRequest, opts ...grpc.CallOption) (*GetDLQTasksResponse, error)
 	// (-- api-linter: core::0165::response-message-name=disabled
-	//
-	//	aip.dev/not-precedent:  --)
+	//     aip.dev/not-precedent:  --)
 	PurgeDLQTasks(ctx context.Context, in *PurgeDLQTasksRequest, opts ...grpc.CallOption) (*PurgeDLQTasksResponse, error)
 	MergeDLQTasks(ctx context.Context, in *MergeDLQTasksRequest, opts ...grpc.CallOption) (*MergeDLQTasksResponse, error)
 	DescribeDLQJob(ctx context.Context, in *DescribeDLQJobRequest, opts ...grpc.CallOption) (*DescribeDLQJobResponse, error)
@@ -159,6 +165,42 @@ type AdminServiceClient interface {
 	ForceUnloadTaskQueuePartition(ctx context.Context, in *ForceUnloadTaskQueuePartitionRequest, opts ...grpc.CallOption) (*ForceUnloadTaskQueuePartitionResponse, error)
 	// MigrateSchedule migrates a schedule between V1 (workflow-backed) and V2 (CHASM-backed) implementations.
 	MigrateSchedule(ctx context.Context, in *MigrateScheduleRequest, opts ...grpc.CallOption) (*MigrateScheduleResponse, error)
+	// ListFaultInjectionRules lists the persistence fault injection rules of the frontend host which handles the request.
+	// Unlike AddFaultInjectionRule and ClearFaultInjectionRules, it doesn't fan out: the rules of the history and
+	// matching hosts are only the same if every change went through this API.
+	ListFaultInjectionRules(ctx context.Context, in *ListFaultInjectionRulesRequest, opts ...grpc.CallOption) (*ListFaultInjectionRulesResponse, error)
+	// AddFaultInjectionRule adds a persistence fault injection rule for a data store method, replacing the rule
+	// previously added for that method. The rule is added to the frontend host which handles the request and to every
+	// history and matching host. Fault injection must be enabled in the persistence config.
+	AddFaultInjectionRule(ctx context.Context, in *AddFaultInjectionRuleRequest, opts ...grpc.CallOption) (*AddFaultInjectionRuleResponse, error)
+	// ClearFaultInjectionRules removes the persistence fault injection rules which were added with AddFaultInjectionRule.
+	ClearFaultInjectionRules(ctx context.Context, in *ClearFaultInjectionRulesRequest, opts ...grpc.CallOption) (*ClearFaultInjectionRulesResponse, error)
+	// AggregateWorkflowExecutions computes aggregate functions (min, max, avg, sum and percentile) over the Int, Double
+	// and Datetime search attributes of the workflow executions which match a visibility query. The query may have a
+	// GROUP BY clause, in which case the aggregations are computed per group.
+	AggregateWorkflowExecutions(ctx context.Context, in *AggregateWorkflowExecutionsRequest, opts ...grpc.CallOption) (*AggregateWorkflowExecutionsResponse, error)
+	// ExplainVisibilityQuery shows how a visibility query is run: the parsed query, its search attributes after alias
+	// mapping, and the SQL statement or Elasticsearch request of the visibility store. If execute is set, it also runs
+	// the query, and returns its duration and the plan of the visibility store.
+	ExplainVisibilityQuery(ctx context.Context, in *ExplainVisibilityQueryRequest, opts ...grpc.CallOption) (*ExplainVisibilityQueryResponse, error)
+	// RehydrateWorkflowExecution imports the archived history of a closed workflow execution which passed the retention
+	// of its namespace back into the history service, so that it can be described, queried and reset again. The
+	// rehydrated execution is deleted again after a temporary retention, and is not archived again.
+	// NOTE: this is experimental API
+	RehydrateWorkflowExecution(ctx context.Context, in *RehydrateWorkflowExecutionRequest, opts ...grpc.CallOption) (*RehydrateWorkflowExecutionResponse, error)
+	// VerifyArchivedHistory reads back the archived histories of a namespace and verifies them against the manifests
+	// which were written with them, to find the corrupted, truncated or missing archived histories. The runs are
+	// listed from the archived visibility records of the namespace, unless a single execution is requested.
+	// NOTE: this is experimental API
+	VerifyArchivedHistory(ctx context.Context, in *VerifyArchivedHistoryRequest, opts ...grpc.CallOption) (*VerifyArchivedHistoryResponse, error)
+	// GetArchivedChasmExecution returns the final component tree of an archived CHASM execution, such as a schedule or
+	// a standalone activity, from the history archival URI of its namespace.
+	// NOTE: this is experimental API
+	GetArchivedChasmExecution(ctx context.Context, in *GetArchivedChasmExecutionRequest, opts ...grpc.CallOption) (*GetArchivedChasmExecutionResponse, error)
+	// AdvanceNamespaceTime moves the virtual time of a namespace with time skipping enabled forward, so that its
+	// timers which are due on the new virtual time fire right away. A zero duration returns the current virtual time.
+	// NOTE: this is experimental API for test suites.
+	AdvanceNamespaceTime(ctx context.Context, in *AdvanceNamespaceTimeRequest, opts ...grpc.CallOption) (*AdvanceNamespaceTimeResponse, error)
 }
 
 type adminServiceClient struct {
@@ -596,6 +638,87 @@ func (c *adminServiceClient) MigrateSchedule(ctx context.Context, in *MigrateSch
 	return out, nil
 }
 
+func (c *adminServiceClient) ListFaultInjectionRules(ctx context.Context, in *ListFaultInjectionRulesRequest, opts ...grpc.CallOption) (*ListFaultInjectionRulesResponse, error) {
+	out := new(ListFaultInjectionRulesResponse)
+	err := c.cc.Invoke(ctx, AdminService_ListFaultInjectionRules_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) AddFaultInjectionRule(ctx context.Context, in *AddFaultInjectionRuleRequest, opts ...grpc.CallOption) (*AddFaultInjectionRuleResponse, error) {
+	out := new(AddFaultInjectionRuleResponse)
+	err := c.cc.Invoke(ctx, AdminService_AddFaultInjectionRule_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ClearFaultInjectionRules(ctx context.Context, in *ClearFaultInjectionRulesRequest, opts ...grpc.CallOption) (*ClearFaultInjectionRulesResponse, error) {
+	out := new(ClearFaultInjectionRulesResponse)
+	err := c.cc.Invoke(ctx, AdminService_ClearFaultInjectionRules_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) AggregateWorkflowExecutions(ctx context.Context, in *AggregateWorkflowExecutionsRequest, opts ...grpc.CallOption) (*AggregateWorkflowExecutionsResponse, error) {
+	out := new(AggregateWorkflowExecutionsResponse)
+	err := c.cc.Invoke(ctx, AdminService_AggregateWorkflowExecutions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ExplainVisibilityQuery(ctx context.Context, in *ExplainVisibilityQueryRequest, opts ...grpc.CallOption) (*ExplainVisibilityQueryResponse, error) {
+	out := new(ExplainVisibilityQueryResponse)
+	err := c.cc.Invoke(ctx, AdminService_ExplainVisibilityQuery_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) RehydrateWorkflowExecution(ctx context.Context, in *RehydrateWorkflowExecutionRequest, opts ...grpc.CallOption) (*RehydrateWorkflowExecutionResponse, error) {
+	out := new(RehydrateWorkflowExecutionResponse)
+	err := c.cc.Invoke(ctx, AdminService_RehydrateWorkflowExecution_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) VerifyArchivedHistory(ctx context.Context, in *VerifyArchivedHistoryRequest, opts ...grpc.CallOption) (*VerifyArchivedHistoryResponse, error) {
+	out := new(VerifyArchivedHistoryResponse)
+	err := c.cc.Invoke(ctx, AdminService_VerifyArchivedHistory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetArchivedChasmExecution(ctx context.Context, in *GetArchivedChasmExecutionRequest, opts ...grpc.CallOption) (*GetArchivedChasmExecutionResponse, error) {
+	out := new(GetArchivedChasmExecutionResponse)
+	err := c.cc.Invoke(ctx, AdminService_GetArchivedChasmExecution_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) AdvanceNamespaceTime(ctx context.Context, in *AdvanceNamespaceTimeRequest, opts ...grpc.CallOption) (*AdvanceNamespaceTimeResponse, error) {
+	out := new(AdvanceNamespaceTimeResponse)
+	err := c.cc.Invoke(ctx, AdminService_AdvanceNamespaceTime_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AdminServiceServer is the server API for AdminService service.
 // All implementations must embed UnimplementedAdminServiceServer
 // for forward compatibility
@@ -652,9 +775,7 @@ type AdminServiceServer interface {
 	// GetDLQMessages returns messages from DLQ.
 	GetDLQMessages(context.Context, *GetDLQMessagesRequest) (*GetDLQMessagesResponse, error)
 	// (-- api-linter: core::0165::response-message-name=disabled
-	//
-	//	aip.dev/not-precedent:  --)
-	//
+	//     aip.dev/not-precedent:  --)
 	// PurgeDLQMessages purges messages from DLQ.
 	PurgeDLQMessages(context.Context, *PurgeDLQMessagesRequest) (*PurgeDLQMessagesResponse, error)
 	// MergeDLQMessages merges messages from DLQ.
@@ -673,8 +794,7 @@ type AdminServiceServer interface {
 	GetNamespace(context.Context, *GetNamespaceRequest) (*GetNamespaceResponse, error)
 	GetDLQTasks(context.Context, *GetDLQTasksRequest) (*GetDLQTasksResponse, error)
 	// (-- api-linter: core::0165::response-message-name=disabled
-	//
-	//	aip.dev/not-precedent:  --)
+	//     aip.dev/not-precedent:  --)
 	PurgeDLQTasks(context.Context, *PurgeDLQTasksRequest) (*PurgeDLQTasksResponse, error)
 	MergeDLQTasks(context.Context, *MergeDLQTasksRequest) (*MergeDLQTasksResponse, error)
 	DescribeDLQJob(context.Context, *DescribeDLQJobRequest) (*DescribeDLQJobResponse, error)
@@ -688,6 +808,42 @@ type AdminServiceServer interface {
 	ForceUnloadTaskQueuePartition(context.Context, *ForceUnloadTaskQueuePartitionRequest) (*ForceUnloadTaskQueuePartitionResponse, error)
 	// MigrateSchedule migrates a schedule between V1 (workflow-backed) and V2 (CHASM-backed) implementations.
 	MigrateSchedule(context.Context, *MigrateScheduleRequest) (*MigrateScheduleResponse, error)
+	// ListFaultInjectionRules lists the persistence fault injection rules of the frontend host which handles the request.
+	// Unlike AddFaultInjectionRule and ClearFaultInjectionRules, it doesn't fan out: the rules of the history and
+	// matching hosts are only the same if every change went through this API.
+	ListFaultInjectionRules(context.Context, *ListFaultInjectionRulesRequest) (*ListFaultInjectionRulesResponse, error)
+	// AddFaultInjectionRule adds a persistence fault injection rule for a data store method, replacing the rule
+	// previously added for that method. The rule is added to the frontend host which handles the request and to every
+	// history and matching host. Fault injection must be enabled in the persistence config.
+	AddFaultInjectionRule(context.Context, *AddFaultInjectionRuleRequest) (*AddFaultInjectionRuleResponse, error)
+	// ClearFaultInjectionRules removes the persistence fault injection rules which were added with AddFaultInjectionRule.
+	ClearFaultInjectionRules(context.Context, *ClearFaultInjectionRulesRequest) (*ClearFaultInjectionRulesResponse, error)
+	// AggregateWorkflowExecutions computes aggregate functions (min, max, avg, sum and percentile) over the Int, Double
+	// and Datetime search attributes of the workflow executions which match a visibility query. The query may have a
+	// GROUP BY clause, in which case the aggregations are computed per group.
+	AggregateWorkflowExecutions(context.Context, *AggregateWorkflowExecutionsRequest) (*AggregateWorkflowExecutionsResponse, error)
+	// ExplainVisibilityQuery shows how a visibility query is run: the parsed query, its search attributes after alias
+	// mapping, and the SQL statement or Elasticsearch request of the visibility store. If execute is set, it also runs
+	// the query, and returns its duration and the plan of the visibility store.
+	ExplainVisibilityQuery(context.Context, *ExplainVisibilityQueryRequest) (*ExplainVisibilityQueryResponse, error)
+	// RehydrateWorkflowExecution imports the archived history of a closed workflow execution which passed the retention
+	// of its namespace back into the history service, so that it can be described, queried and reset again. The
+	// rehydrated execution is deleted again after a temporary retention, and is not archived again.
+	// NOTE: this is experimental API
+	RehydrateWorkflowExecution(context.Context, *RehydrateWorkflowExecutionRequest) (*RehydrateWorkflowExecutionResponse, error)
+	// VerifyArchivedHistory reads back the archived histories of a namespace and verifies them against the manifests
+	// which were written with them, to find the corrupted, truncated or missing archived histories. The runs are
+	// listed from the archived visibility records of the namespace, unless a single execution is requested.
+	// NOTE: this is experimental API
+	VerifyArchivedHistory(context.Context, *VerifyArchivedHistoryRequest) (*VerifyArchivedHistoryResponse, error)
+	// GetArchivedChasmExecution returns the final component tree of an archived CHASM execution, such as a schedule or
+	// a standalone activity, from the history archival URI of its namespace.
+	// NOTE: this is experimental API
+	GetArchivedChasmExecution(context.Context, *GetArchivedChasmExecutionRequest) (*GetArchivedChasmExecutionResponse, error)
+	// AdvanceNamespaceTime moves the virtual time of a namespace with time skipping enabled forward, so that its
+	// timers which are due on the new virtual time fire right away. A zero duration returns the current virtual time.
+	// NOTE: this is experimental API for test suites.
+	AdvanceNamespaceTime(context.Context, *AdvanceNamespaceTimeRequest) (*AdvanceNamespaceTimeResponse, error)
 	mustEmbedUnimplementedAdminServiceServer()
 }
 
@@ -830,6 +986,33 @@ func (UnimplementedAdminServiceServer) ForceUnloadTaskQueuePartition(context.Con
 func (UnimplementedAdminServiceServer) MigrateSchedule(context.Context, *MigrateScheduleRequest) (*MigrateScheduleResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method MigrateSchedule not implemented")
 }
+func (UnimplementedAdminServiceServer) ListFaultInjectionRules(context.Context, *ListFaultInjectionRulesRequest) (*ListFaultInjectionRulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFaultInjectionRules not implemented")
+}
+func (UnimplementedAdminServiceServer) AddFaultInjectionRule(context.Context, *AddFaultInjectionRuleRequest) (*AddFaultInjectionRuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddFaultInjectionRule not implemented")
+}
+func (UnimplementedAdminServiceServer) ClearFaultInjectionRules(context.Context, *ClearFaultInjectionRulesRequest) (*ClearFaultInjectionRulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearFaultInjectionRules not implemented")
+}
+func (UnimplementedAdminServiceServer) AggregateWorkflowExecutions(context.Context, *AggregateWorkflowExecutionsRequest) (*AggregateWorkflowExecutionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AggregateWorkflowExecutions not implemented")
+}
+func (UnimplementedAdminServiceServer) ExplainVisibilityQuery(context.Context, *ExplainVisibilityQueryRequest) (*ExplainVisibilityQueryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExplainVisibilityQuery not implemented")
+}
+func (UnimplementedAdminServiceServer) RehydrateWorkflowExecution(context.Context, *RehydrateWorkflowExecutionRequest) (*RehydrateWorkflowExecutionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RehydrateWorkflowExecution not implemented")
+}
+func (UnimplementedAdminServiceServer) VerifyArchivedHistory(context.Context, *VerifyArchivedHistoryRequest) (*VerifyArchivedHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyArchivedHistory not implemented")
+}
+func (UnimplementedAdminServiceServer) GetArchivedChasmExecution(context.Context, *GetArchivedChasmExecutionRequest) (*GetArchivedChasmExecutionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetArchivedChasmExecution not implemented")
+}
+func (UnimplementedAdminServiceServer) AdvanceNamespaceTime(context.Context, *AdvanceNamespaceTimeRequest) (*AdvanceNamespaceTimeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdvanceNamespaceTime not implemented")
+}
 func (UnimplementedAdminServiceServer) mustEmbedUnimplementedAdminServiceServer() {}
 
 // UnsafeAdminServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -1661,6 +1844,168 @@ func _AdminService_MigrateSchedule_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AdminService_ListFaultInjectionRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFaultInjectionRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListFaultInjectionRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ListFaultInjectionRules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListFaultInjectionRules(ctx, req.(*ListFaultInjectionRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_AddFaultInjectionRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddFaultInjectionRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).AddFaultInjectionRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_AddFaultInjectionRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).AddFaultInjectionRule(ctx, req.(*AddFaultInjectionRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ClearFaultInjectionRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClearFaultInjectionRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ClearFaultInjectionRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ClearFaultInjectionRules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ClearFaultInjectionRules(ctx, req.(*ClearFaultInjectionRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_AggregateWorkflowExecutions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AggregateWorkflowExecutionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).AggregateWorkflowExecutions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_AggregateWorkflowExecutions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).AggregateWorkflowExecutions(ctx, req.(*AggregateWorkflowExecutionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ExplainVisibilityQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExplainVisibilityQueryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ExplainVisibilityQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ExplainVisibilityQuery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ExplainVisibilityQuery(ctx, req.(*ExplainVisibilityQueryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_RehydrateWorkflowExecution_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RehydrateWorkflowExecutionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).RehydrateWorkflowExecution(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_RehydrateWorkflowExecution_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).RehydrateWorkflowExecution(ctx, req.(*RehydrateWorkflowExecutionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_VerifyArchivedHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyArchivedHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).VerifyArchivedHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_VerifyArchivedHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).VerifyArchivedHistory(ctx, req.(*VerifyArchivedHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetArchivedChasmExecution_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetArchivedChasmExecutionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetArchivedChasmExecution(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_GetArchivedChasmExecution_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetArchivedChasmExecution(ctx, req.(*GetArchivedChasmExecutionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_AdvanceNamespaceTime_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdvanceNamespaceTimeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).AdvanceNamespaceTime(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_AdvanceNamespaceTime_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).AdvanceNamespaceTime(ctx, req.(*AdvanceNamespaceTimeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AdminService_ServiceDesc is the grpc.ServiceDesc for AdminService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -1844,6 +2189,42 @@ var AdminService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "MigrateSchedule",
 			Handler:    _AdminService_MigrateSchedule_Handler,
 		},
+		{
+			MethodName: "ListFaultInjectionRules",
+			Handler:    _AdminService_ListFaultInjectionRules_Handler,
+		},
+		{
+			MethodName: "AddFaultInjectionRule",
+			Handler:    _AdminService_AddFaultInjectionRule_Handler,
+		},
+		{
+			MethodName: "ClearFaultInjectionRules",
+			Handler:    _AdminService_ClearFaultInjectionRules_Handler,
+		},
+		{
+			MethodName: "AggregateWorkflowExecutions",
+			Handler:    _AdminService_AggregateWorkflowExecutions_Handler,
+		},
+		{
+			MethodName: "ExplainVisibilityQuery",
+			Handler:    _AdminService_ExplainVisibilityQuery_Handler,
+		},
+		{
+			MethodName: "RehydrateWorkflowExecution",
+			Handler:    _AdminService_RehydrateWorkflowExecution_Handler,
+		},
+		{
+			MethodName: "VerifyArchivedHistory",
+			Handler:    _AdminService_VerifyArchivedHistory_Handler,
+		},
+		{
+			MethodName: "GetArchivedChasmExecution",
+			Handler:    _AdminService_GetArchivedChasmExecution_Handler,
+		},
+		{
+			MethodName: "AdvanceNamespaceTime",
+			Handler:    _AdminService_AdvanceNamespaceTime_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -43,6 +43,26 @@ func (m *MockAdminServiceClient) EXPECT() *MockAdminServiceClientMockRecorder {
 	return m.recorder
 }
 
+// AddFaultInjectionRule mocks base method.
+func (m *MockAdminServiceClient) AddFaultInjectionRule(ctx context.Context, in *adminservice.AddFaultInjectionRuleRequest, opts ...grpc.CallOption) (*adminservice.AddFaultInjectionRuleResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddFaultInjectionRule", varargs...)
+	ret0, _ := ret[0].(*adminservice.AddFaultInjectionRuleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddFaultInjectionRule indicates an expected call of AddFaultInjectionRule.
+func (mr *MockAdminServiceClientMockRecorder) AddFaultInjectionRule(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFaultInjectionRule", reflect.TypeOf((*MockAdminServiceClient)(nil).AddFaultInjectionRule), varargs...)
+}
+
 // AddOrUpdateRemoteCluster mocks base method.
 func (m *MockAdminServiceClient) AddOrUpdateRemoteCluster(ctx context.Context, in *adminservice.AddOrUpdateRemoteClusterRequest, opts ...grpc.CallOption) (*adminservice.AddOrUpdateRemoteClusterResponse, error) {
 	m.ctrl.T.Helper()
@@ -103,6 +123,46 @@ func (mr *MockAdminServiceClientMockRecorder) AddTasks(ctx, in any, opts ...any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTasks", reflect.TypeOf((*MockAdminServiceClient)(nil).AddTasks), varargs...)
 }
 
+// AdvanceNamespaceTime mocks base method.
+func (m *MockAdminServiceClient) AdvanceNamespaceTime(ctx context.Context, in *adminservice.AdvanceNamespaceTimeRequest, opts ...grpc.CallOption) (*adminservice.AdvanceNamespaceTimeResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AdvanceNamespaceTime", varargs...)
+	ret0, _ := ret[0].(*adminservice.AdvanceNamespaceTimeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdvanceNamespaceTime indicates an expected call of AdvanceNamespaceTime.
+func (mr *MockAdminServiceClientMockRecorder) AdvanceNamespaceTime(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdvanceNamespaceTime", reflect.TypeOf((*MockAdminServiceClient)(nil).AdvanceNamespaceTime), varargs...)
+}
+
+// AggregateWorkflowExecutions mocks base method.
+func (m *MockAdminServiceClient) AggregateWorkflowExecutions(ctx context.Context, in *adminservice.AggregateWorkflowExecutionsRequest, opts ...grpc.CallOption) (*adminservice.AggregateWorkflowExecutionsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AggregateWorkflowExecutions", varargs...)
+	ret0, _ := ret[0].(*adminservice.AggregateWorkflowExecutionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AggregateWorkflowExecutions indicates an expected call of AggregateWorkflowExecutions.
+func (mr *MockAdminServiceClientMockRecorder) AggregateWorkflowExecutions(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AggregateWorkflowExecutions", reflect.TypeOf((*MockAdminServiceClient)(nil).AggregateWorkflowExecutions), varargs...)
+}
+
 // CancelDLQJob mocks base method.
 func (m *MockAdminServiceClient) CancelDLQJob(ctx context.Context, in *adminservice.CancelDLQJobRequest, opts ...grpc.CallOption) (*adminservice.CancelDLQJobResponse, error) {
 	m.ctrl.T.Helper()
@@ -123,6 +183,26 @@ func (mr *MockAdminServiceClientMockRecorder) CancelDLQJob(ctx, in any, opts ...
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelDLQJob", reflect.TypeOf((*MockAdminServiceClient)(nil).CancelDLQJob), varargs...)
 }
 
+// ClearFaultInjectionRules mocks base method.
+func (m *MockAdminServiceClient) ClearFaultInjectionRules(ctx context.Context, in *adminservice.ClearFaultInjectionRulesRequest, opts ...grpc.CallOption) (*adminservice.ClearFaultInjectionRulesResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ClearFaultInjectionRules", varargs...)
+	ret0, _ := ret[0].(*adminservice.ClearFaultInjectionRulesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClearFaultInjectionRules indicates an expected call of ClearFaultInjectionRules.
+func (mr *MockAdminServiceClientMockRecorder) ClearFaultInjectionRules(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearFaultInjectionRules", reflect.TypeOf((*MockAdminServiceClient)(nil).ClearFaultInjectionRules), varargs...)
+}
+
 // CloseShard mocks base method.
 func (m *MockAdminServiceClient) CloseShard(ctx context.Context, in *adminservice.CloseShardRequest, opts ...grpc.CallOption) (*adminservice.CloseShardResponse, error) {
 	m.ctrl.T.Helper()
@@ -283,6 +363,26 @@ func (mr *MockAdminServiceClientMockRecorder) DescribeTaskQueuePartition(ctx, in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeTaskQueuePartition", reflect.TypeOf((*MockAdminServiceClient)(nil).DescribeTaskQueuePartition), varargs...)
 }
 
+// ExplainVisibilityQuery mocks base method.
+func (m *MockAdminServiceClient) ExplainVisibilityQuery(ctx context.Context, in *adminservice.ExplainVisibilityQueryRequest, opts ...grpc.CallOption) (*adminservice.ExplainVisibilityQueryResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ExplainVisibilityQuery", varargs...)
+	ret0, _ := ret[0].(*adminservice.ExplainVisibilityQueryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExplainVisibilityQuery indicates an expected call of ExplainVisibilityQuery.
+func (mr *MockAdminServiceClientMockRecorder) ExplainVisibilityQuery(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExplainVisibilityQuery", reflect.TypeOf((*MockAdminServiceClient)(nil).ExplainVisibilityQuery), varargs...)
+}
+
 // ForceUnloadTaskQueuePartition mocks base method.
 func (m *MockAdminServiceClient) ForceUnloadTaskQueuePartition(ctx context.Context, in *adminservice.ForceUnloadTaskQueuePartitionRequest, opts ...grpc.CallOption) (*adminservice.ForceUnloadTaskQueuePartitionResponse, error) {
 	m.ctrl.T.Helper()
@@ -323,6 +423,26 @@ func (mr *MockAdminServiceClientMockRecorder) GenerateLastHistoryReplicationTask
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateLastHistoryReplicationTasks", reflect.TypeOf((*MockAdminServiceClient)(nil).GenerateLastHistoryReplicationTasks), varargs...)
 }
 
+// GetArchivedChasmExecution mocks base method.
+func (m *MockAdminServiceClient) GetArchivedChasmExecution(ctx context.Context, in *adminservice.GetArchivedChasmExecutionRequest, opts ...grpc.CallOption) (*adminservice.GetArchivedChasmExecutionResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetArchivedChasmExecution", varargs...)
+	ret0, _ := ret[0].(*adminservice.GetArchivedChasmExecutionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArchivedChasmExecution indicates an expected call of GetArchivedChasmExecution.
+func (mr *MockAdminServiceClientMockRecorder) GetArchivedChasmExecution(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArchivedChasmExecution", reflect.TypeOf((*MockAdminServiceClient)(nil).GetArchivedChasmExecution), varargs...)
+}
+
 // GetDLQMessages mocks base method.
 func (m *MockAdminServiceClient) GetDLQMessages(ctx context.Context, in *adminservice.GetDLQMessagesRequest, opts ...grpc.CallOption) (*adminservice.GetDLQMessagesResponse, error) {
 	m.ctrl.T.Helper()
@@ -603,6 +723,26 @@ func (mr *MockAdminServiceClientMockRecorder) ListClusters(ctx, in any, opts ...
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListClusters", reflect.TypeOf((*MockAdminServiceClient)(nil).ListClusters), varargs...)
 }
 
+// ListFaultInjectionRules mocks base method.
+func (m *MockAdminServiceClient) ListFaultInjectionRules(ctx context.Context, in *adminservice.ListFaultInjectionRulesRequest, opts ...grpc.CallOption) (*adminservice.ListFaultInjectionRulesResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListFaultInjectionRules", varargs...)
+	ret0, _ := ret[0].(*adminservice.ListFaultInjectionRulesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFaultInjectionRules indicates an expected call of ListFaultInjectionRules.
+func (mr *MockAdminServiceClientMockRecorder) ListFaultInjectionRules(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFaultInjectionRules", reflect.TypeOf((*MockAdminServiceClient)(nil).ListFaultInjectionRules), varargs...)
+}
+
 // ListHistoryTasks mocks base method.
 func (m *MockAdminServiceClient) ListHistoryTasks(ctx context.Context, in *adminservice.ListHistoryTasksRequest, opts ...grpc.CallOption) (*adminservice.ListHistoryTasksResponse, error) {
 	m.ctrl.T.Helper()
@@ -803,6 +943,26 @@ func (mr *MockAdminServiceClientMockRecorder) RefreshWorkflowTasks(ctx, in any,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshWorkflowTasks", reflect.TypeOf((*MockAdminServiceClient)(nil).RefreshWorkflowTasks), varargs...)
 }
 
+// RehydrateWorkflowExecution mocks base method.
+func (m *MockAdminServiceClient) RehydrateWorkflowExecution(ctx context.Context, in *adminservice.RehydrateWorkflowExecutionRequest, opts ...grpc.CallOption) (*adminservice.RehydrateWorkflowExecutionResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RehydrateWorkflowExecution", varargs...)
+	ret0, _ := ret[0].(*adminservice.RehydrateWorkflowExecutionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RehydrateWorkflowExecution indicates an expected call of RehydrateWorkflowExecution.
+func (mr *MockAdminServiceClientMockRecorder) RehydrateWorkflowExecution(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RehydrateWorkflowExecution", reflect.TypeOf((*MockAdminServiceClient)(nil).RehydrateWorkflowExecution), varargs...)
+}
+
 // RemoveRemoteCluster mocks base method.
 func (m *MockAdminServiceClient) RemoveRemoteCluster(ctx context.Context, in *adminservice.RemoveRemoteClusterRequest, opts ...grpc.CallOption) (*adminservice.RemoveRemoteClusterResponse, error) {
 	m.ctrl.T.Helper()
@@ -943,6 +1103,26 @@ func (mr *MockAdminServiceClientMockRecorder) SyncWorkflowState(ctx, in any, opt
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncWorkflowState", reflect.TypeOf((*MockAdminServiceClient)(nil).SyncWorkflowState), varargs...)
 }
 
+// VerifyArchivedHistory mocks base method.
+func (m *MockAdminServiceClient) VerifyArchivedHistory(ctx context.Context, in *adminservice.VerifyArchivedHistoryRequest, opts ...grpc.CallOption) (*adminservice.VerifyArchivedHistoryResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "VerifyArchivedHistory", varargs...)
+	ret0, _ := ret[0].(*adminservice.VerifyArchivedHistoryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyArchivedHistory indicates an expected call of VerifyArchivedHistory.
+func (mr *MockAdminServiceClientMockRecorder) VerifyArchivedHistory(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyArchivedHistory", reflect.TypeOf((*MockAdminServiceClient)(nil).VerifyArchivedHistory), varargs...)
+}
+
 // MockAdminService_StreamWorkflowReplicationMessagesClient is a mock of AdminService_StreamWorkflowReplicationMessagesClient interface.
 type MockAdminService_StreamWorkflowReplicationMessagesClient struct {
 	ctrl     *gomock.Controller
@@ -1105,6 +1285,21 @@ func (m *MockAdminServiceServer) EXPECT() *MockAdminServiceServerMockRecorder {
 	return m.recorder
 }
 
+// AddFaultInjectionRule mocks base method.
+func (m *MockAdminServiceServer) AddFaultInjectionRule(arg0 context.Context, arg1 *adminservice.AddFaultInjectionRuleRequest) (*adminservice.AddFaultInjectionRuleResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddFaultInjectionRule", arg0, arg1)
+	ret0, _ := ret[0].(*adminservice.AddFaultInjectionRuleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddFaultInjectionRule indicates an expected call of AddFaultInjectionRule.
+func (mr *MockAdminServiceServerMockRecorder) AddFaultInjectionRule(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFaultInjectionRule", reflect.TypeOf((*MockAdminServiceServer)(nil).AddFaultInjectionRule), arg0, arg1)
+}
+
 // AddOrUpdateRemoteCluster mocks base method.
 func (m *MockAdminServiceServer) AddOrUpdateRemoteCluster(arg0 context.Context, arg1 *adminservice.AddOrUpdateRemoteClusterRequest) (*adminservice.AddOrUpdateRemoteClusterResponse, error) {
 	m.ctrl.T.Helper()
@@ -1150,6 +1345,36 @@ func (mr *MockAdminServiceServerMockRecorder) AddTasks(arg0, arg1 any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTasks", reflect.TypeOf((*MockAdminServiceServer)(nil).AddTasks), arg0, arg1)
 }
 
+// AdvanceNamespaceTime mocks base method.
+func (m *MockAdminServiceServer) AdvanceNamespaceTime(arg0 context.Context, arg1 *adminservice.AdvanceNamespaceTimeRequest) (*adminservice.AdvanceNamespaceTimeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdvanceNamespaceTime", arg0, arg1)
+	ret0, _ := ret[0].(*adminservice.AdvanceNamespaceTimeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdvanceNamespaceTime indicates an expected call of AdvanceNamespaceTime.
+func (mr *MockAdminServiceServerMockRecorder) AdvanceNamespaceTime(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdvanceNamespaceTime", reflect.TypeOf((*MockAdminServiceServer)(nil).AdvanceNamespaceTime), arg0, arg1)
+}
+
+// AggregateWorkflowExecutions mocks base method.
+func (m *MockAdminServiceServer) AggregateWorkflowExecutions(arg0 context.Context, arg1 *adminservice.AggregateWorkflowExecutionsRequest) (*adminservice.AggregateWorkflowExecutionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AggregateWorkflowExecutions", arg0, arg1)
+	ret0, _ := ret[0].(*adminservice.AggregateWorkflowExecutionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AggregateWorkflowExecutions indicates an expected call of AggregateWorkflowExecutions.
+func (mr *MockAdminServiceServerMockRecorder) AggregateWorkflowExecutions(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AggregateWorkflowExecutions", reflect.TypeOf((*MockAdminServiceServer)(nil).AggregateWorkflowExecutions), arg0, arg1)
+}
+
 // CancelDLQJob mocks base method.
 func (m *MockAdminServiceServer) CancelDLQJob(arg0 context.Context, arg1 *adminservice.CancelDLQJobRequest) (*adminservice.CancelDLQJobResponse, error) {
 	m.ctrl.T.Helper()
@@ -1165,6 +1390,21 @@ func (mr *MockAdminServiceServerMockRecorder) CancelDLQJob(arg0, arg1 any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelDLQJob", reflect.TypeOf((*MockAdminServiceServer)(nil).CancelDLQJob), arg0, arg1)
 }
 
+// ClearFaultInjectionRules mocks base method.
+func (m *MockAdminServiceServer) ClearFaultInjectionRules(arg0 context.Context, arg1 *adminservice.ClearFaultInjectionRulesRequest) (*adminservice.ClearFaultInjectionRulesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearFaultInjectionRules", arg0, arg1)
+	ret0, _ := ret[0].(*adminservice.ClearFaultInjectionRulesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClearFaultInjectionRules indicates an expected call of ClearFaultInjectionRules.
+func (mr *MockAdminServiceServerMockRecorder) ClearFaultInjectionRules(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearFaultInjectionRules", reflect.TypeOf((*MockAdminServiceServer)(nil).ClearFaultInjectionRules), arg0, arg1)
+}
+
 // CloseShard mocks base method.
 func (m *MockAdminServiceServer) CloseShard(arg0 context.Context, arg1 *adminservice.CloseShardRequest) (*adminservice.CloseShardResponse, error) {
 	m.ctrl.T.Helper()
@@ -1285,6 +1525,21 @@ func (mr *MockAdminServiceServerMockRecorder) DescribeTaskQueuePartition(arg0, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeTaskQueuePartition", reflect.TypeOf((*MockAdminServiceServer)(nil).DescribeTaskQueuePartition), arg0, arg1)
 }
 
+// ExplainVisibilityQuery mocks base method.
+func (m *MockAdminServiceServer) ExplainVisibilityQuery(arg0 context.Context, arg1 *adminservice.ExplainVisibilityQueryRequest) (*adminservice.ExplainVisibilityQueryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExplainVisibilityQuery", arg0, arg1)
+	ret0, _ := ret[0].(*adminservice.ExplainVisibilityQueryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExplainVisibilityQuery indicates an expected call of ExplainVisibilityQuery.
+func (mr *MockAdminServiceServerMockRecorder) ExplainVisibilityQuery(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExplainVisibilityQuery", reflect.TypeOf((*MockAdminServiceServer)(nil).ExplainVisibilityQuery), arg0, arg1)
+}
+
 // ForceUnloadTaskQueuePartition mocks base method.
 func (m *MockAdminServiceServer) ForceUnloadTaskQueuePartition(arg0 context.Context, arg1 *adminservice.ForceUnloadTaskQueuePartitionRequest) (*adminservice.ForceUnloadTaskQueuePartitionResponse, error) {
 	m.ctrl.T.Helper()
@@ -1315,6 +1570,21 @@ func (mr *MockAdminServiceServerMockRecorder) GenerateLastHistoryReplicationTask
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateLastHistoryReplicationTasks", reflect.TypeOf((*MockAdminServiceServer)(nil).GenerateLastHistoryReplicationTasks), arg0, arg1)
 }
 
+// GetArchivedChasmExecution mocks base method.
+func (m *MockAdminServiceServer) GetArchivedChasmExecution(arg0 context.Context, arg1 *adminservice.GetArchivedChasmExecutionRequest) (*adminservice.GetArchivedChasmExecutionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArchivedChasmExecution", arg0, arg1)
+	ret0, _ := ret[0].(*adminservice.GetArchivedChasmExecutionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArchivedChasmExecution indicates an expected call of GetArchivedChasmExecution.
+func (mr *MockAdminServiceServerMockRecorder) GetArchivedChasmExecution(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArchivedChasmExecution", reflect.TypeOf((*MockAdminServiceServer)(nil).GetArchivedChasmExecution), arg0, arg1)
+}
+
 // GetDLQMessages mocks base method.
 func (m *MockAdminServiceServer) GetDLQMessages(arg0 context.Context, arg1 *adminservice.GetDLQMessagesRequest) (*adminservice.GetDLQMessagesResponse, error) {
 	m.ctrl.T.Helper()
@@ -1525,6 +1795,21 @@ func (mr *MockAdminServiceServerMockRecorder) ListClusters(arg0, arg1 any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListClusters", reflect.TypeOf((*MockAdminServiceServer)(nil).ListClusters), arg0, arg1)
 }
 
+// ListFaultInjectionRules mocks base method.
+func (m *MockAdminServiceServer) ListFaultInjectionRules(arg0 context.Context, arg1 *adminservice.ListFaultInjectionRulesRequest) (*adminservice.ListFaultInjectionRulesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFaultInjectionRules", arg0, arg1)
+	ret0, _ := ret[0].(*adminservice.ListFaultInjectionRulesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFaultInjectionRules indicates an expected call of ListFaultInjectionRules.
+func (mr *MockAdminServiceServerMockRecorder) ListFaultInjectionRules(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFaultInjectionRules", reflect.TypeOf((*MockAdminServiceServer)(nil).ListFaultInjectionRules), arg0, arg1)
+}
+
 // ListHistoryTasks mocks base method.
 func (m *MockAdminServiceServer) ListHistoryTasks(arg0 context.Context, arg1 *adminservice.ListHistoryTasksRequest) (*adminservice.ListHistoryTasksResponse, error) {
 	m.ctrl.T.Helper()
@@ -1675,6 +1960,21 @@ func (mr *MockAdminServiceServerMockRecorder) RefreshWorkflowTasks(arg0, arg1 an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshWorkflowTasks", reflect.TypeOf((*MockAdminServiceServer)(nil).RefreshWorkflowTasks), arg0, arg1)
 }
 
+// RehydrateWorkflowExecution mocks base method.
+func (m *MockAdminServiceServer) RehydrateWorkflowExecution(arg0 context.Context, arg1 *adminservice.RehydrateWorkflowExecutionRequest) (*adminservice.RehydrateWorkflowExecutionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RehydrateWorkflowExecution", arg0, arg1)
+	ret0, _ := ret[0].(*adminservice.RehydrateWorkflowExecutionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RehydrateWorkflowExecution indicates an expected call of RehydrateWorkflowExecution.
+func (mr *MockAdminServiceServerMockRecorder) RehydrateWorkflowExecution(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RehydrateWorkflowExecution", reflect.TypeOf((*MockAdminServiceServer)(nil).RehydrateWorkflowExecution), arg0, arg1)
+}
+
 // RemoveRemoteCluster mocks base method.
 func (m *MockAdminServiceServer) RemoveRemoteCluster(arg0 context.Context, arg1 *adminservice.RemoveRemoteClusterRequest) (*adminservice.RemoveRemoteClusterResponse, error) {
 	m.ctrl.T.Helper()
@@ -1779,6 +2079,21 @@ func (mr *MockAdminServiceServerMockRecorder) SyncWorkflowState(arg0, arg1 any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncWorkflowState", reflect.TypeOf((*MockAdminServiceServer)(nil).SyncWorkflowState), arg0, arg1)
 }
 
+// VerifyArchivedHistory mocks base method.
+func (m *MockAdminServiceServer) VerifyArchivedHistory(arg0 context.Context, arg1 *adminservice.VerifyArchivedHistoryRequest) (*adminservice.VerifyArchivedHistoryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyArchivedHistory", arg0, arg1)
+	ret0, _ := ret[0].(*adminservice.VerifyArchivedHistoryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyArchivedHistory indicates an expected call of VerifyArchivedHistory.
+func (mr *MockAdminServiceServerMockRecorder) VerifyArchivedHistory(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyArchivedHistory", reflect.TypeOf((*MockAdminServiceServer)(nil).VerifyArchivedHistory), arg0, arg1)
+}
+
 // mustEmbedUnimplementedAdminServiceServer mocks base method.
 func (m *MockAdminServiceServer) mustEmbedUnimplementedAdminServiceServer() {
 	m.ctrl.T.Helper()
@@ -79,6 +79,80 @@ func (this *HistoryBlob) Equal(that interface{}) bool {
 	return proto.Equal(this, that1)
 }
 
+// Marshal an object of type HistoryManifest to the protobuf v3 wire format
+func (val *HistoryManifest) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type HistoryManifest from the protobuf v3 wire format
+func (val *HistoryManifest) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *HistoryManifest) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two HistoryManifest values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *HistoryManifest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *HistoryManifest
+	switch t := that.(type) {
+	case *HistoryManifest:
+		that1 = t
+	case HistoryManifest:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
+
+// Marshal an object of type HistoryManifestBatch to the protobuf v3 wire format
+func (val *HistoryManifestBatch) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type HistoryManifestBatch from the protobuf v3 wire format
+func (val *HistoryManifestBatch) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *HistoryManifestBatch) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two HistoryManifestBatch values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *HistoryManifestBatch) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *HistoryManifestBatch
+	switch t := that.(type) {
+	case *HistoryManifestBatch:
+		that1 = t
+	case HistoryManifestBatch:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
+
 // Marshal an object of type VisibilityRecord to the protobuf v3 wire format
 func (val *VisibilityRecord) Marshal() ([]byte, error) {
 	return proto.Marshal(val)
@@ -115,3 +189,40 @@ func (this *VisibilityRecord) Equal(that interface{}) bool {
 
 	return proto.Equal(this, that1)
 }
+
+// Marshal an object of type ChasmExecution to the protobuf v3 wire format
+func (val *ChasmExecution) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type ChasmExecution from the protobuf v3 wire format
+func (val *ChasmExecution) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *ChasmExecution) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two ChasmExecution values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *ChasmExecution) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *ChasmExecution
+	switch t := that.(type) {
+	case *ChasmExecution:
+		that1 = t
+	case ChasmExecution:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
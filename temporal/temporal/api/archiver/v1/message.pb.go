@@ -11,9 +11,11 @@ import (
 	sync "sync"
 	unsafe "unsafe"
 
-	v12 "go.temporal.io/api/common/v1"
-	v11 "go.temporal.io/api/enums/v1"
+	v13 "go.temporal.io/api/common/v1"
+	v12 "go.temporal.io/api/enums/v1"
 	v1 "go.temporal.io/api/history/v1"
+	v11 "go.temporal.io/server/api/history/v1"
+	v14 "go.temporal.io/server/api/persistence/v1"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	durationpb "google.golang.org/protobuf/types/known/durationpb"
@@ -195,6 +197,172 @@ func (x *HistoryBlob) GetBody() []*v1.History {
 	return nil
 }
 
+// HistoryManifest is written with each archived history, and records its content so that its integrity can be
+// verified later.
+type HistoryManifest struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	NamespaceId          string                 `protobuf:"bytes,1,opt,name=namespace_id,json=namespaceId,proto3" json:"namespace_id,omitempty"`
+	WorkflowId           string                 `protobuf:"bytes,2,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
+	RunId                string                 `protobuf:"bytes,3,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	CloseFailoverVersion int64                  `protobuf:"varint,4,opt,name=close_failover_version,json=closeFailoverVersion,proto3" json:"close_failover_version,omitempty"`
+	// The batches of the archived history, in the order they are returned by the history archiver.
+	Batches     []*HistoryManifestBatch `protobuf:"bytes,5,rep,name=batches,proto3" json:"batches,omitempty"`
+	ArchiveTime *timestamppb.Timestamp  `protobuf:"bytes,6,opt,name=archive_time,json=archiveTime,proto3" json:"archive_time,omitempty"`
+	// The version history of the archived history. It is not set in the manifests written before it was added.
+	VersionHistory *v11.VersionHistory `protobuf:"bytes,7,opt,name=version_history,json=versionHistory,proto3" json:"version_history,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *HistoryManifest) Reset() {
+	*x = HistoryManifest{}
+	mi := &file_temporal_server_api_archiver_v1_message_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HistoryManifest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryManifest) ProtoMessage() {}
+
+func (x *HistoryManifest) ProtoReflect() protoreflect.Message {
+	mi := &file_temporal_server_api_archiver_v1_message_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoryManifest.ProtoReflect.Descriptor instead.
+func (*HistoryManifest) Descriptor() ([]byte, []int) {
+	return file_temporal_server_api_archiver_v1_message_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *HistoryManifest) GetNamespaceId() string {
+	if x != nil {
+		return x.NamespaceId
+	}
+	return ""
+}
+
+func (x *HistoryManifest) GetWorkflowId() string {
+	if x != nil {
+		return x.WorkflowId
+	}
+	return ""
+}
+
+func (x *HistoryManifest) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+func (x *HistoryManifest) GetCloseFailoverVersion() int64 {
+	if x != nil {
+		return x.CloseFailoverVersion
+	}
+	return 0
+}
+
+func (x *HistoryManifest) GetBatches() []*HistoryManifestBatch {
+	if x != nil {
+		return x.Batches
+	}
+	return nil
+}
+
+func (x *HistoryManifest) GetArchiveTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ArchiveTime
+	}
+	return nil
+}
+
+func (x *HistoryManifest) GetVersionHistory() *v11.VersionHistory {
+	if x != nil {
+		return x.VersionHistory
+	}
+	return nil
+}
+
+type HistoryManifestBatch struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	FirstEventId int64                  `protobuf:"varint,1,opt,name=first_event_id,json=firstEventId,proto3" json:"first_event_id,omitempty"`
+	LastEventId  int64                  `protobuf:"varint,2,opt,name=last_event_id,json=lastEventId,proto3" json:"last_event_id,omitempty"`
+	// Hex encoded SHA-256 hash of the deterministic protobuf encoding of the batch.
+	Sha256 string `protobuf:"bytes,3,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	// The version history items of the events of the batch.
+	VersionHistoryItems []*v11.VersionHistoryItem `protobuf:"bytes,4,rep,name=version_history_items,json=versionHistoryItems,proto3" json:"version_history_items,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *HistoryManifestBatch) Reset() {
+	*x = HistoryManifestBatch{}
+	mi := &file_temporal_server_api_archiver_v1_message_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HistoryManifestBatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryManifestBatch) ProtoMessage() {}
+
+func (x *HistoryManifestBatch) ProtoReflect() protoreflect.Message {
+	mi := &file_temporal_server_api_archiver_v1_message_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoryManifestBatch.ProtoReflect.Descriptor instead.
+func (*HistoryManifestBatch) Descriptor() ([]byte, []int) {
+	return file_temporal_server_api_archiver_v1_message_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *HistoryManifestBatch) GetFirstEventId() int64 {
+	if x != nil {
+		return x.FirstEventId
+	}
+	return 0
+}
+
+func (x *HistoryManifestBatch) GetLastEventId() int64 {
+	if x != nil {
+		return x.LastEventId
+	}
+	return 0
+}
+
+func (x *HistoryManifestBatch) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *HistoryManifestBatch) GetVersionHistoryItems() []*v11.VersionHistoryItem {
+	if x != nil {
+		return x.VersionHistoryItems
+	}
+	return nil
+}
+
 // VisibilityRecord is a single workflow visibility record in archive.
 type VisibilityRecord struct {
 	state              protoimpl.MessageState      `protogen:"open.v1"`
@@ -206,9 +374,9 @@ type VisibilityRecord struct {
 	StartTime          *timestamppb.Timestamp      `protobuf:"bytes,6,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
 	ExecutionTime      *timestamppb.Timestamp      `protobuf:"bytes,7,opt,name=execution_time,json=executionTime,proto3" json:"execution_time,omitempty"`
 	CloseTime          *timestamppb.Timestamp      `protobuf:"bytes,8,opt,name=close_time,json=closeTime,proto3" json:"close_time,omitempty"`
-	Status             v11.WorkflowExecutionStatus `protobuf:"varint,9,opt,name=status,proto3,enum=temporal.api.enums.v1.WorkflowExecutionStatus" json:"status,omitempty"`
+	Status             v12.WorkflowExecutionStatus `protobuf:"varint,9,opt,name=status,proto3,enum=temporal.api.enums.v1.WorkflowExecutionStatus" json:"status,omitempty"`
 	HistoryLength      int64                       `protobuf:"varint,10,opt,name=history_length,json=historyLength,proto3" json:"history_length,omitempty"`
-	Memo               *v12.Memo                   `protobuf:"bytes,11,opt,name=memo,proto3" json:"memo,omitempty"`
+	Memo               *v13.Memo                   `protobuf:"bytes,11,opt,name=memo,proto3" json:"memo,omitempty"`
 	SearchAttributes   map[string]string           `protobuf:"bytes,12,rep,name=search_attributes,json=searchAttributes,proto3" json:"search_attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	HistoryArchivalUri string                      `protobuf:"bytes,13,opt,name=history_archival_uri,json=historyArchivalUri,proto3" json:"history_archival_uri,omitempty"`
 	ExecutionDuration  *durationpb.Duration        `protobuf:"bytes,14,opt,name=execution_duration,json=executionDuration,proto3" json:"execution_duration,omitempty"`
@@ -218,7 +386,7 @@ type VisibilityRecord struct {
 
 func (x *VisibilityRecord) Reset() {
 	*x = VisibilityRecord{}
-	mi := &file_temporal_server_api_archiver_v1_message_proto_msgTypes[2]
+	mi := &file_temporal_server_api_archiver_v1_message_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -230,7 +398,7 @@ func (x *VisibilityRecord) String() string {
 func (*VisibilityRecord) ProtoMessage() {}
 
 func (x *VisibilityRecord) ProtoReflect() protoreflect.Message {
-	mi := &file_temporal_server_api_archiver_v1_message_proto_msgTypes[2]
+	mi := &file_temporal_server_api_archiver_v1_message_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -243,7 +411,7 @@ func (x *VisibilityRecord) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VisibilityRecord.ProtoReflect.Descriptor instead.
 func (*VisibilityRecord) Descriptor() ([]byte, []int) {
-	return file_temporal_server_api_archiver_v1_message_proto_rawDescGZIP(), []int{2}
+	return file_temporal_server_api_archiver_v1_message_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *VisibilityRecord) GetNamespaceId() string {
@@ -302,11 +470,11 @@ func (x *VisibilityRecord) GetCloseTime() *timestamppb.Timestamp {
 	return nil
 }
 
-func (x *VisibilityRecord) GetStatus() v11.WorkflowExecutionStatus {
+func (x *VisibilityRecord) GetStatus() v12.WorkflowExecutionStatus {
 	if x != nil {
 		return x.Status
 	}
-	return v11.WorkflowExecutionStatus(0)
+	return v12.WorkflowExecutionStatus(0)
 }
 
 func (x *VisibilityRecord) GetHistoryLength() int64 {
@@ -316,7 +484,7 @@ func (x *VisibilityRecord) GetHistoryLength() int64 {
 	return 0
 }
 
-func (x *VisibilityRecord) GetMemo() *v12.Memo {
+func (x *VisibilityRecord) GetMemo() *v13.Memo {
 	if x != nil {
 		return x.Memo
 	}
@@ -344,11 +512,123 @@ func (x *VisibilityRecord) GetExecutionDuration() *durationpb.Duration {
 	return nil
 }
 
+// ChasmExecution is the final component tree of a closed CHASM execution in archive.
+type ChasmExecution struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	NamespaceId string                 `protobuf:"bytes,1,opt,name=namespace_id,json=namespaceId,proto3" json:"namespace_id,omitempty"`
+	Namespace   string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	BusinessId  string                 `protobuf:"bytes,3,opt,name=business_id,json=businessId,proto3" json:"business_id,omitempty"`
+	RunId       string                 `protobuf:"bytes,4,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	// (-- api-linter: core::0141::forbidden-types=disabled --)
+	ArchetypeId uint32 `protobuf:"varint,5,opt,name=archetype_id,json=archetypeId,proto3" json:"archetype_id,omitempty"`
+	// Fully qualified name of the root component of the execution.
+	Archetype            string                 `protobuf:"bytes,6,opt,name=archetype,proto3" json:"archetype,omitempty"`
+	CloseFailoverVersion int64                  `protobuf:"varint,7,opt,name=close_failover_version,json=closeFailoverVersion,proto3" json:"close_failover_version,omitempty"`
+	CloseTime            *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=close_time,json=closeTime,proto3" json:"close_time,omitempty"`
+	// Serialized nodes of the component tree, keyed by their encoded paths.
+	Nodes         map[string]*v14.ChasmNode `protobuf:"bytes,9,rep,name=nodes,proto3" json:"nodes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChasmExecution) Reset() {
+	*x = ChasmExecution{}
+	mi := &file_temporal_server_api_archiver_v1_message_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChasmExecution) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChasmExecution) ProtoMessage() {}
+
+func (x *ChasmExecution) ProtoReflect() protoreflect.Message {
+	mi := &file_temporal_server_api_archiver_v1_message_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChasmExecution.ProtoReflect.Descriptor instead.
+func (*ChasmExecution) Descriptor() ([]byte, []int) {
+	return file_temporal_server_api_archiver_v1_message_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ChasmExecution) GetNamespaceId() string {
+	if x != nil {
+		return x.NamespaceId
+	}
+	return ""
+}
+
+func (x *ChasmExecution) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *ChasmExecution) GetBusinessId() string {
+	if x != nil {
+		return x.BusinessId
+	}
+	return ""
+}
+
+func (x *ChasmExecution) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+func (x *ChasmExecution) GetArchetypeId() uint32 {
+	if x != nil {
+		return x.ArchetypeId
+	}
+	return 0
+}
+
+func (x *ChasmExecution) GetArchetype() string {
+	if x != nil {
+		return x.Archetype
+	}
+	return ""
+}
+
+func (x *ChasmExecution) GetCloseFailoverVersion() int64 {
+	if x != nil {
+		return x.CloseFailoverVersion
+	}
+	return 0
+}
+
+func (x *ChasmExecution) GetCloseTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CloseTime
+	}
+	return nil
+}
+
+func (x *ChasmExecution) GetNodes() map[string]*v14.ChasmNode {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
 var File_temporal_server_api_archiver_v1_message_proto protoreflect.FileDescriptor
 
 const file_temporal_server_api_archiver_v1_message_proto_rawDesc = "" +
 	"\n" +
-	"-temporal/server/api/archiver/v1/message.proto\x12\x1ftemporal.server.api.archiver.v1\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a$temporal/api/common/v1/message.proto\x1a%temporal/api/history/v1/message.proto\x1a$temporal/api/enums/v1/workflow.proto\"\xfa\x02\n" +
+	"-temporal/server/api/archiver/v1/message.proto\x12\x1ftemporal.server.api.archiver.v1\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a$temporal/api/common/v1/message.proto\x1a%temporal/api/history/v1/message.proto\x1a$temporal/api/enums/v1/workflow.proto\x1a,temporal/server/api/history/v1/message.proto\x1a.temporal/server/api/persistence/v1/chasm.proto\"\xfa\x02\n" +
 	"\x11HistoryBlobHeader\x12\x1c\n" +
 	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12!\n" +
 	"\fnamespace_id\x18\x02 \x01(\tR\vnamespaceId\x12\x1f\n" +
@@ -365,7 +645,21 @@ const file_temporal_server_api_archiver_v1_message_proto_rawDesc = "" +
 	"eventCount\"\x8f\x01\n" +
 	"\vHistoryBlob\x12J\n" +
 	"\x06header\x18\x01 \x01(\v22.temporal.server.api.archiver.v1.HistoryBlobHeaderR\x06header\x124\n" +
-	"\x04body\x18\x02 \x03(\v2 .temporal.api.history.v1.HistoryR\x04body\"\xca\x06\n" +
+	"\x04body\x18\x02 \x03(\v2 .temporal.api.history.v1.HistoryR\x04body\"\x8b\x03\n" +
+	"\x0fHistoryManifest\x12!\n" +
+	"\fnamespace_id\x18\x01 \x01(\tR\vnamespaceId\x12\x1f\n" +
+	"\vworkflow_id\x18\x02 \x01(\tR\n" +
+	"workflowId\x12\x15\n" +
+	"\x06run_id\x18\x03 \x01(\tR\x05runId\x124\n" +
+	"\x16close_failover_version\x18\x04 \x01(\x03R\x14closeFailoverVersion\x12O\n" +
+	"\abatches\x18\x05 \x03(\v25.temporal.server.api.archiver.v1.HistoryManifestBatchR\abatches\x12=\n" +
+	"\farchive_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\varchiveTime\x12W\n" +
+	"\x0fversion_history\x18\a \x01(\v2..temporal.server.api.history.v1.VersionHistoryR\x0eversionHistory\"\xe0\x01\n" +
+	"\x14HistoryManifestBatch\x12$\n" +
+	"\x0efirst_event_id\x18\x01 \x01(\x03R\ffirstEventId\x12\"\n" +
+	"\rlast_event_id\x18\x02 \x01(\x03R\vlastEventId\x12\x16\n" +
+	"\x06sha256\x18\x03 \x01(\tR\x06sha256\x12f\n" +
+	"\x15version_history_items\x18\x04 \x03(\v22.temporal.server.api.history.v1.VersionHistoryItemR\x13versionHistoryItems\"\xca\x06\n" +
 	"\x10VisibilityRecord\x12!\n" +
 	"\fnamespace_id\x18\x01 \x01(\tR\vnamespaceId\x12\x1c\n" +
 	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x1f\n" +
@@ -387,7 +681,23 @@ const file_temporal_server_api_archiver_v1_message_proto_rawDesc = "" +
 	"\x12execution_duration\x18\x0e \x01(\v2\x19.google.protobuf.DurationR\x11executionDuration\x1aC\n" +
 	"\x15SearchAttributesEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B0Z.go.temporal.io/server/api/archiver/v1;archiverb\x06proto3"
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xf6\x03\n" +
+	"\x0eChasmExecution\x12!\n" +
+	"\fnamespace_id\x18\x01 \x01(\tR\vnamespaceId\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x1f\n" +
+	"\vbusiness_id\x18\x03 \x01(\tR\n" +
+	"businessId\x12\x15\n" +
+	"\x06run_id\x18\x04 \x01(\tR\x05runId\x12!\n" +
+	"\farchetype_id\x18\x05 \x01(\rR\varchetypeId\x12\x1c\n" +
+	"\tarchetype\x18\x06 \x01(\tR\tarchetype\x124\n" +
+	"\x16close_failover_version\x18\a \x01(\x03R\x14closeFailoverVersion\x129\n" +
+	"\n" +
+	"close_time\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcloseTime\x12P\n" +
+	"\x05nodes\x18\t \x03(\v2:.temporal.server.api.archiver.v1.ChasmExecution.NodesEntryR\x05nodes\x1ag\n" +
+	"\n" +
+	"NodesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12C\n" +
+	"\x05value\x18\x02 \x01(\v2-.temporal.server.api.persistence.v1.ChasmNodeR\x05value:\x028\x01B0Z.go.temporal.io/server/api/archiver/v1;archiverb\x06proto3"
 
 var (
 	file_temporal_server_api_archiver_v1_message_proto_rawDescOnce sync.Once
@@ -401,33 +711,47 @@ func file_temporal_server_api_archiver_v1_message_proto_rawDescGZIP() []byte {
 	return file_temporal_server_api_archiver_v1_message_proto_rawDescData
 }
 
-var file_temporal_server_api_archiver_v1_message_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_temporal_server_api_archiver_v1_message_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_temporal_server_api_archiver_v1_message_proto_goTypes = []any{
 	(*HistoryBlobHeader)(nil),        // 0: temporal.server.api.archiver.v1.HistoryBlobHeader
 	(*HistoryBlob)(nil),              // 1: temporal.server.api.archiver.v1.HistoryBlob
-	(*VisibilityRecord)(nil),         // 2: temporal.server.api.archiver.v1.VisibilityRecord
-	nil,                              // 3: temporal.server.api.archiver.v1.VisibilityRecord.SearchAttributesEntry
-	(*v1.History)(nil),               // 4: temporal.api.history.v1.History
-	(*timestamppb.Timestamp)(nil),    // 5: google.protobuf.Timestamp
-	(v11.WorkflowExecutionStatus)(0), // 6: temporal.api.enums.v1.WorkflowExecutionStatus
-	(*v12.Memo)(nil),                 // 7: temporal.api.common.v1.Memo
-	(*durationpb.Duration)(nil),      // 8: google.protobuf.Duration
+	(*HistoryManifest)(nil),          // 2: temporal.server.api.archiver.v1.HistoryManifest
+	(*HistoryManifestBatch)(nil),     // 3: temporal.server.api.archiver.v1.HistoryManifestBatch
+	(*VisibilityRecord)(nil),         // 4: temporal.server.api.archiver.v1.VisibilityRecord
+	(*ChasmExecution)(nil),           // 5: temporal.server.api.archiver.v1.ChasmExecution
+	nil,                              // 6: temporal.server.api.archiver.v1.VisibilityRecord.SearchAttributesEntry
+	nil,                              // 7: temporal.server.api.archiver.v1.ChasmExecution.NodesEntry
+	(*v1.History)(nil),               // 8: temporal.api.history.v1.History
+	(*timestamppb.Timestamp)(nil),    // 9: google.protobuf.Timestamp
+	(*v11.VersionHistory)(nil),       // 10: temporal.server.api.history.v1.VersionHistory
+	(*v11.VersionHistoryItem)(nil),   // 11: temporal.server.api.history.v1.VersionHistoryItem
+	(v12.WorkflowExecutionStatus)(0), // 12: temporal.api.enums.v1.WorkflowExecutionStatus
+	(*v13.Memo)(nil),                 // 13: temporal.api.common.v1.Memo
+	(*durationpb.Duration)(nil),      // 14: google.protobuf.Duration
+	(*v14.ChasmNode)(nil),            // 15: temporal.server.api.persistence.v1.ChasmNode
 }
 var file_temporal_server_api_archiver_v1_message_proto_depIdxs = []int32{
-	0, // 0: temporal.server.api.archiver.v1.HistoryBlob.header:type_name -> temporal.server.api.archiver.v1.HistoryBlobHeader
-	4, // 1: temporal.server.api.archiver.v1.HistoryBlob.body:type_name -> temporal.api.history.v1.History
-	5, // 2: temporal.server.api.archiver.v1.VisibilityRecord.start_time:type_name -> google.protobuf.Timestamp
-	5, // 3: temporal.server.api.archiver.v1.VisibilityRecord.execution_time:type_name -> google.protobuf.Timestamp
-	5, // 4: temporal.server.api.archiver.v1.VisibilityRecord.close_time:type_name -> google.protobuf.Timestamp
-	6, // 5: temporal.server.api.archiver.v1.VisibilityRecord.status:type_name -> temporal.api.enums.v1.WorkflowExecutionStatus
-	7, // 6: temporal.server.api.archiver.v1.VisibilityRecord.memo:type_name -> temporal.api.common.v1.Memo
-	3, // 7: temporal.server.api.archiver.v1.VisibilityRecord.search_attributes:type_name -> temporal.server.api.archiver.v1.VisibilityRecord.SearchAttributesEntry
-	8, // 8: temporal.server.api.archiver.v1.VisibilityRecord.execution_duration:type_name -> google.protobuf.Duration
-	9, // [9:9] is the sub-list for method output_type
-	9, // [9:9] is the sub-list for method input_type
-	9, // [9:9] is the sub-list for extension type_name
-	9, // [9:9] is the sub-list for extension extendee
-	0, // [0:9] is the sub-list for field type_name
+	0,  // 0: temporal.server.api.archiver.v1.HistoryBlob.header:type_name -> temporal.server.api.archiver.v1.HistoryBlobHeader
+	8,  // 1: temporal.server.api.archiver.v1.HistoryBlob.body:type_name -> temporal.api.history.v1.History
+	3,  // 2: temporal.server.api.archiver.v1.HistoryManifest.batches:type_name -> temporal.server.api.archiver.v1.HistoryManifestBatch
+	9,  // 3: temporal.server.api.archiver.v1.HistoryManifest.archive_time:type_name -> google.protobuf.Timestamp
+	10, // 4: temporal.server.api.archiver.v1.HistoryManifest.version_history:type_name -> temporal.server.api.history.v1.VersionHistory
+	11, // 5: temporal.server.api.archiver.v1.HistoryManifestBatch.version_history_items:type_name -> temporal.server.api.history.v1.VersionHistoryItem
+	9,  // 6: temporal.server.api.archiver.v1.VisibilityRecord.start_time:type_name -> google.protobuf.Timestamp
+	9,  // 7: temporal.server.api.archiver.v1.VisibilityRecord.execution_time:type_name -> google.protobuf.Timestamp
+	9,  // 8: temporal.server.api.archiver.v1.VisibilityRecord.close_time:type_name -> google.protobuf.Timestamp
+	12, // 9: temporal.server.api.archiver.v1.VisibilityRecord.status:type_name -> temporal.api.enums.v1.WorkflowExecutionStatus
+	13, // 10: temporal.server.api.archiver.v1.VisibilityRecord.memo:type_name -> temporal.api.common.v1.Memo
+	6,  // 11: temporal.server.api.archiver.v1.VisibilityRecord.search_attributes:type_name -> temporal.server.api.archiver.v1.VisibilityRecord.SearchAttributesEntry
+	14, // 12: temporal.server.api.archiver.v1.VisibilityRecord.execution_duration:type_name -> google.protobuf.Duration
+	9,  // 13: temporal.server.api.archiver.v1.ChasmExecution.close_time:type_name -> google.protobuf.Timestamp
+	7,  // 14: temporal.server.api.archiver.v1.ChasmExecution.nodes:type_name -> temporal.server.api.archiver.v1.ChasmExecution.NodesEntry
+	15, // 15: temporal.server.api.archiver.v1.ChasmExecution.NodesEntry.value:type_name -> temporal.server.api.persistence.v1.ChasmNode
+	16, // [16:16] is the sub-list for method output_type
+	16, // [16:16] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_temporal_server_api_archiver_v1_message_proto_init() }
@@ -441,7 +765,7 @@ func file_temporal_server_api_archiver_v1_message_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_temporal_server_api_archiver_v1_message_proto_rawDesc), len(file_temporal_server_api_archiver_v1_message_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,28 @@
+// Code generated by protoc-gen-go-helpers. DO NOT EDIT.
+package enums
+
+import (
+	"fmt"
+)
+
+var (
+	ArchivalIntegrityStatus_shorthandValue = map[string]int32{
+		"Unspecified": 0,
+		"Ok":          1,
+		"Unverified":  2,
+		"Missing":     3,
+		"Truncated":   4,
+		"Corrupted":   5,
+	}
+)
+
+// ArchivalIntegrityStatusFromString parses a ArchivalIntegrityStatus value from  either the protojson
+// canonical SCREAMING_CASE enum or the traditional temporal PascalCase enum to ArchivalIntegrityStatus
+func ArchivalIntegrityStatusFromString(s string) (ArchivalIntegrityStatus, error) {
+	if v, ok := ArchivalIntegrityStatus_value[s]; ok {
+		return ArchivalIntegrityStatus(v), nil
+	} else if v, ok := ArchivalIntegrityStatus_shorthandValue[s]; ok {
+		return ArchivalIntegrityStatus(v), nil
+	}
+	return ArchivalIntegrityStatus(0), fmt.Errorf("%s is not a valid ArchivalIntegrityStatus", s)
+}
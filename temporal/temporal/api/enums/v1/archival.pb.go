@@ -0,0 +1,166 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// plugins:
+// 	protoc-gen-go
+// 	protoc
+// source: temporal/server/api/enums/v1/archival.proto
+
+package enums
+
+import (
+	reflect "reflect"
+	"strconv"
+	sync "sync"
+	unsafe "unsafe"
+
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ArchivalIntegrityStatus is the result of the verification of an archived history against its manifest.
+type ArchivalIntegrityStatus int32
+
+const (
+	ARCHIVAL_INTEGRITY_STATUS_UNSPECIFIED ArchivalIntegrityStatus = 0
+	// The archived history matches its manifest.
+	ARCHIVAL_INTEGRITY_STATUS_OK ArchivalIntegrityStatus = 1
+	// The archived history has no manifest, because it was archived before the manifests were written or by an
+	// archiver which does not write them, so it cannot be verified.
+	ARCHIVAL_INTEGRITY_STATUS_UNVERIFIED ArchivalIntegrityStatus = 2
+	// No archived history is found for the run.
+	ARCHIVAL_INTEGRITY_STATUS_MISSING ArchivalIntegrityStatus = 3
+	// The archived history ends before the last batch of its manifest.
+	ARCHIVAL_INTEGRITY_STATUS_TRUNCATED ArchivalIntegrityStatus = 4
+	// The archived history cannot be decoded, or does not match the event ranges or the hashes of its manifest.
+	ARCHIVAL_INTEGRITY_STATUS_CORRUPTED ArchivalIntegrityStatus = 5
+)
+
+// Enum value maps for ArchivalIntegrityStatus.
+var (
+	ArchivalIntegrityStatus_name = map[int32]string{
+		0: "ARCHIVAL_INTEGRITY_STATUS_UNSPECIFIED",
+		1: "ARCHIVAL_INTEGRITY_STATUS_OK",
+		2: "ARCHIVAL_INTEGRITY_STATUS_UNVERIFIED",
+		3: "ARCHIVAL_INTEGRITY_STATUS_MISSING",
+		4: "ARCHIVAL_INTEGRITY_STATUS_TRUNCATED",
+		5: "ARCHIVAL_INTEGRITY_STATUS_CORRUPTED",
+	}
+	ArchivalIntegrityStatus_value = map[string]int32{
+		"ARCHIVAL_INTEGRITY_STATUS_UNSPECIFIED": 0,
+		"ARCHIVAL_INTEGRITY_STATUS_OK":          1,
+		"ARCHIVAL_INTEGRITY_STATUS_UNVERIFIED":  2,
+		"ARCHIVAL_INTEGRITY_STATUS_MISSING":     3,
+		"ARCHIVAL_INTEGRITY_STATUS_TRUNCATED":   4,
+		"ARCHIVAL_INTEGRITY_STATUS_CORRUPTED":   5,
+	}
+)
+
+func (x ArchivalIntegrityStatus) Enum() *ArchivalIntegrityStatus {
+	p := new(ArchivalIntegrityStatus)
+	*p = x
+	return p
+}
+
+func (x ArchivalIntegrityStatus) String() string {
+	switch x {
+	case ARCHIVAL_INTEGRITY_STATUS_UNSPECIFIED:
+		return "Unspecified"
+	case ARCHIVAL_INTEGRITY_STATUS_OK:
+		return "Ok"
+	case ARCHIVAL_INTEGRITY_STATUS_UNVERIFIED:
+		return "Unverified"
+	case ARCHIVAL_INTEGRITY_STATUS_MISSING:
+		return "Missing"
+	case ARCHIVAL_INTEGRITY_STATUS_TRUNCATED:
+		return "Truncated"
+	case ARCHIVAL_INTEGRITY_STATUS_CORRUPTED:
+		return "Corrupted"
+	default:
+		return strconv.Itoa(int(x))
+	}
+
+}
+
+func (ArchivalIntegrityStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_temporal_server_api_enums_v1_archival_proto_enumTypes[0].Descriptor()
+}
+
+func (ArchivalIntegrityStatus) Type() protoreflect.EnumType {
+	return &file_temporal_server_api_enums_v1_archival_proto_enumTypes[0]
+}
+
+func (x ArchivalIntegrityStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ArchivalIntegrityStatus.Descriptor instead.
+func (ArchivalIntegrityStatus) EnumDescriptor() ([]byte, []int) {
+	return file_temporal_server_api_enums_v1_archival_proto_rawDescGZIP(), []int{0}
+}
+
+var File_temporal_server_api_enums_v1_archival_proto protoreflect.FileDescriptor
+
+const file_temporal_server_api_enums_v1_archival_proto_rawDesc = "" +
+	"\n" +
+	"+temporal/server/api/enums/v1/archival.proto\x12\x1ctemporal.server.api.enums.v1*\x89\x02\n" +
+	"\x17ArchivalIntegrityStatus\x12)\n" +
+	"%ARCHIVAL_INTEGRITY_STATUS_UNSPECIFIED\x10\x00\x12 \n" +
+	"\x1cARCHIVAL_INTEGRITY_STATUS_OK\x10\x01\x12(\n" +
+	"$ARCHIVAL_INTEGRITY_STATUS_UNVERIFIED\x10\x02\x12%\n" +
+	"!ARCHIVAL_INTEGRITY_STATUS_MISSING\x10\x03\x12'\n" +
+	"#ARCHIVAL_INTEGRITY_STATUS_TRUNCATED\x10\x04\x12'\n" +
+	"#ARCHIVAL_INTEGRITY_STATUS_CORRUPTED\x10\x05B*Z(go.temporal.io/server/api/enums/v1;enumsb\x06proto3"
+
+var (
+	file_temporal_server_api_enums_v1_archival_proto_rawDescOnce sync.Once
+	file_temporal_server_api_enums_v1_archival_proto_rawDescData []byte
+)
+
+func file_temporal_server_api_enums_v1_archival_proto_rawDescGZIP() []byte {
+	file_temporal_server_api_enums_v1_archival_proto_rawDescOnce.Do(func() {
+		file_temporal_server_api_enums_v1_archival_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_temporal_server_api_enums_v1_archival_proto_rawDesc), len(file_temporal_server_api_enums_v1_archival_proto_rawDesc)))
+	})
+	return file_temporal_server_api_enums_v1_archival_proto_rawDescData
+}
+
+var file_temporal_server_api_enums_v1_archival_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_temporal_server_api_enums_v1_archival_proto_goTypes = []any{
+	(ArchivalIntegrityStatus)(0), // 0: temporal.server.api.enums.v1.ArchivalIntegrityStatus
+}
+var file_temporal_server_api_enums_v1_archival_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_temporal_server_api_enums_v1_archival_proto_init() }
+func file_temporal_server_api_enums_v1_archival_proto_init() {
+	if File_temporal_server_api_enums_v1_archival_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_temporal_server_api_enums_v1_archival_proto_rawDesc), len(file_temporal_server_api_enums_v1_archival_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   0,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_temporal_server_api_enums_v1_archival_proto_goTypes,
+		DependencyIndexes: file_temporal_server_api_enums_v1_archival_proto_depIdxs,
+		EnumInfos:         file_temporal_server_api_enums_v1_archival_proto_enumTypes,
+	}.Build()
+	File_temporal_server_api_enums_v1_archival_proto = out.File
+	file_temporal_server_api_enums_v1_archival_proto_goTypes = nil
+	file_temporal_server_api_enums_v1_archival_proto_depIdxs = nil
+}
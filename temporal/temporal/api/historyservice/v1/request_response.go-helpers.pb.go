@@ -2743,6 +2743,80 @@ func (this *GetShardResponse) Equal(that interface{}) bool {
 	return proto.Equal(this, that1)
 }
 
+// Marshal an object of type AllocateTaskIdRequest to the protobuf v3 wire format
+func (val *AllocateTaskIdRequest) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type AllocateTaskIdRequest from the protobuf v3 wire format
+func (val *AllocateTaskIdRequest) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *AllocateTaskIdRequest) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two AllocateTaskIdRequest values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *AllocateTaskIdRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *AllocateTaskIdRequest
+	switch t := that.(type) {
+	case *AllocateTaskIdRequest:
+		that1 = t
+	case AllocateTaskIdRequest:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
+
+// Marshal an object of type AllocateTaskIdResponse to the protobuf v3 wire format
+func (val *AllocateTaskIdResponse) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type AllocateTaskIdResponse from the protobuf v3 wire format
+func (val *AllocateTaskIdResponse) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *AllocateTaskIdResponse) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two AllocateTaskIdResponse values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *AllocateTaskIdResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *AllocateTaskIdResponse
+	switch t := that.(type) {
+	case *AllocateTaskIdResponse:
+		that1 = t
+	case AllocateTaskIdResponse:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
+
 // Marshal an object of type RemoveTaskRequest to the protobuf v3 wire format
 func (val *RemoveTaskRequest) Marshal() ([]byte, error) {
 	return proto.Marshal(val)
@@ -5924,3 +5998,373 @@ func (this *CancelNexusOperationResponse) Equal(that interface{}) bool {
 
 	return proto.Equal(this, that1)
 }
+
+// Marshal an object of type AdvanceNamespaceTimeRequest to the protobuf v3 wire format
+func (val *AdvanceNamespaceTimeRequest) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type AdvanceNamespaceTimeRequest from the protobuf v3 wire format
+func (val *AdvanceNamespaceTimeRequest) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *AdvanceNamespaceTimeRequest) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two AdvanceNamespaceTimeRequest values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *AdvanceNamespaceTimeRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *AdvanceNamespaceTimeRequest
+	switch t := that.(type) {
+	case *AdvanceNamespaceTimeRequest:
+		that1 = t
+	case AdvanceNamespaceTimeRequest:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
+
+// Marshal an object of type AdvanceNamespaceTimeResponse to the protobuf v3 wire format
+func (val *AdvanceNamespaceTimeResponse) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type AdvanceNamespaceTimeResponse from the protobuf v3 wire format
+func (val *AdvanceNamespaceTimeResponse) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *AdvanceNamespaceTimeResponse) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two AdvanceNamespaceTimeResponse values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *AdvanceNamespaceTimeResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *AdvanceNamespaceTimeResponse
+	switch t := that.(type) {
+	case *AdvanceNamespaceTimeResponse:
+		that1 = t
+	case AdvanceNamespaceTimeResponse:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
+
+// Marshal an object of type UpdateNamespaceTimeRequest to the protobuf v3 wire format
+func (val *UpdateNamespaceTimeRequest) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type UpdateNamespaceTimeRequest from the protobuf v3 wire format
+func (val *UpdateNamespaceTimeRequest) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *UpdateNamespaceTimeRequest) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two UpdateNamespaceTimeRequest values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *UpdateNamespaceTimeRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *UpdateNamespaceTimeRequest
+	switch t := that.(type) {
+	case *UpdateNamespaceTimeRequest:
+		that1 = t
+	case UpdateNamespaceTimeRequest:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
+
+// Marshal an object of type UpdateNamespaceTimeResponse to the protobuf v3 wire format
+func (val *UpdateNamespaceTimeResponse) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type UpdateNamespaceTimeResponse from the protobuf v3 wire format
+func (val *UpdateNamespaceTimeResponse) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *UpdateNamespaceTimeResponse) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two UpdateNamespaceTimeResponse values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *UpdateNamespaceTimeResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *UpdateNamespaceTimeResponse
+	switch t := that.(type) {
+	case *UpdateNamespaceTimeResponse:
+		that1 = t
+	case UpdateNamespaceTimeResponse:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
+
+// Marshal an object of type DescribeNamespaceTimeRequest to the protobuf v3 wire format
+func (val *DescribeNamespaceTimeRequest) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type DescribeNamespaceTimeRequest from the protobuf v3 wire format
+func (val *DescribeNamespaceTimeRequest) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *DescribeNamespaceTimeRequest) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two DescribeNamespaceTimeRequest values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *DescribeNamespaceTimeRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *DescribeNamespaceTimeRequest
+	switch t := that.(type) {
+	case *DescribeNamespaceTimeRequest:
+		that1 = t
+	case DescribeNamespaceTimeRequest:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
+
+// Marshal an object of type DescribeNamespaceTimeResponse to the protobuf v3 wire format
+func (val *DescribeNamespaceTimeResponse) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type DescribeNamespaceTimeResponse from the protobuf v3 wire format
+func (val *DescribeNamespaceTimeResponse) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *DescribeNamespaceTimeResponse) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two DescribeNamespaceTimeResponse values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *DescribeNamespaceTimeResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *DescribeNamespaceTimeResponse
+	switch t := that.(type) {
+	case *DescribeNamespaceTimeResponse:
+		that1 = t
+	case DescribeNamespaceTimeResponse:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
+
+// Marshal an object of type AddFaultInjectionRuleRequest to the protobuf v3 wire format
+func (val *AddFaultInjectionRuleRequest) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type AddFaultInjectionRuleRequest from the protobuf v3 wire format
+func (val *AddFaultInjectionRuleRequest) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *AddFaultInjectionRuleRequest) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two AddFaultInjectionRuleRequest values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *AddFaultInjectionRuleRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *AddFaultInjectionRuleRequest
+	switch t := that.(type) {
+	case *AddFaultInjectionRuleRequest:
+		that1 = t
+	case AddFaultInjectionRuleRequest:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
+
+// Marshal an object of type AddFaultInjectionRuleResponse to the protobuf v3 wire format
+func (val *AddFaultInjectionRuleResponse) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type AddFaultInjectionRuleResponse from the protobuf v3 wire format
+func (val *AddFaultInjectionRuleResponse) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *AddFaultInjectionRuleResponse) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two AddFaultInjectionRuleResponse values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *AddFaultInjectionRuleResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *AddFaultInjectionRuleResponse
+	switch t := that.(type) {
+	case *AddFaultInjectionRuleResponse:
+		that1 = t
+	case AddFaultInjectionRuleResponse:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
+
+// Marshal an object of type ClearFaultInjectionRulesRequest to the protobuf v3 wire format
+func (val *ClearFaultInjectionRulesRequest) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type ClearFaultInjectionRulesRequest from the protobuf v3 wire format
+func (val *ClearFaultInjectionRulesRequest) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *ClearFaultInjectionRulesRequest) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two ClearFaultInjectionRulesRequest values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *ClearFaultInjectionRulesRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *ClearFaultInjectionRulesRequest
+	switch t := that.(type) {
+	case *ClearFaultInjectionRulesRequest:
+		that1 = t
+	case ClearFaultInjectionRulesRequest:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
+
+// Marshal an object of type ClearFaultInjectionRulesResponse to the protobuf v3 wire format
+func (val *ClearFaultInjectionRulesResponse) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type ClearFaultInjectionRulesResponse from the protobuf v3 wire format
+func (val *ClearFaultInjectionRulesResponse) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *ClearFaultInjectionRulesResponse) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two ClearFaultInjectionRulesResponse values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *ClearFaultInjectionRulesResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *ClearFaultInjectionRulesResponse
+	switch t := that.(type) {
+	case *ClearFaultInjectionRulesResponse:
+		that1 = t
+	case ClearFaultInjectionRulesResponse:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
@@ -56,6 +56,7 @@ const (
 	HistoryService_DescribeHistoryHost_FullMethodName                    = "/temporal.server.api.historyservice.v1.HistoryService/DescribeHistoryHost"
 	HistoryService_CloseShard_FullMethodName                             = "/temporal.server.api.historyservice.v1.HistoryService/CloseShard"
 	HistoryService_GetShard_FullMethodName                               = "/temporal.server.api.historyservice.v1.HistoryService/GetShard"
+	HistoryService_AllocateTaskId_FullMethodName                         = "/temporal.server.api.historyservice.v1.HistoryService/AllocateTaskId"
 	HistoryService_RemoveTask_FullMethodName                             = "/temporal.server.api.historyservice.v1.HistoryService/RemoveTask"
 	HistoryService_GetReplicationMessages_FullMethodName                 = "/temporal.server.api.historyservice.v1.HistoryService/GetReplicationMessages"
 	HistoryService_GetDLQReplicationMessages_FullMethodName              = "/temporal.server.api.historyservice.v1.HistoryService/GetDLQReplicationMessages"
@@ -96,6 +97,11 @@ const (
 	HistoryService_UnpauseWorkflowExecution_FullMethodName               = "/temporal.server.api.historyservice.v1.HistoryService/UnpauseWorkflowExecution"
 	HistoryService_StartNexusOperation_FullMethodName                    = "/temporal.server.api.historyservice.v1.HistoryService/StartNexusOperation"
 	HistoryService_CancelNexusOperation_FullMethodName                   = "/temporal.server.api.historyservice.v1.HistoryService/CancelNexusOperation"
+	HistoryService_AdvanceNamespaceTime_FullMethodName                   = "/temporal.server.api.historyservice.v1.HistoryService/AdvanceNamespaceTime"
+	HistoryService_UpdateNamespaceTime_FullMethodName                    = "/temporal.server.api.historyservice.v1.HistoryService/UpdateNamespaceTime"
+	HistoryService_DescribeNamespaceTime_FullMethodName                  = "/temporal.server.api.historyservice.v1.HistoryService/DescribeNamespaceTime"
+	HistoryService_AddFaultInjectionRule_FullMethodName                  = "/temporal.server.api.historyservice.v1.HistoryService/AddFaultInjectionRule"
+	HistoryService_ClearFaultInjectionRules_FullMethodName               = "/temporal.server.api.historyservice.v1.HistoryService/ClearFaultInjectionRules"
 )
 
 // HistoryServiceClient is the client API for HistoryService service.
@@ -246,6 +252,9 @@ type HistoryServiceClient interface {
 	CloseShard(ctx context.Context, in *CloseShardRequest, opts ...grpc.CallOption) (*CloseShardResponse, error)
 	// GetShard gets the ShardInfo
 	GetShard(ctx context.Context, in *GetShardRequest, opts ...grpc.CallOption) (*GetShardResponse, error)
+	// AllocateTaskId allocates a task ID from the shard, which is greater than the IDs of the tasks which were already
+	// written by the shard and less than the IDs of the tasks which are written after it.
+	AllocateTaskId(ctx context.Context, in *AllocateTaskIdRequest, opts ...grpc.CallOption) (*AllocateTaskIdResponse, error)
 	// RemoveTask remove task based on type, taskid, shardid.
 	RemoveTask(ctx context.Context, in *RemoveTaskRequest, opts ...grpc.CallOption) (*RemoveTaskResponse, error)
 	// GetReplicationMessages return replication messages based on the read level
@@ -387,6 +396,19 @@ type HistoryServiceClient interface {
 	StartNexusOperation(ctx context.Context, in *StartNexusOperationRequest, opts ...grpc.CallOption) (*StartNexusOperationResponse, error)
 	// CancelNexusOperation cancels a Nexus operation on the __temporal_system endpoint.
 	CancelNexusOperation(ctx context.Context, in *CancelNexusOperationRequest, opts ...grpc.CallOption) (*CancelNexusOperationResponse, error)
+	// AdvanceNamespaceTime moves the virtual time of a namespace with time skipping enabled forward. The skipped time is
+	// persisted in the namespace metadata, and then sent to every history host with UpdateNamespaceTime.
+	AdvanceNamespaceTime(ctx context.Context, in *AdvanceNamespaceTimeRequest, opts ...grpc.CallOption) (*AdvanceNamespaceTimeResponse, error)
+	// UpdateNamespaceTime moves the virtual time of a namespace with time skipping enabled forward on the history host,
+	// ahead of the refresh of its namespace registry.
+	UpdateNamespaceTime(ctx context.Context, in *UpdateNamespaceTimeRequest, opts ...grpc.CallOption) (*UpdateNamespaceTimeResponse, error)
+	// DescribeNamespaceTime returns whether the executions of a namespace with time skipping enabled on the shards of
+	// the history host are idle, and the fire time of their next timer.
+	DescribeNamespaceTime(ctx context.Context, in *DescribeNamespaceTimeRequest, opts ...grpc.CallOption) (*DescribeNamespaceTimeResponse, error)
+	// AddFaultInjectionRule adds a persistence fault injection rule to the process of the history host.
+	AddFaultInjectionRule(ctx context.Context, in *AddFaultInjectionRuleRequest, opts ...grpc.CallOption) (*AddFaultInjectionRuleResponse, error)
+	// ClearFaultInjectionRules removes the runtime persistence fault injection rules of the process of the history host.
+	ClearFaultInjectionRules(ctx context.Context, in *ClearFaultInjectionRulesRequest, opts ...grpc.CallOption) (*ClearFaultInjectionRulesResponse, error)
 }
 
 type historyServiceClient struct {
@@ -721,6 +743,15 @@ func (c *historyServiceClient) GetShard(ctx context.Context, in *GetShardRequest
 	return out, nil
 }
 
+func (c *historyServiceClient) AllocateTaskId(ctx context.Context, in *AllocateTaskIdRequest, opts ...grpc.CallOption) (*AllocateTaskIdResponse, error) {
+	out := new(AllocateTaskIdResponse)
+	err := c.cc.Invoke(ctx, HistoryService_AllocateTaskId_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *historyServiceClient) RemoveTask(ctx context.Context, in *RemoveTaskRequest, opts ...grpc.CallOption) (*RemoveTaskResponse, error) {
 	out := new(RemoveTaskResponse)
 	err := c.cc.Invoke(ctx, HistoryService_RemoveTask_FullMethodName, in, out, opts...)
@@ -1103,6 +1134,51 @@ func (c *historyServiceClient) CancelNexusOperation(ctx context.Context, in *Can
 	return out, nil
 }
 
+func (c *historyServiceClient) AdvanceNamespaceTime(ctx context.Context, in *AdvanceNamespaceTimeRequest, opts ...grpc.CallOption) (*AdvanceNamespaceTimeResponse, error) {
+	out := new(AdvanceNamespaceTimeResponse)
+	err := c.cc.Invoke(ctx, HistoryService_AdvanceNamespaceTime_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *historyServiceClient) UpdateNamespaceTime(ctx context.Context, in *UpdateNamespaceTimeRequest, opts ...grpc.CallOption) (*UpdateNamespaceTimeResponse, error) {
+	out := new(UpdateNamespaceTimeResponse)
+	err := c.cc.Invoke(ctx, HistoryService_UpdateNamespaceTime_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *historyServiceClient) DescribeNamespaceTime(ctx context.Context, in *DescribeNamespaceTimeRequest, opts ...grpc.CallOption) (*DescribeNamespaceTimeResponse, error) {
+	out := new(DescribeNamespaceTimeResponse)
+	err := c.cc.Invoke(ctx, HistoryService_DescribeNamespaceTime_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *historyServiceClient) AddFaultInjectionRule(ctx context.Context, in *AddFaultInjectionRuleRequest, opts ...grpc.CallOption) (*AddFaultInjectionRuleResponse, error) {
+	out := new(AddFaultInjectionRuleResponse)
+	err := c.cc.Invoke(ctx, HistoryService_AddFaultInjectionRule_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *historyServiceClient) ClearFaultInjectionRules(ctx context.Context, in *ClearFaultInjectionRulesRequest, opts ...grpc.CallOption) (*ClearFaultInjectionRulesResponse, error) {
+	out := new(ClearFaultInjectionRulesResponse)
+	err := c.cc.Invoke(ctx, HistoryService_ClearFaultInjectionRules_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // HistoryServiceServer is the server API for HistoryService service.
 // All implementations must embed UnimplementedHistoryServiceServer
 // for forward compatibility
@@ -1251,6 +1327,9 @@ type HistoryServiceServer interface {
 	CloseShard(context.Context, *CloseShardRequest) (*CloseShardResponse, error)
 	// GetShard gets the ShardInfo
 	GetShard(context.Context, *GetShardRequest) (*GetShardResponse, error)
+	// AllocateTaskId allocates a task ID from the shard, which is greater than the IDs of the tasks which were already
+	// written by the shard and less than the IDs of the tasks which are written after it.
+	AllocateTaskId(context.Context, *AllocateTaskIdRequest) (*AllocateTaskIdResponse, error)
 	// RemoveTask remove task based on type, taskid, shardid.
 	RemoveTask(context.Context, *RemoveTaskRequest) (*RemoveTaskResponse, error)
 	// GetReplicationMessages return replication messages based on the read level
@@ -1392,6 +1471,19 @@ type HistoryServiceServer interface {
 	StartNexusOperation(context.Context, *StartNexusOperationRequest) (*StartNexusOperationResponse, error)
 	// CancelNexusOperation cancels a Nexus operation on the __temporal_system endpoint.
 	CancelNexusOperation(context.Context, *CancelNexusOperationRequest) (*CancelNexusOperationResponse, error)
+	// AdvanceNamespaceTime moves the virtual time of a namespace with time skipping enabled forward. The skipped time is
+	// persisted in the namespace metadata, and then sent to every history host with UpdateNamespaceTime.
+	AdvanceNamespaceTime(context.Context, *AdvanceNamespaceTimeRequest) (*AdvanceNamespaceTimeResponse, error)
+	// UpdateNamespaceTime moves the virtual time of a namespace with time skipping enabled forward on the history host,
+	// ahead of the refresh of its namespace registry.
+	UpdateNamespaceTime(context.Context, *UpdateNamespaceTimeRequest) (*UpdateNamespaceTimeResponse, error)
+	// DescribeNamespaceTime returns whether the executions of a namespace with time skipping enabled on the shards of
+	// the history host are idle, and the fire time of their next timer.
+	DescribeNamespaceTime(context.Context, *DescribeNamespaceTimeRequest) (*DescribeNamespaceTimeResponse, error)
+	// AddFaultInjectionRule adds a persistence fault injection rule to the process of the history host.
+	AddFaultInjectionRule(context.Context, *AddFaultInjectionRuleRequest) (*AddFaultInjectionRuleResponse, error)
+	// ClearFaultInjectionRules removes the runtime persistence fault injection rules of the process of the history host.
+	ClearFaultInjectionRules(context.Context, *ClearFaultInjectionRulesRequest) (*ClearFaultInjectionRulesResponse, error)
 	mustEmbedUnimplementedHistoryServiceServer()
 }
 
@@ -1507,6 +1599,9 @@ func (UnimplementedHistoryServiceServer) CloseShard(context.Context, *CloseShard
 func (UnimplementedHistoryServiceServer) GetShard(context.Context, *GetShardRequest) (*GetShardResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetShard not implemented")
 }
+func (UnimplementedHistoryServiceServer) AllocateTaskId(context.Context, *AllocateTaskIdRequest) (*AllocateTaskIdResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AllocateTaskId not implemented")
+}
 func (UnimplementedHistoryServiceServer) RemoveTask(context.Context, *RemoveTaskRequest) (*RemoveTaskResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveTask not implemented")
 }
@@ -1627,6 +1722,21 @@ func (UnimplementedHistoryServiceServer) StartNexusOperation(context.Context, *S
 func (UnimplementedHistoryServiceServer) CancelNexusOperation(context.Context, *CancelNexusOperationRequest) (*CancelNexusOperationResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CancelNexusOperation not implemented")
 }
+func (UnimplementedHistoryServiceServer) AdvanceNamespaceTime(context.Context, *AdvanceNamespaceTimeRequest) (*AdvanceNamespaceTimeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdvanceNamespaceTime not implemented")
+}
+func (UnimplementedHistoryServiceServer) UpdateNamespaceTime(context.Context, *UpdateNamespaceTimeRequest) (*UpdateNamespaceTimeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateNamespaceTime not implemented")
+}
+func (UnimplementedHistoryServiceServer) DescribeNamespaceTime(context.Context, *DescribeNamespaceTimeRequest) (*DescribeNamespaceTimeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DescribeNamespaceTime not implemented")
+}
+func (UnimplementedHistoryServiceServer) AddFaultInjectionRule(context.Context, *AddFaultInjectionRuleRequest) (*AddFaultInjectionRuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddFaultInjectionRule not implemented")
+}
+func (UnimplementedHistoryServiceServer) ClearFaultInjectionRules(context.Context, *ClearFaultInjectionRulesRequest) (*ClearFaultInjectionRulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearFaultInjectionRules not implemented")
+}
 func (UnimplementedHistoryServiceServer) mustEmbedUnimplementedHistoryServiceServer() {}
 
 // UnsafeHistoryServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -2288,6 +2398,24 @@ func _HistoryService_GetShard_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _HistoryService_AllocateTaskId_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AllocateTaskIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HistoryServiceServer).AllocateTaskId(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HistoryService_AllocateTaskId_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HistoryServiceServer).AllocateTaskId(ctx, req.(*AllocateTaskIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _HistoryService_RemoveTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RemoveTaskRequest)
 	if err := dec(in); err != nil {
@@ -3016,6 +3144,96 @@ func _HistoryService_CancelNexusOperation_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _HistoryService_AdvanceNamespaceTime_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdvanceNamespaceTimeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HistoryServiceServer).AdvanceNamespaceTime(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HistoryService_AdvanceNamespaceTime_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HistoryServiceServer).AdvanceNamespaceTime(ctx, req.(*AdvanceNamespaceTimeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HistoryService_UpdateNamespaceTime_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateNamespaceTimeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HistoryServiceServer).UpdateNamespaceTime(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HistoryService_UpdateNamespaceTime_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HistoryServiceServer).UpdateNamespaceTime(ctx, req.(*UpdateNamespaceTimeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HistoryService_DescribeNamespaceTime_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DescribeNamespaceTimeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HistoryServiceServer).DescribeNamespaceTime(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HistoryService_DescribeNamespaceTime_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HistoryServiceServer).DescribeNamespaceTime(ctx, req.(*DescribeNamespaceTimeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HistoryService_AddFaultInjectionRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddFaultInjectionRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HistoryServiceServer).AddFaultInjectionRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HistoryService_AddFaultInjectionRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HistoryServiceServer).AddFaultInjectionRule(ctx, req.(*AddFaultInjectionRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HistoryService_ClearFaultInjectionRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClearFaultInjectionRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HistoryServiceServer).ClearFaultInjectionRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HistoryService_ClearFaultInjectionRules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HistoryServiceServer).ClearFaultInjectionRules(ctx, req.(*ClearFaultInjectionRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // HistoryService_ServiceDesc is the grpc.ServiceDesc for HistoryService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -3167,6 +3385,10 @@ var HistoryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetShard",
 			Handler:    _HistoryService_GetShard_Handler,
 		},
+		{
+			MethodName: "AllocateTaskId",
+			Handler:    _HistoryService_AllocateTaskId_Handler,
+		},
 		{
 			MethodName: "RemoveTask",
 			Handler:    _HistoryService_RemoveTask_Handler,
@@ -3323,6 +3545,26 @@ var HistoryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CancelNexusOperation",
 			Handler:    _HistoryService_CancelNexusOperation_Handler,
 		},
+		{
+			MethodName: "AdvanceNamespaceTime",
+			Handler:    _HistoryService_AdvanceNamespaceTime_Handler,
+		},
+		{
+			MethodName: "UpdateNamespaceTime",
+			Handler:    _HistoryService_UpdateNamespaceTime_Handler,
+		},
+		{
+			MethodName: "DescribeNamespaceTime",
+			Handler:    _HistoryService_DescribeNamespaceTime_Handler,
+		},
+		{
+			MethodName: "AddFaultInjectionRule",
+			Handler:    _HistoryService_AddFaultInjectionRule_Handler,
+		},
+		{
+			MethodName: "ClearFaultInjectionRules",
+			Handler:    _HistoryService_ClearFaultInjectionRules_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
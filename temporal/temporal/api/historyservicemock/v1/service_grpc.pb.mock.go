@@ -43,6 +43,26 @@ func (m *MockHistoryServiceClient) EXPECT() *MockHistoryServiceClientMockRecorde
 	return m.recorder
 }
 
+// AddFaultInjectionRule mocks base method.
+func (m *MockHistoryServiceClient) AddFaultInjectionRule(ctx context.Context, in *historyservice.AddFaultInjectionRuleRequest, opts ...grpc.CallOption) (*historyservice.AddFaultInjectionRuleResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddFaultInjectionRule", varargs...)
+	ret0, _ := ret[0].(*historyservice.AddFaultInjectionRuleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddFaultInjectionRule indicates an expected call of AddFaultInjectionRule.
+func (mr *MockHistoryServiceClientMockRecorder) AddFaultInjectionRule(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFaultInjectionRule", reflect.TypeOf((*MockHistoryServiceClient)(nil).AddFaultInjectionRule), varargs...)
+}
+
 // AddTasks mocks base method.
 func (m *MockHistoryServiceClient) AddTasks(ctx context.Context, in *historyservice.AddTasksRequest, opts ...grpc.CallOption) (*historyservice.AddTasksResponse, error) {
 	m.ctrl.T.Helper()
@@ -63,6 +83,46 @@ func (mr *MockHistoryServiceClientMockRecorder) AddTasks(ctx, in any, opts ...an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTasks", reflect.TypeOf((*MockHistoryServiceClient)(nil).AddTasks), varargs...)
 }
 
+// AdvanceNamespaceTime mocks base method.
+func (m *MockHistoryServiceClient) AdvanceNamespaceTime(ctx context.Context, in *historyservice.AdvanceNamespaceTimeRequest, opts ...grpc.CallOption) (*historyservice.AdvanceNamespaceTimeResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AdvanceNamespaceTime", varargs...)
+	ret0, _ := ret[0].(*historyservice.AdvanceNamespaceTimeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdvanceNamespaceTime indicates an expected call of AdvanceNamespaceTime.
+func (mr *MockHistoryServiceClientMockRecorder) AdvanceNamespaceTime(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdvanceNamespaceTime", reflect.TypeOf((*MockHistoryServiceClient)(nil).AdvanceNamespaceTime), varargs...)
+}
+
+// AllocateTaskId mocks base method.
+func (m *MockHistoryServiceClient) AllocateTaskId(ctx context.Context, in *historyservice.AllocateTaskIdRequest, opts ...grpc.CallOption) (*historyservice.AllocateTaskIdResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AllocateTaskId", varargs...)
+	ret0, _ := ret[0].(*historyservice.AllocateTaskIdResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AllocateTaskId indicates an expected call of AllocateTaskId.
+func (mr *MockHistoryServiceClientMockRecorder) AllocateTaskId(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllocateTaskId", reflect.TypeOf((*MockHistoryServiceClient)(nil).AllocateTaskId), varargs...)
+}
+
 // CancelNexusOperation mocks base method.
 func (m *MockHistoryServiceClient) CancelNexusOperation(ctx context.Context, in *historyservice.CancelNexusOperationRequest, opts ...grpc.CallOption) (*historyservice.CancelNexusOperationResponse, error) {
 	m.ctrl.T.Helper()
@@ -83,6 +143,26 @@ func (mr *MockHistoryServiceClientMockRecorder) CancelNexusOperation(ctx, in any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelNexusOperation", reflect.TypeOf((*MockHistoryServiceClient)(nil).CancelNexusOperation), varargs...)
 }
 
+// ClearFaultInjectionRules mocks base method.
+func (m *MockHistoryServiceClient) ClearFaultInjectionRules(ctx context.Context, in *historyservice.ClearFaultInjectionRulesRequest, opts ...grpc.CallOption) (*historyservice.ClearFaultInjectionRulesResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ClearFaultInjectionRules", varargs...)
+	ret0, _ := ret[0].(*historyservice.ClearFaultInjectionRulesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClearFaultInjectionRules indicates an expected call of ClearFaultInjectionRules.
+func (mr *MockHistoryServiceClientMockRecorder) ClearFaultInjectionRules(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearFaultInjectionRules", reflect.TypeOf((*MockHistoryServiceClient)(nil).ClearFaultInjectionRules), varargs...)
+}
+
 // CloseShard mocks base method.
 func (m *MockHistoryServiceClient) CloseShard(ctx context.Context, in *historyservice.CloseShardRequest, opts ...grpc.CallOption) (*historyservice.CloseShardResponse, error) {
 	m.ctrl.T.Helper()
@@ -263,6 +343,26 @@ func (mr *MockHistoryServiceClientMockRecorder) DescribeMutableState(ctx, in any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeMutableState", reflect.TypeOf((*MockHistoryServiceClient)(nil).DescribeMutableState), varargs...)
 }
 
+// DescribeNamespaceTime mocks base method.
+func (m *MockHistoryServiceClient) DescribeNamespaceTime(ctx context.Context, in *historyservice.DescribeNamespaceTimeRequest, opts ...grpc.CallOption) (*historyservice.DescribeNamespaceTimeResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribeNamespaceTime", varargs...)
+	ret0, _ := ret[0].(*historyservice.DescribeNamespaceTimeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeNamespaceTime indicates an expected call of DescribeNamespaceTime.
+func (mr *MockHistoryServiceClientMockRecorder) DescribeNamespaceTime(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeNamespaceTime", reflect.TypeOf((*MockHistoryServiceClient)(nil).DescribeNamespaceTime), varargs...)
+}
+
 // DescribeWorkflowExecution mocks base method.
 func (m *MockHistoryServiceClient) DescribeWorkflowExecution(ctx context.Context, in *historyservice.DescribeWorkflowExecutionRequest, opts ...grpc.CallOption) (*historyservice.DescribeWorkflowExecutionResponse, error) {
 	m.ctrl.T.Helper()
@@ -1483,6 +1583,26 @@ func (mr *MockHistoryServiceClientMockRecorder) UpdateActivityOptions(ctx, in an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateActivityOptions", reflect.TypeOf((*MockHistoryServiceClient)(nil).UpdateActivityOptions), varargs...)
 }
 
+// UpdateNamespaceTime mocks base method.
+func (m *MockHistoryServiceClient) UpdateNamespaceTime(ctx context.Context, in *historyservice.UpdateNamespaceTimeRequest, opts ...grpc.CallOption) (*historyservice.UpdateNamespaceTimeResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateNamespaceTime", varargs...)
+	ret0, _ := ret[0].(*historyservice.UpdateNamespaceTimeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateNamespaceTime indicates an expected call of UpdateNamespaceTime.
+func (mr *MockHistoryServiceClientMockRecorder) UpdateNamespaceTime(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNamespaceTime", reflect.TypeOf((*MockHistoryServiceClient)(nil).UpdateNamespaceTime), varargs...)
+}
+
 // UpdateWorkflowExecution mocks base method.
 func (m *MockHistoryServiceClient) UpdateWorkflowExecution(ctx context.Context, in *historyservice.UpdateWorkflowExecutionRequest, opts ...grpc.CallOption) (*historyservice.UpdateWorkflowExecutionResponse, error) {
 	m.ctrl.T.Helper()
@@ -1725,6 +1845,21 @@ func (m *MockHistoryServiceServer) EXPECT() *MockHistoryServiceServerMockRecorde
 	return m.recorder
 }
 
+// AddFaultInjectionRule mocks base method.
+func (m *MockHistoryServiceServer) AddFaultInjectionRule(arg0 context.Context, arg1 *historyservice.AddFaultInjectionRuleRequest) (*historyservice.AddFaultInjectionRuleResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddFaultInjectionRule", arg0, arg1)
+	ret0, _ := ret[0].(*historyservice.AddFaultInjectionRuleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddFaultInjectionRule indicates an expected call of AddFaultInjectionRule.
+func (mr *MockHistoryServiceServerMockRecorder) AddFaultInjectionRule(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFaultInjectionRule", reflect.TypeOf((*MockHistoryServiceServer)(nil).AddFaultInjectionRule), arg0, arg1)
+}
+
 // AddTasks mocks base method.
 func (m *MockHistoryServiceServer) AddTasks(arg0 context.Context, arg1 *historyservice.AddTasksRequest) (*historyservice.AddTasksResponse, error) {
 	m.ctrl.T.Helper()
@@ -1740,6 +1875,36 @@ func (mr *MockHistoryServiceServerMockRecorder) AddTasks(arg0, arg1 any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTasks", reflect.TypeOf((*MockHistoryServiceServer)(nil).AddTasks), arg0, arg1)
 }
 
+// AdvanceNamespaceTime mocks base method.
+func (m *MockHistoryServiceServer) AdvanceNamespaceTime(arg0 context.Context, arg1 *historyservice.AdvanceNamespaceTimeRequest) (*historyservice.AdvanceNamespaceTimeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdvanceNamespaceTime", arg0, arg1)
+	ret0, _ := ret[0].(*historyservice.AdvanceNamespaceTimeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdvanceNamespaceTime indicates an expected call of AdvanceNamespaceTime.
+func (mr *MockHistoryServiceServerMockRecorder) AdvanceNamespaceTime(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdvanceNamespaceTime", reflect.TypeOf((*MockHistoryServiceServer)(nil).AdvanceNamespaceTime), arg0, arg1)
+}
+
+// AllocateTaskId mocks base method.
+func (m *MockHistoryServiceServer) AllocateTaskId(arg0 context.Context, arg1 *historyservice.AllocateTaskIdRequest) (*historyservice.AllocateTaskIdResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllocateTaskId", arg0, arg1)
+	ret0, _ := ret[0].(*historyservice.AllocateTaskIdResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AllocateTaskId indicates an expected call of AllocateTaskId.
+func (mr *MockHistoryServiceServerMockRecorder) AllocateTaskId(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllocateTaskId", reflect.TypeOf((*MockHistoryServiceServer)(nil).AllocateTaskId), arg0, arg1)
+}
+
 // CancelNexusOperation mocks base method.
 func (m *MockHistoryServiceServer) CancelNexusOperation(arg0 context.Context, arg1 *historyservice.CancelNexusOperationRequest) (*historyservice.CancelNexusOperationResponse, error) {
 	m.ctrl.T.Helper()
@@ -1755,6 +1920,21 @@ func (mr *MockHistoryServiceServerMockRecorder) CancelNexusOperation(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelNexusOperation", reflect.TypeOf((*MockHistoryServiceServer)(nil).CancelNexusOperation), arg0, arg1)
 }
 
+// ClearFaultInjectionRules mocks base method.
+func (m *MockHistoryServiceServer) ClearFaultInjectionRules(arg0 context.Context, arg1 *historyservice.ClearFaultInjectionRulesRequest) (*historyservice.ClearFaultInjectionRulesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearFaultInjectionRules", arg0, arg1)
+	ret0, _ := ret[0].(*historyservice.ClearFaultInjectionRulesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClearFaultInjectionRules indicates an expected call of ClearFaultInjectionRules.
+func (mr *MockHistoryServiceServerMockRecorder) ClearFaultInjectionRules(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearFaultInjectionRules", reflect.TypeOf((*MockHistoryServiceServer)(nil).ClearFaultInjectionRules), arg0, arg1)
+}
+
 // CloseShard mocks base method.
 func (m *MockHistoryServiceServer) CloseShard(arg0 context.Context, arg1 *historyservice.CloseShardRequest) (*historyservice.CloseShardResponse, error) {
 	m.ctrl.T.Helper()
@@ -1890,6 +2070,21 @@ func (mr *MockHistoryServiceServerMockRecorder) DescribeMutableState(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeMutableState", reflect.TypeOf((*MockHistoryServiceServer)(nil).DescribeMutableState), arg0, arg1)
 }
 
+// DescribeNamespaceTime mocks base method.
+func (m *MockHistoryServiceServer) DescribeNamespaceTime(arg0 context.Context, arg1 *historyservice.DescribeNamespaceTimeRequest) (*historyservice.DescribeNamespaceTimeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeNamespaceTime", arg0, arg1)
+	ret0, _ := ret[0].(*historyservice.DescribeNamespaceTimeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeNamespaceTime indicates an expected call of DescribeNamespaceTime.
+func (mr *MockHistoryServiceServerMockRecorder) DescribeNamespaceTime(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeNamespaceTime", reflect.TypeOf((*MockHistoryServiceServer)(nil).DescribeNamespaceTime), arg0, arg1)
+}
+
 // DescribeWorkflowExecution mocks base method.
 func (m *MockHistoryServiceServer) DescribeWorkflowExecution(arg0 context.Context, arg1 *historyservice.DescribeWorkflowExecutionRequest) (*historyservice.DescribeWorkflowExecutionResponse, error) {
 	m.ctrl.T.Helper()
@@ -2804,6 +2999,21 @@ func (mr *MockHistoryServiceServerMockRecorder) UpdateActivityOptions(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateActivityOptions", reflect.TypeOf((*MockHistoryServiceServer)(nil).UpdateActivityOptions), arg0, arg1)
 }
 
+// UpdateNamespaceTime mocks base method.
+func (m *MockHistoryServiceServer) UpdateNamespaceTime(arg0 context.Context, arg1 *historyservice.UpdateNamespaceTimeRequest) (*historyservice.UpdateNamespaceTimeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNamespaceTime", arg0, arg1)
+	ret0, _ := ret[0].(*historyservice.UpdateNamespaceTimeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateNamespaceTime indicates an expected call of UpdateNamespaceTime.
+func (mr *MockHistoryServiceServerMockRecorder) UpdateNamespaceTime(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNamespaceTime", reflect.TypeOf((*MockHistoryServiceServer)(nil).UpdateNamespaceTime), arg0, arg1)
+}
+
 // UpdateWorkflowExecution mocks base method.
 func (m *MockHistoryServiceServer) UpdateWorkflowExecution(arg0 context.Context, arg1 *historyservice.UpdateWorkflowExecutionRequest) (*historyservice.UpdateWorkflowExecutionResponse, error) {
 	m.ctrl.T.Helper()
@@ -3038,3 +3038,151 @@ func (this *PollConditions) Equal(that interface{}) bool {
 
 	return proto.Equal(this, that1)
 }
+
+// Marshal an object of type AddFaultInjectionRuleRequest to the protobuf v3 wire format
+func (val *AddFaultInjectionRuleRequest) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type AddFaultInjectionRuleRequest from the protobuf v3 wire format
+func (val *AddFaultInjectionRuleRequest) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *AddFaultInjectionRuleRequest) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two AddFaultInjectionRuleRequest values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *AddFaultInjectionRuleRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *AddFaultInjectionRuleRequest
+	switch t := that.(type) {
+	case *AddFaultInjectionRuleRequest:
+		that1 = t
+	case AddFaultInjectionRuleRequest:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
+
+// Marshal an object of type AddFaultInjectionRuleResponse to the protobuf v3 wire format
+func (val *AddFaultInjectionRuleResponse) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type AddFaultInjectionRuleResponse from the protobuf v3 wire format
+func (val *AddFaultInjectionRuleResponse) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *AddFaultInjectionRuleResponse) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two AddFaultInjectionRuleResponse values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *AddFaultInjectionRuleResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *AddFaultInjectionRuleResponse
+	switch t := that.(type) {
+	case *AddFaultInjectionRuleResponse:
+		that1 = t
+	case AddFaultInjectionRuleResponse:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
+
+// Marshal an object of type ClearFaultInjectionRulesRequest to the protobuf v3 wire format
+func (val *ClearFaultInjectionRulesRequest) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type ClearFaultInjectionRulesRequest from the protobuf v3 wire format
+func (val *ClearFaultInjectionRulesRequest) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *ClearFaultInjectionRulesRequest) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two ClearFaultInjectionRulesRequest values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *ClearFaultInjectionRulesRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *ClearFaultInjectionRulesRequest
+	switch t := that.(type) {
+	case *ClearFaultInjectionRulesRequest:
+		that1 = t
+	case ClearFaultInjectionRulesRequest:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
+
+// Marshal an object of type ClearFaultInjectionRulesResponse to the protobuf v3 wire format
+func (val *ClearFaultInjectionRulesResponse) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type ClearFaultInjectionRulesResponse from the protobuf v3 wire format
+func (val *ClearFaultInjectionRulesResponse) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *ClearFaultInjectionRulesResponse) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two ClearFaultInjectionRulesResponse values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *ClearFaultInjectionRulesResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *ClearFaultInjectionRulesResponse
+	switch t := that.(type) {
+	case *ClearFaultInjectionRulesResponse:
+		that1 = t
+	case ClearFaultInjectionRulesResponse:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
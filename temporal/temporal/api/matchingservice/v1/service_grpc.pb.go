@@ -60,6 +60,8 @@ const (
 	MatchingService_DescribeWorker_FullMethodName                         = "/temporal.server.api.matchingservice.v1.MatchingService/DescribeWorker"
 	MatchingService_UpdateFairnessState_FullMethodName                    = "/temporal.server.api.matchingservice.v1.MatchingService/UpdateFairnessState"
 	MatchingService_CheckTaskQueueVersionMembership_FullMethodName        = "/temporal.server.api.matchingservice.v1.MatchingService/CheckTaskQueueVersionMembership"
+	MatchingService_AddFaultInjectionRule_FullMethodName                  = "/temporal.server.api.matchingservice.v1.MatchingService/AddFaultInjectionRule"
+	MatchingService_ClearFaultInjectionRules_FullMethodName               = "/temporal.server.api.matchingservice.v1.MatchingService/ClearFaultInjectionRules"
 )
 
 // MatchingServiceClient is the client API for MatchingService service.
@@ -246,6 +248,10 @@ type MatchingServiceClient interface {
 	UpdateFairnessState(ctx context.Context, in *UpdateFairnessStateRequest, opts ...grpc.CallOption) (*UpdateFairnessStateResponse, error)
 	// CheckTaskQueueVersionMembership checks if a task queue is part of a specific deployment version.
 	CheckTaskQueueVersionMembership(ctx context.Context, in *CheckTaskQueueVersionMembershipRequest, opts ...grpc.CallOption) (*CheckTaskQueueVersionMembershipResponse, error)
+	// AddFaultInjectionRule adds a persistence fault injection rule to the process of the matching host.
+	AddFaultInjectionRule(ctx context.Context, in *AddFaultInjectionRuleRequest, opts ...grpc.CallOption) (*AddFaultInjectionRuleResponse, error)
+	// ClearFaultInjectionRules removes the runtime persistence fault injection rules of the process of the matching host.
+	ClearFaultInjectionRules(ctx context.Context, in *ClearFaultInjectionRulesRequest, opts ...grpc.CallOption) (*ClearFaultInjectionRulesResponse, error)
 }
 
 type matchingServiceClient struct {
@@ -616,6 +622,24 @@ func (c *matchingServiceClient) CheckTaskQueueVersionMembership(ctx context.Cont
 	return out, nil
 }
 
+func (c *matchingServiceClient) AddFaultInjectionRule(ctx context.Context, in *AddFaultInjectionRuleRequest, opts ...grpc.CallOption) (*AddFaultInjectionRuleResponse, error) {
+	out := new(AddFaultInjectionRuleResponse)
+	err := c.cc.Invoke(ctx, MatchingService_AddFaultInjectionRule_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchingServiceClient) ClearFaultInjectionRules(ctx context.Context, in *ClearFaultInjectionRulesRequest, opts ...grpc.CallOption) (*ClearFaultInjectionRulesResponse, error) {
+	out := new(ClearFaultInjectionRulesResponse)
+	err := c.cc.Invoke(ctx, MatchingService_ClearFaultInjectionRules_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MatchingServiceServer is the server API for MatchingService service.
 // All implementations must embed UnimplementedMatchingServiceServer
 // for forward compatibility
@@ -800,6 +824,10 @@ type MatchingServiceServer interface {
 	UpdateFairnessState(context.Context, *UpdateFairnessStateRequest) (*UpdateFairnessStateResponse, error)
 	// CheckTaskQueueVersionMembership checks if a task queue is part of a specific deployment version.
 	CheckTaskQueueVersionMembership(context.Context, *CheckTaskQueueVersionMembershipRequest) (*CheckTaskQueueVersionMembershipResponse, error)
+	// AddFaultInjectionRule adds a persistence fault injection rule to the process of the matching host.
+	AddFaultInjectionRule(context.Context, *AddFaultInjectionRuleRequest) (*AddFaultInjectionRuleResponse, error)
+	// ClearFaultInjectionRules removes the runtime persistence fault injection rules of the process of the matching host.
+	ClearFaultInjectionRules(context.Context, *ClearFaultInjectionRulesRequest) (*ClearFaultInjectionRulesResponse, error)
 	mustEmbedUnimplementedMatchingServiceServer()
 }
 
@@ -927,6 +955,12 @@ func (UnimplementedMatchingServiceServer) UpdateFairnessState(context.Context, *
 func (UnimplementedMatchingServiceServer) CheckTaskQueueVersionMembership(context.Context, *CheckTaskQueueVersionMembershipRequest) (*CheckTaskQueueVersionMembershipResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CheckTaskQueueVersionMembership not implemented")
 }
+func (UnimplementedMatchingServiceServer) AddFaultInjectionRule(context.Context, *AddFaultInjectionRuleRequest) (*AddFaultInjectionRuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddFaultInjectionRule not implemented")
+}
+func (UnimplementedMatchingServiceServer) ClearFaultInjectionRules(context.Context, *ClearFaultInjectionRulesRequest) (*ClearFaultInjectionRulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearFaultInjectionRules not implemented")
+}
 func (UnimplementedMatchingServiceServer) mustEmbedUnimplementedMatchingServiceServer() {}
 
 // UnsafeMatchingServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -1660,6 +1694,42 @@ func _MatchingService_CheckTaskQueueVersionMembership_Handler(srv interface{}, c
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MatchingService_AddFaultInjectionRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddFaultInjectionRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchingServiceServer).AddFaultInjectionRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchingService_AddFaultInjectionRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchingServiceServer).AddFaultInjectionRule(ctx, req.(*AddFaultInjectionRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchingService_ClearFaultInjectionRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClearFaultInjectionRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchingServiceServer).ClearFaultInjectionRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchingService_ClearFaultInjectionRules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchingServiceServer).ClearFaultInjectionRules(ctx, req.(*ClearFaultInjectionRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // MatchingService_ServiceDesc is the grpc.ServiceDesc for MatchingService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -1827,6 +1897,14 @@ var MatchingService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CheckTaskQueueVersionMembership",
 			Handler:    _MatchingService_CheckTaskQueueVersionMembership_Handler,
 		},
+		{
+			MethodName: "AddFaultInjectionRule",
+			Handler:    _MatchingService_AddFaultInjectionRule_Handler,
+		},
+		{
+			MethodName: "ClearFaultInjectionRules",
+			Handler:    _MatchingService_ClearFaultInjectionRules_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "temporal/server/api/matchingservice/v1/service.proto",
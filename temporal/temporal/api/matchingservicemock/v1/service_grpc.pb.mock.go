@@ -62,6 +62,26 @@ func (mr *MockMatchingServiceClientMockRecorder) AddActivityTask(ctx, in any, op
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddActivityTask", reflect.TypeOf((*MockMatchingServiceClient)(nil).AddActivityTask), varargs...)
 }
 
+// AddFaultInjectionRule mocks base method.
+func (m *MockMatchingServiceClient) AddFaultInjectionRule(ctx context.Context, in *matchingservice.AddFaultInjectionRuleRequest, opts ...grpc.CallOption) (*matchingservice.AddFaultInjectionRuleResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddFaultInjectionRule", varargs...)
+	ret0, _ := ret[0].(*matchingservice.AddFaultInjectionRuleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddFaultInjectionRule indicates an expected call of AddFaultInjectionRule.
+func (mr *MockMatchingServiceClientMockRecorder) AddFaultInjectionRule(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFaultInjectionRule", reflect.TypeOf((*MockMatchingServiceClient)(nil).AddFaultInjectionRule), varargs...)
+}
+
 // AddWorkflowTask mocks base method.
 func (m *MockMatchingServiceClient) AddWorkflowTask(ctx context.Context, in *matchingservice.AddWorkflowTaskRequest, opts ...grpc.CallOption) (*matchingservice.AddWorkflowTaskResponse, error) {
 	m.ctrl.T.Helper()
@@ -182,6 +202,26 @@ func (mr *MockMatchingServiceClientMockRecorder) CheckTaskQueueVersionMembership
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckTaskQueueVersionMembership", reflect.TypeOf((*MockMatchingServiceClient)(nil).CheckTaskQueueVersionMembership), varargs...)
 }
 
+// ClearFaultInjectionRules mocks base method.
+func (m *MockMatchingServiceClient) ClearFaultInjectionRules(ctx context.Context, in *matchingservice.ClearFaultInjectionRulesRequest, opts ...grpc.CallOption) (*matchingservice.ClearFaultInjectionRulesResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ClearFaultInjectionRules", varargs...)
+	ret0, _ := ret[0].(*matchingservice.ClearFaultInjectionRulesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClearFaultInjectionRules indicates an expected call of ClearFaultInjectionRules.
+func (mr *MockMatchingServiceClientMockRecorder) ClearFaultInjectionRules(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearFaultInjectionRules", reflect.TypeOf((*MockMatchingServiceClient)(nil).ClearFaultInjectionRules), varargs...)
+}
+
 // CreateNexusEndpoint mocks base method.
 func (m *MockMatchingServiceClient) CreateNexusEndpoint(ctx context.Context, in *matchingservice.CreateNexusEndpointRequest, opts ...grpc.CallOption) (*matchingservice.CreateNexusEndpointResponse, error) {
 	m.ctrl.T.Helper()
@@ -881,6 +921,21 @@ func (mr *MockMatchingServiceServerMockRecorder) AddActivityTask(arg0, arg1 any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddActivityTask", reflect.TypeOf((*MockMatchingServiceServer)(nil).AddActivityTask), arg0, arg1)
 }
 
+// AddFaultInjectionRule mocks base method.
+func (m *MockMatchingServiceServer) AddFaultInjectionRule(arg0 context.Context, arg1 *matchingservice.AddFaultInjectionRuleRequest) (*matchingservice.AddFaultInjectionRuleResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddFaultInjectionRule", arg0, arg1)
+	ret0, _ := ret[0].(*matchingservice.AddFaultInjectionRuleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddFaultInjectionRule indicates an expected call of AddFaultInjectionRule.
+func (mr *MockMatchingServiceServerMockRecorder) AddFaultInjectionRule(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFaultInjectionRule", reflect.TypeOf((*MockMatchingServiceServer)(nil).AddFaultInjectionRule), arg0, arg1)
+}
+
 // AddWorkflowTask mocks base method.
 func (m *MockMatchingServiceServer) AddWorkflowTask(arg0 context.Context, arg1 *matchingservice.AddWorkflowTaskRequest) (*matchingservice.AddWorkflowTaskResponse, error) {
 	m.ctrl.T.Helper()
@@ -971,6 +1026,21 @@ func (mr *MockMatchingServiceServerMockRecorder) CheckTaskQueueVersionMembership
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckTaskQueueVersionMembership", reflect.TypeOf((*MockMatchingServiceServer)(nil).CheckTaskQueueVersionMembership), arg0, arg1)
 }
 
+// ClearFaultInjectionRules mocks base method.
+func (m *MockMatchingServiceServer) ClearFaultInjectionRules(arg0 context.Context, arg1 *matchingservice.ClearFaultInjectionRulesRequest) (*matchingservice.ClearFaultInjectionRulesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearFaultInjectionRules", arg0, arg1)
+	ret0, _ := ret[0].(*matchingservice.ClearFaultInjectionRulesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClearFaultInjectionRules indicates an expected call of ClearFaultInjectionRules.
+func (mr *MockMatchingServiceServerMockRecorder) ClearFaultInjectionRules(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearFaultInjectionRules", reflect.TypeOf((*MockMatchingServiceServer)(nil).ClearFaultInjectionRules), arg0, arg1)
+}
+
 // CreateNexusEndpoint mocks base method.
 func (m *MockMatchingServiceServer) CreateNexusEndpoint(arg0 context.Context, arg1 *matchingservice.CreateNexusEndpointRequest) (*matchingservice.CreateNexusEndpointResponse, error) {
 	m.ctrl.T.Helper()
@@ -38,6 +38,7 @@ func (l *componentOnlyLibrary) Components() []*chasm.RegistrableComponent {
 				chasm.SearchAttributeTaskQueue,
 			),
 			chasm.WithBusinessIDAlias("ActivityId"),
+			chasm.WithArchival(),
 		),
 	}
 }
@@ -27,7 +27,7 @@ func (l *Library) Name() string {
 
 func (l *Library) Components() []*chasm.RegistrableComponent {
 	return []*chasm.RegistrableComponent{
-		chasm.NewRegistrableComponent[*Operation]("operation"),
+		chasm.NewRegistrableComponent[*Operation]("operation", chasm.WithArchival()),
 		chasm.NewRegistrableComponent[*Operation]("cancellation"),
 	}
 }
@@ -48,6 +48,7 @@ func (l *Library) Components() []*chasm.RegistrableComponent {
 			chasm.SchedulerComponentName,
 			chasm.WithBusinessIDAlias("ScheduleId"),
 			chasm.WithSearchAttributes(executionStatusSearchAttribute),
+			chasm.WithArchival(),
 		),
 		chasm.NewRegistrableComponent[*Generator]("generator"),
 		chasm.NewRegistrableComponent[*Invoker]("invoker"),
@@ -22,6 +22,7 @@ type (
 		ephemeral     bool
 		singleCluster bool
 		detached      bool
+		archival      bool
 
 		searchAttributesMapper *VisibilitySearchAttributesMapper
 
@@ -74,6 +75,20 @@ func (rc *RegistrableComponent) IsDetached() bool {
 	return rc.detached
 }
 
+// WithArchival marks the registrable component as archivable. When the component is the root of an execution, the
+// final component tree and the visibility record of the execution are archived into the history and visibility
+// archivers of its namespace when the execution is closed, before it is deleted by retention.
+func WithArchival() RegistrableComponentOption {
+	return func(rc *RegistrableComponent) {
+		rc.archival = true
+	}
+}
+
+// IsArchivable returns true if the component type is registered with archival.
+func (rc *RegistrableComponent) IsArchivable() bool {
+	return rc.archival
+}
+
 // WithBusinessIDAlias allows specifying the business ID alias of the component.
 // This option must be specified if the archetype uses the Visibility component.
 func WithBusinessIDAlias(
@@ -107,6 +107,29 @@ func (s *RegistryTestSuite) TestRegistry_RegisterComponents_WithDetached() {
 	s.Require().False(normalRC.IsDetached())
 }
 
+func (s *RegistryTestSuite) TestRegistry_RegisterComponents_WithArchival() {
+	r := chasm.NewRegistry(s.logger)
+	ctrl := gomock.NewController(s.T())
+	lib := chasm.NewMockLibrary(ctrl)
+	lib.EXPECT().Name().Return("TestLibrary").AnyTimes()
+	lib.EXPECT().Components().Return([]*chasm.RegistrableComponent{
+		chasm.NewRegistrableComponent[*chasm.MockComponent]("ArchivableComponent", chasm.WithArchival()),
+	})
+	lib.EXPECT().Tasks().Return(nil)
+	lib.EXPECT().NexusServices().Return(nil)
+	lib.EXPECT().NexusServiceProcessors().Return(nil)
+
+	err := r.Register(lib)
+	s.Require().NoError(err)
+
+	archivableRC, ok := r.Component("TestLibrary.ArchivableComponent")
+	s.Require().True(ok)
+	s.Require().True(archivableRC.IsArchivable())
+
+	normalRC := chasm.NewRegistrableComponent[*chasm.MockComponent]("NormalComponent")
+	s.Require().False(normalRC.IsArchivable())
+}
+
 func (s *RegistryTestSuite) TestRegistry_RegisterTasks_Success() {
 	r := chasm.NewRegistry(s.logger)
 	ctrl := gomock.NewController(s.T())
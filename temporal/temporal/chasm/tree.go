@@ -2571,6 +2571,12 @@ func (n *Node) Archetype() (Archetype, error) {
 	return Archetype(fqn), nil
 }
 
+// IsArchivable returns true if the root component of the tree is registered with archival.
+func (n *Node) IsArchivable() bool {
+	rc, ok := n.registry.ComponentByID(n.ArchetypeID())
+	return ok && rc.IsArchivable()
+}
+
 func (n *Node) root() *Node {
 	if n.parent == nil {
 		return n
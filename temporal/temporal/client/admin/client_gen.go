@@ -9,6 +9,16 @@ import (
 	"google.golang.org/grpc"
 )
 
+func (c *clientImpl) AddFaultInjectionRule(
+	ctx context.Context,
+	request *adminservice.AddFaultInjectionRuleRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.AddFaultInjectionRuleResponse, error) {
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return c.client.AddFaultInjectionRule(ctx, request, opts...)
+}
+
 func (c *clientImpl) AddOrUpdateRemoteCluster(
 	ctx context.Context,
 	request *adminservice.AddOrUpdateRemoteClusterRequest,
@@ -39,6 +49,26 @@ func (c *clientImpl) AddTasks(
 	return c.client.AddTasks(ctx, request, opts...)
 }
 
+func (c *clientImpl) AdvanceNamespaceTime(
+	ctx context.Context,
+	request *adminservice.AdvanceNamespaceTimeRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.AdvanceNamespaceTimeResponse, error) {
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return c.client.AdvanceNamespaceTime(ctx, request, opts...)
+}
+
+func (c *clientImpl) AggregateWorkflowExecutions(
+	ctx context.Context,
+	request *adminservice.AggregateWorkflowExecutionsRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.AggregateWorkflowExecutionsResponse, error) {
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return c.client.AggregateWorkflowExecutions(ctx, request, opts...)
+}
+
 func (c *clientImpl) CancelDLQJob(
 	ctx context.Context,
 	request *adminservice.CancelDLQJobRequest,
@@ -49,6 +79,16 @@ func (c *clientImpl) CancelDLQJob(
 	return c.client.CancelDLQJob(ctx, request, opts...)
 }
 
+func (c *clientImpl) ClearFaultInjectionRules(
+	ctx context.Context,
+	request *adminservice.ClearFaultInjectionRulesRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ClearFaultInjectionRulesResponse, error) {
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return c.client.ClearFaultInjectionRules(ctx, request, opts...)
+}
+
 func (c *clientImpl) CloseShard(
 	ctx context.Context,
 	request *adminservice.CloseShardRequest,
@@ -129,6 +169,16 @@ func (c *clientImpl) DescribeTaskQueuePartition(
 	return c.client.DescribeTaskQueuePartition(ctx, request, opts...)
 }
 
+func (c *clientImpl) ExplainVisibilityQuery(
+	ctx context.Context,
+	request *adminservice.ExplainVisibilityQueryRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ExplainVisibilityQueryResponse, error) {
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return c.client.ExplainVisibilityQuery(ctx, request, opts...)
+}
+
 func (c *clientImpl) ForceUnloadTaskQueuePartition(
 	ctx context.Context,
 	request *adminservice.ForceUnloadTaskQueuePartitionRequest,
@@ -149,6 +199,16 @@ func (c *clientImpl) GenerateLastHistoryReplicationTasks(
 	return c.client.GenerateLastHistoryReplicationTasks(ctx, request, opts...)
 }
 
+func (c *clientImpl) GetArchivedChasmExecution(
+	ctx context.Context,
+	request *adminservice.GetArchivedChasmExecutionRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.GetArchivedChasmExecutionResponse, error) {
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return c.client.GetArchivedChasmExecution(ctx, request, opts...)
+}
+
 func (c *clientImpl) GetDLQMessages(
 	ctx context.Context,
 	request *adminservice.GetDLQMessagesRequest,
@@ -289,6 +349,16 @@ func (c *clientImpl) ListClusters(
 	return c.client.ListClusters(ctx, request, opts...)
 }
 
+func (c *clientImpl) ListFaultInjectionRules(
+	ctx context.Context,
+	request *adminservice.ListFaultInjectionRulesRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ListFaultInjectionRulesResponse, error) {
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return c.client.ListFaultInjectionRules(ctx, request, opts...)
+}
+
 func (c *clientImpl) ListHistoryTasks(
 	ctx context.Context,
 	request *adminservice.ListHistoryTasksRequest,
@@ -389,6 +459,16 @@ func (c *clientImpl) RefreshWorkflowTasks(
 	return c.client.RefreshWorkflowTasks(ctx, request, opts...)
 }
 
+func (c *clientImpl) RehydrateWorkflowExecution(
+	ctx context.Context,
+	request *adminservice.RehydrateWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.RehydrateWorkflowExecutionResponse, error) {
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return c.client.RehydrateWorkflowExecution(ctx, request, opts...)
+}
+
 func (c *clientImpl) RemoveRemoteCluster(
 	ctx context.Context,
 	request *adminservice.RemoveRemoteClusterRequest,
@@ -448,3 +528,13 @@ func (c *clientImpl) SyncWorkflowState(
 	defer cancel()
 	return c.client.SyncWorkflowState(ctx, request, opts...)
 }
+
+func (c *clientImpl) VerifyArchivedHistory(
+	ctx context.Context,
+	request *adminservice.VerifyArchivedHistoryRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.VerifyArchivedHistoryResponse, error) {
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return c.client.VerifyArchivedHistory(ctx, request, opts...)
+}
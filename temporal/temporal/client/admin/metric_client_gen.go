@@ -9,6 +9,20 @@ import (
 	"google.golang.org/grpc"
 )
 
+func (c *metricClient) AddFaultInjectionRule(
+	ctx context.Context,
+	request *adminservice.AddFaultInjectionRuleRequest,
+	opts ...grpc.CallOption,
+) (_ *adminservice.AddFaultInjectionRuleResponse, retError error) {
+
+	metricsHandler, startTime := c.startMetricsRecording(ctx, "AdminClientAddFaultInjectionRule")
+	defer func() {
+		c.finishMetricsRecording(metricsHandler, startTime, retError)
+	}()
+
+	return c.client.AddFaultInjectionRule(ctx, request, opts...)
+}
+
 func (c *metricClient) AddOrUpdateRemoteCluster(
 	ctx context.Context,
 	request *adminservice.AddOrUpdateRemoteClusterRequest,
@@ -51,6 +65,34 @@ func (c *metricClient) AddTasks(
 	return c.client.AddTasks(ctx, request, opts...)
 }
 
+func (c *metricClient) AdvanceNamespaceTime(
+	ctx context.Context,
+	request *adminservice.AdvanceNamespaceTimeRequest,
+	opts ...grpc.CallOption,
+) (_ *adminservice.AdvanceNamespaceTimeResponse, retError error) {
+
+	metricsHandler, startTime := c.startMetricsRecording(ctx, "AdminClientAdvanceNamespaceTime")
+	defer func() {
+		c.finishMetricsRecording(metricsHandler, startTime, retError)
+	}()
+
+	return c.client.AdvanceNamespaceTime(ctx, request, opts...)
+}
+
+func (c *metricClient) AggregateWorkflowExecutions(
+	ctx context.Context,
+	request *adminservice.AggregateWorkflowExecutionsRequest,
+	opts ...grpc.CallOption,
+) (_ *adminservice.AggregateWorkflowExecutionsResponse, retError error) {
+
+	metricsHandler, startTime := c.startMetricsRecording(ctx, "AdminClientAggregateWorkflowExecutions")
+	defer func() {
+		c.finishMetricsRecording(metricsHandler, startTime, retError)
+	}()
+
+	return c.client.AggregateWorkflowExecutions(ctx, request, opts...)
+}
+
 func (c *metricClient) CancelDLQJob(
 	ctx context.Context,
 	request *adminservice.CancelDLQJobRequest,
@@ -65,6 +107,20 @@ func (c *metricClient) CancelDLQJob(
 	return c.client.CancelDLQJob(ctx, request, opts...)
 }
 
+func (c *metricClient) ClearFaultInjectionRules(
+	ctx context.Context,
+	request *adminservice.ClearFaultInjectionRulesRequest,
+	opts ...grpc.CallOption,
+) (_ *adminservice.ClearFaultInjectionRulesResponse, retError error) {
+
+	metricsHandler, startTime := c.startMetricsRecording(ctx, "AdminClientClearFaultInjectionRules")
+	defer func() {
+		c.finishMetricsRecording(metricsHandler, startTime, retError)
+	}()
+
+	return c.client.ClearFaultInjectionRules(ctx, request, opts...)
+}
+
 func (c *metricClient) CloseShard(
 	ctx context.Context,
 	request *adminservice.CloseShardRequest,
@@ -177,6 +233,20 @@ func (c *metricClient) DescribeTaskQueuePartition(
 	return c.client.DescribeTaskQueuePartition(ctx, request, opts...)
 }
 
+func (c *metricClient) ExplainVisibilityQuery(
+	ctx context.Context,
+	request *adminservice.ExplainVisibilityQueryRequest,
+	opts ...grpc.CallOption,
+) (_ *adminservice.ExplainVisibilityQueryResponse, retError error) {
+
+	metricsHandler, startTime := c.startMetricsRecording(ctx, "AdminClientExplainVisibilityQuery")
+	defer func() {
+		c.finishMetricsRecording(metricsHandler, startTime, retError)
+	}()
+
+	return c.client.ExplainVisibilityQuery(ctx, request, opts...)
+}
+
 func (c *metricClient) ForceUnloadTaskQueuePartition(
 	ctx context.Context,
 	request *adminservice.ForceUnloadTaskQueuePartitionRequest,
@@ -205,6 +275,20 @@ func (c *metricClient) GenerateLastHistoryReplicationTasks(
 	return c.client.GenerateLastHistoryReplicationTasks(ctx, request, opts...)
 }
 
+func (c *metricClient) GetArchivedChasmExecution(
+	ctx context.Context,
+	request *adminservice.GetArchivedChasmExecutionRequest,
+	opts ...grpc.CallOption,
+) (_ *adminservice.GetArchivedChasmExecutionResponse, retError error) {
+
+	metricsHandler, startTime := c.startMetricsRecording(ctx, "AdminClientGetArchivedChasmExecution")
+	defer func() {
+		c.finishMetricsRecording(metricsHandler, startTime, retError)
+	}()
+
+	return c.client.GetArchivedChasmExecution(ctx, request, opts...)
+}
+
 func (c *metricClient) GetDLQMessages(
 	ctx context.Context,
 	request *adminservice.GetDLQMessagesRequest,
@@ -401,6 +485,20 @@ func (c *metricClient) ListClusters(
 	return c.client.ListClusters(ctx, request, opts...)
 }
 
+func (c *metricClient) ListFaultInjectionRules(
+	ctx context.Context,
+	request *adminservice.ListFaultInjectionRulesRequest,
+	opts ...grpc.CallOption,
+) (_ *adminservice.ListFaultInjectionRulesResponse, retError error) {
+
+	metricsHandler, startTime := c.startMetricsRecording(ctx, "AdminClientListFaultInjectionRules")
+	defer func() {
+		c.finishMetricsRecording(metricsHandler, startTime, retError)
+	}()
+
+	return c.client.ListFaultInjectionRules(ctx, request, opts...)
+}
+
 func (c *metricClient) ListHistoryTasks(
 	ctx context.Context,
 	request *adminservice.ListHistoryTasksRequest,
@@ -541,6 +639,20 @@ func (c *metricClient) RefreshWorkflowTasks(
 	return c.client.RefreshWorkflowTasks(ctx, request, opts...)
 }
 
+func (c *metricClient) RehydrateWorkflowExecution(
+	ctx context.Context,
+	request *adminservice.RehydrateWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (_ *adminservice.RehydrateWorkflowExecutionResponse, retError error) {
+
+	metricsHandler, startTime := c.startMetricsRecording(ctx, "AdminClientRehydrateWorkflowExecution")
+	defer func() {
+		c.finishMetricsRecording(metricsHandler, startTime, retError)
+	}()
+
+	return c.client.RehydrateWorkflowExecution(ctx, request, opts...)
+}
+
 func (c *metricClient) RemoveRemoteCluster(
 	ctx context.Context,
 	request *adminservice.RemoveRemoteClusterRequest,
@@ -624,3 +736,17 @@ func (c *metricClient) SyncWorkflowState(
 
 	return c.client.SyncWorkflowState(ctx, request, opts...)
 }
+
+func (c *metricClient) VerifyArchivedHistory(
+	ctx context.Context,
+	request *adminservice.VerifyArchivedHistoryRequest,
+	opts ...grpc.CallOption,
+) (_ *adminservice.VerifyArchivedHistoryResponse, retError error) {
+
+	metricsHandler, startTime := c.startMetricsRecording(ctx, "AdminClientVerifyArchivedHistory")
+	defer func() {
+		c.finishMetricsRecording(metricsHandler, startTime, retError)
+	}()
+
+	return c.client.VerifyArchivedHistory(ctx, request, opts...)
+}
@@ -11,6 +11,21 @@ import (
 	"go.temporal.io/server/common/backoff"
 )
 
+func (c *retryableClient) AddFaultInjectionRule(
+	ctx context.Context,
+	request *adminservice.AddFaultInjectionRuleRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.AddFaultInjectionRuleResponse, error) {
+	var resp *adminservice.AddFaultInjectionRuleResponse
+	op := func(ctx context.Context) error {
+		var err error
+		resp, err = c.client.AddFaultInjectionRule(ctx, request, opts...)
+		return err
+	}
+	err := backoff.ThrottleRetryContext(ctx, op, c.policy, c.isRetryable)
+	return resp, err
+}
+
 func (c *retryableClient) AddOrUpdateRemoteCluster(
 	ctx context.Context,
 	request *adminservice.AddOrUpdateRemoteClusterRequest,
@@ -56,6 +71,36 @@ func (c *retryableClient) AddTasks(
 	return resp, err
 }
 
+func (c *retryableClient) AdvanceNamespaceTime(
+	ctx context.Context,
+	request *adminservice.AdvanceNamespaceTimeRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.AdvanceNamespaceTimeResponse, error) {
+	var resp *adminservice.AdvanceNamespaceTimeResponse
+	op := func(ctx context.Context) error {
+		var err error
+		resp, err = c.client.AdvanceNamespaceTime(ctx, request, opts...)
+		return err
+	}
+	err := backoff.ThrottleRetryContext(ctx, op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) AggregateWorkflowExecutions(
+	ctx context.Context,
+	request *adminservice.AggregateWorkflowExecutionsRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.AggregateWorkflowExecutionsResponse, error) {
+	var resp *adminservice.AggregateWorkflowExecutionsResponse
+	op := func(ctx context.Context) error {
+		var err error
+		resp, err = c.client.AggregateWorkflowExecutions(ctx, request, opts...)
+		return err
+	}
+	err := backoff.ThrottleRetryContext(ctx, op, c.policy, c.isRetryable)
+	return resp, err
+}
+
 func (c *retryableClient) CancelDLQJob(
 	ctx context.Context,
 	request *adminservice.CancelDLQJobRequest,
@@ -71,6 +116,21 @@ func (c *retryableClient) CancelDLQJob(
 	return resp, err
 }
 
+func (c *retryableClient) ClearFaultInjectionRules(
+	ctx context.Context,
+	request *adminservice.ClearFaultInjectionRulesRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ClearFaultInjectionRulesResponse, error) {
+	var resp *adminservice.ClearFaultInjectionRulesResponse
+	op := func(ctx context.Context) error {
+		var err error
+		resp, err = c.client.ClearFaultInjectionRules(ctx, request, opts...)
+		return err
+	}
+	err := backoff.ThrottleRetryContext(ctx, op, c.policy, c.isRetryable)
+	return resp, err
+}
+
 func (c *retryableClient) CloseShard(
 	ctx context.Context,
 	request *adminservice.CloseShardRequest,
@@ -191,6 +251,21 @@ func (c *retryableClient) DescribeTaskQueuePartition(
 	return resp, err
 }
 
+func (c *retryableClient) ExplainVisibilityQuery(
+	ctx context.Context,
+	request *adminservice.ExplainVisibilityQueryRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ExplainVisibilityQueryResponse, error) {
+	var resp *adminservice.ExplainVisibilityQueryResponse
+	op := func(ctx context.Context) error {
+		var err error
+		resp, err = c.client.ExplainVisibilityQuery(ctx, request, opts...)
+		return err
+	}
+	err := backoff.ThrottleRetryContext(ctx, op, c.policy, c.isRetryable)
+	return resp, err
+}
+
 func (c *retryableClient) ForceUnloadTaskQueuePartition(
 	ctx context.Context,
 	request *adminservice.ForceUnloadTaskQueuePartitionRequest,
@@ -221,6 +296,21 @@ func (c *retryableClient) GenerateLastHistoryReplicationTasks(
 	return resp, err
 }
 
+func (c *retryableClient) GetArchivedChasmExecution(
+	ctx context.Context,
+	request *adminservice.GetArchivedChasmExecutionRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.GetArchivedChasmExecutionResponse, error) {
+	var resp *adminservice.GetArchivedChasmExecutionResponse
+	op := func(ctx context.Context) error {
+		var err error
+		resp, err = c.client.GetArchivedChasmExecution(ctx, request, opts...)
+		return err
+	}
+	err := backoff.ThrottleRetryContext(ctx, op, c.policy, c.isRetryable)
+	return resp, err
+}
+
 func (c *retryableClient) GetDLQMessages(
 	ctx context.Context,
 	request *adminservice.GetDLQMessagesRequest,
@@ -431,6 +521,21 @@ func (c *retryableClient) ListClusters(
 	return resp, err
 }
 
+func (c *retryableClient) ListFaultInjectionRules(
+	ctx context.Context,
+	request *adminservice.ListFaultInjectionRulesRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ListFaultInjectionRulesResponse, error) {
+	var resp *adminservice.ListFaultInjectionRulesResponse
+	op := func(ctx context.Context) error {
+		var err error
+		resp, err = c.client.ListFaultInjectionRules(ctx, request, opts...)
+		return err
+	}
+	err := backoff.ThrottleRetryContext(ctx, op, c.policy, c.isRetryable)
+	return resp, err
+}
+
 func (c *retryableClient) ListHistoryTasks(
 	ctx context.Context,
 	request *adminservice.ListHistoryTasksRequest,
@@ -581,6 +686,21 @@ func (c *retryableClient) RefreshWorkflowTasks(
 	return resp, err
 }
 
+func (c *retryableClient) RehydrateWorkflowExecution(
+	ctx context.Context,
+	request *adminservice.RehydrateWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.RehydrateWorkflowExecutionResponse, error) {
+	var resp *adminservice.RehydrateWorkflowExecutionResponse
+	op := func(ctx context.Context) error {
+		var err error
+		resp, err = c.client.RehydrateWorkflowExecution(ctx, request, opts...)
+		return err
+	}
+	err := backoff.ThrottleRetryContext(ctx, op, c.policy, c.isRetryable)
+	return resp, err
+}
+
 func (c *retryableClient) RemoveRemoteCluster(
 	ctx context.Context,
 	request *adminservice.RemoveRemoteClusterRequest,
@@ -670,3 +790,18 @@ func (c *retryableClient) SyncWorkflowState(
 	err := backoff.ThrottleRetryContext(ctx, op, c.policy, c.isRetryable)
 	return resp, err
 }
+
+func (c *retryableClient) VerifyArchivedHistory(
+	ctx context.Context,
+	request *adminservice.VerifyArchivedHistoryRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.VerifyArchivedHistoryResponse, error) {
+	var resp *adminservice.VerifyArchivedHistoryResponse
+	op := func(ctx context.Context) error {
+		var err error
+		resp, err = c.client.VerifyArchivedHistory(ctx, request, opts...)
+		return err
+	}
+	err := backoff.ThrottleRetryContext(ctx, op, c.policy, c.isRetryable)
+	return resp, err
+}
@@ -81,6 +81,30 @@ func (c *clientImpl) DeepHealthCheck(ctx context.Context, request *historyservic
 	return c.connections.getOrCreateClientConn(rpcAddress(request.GetHostAddress())).grpcClient.DeepHealthCheck(ctx, request, opts...)
 }
 
+func (c *clientImpl) AddFaultInjectionRule(ctx context.Context, request *historyservice.AddFaultInjectionRuleRequest, opts ...grpc.CallOption) (*historyservice.AddFaultInjectionRuleResponse, error) {
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return c.connections.getOrCreateClientConn(rpcAddress(request.GetHostAddress())).grpcClient.AddFaultInjectionRule(ctx, request, opts...)
+}
+
+func (c *clientImpl) ClearFaultInjectionRules(ctx context.Context, request *historyservice.ClearFaultInjectionRulesRequest, opts ...grpc.CallOption) (*historyservice.ClearFaultInjectionRulesResponse, error) {
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return c.connections.getOrCreateClientConn(rpcAddress(request.GetHostAddress())).grpcClient.ClearFaultInjectionRules(ctx, request, opts...)
+}
+
+func (c *clientImpl) UpdateNamespaceTime(ctx context.Context, request *historyservice.UpdateNamespaceTimeRequest, opts ...grpc.CallOption) (*historyservice.UpdateNamespaceTimeResponse, error) {
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return c.connections.getOrCreateClientConn(rpcAddress(request.GetHostAddress())).grpcClient.UpdateNamespaceTime(ctx, request, opts...)
+}
+
+func (c *clientImpl) DescribeNamespaceTime(ctx context.Context, request *historyservice.DescribeNamespaceTimeRequest, opts ...grpc.CallOption) (*historyservice.DescribeNamespaceTimeResponse, error) {
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return c.connections.getOrCreateClientConn(rpcAddress(request.GetHostAddress())).grpcClient.DescribeNamespaceTime(ctx, request, opts...)
+}
+
 func (c *clientImpl) DescribeHistoryHost(
 	ctx context.Context,
 	request *historyservice.DescribeHistoryHostRequest,
@@ -30,6 +30,46 @@ func (c *clientImpl) AddTasks(
 	return response, nil
 }
 
+func (c *clientImpl) AdvanceNamespaceTime(
+	ctx context.Context,
+	request *historyservice.AdvanceNamespaceTimeRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.AdvanceNamespaceTimeResponse, error) {
+	shardID := request.GetShardId()
+	var response *historyservice.AdvanceNamespaceTimeResponse
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
+		var err error
+		ctx, cancel := c.createContext(ctx)
+		defer cancel()
+		response, err = client.AdvanceNamespaceTime(ctx, request, opts...)
+		return err
+	}
+	if err := c.executeWithRedirect(ctx, shardID, op); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (c *clientImpl) AllocateTaskId(
+	ctx context.Context,
+	request *historyservice.AllocateTaskIdRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.AllocateTaskIdResponse, error) {
+	shardID := request.GetShardId()
+	var response *historyservice.AllocateTaskIdResponse
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
+		var err error
+		ctx, cancel := c.createContext(ctx)
+		defer cancel()
+		response, err = client.AllocateTaskId(ctx, request, opts...)
+		return err
+	}
+	if err := c.executeWithRedirect(ctx, shardID, op); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
 func (c *clientImpl) CancelNexusOperation(
 	ctx context.Context,
 	request *historyservice.CancelNexusOperationRequest,
@@ -9,6 +9,20 @@ import (
 	"google.golang.org/grpc"
 )
 
+func (c *metricClient) AddFaultInjectionRule(
+	ctx context.Context,
+	request *historyservice.AddFaultInjectionRuleRequest,
+	opts ...grpc.CallOption,
+) (_ *historyservice.AddFaultInjectionRuleResponse, retError error) {
+
+	metricsHandler, startTime := c.startMetricsRecording(ctx, "HistoryClientAddFaultInjectionRule")
+	defer func() {
+		c.finishMetricsRecording(metricsHandler, startTime, retError)
+	}()
+
+	return c.client.AddFaultInjectionRule(ctx, request, opts...)
+}
+
 func (c *metricClient) AddTasks(
 	ctx context.Context,
 	request *historyservice.AddTasksRequest,
@@ -23,6 +37,48 @@ func (c *metricClient) AddTasks(
 	return c.client.AddTasks(ctx, request, opts...)
 }
 
+func (c *metricClient) AdvanceNamespaceTime(
+	ctx context.Context,
+	request *historyservice.AdvanceNamespaceTimeRequest,
+	opts ...grpc.CallOption,
+) (_ *historyservice.AdvanceNamespaceTimeResponse, retError error) {
+
+	metricsHandler, startTime := c.startMetricsRecording(ctx, "HistoryClientAdvanceNamespaceTime")
+	defer func() {
+		c.finishMetricsRecording(metricsHandler, startTime, retError)
+	}()
+
+	return c.client.AdvanceNamespaceTime(ctx, request, opts...)
+}
+
+func (c *metricClient) AllocateTaskId(
+	ctx context.Context,
+	request *historyservice.AllocateTaskIdRequest,
+	opts ...grpc.CallOption,
+) (_ *historyservice.AllocateTaskIdResponse, retError error) {
+
+	metricsHandler, startTime := c.startMetricsRecording(ctx, "HistoryClientAllocateTaskId")
+	defer func() {
+		c.finishMetricsRecording(metricsHandler, startTime, retError)
+	}()
+
+	return c.client.AllocateTaskId(ctx, request, opts...)
+}
+
+func (c *metricClient) ClearFaultInjectionRules(
+	ctx context.Context,
+	request *historyservice.ClearFaultInjectionRulesRequest,
+	opts ...grpc.CallOption,
+) (_ *historyservice.ClearFaultInjectionRulesResponse, retError error) {
+
+	metricsHandler, startTime := c.startMetricsRecording(ctx, "HistoryClientClearFaultInjectionRules")
+	defer func() {
+		c.finishMetricsRecording(metricsHandler, startTime, retError)
+	}()
+
+	return c.client.ClearFaultInjectionRules(ctx, request, opts...)
+}
+
 func (c *metricClient) CloseShard(
 	ctx context.Context,
 	request *historyservice.CloseShardRequest,
@@ -149,6 +205,20 @@ func (c *metricClient) DescribeMutableState(
 	return c.client.DescribeMutableState(ctx, request, opts...)
 }
 
+func (c *metricClient) DescribeNamespaceTime(
+	ctx context.Context,
+	request *historyservice.DescribeNamespaceTimeRequest,
+	opts ...grpc.CallOption,
+) (_ *historyservice.DescribeNamespaceTimeResponse, retError error) {
+
+	metricsHandler, startTime := c.startMetricsRecording(ctx, "HistoryClientDescribeNamespaceTime")
+	defer func() {
+		c.finishMetricsRecording(metricsHandler, startTime, retError)
+	}()
+
+	return c.client.DescribeNamespaceTime(ctx, request, opts...)
+}
+
 func (c *metricClient) DescribeWorkflowExecution(
 	ctx context.Context,
 	request *historyservice.DescribeWorkflowExecutionRequest,
@@ -975,6 +1045,20 @@ func (c *metricClient) UpdateActivityOptions(
 	return c.client.UpdateActivityOptions(ctx, request, opts...)
 }
 
+func (c *metricClient) UpdateNamespaceTime(
+	ctx context.Context,
+	request *historyservice.UpdateNamespaceTimeRequest,
+	opts ...grpc.CallOption,
+) (_ *historyservice.UpdateNamespaceTimeResponse, retError error) {
+
+	metricsHandler, startTime := c.startMetricsRecording(ctx, "HistoryClientUpdateNamespaceTime")
+	defer func() {
+		c.finishMetricsRecording(metricsHandler, startTime, retError)
+	}()
+
+	return c.client.UpdateNamespaceTime(ctx, request, opts...)
+}
+
 func (c *metricClient) UpdateWorkflowExecution(
 	ctx context.Context,
 	request *historyservice.UpdateWorkflowExecutionRequest,
@@ -11,6 +11,21 @@ import (
 	"go.temporal.io/server/common/backoff"
 )
 
+func (c *retryableClient) AddFaultInjectionRule(
+	ctx context.Context,
+	request *historyservice.AddFaultInjectionRuleRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.AddFaultInjectionRuleResponse, error) {
+	var resp *historyservice.AddFaultInjectionRuleResponse
+	op := func(ctx context.Context) error {
+		var err error
+		resp, err = c.client.AddFaultInjectionRule(ctx, request, opts...)
+		return err
+	}
+	err := backoff.ThrottleRetryContext(ctx, op, c.policy, c.isRetryable)
+	return resp, err
+}
+
 func (c *retryableClient) AddTasks(
 	ctx context.Context,
 	request *historyservice.AddTasksRequest,
@@ -26,6 +41,36 @@ func (c *retryableClient) AddTasks(
 	return resp, err
 }
 
+func (c *retryableClient) AdvanceNamespaceTime(
+	ctx context.Context,
+	request *historyservice.AdvanceNamespaceTimeRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.AdvanceNamespaceTimeResponse, error) {
+	var resp *historyservice.AdvanceNamespaceTimeResponse
+	op := func(ctx context.Context) error {
+		var err error
+		resp, err = c.client.AdvanceNamespaceTime(ctx, request, opts...)
+		return err
+	}
+	err := backoff.ThrottleRetryContext(ctx, op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) AllocateTaskId(
+	ctx context.Context,
+	request *historyservice.AllocateTaskIdRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.AllocateTaskIdResponse, error) {
+	var resp *historyservice.AllocateTaskIdResponse
+	op := func(ctx context.Context) error {
+		var err error
+		resp, err = c.client.AllocateTaskId(ctx, request, opts...)
+		return err
+	}
+	err := backoff.ThrottleRetryContext(ctx, op, c.policy, c.isRetryable)
+	return resp, err
+}
+
 func (c *retryableClient) CancelNexusOperation(
 	ctx context.Context,
 	request *historyservice.CancelNexusOperationRequest,
@@ -41,6 +86,21 @@ func (c *retryableClient) CancelNexusOperation(
 	return resp, err
 }
 
+func (c *retryableClient) ClearFaultInjectionRules(
+	ctx context.Context,
+	request *historyservice.ClearFaultInjectionRulesRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.ClearFaultInjectionRulesResponse, error) {
+	var resp *historyservice.ClearFaultInjectionRulesResponse
+	op := func(ctx context.Context) error {
+		var err error
+		resp, err = c.client.ClearFaultInjectionRules(ctx, request, opts...)
+		return err
+	}
+	err := backoff.ThrottleRetryContext(ctx, op, c.policy, c.isRetryable)
+	return resp, err
+}
+
 func (c *retryableClient) CloseShard(
 	ctx context.Context,
 	request *historyservice.CloseShardRequest,
@@ -176,6 +236,21 @@ func (c *retryableClient) DescribeMutableState(
 	return resp, err
 }
 
+func (c *retryableClient) DescribeNamespaceTime(
+	ctx context.Context,
+	request *historyservice.DescribeNamespaceTimeRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.DescribeNamespaceTimeResponse, error) {
+	var resp *historyservice.DescribeNamespaceTimeResponse
+	op := func(ctx context.Context) error {
+		var err error
+		resp, err = c.client.DescribeNamespaceTime(ctx, request, opts...)
+		return err
+	}
+	err := backoff.ThrottleRetryContext(ctx, op, c.policy, c.isRetryable)
+	return resp, err
+}
+
 func (c *retryableClient) DescribeWorkflowExecution(
 	ctx context.Context,
 	request *historyservice.DescribeWorkflowExecutionRequest,
@@ -1076,6 +1151,21 @@ func (c *retryableClient) UpdateActivityOptions(
 	return resp, err
 }
 
+func (c *retryableClient) UpdateNamespaceTime(
+	ctx context.Context,
+	request *historyservice.UpdateNamespaceTimeRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.UpdateNamespaceTimeResponse, error) {
+	var resp *historyservice.UpdateNamespaceTimeResponse
+	op := func(ctx context.Context) error {
+		var err error
+		resp, err = c.client.UpdateNamespaceTime(ctx, request, opts...)
+		return err
+	}
+	err := backoff.ThrottleRetryContext(ctx, op, c.policy, c.isRetryable)
+	return resp, err
+}
+
 func (c *retryableClient) UpdateWorkflowExecution(
 	ctx context.Context,
 	request *historyservice.UpdateWorkflowExecutionRequest,
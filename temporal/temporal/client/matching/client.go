@@ -157,6 +157,26 @@ func (c *clientImpl) QueryWorkflow(ctx context.Context, request *matchingservice
 	return client.QueryWorkflow(ctx, request, opts...)
 }
 
+func (c *clientImpl) AddFaultInjectionRule(ctx context.Context, request *matchingservice.AddFaultInjectionRuleRequest, opts ...grpc.CallOption) (*matchingservice.AddFaultInjectionRuleResponse, error) {
+	client, err := c.clients.GetClientForClientKey(request.GetHostAddress())
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.(matchingservice.MatchingServiceClient).AddFaultInjectionRule(ctx, request, opts...)
+}
+
+func (c *clientImpl) ClearFaultInjectionRules(ctx context.Context, request *matchingservice.ClearFaultInjectionRulesRequest, opts ...grpc.CallOption) (*matchingservice.ClearFaultInjectionRulesResponse, error) {
+	client, err := c.clients.GetClientForClientKey(request.GetHostAddress())
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.(matchingservice.MatchingServiceClient).ClearFaultInjectionRules(ctx, request, opts...)
+}
+
 // processInputPartition returns a partition in certain cases that load balancer involvement is not necessary,
 // otherwise, returns a task queue to pass down to the load balancer.
 func (c *clientImpl) processInputPartition(proto *taskqueuepb.TaskQueue, nsid string, taskType enumspb.TaskQueueType, forwardedFrom string) (tqid.Partition, *tqid.TaskQueue) {
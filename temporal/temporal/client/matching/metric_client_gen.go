@@ -9,6 +9,20 @@ import (
 	"google.golang.org/grpc"
 )
 
+func (c *metricClient) AddFaultInjectionRule(
+	ctx context.Context,
+	request *matchingservice.AddFaultInjectionRuleRequest,
+	opts ...grpc.CallOption,
+) (_ *matchingservice.AddFaultInjectionRuleResponse, retError error) {
+
+	metricsHandler, startTime := c.startMetricsRecording(ctx, "MatchingClientAddFaultInjectionRule")
+	defer func() {
+		c.finishMetricsRecording(metricsHandler, startTime, retError)
+	}()
+
+	return c.client.AddFaultInjectionRule(ctx, request, opts...)
+}
+
 func (c *metricClient) ApplyTaskQueueUserDataReplicationEvent(
 	ctx context.Context,
 	request *matchingservice.ApplyTaskQueueUserDataReplicationEventRequest,
@@ -79,6 +93,20 @@ func (c *metricClient) CheckTaskQueueVersionMembership(
 	return c.client.CheckTaskQueueVersionMembership(ctx, request, opts...)
 }
 
+func (c *metricClient) ClearFaultInjectionRules(
+	ctx context.Context,
+	request *matchingservice.ClearFaultInjectionRulesRequest,
+	opts ...grpc.CallOption,
+) (_ *matchingservice.ClearFaultInjectionRulesResponse, retError error) {
+
+	metricsHandler, startTime := c.startMetricsRecording(ctx, "MatchingClientClearFaultInjectionRules")
+	defer func() {
+		c.finishMetricsRecording(metricsHandler, startTime, retError)
+	}()
+
+	return c.client.ClearFaultInjectionRules(ctx, request, opts...)
+}
+
 func (c *metricClient) CreateNexusEndpoint(
 	ctx context.Context,
 	request *matchingservice.CreateNexusEndpointRequest,
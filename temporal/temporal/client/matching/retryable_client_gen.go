@@ -26,6 +26,21 @@ func (c *retryableClient) AddActivityTask(
 	return resp, err
 }
 
+func (c *retryableClient) AddFaultInjectionRule(
+	ctx context.Context,
+	request *matchingservice.AddFaultInjectionRuleRequest,
+	opts ...grpc.CallOption,
+) (*matchingservice.AddFaultInjectionRuleResponse, error) {
+	var resp *matchingservice.AddFaultInjectionRuleResponse
+	op := func(ctx context.Context) error {
+		var err error
+		resp, err = c.client.AddFaultInjectionRule(ctx, request, opts...)
+		return err
+	}
+	err := backoff.ThrottleRetryContext(ctx, op, c.policy, c.isRetryable)
+	return resp, err
+}
+
 func (c *retryableClient) AddWorkflowTask(
 	ctx context.Context,
 	request *matchingservice.AddWorkflowTaskRequest,
@@ -116,6 +131,21 @@ func (c *retryableClient) CheckTaskQueueVersionMembership(
 	return resp, err
 }
 
+func (c *retryableClient) ClearFaultInjectionRules(
+	ctx context.Context,
+	request *matchingservice.ClearFaultInjectionRulesRequest,
+	opts ...grpc.CallOption,
+) (*matchingservice.ClearFaultInjectionRulesResponse, error) {
+	var resp *matchingservice.ClearFaultInjectionRulesResponse
+	op := func(ctx context.Context) error {
+		var err error
+		resp, err = c.client.ClearFaultInjectionRules(ctx, request, opts...)
+		return err
+	}
+	err := backoff.ThrottleRetryContext(ctx, op, c.policy, c.isRetryable)
+	return resp, err
+}
+
 func (c *retryableClient) CreateNexusEndpoint(
 	ctx context.Context,
 	request *matchingservice.CreateNexusEndpointRequest,
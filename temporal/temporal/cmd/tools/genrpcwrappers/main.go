@@ -98,6 +98,9 @@ var (
 		"client.matching.PollActivityTaskQueue": true,
 		"client.matching.PollWorkflowTaskQueue": true,
 		"client.matching.QueryWorkflow":         true,
+		// these are sent to a host address.
+		"client.matching.AddFaultInjectionRule":    true,
+		"client.matching.ClearFaultInjectionRules": true,
 		// these do forwarding stats. too complicated.
 		"metricsClient.matching.AddActivityTask":       true,
 		"metricsClient.matching.AddWorkflowTask":       true,
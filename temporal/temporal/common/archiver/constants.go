@@ -20,6 +20,11 @@ const (
 	ErrReasonReadHistory = "failed to read history batches"
 	// ErrReasonHistoryMutated is the error reason for mutated history
 	ErrReasonHistoryMutated = "history was mutated"
+
+	// VisibilityFormatRecord is the visibility archival format which archives each record in its own blob
+	VisibilityFormatRecord = "record"
+	// VisibilityFormatParquet is the visibility archival format which batches the records in Parquet files
+	VisibilityFormatParquet = "parquet"
 )
 
 var (
@@ -37,4 +42,12 @@ var (
 	ErrNextPageTokenCorrupted = errors.New("next page token is corrupted")
 	// ErrHistoryNotExist is the error for non-exist history
 	ErrHistoryNotExist = errors.New("requested workflow history does not exist")
+	// ErrManifestNotExist is the error for non-exist history manifest
+	ErrManifestNotExist = errors.New("requested workflow history manifest does not exist")
+	// ErrChasmExecutionNotExist is the error for non-exist CHASM execution
+	ErrChasmExecutionNotExist = errors.New("requested CHASM execution does not exist")
+	// ErrInvalidGetChasmExecutionRequest is the error for invalid GetChasmExecution request
+	ErrInvalidGetChasmExecutionRequest = errors.New("get archived CHASM execution request is invalid")
+	// ErrUnknownVisibilityFormat is the error for unknown visibility archival format
+	ErrUnknownVisibilityFormat = errors.New("unknown visibility archival format")
 )
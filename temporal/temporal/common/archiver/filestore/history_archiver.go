@@ -2,7 +2,10 @@
 
 // Each Archive() request results in a file named in the format of
 // hash(namespaceID, workflowID, runID)_version.history being created in the specified
-// directory. Workflow histories stored in that file are encoded in JSON format.
+// directory. Workflow histories stored in that file are encoded in JSON format. A manifest
+// of the history batches is written next to it, in a file with the .manifest extension.
+// The component trees of CHASM executions are archived in files with the .chasm extension
+// instead, by the ArchiveChasmExecution() method.
 
 // The Get() method retrieves the archived histories from the directory specified in the
 // URI. It optionally takes in a NextPageToken which specifies the workflow close failover
@@ -20,9 +23,11 @@ import (
 	"os"
 	"path"
 	"strconv"
+	"strings"
 
 	historypb "go.temporal.io/api/history/v1"
 	"go.temporal.io/api/serviceerror"
+	archiverspb "go.temporal.io/server/api/archiver/v1"
 	"go.temporal.io/server/common"
 	"go.temporal.io/server/common/archiver"
 	"go.temporal.io/server/common/codec"
@@ -37,9 +42,18 @@ const (
 	// URIScheme is the scheme for the filestore implementation
 	URIScheme = "file"
 
-	errEncodeHistory = "failed to encode history batches"
-	errMakeDirectory = "failed to make directory"
-	errWriteFile     = "failed to write history to file"
+	errEncodeHistory  = "failed to encode history batches"
+	errEncodeManifest = "failed to encode history manifest"
+	errMakeDirectory  = "failed to make directory"
+	errWriteFile      = "failed to write history to file"
+	errWriteManifest  = "failed to write history manifest to file"
+
+	errEncodeChasmExecution = "failed to encode CHASM execution"
+	errWriteChasmExecution  = "failed to write CHASM execution to file"
+
+	historyFileExtension         = ".history"
+	historyManifestFileExtension = ".manifest"
+	chasmExecutionFileExtension  = ".chasm"
 
 	targetHistoryBlobSize = 2 * 1024 * 1024 // 2MB
 )
@@ -180,6 +194,24 @@ func (h *historyArchiver) Archive(
 		return err
 	}
 
+	// the manifest is written after the history, so a history without a manifest is reported as unverified rather than
+	// a manifest without a history being reported as missing.
+	manifestBatches, err := archiver.NewHistoryManifestBatches(historyBatches)
+	if err != nil {
+		logger.Error(archiver.ArchiveNonRetryableErrorMsg, tag.ArchivalArchiveFailReason(errEncodeManifest), tag.Error(err))
+		return err
+	}
+	encodedManifest, err := encode(archiver.NewHistoryManifest(request, manifestBatches))
+	if err != nil {
+		logger.Error(archiver.ArchiveNonRetryableErrorMsg, tag.ArchivalArchiveFailReason(errEncodeManifest), tag.Error(err))
+		return err
+	}
+	manifestFilename := constructHistoryManifestFilename(request.NamespaceID, request.WorkflowID, request.RunID, request.CloseFailoverVersion)
+	if err := writeFile(path.Join(dirPath, manifestFilename), encodedManifest, h.fileMode); err != nil {
+		logger.Error(archiver.ArchiveNonRetryableErrorMsg, tag.ArchivalArchiveFailReason(errWriteManifest), tag.Error(err))
+		return err
+	}
+
 	return nil
 }
 
@@ -219,6 +251,9 @@ func (h *historyArchiver) Get(
 	} else {
 		highestVersion, err := getHighestVersion(dirPath, request)
 		if err != nil {
+			if err == archiver.ErrHistoryNotExist {
+				return nil, serviceerror.NewNotFound(err.Error())
+			}
 			return nil, serviceerror.NewInternal(err.Error())
 		}
 		token = &getHistoryToken{
@@ -273,6 +308,164 @@ func (h *historyArchiver) Get(
 	return response, nil
 }
 
+func (h *historyArchiver) GetManifest(
+	ctx context.Context,
+	URI archiver.URI,
+	request *archiver.GetHistoryRequest,
+) (*archiverspb.HistoryManifest, error) {
+	if err := h.ValidateURI(URI); err != nil {
+		return nil, serviceerror.NewInvalidArgument(archiver.ErrInvalidURI.Error())
+	}
+
+	if err := archiver.ValidateGetRequest(request); err != nil {
+		return nil, serviceerror.NewInvalidArgument(archiver.ErrInvalidGetHistoryRequest.Error())
+	}
+
+	dirPath := URI.Path()
+	exists, err := directoryExists(dirPath)
+	if err != nil {
+		return nil, serviceerror.NewInternal(err.Error())
+	}
+	if !exists {
+		return nil, serviceerror.NewNotFound(archiver.ErrHistoryNotExist.Error())
+	}
+
+	var version int64
+	if request.CloseFailoverVersion != nil {
+		version = *request.CloseFailoverVersion
+	} else {
+		highestVersion, err := getHighestVersion(dirPath, request)
+		if err != nil {
+			if err == archiver.ErrHistoryNotExist {
+				return nil, serviceerror.NewNotFound(err.Error())
+			}
+			return nil, serviceerror.NewInternal(err.Error())
+		}
+		version = *highestVersion
+	}
+
+	filepath := path.Join(dirPath, constructHistoryManifestFilename(request.NamespaceID, request.WorkflowID, request.RunID, version))
+	exists, err = fileExists(filepath)
+	if err != nil {
+		return nil, serviceerror.NewInternal(err.Error())
+	}
+	if !exists {
+		return nil, serviceerror.NewNotFound(archiver.ErrManifestNotExist.Error())
+	}
+
+	data, err := readFile(filepath)
+	if err != nil {
+		return nil, serviceerror.NewInternal(err.Error())
+	}
+	manifest := &archiverspb.HistoryManifest{}
+	if err := codec.NewJSONPBEncoder().Decode(data, manifest); err != nil {
+		return nil, serviceerror.NewInternal(err.Error())
+	}
+	return manifest, nil
+}
+
+func (h *historyArchiver) ArchiveChasmExecution(
+	ctx context.Context,
+	URI archiver.URI,
+	request *archiverspb.ChasmExecution,
+	opts ...archiver.ArchiveOption,
+) (err error) {
+	featureCatalog := archiver.GetFeatureCatalog(opts...)
+	defer func() {
+		if err != nil && !common.IsPersistenceTransientError(err) && featureCatalog.NonRetryableError != nil {
+			err = featureCatalog.NonRetryableError()
+		}
+	}()
+
+	logger := archiver.TagLoggerWithArchiveChasmExecutionRequestAndURI(h.logger, request, URI.String())
+
+	if err := h.ValidateURI(URI); err != nil {
+		logger.Error(archiver.ArchiveNonRetryableErrorMsg, tag.ArchivalArchiveFailReason(archiver.ErrReasonInvalidURI), tag.Error(err))
+		return err
+	}
+
+	if err := archiver.ValidateChasmExecutionArchivalRequest(request); err != nil {
+		logger.Error(archiver.ArchiveNonRetryableErrorMsg, tag.ArchivalArchiveFailReason(archiver.ErrReasonInvalidArchiveRequest), tag.Error(err))
+		return err
+	}
+
+	encodedExecution, err := encode(request)
+	if err != nil {
+		logger.Error(archiver.ArchiveNonRetryableErrorMsg, tag.ArchivalArchiveFailReason(errEncodeChasmExecution), tag.Error(err))
+		return err
+	}
+
+	dirPath := URI.Path()
+	if err = mkdirAll(dirPath, h.dirMode); err != nil {
+		logger.Error(archiver.ArchiveNonRetryableErrorMsg, tag.ArchivalArchiveFailReason(errMakeDirectory), tag.Error(err))
+		return err
+	}
+
+	filename := constructChasmExecutionFilename(request.GetNamespaceId(), request.GetBusinessId(), request.GetRunId(), request.GetCloseFailoverVersion())
+	if err := writeFile(path.Join(dirPath, filename), encodedExecution, h.fileMode); err != nil {
+		logger.Error(archiver.ArchiveNonRetryableErrorMsg, tag.ArchivalArchiveFailReason(errWriteChasmExecution), tag.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (h *historyArchiver) GetChasmExecution(
+	ctx context.Context,
+	URI archiver.URI,
+	request *archiver.GetChasmExecutionRequest,
+) (*archiverspb.ChasmExecution, error) {
+	if err := h.ValidateURI(URI); err != nil {
+		return nil, serviceerror.NewInvalidArgument(archiver.ErrInvalidURI.Error())
+	}
+
+	if err := archiver.ValidateGetChasmExecutionRequest(request); err != nil {
+		return nil, serviceerror.NewInvalidArgument(archiver.ErrInvalidGetChasmExecutionRequest.Error())
+	}
+
+	dirPath := URI.Path()
+	exists, err := directoryExists(dirPath)
+	if err != nil {
+		return nil, serviceerror.NewInternal(err.Error())
+	}
+	if !exists {
+		return nil, serviceerror.NewNotFound(archiver.ErrChasmExecutionNotExist.Error())
+	}
+
+	var version int64
+	if request.CloseFailoverVersion != nil {
+		version = *request.CloseFailoverVersion
+	} else {
+		prefix := constructHistoryFilenamePrefix(request.NamespaceID, request.BusinessID, request.RunID)
+		highestVersion, err := getHighestVersionOfFiles(dirPath, prefix, chasmExecutionFileExtension)
+		if err != nil {
+			return nil, serviceerror.NewInternal(err.Error())
+		}
+		if highestVersion == nil {
+			return nil, serviceerror.NewNotFound(archiver.ErrChasmExecutionNotExist.Error())
+		}
+		version = *highestVersion
+	}
+
+	filepath := path.Join(dirPath, constructChasmExecutionFilename(request.NamespaceID, request.BusinessID, request.RunID, version))
+	exists, err = fileExists(filepath)
+	if err != nil {
+		return nil, serviceerror.NewInternal(err.Error())
+	}
+	if !exists {
+		return nil, serviceerror.NewNotFound(archiver.ErrChasmExecutionNotExist.Error())
+	}
+
+	data, err := readFile(filepath)
+	if err != nil {
+		return nil, serviceerror.NewInternal(err.Error())
+	}
+	execution := &archiverspb.ChasmExecution{}
+	if err := codec.NewJSONPBEncoder().Decode(data, execution); err != nil {
+		return nil, serviceerror.NewInternal(err.Error())
+	}
+	return execution, nil
+}
+
 func (h *historyArchiver) ValidateURI(URI archiver.URI) error {
 	if URI.Scheme() != URIScheme {
 		return archiver.ErrURISchemeMismatch
@@ -282,13 +475,30 @@ func (h *historyArchiver) ValidateURI(URI archiver.URI) error {
 }
 
 func getHighestVersion(dirPath string, request *archiver.GetHistoryRequest) (*int64, error) {
-	filenames, err := listFilesByPrefix(dirPath, constructHistoryFilenamePrefix(request.NamespaceID, request.WorkflowID, request.RunID))
+	prefix := constructHistoryFilenamePrefix(request.NamespaceID, request.WorkflowID, request.RunID)
+	highestVersion, err := getHighestVersionOfFiles(dirPath, prefix, historyFileExtension)
+	if err != nil {
+		return nil, err
+	}
+	if highestVersion == nil {
+		return nil, archiver.ErrHistoryNotExist
+	}
+	return highestVersion, nil
+}
+
+// getHighestVersionOfFiles returns the highest close failover version of the files with the prefix and the extension,
+// or nil if there is no such file.
+func getHighestVersionOfFiles(dirPath string, prefix string, extension string) (*int64, error) {
+	filenames, err := listFilesByPrefix(dirPath, prefix)
 	if err != nil {
 		return nil, err
 	}
 
 	var highestVersion *int64
 	for _, filename := range filenames {
+		if !strings.HasSuffix(filename, extension) {
+			continue
+		}
 		version, err := extractCloseFailoverVersion(filename)
 		if err != nil {
 			continue
@@ -297,8 +507,5 @@ func getHighestVersion(dirPath string, request *archiver.GetHistoryRequest) (*in
 			highestVersion = &version
 		}
 	}
-	if highestVersion == nil {
-		return nil, archiver.ErrHistoryNotExist
-	}
 	return highestVersion, nil
 }
@@ -10,16 +10,20 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	commonpb "go.temporal.io/api/common/v1"
 	enumspb "go.temporal.io/api/enums/v1"
 	historypb "go.temporal.io/api/history/v1"
 	"go.temporal.io/api/serviceerror"
 	archiverspb "go.temporal.io/server/api/archiver/v1"
+	enumsspb "go.temporal.io/server/api/enums/v1"
+	persistencespb "go.temporal.io/server/api/persistence/v1"
 	"go.temporal.io/server/common"
 	"go.temporal.io/server/common/archiver"
 	"go.temporal.io/server/common/config"
 	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/metrics"
 	"go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/testing/protorequire"
 	"go.temporal.io/server/tests/testutils"
 	"go.uber.org/mock/gomock"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -513,7 +517,7 @@ func (s *historyArchiverSuite) TestArchiveAndGet() {
 		Header: &archiverspb.HistoryBlobHeader{
 			IsLast: true,
 		},
-		Body: s.historyBatchesV100,
+		Body: cloneHistoryBatches(s.historyBatchesV100),
 	}
 	gomock.InOrder(
 		historyIterator.EXPECT().HasNext().Return(true),
@@ -554,6 +558,176 @@ func (s *historyArchiverSuite) TestArchiveAndGet() {
 	s.Equal(s.historyBatchesV100, response.HistoryBatches)
 }
 
+func (s *historyArchiverSuite) TestArchiveAndVerify() {
+	mockCtrl := gomock.NewController(s.T())
+	defer mockCtrl.Finish()
+	historyIterator := archiver.NewMockHistoryIterator(mockCtrl)
+	historyBatches := cloneHistoryBatches(s.historyBatchesV100)
+	historyBlob := &archiverspb.HistoryBlob{
+		Header: &archiverspb.HistoryBlobHeader{
+			IsLast: true,
+		},
+		Body: historyBatches,
+	}
+	gomock.InOrder(
+		historyIterator.EXPECT().HasNext().Return(true),
+		historyIterator.EXPECT().Next(gomock.Any()).Return(historyBlob, nil),
+		historyIterator.EXPECT().HasNext().Return(false),
+	)
+
+	dir := testutils.MkdirTemp(s.T(), "", "TestArchiveAndVerify")
+
+	historyArchiver := s.newTestHistoryArchiver(historyIterator)
+	archiveRequest := &archiver.ArchiveHistoryRequest{
+		NamespaceID:          testNamespaceID,
+		Namespace:            testNamespace,
+		WorkflowID:           testWorkflowID,
+		RunID:                testRunID,
+		BranchToken:          testBranchToken,
+		NextEventID:          testNextEventID,
+		CloseFailoverVersion: testCloseFailoverVersion,
+	}
+	URI, err := archiver.NewURI("file://" + dir)
+	s.NoError(err)
+	s.NoError(historyArchiver.Archive(context.Background(), URI, archiveRequest))
+	s.assertFileExists(path.Join(dir, constructHistoryManifestFilename(testNamespaceID, testWorkflowID, testRunID, testCloseFailoverVersion)))
+
+	manifest, err := historyArchiver.GetManifest(context.Background(), URI, &archiver.GetHistoryRequest{
+		NamespaceID: testNamespaceID,
+		WorkflowID:  testWorkflowID,
+		RunID:       testRunID,
+		PageSize:    testPageSize,
+	})
+	s.NoError(err)
+	s.Equal(testCloseFailoverVersion, manifest.GetCloseFailoverVersion())
+	s.Len(manifest.GetBatches(), len(historyBatches))
+
+	verifyRequest := &archiver.VerifyHistoryRequest{
+		NamespaceID: testNamespaceID,
+		WorkflowID:  testWorkflowID,
+		RunID:       testRunID,
+	}
+	verification, err := archiver.VerifyHistory(context.Background(), historyArchiver, URI, verifyRequest)
+	s.NoError(err)
+	s.Equal(enumsspb.ARCHIVAL_INTEGRITY_STATUS_OK, verification.Status)
+	s.Equal(testCloseFailoverVersion, verification.CloseFailoverVersion)
+
+	historyFilepath := path.Join(dir, constructHistoryFilename(testNamespaceID, testWorkflowID, testRunID, testCloseFailoverVersion))
+	data, err := encodeHistories(historyBatches[:1])
+	s.NoError(err)
+	s.NoError(writeFile(historyFilepath, data, testFileMode))
+	verification, err = archiver.VerifyHistory(context.Background(), historyArchiver, URI, verifyRequest)
+	s.NoError(err)
+	s.Equal(enumsspb.ARCHIVAL_INTEGRITY_STATUS_TRUNCATED, verification.Status)
+
+	s.NoError(writeFile(historyFilepath, []byte("corrupted"), testFileMode))
+	verification, err = archiver.VerifyHistory(context.Background(), historyArchiver, URI, verifyRequest)
+	s.NoError(err)
+	s.Equal(enumsspb.ARCHIVAL_INTEGRITY_STATUS_CORRUPTED, verification.Status)
+
+	s.NoError(os.Remove(historyFilepath))
+	verification, err = archiver.VerifyHistory(context.Background(), historyArchiver, URI, verifyRequest)
+	s.NoError(err)
+	s.Equal(enumsspb.ARCHIVAL_INTEGRITY_STATUS_MISSING, verification.Status)
+}
+
+func (s *historyArchiverSuite) TestGetManifest_Fail_ManifestNotExist() {
+	historyArchiver := s.newTestHistoryArchiver(nil)
+	URI, err := archiver.NewURI("file://" + s.testGetDirectory)
+	s.NoError(err)
+	request := &archiver.GetHistoryRequest{
+		NamespaceID: testNamespaceID,
+		WorkflowID:  testWorkflowID,
+		RunID:       testRunID,
+		PageSize:    testPageSize,
+	}
+	manifest, err := historyArchiver.GetManifest(context.Background(), URI, request)
+	s.Nil(manifest)
+	s.IsType(&serviceerror.NotFound{}, err)
+
+	verification, err := archiver.VerifyHistory(context.Background(), historyArchiver, URI, &archiver.VerifyHistoryRequest{
+		NamespaceID: testNamespaceID,
+		WorkflowID:  testWorkflowID,
+		RunID:       testRunID,
+	})
+	s.NoError(err)
+	s.Equal(enumsspb.ARCHIVAL_INTEGRITY_STATUS_UNVERIFIED, verification.Status)
+}
+
+func (s *historyArchiverSuite) TestArchiveChasmExecution_Fail_InvalidRequest() {
+	historyArchiver := s.newTestHistoryArchiver(nil)
+	URI, err := archiver.NewURI("file://" + testutils.MkdirTemp(s.T(), "", "TestArchiveChasmExecution"))
+	s.NoError(err)
+	execution := newTestChasmExecution(testCloseFailoverVersion)
+	execution.Nodes = nil
+	err = historyArchiver.ArchiveChasmExecution(context.Background(), URI, execution)
+	s.Error(err)
+}
+
+func (s *historyArchiverSuite) TestArchiveAndGetChasmExecution() {
+	dir := testutils.MkdirTemp(s.T(), "", "TestArchiveAndGetChasmExecution")
+	historyArchiver := s.newTestHistoryArchiver(nil)
+	URI, err := archiver.NewURI("file://" + dir)
+	s.NoError(err)
+
+	getRequest := &archiver.GetChasmExecutionRequest{
+		NamespaceID: testNamespaceID,
+		BusinessID:  testWorkflowID,
+		RunID:       testRunID,
+	}
+	_, err = historyArchiver.GetChasmExecution(context.Background(), URI, getRequest)
+	s.IsType(&serviceerror.NotFound{}, err)
+
+	executionV1 := newTestChasmExecution(1)
+	executionV100 := newTestChasmExecution(testCloseFailoverVersion)
+	s.NoError(historyArchiver.ArchiveChasmExecution(context.Background(), URI, executionV1))
+	s.NoError(historyArchiver.ArchiveChasmExecution(context.Background(), URI, executionV100))
+	s.assertFileExists(path.Join(dir, constructChasmExecutionFilename(testNamespaceID, testWorkflowID, testRunID, testCloseFailoverVersion)))
+
+	// the highest version is returned if no version is requested
+	execution, err := historyArchiver.GetChasmExecution(context.Background(), URI, getRequest)
+	s.NoError(err)
+	protorequire.ProtoEqual(s.T(), executionV100, execution)
+
+	version := int64(1)
+	getRequest.CloseFailoverVersion = &version
+	execution, err = historyArchiver.GetChasmExecution(context.Background(), URI, getRequest)
+	s.NoError(err)
+	protorequire.ProtoEqual(s.T(), executionV1, execution)
+
+	// the archived history of the same run is not mistaken for the CHASM execution
+	getRequest.CloseFailoverVersion = nil
+	getRequest.RunID = "other-run-id"
+	_, err = historyArchiver.GetChasmExecution(context.Background(), URI, getRequest)
+	s.IsType(&serviceerror.NotFound{}, err)
+}
+
+func newTestChasmExecution(version int64) *archiverspb.ChasmExecution {
+	return &archiverspb.ChasmExecution{
+		NamespaceId:          testNamespaceID,
+		Namespace:            testNamespace,
+		BusinessId:           testWorkflowID,
+		RunId:                testRunID,
+		ArchetypeId:          1234,
+		Archetype:            "scheduler.scheduler",
+		CloseFailoverVersion: version,
+		CloseTime:            timestamppb.New(time.Date(2020, 8, 22, 1, 2, 3, 4, time.UTC)),
+		Nodes: map[string]*persistencespb.ChasmNode{
+			"": {
+				Metadata: &persistencespb.ChasmNodeMetadata{
+					Attributes: &persistencespb.ChasmNodeMetadata_ComponentAttributes{
+						ComponentAttributes: &persistencespb.ChasmComponentAttributes{TypeId: 1234},
+					},
+				},
+				Data: &commonpb.DataBlob{
+					EncodingType: enumspb.ENCODING_TYPE_PROTO3,
+					Data:         []byte{byte(version)},
+				},
+			},
+		},
+	}
+}
+
 func (s *historyArchiverSuite) newTestHistoryArchiver(historyIterator archiver.HistoryIterator) *historyArchiver {
 	config := &config.FilestoreArchiver{
 		FileMode: testFileModeStr,
@@ -622,6 +796,15 @@ func (s *historyArchiverSuite) assertFileExists(filepath string) {
 	s.True(exists)
 }
 
+// cloneHistoryBatches clones the batches shared between tests, as archiving them caches their size.
+func cloneHistoryBatches(historyBatches []*historypb.History) []*historypb.History {
+	clones := make([]*historypb.History, 0, len(historyBatches))
+	for _, batch := range historyBatches {
+		clones = append(clones, common.CloneProto(batch))
+	}
+	return clones
+}
+
 func getCanceledContext() context.Context {
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel()
@@ -0,0 +1,60 @@
+package filestore
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"go.temporal.io/server/common/archiver/parquet"
+)
+
+type (
+	// parquetStorage is the parquet.Storage of the Parquet visibility archives, whose keys are file paths.
+	parquetStorage struct {
+		fileMode os.FileMode
+		dirMode  os.FileMode
+	}
+)
+
+var _ parquet.Storage = (*parquetStorage)(nil)
+
+func newParquetStorage(fileMode os.FileMode, dirMode os.FileMode) *parquetStorage {
+	return &parquetStorage{
+		fileMode: fileMode,
+		dirMode:  dirMode,
+	}
+}
+
+func (s *parquetStorage) Put(_ context.Context, key string, data []byte) error {
+	if err := mkdirAll(filepath.Dir(key), s.dirMode); err != nil {
+		return err
+	}
+	// The file is written under a temporary name and renamed, so that the readers never see a partial file.
+	tmpPath := key + ".tmp"
+	if err := writeFile(tmpPath, data, s.fileMode); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, key)
+}
+
+func (s *parquetStorage) Get(_ context.Context, key string) ([]byte, error) {
+	return readFile(key)
+}
+
+func (s *parquetStorage) Delete(_ context.Context, key string) error {
+	if err := os.Remove(key); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	// The staging directories of the compacted buckets are removed, so that they are not listed anymore. The removal
+	// fails if the directory is not empty.
+	_ = os.Remove(filepath.Dir(key))
+	return nil
+}
+
+func (s *parquetStorage) List(_ context.Context, dir string) ([]string, error) {
+	exists, err := directoryExists(dir)
+	if err != nil || !exists {
+		return nil, err
+	}
+	return listFiles(dir)
+}
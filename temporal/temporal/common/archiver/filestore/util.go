@@ -162,7 +162,17 @@ func deserializeQueryVisibilityToken(bytes []byte) (*queryVisibilityToken, error
 
 func constructHistoryFilename(namespaceID, workflowID, runID string, version int64) string {
 	combinedHash := constructHistoryFilenamePrefix(namespaceID, workflowID, runID)
-	return fmt.Sprintf("%s_%v.history", combinedHash, version)
+	return fmt.Sprintf("%s_%v%s", combinedHash, version, historyFileExtension)
+}
+
+func constructHistoryManifestFilename(namespaceID, workflowID, runID string, version int64) string {
+	combinedHash := constructHistoryFilenamePrefix(namespaceID, workflowID, runID)
+	return fmt.Sprintf("%s_%v%s", combinedHash, version, historyManifestFileExtension)
+}
+
+func constructChasmExecutionFilename(namespaceID, businessID, runID string, version int64) string {
+	combinedHash := constructHistoryFilenamePrefix(namespaceID, businessID, runID)
+	return fmt.Sprintf("%s_%v%s", combinedHash, version, chasmExecutionFileExtension)
 }
 
 func constructHistoryFilenamePrefix(namespaceID, workflowID, runID string) string {
@@ -2,6 +2,7 @@ package filestore
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path"
@@ -15,6 +16,9 @@ import (
 	workflowpb "go.temporal.io/api/workflow/v1"
 	archiverspb "go.temporal.io/server/api/archiver/v1"
 	"go.temporal.io/server/common/archiver"
+	"go.temporal.io/server/common/archiver/parquet"
+	"go.temporal.io/server/common/archiver/visibilityquery"
+	"go.temporal.io/server/common/clock"
 	"go.temporal.io/server/common/config"
 	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/log/tag"
@@ -25,6 +29,10 @@ import (
 
 const (
 	errEncodeVisibilityRecord = "failed to encode visibility record"
+
+	// parquetDirName is the directory of the Parquet visibility archives under the URI path, which is separate from
+	// the namespace directories of the per-record archives.
+	parquetDirName = "parquet"
 )
 
 type (
@@ -33,7 +41,9 @@ type (
 		metricsHandler metrics.Handler
 		fileMode       os.FileMode
 		dirMode        os.FileMode
-		queryParser    QueryParser
+		// parquetArchive is nil unless the visibility records are archived in Parquet files.
+		parquetArchive *parquet.VisibilityArchive
+		parquetStorage parquet.Storage
 	}
 
 	queryVisibilityToken struct {
@@ -45,7 +55,7 @@ type (
 		namespaceID   string
 		pageSize      int
 		nextPageToken []byte
-		parsedQuery   *parsedQuery
+		evaluator     *visibilityquery.Evaluator
 	}
 )
 
@@ -63,13 +73,21 @@ func NewVisibilityArchiver(
 	if err != nil {
 		return nil, errInvalidDirMode
 	}
-	return &visibilityArchiver{
+	v := &visibilityArchiver{
 		logger:         logger,
 		metricsHandler: metricsHandler,
 		fileMode:       os.FileMode(fileMode),
 		dirMode:        os.FileMode(dirMode),
-		queryParser:    NewQueryParser(),
-	}, nil
+	}
+	switch config.VisibilityFormat {
+	case "", archiver.VisibilityFormatRecord:
+	case archiver.VisibilityFormatParquet:
+		v.parquetArchive = parquet.NewVisibilityArchive(config.VisibilityBucketInterval, clock.NewRealTimeSource())
+		v.parquetStorage = newParquetStorage(v.fileMode, v.dirMode)
+	default:
+		return nil, archiver.ErrUnknownVisibilityFormat
+	}
+	return v, nil
 }
 
 func (v *visibilityArchiver) Archive(
@@ -97,6 +115,15 @@ func (v *visibilityArchiver) Archive(
 		return err
 	}
 
+	if v.parquetArchive != nil {
+		dirPath := path.Join(URI.Path(), parquetDirName, request.GetNamespaceId())
+		if err := v.parquetArchive.Archive(ctx, v.parquetStorage, dirPath, request); err != nil {
+			logger.Error(archiver.ArchiveNonRetryableErrorMsg, tag.ArchivalArchiveFailReason(errWriteFile), tag.Error(err))
+			return err
+		}
+		return nil
+	}
+
 	dirPath := path.Join(URI.Path(), request.GetNamespaceId())
 	if err = mkdirAll(dirPath, v.dirMode); err != nil {
 		logger.Error(archiver.ArchiveNonRetryableErrorMsg, tag.ArchivalArchiveFailReason(errMakeDirectory), tag.Error(err))
@@ -134,13 +161,13 @@ func (v *visibilityArchiver) Query(
 		return nil, serviceerror.NewInvalidArgument(archiver.ErrInvalidQueryVisibilityRequest.Error())
 	}
 
-	parsedQuery, err := v.queryParser.Parse(request.Query)
+	evaluator, err := visibilityquery.NewEvaluator(request, saTypeMap)
 	if err != nil {
-		return nil, serviceerror.NewInvalidArgument(err.Error())
+		return nil, err
 	}
 
-	if parsedQuery.emptyResult {
-		return &archiver.QueryVisibilityResponse{}, nil
+	if v.parquetArchive != nil {
+		return v.queryParquet(ctx, URI, request, evaluator, saTypeMap)
 	}
 
 	return v.query(
@@ -150,7 +177,7 @@ func (v *visibilityArchiver) Query(
 			namespaceID:   request.NamespaceID,
 			pageSize:      request.PageSize,
 			nextPageToken: request.NextPageToken,
-			parsedQuery:   parsedQuery,
+			evaluator:     evaluator,
 		},
 		saTypeMap,
 	)
@@ -162,6 +189,10 @@ func (v *visibilityArchiver) query(
 	request *queryVisibilityRequest,
 	saTypeMap searchattribute.NameTypeMap,
 ) (*archiver.QueryVisibilityResponse, error) {
+	if request.evaluator.Ordered() {
+		return v.queryOrdered(ctx, URI, request, saTypeMap)
+	}
+
 	var token *queryVisibilityToken
 	if request.nextPageToken != nil {
 		var err error
@@ -171,45 +202,28 @@ func (v *visibilityArchiver) query(
 		}
 	}
 
-	dirPath := path.Join(URI.Path(), request.namespaceID)
-	exists, err := directoryExists(dirPath)
+	files, err := v.listVisibilityFiles(URI, request.namespaceID, token)
 	if err != nil {
-		return nil, serviceerror.NewInternal(err.Error())
-	}
-	if !exists {
-		return &archiver.QueryVisibilityResponse{}, nil
-	}
-
-	files, err := listFiles(dirPath)
-	if err != nil {
-		return nil, serviceerror.NewInternal(err.Error())
-	}
-
-	files, err = sortAndFilterFiles(files, token)
-	if err != nil {
-		return nil, serviceerror.NewInternal(err.Error())
-	}
-	if len(files) == 0 {
-		return &archiver.QueryVisibilityResponse{}, nil
+		return nil, err
 	}
 
 	response := &archiver.QueryVisibilityResponse{}
+	earliestCloseTime, latestCloseTime := request.evaluator.CloseTimeRange()
 	for idx, file := range files {
-		encodedRecord, err := readFile(path.Join(dirPath, file))
+		record, err := v.readVisibilityRecord(URI, request.namespaceID, file)
 		if err != nil {
-			return nil, serviceerror.NewInternal(err.Error())
+			return nil, err
 		}
 
-		record, err := decodeVisibilityRecord(encodedRecord)
-		if err != nil {
-			return nil, serviceerror.NewInternal(err.Error())
+		closeTime := record.CloseTime.AsTime()
+		if !latestCloseTime.IsZero() && closeTime.After(latestCloseTime) {
+			continue
 		}
-
-		if record.CloseTime.AsTime().Before(request.parsedQuery.earliestCloseTime) {
+		if closeTime.Before(earliestCloseTime) {
 			break
 		}
 
-		if matchQuery(record, request.parsedQuery) {
+		if request.evaluator.Match(record) {
 			executionInfo, err := convertToExecutionInfo(record, saTypeMap)
 			if err != nil {
 				return nil, serviceerror.NewInternal(err.Error())
@@ -236,6 +250,168 @@ func (v *visibilityArchiver) query(
 	return response, nil
 }
 
+// queryOrdered queries the records of a query with an ORDER BY clause, which are all read to be sorted. It returns an
+// InvalidArgument error if more than visibilityquery.MaxOrderedRecords records match the query.
+func (v *visibilityArchiver) queryOrdered(
+	_ context.Context,
+	URI archiver.URI,
+	request *queryVisibilityRequest,
+	saTypeMap searchattribute.NameTypeMap,
+) (*archiver.QueryVisibilityResponse, error) {
+	files, err := v.listVisibilityFiles(URI, request.namespaceID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []*archiverspb.VisibilityRecord
+	earliestCloseTime, latestCloseTime := request.evaluator.CloseTimeRange()
+	for _, file := range files {
+		record, err := v.readVisibilityRecord(URI, request.namespaceID, file)
+		if err != nil {
+			return nil, err
+		}
+		closeTime := record.CloseTime.AsTime()
+		if !latestCloseTime.IsZero() && closeTime.After(latestCloseTime) {
+			continue
+		}
+		if closeTime.Before(earliestCloseTime) {
+			break
+		}
+		if request.evaluator.Match(record) {
+			records = append(records, record)
+			if err := visibilityquery.CheckOrderedRecords(len(records)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	records, nextPageToken, err := request.evaluator.SortPage(records, request.pageSize, request.nextPageToken)
+	if err != nil {
+		return nil, err
+	}
+	response := &archiver.QueryVisibilityResponse{NextPageToken: nextPageToken}
+	for _, record := range records {
+		executionInfo, err := convertToExecutionInfo(record, saTypeMap)
+		if err != nil {
+			return nil, serviceerror.NewInternal(err.Error())
+		}
+		response.Executions = append(response.Executions, executionInfo)
+	}
+	return response, nil
+}
+
+// listVisibilityFiles returns the visibility files of the namespace by descending close time, which are after the
+// token if it is not nil.
+func (v *visibilityArchiver) listVisibilityFiles(
+	URI archiver.URI,
+	namespaceID string,
+	token *queryVisibilityToken,
+) ([]string, error) {
+	dirPath := path.Join(URI.Path(), namespaceID)
+	exists, err := directoryExists(dirPath)
+	if err != nil {
+		return nil, serviceerror.NewInternal(err.Error())
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	files, err := listFiles(dirPath)
+	if err != nil {
+		return nil, serviceerror.NewInternal(err.Error())
+	}
+
+	files, err = sortAndFilterFiles(files, token)
+	if err != nil {
+		return nil, serviceerror.NewInternal(err.Error())
+	}
+	return files, nil
+}
+
+func (v *visibilityArchiver) readVisibilityRecord(
+	URI archiver.URI,
+	namespaceID string,
+	file string,
+) (*archiverspb.VisibilityRecord, error) {
+	encodedRecord, err := readFile(path.Join(URI.Path(), namespaceID, file))
+	if err != nil {
+		return nil, serviceerror.NewInternal(err.Error())
+	}
+
+	record, err := decodeVisibilityRecord(encodedRecord)
+	if err != nil {
+		return nil, serviceerror.NewInternal(err.Error())
+	}
+	return record, nil
+}
+
+func (v *visibilityArchiver) queryParquet(
+	ctx context.Context,
+	URI archiver.URI,
+	request *archiver.QueryVisibilityRequest,
+	evaluator *visibilityquery.Evaluator,
+	saTypeMap searchattribute.NameTypeMap,
+) (*archiver.QueryVisibilityResponse, error) {
+	dirPath := path.Join(URI.Path(), parquetDirName, request.NamespaceID)
+	parquetRequest := &parquet.QueryRequest{
+		PageSize:      request.PageSize,
+		NextPageToken: request.NextPageToken,
+		Filter:        evaluator.Match,
+	}
+	parquetRequest.EarliestCloseTime, parquetRequest.LatestCloseTime = evaluator.CloseTimeRange()
+	if evaluator.Ordered() {
+		// all the matching records are read to be sorted
+		parquetRequest.PageSize = 0
+		parquetRequest.NextPageToken = nil
+		parquetRequest.MaxRecords = visibilityquery.MaxOrderedRecords
+	}
+	parquetResponse, err := v.parquetArchive.Query(ctx, v.parquetStorage, dirPath, parquetRequest)
+	if err != nil {
+		if errors.Is(err, archiver.ErrNextPageTokenCorrupted) {
+			return nil, serviceerror.NewInvalidArgument(err.Error())
+		}
+		if errors.Is(err, parquet.ErrTooManyRecords) {
+			return nil, visibilityquery.ErrTooManyOrderedRecords
+		}
+		return nil, serviceerror.NewInternal(err.Error())
+	}
+
+	records, nextPageToken := parquetResponse.Records, parquetResponse.NextPageToken
+	if evaluator.Ordered() {
+		records, nextPageToken, err = evaluator.SortPage(records, request.PageSize, request.NextPageToken)
+		if err != nil {
+			return nil, err
+		}
+	}
+	response := &archiver.QueryVisibilityResponse{NextPageToken: nextPageToken}
+	for _, record := range records {
+		executionInfo, err := convertToExecutionInfo(record, saTypeMap)
+		if err != nil {
+			return nil, serviceerror.NewInternal(err.Error())
+		}
+		response.Executions = append(response.Executions, executionInfo)
+	}
+	return response, nil
+}
+
+// CompactVisibility compacts the staged records of the sealed buckets of the Parquet archive of the namespace. It does
+// nothing if the archiver doesn't use the Parquet format.
+func (v *visibilityArchiver) CompactVisibility(
+	ctx context.Context,
+	URI archiver.URI,
+	namespaceID string,
+	saTypeMap searchattribute.NameTypeMap,
+) error {
+	if v.parquetArchive == nil {
+		return nil
+	}
+	if err := v.ValidateURI(URI); err != nil {
+		return err
+	}
+	dirPath := path.Join(URI.Path(), parquetDirName, namespaceID)
+	return v.parquetArchive.Compact(ctx, v.parquetStorage, dirPath, &saTypeMap)
+}
+
 func (v *visibilityArchiver) ValidateURI(URI archiver.URI) error {
 	if URI.Scheme() != URIScheme {
 		return archiver.ErrURISchemeMismatch
@@ -302,26 +478,6 @@ func sortAndFilterFiles(filenames []string, token *queryVisibilityToken) ([]stri
 	return filteredFilenames, nil
 }
 
-func matchQuery(record *archiverspb.VisibilityRecord, query *parsedQuery) bool {
-	closeTime := record.CloseTime.AsTime()
-	if closeTime.Before(query.earliestCloseTime) || closeTime.After(query.latestCloseTime) {
-		return false
-	}
-	if query.workflowID != nil && record.GetWorkflowId() != *query.workflowID {
-		return false
-	}
-	if query.runID != nil && record.GetRunId() != *query.runID {
-		return false
-	}
-	if query.workflowTypeName != nil && record.WorkflowTypeName != *query.workflowTypeName {
-		return false
-	}
-	if query.status != nil && record.Status != *query.status {
-		return false
-	}
-	return true
-}
-
 func convertToExecutionInfo(record *archiverspb.VisibilityRecord, saTypeMap searchattribute.NameTypeMap) (*workflowpb.WorkflowExecutionInfo, error) {
 	searchAttributes, err := searchattribute.Parse(record.SearchAttributes, &saTypeMap)
 	if err != nil {
@@ -3,8 +3,10 @@ package filestore
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
 	"path"
+	"strconv"
 	"testing"
 	"time"
 
@@ -23,7 +25,7 @@ import (
 	"go.temporal.io/server/common/payload"
 	"go.temporal.io/server/common/primitives/timestamp"
 	"go.temporal.io/server/common/searchattribute"
-	"go.temporal.io/server/common/util"
+	"go.temporal.io/server/common/testing/protorequire"
 	"go.temporal.io/server/tests/testutils"
 	"go.uber.org/mock/gomock"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -185,90 +187,6 @@ func (s *visibilityArchiverSuite) TestArchive_Success() {
 	s.Equal(request, archivedRecord)
 }
 
-func (s *visibilityArchiverSuite) TestMatchQuery() {
-	testCases := []struct {
-		query       *parsedQuery
-		record      *archiverspb.VisibilityRecord
-		shouldMatch bool
-	}{
-		{
-			query: &parsedQuery{
-				earliestCloseTime: time.Unix(0, 1000),
-				latestCloseTime:   time.Unix(0, 12345),
-			},
-			record: &archiverspb.VisibilityRecord{
-				CloseTime: timestamp.UnixOrZeroTimePtr(1999),
-			},
-			shouldMatch: true,
-		},
-		{
-			query: &parsedQuery{
-				earliestCloseTime: time.Unix(0, 1000),
-				latestCloseTime:   time.Unix(0, 12345),
-			},
-			record: &archiverspb.VisibilityRecord{
-				CloseTime: timestamp.UnixOrZeroTimePtr(999),
-			},
-			shouldMatch: false,
-		},
-		{
-			query: &parsedQuery{
-				earliestCloseTime: time.Unix(0, 1000),
-				latestCloseTime:   time.Unix(0, 12345),
-				workflowID:        util.Ptr("random workflowID"),
-			},
-			record: &archiverspb.VisibilityRecord{
-				CloseTime: timestamp.UnixOrZeroTimePtr(2000),
-			},
-			shouldMatch: false,
-		},
-		{
-			query: &parsedQuery{
-				earliestCloseTime: time.Unix(0, 1000),
-				latestCloseTime:   time.Unix(0, 12345),
-				workflowID:        util.Ptr("random workflowID"),
-				runID:             util.Ptr("random runID"),
-			},
-			record: &archiverspb.VisibilityRecord{
-				CloseTime:        timestamp.UnixOrZeroTimePtr(12345),
-				WorkflowId:       "random workflowID",
-				RunId:            "random runID",
-				WorkflowTypeName: "random type name",
-			},
-			shouldMatch: true,
-		},
-		{
-			query: &parsedQuery{
-				earliestCloseTime: time.Unix(0, 1000),
-				latestCloseTime:   time.Unix(0, 12345),
-				workflowTypeName:  util.Ptr("some random type name"),
-			},
-			record: &archiverspb.VisibilityRecord{
-				CloseTime: timestamp.UnixOrZeroTimePtr(12345),
-			},
-			shouldMatch: false,
-		},
-		{
-			query: &parsedQuery{
-				earliestCloseTime: time.Unix(0, 1000),
-				latestCloseTime:   time.Unix(0, 12345),
-				workflowTypeName:  util.Ptr("some random type name"),
-				status:            toWorkflowExecutionStatusPtr(enumspb.WORKFLOW_EXECUTION_STATUS_CONTINUED_AS_NEW),
-			},
-			record: &archiverspb.VisibilityRecord{
-				CloseTime:        timestamp.UnixOrZeroTimePtr(12345),
-				Status:           enumspb.WORKFLOW_EXECUTION_STATUS_CONTINUED_AS_NEW,
-				WorkflowTypeName: "some random type name",
-			},
-			shouldMatch: true,
-		},
-	}
-
-	for _, tc := range testCases {
-		s.Equal(tc.shouldMatch, matchQuery(tc.record, tc.query))
-	}
-}
-
 func (s *visibilityArchiverSuite) TestSortAndFilterFiles() {
 	testCases := []struct {
 		filenames      []string
@@ -331,29 +249,21 @@ func (s *visibilityArchiverSuite) TestQuery_Fail_InvalidRequest() {
 
 func (s *visibilityArchiverSuite) TestQuery_Fail_InvalidQuery() {
 	visibilityArchiver := s.newTestVisibilityArchiver()
-	mockParser := NewMockQueryParser(s.controller)
-	mockParser.EXPECT().Parse(gomock.Any()).Return(nil, errors.New("invalid query"))
-	visibilityArchiver.queryParser = mockParser
 	response, err := visibilityArchiver.Query(context.Background(), s.testArchivalURI, &archiver.QueryVisibilityRequest{
 		NamespaceID: "some random namespaceID",
 		PageSize:    10,
 		Query:       "some invalid query",
 	}, searchattribute.TestNameTypeMap())
-	s.Error(err)
+	var invalidArgument *serviceerror.InvalidArgument
+	s.ErrorAs(err, &invalidArgument)
 	s.Nil(response)
 }
 
 func (s *visibilityArchiverSuite) TestQuery_Success_DirectoryNotExist() {
 	visibilityArchiver := s.newTestVisibilityArchiver()
-	mockParser := NewMockQueryParser(s.controller)
-	mockParser.EXPECT().Parse(gomock.Any()).Return(&parsedQuery{
-		earliestCloseTime: time.Unix(0, 1),
-		latestCloseTime:   time.Unix(0, 101),
-	}, nil)
-	visibilityArchiver.queryParser = mockParser
 	request := &archiver.QueryVisibilityRequest{
 		NamespaceID: testNamespaceID,
-		Query:       "parsed by mockParser",
+		Query:       "CloseTime <= '1970-01-01T00:00:00.000000101Z'",
 		PageSize:    1,
 	}
 	response, err := visibilityArchiver.Query(context.Background(), s.testArchivalURI, request, searchattribute.TestNameTypeMap())
@@ -365,15 +275,8 @@ func (s *visibilityArchiverSuite) TestQuery_Success_DirectoryNotExist() {
 
 func (s *visibilityArchiverSuite) TestQuery_Fail_InvalidToken() {
 	visibilityArchiver := s.newTestVisibilityArchiver()
-	mockParser := NewMockQueryParser(s.controller)
-	mockParser.EXPECT().Parse(gomock.Any()).Return(&parsedQuery{
-		earliestCloseTime: time.Unix(0, 1),
-		latestCloseTime:   time.Unix(0, 101),
-	}, nil)
-	visibilityArchiver.queryParser = mockParser
 	request := &archiver.QueryVisibilityRequest{
 		NamespaceID:   testNamespaceID,
-		Query:         "parsed by mockParser",
 		PageSize:      1,
 		NextPageToken: []byte{1, 2, 3},
 	}
@@ -384,17 +287,10 @@ func (s *visibilityArchiverSuite) TestQuery_Fail_InvalidToken() {
 
 func (s *visibilityArchiverSuite) TestQuery_Success_NoNextPageToken() {
 	visibilityArchiver := s.newTestVisibilityArchiver()
-	mockParser := NewMockQueryParser(s.controller)
-	mockParser.EXPECT().Parse(gomock.Any()).Return(&parsedQuery{
-		earliestCloseTime: time.Unix(0, 1),
-		latestCloseTime:   time.Unix(0, 10001),
-		workflowID:        util.Ptr(testWorkflowID),
-	}, nil)
-	visibilityArchiver.queryParser = mockParser
 	request := &archiver.QueryVisibilityRequest{
 		NamespaceID: testNamespaceID,
 		PageSize:    10,
-		Query:       "parsed by mockParser",
+		Query:       fmt.Sprintf("WorkflowId = '%s'", testWorkflowID),
 	}
 	URI, err := archiver.NewURI("file://" + s.testQueryDirectory)
 	s.NoError(err)
@@ -410,17 +306,10 @@ func (s *visibilityArchiverSuite) TestQuery_Success_NoNextPageToken() {
 
 func (s *visibilityArchiverSuite) TestQuery_Success_SmallPageSize() {
 	visibilityArchiver := s.newTestVisibilityArchiver()
-	mockParser := NewMockQueryParser(s.controller)
-	mockParser.EXPECT().Parse(gomock.Any()).Return(&parsedQuery{
-		earliestCloseTime: time.Unix(0, 1),
-		latestCloseTime:   time.Unix(0, 10001),
-		status:            toWorkflowExecutionStatusPtr(enumspb.WORKFLOW_EXECUTION_STATUS_FAILED),
-	}, nil).AnyTimes()
-	visibilityArchiver.queryParser = mockParser
 	request := &archiver.QueryVisibilityRequest{
 		NamespaceID: testNamespaceID,
 		PageSize:    2,
-		Query:       "parsed by mockParser",
+		Query:       "ExecutionStatus = 'Failed'",
 	}
 	URI, err := archiver.NewURI("file://" + s.testQueryDirectory)
 	s.NoError(err)
@@ -447,17 +336,59 @@ func (s *visibilityArchiverSuite) TestQuery_Success_SmallPageSize() {
 	s.Equal(ei, response.Executions[0])
 }
 
+func (s *visibilityArchiverSuite) TestQuery_Success_LiveVisibilityGrammar() {
+	visibilityArchiver := s.newTestVisibilityArchiver()
+	URI, err := archiver.NewURI("file://" + s.testQueryDirectory)
+	s.NoError(err)
+
+	testCases := []struct {
+		query    string
+		expected []int
+	}{
+		{
+			query:    "WorkflowId IN ('some random workflow ID', 'another workflow ID') OR HistoryLength = 101",
+			expected: []int{0, 1, 2},
+		},
+		{
+			query:    "WorkflowType = 'test-workflow-type' AND NOT (ExecutionStatus = 'Failed')",
+			expected: []int{2},
+		},
+		{
+			query:    "StartTime > '1970-01-01T00:00:00.000000001Z' AND CloseTime <= '1970-01-01T00:00:00.000001Z'",
+			expected: []int{1, 2, 3},
+		},
+		{
+			query:    "ExecutionStatus = 'Failed' ORDER BY StartTime ASC",
+			expected: []int{0, 1, 3},
+		},
+	}
+	for _, tc := range testCases {
+		request := &archiver.QueryVisibilityRequest{
+			NamespaceID: testNamespaceID,
+			PageSize:    2,
+			Query:       tc.query,
+		}
+		var executions []*workflowpb.WorkflowExecutionInfo
+		for len(executions) == 0 || request.NextPageToken != nil {
+			response, err := visibilityArchiver.Query(context.Background(), URI, request, searchattribute.TestNameTypeMap())
+			s.NoError(err, tc.query)
+			s.LessOrEqual(len(response.Executions), 2)
+			executions = append(executions, response.Executions...)
+			request.NextPageToken = response.NextPageToken
+		}
+		s.Len(executions, len(tc.expected), tc.query)
+		for i, idx := range tc.expected {
+			ei, err := convertToExecutionInfo(s.visibilityRecords[idx], searchattribute.TestNameTypeMap())
+			s.NoError(err)
+			s.Equal(ei, executions[i], tc.query)
+		}
+	}
+}
+
 func (s *visibilityArchiverSuite) TestArchiveAndQuery() {
 	dir := testutils.MkdirTemp(s.T(), "", "TestArchiveAndQuery")
 
 	visibilityArchiver := s.newTestVisibilityArchiver()
-	mockParser := NewMockQueryParser(s.controller)
-	mockParser.EXPECT().Parse(gomock.Any()).Return(&parsedQuery{
-		earliestCloseTime: time.Unix(0, 10),
-		latestCloseTime:   time.Unix(0, 10001),
-		status:            toWorkflowExecutionStatusPtr(enumspb.WORKFLOW_EXECUTION_STATUS_FAILED),
-	}, nil).AnyTimes()
-	visibilityArchiver.queryParser = mockParser
 	URI, err := archiver.NewURI("file://" + dir)
 	s.NoError(err)
 	for _, record := range s.visibilityRecords {
@@ -468,7 +399,7 @@ func (s *visibilityArchiverSuite) TestArchiveAndQuery() {
 	request := &archiver.QueryVisibilityRequest{
 		NamespaceID: testNamespaceID,
 		PageSize:    1,
-		Query:       "parsed by mockParser",
+		Query:       "ExecutionStatus = 'Failed' AND CloseTime >= '1970-01-01T00:00:00.00000001Z'",
 	}
 	executions := []*workflowpb.WorkflowExecutionInfo{}
 	for len(executions) == 0 || request.NextPageToken != nil {
@@ -487,17 +418,90 @@ func (s *visibilityArchiverSuite) TestArchiveAndQuery() {
 	s.Equal(ei, executions[1])
 }
 
+func (s *visibilityArchiverSuite) TestNewVisibilityArchiver_UnknownFormat() {
+	_, err := NewVisibilityArchiver(s.logger, s.metricsHandler, &config.FilestoreArchiver{
+		FileMode:         testFileModeStr,
+		DirMode:          testDirModeStr,
+		VisibilityFormat: "csv",
+	})
+	s.ErrorIs(err, archiver.ErrUnknownVisibilityFormat)
+}
+
+func (s *visibilityArchiverSuite) TestArchiveAndQuery_Parquet() {
+	dir := testutils.MkdirTemp(s.T(), "", "TestArchiveAndQueryParquet")
+
+	a, err := NewVisibilityArchiver(s.logger, s.metricsHandler, &config.FilestoreArchiver{
+		FileMode:         testFileModeStr,
+		DirMode:          testDirModeStr,
+		VisibilityFormat: archiver.VisibilityFormatParquet,
+	})
+	s.NoError(err)
+	visibilityArchiver := a.(*visibilityArchiver)
+	URI, err := archiver.NewURI("file://" + dir)
+	s.NoError(err)
+
+	saTypeMap := searchattribute.TestNameTypeMap()
+	closeTime := time.Date(2024, 5, 6, 7, 8, 9, 123456000, time.UTC)
+	var records []*archiverspb.VisibilityRecord
+	for i, status := range []enumspb.WorkflowExecutionStatus{
+		enumspb.WORKFLOW_EXECUTION_STATUS_FAILED,
+		enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED,
+		enumspb.WORKFLOW_EXECUTION_STATUS_FAILED,
+		enumspb.WORKFLOW_EXECUTION_STATUS_FAILED,
+	} {
+		record := &archiverspb.VisibilityRecord{
+			NamespaceId:      testNamespaceID,
+			Namespace:        testNamespace,
+			WorkflowId:       fmt.Sprintf("workflow-id-%d", i),
+			RunId:            fmt.Sprintf("run-id-%d", i),
+			WorkflowTypeName: testWorkflowTypeName,
+			StartTime:        timestamppb.New(closeTime.Add(-time.Hour)),
+			CloseTime:        timestamppb.New(closeTime.Add(time.Duration(i) * time.Hour)),
+			Status:           status,
+			HistoryLength:    int64(i),
+			SearchAttributes: map[string]string{"Int01": strconv.Itoa(i)},
+		}
+		records = append(records, record)
+		err := visibilityArchiver.Archive(context.Background(), URI, record, archiver.GetSearchAttributeTypesOption(saTypeMap))
+		s.NoError(err)
+	}
+	// The records are staged until the sealed buckets are compacted.
+	batchesDir := path.Join(dir, parquetDirName, testNamespaceID, "batches")
+	_, err = os.Stat(batchesDir)
+	s.True(os.IsNotExist(err))
+	s.NoError(visibilityArchiver.CompactVisibility(context.Background(), URI, testNamespaceID, saTypeMap))
+	batches, err := listFiles(batchesDir)
+	s.NoError(err)
+	s.Len(batches, len(records))
+
+	request := &archiver.QueryVisibilityRequest{
+		NamespaceID: testNamespaceID,
+		PageSize:    1,
+		Query: fmt.Sprintf(
+			"ExecutionStatus = 'Failed' AND CloseTime BETWEEN '%s' AND '%s'",
+			closeTime.Format(time.RFC3339Nano),
+			closeTime.Add(2*time.Hour).Format(time.RFC3339Nano),
+		),
+	}
+	var executions []*workflowpb.WorkflowExecutionInfo
+	for len(executions) == 0 || request.NextPageToken != nil {
+		response, err := visibilityArchiver.Query(context.Background(), URI, request, saTypeMap)
+		s.NoError(err)
+		executions = append(executions, response.Executions...)
+		request.NextPageToken = response.NextPageToken
+	}
+	s.Len(executions, 2)
+	for i, record := range []*archiverspb.VisibilityRecord{records[2], records[0]} {
+		ei, err := convertToExecutionInfo(record, saTypeMap)
+		s.NoError(err)
+		protorequire.ProtoEqual(s.T(), ei, executions[i])
+	}
+}
+
 func (s *visibilityArchiverSuite) TestQuery_EmptyQuery_InvalidNamespace() {
 	URI := s.testArchivalURI
 
 	visibilityArchiver := s.newTestVisibilityArchiver()
-	mockParser := NewMockQueryParser(s.controller)
-	mockParser.EXPECT().Parse(gomock.Any()).Return(&parsedQuery{
-		earliestCloseTime: time.Unix(0, 10),
-		latestCloseTime:   time.Unix(0, 10001),
-		status:            toWorkflowExecutionStatusPtr(enumspb.WORKFLOW_EXECUTION_STATUS_FAILED),
-	}, nil).AnyTimes()
-	visibilityArchiver.queryParser = mockParser
 	req := &archiver.QueryVisibilityRequest{
 		NamespaceID:   "",
 		PageSize:      1,
@@ -7,8 +7,10 @@ import (
 	"path/filepath"
 	"time"
 
+	"cloud.google.com/go/storage"
 	historypb "go.temporal.io/api/history/v1"
 	"go.temporal.io/api/serviceerror"
+	archiverspb "go.temporal.io/server/api/archiver/v1"
 	"go.temporal.io/server/common"
 	"go.temporal.io/server/common/archiver"
 	"go.temporal.io/server/common/archiver/gcloud/connector"
@@ -32,6 +34,13 @@ const (
 	errEncodeHistory      = "failed to encode history batches"
 	errBucketHistory      = "failed to get google storage bucket handle"
 	errWriteFile          = "failed to write history to google storage"
+	errEncodeManifest     = "failed to encode history manifest"
+	errWriteManifest      = "failed to write history manifest to google storage"
+
+	errEncodeChasmExecution = "failed to encode CHASM execution"
+	errWriteChasmExecution  = "failed to write CHASM execution to google storage"
+
+	chasmExecutionFileExtension = ".chasm"
 )
 
 type historyArchiver struct {
@@ -47,6 +56,8 @@ type historyArchiver struct {
 type progress struct {
 	CurrentPageNumber int
 	IteratorState     []byte
+	// ManifestBatches are the manifest batches of the history parts which are already uploaded.
+	ManifestBatches []*archiverspb.HistoryManifestBatch
 }
 
 type getHistoryToken struct {
@@ -162,6 +173,12 @@ func (h *historyArchiver) Archive(ctx context.Context, URI archiver.URI, request
 			return errUploadNonRetryable
 		}
 
+		manifestBatches, err := archiver.NewHistoryManifestBatches(historyBlob.Body)
+		if err != nil {
+			logger.Error(archiver.ArchiveNonRetryableErrorMsg, tag.ArchivalArchiveFailReason(errEncodeManifest), tag.Error(err))
+			return errUploadNonRetryable
+		}
+
 		filename := constructHistoryFilenameMultipart(request.NamespaceID, request.WorkflowID, request.RunID, request.CloseFailoverVersion, part)
 		if exist, _ := h.gcloudStorage.Exist(ctx, URI, filename); !exist {
 			if err := h.gcloudStorage.Upload(ctx, URI, filename, encodedHistoryPart); err != nil {
@@ -173,11 +190,24 @@ func (h *historyArchiver) Archive(ctx context.Context, URI archiver.URI, request
 			totalUploadSize = totalUploadSize + int64(binary.Size(encodedHistoryPart))
 		}
 
+		progress.ManifestBatches = append(progress.ManifestBatches, manifestBatches...)
 		if err := saveHistoryIteratorState(ctx, featureCatalog, historyIterator, part, &progress); err != nil {
 			return err
 		}
 	}
 
+	encodedManifest, err := encode(archiver.NewHistoryManifest(request, progress.ManifestBatches))
+	if err != nil {
+		logger.Error(archiver.ArchiveNonRetryableErrorMsg, tag.ArchivalArchiveFailReason(errEncodeManifest), tag.Error(err))
+		return errUploadNonRetryable
+	}
+	manifestFilename := constructHistoryManifestFilename(request.NamespaceID, request.WorkflowID, request.RunID, request.CloseFailoverVersion)
+	if err := h.gcloudStorage.Upload(ctx, URI, manifestFilename, encodedManifest); err != nil {
+		logger.Error(archiver.ArchiveTransientErrorMsg, tag.ArchivalArchiveFailReason(errWriteManifest), tag.Error(err))
+		metrics.HistoryArchiverArchiveTransientErrorCount.With(handler).Record(1)
+		return err
+	}
+
 	metrics.HistoryArchiverTotalUploadSize.With(handler).Record(totalUploadSize)
 	metrics.HistoryArchiverHistorySize.With(handler).Record(totalUploadSize)
 	metrics.HistoryArchiverArchiveSuccessCount.With(handler).Record(1)
@@ -231,6 +261,9 @@ outer:
 		filename := constructHistoryFilenameMultipart(request.NamespaceID, request.WorkflowID, request.RunID, token.CloseFailoverVersion, token.CurrentPart)
 		encodedHistoryBatches, err := h.gcloudStorage.Get(ctx, URI, filename)
 		if err != nil {
+			if errors.Is(err, storage.ErrObjectNotExist) {
+				return nil, serviceerror.NewNotFound(archiver.ErrHistoryNotExist.Error())
+			}
 			return nil, serviceerror.NewUnavailable(err.Error())
 		}
 		if encodedHistoryBatches == nil {
@@ -276,6 +309,126 @@ outer:
 	return response, nil
 }
 
+// GetManifest is used to access the manifest of an archived history, which is written once all its parts are uploaded.
+func (h *historyArchiver) GetManifest(ctx context.Context, URI archiver.URI, request *archiver.GetHistoryRequest) (*archiverspb.HistoryManifest, error) {
+	if err := h.ValidateURI(URI); err != nil {
+		return nil, serviceerror.NewInvalidArgument(archiver.ErrInvalidURI.Error())
+	}
+
+	if err := archiver.ValidateGetRequest(request); err != nil {
+		return nil, serviceerror.NewInvalidArgument(archiver.ErrInvalidGetHistoryRequest.Error())
+	}
+
+	version := request.CloseFailoverVersion
+	if version == nil {
+		highestVersion, _, _, err := h.getHighestVersion(ctx, URI, request)
+		if err != nil {
+			return nil, serviceerror.NewUnavailable(err.Error())
+		}
+		if highestVersion == nil {
+			return nil, serviceerror.NewNotFound(archiver.ErrHistoryNotExist.Error())
+		}
+		version = highestVersion
+	}
+
+	filename := constructHistoryManifestFilename(request.NamespaceID, request.WorkflowID, request.RunID, *version)
+	encodedManifest, err := h.gcloudStorage.Get(ctx, URI, filename)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			return nil, serviceerror.NewNotFound(archiver.ErrManifestNotExist.Error())
+		}
+		return nil, serviceerror.NewUnavailable(err.Error())
+	}
+
+	manifest := &archiverspb.HistoryManifest{}
+	if err := codec.NewJSONPBEncoder().Decode(encodedManifest, manifest); err != nil {
+		return nil, serviceerror.NewInternal(err.Error())
+	}
+	return manifest, nil
+}
+
+// ArchiveChasmExecution is used to archive the component tree of a closed CHASM execution, which is written in a file
+// with the .chasm extension next to the archived histories.
+func (h *historyArchiver) ArchiveChasmExecution(ctx context.Context, URI archiver.URI, request *archiverspb.ChasmExecution, opts ...archiver.ArchiveOption) (err error) {
+	featureCatalog := archiver.GetFeatureCatalog(opts...)
+	defer func() {
+		if err != nil && err.Error() == errUploadNonRetryable.Error() && featureCatalog.NonRetryableError != nil {
+			err = featureCatalog.NonRetryableError()
+		}
+	}()
+
+	logger := archiver.TagLoggerWithArchiveChasmExecutionRequestAndURI(h.logger, request, URI.String())
+
+	if err := h.ValidateURI(URI); err != nil {
+		logger.Error(archiver.ArchiveNonRetryableErrorMsg, tag.ArchivalArchiveFailReason(archiver.ErrReasonInvalidURI), tag.Error(err))
+		return errUploadNonRetryable
+	}
+
+	if err := archiver.ValidateChasmExecutionArchivalRequest(request); err != nil {
+		logger.Error(archiver.ArchiveNonRetryableErrorMsg, tag.ArchivalArchiveFailReason(archiver.ErrReasonInvalidArchiveRequest), tag.Error(err))
+		return errUploadNonRetryable
+	}
+
+	encodedExecution, err := encode(request)
+	if err != nil {
+		logger.Error(archiver.ArchiveNonRetryableErrorMsg, tag.ArchivalArchiveFailReason(errEncodeChasmExecution), tag.Error(err))
+		return errUploadNonRetryable
+	}
+
+	filename := constructChasmExecutionFilename(request.GetNamespaceId(), request.GetBusinessId(), request.GetRunId(), request.GetCloseFailoverVersion())
+	if err := h.gcloudStorage.Upload(ctx, URI, filename, encodedExecution); err != nil {
+		logger.Error(archiver.ArchiveTransientErrorMsg, tag.ArchivalArchiveFailReason(errWriteChasmExecution), tag.Error(err))
+		return err
+	}
+	return nil
+}
+
+// GetChasmExecution is used to access the component tree of an archived CHASM execution.
+func (h *historyArchiver) GetChasmExecution(ctx context.Context, URI archiver.URI, request *archiver.GetChasmExecutionRequest) (*archiverspb.ChasmExecution, error) {
+	if err := h.ValidateURI(URI); err != nil {
+		return nil, serviceerror.NewInvalidArgument(archiver.ErrInvalidURI.Error())
+	}
+
+	if err := archiver.ValidateGetChasmExecutionRequest(request); err != nil {
+		return nil, serviceerror.NewInvalidArgument(archiver.ErrInvalidGetChasmExecutionRequest.Error())
+	}
+
+	version := request.CloseFailoverVersion
+	if version == nil {
+		filenames, err := h.gcloudStorage.Query(ctx, URI, constructHistoryFilenamePrefix(request.NamespaceID, request.BusinessID, request.RunID))
+		if err != nil {
+			return nil, serviceerror.NewUnavailable(err.Error())
+		}
+		for _, filename := range filenames {
+			fileVersion, err := extractChasmExecutionCloseFailoverVersion(filepath.Base(filename))
+			if err != nil {
+				continue
+			}
+			if version == nil || fileVersion > *version {
+				version = &fileVersion
+			}
+		}
+		if version == nil {
+			return nil, serviceerror.NewNotFound(archiver.ErrChasmExecutionNotExist.Error())
+		}
+	}
+
+	filename := constructChasmExecutionFilename(request.NamespaceID, request.BusinessID, request.RunID, *version)
+	encodedExecution, err := h.gcloudStorage.Get(ctx, URI, filename)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			return nil, serviceerror.NewNotFound(archiver.ErrChasmExecutionNotExist.Error())
+		}
+		return nil, serviceerror.NewUnavailable(err.Error())
+	}
+
+	execution := &archiverspb.ChasmExecution{}
+	if err := codec.NewJSONPBEncoder().Decode(encodedExecution, execution); err != nil {
+		return nil, serviceerror.NewInternal(err.Error())
+	}
+	return execution, nil
+}
+
 // ValidateURI is used to define what a valid URI for an implementation is.
 func (h *historyArchiver) ValidateURI(URI archiver.URI) (err error) {
 
@@ -6,20 +6,25 @@ import (
 	"testing"
 	"time"
 
+	"cloud.google.com/go/storage"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	commonpb "go.temporal.io/api/common/v1"
 	enumspb "go.temporal.io/api/enums/v1"
 	historypb "go.temporal.io/api/history/v1"
 	"go.temporal.io/api/serviceerror"
 	archiverspb "go.temporal.io/server/api/archiver/v1"
+	persistencespb "go.temporal.io/server/api/persistence/v1"
 	"go.temporal.io/server/common"
 	"go.temporal.io/server/common/archiver"
 	"go.temporal.io/server/common/archiver/gcloud/connector"
+	"go.temporal.io/server/common/codec"
 	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/metrics"
 	"go.temporal.io/server/common/persistence"
 	"go.temporal.io/server/common/util"
 	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -339,7 +344,13 @@ func (h *historyArchiverSuite) TestArchive_Success() {
 
 	storageWrapper := connector.NewMockClient(h.controller)
 	storageWrapper.EXPECT().Exist(ctx, h.testArchivalURI, gomock.Any()).Return(false, nil).Times(2)
-	storageWrapper.EXPECT().Upload(ctx, h.testArchivalURI, gomock.Any(), gomock.Any()).Return(nil)
+	storageWrapper.EXPECT().Upload(ctx, h.testArchivalURI, constructHistoryFilenameMultipart(testNamespaceID, testWorkflowID, testRunID, testCloseFailoverVersion, 0), gomock.Any()).Return(nil)
+	var encodedManifest []byte
+	storageWrapper.EXPECT().Upload(ctx, h.testArchivalURI, constructHistoryManifestFilename(testNamespaceID, testWorkflowID, testRunID, testCloseFailoverVersion), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ archiver.URI, _ string, data []byte) error {
+			encodedManifest = data
+			return nil
+		})
 
 	historyIterator := archiver.NewMockHistoryIterator(h.controller)
 	historyBatches := []*historypb.History{
@@ -393,6 +404,13 @@ func (h *historyArchiverSuite) TestArchive_Success() {
 
 	err := historyArchiver.Archive(ctx, h.testArchivalURI, request)
 	h.NoError(err)
+
+	manifest := &archiverspb.HistoryManifest{}
+	h.NoError(codec.NewJSONPBEncoder().Decode(encodedManifest, manifest))
+	h.Equal(int64(testCloseFailoverVersion), manifest.GetCloseFailoverVersion())
+	h.Len(manifest.GetBatches(), 2)
+	h.Equal(common.FirstEventID+1, manifest.GetBatches()[0].GetFirstEventId())
+	h.Equal(int64(testNextEventID-1), manifest.GetBatches()[1].GetLastEventId())
 }
 
 func (h *historyArchiverSuite) TestGet_Fail_InvalidURI() {
@@ -587,3 +605,155 @@ func (h *historyArchiverSuite) TestGet_NoHistory() {
 	_, err := historyArchiver.Get(ctx, h.testArchivalURI, request)
 	h.Assert().IsType(&serviceerror.NotFound{}, err)
 }
+
+func (h *historyArchiverSuite) TestGetManifest_Success() {
+	ctx := context.Background()
+	manifest := &archiverspb.HistoryManifest{
+		NamespaceId:          testNamespaceID,
+		WorkflowId:           testWorkflowID,
+		RunId:                testRunID,
+		CloseFailoverVersion: -24,
+		Batches: []*archiverspb.HistoryManifestBatch{
+			{FirstEventId: 1, LastEventId: 1, Sha256: "hash"},
+		},
+	}
+	encodedManifest, err := encode(manifest)
+	h.NoError(err)
+
+	storageWrapper := connector.NewMockClient(h.controller)
+	storageWrapper.EXPECT().Exist(ctx, h.testArchivalURI, "").Return(true, nil)
+	storageWrapper.EXPECT().Query(ctx, h.testArchivalURI, gomock.Any()).Return([]string{"905702227796330300141628222723188294514017512010591354159_-24_0.history", "905702227796330300141628222723188294514017512010591354159_-24.manifest"}, nil)
+	storageWrapper.EXPECT().Get(ctx, h.testArchivalURI, "141323698701063509081739672280485489488911532452831150339470_-24.manifest").Return(encodedManifest, nil)
+	historyArchiver := newHistoryArchiver(h.executionManager, h.logger, h.metricsHandler, nil, storageWrapper).(*historyArchiver)
+	request := &archiver.GetHistoryRequest{
+		NamespaceID: testNamespaceID,
+		WorkflowID:  testWorkflowID,
+		RunID:       testRunID,
+		PageSize:    testPageSize,
+	}
+
+	response, err := historyArchiver.GetManifest(ctx, h.testArchivalURI, request)
+	h.NoError(err)
+	h.Equal(int64(-24), response.GetCloseFailoverVersion())
+	h.Len(response.GetBatches(), 1)
+}
+
+func (h *historyArchiverSuite) TestGetManifest_ManifestNotExist() {
+	ctx := context.Background()
+	storageWrapper := connector.NewMockClient(h.controller)
+	storageWrapper.EXPECT().Exist(ctx, h.testArchivalURI, "").Return(true, nil)
+	storageWrapper.EXPECT().Get(ctx, h.testArchivalURI, "141323698701063509081739672280485489488911532452831150339470_-24.manifest").Return(nil, storage.ErrObjectNotExist)
+	historyArchiver := newHistoryArchiver(h.executionManager, h.logger, h.metricsHandler, nil, storageWrapper).(*historyArchiver)
+	request := &archiver.GetHistoryRequest{
+		NamespaceID:          testNamespaceID,
+		WorkflowID:           testWorkflowID,
+		RunID:                testRunID,
+		PageSize:             testPageSize,
+		CloseFailoverVersion: util.Ptr(int64(-24)),
+	}
+
+	_, err := historyArchiver.GetManifest(ctx, h.testArchivalURI, request)
+	h.IsType(&serviceerror.NotFound{}, err)
+}
+
+func (h *historyArchiverSuite) TestArchiveChasmExecution_Fail_InvalidRequest() {
+	ctx := context.Background()
+	storageWrapper := connector.NewMockClient(h.controller)
+	storageWrapper.EXPECT().Exist(ctx, h.testArchivalURI, "").Return(true, nil)
+	historyArchiver := newHistoryArchiver(h.executionManager, h.logger, h.metricsHandler, nil, storageWrapper).(*historyArchiver)
+
+	execution := newTestChasmExecution(testCloseFailoverVersion)
+	execution.Nodes = nil
+	err := historyArchiver.ArchiveChasmExecution(ctx, h.testArchivalURI, execution)
+	h.ErrorIs(err, errUploadNonRetryable)
+}
+
+func (h *historyArchiverSuite) TestArchiveChasmExecution_Success() {
+	ctx := context.Background()
+	execution := newTestChasmExecution(testCloseFailoverVersion)
+	storageWrapper := connector.NewMockClient(h.controller)
+	storageWrapper.EXPECT().Exist(ctx, h.testArchivalURI, "").Return(true, nil)
+	storageWrapper.EXPECT().Upload(ctx, h.testArchivalURI, constructChasmExecutionFilename(testNamespaceID, testWorkflowID, testRunID, testCloseFailoverVersion), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ archiver.URI, _ string, data []byte) error {
+			uploaded := &archiverspb.ChasmExecution{}
+			h.NoError(codec.NewJSONPBEncoder().Decode(data, uploaded))
+			h.True(proto.Equal(execution, uploaded))
+			return nil
+		})
+	historyArchiver := newHistoryArchiver(h.executionManager, h.logger, h.metricsHandler, nil, storageWrapper).(*historyArchiver)
+
+	h.NoError(historyArchiver.ArchiveChasmExecution(ctx, h.testArchivalURI, execution))
+}
+
+func (h *historyArchiverSuite) TestGetChasmExecution_PickHighestVersion() {
+	ctx := context.Background()
+	encodedExecution, err := encode(newTestChasmExecution(testCloseFailoverVersion))
+	h.NoError(err)
+
+	storageWrapper := connector.NewMockClient(h.controller)
+	storageWrapper.EXPECT().Exist(ctx, h.testArchivalURI, "").Return(true, nil)
+	storageWrapper.EXPECT().Query(ctx, h.testArchivalURI, constructHistoryFilenamePrefix(testNamespaceID, testWorkflowID, testRunID)).Return([]string{
+		constructChasmExecutionFilename(testNamespaceID, testWorkflowID, testRunID, 1),
+		constructChasmExecutionFilename(testNamespaceID, testWorkflowID, testRunID, testCloseFailoverVersion),
+		constructHistoryFilenameMultipart(testNamespaceID, testWorkflowID, testRunID, testCloseFailoverVersion+1, 0),
+	}, nil)
+	storageWrapper.EXPECT().Get(ctx, h.testArchivalURI, constructChasmExecutionFilename(testNamespaceID, testWorkflowID, testRunID, testCloseFailoverVersion)).Return(encodedExecution, nil)
+	historyArchiver := newHistoryArchiver(h.executionManager, h.logger, h.metricsHandler, nil, storageWrapper).(*historyArchiver)
+
+	execution, err := historyArchiver.GetChasmExecution(ctx, h.testArchivalURI, &archiver.GetChasmExecutionRequest{
+		NamespaceID: testNamespaceID,
+		BusinessID:  testWorkflowID,
+		RunID:       testRunID,
+	})
+	h.NoError(err)
+	h.Equal(int64(testCloseFailoverVersion), execution.GetCloseFailoverVersion())
+}
+
+func (h *historyArchiverSuite) TestGetChasmExecution_NotExist() {
+	ctx := context.Background()
+	storageWrapper := connector.NewMockClient(h.controller)
+	storageWrapper.EXPECT().Exist(ctx, h.testArchivalURI, "").Return(true, nil).Times(2)
+	storageWrapper.EXPECT().Query(ctx, h.testArchivalURI, gomock.Any()).Return([]string{
+		constructHistoryManifestFilename(testNamespaceID, testWorkflowID, testRunID, testCloseFailoverVersion),
+	}, nil)
+	storageWrapper.EXPECT().Get(ctx, h.testArchivalURI, constructChasmExecutionFilename(testNamespaceID, testWorkflowID, testRunID, 1)).Return(nil, storage.ErrObjectNotExist)
+	historyArchiver := newHistoryArchiver(h.executionManager, h.logger, h.metricsHandler, nil, storageWrapper).(*historyArchiver)
+	request := &archiver.GetChasmExecutionRequest{
+		NamespaceID: testNamespaceID,
+		BusinessID:  testWorkflowID,
+		RunID:       testRunID,
+	}
+
+	_, err := historyArchiver.GetChasmExecution(ctx, h.testArchivalURI, request)
+	h.IsType(&serviceerror.NotFound{}, err)
+
+	request.CloseFailoverVersion = util.Ptr(int64(1))
+	_, err = historyArchiver.GetChasmExecution(ctx, h.testArchivalURI, request)
+	h.IsType(&serviceerror.NotFound{}, err)
+}
+
+func newTestChasmExecution(version int64) *archiverspb.ChasmExecution {
+	return &archiverspb.ChasmExecution{
+		NamespaceId:          testNamespaceID,
+		Namespace:            testNamespace,
+		BusinessId:           testWorkflowID,
+		RunId:                testRunID,
+		ArchetypeId:          1234,
+		Archetype:            "scheduler.scheduler",
+		CloseFailoverVersion: version,
+		CloseTime:            timestamppb.New(time.Date(2020, 8, 22, 1, 2, 3, 4, time.UTC)),
+		Nodes: map[string]*persistencespb.ChasmNode{
+			"": {
+				Metadata: &persistencespb.ChasmNodeMetadata{
+					Attributes: &persistencespb.ChasmNodeMetadata_ComponentAttributes{
+						ComponentAttributes: &persistencespb.ChasmComponentAttributes{TypeId: 1234},
+					},
+				},
+				Data: &commonpb.DataBlob{
+					EncodingType: enumspb.ENCODING_TYPE_PROTO3,
+					Data:         []byte{byte(version)},
+				},
+			},
+		},
+	}
+}
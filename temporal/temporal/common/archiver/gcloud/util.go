@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -29,6 +30,16 @@ func constructHistoryFilenameMultipart(namespaceID, workflowID, runID string, ve
 	return fmt.Sprintf("%s_%v_%v.history", combinedHash, version, partNumber)
 }
 
+func constructHistoryManifestFilename(namespaceID, workflowID, runID string, version int64) string {
+	combinedHash := constructHistoryFilenamePrefix(namespaceID, workflowID, runID)
+	return fmt.Sprintf("%s_%v.manifest", combinedHash, version)
+}
+
+func constructChasmExecutionFilename(namespaceID, businessID, runID string, version int64) string {
+	combinedHash := constructHistoryFilenamePrefix(namespaceID, businessID, runID)
+	return fmt.Sprintf("%s_%v%s", combinedHash, version, chasmExecutionFileExtension)
+}
+
 func constructHistoryFilenamePrefix(namespaceID, workflowID, runID string) string {
 	return strings.Join([]string{hash(namespaceID), hash(workflowID), hash(runID)}, "")
 }
@@ -37,23 +48,20 @@ func constructVisibilityFilenamePrefix(namespaceID, tag string) string {
 	return fmt.Sprintf("%s/%s", namespaceID, tag)
 }
 
-func constructTimeBasedSearchKey(namespaceID, tag string, t time.Time, precision string) string {
-	var timeFormat = ""
-	switch precision {
-	case PrecisionSecond:
-		timeFormat = ":05"
-		fallthrough
-	case PrecisionMinute:
-		timeFormat = ":04" + timeFormat
-		fallthrough
-	case PrecisionHour:
-		timeFormat = "15" + timeFormat
-		fallthrough
-	case PrecisionDay:
-		timeFormat = "2006-01-02T" + timeFormat
+// constructCloseTimeSearchPrefix returns the prefix of the filenames of the closeTimeout index whose close time is in
+// the range, which are zero if the range is unbounded.
+func constructCloseTimeSearchPrefix(namespaceID string, earliest, latest time.Time) string {
+	prefix := constructVisibilityFilenamePrefix(namespaceID, indexKeyCloseTimeout)
+	if earliest.IsZero() || latest.IsZero() {
+		return prefix
 	}
-
-	return fmt.Sprintf("%s_%s", constructVisibilityFilenamePrefix(namespaceID, tag), t.Format(timeFormat))
+	from := earliest.UTC().Format(time.RFC3339)
+	to := latest.UTC().Format(time.RFC3339)
+	i := 0
+	for i < len(from) && i < len(to) && from[i] == to[i] {
+		i++
+	}
+	return fmt.Sprintf("%s_%s", prefix, from[:i])
 }
 
 func hash(s string) (result string) {
@@ -86,6 +94,19 @@ func extractCloseFailoverVersion(filename string) (int64, int, error) {
 	return failoverVersion, highestPart, err
 }
 
+// extractChasmExecutionCloseFailoverVersion returns the close failover version of a CHASM execution filename.
+func extractChasmExecutionCloseFailoverVersion(filename string) (int64, error) {
+	name, ok := strings.CutSuffix(filename, chasmExecutionFileExtension)
+	if !ok {
+		return -1, errors.New("unknown filename structure")
+	}
+	filenameParts := strings.Split(name, "_")
+	if len(filenameParts) != 2 {
+		return -1, errors.New("unknown filename structure")
+	}
+	return strconv.ParseInt(filenameParts[1], 10, 64)
+}
+
 func serializeToken(token any) ([]byte, error) {
 	if token == nil {
 		return nil, nil
@@ -211,6 +232,27 @@ func newWorkflowTypeNamePrecondition(workflowTypeName string) connector.Precondi
 	}
 }
 
+// newCloseTimePrecondition returns the precondition of the filenames of the closeTimeout index whose close time is in
+// the range, which are zero if the range is unbounded. The close times of the filenames are truncated to the second.
+func newCloseTimePrecondition(earliest, latest time.Time) connector.Precondition {
+	return func(subject any) bool {
+		fileName, ok := subject.(string)
+		if !ok {
+			return false
+		}
+
+		fileNameParts := strings.SplitN(filepath.Base(fileName), "_", 5)
+		if len(fileNameParts) != 5 {
+			return true
+		}
+		closeTime, err := time.Parse(time.RFC3339, fileNameParts[1])
+		if err != nil {
+			return true
+		}
+		return (latest.IsZero() || !closeTime.After(latest)) && closeTime.Add(time.Second).After(earliest)
+	}
+}
+
 func isRetryableError(err error) (retryable bool) {
 	switch err.Error() {
 	case connector.ErrBucketNotFound.Error(),
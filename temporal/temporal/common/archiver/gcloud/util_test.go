@@ -107,13 +107,49 @@ func (s *utilSuite) TestConstructHistoryFilenameMultipart() {
 	s.Equal("67753999582745295208344541402884576509131521284625246243_-24_0.history", constructHistoryFilenameMultipart("namespaceID", "workflowID", "runID", -24, 0))
 }
 
+func (s *utilSuite) TestConstructChasmExecutionFilename() {
+	s.Equal("67753999582745295208344541402884576509131521284625246243_-24.chasm", constructChasmExecutionFilename("namespaceID", "workflowID", "runID", -24))
+}
+
+func (s *utilSuite) TestExtractChasmExecutionCloseFailoverVersion() {
+	version, err := extractChasmExecutionCloseFailoverVersion("67753999582745295208344541402884576509131521284625246243_-24.chasm")
+	s.NoError(err)
+	s.Equal(int64(-24), version)
+
+	_, err = extractChasmExecutionCloseFailoverVersion("67753999582745295208344541402884576509131521284625246243_-24.manifest")
+	s.Error(err)
+	_, err = extractChasmExecutionCloseFailoverVersion("67753999582745295208344541402884576509131521284625246243_-24_0.history")
+	s.Error(err)
+}
+
 func (s *utilSuite) TestConstructVisibilityFilenamePrefix() {
 	s.Equal("namespaceID/startTimeout", constructVisibilityFilenamePrefix("namespaceID", indexKeyStartTimeout))
 }
 
-func (s *utilSuite) TestConstructTimeBasedSearchKey() {
-	t, _ := time.Parse(time.RFC3339, "2019-10-04T11:00:00+00:00")
-	s.Equal("namespaceID/startTimeout_2019-10-04T", constructTimeBasedSearchKey("namespaceID", indexKeyStartTimeout, t, "Day"))
+func (s *utilSuite) TestConstructCloseTimeSearchPrefix() {
+	earliest, _ := time.Parse(time.RFC3339, "2019-10-04T00:00:00+00:00")
+	latest, _ := time.Parse(time.RFC3339, "2019-10-04T23:59:59+00:00")
+	s.Equal("namespaceID/closeTimeout_2019-10-04T", constructCloseTimeSearchPrefix("namespaceID", earliest, latest))
+	s.Equal("namespaceID/closeTimeout_2019-10-04T11:00:00Z", constructCloseTimeSearchPrefix("namespaceID", earliest.Add(11*time.Hour), earliest.Add(11*time.Hour)))
+	s.Equal("namespaceID/closeTimeout", constructCloseTimeSearchPrefix("namespaceID", earliest, time.Time{}))
+}
+
+func (s *utilSuite) TestCloseTimePrecondition() {
+	fileName := constructVisibilityFilename("namespaceID", "workflowTypeName", "workflowID", "runID", indexKeyCloseTimeout, time.Date(2019, 10, 4, 11, 0, 0, 0, time.UTC))
+	testCases := []struct {
+		earliest time.Time
+		latest   time.Time
+		expected bool
+	}{
+		{expected: true},
+		{earliest: time.Date(2019, 10, 4, 11, 0, 0, int(500*time.Millisecond), time.UTC), expected: true},
+		{earliest: time.Date(2019, 10, 4, 11, 0, 1, 0, time.UTC), expected: false},
+		{latest: time.Date(2019, 10, 4, 11, 0, 0, 0, time.UTC), expected: true},
+		{latest: time.Date(2019, 10, 4, 10, 59, 59, 0, time.UTC), expected: false},
+	}
+	for _, tc := range testCases {
+		s.Equal(tc.expected, newCloseTimePrecondition(tc.earliest, tc.latest)(fileName), "%v %v", tc.earliest, tc.latest)
+	}
 }
 
 func (s *utilSuite) TestConstructVisibilityFilename() {
@@ -5,13 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
-	"strings"
 	"time"
 
 	"go.temporal.io/api/serviceerror"
 	archiverspb "go.temporal.io/server/api/archiver/v1"
 	"go.temporal.io/server/common/archiver"
 	"go.temporal.io/server/common/archiver/gcloud/connector"
+	"go.temporal.io/server/common/archiver/visibilityquery"
 	"go.temporal.io/server/common/config"
 	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/log/tag"
@@ -35,7 +35,6 @@ type (
 		logger         log.Logger
 		metricsHandler metrics.Handler
 		gcloudStorage  connector.Client
-		queryParser    QueryParser
 	}
 
 	queryVisibilityToken struct {
@@ -46,7 +45,7 @@ type (
 		namespaceID   string
 		pageSize      int
 		nextPageToken []byte
-		parsedQuery   *parsedQuery
+		evaluator     *visibilityquery.Evaluator
 	}
 )
 
@@ -55,7 +54,6 @@ func newVisibilityArchiver(logger log.Logger, metricsHandler metrics.Handler, st
 		logger:         logger,
 		metricsHandler: metricsHandler,
 		gcloudStorage:  storage,
-		queryParser:    NewQueryParser(),
 	}
 }
 
@@ -148,17 +146,9 @@ func (v *visibilityArchiver) Query(
 		return nil, &serviceerror.InvalidArgument{Message: archiver.ErrInvalidQueryVisibilityRequest.Error()}
 	}
 
-	if strings.TrimSpace(request.Query) == "" {
-		return v.queryAll(ctx, URI, request, saTypeMap)
-	}
-
-	parsedQuery, err := v.queryParser.Parse(request.Query)
+	evaluator, err := visibilityquery.NewEvaluator(request, saTypeMap)
 	if err != nil {
-		return nil, &serviceerror.InvalidArgument{Message: err.Error()}
-	}
-
-	if parsedQuery.emptyResult {
-		return &archiver.QueryVisibilityResponse{}, nil
+		return nil, err
 	}
 
 	return v.query(
@@ -168,110 +158,129 @@ func (v *visibilityArchiver) Query(
 			namespaceID:   request.NamespaceID,
 			pageSize:      request.PageSize,
 			nextPageToken: request.NextPageToken,
-			parsedQuery:   parsedQuery,
+			evaluator:     evaluator,
 		},
 		saTypeMap,
 	)
 }
 
+// query returns the workflow executions which match the query, in the order of the filenames of the closeTimeout
+// index unless the query has an ORDER BY clause.
 func (v *visibilityArchiver) query(
 	ctx context.Context,
 	uri archiver.URI,
 	request *queryVisibilityRequest,
 	saTypeMap searchattribute.NameTypeMap,
 ) (*archiver.QueryVisibilityResponse, error) {
-	prefix := constructVisibilityFilenamePrefix(request.namespaceID, indexKeyCloseTimeout)
-	if !request.parsedQuery.closeTime.IsZero() {
-		prefix = constructTimeBasedSearchKey(
-			request.namespaceID,
-			indexKeyCloseTimeout,
-			request.parsedQuery.closeTime,
-			*request.parsedQuery.searchPrecision,
-		)
+	earliestCloseTime, latestCloseTime := request.evaluator.CloseTimeRange()
+	prefix := constructCloseTimeSearchPrefix(request.namespaceID, earliestCloseTime, latestCloseTime)
+	filters := []connector.Precondition{newCloseTimePrecondition(earliestCloseTime, latestCloseTime)}
+	if workflowID, ok := request.evaluator.WorkflowID(); ok {
+		filters = append(filters, newWorkflowIDPrecondition(hash(workflowID)))
 	}
-
-	if !request.parsedQuery.startTime.IsZero() {
-		prefix = constructTimeBasedSearchKey(
-			request.namespaceID,
-			indexKeyStartTimeout,
-			request.parsedQuery.startTime,
-			*request.parsedQuery.searchPrecision,
-		)
+	if runID, ok := request.evaluator.RunID(); ok {
+		filters = append(filters, newRunIDPrecondition(hash(runID)))
 	}
-
-	return v.queryPrefix(ctx, uri, request, saTypeMap, prefix)
-}
-
-func (v *visibilityArchiver) queryAll(
-	ctx context.Context,
-	URI archiver.URI,
-	request *archiver.QueryVisibilityRequest,
-	saTypeMap searchattribute.NameTypeMap,
-) (*archiver.QueryVisibilityResponse, error) {
-
-	return v.queryPrefix(ctx, URI, &queryVisibilityRequest{
-		namespaceID:   request.NamespaceID,
-		pageSize:      request.PageSize,
-		nextPageToken: request.NextPageToken,
-		parsedQuery:   &parsedQuery{},
-	}, saTypeMap, request.NamespaceID)
-}
-
-func (v *visibilityArchiver) queryPrefix(ctx context.Context, uri archiver.URI, request *queryVisibilityRequest, saTypeMap searchattribute.NameTypeMap, prefix string) (*archiver.QueryVisibilityResponse, error) {
-	token, err := v.parseToken(request.nextPageToken)
-	if err != nil {
-		return nil, err
-	}
-
-	filters := make([]connector.Precondition, 0)
-	if request.parsedQuery.workflowID != nil {
-		filters = append(filters, newWorkflowIDPrecondition(hash(*request.parsedQuery.workflowID)))
+	if workflowTypeName, ok := request.evaluator.WorkflowTypeName(); ok {
+		filters = append(filters, newWorkflowTypeNamePrecondition(hash(workflowTypeName)))
 	}
 
-	if request.parsedQuery.runID != nil {
-		filters = append(filters, newWorkflowIDPrecondition(hash(*request.parsedQuery.runID)))
+	var records []*archiverspb.VisibilityRecord
+	var nextPageToken []byte
+	if request.evaluator.Ordered() {
+		// All the matching records are read to be sorted.
+		var err error
+		records, _, err = v.queryPrefix(ctx, uri, request.namespaceID, prefix, filters, 0, 0, request.evaluator)
+		if err != nil {
+			return nil, err
+		}
+		records, nextPageToken, err = request.evaluator.SortPage(records, request.pageSize, request.nextPageToken)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		token, err := v.parseToken(request.nextPageToken)
+		if err != nil {
+			return nil, err
+		}
+		// We need to loop because the number of records which match the query may be fewer than the number of
+		// filenames, since the records are filtered after they are downloaded (client-side filtering).
+		remaining := request.pageSize
+		for {
+			pageRecords, nextToken, err := v.queryPrefix(ctx, uri, request.namespaceID, prefix, filters, remaining, token.Offset, request.evaluator)
+			if err != nil {
+				return nil, err
+			}
+			records = append(records, pageRecords...)
+			remaining -= len(pageRecords)
+			token = nextToken
+			if token == nil || remaining <= 0 {
+				break
+			}
+		}
+		if token != nil {
+			nextPageToken, err = serializeToken(token)
+			if err != nil {
+				return nil, &serviceerror.InvalidArgument{Message: err.Error()}
+			}
+		}
 	}
 
-	if request.parsedQuery.workflowType != nil {
-		filters = append(filters, newWorkflowIDPrecondition(hash(*request.parsedQuery.workflowType)))
+	response := &archiver.QueryVisibilityResponse{NextPageToken: nextPageToken}
+	for _, record := range records {
+		executionInfo, err := convertToExecutionInfo(record, saTypeMap)
+		if err != nil {
+			return nil, serviceerror.NewInternal(err.Error())
+		}
+		response.Executions = append(response.Executions, executionInfo)
 	}
+	return response, nil
+}
 
-	filenames, completed, currentCursorPos, err := v.gcloudStorage.QueryWithFilters(ctx, uri, prefix, request.pageSize, token.Offset, filters)
+// queryPrefix returns the records of at most pageSize filenames with the given prefix and preconditions, starting at
+// the offset, which match the query, and the token of the next filenames if there are more. A pageSize of 0 means all
+// the filenames. It returns an InvalidArgument error if the query is ordered and more than
+// visibilityquery.MaxOrderedRecords records match it.
+func (v *visibilityArchiver) queryPrefix(
+	ctx context.Context,
+	uri archiver.URI,
+	namespaceID string,
+	prefix string,
+	filters []connector.Precondition,
+	pageSize int,
+	offset int,
+	evaluator *visibilityquery.Evaluator,
+) ([]*archiverspb.VisibilityRecord, *queryVisibilityToken, error) {
+	filenames, completed, currentCursorPos, err := v.gcloudStorage.QueryWithFilters(ctx, uri, prefix, pageSize, offset, filters)
 	if err != nil {
-		return nil, &serviceerror.InvalidArgument{Message: err.Error()}
+		return nil, nil, &serviceerror.InvalidArgument{Message: err.Error()}
 	}
 
-	response := &archiver.QueryVisibilityResponse{}
+	var records []*archiverspb.VisibilityRecord
 	for _, file := range filenames {
-		encodedRecord, err := v.gcloudStorage.Get(ctx, uri, fmt.Sprintf("%s/%s", request.namespaceID, filepath.Base(file)))
+		encodedRecord, err := v.gcloudStorage.Get(ctx, uri, fmt.Sprintf("%s/%s", namespaceID, filepath.Base(file)))
 		if err != nil {
-			return nil, &serviceerror.InvalidArgument{Message: err.Error()}
+			return nil, nil, &serviceerror.InvalidArgument{Message: err.Error()}
 		}
 
 		record, err := decodeVisibilityRecord(encodedRecord)
 		if err != nil {
-			return nil, &serviceerror.InvalidArgument{Message: err.Error()}
+			return nil, nil, &serviceerror.InvalidArgument{Message: err.Error()}
 		}
-
-		executionInfo, err := convertToExecutionInfo(record, saTypeMap)
-		if err != nil {
-			return nil, serviceerror.NewInternal(err.Error())
+		if evaluator.Match(record) {
+			records = append(records, record)
+			if evaluator.Ordered() {
+				if err := visibilityquery.CheckOrderedRecords(len(records)); err != nil {
+					return nil, nil, err
+				}
+			}
 		}
-		response.Executions = append(response.Executions, executionInfo)
 	}
 
-	if !completed {
-		newToken := &queryVisibilityToken{
-			Offset: currentCursorPos,
-		}
-		encodedToken, err := serializeToken(newToken)
-		if err != nil {
-			return nil, &serviceerror.InvalidArgument{Message: err.Error()}
-		}
-		response.NextPageToken = encodedToken
+	if completed {
+		return records, nil, nil
 	}
-
-	return response, nil
+	return records, &queryVisibilityToken{Offset: currentCursorPos}, nil
 }
 
 func (v *visibilityArchiver) parseToken(nextPageToken []byte) (*queryVisibilityToken, error) {
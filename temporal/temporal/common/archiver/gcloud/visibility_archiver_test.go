@@ -2,7 +2,7 @@ package gcloud
 
 import (
 	"context"
-	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -19,7 +19,6 @@ import (
 	"go.temporal.io/server/common/primitives/timestamp"
 	"go.temporal.io/server/common/searchattribute"
 	"go.temporal.io/server/common/testing/protorequire"
-	"go.temporal.io/server/common/util"
 	"go.uber.org/mock/gomock"
 )
 
@@ -141,7 +140,7 @@ func (s *visibilityArchiverSuite) TestQuery_Fail_InvalidVisibilityURI() {
 	request := &archiver.QueryVisibilityRequest{
 		NamespaceID: testNamespaceID,
 		PageSize:    10,
-		Query:       "WorkflowType='type::example' AND CloseTime='2020-02-05T11:00:00Z' AND SearchPrecision='Day'",
+		Query:       "WorkflowType='type::example' AND CloseTime='2020-02-05T11:00:00Z'",
 	}
 
 	_, err = visibilityArchiver.Query(ctx, URI, request, searchattribute.TestNameTypeMap())
@@ -185,15 +184,13 @@ func (s *visibilityArchiverSuite) TestQuery_Fail_InvalidQuery() {
 	visibilityArchiver := newVisibilityArchiver(s.logger, s.metricsHandler, storageWrapper)
 	s.NoError(err)
 
-	mockParser := NewMockQueryParser(s.controller)
-	mockParser.EXPECT().Parse(gomock.Any()).Return(nil, errors.New("invalid query"))
-	visibilityArchiver.queryParser = mockParser
 	response, err := visibilityArchiver.Query(ctx, URI, &archiver.QueryVisibilityRequest{
 		NamespaceID: "some random namespaceID",
 		PageSize:    10,
 		Query:       "some invalid query",
 	}, searchattribute.TestNameTypeMap())
-	s.Error(err)
+	var invalidArgument *serviceerror.InvalidArgument
+	s.ErrorAs(err, &invalidArgument)
 	s.Nil(response)
 }
 
@@ -205,19 +202,9 @@ func (s *visibilityArchiverSuite) TestQuery_Fail_InvalidToken() {
 	visibilityArchiver := newVisibilityArchiver(s.logger, s.metricsHandler, storageWrapper)
 	s.NoError(err)
 
-	mockParser := NewMockQueryParser(s.controller)
-	startTime, _ := time.Parse(time.RFC3339, "2019-10-04T11:00:00+00:00")
-	closeTime := startTime.Add(time.Hour)
-	precision := PrecisionDay
-	mockParser.EXPECT().Parse(gomock.Any()).Return(&parsedQuery{
-		closeTime:       closeTime,
-		startTime:       startTime,
-		searchPrecision: &precision,
-	}, nil)
-	visibilityArchiver.queryParser = mockParser
 	request := &archiver.QueryVisibilityRequest{
 		NamespaceID:   testNamespaceID,
-		Query:         "parsed by mockParser",
+		Query:         "StartTime >= '2019-10-04T11:00:00Z' AND CloseTime <= '2019-10-04T12:00:00Z'",
 		PageSize:      1,
 		NextPageToken: []byte{1, 2, 3},
 	}
@@ -232,27 +219,20 @@ func (s *visibilityArchiverSuite) TestQuery_Success_NoNextPageToken() {
 	s.NoError(err)
 	storageWrapper := connector.NewMockClient(s.controller)
 	storageWrapper.EXPECT().Exist(gomock.Any(), URI, gomock.Any()).Return(false, nil)
-	storageWrapper.EXPECT().QueryWithFilters(gomock.Any(), URI, gomock.Any(), 10, 0, gomock.Any()).Return([]string{"closeTimeout_2020-02-05T09:56:14Z_test-workflow-id_MobileOnlyWorkflow::processMobileOnly_test-run-id.visibility"}, true, 1, nil)
+	storageWrapper.EXPECT().QueryWithFilters(gomock.Any(), URI, "test-namespace-id/closeTimeout_2020-02-05T", 10, 0, gomock.Any()).Return([]string{"closeTimeout_2020-02-05T09:56:14Z_test-workflow-id_MobileOnlyWorkflow::processMobileOnly_test-run-id.visibility"}, true, 1, nil)
 	storageWrapper.EXPECT().Get(gomock.Any(), URI, "test-namespace-id/closeTimeout_2020-02-05T09:56:14Z_test-workflow-id_MobileOnlyWorkflow::processMobileOnly_test-run-id.visibility").Return([]byte(exampleVisibilityRecord), nil)
 
 	visibilityArchiver := newVisibilityArchiver(s.logger, s.metricsHandler, storageWrapper)
 	s.NoError(err)
 
-	mockParser := NewMockQueryParser(s.controller)
-	dayPrecision := "Day"
-	closeTime, _ := time.Parse(time.RFC3339, "2019-10-04T11:00:00+00:00")
-	mockParser.EXPECT().Parse(gomock.Any()).Return(&parsedQuery{
-		closeTime:       closeTime,
-		searchPrecision: &dayPrecision,
-		workflowType:    util.Ptr("MobileOnlyWorkflow::processMobileOnly"),
-		workflowID:      util.Ptr(testWorkflowID),
-		runID:           util.Ptr(testRunID),
-	}, nil)
-	visibilityArchiver.queryParser = mockParser
 	request := &archiver.QueryVisibilityRequest{
 		NamespaceID: testNamespaceID,
 		PageSize:    10,
-		Query:       "parsed by mockParser",
+		Query: fmt.Sprintf(
+			"WorkflowId = '%s' AND RunId = '%s' AND CloseTime BETWEEN '2020-02-05T00:00:00Z' AND '2020-02-05T23:59:59Z'",
+			testWorkflowID,
+			testRunID,
+		),
 	}
 
 	response, err := visibilityArchiver.Query(ctx, URI, request, searchattribute.TestNameTypeMap())
@@ -281,21 +261,10 @@ func (s *visibilityArchiverSuite) TestQuery_Success_SmallPageSize() {
 	visibilityArchiver := newVisibilityArchiver(s.logger, s.metricsHandler, storageWrapper)
 	s.NoError(err)
 
-	mockParser := NewMockQueryParser(s.controller)
-	dayPrecision := "Day"
-	closeTime, _ := time.Parse(time.RFC3339, "2019-10-04T11:00:00+00:00")
-	mockParser.EXPECT().Parse(gomock.Any()).Return(&parsedQuery{
-		closeTime:       closeTime,
-		searchPrecision: &dayPrecision,
-		workflowType:    util.Ptr("MobileOnlyWorkflow::processMobileOnly"),
-		workflowID:      util.Ptr(testWorkflowID),
-		runID:           util.Ptr(testRunID),
-	}, nil).AnyTimes()
-	visibilityArchiver.queryParser = mockParser
 	request := &archiver.QueryVisibilityRequest{
 		NamespaceID: testNamespaceID,
 		PageSize:    pageSize,
-		Query:       "parsed by mockParser",
+		Query:       fmt.Sprintf("WorkflowType = '%s' AND CloseTime >= '2020-02-05T00:00:00Z'", testWorkflowTypeName),
 	}
 
 	response, err := visibilityArchiver.Query(ctx, URI, request, searchattribute.TestNameTypeMap())
@@ -439,3 +408,37 @@ func (s *visibilityArchiverSuite) TestQuery_EmptyQuery_Pagination() {
 	}
 	s.Len(executions, 2, "there should be exactly 2 unique executions")
 }
+
+func (s *visibilityArchiverSuite) TestQuery_Success_LiveVisibilityGrammar() {
+	URI, err := archiver.NewURI("gs://my-bucket-cad/temporal_archival/visibility")
+	s.NoError(err)
+	filenames := []string{
+		"closeTimeout_2020-02-05T09:56:15Z_test-workflow-id_MobileOnlyWorkflow::processMobileOnly_test-run-id.visibility",
+		"closeTimeout_2020-02-05T09:56:15Z_test-workflow-id2_MobileOnlyWorkflow::processMobileOnly_test-run-id.visibility",
+	}
+	storageWrapper := connector.NewMockClient(s.controller)
+	storageWrapper.EXPECT().Exist(gomock.Any(), URI, gomock.Any()).Return(true, nil).AnyTimes()
+	storageWrapper.EXPECT().QueryWithFilters(gomock.Any(), URI, gomock.Any(), gomock.Any(), 0, gomock.Any()).Return(filenames, true, 2, nil).AnyTimes()
+	storageWrapper.EXPECT().Get(gomock.Any(), URI, testNamespaceID+"/"+filenames[0]).Return([]byte(exampleVisibilityRecord), nil).AnyTimes()
+	storageWrapper.EXPECT().Get(gomock.Any(), URI, testNamespaceID+"/"+filenames[1]).Return([]byte(exampleVisibilityRecord2), nil).AnyTimes()
+	arc := newVisibilityArchiver(s.logger, s.metricsHandler, storageWrapper)
+
+	query := func(query string) []string {
+		response, err := arc.Query(context.Background(), URI, &archiver.QueryVisibilityRequest{
+			NamespaceID: testNamespaceID,
+			PageSize:    10,
+			Query:       query,
+		}, searchattribute.TestNameTypeMap())
+		s.NoError(err, query)
+		var workflowIDs []string
+		for _, execution := range response.Executions {
+			workflowIDs = append(workflowIDs, execution.GetExecution().GetWorkflowId())
+		}
+		return workflowIDs
+	}
+
+	s.Equal([]string{"test-workflow-id2"}, query("WorkflowId STARTS_WITH 'test-workflow-id2' OR HistoryLength > 100"))
+	s.Equal([]string{"test-workflow-id"}, query("NOT (WorkflowId = 'test-workflow-id2') AND ExecutionStatus = 'Completed'"))
+	s.Equal([]string{"test-workflow-id2", "test-workflow-id"}, query("CloseTime < '2020-02-06T00:00:00Z' ORDER BY WorkflowId DESC"))
+	s.Empty(query(fmt.Sprintf("CloseTime > '%s'", time.Date(2020, 2, 5, 9, 56, 16, 0, time.UTC).Format(time.RFC3339))))
+}
@@ -194,6 +194,7 @@ func (i *historyIterator) readHistoryBatches(
 
 func (i *historyIterator) readHistory(ctx context.Context, firstEventID int64) ([]*historypb.History, error) {
 	req := &persistence.ReadHistoryBranchRequest{
+		NamespaceID: i.request.NamespaceID,
 		BranchToken: i.request.BranchToken,
 		MinEventID:  firstEventID,
 		MaxEventID:  common.EndEventID,
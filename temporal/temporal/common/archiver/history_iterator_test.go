@@ -603,6 +603,7 @@ func (s *HistoryIteratorSuite) initMockExecutionManager(batchInfo []int, returnE
 			MaxEventID:  common.EndEventID,
 			PageSize:    testDefaultPersistencePageSize,
 			ShardID:     testShardId,
+			NamespaceID: testNamespaceID,
 		}
 		if returnErrorOnPage == i {
 			s.mockExecutionMgr.EXPECT().ReadHistoryBranchByBatch(gomock.Any(), req).Return(nil, errors.New("got error getting workflow execution history"))
@@ -622,6 +623,7 @@ func (s *HistoryIteratorSuite) initMockExecutionManager(batchInfo []int, returnE
 			MaxEventID:  common.EndEventID,
 			PageSize:    testDefaultPersistencePageSize,
 			ShardID:     testShardId,
+			NamespaceID: testNamespaceID,
 		}
 		s.mockExecutionMgr.EXPECT().ReadHistoryBranchByBatch(gomock.Any(), req).Return(nil, serviceerror.NewNotFound("Reach the end"))
 	}
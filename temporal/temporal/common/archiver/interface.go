@@ -58,12 +58,45 @@ type (
 		ValidateURI(uri URI) error
 	}
 
+	// HistoryManifestReader is implemented by the history archivers which write a HistoryManifest with each archived
+	// history, so that VerifyHistory can verify the integrity of the archived histories.
+	HistoryManifestReader interface {
+		// GetManifest returns the manifest of the archived history of the request. It returns the manifest of the
+		// CloseFailoverVersion of the request, or of the highest archived version if it is nil, and a NotFound error if
+		// the history or its manifest does not exist.
+		GetManifest(ctx context.Context, url URI, request *GetHistoryRequest) (*archiverspb.HistoryManifest, error)
+	}
+
+	// GetChasmExecutionRequest is the request to get an archived CHASM execution
+	GetChasmExecutionRequest struct {
+		NamespaceID          string
+		BusinessID           string
+		RunID                string
+		CloseFailoverVersion *int64
+	}
+
+	// ChasmExecutionArchiver is implemented by the history archivers which can archive the final component trees of
+	// CHASM executions, such as schedules and standalone activities, which have no workflow history.
+	ChasmExecutionArchiver interface {
+		// ArchiveChasmExecution archives the component tree of a closed CHASM execution. Check the Archive method of the
+		// HistoryArchiver interface for the meaning of the parameters. It may be invoked more than once for the same
+		// execution.
+		ArchiveChasmExecution(ctx context.Context, uri URI, request *archiverspb.ChasmExecution, opts ...ArchiveOption) error
+		// GetChasmExecution returns the archived CHASM execution of the CloseFailoverVersion of the request, or of the
+		// highest archived version if it is nil, and a NotFound error if the execution does not exist.
+		GetChasmExecution(ctx context.Context, uri URI, request *GetChasmExecutionRequest) (*archiverspb.ChasmExecution, error)
+	}
+
 	// QueryVisibilityRequest is the request to query archived visibility records
 	QueryVisibilityRequest struct {
 		NamespaceID   string
 		PageSize      int
 		NextPageToken []byte
 		Query         string
+		// Namespace and SearchAttributesMapper resolve the aliases of the custom search attributes of the query. The
+		// aliases are not resolved if SearchAttributesMapper is nil.
+		Namespace              string
+		SearchAttributesMapper searchattribute.Mapper
 	}
 
 	// QueryVisibilityResponse is the response of querying archived visibility records
@@ -92,4 +125,12 @@ type (
 		// ValidateURI is used to define what a valid URI for an implementation is.
 		ValidateURI(uri URI) error
 	}
+
+	// VisibilityCompactor is implemented by the visibility archivers which stage the archived records and batch them
+	// later, such as the Parquet format of the filestore and s3store archivers, so that Archive doesn't batch them.
+	VisibilityCompactor interface {
+		// CompactVisibility batches the staged records of the namespace which can't receive new records anymore. It is
+		// invoked periodically by the worker service, and may be invoked concurrently for the same namespace.
+		CompactVisibility(ctx context.Context, uri URI, namespaceID string, saTypeMap searchattribute.NameTypeMap) error
+	}
 )
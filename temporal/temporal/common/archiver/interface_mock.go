@@ -90,6 +90,103 @@ func (mr *MockHistoryArchiverMockRecorder) ValidateURI(uri any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateURI", reflect.TypeOf((*MockHistoryArchiver)(nil).ValidateURI), uri)
 }
 
+// MockHistoryManifestReader is a mock of HistoryManifestReader interface.
+type MockHistoryManifestReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockHistoryManifestReaderMockRecorder
+	isgomock struct{}
+}
+
+// MockHistoryManifestReaderMockRecorder is the mock recorder for MockHistoryManifestReader.
+type MockHistoryManifestReaderMockRecorder struct {
+	mock *MockHistoryManifestReader
+}
+
+// NewMockHistoryManifestReader creates a new mock instance.
+func NewMockHistoryManifestReader(ctrl *gomock.Controller) *MockHistoryManifestReader {
+	mock := &MockHistoryManifestReader{ctrl: ctrl}
+	mock.recorder = &MockHistoryManifestReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHistoryManifestReader) EXPECT() *MockHistoryManifestReaderMockRecorder {
+	return m.recorder
+}
+
+// GetManifest mocks base method.
+func (m *MockHistoryManifestReader) GetManifest(ctx context.Context, url URI, request *GetHistoryRequest) (*archiver.HistoryManifest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetManifest", ctx, url, request)
+	ret0, _ := ret[0].(*archiver.HistoryManifest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetManifest indicates an expected call of GetManifest.
+func (mr *MockHistoryManifestReaderMockRecorder) GetManifest(ctx, url, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManifest", reflect.TypeOf((*MockHistoryManifestReader)(nil).GetManifest), ctx, url, request)
+}
+
+// MockChasmExecutionArchiver is a mock of ChasmExecutionArchiver interface.
+type MockChasmExecutionArchiver struct {
+	ctrl     *gomock.Controller
+	recorder *MockChasmExecutionArchiverMockRecorder
+	isgomock struct{}
+}
+
+// MockChasmExecutionArchiverMockRecorder is the mock recorder for MockChasmExecutionArchiver.
+type MockChasmExecutionArchiverMockRecorder struct {
+	mock *MockChasmExecutionArchiver
+}
+
+// NewMockChasmExecutionArchiver creates a new mock instance.
+func NewMockChasmExecutionArchiver(ctrl *gomock.Controller) *MockChasmExecutionArchiver {
+	mock := &MockChasmExecutionArchiver{ctrl: ctrl}
+	mock.recorder = &MockChasmExecutionArchiverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChasmExecutionArchiver) EXPECT() *MockChasmExecutionArchiverMockRecorder {
+	return m.recorder
+}
+
+// ArchiveChasmExecution mocks base method.
+func (m *MockChasmExecutionArchiver) ArchiveChasmExecution(ctx context.Context, uri URI, request *archiver.ChasmExecution, opts ...ArchiveOption) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, uri, request}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ArchiveChasmExecution", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ArchiveChasmExecution indicates an expected call of ArchiveChasmExecution.
+func (mr *MockChasmExecutionArchiverMockRecorder) ArchiveChasmExecution(ctx, uri, request any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, uri, request}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveChasmExecution", reflect.TypeOf((*MockChasmExecutionArchiver)(nil).ArchiveChasmExecution), varargs...)
+}
+
+// GetChasmExecution mocks base method.
+func (m *MockChasmExecutionArchiver) GetChasmExecution(ctx context.Context, uri URI, request *GetChasmExecutionRequest) (*archiver.ChasmExecution, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChasmExecution", ctx, uri, request)
+	ret0, _ := ret[0].(*archiver.ChasmExecution)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChasmExecution indicates an expected call of GetChasmExecution.
+func (mr *MockChasmExecutionArchiverMockRecorder) GetChasmExecution(ctx, uri, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChasmExecution", reflect.TypeOf((*MockChasmExecutionArchiver)(nil).GetChasmExecution), ctx, uri, request)
+}
+
 // MockVisibilityArchiver is a mock of VisibilityArchiver interface.
 type MockVisibilityArchiver struct {
 	ctrl     *gomock.Controller
@@ -161,3 +258,41 @@ func (mr *MockVisibilityArchiverMockRecorder) ValidateURI(uri any) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateURI", reflect.TypeOf((*MockVisibilityArchiver)(nil).ValidateURI), uri)
 }
+
+// MockVisibilityCompactor is a mock of VisibilityCompactor interface.
+type MockVisibilityCompactor struct {
+	ctrl     *gomock.Controller
+	recorder *MockVisibilityCompactorMockRecorder
+	isgomock struct{}
+}
+
+// MockVisibilityCompactorMockRecorder is the mock recorder for MockVisibilityCompactor.
+type MockVisibilityCompactorMockRecorder struct {
+	mock *MockVisibilityCompactor
+}
+
+// NewMockVisibilityCompactor creates a new mock instance.
+func NewMockVisibilityCompactor(ctrl *gomock.Controller) *MockVisibilityCompactor {
+	mock := &MockVisibilityCompactor{ctrl: ctrl}
+	mock.recorder = &MockVisibilityCompactorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVisibilityCompactor) EXPECT() *MockVisibilityCompactorMockRecorder {
+	return m.recorder
+}
+
+// CompactVisibility mocks base method.
+func (m *MockVisibilityCompactor) CompactVisibility(ctx context.Context, uri URI, namespaceID string, saTypeMap searchattribute.NameTypeMap) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompactVisibility", ctx, uri, namespaceID, saTypeMap)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompactVisibility indicates an expected call of CompactVisibility.
+func (mr *MockVisibilityCompactorMockRecorder) CompactVisibility(ctx, uri, namespaceID, saTypeMap any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompactVisibility", reflect.TypeOf((*MockVisibilityCompactor)(nil).CompactVisibility), ctx, uri, namespaceID, saTypeMap)
+}
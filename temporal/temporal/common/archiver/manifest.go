@@ -0,0 +1,220 @@
+package archiver
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	historypb "go.temporal.io/api/history/v1"
+	"go.temporal.io/api/serviceerror"
+	archiverspb "go.temporal.io/server/api/archiver/v1"
+	enumsspb "go.temporal.io/server/api/enums/v1"
+	historyspb "go.temporal.io/server/api/history/v1"
+	"go.temporal.io/server/common/persistence/versionhistory"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const verifyHistoryPageSize = 1000
+
+type (
+	// VerifyHistoryRequest is the request to verify an archived history against its manifest
+	VerifyHistoryRequest struct {
+		NamespaceID string
+		WorkflowID  string
+		RunID       string
+	}
+
+	// HistoryVerification is the result of the verification of an archived history against its manifest
+	HistoryVerification struct {
+		CloseFailoverVersion int64
+		Status               enumsspb.ArchivalIntegrityStatus
+		// Details describes the first mismatch with the manifest if the status is not OK.
+		Details string
+	}
+)
+
+// NewHistoryManifest returns the manifest of the archived history of the request, whose batches are in the order they
+// are returned by the history archiver.
+func NewHistoryManifest(request *ArchiveHistoryRequest, batches []*archiverspb.HistoryManifestBatch) *archiverspb.HistoryManifest {
+	return &archiverspb.HistoryManifest{
+		NamespaceId:          request.NamespaceID,
+		WorkflowId:           request.WorkflowID,
+		RunId:                request.RunID,
+		CloseFailoverVersion: request.CloseFailoverVersion,
+		Batches:              batches,
+		ArchiveTime:          timestamppb.New(time.Now().UTC()),
+		VersionHistory:       newManifestVersionHistory(batches),
+	}
+}
+
+// newManifestVersionHistory returns the version history of the manifest batches, or nil if a batch has events but no
+// version history items, because its events don't have increasing event IDs and versions.
+func newManifestVersionHistory(batches []*archiverspb.HistoryManifestBatch) *historyspb.VersionHistory {
+	versionHistory := versionhistory.NewVersionHistory(nil, nil)
+	for _, batch := range batches {
+		if batch.GetLastEventId() != 0 && len(batch.GetVersionHistoryItems()) == 0 {
+			return nil
+		}
+		for _, item := range batch.GetVersionHistoryItems() {
+			if err := versionhistory.AddOrUpdateVersionHistoryItem(versionHistory, item); err != nil {
+				return nil
+			}
+		}
+	}
+	return versionHistory
+}
+
+// NewHistoryManifestBatches returns the manifest batches of the history batches, in the same order. The archivers
+// which write a history in several attempts accumulate the manifest batches of each attempt with it.
+func NewHistoryManifestBatches(historyBatches []*historypb.History) ([]*archiverspb.HistoryManifestBatch, error) {
+	manifestBatches := make([]*archiverspb.HistoryManifestBatch, 0, len(historyBatches))
+	for _, batch := range historyBatches {
+		manifestBatch, err := newHistoryManifestBatch(batch)
+		if err != nil {
+			return nil, err
+		}
+		manifestBatches = append(manifestBatches, manifestBatch)
+	}
+	return manifestBatches, nil
+}
+
+func newHistoryManifestBatch(batch *historypb.History) (*archiverspb.HistoryManifestBatch, error) {
+	// the deterministic encoding does not depend on the encoding of the archive, so the hash of a batch decoded from
+	// the archive matches the hash of the batch which was archived.
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(batch)
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(data)
+	manifestBatch := &archiverspb.HistoryManifestBatch{
+		Sha256: hex.EncodeToString(hash[:]),
+	}
+	if events := batch.GetEvents(); len(events) > 0 {
+		manifestBatch.FirstEventId = events[0].GetEventId()
+		manifestBatch.LastEventId = events[len(events)-1].GetEventId()
+	}
+	versionHistory := versionhistory.NewVersionHistory(nil, nil)
+	for _, event := range batch.GetEvents() {
+		item := versionhistory.NewVersionHistoryItem(event.GetEventId(), event.GetVersion())
+		if err := versionhistory.AddOrUpdateVersionHistoryItem(versionHistory, item); err != nil {
+			// the manifest has no version history, but the batch can still be verified
+			return manifestBatch, nil
+		}
+	}
+	manifestBatch.VersionHistoryItems = versionHistory.GetItems()
+	return manifestBatch, nil
+}
+
+// VerifyHistory reads back the archived history of the request, and verifies it against its manifest if the history
+// archiver is a HistoryManifestReader. It returns an error only if the archive cannot be read, and a verification with
+// a status other than OK if it is missing, truncated, corrupted or has no manifest.
+func VerifyHistory(
+	ctx context.Context,
+	historyArchiver HistoryArchiver,
+	URI URI,
+	request *VerifyHistoryRequest,
+) (*HistoryVerification, error) {
+	getRequest := &GetHistoryRequest{
+		NamespaceID: request.NamespaceID,
+		WorkflowID:  request.WorkflowID,
+		RunID:       request.RunID,
+		PageSize:    verifyHistoryPageSize,
+	}
+
+	var manifest *archiverspb.HistoryManifest
+	if manifestReader, ok := historyArchiver.(HistoryManifestReader); ok {
+		var err error
+		manifest, err = manifestReader.GetManifest(ctx, URI, getRequest)
+		switch err.(type) {
+		case nil:
+			closeFailoverVersion := manifest.GetCloseFailoverVersion()
+			getRequest.CloseFailoverVersion = &closeFailoverVersion
+		case *serviceerror.NotFound:
+		case *serviceerror.Internal:
+			return &HistoryVerification{
+				Status:  enumsspb.ARCHIVAL_INTEGRITY_STATUS_CORRUPTED,
+				Details: fmt.Sprintf("unable to read manifest: %v", err),
+			}, nil
+		default:
+			return nil, err
+		}
+	}
+
+	verification := &HistoryVerification{
+		CloseFailoverVersion: manifest.GetCloseFailoverVersion(),
+	}
+	var historyBatches []*historypb.History
+	for {
+		resp, err := historyArchiver.Get(ctx, URI, getRequest)
+		switch err.(type) {
+		case nil:
+		case *serviceerror.NotFound:
+			if getRequest.NextPageToken == nil {
+				verification.Status = enumsspb.ARCHIVAL_INTEGRITY_STATUS_MISSING
+				verification.Details = err.Error()
+			} else {
+				verification.Status = enumsspb.ARCHIVAL_INTEGRITY_STATUS_TRUNCATED
+				verification.Details = fmt.Sprintf("archived history ends after %d batches: %v", len(historyBatches), err)
+			}
+			return verification, nil
+		case *serviceerror.Internal:
+			verification.Status = enumsspb.ARCHIVAL_INTEGRITY_STATUS_CORRUPTED
+			verification.Details = fmt.Sprintf("unable to read archived history: %v", err)
+			return verification, nil
+		default:
+			return nil, err
+		}
+		historyBatches = append(historyBatches, resp.HistoryBatches...)
+		if len(resp.NextPageToken) == 0 {
+			break
+		}
+		getRequest.NextPageToken = resp.NextPageToken
+	}
+
+	if manifest == nil {
+		verification.Status = enumsspb.ARCHIVAL_INTEGRITY_STATUS_UNVERIFIED
+		verification.Details = "archived history has no manifest"
+		return verification, nil
+	}
+	verification.Status, verification.Details = compareManifest(manifest, historyBatches)
+	return verification, nil
+}
+
+func compareManifest(
+	manifest *archiverspb.HistoryManifest,
+	historyBatches []*historypb.History,
+) (enumsspb.ArchivalIntegrityStatus, string) {
+	manifestBatches := manifest.GetBatches()
+	for i, batch := range historyBatches {
+		if i >= len(manifestBatches) {
+			return enumsspb.ARCHIVAL_INTEGRITY_STATUS_CORRUPTED,
+				fmt.Sprintf("archived history has %d batches, manifest has %d", len(historyBatches), len(manifestBatches))
+		}
+		actual, err := newHistoryManifestBatch(batch)
+		if err != nil {
+			return enumsspb.ARCHIVAL_INTEGRITY_STATUS_CORRUPTED, fmt.Sprintf("unable to encode batch %d: %v", i, err)
+		}
+		expected := manifestBatches[i]
+		if actual.GetFirstEventId() != expected.GetFirstEventId() || actual.GetLastEventId() != expected.GetLastEventId() {
+			return enumsspb.ARCHIVAL_INTEGRITY_STATUS_CORRUPTED,
+				fmt.Sprintf("batch %d has events [%d, %d], manifest has [%d, %d]", i,
+					actual.GetFirstEventId(), actual.GetLastEventId(), expected.GetFirstEventId(), expected.GetLastEventId())
+		}
+		if actual.GetSha256() != expected.GetSha256() {
+			return enumsspb.ARCHIVAL_INTEGRITY_STATUS_CORRUPTED, fmt.Sprintf("batch %d does not match its hash in the manifest", i)
+		}
+	}
+	if len(historyBatches) < len(manifestBatches) {
+		var lastEventID int64
+		if len(historyBatches) > 0 {
+			lastEventID = manifestBatches[len(historyBatches)-1].GetLastEventId()
+		}
+		return enumsspb.ARCHIVAL_INTEGRITY_STATUS_TRUNCATED,
+			fmt.Sprintf("archived history ends at event %d, manifest ends at event %d",
+				lastEventID, manifestBatches[len(manifestBatches)-1].GetLastEventId())
+	}
+	return enumsspb.ARCHIVAL_INTEGRITY_STATUS_OK, ""
+}
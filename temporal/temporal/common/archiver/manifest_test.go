@@ -0,0 +1,184 @@
+package archiver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	historypb "go.temporal.io/api/history/v1"
+	"go.temporal.io/api/serviceerror"
+	enumsspb "go.temporal.io/server/api/enums/v1"
+	historyspb "go.temporal.io/server/api/history/v1"
+	"go.temporal.io/server/common/persistence/versionhistory"
+	"go.uber.org/mock/gomock"
+)
+
+type manifestTestArchiver struct {
+	*MockHistoryArchiver
+	*MockHistoryManifestReader
+}
+
+func TestNewHistoryManifestBatches(t *testing.T) {
+	batches := newManifestTestHistoryBatches()
+	manifestBatches, err := NewHistoryManifestBatches(batches)
+	require.NoError(t, err)
+	require.Len(t, manifestBatches, 2)
+	require.Equal(t, int64(1), manifestBatches[0].GetFirstEventId())
+	require.Equal(t, int64(2), manifestBatches[0].GetLastEventId())
+	require.Equal(t, int64(3), manifestBatches[1].GetFirstEventId())
+	require.Equal(t, int64(3), manifestBatches[1].GetLastEventId())
+	require.Len(t, manifestBatches[0].GetSha256(), 64)
+	require.NotEqual(t, manifestBatches[0].GetSha256(), manifestBatches[1].GetSha256())
+
+	again, err := NewHistoryManifestBatches(newManifestTestHistoryBatches())
+	require.NoError(t, err)
+	require.Equal(t, manifestBatches[0].GetSha256(), again[0].GetSha256())
+}
+
+func TestNewHistoryManifest_VersionHistory(t *testing.T) {
+	batches := newManifestTestHistoryBatches()
+	batches = append(batches, &historypb.History{
+		Events: []*historypb.HistoryEvent{
+			{EventId: 4, Version: testCloseFailoverVersion + 1},
+			{EventId: 5, Version: testCloseFailoverVersion + 1},
+		},
+	})
+	manifestBatches, err := NewHistoryManifestBatches(batches)
+	require.NoError(t, err)
+	require.Len(t, manifestBatches[0].GetVersionHistoryItems(), 1)
+	require.Equal(t, int64(2), manifestBatches[0].GetVersionHistoryItems()[0].GetEventId())
+
+	manifest := NewHistoryManifest(&ArchiveHistoryRequest{CloseFailoverVersion: testCloseFailoverVersion + 1}, manifestBatches)
+	require.Equal(t, []*historyspb.VersionHistoryItem{
+		versionhistory.NewVersionHistoryItem(3, testCloseFailoverVersion),
+		versionhistory.NewVersionHistoryItem(5, testCloseFailoverVersion+1),
+	}, manifest.GetVersionHistory().GetItems())
+
+	// The manifest has no version history if the event IDs are not increasing.
+	batches = append(batches, &historypb.History{
+		Events: []*historypb.HistoryEvent{
+			{EventId: 6, Version: testCloseFailoverVersion + 1},
+			{EventId: 6, Version: testCloseFailoverVersion + 1},
+		},
+	})
+	manifestBatches, err = NewHistoryManifestBatches(batches)
+	require.NoError(t, err)
+	require.Empty(t, manifestBatches[3].GetVersionHistoryItems())
+	require.Nil(t, NewHistoryManifest(&ArchiveHistoryRequest{}, manifestBatches).GetVersionHistory())
+}
+
+func TestVerifyHistory(t *testing.T) {
+	URI, err := NewURI("test:///archival")
+	require.NoError(t, err)
+	manifestBatches, err := NewHistoryManifestBatches(newManifestTestHistoryBatches())
+	require.NoError(t, err)
+	manifest := NewHistoryManifest(&ArchiveHistoryRequest{
+		NamespaceID:          testNamespaceID,
+		WorkflowID:           testWorkflowID,
+		RunID:                testRunID,
+		CloseFailoverVersion: testCloseFailoverVersion,
+	}, manifestBatches)
+
+	tamperedBatches := newManifestTestHistoryBatches()
+	tamperedBatches[1].Events[0].Version = testCloseFailoverVersion + 1
+
+	testCases := []struct {
+		name           string
+		manifestErr    error
+		historyBatches []*historypb.History
+		historyErr     error
+		expectedStatus enumsspb.ArchivalIntegrityStatus
+	}{
+		{
+			name:           "ok",
+			historyBatches: newManifestTestHistoryBatches(),
+			expectedStatus: enumsspb.ARCHIVAL_INTEGRITY_STATUS_OK,
+		},
+		{
+			name:           "no manifest",
+			manifestErr:    serviceerror.NewNotFound(ErrManifestNotExist.Error()),
+			historyBatches: newManifestTestHistoryBatches(),
+			expectedStatus: enumsspb.ARCHIVAL_INTEGRITY_STATUS_UNVERIFIED,
+		},
+		{
+			name:           "missing",
+			historyErr:     serviceerror.NewNotFound(ErrHistoryNotExist.Error()),
+			expectedStatus: enumsspb.ARCHIVAL_INTEGRITY_STATUS_MISSING,
+		},
+		{
+			name:           "truncated",
+			historyBatches: newManifestTestHistoryBatches()[:1],
+			expectedStatus: enumsspb.ARCHIVAL_INTEGRITY_STATUS_TRUNCATED,
+		},
+		{
+			name:           "tampered",
+			historyBatches: tamperedBatches,
+			expectedStatus: enumsspb.ARCHIVAL_INTEGRITY_STATUS_CORRUPTED,
+		},
+		{
+			name:           "undecodable",
+			historyErr:     serviceerror.NewInternal("invalid character"),
+			expectedStatus: enumsspb.ARCHIVAL_INTEGRITY_STATUS_CORRUPTED,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			historyArchiver := &manifestTestArchiver{
+				MockHistoryArchiver:       NewMockHistoryArchiver(controller),
+				MockHistoryManifestReader: NewMockHistoryManifestReader(controller),
+			}
+			if tc.manifestErr != nil {
+				historyArchiver.MockHistoryManifestReader.EXPECT().GetManifest(gomock.Any(), URI, gomock.Any()).Return(nil, tc.manifestErr)
+			} else {
+				historyArchiver.MockHistoryManifestReader.EXPECT().GetManifest(gomock.Any(), URI, gomock.Any()).Return(manifest, nil)
+			}
+			if tc.historyErr != nil {
+				historyArchiver.MockHistoryArchiver.EXPECT().Get(gomock.Any(), URI, gomock.Any()).Return(nil, tc.historyErr)
+			} else {
+				historyArchiver.MockHistoryArchiver.EXPECT().Get(gomock.Any(), URI, gomock.Any()).Return(&GetHistoryResponse{
+					HistoryBatches: tc.historyBatches,
+				}, nil)
+			}
+
+			verification, err := VerifyHistory(context.Background(), historyArchiver, URI, &VerifyHistoryRequest{
+				NamespaceID: testNamespaceID,
+				WorkflowID:  testWorkflowID,
+				RunID:       testRunID,
+			})
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedStatus, verification.Status, verification.Details)
+		})
+	}
+}
+
+func TestVerifyHistory_ArchiverError(t *testing.T) {
+	URI, err := NewURI("test:///archival")
+	require.NoError(t, err)
+	historyArchiver := NewMockHistoryArchiver(gomock.NewController(t))
+	historyArchiver.EXPECT().Get(gomock.Any(), URI, gomock.Any()).Return(nil, serviceerror.NewUnavailable("unavailable"))
+
+	_, err = VerifyHistory(context.Background(), historyArchiver, URI, &VerifyHistoryRequest{
+		NamespaceID: testNamespaceID,
+		WorkflowID:  testWorkflowID,
+		RunID:       testRunID,
+	})
+	require.IsType(t, &serviceerror.Unavailable{}, err)
+}
+
+func newManifestTestHistoryBatches() []*historypb.History {
+	return []*historypb.History{
+		{
+			Events: []*historypb.HistoryEvent{
+				{EventId: 1, Version: testCloseFailoverVersion},
+				{EventId: 2, Version: testCloseFailoverVersion},
+			},
+		},
+		{
+			Events: []*historypb.HistoryEvent{
+				{EventId: 3, Version: testCloseFailoverVersion},
+			},
+		},
+	}
+}
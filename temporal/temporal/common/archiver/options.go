@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	"go.temporal.io/sdk/activity"
+	"go.temporal.io/server/common/searchattribute"
 )
 
 type (
@@ -17,6 +18,9 @@ type (
 	ArchiveFeatureCatalog struct {
 		ProgressManager   ProgressManager
 		NonRetryableError NonRetryableError
+		// SearchAttributeTypes are the types of the search attributes of the archived visibility record, which
+		// are stringified in the record.
+		SearchAttributeTypes *searchattribute.NameTypeMap
 	}
 
 	// NonRetryableError returns an error indicating archiver has encountered an non-retryable error
@@ -75,3 +79,11 @@ func GetNonRetryableErrorOption(nonRetryableErr error) ArchiveOption {
 		}
 	}
 }
+
+// GetSearchAttributeTypesOption returns an ArchiveOption so that the visibility archiver knows the types of the
+// search attributes of the archived record.
+func GetSearchAttributeTypesOption(saTypeMap searchattribute.NameTypeMap) ArchiveOption {
+	return func(catalog *ArchiveFeatureCatalog) {
+		catalog.SearchAttributeTypes = &saTypeMap
+	}
+}
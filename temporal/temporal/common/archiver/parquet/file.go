@@ -0,0 +1,232 @@
+package parquet
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/deprecated"
+	"github.com/parquet-go/parquet-go/format"
+)
+
+// This file maps the visibility columns to the Parquet files written and read by parquet-go: a flat schema of
+// optional columns of primitive types, compressed with snappy.
+
+type (
+	columnType int
+
+	// column is a column of a Parquet file. The values are nil for the rows which have no value, otherwise their
+	// Go type depends on the column type: string, []byte, int64, float64, bool or time.Time.
+	column struct {
+		name       string
+		columnType columnType
+		values     []any
+	}
+)
+
+const (
+	columnTypeString columnType = iota
+	columnTypeBytes
+	columnTypeInt64
+	columnTypeDouble
+	columnTypeBool
+	columnTypeTimestamp
+)
+
+const readBatchSize = 1024
+
+var (
+	errInvalidFile = errors.New("invalid parquet file")
+)
+
+func (t columnType) node() parquet.Node {
+	switch t {
+	case columnTypeString:
+		return parquet.String()
+	case columnTypeInt64:
+		return parquet.Int(64)
+	case columnTypeDouble:
+		return parquet.Leaf(parquet.DoubleType)
+	case columnTypeBool:
+		return parquet.Leaf(parquet.BooleanType)
+	case columnTypeTimestamp:
+		return parquet.Timestamp(parquet.Microsecond)
+	default:
+		return parquet.Leaf(parquet.ByteArrayType)
+	}
+}
+
+func (t columnType) value(v any) (parquet.Value, bool) {
+	switch t {
+	case columnTypeString:
+		s, ok := v.(string)
+		return parquet.ByteArrayValue([]byte(s)), ok
+	case columnTypeBytes:
+		b, ok := v.([]byte)
+		return parquet.ByteArrayValue(b), ok
+	case columnTypeInt64:
+		n, ok := v.(int64)
+		return parquet.Int64Value(n), ok
+	case columnTypeDouble:
+		f, ok := v.(float64)
+		return parquet.DoubleValue(f), ok
+	case columnTypeBool:
+		b, ok := v.(bool)
+		return parquet.BooleanValue(b), ok
+	case columnTypeTimestamp:
+		ts, ok := v.(time.Time)
+		return parquet.Int64Value(ts.UnixMicro()), ok
+	default:
+		return parquet.Value{}, false
+	}
+}
+
+// columnTypeOf returns the column type of a leaf of the schema, and the function which converts its values. The
+// files of other writers may use other logical or converted types for the same physical types.
+func columnTypeOf(name string, node parquet.Node) (columnType, func(parquet.Value) any, error) {
+	t := node.Type()
+	var logicalType format.LogicalTypeValue
+	if t.LogicalType() != nil {
+		logicalType = t.LogicalType().Value
+	}
+	switch t.Kind() {
+	case parquet.ByteArray:
+		_, isString := logicalType.(*format.StringType)
+		if converted := t.ConvertedType(); isString || (converted != nil && *converted == deprecated.UTF8) {
+			return columnTypeString, func(v parquet.Value) any { return string(v.ByteArray()) }, nil
+		}
+		return columnTypeBytes, func(v parquet.Value) any { return bytes.Clone(v.ByteArray()) }, nil
+	case parquet.Int64:
+		if timestampType, ok := logicalType.(*format.TimestampType); ok && timestampType.Unit.Value != nil {
+			unit := timestampType.Unit.Value.Duration()
+			return columnTypeTimestamp, func(v parquet.Value) any { return time.Unix(0, v.Int64()*int64(unit)).UTC() }, nil
+		}
+		return columnTypeInt64, func(v parquet.Value) any { return v.Int64() }, nil
+	case parquet.Double:
+		return columnTypeDouble, func(v parquet.Value) any { return v.Double() }, nil
+	case parquet.Boolean:
+		return columnTypeBool, func(v parquet.Value) any { return v.Boolean() }, nil
+	default:
+		return 0, nil, fmt.Errorf("%w: unsupported type %v of column %s", errInvalidFile, t, name)
+	}
+}
+
+// writeFile writes the columns, which must have the same number of values, as a Parquet file. The metadata is
+// written as the key value metadata of the file.
+func writeFile(columns []*column, metadata map[string]string) ([]byte, error) {
+	numRows := 0
+	if len(columns) > 0 {
+		numRows = len(columns[0].values)
+	}
+
+	group := make(parquet.Group, len(columns))
+	for _, c := range columns {
+		if len(c.values) != numRows {
+			return nil, fmt.Errorf("column %s has %d values instead of %d", c.name, len(c.values), numRows)
+		}
+		group[c.name] = parquet.Optional(c.columnType.node())
+	}
+	schema := parquet.NewSchema("schema", group)
+
+	// The leaf columns of the schema are ordered by name.
+	rows := make([]parquet.Row, numRows)
+	for i := range rows {
+		rows[i] = make(parquet.Row, len(columns))
+	}
+	for _, c := range columns {
+		leaf, _ := schema.Lookup(c.name)
+		for i, v := range c.values {
+			if v == nil {
+				rows[i][leaf.ColumnIndex] = parquet.NullValue().Level(0, 0, leaf.ColumnIndex)
+				continue
+			}
+			value, ok := c.columnType.value(v)
+			if !ok {
+				return nil, fmt.Errorf("invalid value of type %T for column %s", v, c.name)
+			}
+			rows[i][leaf.ColumnIndex] = value.Level(0, 1, leaf.ColumnIndex)
+		}
+	}
+
+	options := []parquet.WriterOption{schema, parquet.Compression(&parquet.Snappy)}
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		options = append(options, parquet.KeyValueMetadata(key, metadata[key]))
+	}
+
+	var buffer bytes.Buffer
+	writer := parquet.NewWriter(&buffer, options...)
+	if _, err := writer.WriteRows(rows); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// readFile reads the columns, ordered by name, and the key value metadata of a Parquet file.
+func readFile(data []byte) ([]*column, map[string]string, error) {
+	file, err := parquet.OpenFile(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", errInvalidFile, err)
+	}
+
+	schema := file.Schema()
+	columnPaths := schema.Columns()
+	columns := make([]*column, len(columnPaths))
+	converters := make([]func(parquet.Value) any, len(columnPaths))
+	for _, columnPath := range columnPaths {
+		if len(columnPath) != 1 {
+			return nil, nil, fmt.Errorf("%w: nested column %v", errInvalidFile, columnPath)
+		}
+		leaf, _ := schema.Lookup(columnPath...)
+		if leaf.MaxRepetitionLevel > 0 {
+			return nil, nil, fmt.Errorf("%w: repeated column %s", errInvalidFile, columnPath[0])
+		}
+		t, convert, err := columnTypeOf(columnPath[0], leaf.Node)
+		if err != nil {
+			return nil, nil, err
+		}
+		columns[leaf.ColumnIndex] = &column{name: columnPath[0], columnType: t, values: make([]any, 0, file.NumRows())}
+		converters[leaf.ColumnIndex] = convert
+	}
+
+	reader := parquet.NewReader(file)
+	defer func() { _ = reader.Close() }()
+	rows := make([]parquet.Row, readBatchSize)
+	for {
+		n, err := reader.ReadRows(rows)
+		for _, row := range rows[:n] {
+			values := make([]any, len(columns))
+			for _, value := range row {
+				if !value.IsNull() {
+					values[value.Column()] = converters[value.Column()](value)
+				}
+			}
+			for i, c := range columns {
+				c.values = append(c.values, values[i])
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %v", errInvalidFile, err)
+		}
+	}
+
+	metadata := make(map[string]string, len(file.Metadata().KeyValueMetadata))
+	for _, kv := range file.Metadata().KeyValueMetadata {
+		metadata[kv.Key] = kv.Value
+	}
+	return columns, metadata, nil
+}
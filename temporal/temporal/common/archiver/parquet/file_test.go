@@ -0,0 +1,84 @@
+package parquet
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteReadFile(t *testing.T) {
+	now := time.Date(2024, 5, 6, 7, 8, 9, 123456000, time.UTC)
+	// The columns are ordered by name.
+	columns := []*column{
+		{name: "bool", columnType: columnTypeBool, values: []any{true, false, nil, true}},
+		{name: "bytes", columnType: columnTypeBytes, values: []any{[]byte{1, 2}, []byte{}, nil, nil}},
+		{name: "double", columnType: columnTypeDouble, values: []any{nil, 1.5, -2.25, 0.0}},
+		{name: "empty", columnType: columnTypeString, values: []any{nil, nil, nil, nil}},
+		{name: "int64", columnType: columnTypeInt64, values: []any{int64(-1), int64(0), int64(1 << 40), nil}},
+		{name: "string", columnType: columnTypeString, values: []any{"a", nil, "", "long string value"}},
+		{name: "timestamp", columnType: columnTypeTimestamp, values: []any{now, nil, nil, time.Unix(0, 0).UTC()}},
+	}
+
+	data, err := writeFile(columns, map[string]string{"key": "value"})
+	require.NoError(t, err)
+	require.Equal(t, "PAR1", string(data[:4]))
+	require.Equal(t, "PAR1", string(data[len(data)-4:]))
+
+	readColumns, metadata, err := readFile(data)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"key": "value"}, metadata)
+	require.Equal(t, columns, readColumns)
+}
+
+func TestWriteFile_InvalidColumns(t *testing.T) {
+	_, err := writeFile([]*column{
+		{name: "a", columnType: columnTypeString, values: []any{"a", "b"}},
+		{name: "b", columnType: columnTypeString, values: []any{"a"}},
+	}, nil)
+	require.Error(t, err)
+
+	_, err = writeFile([]*column{
+		{name: "a", columnType: columnTypeInt64, values: []any{"a"}},
+	}, nil)
+	require.Error(t, err)
+}
+
+func TestReadFile_Invalid(t *testing.T) {
+	_, _, err := readFile([]byte("PAR1"))
+	require.ErrorIs(t, err, errInvalidFile)
+
+	data, err := writeFile([]*column{{name: "a", columnType: columnTypeString, values: []any{"a"}}}, nil)
+	require.NoError(t, err)
+	_, _, err = readFile(data[:len(data)-1])
+	require.ErrorIs(t, err, errInvalidFile)
+
+	_, _, err = readFile(data[1:])
+	require.ErrorIs(t, err, errInvalidFile)
+}
+
+func TestReadFile_OtherWriter(t *testing.T) {
+	// The files of other writers may have required columns, and timestamps of other units.
+	type row struct {
+		Name      string    `parquet:"name"`
+		Count     int64     `parquet:"count,optional"`
+		CloseTime time.Time `parquet:"close_time,timestamp(millisecond)"`
+	}
+	closeTime := time.Date(2024, 5, 6, 7, 8, 9, 123000000, time.UTC)
+	var buffer bytes.Buffer
+	writer := parquet.NewGenericWriter[row](&buffer, parquet.KeyValueMetadata("key", "value"))
+	_, err := writer.Write([]row{{Name: "a", Count: 1, CloseTime: closeTime}, {Name: "b", CloseTime: closeTime}})
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	columns, metadata, err := readFile(buffer.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"key": "value"}, metadata)
+	require.ElementsMatch(t, []*column{
+		{name: "name", columnType: columnTypeString, values: []any{"a", "b"}},
+		{name: "count", columnType: columnTypeInt64, values: []any{int64(1), nil}},
+		{name: "close_time", columnType: columnTypeTimestamp, values: []any{closeTime, closeTime}},
+	}, columns)
+}
@@ -0,0 +1,320 @@
+package parquet
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	archiverspb "go.temporal.io/server/api/archiver/v1"
+	"go.temporal.io/server/common/searchattribute"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// The columns of the visibility records. The search attributes are stored in one column per search attribute, named
+// with the searchAttributeColumnPrefix, and typed by the search attribute type when all the values can be parsed as
+// such, or as strings otherwise.
+const (
+	columnNamespaceID        = "namespace_id"
+	columnNamespace          = "namespace"
+	columnWorkflowID         = "workflow_id"
+	columnRunID              = "run_id"
+	columnWorkflowTypeName   = "workflow_type_name"
+	columnStartTime          = "start_time"
+	columnExecutionTime      = "execution_time"
+	columnCloseTime          = "close_time"
+	columnStatus             = "status"
+	columnHistoryLength      = "history_length"
+	columnExecutionDuration  = "execution_duration_ns"
+	columnHistoryArchivalURI = "history_archival_uri"
+	columnMemo               = "memo"
+
+	searchAttributeColumnPrefix = "sa_"
+
+	metadataSchemaVersion = "temporal.visibility.schema_version"
+	schemaVersion         = "1"
+)
+
+// EncodeVisibilityRecords encodes the visibility records as a Parquet file. The search attribute types are used to
+// type the search attribute columns, and can be nil.
+func EncodeVisibilityRecords(
+	records []*archiverspb.VisibilityRecord,
+	saTypeMap *searchattribute.NameTypeMap,
+) ([]byte, error) {
+	newColumn := func(name string, t columnType) *column {
+		return &column{name: name, columnType: t, values: make([]any, len(records))}
+	}
+	namespaceID := newColumn(columnNamespaceID, columnTypeString)
+	namespaceName := newColumn(columnNamespace, columnTypeString)
+	workflowID := newColumn(columnWorkflowID, columnTypeString)
+	runID := newColumn(columnRunID, columnTypeString)
+	workflowTypeName := newColumn(columnWorkflowTypeName, columnTypeString)
+	startTime := newColumn(columnStartTime, columnTypeTimestamp)
+	executionTime := newColumn(columnExecutionTime, columnTypeTimestamp)
+	closeTime := newColumn(columnCloseTime, columnTypeTimestamp)
+	status := newColumn(columnStatus, columnTypeString)
+	historyLength := newColumn(columnHistoryLength, columnTypeInt64)
+	executionDuration := newColumn(columnExecutionDuration, columnTypeInt64)
+	historyArchivalURI := newColumn(columnHistoryArchivalURI, columnTypeString)
+	memo := newColumn(columnMemo, columnTypeBytes)
+
+	searchAttributes := make(map[string][]string)
+	for i, record := range records {
+		namespaceID.values[i] = record.GetNamespaceId()
+		namespaceName.values[i] = record.GetNamespace()
+		workflowID.values[i] = record.GetWorkflowId()
+		runID.values[i] = record.GetRunId()
+		workflowTypeName.values[i] = record.GetWorkflowTypeName()
+		startTime.values[i] = timestampValue(record.GetStartTime())
+		executionTime.values[i] = timestampValue(record.GetExecutionTime())
+		closeTime.values[i] = timestampValue(record.GetCloseTime())
+		status.values[i] = record.GetStatus().String()
+		historyLength.values[i] = record.GetHistoryLength()
+		if record.GetExecutionDuration() != nil {
+			executionDuration.values[i] = int64(record.GetExecutionDuration().AsDuration())
+		}
+		if uri := record.GetHistoryArchivalUri(); uri != "" {
+			historyArchivalURI.values[i] = uri
+		}
+		if record.GetMemo() != nil {
+			data, err := proto.Marshal(record.GetMemo())
+			if err != nil {
+				return nil, err
+			}
+			memo.values[i] = data
+		}
+		for name, value := range record.GetSearchAttributes() {
+			if searchAttributes[name] == nil {
+				searchAttributes[name] = make([]string, len(records))
+			}
+			searchAttributes[name][i] = value
+		}
+	}
+
+	columns := []*column{
+		namespaceID,
+		namespaceName,
+		workflowID,
+		runID,
+		workflowTypeName,
+		startTime,
+		executionTime,
+		closeTime,
+		status,
+		historyLength,
+		executionDuration,
+		historyArchivalURI,
+		memo,
+	}
+	names := make([]string, 0, len(searchAttributes))
+	for name := range searchAttributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		saType := enumspb.INDEXED_VALUE_TYPE_UNSPECIFIED
+		if saTypeMap != nil {
+			saType, _ = saTypeMap.GetType(name)
+		}
+		columns = append(columns, searchAttributeColumn(name, saType, searchAttributes[name], records))
+	}
+
+	return writeFile(columns, map[string]string{metadataSchemaVersion: schemaVersion})
+}
+
+func searchAttributeColumn(
+	name string,
+	saType enumspb.IndexedValueType,
+	stringValues []string,
+	records []*archiverspb.VisibilityRecord,
+) *column {
+	c := &column{name: searchAttributeColumnPrefix + name, columnType: columnTypeString, values: make([]any, len(records))}
+	parse := searchAttributeParser(saType)
+	if parse != nil {
+		c.columnType = saColumnType(saType)
+		for i, value := range stringValues {
+			if _, ok := records[i].GetSearchAttributes()[name]; !ok {
+				continue
+			}
+			parsed, err := parse(value)
+			if err != nil {
+				// The values which don't match the type of the search attribute, such as the values of a search
+				// attribute which was re-registered with another type, are kept as strings.
+				parse = nil
+				break
+			}
+			c.values[i] = parsed
+		}
+	}
+	if parse == nil {
+		c.columnType = columnTypeString
+		for i, value := range stringValues {
+			if _, ok := records[i].GetSearchAttributes()[name]; ok {
+				c.values[i] = value
+			}
+		}
+	}
+	return c
+}
+
+func searchAttributeParser(saType enumspb.IndexedValueType) func(string) (any, error) {
+	switch saType {
+	case enumspb.INDEXED_VALUE_TYPE_INT:
+		return func(s string) (any, error) { return strconv.ParseInt(s, 10, 64) }
+	case enumspb.INDEXED_VALUE_TYPE_DOUBLE:
+		return func(s string) (any, error) { return strconv.ParseFloat(s, 64) }
+	case enumspb.INDEXED_VALUE_TYPE_BOOL:
+		return func(s string) (any, error) { return strconv.ParseBool(s) }
+	case enumspb.INDEXED_VALUE_TYPE_DATETIME:
+		return func(s string) (any, error) { return time.Parse(time.RFC3339Nano, s) }
+	default:
+		return nil
+	}
+}
+
+func saColumnType(saType enumspb.IndexedValueType) columnType {
+	switch saType {
+	case enumspb.INDEXED_VALUE_TYPE_INT:
+		return columnTypeInt64
+	case enumspb.INDEXED_VALUE_TYPE_DOUBLE:
+		return columnTypeDouble
+	case enumspb.INDEXED_VALUE_TYPE_BOOL:
+		return columnTypeBool
+	case enumspb.INDEXED_VALUE_TYPE_DATETIME:
+		return columnTypeTimestamp
+	default:
+		return columnTypeString
+	}
+}
+
+// DecodeVisibilityRecords decodes the visibility records of a Parquet file written by EncodeVisibilityRecords.
+// The timestamps are truncated to microseconds.
+func DecodeVisibilityRecords(data []byte) ([]*archiverspb.VisibilityRecord, error) {
+	columns, metadata, err := readFile(data)
+	if err != nil {
+		return nil, err
+	}
+	if version := metadata[metadataSchemaVersion]; version != schemaVersion {
+		return nil, fmt.Errorf("%w: unsupported visibility schema version %q", errInvalidFile, version)
+	}
+
+	var records []*archiverspb.VisibilityRecord
+	if len(columns) > 0 {
+		records = make([]*archiverspb.VisibilityRecord, len(columns[0].values))
+	}
+	for i := range records {
+		records[i] = &archiverspb.VisibilityRecord{}
+	}
+	for _, c := range columns {
+		for i, value := range c.values {
+			if value == nil {
+				continue
+			}
+			if err := setRecordValue(records[i], c, value); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return records, nil
+}
+
+func setRecordValue(record *archiverspb.VisibilityRecord, c *column, value any) error {
+	if name, ok := strings.CutPrefix(c.name, searchAttributeColumnPrefix); ok {
+		if record.SearchAttributes == nil {
+			record.SearchAttributes = make(map[string]string)
+		}
+		record.SearchAttributes[name] = formatSearchAttributeValue(value)
+		return nil
+	}
+
+	var ok bool
+	switch c.name {
+	case columnNamespaceID:
+		record.NamespaceId, ok = value.(string)
+	case columnNamespace:
+		record.Namespace, ok = value.(string)
+	case columnWorkflowID:
+		record.WorkflowId, ok = value.(string)
+	case columnRunID:
+		record.RunId, ok = value.(string)
+	case columnWorkflowTypeName:
+		record.WorkflowTypeName, ok = value.(string)
+	case columnStartTime:
+		record.StartTime, ok = timestampProto(value)
+	case columnExecutionTime:
+		record.ExecutionTime, ok = timestampProto(value)
+	case columnCloseTime:
+		record.CloseTime, ok = timestampProto(value)
+	case columnStatus:
+		var s string
+		if s, ok = value.(string); ok {
+			status, err := enumspb.WorkflowExecutionStatusFromString(s)
+			if err != nil {
+				return err
+			}
+			record.Status = status
+		}
+	case columnHistoryLength:
+		record.HistoryLength, ok = value.(int64)
+	case columnExecutionDuration:
+		var d int64
+		if d, ok = value.(int64); ok {
+			record.ExecutionDuration = durationpb.New(time.Duration(d))
+		}
+	case columnHistoryArchivalURI:
+		record.HistoryArchivalUri, ok = value.(string)
+	case columnMemo:
+		var data []byte
+		if data, ok = value.([]byte); ok {
+			record.Memo = &commonpb.Memo{}
+			if err := proto.Unmarshal(data, record.Memo); err != nil {
+				return err
+			}
+		}
+	default:
+		// The columns which are added by future schema changes are ignored.
+		return nil
+	}
+	if !ok {
+		return fmt.Errorf("%w: invalid value of type %T for column %s", errInvalidFile, value, c.name)
+	}
+	return nil
+}
+
+// formatSearchAttributeValue formats a search attribute value like searchattribute.Stringify.
+func formatSearchAttributeValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func timestampValue(t *timestamppb.Timestamp) any {
+	if t == nil {
+		return nil
+	}
+	return t.AsTime()
+}
+
+func timestampProto(value any) (*timestamppb.Timestamp, bool) {
+	t, ok := value.(time.Time)
+	if !ok {
+		return nil, false
+	}
+	return timestamppb.New(t), true
+}
@@ -0,0 +1,409 @@
+package parquet
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	archiverspb "go.temporal.io/server/api/archiver/v1"
+	"go.temporal.io/server/common/archiver"
+	"go.temporal.io/server/common/clock"
+	"go.temporal.io/server/common/searchattribute"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// DefaultBucketInterval is the default close time interval of the visibility records which are batched in the
+	// same Parquet files.
+	DefaultBucketInterval = time.Hour
+
+	// The records are first staged one per blob under stagingDir/<bucket>/, and compacted into a Parquet file under
+	// batchesDir/<bucket>/ once the bucket is sealed, that is once its interval ended one bucket interval ago.
+	stagingDir         = "staging"
+	batchesDir         = "batches"
+	bucketFormat       = "20060102T150405Z"
+	stagedRecordSuffix = ".visibility"
+	batchFileSuffix    = ".parquet"
+)
+
+var (
+	// ErrTooManyRecords is returned by Query if more records than the MaxRecords of the request match it.
+	ErrTooManyRecords = errors.New("too many matching records")
+)
+
+type (
+	// Storage is the storage of the Parquet visibility archives of an archiver provider. The keys are slash
+	// separated paths.
+	Storage interface {
+		Put(ctx context.Context, key string, data []byte) error
+		Get(ctx context.Context, key string) ([]byte, error)
+		Delete(ctx context.Context, key string) error
+		// List returns the names of the blobs and the directories directly under the dir, without the dir. It
+		// returns nothing if the dir doesn't exist.
+		List(ctx context.Context, dir string) ([]string, error)
+	}
+
+	// VisibilityArchive archives the visibility records of the namespaces in Parquet files, which batch the records
+	// of a namespace by close time bucket. The records are staged until their bucket is sealed, and then compacted
+	// into a Parquet file by Compact, which is called periodically in the background.
+	VisibilityArchive struct {
+		bucketInterval time.Duration
+		timeSource     clock.TimeSource
+	}
+
+	// QueryRequest is the request to query the archived visibility records of a namespace.
+	QueryRequest struct {
+		// PageSize is the maximum number of records of the response, which has all the matching records if PageSize
+		// isn't positive.
+		PageSize      int
+		NextPageToken []byte
+		// EarliestCloseTime and LatestCloseTime bound the close time of the records, if they are set.
+		EarliestCloseTime time.Time
+		LatestCloseTime   time.Time
+		// Filter returns whether the record matches the query.
+		Filter func(record *archiverspb.VisibilityRecord) bool
+		// MaxRecords is the maximum number of matching records of a request without a PageSize, if it is positive.
+		MaxRecords int
+	}
+
+	// QueryResponse is the response of QueryRequest. The records are ordered by close time, latest first.
+	QueryResponse struct {
+		Records       []*archiverspb.VisibilityRecord
+		NextPageToken []byte
+	}
+
+	queryToken struct {
+		LastCloseTime time.Time
+		LastRunID     string
+	}
+)
+
+// NewVisibilityArchive creates a VisibilityArchive which batches the records by close time buckets of the interval.
+// The interval must not be changed once records are archived, since the query results are ordered by bucket.
+func NewVisibilityArchive(bucketInterval time.Duration, timeSource clock.TimeSource) *VisibilityArchive {
+	if bucketInterval <= 0 {
+		bucketInterval = DefaultBucketInterval
+	}
+	return &VisibilityArchive{
+		bucketInterval: bucketInterval,
+		timeSource:     timeSource,
+	}
+}
+
+// Archive stages the record in the dir of its namespace.
+func (a *VisibilityArchive) Archive(
+	ctx context.Context,
+	storage Storage,
+	dir string,
+	record *archiverspb.VisibilityRecord,
+) error {
+	data, err := proto.Marshal(record)
+	if err != nil {
+		return err
+	}
+	closeTime := record.GetCloseTime().AsTime()
+	key := path.Join(dir, stagingDir, a.bucketName(closeTime), stagedRecordName(closeTime, record.GetRunId()))
+	return storage.Put(ctx, key, data)
+}
+
+// Compact compacts the staged records of the sealed buckets of the dir into Parquet files.
+func (a *VisibilityArchive) Compact(
+	ctx context.Context,
+	storage Storage,
+	dir string,
+	saTypeMap *searchattribute.NameTypeMap,
+) error {
+	now := a.timeSource.Now()
+	buckets, err := storage.List(ctx, path.Join(dir, stagingDir))
+	if err != nil {
+		return err
+	}
+	for _, bucket := range buckets {
+		bucketStart, err := time.Parse(bucketFormat, bucket)
+		if err != nil {
+			continue
+		}
+		if bucketStart.Add(2 * a.bucketInterval).After(now) {
+			continue
+		}
+		if err := a.compactBucket(ctx, storage, dir, bucket, saTypeMap); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// compactBucket writes the staged records of the bucket into a Parquet file, and deletes them. The name of the file
+// is derived from the staged records, so that concurrent compactions of the same records write the same file. The
+// records which are in several files, such as when the deletion of the staged records failed, are deduplicated when
+// they are queried.
+func (a *VisibilityArchive) compactBucket(
+	ctx context.Context,
+	storage Storage,
+	dir string,
+	bucket string,
+	saTypeMap *searchattribute.NameTypeMap,
+) error {
+	bucketStagingDir := path.Join(dir, stagingDir, bucket)
+	names, err := storage.List(ctx, bucketStagingDir)
+	if err != nil {
+		return err
+	}
+	var stagedNames []string
+	for _, name := range names {
+		if strings.HasSuffix(name, stagedRecordSuffix) {
+			stagedNames = append(stagedNames, name)
+		}
+	}
+	if len(stagedNames) == 0 {
+		return nil
+	}
+	sort.Strings(stagedNames)
+
+	records, err := readStagedRecords(ctx, storage, bucketStagingDir, stagedNames)
+	if err != nil {
+		return err
+	}
+	sortRecords(records)
+	data, err := EncodeVisibilityRecords(records, saTypeMap)
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256([]byte(strings.Join(stagedNames, "\n")))
+	batchName := hex.EncodeToString(sum[:16]) + batchFileSuffix
+	if err := storage.Put(ctx, path.Join(dir, batchesDir, bucket, batchName), data); err != nil {
+		return err
+	}
+	for _, name := range stagedNames {
+		if err := storage.Delete(ctx, path.Join(bucketStagingDir, name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Query returns the records of the dir which match the request.
+func (a *VisibilityArchive) Query(
+	ctx context.Context,
+	storage Storage,
+	dir string,
+	request *QueryRequest,
+) (*QueryResponse, error) {
+	var token *queryToken
+	if len(request.NextPageToken) > 0 {
+		token = &queryToken{}
+		if err := json.Unmarshal(request.NextPageToken, token); err != nil {
+			return nil, archiver.ErrNextPageTokenCorrupted
+		}
+	}
+
+	buckets, err := a.listBuckets(ctx, storage, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &QueryResponse{}
+	for _, bucketStart := range buckets {
+		if token != nil && bucketStart.After(token.LastCloseTime) {
+			continue
+		}
+		if !request.LatestCloseTime.IsZero() && bucketStart.After(request.LatestCloseTime) {
+			continue
+		}
+		if !request.EarliestCloseTime.IsZero() && !bucketStart.Add(a.bucketInterval).After(request.EarliestCloseTime) {
+			break
+		}
+
+		records, err := a.readBucket(ctx, storage, dir, bucketStart.Format(bucketFormat))
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range records {
+			closeTime := record.GetCloseTime().AsTime()
+			if token != nil && !recordBefore(closeTime, record.GetRunId(), token.LastCloseTime, token.LastRunID) {
+				continue
+			}
+			if !request.EarliestCloseTime.IsZero() && closeTime.Before(request.EarliestCloseTime) {
+				continue
+			}
+			if !request.LatestCloseTime.IsZero() && closeTime.After(request.LatestCloseTime) {
+				continue
+			}
+			if request.Filter != nil && !request.Filter(record) {
+				continue
+			}
+			response.Records = append(response.Records, record)
+			if request.MaxRecords > 0 && len(response.Records) > request.MaxRecords {
+				return nil, ErrTooManyRecords
+			}
+			if len(response.Records) == request.PageSize {
+				nextPageToken, err := json.Marshal(&queryToken{LastCloseTime: closeTime, LastRunID: record.GetRunId()})
+				if err != nil {
+					return nil, err
+				}
+				response.NextPageToken = nextPageToken
+				return response, nil
+			}
+		}
+	}
+	return response, nil
+}
+
+// listBuckets returns the start times of the buckets which have staged or compacted records, latest first.
+func (a *VisibilityArchive) listBuckets(ctx context.Context, storage Storage, dir string) ([]time.Time, error) {
+	var names []string
+	for _, subDir := range []string{batchesDir, stagingDir} {
+		subDirNames, err := storage.List(ctx, path.Join(dir, subDir))
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, subDirNames...)
+	}
+
+	seen := make(map[time.Time]struct{}, len(names))
+	var buckets []time.Time
+	for _, name := range names {
+		bucketStart, err := time.Parse(bucketFormat, strings.TrimSuffix(name, "/"))
+		if err != nil {
+			continue
+		}
+		if _, ok := seen[bucketStart]; ok {
+			continue
+		}
+		seen[bucketStart] = struct{}{}
+		buckets = append(buckets, bucketStart)
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		return buckets[i].After(buckets[j])
+	})
+	return buckets, nil
+}
+
+// readBucket returns the compacted and the staged records of the bucket, without duplicates, latest first.
+func (a *VisibilityArchive) readBucket(
+	ctx context.Context,
+	storage Storage,
+	dir string,
+	bucket string,
+) ([]*archiverspb.VisibilityRecord, error) {
+	var records []*archiverspb.VisibilityRecord
+
+	bucketBatchesDir := path.Join(dir, batchesDir, bucket)
+	batchNames, err := storage.List(ctx, bucketBatchesDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range batchNames {
+		if !strings.HasSuffix(name, batchFileSuffix) {
+			continue
+		}
+		data, err := storage.Get(ctx, path.Join(bucketBatchesDir, name))
+		if err != nil {
+			return nil, err
+		}
+		batch, err := DecodeVisibilityRecords(data)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, batch...)
+	}
+
+	bucketStagingDir := path.Join(dir, stagingDir, bucket)
+	stagedNames, err := storage.List(ctx, bucketStagingDir)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, name := range stagedNames {
+		if strings.HasSuffix(name, stagedRecordSuffix) {
+			names = append(names, name)
+		}
+	}
+	staged, err := readStagedRecords(ctx, storage, bucketStagingDir, names)
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range staged {
+		// The timestamps of the records of the Parquet files are truncated to microseconds, the records are ordered
+		// the same whether they are compacted or not.
+		record.StartTime = truncateTimestamp(record.StartTime)
+		record.ExecutionTime = truncateTimestamp(record.ExecutionTime)
+		record.CloseTime = truncateTimestamp(record.CloseTime)
+	}
+	records = append(records, staged...)
+
+	seen := make(map[string]struct{}, len(records))
+	deduplicated := records[:0]
+	for _, record := range records {
+		if _, ok := seen[record.GetRunId()]; ok {
+			continue
+		}
+		seen[record.GetRunId()] = struct{}{}
+		deduplicated = append(deduplicated, record)
+	}
+	sortRecords(deduplicated)
+	return deduplicated, nil
+}
+
+func (a *VisibilityArchive) bucketName(closeTime time.Time) string {
+	return closeTime.UTC().Truncate(a.bucketInterval).Format(bucketFormat)
+}
+
+func readStagedRecords(
+	ctx context.Context,
+	storage Storage,
+	dir string,
+	names []string,
+) ([]*archiverspb.VisibilityRecord, error) {
+	records := make([]*archiverspb.VisibilityRecord, 0, len(names))
+	for _, name := range names {
+		data, err := storage.Get(ctx, path.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		record := &archiverspb.VisibilityRecord{}
+		if err := proto.Unmarshal(data, record); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func stagedRecordName(closeTime time.Time, runID string) string {
+	return strconv.FormatInt(closeTime.UnixNano(), 10) + "_" + runID + stagedRecordSuffix
+}
+
+// sortRecords sorts the records by close time, latest first, and by run ID to break ties.
+func sortRecords(records []*archiverspb.VisibilityRecord) {
+	sort.Slice(records, func(i, j int) bool {
+		return recordBefore(
+			records[j].GetCloseTime().AsTime(), records[j].GetRunId(),
+			records[i].GetCloseTime().AsTime(), records[i].GetRunId(),
+		)
+	})
+}
+
+// recordBefore returns whether the record of the close time and run ID is ordered before the other one, that is
+// whether it is returned after it by the queries.
+func recordBefore(closeTime time.Time, runID string, otherCloseTime time.Time, otherRunID string) bool {
+	if closeTime.Equal(otherCloseTime) {
+		return runID < otherRunID
+	}
+	return closeTime.Before(otherCloseTime)
+}
+
+func truncateTimestamp(t *timestamppb.Timestamp) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(t.AsTime().Truncate(time.Microsecond))
+}
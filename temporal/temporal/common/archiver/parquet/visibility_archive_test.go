@@ -0,0 +1,214 @@
+package parquet
+
+import (
+	"context"
+	"errors"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	archiverspb "go.temporal.io/server/api/archiver/v1"
+	"go.temporal.io/server/common/archiver"
+	"go.temporal.io/server/common/clock"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const testDir = "archive/namespace-id"
+
+type memoryStorage struct {
+	sync.Mutex
+	blobs map[string][]byte
+}
+
+func newMemoryStorage() *memoryStorage {
+	return &memoryStorage{blobs: make(map[string][]byte)}
+}
+
+func (s *memoryStorage) Put(_ context.Context, key string, data []byte) error {
+	s.Lock()
+	defer s.Unlock()
+	s.blobs[key] = data
+	return nil
+}
+
+func (s *memoryStorage) Get(_ context.Context, key string) ([]byte, error) {
+	s.Lock()
+	defer s.Unlock()
+	data, ok := s.blobs[key]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return data, nil
+}
+
+func (s *memoryStorage) Delete(_ context.Context, key string) error {
+	s.Lock()
+	defer s.Unlock()
+	delete(s.blobs, key)
+	return nil
+}
+
+func (s *memoryStorage) List(_ context.Context, dir string) ([]string, error) {
+	s.Lock()
+	defer s.Unlock()
+	names := make(map[string]struct{})
+	for key := range s.blobs {
+		if rest, ok := strings.CutPrefix(key, dir+"/"); ok {
+			name, _, _ := strings.Cut(rest, "/")
+			names[name] = struct{}{}
+		}
+	}
+	var result []string
+	for name := range names {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+func (s *memoryStorage) keys(dir string) []string {
+	s.Lock()
+	defer s.Unlock()
+	var keys []string
+	for key := range s.blobs {
+		if strings.HasPrefix(key, dir+"/") {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func newTestRecord(runID string, closeTime time.Time) *archiverspb.VisibilityRecord {
+	return &archiverspb.VisibilityRecord{
+		NamespaceId:      "namespace-id",
+		WorkflowId:       "workflow-" + runID,
+		RunId:            runID,
+		WorkflowTypeName: "workflow-type",
+		CloseTime:        timestamppb.New(closeTime),
+	}
+}
+
+func queryRunIDs(t *testing.T, a *VisibilityArchive, storage Storage, request *QueryRequest) ([]string, []byte) {
+	response, err := a.Query(context.Background(), storage, testDir, request)
+	require.NoError(t, err)
+	var runIDs []string
+	for _, record := range response.Records {
+		runIDs = append(runIDs, record.GetRunId())
+	}
+	return runIDs, response.NextPageToken
+}
+
+func TestVisibilityArchive_ArchiveAndCompact(t *testing.T) {
+	ctx := context.Background()
+	storage := newMemoryStorage()
+	start := time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)
+	timeSource := clock.NewEventTimeSource().Update(start.Add(30 * time.Minute))
+	a := NewVisibilityArchive(time.Hour, timeSource)
+
+	require.NoError(t, a.Archive(ctx, storage, testDir, newTestRecord("1", start.Add(10*time.Minute))))
+	require.NoError(t, a.Archive(ctx, storage, testDir, newTestRecord("2", start.Add(20*time.Minute))))
+	require.Equal(t, []string{
+		path.Join(testDir, "staging/20240506T000000Z", "1714954200000000000_1.visibility"),
+		path.Join(testDir, "staging/20240506T000000Z", "1714954800000000000_2.visibility"),
+	}, storage.keys(testDir))
+
+	// The bucket isn't compacted until it is sealed, one bucket interval after its end.
+	timeSource.Update(start.Add(2*time.Hour - time.Nanosecond))
+	require.NoError(t, a.Compact(ctx, storage, testDir, nil))
+	require.Empty(t, storage.keys(path.Join(testDir, batchesDir)))
+
+	timeSource.Update(start.Add(2 * time.Hour))
+	require.NoError(t, a.Archive(ctx, storage, testDir, newTestRecord("3", start.Add(2*time.Hour))))
+	require.NoError(t, a.Compact(ctx, storage, testDir, nil))
+	keys := storage.keys(testDir)
+	require.Len(t, keys, 2)
+	require.True(t, strings.HasPrefix(keys[0], path.Join(testDir, "batches/20240506T000000Z")+"/"))
+	require.True(t, strings.HasSuffix(keys[0], ".parquet"))
+	require.Equal(t, path.Join(testDir, "staging/20240506T020000Z/1714960800000000000_3.visibility"), keys[1])
+
+	records, err := DecodeVisibilityRecords(storage.blobs[keys[0]])
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	require.Equal(t, "2", records[0].GetRunId())
+	require.Equal(t, "1", records[1].GetRunId())
+
+	// A late record of a sealed bucket is compacted into another file of the bucket.
+	require.NoError(t, a.Archive(ctx, storage, testDir, newTestRecord("4", start.Add(30*time.Minute))))
+	require.NoError(t, a.Compact(ctx, storage, testDir, nil))
+	keys = storage.keys(path.Join(testDir, batchesDir))
+	require.Len(t, keys, 2)
+
+	runIDs, _ := queryRunIDs(t, a, storage, &QueryRequest{PageSize: 10})
+	require.Equal(t, []string{"3", "4", "2", "1"}, runIDs)
+}
+
+func TestVisibilityArchive_Query(t *testing.T) {
+	ctx := context.Background()
+	storage := newMemoryStorage()
+	start := time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)
+	timeSource := clock.NewEventTimeSource().Update(start)
+	a := NewVisibilityArchive(time.Hour, timeSource)
+
+	for i, runID := range []string{"a", "b", "c", "d", "e", "f"} {
+		closeTime := start.Add(time.Duration(i) * 40 * time.Minute)
+		timeSource.Update(closeTime)
+		require.NoError(t, a.Archive(ctx, storage, testDir, newTestRecord(runID, closeTime)))
+		require.NoError(t, a.Compact(ctx, storage, testDir, nil))
+	}
+	// The query merges the compacted and the staged records.
+	require.NotEmpty(t, storage.keys(path.Join(testDir, batchesDir)))
+	require.NotEmpty(t, storage.keys(path.Join(testDir, stagingDir)))
+
+	runIDs, token := queryRunIDs(t, a, storage, &QueryRequest{PageSize: 4})
+	require.Equal(t, []string{"f", "e", "d", "c"}, runIDs)
+	runIDs, token = queryRunIDs(t, a, storage, &QueryRequest{PageSize: 4, NextPageToken: token})
+	require.Equal(t, []string{"b", "a"}, runIDs)
+	require.Nil(t, token)
+
+	runIDs, _ = queryRunIDs(t, a, storage, &QueryRequest{
+		PageSize:          10,
+		EarliestCloseTime: start.Add(40 * time.Minute),
+		LatestCloseTime:   start.Add(160 * time.Minute),
+	})
+	require.Equal(t, []string{"e", "d", "c", "b"}, runIDs)
+
+	runIDs, _ = queryRunIDs(t, a, storage, &QueryRequest{
+		PageSize: 10,
+		Filter: func(record *archiverspb.VisibilityRecord) bool {
+			return record.GetRunId() != "c"
+		},
+	})
+	require.Equal(t, []string{"f", "e", "d", "b", "a"}, runIDs)
+
+	_, err := a.Query(ctx, storage, testDir, &QueryRequest{PageSize: 10, NextPageToken: []byte("invalid")})
+	require.ErrorIs(t, err, archiver.ErrNextPageTokenCorrupted)
+
+	runIDs, _ = queryRunIDs(t, a, storage, &QueryRequest{MaxRecords: 6})
+	require.Len(t, runIDs, 6)
+	_, err = a.Query(ctx, storage, testDir, &QueryRequest{MaxRecords: 5})
+	require.ErrorIs(t, err, ErrTooManyRecords)
+}
+
+func TestVisibilityArchive_QueryDuplicates(t *testing.T) {
+	ctx := context.Background()
+	storage := newMemoryStorage()
+	start := time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)
+	timeSource := clock.NewEventTimeSource().Update(start.Add(3 * time.Hour))
+	a := NewVisibilityArchive(time.Hour, timeSource)
+
+	// The staged record is left behind, as if its deletion failed after the compaction.
+	record := newTestRecord("a", start.Add(123456789*time.Nanosecond))
+	require.NoError(t, a.Archive(ctx, storage, testDir, record))
+	require.NoError(t, a.Compact(ctx, storage, testDir, nil))
+	require.NotEmpty(t, storage.keys(path.Join(testDir, batchesDir)))
+	require.NoError(t, a.Archive(ctx, storage, testDir, record))
+	require.NotEmpty(t, storage.keys(path.Join(testDir, stagingDir)))
+
+	runIDs, _ := queryRunIDs(t, a, storage, &QueryRequest{PageSize: 10})
+	require.Equal(t, []string{"a"}, runIDs)
+}
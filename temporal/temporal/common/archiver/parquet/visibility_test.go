@@ -0,0 +1,110 @@
+package parquet
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	archiverspb "go.temporal.io/server/api/archiver/v1"
+	"go.temporal.io/server/common/payload"
+	"go.temporal.io/server/common/searchattribute"
+	"go.temporal.io/server/common/testing/protorequire"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestEncodeDecodeVisibilityRecords(t *testing.T) {
+	closeTime := time.Date(2024, 5, 6, 7, 8, 9, 123456000, time.UTC)
+	records := []*archiverspb.VisibilityRecord{
+		{
+			NamespaceId:        "namespace-id",
+			Namespace:          "namespace",
+			WorkflowId:         "workflow-id",
+			RunId:              "run-id",
+			WorkflowTypeName:   "workflow-type",
+			StartTime:          timestamppb.New(closeTime.Add(-time.Hour)),
+			ExecutionTime:      timestamppb.New(closeTime.Add(-time.Hour)),
+			CloseTime:          timestamppb.New(closeTime),
+			ExecutionDuration:  durationpb.New(time.Hour),
+			Status:             enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED,
+			HistoryLength:      42,
+			Memo:               &commonpb.Memo{Fields: map[string]*commonpb.Payload{"memo": payload.EncodeString("value")}},
+			HistoryArchivalUri: "file:///tmp/history",
+			SearchAttributes: map[string]string{
+				"CustomIntField":      "123",
+				"CustomDoubleField":   "1.5",
+				"CustomBoolField":     "true",
+				"CustomDatetimeField": "2024-05-06T07:08:09.123456Z",
+				"CustomKeywordField":  "keyword",
+				"CustomKeywordList":   `["a","b"]`,
+			},
+		},
+		{
+			NamespaceId:      "namespace-id",
+			Namespace:        "namespace",
+			WorkflowId:       "other-workflow-id",
+			RunId:            "other-run-id",
+			WorkflowTypeName: "workflow-type",
+			CloseTime:        timestamppb.New(closeTime),
+			Status:           enumspb.WORKFLOW_EXECUTION_STATUS_FAILED,
+			SearchAttributes: map[string]string{
+				"CustomIntField": "456",
+				"UnknownField":   "unknown",
+			},
+		},
+	}
+	saTypeMap := searchattribute.TestEsNameTypeMap()
+
+	data, err := EncodeVisibilityRecords(records, &saTypeMap)
+	require.NoError(t, err)
+
+	columns, _, err := readFile(data)
+	require.NoError(t, err)
+	columnTypes := make(map[string]columnType, len(columns))
+	for _, c := range columns {
+		columnTypes[c.name] = c.columnType
+	}
+	require.Equal(t, columnTypeInt64, columnTypes["sa_CustomIntField"])
+	require.Equal(t, columnTypeDouble, columnTypes["sa_CustomDoubleField"])
+	require.Equal(t, columnTypeBool, columnTypes["sa_CustomBoolField"])
+	require.Equal(t, columnTypeTimestamp, columnTypes["sa_CustomDatetimeField"])
+	require.Equal(t, columnTypeString, columnTypes["sa_CustomKeywordField"])
+	require.Equal(t, columnTypeString, columnTypes["sa_UnknownField"])
+	require.Equal(t, columnTypeTimestamp, columnTypes[columnCloseTime])
+
+	decoded, err := DecodeVisibilityRecords(data)
+	require.NoError(t, err)
+	protorequire.ProtoSliceEqual(t, records, decoded)
+}
+
+func TestEncodeVisibilityRecords_MismatchedSearchAttributeType(t *testing.T) {
+	records := []*archiverspb.VisibilityRecord{
+		{RunId: "1", SearchAttributes: map[string]string{"CustomIntField": "1"}},
+		{RunId: "2", SearchAttributes: map[string]string{"CustomIntField": "[1,2]"}},
+		{RunId: "3"},
+	}
+	saTypeMap := searchattribute.TestEsNameTypeMap()
+
+	data, err := EncodeVisibilityRecords(records, &saTypeMap)
+	require.NoError(t, err)
+	columns, _, err := readFile(data)
+	require.NoError(t, err)
+	saColumn := columns[slices.IndexFunc(columns, func(c *column) bool { return c.name == "sa_CustomIntField" })]
+	require.Equal(t, columnTypeString, saColumn.columnType)
+
+	decoded, err := DecodeVisibilityRecords(data)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"CustomIntField": "1"}, decoded[0].SearchAttributes)
+	require.Equal(t, map[string]string{"CustomIntField": "[1,2]"}, decoded[1].SearchAttributes)
+	require.Empty(t, decoded[2].SearchAttributes)
+}
+
+func TestDecodeVisibilityRecords_UnknownSchema(t *testing.T) {
+	data, err := writeFile([]*column{{name: columnRunID, columnType: columnTypeString, values: []any{"run-id"}}}, nil)
+	require.NoError(t, err)
+	_, err = DecodeVisibilityRecords(data)
+	require.ErrorIs(t, err, errInvalidFile)
+}
@@ -10,6 +10,7 @@ import (
 	"go.temporal.io/server/common/archiver/filestore"
 	"go.temporal.io/server/common/archiver/gcloud"
 	"go.temporal.io/server/common/archiver/s3store"
+	"go.temporal.io/server/common/archiver/sqlstore"
 	"go.temporal.io/server/common/config"
 	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/metrics"
@@ -93,6 +94,11 @@ func (p *archiverProvider) GetHistoryArchiver(scheme string) (historyArchiver ar
 			return nil, ErrArchiverConfigNotFound
 		}
 		historyArchiver, err = s3store.NewHistoryArchiver(p.executionManager, p.logger, p.metricsHandler, p.historyArchiverConfigs.S3store)
+	case sqlstore.URIScheme:
+		if p.historyArchiverConfigs.SQLStore == nil {
+			return nil, ErrArchiverConfigNotFound
+		}
+		historyArchiver, err = sqlstore.NewHistoryArchiver(p.executionManager, p.logger, p.metricsHandler, p.historyArchiverConfigs.SQLStore)
 	default:
 		return nil, ErrUnknownScheme
 	}
@@ -137,6 +143,11 @@ func (p *archiverProvider) GetVisibilityArchiver(scheme string) (archiver.Visibi
 			return nil, ErrArchiverConfigNotFound
 		}
 		visibilityArchiver, err = gcloud.NewVisibilityArchiver(p.logger, p.metricsHandler, p.visibilityArchiverConfigs.Gstorage)
+	case sqlstore.URIScheme:
+		if p.visibilityArchiverConfigs.SQLStore == nil {
+			return nil, ErrArchiverConfigNotFound
+		}
+		visibilityArchiver, err = sqlstore.NewVisibilityArchiver(p.logger, p.metricsHandler, p.visibilityArchiverConfigs.SQLStore)
 
 	default:
 		return nil, ErrUnknownScheme
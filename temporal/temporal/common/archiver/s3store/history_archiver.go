@@ -33,6 +33,10 @@ const (
 	URIScheme               = "s3"
 	errEncodeHistory        = "failed to encode history batches"
 	errWriteKey             = "failed to write history to s3"
+	errEncodeManifest       = "failed to encode history manifest"
+	errWriteManifest        = "failed to write history manifest to s3"
+	errEncodeChasmExecution = "failed to encode CHASM execution"
+	errWriteChasmExecution  = "failed to write CHASM execution to s3"
 	defaultBlobstoreTimeout = time.Minute
 	targetHistoryBlobSize   = 2 * 1024 * 1024 // 2MB
 )
@@ -61,8 +65,10 @@ type (
 	uploadProgress struct {
 		BatchIdx      int
 		IteratorState []byte
-		uploadedSize  int64
-		historySize   int64
+		// ManifestBatches are the manifest batches of the history blobs which are already uploaded.
+		ManifestBatches []*archiverspb.HistoryManifestBatch
+		uploadedSize    int64
+		historySize     int64
 	}
 )
 
@@ -177,6 +183,11 @@ func (h *historyArchiver) Archive(
 			logger.Error(archiver.ArchiveNonRetryableErrorMsg, tag.ArchivalArchiveFailReason(errEncodeHistory), tag.Error(err))
 			return err
 		}
+		manifestBatches, err := archiver.NewHistoryManifestBatches(historyBlob.Body)
+		if err != nil {
+			logger.Error(archiver.ArchiveNonRetryableErrorMsg, tag.ArchivalArchiveFailReason(errEncodeManifest), tag.Error(err))
+			return err
+		}
 		key := constructHistoryKey(URI.Path(), request.NamespaceID, request.WorkflowID, request.RunID, request.CloseFailoverVersion, progress.BatchIdx)
 
 		exists, err := KeyExists(ctx, h.s3cli, URI, key)
@@ -206,9 +217,25 @@ func (h *historyArchiver) Archive(
 
 		progress.historySize += blobSize
 		progress.BatchIdx = progress.BatchIdx + 1
+		progress.ManifestBatches = append(progress.ManifestBatches, manifestBatches...)
 		saveHistoryIteratorState(ctx, featureCatalog, historyIterator, &progress)
 	}
 
+	encodedManifest, err := codec.NewJSONPBEncoder().Encode(archiver.NewHistoryManifest(request, progress.ManifestBatches))
+	if err != nil {
+		logger.Error(archiver.ArchiveNonRetryableErrorMsg, tag.ArchivalArchiveFailReason(errEncodeManifest), tag.Error(err))
+		return err
+	}
+	manifestKey := constructHistoryManifestKey(URI.Path(), request.NamespaceID, request.WorkflowID, request.RunID, request.CloseFailoverVersion)
+	if err := Upload(ctx, h.s3cli, URI, manifestKey, encodedManifest); err != nil {
+		if isRetryableError(err) {
+			logger.Error(archiver.ArchiveTransientErrorMsg, tag.ArchivalArchiveFailReason(errWriteManifest), tag.Error(err))
+		} else {
+			logger.Error(archiver.ArchiveNonRetryableErrorMsg, tag.ArchivalArchiveFailReason(errWriteManifest), tag.Error(err))
+		}
+		return err
+	}
+
 	handler.Histogram(metrics.HistoryArchiverTotalUploadSize.Name(), metrics.HistoryArchiverTotalUploadSize.Unit()).Record(progress.uploadedSize)
 	handler.Histogram(metrics.HistoryArchiverHistorySize.Name(), metrics.HistoryArchiverHistorySize.Unit()).Record(progress.historySize)
 	metrics.HistoryArchiverArchiveSuccessCount.With(handler).Record(1)
@@ -227,6 +254,7 @@ func loadHistoryIterator(ctx context.Context, request *archiver.ArchiveHistoryRe
 			}
 			progress.IteratorState = nil
 			progress.BatchIdx = 0
+			progress.ManifestBatches = nil
 			progress.historySize = 0
 			progress.uploadedSize = 0
 		}
@@ -337,6 +365,148 @@ func (h *historyArchiver) Get(
 	return response, nil
 }
 
+func (h *historyArchiver) GetManifest(
+	ctx context.Context,
+	URI archiver.URI,
+	request *archiver.GetHistoryRequest,
+) (*archiverspb.HistoryManifest, error) {
+	if err := SoftValidateURI(URI); err != nil {
+		return nil, serviceerror.NewInvalidArgument(archiver.ErrInvalidURI.Error())
+	}
+
+	if err := archiver.ValidateGetRequest(request); err != nil {
+		return nil, serviceerror.NewInvalidArgument(archiver.ErrInvalidGetHistoryRequest.Error())
+	}
+
+	version := request.CloseFailoverVersion
+	if version == nil {
+		highestVersion, err := h.getHighestVersion(ctx, URI, request)
+		if err != nil {
+			if err == archiver.ErrHistoryNotExist {
+				return nil, serviceerror.NewNotFound(err.Error())
+			}
+			return nil, serviceerror.NewInvalidArgument(err.Error())
+		}
+		version = highestVersion
+	}
+
+	key := constructHistoryManifestKey(URI.Path(), request.NamespaceID, request.WorkflowID, request.RunID, *version)
+	encodedManifest, err := Download(ctx, h.s3cli, URI, key)
+	if err != nil {
+		if isRetryableError(err) {
+			return nil, serviceerror.NewUnavailable(err.Error())
+		}
+		switch err.(type) {
+		case *serviceerror.NotFound:
+			return nil, serviceerror.NewNotFound(archiver.ErrManifestNotExist.Error())
+		case *serviceerror.InvalidArgument, *serviceerror.Unavailable:
+			return nil, err
+		default:
+			return nil, serviceerror.NewInternal(err.Error())
+		}
+	}
+
+	manifest := &archiverspb.HistoryManifest{}
+	if err := codec.NewJSONPBEncoder().Decode(encodedManifest, manifest); err != nil {
+		return nil, serviceerror.NewInternal(err.Error())
+	}
+	return manifest, nil
+}
+
+func (h *historyArchiver) ArchiveChasmExecution(
+	ctx context.Context,
+	URI archiver.URI,
+	request *archiverspb.ChasmExecution,
+	opts ...archiver.ArchiveOption,
+) (err error) {
+	featureCatalog := archiver.GetFeatureCatalog(opts...)
+	defer func() {
+		if err != nil && !common.IsPersistenceTransientError(err) && !isRetryableError(err) && featureCatalog.NonRetryableError != nil {
+			err = featureCatalog.NonRetryableError()
+		}
+	}()
+
+	logger := archiver.TagLoggerWithArchiveChasmExecutionRequestAndURI(h.logger, request, URI.String())
+
+	if err := SoftValidateURI(URI); err != nil {
+		logger.Error(archiver.ArchiveNonRetryableErrorMsg, tag.ArchivalArchiveFailReason(archiver.ErrReasonInvalidURI), tag.Error(err))
+		return err
+	}
+
+	if err := archiver.ValidateChasmExecutionArchivalRequest(request); err != nil {
+		logger.Error(archiver.ArchiveNonRetryableErrorMsg, tag.ArchivalArchiveFailReason(archiver.ErrReasonInvalidArchiveRequest), tag.Error(err))
+		return err
+	}
+
+	encodedExecution, err := codec.NewJSONPBEncoder().Encode(request)
+	if err != nil {
+		logger.Error(archiver.ArchiveNonRetryableErrorMsg, tag.ArchivalArchiveFailReason(errEncodeChasmExecution), tag.Error(err))
+		return err
+	}
+
+	key := constructChasmExecutionKey(URI.Path(), request.GetNamespaceId(), request.GetBusinessId(), request.GetRunId(), request.GetCloseFailoverVersion())
+	if err := Upload(ctx, h.s3cli, URI, key, encodedExecution); err != nil {
+		if isRetryableError(err) {
+			logger.Error(archiver.ArchiveTransientErrorMsg, tag.ArchivalArchiveFailReason(errWriteChasmExecution), tag.Error(err))
+		} else {
+			logger.Error(archiver.ArchiveNonRetryableErrorMsg, tag.ArchivalArchiveFailReason(errWriteChasmExecution), tag.Error(err))
+		}
+		return err
+	}
+	return nil
+}
+
+func (h *historyArchiver) GetChasmExecution(
+	ctx context.Context,
+	URI archiver.URI,
+	request *archiver.GetChasmExecutionRequest,
+) (*archiverspb.ChasmExecution, error) {
+	if err := SoftValidateURI(URI); err != nil {
+		return nil, serviceerror.NewInvalidArgument(archiver.ErrInvalidURI.Error())
+	}
+
+	if err := archiver.ValidateGetChasmExecutionRequest(request); err != nil {
+		return nil, serviceerror.NewInvalidArgument(archiver.ErrInvalidGetChasmExecutionRequest.Error())
+	}
+
+	version := request.CloseFailoverVersion
+	if version == nil {
+		highestVersion, err := h.getHighestChasmExecutionVersion(ctx, URI, request)
+		if err != nil {
+			if isRetryableError(err) {
+				return nil, serviceerror.NewUnavailable(err.Error())
+			}
+			return nil, serviceerror.NewInvalidArgument(err.Error())
+		}
+		if highestVersion == nil {
+			return nil, serviceerror.NewNotFound(archiver.ErrChasmExecutionNotExist.Error())
+		}
+		version = highestVersion
+	}
+
+	key := constructChasmExecutionKey(URI.Path(), request.NamespaceID, request.BusinessID, request.RunID, *version)
+	encodedExecution, err := Download(ctx, h.s3cli, URI, key)
+	if err != nil {
+		if isRetryableError(err) {
+			return nil, serviceerror.NewUnavailable(err.Error())
+		}
+		switch err.(type) {
+		case *serviceerror.NotFound:
+			return nil, serviceerror.NewNotFound(archiver.ErrChasmExecutionNotExist.Error())
+		case *serviceerror.InvalidArgument, *serviceerror.Unavailable:
+			return nil, err
+		default:
+			return nil, serviceerror.NewInternal(err.Error())
+		}
+	}
+
+	execution := &archiverspb.ChasmExecution{}
+	if err := codec.NewJSONPBEncoder().Decode(encodedExecution, execution); err != nil {
+		return nil, serviceerror.NewInternal(err.Error())
+	}
+	return execution, nil
+}
+
 func (h *historyArchiver) ValidateURI(URI archiver.URI) error {
 	err := SoftValidateURI(URI)
 	if err != nil {
@@ -378,6 +548,35 @@ func (h *historyArchiver) getHighestVersion(ctx context.Context, URI archiver.UR
 	return highestVersion, nil
 }
 
+// getHighestChasmExecutionVersion returns the highest close failover version of the archived CHASM execution, or nil
+// if it is not archived.
+func (h *historyArchiver) getHighestChasmExecutionVersion(ctx context.Context, URI archiver.URI, request *archiver.GetChasmExecutionRequest) (*int64, error) {
+	ctx, cancel := ensureContextTimeout(ctx)
+	defer cancel()
+	var prefix = constructChasmExecutionKeyPrefix(URI.Path(), request.NamespaceID, request.BusinessID, request.RunID) + "/"
+	results, err := h.s3cli.ListObjectsV2WithContext(ctx, &s3.ListObjectsV2Input{
+		Bucket: aws.String(URI.Hostname()),
+		Prefix: aws.String(prefix),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == s3.ErrCodeNoSuchBucket {
+			return nil, errBucketNotExists
+		}
+		return nil, err
+	}
+	var highestVersion *int64
+	for _, object := range results.Contents {
+		version, err := strconv.ParseInt(strings.TrimPrefix(*object.Key, prefix), 10, 64)
+		if err != nil {
+			continue
+		}
+		if highestVersion == nil || version > *highestVersion {
+			highestVersion = &version
+		}
+	}
+	return highestVersion, nil
+}
+
 func isRetryableError(err error) bool {
 	if err == nil {
 		return false
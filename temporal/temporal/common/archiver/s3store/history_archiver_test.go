@@ -18,10 +18,13 @@ import (
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	commonpb "go.temporal.io/api/common/v1"
 	enumspb "go.temporal.io/api/enums/v1"
 	historypb "go.temporal.io/api/history/v1"
 	"go.temporal.io/api/serviceerror"
 	archiverspb "go.temporal.io/server/api/archiver/v1"
+	enumsspb "go.temporal.io/server/api/enums/v1"
+	persistencespb "go.temporal.io/server/api/persistence/v1"
 	"go.temporal.io/server/common"
 	"go.temporal.io/server/common/archiver"
 	"go.temporal.io/server/common/archiver/s3store/mocks"
@@ -29,6 +32,7 @@ import (
 	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/metrics"
 	"go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/testing/protorequire"
 	"go.temporal.io/server/common/util"
 	"go.uber.org/mock/gomock"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -177,6 +181,12 @@ func setupFsEmulation(s3cli *mocks.MockS3API) {
 		}).AnyTimes()
 	s3cli.EXPECT().PutObjectWithContext(gomock.Any(), gomock.Any()).DoAndReturn(putObjectFn).AnyTimes()
 
+	s3cli.EXPECT().DeleteObjectWithContext(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ aws.Context, input *s3.DeleteObjectInput, _ ...request.Option) (*s3.DeleteObjectOutput, error) {
+			delete(fs, *input.Bucket+*input.Key)
+			return &s3.DeleteObjectOutput{}, nil
+		}).AnyTimes()
+
 	s3cli.EXPECT().HeadObjectWithContext(gomock.Any(), gomock.Any()).DoAndReturn(
 		func(ctx aws.Context, input *s3.HeadObjectInput, options ...request.Option) (*s3.HeadObjectOutput, error) {
 			_, ok := fs[*input.Bucket+*input.Key]
@@ -667,7 +677,117 @@ func (s *historyArchiverSuite) TestArchiveAndGet() {
 	s.NoError(err)
 	s.NotNil(response)
 	s.Nil(response.NextPageToken)
-	s.Equal(append(s.historyBatchesV100[0].Body, s.historyBatchesV100[1].Body...), response.HistoryBatches)
+	// archiving the batches caches their size, so they are compared as protos
+	protorequire.ProtoSliceEqual(s.T(), append(s.historyBatchesV100[0].Body, s.historyBatchesV100[1].Body...), response.HistoryBatches)
+}
+
+func (s *historyArchiverSuite) TestArchiveAndVerify() {
+	historyIterator := archiver.NewMockHistoryIterator(s.controller)
+	gomock.InOrder(
+		historyIterator.EXPECT().HasNext().Return(true),
+		historyIterator.EXPECT().Next(gomock.Any()).Return(s.historyBatchesV100[0], nil),
+		historyIterator.EXPECT().HasNext().Return(true),
+		historyIterator.EXPECT().Next(gomock.Any()).Return(s.historyBatchesV100[1], nil),
+		historyIterator.EXPECT().HasNext().Return(false),
+	)
+
+	historyArchiver := s.newTestHistoryArchiver(historyIterator)
+	archiveRequest := &archiver.ArchiveHistoryRequest{
+		NamespaceID:          testNamespaceID,
+		Namespace:            testNamespace,
+		WorkflowID:           testWorkflowID,
+		RunID:                testRunID,
+		BranchToken:          testBranchToken,
+		NextEventID:          testNextEventID,
+		CloseFailoverVersion: testCloseFailoverVersion,
+	}
+	URI, err := archiver.NewURI(testBucketURI + "/TestArchiveAndVerify")
+	s.NoError(err)
+	s.NoError(historyArchiver.Archive(context.Background(), URI, archiveRequest))
+	s.assertKeyExists(constructHistoryManifestKey(URI.Path(), testNamespaceID, testWorkflowID, testRunID, testCloseFailoverVersion))
+
+	verifyRequest := &archiver.VerifyHistoryRequest{
+		NamespaceID: testNamespaceID,
+		WorkflowID:  testWorkflowID,
+		RunID:       testRunID,
+	}
+	verification, err := archiver.VerifyHistory(context.Background(), historyArchiver, URI, verifyRequest)
+	s.NoError(err)
+	s.Equal(enumsspb.ARCHIVAL_INTEGRITY_STATUS_OK, verification.Status)
+	s.Equal(int64(testCloseFailoverVersion), verification.CloseFailoverVersion)
+
+	// the first blob is rewritten as the last one
+	data, err := codec.NewJSONPBEncoder().Encode(&archiverspb.HistoryBlob{
+		Header: &archiverspb.HistoryBlobHeader{IsLast: true},
+		Body:   s.historyBatchesV100[0].Body,
+	})
+	s.NoError(err)
+	_, err = s.s3cli.PutObjectWithContext(context.Background(), &s3.PutObjectInput{
+		Bucket: aws.String(testBucket),
+		Key:    aws.String(constructHistoryKey(URI.Path(), testNamespaceID, testWorkflowID, testRunID, testCloseFailoverVersion, 0)),
+		Body:   bytes.NewReader(data),
+	})
+	s.NoError(err)
+	verification, err = archiver.VerifyHistory(context.Background(), historyArchiver, URI, verifyRequest)
+	s.NoError(err)
+	s.Equal(enumsspb.ARCHIVAL_INTEGRITY_STATUS_TRUNCATED, verification.Status)
+}
+
+func (s *historyArchiverSuite) TestVerify_NoManifest() {
+	historyArchiver := s.newTestHistoryArchiver(nil)
+	verification, err := archiver.VerifyHistory(context.Background(), historyArchiver, s.testArchivalURI, &archiver.VerifyHistoryRequest{
+		NamespaceID: testNamespaceID,
+		WorkflowID:  testWorkflowID,
+		RunID:       testRunID,
+	})
+	s.NoError(err)
+	s.Equal(enumsspb.ARCHIVAL_INTEGRITY_STATUS_UNVERIFIED, verification.Status)
+	s.Equal(int64(0), verification.CloseFailoverVersion)
+}
+
+func (s *historyArchiverSuite) TestArchiveChasmExecution_Fail_InvalidRequest() {
+	historyArchiver := s.newTestHistoryArchiver(nil)
+	execution := newTestChasmExecution(testCloseFailoverVersion)
+	execution.Nodes = nil
+	err := historyArchiver.ArchiveChasmExecution(context.Background(), s.testArchivalURI, execution)
+	s.Error(err)
+}
+
+func (s *historyArchiverSuite) TestArchiveAndGetChasmExecution() {
+	historyArchiver := s.newTestHistoryArchiver(nil)
+	URI, err := archiver.NewURI(testBucketURI + "/TestArchiveAndGetChasmExecution")
+	s.NoError(err)
+
+	getRequest := &archiver.GetChasmExecutionRequest{
+		NamespaceID: testNamespaceID,
+		BusinessID:  testWorkflowID,
+		RunID:       testRunID,
+	}
+	_, err = historyArchiver.GetChasmExecution(context.Background(), URI, getRequest)
+	s.IsType(&serviceerror.NotFound{}, err)
+
+	executionV1 := newTestChasmExecution(1)
+	executionV100 := newTestChasmExecution(testCloseFailoverVersion)
+	s.NoError(historyArchiver.ArchiveChasmExecution(context.Background(), URI, executionV1))
+	s.NoError(historyArchiver.ArchiveChasmExecution(context.Background(), URI, executionV100))
+	s.assertKeyExists(constructChasmExecutionKey(URI.Path(), testNamespaceID, testWorkflowID, testRunID, testCloseFailoverVersion))
+
+	// the highest version is returned if no version is requested
+	execution, err := historyArchiver.GetChasmExecution(context.Background(), URI, getRequest)
+	s.NoError(err)
+	protorequire.ProtoEqual(s.T(), executionV100, execution)
+
+	version := int64(1)
+	getRequest.CloseFailoverVersion = &version
+	execution, err = historyArchiver.GetChasmExecution(context.Background(), URI, getRequest)
+	s.NoError(err)
+	protorequire.ProtoEqual(s.T(), executionV1, execution)
+
+	// the archived history of the same run is not mistaken for the CHASM execution
+	getRequest.CloseFailoverVersion = nil
+	getRequest.RunID = "other-run-id"
+	_, err = historyArchiver.GetChasmExecution(context.Background(), URI, getRequest)
+	s.IsType(&serviceerror.NotFound{}, err)
 }
 
 func (s *historyArchiverSuite) newTestHistoryArchiver(historyIterator archiver.HistoryIterator) *historyArchiver {
@@ -775,3 +895,29 @@ func getCanceledContext() context.Context {
 	cancel()
 	return ctx
 }
+
+func newTestChasmExecution(version int64) *archiverspb.ChasmExecution {
+	return &archiverspb.ChasmExecution{
+		NamespaceId:          testNamespaceID,
+		Namespace:            testNamespace,
+		BusinessId:           testWorkflowID,
+		RunId:                testRunID,
+		ArchetypeId:          1234,
+		Archetype:            "scheduler.scheduler",
+		CloseFailoverVersion: version,
+		CloseTime:            timestamppb.New(time.Date(2020, 8, 22, 1, 2, 3, 4, time.UTC)),
+		Nodes: map[string]*persistencespb.ChasmNode{
+			"": {
+				Metadata: &persistencespb.ChasmNodeMetadata{
+					Attributes: &persistencespb.ChasmNodeMetadata_ComponentAttributes{
+						ComponentAttributes: &persistencespb.ChasmComponentAttributes{TypeId: 1234},
+					},
+				},
+				Data: &commonpb.DataBlob{
+					EncodingType: enumspb.ENCODING_TYPE_PROTO3,
+					Data:         []byte{byte(version)},
+				},
+			},
+		},
+	}
+}
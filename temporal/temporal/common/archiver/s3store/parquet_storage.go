@@ -0,0 +1,78 @@
+package s3store
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"go.temporal.io/server/common/archiver"
+	"go.temporal.io/server/common/archiver/parquet"
+)
+
+type (
+	// parquetStorage is the parquet.Storage of the Parquet visibility archives of a bucket, whose keys are object
+	// keys.
+	parquetStorage struct {
+		s3cli s3iface.S3API
+		URI   archiver.URI
+	}
+)
+
+var _ parquet.Storage = (*parquetStorage)(nil)
+
+func newParquetStorage(s3cli s3iface.S3API, URI archiver.URI) *parquetStorage {
+	return &parquetStorage{
+		s3cli: s3cli,
+		URI:   URI,
+	}
+}
+
+func (s *parquetStorage) Put(ctx context.Context, key string, data []byte) error {
+	return Upload(ctx, s.s3cli, s.URI, key, data)
+}
+
+func (s *parquetStorage) Get(ctx context.Context, key string) ([]byte, error) {
+	return Download(ctx, s.s3cli, s.URI, key)
+}
+
+func (s *parquetStorage) Delete(ctx context.Context, key string) error {
+	ctx, cancel := ensureContextTimeout(ctx)
+	defer cancel()
+	_, err := s.s3cli.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.URI.Hostname()),
+		Key:    aws.String(key),
+	})
+	return err
+}
+
+func (s *parquetStorage) List(ctx context.Context, dir string) ([]string, error) {
+	ctx, cancel := ensureContextTimeout(ctx)
+	defer cancel()
+
+	prefix := dir + "/"
+	var names []string
+	var token *string
+	for {
+		results, err := s.s3cli.ListObjectsV2WithContext(ctx, &s3.ListObjectsV2Input{
+			Bucket:            aws.String(s.URI.Hostname()),
+			Prefix:            aws.String(prefix),
+			Delimiter:         aws.String("/"),
+			ContinuationToken: token,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, commonPrefix := range results.CommonPrefixes {
+			names = append(names, strings.TrimSuffix(strings.TrimPrefix(*commonPrefix.Prefix, prefix), "/"))
+		}
+		for _, item := range results.Contents {
+			names = append(names, strings.TrimPrefix(*item.Key, prefix))
+		}
+		if !aws.BoolValue(results.IsTruncated) {
+			return names, nil
+		}
+		token = results.NextContinuationToken
+	}
+}
@@ -116,6 +116,11 @@ func constructHistoryKey(path, namespaceID, workflowID, runID string, version in
 	return fmt.Sprintf("%s%d", prefix, batchIdx)
 }
 
+func constructHistoryManifestKey(path, namespaceID, workflowID, runID string, version int64) string {
+	prefix := constructHistoryKeyPrefixWithVersion(path, namespaceID, workflowID, runID, version)
+	return prefix + "manifest"
+}
+
 func constructHistoryKeyPrefixWithVersion(path, namespaceID, workflowID, runID string, version int64) string {
 	prefix := constructHistoryKeyPrefix(path, namespaceID, workflowID, runID)
 	return fmt.Sprintf("%s/%v/", prefix, version)
@@ -125,27 +130,13 @@ func constructHistoryKeyPrefix(path, namespaceID, workflowID, runID string) stri
 	return strings.TrimLeft(strings.Join([]string{path, namespaceID, "history", workflowID, runID}, "/"), "/")
 }
 
-func constructTimeBasedSearchKey(path, namespaceID, primaryIndexKey, primaryIndexValue, secondaryIndexKey string, t time.Time, precision string) string {
-	var timeFormat = ""
-	switch precision {
-	case PrecisionSecond:
-		timeFormat = ":05"
-		fallthrough
-	case PrecisionMinute:
-		timeFormat = ":04" + timeFormat
-		fallthrough
-	case PrecisionHour:
-		timeFormat = "15" + timeFormat
-		fallthrough
-	case PrecisionDay:
-		timeFormat = "2006-01-02T" + timeFormat
-	}
+func constructChasmExecutionKey(path, namespaceID, businessID, runID string, version int64) string {
+	prefix := constructChasmExecutionKeyPrefix(path, namespaceID, businessID, runID)
+	return fmt.Sprintf("%s/%v", prefix, version)
+}
 
-	return fmt.Sprintf(
-		"%s/%s",
-		constructIndexedVisibilitySearchPrefix(path, namespaceID, primaryIndexKey, primaryIndexValue, secondaryIndexKey),
-		t.Format(timeFormat),
-	)
+func constructChasmExecutionKeyPrefix(path, namespaceID, businessID, runID string) string {
+	return strings.TrimLeft(strings.Join([]string{path, namespaceID, "chasm", businessID, runID}, "/"), "/")
 }
 
 func constructTimestampIndex(path, namespaceID, primaryIndexKey, primaryIndexValue, secondaryIndexKey string, secondaryIndexValue time.Time, runID string) string {
@@ -173,6 +164,10 @@ func constructIndexedVisibilitySearchPrefix(
 	)
 }
 
+func constructParquetVisibilityDir(path, namespaceID string) string {
+	return strings.TrimLeft(strings.Join([]string{path, namespaceID, "visibility-parquet"}, "/"), "/")
+}
+
 func constructVisibilitySearchPrefix(path, namespaceID string) string {
 	return strings.TrimLeft(strings.Join([]string{path, namespaceID, "visibility"}, "/"), "/")
 }
@@ -2,8 +2,8 @@ package s3store
 
 import (
 	"context"
+	"errors"
 	"path"
-	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -11,9 +11,11 @@ import (
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"go.temporal.io/api/serviceerror"
-	workflowpb "go.temporal.io/api/workflow/v1"
 	archiverspb "go.temporal.io/server/api/archiver/v1"
 	"go.temporal.io/server/common/archiver"
+	"go.temporal.io/server/common/archiver/parquet"
+	"go.temporal.io/server/common/archiver/visibilityquery"
+	"go.temporal.io/server/common/clock"
 	"go.temporal.io/server/common/config"
 	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/log/tag"
@@ -27,14 +29,15 @@ type (
 		logger         log.Logger
 		metricsHandler metrics.Handler
 		s3cli          s3iface.S3API
-		queryParser    QueryParser
+		// parquetArchive is nil unless the visibility records are archived in Parquet objects.
+		parquetArchive *parquet.VisibilityArchive
 	}
 
 	queryVisibilityRequest struct {
 		namespaceID   string
 		pageSize      int
 		nextPageToken []byte
-		parsedQuery   *parsedQuery
+		evaluator     *visibilityquery.Evaluator
 	}
 
 	indexToArchive struct {
@@ -51,6 +54,9 @@ const (
 	secondaryIndexKeyCloseTimeout   = "closeTimeout"
 	primaryIndexKeyWorkflowTypeName = "workflowTypeName"
 	primaryIndexKeyWorkflowID       = "workflowID"
+
+	// listObjectsMaxKeys is the maximum number of keys listed by a request to S3.
+	listObjectsMaxKeys = 1000
 )
 
 // NewVisibilityArchiver creates a new archiver.VisibilityArchiver based on s3
@@ -72,6 +78,14 @@ func newVisibilityArchiver(
 		S3ForcePathStyle: aws.Bool(config.S3ForcePathStyle),
 		LogLevel:         (*aws.LogLevelType)(&config.LogLevel),
 	}
+	var parquetArchive *parquet.VisibilityArchive
+	switch config.VisibilityFormat {
+	case "", archiver.VisibilityFormatRecord:
+	case archiver.VisibilityFormatParquet:
+		parquetArchive = parquet.NewVisibilityArchive(config.VisibilityBucketInterval, clock.NewRealTimeSource())
+	default:
+		return nil, archiver.ErrUnknownVisibilityFormat
+	}
 	sess, err := session.NewSession(s3Config)
 	if err != nil {
 		return nil, err
@@ -80,7 +94,7 @@ func newVisibilityArchiver(
 		logger:         logger,
 		metricsHandler: metricsHandler,
 		s3cli:          s3.New(sess),
-		queryParser:    NewQueryParser(),
+		parquetArchive: parquetArchive,
 	}, nil
 }
 
@@ -121,6 +135,17 @@ func (v *visibilityArchiver) Archive(
 		return err
 	}
 
+	if v.parquetArchive != nil {
+		dir := constructParquetVisibilityDir(URI.Path(), request.GetNamespaceId())
+		storage := newParquetStorage(v.s3cli, URI)
+		if err := v.parquetArchive.Archive(ctx, storage, dir, request); err != nil {
+			archiveFailReason = errWriteKey
+			return err
+		}
+		metrics.VisibilityArchiveSuccessCount.With(handler).Record(1)
+		return nil
+	}
+
 	encodedVisibilityRecord, err := Encode(request)
 	if err != nil {
 		archiveFailReason = errEncodeVisibilityRecord
@@ -163,13 +188,13 @@ func (v *visibilityArchiver) Query(
 		return nil, serviceerror.NewInvalidArgument(archiver.ErrInvalidQueryVisibilityRequest.Error())
 	}
 
-	if strings.TrimSpace(request.Query) == "" {
-		return v.queryAll(ctx, URI, request, saTypeMap)
+	evaluator, err := visibilityquery.NewEvaluator(request, saTypeMap)
+	if err != nil {
+		return nil, err
 	}
 
-	parsedQuery, err := v.queryParser.Parse(request.Query)
-	if err != nil {
-		return nil, serviceerror.NewInvalidArgument(err.Error())
+	if v.parquetArchive != nil {
+		return v.queryParquet(ctx, URI, request, evaluator, saTypeMap)
 	}
 
 	return v.query(
@@ -179,136 +204,149 @@ func (v *visibilityArchiver) Query(
 			namespaceID:   request.NamespaceID,
 			pageSize:      request.PageSize,
 			nextPageToken: request.NextPageToken,
-			parsedQuery:   parsedQuery,
+			evaluator:     evaluator,
 		},
 		saTypeMap,
 	)
 }
 
-// queryAll returns all workflow executions in the archive.
-func (v *visibilityArchiver) queryAll(
+// query returns the workflow executions which match the query, in the order of the keys of the closeTimeout index
+// unless the query has an ORDER BY clause.
+func (v *visibilityArchiver) query(
 	ctx context.Context,
-	uri archiver.URI,
-	request *archiver.QueryVisibilityRequest,
+	URI archiver.URI,
+	request *queryVisibilityRequest,
 	saTypeMap searchattribute.NameTypeMap,
 ) (*archiver.QueryVisibilityResponse, error) {
-	// remaining is the number of workflow executions left to return before we reach pageSize.
-	remaining := request.PageSize
-	nextPageToken := request.NextPageToken
-	var executions []*workflowpb.WorkflowExecutionInfo
-	// We need to loop because the number of workflow executions returned by each call to query may be fewer than
-	// pageSize. This is because we may have to skip some workflow executions after querying S3 (client-side filtering)
-	// because there are 2 entries in S3 for each workflow execution indexed by workflowTypeName (one for closeTimeout
-	// and one for startTimeout), and we only want to return one entry per workflow execution. See
-	// createIndexesToArchive for a list of all indexes.
-	for {
-		searchPrefix := constructVisibilitySearchPrefix(uri.Path(), request.NamespaceID)
-		// We suffix searchPrefix with workflowTypeName because the data in S3 is duplicated across combinations of 2
-		// different primary indices (workflowID and workflowTypeName) and 2 different secondary indices (closeTimeout
-		// and startTimeout). We only want to return one entry per workflow execution, but the full path to the S3 key
-		// is <primaryIndexKey>/<primaryIndexValue>/<secondaryIndexKey>/<secondaryIndexValue>/<runID>, and we don't have
-		// the primaryIndexValue when we make the call to query, so we can only specify the primaryIndexKey.
-		searchPrefix += "/" + primaryIndexKeyWorkflowTypeName
-		// The pageSize we supply here is actually the maximum number of keys to fetch from S3. For each execution,
-		// there should be 2 keys in S3 for this prefix, so you might think that we should multiply the pageSize by 2.
-		// However, if we do that, we may end up returning more than pageSize workflow executions to the end user of
-		// this API. This is because we aren't guaranteed that both keys for a given workflow execution will be returned
-		// in the same call. For example, if the user supplies a pageSize of 1, and we specify a maximum number of keys
-		// of 2 to S3, we may get back entries from S3 for 2 different workflow executions. You might think that we can
-		// just truncate this result to 1 workflow execution, but then the nextPageToken would be incorrect. So, we may
-		// need to make multiple calls to S3 to get the correct number of workflow executions, which will probably make
-		// this API call slower.
-		res, err := v.queryPrefix(ctx, uri, &queryVisibilityRequest{
-			namespaceID:   request.NamespaceID,
-			pageSize:      remaining,
-			nextPageToken: nextPageToken,
-			parsedQuery:   &parsedQuery{},
-		}, saTypeMap, searchPrefix, func(key string) bool {
-			// We only want to return entries for the closeTimeout secondary index. Keys for this
-			// index are always of the form:
-			//   .../closeTimeout/<timestamp>/<runID>
-			// Walk from the end instead of splitting the whole string to avoid unnecessary
-			// allocations and to keep the logic clear:
-			//   - drop <runID>
-			//   - drop <timestamp>
-			//   - check the remaining last segment equals "closeTimeout".
-			dir := path.Dir(key) // drop runID
-			dir = path.Dir(dir)  // drop <timestamp>
-			return path.Base(dir) == secondaryIndexKeyCloseTimeout
-		})
+	prefix, keyFilter := searchPrefix(URI, request)
+
+	var records []*archiverspb.VisibilityRecord
+	var nextPageToken []byte
+	if request.evaluator.Ordered() {
+		// All the matching records are read to be sorted, up to visibilityquery.MaxOrderedRecords.
+		for {
+			pageRecords, pageToken, err := v.queryPrefix(ctx, URI, &queryVisibilityRequest{
+				namespaceID:   request.namespaceID,
+				pageSize:      listObjectsMaxKeys,
+				nextPageToken: nextPageToken,
+				evaluator:     request.evaluator,
+			}, prefix, keyFilter)
+			if err != nil {
+				return nil, err
+			}
+			records = append(records, pageRecords...)
+			if err := visibilityquery.CheckOrderedRecords(len(records)); err != nil {
+				return nil, err
+			}
+			nextPageToken = pageToken
+			if len(nextPageToken) == 0 {
+				break
+			}
+		}
+		var err error
+		records, nextPageToken, err = request.evaluator.SortPage(records, request.pageSize, request.nextPageToken)
 		if err != nil {
 			return nil, err
 		}
-		nextPageToken = res.NextPageToken
-		executions = append(executions, res.Executions...)
-		remaining -= len(res.Executions)
-		if len(nextPageToken) == 0 || remaining <= 0 {
-			break
+	} else {
+		// We need to loop because the number of records returned by each call to queryPrefix may be fewer than
+		// pageSize. This is because we may have to skip some keys and records after querying S3 (client-side
+		// filtering), either because they are not in the closeTimeout index or in the close time range, or because
+		// they don't match the query.
+		//
+		// The pageSize we supply to queryPrefix is actually the maximum number of keys to fetch from S3, and is the
+		// number of records left to return before we reach pageSize. If we fetched more keys, we may end up returning
+		// more than pageSize records to the end user of this API, and truncating the result would make the
+		// nextPageToken incorrect. So, we may need to make multiple calls to S3 to get the correct number of records,
+		// which will probably make this API call slower.
+		remaining := request.pageSize
+		nextPageToken = request.nextPageToken
+		for {
+			pageRecords, pageToken, err := v.queryPrefix(ctx, URI, &queryVisibilityRequest{
+				namespaceID:   request.namespaceID,
+				pageSize:      remaining,
+				nextPageToken: nextPageToken,
+				evaluator:     request.evaluator,
+			}, prefix, keyFilter)
+			if err != nil {
+				return nil, err
+			}
+			records = append(records, pageRecords...)
+			remaining -= len(pageRecords)
+			nextPageToken = pageToken
+			if len(nextPageToken) == 0 || remaining <= 0 {
+				break
+			}
 		}
 	}
-	return &archiver.QueryVisibilityResponse{
-		Executions:    executions,
-		NextPageToken: nextPageToken,
-	}, nil
+
+	response := &archiver.QueryVisibilityResponse{NextPageToken: nextPageToken}
+	for _, record := range records {
+		executionInfo, err := convertToExecutionInfo(record, saTypeMap)
+		if err != nil {
+			return nil, serviceerror.NewInternal(err.Error())
+		}
+		response.Executions = append(response.Executions, executionInfo)
+	}
+	return response, nil
 }
 
-func (v *visibilityArchiver) query(
-	ctx context.Context,
-	URI archiver.URI,
-	request *queryVisibilityRequest,
-	saTypeMap searchattribute.NameTypeMap,
-) (*archiver.QueryVisibilityResponse, error) {
-	primaryIndex := primaryIndexKeyWorkflowTypeName
-	primaryIndexValue := request.parsedQuery.workflowTypeName
-	if request.parsedQuery.workflowID != nil {
-		primaryIndex = primaryIndexKeyWorkflowID
-		primaryIndexValue = request.parsedQuery.workflowID
-	}
-
-	prefix := constructIndexedVisibilitySearchPrefix(
-		URI.Path(),
-		request.namespaceID,
-		primaryIndex,
-		*primaryIndexValue,
-		secondaryIndexKeyCloseTimeout,
-	) + "/"
-	if request.parsedQuery.closeTime != nil {
-		prefix = constructTimeBasedSearchKey(
+// searchPrefix returns the prefix of the keys of the closeTimeout index in which the records of the query are
+// searched, and the filter of these keys. The records are searched in the index of their workflow ID or workflow type
+// name if the query requires one, and in the indexes of all the workflow type names otherwise.
+func searchPrefix(URI archiver.URI, request *queryVisibilityRequest) (string, func(key string) bool) {
+	// The keys of the closeTimeout index are of the form .../closeTimeout/<timestamp>/<runID>, where the timestamp is
+	// the close time truncated to the second.
+	earliestCloseTime, latestCloseTime := request.evaluator.CloseTimeRange()
+	closeTimeFilter := func(key string) bool {
+		closeTime, err := time.Parse(time.RFC3339, path.Base(path.Dir(key)))
+		if err != nil {
+			return true
+		}
+		return (latestCloseTime.IsZero() || !closeTime.After(latestCloseTime)) &&
+			closeTime.Add(time.Second).After(earliestCloseTime)
+	}
+
+	if workflowID, ok := request.evaluator.WorkflowID(); ok {
+		return constructIndexedVisibilitySearchPrefix(
 			URI.Path(),
 			request.namespaceID,
-			primaryIndex,
-			*primaryIndexValue,
+			primaryIndexKeyWorkflowID,
+			workflowID,
 			secondaryIndexKeyCloseTimeout,
-			*request.parsedQuery.closeTime,
-			*request.parsedQuery.searchPrecision,
-		)
+		) + "/", closeTimeFilter
 	}
-	if request.parsedQuery.startTime != nil {
-		prefix = constructTimeBasedSearchKey(
+	if workflowTypeName, ok := request.evaluator.WorkflowTypeName(); ok {
+		return constructIndexedVisibilitySearchPrefix(
 			URI.Path(),
 			request.namespaceID,
-			primaryIndex,
-			*primaryIndexValue,
-			secondaryIndexKeyStartTimeout,
-			*request.parsedQuery.startTime,
-			*request.parsedQuery.searchPrecision,
-		)
+			primaryIndexKeyWorkflowTypeName,
+			workflowTypeName,
+			secondaryIndexKeyCloseTimeout,
+		) + "/", closeTimeFilter
 	}
 
-	return v.queryPrefix(ctx, URI, request, saTypeMap, prefix, nil)
+	// The records are duplicated across combinations of 2 different primary indices (workflowID and
+	// workflowTypeName) and 2 different secondary indices (closeTimeout and startTimeout). We only want to return
+	// one entry per record, but we don't have the primaryIndexValue, so we can only specify the primaryIndexKey and
+	// skip the keys of the startTimeout index. See createIndexesToArchive for a list of all indexes.
+	prefix := constructVisibilitySearchPrefix(URI.Path(), request.namespaceID) + "/" + primaryIndexKeyWorkflowTypeName
+	return prefix, func(key string) bool {
+		// drop <runID> and <timestamp>
+		return path.Base(path.Dir(path.Dir(key))) == secondaryIndexKeyCloseTimeout && closeTimeFilter(key)
+	}
 }
 
-// queryPrefix returns all workflow executions in the archive that match the given prefix. The keyFilter function is an
-// optional filter that can be used to further filter the results. If keyFilter returns false for a given key, that key
-// will be skipped, and the object will not be downloaded from S3 or included in the results.
+// queryPrefix returns the records of at most pageSize keys with the given prefix which match the query, and the
+// token of the next keys. The keyFilter function is an optional filter that can be used to further filter the results.
+// If keyFilter returns false for a given key, that key will be skipped, and the object will not be downloaded from S3.
 func (v *visibilityArchiver) queryPrefix(
 	ctx context.Context,
 	uri archiver.URI,
 	request *queryVisibilityRequest,
-	saTypeMap searchattribute.NameTypeMap,
 	prefix string,
 	keyFilter func(key string) bool,
-) (*archiver.QueryVisibilityResponse, error) {
+) ([]*archiverspb.VisibilityRecord, []byte, error) {
 	ctx, cancel := ensureContextTimeout(ctx)
 	defer cancel()
 
@@ -325,18 +363,19 @@ func (v *visibilityArchiver) queryPrefix(
 	})
 	if err != nil {
 		if isRetryableError(err) {
-			return nil, serviceerror.NewUnavailable(err.Error())
+			return nil, nil, serviceerror.NewUnavailable(err.Error())
 		}
-		return nil, serviceerror.NewInvalidArgument(err.Error())
+		return nil, nil, serviceerror.NewInvalidArgument(err.Error())
 	}
 	if len(results.Contents) == 0 {
-		return &archiver.QueryVisibilityResponse{}, nil
+		return nil, nil, nil
 	}
 
-	response := &archiver.QueryVisibilityResponse{}
+	var nextPageToken []byte
 	if *results.IsTruncated {
-		response.NextPageToken = serializeQueryVisibilityToken(*results.NextContinuationToken)
+		nextPageToken = serializeQueryVisibilityToken(*results.NextContinuationToken)
 	}
+	var records []*archiverspb.VisibilityRecord
 	for _, item := range results.Contents {
 		if keyFilter != nil && !keyFilter(*item.Key) {
 			continue
@@ -344,13 +383,64 @@ func (v *visibilityArchiver) queryPrefix(
 
 		encodedRecord, err := Download(ctx, v.s3cli, uri, *item.Key)
 		if err != nil {
-			return nil, serviceerror.NewUnavailable(err.Error())
+			return nil, nil, serviceerror.NewUnavailable(err.Error())
 		}
 
 		record, err := decodeVisibilityRecord(encodedRecord)
 		if err != nil {
-			return nil, serviceerror.NewInternal(err.Error())
+			return nil, nil, serviceerror.NewInternal(err.Error())
+		}
+		if request.evaluator.Match(record) {
+			records = append(records, record)
+		}
+	}
+	return records, nextPageToken, nil
+}
+
+// queryParquet returns the workflow executions of the Parquet archive which match the query.
+func (v *visibilityArchiver) queryParquet(
+	ctx context.Context,
+	URI archiver.URI,
+	request *archiver.QueryVisibilityRequest,
+	evaluator *visibilityquery.Evaluator,
+	saTypeMap searchattribute.NameTypeMap,
+) (*archiver.QueryVisibilityResponse, error) {
+	parquetRequest := &parquet.QueryRequest{
+		PageSize:      request.PageSize,
+		NextPageToken: request.NextPageToken,
+		Filter:        evaluator.Match,
+	}
+	parquetRequest.EarliestCloseTime, parquetRequest.LatestCloseTime = evaluator.CloseTimeRange()
+	if evaluator.Ordered() {
+		// all the matching records are read to be sorted
+		parquetRequest.PageSize = 0
+		parquetRequest.NextPageToken = nil
+		parquetRequest.MaxRecords = visibilityquery.MaxOrderedRecords
+	}
+	dir := constructParquetVisibilityDir(URI.Path(), request.NamespaceID)
+	parquetResponse, err := v.parquetArchive.Query(ctx, newParquetStorage(v.s3cli, URI), dir, parquetRequest)
+	if err != nil {
+		if errors.Is(err, archiver.ErrNextPageTokenCorrupted) {
+			return nil, serviceerror.NewInvalidArgument(err.Error())
+		}
+		if errors.Is(err, parquet.ErrTooManyRecords) {
+			return nil, visibilityquery.ErrTooManyOrderedRecords
+		}
+		if isRetryableError(err) {
+			return nil, serviceerror.NewUnavailable(err.Error())
+		}
+		return nil, serviceerror.NewInternal(err.Error())
+	}
+
+	records, nextPageToken := parquetResponse.Records, parquetResponse.NextPageToken
+	if evaluator.Ordered() {
+		records, nextPageToken, err = evaluator.SortPage(records, request.PageSize, request.NextPageToken)
+		if err != nil {
+			return nil, err
 		}
+	}
+	response := &archiver.QueryVisibilityResponse{NextPageToken: nextPageToken}
+	for _, record := range records {
 		executionInfo, err := convertToExecutionInfo(record, saTypeMap)
 		if err != nil {
 			return nil, serviceerror.NewInternal(err.Error())
@@ -360,6 +450,24 @@ func (v *visibilityArchiver) queryPrefix(
 	return response, nil
 }
 
+// CompactVisibility compacts the staged records of the sealed buckets of the Parquet archive of the namespace. It does
+// nothing if the archiver doesn't use the Parquet format.
+func (v *visibilityArchiver) CompactVisibility(
+	ctx context.Context,
+	URI archiver.URI,
+	namespaceID string,
+	saTypeMap searchattribute.NameTypeMap,
+) error {
+	if v.parquetArchive == nil {
+		return nil
+	}
+	if err := SoftValidateURI(URI); err != nil {
+		return err
+	}
+	dir := constructParquetVisibilityDir(URI.Path(), namespaceID)
+	return v.parquetArchive.Compact(ctx, newParquetStorage(v.s3cli, URI), dir, &saTypeMap)
+}
+
 func (v *visibilityArchiver) ValidateURI(URI archiver.URI) error {
 	err := SoftValidateURI(URI)
 	if err != nil {
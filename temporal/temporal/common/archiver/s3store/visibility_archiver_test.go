@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"testing"
 	"time"
 
@@ -19,14 +20,17 @@ import (
 	workflowpb "go.temporal.io/api/workflow/v1"
 	archiverspb "go.temporal.io/server/api/archiver/v1"
 	"go.temporal.io/server/common/archiver"
+	"go.temporal.io/server/common/archiver/parquet"
 	"go.temporal.io/server/common/archiver/s3store/mocks"
+	"go.temporal.io/server/common/clock"
 	"go.temporal.io/server/common/codec"
+	"go.temporal.io/server/common/config"
 	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/metrics"
 	"go.temporal.io/server/common/payload"
 	"go.temporal.io/server/common/primitives/timestamp"
 	"go.temporal.io/server/common/searchattribute"
-	"go.temporal.io/server/common/util"
+	"go.temporal.io/server/common/testing/protorequire"
 	"go.uber.org/mock/gomock"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -97,7 +101,6 @@ func (s *visibilityArchiverSuite) newTestVisibilityArchiver() *visibilityArchive
 		logger:         s.logger,
 		metricsHandler: s.metricsHandler,
 		s3cli:          s.s3cli,
-		queryParser:    NewQueryParser(),
 	}
 }
 
@@ -231,30 +234,21 @@ func (s *visibilityArchiverSuite) TestQuery_Fail_InvalidRequest() {
 
 func (s *visibilityArchiverSuite) TestQuery_Fail_InvalidQuery() {
 	visibilityArchiver := s.newTestVisibilityArchiver()
-	mockParser := NewMockQueryParser(s.controller)
-	mockParser.EXPECT().Parse(gomock.Any()).Return(nil, errors.New("invalid query"))
-	visibilityArchiver.queryParser = mockParser
 	response, err := visibilityArchiver.Query(context.Background(), s.testArchivalURI, &archiver.QueryVisibilityRequest{
 		NamespaceID: "some random namespaceID",
 		PageSize:    10,
 		Query:       "some invalid query",
 	}, searchattribute.TestNameTypeMap())
-	s.Error(err)
+	var invalidArgument *serviceerror.InvalidArgument
+	s.ErrorAs(err, &invalidArgument)
 	s.Nil(response)
 }
 
 func (s *visibilityArchiverSuite) TestQuery_Success_DirectoryNotExist() {
 	visibilityArchiver := s.newTestVisibilityArchiver()
-	mockParser := NewMockQueryParser(s.controller)
-	mockParser.EXPECT().Parse(gomock.Any()).Return(&parsedQuery{
-		workflowID:      util.Ptr(testWorkflowID),
-		closeTime:       &time.Time{},
-		searchPrecision: util.Ptr(PrecisionSecond),
-	}, nil)
-	visibilityArchiver.queryParser = mockParser
 	request := &archiver.QueryVisibilityRequest{
 		NamespaceID: testNamespaceID,
-		Query:       "parsed by mockParser",
+		Query:       "WorkflowId = 'some-other-workflow-id'",
 		PageSize:    1,
 	}
 	response, err := visibilityArchiver.Query(context.Background(), s.testArchivalURI, request, searchattribute.TestNameTypeMap())
@@ -266,17 +260,13 @@ func (s *visibilityArchiverSuite) TestQuery_Success_DirectoryNotExist() {
 
 func (s *visibilityArchiverSuite) TestQuery_Success_NoNextPageToken() {
 	visibilityArchiver := s.newTestVisibilityArchiver()
-	mockParser := NewMockQueryParser(s.controller)
-	mockParser.EXPECT().Parse(gomock.Any()).Return(&parsedQuery{
-		closeTime:       util.Ptr(time.Unix(0, int64(1*time.Hour)).UTC()),
-		searchPrecision: util.Ptr(PrecisionHour),
-		workflowID:      util.Ptr(testWorkflowID),
-	}, nil)
-	visibilityArchiver.queryParser = mockParser
 	request := &archiver.QueryVisibilityRequest{
 		NamespaceID: testNamespaceID,
 		PageSize:    10,
-		Query:       "parsed by mockParser",
+		Query: fmt.Sprintf(
+			"WorkflowId = '%s' AND CloseTime >= '1970-01-01T01:00:00Z' AND CloseTime < '1970-01-01T02:00:00Z'",
+			testWorkflowID,
+		),
 	}
 	URI, err := archiver.NewURI(testBucketURI)
 	s.NoError(err)
@@ -292,17 +282,13 @@ func (s *visibilityArchiverSuite) TestQuery_Success_NoNextPageToken() {
 
 func (s *visibilityArchiverSuite) TestQuery_Success_SmallPageSize() {
 	visibilityArchiver := s.newTestVisibilityArchiver()
-	mockParser := NewMockQueryParser(s.controller)
-	mockParser.EXPECT().Parse(gomock.Any()).Return(&parsedQuery{
-		closeTime:       util.Ptr(time.Unix(0, 0).UTC()),
-		searchPrecision: util.Ptr(PrecisionDay),
-		workflowID:      util.Ptr(testWorkflowID),
-	}, nil).AnyTimes()
-	visibilityArchiver.queryParser = mockParser
 	request := &archiver.QueryVisibilityRequest{
 		NamespaceID: testNamespaceID,
 		PageSize:    2,
-		Query:       "parsed by mockParser",
+		Query: fmt.Sprintf(
+			"WorkflowId = '%s' AND CloseTime BETWEEN '1970-01-01T00:00:00Z' AND '1970-01-01T23:59:59Z'",
+			testWorkflowID,
+		),
 	}
 	URI, err := archiver.NewURI(testBucketURI)
 	s.NoError(err)
@@ -400,100 +386,26 @@ func (s *visibilityArchiverSuite) TestQuery_EmptyQuery_Pagination() {
 	s.Len(executions, len(s.visibilityRecords))
 }
 
-type precisionTest struct {
-	day       int
-	hour      int
-	minute    int
-	second    int
-	precision string
-}
-
-func (s *visibilityArchiverSuite) TestArchiveAndQueryPrecisions() {
-	precisionTests := []*precisionTest{
-		{
-			day:       1,
-			hour:      0,
-			minute:    0,
-			second:    0,
-			precision: PrecisionDay,
-		},
-		{
-			day:       1,
-			hour:      1,
-			minute:    0,
-			second:    0,
-			precision: PrecisionDay,
-		},
-		{
-			day:       2,
-			hour:      1,
-			minute:    0,
-			second:    0,
-			precision: PrecisionHour,
-		},
-		{
-			day:       2,
-			hour:      1,
-			minute:    30,
-			second:    0,
-			precision: PrecisionHour,
-		},
-		{
-			day:       3,
-			hour:      2,
-			minute:    1,
-			second:    0,
-			precision: PrecisionMinute,
-		},
-		{
-			day:       3,
-			hour:      2,
-			minute:    1,
-			second:    30,
-			precision: PrecisionMinute,
-		},
-		{
-			day:       4,
-			hour:      3,
-			minute:    2,
-			second:    1,
-			precision: PrecisionSecond,
-		},
-		{
-			day:       4,
-			hour:      3,
-			minute:    2,
-			second:    1,
-			precision: PrecisionSecond,
-		},
-		{
-			day:       4,
-			hour:      3,
-			minute:    2,
-			second:    2,
-			precision: PrecisionSecond,
-		},
-		{
-			day:       4,
-			hour:      3,
-			minute:    2,
-			second:    2,
-			precision: PrecisionSecond,
-		},
-	}
+func (s *visibilityArchiverSuite) TestArchiveAndQuery_CloseTimeRange() {
 	visibilityArchiver := s.newTestVisibilityArchiver()
-	URI, err := archiver.NewURI(testBucketURI + "/archive-and-query-precision")
+	URI, err := archiver.NewURI(testBucketURI + "/archive-and-query-close-time-range")
 	s.NoError(err)
 
-	for i, testData := range precisionTests {
+	closeTimes := []time.Time{
+		time.Date(2000, 1, 1, 0, 0, 0, int(500*time.Millisecond), time.UTC),
+		time.Date(2000, 1, 1, 1, 0, 0, 0, time.UTC),
+		time.Date(2000, 1, 1, 1, 0, 1, 0, time.UTC),
+		time.Date(2000, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+	for i, closeTime := range closeTimes {
 		record := archiverspb.VisibilityRecord{
 			NamespaceId:      testNamespaceID,
 			Namespace:        testNamespace,
 			WorkflowId:       testWorkflowID,
 			RunId:            fmt.Sprintf("%s-%d", testRunID, i),
 			WorkflowTypeName: testWorkflowTypeName,
-			StartTime:        timestamppb.New(time.Date(2000, 1, testData.day, testData.hour, testData.minute, testData.second, 0, time.UTC)),
-			CloseTime:        timestamppb.New(time.Date(2000, 1, testData.day, testData.hour, testData.minute, testData.second, 0, time.UTC)),
+			StartTime:        timestamppb.New(closeTime.Add(-time.Hour)),
+			CloseTime:        timestamppb.New(closeTime),
 			Status:           enumspb.WORKFLOW_EXECUTION_STATUS_FAILED,
 			HistoryLength:    101,
 		}
@@ -501,65 +413,97 @@ func (s *visibilityArchiverSuite) TestArchiveAndQueryPrecisions() {
 		s.NoError(err, "case %d", i)
 	}
 
-	request := &archiver.QueryVisibilityRequest{
-		NamespaceID: testNamespaceID,
-		PageSize:    100,
-		Query:       "parsed by mockParser",
+	testCases := []struct {
+		query    string
+		expected []int
+	}{
+		{query: "CloseTime = '2000-01-01T00:00:00.5Z'", expected: []int{0}},
+		{query: "CloseTime > '2000-01-01T00:00:00.5Z' AND CloseTime <= '2000-01-01T01:00:01Z'", expected: []int{1, 2}},
+		{query: "CloseTime BETWEEN '2000-01-01T00:00:00Z' AND '2000-01-01T23:59:59Z'", expected: []int{0, 1, 2}},
+		{query: "CloseTime >= '2000-01-02T00:00:00Z'", expected: []int{3}},
+		{query: "StartTime < '2000-01-01T00:00:01Z'", expected: []int{0, 1}},
 	}
+	// The close time range narrows the keys of the index of the workflow ID, of the workflow type name, or of all
+	// the workflow type names.
+	for _, indexQuery := range []string{
+		fmt.Sprintf("WorkflowId = '%s' AND ", testWorkflowID),
+		fmt.Sprintf("WorkflowType = '%s' AND ", testWorkflowTypeName),
+		"",
+	} {
+		for _, tc := range testCases {
+			request := &archiver.QueryVisibilityRequest{
+				NamespaceID: testNamespaceID,
+				PageSize:    100,
+				Query:       indexQuery + tc.query,
+			}
+			response, err := visibilityArchiver.Query(context.Background(), URI, request, searchattribute.TestNameTypeMap())
+			s.NoError(err, request.Query)
+			var runIDs []string
+			for _, execution := range response.Executions {
+				runIDs = append(runIDs, execution.GetExecution().GetRunId())
+			}
+			var expected []string
+			for _, i := range tc.expected {
+				expected = append(expected, fmt.Sprintf("%s-%d", testRunID, i))
+			}
+			s.Equal(expected, runIDs, request.Query)
+		}
+	}
+}
 
-	for i, testData := range precisionTests {
-		mockParser := NewMockQueryParser(s.controller)
-		mockParser.EXPECT().Parse(gomock.Any()).Return(&parsedQuery{
-			closeTime:       util.Ptr(time.Date(2000, 1, testData.day, testData.hour, testData.minute, testData.second, 0, time.UTC)),
-			searchPrecision: util.Ptr(testData.precision),
-			workflowID:      util.Ptr(testWorkflowID),
-		}, nil).AnyTimes()
-		visibilityArchiver.queryParser = mockParser
-
-		response, err := visibilityArchiver.Query(context.Background(), URI, request, searchattribute.TestNameTypeMap())
-		s.NoError(err)
-		s.NotNil(response)
-		s.Len(response.Executions, 2, "Iteration ", i)
-
-		mockParser = NewMockQueryParser(s.controller)
-		mockParser.EXPECT().Parse(gomock.Any()).Return(&parsedQuery{
-			startTime:       util.Ptr(time.Date(2000, 1, testData.day, testData.hour, testData.minute, testData.second, 0, time.UTC)),
-			searchPrecision: util.Ptr(testData.precision),
-			workflowID:      util.Ptr(testWorkflowID),
-		}, nil).AnyTimes()
-		visibilityArchiver.queryParser = mockParser
-
-		response, err = visibilityArchiver.Query(context.Background(), URI, request, searchattribute.TestNameTypeMap())
-		s.NoError(err)
-		s.NotNil(response)
-		s.Len(response.Executions, 2, "Iteration ", i)
-
-		mockParser = NewMockQueryParser(s.controller)
-		mockParser.EXPECT().Parse(gomock.Any()).Return(&parsedQuery{
-			closeTime:        util.Ptr(time.Date(2000, 1, testData.day, testData.hour, testData.minute, testData.second, 0, time.UTC)),
-			searchPrecision:  util.Ptr(testData.precision),
-			workflowTypeName: util.Ptr(testWorkflowTypeName),
-		}, nil).AnyTimes()
-		visibilityArchiver.queryParser = mockParser
+func (s *visibilityArchiverSuite) TestArchiveAndQuery_LiveVisibilityGrammar() {
+	visibilityArchiver := s.newTestVisibilityArchiver()
+	URI, err := archiver.NewURI(testBucketURI + "/archive-and-query-live-visibility-grammar")
+	s.NoError(err)
 
-		response, err = visibilityArchiver.Query(context.Background(), URI, request, searchattribute.TestNameTypeMap())
+	closeTime := time.Date(2024, 5, 6, 22, 8, 9, 0, time.UTC)
+	for i, status := range []enumspb.WorkflowExecutionStatus{
+		enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED,
+		enumspb.WORKFLOW_EXECUTION_STATUS_FAILED,
+		enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED,
+		enumspb.WORKFLOW_EXECUTION_STATUS_TIMED_OUT,
+	} {
+		record := &archiverspb.VisibilityRecord{
+			NamespaceId:      testNamespaceID,
+			Namespace:        testNamespace,
+			WorkflowId:       fmt.Sprintf("workflow-id-%d", i),
+			RunId:            fmt.Sprintf("run-id-%d", i),
+			WorkflowTypeName: testWorkflowTypeName,
+			StartTime:        timestamppb.New(closeTime.Add(-time.Duration(i) * time.Hour)),
+			CloseTime:        timestamppb.New(closeTime.Add(time.Duration(i) * time.Minute)),
+			Status:           status,
+			HistoryLength:    int64(10 - i),
+		}
+		err := visibilityArchiver.Archive(context.Background(), URI, record)
 		s.NoError(err)
-		s.NotNil(response)
-		s.Len(response.Executions, 2, "Iteration ", i)
-
-		mockParser = NewMockQueryParser(s.controller)
-		mockParser.EXPECT().Parse(gomock.Any()).Return(&parsedQuery{
-			startTime:        util.Ptr(time.Date(2000, 1, testData.day, testData.hour, testData.minute, testData.second, 0, time.UTC)),
-			searchPrecision:  util.Ptr(testData.precision),
-			workflowTypeName: util.Ptr(testWorkflowTypeName),
-		}, nil).AnyTimes()
-		visibilityArchiver.queryParser = mockParser
+	}
 
-		response, err = visibilityArchiver.Query(context.Background(), URI, request, searchattribute.TestNameTypeMap())
-		s.NoError(err)
-		s.NotNil(response)
-		s.Len(response.Executions, 2, "Iteration ", i)
+	queryAll := func(query string) []string {
+		request := &archiver.QueryVisibilityRequest{
+			NamespaceID: testNamespaceID,
+			PageSize:    1,
+			Query:       query,
+		}
+		var runIDs []string
+		for first := true; first || request.NextPageToken != nil; first = false {
+			response, err := visibilityArchiver.Query(context.Background(), URI, request, searchattribute.TestNameTypeMap())
+			s.NoError(err, query)
+			s.LessOrEqual(len(response.Executions), 1)
+			for _, execution := range response.Executions {
+				runIDs = append(runIDs, execution.GetExecution().GetRunId())
+			}
+			request.NextPageToken = response.NextPageToken
+		}
+		return runIDs
 	}
+
+	s.Equal([]string{"run-id-0", "run-id-2"}, queryAll("WorkflowId IN ('workflow-id-0', 'workflow-id-2')"))
+	s.Equal([]string{"run-id-1", "run-id-3"}, queryAll("ExecutionStatus = 'Failed' OR ExecutionStatus = 'TimedOut'"))
+	s.Equal([]string{"run-id-1", "run-id-2", "run-id-3"}, queryAll("NOT (WorkflowId = 'workflow-id-0')"))
+	s.Equal([]string{"run-id-3", "run-id-2", "run-id-1"}, queryAll("HistoryLength < 10 ORDER BY StartTime ASC"))
+	s.Equal([]string{"run-id-3", "run-id-2", "run-id-1", "run-id-0"}, queryAll(fmt.Sprintf(
+		"WorkflowType = '%s' ORDER BY CloseTime DESC", testWorkflowTypeName,
+	)))
 }
 
 func (s *visibilityArchiverSuite) TestArchiveAndQuery() {
@@ -571,15 +515,10 @@ func (s *visibilityArchiverSuite) TestArchiveAndQuery() {
 		s.NoError(err)
 	}
 
-	mockParser := NewMockQueryParser(s.controller)
-	mockParser.EXPECT().Parse(gomock.Any()).Return(&parsedQuery{
-		workflowID: util.Ptr(testWorkflowID),
-	}, nil).AnyTimes()
-	visibilityArchiver.queryParser = mockParser
 	request := &archiver.QueryVisibilityRequest{
 		NamespaceID: testNamespaceID,
 		PageSize:    1,
-		Query:       "parsed by mockParser",
+		Query:       fmt.Sprintf("WorkflowId = '%s'", testWorkflowID),
 	}
 	executions := []*workflowpb.WorkflowExecutionInfo{}
 	first := true
@@ -602,15 +541,10 @@ func (s *visibilityArchiverSuite) TestArchiveAndQuery() {
 	s.NoError(err)
 	s.Equal(ei, executions[2])
 
-	mockParser = NewMockQueryParser(s.controller)
-	mockParser.EXPECT().Parse(gomock.Any()).Return(&parsedQuery{
-		workflowTypeName: util.Ptr(testWorkflowTypeName),
-	}, nil).AnyTimes()
-	visibilityArchiver.queryParser = mockParser
 	request = &archiver.QueryVisibilityRequest{
 		NamespaceID: testNamespaceID,
 		PageSize:    1,
-		Query:       "parsed by mockParser",
+		Query:       fmt.Sprintf("WorkflowType = '%s'", testWorkflowTypeName),
 	}
 	executions = []*workflowpb.WorkflowExecutionInfo{}
 	first = true
@@ -634,6 +568,79 @@ func (s *visibilityArchiverSuite) TestArchiveAndQuery() {
 	s.Equal(ei, executions[2])
 }
 
+func (s *visibilityArchiverSuite) TestNewVisibilityArchiver_UnknownFormat() {
+	_, err := NewVisibilityArchiver(s.logger, s.metricsHandler, &config.S3Archiver{
+		Region:           "us-east-1",
+		VisibilityFormat: "csv",
+	})
+	s.ErrorIs(err, archiver.ErrUnknownVisibilityFormat)
+}
+
+func (s *visibilityArchiverSuite) TestArchiveAndQuery_Parquet() {
+	closeTime := time.Date(2024, 5, 6, 22, 8, 9, 123456000, time.UTC)
+	timeSource := clock.NewEventTimeSource().Update(closeTime.Add(24 * time.Hour))
+	visibilityArchiver := s.newTestVisibilityArchiver()
+	visibilityArchiver.parquetArchive = parquet.NewVisibilityArchive(time.Hour, timeSource)
+	URI, err := archiver.NewURI(testBucketURI + "/archive-and-query-parquet")
+	s.NoError(err)
+
+	saTypeMap := searchattribute.TestNameTypeMap()
+	var records []*archiverspb.VisibilityRecord
+	for i, workflowTypeName := range []string{testWorkflowTypeName, "other-workflow-type", testWorkflowTypeName, testWorkflowTypeName} {
+		record := &archiverspb.VisibilityRecord{
+			NamespaceId:      testNamespaceID,
+			Namespace:        testNamespace,
+			WorkflowId:       fmt.Sprintf("workflow-id-%d", i),
+			RunId:            fmt.Sprintf("run-id-%d", i),
+			WorkflowTypeName: workflowTypeName,
+			StartTime:        timestamppb.New(closeTime.Add(-time.Hour)),
+			CloseTime:        timestamppb.New(closeTime.Add(time.Duration(i) * time.Hour)),
+			Status:           enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED,
+			HistoryLength:    int64(i),
+			SearchAttributes: map[string]string{"Int01": strconv.Itoa(i)},
+		}
+		records = append(records, record)
+		err := visibilityArchiver.Archive(context.Background(), URI, record, archiver.GetSearchAttributeTypesOption(saTypeMap))
+		s.NoError(err)
+	}
+	s.NoError(visibilityArchiver.CompactVisibility(context.Background(), URI, testNamespaceID, saTypeMap))
+
+	queryAll := func(query string) []*workflowpb.WorkflowExecutionInfo {
+		request := &archiver.QueryVisibilityRequest{
+			NamespaceID: testNamespaceID,
+			PageSize:    1,
+			Query:       query,
+		}
+		var executions []*workflowpb.WorkflowExecutionInfo
+		for first := true; first || request.NextPageToken != nil; first = false {
+			response, err := visibilityArchiver.Query(context.Background(), URI, request, saTypeMap)
+			s.NoError(err)
+			executions = append(executions, response.Executions...)
+			request.NextPageToken = response.NextPageToken
+		}
+		return executions
+	}
+	assertExecutions := func(expected []*archiverspb.VisibilityRecord, executions []*workflowpb.WorkflowExecutionInfo) {
+		s.Len(executions, len(expected))
+		for i, record := range expected {
+			ei, err := convertToExecutionInfo(record, saTypeMap)
+			s.NoError(err)
+			protorequire.ProtoEqual(s.T(), ei, executions[i])
+		}
+	}
+
+	assertExecutions([]*archiverspb.VisibilityRecord{records[3], records[2], records[1], records[0]}, queryAll(""))
+	assertExecutions([]*archiverspb.VisibilityRecord{records[3], records[2], records[0]}, queryAll(fmt.Sprintf(
+		"WorkflowType = '%s'", testWorkflowTypeName,
+	)))
+	assertExecutions([]*archiverspb.VisibilityRecord{records[0]}, queryAll(fmt.Sprintf(
+		"WorkflowType = '%s' AND CloseTime BETWEEN '2024-05-06T00:00:00Z' AND '2024-05-06T23:59:59Z'", testWorkflowTypeName,
+	)))
+	assertExecutions([]*archiverspb.VisibilityRecord{records[1], records[2], records[3]}, queryAll(
+		"Int01 > 0 ORDER BY CloseTime ASC",
+	))
+}
+
 func (s *visibilityArchiverSuite) setupVisibilityDirectory() {
 	s.visibilityRecords = []*archiverspb.VisibilityRecord{
 		{
@@ -0,0 +1,467 @@
+// SQL History Archiver will archive workflow histories to a SQL database set up with the archival schema.
+
+// Each Archive() request writes the history batches of the workflow run to the history_archive table, one row per
+// batch keyed by namespaceID, workflowID, runID, close failover version and batch index, and the manifest of the
+// batches to the history_archive_manifests table, in a single transaction. The component trees of CHASM executions are
+// written to the chasm_execution_archive table instead, by the ArchiveChasmExecution() method.
+
+// The Get() method retrieves the archived histories from the database. It optionally takes in a NextPageToken which
+// specifies the workflow close failover version and the index of the first history batch that should be returned.
+// Instead of NextPageToken, caller can also provide a close failover version, in which case, Get() method will return
+// history batches starting from the beginning of that history version. If neither of NextPageToken or close failover
+// version is specified, the highest close failover version will be picked.
+
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	historypb "go.temporal.io/api/history/v1"
+	"go.temporal.io/api/serviceerror"
+	archiverspb "go.temporal.io/server/api/archiver/v1"
+	"go.temporal.io/server/common"
+	"go.temporal.io/server/common/archiver"
+	"go.temporal.io/server/common/config"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/log/tag"
+	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/persistence/serialization"
+	persistencesql "go.temporal.io/server/common/persistence/sql"
+	"go.temporal.io/server/common/persistence/sql/sqlplugin"
+	"go.temporal.io/server/common/resolver"
+)
+
+const (
+	// URIScheme is the scheme for the SQL implementation
+	URIScheme = "sql"
+
+	errEncodeHistory        = "failed to encode history batches"
+	errEncodeManifest       = "failed to encode history manifest"
+	errWriteHistory         = "failed to write history to database"
+	errEncodeChasmExecution = "failed to encode CHASM execution"
+	errWriteChasmExecution  = "failed to write CHASM execution to database"
+
+	targetHistoryBlobSize = 2 * 1024 * 1024 // 2MB
+
+	// maxRowsPerInsert bounds the number of history batches inserted per statement, so that the number of
+	// parameters stays below the limits of the databases.
+	maxRowsPerInsert = 100
+)
+
+type (
+	historyArchiver struct {
+		executionManager persistence.ExecutionManager
+		logger           log.Logger
+		metricsHandler   metrics.Handler
+		db               sqlplugin.ArchivalDB
+
+		// only set in test code
+		historyIterator archiver.HistoryIterator
+	}
+
+	getHistoryToken struct {
+		CloseFailoverVersion int64
+		NextBatchIdx         int64
+	}
+)
+
+// NewHistoryArchiver creates a new archiver.HistoryArchiver based on a SQL database
+func NewHistoryArchiver(
+	executionManager persistence.ExecutionManager,
+	logger log.Logger,
+	metricsHandler metrics.Handler,
+	config *config.SQL,
+) (archiver.HistoryArchiver, error) {
+	db, err := persistencesql.NewSQLArchivalDB(config, resolver.NewNoopResolver(), logger, metricsHandler)
+	if err != nil {
+		return nil, err
+	}
+	return newHistoryArchiver(executionManager, logger, metricsHandler, db, nil), nil
+}
+
+func newHistoryArchiver(
+	executionManager persistence.ExecutionManager,
+	logger log.Logger,
+	metricsHandler metrics.Handler,
+	db sqlplugin.ArchivalDB,
+	historyIterator archiver.HistoryIterator,
+) *historyArchiver {
+	return &historyArchiver{
+		executionManager: executionManager,
+		logger:           logger,
+		metricsHandler:   metricsHandler,
+		db:               db,
+		historyIterator:  historyIterator,
+	}
+}
+
+func (h *historyArchiver) Archive(
+	ctx context.Context,
+	URI archiver.URI,
+	request *archiver.ArchiveHistoryRequest,
+	opts ...archiver.ArchiveOption,
+) (err error) {
+	featureCatalog := archiver.GetFeatureCatalog(opts...)
+	defer func() {
+		if err != nil && !common.IsPersistenceTransientError(err) && featureCatalog.NonRetryableError != nil {
+			err = featureCatalog.NonRetryableError()
+		}
+	}()
+
+	logger := archiver.TagLoggerWithArchiveHistoryRequestAndURI(h.logger, request, URI.String())
+
+	if err := h.ValidateURI(URI); err != nil {
+		logger.Error(archiver.ArchiveNonRetryableErrorMsg, tag.ArchivalArchiveFailReason(archiver.ErrReasonInvalidURI), tag.Error(err))
+		return err
+	}
+
+	if err := archiver.ValidateHistoryArchiveRequest(request); err != nil {
+		logger.Error(archiver.ArchiveNonRetryableErrorMsg, tag.ArchivalArchiveFailReason(archiver.ErrReasonInvalidArchiveRequest), tag.Error(err))
+		return err
+	}
+
+	historyIterator := h.historyIterator
+	if historyIterator == nil { // will only be set by testing code
+		historyIterator = archiver.NewHistoryIterator(request, h.executionManager, targetHistoryBlobSize)
+	}
+
+	var historyBatches []*historypb.History
+	for historyIterator.HasNext() {
+		historyBlob, err := historyIterator.Next(ctx)
+		if err != nil {
+			if _, isNotFound := err.(*serviceerror.NotFound); isNotFound {
+				// workflow history no longer exists, may due to duplicated archival signal
+				// this may happen even in the middle of iterating history as two archival signals
+				// can be processed concurrently.
+				logger.Info(archiver.ArchiveSkippedInfoMsg)
+				return nil
+			}
+
+			logger = log.With(logger, tag.ArchivalArchiveFailReason(archiver.ErrReasonReadHistory), tag.Error(err))
+			if !common.IsPersistenceTransientError(err) {
+				logger.Error(archiver.ArchiveNonRetryableErrorMsg)
+			} else {
+				logger.Error(archiver.ArchiveTransientErrorMsg)
+			}
+			return err
+		}
+
+		if historyMutated(request, historyBlob.Body, historyBlob.Header.IsLast) {
+			logger.Error(archiver.ArchiveNonRetryableErrorMsg, tag.ArchivalArchiveFailReason(archiver.ErrReasonHistoryMutated))
+			return archiver.ErrHistoryMutated
+		}
+
+		historyBatches = append(historyBatches, historyBlob.Body...)
+	}
+
+	rows := make([]sqlplugin.HistoryArchiveRow, 0, len(historyBatches))
+	for idx, batch := range historyBatches {
+		blob, err := serialization.ProtoEncode(batch)
+		if err != nil {
+			logger.Error(archiver.ArchiveNonRetryableErrorMsg, tag.ArchivalArchiveFailReason(errEncodeHistory), tag.Error(err))
+			return err
+		}
+		rows = append(rows, sqlplugin.HistoryArchiveRow{
+			NamespaceID:          request.NamespaceID,
+			WorkflowID:           request.WorkflowID,
+			RunID:                request.RunID,
+			CloseFailoverVersion: request.CloseFailoverVersion,
+			BatchIdx:             int64(idx),
+			Data:                 blob.Data,
+			DataEncoding:         blob.EncodingType.String(),
+		})
+	}
+
+	manifestBatches, err := archiver.NewHistoryManifestBatches(historyBatches)
+	if err != nil {
+		logger.Error(archiver.ArchiveNonRetryableErrorMsg, tag.ArchivalArchiveFailReason(errEncodeManifest), tag.Error(err))
+		return err
+	}
+	manifestBlob, err := serialization.ProtoEncode(archiver.NewHistoryManifest(request, manifestBatches))
+	if err != nil {
+		logger.Error(archiver.ArchiveNonRetryableErrorMsg, tag.ArchivalArchiveFailReason(errEncodeManifest), tag.Error(err))
+		return err
+	}
+	manifestRow := &sqlplugin.HistoryArchiveManifestsRow{
+		NamespaceID:          request.NamespaceID,
+		WorkflowID:           request.WorkflowID,
+		RunID:                request.RunID,
+		CloseFailoverVersion: request.CloseFailoverVersion,
+		Data:                 manifestBlob.Data,
+		DataEncoding:         manifestBlob.EncodingType.String(),
+	}
+
+	if err := h.writeHistory(ctx, request, rows, manifestRow); err != nil {
+		// the database may be temporarily unavailable, so the archival is retried
+		logger.Error(archiver.ArchiveTransientErrorMsg, tag.ArchivalArchiveFailReason(errWriteHistory), tag.Error(err))
+		return serviceerror.NewUnavailable(err.Error())
+	}
+	return nil
+}
+
+// writeHistory writes the history batches and their manifest in a transaction, and deletes the batches of a previous
+// archival of the same history version which are past the new ones.
+func (h *historyArchiver) writeHistory(
+	ctx context.Context,
+	request *archiver.ArchiveHistoryRequest,
+	rows []sqlplugin.HistoryArchiveRow,
+	manifestRow *sqlplugin.HistoryArchiveManifestsRow,
+) (retErr error) {
+	tx, err := h.db.BeginArchivalTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if retErr != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	for start := 0; start < len(rows); start += maxRowsPerInsert {
+		end := min(start+maxRowsPerInsert, len(rows))
+		if _, err := tx.ReplaceIntoHistoryArchive(ctx, rows[start:end]); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.DeleteFromHistoryArchive(ctx, sqlplugin.HistoryArchiveFilter{
+		NamespaceID:          request.NamespaceID,
+		WorkflowID:           request.WorkflowID,
+		RunID:                request.RunID,
+		CloseFailoverVersion: request.CloseFailoverVersion,
+		MinBatchIdx:          int64(len(rows)),
+	}); err != nil {
+		return err
+	}
+	if _, err := tx.ReplaceIntoHistoryArchiveManifests(ctx, manifestRow); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (h *historyArchiver) Get(
+	ctx context.Context,
+	URI archiver.URI,
+	request *archiver.GetHistoryRequest,
+) (*archiver.GetHistoryResponse, error) {
+	if err := h.ValidateURI(URI); err != nil {
+		return nil, serviceerror.NewInvalidArgument(archiver.ErrInvalidURI.Error())
+	}
+
+	if err := archiver.ValidateGetRequest(request); err != nil {
+		return nil, serviceerror.NewInvalidArgument(archiver.ErrInvalidGetHistoryRequest.Error())
+	}
+
+	var token *getHistoryToken
+	if request.NextPageToken != nil {
+		var err error
+		token, err = deserializeGetHistoryToken(request.NextPageToken)
+		if err != nil {
+			return nil, serviceerror.NewInvalidArgument(archiver.ErrNextPageTokenCorrupted.Error())
+		}
+	} else if request.CloseFailoverVersion != nil {
+		token = &getHistoryToken{
+			CloseFailoverVersion: *request.CloseFailoverVersion,
+			NextBatchIdx:         0,
+		}
+	} else {
+		highestVersion, err := h.getHighestVersion(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		token = &getHistoryToken{
+			CloseFailoverVersion: highestVersion,
+			NextBatchIdx:         0,
+		}
+	}
+
+	// each batch has at least one event, so reading one more batch than the page size tells if there are more
+	rows, err := h.db.SelectFromHistoryArchive(ctx, sqlplugin.HistoryArchiveFilter{
+		NamespaceID:          request.NamespaceID,
+		WorkflowID:           request.WorkflowID,
+		RunID:                request.RunID,
+		CloseFailoverVersion: token.CloseFailoverVersion,
+		MinBatchIdx:          token.NextBatchIdx,
+		PageSize:             request.PageSize + 1,
+	})
+	if err != nil {
+		return nil, serviceerror.NewUnavailable(err.Error())
+	}
+	if len(rows) == 0 {
+		return nil, serviceerror.NewNotFound(archiver.ErrHistoryNotExist.Error())
+	}
+
+	response := &archiver.GetHistoryResponse{}
+	numOfEvents := 0
+	numOfBatches := 0
+	for _, row := range rows {
+		batch := &historypb.History{}
+		if err := serialization.Decode(persistence.NewDataBlob(row.Data, row.DataEncoding), batch); err != nil {
+			return nil, serviceerror.NewInternal(err.Error())
+		}
+		response.HistoryBatches = append(response.HistoryBatches, batch)
+		numOfBatches++
+		numOfEvents += len(batch.Events)
+		if numOfEvents >= request.PageSize {
+			break
+		}
+	}
+
+	if numOfBatches < len(rows) {
+		token.NextBatchIdx += int64(numOfBatches)
+		nextToken, err := serializeToken(token)
+		if err != nil {
+			return nil, serviceerror.NewInternal(err.Error())
+		}
+		response.NextPageToken = nextToken
+	}
+
+	return response, nil
+}
+
+func (h *historyArchiver) GetManifest(
+	ctx context.Context,
+	URI archiver.URI,
+	request *archiver.GetHistoryRequest,
+) (*archiverspb.HistoryManifest, error) {
+	if err := h.ValidateURI(URI); err != nil {
+		return nil, serviceerror.NewInvalidArgument(archiver.ErrInvalidURI.Error())
+	}
+
+	if err := archiver.ValidateGetRequest(request); err != nil {
+		return nil, serviceerror.NewInvalidArgument(archiver.ErrInvalidGetHistoryRequest.Error())
+	}
+
+	var version int64
+	if request.CloseFailoverVersion != nil {
+		version = *request.CloseFailoverVersion
+	} else {
+		highestVersion, err := h.getHighestVersion(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		version = highestVersion
+	}
+
+	row, err := h.db.SelectFromHistoryArchiveManifests(ctx, sqlplugin.HistoryArchiveManifestsFilter{
+		NamespaceID:          request.NamespaceID,
+		WorkflowID:           request.WorkflowID,
+		RunID:                request.RunID,
+		CloseFailoverVersion: version,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, serviceerror.NewNotFound(archiver.ErrManifestNotExist.Error())
+		}
+		return nil, serviceerror.NewUnavailable(err.Error())
+	}
+
+	manifest := &archiverspb.HistoryManifest{}
+	if err := serialization.Decode(persistence.NewDataBlob(row.Data, row.DataEncoding), manifest); err != nil {
+		return nil, serviceerror.NewInternal(err.Error())
+	}
+	return manifest, nil
+}
+
+func (h *historyArchiver) ArchiveChasmExecution(
+	ctx context.Context,
+	URI archiver.URI,
+	request *archiverspb.ChasmExecution,
+	opts ...archiver.ArchiveOption,
+) (err error) {
+	featureCatalog := archiver.GetFeatureCatalog(opts...)
+	defer func() {
+		if err != nil && !common.IsPersistenceTransientError(err) && featureCatalog.NonRetryableError != nil {
+			err = featureCatalog.NonRetryableError()
+		}
+	}()
+
+	logger := archiver.TagLoggerWithArchiveChasmExecutionRequestAndURI(h.logger, request, URI.String())
+
+	if err := h.ValidateURI(URI); err != nil {
+		logger.Error(archiver.ArchiveNonRetryableErrorMsg, tag.ArchivalArchiveFailReason(archiver.ErrReasonInvalidURI), tag.Error(err))
+		return err
+	}
+
+	if err := archiver.ValidateChasmExecutionArchivalRequest(request); err != nil {
+		logger.Error(archiver.ArchiveNonRetryableErrorMsg, tag.ArchivalArchiveFailReason(archiver.ErrReasonInvalidArchiveRequest), tag.Error(err))
+		return err
+	}
+
+	blob, err := serialization.ProtoEncode(request)
+	if err != nil {
+		logger.Error(archiver.ArchiveNonRetryableErrorMsg, tag.ArchivalArchiveFailReason(errEncodeChasmExecution), tag.Error(err))
+		return err
+	}
+
+	if _, err := h.db.ReplaceIntoChasmExecutionArchive(ctx, &sqlplugin.ChasmExecutionArchiveRow{
+		NamespaceID:          request.GetNamespaceId(),
+		BusinessID:           request.GetBusinessId(),
+		RunID:                request.GetRunId(),
+		CloseFailoverVersion: request.GetCloseFailoverVersion(),
+		Data:                 blob.Data,
+		DataEncoding:         blob.EncodingType.String(),
+	}); err != nil {
+		// the database may be temporarily unavailable, so the archival is retried
+		logger.Error(archiver.ArchiveTransientErrorMsg, tag.ArchivalArchiveFailReason(errWriteChasmExecution), tag.Error(err))
+		return serviceerror.NewUnavailable(err.Error())
+	}
+	return nil
+}
+
+func (h *historyArchiver) GetChasmExecution(
+	ctx context.Context,
+	URI archiver.URI,
+	request *archiver.GetChasmExecutionRequest,
+) (*archiverspb.ChasmExecution, error) {
+	if err := h.ValidateURI(URI); err != nil {
+		return nil, serviceerror.NewInvalidArgument(archiver.ErrInvalidURI.Error())
+	}
+
+	if err := archiver.ValidateGetChasmExecutionRequest(request); err != nil {
+		return nil, serviceerror.NewInvalidArgument(archiver.ErrInvalidGetChasmExecutionRequest.Error())
+	}
+
+	row, err := h.db.SelectFromChasmExecutionArchive(ctx, sqlplugin.ChasmExecutionArchiveFilter{
+		NamespaceID:          request.NamespaceID,
+		BusinessID:           request.BusinessID,
+		RunID:                request.RunID,
+		CloseFailoverVersion: request.CloseFailoverVersion,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, serviceerror.NewNotFound(archiver.ErrChasmExecutionNotExist.Error())
+		}
+		return nil, serviceerror.NewUnavailable(err.Error())
+	}
+
+	execution := &archiverspb.ChasmExecution{}
+	if err := serialization.Decode(persistence.NewDataBlob(row.Data, row.DataEncoding), execution); err != nil {
+		return nil, serviceerror.NewInternal(err.Error())
+	}
+	return execution, nil
+}
+
+func (h *historyArchiver) ValidateURI(URI archiver.URI) error {
+	if URI.Scheme() != URIScheme {
+		return archiver.ErrURISchemeMismatch
+	}
+	return nil
+}
+
+func (h *historyArchiver) getHighestVersion(ctx context.Context, request *archiver.GetHistoryRequest) (int64, error) {
+	versions, err := h.db.SelectHistoryArchiveVersions(ctx, sqlplugin.HistoryArchiveVersionsFilter{
+		NamespaceID: request.NamespaceID,
+		WorkflowID:  request.WorkflowID,
+		RunID:       request.RunID,
+	})
+	if err != nil {
+		return 0, serviceerror.NewUnavailable(err.Error())
+	}
+	if len(versions) == 0 {
+		return 0, serviceerror.NewNotFound(archiver.ErrHistoryNotExist.Error())
+	}
+	return versions[0], nil
+}
@@ -0,0 +1,516 @@
+package sqlstore
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	historypb "go.temporal.io/api/history/v1"
+	"go.temporal.io/api/serviceerror"
+	archiverspb "go.temporal.io/server/api/archiver/v1"
+	enumsspb "go.temporal.io/server/api/enums/v1"
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common"
+	"go.temporal.io/server/common/archiver"
+	"go.temporal.io/server/common/config"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/common/persistence/serialization"
+	persistencesql "go.temporal.io/server/common/persistence/sql"
+	"go.temporal.io/server/common/persistence/sql/sqlplugin"
+	_ "go.temporal.io/server/common/persistence/sql/sqlplugin/sqlite"
+	"go.temporal.io/server/common/resolver"
+	"go.temporal.io/server/common/testing/protorequire"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	testNamespaceID          = "test-namespace-id"
+	testNamespace            = "test-namespace"
+	testWorkflowID           = "test-workflow-id"
+	testRunID                = "test-run-id"
+	testNextEventID          = 1800
+	testCloseFailoverVersion = int64(100)
+	testPageSize             = 100
+)
+
+var (
+	testBranchToken = []byte{1, 2, 3}
+)
+
+type historyArchiverSuite struct {
+	*require.Assertions
+	protorequire.ProtoAssertions
+	suite.Suite
+
+	logger             log.Logger
+	metricsHandler     metrics.Handler
+	db                 sqlplugin.ArchivalDB
+	testArchivalURI    archiver.URI
+	historyBatchesV1   []*historypb.History
+	historyBatchesV100 []*historypb.History
+}
+
+func TestHistoryArchiverSuite(t *testing.T) {
+	suite.Run(t, new(historyArchiverSuite))
+}
+
+func (s *historyArchiverSuite) SetupSuite() {
+	var err error
+	s.testArchivalURI, err = archiver.NewURI("sql://")
+	s.Require().NoError(err)
+	s.setupHistoryBatches()
+}
+
+func (s *historyArchiverSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.ProtoAssertions = protorequire.New(s.T())
+	s.logger = log.NewNoopLogger()
+	s.metricsHandler = metrics.NoopMetricsHandler
+	s.db = newTestArchivalDB(s.T())
+}
+
+func (s *historyArchiverSuite) TearDownTest() {
+	s.NoError(s.db.Close())
+}
+
+func (s *historyArchiverSuite) TestValidateURI() {
+	testCases := []struct {
+		URI         string
+		expectedErr error
+	}{
+		{
+			URI:         "wrongscheme://",
+			expectedErr: archiver.ErrURISchemeMismatch,
+		},
+		{
+			URI:         "sql://",
+			expectedErr: nil,
+		},
+	}
+
+	historyArchiver := s.newTestHistoryArchiver(nil)
+	for _, tc := range testCases {
+		URI, err := archiver.NewURI(tc.URI)
+		s.NoError(err)
+		s.Equal(tc.expectedErr, historyArchiver.ValidateURI(URI))
+	}
+}
+
+func (s *historyArchiverSuite) TestArchive_Fail_InvalidURI() {
+	historyArchiver := s.newTestHistoryArchiver(nil)
+	URI, err := archiver.NewURI("wrongscheme://")
+	s.NoError(err)
+	err = historyArchiver.Archive(context.Background(), URI, s.newArchiveRequest(testCloseFailoverVersion))
+	s.Error(err)
+}
+
+func (s *historyArchiverSuite) TestArchive_Fail_InvalidRequest() {
+	historyArchiver := s.newTestHistoryArchiver(nil)
+	request := s.newArchiveRequest(testCloseFailoverVersion)
+	request.WorkflowID = "" // an invalid request
+	err := historyArchiver.Archive(context.Background(), s.testArchivalURI, request)
+	s.Error(err)
+}
+
+func (s *historyArchiverSuite) TestArchive_Fail_NonRetryableErrorOption() {
+	mockCtrl := gomock.NewController(s.T())
+	historyIterator := archiver.NewMockHistoryIterator(mockCtrl)
+	gomock.InOrder(
+		historyIterator.EXPECT().HasNext().Return(true),
+		historyIterator.EXPECT().Next(gomock.Any()).Return(nil, errors.New("some random error")),
+	)
+
+	historyArchiver := s.newTestHistoryArchiver(historyIterator)
+	nonRetryableErr := errors.New("some non-retryable error")
+	err := historyArchiver.Archive(context.Background(), s.testArchivalURI, s.newArchiveRequest(testCloseFailoverVersion), archiver.GetNonRetryableErrorOption(nonRetryableErr))
+	s.Equal(nonRetryableErr, err)
+}
+
+func (s *historyArchiverSuite) TestArchive_Fail_HistoryMutated() {
+	historyBatches := cloneHistoryBatches(s.historyBatchesV100)
+	historyBatches[1].Events[0].Version = testCloseFailoverVersion + 1
+
+	historyArchiver := s.newTestHistoryArchiver(s.newHistoryIterator(historyBatches))
+	err := historyArchiver.Archive(context.Background(), s.testArchivalURI, s.newArchiveRequest(testCloseFailoverVersion))
+	s.Equal(archiver.ErrHistoryMutated, err)
+}
+
+func (s *historyArchiverSuite) TestArchive_Skip() {
+	mockCtrl := gomock.NewController(s.T())
+	historyIterator := archiver.NewMockHistoryIterator(mockCtrl)
+	historyBlob := &archiverspb.HistoryBlob{
+		Header: &archiverspb.HistoryBlobHeader{
+			IsLast: false,
+		},
+		Body: cloneHistoryBatches(s.historyBatchesV100[:1]),
+	}
+	gomock.InOrder(
+		historyIterator.EXPECT().HasNext().Return(true),
+		historyIterator.EXPECT().Next(gomock.Any()).Return(historyBlob, nil),
+		historyIterator.EXPECT().HasNext().Return(true),
+		historyIterator.EXPECT().Next(gomock.Any()).Return(nil, serviceerror.NewNotFound("workflow not found")),
+	)
+
+	historyArchiver := s.newTestHistoryArchiver(historyIterator)
+	err := historyArchiver.Archive(context.Background(), s.testArchivalURI, s.newArchiveRequest(testCloseFailoverVersion))
+	s.NoError(err)
+
+	_, err = historyArchiver.Get(context.Background(), s.testArchivalURI, s.newGetRequest())
+	s.IsType(&serviceerror.NotFound{}, err)
+}
+
+func (s *historyArchiverSuite) TestGet_Fail_InvalidURI() {
+	historyArchiver := s.newTestHistoryArchiver(nil)
+	URI, err := archiver.NewURI("wrongscheme://")
+	s.NoError(err)
+	response, err := historyArchiver.Get(context.Background(), URI, s.newGetRequest())
+	s.Nil(response)
+	s.IsType(&serviceerror.InvalidArgument{}, err)
+}
+
+func (s *historyArchiverSuite) TestGet_Fail_InvalidToken() {
+	historyArchiver := s.newTestHistoryArchiver(nil)
+	request := s.newGetRequest()
+	request.NextPageToken = []byte{'r', 'a', 'n', 'd', 'o', 'm'}
+	response, err := historyArchiver.Get(context.Background(), s.testArchivalURI, request)
+	s.Nil(response)
+	s.IsType(&serviceerror.InvalidArgument{}, err)
+}
+
+func (s *historyArchiverSuite) TestGet_Fail_HistoryNotExist() {
+	historyArchiver := s.newTestHistoryArchiver(nil)
+	response, err := historyArchiver.Get(context.Background(), s.testArchivalURI, s.newGetRequest())
+	s.Nil(response)
+	s.IsType(&serviceerror.NotFound{}, err)
+}
+
+func (s *historyArchiverSuite) TestArchiveAndGet_PickHighestVersion() {
+	s.archive(s.historyBatchesV1, 1)
+	s.archive(s.historyBatchesV100, testCloseFailoverVersion)
+
+	historyArchiver := s.newTestHistoryArchiver(nil)
+	response, err := historyArchiver.Get(context.Background(), s.testArchivalURI, s.newGetRequest())
+	s.NoError(err)
+	s.Nil(response.NextPageToken)
+	s.DeepEqual(s.historyBatchesV100, response.HistoryBatches)
+
+	request := s.newGetRequest()
+	version := int64(1)
+	request.CloseFailoverVersion = &version
+	response, err = historyArchiver.Get(context.Background(), s.testArchivalURI, request)
+	s.NoError(err)
+	s.Nil(response.NextPageToken)
+	s.DeepEqual(s.historyBatchesV1, response.HistoryBatches)
+}
+
+func (s *historyArchiverSuite) TestArchiveAndGet_SmallPageSize() {
+	s.archive(s.historyBatchesV100, testCloseFailoverVersion)
+
+	historyArchiver := s.newTestHistoryArchiver(nil)
+	request := s.newGetRequest()
+	request.PageSize = 1
+	var combinedHistory []*historypb.History
+
+	response, err := historyArchiver.Get(context.Background(), s.testArchivalURI, request)
+	s.NoError(err)
+	s.NotNil(response.NextPageToken)
+	s.Len(response.HistoryBatches, 1)
+	combinedHistory = append(combinedHistory, response.HistoryBatches...)
+
+	request.NextPageToken = response.NextPageToken
+	response, err = historyArchiver.Get(context.Background(), s.testArchivalURI, request)
+	s.NoError(err)
+	s.Nil(response.NextPageToken)
+	s.Len(response.HistoryBatches, 1)
+	combinedHistory = append(combinedHistory, response.HistoryBatches...)
+
+	s.DeepEqual(s.historyBatchesV100, combinedHistory)
+}
+
+func (s *historyArchiverSuite) TestArchive_Retry_FewerBatches() {
+	historyBatches := []*historypb.History{
+		s.historyBatchesV100[0],
+		s.historyBatchesV100[0],
+		s.historyBatchesV100[1],
+	}
+	s.archive(historyBatches, testCloseFailoverVersion)
+	s.archive(s.historyBatchesV100, testCloseFailoverVersion)
+
+	historyArchiver := s.newTestHistoryArchiver(nil)
+	response, err := historyArchiver.Get(context.Background(), s.testArchivalURI, s.newGetRequest())
+	s.NoError(err)
+	s.DeepEqual(s.historyBatchesV100, response.HistoryBatches)
+}
+
+func (s *historyArchiverSuite) TestArchiveAndVerify() {
+	s.archive(s.historyBatchesV100, testCloseFailoverVersion)
+
+	historyArchiver := s.newTestHistoryArchiver(nil)
+	manifest, err := historyArchiver.GetManifest(context.Background(), s.testArchivalURI, s.newGetRequest())
+	s.NoError(err)
+	s.Equal(testCloseFailoverVersion, manifest.GetCloseFailoverVersion())
+	s.Len(manifest.GetBatches(), len(s.historyBatchesV100))
+
+	verifyRequest := &archiver.VerifyHistoryRequest{
+		NamespaceID: testNamespaceID,
+		WorkflowID:  testWorkflowID,
+		RunID:       testRunID,
+	}
+	verification, err := archiver.VerifyHistory(context.Background(), historyArchiver, s.testArchivalURI, verifyRequest)
+	s.NoError(err)
+	s.Equal(enumsspb.ARCHIVAL_INTEGRITY_STATUS_OK, verification.Status)
+	s.Equal(testCloseFailoverVersion, verification.CloseFailoverVersion)
+
+	filter := sqlplugin.HistoryArchiveFilter{
+		NamespaceID:          testNamespaceID,
+		WorkflowID:           testWorkflowID,
+		RunID:                testRunID,
+		CloseFailoverVersion: testCloseFailoverVersion,
+		MinBatchIdx:          1,
+	}
+	_, err = s.db.DeleteFromHistoryArchive(context.Background(), filter)
+	s.NoError(err)
+	verification, err = archiver.VerifyHistory(context.Background(), historyArchiver, s.testArchivalURI, verifyRequest)
+	s.NoError(err)
+	s.Equal(enumsspb.ARCHIVAL_INTEGRITY_STATUS_TRUNCATED, verification.Status)
+
+	_, err = s.db.ReplaceIntoHistoryArchive(context.Background(), []sqlplugin.HistoryArchiveRow{{
+		NamespaceID:          testNamespaceID,
+		WorkflowID:           testWorkflowID,
+		RunID:                testRunID,
+		CloseFailoverVersion: testCloseFailoverVersion,
+		BatchIdx:             0,
+		Data:                 []byte("corrupted"),
+		DataEncoding:         "Proto3",
+	}})
+	s.NoError(err)
+	verification, err = archiver.VerifyHistory(context.Background(), historyArchiver, s.testArchivalURI, verifyRequest)
+	s.NoError(err)
+	s.Equal(enumsspb.ARCHIVAL_INTEGRITY_STATUS_CORRUPTED, verification.Status)
+
+	filter.MinBatchIdx = 0
+	_, err = s.db.DeleteFromHistoryArchive(context.Background(), filter)
+	s.NoError(err)
+	verification, err = archiver.VerifyHistory(context.Background(), historyArchiver, s.testArchivalURI, verifyRequest)
+	s.NoError(err)
+	s.Equal(enumsspb.ARCHIVAL_INTEGRITY_STATUS_MISSING, verification.Status)
+}
+
+func (s *historyArchiverSuite) TestGetManifest_Fail_ManifestNotExist() {
+	blob, err := serialization.ProtoEncode(s.historyBatchesV1[0])
+	s.NoError(err)
+	_, err = s.db.ReplaceIntoHistoryArchive(context.Background(), []sqlplugin.HistoryArchiveRow{{
+		NamespaceID:          testNamespaceID,
+		WorkflowID:           testWorkflowID,
+		RunID:                testRunID,
+		CloseFailoverVersion: 1,
+		BatchIdx:             0,
+		Data:                 blob.Data,
+		DataEncoding:         blob.EncodingType.String(),
+	}})
+	s.NoError(err)
+
+	historyArchiver := s.newTestHistoryArchiver(nil)
+	manifest, err := historyArchiver.GetManifest(context.Background(), s.testArchivalURI, s.newGetRequest())
+	s.Nil(manifest)
+	s.IsType(&serviceerror.NotFound{}, err)
+
+	verification, err := archiver.VerifyHistory(context.Background(), historyArchiver, s.testArchivalURI, &archiver.VerifyHistoryRequest{
+		NamespaceID: testNamespaceID,
+		WorkflowID:  testWorkflowID,
+		RunID:       testRunID,
+	})
+	s.NoError(err)
+	s.Equal(enumsspb.ARCHIVAL_INTEGRITY_STATUS_UNVERIFIED, verification.Status)
+}
+
+func (s *historyArchiverSuite) TestArchiveChasmExecution_Fail_InvalidRequest() {
+	historyArchiver := s.newTestHistoryArchiver(nil)
+	execution := newTestChasmExecution(testCloseFailoverVersion)
+	execution.Nodes = nil
+	err := historyArchiver.ArchiveChasmExecution(context.Background(), s.testArchivalURI, execution)
+	s.Error(err)
+}
+
+func (s *historyArchiverSuite) TestArchiveAndGetChasmExecution() {
+	historyArchiver := s.newTestHistoryArchiver(nil)
+	getRequest := &archiver.GetChasmExecutionRequest{
+		NamespaceID: testNamespaceID,
+		BusinessID:  testWorkflowID,
+		RunID:       testRunID,
+	}
+	_, err := historyArchiver.GetChasmExecution(context.Background(), s.testArchivalURI, getRequest)
+	s.IsType(&serviceerror.NotFound{}, err)
+
+	executionV1 := newTestChasmExecution(1)
+	executionV100 := newTestChasmExecution(testCloseFailoverVersion)
+	s.NoError(historyArchiver.ArchiveChasmExecution(context.Background(), s.testArchivalURI, executionV100))
+	s.NoError(historyArchiver.ArchiveChasmExecution(context.Background(), s.testArchivalURI, executionV1))
+	// an archival which is retried overwrites the previous attempt
+	s.NoError(historyArchiver.ArchiveChasmExecution(context.Background(), s.testArchivalURI, executionV1))
+
+	// the highest version is returned if no version is requested
+	execution, err := historyArchiver.GetChasmExecution(context.Background(), s.testArchivalURI, getRequest)
+	s.NoError(err)
+	s.ProtoEqual(executionV100, execution)
+
+	version := int64(1)
+	getRequest.CloseFailoverVersion = &version
+	execution, err = historyArchiver.GetChasmExecution(context.Background(), s.testArchivalURI, getRequest)
+	s.NoError(err)
+	s.ProtoEqual(executionV1, execution)
+
+	missingVersion := int64(2)
+	getRequest.CloseFailoverVersion = &missingVersion
+	_, err = historyArchiver.GetChasmExecution(context.Background(), s.testArchivalURI, getRequest)
+	s.IsType(&serviceerror.NotFound{}, err)
+}
+
+func (s *historyArchiverSuite) newTestHistoryArchiver(historyIterator archiver.HistoryIterator) *historyArchiver {
+	return newHistoryArchiver(nil, s.logger, s.metricsHandler, s.db, historyIterator)
+}
+
+func (s *historyArchiverSuite) newHistoryIterator(historyBatches []*historypb.History) archiver.HistoryIterator {
+	mockCtrl := gomock.NewController(s.T())
+	historyIterator := archiver.NewMockHistoryIterator(mockCtrl)
+	historyBlob := &archiverspb.HistoryBlob{
+		Header: &archiverspb.HistoryBlobHeader{
+			IsLast: true,
+		},
+		Body: historyBatches,
+	}
+	gomock.InOrder(
+		historyIterator.EXPECT().HasNext().Return(true),
+		historyIterator.EXPECT().Next(gomock.Any()).Return(historyBlob, nil),
+		historyIterator.EXPECT().HasNext().Return(false).AnyTimes(),
+	)
+	return historyIterator
+}
+
+func (s *historyArchiverSuite) newArchiveRequest(version int64) *archiver.ArchiveHistoryRequest {
+	return &archiver.ArchiveHistoryRequest{
+		NamespaceID:          testNamespaceID,
+		Namespace:            testNamespace,
+		WorkflowID:           testWorkflowID,
+		RunID:                testRunID,
+		BranchToken:          testBranchToken,
+		NextEventID:          testNextEventID,
+		CloseFailoverVersion: version,
+	}
+}
+
+func (s *historyArchiverSuite) newGetRequest() *archiver.GetHistoryRequest {
+	return &archiver.GetHistoryRequest{
+		NamespaceID: testNamespaceID,
+		WorkflowID:  testWorkflowID,
+		RunID:       testRunID,
+		PageSize:    testPageSize,
+	}
+}
+
+func (s *historyArchiverSuite) archive(historyBatches []*historypb.History, version int64) {
+	historyArchiver := s.newTestHistoryArchiver(s.newHistoryIterator(cloneHistoryBatches(historyBatches)))
+	s.NoError(historyArchiver.Archive(context.Background(), s.testArchivalURI, s.newArchiveRequest(version)))
+}
+
+func (s *historyArchiverSuite) setupHistoryBatches() {
+	now := timestamppb.New(time.Date(2020, 8, 22, 1, 2, 3, 4, time.UTC))
+	s.historyBatchesV1 = []*historypb.History{
+		{
+			Events: []*historypb.HistoryEvent{
+				{
+					EventId:   testNextEventID - 1,
+					EventTime: now,
+					Version:   1,
+				},
+			},
+		},
+	}
+
+	s.historyBatchesV100 = []*historypb.History{
+		{
+			Events: []*historypb.HistoryEvent{
+				{
+					EventId:   common.FirstEventID + 1,
+					EventTime: now,
+					Version:   testCloseFailoverVersion,
+				},
+				{
+					EventId:   common.FirstEventID + 2,
+					EventTime: now,
+					Version:   testCloseFailoverVersion,
+				},
+			},
+		},
+		{
+			Events: []*historypb.HistoryEvent{
+				{
+					EventId:   testNextEventID - 1,
+					EventTime: now,
+					Version:   testCloseFailoverVersion,
+				},
+			},
+		},
+	}
+}
+
+// newTestArchivalDB returns a new in-memory SQLite database set up with the archival schema. The SQLite plugin keeps
+// the in-memory databases for the lifetime of the process, so each test uses its own database.
+func newTestArchivalDB(t *testing.T) sqlplugin.ArchivalDB {
+	cfg := &config.SQL{
+		PluginName:      "sqlite",
+		DatabaseName:    "temporal_archival_" + uuid.NewString(),
+		ConnectAddr:     "localhost",
+		ConnectProtocol: "tcp",
+		ConnectAttributes: map[string]string{
+			"mode":  "memory",
+			"cache": "private",
+		},
+	}
+	db, err := persistencesql.NewSQLArchivalDB(cfg, resolver.NewNoopResolver(), log.NewNoopLogger(), metrics.NoopMetricsHandler)
+	require.NoError(t, err)
+	return db
+}
+
+// cloneHistoryBatches clones the batches shared between tests, as archiving them caches their size.
+func cloneHistoryBatches(historyBatches []*historypb.History) []*historypb.History {
+	clones := make([]*historypb.History, 0, len(historyBatches))
+	for _, batch := range historyBatches {
+		clones = append(clones, common.CloneProto(batch))
+	}
+	return clones
+}
+
+func newTestChasmExecution(version int64) *archiverspb.ChasmExecution {
+	return &archiverspb.ChasmExecution{
+		NamespaceId:          testNamespaceID,
+		Namespace:            testNamespace,
+		BusinessId:           testWorkflowID,
+		RunId:                testRunID,
+		ArchetypeId:          1234,
+		Archetype:            "scheduler.scheduler",
+		CloseFailoverVersion: version,
+		CloseTime:            timestamppb.New(time.Date(2020, 8, 22, 1, 2, 3, 4, time.UTC)),
+		Nodes: map[string]*persistencespb.ChasmNode{
+			"": {
+				Metadata: &persistencespb.ChasmNodeMetadata{
+					Attributes: &persistencespb.ChasmNodeMetadata_ComponentAttributes{
+						ComponentAttributes: &persistencespb.ChasmComponentAttributes{TypeId: 1234},
+					},
+				},
+				Data: &commonpb.DataBlob{
+					EncodingType: enumspb.ENCODING_TYPE_PROTO3,
+					Data:         []byte{byte(version)},
+				},
+			},
+		},
+	}
+}
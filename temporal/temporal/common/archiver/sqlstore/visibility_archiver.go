@@ -0,0 +1,321 @@
+package sqlstore
+
+import (
+	"context"
+	"time"
+
+	"go.temporal.io/api/serviceerror"
+	archiverspb "go.temporal.io/server/api/archiver/v1"
+	"go.temporal.io/server/common"
+	"go.temporal.io/server/common/archiver"
+	"go.temporal.io/server/common/archiver/visibilityquery"
+	"go.temporal.io/server/common/config"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/log/tag"
+	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/persistence/serialization"
+	persistencesql "go.temporal.io/server/common/persistence/sql"
+	"go.temporal.io/server/common/persistence/sql/sqlplugin"
+	"go.temporal.io/server/common/resolver"
+	"go.temporal.io/server/common/searchattribute"
+)
+
+const (
+	errEncodeVisibilityRecord = "failed to encode visibility record"
+	errWriteVisibilityRecord  = "failed to write visibility record to database"
+
+	// readPageSize is the number of visibility records read per query to the database. The records are narrowed
+	// down by the indexed columns in the database, and the rest of the query is evaluated on the records.
+	readPageSize = 1000
+	// maxScannedRowsPerPage is the maximum number of visibility records read for a page of a query. A page is returned
+	// with fewer executions than its page size, and a token to resume reading after the last read record, once it is
+	// reached.
+	maxScannedRowsPerPage = 10 * readPageSize
+	// maxOrderedScannedRows is the maximum number of visibility records read for a query with an ORDER BY clause, whose
+	// records are all read to be sorted for every page.
+	maxOrderedScannedRows = 100 * readPageSize
+)
+
+var errTooManyOrderedScannedRecords = serviceerror.NewInvalidArgumentf(
+	"query with an ORDER BY clause reads more than %d archived records, narrow it with a CloseTime range, WorkflowId, WorkflowType or RunId, or remove the ORDER BY clause",
+	maxOrderedScannedRows,
+)
+
+type (
+	visibilityArchiver struct {
+		logger                log.Logger
+		metricsHandler        metrics.Handler
+		db                    sqlplugin.ArchivalDB
+		maxScannedRows        int
+		maxOrderedScannedRows int
+	}
+
+	queryVisibilityToken struct {
+		LastCloseTime time.Time
+		LastRunID     string
+	}
+)
+
+// NewVisibilityArchiver creates a new archiver.VisibilityArchiver based on a SQL database
+func NewVisibilityArchiver(
+	logger log.Logger,
+	metricsHandler metrics.Handler,
+	config *config.SQL,
+) (archiver.VisibilityArchiver, error) {
+	db, err := persistencesql.NewSQLArchivalDB(config, resolver.NewNoopResolver(), logger, metricsHandler)
+	if err != nil {
+		return nil, err
+	}
+	return newVisibilityArchiver(logger, metricsHandler, db), nil
+}
+
+func newVisibilityArchiver(
+	logger log.Logger,
+	metricsHandler metrics.Handler,
+	db sqlplugin.ArchivalDB,
+) *visibilityArchiver {
+	return &visibilityArchiver{
+		logger:                logger,
+		metricsHandler:        metricsHandler,
+		db:                    db,
+		maxScannedRows:        maxScannedRowsPerPage,
+		maxOrderedScannedRows: maxOrderedScannedRows,
+	}
+}
+
+func (v *visibilityArchiver) Archive(
+	ctx context.Context,
+	URI archiver.URI,
+	request *archiverspb.VisibilityRecord,
+	opts ...archiver.ArchiveOption,
+) (err error) {
+	featureCatalog := archiver.GetFeatureCatalog(opts...)
+	defer func() {
+		if err != nil && !common.IsPersistenceTransientError(err) && featureCatalog.NonRetryableError != nil {
+			err = featureCatalog.NonRetryableError()
+		}
+	}()
+
+	logger := archiver.TagLoggerWithArchiveVisibilityRequestAndURI(v.logger, request, URI.String())
+
+	if err := v.ValidateURI(URI); err != nil {
+		logger.Error(archiver.ArchiveNonRetryableErrorMsg, tag.ArchivalArchiveFailReason(archiver.ErrReasonInvalidURI), tag.Error(err))
+		return err
+	}
+
+	if err := archiver.ValidateVisibilityArchivalRequest(request); err != nil {
+		logger.Error(archiver.ArchiveNonRetryableErrorMsg, tag.ArchivalArchiveFailReason(archiver.ErrReasonInvalidArchiveRequest), tag.Error(err))
+		return err
+	}
+
+	blob, err := serialization.ProtoEncode(request)
+	if err != nil {
+		logger.Error(archiver.ArchiveNonRetryableErrorMsg, tag.ArchivalArchiveFailReason(errEncodeVisibilityRecord), tag.Error(err))
+		return err
+	}
+
+	if _, err := v.db.ReplaceIntoVisibilityArchive(ctx, &sqlplugin.VisibilityArchiveRow{
+		NamespaceID:      request.GetNamespaceId(),
+		RunID:            request.GetRunId(),
+		WorkflowID:       request.GetWorkflowId(),
+		WorkflowTypeName: request.GetWorkflowTypeName(),
+		CloseTime:        request.GetCloseTime().AsTime(),
+		Data:             blob.Data,
+		DataEncoding:     blob.EncodingType.String(),
+	}); err != nil {
+		// the database may be temporarily unavailable, so the archival is retried
+		logger.Error(archiver.ArchiveTransientErrorMsg, tag.ArchivalArchiveFailReason(errWriteVisibilityRecord), tag.Error(err))
+		return serviceerror.NewUnavailable(err.Error())
+	}
+	return nil
+}
+
+func (v *visibilityArchiver) Query(
+	ctx context.Context,
+	URI archiver.URI,
+	request *archiver.QueryVisibilityRequest,
+	saTypeMap searchattribute.NameTypeMap,
+) (*archiver.QueryVisibilityResponse, error) {
+	if err := v.ValidateURI(URI); err != nil {
+		return nil, serviceerror.NewInvalidArgument(archiver.ErrInvalidURI.Error())
+	}
+
+	if err := archiver.ValidateQueryRequest(request); err != nil {
+		return nil, serviceerror.NewInvalidArgument(archiver.ErrInvalidQueryVisibilityRequest.Error())
+	}
+
+	evaluator, err := visibilityquery.NewEvaluator(request, saTypeMap)
+	if err != nil {
+		return nil, err
+	}
+
+	if evaluator.Ordered() {
+		return v.queryOrdered(ctx, request, evaluator, saTypeMap)
+	}
+
+	filter := newVisibilityArchiveFilter(request.NamespaceID, evaluator)
+	if request.NextPageToken != nil {
+		token, err := deserializeQueryVisibilityToken(request.NextPageToken)
+		if err != nil {
+			return nil, serviceerror.NewInvalidArgument(archiver.ErrNextPageTokenCorrupted.Error())
+		}
+		filter.LastCloseTime = &token.LastCloseTime
+		filter.LastRunID = &token.LastRunID
+	}
+
+	response := &archiver.QueryVisibilityResponse{}
+	scanned := 0
+	for {
+		rows, err := v.db.SelectFromVisibilityArchive(ctx, filter)
+		if err != nil {
+			return nil, serviceerror.NewUnavailable(err.Error())
+		}
+		scanned += len(rows)
+		for idx, row := range rows {
+			record, err := decodeVisibilityRecord(row)
+			if err != nil {
+				return nil, err
+			}
+			if !evaluator.Match(record) {
+				continue
+			}
+
+			executionInfo, err := convertToExecutionInfo(record, saTypeMap)
+			if err != nil {
+				return nil, serviceerror.NewInternal(err.Error())
+			}
+			response.Executions = append(response.Executions, executionInfo)
+			if len(response.Executions) == request.PageSize {
+				if idx < len(rows)-1 || len(rows) == filter.PageSize {
+					if response.NextPageToken, err = newQueryVisibilityToken(row); err != nil {
+						return nil, err
+					}
+				}
+				return response, nil
+			}
+		}
+		if len(rows) < filter.PageSize {
+			return response, nil
+		}
+		lastRow := rows[len(rows)-1]
+		if scanned >= v.maxScannedRows {
+			// the page is cut short, so that a query which matches few records doesn't read the whole namespace at once
+			if response.NextPageToken, err = newQueryVisibilityToken(lastRow); err != nil {
+				return nil, err
+			}
+			return response, nil
+		}
+		filter.LastCloseTime = &lastRow.CloseTime
+		filter.LastRunID = &lastRow.RunID
+	}
+}
+
+// queryOrdered queries the records of a query with an ORDER BY clause, which are all read to be sorted. It returns an
+// InvalidArgument error if more than visibilityquery.MaxOrderedRecords records match the query, or if more than
+// maxOrderedScannedRows records are read.
+func (v *visibilityArchiver) queryOrdered(
+	ctx context.Context,
+	request *archiver.QueryVisibilityRequest,
+	evaluator *visibilityquery.Evaluator,
+	saTypeMap searchattribute.NameTypeMap,
+) (*archiver.QueryVisibilityResponse, error) {
+	filter := newVisibilityArchiveFilter(request.NamespaceID, evaluator)
+
+	var records []*archiverspb.VisibilityRecord
+	scanned := 0
+	for {
+		rows, err := v.db.SelectFromVisibilityArchive(ctx, filter)
+		if err != nil {
+			return nil, serviceerror.NewUnavailable(err.Error())
+		}
+		if scanned += len(rows); scanned > v.maxOrderedScannedRows {
+			return nil, errTooManyOrderedScannedRecords
+		}
+		for _, row := range rows {
+			record, err := decodeVisibilityRecord(row)
+			if err != nil {
+				return nil, err
+			}
+			if evaluator.Match(record) {
+				records = append(records, record)
+				if err := visibilityquery.CheckOrderedRecords(len(records)); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if len(rows) < filter.PageSize {
+			break
+		}
+		lastRow := rows[len(rows)-1]
+		filter.LastCloseTime = &lastRow.CloseTime
+		filter.LastRunID = &lastRow.RunID
+	}
+
+	records, nextPageToken, err := evaluator.SortPage(records, request.PageSize, request.NextPageToken)
+	if err != nil {
+		return nil, err
+	}
+	response := &archiver.QueryVisibilityResponse{NextPageToken: nextPageToken}
+	for _, record := range records {
+		executionInfo, err := convertToExecutionInfo(record, saTypeMap)
+		if err != nil {
+			return nil, serviceerror.NewInternal(err.Error())
+		}
+		response.Executions = append(response.Executions, executionInfo)
+	}
+	return response, nil
+}
+
+func (v *visibilityArchiver) ValidateURI(URI archiver.URI) error {
+	if URI.Scheme() != URIScheme {
+		return archiver.ErrURISchemeMismatch
+	}
+	return nil
+}
+
+// newVisibilityArchiveFilter returns the filter of the records of the namespace which can match the query of the
+// evaluator, on the indexed columns of the visibility_archive table.
+func newVisibilityArchiveFilter(namespaceID string, evaluator *visibilityquery.Evaluator) sqlplugin.VisibilityArchiveFilter {
+	filter := sqlplugin.VisibilityArchiveFilter{
+		NamespaceID: namespaceID,
+		PageSize:    readPageSize,
+	}
+	if workflowID, ok := evaluator.WorkflowID(); ok {
+		filter.WorkflowID = &workflowID
+	}
+	if workflowTypeName, ok := evaluator.WorkflowTypeName(); ok {
+		filter.WorkflowTypeName = &workflowTypeName
+	}
+	if runID, ok := evaluator.RunID(); ok {
+		filter.RunID = &runID
+	}
+	earliestCloseTime, latestCloseTime := evaluator.CloseTimeRange()
+	if !earliestCloseTime.IsZero() {
+		filter.EarliestCloseTime = &earliestCloseTime
+	}
+	if !latestCloseTime.IsZero() {
+		filter.LatestCloseTime = &latestCloseTime
+	}
+	return filter
+}
+
+// newQueryVisibilityToken returns the next page token of a query which resumes reading after the row.
+func newQueryVisibilityToken(row sqlplugin.VisibilityArchiveRow) ([]byte, error) {
+	token, err := serializeToken(&queryVisibilityToken{
+		LastCloseTime: row.CloseTime,
+		LastRunID:     row.RunID,
+	})
+	if err != nil {
+		return nil, serviceerror.NewInternal(err.Error())
+	}
+	return token, nil
+}
+
+func decodeVisibilityRecord(row sqlplugin.VisibilityArchiveRow) (*archiverspb.VisibilityRecord, error) {
+	record := &archiverspb.VisibilityRecord{}
+	if err := serialization.Decode(persistence.NewDataBlob(row.Data, row.DataEncoding), record); err != nil {
+		return nil, serviceerror.NewInternal(err.Error())
+	}
+	return record, nil
+}
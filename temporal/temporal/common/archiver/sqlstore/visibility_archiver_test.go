@@ -0,0 +1,410 @@
+package sqlstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	archiverspb "go.temporal.io/server/api/archiver/v1"
+	"go.temporal.io/server/common/archiver"
+	"go.temporal.io/server/common/archiver/visibilityquery"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/common/persistence/sql/sqlplugin"
+	"go.temporal.io/server/common/searchattribute"
+	"go.temporal.io/server/common/testing/protorequire"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	testWorkflowTypeName = "test-workflow-type"
+)
+
+var (
+	testCloseTime = time.Date(2020, 8, 22, 1, 2, 3, 0, time.UTC)
+)
+
+type visibilityArchiverSuite struct {
+	*require.Assertions
+	protorequire.ProtoAssertions
+	suite.Suite
+
+	logger            log.Logger
+	metricsHandler    metrics.Handler
+	db                sqlplugin.ArchivalDB
+	testArchivalURI   archiver.URI
+	visibilityRecords []*archiverspb.VisibilityRecord
+}
+
+func TestVisibilityArchiverSuite(t *testing.T) {
+	suite.Run(t, new(visibilityArchiverSuite))
+}
+
+func (s *visibilityArchiverSuite) SetupSuite() {
+	var err error
+	s.testArchivalURI, err = archiver.NewURI("sql://")
+	s.Require().NoError(err)
+	s.setupVisibilityRecords()
+}
+
+func (s *visibilityArchiverSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.ProtoAssertions = protorequire.New(s.T())
+	s.logger = log.NewNoopLogger()
+	s.metricsHandler = metrics.NoopMetricsHandler
+	s.db = newTestArchivalDB(s.T())
+}
+
+func (s *visibilityArchiverSuite) TearDownTest() {
+	s.NoError(s.db.Close())
+}
+
+func (s *visibilityArchiverSuite) TestValidateURI() {
+	testCases := []struct {
+		URI         string
+		expectedErr error
+	}{
+		{
+			URI:         "wrongscheme://",
+			expectedErr: archiver.ErrURISchemeMismatch,
+		},
+		{
+			URI:         "sql://",
+			expectedErr: nil,
+		},
+	}
+
+	visibilityArchiver := s.newTestVisibilityArchiver()
+	for _, tc := range testCases {
+		URI, err := archiver.NewURI(tc.URI)
+		s.NoError(err)
+		s.Equal(tc.expectedErr, visibilityArchiver.ValidateURI(URI))
+	}
+}
+
+func (s *visibilityArchiverSuite) TestArchive_Fail_InvalidURI() {
+	visibilityArchiver := s.newTestVisibilityArchiver()
+	URI, err := archiver.NewURI("wrongscheme://")
+	s.NoError(err)
+	err = visibilityArchiver.Archive(context.Background(), URI, s.visibilityRecords[0])
+	s.Error(err)
+}
+
+func (s *visibilityArchiverSuite) TestArchive_Fail_NonRetryableErrorOption() {
+	visibilityArchiver := s.newTestVisibilityArchiver()
+	nonRetryableErr := errors.New("some non-retryable error")
+	err := visibilityArchiver.Archive(context.Background(), s.testArchivalURI, &archiverspb.VisibilityRecord{}, archiver.GetNonRetryableErrorOption(nonRetryableErr))
+	s.Equal(nonRetryableErr, err)
+}
+
+func (s *visibilityArchiverSuite) TestQuery_Fail_InvalidQuery() {
+	visibilityArchiver := s.newTestVisibilityArchiver()
+	request := &archiver.QueryVisibilityRequest{
+		NamespaceID: testNamespaceID,
+		PageSize:    1,
+		Query:       "some invalid query",
+	}
+	response, err := visibilityArchiver.Query(context.Background(), s.testArchivalURI, request, searchattribute.TestNameTypeMap())
+	s.Error(err)
+	s.Nil(response)
+}
+
+func (s *visibilityArchiverSuite) TestQuery_Fail_InvalidToken() {
+	visibilityArchiver := s.newTestVisibilityArchiver()
+	request := &archiver.QueryVisibilityRequest{
+		NamespaceID:   testNamespaceID,
+		PageSize:      1,
+		NextPageToken: []byte{1, 2, 3},
+	}
+	response, err := visibilityArchiver.Query(context.Background(), s.testArchivalURI, request, searchattribute.TestNameTypeMap())
+	s.IsType(&serviceerror.InvalidArgument{}, err)
+	s.Nil(response)
+}
+
+func (s *visibilityArchiverSuite) TestArchive_Replace() {
+	visibilityArchiver := s.newTestVisibilityArchiver()
+	record := &archiverspb.VisibilityRecord{
+		NamespaceId:      testNamespaceID,
+		Namespace:        testNamespace,
+		WorkflowId:       testWorkflowID,
+		RunId:            testRunID,
+		WorkflowTypeName: testWorkflowTypeName,
+		StartTime:        timestamppb.New(testCloseTime.Add(-time.Hour)),
+		CloseTime:        timestamppb.New(testCloseTime),
+		Status:           enumspb.WORKFLOW_EXECUTION_STATUS_FAILED,
+	}
+	s.NoError(visibilityArchiver.Archive(context.Background(), s.testArchivalURI, record))
+	s.NoError(visibilityArchiver.Archive(context.Background(), s.testArchivalURI, s.visibilityRecords[0]))
+
+	executions := s.queryAll(&archiver.QueryVisibilityRequest{
+		NamespaceID: testNamespaceID,
+		PageSize:    10,
+	})
+	s.Len(executions, 1)
+	s.ProtoEqual(s.executionInfo(0), executions[0])
+}
+
+func (s *visibilityArchiverSuite) TestArchiveAndQuery() {
+	s.archiveAll()
+
+	testCases := []struct {
+		query    string
+		expected []int
+	}{
+		{
+			query:    "",
+			expected: []int{0, 1, 2, 3},
+		},
+		{
+			query:    fmt.Sprintf("WorkflowId = '%s'", testWorkflowID),
+			expected: []int{0},
+		},
+		{
+			query:    "RunId = 'another run ID'",
+			expected: []int{2},
+		},
+		{
+			query:    "WorkflowType = 'test-workflow-type' AND ExecutionStatus = 'Failed'",
+			expected: []int{0, 1, 3},
+		},
+		{
+			query:    "WorkflowId IN ('some random workflow ID', 'another workflow ID') OR HistoryLength = 101",
+			expected: []int{0, 1, 2},
+		},
+		{
+			query:    "CloseTime >= '2020-08-22T01:02:03Z' AND CloseTime < '2020-08-22T03:02:03Z'",
+			expected: []int{2, 3},
+		},
+		{
+			query:    "ExecutionStatus = 'Failed' ORDER BY StartTime ASC",
+			expected: []int{0, 1, 3},
+		},
+	}
+	for _, tc := range testCases {
+		executions := s.queryAll(&archiver.QueryVisibilityRequest{
+			NamespaceID: testNamespaceID,
+			PageSize:    1,
+			Query:       tc.query,
+		})
+		s.Len(executions, len(tc.expected), tc.query)
+		for i, idx := range tc.expected {
+			s.ProtoEqual(s.executionInfo(idx), executions[i])
+		}
+	}
+}
+
+func (s *visibilityArchiverSuite) TestQuery_ReadPages() {
+	visibilityArchiver := s.newTestVisibilityArchiver()
+	numRecords := readPageSize + 10
+	for i := 0; i < numRecords; i++ {
+		record := &archiverspb.VisibilityRecord{
+			NamespaceId:      testNamespaceID,
+			Namespace:        testNamespace,
+			WorkflowId:       fmt.Sprintf("workflow-%d", i),
+			RunId:            fmt.Sprintf("run-%04d", i),
+			WorkflowTypeName: testWorkflowTypeName,
+			StartTime:        timestamppb.New(testCloseTime.Add(-time.Hour)),
+			CloseTime:        timestamppb.New(testCloseTime),
+			Status:           enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED,
+			HistoryLength:    int64(i),
+		}
+		s.NoError(visibilityArchiver.Archive(context.Background(), s.testArchivalURI, record))
+	}
+
+	request := &archiver.QueryVisibilityRequest{
+		NamespaceID: testNamespaceID,
+		PageSize:    5,
+		Query:       "HistoryLength < 3",
+	}
+	response, err := visibilityArchiver.Query(context.Background(), s.testArchivalURI, request, searchattribute.TestNameTypeMap())
+	s.NoError(err)
+	s.Nil(response.NextPageToken)
+	s.Len(response.Executions, 3)
+	s.Equal("run-0002", response.Executions[0].GetExecution().GetRunId())
+
+	executions := s.queryAll(&archiver.QueryVisibilityRequest{
+		NamespaceID: testNamespaceID,
+		PageSize:    100,
+	})
+	s.Len(executions, numRecords)
+}
+
+func (s *visibilityArchiverSuite) TestQuery_MaxScannedRows() {
+	visibilityArchiver := s.newTestVisibilityArchiver()
+	visibilityArchiver.maxScannedRows = readPageSize
+	visibilityArchiver.maxOrderedScannedRows = readPageSize
+	numRecords := readPageSize + 10
+	for i := 0; i < numRecords; i++ {
+		record := &archiverspb.VisibilityRecord{
+			NamespaceId:      testNamespaceID,
+			Namespace:        testNamespace,
+			WorkflowId:       fmt.Sprintf("workflow-%d", i),
+			RunId:            fmt.Sprintf("run-%04d", i),
+			WorkflowTypeName: testWorkflowTypeName,
+			StartTime:        timestamppb.New(testCloseTime.Add(-time.Hour)),
+			CloseTime:        timestamppb.New(testCloseTime),
+			Status:           enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED,
+			HistoryLength:    int64(i),
+		}
+		s.NoError(visibilityArchiver.Archive(context.Background(), s.testArchivalURI, record))
+	}
+
+	// The first page stops reading after maxScannedRows records, none of which match.
+	request := &archiver.QueryVisibilityRequest{
+		NamespaceID: testNamespaceID,
+		PageSize:    5,
+		Query:       "HistoryLength < 3",
+	}
+	response, err := visibilityArchiver.Query(context.Background(), s.testArchivalURI, request, searchattribute.TestNameTypeMap())
+	s.NoError(err)
+	s.Empty(response.Executions)
+	s.NotNil(response.NextPageToken)
+
+	request.NextPageToken = response.NextPageToken
+	response, err = visibilityArchiver.Query(context.Background(), s.testArchivalURI, request, searchattribute.TestNameTypeMap())
+	s.NoError(err)
+	s.Nil(response.NextPageToken)
+	s.Len(response.Executions, 3)
+	s.Equal("run-0002", response.Executions[0].GetExecution().GetRunId())
+
+	// An ordered query can't resume its scan, so it is rejected instead.
+	request = &archiver.QueryVisibilityRequest{
+		NamespaceID: testNamespaceID,
+		PageSize:    5,
+		Query:       "HistoryLength < 3 ORDER BY HistoryLength ASC",
+	}
+	_, err = visibilityArchiver.Query(context.Background(), s.testArchivalURI, request, searchattribute.TestNameTypeMap())
+	s.ErrorIs(err, errTooManyOrderedScannedRecords)
+}
+
+func (s *visibilityArchiverSuite) TestQuery_OrderedTooManyRecords() {
+	visibilityArchiver := s.newTestVisibilityArchiver()
+	for i := 0; i <= visibilityquery.MaxOrderedRecords; i++ {
+		record := &archiverspb.VisibilityRecord{
+			NamespaceId:      testNamespaceID,
+			Namespace:        testNamespace,
+			WorkflowId:       fmt.Sprintf("workflow-%d", i),
+			RunId:            fmt.Sprintf("run-%05d", i),
+			WorkflowTypeName: testWorkflowTypeName,
+			StartTime:        timestamppb.New(testCloseTime.Add(-time.Hour)),
+			CloseTime:        timestamppb.New(testCloseTime.Add(time.Duration(i) * time.Second)),
+			Status:           enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED,
+			HistoryLength:    int64(i),
+		}
+		s.NoError(visibilityArchiver.Archive(context.Background(), s.testArchivalURI, record))
+	}
+
+	request := &archiver.QueryVisibilityRequest{
+		NamespaceID: testNamespaceID,
+		PageSize:    5,
+		Query:       "ORDER BY HistoryLength ASC",
+	}
+	_, err := visibilityArchiver.Query(context.Background(), s.testArchivalURI, request, searchattribute.TestNameTypeMap())
+	s.ErrorIs(err, visibilityquery.ErrTooManyOrderedRecords)
+
+	// A narrower query is sorted.
+	request.Query = fmt.Sprintf("CloseTime >= '%s' ORDER BY HistoryLength ASC", testCloseTime.Add(time.Second).Format(time.RFC3339))
+	response, err := visibilityArchiver.Query(context.Background(), s.testArchivalURI, request, searchattribute.TestNameTypeMap())
+	s.NoError(err)
+	s.Len(response.Executions, 5)
+	s.Equal("run-00001", response.Executions[0].GetExecution().GetRunId())
+}
+
+func (s *visibilityArchiverSuite) newTestVisibilityArchiver() *visibilityArchiver {
+	return newVisibilityArchiver(s.logger, s.metricsHandler, s.db)
+}
+
+func (s *visibilityArchiverSuite) archiveAll() {
+	visibilityArchiver := s.newTestVisibilityArchiver()
+	for _, record := range s.visibilityRecords {
+		s.NoError(visibilityArchiver.Archive(context.Background(), s.testArchivalURI, record))
+	}
+}
+
+func (s *visibilityArchiverSuite) queryAll(request *archiver.QueryVisibilityRequest) []*workflowpb.WorkflowExecutionInfo {
+	visibilityArchiver := s.newTestVisibilityArchiver()
+	var executions []*workflowpb.WorkflowExecutionInfo
+	for {
+		response, err := visibilityArchiver.Query(context.Background(), s.testArchivalURI, request, searchattribute.TestNameTypeMap())
+		s.NoError(err, request.Query)
+		s.LessOrEqual(len(response.Executions), request.PageSize)
+		executions = append(executions, response.Executions...)
+		if response.NextPageToken == nil {
+			return executions
+		}
+		request.NextPageToken = response.NextPageToken
+	}
+}
+
+func (s *visibilityArchiverSuite) executionInfo(idx int) *workflowpb.WorkflowExecutionInfo {
+	executionInfo, err := convertToExecutionInfo(s.visibilityRecords[idx], searchattribute.TestNameTypeMap())
+	s.NoError(err)
+	return executionInfo
+}
+
+func (s *visibilityArchiverSuite) setupVisibilityRecords() {
+	s.visibilityRecords = []*archiverspb.VisibilityRecord{
+		&archiverspb.VisibilityRecord{
+			NamespaceId:      testNamespaceID,
+			Namespace:        testNamespace,
+			WorkflowId:       testWorkflowID,
+			RunId:            testRunID,
+			WorkflowTypeName: testWorkflowTypeName,
+			StartTime:        timestamppb.New(testCloseTime.Add(-time.Hour)),
+			CloseTime:        timestamppb.New(testCloseTime.Add(3 * time.Hour)),
+			Status:           enumspb.WORKFLOW_EXECUTION_STATUS_FAILED,
+			HistoryLength:    101,
+		},
+		&archiverspb.VisibilityRecord{
+			NamespaceId:      testNamespaceID,
+			Namespace:        testNamespace,
+			WorkflowId:       "some random workflow ID",
+			RunId:            "some random run ID",
+			WorkflowTypeName: testWorkflowTypeName,
+			StartTime:        timestamppb.New(testCloseTime.Add(-time.Minute)),
+			CloseTime:        timestamppb.New(testCloseTime.Add(2 * time.Hour)),
+			Status:           enumspb.WORKFLOW_EXECUTION_STATUS_FAILED,
+			HistoryLength:    123,
+		},
+		&archiverspb.VisibilityRecord{
+			NamespaceId:      testNamespaceID,
+			Namespace:        testNamespace,
+			WorkflowId:       "another workflow ID",
+			RunId:            "another run ID",
+			WorkflowTypeName: testWorkflowTypeName,
+			StartTime:        timestamppb.New(testCloseTime.Add(-time.Second)),
+			CloseTime:        timestamppb.New(testCloseTime.Add(time.Hour)),
+			Status:           enumspb.WORKFLOW_EXECUTION_STATUS_CONTINUED_AS_NEW,
+			HistoryLength:    456,
+		},
+		&archiverspb.VisibilityRecord{
+			NamespaceId:      testNamespaceID,
+			Namespace:        testNamespace,
+			WorkflowId:       "and another workflow ID",
+			RunId:            "and another run ID",
+			WorkflowTypeName: testWorkflowTypeName,
+			StartTime:        timestamppb.New(testCloseTime.Add(-time.Second)),
+			CloseTime:        timestamppb.New(testCloseTime),
+			Status:           enumspb.WORKFLOW_EXECUTION_STATUS_FAILED,
+			HistoryLength:    456,
+		},
+		&archiverspb.VisibilityRecord{
+			NamespaceId:      "some random namespace ID",
+			Namespace:        "some random namespace name",
+			WorkflowId:       "another workflow ID",
+			RunId:            "another run ID",
+			WorkflowTypeName: testWorkflowTypeName,
+			StartTime:        timestamppb.New(testCloseTime.Add(-time.Second)),
+			CloseTime:        timestamppb.New(testCloseTime.Add(3 * time.Hour)),
+			Status:           enumspb.WORKFLOW_EXECUTION_STATUS_CONTINUED_AS_NEW,
+			HistoryLength:    456,
+		},
+	}
+}
@@ -13,6 +13,9 @@ var (
 	errEmptyNamespace        = errors.New("field Namespace is empty")
 	errEmptyWorkflowID       = errors.New("field WorkflowId is empty")
 	errEmptyRunID            = errors.New("field RunId is empty")
+	errEmptyBusinessID       = errors.New("field BusinessId is empty")
+	errEmptyArchetypeID      = errors.New("field ArchetypeId is empty")
+	errEmptyNodes            = errors.New("field Nodes is empty")
 	errInvalidPageSize       = errors.New("field PageSize should be greater than 0")
 	errEmptyWorkflowTypeName = errors.New("field WorkflowTypeName is empty")
 	errEmptyStartTime        = errors.New("field StartTime is empty")
@@ -50,6 +53,20 @@ func TagLoggerWithArchiveVisibilityRequestAndURI(logger log.Logger, request *arc
 	)
 }
 
+// TagLoggerWithArchiveChasmExecutionRequestAndURI tags logger with fields in the archive CHASM execution request and
+// the URI
+func TagLoggerWithArchiveChasmExecutionRequestAndURI(logger log.Logger, request *archiverspb.ChasmExecution, URI string) log.Logger {
+	return log.With(
+		logger,
+		tag.ArchivalRequestNamespaceID(request.GetNamespaceId()),
+		tag.ArchivalRequestNamespace(request.GetNamespace()),
+		tag.ArchivalRequestWorkflowID(request.GetBusinessId()),
+		tag.ArchivalRequestRunID(request.GetRunId()),
+		tag.ArchivalRequestCloseFailoverVersion(request.GetCloseFailoverVersion()),
+		tag.ArchivalURI(URI),
+	)
+}
+
 // ValidateHistoryArchiveRequest validates the archive history request
 func ValidateHistoryArchiveRequest(request *ArchiveHistoryRequest) error {
 	if request.NamespaceID == "" {
@@ -84,6 +101,43 @@ func ValidateGetRequest(request *GetHistoryRequest) error {
 	return nil
 }
 
+// ValidateChasmExecutionArchivalRequest validates the archive CHASM execution request
+func ValidateChasmExecutionArchivalRequest(request *archiverspb.ChasmExecution) error {
+	if request.GetNamespaceId() == "" {
+		return errEmptyNamespaceID
+	}
+	if request.GetNamespace() == "" {
+		return errEmptyNamespace
+	}
+	if request.GetBusinessId() == "" {
+		return errEmptyBusinessID
+	}
+	if request.GetRunId() == "" {
+		return errEmptyRunID
+	}
+	if request.GetArchetypeId() == 0 {
+		return errEmptyArchetypeID
+	}
+	if len(request.GetNodes()) == 0 {
+		return errEmptyNodes
+	}
+	return nil
+}
+
+// ValidateGetChasmExecutionRequest validates the get archived CHASM execution request
+func ValidateGetChasmExecutionRequest(request *GetChasmExecutionRequest) error {
+	if request.NamespaceID == "" {
+		return errEmptyNamespaceID
+	}
+	if request.BusinessID == "" {
+		return errEmptyBusinessID
+	}
+	if request.RunID == "" {
+		return errEmptyRunID
+	}
+	return nil
+}
+
 // ValidateVisibilityArchivalRequest validates the archive visibility request
 func ValidateVisibilityArchivalRequest(request *archiverspb.VisibilityRecord) error {
 	if request.GetNamespaceId() == "" {
@@ -0,0 +1,185 @@
+// Package visibilityquery evaluates visibility queries against archived visibility records, with the same grammar as
+// the live visibility stores, so that the same query works against live and archived visibility.
+package visibilityquery
+
+import (
+	"errors"
+	"time"
+
+	"go.temporal.io/api/serviceerror"
+	archiverspb "go.temporal.io/server/api/archiver/v1"
+	"go.temporal.io/server/common/archiver"
+	"go.temporal.io/server/common/namespace"
+	"go.temporal.io/server/common/persistence/visibility/store/query"
+	"go.temporal.io/server/common/searchattribute"
+	"go.temporal.io/server/common/searchattribute/sadefs"
+)
+
+// MaxOrderedRecords is the maximum number of matching records of a query with an ORDER BY clause. The matching records
+// of an ordered query are all read into memory and sorted for every page, so the queries which match more records are
+// rejected.
+const MaxOrderedRecords = 10000
+
+// ErrTooManyOrderedRecords is the error of the queries with an ORDER BY clause which match more than MaxOrderedRecords
+// records.
+var ErrTooManyOrderedRecords = serviceerror.NewInvalidArgumentf(
+	"query with an ORDER BY clause matches more than %d archived records, narrow it with a CloseTime range or remove the ORDER BY clause",
+	MaxOrderedRecords,
+)
+
+type (
+	// Evaluator evaluates the query of a QueryVisibilityRequest against archived visibility records.
+	Evaluator struct {
+		saTypeMap searchattribute.NameTypeMap
+		predicate query.ValuesPredicate
+		// sortFields are nil unless the query has an ORDER BY clause.
+		sortFields []query.SortField
+		hints      *hints
+	}
+)
+
+// NewEvaluator returns the Evaluator of the query of the request. The search attributes of the query are resolved with
+// the SearchAttributesMapper of the request, or are not aliased if there is none. It returns an InvalidArgument error
+// if the query is invalid.
+func NewEvaluator(
+	request *archiver.QueryVisibilityRequest,
+	saTypeMap searchattribute.NameTypeMap,
+) (*Evaluator, error) {
+	saMapper := request.SearchAttributesMapper
+	if saMapper == nil {
+		saMapper = searchattribute.NewNoopMapper()
+	}
+
+	queryParams, err := query.NewQueryConverter[query.ValuesPredicate](
+		&query.ValuesConverter{},
+		namespace.Name(request.Namespace),
+		saTypeMap,
+		saMapper,
+	).Convert(request.Query)
+	if err != nil {
+		return nil, convertQueryError(err)
+	}
+	if len(queryParams.GroupBy) > 0 {
+		return nil, serviceerror.NewInvalidArgument("GROUP BY clause is not supported")
+	}
+	// The hints are built from a second conversion of the query, which cannot fail if the first one succeeded.
+	hintsParams, err := query.NewQueryConverter[*hints](
+		&hintsConverter{},
+		namespace.Name(request.Namespace),
+		saTypeMap,
+		saMapper,
+	).Convert(request.Query)
+	if err != nil {
+		return nil, convertQueryError(err)
+	}
+
+	e := &Evaluator{
+		saTypeMap: saTypeMap,
+		predicate: queryParams.QueryExpr,
+		hints:     hintsParams.QueryExpr,
+	}
+	if len(queryParams.OrderBy) > 0 {
+		e.sortFields = query.NewSortFields(queryParams.OrderBy)
+	}
+	return e, nil
+}
+
+// Match returns true if the record matches the query.
+func (e *Evaluator) Match(record *archiverspb.VisibilityRecord) bool {
+	return e.predicate == nil || e.predicate(e.values(record))
+}
+
+// CloseTimeRange returns the range of the close times of the records which can match the query. The bounds are
+// inclusive, and are zero if the range is unbounded.
+func (e *Evaluator) CloseTimeRange() (earliest time.Time, latest time.Time) {
+	if e.hints == nil {
+		return time.Time{}, time.Time{}
+	}
+	return e.hints.earliestCloseTime, e.hints.latestCloseTime
+}
+
+// WorkflowID returns the workflow ID of the records which can match the query, if the query only matches one.
+func (e *Evaluator) WorkflowID() (string, bool) {
+	return e.hints.equalValue(sadefs.WorkflowID)
+}
+
+// WorkflowTypeName returns the workflow type name of the records which can match the query, if the query only matches
+// one.
+func (e *Evaluator) WorkflowTypeName() (string, bool) {
+	return e.hints.equalValue(sadefs.WorkflowType)
+}
+
+// RunID returns the run ID of the records which can match the query, if the query only matches one.
+func (e *Evaluator) RunID() (string, bool) {
+	return e.hints.equalValue(sadefs.RunID)
+}
+
+// Ordered returns true if the query has an ORDER BY clause. The records of an ordered query are sorted and paginated
+// with SortPage, otherwise they are in the order of the archiver, which is by descending close time.
+func (e *Evaluator) Ordered() bool {
+	return e.sortFields != nil
+}
+
+// SortPage sorts the matching records of an ordered query, and returns the page of at most pageSize records which
+// starts after the page token, and the token of the next page if there are more records.
+func (e *Evaluator) SortPage(
+	records []*archiverspb.VisibilityRecord,
+	pageSize int,
+	nextPageToken []byte,
+) ([]*archiverspb.VisibilityRecord, []byte, error) {
+	return query.SortPage(records, e.values, e.sortFields, pageSize, nextPageToken)
+}
+
+// CheckOrderedRecords returns ErrTooManyOrderedRecords if the count of matching records of an ordered query exceeds
+// MaxOrderedRecords.
+func CheckOrderedRecords(count int) error {
+	if count > MaxOrderedRecords {
+		return ErrTooManyOrderedRecords
+	}
+	return nil
+}
+
+// values returns the values of the search attributes of the record by field name, as evaluated by
+// query.ValuesConverter. The search attributes whose value cannot be parsed with their registered type are absent.
+func (e *Evaluator) values(record *archiverspb.VisibilityRecord) map[string]any {
+	searchAttributes, _ := searchattribute.Parse(record.GetSearchAttributes(), &e.saTypeMap)
+	values, _ := searchattribute.Decode(searchAttributes, &e.saTypeMap, false)
+	if values == nil {
+		values = make(map[string]any)
+	}
+	for name, value := range values {
+		if value == nil {
+			delete(values, name)
+		}
+	}
+
+	values[sadefs.NamespaceID] = record.GetNamespaceId()
+	values[sadefs.WorkflowID] = record.GetWorkflowId()
+	values[sadefs.RunID] = record.GetRunId()
+	values[sadefs.WorkflowType] = record.GetWorkflowTypeName()
+	values[sadefs.ExecutionStatus] = record.GetStatus().String()
+	values[sadefs.HistoryLength] = record.GetHistoryLength()
+	if record.StartTime != nil {
+		values[sadefs.StartTime] = record.StartTime.AsTime()
+	}
+	if record.ExecutionTime != nil {
+		values[sadefs.ExecutionTime] = record.ExecutionTime.AsTime()
+	}
+	if record.CloseTime != nil {
+		values[sadefs.CloseTime] = record.CloseTime.AsTime()
+	}
+	if record.ExecutionDuration != nil {
+		values[sadefs.ExecutionDuration] = record.ExecutionDuration.AsDuration().Nanoseconds()
+	}
+	return values
+}
+
+// convertQueryError converts ConverterError to InvalidArgument and passes through all other errors (which should be
+// only mapper errors).
+func convertQueryError(err error) error {
+	var converterErr *query.ConverterError
+	if errors.As(err, &converterErr) {
+		return converterErr.ToInvalidArgument()
+	}
+	return err
+}
@@ -0,0 +1,204 @@
+package visibilityquery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	archiverspb "go.temporal.io/server/api/archiver/v1"
+	"go.temporal.io/server/common/archiver"
+	"go.temporal.io/server/common/searchattribute"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var testCloseTime = time.Date(2024, 5, 6, 12, 0, 0, 0, time.UTC)
+
+func testRecords() []*archiverspb.VisibilityRecord {
+	return []*archiverspb.VisibilityRecord{
+		{
+			WorkflowId:        "wid-1",
+			RunId:             "completed",
+			WorkflowTypeName:  "type-a",
+			StartTime:         timestamppb.New(testCloseTime.Add(-time.Hour)),
+			CloseTime:         timestamppb.New(testCloseTime),
+			ExecutionDuration: durationpb.New(time.Hour),
+			Status:            enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED,
+			HistoryLength:     10,
+			SearchAttributes: map[string]string{
+				"Keyword01":     "order-123",
+				"Int01":         "5",
+				"Datetime01":    "2024-05-06T00:00:00Z",
+				"KeywordList01": `["red","green"]`,
+				"Text01":        "The quick brown fox",
+			},
+		},
+		{
+			WorkflowId:       "wid-2",
+			RunId:            "failed",
+			WorkflowTypeName: "type-b",
+			StartTime:        timestamppb.New(testCloseTime.Add(-2 * time.Hour)),
+			CloseTime:        timestamppb.New(testCloseTime.Add(time.Hour)),
+			Status:           enumspb.WORKFLOW_EXECUTION_STATUS_FAILED,
+			HistoryLength:    20,
+			SearchAttributes: map[string]string{
+				"Keyword01": "invoice-456",
+				"Int01":     "not a number",
+			},
+		},
+		{
+			WorkflowId:       "wid-3",
+			RunId:            "scheduler",
+			WorkflowTypeName: "type-a",
+			CloseTime:        timestamppb.New(testCloseTime.Add(2 * time.Hour)),
+			Status:           enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED,
+			SearchAttributes: map[string]string{
+				"TemporalNamespaceDivision": "TemporalScheduler",
+			},
+		},
+	}
+}
+
+func newTestEvaluator(t *testing.T, query string) *Evaluator {
+	e, err := NewEvaluator(&archiver.QueryVisibilityRequest{Query: query}, searchattribute.TestNameTypeMap())
+	require.NoError(t, err)
+	return e
+}
+
+func TestEvaluator_Match(t *testing.T) {
+	testCases := []struct {
+		name     string
+		query    string
+		expected []string
+	}{
+		{name: "empty", query: "", expected: []string{"completed", "failed"}},
+		{name: "workflow ID", query: "WorkflowId = 'wid-1'", expected: []string{"completed"}},
+		{name: "or", query: "WorkflowId = 'wid-1' OR WorkflowType = 'type-b'", expected: []string{"completed", "failed"}},
+		{name: "in", query: "RunId IN ('failed', 'other')", expected: []string{"failed"}},
+		{name: "status", query: "ExecutionStatus = 'Failed'", expected: []string{"failed"}},
+		{name: "close time", query: "CloseTime > '2024-05-06T12:30:00Z'", expected: []string{"failed"}},
+		{name: "duration", query: "ExecutionDuration >= '1h'", expected: []string{"completed"}},
+		{name: "history length", query: "HistoryLength BETWEEN 15 AND 25", expected: []string{"failed"}},
+		{name: "keyword starts with", query: "Keyword01 STARTS_WITH 'order-'", expected: []string{"completed"}},
+		{name: "int", query: "Int01 = 5", expected: []string{"completed"}},
+		{name: "unparsable value", query: "Int01 != 5", expected: []string{"failed"}},
+		{name: "datetime", query: "Datetime01 < '2024-05-07T00:00:00Z'", expected: []string{"completed"}},
+		{name: "keyword list", query: "KeywordList01 = 'green'", expected: []string{"completed"}},
+		{name: "text", query: "Text01 = 'fox'", expected: []string{"completed"}},
+		{name: "not", query: "NOT (WorkflowType = 'type-a')", expected: []string{"failed"}},
+		{name: "namespace division", query: "TemporalNamespaceDivision = 'TemporalScheduler'", expected: []string{"scheduler"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := newTestEvaluator(t, tc.query)
+			var matched []string
+			for _, record := range testRecords() {
+				if e.Match(record) {
+					matched = append(matched, record.GetRunId())
+				}
+			}
+			require.Equal(t, tc.expected, matched)
+		})
+	}
+}
+
+func TestEvaluator_InvalidQuery(t *testing.T) {
+	for _, query := range []string{
+		"WorkflowId = ",
+		"UnknownField = 'value'",
+		"WorkflowId = 'wid' GROUP BY ExecutionStatus",
+	} {
+		_, err := NewEvaluator(&archiver.QueryVisibilityRequest{Query: query}, searchattribute.TestNameTypeMap())
+		var invalidArgument *serviceerror.InvalidArgument
+		require.ErrorAs(t, err, &invalidArgument, query)
+	}
+}
+
+func TestEvaluator_SearchAttributesMapper(t *testing.T) {
+	e, err := NewEvaluator(&archiver.QueryVisibilityRequest{
+		Namespace:              "test-namespace",
+		Query:                  "AliasForKeyword01 = 'order-123'",
+		SearchAttributesMapper: &searchattribute.TestMapper{},
+	}, searchattribute.TestNameTypeMap())
+	require.NoError(t, err)
+	require.True(t, e.Match(testRecords()[0]))
+	require.False(t, e.Match(testRecords()[1]))
+}
+
+func TestEvaluator_Hints(t *testing.T) {
+	e := newTestEvaluator(t, "")
+	earliest, latest := e.CloseTimeRange()
+	require.True(t, earliest.IsZero())
+	require.True(t, latest.IsZero())
+	_, ok := e.WorkflowID()
+	require.False(t, ok)
+
+	e = newTestEvaluator(t, "WorkflowId = 'wid-1' AND WorkflowType = 'type-a' AND RunId = 'run' AND "+
+		"CloseTime >= '2024-05-06T00:00:00Z' AND CloseTime < '2024-05-07T00:00:00Z' AND CloseTime <= '2024-05-08T00:00:00Z'")
+	earliest, latest = e.CloseTimeRange()
+	require.Equal(t, time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC), earliest)
+	require.Equal(t, time.Date(2024, 5, 7, 0, 0, 0, 0, time.UTC), latest)
+	workflowID, ok := e.WorkflowID()
+	require.True(t, ok)
+	require.Equal(t, "wid-1", workflowID)
+	workflowTypeName, ok := e.WorkflowTypeName()
+	require.True(t, ok)
+	require.Equal(t, "type-a", workflowTypeName)
+	runID, ok := e.RunID()
+	require.True(t, ok)
+	require.Equal(t, "run", runID)
+
+	e = newTestEvaluator(t, "(WorkflowId = 'wid-1' AND CloseTime BETWEEN '2024-05-06T00:00:00Z' AND '2024-05-07T00:00:00Z') "+
+		"OR (WorkflowId = 'wid-1' AND CloseTime = '2024-05-08T00:00:00Z')")
+	earliest, latest = e.CloseTimeRange()
+	require.Equal(t, time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC), earliest)
+	require.Equal(t, time.Date(2024, 5, 8, 0, 0, 0, 0, time.UTC), latest)
+	workflowID, ok = e.WorkflowID()
+	require.True(t, ok)
+	require.Equal(t, "wid-1", workflowID)
+
+	e = newTestEvaluator(t, "WorkflowId = 'wid-1' OR CloseTime > '2024-05-06T00:00:00Z'")
+	earliest, latest = e.CloseTimeRange()
+	require.True(t, earliest.IsZero())
+	require.True(t, latest.IsZero())
+	_, ok = e.WorkflowID()
+	require.False(t, ok)
+
+	e = newTestEvaluator(t, "NOT (WorkflowId = 'wid-1') AND WorkflowId != 'wid-2'")
+	_, ok = e.WorkflowID()
+	require.False(t, ok)
+}
+
+func TestEvaluator_SortPage(t *testing.T) {
+	e := newTestEvaluator(t, "")
+	require.False(t, e.Ordered())
+
+	e = newTestEvaluator(t, "ExecutionStatus != 'Running' ORDER BY HistoryLength DESC")
+	require.True(t, e.Ordered())
+	records := testRecords()
+	page, token, err := e.SortPage(records, 2, nil)
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	require.Equal(t, "failed", page[0].GetRunId())
+	require.Equal(t, "completed", page[1].GetRunId())
+	require.NotNil(t, token)
+
+	page, token, err = e.SortPage(records, 2, token)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	require.Equal(t, "scheduler", page[0].GetRunId())
+	require.Nil(t, token)
+
+	_, _, err = e.SortPage(records, 2, []byte("invalid"))
+	var invalidArgument *serviceerror.InvalidArgument
+	require.ErrorAs(t, err, &invalidArgument)
+}
+
+func TestCheckOrderedRecords(t *testing.T) {
+	require.NoError(t, CheckOrderedRecords(MaxOrderedRecords))
+	err := CheckOrderedRecords(MaxOrderedRecords + 1)
+	var invalidArgument *serviceerror.InvalidArgument
+	require.ErrorAs(t, err, &invalidArgument)
+}
@@ -0,0 +1,164 @@
+package visibilityquery
+
+import (
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/temporalio/sqlparser"
+	"go.temporal.io/server/common/persistence/visibility/store/query"
+	"go.temporal.io/server/common/searchattribute/sadefs"
+	"go.temporal.io/server/common/util"
+)
+
+type (
+	// hints are the constraints of a query which all the matching records satisfy, and which the archivers use to
+	// narrow the records they read. A nil hints has no constraints.
+	hints struct {
+		// earliestCloseTime and latestCloseTime are the inclusive bounds of the close time, which are zero if the
+		// range is unbounded.
+		earliestCloseTime time.Time
+		latestCloseTime   time.Time
+		// equalValues are the values of the hinted Keyword search attributes which the query requires.
+		equalValues map[string]string
+	}
+
+	// hintsConverter builds the hints of a query from the comparisons of the close time and of the hinted Keyword
+	// search attributes, and from their conjunctions and disjunctions. Negations have no hints.
+	hintsConverter struct{}
+)
+
+var _ query.StoreQueryConverter[*hints] = (*hintsConverter)(nil)
+
+// hintedKeywords are the Keyword search attributes whose equality constraints are hinted, which are the ones which the
+// archivers index.
+var hintedKeywords = map[string]struct{}{
+	sadefs.WorkflowID:   {},
+	sadefs.WorkflowType: {},
+	sadefs.RunID:        {},
+}
+
+func (h *hints) equalValue(fieldName string) (string, bool) {
+	if h == nil {
+		return "", false
+	}
+	value, ok := h.equalValues[fieldName]
+	return value, ok
+}
+
+func (c *hintsConverter) GetDatetimeFormat() string {
+	return time.RFC3339Nano
+}
+
+func (c *hintsConverter) BuildParenExpr(expr *hints) (*hints, error) {
+	return expr, nil
+}
+
+func (c *hintsConverter) BuildNotExpr(*hints) (*hints, error) {
+	return nil, nil
+}
+
+func (c *hintsConverter) BuildAndExpr(exprs ...*hints) (*hints, error) {
+	res := &hints{equalValues: make(map[string]string)}
+	for _, expr := range exprs {
+		if expr == nil {
+			continue
+		}
+		res.earliestCloseTime = util.MaxTime(res.earliestCloseTime, expr.earliestCloseTime)
+		if res.latestCloseTime.IsZero() {
+			res.latestCloseTime = expr.latestCloseTime
+		} else if !expr.latestCloseTime.IsZero() {
+			res.latestCloseTime = util.MinTime(res.latestCloseTime, expr.latestCloseTime)
+		}
+		maps.Copy(res.equalValues, expr.equalValues)
+	}
+	return res, nil
+}
+
+func (c *hintsConverter) BuildOrExpr(exprs ...*hints) (*hints, error) {
+	if len(exprs) == 0 || slices.Contains(exprs, nil) {
+		return nil, nil
+	}
+	res := &hints{
+		earliestCloseTime: exprs[0].earliestCloseTime,
+		latestCloseTime:   exprs[0].latestCloseTime,
+		equalValues:       maps.Clone(exprs[0].equalValues),
+	}
+	for _, expr := range exprs[1:] {
+		res.earliestCloseTime = util.MinTime(res.earliestCloseTime, expr.earliestCloseTime)
+		if expr.latestCloseTime.IsZero() {
+			res.latestCloseTime = time.Time{}
+		} else if !res.latestCloseTime.IsZero() {
+			res.latestCloseTime = util.MaxTime(res.latestCloseTime, expr.latestCloseTime)
+		}
+		maps.DeleteFunc(res.equalValues, func(name string, value string) bool {
+			other, ok := expr.equalValues[name]
+			return !ok || other != value
+		})
+	}
+	return res, nil
+}
+
+func (c *hintsConverter) ConvertComparisonExpr(operator string, col *query.SAColumn, value any) (*hints, error) {
+	if col.FieldName != sadefs.CloseTime {
+		return nil, nil
+	}
+	t, ok := parseTime(value)
+	if !ok {
+		return nil, nil
+	}
+	switch operator {
+	case sqlparser.EqualStr:
+		return &hints{earliestCloseTime: t, latestCloseTime: t}, nil
+	case sqlparser.GreaterThanStr, sqlparser.GreaterEqualStr:
+		return &hints{earliestCloseTime: t}, nil
+	case sqlparser.LessThanStr, sqlparser.LessEqualStr:
+		return &hints{latestCloseTime: t}, nil
+	default:
+		return nil, nil
+	}
+}
+
+func (c *hintsConverter) ConvertKeywordComparisonExpr(operator string, col *query.SAColumn, value any) (*hints, error) {
+	if _, ok := hintedKeywords[col.FieldName]; !ok || operator != sqlparser.EqualStr {
+		return nil, nil
+	}
+	s, ok := value.(string)
+	if !ok {
+		return nil, nil
+	}
+	return &hints{equalValues: map[string]string{col.FieldName: s}}, nil
+}
+
+func (c *hintsConverter) ConvertKeywordListComparisonExpr(string, *query.SAColumn, any) (*hints, error) {
+	return nil, nil
+}
+
+func (c *hintsConverter) ConvertTextComparisonExpr(string, *query.SAColumn, any) (*hints, error) {
+	return nil, nil
+}
+
+func (c *hintsConverter) ConvertRangeExpr(operator string, col *query.SAColumn, from, to any) (*hints, error) {
+	if col.FieldName != sadefs.CloseTime || operator != sqlparser.BetweenStr {
+		return nil, nil
+	}
+	fromTime, fromOK := parseTime(from)
+	toTime, toOK := parseTime(to)
+	if !fromOK || !toOK {
+		return nil, nil
+	}
+	return &hints{earliestCloseTime: fromTime, latestCloseTime: toTime}, nil
+}
+
+func (c *hintsConverter) ConvertIsExpr(string, *query.SAColumn) (*hints, error) {
+	return nil, nil
+}
+
+func parseTime(value any) (time.Time, bool) {
+	s, ok := value.(string)
+	if !ok {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	return t, err == nil
+}
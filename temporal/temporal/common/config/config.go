@@ -53,6 +53,8 @@ type (
 		ExporterConfig telemetry.ExportConfig `yaml:"otel"`
 		// Visibility related config
 		Visibility Visibility `yaml:"visibility"`
+		// PayloadOffload is the config for offloading large payloads of history events into a blob store
+		PayloadOffload PayloadOffload `yaml:"payloadOffload"`
 	}
 
 	// Service contains the service specific config items
@@ -257,6 +259,10 @@ type (
 	Persistence struct {
 		// DefaultStore is the name of the default data store to use
 		DefaultStore string `yaml:"defaultStore" validate:"nonzero"`
+		// SecondaryStore is the name of the datastore which the data of the default store is dual-written to, to
+		// migrate it online to another database. How it is written is controlled by the
+		// system.persistenceDualWriteMode dynamic config.
+		SecondaryStore string `yaml:"secondaryStore"`
 		// VisibilityStore is the name of the datastore to be used for visibility records
 		VisibilityStore string `yaml:"visibilityStore"`
 		// SecondaryVisibilityStore is the name of the secondary datastore to be used for visibility records
@@ -268,6 +274,16 @@ type (
 		DataStores map[string]DataStore `yaml:"datastores"`
 		// TransactionSizeLimit is the largest allowed transaction size
 		TransactionSizeLimit dynamicconfig.IntPropertyFn `yaml:"-" json:"-"`
+		// Encryption contains the config for the encryption at rest of history and mutable state blobs
+		Encryption *PersistenceEncryption `yaml:"encryption"`
+	}
+
+	// PersistenceEncryption is the config for the encryption at rest of history and mutable state blobs. Encryption
+	// is enabled per namespace with the system.persistenceBlobEncryptionEnabled dynamic config.
+	PersistenceEncryption struct {
+		// KeyFile is the path of the key file of the built-in file key provider. It is ignored if a custom key
+		// provider is set with the WithPersistenceKeyProvider server option.
+		KeyFile string `yaml:"keyFile"`
 	}
 
 	// DataStore is the configuration for a single datastore
@@ -300,6 +316,9 @@ type (
 		*/
 		// This will cause the UpdateShard method of the ShardStore to always return ShardOwnershipLostError.
 		// See config/development-cass-es-fi.yaml for a more detailed example.
+		// The targets can be overridden at runtime with the system.persistenceFaultInjectionTargets dynamic config
+		// and with the fault injection rules of the admin API. Both require this config to be present, even if it
+		// has no targets.
 		Targets FaultInjectionTargets `yaml:"targets"`
 	}
 
@@ -337,6 +356,14 @@ type (
 		// and the underlying method will be called 70% of the time.
 		Errors map[string]float64 `yaml:"errors"`
 
+		// Latency delays calls to the method before they reach the underlying datastore. Latency is sampled
+		// independently of Errors, so a call can be both delayed and failed.
+		Latency *FaultInjectionLatencyConfig `yaml:"latency"`
+
+		// Windows restricts fault injection for this method to the given time windows. Outside of them, the method
+		// always calls the underlying datastore. If no windows are configured, faults are injected all the time.
+		Windows []FaultInjectionWindow `yaml:"windows"`
+
 		// Seed is the seed for the random number generator used to sample faults from the Errors map. You can use this
 		// to make the fault injection deterministic.
 		// If the test config does not set this to a non-zero number, the fault injector will set it to the current time
@@ -344,6 +371,43 @@ type (
 		Seed int64 `yaml:"seed"`
 	}
 
+	// FaultInjectionLatencyConfig is the latency fault injection config for a single method of a data store.
+	/*
+		latency:
+		  rate: 0.2 # 20% of the calls are delayed
+		  distribution: uniform
+		  min: 100ms
+		  max: 2s
+	*/
+	FaultInjectionLatencyConfig struct {
+		// Rate is the probability of delaying a call. 0.0 means never, 1.0 means always.
+		Rate float64 `yaml:"rate"`
+		// Distribution of the injected latency. Defaults to FaultInjectionLatencyFixed.
+		Distribution FaultInjectionLatencyDistribution `yaml:"distribution"`
+		// Min is the injected latency of the fixed distribution, the lower bound of the uniform distribution and
+		// the latency most calls see with the long-tail distribution.
+		Min time.Duration `yaml:"min"`
+		// Max is the upper bound of the uniform and long-tail distributions. It is optional for the long-tail
+		// distribution, which is unbounded otherwise.
+		Max time.Duration `yaml:"max"`
+	}
+
+	// FaultInjectionLatencyDistribution is the name of a latency distribution.
+	FaultInjectionLatencyDistribution string
+
+	// FaultInjectionWindow is a time window in which faults are injected. The window is relative to the time the
+	// method config was applied, which is the time the data store was created for the static config, so that it
+	// behaves the same way on every run.
+	FaultInjectionWindow struct {
+		// Start is the offset of the beginning of the window.
+		Start time.Duration `yaml:"start"`
+		// Duration is the length of the window. Zero means that the window never closes.
+		Duration time.Duration `yaml:"duration"`
+		// Period repeats the window, starting a new one every Period after Start. Zero means that the window
+		// only opens once.
+		Period time.Duration `yaml:"period"`
+	}
+
 	// Cassandra contains configuration to connect to Cassandra cluster
 	Cassandra struct {
 		// Hosts is a csv of cassandra endpoints
@@ -430,6 +494,36 @@ type (
 		TaskScanPartitions int `yaml:"taskScanPartitions"`
 		// TLS is the configuration for TLS connections
 		TLS *auth.TLS `yaml:"tls"`
+		// ReadReplica is the configuration for connecting to a read replica of the database. Optional.
+		// Reads which tolerate stale data are served by the replica while its replication lag is acceptable: the
+		// visibility queries, the history branch reads and the scans of the visibility scanner and history scavenger.
+		ReadReplica *SQLReadReplica `yaml:"readReplica"`
+	}
+
+	// SQLReadReplica is the configuration for connecting to a read replica of a SQL datastore. Settings which are not
+	// set are inherited from the SQL config of the primary database.
+	SQLReadReplica struct {
+		// ConnectAddr is the remote addr of the replica
+		ConnectAddr string `yaml:"connectAddr" validate:"nonzero"`
+		// User is the username to be used for the conn
+		User string `yaml:"user"`
+		// Password is the password corresponding to the user name
+		Password string `yaml:"password"`
+		// ConnectAttributes is a set of key-value attributes to be sent as part of connect data_source_name url
+		ConnectAttributes map[string]string `yaml:"connectAttributes"`
+		// MaxConns the max number of connections to the replica
+		MaxConns int `yaml:"maxConns"`
+		// MaxIdleConns is the max number of idle connections to the replica
+		MaxIdleConns int `yaml:"maxIdleConns"`
+		// MaxConnLifetime is the maximum time a connection can be alive
+		MaxConnLifetime time.Duration `yaml:"maxConnLifetime"`
+		// TLS is the configuration for TLS connections to the replica
+		TLS *auth.TLS `yaml:"tls"`
+		// MaxReplicationLag is the replication lag above which reads fall back to the primary database.
+		// Defaults to 5s.
+		MaxReplicationLag time.Duration `yaml:"maxReplicationLag"`
+		// LagCheckInterval is the interval at which the replication lag is checked. Defaults to 1s.
+		LagCheckInterval time.Duration `yaml:"lagCheckInterval"`
 	}
 
 	// CustomDatastoreConfig is the configuration for connecting to a custom datastore that is not supported by temporal core
@@ -484,6 +578,8 @@ type (
 		Filestore *FilestoreArchiver `yaml:"filestore"`
 		Gstorage  *GstorageArchiver  `yaml:"gstorage"`
 		S3store   *S3Archiver        `yaml:"s3store"`
+		// SQLStore is the SQL database which stores the archived histories, set up with the archival schema
+		SQLStore *SQL `yaml:"sqlstore"`
 	}
 
 	// VisibilityArchival contains the config for visibility archival
@@ -501,12 +597,20 @@ type (
 		Filestore *FilestoreArchiver `yaml:"filestore"`
 		S3store   *S3Archiver        `yaml:"s3store"`
 		Gstorage  *GstorageArchiver  `yaml:"gstorage"`
+		// SQLStore is the SQL database which stores the archived visibility records, set up with the archival schema
+		SQLStore *SQL `yaml:"sqlstore"`
 	}
 
 	// FilestoreArchiver contain the config for filestore archiver
 	FilestoreArchiver struct {
 		FileMode string `yaml:"fileMode"`
 		DirMode  string `yaml:"dirMode"`
+		// VisibilityFormat is the format of the archived visibility records: "record" (default), one file per
+		// record, or "parquet", Parquet files batching the records by namespace and close time bucket.
+		VisibilityFormat string `yaml:"visibilityFormat"`
+		// VisibilityBucketInterval is the close time interval of the records batched in the same Parquet files.
+		// It defaults to 1 hour, and must not be changed once records are archived.
+		VisibilityBucketInterval time.Duration `yaml:"visibilityBucketInterval"`
 	}
 
 	// GstorageArchiver contain the config for google storage archiver
@@ -520,6 +624,46 @@ type (
 		Endpoint         *string `yaml:"endpoint"`
 		S3ForcePathStyle bool    `yaml:"s3ForcePathStyle"`
 		LogLevel         uint    `yaml:"logLevel"`
+		// VisibilityFormat is the format of the archived visibility records: "record" (default), one object per
+		// record, or "parquet", Parquet objects batching the records by namespace and close time bucket.
+		VisibilityFormat string `yaml:"visibilityFormat"`
+		// VisibilityBucketInterval is the close time interval of the records batched in the same Parquet objects.
+		// It defaults to 1 hour, and must not be changed once records are archived.
+		VisibilityBucketInterval time.Duration `yaml:"visibilityBucketInterval"`
+	}
+
+	// PayloadOffload contains the config for offloading the large payloads of history events into a blob store. The
+	// payloads are offloaded only if one of the blob stores is configured. The replicated history events are
+	// rehydrated before they are sent, so the clusters which replicate the namespaces don't need to share it.
+	PayloadOffload struct {
+		Filestore *FilestorePayloadStore `yaml:"filestore"`
+		S3store   *S3PayloadStore        `yaml:"s3store"`
+	}
+
+	// FilestorePayloadStore contains the config for the local filestore of offloaded payloads
+	FilestorePayloadStore struct {
+		// DirPath is the directory which contains the offloaded payloads
+		DirPath  string `yaml:"dirPath"`
+		FileMode string `yaml:"fileMode"`
+		DirMode  string `yaml:"dirMode"`
+	}
+
+	// S3PayloadStore contains the config for the S3-compatible store of offloaded payloads
+	S3PayloadStore struct {
+		// Bucket is the bucket which contains the offloaded payloads
+		Bucket string `yaml:"bucket"`
+		// Prefix is prepended to the keys of the offloaded payloads in the bucket
+		Prefix           string  `yaml:"prefix"`
+		Region           string  `yaml:"region"`
+		Endpoint         *string `yaml:"endpoint"`
+		S3ForcePathStyle bool    `yaml:"s3ForcePathStyle"`
+		LogLevel         uint    `yaml:"logLevel"`
+		// VisibilityFormat is the format of the archived visibility records: "record" (default), one object per
+		// record, or "parquet", Parquet objects batching the records by namespace and close time bucket.
+		VisibilityFormat string `yaml:"visibilityFormat"`
+		// VisibilityBucketInterval is the close time interval of the records batched in the same Parquet objects.
+		// It defaults to 1 hour, and must not be changed once records are archived.
+		VisibilityBucketInterval time.Duration `yaml:"visibilityBucketInterval"`
 	}
 
 	// PublicClient is the config for internal nodes (history/matching/worker) connecting to
@@ -639,6 +783,17 @@ const (
 	NexusEndpointStoreName DataStoreName = "NexusEndpointStore"
 )
 
+const (
+	// FaultInjectionLatencyFixed always injects FaultInjectionLatencyConfig.Min.
+	FaultInjectionLatencyFixed FaultInjectionLatencyDistribution = "fixed"
+	// FaultInjectionLatencyUniform injects a latency which is uniformly distributed between
+	// FaultInjectionLatencyConfig.Min and FaultInjectionLatencyConfig.Max.
+	FaultInjectionLatencyUniform FaultInjectionLatencyDistribution = "uniform"
+	// FaultInjectionLatencyLongTail injects a Pareto distributed latency with FaultInjectionLatencyConfig.Min as
+	// its scale: half of the delayed calls take up to 1.6x Min, but 1% of them take more than 21x Min.
+	FaultInjectionLatencyLongTail FaultInjectionLatencyDistribution = "longTail"
+)
+
 const (
 	ForceTLSConfigAuto      = ""
 	ForceTLSConfigInternode = "internode"
@@ -795,6 +950,26 @@ func (fi *FaultInjection) WithMethodSeed(storeName DataStoreName, methodName str
 	return fi
 }
 
+func (fi *FaultInjection) WithMethodLatency(storeName DataStoreName, methodName string, latency FaultInjectionLatencyConfig) *FaultInjection {
+	if fi == nil {
+		return nil
+	}
+	m := fi.method(storeName, methodName)
+	m.Latency = &latency
+	fi.Targets.DataStores[storeName].Methods[methodName] = m
+	return fi
+}
+
+func (fi *FaultInjection) WithMethodWindow(storeName DataStoreName, methodName string, window FaultInjectionWindow) *FaultInjection {
+	if fi == nil {
+		return nil
+	}
+	m := fi.method(storeName, methodName)
+	m.Windows = append(m.Windows, window)
+	fi.Targets.DataStores[storeName].Methods[methodName] = m
+	return fi
+}
+
 func (fi *FaultInjection) method(storeName DataStoreName, methodName string) FaultInjectionMethodConfig {
 	if fi.Targets.DataStores == nil {
 		fi.Targets.DataStores = map[DataStoreName]FaultInjectionDataStoreConfig{}
@@ -3,8 +3,10 @@ package config
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/gocql/gocql"
 	"go.temporal.io/server/common/persistence/visibility/store/elasticsearch/client"
@@ -17,6 +19,11 @@ const (
 	StoreTypeNoSQL = "nosql"
 )
 
+const (
+	defaultMaxReplicationLag = 5 * time.Second
+	defaultLagCheckInterval  = time.Second
+)
+
 var ErrPersistenceConfig = errors.New("persistence config error")
 
 // DefaultStoreType returns the storeType for the default persistence store
@@ -55,6 +62,15 @@ func (c *Persistence) Validate() error {
 	if c.VisibilityStore == "" {
 		return fmt.Errorf("%w: visibilityStore must be specified", ErrPersistenceConfig)
 	}
+	if c.SecondaryStore != "" {
+		if c.SecondaryStore == c.DefaultStore {
+			return fmt.Errorf("%w: secondaryStore must be different from defaultStore", ErrPersistenceConfig)
+		}
+		if c.DataStores[c.SecondaryStore].Elasticsearch != nil {
+			return fmt.Errorf("%w: secondaryStore cannot be an Elasticsearch datastore", ErrPersistenceConfig)
+		}
+		stores = append(stores, c.SecondaryStore)
+	}
 	if c.SecondaryVisibilityStore != "" {
 		isAnyCustom := c.DataStores[c.VisibilityStore].CustomDataStoreConfig != nil ||
 			c.DataStores[c.SecondaryVisibilityStore].CustomDataStoreConfig != nil
@@ -173,6 +189,11 @@ func (ds *DataStore) Validate() error {
 	if ds.SQL != nil && ds.SQL.TaskScanPartitions == 0 {
 		ds.SQL.TaskScanPartitions = 1
 	}
+	if ds.SQL != nil && ds.SQL.ReadReplica != nil {
+		if err := ds.SQL.ReadReplica.validate(); err != nil {
+			return err
+		}
+	}
 	if ds.Cassandra != nil {
 		if err := ds.Cassandra.validate(); err != nil {
 			return err
@@ -186,6 +207,57 @@ func (ds *DataStore) Validate() error {
 	return nil
 }
 
+// ReadReplicaConfig returns the SQL config for connecting to the read replica, or nil if no read replica is
+// configured.
+func (c *SQL) ReadReplicaConfig() *SQL {
+	replica := c.ReadReplica
+	if replica == nil {
+		return nil
+	}
+	cfg := *c
+	cfg.ReadReplica = nil
+	cfg.ConnectAddr = replica.ConnectAddr
+	if replica.User != "" {
+		cfg.User = replica.User
+		cfg.Password = replica.Password
+	}
+	// the connect attributes are cloned since plugins may add attributes to them
+	if replica.ConnectAttributes != nil {
+		cfg.ConnectAttributes = maps.Clone(replica.ConnectAttributes)
+	} else {
+		cfg.ConnectAttributes = maps.Clone(c.ConnectAttributes)
+	}
+	if replica.MaxConns != 0 {
+		cfg.MaxConns = replica.MaxConns
+	}
+	if replica.MaxIdleConns != 0 {
+		cfg.MaxIdleConns = replica.MaxIdleConns
+	}
+	if replica.MaxConnLifetime != 0 {
+		cfg.MaxConnLifetime = replica.MaxConnLifetime
+	}
+	if replica.TLS != nil {
+		cfg.TLS = replica.TLS
+	}
+	return &cfg
+}
+
+func (r *SQLReadReplica) validate() error {
+	if r.ConnectAddr == "" {
+		return errors.New("readReplica.connectAddr must be specified")
+	}
+	if r.MaxReplicationLag < 0 || r.LagCheckInterval < 0 {
+		return errors.New("readReplica.maxReplicationLag and readReplica.lagCheckInterval cannot be negative")
+	}
+	if r.MaxReplicationLag == 0 {
+		r.MaxReplicationLag = defaultMaxReplicationLag
+	}
+	if r.LagCheckInterval == 0 {
+		r.LagCheckInterval = defaultLagCheckInterval
+	}
+	return nil
+}
+
 // GetConsistency returns the gosql.Consistency setting from the configuration for the given store type
 func (c *CassandraStoreConsistency) GetConsistency() gocql.Consistency {
 	return gocql.ParseConsistency(c.getConsistencySettings().Consistency)
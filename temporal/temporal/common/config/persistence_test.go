@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/gocql/gocql"
+	"go.temporal.io/server/common/persistence/visibility/store/elasticsearch/client"
 )
 
 func TestCassandraStoreConsistency_GetConsistency(t *testing.T) {
@@ -206,3 +207,99 @@ func TestCassandraStoreConsistency_validate(t *testing.T) {
 		})
 	}
 }
+
+func TestPersistence_ValidateSecondaryStore(t *testing.T) {
+	t.Parallel()
+
+	dataStores := map[string]DataStore{
+		"cassandra":     {Cassandra: &Cassandra{Hosts: "127.0.0.1"}},
+		"postgres":      {SQL: &SQL{PluginName: "postgres12"}},
+		"elasticsearch": {Elasticsearch: &client.Config{}},
+	}
+	tests := []struct {
+		name           string
+		secondaryStore string
+		wantErr        bool
+	}{
+		{
+			name:           "no secondary store",
+			secondaryStore: "",
+			wantErr:        false,
+		},
+		{
+			name:           "sql secondary store",
+			secondaryStore: "postgres",
+			wantErr:        false,
+		},
+		{
+			name:           "same as default store",
+			secondaryStore: "cassandra",
+			wantErr:        true,
+		},
+		{
+			name:           "elasticsearch secondary store",
+			secondaryStore: "elasticsearch",
+			wantErr:        true,
+		},
+		{
+			name:           "missing secondary store",
+			secondaryStore: "mysql",
+			wantErr:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Persistence{
+				DefaultStore:    "cassandra",
+				VisibilityStore: "postgres",
+				SecondaryStore:  tt.secondaryStore,
+				DataStores:      dataStores,
+			}
+			if err := c.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Persistence.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSQL_ReadReplicaConfig(t *testing.T) {
+	t.Parallel()
+
+	primary := &SQL{
+		User:         "temporal",
+		Password:     "secret",
+		PluginName:   "postgres12",
+		DatabaseName: "temporal",
+		ConnectAddr:  "primary:5432",
+		MaxConns:     20,
+		MaxIdleConns: 20,
+	}
+	if cfg := primary.ReadReplicaConfig(); cfg != nil {
+		t.Errorf("SQL.ReadReplicaConfig() = %v, want nil", cfg)
+	}
+
+	primary.ReadReplica = &SQLReadReplica{
+		ConnectAddr: "replica:5432",
+		MaxConns:    50,
+	}
+	ds := &DataStore{SQL: primary}
+	if err := ds.Validate(); err != nil {
+		t.Fatalf("DataStore.Validate() error = %v", err)
+	}
+	if primary.ReadReplica.MaxReplicationLag != defaultMaxReplicationLag || primary.ReadReplica.LagCheckInterval != defaultLagCheckInterval {
+		t.Errorf("DataStore.Validate() did not set read replica defaults: %+v", primary.ReadReplica)
+	}
+
+	want := *primary
+	want.ReadReplica = nil
+	want.ConnectAddr = "replica:5432"
+	want.MaxConns = 50
+	if got := primary.ReadReplicaConfig(); !reflect.DeepEqual(got, &want) {
+		t.Errorf("SQL.ReadReplicaConfig() = %+v, want %+v", got, &want)
+	}
+
+	primary.ReadReplica = &SQLReadReplica{}
+	if err := ds.Validate(); err == nil {
+		t.Error("DataStore.Validate() expected error for read replica without connectAddr")
+	}
+}
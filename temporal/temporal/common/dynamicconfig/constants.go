@@ -97,6 +97,12 @@ values in system search attributes.`,
 		`VisibilityEnableUnifiedQueryConverter enables the unified query converter for parsing the
 query.`,
 	)
+	VisibilityCountGroupByMaxGroups = NewNamespaceIntSetting(
+		"system.visibilityCountGroupByMaxGroups",
+		1000,
+		`VisibilityCountGroupByMaxGroups is the max number of groups returned by a CountWorkflowExecutions
+query with a GROUP BY clause. Only the groups with the highest counts are returned.`,
+	)
 
 	HistoryArchivalState = NewGlobalStringSetting(
 		"system.historyArchivalState",
@@ -263,6 +269,53 @@ operator API calls (highest priority). Should be >0.0 and <= 1.0 (defaults to 20
 		`PersistenceQPSBurstRatio is the burst ratio for persistence QPS. This flag controls the burst ratio for all services.`,
 	)
 
+	PersistenceBlobCompressionCategories = NewNamespaceIDTypedSetting(
+		"system.persistenceBlobCompressionCategories",
+		[]string(nil),
+		`PersistenceBlobCompressionCategories is the list of blob categories which are compressed with zstd when they are
+written to persistence, per namespace ID. Supported categories are "history" (history events) and "mutableState"
+(execution info and pending activity, timer, child workflow, request cancel and signal infos). Compressed and
+uncompressed blobs can be read side by side, but compressed blobs can't be read by older server versions, so this
+must only be enabled once all the hosts of all the clusters of the namespace can read them.`,
+	)
+	PersistenceBlobCompressionLevel = NewGlobalIntSetting(
+		"system.persistenceBlobCompressionLevel",
+		3,
+		`PersistenceBlobCompressionLevel is the zstd compression level of the blobs enabled by
+PersistenceBlobCompressionCategories. Levels are mapped to the closest level supported by the encoder: fastest (1),
+default (2-5), better compression (6-9) or best compression (10+).`,
+	)
+	PersistenceBlobEncryptionEnabled = NewNamespaceIDBoolSetting(
+		"system.persistenceBlobEncryptionEnabled",
+		false,
+		`PersistenceBlobEncryptionEnabled enables the envelope encryption of history events and mutable state blobs when
+they are written to persistence, per namespace ID. Blobs are encrypted with per-namespace data keys, which are wrapped
+by the key-encryption keys of the persistence key provider. Encrypted and plaintext blobs can be read side by side, but
+encrypted blobs can only be read by hosts which have a key provider with the key-encryption keys that wrapped them.`,
+	)
+	PersistenceBlobEncryptionDataKeyRotationInterval = NewGlobalDurationSetting(
+		"system.persistenceBlobEncryptionDataKeyRotationInterval",
+		24*time.Hour,
+		`PersistenceBlobEncryptionDataKeyRotationInterval is how long a namespace data key is used to encrypt new blobs
+before a new data key is generated. A new data key is also generated whenever the current key-encryption key of the
+key provider changes.`,
+	)
+	PersistenceDualWriteMode = NewGlobalStringSetting(
+		"system.persistenceDualWriteMode",
+		"off",
+		`PersistenceDualWriteMode is how the data stores are dual-written when the persistence secondaryStore is
+configured. Supported modes are "off" (only the default store is used), "dual" (the default store is authoritative and
+its writes are mirrored to the secondary store) and "flipped" (the secondary store is authoritative and its writes are
+mirrored to the default store). Errors of the mirrored writes are logged, but not returned.`,
+	)
+	PersistenceDualWriteReadComparisonRate = NewGlobalFloatSetting(
+		"system.persistenceDualWriteReadComparisonRate",
+		0.01,
+		`PersistenceDualWriteReadComparisonRate is the rate (between 0 and 1) of the point reads of dual-written stores
+which are also read from the mirror store, and compared with the result of the authoritative store. Compared reads
+add load to the mirror store, so only a small sample is compared by default.`,
+	)
+
 	EnableDataLossMetrics = NewGlobalBoolSetting(
 		"system.enableDataLossMetrics",
 		false,
@@ -808,6 +861,12 @@ This config is EXPERIMENTAL and may be changed or removed in a later release.`,
 		10000,
 		`VisibilityArchivalQueryMaxPageSize is the maximum page size for a visibility archival query`,
 	)
+	RehydratedWorkflowRetention = NewNamespaceDurationSetting(
+		"frontend.rehydratedWorkflowRetention",
+		7*24*time.Hour,
+		`RehydratedWorkflowRetention is the default duration for which a workflow execution rehydrated from the archival
+is kept in the primary persistence before it is deleted again`,
+	)
 	EnableServerVersionCheck = NewGlobalBoolSetting(
 		"frontend.enableServerVersionCheck",
 		os.Getenv("TEMPORAL_VERSION_CHECK_DISABLED") == "",
@@ -1900,6 +1959,21 @@ Higher values allow limited parallelism per workflow. Values <= 0 are capped to
 		2,
 		`TimerQueueMaxReaderCount is the max number of readers in one multi-cursor timer queue`,
 	)
+	TimeSkippingEnabled = NewNamespaceBoolSetting(
+		"history.timeSkippingEnabled",
+		false,
+		`TimeSkippingEnabled runs the timer queue of the namespace against a virtual clock, which auto-advances to the
+next pending timer when all the workflows of the namespace are idle, and which can be moved forward with the
+AdvanceNamespaceTime admin API. The skipped time is persisted in the namespace metadata. This is only meant for test
+servers, like the temporaltest server, and it should be enabled before the shards of the namespace are loaded.`,
+	)
+	TimeSkippingAutoAdvanceInterval = NewGlobalDurationSetting(
+		"history.timeSkippingAutoAdvanceInterval",
+		100*time.Millisecond,
+		`TimeSkippingAutoAdvanceInterval is the interval at which the history host of the coordinator shard of a namespace
+with time skipping enabled checks whether the namespace is idle on every history host, and so whether its virtual clock
+can be auto-advanced to its next pending timer`,
+	)
 	RetentionTimerJitterDuration = NewGlobalDurationSetting(
 		"history.retentionTimerJitterDuration",
 		30*time.Minute,
@@ -2855,6 +2929,13 @@ instead of the previous HSM backed implementation.`,
 		false,
 		`ExternalPayloadsEnabled controls whether external payload features are enabled for a namespace.`,
 	)
+	PayloadOffloadThresholdBytes = NewGlobalIntSetting(
+		"history.payloadOffloadThresholdBytes",
+		512*1024,
+		`PayloadOffloadThresholdBytes is the size above which the payloads of history events are offloaded into the blob
+store of the payloadOffload static config, and replaced by references in the persisted events. The payloads are not
+offloaded if it is 0 or if no blob store is configured.`,
+	)
 
 	// keys for worker
 
@@ -2991,6 +3072,51 @@ Should be at least WorkerESProcessorFlushInterval+<time to process request>.`,
 		false,
 		`ExecutionsScannerEnabled indicates if executions scanner should be started as part of worker.Scanner. This flag has no effect when SQL persistence is used,
 because executions scanner support for SQL is not yet implemented.`,
+	)
+	VisibilityScannerEnabled = NewGlobalBoolSetting(
+		"worker.visibilityScannerEnabled",
+		false,
+		`VisibilityScannerEnabled indicates if the visibility scanner should be started as part of worker.Scanner. The
+visibility scanner compares the status, close time and search attributes of the executions with their visibility
+records, and reports the drift in the result of its workflow.`,
+	)
+	VisibilityScannerSampleRate = NewGlobalFloatSetting(
+		"worker.visibilityScannerSampleRate",
+		0.01,
+		`VisibilityScannerSampleRate is the fraction of the executions which are checked by the visibility scanner,
+between 0 and 1. Set it to 1 to check all the executions.`,
+	)
+	VisibilityScannerRPS = NewGlobalFloatSetting(
+		"worker.visibilityScannerRPS",
+		10.0,
+		`VisibilityScannerRPS is the rate limit for visibility calls from the visibility scanner`,
+	)
+	VisibilityScannerAutoRepair = NewGlobalBoolSetting(
+		"worker.visibilityScannerAutoRepair",
+		false,
+		`VisibilityScannerAutoRepair indicates if the visibility scanner should regenerate the visibility tasks of the
+executions of which the visibility record drifted from the mutable state.`,
+	)
+	ArchivalVerifierEnabled = NewGlobalBoolSetting(
+		"worker.archivalVerifierEnabled",
+		false,
+		`ArchivalVerifierEnabled indicates if the archival verifier should be started as part of worker.Scanner. The
+archival verifier re-reads the archived histories of the namespaces with history and visibility archival enabled,
+verifies them against their manifests, and reports the corrupted, truncated or missing runs in the result of its
+workflow.`,
+	)
+	ArchivalVerifierRPS = NewGlobalFloatSetting(
+		"worker.archivalVerifierRPS",
+		1.0,
+		`ArchivalVerifierRPS is the rate limit for archival verification calls from the archival verifier`,
+	)
+	VisibilityArchiveCompactorEnabled = NewGlobalBoolSetting(
+		"worker.visibilityArchiveCompactorEnabled",
+		false,
+		`VisibilityArchiveCompactorEnabled indicates if the visibility archive compactor should be started as part of
+worker.Scanner. The compactor periodically batches the visibility records which are staged by the visibility archivers,
+such as the ones with the parquet visibility format. It should be enabled when such an archiver is configured, as the
+queries of the archived visibility records slow down while their records stay staged.`,
 	)
 	HistoryScannerDataMinAge = NewGlobalDurationSetting(
 		"worker.historyScannerDataMinAge",
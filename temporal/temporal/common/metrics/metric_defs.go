@@ -28,6 +28,7 @@ const (
 	PartitionTagName            = "partition"
 	PriorityTagName             = "priority"
 	PersistenceDBKindTagName    = "db_kind"
+	PersistenceDBPoolTagName    = "db_pool"
 	WorkerPluginNameTagName     = "worker_plugin_name"
 	headerCallsiteTagName       = "header_callsite"
 )
@@ -88,6 +89,10 @@ const (
 	TaskQueueScavengerScope = "TaskQueueScavenger"
 	// ExecutionsScavengerScope is scope used by all metrics emitted by worker.executions.Scavenger module
 	ExecutionsScavengerScope = "ExecutionsScavenger"
+	// VisibilityScannerScope is scope used by all metrics emitted by worker.visibility.Scanner module
+	VisibilityScannerScope = "VisibilityScanner"
+	// ArchivalVerifierScope is scope used by all metrics emitted by worker.archival.Verifier module
+	ArchivalVerifierScope = "ArchivalVerifier"
 )
 
 const (
@@ -294,10 +299,14 @@ const (
 	VisibilityPersistenceCountWorkflowExecutionsScope = "CountWorkflowExecutions"
 	// VisibilityPersistenceCountChasmExecutionsScope tracks CountChasmExecutions calls made by service to visibility persistence layer
 	VisibilityPersistenceCountChasmExecutionsScope = "CountChasmExecutions"
+	// VisibilityPersistenceAggregateWorkflowExecutionsScope tracks AggregateWorkflowExecutions calls made by service to visibility persistence layer
+	VisibilityPersistenceAggregateWorkflowExecutionsScope = "AggregateWorkflowExecutions"
 	// VisibilityPersistenceGetWorkflowExecutionScope tracks GetWorkflowExecution calls made by service to visibility persistence layer
 	VisibilityPersistenceGetWorkflowExecutionScope = "GetWorkflowExecution"
 	// VisibilityPersistenceAddSearchAttributesScope tracks AddSearchAttributes calls made by service to visibility persistence layer
 	VisibilityPersistenceAddSearchAttributesScope = "AddSearchAttributes"
+	// VisibilityPersistenceExplainQueryScope tracks ExplainQuery calls made by service to visibility persistence layer
+	VisibilityPersistenceExplainQueryScope = "ExplainQuery"
 )
 
 // Common
@@ -1244,6 +1253,28 @@ var (
 	ScavengerValidationSkipsCount                   = NewCounterDef("scavenger_validation_skips")
 	AddSearchAttributesFailuresCount                = NewCounterDef("add_search_attributes_failures")
 
+	// Visibility scanner metrics.
+	VisibilityScannerCheckedExecutions = NewCounterDef(
+		"visibility_scanner_checked_executions",
+		WithDescription("Number of executions which the visibility scanner compared with their visibility record"),
+	)
+	VisibilityScannerDriftedExecutions = NewCounterDef(
+		"visibility_scanner_drifted_executions",
+		WithDescription("Number of executions of which the visibility record drifted from the mutable state, tagged by drift category"),
+	)
+	VisibilityScannerSkippedExecutions = NewCounterDef(
+		"visibility_scanner_skipped_executions",
+		WithDescription("Number of sampled executions which the visibility scanner was unable to check"),
+	)
+	VisibilityScannerRepairedExecutions = NewCounterDef(
+		"visibility_scanner_repaired_executions",
+		WithDescription("Number of drifted executions of which the visibility scanner regenerated the visibility tasks"),
+	)
+	VisibilityScannerFailedRepairs = NewCounterDef(
+		"visibility_scanner_failed_repairs",
+		WithDescription("Number of drifted executions of which the visibility scanner was unable to regenerate the visibility tasks"),
+	)
+
 	// Delete Namespace metrics.
 	ReclaimResourcesNamespaceDeleteSuccessCount = NewCounterDef(
 		"reclaim_resources_namespace_delete_success",
@@ -1393,6 +1424,30 @@ var (
 		"persistence_latency",
 		WithDescription("Persistence latency, keyed by `operation`"),
 	)
+	PersistenceBlobUncompressedSize = NewBytesHistogramDef(
+		"persistence_blob_uncompressed_size",
+		WithDescription("Size of persisted blobs before compression, keyed by `blob_category`"),
+	)
+	PersistenceBlobCompressedSize = NewBytesHistogramDef(
+		"persistence_blob_compressed_size",
+		WithDescription("Size of persisted blobs after compression, keyed by `blob_category`"),
+	)
+	PersistenceBlobCompressedPercent = NewDimensionlessHistogramDef(
+		"persistence_blob_compressed_percent",
+		WithDescription("Compressed size of persisted blobs as a percentage of their uncompressed size, keyed by `blob_category`"),
+	)
+	PersistenceDualWriteMirrorErrors = NewCounterDef(
+		"persistence_dual_write_mirror_errors",
+		WithDescription("Number of writes which failed on the mirror store of a dual-written store, keyed by `operation`"),
+	)
+	PersistenceDualWriteReadComparisons = NewCounterDef(
+		"persistence_dual_write_read_comparisons",
+		WithDescription("Number of reads which were compared between the stores of a dual-written store, keyed by `operation`"),
+	)
+	PersistenceDualWriteReadDivergences = NewCounterDef(
+		"persistence_dual_write_read_divergences",
+		WithDescription("Number of reads which returned different results from the stores of a dual-written store, keyed by `operation`"),
+	)
 	PersistenceShardRPS                    = NewDimensionlessHistogramDef("persistence_shard_rps")
 	PersistenceErrResourceExhaustedCounter = NewCounterDef("persistence_errors_resource_exhausted")
 	VisibilityPersistenceRequests          = NewCounterDef("visibility_persistence_requests")
@@ -1408,6 +1463,14 @@ var (
 	PersistenceSQLOpenConn                 = NewGaugeDef("persistence_sql_open_conn")
 	PersistenceSQLIdleConn                 = NewGaugeDef("persistence_sql_idle_conn")
 	PersistenceSQLInUse                    = NewGaugeDef("persistence_sql_in_use")
+	PersistenceSQLReplicationLag           = NewGaugeDef(
+		"persistence_sql_replication_lag_seconds",
+		WithDescription("Replication lag of the read replica of a SQL datastore, as of the last lag check"),
+	)
+	PersistenceSQLReplicaReads = NewCounterDef(
+		"persistence_sql_replica_reads",
+		WithDescription("Number of reads tolerating stale data, keyed by the `db_pool` which served them"),
+	)
 
 	// Common service base metrics
 	RestartCount            = NewCounterDef("restarts")
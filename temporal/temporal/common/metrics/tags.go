@@ -504,6 +504,10 @@ func PersistenceDBKindTag(kind string) Tag {
 	return Tag{Key: PersistenceDBKindTagName, Value: kind}
 }
 
+func PersistenceDBPoolTag(pool string) Tag {
+	return Tag{Key: PersistenceDBPoolTagName, Value: pool}
+}
+
 func HeaderCallsiteTag(kind string) Tag {
 	return Tag{Key: headerCallsiteTagName, Value: kind}
 }
@@ -274,6 +274,12 @@ func (ns *Namespace) Retention() time.Duration {
 	return ns.config.Retention.AsDuration()
 }
 
+// TimeSkipped returns the duration the virtual time of the namespace is ahead of the real time, which is only set if
+// time skipping is enabled for the namespace.
+func (ns *Namespace) TimeSkipped() time.Duration {
+	return ns.config.GetTimeSkipped().AsDuration()
+}
+
 // CustomSearchAttributesMapper is a part of temporary solution. Do not use this method.
 func (ns *Namespace) CustomSearchAttributesMapper() CustomSearchAttributesMapper {
 	return ns.customSearchAttributesMapper
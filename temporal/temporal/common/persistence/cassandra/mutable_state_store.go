@@ -80,7 +80,7 @@ const (
 		`and visibility_ts = ? ` +
 		`and task_id = ?`
 
-	templateListWorkflowExecutionQuery = `SELECT run_id, execution, execution_encoding, execution_state, execution_state_encoding, next_event_id ` +
+	templateListWorkflowExecutionQuery = `SELECT namespace_id, workflow_id, run_id, execution, execution_encoding, execution_state, execution_state_encoding, next_event_id ` +
 		`FROM executions ` +
 		`WHERE shard_id = ? ` +
 		`and type = ?`
@@ -1078,6 +1078,9 @@ func (d *MutableStateStore) ListConcreteExecutions(
 			if err != nil {
 				return nil, err
 			}
+			state.NamespaceID = gocql.UUIDToString(result["namespace_id"])
+			state.WorkflowID, _ = result["workflow_id"].(string)
+			state.RunID = gocql.UUIDToString(result["run_id"])
 			response.States = append(response.States, state)
 		}
 
@@ -61,6 +61,8 @@ type (
 		healthSignals                               persistence.HealthSignalAggregator
 		enableDataLossMetrics                       dynamicconfig.BoolPropertyFn
 		enableBestEffortDeleteTasksOnWorkflowUpdate dynamicconfig.BoolPropertyFn
+		blobCompressor                              *serialization.BlobCompressor
+		blobEncryptor                               *serialization.BlobEncryptor
 	}
 )
 
@@ -85,6 +87,8 @@ func NewFactory(
 	healthSignals persistence.HealthSignalAggregator,
 	enableDataLossMetrics EnableDataLossMetrics,
 	enableBestEffortDeleteTasksOnWorkflowUpdate EnableBestEffortDeleteTasksOnWorkflowUpdate,
+	blobCompressor *serialization.BlobCompressor,
+	blobEncryptor *serialization.BlobEncryptor,
 ) Factory {
 	factory := &factoryImpl{
 		dataStoreFactory:      dataStoreFactory,
@@ -100,6 +104,8 @@ func NewFactory(
 		healthSignals:         healthSignals,
 		enableDataLossMetrics: dynamicconfig.BoolPropertyFn(enableDataLossMetrics),
 		enableBestEffortDeleteTasksOnWorkflowUpdate: dynamicconfig.BoolPropertyFn(enableBestEffortDeleteTasksOnWorkflowUpdate),
+		blobCompressor: blobCompressor,
+		blobEncryptor:  blobEncryptor,
 	}
 	factory.initDependencies()
 	return factory
@@ -207,6 +213,8 @@ func (f *factoryImpl) NewExecutionManager() (persistence.ExecutionManager, error
 		f.logger,
 		f.config.TransactionSizeLimit,
 		f.enableBestEffortDeleteTasksOnWorkflowUpdate,
+		f.blobCompressor,
+		f.blobEncryptor,
 	)
 	if f.systemRateLimiter != nil && f.namespaceRateLimiter != nil {
 		result = persistence.NewExecutionPersistenceRateLimitedClient(result, f.systemRateLimiter, f.namespaceRateLimiter, f.shardRateLimiter, f.logger)
@@ -50,6 +50,8 @@ func TestFactoryImpl_NewHistoryTaskQueueManager(t *testing.T) {
 				nil,
 				func() bool { return false },
 				func() bool { return false },
+				nil,
+				nil,
 			)
 			historyTaskQueueManager, err := factory.NewHistoryTaskQueueManager()
 			if tc.err != nil {
@@ -2,10 +2,12 @@ package client
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"go.opentelemetry.io/otel/trace"
 	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/server/common/clock"
 	"go.temporal.io/server/common/cluster"
 	"go.temporal.io/server/common/config"
 	"go.temporal.io/server/common/dynamicconfig"
@@ -13,6 +15,7 @@ import (
 	"go.temporal.io/server/common/metrics"
 	"go.temporal.io/server/common/persistence"
 	"go.temporal.io/server/common/persistence/cassandra"
+	"go.temporal.io/server/common/persistence/dualwrite"
 	"go.temporal.io/server/common/persistence/faultinjection"
 	"go.temporal.io/server/common/persistence/serialization"
 	"go.temporal.io/server/common/persistence/sql"
@@ -58,13 +61,55 @@ type (
 		EnableDataLossMetrics                       EnableDataLossMetrics
 		EnableBestEffortDeleteTasksOnWorkflowUpdate EnableBestEffortDeleteTasksOnWorkflowUpdate
 		Serializer                                  serialization.Serializer
+		BlobCompressor                              *serialization.BlobCompressor
+		BlobEncryptor                               *serialization.BlobEncryptor
+	}
+
+	BlobEncryptorParams struct {
+		fx.In
+
+		Cfg               *config.Persistence
+		KeyProvider       serialization.KeyProvider `optional:"true"`
+		DynamicCollection *dynamicconfig.Collection
+		TimeSource        clock.TimeSource
+		Logger            log.Logger
+	}
+
+	DataStoreFactoryParams struct {
+		fx.In
+
+		ClusterName               ClusterName
+		ServiceResolver           resolver.ServiceResolver
+		Cfg                       *config.Persistence
+		FaultInjectionRules       *faultinjection.Rules
+		AbstractDataStoreFactory  AbstractDataStoreFactory
+		Logger                    log.Logger
+		MetricsHandler            metrics.Handler
+		TracerProvider            trace.TracerProvider
+		Serializer                serialization.Serializer
+		DualWriteDataStoreFactory *dualwrite.DataStoreFactory
+	}
+
+	DualWriteDataStoreFactoryParams struct {
+		fx.In
+
+		ClusterName              ClusterName
+		ServiceResolver          resolver.ServiceResolver
+		Cfg                      *config.Persistence
+		FaultInjectionRules      *faultinjection.Rules
+		AbstractDataStoreFactory AbstractDataStoreFactory
+		Logger                   log.Logger
+		MetricsHandler           metrics.Handler
+		Serializer               serialization.Serializer
+		DynamicCollection        *dynamicconfig.Collection
 	}
 
 	FactoryProviderFn func(NewFactoryParams) Factory
 )
 
 var Module = fx.Options(
-	fx.Provide(DataStoreFactoryProvider),
+	fx.Provide(DualWriteDataStoreFactoryProvider),
+	fx.Provide(DataStoreFactoryWithDualWriteProvider),
 	fx.Invoke(DataStoreFactoryLifetimeHooks),
 	fx.Provide(managerProvider(Factory.NewClusterMetadataManager)),
 	fx.Provide(managerProvider(Factory.NewMetadataManager)),
@@ -82,6 +127,8 @@ var Module = fx.Options(
 	fx.Provide(EventBlobCacheProvider),
 	fx.Provide(EnableDataLossMetricsProvider),
 	fx.Provide(EnableBestEffortDeleteTasksOnWorkflowUpdateProvider),
+	fx.Provide(BlobCompressorProvider),
+	fx.Provide(BlobEncryptorProvider),
 )
 
 func ClusterNameProvider(config *cluster.Config) ClusterName {
@@ -112,6 +159,30 @@ func EnableBestEffortDeleteTasksOnWorkflowUpdateProvider(
 	return EnableBestEffortDeleteTasksOnWorkflowUpdate(dynamicconfig.EnableBestEffortDeleteTasksOnWorkflowUpdate.Get(dc))
 }
 
+func BlobCompressorProvider(
+	dc *dynamicconfig.Collection,
+	metricsHandler metrics.Handler,
+) *serialization.BlobCompressor {
+	return serialization.NewBlobCompressor(dc, metricsHandler)
+}
+
+// BlobEncryptorProvider returns the BlobEncryptor of the custom key provider if there is one, or of the file key
+// provider of the persistence config. It returns nil if neither is configured.
+func BlobEncryptorProvider(params BlobEncryptorParams) (*serialization.BlobEncryptor, error) {
+	keyProvider := params.KeyProvider
+	if keyProvider == nil {
+		if params.Cfg.Encryption == nil || params.Cfg.Encryption.KeyFile == "" {
+			return nil, nil
+		}
+		fileKeyProvider, err := serialization.NewFileKeyProvider(params.Cfg.Encryption.KeyFile, params.Logger, params.TimeSource)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load persistence key file: %w", err)
+		}
+		keyProvider = fileKeyProvider
+	}
+	return serialization.NewBlobEncryptor(keyProvider, params.DynamicCollection, params.TimeSource), nil
+}
+
 func FactoryProvider(
 	params NewFactoryParams,
 ) Factory {
@@ -157,6 +228,8 @@ func FactoryProvider(
 		params.HealthSignals,
 		params.EnableDataLossMetrics,
 		params.EnableBestEffortDeleteTasksOnWorkflowUpdate,
+		params.BlobCompressor,
+		params.BlobEncryptor,
 	)
 }
 
@@ -182,34 +255,128 @@ func DataStoreFactoryProvider(
 	clusterName ClusterName,
 	r resolver.ServiceResolver,
 	cfg *config.Persistence,
+	faultInjectionRules *faultinjection.Rules,
 	abstractDataStoreFactory AbstractDataStoreFactory,
 	logger log.Logger,
 	metricsHandler metrics.Handler,
 	tracerProvider trace.TracerProvider,
 	serializer serialization.Serializer,
+) persistence.DataStoreFactory {
+	dataStoreFactory := newDataStoreFactory(
+		cfg.DefaultStore,
+		clusterName,
+		r,
+		cfg,
+		faultInjectionRules,
+		abstractDataStoreFactory,
+		logger,
+		metricsHandler,
+		serializer,
+	)
+	return withTelemetry(dataStoreFactory, logger, tracerProvider)
+}
+
+// DataStoreFactoryWithDualWriteProvider returns the dual write data store factory if a secondary store is configured,
+// and the data store factory of the default store otherwise.
+func DataStoreFactoryWithDualWriteProvider(params DataStoreFactoryParams) persistence.DataStoreFactory {
+	if params.DualWriteDataStoreFactory != nil {
+		return withTelemetry(params.DualWriteDataStoreFactory, params.Logger, params.TracerProvider)
+	}
+	return DataStoreFactoryProvider(
+		params.ClusterName,
+		params.ServiceResolver,
+		params.Cfg,
+		params.FaultInjectionRules,
+		params.AbstractDataStoreFactory,
+		params.Logger,
+		params.MetricsHandler,
+		params.TracerProvider,
+		params.Serializer,
+	)
+}
+
+// DualWriteDataStoreFactoryProvider returns a data store factory which dual-writes to the default and the secondary
+// store of the persistence config. It returns nil if no secondary store is configured.
+func DualWriteDataStoreFactoryProvider(params DualWriteDataStoreFactoryParams) *dualwrite.DataStoreFactory {
+	if params.Cfg.SecondaryStore == "" {
+		return nil
+	}
+	primary := newDataStoreFactory(
+		params.Cfg.DefaultStore,
+		params.ClusterName,
+		params.ServiceResolver,
+		params.Cfg,
+		params.FaultInjectionRules,
+		params.AbstractDataStoreFactory,
+		params.Logger,
+		params.MetricsHandler,
+		params.Serializer,
+	)
+	secondary := newDataStoreFactory(
+		params.Cfg.SecondaryStore,
+		params.ClusterName,
+		params.ServiceResolver,
+		params.Cfg,
+		params.FaultInjectionRules,
+		params.AbstractDataStoreFactory,
+		params.Logger,
+		params.MetricsHandler,
+		params.Serializer,
+	)
+	return dualwrite.NewDataStoreFactory(
+		primary,
+		secondary,
+		params.Serializer,
+		params.DynamicCollection,
+		params.MetricsHandler,
+		params.Logger,
+	)
+}
+
+// newDataStoreFactory returns the data store factory of the named store. The fault injection rules only apply to the
+// default store, other stores use the static fault injection config of their datastore config.
+func newDataStoreFactory(
+	storeName string,
+	clusterName ClusterName,
+	r resolver.ServiceResolver,
+	cfg *config.Persistence,
+	faultInjectionRules *faultinjection.Rules,
+	abstractDataStoreFactory AbstractDataStoreFactory,
+	logger log.Logger,
+	metricsHandler metrics.Handler,
+	serializer serialization.Serializer,
 ) persistence.DataStoreFactory {
 	var dataStoreFactory persistence.DataStoreFactory
-	defaultStoreCfg := cfg.DataStores[cfg.DefaultStore]
+	storeCfg := cfg.DataStores[storeName]
 	switch {
-	case defaultStoreCfg.Cassandra != nil:
-		dataStoreFactory = cassandra.NewFactory(*defaultStoreCfg.Cassandra, r, string(clusterName), logger, metricsHandler, serializer)
-	case defaultStoreCfg.SQL != nil:
-		dataStoreFactory = sql.NewFactory(*defaultStoreCfg.SQL, r, string(clusterName), logger, metricsHandler, serializer)
-	case defaultStoreCfg.CustomDataStoreConfig != nil:
-		dataStoreFactory = abstractDataStoreFactory.NewFactory(*defaultStoreCfg.CustomDataStoreConfig, r, string(clusterName), logger, metricsHandler, serializer)
+	case storeCfg.Cassandra != nil:
+		dataStoreFactory = cassandra.NewFactory(*storeCfg.Cassandra, r, string(clusterName), logger, metricsHandler, serializer)
+	case storeCfg.SQL != nil:
+		dataStoreFactory = sql.NewFactory(*storeCfg.SQL, r, string(clusterName), logger, metricsHandler, serializer)
+	case storeCfg.CustomDataStoreConfig != nil:
+		dataStoreFactory = abstractDataStoreFactory.NewFactory(*storeCfg.CustomDataStoreConfig, r, string(clusterName), logger, metricsHandler, serializer)
 	default:
 		logger.Fatal("invalid config: one of cassandra, sql, or custom datastore params must be specified")
 	}
 
-	if defaultStoreCfg.FaultInjection != nil {
-		dataStoreFactory = faultinjection.NewFaultInjectionDatastoreFactory(defaultStoreCfg.FaultInjection, dataStoreFactory)
+	if storeCfg.FaultInjection != nil {
+		if faultInjectionRules == nil || storeName != cfg.DefaultStore {
+			faultInjectionRules = faultinjection.NewRules(storeCfg.FaultInjection, nil, logger)
+		}
+		dataStoreFactory = faultinjection.NewFaultInjectionDatastoreFactory(faultInjectionRules, dataStoreFactory)
 	}
+	return dataStoreFactory
+}
 
+func withTelemetry(
+	dataStoreFactory persistence.DataStoreFactory,
+	logger log.Logger,
+	tracerProvider trace.TracerProvider,
+) persistence.DataStoreFactory {
 	tracer := tracerProvider.Tracer(otel.ComponentPersistence)
 	if otel.IsEnabled(tracer) {
 		dataStoreFactory = telemetry.NewTelemetryDataStoreFactory(dataStoreFactory, logger, tracer)
 	}
-
 	return dataStoreFactory
 }
 
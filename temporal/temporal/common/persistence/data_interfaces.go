@@ -773,6 +773,8 @@ type (
 		IsNewBranch bool
 		// the info for clean up data in background
 		Info string
+		// The namespace of the workflow, used to decide whether the events are compressed and encrypted. Required.
+		NamespaceID string
 		// The branch to be appended
 		BranchToken []byte
 		// The batch of events to be appended. The first eventID will become the nodeID of this batch
@@ -797,6 +799,8 @@ type (
 		IsNewBranch bool
 		// the info for clean up data in background
 		Info string
+		// The namespace of the workflow, used to decide whether the events are compressed and encrypted. Required.
+		NamespaceID string
 		// The branch to be appended
 		BranchToken []byte
 		// The batch of events to be appended. The first eventID will become the nodeID of this batch
@@ -813,6 +817,8 @@ type (
 	ReadHistoryBranchRequest struct {
 		// The shard to get history branch data
 		ShardID int32
+		// The namespace of the workflow, which is required to read encrypted events.
+		NamespaceID string
 		// The branch to be read
 		BranchToken []byte
 		// Get the history nodes from MinEventID. Inclusive.
@@ -842,6 +848,8 @@ type (
 	ReadHistoryBranchReverseRequest struct {
 		// The shard to get history branch data
 		ShardID int32
+		// The namespace of the workflow, which is required to read encrypted events.
+		NamespaceID string
 		// The branch to be read
 		BranchToken []byte
 		// Get the history nodes upto MaxEventID.  Exclusive.
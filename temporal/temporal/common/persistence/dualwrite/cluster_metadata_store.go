@@ -0,0 +1,93 @@
+package dualwrite
+
+import (
+	"context"
+
+	"go.temporal.io/server/common/persistence"
+)
+
+type (
+	// clusterMetadataStore is a persistence.ClusterMetadataStore which dual-writes cluster metadata and cluster
+	// membership. Lists and cluster members are only read from the authoritative store, since the membership
+	// heartbeats change them continuously.
+	clusterMetadataStore struct {
+		*dualWriter
+		primary   persistence.ClusterMetadataStore
+		secondary persistence.ClusterMetadataStore
+	}
+)
+
+var _ persistence.ClusterMetadataStore = (*clusterMetadataStore)(nil)
+
+func (s *clusterMetadataStore) authoritative() persistence.ClusterMetadataStore {
+	authoritative, _, _ := selectStores(s.dualWriter, s.primary, s.secondary)
+	return authoritative
+}
+
+func (s *clusterMetadataStore) Close() {
+	s.primary.Close()
+	s.secondary.Close()
+}
+
+func (s *clusterMetadataStore) GetName() string {
+	return s.authoritative().GetName()
+}
+
+func (s *clusterMetadataStore) ListClusterMetadata(
+	ctx context.Context,
+	request *persistence.InternalListClusterMetadataRequest,
+) (*persistence.InternalListClusterMetadataResponse, error) {
+	return s.authoritative().ListClusterMetadata(ctx, request)
+}
+
+func (s *clusterMetadataStore) GetClusterMetadata(
+	ctx context.Context,
+	request *persistence.InternalGetClusterMetadataRequest,
+) (*persistence.InternalGetClusterMetadataResponse, error) {
+	return read(s.dualWriter, "GetClusterMetadata", s.primary, s.secondary, func(store persistence.ClusterMetadataStore) (*persistence.InternalGetClusterMetadataResponse, error) {
+		return store.GetClusterMetadata(ctx, request)
+	})
+}
+
+func (s *clusterMetadataStore) SaveClusterMetadata(
+	ctx context.Context,
+	request *persistence.InternalSaveClusterMetadataRequest,
+) (bool, error) {
+	return writeWithResponse(s.dualWriter, "SaveClusterMetadata", s.primary, s.secondary, func(store persistence.ClusterMetadataStore) (bool, error) {
+		return store.SaveClusterMetadata(ctx, request)
+	})
+}
+
+func (s *clusterMetadataStore) DeleteClusterMetadata(
+	ctx context.Context,
+	request *persistence.InternalDeleteClusterMetadataRequest,
+) error {
+	return write(s.dualWriter, "DeleteClusterMetadata", s.primary, s.secondary, func(store persistence.ClusterMetadataStore) error {
+		return store.DeleteClusterMetadata(ctx, request)
+	})
+}
+
+func (s *clusterMetadataStore) GetClusterMembers(
+	ctx context.Context,
+	request *persistence.GetClusterMembersRequest,
+) (*persistence.GetClusterMembersResponse, error) {
+	return s.authoritative().GetClusterMembers(ctx, request)
+}
+
+func (s *clusterMetadataStore) UpsertClusterMembership(
+	ctx context.Context,
+	request *persistence.UpsertClusterMembershipRequest,
+) error {
+	return write(s.dualWriter, "UpsertClusterMembership", s.primary, s.secondary, func(store persistence.ClusterMetadataStore) error {
+		return store.UpsertClusterMembership(ctx, request)
+	})
+}
+
+func (s *clusterMetadataStore) PruneClusterMembership(
+	ctx context.Context,
+	request *persistence.PruneClusterMembershipRequest,
+) error {
+	return write(s.dualWriter, "PruneClusterMembership", s.primary, s.secondary, func(store persistence.ClusterMetadataStore) error {
+		return store.PruneClusterMembership(ctx, request)
+	})
+}
@@ -0,0 +1,187 @@
+// Package dualwrite dual-writes all the data stores of a primary and a secondary data store, so that the persistence
+// of a cluster can be migrated online to another database.
+//
+// Existing data must be copied to the secondary store separately. Once all the shards are in sync, which is reported
+// by the dual write verification workflow, the secondary store can be made authoritative with the "flipped" mode of
+// the system.persistenceDualWriteMode dynamic config, while its writes are still mirrored to the primary store so that
+// the migration can be rolled back.
+package dualwrite
+
+import (
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/persistence/serialization"
+)
+
+type (
+	// DataStoreFactory is a persistence.DataStoreFactory which dual-writes the stores of the primary and the
+	// secondary factory.
+	DataStoreFactory struct {
+		*dualWriter
+		primary   persistence.DataStoreFactory
+		secondary persistence.DataStoreFactory
+	}
+)
+
+var _ persistence.DataStoreFactory = (*DataStoreFactory)(nil)
+
+// NewDataStoreFactory returns a new DataStoreFactory which dual-writes to the stores of the primary and the secondary
+// factory according to the PersistenceDualWriteMode dynamic config.
+func NewDataStoreFactory(
+	primary persistence.DataStoreFactory,
+	secondary persistence.DataStoreFactory,
+	serializer serialization.Serializer,
+	dc *dynamicconfig.Collection,
+	metricsHandler metrics.Handler,
+	logger log.Logger,
+) *DataStoreFactory {
+	return &DataStoreFactory{
+		dualWriter: newDualWriter(serializer, dc, metricsHandler, logger),
+		primary:    primary,
+		secondary:  secondary,
+	}
+}
+
+func (f *DataStoreFactory) Close() {
+	f.primary.Close()
+	f.secondary.Close()
+}
+
+func (f *DataStoreFactory) NewTaskStore() (persistence.TaskStore, error) {
+	primary, err := f.primary.NewTaskStore()
+	if err != nil {
+		return nil, err
+	}
+	secondary, err := f.secondary.NewTaskStore()
+	if err != nil {
+		return nil, err
+	}
+	return &taskStore{dualWriter: f.dualWriter, primary: primary, secondary: secondary}, nil
+}
+
+func (f *DataStoreFactory) NewFairTaskStore() (persistence.TaskStore, error) {
+	primary, err := f.primary.NewFairTaskStore()
+	if err != nil {
+		return nil, err
+	}
+	secondary, err := f.secondary.NewFairTaskStore()
+	if err != nil {
+		return nil, err
+	}
+	return &taskStore{dualWriter: f.dualWriter, primary: primary, secondary: secondary}, nil
+}
+
+func (f *DataStoreFactory) NewShardStore() (persistence.ShardStore, error) {
+	primary, err := f.primary.NewShardStore()
+	if err != nil {
+		return nil, err
+	}
+	secondary, err := f.secondary.NewShardStore()
+	if err != nil {
+		return nil, err
+	}
+	return &shardStore{dualWriter: f.dualWriter, primary: primary, secondary: secondary}, nil
+}
+
+func (f *DataStoreFactory) NewExecutionStore() (persistence.ExecutionStore, error) {
+	primary, err := f.primary.NewExecutionStore()
+	if err != nil {
+		return nil, err
+	}
+	secondary, err := f.secondary.NewExecutionStore()
+	if err != nil {
+		return nil, err
+	}
+	return &executionStore{dualWriter: f.dualWriter, primary: primary, secondary: secondary}, nil
+}
+
+func (f *DataStoreFactory) NewMetadataStore() (persistence.MetadataStore, error) {
+	primary, err := f.primary.NewMetadataStore()
+	if err != nil {
+		return nil, err
+	}
+	secondary, err := f.secondary.NewMetadataStore()
+	if err != nil {
+		return nil, err
+	}
+	return &metadataStore{dualWriter: f.dualWriter, primary: primary, secondary: secondary}, nil
+}
+
+func (f *DataStoreFactory) NewQueue(queueType persistence.QueueType) (persistence.Queue, error) {
+	primary, err := f.primary.NewQueue(queueType)
+	if err != nil {
+		return nil, err
+	}
+	secondary, err := f.secondary.NewQueue(queueType)
+	if err != nil {
+		return nil, err
+	}
+	return &queue{dualWriter: f.dualWriter, primary: primary, secondary: secondary}, nil
+}
+
+func (f *DataStoreFactory) NewQueueV2() (persistence.QueueV2, error) {
+	primary, err := f.primary.NewQueueV2()
+	if err != nil {
+		return nil, err
+	}
+	secondary, err := f.secondary.NewQueueV2()
+	if err != nil {
+		return nil, err
+	}
+	return &queueV2{dualWriter: f.dualWriter, primary: primary, secondary: secondary}, nil
+}
+
+func (f *DataStoreFactory) NewClusterMetadataStore() (persistence.ClusterMetadataStore, error) {
+	primary, err := f.primary.NewClusterMetadataStore()
+	if err != nil {
+		return nil, err
+	}
+	secondary, err := f.secondary.NewClusterMetadataStore()
+	if err != nil {
+		return nil, err
+	}
+	return &clusterMetadataStore{dualWriter: f.dualWriter, primary: primary, secondary: secondary}, nil
+}
+
+func (f *DataStoreFactory) NewNexusEndpointStore() (persistence.NexusEndpointStore, error) {
+	primary, err := f.primary.NewNexusEndpointStore()
+	if err != nil {
+		return nil, err
+	}
+	secondary, err := f.secondary.NewNexusEndpointStore()
+	if err != nil {
+		return nil, err
+	}
+	return &nexusEndpointStore{dualWriter: f.dualWriter, primary: primary, secondary: secondary}, nil
+}
+
+// NewShardVerifier returns a ShardVerifier which compares the shards of the primary and the secondary store.
+// encryptor decrypts the execution infos of encrypted executions, and may be nil if encryption is not configured.
+func (f *DataStoreFactory) NewShardVerifier(encryptor *serialization.BlobEncryptor) (*ShardVerifier, error) {
+	primaryShards, err := f.primary.NewShardStore()
+	if err != nil {
+		return nil, err
+	}
+	secondaryShards, err := f.secondary.NewShardStore()
+	if err != nil {
+		return nil, err
+	}
+	primaryExecutions, err := f.primary.NewExecutionStore()
+	if err != nil {
+		return nil, err
+	}
+	secondaryExecutions, err := f.secondary.NewExecutionStore()
+	if err != nil {
+		return nil, err
+	}
+	return NewShardVerifier(
+		primaryShards,
+		secondaryShards,
+		primaryExecutions,
+		secondaryExecutions,
+		f.serializer,
+		encryptor,
+	), nil
+}
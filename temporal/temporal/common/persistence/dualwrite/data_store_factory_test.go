@@ -0,0 +1,269 @@
+package dualwrite
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/common/metrics/metricstest"
+	"go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/persistence/mock"
+	"go.temporal.io/server/common/persistence/serialization"
+	"go.uber.org/mock/gomock"
+)
+
+type testStores struct {
+	capture             *metricstest.Capture
+	primaryShards       *mock.MockShardStore
+	secondaryShards     *mock.MockShardStore
+	primaryTasks        *mock.MockTaskStore
+	secondaryTasks      *mock.MockTaskStore
+	primaryExecutions   *mock.MockExecutionStore
+	secondaryExecutions *mock.MockExecutionStore
+	primaryMetadata     *mock.MockMetadataStore
+	secondaryMetadata   *mock.MockMetadataStore
+	primaryEndpoints    *mock.MockNexusEndpointStore
+	secondaryEndpoints  *mock.MockNexusEndpointStore
+	shards              persistence.ShardStore
+	tasks               persistence.TaskStore
+	executions          persistence.ExecutionStore
+	metadata            persistence.MetadataStore
+	endpoints           persistence.NexusEndpointStore
+}
+
+func newTestStores(t *testing.T, mode string) *testStores {
+	ctrl := gomock.NewController(t)
+	dcClient := dynamicconfig.NewMemoryClient()
+	dcClient.OverrideSetting(dynamicconfig.PersistenceDualWriteMode, mode)
+	// compare all the reads to make the tests deterministic
+	dcClient.OverrideSetting(dynamicconfig.PersistenceDualWriteReadComparisonRate, 1.0)
+	dc := dynamicconfig.NewCollection(dcClient, log.NewNoopLogger())
+	metricsHandler := metricstest.NewCaptureHandler()
+
+	primary := mock.NewMockDataStoreFactory(ctrl)
+	secondary := mock.NewMockDataStoreFactory(ctrl)
+	factory := NewDataStoreFactory(primary, secondary, serialization.NewSerializer(), dc, metricsHandler, log.NewNoopLogger())
+
+	s := &testStores{
+		capture:             metricsHandler.StartCapture(),
+		primaryShards:       mock.NewMockShardStore(ctrl),
+		secondaryShards:     mock.NewMockShardStore(ctrl),
+		primaryTasks:        mock.NewMockTaskStore(ctrl),
+		secondaryTasks:      mock.NewMockTaskStore(ctrl),
+		primaryExecutions:   mock.NewMockExecutionStore(ctrl),
+		secondaryExecutions: mock.NewMockExecutionStore(ctrl),
+		primaryMetadata:     mock.NewMockMetadataStore(ctrl),
+		secondaryMetadata:   mock.NewMockMetadataStore(ctrl),
+		primaryEndpoints:    mock.NewMockNexusEndpointStore(ctrl),
+		secondaryEndpoints:  mock.NewMockNexusEndpointStore(ctrl),
+	}
+	primary.EXPECT().NewShardStore().Return(s.primaryShards, nil)
+	secondary.EXPECT().NewShardStore().Return(s.secondaryShards, nil)
+	primary.EXPECT().NewTaskStore().Return(s.primaryTasks, nil)
+	secondary.EXPECT().NewTaskStore().Return(s.secondaryTasks, nil)
+	primary.EXPECT().NewExecutionStore().Return(s.primaryExecutions, nil)
+	secondary.EXPECT().NewExecutionStore().Return(s.secondaryExecutions, nil)
+	primary.EXPECT().NewMetadataStore().Return(s.primaryMetadata, nil)
+	secondary.EXPECT().NewMetadataStore().Return(s.secondaryMetadata, nil)
+	primary.EXPECT().NewNexusEndpointStore().Return(s.primaryEndpoints, nil)
+	secondary.EXPECT().NewNexusEndpointStore().Return(s.secondaryEndpoints, nil)
+	s.primaryExecutions.EXPECT().GetName().Return("cassandra").AnyTimes()
+	s.secondaryExecutions.EXPECT().GetName().Return("postgres12").AnyTimes()
+
+	var err error
+	s.shards, err = factory.NewShardStore()
+	require.NoError(t, err)
+	s.tasks, err = factory.NewTaskStore()
+	require.NoError(t, err)
+	s.executions, err = factory.NewExecutionStore()
+	require.NoError(t, err)
+	s.metadata, err = factory.NewMetadataStore()
+	require.NoError(t, err)
+	s.endpoints, err = factory.NewNexusEndpointStore()
+	require.NoError(t, err)
+	return s
+}
+
+func (s *testStores) count(name string) int {
+	return len(s.capture.Snapshot()[name])
+}
+
+func TestDataStoreFactory_ModeOff(t *testing.T) {
+	t.Parallel()
+	s := newTestStores(t, ModeOff)
+
+	s.primaryShards.EXPECT().UpdateShard(gomock.Any(), gomock.Any()).Return(nil)
+	require.NoError(t, s.shards.UpdateShard(context.Background(), &persistence.InternalUpdateShardRequest{ShardID: 1}))
+
+	s.primaryTasks.EXPECT().GetTaskQueue(gomock.Any(), gomock.Any()).Return(&persistence.InternalGetTaskQueueResponse{RangeID: 1}, nil)
+	_, err := s.tasks.GetTaskQueue(context.Background(), &persistence.InternalGetTaskQueueRequest{})
+	require.NoError(t, err)
+	require.Zero(t, s.count(metrics.PersistenceDualWriteReadComparisons.Name()))
+}
+
+func TestDataStoreFactory_MirrorsWrites(t *testing.T) {
+	t.Parallel()
+	s := newTestStores(t, ModeDual)
+
+	request := &persistence.InternalUpdateShardRequest{ShardID: 1}
+	gomock.InOrder(
+		s.primaryShards.EXPECT().UpdateShard(gomock.Any(), request).Return(nil),
+		s.secondaryShards.EXPECT().UpdateShard(gomock.Any(), request).Return(nil),
+	)
+	require.NoError(t, s.shards.UpdateShard(context.Background(), request))
+
+	// errors of the mirror store are only counted
+	s.primaryTasks.EXPECT().CreateTasks(gomock.Any(), gomock.Any()).Return(&persistence.CreateTasksResponse{}, nil)
+	s.secondaryTasks.EXPECT().CreateTasks(gomock.Any(), gomock.Any()).Return(nil, serviceerror.NewUnavailable("unavailable"))
+	_, err := s.tasks.CreateTasks(context.Background(), &persistence.InternalCreateTasksRequest{})
+	require.NoError(t, err)
+	require.Equal(t, 1, s.count(metrics.PersistenceDualWriteMirrorErrors.Name()))
+
+	// writes which fail in the authoritative store are not mirrored
+	s.primaryShards.EXPECT().UpdateShard(gomock.Any(), request).Return(&persistence.ShardOwnershipLostError{ShardID: 1})
+	var ownershipLost *persistence.ShardOwnershipLostError
+	require.ErrorAs(t, s.shards.UpdateShard(context.Background(), request), &ownershipLost)
+}
+
+func TestDataStoreFactory_MirrorsMetadata(t *testing.T) {
+	t.Parallel()
+	s := newTestStores(t, ModeDual)
+
+	createRequest := &persistence.InternalCreateNamespaceRequest{ID: "namespace-id", Name: "namespace"}
+	gomock.InOrder(
+		s.primaryMetadata.EXPECT().CreateNamespace(gomock.Any(), createRequest).Return(&persistence.CreateNamespaceResponse{ID: "namespace-id"}, nil),
+		s.secondaryMetadata.EXPECT().CreateNamespace(gomock.Any(), createRequest).Return(&persistence.CreateNamespaceResponse{ID: "namespace-id"}, nil),
+	)
+	_, err := s.metadata.CreateNamespace(context.Background(), createRequest)
+	require.NoError(t, err)
+
+	s.primaryMetadata.EXPECT().GetNamespace(gomock.Any(), gomock.Any()).Return(&persistence.InternalGetNamespaceResponse{NotificationVersion: 1}, nil)
+	s.secondaryMetadata.EXPECT().GetNamespace(gomock.Any(), gomock.Any()).Return(&persistence.InternalGetNamespaceResponse{NotificationVersion: 1}, nil)
+	_, err = s.metadata.GetNamespace(context.Background(), &persistence.GetNamespaceRequest{ID: "namespace-id"})
+	require.NoError(t, err)
+	require.Equal(t, 1, s.count(metrics.PersistenceDualWriteReadComparisons.Name()))
+
+	// lists are only read from the authoritative store
+	s.primaryMetadata.EXPECT().ListNamespaces(gomock.Any(), gomock.Any()).Return(&persistence.InternalListNamespacesResponse{}, nil)
+	_, err = s.metadata.ListNamespaces(context.Background(), &persistence.InternalListNamespacesRequest{})
+	require.NoError(t, err)
+
+	endpointRequest := &persistence.InternalCreateOrUpdateNexusEndpointRequest{}
+	gomock.InOrder(
+		s.primaryEndpoints.EXPECT().CreateOrUpdateNexusEndpoint(gomock.Any(), endpointRequest).Return(nil),
+		s.secondaryEndpoints.EXPECT().CreateOrUpdateNexusEndpoint(gomock.Any(), endpointRequest).Return(nil),
+	)
+	require.NoError(t, s.endpoints.CreateOrUpdateNexusEndpoint(context.Background(), endpointRequest))
+}
+
+func TestDataStoreFactory_Flipped(t *testing.T) {
+	t.Parallel()
+	s := newTestStores(t, ModeFlipped)
+
+	gomock.InOrder(
+		s.secondaryExecutions.EXPECT().DeleteWorkflowExecution(gomock.Any(), gomock.Any()).Return(nil),
+		s.primaryExecutions.EXPECT().DeleteWorkflowExecution(gomock.Any(), gomock.Any()).Return(nil),
+	)
+	require.NoError(t, s.executions.DeleteWorkflowExecution(context.Background(), &persistence.DeleteWorkflowExecutionRequest{}))
+
+	s.secondaryExecutions.EXPECT().GetHistoryTasks(gomock.Any(), gomock.Any()).Return(&persistence.InternalGetHistoryTasksResponse{}, nil)
+	_, err := s.executions.GetHistoryTasks(context.Background(), &persistence.GetHistoryTasksRequest{})
+	require.NoError(t, err)
+	require.Equal(t, "postgres12", s.executions.GetName())
+}
+
+func TestDataStoreFactory_ComparesReads(t *testing.T) {
+	t.Parallel()
+	s := newTestStores(t, ModeDual)
+
+	s.primaryTasks.EXPECT().GetTaskQueue(gomock.Any(), gomock.Any()).Return(&persistence.InternalGetTaskQueueResponse{RangeID: 1}, nil)
+	s.secondaryTasks.EXPECT().GetTaskQueue(gomock.Any(), gomock.Any()).Return(&persistence.InternalGetTaskQueueResponse{RangeID: 1}, nil)
+	resp, err := s.tasks.GetTaskQueue(context.Background(), &persistence.InternalGetTaskQueueRequest{})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), resp.RangeID)
+	require.Equal(t, 1, s.count(metrics.PersistenceDualWriteReadComparisons.Name()))
+	require.Zero(t, s.count(metrics.PersistenceDualWriteReadDivergences.Name()))
+
+	// the result of the authoritative store is returned
+	s.primaryTasks.EXPECT().GetTaskQueue(gomock.Any(), gomock.Any()).Return(&persistence.InternalGetTaskQueueResponse{RangeID: 2}, nil)
+	s.secondaryTasks.EXPECT().GetTaskQueue(gomock.Any(), gomock.Any()).Return(&persistence.InternalGetTaskQueueResponse{RangeID: 1}, nil)
+	resp, err = s.tasks.GetTaskQueue(context.Background(), &persistence.InternalGetTaskQueueRequest{})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), resp.RangeID)
+	require.Equal(t, 1, s.count(metrics.PersistenceDualWriteReadDivergences.Name()))
+
+	// errors of the same type are the same
+	s.primaryExecutions.EXPECT().GetCurrentExecution(gomock.Any(), gomock.Any()).Return(nil, serviceerror.NewNotFound("primary"))
+	s.secondaryExecutions.EXPECT().GetCurrentExecution(gomock.Any(), gomock.Any()).Return(nil, serviceerror.NewNotFound("secondary"))
+	_, err = s.executions.GetCurrentExecution(context.Background(), &persistence.GetCurrentExecutionRequest{})
+	var notFound *serviceerror.NotFound
+	require.ErrorAs(t, err, &notFound)
+	require.Equal(t, 1, s.count(metrics.PersistenceDualWriteReadDivergences.Name()))
+
+	s.primaryExecutions.EXPECT().GetCurrentExecution(gomock.Any(), gomock.Any()).Return(nil, serviceerror.NewNotFound("primary"))
+	s.secondaryExecutions.EXPECT().GetCurrentExecution(gomock.Any(), gomock.Any()).Return(&persistence.InternalGetCurrentExecutionResponse{}, nil)
+	_, err = s.executions.GetCurrentExecution(context.Background(), &persistence.GetCurrentExecutionRequest{})
+	require.ErrorAs(t, err, &notFound)
+	require.Equal(t, 2, s.count(metrics.PersistenceDualWriteReadDivergences.Name()))
+}
+
+func TestDataStoreFactory_GetOrCreateShard(t *testing.T) {
+	t.Parallel()
+	s := newTestStores(t, ModeDual)
+
+	shardInfo := &commonpb.DataBlob{EncodingType: enumspb.ENCODING_TYPE_PROTO3, Data: []byte("shard")}
+	s.primaryShards.EXPECT().GetOrCreateShard(gomock.Any(), gomock.Any()).Return(&persistence.InternalGetOrCreateShardResponse{ShardInfo: shardInfo}, nil)
+	s.secondaryShards.EXPECT().GetOrCreateShard(gomock.Any(), gomock.Any()).Return(&persistence.InternalGetOrCreateShardResponse{ShardInfo: shardInfo}, nil)
+	resp, err := s.shards.GetOrCreateShard(context.Background(), &persistence.InternalGetOrCreateShardRequest{ShardID: 1})
+	require.NoError(t, err)
+	require.Equal(t, shardInfo, resp.ShardInfo)
+	require.Equal(t, 1, s.count(metrics.PersistenceDualWriteReadComparisons.Name()))
+	require.Zero(t, s.count(metrics.PersistenceDualWriteReadDivergences.Name()))
+
+	s.primaryShards.EXPECT().GetOrCreateShard(gomock.Any(), gomock.Any()).Return(nil, errors.New("failed"))
+	_, err = s.shards.GetOrCreateShard(context.Background(), &persistence.InternalGetOrCreateShardRequest{ShardID: 1})
+	require.Error(t, err)
+}
+
+func TestDataStoreFactory_ConvertsChasmNodes(t *testing.T) {
+	t.Parallel()
+	s := newTestStores(t, ModeDual)
+
+	serializer := serialization.NewSerializer()
+	node := &persistencespb.ChasmNode{
+		Metadata: &persistencespb.ChasmNodeMetadata{
+			InitialVersionedTransition: &persistencespb.VersionedTransition{TransitionCount: 1},
+		},
+		Data: &commonpb.DataBlob{EncodingType: enumspb.ENCODING_TYPE_PROTO3, Data: []byte("data")},
+	}
+	blob, err := serializer.ChasmNodeToBlob(node)
+	require.NoError(t, err)
+
+	request := &persistence.InternalUpdateWorkflowExecutionRequest{
+		UpdateWorkflowMutation: persistence.InternalWorkflowMutation{
+			UpsertChasmNodes: map[string]persistence.InternalChasmNode{"root": {CassandraBlob: blob}},
+		},
+	}
+	s.primaryExecutions.EXPECT().UpdateWorkflowExecution(gomock.Any(), request).Return(nil)
+	s.secondaryExecutions.EXPECT().UpdateWorkflowExecution(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *persistence.InternalUpdateWorkflowExecutionRequest) error {
+			converted := request.UpdateWorkflowMutation.UpsertChasmNodes["root"]
+			require.Nil(t, converted.CassandraBlob)
+			decoded, err := serializer.ChasmNodeFromBlobs(converted.Metadata, converted.Data)
+			require.NoError(t, err)
+			require.Equal(t, node.GetMetadata().GetInitialVersionedTransition().GetTransitionCount(), decoded.GetMetadata().GetInitialVersionedTransition().GetTransitionCount())
+			require.Equal(t, node.GetData().GetData(), decoded.GetData().GetData())
+			return nil
+		},
+	)
+	require.NoError(t, s.executions.UpdateWorkflowExecution(context.Background(), request))
+	require.NotNil(t, request.UpdateWorkflowMutation.UpsertChasmNodes["root"].CassandraBlob, "request of the caller is not modified")
+}
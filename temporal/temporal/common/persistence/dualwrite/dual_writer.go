@@ -0,0 +1,228 @@
+package dualwrite
+
+import (
+	"math/rand"
+	"reflect"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/log/tag"
+	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/persistence/serialization"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+const (
+	// ModeOff means that only the primary store is used.
+	ModeOff = "off"
+	// ModeDual means that the primary store is authoritative, and its writes are mirrored to the secondary store.
+	ModeDual = "dual"
+	// ModeFlipped means that the secondary store is authoritative, and its writes are mirrored to the primary store.
+	ModeFlipped = "flipped"
+
+	// maxLoggedDiffLength is the maximum length of the diffs of divergent reads which are logged.
+	maxLoggedDiffLength = 4096
+)
+
+type (
+	// dualWriter holds the config and the helpers shared by the dual-written stores.
+	dualWriter struct {
+		mode           dynamicconfig.StringPropertyFn
+		comparisonRate dynamicconfig.FloatPropertyFn
+		serializer     serialization.Serializer
+		metricsHandler metrics.Handler
+		logger         log.Logger
+		compareOptions cmp.Options
+	}
+)
+
+func newDualWriter(
+	serializer serialization.Serializer,
+	dc *dynamicconfig.Collection,
+	metricsHandler metrics.Handler,
+	logger log.Logger,
+) *dualWriter {
+	return &dualWriter{
+		mode:           dynamicconfig.PersistenceDualWriteMode.Get(dc),
+		comparisonRate: dynamicconfig.PersistenceDualWriteReadComparisonRate.Get(dc),
+		serializer:     serializer,
+		metricsHandler: metricsHandler,
+		logger:         logger,
+		compareOptions: newCompareOptions(serializer),
+	}
+}
+
+// newCompareOptions returns the options to compare the results of the stores. Chasm nodes are compared decoded,
+// since Cassandra stores them in a single blob and the other stores in separate metadata and data blobs.
+func newCompareOptions(serializer serialization.Serializer) cmp.Options {
+	return cmp.Options{
+		cmp.Transformer("ChasmNode", func(node persistence.InternalChasmNode) *persistencespb.ChasmNode {
+			var decoded *persistencespb.ChasmNode
+			if node.CassandraBlob != nil {
+				decoded, _ = serializer.ChasmNodeFromBlob(node.CassandraBlob)
+			} else {
+				decoded, _ = serializer.ChasmNodeFromBlobs(node.Metadata, node.Data)
+			}
+			return decoded
+		}),
+		protocmp.Transform(),
+		cmpopts.EquateEmpty(),
+		cmpopts.SortSlices(func(a, b string) bool { return a < b }),
+	}
+}
+
+// selectStores returns the authoritative and the mirror store for the current mode. The mirror store is not set if
+// dual writes are off.
+func selectStores[S any](d *dualWriter, primary S, secondary S) (authoritative S, mirror S, dual bool) {
+	switch d.mode() {
+	case ModeDual:
+		return primary, secondary, true
+	case ModeFlipped:
+		return secondary, primary, true
+	default:
+		var none S
+		return primary, none, false
+	}
+}
+
+// write calls fn with the authoritative store, and then with the mirror store if dual writes are enabled and the
+// authoritative store succeeded. Errors of the mirror store are logged and counted, but not returned.
+func write[S any](d *dualWriter, operation string, primary S, secondary S, fn func(S) error) error {
+	_, err := writeWithResponse(d, operation, primary, secondary, func(store S) (struct{}, error) {
+		return struct{}{}, fn(store)
+	})
+	return err
+}
+
+// writeWithResponse is like write, for operations which return a response. The response of the authoritative store is
+// returned.
+func writeWithResponse[S any, R any](d *dualWriter, operation string, primary S, secondary S, fn func(S) (R, error)) (R, error) {
+	authoritative, mirror, dual := selectStores(d, primary, secondary)
+	resp, err := fn(authoritative)
+	if err != nil || !dual {
+		return resp, err
+	}
+	if _, mirrorErr := fn(mirror); mirrorErr != nil {
+		metrics.PersistenceDualWriteMirrorErrors.With(d.metricsHandler).Record(1, metrics.OperationTag(operation))
+		d.logger.Warn("Persistence dual write to the mirror store failed.", tag.Operation(operation), tag.Error(mirrorErr))
+	}
+	return resp, nil
+}
+
+// read calls fn with the authoritative store. If dual writes are enabled, then a sample of the reads is also made
+// from the mirror store concurrently, and the results are compared. The result of the authoritative store is
+// returned.
+func read[S any, R any](d *dualWriter, operation string, primary S, secondary S, fn func(S) (R, error)) (R, error) {
+	authoritative, mirror, dual := selectStores(d, primary, secondary)
+	if !dual || rand.Float64() >= d.comparisonRate() {
+		return fn(authoritative)
+	}
+
+	var mirrorResp R
+	var mirrorErr error
+	mirrorDone := make(chan struct{})
+	go func() {
+		defer close(mirrorDone)
+		mirrorResp, mirrorErr = fn(mirror)
+	}()
+	resp, err := fn(authoritative)
+	<-mirrorDone
+
+	d.compare(operation, resp, err, mirrorResp, mirrorErr)
+	return resp, err
+}
+
+// compare records whether the results of the authoritative and the mirror store are the same, and logs the
+// difference if they are not.
+func (d *dualWriter) compare(operation string, resp any, err error, mirrorResp any, mirrorErr error) {
+	metrics.PersistenceDualWriteReadComparisons.With(d.metricsHandler).Record(1, metrics.OperationTag(operation))
+
+	var diff string
+	if err != nil || mirrorErr != nil {
+		// errors are the same if they have the same type, e.g. both are NotFound errors
+		if reflect.TypeOf(err) == reflect.TypeOf(mirrorErr) {
+			return
+		}
+		diff = "error: " + errorString(err) + ", mirror error: " + errorString(mirrorErr)
+	} else {
+		diff = cmp.Diff(resp, mirrorResp, d.compareOptions)
+	}
+	if diff == "" {
+		return
+	}
+
+	metrics.PersistenceDualWriteReadDivergences.With(d.metricsHandler).Record(1, metrics.OperationTag(operation))
+	if len(diff) > maxLoggedDiffLength {
+		diff = diff[:maxLoggedDiffLength] + "..."
+	}
+	d.logger.Warn("Persistence dual write stores returned different results.",
+		tag.Operation(operation),
+		tag.NewStringTag("diff", diff),
+	)
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return "<nil>"
+	}
+	return err.Error()
+}
+
+// isCassandraStore returns true if the store stores chasm nodes in single blobs, like the execution manager.
+func isCassandraStore(store persistence.ExecutionStore) bool {
+	return strings.Contains(store.GetName(), "cassandra")
+}
+
+// chasmNodesFor returns the chasm nodes in the representation of the store. The nodes are returned unchanged if
+// they are in that representation already.
+func (d *dualWriter) chasmNodesFor(
+	store persistence.ExecutionStore,
+	nodes map[string]persistence.InternalChasmNode,
+) (map[string]persistence.InternalChasmNode, error) {
+	cassandra := isCassandraStore(store)
+	needsConversion := false
+	for _, node := range nodes {
+		if (node.CassandraBlob != nil) != cassandra {
+			needsConversion = true
+			break
+		}
+	}
+	if !needsConversion {
+		return nodes, nil
+	}
+
+	converted := make(map[string]persistence.InternalChasmNode, len(nodes))
+	for path, node := range nodes {
+		if (node.CassandraBlob != nil) == cassandra {
+			converted[path] = node
+			continue
+		}
+		if cassandra {
+			decoded, err := d.serializer.ChasmNodeFromBlobs(node.Metadata, node.Data)
+			if err != nil {
+				return nil, err
+			}
+			blob, err := d.serializer.ChasmNodeToBlob(decoded)
+			if err != nil {
+				return nil, err
+			}
+			converted[path] = persistence.InternalChasmNode{CassandraBlob: blob}
+		} else {
+			decoded, err := d.serializer.ChasmNodeFromBlob(node.CassandraBlob)
+			if err != nil {
+				return nil, err
+			}
+			metadata, data, err := d.serializer.ChasmNodeToBlobs(decoded)
+			if err != nil {
+				return nil, err
+			}
+			converted[path] = persistence.InternalChasmNode{Metadata: metadata, Data: data}
+		}
+	}
+	return converted, nil
+}
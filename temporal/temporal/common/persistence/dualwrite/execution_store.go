@@ -0,0 +1,315 @@
+package dualwrite
+
+import (
+	"context"
+
+	"go.temporal.io/server/common/persistence"
+)
+
+type (
+	// executionStore is a persistence.ExecutionStore which dual-writes workflow executions, history and history
+	// tasks. Paginated reads are only made from the authoritative store.
+	executionStore struct {
+		*dualWriter
+		primary   persistence.ExecutionStore
+		secondary persistence.ExecutionStore
+	}
+)
+
+var _ persistence.ExecutionStore = (*executionStore)(nil)
+
+func (s *executionStore) authoritative() persistence.ExecutionStore {
+	authoritative, _, _ := selectStores(s.dualWriter, s.primary, s.secondary)
+	return authoritative
+}
+
+func (s *executionStore) Close() {
+	s.primary.Close()
+	s.secondary.Close()
+}
+
+// GetName returns the name of the authoritative store. The execution manager encodes chasm nodes for that store, and
+// they are converted when they are mirrored to a store which encodes them differently.
+func (s *executionStore) GetName() string {
+	return s.authoritative().GetName()
+}
+
+func (s *executionStore) GetHistoryBranchUtil() persistence.HistoryBranchUtil {
+	return s.authoritative().GetHistoryBranchUtil()
+}
+
+func (s *executionStore) CreateWorkflowExecution(
+	ctx context.Context,
+	request *persistence.InternalCreateWorkflowExecutionRequest,
+) (*persistence.InternalCreateWorkflowExecutionResponse, error) {
+	return writeWithResponse(s.dualWriter, "CreateWorkflowExecution", s.primary, s.secondary, func(store persistence.ExecutionStore) (*persistence.InternalCreateWorkflowExecutionResponse, error) {
+		storeRequest := *request
+		var err error
+		if storeRequest.NewWorkflowSnapshot, err = s.snapshotFor(store, request.NewWorkflowSnapshot); err != nil {
+			return nil, err
+		}
+		return store.CreateWorkflowExecution(ctx, &storeRequest)
+	})
+}
+
+func (s *executionStore) UpdateWorkflowExecution(
+	ctx context.Context,
+	request *persistence.InternalUpdateWorkflowExecutionRequest,
+) error {
+	return write(s.dualWriter, "UpdateWorkflowExecution", s.primary, s.secondary, func(store persistence.ExecutionStore) error {
+		storeRequest := *request
+		var err error
+		if storeRequest.UpdateWorkflowMutation, err = s.mutationFor(store, request.UpdateWorkflowMutation); err != nil {
+			return err
+		}
+		if storeRequest.NewWorkflowSnapshot, err = s.snapshotPtrFor(store, request.NewWorkflowSnapshot); err != nil {
+			return err
+		}
+		return store.UpdateWorkflowExecution(ctx, &storeRequest)
+	})
+}
+
+func (s *executionStore) ConflictResolveWorkflowExecution(
+	ctx context.Context,
+	request *persistence.InternalConflictResolveWorkflowExecutionRequest,
+) error {
+	return write(s.dualWriter, "ConflictResolveWorkflowExecution", s.primary, s.secondary, func(store persistence.ExecutionStore) error {
+		storeRequest := *request
+		var err error
+		if storeRequest.ResetWorkflowSnapshot, err = s.snapshotFor(store, request.ResetWorkflowSnapshot); err != nil {
+			return err
+		}
+		if storeRequest.NewWorkflowSnapshot, err = s.snapshotPtrFor(store, request.NewWorkflowSnapshot); err != nil {
+			return err
+		}
+		if request.CurrentWorkflowMutation != nil {
+			mutation, err := s.mutationFor(store, *request.CurrentWorkflowMutation)
+			if err != nil {
+				return err
+			}
+			storeRequest.CurrentWorkflowMutation = &mutation
+		}
+		return store.ConflictResolveWorkflowExecution(ctx, &storeRequest)
+	})
+}
+
+func (s *executionStore) DeleteWorkflowExecution(
+	ctx context.Context,
+	request *persistence.DeleteWorkflowExecutionRequest,
+) error {
+	return write(s.dualWriter, "DeleteWorkflowExecution", s.primary, s.secondary, func(store persistence.ExecutionStore) error {
+		return store.DeleteWorkflowExecution(ctx, request)
+	})
+}
+
+func (s *executionStore) DeleteCurrentWorkflowExecution(
+	ctx context.Context,
+	request *persistence.DeleteCurrentWorkflowExecutionRequest,
+) error {
+	return write(s.dualWriter, "DeleteCurrentWorkflowExecution", s.primary, s.secondary, func(store persistence.ExecutionStore) error {
+		return store.DeleteCurrentWorkflowExecution(ctx, request)
+	})
+}
+
+func (s *executionStore) GetCurrentExecution(
+	ctx context.Context,
+	request *persistence.GetCurrentExecutionRequest,
+) (*persistence.InternalGetCurrentExecutionResponse, error) {
+	return read(s.dualWriter, "GetCurrentExecution", s.primary, s.secondary, func(store persistence.ExecutionStore) (*persistence.InternalGetCurrentExecutionResponse, error) {
+		return store.GetCurrentExecution(ctx, request)
+	})
+}
+
+func (s *executionStore) GetWorkflowExecution(
+	ctx context.Context,
+	request *persistence.GetWorkflowExecutionRequest,
+) (*persistence.InternalGetWorkflowExecutionResponse, error) {
+	return read(s.dualWriter, "GetWorkflowExecution", s.primary, s.secondary, func(store persistence.ExecutionStore) (*persistence.InternalGetWorkflowExecutionResponse, error) {
+		return store.GetWorkflowExecution(ctx, request)
+	})
+}
+
+func (s *executionStore) SetWorkflowExecution(
+	ctx context.Context,
+	request *persistence.InternalSetWorkflowExecutionRequest,
+) error {
+	return write(s.dualWriter, "SetWorkflowExecution", s.primary, s.secondary, func(store persistence.ExecutionStore) error {
+		storeRequest := *request
+		var err error
+		if storeRequest.SetWorkflowSnapshot, err = s.snapshotFor(store, request.SetWorkflowSnapshot); err != nil {
+			return err
+		}
+		return store.SetWorkflowExecution(ctx, &storeRequest)
+	})
+}
+
+func (s *executionStore) ListConcreteExecutions(
+	ctx context.Context,
+	request *persistence.ListConcreteExecutionsRequest,
+) (*persistence.InternalListConcreteExecutionsResponse, error) {
+	return s.authoritative().ListConcreteExecutions(ctx, request)
+}
+
+func (s *executionStore) AddHistoryTasks(
+	ctx context.Context,
+	request *persistence.InternalAddHistoryTasksRequest,
+) error {
+	return write(s.dualWriter, "AddHistoryTasks", s.primary, s.secondary, func(store persistence.ExecutionStore) error {
+		return store.AddHistoryTasks(ctx, request)
+	})
+}
+
+func (s *executionStore) GetHistoryTasks(
+	ctx context.Context,
+	request *persistence.GetHistoryTasksRequest,
+) (*persistence.InternalGetHistoryTasksResponse, error) {
+	return s.authoritative().GetHistoryTasks(ctx, request)
+}
+
+func (s *executionStore) CompleteHistoryTask(
+	ctx context.Context,
+	request *persistence.CompleteHistoryTaskRequest,
+) error {
+	return write(s.dualWriter, "CompleteHistoryTask", s.primary, s.secondary, func(store persistence.ExecutionStore) error {
+		return store.CompleteHistoryTask(ctx, request)
+	})
+}
+
+func (s *executionStore) RangeCompleteHistoryTasks(
+	ctx context.Context,
+	request *persistence.RangeCompleteHistoryTasksRequest,
+) error {
+	return write(s.dualWriter, "RangeCompleteHistoryTasks", s.primary, s.secondary, func(store persistence.ExecutionStore) error {
+		return store.RangeCompleteHistoryTasks(ctx, request)
+	})
+}
+
+func (s *executionStore) PutReplicationTaskToDLQ(
+	ctx context.Context,
+	request *persistence.PutReplicationTaskToDLQRequest,
+) error {
+	return write(s.dualWriter, "PutReplicationTaskToDLQ", s.primary, s.secondary, func(store persistence.ExecutionStore) error {
+		return store.PutReplicationTaskToDLQ(ctx, request)
+	})
+}
+
+func (s *executionStore) GetReplicationTasksFromDLQ(
+	ctx context.Context,
+	request *persistence.GetReplicationTasksFromDLQRequest,
+) (*persistence.InternalGetReplicationTasksFromDLQResponse, error) {
+	return s.authoritative().GetReplicationTasksFromDLQ(ctx, request)
+}
+
+func (s *executionStore) DeleteReplicationTaskFromDLQ(
+	ctx context.Context,
+	request *persistence.DeleteReplicationTaskFromDLQRequest,
+) error {
+	return write(s.dualWriter, "DeleteReplicationTaskFromDLQ", s.primary, s.secondary, func(store persistence.ExecutionStore) error {
+		return store.DeleteReplicationTaskFromDLQ(ctx, request)
+	})
+}
+
+func (s *executionStore) RangeDeleteReplicationTaskFromDLQ(
+	ctx context.Context,
+	request *persistence.RangeDeleteReplicationTaskFromDLQRequest,
+) error {
+	return write(s.dualWriter, "RangeDeleteReplicationTaskFromDLQ", s.primary, s.secondary, func(store persistence.ExecutionStore) error {
+		return store.RangeDeleteReplicationTaskFromDLQ(ctx, request)
+	})
+}
+
+func (s *executionStore) IsReplicationDLQEmpty(
+	ctx context.Context,
+	request *persistence.GetReplicationTasksFromDLQRequest,
+) (bool, error) {
+	return s.authoritative().IsReplicationDLQEmpty(ctx, request)
+}
+
+func (s *executionStore) AppendHistoryNodes(
+	ctx context.Context,
+	request *persistence.InternalAppendHistoryNodesRequest,
+) error {
+	return write(s.dualWriter, "AppendHistoryNodes", s.primary, s.secondary, func(store persistence.ExecutionStore) error {
+		return store.AppendHistoryNodes(ctx, request)
+	})
+}
+
+func (s *executionStore) DeleteHistoryNodes(
+	ctx context.Context,
+	request *persistence.InternalDeleteHistoryNodesRequest,
+) error {
+	return write(s.dualWriter, "DeleteHistoryNodes", s.primary, s.secondary, func(store persistence.ExecutionStore) error {
+		return store.DeleteHistoryNodes(ctx, request)
+	})
+}
+
+func (s *executionStore) ReadHistoryBranch(
+	ctx context.Context,
+	request *persistence.InternalReadHistoryBranchRequest,
+) (*persistence.InternalReadHistoryBranchResponse, error) {
+	return s.authoritative().ReadHistoryBranch(ctx, request)
+}
+
+func (s *executionStore) ForkHistoryBranch(
+	ctx context.Context,
+	request *persistence.InternalForkHistoryBranchRequest,
+) error {
+	return write(s.dualWriter, "ForkHistoryBranch", s.primary, s.secondary, func(store persistence.ExecutionStore) error {
+		return store.ForkHistoryBranch(ctx, request)
+	})
+}
+
+func (s *executionStore) DeleteHistoryBranch(
+	ctx context.Context,
+	request *persistence.InternalDeleteHistoryBranchRequest,
+) error {
+	return write(s.dualWriter, "DeleteHistoryBranch", s.primary, s.secondary, func(store persistence.ExecutionStore) error {
+		return store.DeleteHistoryBranch(ctx, request)
+	})
+}
+
+func (s *executionStore) GetHistoryTreeContainingBranch(
+	ctx context.Context,
+	request *persistence.InternalGetHistoryTreeContainingBranchRequest,
+) (*persistence.InternalGetHistoryTreeContainingBranchResponse, error) {
+	return s.authoritative().GetHistoryTreeContainingBranch(ctx, request)
+}
+
+func (s *executionStore) GetAllHistoryTreeBranches(
+	ctx context.Context,
+	request *persistence.GetAllHistoryTreeBranchesRequest,
+) (*persistence.InternalGetAllHistoryTreeBranchesResponse, error) {
+	return s.authoritative().GetAllHistoryTreeBranches(ctx, request)
+}
+
+func (s *executionStore) snapshotFor(
+	store persistence.ExecutionStore,
+	snapshot persistence.InternalWorkflowSnapshot,
+) (persistence.InternalWorkflowSnapshot, error) {
+	var err error
+	snapshot.ChasmNodes, err = s.chasmNodesFor(store, snapshot.ChasmNodes)
+	return snapshot, err
+}
+
+func (s *executionStore) snapshotPtrFor(
+	store persistence.ExecutionStore,
+	snapshot *persistence.InternalWorkflowSnapshot,
+) (*persistence.InternalWorkflowSnapshot, error) {
+	if snapshot == nil {
+		return nil, nil
+	}
+	storeSnapshot, err := s.snapshotFor(store, *snapshot)
+	if err != nil {
+		return nil, err
+	}
+	return &storeSnapshot, nil
+}
+
+func (s *executionStore) mutationFor(
+	store persistence.ExecutionStore,
+	mutation persistence.InternalWorkflowMutation,
+) (persistence.InternalWorkflowMutation, error) {
+	var err error
+	mutation.UpsertChasmNodes, err = s.chasmNodesFor(store, mutation.UpsertChasmNodes)
+	return mutation, err
+}
@@ -0,0 +1,102 @@
+package dualwrite
+
+import (
+	"context"
+
+	"go.temporal.io/server/common/persistence"
+)
+
+type (
+	// metadataStore is a persistence.MetadataStore which dual-writes namespaces. Namespace lists are only read from
+	// the authoritative store.
+	metadataStore struct {
+		*dualWriter
+		primary   persistence.MetadataStore
+		secondary persistence.MetadataStore
+	}
+)
+
+var _ persistence.MetadataStore = (*metadataStore)(nil)
+
+func (s *metadataStore) authoritative() persistence.MetadataStore {
+	authoritative, _, _ := selectStores(s.dualWriter, s.primary, s.secondary)
+	return authoritative
+}
+
+func (s *metadataStore) Close() {
+	s.primary.Close()
+	s.secondary.Close()
+}
+
+func (s *metadataStore) GetName() string {
+	return s.authoritative().GetName()
+}
+
+func (s *metadataStore) CreateNamespace(
+	ctx context.Context,
+	request *persistence.InternalCreateNamespaceRequest,
+) (*persistence.CreateNamespaceResponse, error) {
+	return writeWithResponse(s.dualWriter, "CreateNamespace", s.primary, s.secondary, func(store persistence.MetadataStore) (*persistence.CreateNamespaceResponse, error) {
+		return store.CreateNamespace(ctx, request)
+	})
+}
+
+func (s *metadataStore) GetNamespace(
+	ctx context.Context,
+	request *persistence.GetNamespaceRequest,
+) (*persistence.InternalGetNamespaceResponse, error) {
+	return read(s.dualWriter, "GetNamespace", s.primary, s.secondary, func(store persistence.MetadataStore) (*persistence.InternalGetNamespaceResponse, error) {
+		return store.GetNamespace(ctx, request)
+	})
+}
+
+func (s *metadataStore) UpdateNamespace(
+	ctx context.Context,
+	request *persistence.InternalUpdateNamespaceRequest,
+) error {
+	return write(s.dualWriter, "UpdateNamespace", s.primary, s.secondary, func(store persistence.MetadataStore) error {
+		return store.UpdateNamespace(ctx, request)
+	})
+}
+
+func (s *metadataStore) RenameNamespace(
+	ctx context.Context,
+	request *persistence.InternalRenameNamespaceRequest,
+) error {
+	return write(s.dualWriter, "RenameNamespace", s.primary, s.secondary, func(store persistence.MetadataStore) error {
+		return store.RenameNamespace(ctx, request)
+	})
+}
+
+func (s *metadataStore) DeleteNamespace(
+	ctx context.Context,
+	request *persistence.DeleteNamespaceRequest,
+) error {
+	return write(s.dualWriter, "DeleteNamespace", s.primary, s.secondary, func(store persistence.MetadataStore) error {
+		return store.DeleteNamespace(ctx, request)
+	})
+}
+
+func (s *metadataStore) DeleteNamespaceByName(
+	ctx context.Context,
+	request *persistence.DeleteNamespaceByNameRequest,
+) error {
+	return write(s.dualWriter, "DeleteNamespaceByName", s.primary, s.secondary, func(store persistence.MetadataStore) error {
+		return store.DeleteNamespaceByName(ctx, request)
+	})
+}
+
+func (s *metadataStore) ListNamespaces(
+	ctx context.Context,
+	request *persistence.InternalListNamespacesRequest,
+) (*persistence.InternalListNamespacesResponse, error) {
+	return s.authoritative().ListNamespaces(ctx, request)
+}
+
+func (s *metadataStore) GetMetadata(
+	ctx context.Context,
+) (*persistence.GetMetadataResponse, error) {
+	return read(s.dualWriter, "GetMetadata", s.primary, s.secondary, func(store persistence.MetadataStore) (*persistence.GetMetadataResponse, error) {
+		return store.GetMetadata(ctx)
+	})
+}
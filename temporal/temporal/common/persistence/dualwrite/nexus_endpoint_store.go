@@ -0,0 +1,67 @@
+package dualwrite
+
+import (
+	"context"
+
+	"go.temporal.io/server/common/persistence"
+)
+
+type (
+	// nexusEndpointStore is a persistence.NexusEndpointStore which dual-writes Nexus endpoints. Endpoint lists are only
+	// read from the authoritative store.
+	nexusEndpointStore struct {
+		*dualWriter
+		primary   persistence.NexusEndpointStore
+		secondary persistence.NexusEndpointStore
+	}
+)
+
+var _ persistence.NexusEndpointStore = (*nexusEndpointStore)(nil)
+
+func (s *nexusEndpointStore) authoritative() persistence.NexusEndpointStore {
+	authoritative, _, _ := selectStores(s.dualWriter, s.primary, s.secondary)
+	return authoritative
+}
+
+func (s *nexusEndpointStore) Close() {
+	s.primary.Close()
+	s.secondary.Close()
+}
+
+func (s *nexusEndpointStore) GetName() string {
+	return s.authoritative().GetName()
+}
+
+func (s *nexusEndpointStore) CreateOrUpdateNexusEndpoint(
+	ctx context.Context,
+	request *persistence.InternalCreateOrUpdateNexusEndpointRequest,
+) error {
+	return write(s.dualWriter, "CreateOrUpdateNexusEndpoint", s.primary, s.secondary, func(store persistence.NexusEndpointStore) error {
+		return store.CreateOrUpdateNexusEndpoint(ctx, request)
+	})
+}
+
+func (s *nexusEndpointStore) DeleteNexusEndpoint(
+	ctx context.Context,
+	request *persistence.DeleteNexusEndpointRequest,
+) error {
+	return write(s.dualWriter, "DeleteNexusEndpoint", s.primary, s.secondary, func(store persistence.NexusEndpointStore) error {
+		return store.DeleteNexusEndpoint(ctx, request)
+	})
+}
+
+func (s *nexusEndpointStore) GetNexusEndpoint(
+	ctx context.Context,
+	request *persistence.GetNexusEndpointRequest,
+) (*persistence.InternalNexusEndpoint, error) {
+	return read(s.dualWriter, "GetNexusEndpoint", s.primary, s.secondary, func(store persistence.NexusEndpointStore) (*persistence.InternalNexusEndpoint, error) {
+		return store.GetNexusEndpoint(ctx, request)
+	})
+}
+
+func (s *nexusEndpointStore) ListNexusEndpoints(
+	ctx context.Context,
+	request *persistence.ListNexusEndpointsRequest,
+) (*persistence.InternalListNexusEndpointsResponse, error) {
+	return s.authoritative().ListNexusEndpoints(ctx, request)
+}
@@ -0,0 +1,190 @@
+package dualwrite
+
+import (
+	"context"
+
+	commonpb "go.temporal.io/api/common/v1"
+	"go.temporal.io/server/common/persistence"
+)
+
+type (
+	// queue is a persistence.Queue which dual-writes queue messages and ack levels. Each store assigns its own
+	// message IDs, so messages are only read from the authoritative store.
+	queue struct {
+		*dualWriter
+		primary   persistence.Queue
+		secondary persistence.Queue
+	}
+
+	// queueV2 is a persistence.QueueV2 which dual-writes queues and their messages. Each store assigns its own
+	// message IDs, so messages are only read from the authoritative store.
+	queueV2 struct {
+		*dualWriter
+		primary   persistence.QueueV2
+		secondary persistence.QueueV2
+	}
+)
+
+var (
+	_ persistence.Queue   = (*queue)(nil)
+	_ persistence.QueueV2 = (*queueV2)(nil)
+)
+
+func (q *queue) authoritative() persistence.Queue {
+	authoritative, _, _ := selectStores(q.dualWriter, q.primary, q.secondary)
+	return authoritative
+}
+
+func (q *queue) Close() {
+	q.primary.Close()
+	q.secondary.Close()
+}
+
+func (q *queue) Init(
+	ctx context.Context,
+	blob *commonpb.DataBlob,
+) error {
+	return write(q.dualWriter, "QueueInit", q.primary, q.secondary, func(store persistence.Queue) error {
+		return store.Init(ctx, blob)
+	})
+}
+
+func (q *queue) EnqueueMessage(
+	ctx context.Context,
+	blob *commonpb.DataBlob,
+) error {
+	return write(q.dualWriter, "EnqueueMessage", q.primary, q.secondary, func(store persistence.Queue) error {
+		return store.EnqueueMessage(ctx, blob)
+	})
+}
+
+func (q *queue) ReadMessages(
+	ctx context.Context,
+	lastMessageID int64,
+	maxCount int,
+) ([]*persistence.QueueMessage, error) {
+	return q.authoritative().ReadMessages(ctx, lastMessageID, maxCount)
+}
+
+func (q *queue) DeleteMessagesBefore(
+	ctx context.Context,
+	messageID int64,
+) error {
+	return write(q.dualWriter, "DeleteMessagesBefore", q.primary, q.secondary, func(store persistence.Queue) error {
+		return store.DeleteMessagesBefore(ctx, messageID)
+	})
+}
+
+func (q *queue) UpdateAckLevel(
+	ctx context.Context,
+	metadata *persistence.InternalQueueMetadata,
+) error {
+	return write(q.dualWriter, "UpdateAckLevel", q.primary, q.secondary, func(store persistence.Queue) error {
+		return store.UpdateAckLevel(ctx, metadata)
+	})
+}
+
+func (q *queue) GetAckLevels(
+	ctx context.Context,
+) (*persistence.InternalQueueMetadata, error) {
+	return q.authoritative().GetAckLevels(ctx)
+}
+
+func (q *queue) EnqueueMessageToDLQ(
+	ctx context.Context,
+	blob *commonpb.DataBlob,
+) (int64, error) {
+	return writeWithResponse(q.dualWriter, "EnqueueMessageToDLQ", q.primary, q.secondary, func(store persistence.Queue) (int64, error) {
+		return store.EnqueueMessageToDLQ(ctx, blob)
+	})
+}
+
+func (q *queue) ReadMessagesFromDLQ(
+	ctx context.Context,
+	firstMessageID int64,
+	lastMessageID int64,
+	pageSize int,
+	pageToken []byte,
+) ([]*persistence.QueueMessage, []byte, error) {
+	return q.authoritative().ReadMessagesFromDLQ(ctx, firstMessageID, lastMessageID, pageSize, pageToken)
+}
+
+func (q *queue) DeleteMessageFromDLQ(
+	ctx context.Context,
+	messageID int64,
+) error {
+	return write(q.dualWriter, "DeleteMessageFromDLQ", q.primary, q.secondary, func(store persistence.Queue) error {
+		return store.DeleteMessageFromDLQ(ctx, messageID)
+	})
+}
+
+func (q *queue) RangeDeleteMessagesFromDLQ(
+	ctx context.Context,
+	firstMessageID int64,
+	lastMessageID int64,
+) error {
+	return write(q.dualWriter, "RangeDeleteMessagesFromDLQ", q.primary, q.secondary, func(store persistence.Queue) error {
+		return store.RangeDeleteMessagesFromDLQ(ctx, firstMessageID, lastMessageID)
+	})
+}
+
+func (q *queue) UpdateDLQAckLevel(
+	ctx context.Context,
+	metadata *persistence.InternalQueueMetadata,
+) error {
+	return write(q.dualWriter, "UpdateDLQAckLevel", q.primary, q.secondary, func(store persistence.Queue) error {
+		return store.UpdateDLQAckLevel(ctx, metadata)
+	})
+}
+
+func (q *queue) GetDLQAckLevels(
+	ctx context.Context,
+) (*persistence.InternalQueueMetadata, error) {
+	return q.authoritative().GetDLQAckLevels(ctx)
+}
+
+func (q *queueV2) authoritative() persistence.QueueV2 {
+	authoritative, _, _ := selectStores(q.dualWriter, q.primary, q.secondary)
+	return authoritative
+}
+
+func (q *queueV2) EnqueueMessage(
+	ctx context.Context,
+	request *persistence.InternalEnqueueMessageRequest,
+) (*persistence.InternalEnqueueMessageResponse, error) {
+	return writeWithResponse(q.dualWriter, "EnqueueMessageV2", q.primary, q.secondary, func(store persistence.QueueV2) (*persistence.InternalEnqueueMessageResponse, error) {
+		return store.EnqueueMessage(ctx, request)
+	})
+}
+
+func (q *queueV2) ReadMessages(
+	ctx context.Context,
+	request *persistence.InternalReadMessagesRequest,
+) (*persistence.InternalReadMessagesResponse, error) {
+	return q.authoritative().ReadMessages(ctx, request)
+}
+
+func (q *queueV2) CreateQueue(
+	ctx context.Context,
+	request *persistence.InternalCreateQueueRequest,
+) (*persistence.InternalCreateQueueResponse, error) {
+	return writeWithResponse(q.dualWriter, "CreateQueueV2", q.primary, q.secondary, func(store persistence.QueueV2) (*persistence.InternalCreateQueueResponse, error) {
+		return store.CreateQueue(ctx, request)
+	})
+}
+
+func (q *queueV2) RangeDeleteMessages(
+	ctx context.Context,
+	request *persistence.InternalRangeDeleteMessagesRequest,
+) (*persistence.InternalRangeDeleteMessagesResponse, error) {
+	return writeWithResponse(q.dualWriter, "RangeDeleteMessagesV2", q.primary, q.secondary, func(store persistence.QueueV2) (*persistence.InternalRangeDeleteMessagesResponse, error) {
+		return store.RangeDeleteMessages(ctx, request)
+	})
+}
+
+func (q *queueV2) ListQueues(
+	ctx context.Context,
+	request *persistence.InternalListQueuesRequest,
+) (*persistence.InternalListQueuesResponse, error) {
+	return q.authoritative().ListQueues(ctx, request)
+}
@@ -0,0 +1,68 @@
+package dualwrite
+
+import (
+	"context"
+
+	"go.temporal.io/server/common/persistence"
+)
+
+type (
+	// shardStore is a persistence.ShardStore which dual-writes shards.
+	shardStore struct {
+		*dualWriter
+		primary   persistence.ShardStore
+		secondary persistence.ShardStore
+	}
+)
+
+var _ persistence.ShardStore = (*shardStore)(nil)
+
+func (s *shardStore) authoritative() persistence.ShardStore {
+	authoritative, _, _ := selectStores(s.dualWriter, s.primary, s.secondary)
+	return authoritative
+}
+
+func (s *shardStore) Close() {
+	s.primary.Close()
+	s.secondary.Close()
+}
+
+func (s *shardStore) GetName() string {
+	return s.authoritative().GetName()
+}
+
+func (s *shardStore) GetClusterName() string {
+	return s.primary.GetClusterName()
+}
+
+// GetOrCreateShard gets the shard from the authoritative store. If dual writes are enabled, then it also gets or
+// creates the shard in the mirror store, and compares the results.
+func (s *shardStore) GetOrCreateShard(
+	ctx context.Context,
+	request *persistence.InternalGetOrCreateShardRequest,
+) (*persistence.InternalGetOrCreateShardResponse, error) {
+	authoritative, mirror, dual := selectStores(s.dualWriter, s.primary, s.secondary)
+	resp, err := authoritative.GetOrCreateShard(ctx, request)
+	if err != nil || !dual {
+		return resp, err
+	}
+	mirrorResp, mirrorErr := mirror.GetOrCreateShard(ctx, request)
+	s.compare("GetOrCreateShard", resp, err, mirrorResp, mirrorErr)
+	return resp, nil
+}
+
+func (s *shardStore) UpdateShard(
+	ctx context.Context,
+	request *persistence.InternalUpdateShardRequest,
+) error {
+	return write(s.dualWriter, "UpdateShard", s.primary, s.secondary, func(store persistence.ShardStore) error {
+		return store.UpdateShard(ctx, request)
+	})
+}
+
+func (s *shardStore) AssertShardOwnership(
+	ctx context.Context,
+	request *persistence.AssertShardOwnershipRequest,
+) error {
+	return s.authoritative().AssertShardOwnership(ctx, request)
+}
@@ -0,0 +1,373 @@
+package dualwrite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/google/go-cmp/cmp"
+	commonpb "go.temporal.io/api/common/v1"
+	"go.temporal.io/api/serviceerror"
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common"
+	"go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/persistence/serialization"
+	"go.temporal.io/server/common/persistence/versionhistory"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// MaxReportedDivergences is the maximum number of divergences which are described per page of executions.
+	MaxReportedDivergences = 10
+
+	historyPageSize = 100
+)
+
+var (
+	// ErrExecutionsNotListable is returned when neither store supports listing the executions of a shard.
+	ErrExecutionsNotListable = errors.New("neither the primary nor the secondary store supports listing executions")
+
+	errShardNotCreated = errors.New("shard doesn't exist")
+)
+
+type (
+	// ShardVerifier compares the data of shards in the primary and the secondary store. A shard is in sync when its
+	// shard info, and the mutable state and current history branch of each of its executions, are the same in both
+	// stores. Executions are listed from the primary store, or from the secondary store if the primary store doesn't
+	// support listing executions, so executions which only exist in the other store are not detected.
+	ShardVerifier struct {
+		primaryShards       persistence.ShardStore
+		secondaryShards     persistence.ShardStore
+		primaryExecutions   persistence.ExecutionStore
+		secondaryExecutions persistence.ExecutionStore
+		serializer          serialization.Serializer
+		encryptor           *serialization.BlobEncryptor
+		compareOptions      cmp.Options
+	}
+
+	// ExecutionsVerification is the result of the verification of a page of the executions of a shard.
+	ExecutionsVerification struct {
+		// Verified is the number of executions which were verified.
+		Verified int
+		// Divergent is the number of executions which are different in the stores.
+		Divergent int
+		// Divergences describes up to MaxReportedDivergences of the divergent executions.
+		Divergences []string
+		// NextPageToken is the token of the next page, or empty if this was the last page.
+		NextPageToken []byte
+	}
+)
+
+// NewShardVerifier returns a new ShardVerifier for the stores.
+func NewShardVerifier(
+	primaryShards persistence.ShardStore,
+	secondaryShards persistence.ShardStore,
+	primaryExecutions persistence.ExecutionStore,
+	secondaryExecutions persistence.ExecutionStore,
+	serializer serialization.Serializer,
+	encryptor *serialization.BlobEncryptor,
+) *ShardVerifier {
+	return &ShardVerifier{
+		primaryShards:       primaryShards,
+		secondaryShards:     secondaryShards,
+		primaryExecutions:   primaryExecutions,
+		secondaryExecutions: secondaryExecutions,
+		serializer:          serializer,
+		encryptor:           encryptor,
+		compareOptions:      newCompareOptions(serializer),
+	}
+}
+
+// VerifyShardInfo compares the shard info of the shard in both stores. It returns a description of the difference,
+// or an empty string if the shard info is the same.
+func (v *ShardVerifier) VerifyShardInfo(ctx context.Context, shardID int32) (string, error) {
+	getShard := func(store persistence.ShardStore) (*commonpb.DataBlob, error) {
+		// stores may wrap the error of CreateShardInfo, so whether the shard exists is tracked separately
+		notCreated := false
+		resp, err := store.GetOrCreateShard(ctx, &persistence.InternalGetOrCreateShardRequest{
+			ShardID: shardID,
+			CreateShardInfo: func() (int64, *commonpb.DataBlob, error) {
+				notCreated = true
+				return 0, nil, errShardNotCreated
+			},
+			LifecycleContext: ctx,
+		})
+		if notCreated {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return resp.ShardInfo, nil
+	}
+
+	primaryShardInfo, err := getShard(v.primaryShards)
+	if err != nil {
+		return "", err
+	}
+	secondaryShardInfo, err := getShard(v.secondaryShards)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case primaryShardInfo == nil && secondaryShardInfo == nil:
+		return "", nil
+	case primaryShardInfo == nil:
+		return "shard only exists in the secondary store", nil
+	case secondaryShardInfo == nil:
+		return "shard only exists in the primary store", nil
+	}
+
+	primaryInfo, err := v.serializer.ShardInfoFromBlob(primaryShardInfo)
+	if err != nil {
+		return "", err
+	}
+	secondaryInfo, err := v.serializer.ShardInfoFromBlob(secondaryShardInfo)
+	if err != nil {
+		return "", err
+	}
+	if diff := cmp.Diff(primaryInfo, secondaryInfo, v.compareOptions); diff != "" {
+		return "shard info is different: " + diff, nil
+	}
+	return "", nil
+}
+
+// VerifyExecutions compares a page of the executions of the shard in both stores. It returns
+// ErrExecutionsNotListable if neither store supports listing executions.
+func (v *ShardVerifier) VerifyExecutions(
+	ctx context.Context,
+	shardID int32,
+	pageSize int,
+	pageToken []byte,
+) (*ExecutionsVerification, error) {
+	resp, err := v.listExecutions(ctx, &persistence.ListConcreteExecutionsRequest{
+		ShardID:   shardID,
+		PageSize:  pageSize,
+		PageToken: pageToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ExecutionsVerification{
+		NextPageToken: resp.NextPageToken,
+	}
+	for _, state := range resp.States {
+		divergence, err := v.verifyExecution(ctx, shardID, state)
+		if err != nil {
+			return nil, err
+		}
+		result.Verified++
+		if divergence == "" {
+			continue
+		}
+		result.Divergent++
+		if len(result.Divergences) < MaxReportedDivergences {
+			result.Divergences = append(result.Divergences, divergence)
+		}
+	}
+	return result, nil
+}
+
+func (v *ShardVerifier) listExecutions(
+	ctx context.Context,
+	request *persistence.ListConcreteExecutionsRequest,
+) (*persistence.InternalListConcreteExecutionsResponse, error) {
+	var unimplemented *serviceerror.Unimplemented
+	resp, err := v.primaryExecutions.ListConcreteExecutions(ctx, request)
+	if !errors.As(err, &unimplemented) {
+		return resp, err
+	}
+	resp, err = v.secondaryExecutions.ListConcreteExecutions(ctx, request)
+	if errors.As(err, &unimplemented) {
+		return nil, ErrExecutionsNotListable
+	}
+	return resp, err
+}
+
+// verifyExecution compares an execution in both stores. It returns a description of the difference, or an empty
+// string if the execution is the same.
+func (v *ShardVerifier) verifyExecution(
+	ctx context.Context,
+	shardID int32,
+	state *persistence.InternalWorkflowMutableState,
+) (string, error) {
+	executionInfo := &persistencespb.WorkflowExecutionInfo{}
+	blobContext := serialization.MutableStateBlobContext(
+		state.NamespaceID, state.WorkflowID, state.RunID, serialization.ExecutionInfoBlobField,
+	)
+	if err := v.decode(blobContext, state.ExecutionInfo, executionInfo); err != nil {
+		return "", err
+	}
+	executionState := &persistencespb.WorkflowExecutionState{}
+	if err := serialization.Decode(state.ExecutionState, executionState); err != nil {
+		return "", err
+	}
+	execution := fmt.Sprintf("execution %s/%s/%s", executionInfo.GetNamespaceId(), executionInfo.GetWorkflowId(), executionState.GetRunId())
+
+	request := &persistence.GetWorkflowExecutionRequest{
+		ShardID:     shardID,
+		NamespaceID: executionInfo.GetNamespaceId(),
+		WorkflowID:  executionInfo.GetWorkflowId(),
+		RunID:       executionState.GetRunId(),
+	}
+	primaryResp, primaryErr := v.primaryExecutions.GetWorkflowExecution(ctx, request)
+	secondaryResp, secondaryErr := v.secondaryExecutions.GetWorkflowExecution(ctx, request)
+	if divergence, err := v.compareResults(primaryErr, secondaryErr); divergence != "" || err != nil {
+		return execution + " " + divergence, err
+	}
+	if primaryErr != nil {
+		// the execution was deleted from both stores since it was listed
+		return "", nil
+	}
+	if diff := cmp.Diff(primaryResp, secondaryResp, v.compareOptions); diff != "" {
+		return execution + " mutable state is different: " + diff, nil
+	}
+
+	divergence, err := v.verifyHistory(ctx, shardID, executionInfo)
+	if divergence != "" {
+		divergence = execution + " " + divergence
+	}
+	return divergence, err
+}
+
+// verifyHistory compares the nodes of the current history branch of the execution in both stores, including the
+// nodes the branch shares with its ancestors.
+func (v *ShardVerifier) verifyHistory(
+	ctx context.Context,
+	shardID int32,
+	executionInfo *persistencespb.WorkflowExecutionInfo,
+) (string, error) {
+	if executionInfo.GetVersionHistories() == nil {
+		// executions without events, like CHASM executions, don't have history
+		return "", nil
+	}
+	currentVersionHistory, err := versionhistory.GetCurrentVersionHistory(executionInfo.GetVersionHistories())
+	if err != nil {
+		return "", err
+	}
+	branchToken := currentVersionHistory.GetBranchToken()
+	branch, err := v.primaryExecutions.GetHistoryBranchUtil().ParseHistoryBranchInfo(branchToken)
+	if err != nil {
+		return "", err
+	}
+
+	// merge the branch into its ancestors, as ReadHistoryBranch does, so that every range is read from the branch
+	// which owns its nodes
+	beginNodeID := common.FirstEventID
+	if len(branch.Ancestors) > 0 {
+		beginNodeID = branch.Ancestors[len(branch.Ancestors)-1].GetEndNodeId()
+	}
+	branchRanges := append(slices.Clone(branch.Ancestors), &persistencespb.HistoryBranchRange{
+		BranchId:    branch.GetBranchId(),
+		BeginNodeId: beginNodeID,
+		EndNodeId:   math.MaxInt64,
+	})
+
+	request := &persistence.InternalReadHistoryBranchRequest{
+		BranchToken: branchToken,
+		PageSize:    historyPageSize,
+		ShardID:     shardID,
+	}
+	primaryNodes := newHistoryNodeIterator(v.primaryExecutions, branchRanges, request)
+	secondaryNodes := newHistoryNodeIterator(v.secondaryExecutions, branchRanges, request)
+	for {
+		primaryNode, primaryErr := primaryNodes.next(ctx)
+		secondaryNode, secondaryErr := secondaryNodes.next(ctx)
+		if divergence, err := v.compareResults(primaryErr, secondaryErr); divergence != "" || err != nil {
+			return "history " + divergence, err
+		}
+		if primaryErr != nil {
+			// history is deleted from both stores
+			return "", nil
+		}
+		if primaryNode == nil && secondaryNode == nil {
+			return "", nil
+		}
+		if diff := cmp.Diff(primaryNode, secondaryNode, v.compareOptions); diff != "" {
+			return "history is different: " + diff, nil
+		}
+	}
+}
+
+// compareResults returns a description of the difference of the errors of reads from both stores, or the error if
+// both reads failed with the same error other than NotFound.
+func (v *ShardVerifier) compareResults(primaryErr error, secondaryErr error) (string, error) {
+	var notFound *serviceerror.NotFound
+	primaryNotFound := errors.As(primaryErr, &notFound)
+	secondaryNotFound := errors.As(secondaryErr, &notFound)
+	switch {
+	case primaryErr != nil && !primaryNotFound:
+		return "", primaryErr
+	case secondaryErr != nil && !secondaryNotFound:
+		return "", secondaryErr
+	case primaryNotFound && !secondaryNotFound:
+		return "only exists in the secondary store", nil
+	case !primaryNotFound && secondaryNotFound:
+		return "only exists in the primary store", nil
+	}
+	return "", nil
+}
+
+func (v *ShardVerifier) decode(
+	blobContext serialization.BlobContext,
+	blob *commonpb.DataBlob,
+	result proto.Message,
+) error {
+	blob, err := v.encryptor.Decrypt(blobContext, blob)
+	if err != nil {
+		return err
+	}
+	return serialization.Decode(blob, result)
+}
+
+type historyNodeIterator struct {
+	store   persistence.ExecutionStore
+	ranges  []*persistencespb.HistoryBranchRange
+	request persistence.InternalReadHistoryBranchRequest
+	nodes   []persistence.InternalHistoryNode
+	done    bool
+}
+
+func newHistoryNodeIterator(
+	store persistence.ExecutionStore,
+	ranges []*persistencespb.HistoryBranchRange,
+	request *persistence.InternalReadHistoryBranchRequest,
+) *historyNodeIterator {
+	return &historyNodeIterator{
+		store:   store,
+		ranges:  ranges,
+		request: *request,
+		done:    true,
+	}
+}
+
+// next returns the next history node, or nil after the last node of the last range.
+func (i *historyNodeIterator) next(ctx context.Context) (*persistence.InternalHistoryNode, error) {
+	for len(i.nodes) == 0 {
+		if i.done {
+			if len(i.ranges) == 0 {
+				return nil, nil
+			}
+			branchRange := i.ranges[0]
+			i.ranges = i.ranges[1:]
+			i.request.BranchID = branchRange.GetBranchId()
+			i.request.MinNodeID = branchRange.GetBeginNodeId()
+			i.request.MaxNodeID = branchRange.GetEndNodeId()
+			i.request.NextPageToken = nil
+			i.done = false
+		}
+		resp, err := i.store.ReadHistoryBranch(ctx, &i.request)
+		if err != nil {
+			return nil, err
+		}
+		i.nodes = resp.Nodes
+		i.request.NextPageToken = resp.NextPageToken
+		i.done = len(resp.NextPageToken) == 0
+	}
+	node := i.nodes[0]
+	i.nodes = i.nodes[1:]
+	return &node, nil
+}
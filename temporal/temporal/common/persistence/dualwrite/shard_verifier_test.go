@@ -0,0 +1,239 @@
+package dualwrite
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	historyspb "go.temporal.io/server/api/history/v1"
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/persistence/mock"
+	"go.temporal.io/server/common/persistence/serialization"
+	"go.uber.org/mock/gomock"
+)
+
+type testVerifier struct {
+	serializer          serialization.Serializer
+	primaryShards       *mock.MockShardStore
+	secondaryShards     *mock.MockShardStore
+	primaryExecutions   *mock.MockExecutionStore
+	secondaryExecutions *mock.MockExecutionStore
+	verifier            *ShardVerifier
+}
+
+func newTestVerifier(t *testing.T) *testVerifier {
+	ctrl := gomock.NewController(t)
+	v := &testVerifier{
+		serializer:          serialization.NewSerializer(),
+		primaryShards:       mock.NewMockShardStore(ctrl),
+		secondaryShards:     mock.NewMockShardStore(ctrl),
+		primaryExecutions:   mock.NewMockExecutionStore(ctrl),
+		secondaryExecutions: mock.NewMockExecutionStore(ctrl),
+	}
+	v.verifier = NewShardVerifier(v.primaryShards, v.secondaryShards, v.primaryExecutions, v.secondaryExecutions, v.serializer, nil)
+	return v
+}
+
+func (v *testVerifier) shardInfo(t *testing.T, rangeID int64) *commonpb.DataBlob {
+	blob, err := v.serializer.ShardInfoToBlob(&persistencespb.ShardInfo{ShardId: 1, RangeId: rangeID})
+	require.NoError(t, err)
+	return blob
+}
+
+func (v *testVerifier) mutableState(t *testing.T, info *persistencespb.WorkflowExecutionInfo) *persistence.InternalWorkflowMutableState {
+	infoBlob, err := v.serializer.WorkflowExecutionInfoToBlob(info)
+	require.NoError(t, err)
+	stateBlob, err := v.serializer.WorkflowExecutionStateToBlob(&persistencespb.WorkflowExecutionState{RunId: "run-id"})
+	require.NoError(t, err)
+	return &persistence.InternalWorkflowMutableState{
+		ExecutionInfo:  infoBlob,
+		ExecutionState: stateBlob,
+		NextEventID:    3,
+	}
+}
+
+func TestShardVerifier_VerifyShardInfo(t *testing.T) {
+	t.Parallel()
+	v := newTestVerifier(t)
+
+	v.primaryShards.EXPECT().GetOrCreateShard(gomock.Any(), gomock.Any()).Return(&persistence.InternalGetOrCreateShardResponse{ShardInfo: v.shardInfo(t, 5)}, nil)
+	v.secondaryShards.EXPECT().GetOrCreateShard(gomock.Any(), gomock.Any()).Return(&persistence.InternalGetOrCreateShardResponse{ShardInfo: v.shardInfo(t, 5)}, nil)
+	divergence, err := v.verifier.VerifyShardInfo(context.Background(), 1)
+	require.NoError(t, err)
+	require.Empty(t, divergence)
+
+	v.primaryShards.EXPECT().GetOrCreateShard(gomock.Any(), gomock.Any()).Return(&persistence.InternalGetOrCreateShardResponse{ShardInfo: v.shardInfo(t, 5)}, nil)
+	v.secondaryShards.EXPECT().GetOrCreateShard(gomock.Any(), gomock.Any()).Return(&persistence.InternalGetOrCreateShardResponse{ShardInfo: v.shardInfo(t, 4)}, nil)
+	divergence, err = v.verifier.VerifyShardInfo(context.Background(), 1)
+	require.NoError(t, err)
+	require.Contains(t, divergence, "shard info is different")
+
+	// the shard is not created in a store which doesn't have it, and stores may wrap the error of CreateShardInfo
+	v.primaryShards.EXPECT().GetOrCreateShard(gomock.Any(), gomock.Any()).Return(&persistence.InternalGetOrCreateShardResponse{ShardInfo: v.shardInfo(t, 5)}, nil)
+	v.secondaryShards.EXPECT().GetOrCreateShard(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *persistence.InternalGetOrCreateShardRequest) (*persistence.InternalGetOrCreateShardResponse, error) {
+			_, _, err := request.CreateShardInfo()
+			return nil, serviceerror.NewUnavailablef("GetOrCreateShard: failed to create shard info: %v", err)
+		},
+	)
+	divergence, err = v.verifier.VerifyShardInfo(context.Background(), 1)
+	require.NoError(t, err)
+	require.Equal(t, "shard only exists in the primary store", divergence)
+}
+
+func TestShardVerifier_VerifyExecutions(t *testing.T) {
+	t.Parallel()
+	v := newTestVerifier(t)
+
+	inSync := v.mutableState(t, &persistencespb.WorkflowExecutionInfo{NamespaceId: "namespace-id", WorkflowId: "in-sync"})
+	divergent := v.mutableState(t, &persistencespb.WorkflowExecutionInfo{NamespaceId: "namespace-id", WorkflowId: "divergent"})
+	deleted := v.mutableState(t, &persistencespb.WorkflowExecutionInfo{NamespaceId: "namespace-id", WorkflowId: "deleted"})
+
+	// executions are listed from the secondary store if the primary store can't list them
+	v.primaryExecutions.EXPECT().ListConcreteExecutions(gomock.Any(), gomock.Any()).Return(nil, serviceerror.NewUnimplemented("unimplemented"))
+	v.secondaryExecutions.EXPECT().ListConcreteExecutions(gomock.Any(), gomock.Any()).Return(&persistence.InternalListConcreteExecutionsResponse{
+		States:        []*persistence.InternalWorkflowMutableState{inSync, divergent, deleted},
+		NextPageToken: []byte("next"),
+	}, nil)
+	getExecution := func(store *mock.MockExecutionStore, workflowID string, state *persistence.InternalWorkflowMutableState, err error) {
+		store.EXPECT().GetWorkflowExecution(gomock.Any(), &persistence.GetWorkflowExecutionRequest{
+			ShardID:     1,
+			NamespaceID: "namespace-id",
+			WorkflowID:  workflowID,
+			RunID:       "run-id",
+		}).Return(&persistence.InternalGetWorkflowExecutionResponse{State: state, DBRecordVersion: 1}, err)
+	}
+	getExecution(v.primaryExecutions, "in-sync", inSync, nil)
+	getExecution(v.secondaryExecutions, "in-sync", inSync, nil)
+	getExecution(v.primaryExecutions, "divergent", divergent, nil)
+	getExecution(v.secondaryExecutions, "divergent", &persistence.InternalWorkflowMutableState{
+		ExecutionInfo:  divergent.ExecutionInfo,
+		ExecutionState: divergent.ExecutionState,
+		NextEventID:    4,
+	}, nil)
+	getExecution(v.primaryExecutions, "deleted", deleted, nil)
+	getExecution(v.secondaryExecutions, "deleted", nil, serviceerror.NewNotFound("not found"))
+
+	result, err := v.verifier.VerifyExecutions(context.Background(), 1, 10, nil)
+	require.NoError(t, err)
+	require.Equal(t, 3, result.Verified)
+	require.Equal(t, 2, result.Divergent)
+	require.Len(t, result.Divergences, 2)
+	require.Contains(t, result.Divergences[0], "namespace-id/divergent/run-id mutable state is different")
+	require.Contains(t, result.Divergences[1], "namespace-id/deleted/run-id only exists in the primary store")
+	require.Equal(t, []byte("next"), result.NextPageToken)
+
+	v.primaryExecutions.EXPECT().ListConcreteExecutions(gomock.Any(), gomock.Any()).Return(nil, serviceerror.NewUnimplemented("unimplemented"))
+	v.secondaryExecutions.EXPECT().ListConcreteExecutions(gomock.Any(), gomock.Any()).Return(nil, serviceerror.NewUnimplemented("unimplemented"))
+	_, err = v.verifier.VerifyExecutions(context.Background(), 1, 10, nil)
+	require.ErrorIs(t, err, ErrExecutionsNotListable)
+}
+
+func TestShardVerifier_VerifyHistory(t *testing.T) {
+	t.Parallel()
+	v := newTestVerifier(t)
+
+	branchUtil := persistence.NewHistoryBranchUtil(v.serializer)
+	branchToken, err := branchUtil.NewHistoryBranch("namespace-id", "workflow-id", "run-id", "tree-id", nil, nil, time.Hour, time.Hour, time.Hour)
+	require.NoError(t, err)
+	state := v.mutableState(t, &persistencespb.WorkflowExecutionInfo{
+		NamespaceId: "namespace-id",
+		WorkflowId:  "workflow-id",
+		VersionHistories: &historyspb.VersionHistories{
+			Histories: []*historyspb.VersionHistory{{BranchToken: branchToken}},
+		},
+	})
+
+	v.primaryExecutions.EXPECT().ListConcreteExecutions(gomock.Any(), gomock.Any()).Return(&persistence.InternalListConcreteExecutionsResponse{
+		States: []*persistence.InternalWorkflowMutableState{state},
+	}, nil)
+	v.primaryExecutions.EXPECT().GetWorkflowExecution(gomock.Any(), gomock.Any()).Return(&persistence.InternalGetWorkflowExecutionResponse{State: state}, nil)
+	v.secondaryExecutions.EXPECT().GetWorkflowExecution(gomock.Any(), gomock.Any()).Return(&persistence.InternalGetWorkflowExecutionResponse{State: state}, nil)
+	v.primaryExecutions.EXPECT().GetHistoryBranchUtil().Return(branchUtil)
+
+	node := func(nodeID int64) persistence.InternalHistoryNode {
+		return persistence.InternalHistoryNode{
+			NodeID: nodeID,
+			Events: &commonpb.DataBlob{EncodingType: enumspb.ENCODING_TYPE_PROTO3, Data: []byte{byte(nodeID)}},
+		}
+	}
+	// the stores are compared node by node, regardless of their page sizes
+	v.primaryExecutions.EXPECT().ReadHistoryBranch(gomock.Any(), gomock.Any()).Return(&persistence.InternalReadHistoryBranchResponse{
+		Nodes: []persistence.InternalHistoryNode{node(1), node(3)},
+	}, nil)
+	gomock.InOrder(
+		v.secondaryExecutions.EXPECT().ReadHistoryBranch(gomock.Any(), gomock.Any()).Return(&persistence.InternalReadHistoryBranchResponse{
+			Nodes:         []persistence.InternalHistoryNode{node(1)},
+			NextPageToken: []byte("next"),
+		}, nil),
+		v.secondaryExecutions.EXPECT().ReadHistoryBranch(gomock.Any(), gomock.Any()).Return(&persistence.InternalReadHistoryBranchResponse{
+			Nodes: []persistence.InternalHistoryNode{node(3), node(5)},
+		}, nil),
+	)
+
+	result, err := v.verifier.VerifyExecutions(context.Background(), 1, 10, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Divergent)
+	require.Contains(t, result.Divergences[0], "history is different")
+}
+
+func TestShardVerifier_VerifyHistory_Ancestors(t *testing.T) {
+	t.Parallel()
+	v := newTestVerifier(t)
+
+	branchUtil := persistence.NewHistoryBranchUtil(v.serializer)
+	branchID := "branch-id"
+	ancestors := []*persistencespb.HistoryBranchRange{{BranchId: "ancestor-id", BeginNodeId: 1, EndNodeId: 3}}
+	branchToken, err := branchUtil.NewHistoryBranch("namespace-id", "workflow-id", "run-id", "tree-id", &branchID, ancestors, time.Hour, time.Hour, time.Hour)
+	require.NoError(t, err)
+	state := v.mutableState(t, &persistencespb.WorkflowExecutionInfo{
+		NamespaceId: "namespace-id",
+		WorkflowId:  "workflow-id",
+		VersionHistories: &historyspb.VersionHistories{
+			Histories: []*historyspb.VersionHistory{{BranchToken: branchToken}},
+		},
+	})
+
+	v.primaryExecutions.EXPECT().ListConcreteExecutions(gomock.Any(), gomock.Any()).Return(&persistence.InternalListConcreteExecutionsResponse{
+		States: []*persistence.InternalWorkflowMutableState{state},
+	}, nil)
+	v.primaryExecutions.EXPECT().GetWorkflowExecution(gomock.Any(), gomock.Any()).Return(&persistence.InternalGetWorkflowExecutionResponse{State: state}, nil)
+	v.secondaryExecutions.EXPECT().GetWorkflowExecution(gomock.Any(), gomock.Any()).Return(&persistence.InternalGetWorkflowExecutionResponse{State: state}, nil)
+	v.primaryExecutions.EXPECT().GetHistoryBranchUtil().Return(branchUtil)
+
+	node := func(nodeID int64, data byte) persistence.InternalHistoryNode {
+		return persistence.InternalHistoryNode{
+			NodeID: nodeID,
+			Events: &commonpb.DataBlob{EncodingType: enumspb.ENCODING_TYPE_PROTO3, Data: []byte{data}},
+		}
+	}
+	readBranch := func(store *mock.MockExecutionStore, branchID string, minNodeID int64, maxNodeID int64, nodes ...persistence.InternalHistoryNode) *gomock.Call {
+		return store.EXPECT().ReadHistoryBranch(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, request *persistence.InternalReadHistoryBranchRequest) (*persistence.InternalReadHistoryBranchResponse, error) {
+				require.Equal(t, branchID, request.BranchID)
+				require.Equal(t, minNodeID, request.MinNodeID)
+				require.Equal(t, maxNodeID, request.MaxNodeID)
+				return &persistence.InternalReadHistoryBranchResponse{Nodes: nodes}, nil
+			})
+	}
+	// the nodes before the fork are only stored in the ancestor branch
+	gomock.InOrder(
+		readBranch(v.primaryExecutions, "ancestor-id", 1, 3, node(1, 1)),
+		readBranch(v.primaryExecutions, branchID, 3, math.MaxInt64, node(3, 3)),
+	)
+	gomock.InOrder(
+		readBranch(v.secondaryExecutions, "ancestor-id", 1, 3, node(1, 1)),
+		readBranch(v.secondaryExecutions, branchID, 3, math.MaxInt64, node(3, 4)),
+	)
+
+	result, err := v.verifier.VerifyExecutions(context.Background(), 1, 10, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Divergent)
+	require.Contains(t, result.Divergences[0], "history is different")
+}
@@ -0,0 +1,140 @@
+package dualwrite
+
+import (
+	"context"
+
+	"go.temporal.io/server/common/persistence"
+)
+
+type (
+	// taskStore is a persistence.TaskStore which dual-writes task queues, tasks and task queue user data. Paginated
+	// reads are only made from the authoritative store.
+	taskStore struct {
+		*dualWriter
+		primary   persistence.TaskStore
+		secondary persistence.TaskStore
+	}
+)
+
+var _ persistence.TaskStore = (*taskStore)(nil)
+
+func (s *taskStore) authoritative() persistence.TaskStore {
+	authoritative, _, _ := selectStores(s.dualWriter, s.primary, s.secondary)
+	return authoritative
+}
+
+func (s *taskStore) Close() {
+	s.primary.Close()
+	s.secondary.Close()
+}
+
+func (s *taskStore) GetName() string {
+	return s.authoritative().GetName()
+}
+
+func (s *taskStore) CreateTaskQueue(
+	ctx context.Context,
+	request *persistence.InternalCreateTaskQueueRequest,
+) error {
+	return write(s.dualWriter, "CreateTaskQueue", s.primary, s.secondary, func(store persistence.TaskStore) error {
+		return store.CreateTaskQueue(ctx, request)
+	})
+}
+
+func (s *taskStore) GetTaskQueue(
+	ctx context.Context,
+	request *persistence.InternalGetTaskQueueRequest,
+) (*persistence.InternalGetTaskQueueResponse, error) {
+	return read(s.dualWriter, "GetTaskQueue", s.primary, s.secondary, func(store persistence.TaskStore) (*persistence.InternalGetTaskQueueResponse, error) {
+		return store.GetTaskQueue(ctx, request)
+	})
+}
+
+func (s *taskStore) UpdateTaskQueue(
+	ctx context.Context,
+	request *persistence.InternalUpdateTaskQueueRequest,
+) (*persistence.UpdateTaskQueueResponse, error) {
+	return writeWithResponse(s.dualWriter, "UpdateTaskQueue", s.primary, s.secondary, func(store persistence.TaskStore) (*persistence.UpdateTaskQueueResponse, error) {
+		return store.UpdateTaskQueue(ctx, request)
+	})
+}
+
+func (s *taskStore) ListTaskQueue(
+	ctx context.Context,
+	request *persistence.ListTaskQueueRequest,
+) (*persistence.InternalListTaskQueueResponse, error) {
+	return s.authoritative().ListTaskQueue(ctx, request)
+}
+
+func (s *taskStore) DeleteTaskQueue(
+	ctx context.Context,
+	request *persistence.DeleteTaskQueueRequest,
+) error {
+	return write(s.dualWriter, "DeleteTaskQueue", s.primary, s.secondary, func(store persistence.TaskStore) error {
+		return store.DeleteTaskQueue(ctx, request)
+	})
+}
+
+func (s *taskStore) CreateTasks(
+	ctx context.Context,
+	request *persistence.InternalCreateTasksRequest,
+) (*persistence.CreateTasksResponse, error) {
+	return writeWithResponse(s.dualWriter, "CreateTasks", s.primary, s.secondary, func(store persistence.TaskStore) (*persistence.CreateTasksResponse, error) {
+		return store.CreateTasks(ctx, request)
+	})
+}
+
+func (s *taskStore) GetTasks(
+	ctx context.Context,
+	request *persistence.GetTasksRequest,
+) (*persistence.InternalGetTasksResponse, error) {
+	return s.authoritative().GetTasks(ctx, request)
+}
+
+func (s *taskStore) CompleteTasksLessThan(
+	ctx context.Context,
+	request *persistence.CompleteTasksLessThanRequest,
+) (int, error) {
+	return writeWithResponse(s.dualWriter, "CompleteTasksLessThan", s.primary, s.secondary, func(store persistence.TaskStore) (int, error) {
+		return store.CompleteTasksLessThan(ctx, request)
+	})
+}
+
+func (s *taskStore) GetTaskQueueUserData(
+	ctx context.Context,
+	request *persistence.GetTaskQueueUserDataRequest,
+) (*persistence.InternalGetTaskQueueUserDataResponse, error) {
+	return read(s.dualWriter, "GetTaskQueueUserData", s.primary, s.secondary, func(store persistence.TaskStore) (*persistence.InternalGetTaskQueueUserDataResponse, error) {
+		return store.GetTaskQueueUserData(ctx, request)
+	})
+}
+
+func (s *taskStore) UpdateTaskQueueUserData(
+	ctx context.Context,
+	request *persistence.InternalUpdateTaskQueueUserDataRequest,
+) error {
+	return write(s.dualWriter, "UpdateTaskQueueUserData", s.primary, s.secondary, func(store persistence.TaskStore) error {
+		return store.UpdateTaskQueueUserData(ctx, request)
+	})
+}
+
+func (s *taskStore) ListTaskQueueUserDataEntries(
+	ctx context.Context,
+	request *persistence.ListTaskQueueUserDataEntriesRequest,
+) (*persistence.InternalListTaskQueueUserDataEntriesResponse, error) {
+	return s.authoritative().ListTaskQueueUserDataEntries(ctx, request)
+}
+
+func (s *taskStore) GetTaskQueuesByBuildId(
+	ctx context.Context,
+	request *persistence.GetTaskQueuesByBuildIdRequest,
+) ([]string, error) {
+	return s.authoritative().GetTaskQueuesByBuildId(ctx, request)
+}
+
+func (s *taskStore) CountTaskQueuesByBuildId(
+	ctx context.Context,
+	request *persistence.CountTaskQueuesByBuildIdRequest,
+) (int, error) {
+	return s.authoritative().CountTaskQueuesByBuildId(ctx, request)
+}
@@ -3,6 +3,7 @@ package persistence
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 
 	commonpb "go.temporal.io/api/common/v1"
@@ -34,6 +35,8 @@ type (
 		pagingTokenSerializer                       *jsonHistoryTokenSerializer
 		transactionSizeLimit                        dynamicconfig.IntPropertyFn
 		enableBestEffortDeleteTasksOnWorkflowUpdate dynamicconfig.BoolPropertyFn
+		blobCompressor                              *serialization.BlobCompressor
+		blobEncryptor                               *serialization.BlobEncryptor
 	}
 )
 
@@ -47,6 +50,8 @@ func NewExecutionManager(
 	logger log.Logger,
 	transactionSizeLimit dynamicconfig.IntPropertyFn,
 	enableBestEffortDeleteTasksOnWorkflowUpdate dynamicconfig.BoolPropertyFn,
+	blobCompressor *serialization.BlobCompressor,
+	blobEncryptor *serialization.BlobEncryptor,
 ) ExecutionManager {
 	return &executionManagerImpl{
 		serializer:            serializer,
@@ -56,6 +61,8 @@ func NewExecutionManager(
 		pagingTokenSerializer: newJSONHistoryTokenSerializer(),
 		transactionSizeLimit:  transactionSizeLimit,
 		enableBestEffortDeleteTasksOnWorkflowUpdate: enableBestEffortDeleteTasksOnWorkflowUpdate,
+		blobCompressor: blobCompressor,
+		blobEncryptor:  blobEncryptor,
 	}
 }
 
@@ -441,7 +448,7 @@ func (m *executionManagerImpl) GetWorkflowExecution(
 		// try to utilize resp as much as possible, for RebuildMutableState API
 		return nil, respErr
 	}
-	state, err := m.toWorkflowMutableState(response.State)
+	state, err := m.toWorkflowMutableState(request.NamespaceID, request.WorkflowID, request.RunID, response.State)
 	if err != nil {
 		return nil, err
 	}
@@ -525,6 +532,16 @@ func (m *executionManagerImpl) serializeWorkflowEventBatches(
 		workflowNewEvents = append(workflowNewEvents, newEvents)
 		historyStatistics.SizeDiff += len(newEvents.Node.Events.Data)
 		historyStatistics.CountDiff += len(workflowEvents.Events)
+
+		// History size and the XDC cache are based on the plaintext events.
+		newEvents.Node.Events, err = m.encodeBlob(
+			historyNodeBlobContext(workflowEvents.NamespaceID, newEvents),
+			serialization.BlobCategoryHistory,
+			newEvents.Node.Events,
+		)
+		if err != nil {
+			return nil, nil, nil, err
+		}
 	}
 	return xdcKVs, workflowNewEvents, &historyStatistics, nil
 }
@@ -571,6 +588,7 @@ func (m *executionManagerImpl) serializeWorkflowEvents(
 
 	request := &AppendHistoryNodesRequest{
 		ShardID:           shardID,
+		NamespaceID:       workflowEvents.NamespaceID,
 		BranchToken:       workflowEvents.BranchToken,
 		Events:            workflowEvents.Events,
 		PrevTransactionID: workflowEvents.PrevTxnID,
@@ -693,6 +711,30 @@ func (m *executionManagerImpl) SerializeWorkflowMutation( // unexport
 		if err != nil {
 			return nil, err
 		}
+		result.NewBufferedEvents, err = m.encodeBlob(
+			serialization.MutableStateBlobContext(
+				result.NamespaceID, result.WorkflowID, result.RunID, serialization.BufferedEventsBlobField,
+			),
+			serialization.BlobCategoryHistory,
+			result.NewBufferedEvents,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := m.encodeMutableStateBlobs(
+		result.NamespaceID,
+		result.WorkflowID,
+		result.RunID,
+		&result.ExecutionInfoBlob,
+		result.UpsertActivityInfos,
+		result.UpsertTimerInfos,
+		result.UpsertChildExecutionInfos,
+		result.UpsertRequestCancelInfos,
+		result.UpsertSignalInfos,
+	); err != nil {
+		return nil, err
 	}
 
 	result.LastWriteVersion, err = getCurrentBranchLastWriteVersion(input.ExecutionInfo.VersionHistories, input.ExecutionInfo.TransitionHistory)
@@ -795,6 +837,20 @@ func (m *executionManagerImpl) SerializeWorkflowSnapshot( // unexport
 	}
 	result.ChasmNodes = nodeMap
 
+	if err := m.encodeMutableStateBlobs(
+		result.NamespaceID,
+		result.WorkflowID,
+		result.RunID,
+		&result.ExecutionInfoBlob,
+		result.ActivityInfos,
+		result.TimerInfos,
+		result.ChildExecutionInfos,
+		result.RequestCancelInfos,
+		result.SignalInfos,
+	); err != nil {
+		return nil, err
+	}
+
 	result.Checksum, err = m.serializer.ChecksumToBlob(input.Checksum)
 	if err != nil {
 		return nil, err
@@ -803,6 +859,133 @@ func (m *executionManagerImpl) SerializeWorkflowSnapshot( // unexport
 	return result, nil
 }
 
+// encodeBlob compresses and then encrypts a blob, if compression of the blob category or encryption is enabled for
+// the namespace of the blob context.
+func (m *executionManagerImpl) encodeBlob(
+	blobContext serialization.BlobContext,
+	category serialization.BlobCategory,
+	blob *commonpb.DataBlob,
+) (*commonpb.DataBlob, error) {
+	blob, err := m.blobCompressor.Compress(blobContext.NamespaceID, category, blob)
+	if err != nil {
+		return nil, err
+	}
+	return m.blobEncryptor.Encrypt(blobContext, blob)
+}
+
+// encodeMutableStateBlobs compresses and encrypts the execution info and pending info blobs of a workflow in place,
+// if mutable state compression or encryption is enabled for its namespace.
+func (m *executionManagerImpl) encodeMutableStateBlobs(
+	namespaceID string,
+	workflowID string,
+	runID string,
+	executionInfoBlob **commonpb.DataBlob,
+	activityInfos map[int64]*commonpb.DataBlob,
+	timerInfos map[string]*commonpb.DataBlob,
+	childExecutionInfos map[int64]*commonpb.DataBlob,
+	requestCancelInfos map[int64]*commonpb.DataBlob,
+	signalInfos map[int64]*commonpb.DataBlob,
+) error {
+	if !m.blobCompressor.Enabled(namespaceID, serialization.BlobCategoryMutableState) &&
+		!m.blobEncryptor.Enabled(namespaceID) {
+		return nil
+	}
+
+	var err error
+	encode := func(field string, blob *commonpb.DataBlob) *commonpb.DataBlob {
+		if err != nil {
+			return blob
+		}
+		var encoded *commonpb.DataBlob
+		encoded, err = m.encodeBlob(
+			serialization.MutableStateBlobContext(namespaceID, workflowID, runID, field),
+			serialization.BlobCategoryMutableState,
+			blob,
+		)
+		if err != nil {
+			return blob
+		}
+		return encoded
+	}
+	*executionInfoBlob = encode(serialization.ExecutionInfoBlobField, *executionInfoBlob)
+	for key, blob := range activityInfos {
+		activityInfos[key] = encode(mutableStateMapBlobField(serialization.ActivityInfoBlobField, key), blob)
+	}
+	for key, blob := range timerInfos {
+		timerInfos[key] = encode(mutableStateMapBlobField(serialization.TimerInfoBlobField, key), blob)
+	}
+	for key, blob := range childExecutionInfos {
+		childExecutionInfos[key] = encode(mutableStateMapBlobField(serialization.ChildExecutionInfoBlobField, key), blob)
+	}
+	for key, blob := range requestCancelInfos {
+		requestCancelInfos[key] = encode(mutableStateMapBlobField(serialization.RequestCancelInfoBlobField, key), blob)
+	}
+	for key, blob := range signalInfos {
+		signalInfos[key] = encode(mutableStateMapBlobField(serialization.SignalInfoBlobField, key), blob)
+	}
+	return err
+}
+
+// decryptMutableStateBlobs decrypts the encrypted blobs of a workflow mutable state in place.
+func (m *executionManagerImpl) decryptMutableStateBlobs(
+	namespaceID string,
+	workflowID string,
+	runID string,
+	internState *InternalWorkflowMutableState,
+) error {
+	var err error
+	decrypt := func(field string, blob *commonpb.DataBlob) *commonpb.DataBlob {
+		if err != nil || !serialization.IsEncrypted(blob) {
+			return blob
+		}
+		var decrypted *commonpb.DataBlob
+		decrypted, err = m.blobEncryptor.Decrypt(
+			serialization.MutableStateBlobContext(namespaceID, workflowID, runID, field),
+			blob,
+		)
+		if err != nil {
+			return blob
+		}
+		return decrypted
+	}
+	internState.ExecutionInfo = decrypt(serialization.ExecutionInfoBlobField, internState.ExecutionInfo)
+	for key, blob := range internState.ActivityInfos {
+		internState.ActivityInfos[key] = decrypt(mutableStateMapBlobField(serialization.ActivityInfoBlobField, key), blob)
+	}
+	for key, blob := range internState.TimerInfos {
+		internState.TimerInfos[key] = decrypt(mutableStateMapBlobField(serialization.TimerInfoBlobField, key), blob)
+	}
+	for key, blob := range internState.ChildExecutionInfos {
+		internState.ChildExecutionInfos[key] = decrypt(mutableStateMapBlobField(serialization.ChildExecutionInfoBlobField, key), blob)
+	}
+	for key, blob := range internState.RequestCancelInfos {
+		internState.RequestCancelInfos[key] = decrypt(mutableStateMapBlobField(serialization.RequestCancelInfoBlobField, key), blob)
+	}
+	for key, blob := range internState.SignalInfos {
+		internState.SignalInfos[key] = decrypt(mutableStateMapBlobField(serialization.SignalInfoBlobField, key), blob)
+	}
+	for i, blob := range internState.BufferedEvents {
+		internState.BufferedEvents[i] = decrypt(serialization.BufferedEventsBlobField, blob)
+	}
+	return err
+}
+
+// mutableStateMapBlobField returns the blob context field of a blob of a pending info map of mutable state.
+func mutableStateMapBlobField[K int64 | string](field string, key K) string {
+	return fmt.Sprintf("%s/%v", field, key)
+}
+
+// historyNodeBlobContext returns the blob context of the events of a history node which is appended.
+func historyNodeBlobContext(namespaceID string, request *InternalAppendHistoryNodesRequest) serialization.BlobContext {
+	return serialization.HistoryNodeBlobContext(
+		namespaceID,
+		request.BranchInfo.GetTreeId(),
+		request.BranchInfo.GetBranchId(),
+		request.Node.NodeID,
+		request.Node.TransactionID,
+	)
+}
+
 func (m *executionManagerImpl) DeleteWorkflowExecution(
 	ctx context.Context,
 	request *DeleteWorkflowExecutionRequest,
@@ -883,7 +1066,7 @@ func (m *executionManagerImpl) ListConcreteExecutions(
 		PageToken: response.NextPageToken,
 	}
 	for i, s := range response.States {
-		state, err := m.toWorkflowMutableState(s)
+		state, err := m.toWorkflowMutableState(s.NamespaceID, s.WorkflowID, s.RunID, s)
 		if err != nil {
 			return nil, err
 		}
@@ -1099,7 +1282,15 @@ func (m *executionManagerImpl) trimHistoryNode(
 	}
 }
 
-func (m *executionManagerImpl) toWorkflowMutableState(internState *InternalWorkflowMutableState) (*persistencespb.WorkflowMutableState, error) {
+func (m *executionManagerImpl) toWorkflowMutableState(
+	namespaceID string,
+	workflowID string,
+	runID string,
+	internState *InternalWorkflowMutableState,
+) (*persistencespb.WorkflowMutableState, error) {
+	if err := m.decryptMutableStateBlobs(namespaceID, workflowID, runID, internState); err != nil {
+		return nil, err
+	}
 	state := &persistencespb.WorkflowMutableState{
 		ActivityInfos:       make(map[int64]*persistencespb.ActivityInfo),
 		TimerInfos:          make(map[string]*persistencespb.TimerInfo),
@@ -2,9 +2,11 @@ package persistence_test
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/require"
 	enumspb "go.temporal.io/api/enums/v1"
 	"go.temporal.io/api/serviceerror"
 	enumsspb "go.temporal.io/server/api/enums/v1"
@@ -13,6 +15,7 @@ import (
 	"go.temporal.io/server/chasm"
 	"go.temporal.io/server/common/dynamicconfig"
 	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/metrics"
 	p "go.temporal.io/server/common/persistence"
 	mockp "go.temporal.io/server/common/persistence/mock"
 	"go.temporal.io/server/common/persistence/serialization"
@@ -73,6 +76,8 @@ func TestExecutionManager_DeletesTasksWhenEnabled(t *testing.T) {
 		log.NewNoopLogger(),
 		dynamicconfig.GetIntPropertyFn(1024*1024),
 		dynamicconfig.GetBoolPropertyFn(true),
+		nil,
+		nil,
 	)
 
 	_, err := em.UpdateWorkflowExecution(context.Background(), newTestUpdateRequest(expectedKeys))
@@ -98,6 +103,8 @@ func TestExecutionManager_DoesNotDeleteTasksWhenDisabled(t *testing.T) {
 		log.NewNoopLogger(),
 		dynamicconfig.GetIntPropertyFn(1024*1024),
 		dynamicconfig.GetBoolPropertyFn(false),
+		nil,
+		nil,
 	)
 
 	keys := []tasks.Key{tasks.NewKey(time.Now().UTC(), 789)}
@@ -134,6 +141,8 @@ func TestExecutionManager_DeleteTasksError_DoesNotFailUpdate(t *testing.T) {
 		log.NewNoopLogger(),
 		dynamicconfig.GetIntPropertyFn(1024*1024),
 		dynamicconfig.GetBoolPropertyFn(true),
+		nil,
+		nil,
 	)
 
 	// UpdateWorkflowExecution should succeed even though CompleteHistoryTask failed
@@ -143,6 +152,45 @@ func TestExecutionManager_DeleteTasksError_DoesNotFailUpdate(t *testing.T) {
 	}
 }
 
+func TestExecutionManager_CompressesMutableStateWhenEnabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dcClient := dynamicconfig.NewMemoryClient()
+	dcClient.OverrideSetting(dynamicconfig.PersistenceBlobCompressionCategories, []string{string(serialization.BlobCategoryMutableState)})
+	compressor := serialization.NewBlobCompressor(dynamicconfig.NewCollection(dcClient, log.NewNoopLogger()), metrics.NoopMetricsHandler)
+
+	request := newTestUpdateRequest(nil)
+	request.UpdateWorkflowMutation.ExecutionInfo.WorkflowId = strings.Repeat("wid", 200)
+
+	store := mockp.NewMockExecutionStore(ctrl)
+	store.EXPECT().GetName().AnyTimes().Return("mock-store")
+	store.EXPECT().UpdateWorkflowExecution(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, req *p.InternalUpdateWorkflowExecutionRequest) error {
+			blob := req.UpdateWorkflowMutation.ExecutionInfoBlob
+			require.True(t, serialization.IsCompressed(blob))
+			info, err := serialization.NewSerializer().WorkflowExecutionInfoFromBlob(blob)
+			require.NoError(t, err)
+			require.Equal(t, request.UpdateWorkflowMutation.ExecutionInfo.WorkflowId, info.WorkflowId)
+			require.False(t, serialization.IsCompressed(req.UpdateWorkflowMutation.ExecutionStateBlob))
+			return nil
+		},
+	)
+
+	em := p.NewExecutionManager(
+		store,
+		serialization.NewSerializer(),
+		nil,
+		log.NewNoopLogger(),
+		dynamicconfig.GetIntPropertyFn(1024*1024),
+		dynamicconfig.GetBoolPropertyFn(false),
+		compressor,
+		nil,
+	)
+	_, err := em.UpdateWorkflowExecution(context.Background(), request)
+	require.NoError(t, err)
+}
+
 func TestExecutionManager_TrimHistoryBranchSkipped_NonWorkflow(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -163,6 +211,8 @@ func TestExecutionManager_TrimHistoryBranchSkipped_NonWorkflow(t *testing.T) {
 		testlogger.NewTestLogger(t, testlogger.FailOnAnyUnexpectedError),
 		dynamicconfig.GetIntPropertyFn(1024*1024),
 		dynamicconfig.GetBoolPropertyFn(false),
+		nil,
+		nil,
 	)
 
 	req := newTestUpdateRequest(nil)
@@ -226,6 +276,8 @@ func TestExecutionManager_TrimHistoryBranchSkipped_EmptyBranchToken(t *testing.T
 		testlogger.NewTestLogger(t, testlogger.FailOnAnyUnexpectedError),
 		dynamicconfig.GetIntPropertyFn(1024*1024),
 		dynamicconfig.GetBoolPropertyFn(false),
+		nil,
+		nil,
 	)
 
 	_, err = em.UpdateWorkflowExecution(context.Background(), newTestUpdateRequest(nil))
@@ -1,6 +1,9 @@
 package faultinjection
 
 import (
+	"sync"
+
+	"go.temporal.io/server/common/clock"
 	"go.temporal.io/server/common/config"
 	"go.temporal.io/server/common/persistence"
 )
@@ -8,7 +11,12 @@ import (
 type (
 	FaultInjectionDataStoreFactory struct {
 		baseFactory persistence.DataStoreFactory
-		fiConfig    *config.FaultInjection
+		timeSource  clock.TimeSource
+
+		generatorsLock sync.Mutex
+		targets        config.FaultInjectionTargets
+		generators     []dataStoreFaultGenerator
+		unsubscribe    func()
 
 		taskStore          persistence.TaskStore
 		fairTaskStore      persistence.TaskStore
@@ -20,36 +28,63 @@ type (
 		clusterMDStore     persistence.ClusterMetadataStore
 		nexusEndpointStore persistence.NexusEndpointStore
 	}
+
+	dataStoreFaultGenerator struct {
+		dataStore config.DataStoreName
+		generator *storeFaultGenerator
+	}
 )
 
+// NewFaultInjectionDatastoreFactory returns a data store factory which injects faults into all the data stores of
+// the base factory. The faults are reloaded whenever the rules change.
 func NewFaultInjectionDatastoreFactory(
-	fiConfig *config.FaultInjection,
+	rules *Rules,
 	baseFactory persistence.DataStoreFactory,
 ) *FaultInjectionDataStoreFactory {
-	return &FaultInjectionDataStoreFactory{
+	d := &FaultInjectionDataStoreFactory{
 		baseFactory: baseFactory,
-		fiConfig:    fiConfig,
+		timeSource:  clock.NewRealTimeSource(),
 	}
+	d.unsubscribe = rules.subscribe(d.updateTargets)
+	return d
 }
 
 func (d *FaultInjectionDataStoreFactory) Close() {
+	d.unsubscribe()
 	d.baseFactory.Close()
 }
 
+func (d *FaultInjectionDataStoreFactory) newStoreFaultGenerator(dataStore config.DataStoreName) *storeFaultGenerator {
+	d.generatorsLock.Lock()
+	defer d.generatorsLock.Unlock()
+
+	storeConfig := d.targets.DataStores[dataStore]
+	generator := newStoreFaultGenerator(&storeConfig, d.timeSource)
+	d.generators = append(d.generators, dataStoreFaultGenerator{dataStore: dataStore, generator: generator})
+	return generator
+}
+
+func (d *FaultInjectionDataStoreFactory) updateTargets(targets config.FaultInjectionTargets) {
+	d.generatorsLock.Lock()
+	defer d.generatorsLock.Unlock()
+
+	d.targets = targets
+	for _, g := range d.generators {
+		storeConfig := targets.DataStores[g.dataStore]
+		g.generator.update(&storeConfig)
+	}
+}
+
 func (d *FaultInjectionDataStoreFactory) NewTaskStore() (persistence.TaskStore, error) {
 	if d.taskStore == nil {
 		baseStore, err := d.baseFactory.NewTaskStore()
 		if err != nil {
 			return nil, err
 		}
-		if storeConfig, ok := d.fiConfig.Targets.DataStores[config.TaskStoreName]; ok && len(storeConfig.Methods) > 0 {
-			d.taskStore = newFaultInjectionTaskStore(
-				baseStore,
-				newStoreFaultGenerator(&storeConfig),
-			)
-		} else {
-			d.taskStore = baseStore
-		}
+		d.taskStore = newFaultInjectionTaskStore(
+			baseStore,
+			d.newStoreFaultGenerator(config.TaskStoreName),
+		)
 	}
 	return d.taskStore, nil
 }
@@ -60,14 +95,10 @@ func (d *FaultInjectionDataStoreFactory) NewFairTaskStore() (persistence.TaskSto
 		if err != nil {
 			return nil, err
 		}
-		if storeConfig, ok := d.fiConfig.Targets.DataStores[config.TaskStoreName]; ok && len(storeConfig.Methods) > 0 {
-			d.fairTaskStore = newFaultInjectionTaskStore(
-				baseStore,
-				newStoreFaultGenerator(&storeConfig),
-			)
-		} else {
-			d.fairTaskStore = baseStore
-		}
+		d.fairTaskStore = newFaultInjectionTaskStore(
+			baseStore,
+			d.newStoreFaultGenerator(config.TaskStoreName),
+		)
 	}
 	return d.fairTaskStore, nil
 }
@@ -78,31 +109,24 @@ func (d *FaultInjectionDataStoreFactory) NewShardStore() (persistence.ShardStore
 		if err != nil {
 			return nil, err
 		}
-		if storeConfig, ok := d.fiConfig.Targets.DataStores[config.ShardStoreName]; ok && len(storeConfig.Methods) > 0 {
-			d.shardStore = newFaultInjectionShardStore(
-				baseStore,
-				newStoreFaultGenerator(&storeConfig),
-			)
-		} else {
-			d.shardStore = baseStore
-		}
+		d.shardStore = newFaultInjectionShardStore(
+			baseStore,
+			d.newStoreFaultGenerator(config.ShardStoreName),
+		)
 	}
 	return d.shardStore, nil
 }
+
 func (d *FaultInjectionDataStoreFactory) NewMetadataStore() (persistence.MetadataStore, error) {
 	if d.metadataStore == nil {
 		baseStore, err := d.baseFactory.NewMetadataStore()
 		if err != nil {
 			return nil, err
 		}
-		if storeConfig, ok := d.fiConfig.Targets.DataStores[config.MetadataStoreName]; ok && len(storeConfig.Methods) > 0 {
-			d.metadataStore = newFaultInjectionMetadataStore(
-				baseStore,
-				newStoreFaultGenerator(&storeConfig),
-			)
-		} else {
-			d.metadataStore = baseStore
-		}
+		d.metadataStore = newFaultInjectionMetadataStore(
+			baseStore,
+			d.newStoreFaultGenerator(config.MetadataStoreName),
+		)
 	}
 	return d.metadataStore, nil
 }
@@ -113,14 +137,10 @@ func (d *FaultInjectionDataStoreFactory) NewExecutionStore() (persistence.Execut
 		if err != nil {
 			return nil, err
 		}
-		if storeConfig, ok := d.fiConfig.Targets.DataStores[config.ExecutionStoreName]; ok && len(storeConfig.Methods) > 0 {
-			d.executionStore = newFaultInjectionExecutionStore(
-				baseStore,
-				newStoreFaultGenerator(&storeConfig),
-			)
-		} else {
-			d.executionStore = baseStore
-		}
+		d.executionStore = newFaultInjectionExecutionStore(
+			baseStore,
+			d.newStoreFaultGenerator(config.ExecutionStoreName),
+		)
 	}
 	return d.executionStore, nil
 }
@@ -131,14 +151,10 @@ func (d *FaultInjectionDataStoreFactory) NewQueue(queueType persistence.QueueTyp
 		if err != nil {
 			return baseQueue, err
 		}
-		if storeConfig, ok := d.fiConfig.Targets.DataStores[config.QueueName]; ok && len(storeConfig.Methods) > 0 {
-			d.queue = newFaultInjectionQueue(
-				baseQueue,
-				newStoreFaultGenerator(&storeConfig),
-			)
-		} else {
-			d.queue = baseQueue
-		}
+		d.queue = newFaultInjectionQueue(
+			baseQueue,
+			d.newStoreFaultGenerator(config.QueueName),
+		)
 	}
 	return d.queue, nil
 }
@@ -149,14 +165,10 @@ func (d *FaultInjectionDataStoreFactory) NewQueueV2() (persistence.QueueV2, erro
 		if err != nil {
 			return baseQueue, err
 		}
-		if storeConfig, ok := d.fiConfig.Targets.DataStores[config.QueueV2Name]; ok && len(storeConfig.Methods) > 0 {
-			d.queueV2 = newFaultInjectionQueueV2(
-				baseQueue,
-				newStoreFaultGenerator(&storeConfig),
-			)
-		} else {
-			d.queueV2 = baseQueue
-		}
+		d.queueV2 = newFaultInjectionQueueV2(
+			baseQueue,
+			d.newStoreFaultGenerator(config.QueueV2Name),
+		)
 	}
 	return d.queueV2, nil
 }
@@ -167,14 +179,10 @@ func (d *FaultInjectionDataStoreFactory) NewClusterMetadataStore() (persistence.
 		if err != nil {
 			return nil, err
 		}
-		if storeConfig, ok := d.fiConfig.Targets.DataStores[config.ClusterMDStoreName]; ok && len(storeConfig.Methods) > 0 {
-			d.clusterMDStore = newFaultInjectionClusterMetadataStore(
-				baseStore,
-				newStoreFaultGenerator(&storeConfig),
-			)
-		} else {
-			d.clusterMDStore = baseStore
-		}
+		d.clusterMDStore = newFaultInjectionClusterMetadataStore(
+			baseStore,
+			d.newStoreFaultGenerator(config.ClusterMDStoreName),
+		)
 	}
 	return d.clusterMDStore, nil
 }
@@ -185,14 +193,10 @@ func (d *FaultInjectionDataStoreFactory) NewNexusEndpointStore() (persistence.Ne
 		if err != nil {
 			return nil, err
 		}
-		if storeConfig, ok := d.fiConfig.Targets.DataStores[config.NexusEndpointStoreName]; ok && len(storeConfig.Methods) > 0 {
-			d.nexusEndpointStore = newFaultInjectionNexusEndpointStore(
-				baseStore,
-				newStoreFaultGenerator(&storeConfig),
-			)
-		} else {
-			d.nexusEndpointStore = baseStore
-		}
+		d.nexusEndpointStore = newFaultInjectionNexusEndpointStore(
+			baseStore,
+			d.newStoreFaultGenerator(config.NexusEndpointStoreName),
+		)
 	}
 	return d.nexusEndpointStore, nil
 }
@@ -3,6 +3,8 @@ package faultinjection
 import (
 	"context"
 	"fmt"
+	"slices"
+	"time"
 
 	enumspb "go.temporal.io/api/enums/v1"
 	"go.temporal.io/api/serviceerror"
@@ -16,9 +18,22 @@ type (
 		execOp bool
 		// How often this fault should be injected. 0.0 means never, 1.0 means always.
 		rate float64
+		// delay is the latency injected before the operation is executed or the error is returned.
+		delay time.Duration
 	}
 )
 
+// faultNames are the names of the faults which are supported by newFault.
+var faultNames = []string{
+	"ShardOwnershipLost",
+	"DeadlineExceeded",
+	"Timeout",
+	"ExecuteAndTimeout",
+	"ExecuteAndDeadlineExceeded",
+	"ResourceExhausted",
+	"Unavailable",
+}
+
 func newFaultFromError(err error, rate float64) fault {
 	return fault{
 		err:  err,
@@ -45,6 +60,12 @@ func newFault(errName string, errRate float64, methodName string) fault {
 		f := newFaultFromError(&persistence.TimeoutError{Msg: fmt.Sprintf("%s: persistence.TimeoutError", header)}, errRate)
 		f.execOp = true
 		return f
+	case "ExecuteAndDeadlineExceeded":
+		// Same as "ExecuteAndTimeout", but the caller gets context.DeadlineExceeded, like it would when its own
+		// deadline expires while waiting for the response of a successful write.
+		f := newFaultFromError(fmt.Errorf("%s: %w", header, context.DeadlineExceeded), errRate)
+		f.execOp = true
+		return f
 	case "ResourceExhausted":
 		return newFaultFromError(&serviceerror.ResourceExhausted{
 			Cause:   enumspb.RESOURCE_EXHAUSTED_CAUSE_SYSTEM_OVERLOADED,
@@ -58,10 +79,33 @@ func newFault(errName string, errRate float64, methodName string) fault {
 	}
 }
 
-func (f *fault) inject(op func() error) error {
+// validateFault returns an error if the fault can't be created by newFault.
+func validateFault(errName string, errRate float64) error {
+	if !slices.Contains(faultNames, errName) {
+		return fmt.Errorf("unsupported error type: %v", errName)
+	}
+	if errRate < 0 || errRate > 1 {
+		return fmt.Errorf("invalid rate %v for error type %v", errRate, errName)
+	}
+	return nil
+}
+
+func (f *fault) inject(ctx context.Context, op func() error) error {
 	if f == nil {
 		return op()
 	}
+	if f.delay > 0 {
+		timer := time.NewTimer(f.delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+	if f.err == nil {
+		return op()
+	}
 	if f.execOp {
 		err := op()
 		if err != nil {
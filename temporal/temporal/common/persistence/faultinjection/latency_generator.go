@@ -0,0 +1,95 @@
+package faultinjection
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"go.temporal.io/server/common/config"
+)
+
+const (
+	// longTailShape is the shape of the Pareto distribution used for long-tail latencies.
+	longTailShape = 1.5
+)
+
+type (
+	// latencyGenerator samples the latency injected into calls of a single method.
+	latencyGenerator struct {
+		rate         float64
+		distribution config.FaultInjectionLatencyDistribution
+		min          time.Duration
+		max          time.Duration
+	}
+)
+
+// newLatencyGenerator returns a latency generator for the provided config. If the config is invalid, then this method
+// will panic.
+func newLatencyGenerator(cfg *config.FaultInjectionLatencyConfig, methodName string) *latencyGenerator {
+	if cfg == nil {
+		return nil
+	}
+	if err := validateLatencyConfig(cfg); err != nil {
+		panic(fmt.Sprintf("%v at %s", err, methodName))
+	}
+	distribution := cfg.Distribution
+	if distribution == "" {
+		distribution = config.FaultInjectionLatencyFixed
+	}
+	return &latencyGenerator{
+		rate:         cfg.Rate,
+		distribution: distribution,
+		min:          cfg.Min,
+		max:          cfg.Max,
+	}
+}
+
+// validateLatencyConfig returns an error if the latency config can't be used to generate latencies.
+func validateLatencyConfig(cfg *config.FaultInjectionLatencyConfig) error {
+	if cfg.Rate < 0 || cfg.Rate > 1 {
+		return fmt.Errorf("invalid latency rate %v", cfg.Rate)
+	}
+	if cfg.Min < 0 || cfg.Max < 0 {
+		return errors.New("negative latency")
+	}
+	switch cfg.Distribution {
+	case "", config.FaultInjectionLatencyFixed:
+	case config.FaultInjectionLatencyUniform:
+		if cfg.Max < cfg.Min {
+			return fmt.Errorf("max latency %v is less than min latency %v", cfg.Max, cfg.Min)
+		}
+	case config.FaultInjectionLatencyLongTail:
+		if cfg.Min == 0 {
+			return errors.New("long-tail latency requires min latency")
+		}
+	default:
+		return fmt.Errorf("unsupported latency distribution: %v", cfg.Distribution)
+	}
+	return nil
+}
+
+// sample returns the latency for the next call, or 0 if the call shouldn't be delayed. All randomness comes from rnd,
+// so that seeded generators are deterministic.
+func (l *latencyGenerator) sample(rnd *rand.Rand) time.Duration {
+	if rnd.Float64() >= l.rate {
+		return 0
+	}
+	switch l.distribution {
+	case config.FaultInjectionLatencyUniform:
+		return l.min + time.Duration(rnd.Int63n(int64(l.max-l.min)+1))
+	case config.FaultInjectionLatencyLongTail:
+		// inverse transform sampling of the Pareto distribution
+		latency := float64(l.min) / math.Pow(1-rnd.Float64(), 1/longTailShape)
+		if l.max > 0 && latency > float64(l.max) {
+			return l.max
+		}
+		if latency >= math.MaxInt64 {
+			return time.Duration(math.MaxInt64)
+		}
+		return time.Duration(latency)
+	default:
+		return l.min
+	}
+}
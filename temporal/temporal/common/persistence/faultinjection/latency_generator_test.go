@@ -0,0 +1,73 @@
+package faultinjection
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/server/common/config"
+)
+
+func TestLatencyGenerator_Fixed(t *testing.T) {
+	t.Parallel()
+
+	gen := newLatencyGenerator(&config.FaultInjectionLatencyConfig{Rate: 1, Min: time.Second}, "UpdateShard")
+	rnd := rand.New(rand.NewSource(1))
+	for range 100 {
+		require.Equal(t, time.Second, gen.sample(rnd))
+	}
+}
+
+func TestLatencyGenerator_Rate(t *testing.T) {
+	t.Parallel()
+
+	gen := newLatencyGenerator(&config.FaultInjectionLatencyConfig{Rate: 0, Min: time.Second}, "UpdateShard")
+	rnd := rand.New(rand.NewSource(1))
+	for range 100 {
+		require.Zero(t, gen.sample(rnd))
+	}
+}
+
+func TestLatencyGenerator_LongTail(t *testing.T) {
+	t.Parallel()
+
+	gen := newLatencyGenerator(&config.FaultInjectionLatencyConfig{
+		Rate:         1,
+		Distribution: config.FaultInjectionLatencyLongTail,
+		Min:          time.Millisecond,
+		Max:          time.Second,
+	}, "UpdateShard")
+	rnd := rand.New(rand.NewSource(1))
+	var short, long int
+	for range 10000 {
+		latency := gen.sample(rnd)
+		require.GreaterOrEqual(t, latency, time.Millisecond)
+		require.LessOrEqual(t, latency, time.Second)
+		if latency <= 2*time.Millisecond {
+			short++
+		}
+		if latency >= 20*time.Millisecond {
+			long++
+		}
+	}
+	// 2^(-1.5) = 35% of the samples are above 2x min and 20^(-1.5) = 1.1% are above 20x min.
+	require.InDelta(t, 6500, short, 300)
+	require.InDelta(t, 110, long, 50)
+}
+
+func TestLatencyGenerator_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for _, cfg := range []config.FaultInjectionLatencyConfig{
+		{Rate: 2, Min: time.Second},
+		{Rate: 1, Min: -time.Second},
+		{Rate: 1, Distribution: config.FaultInjectionLatencyUniform, Min: time.Second, Max: time.Millisecond},
+		{Rate: 1, Distribution: config.FaultInjectionLatencyLongTail},
+		{Rate: 1, Distribution: "normal", Min: time.Second},
+	} {
+		require.Panics(t, func() {
+			newLatencyGenerator(&cfg, "UpdateShard")
+		})
+	}
+}
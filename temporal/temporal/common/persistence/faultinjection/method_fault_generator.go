@@ -4,6 +4,9 @@ import (
 	"math/rand"
 	"sync"
 	"time"
+
+	"go.temporal.io/server/common/clock"
+	"go.temporal.io/server/common/config"
 )
 
 type (
@@ -16,12 +19,23 @@ type (
 		rndMu sync.Mutex
 		rnd   *rand.Rand // rand is not thread-safe
 
-		rate           float64         // chance for one of the errors for this method to be returned
-		faultsMetadata []faultMetadata // faults with their thresholds that might be generated for this method
+		rate           float64           // chance for one of the errors for this method to be returned
+		faultsMetadata []faultMetadata   // faults with their thresholds that might be generated for this method
+		latency        *latencyGenerator // optional latency injected into calls of this method
+
+		timeSource clock.TimeSource
+		createTime time.Time                     // windows are relative to the creation of the generator
+		windows    []config.FaultInjectionWindow // time windows when faults are generated, always if empty
 	}
 )
 
-func newMethodFaultGenerator(faults []fault, seed int64) *methodFaultGenerator {
+func newMethodFaultGenerator(
+	faults []fault,
+	latency *latencyGenerator,
+	windows []config.FaultInjectionWindow,
+	seed int64,
+	timeSource clock.TimeSource,
+) *methodFaultGenerator {
 	if seed == 0 {
 		seed = time.Now().UnixNano()
 	}
@@ -39,27 +53,72 @@ func newMethodFaultGenerator(faults []fault, seed int64) *methodFaultGenerator {
 	return &methodFaultGenerator{
 		rate:           totalRate,
 		faultsMetadata: fm,
+		latency:        latency,
 		rnd:            rand.New(rand.NewSource(seed)),
+		timeSource:     timeSource,
+		createTime:     timeSource.Now(),
+		windows:        windows,
 	}
 }
 
 func (p *methodFaultGenerator) generate(_ string) *fault {
-	if p.rate <= 0 {
+	if p.rate <= 0 && p.latency == nil {
+		return nil
+	}
+	if !p.active() {
 		return nil
 	}
 
 	p.rndMu.Lock()
-	roll := p.rnd.Float64()
-	p.rndMu.Unlock()
+	defer p.rndMu.Unlock()
 
-	if roll < p.rate {
-		// Yes, this method call should be failed.
-		// Let's find out with what fault.
-		for i := range p.faultsMetadata {
-			if roll < p.faultsMetadata[i].threshold {
-				return &p.faultsMetadata[i].fault
+	var f *fault
+	if p.rate > 0 {
+		roll := p.rnd.Float64()
+		if roll < p.rate {
+			// Yes, this method call should be failed.
+			// Let's find out with what fault.
+			for i := range p.faultsMetadata {
+				if roll < p.faultsMetadata[i].threshold {
+					f = &p.faultsMetadata[i].fault
+					break
+				}
 			}
 		}
 	}
-	return nil
+
+	if p.latency == nil {
+		return f
+	}
+	delay := p.latency.sample(p.rnd)
+	if delay <= 0 {
+		return f
+	}
+	var delayed fault
+	if f != nil {
+		delayed = *f
+	}
+	delayed.delay = delay
+	return &delayed
+}
+
+// active returns true if faults should be generated at the current time.
+func (p *methodFaultGenerator) active() bool {
+	if len(p.windows) == 0 {
+		return true
+	}
+	elapsed := p.timeSource.Since(p.createTime)
+	for _, w := range p.windows {
+		offset := elapsed - w.Start
+		if offset < 0 {
+			continue
+		}
+		if w.Period > 0 {
+			offset %= w.Period
+		}
+		if w.Duration <= 0 || offset < w.Duration {
+			return true
+		}
+	}
+	return false
 }
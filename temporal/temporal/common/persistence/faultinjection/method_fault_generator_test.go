@@ -4,9 +4,12 @@ import (
 	"errors"
 	"math"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"go.temporal.io/server/common/clock"
+	"go.temporal.io/server/common/config"
 )
 
 type (
@@ -49,7 +52,7 @@ func (s *methodFaultGeneratorSuite) Test_Generate() {
 			rate:   0.22,
 		},
 	}
-	gen := newMethodFaultGenerator(faults, 2208)
+	gen := newMethodFaultGenerator(faults, nil, nil, 2208, clock.NewRealTimeSource())
 
 	s.EqualValues(34, math.Round(gen.rate*100))
 	s.Len(gen.faultsMetadata, 3)
@@ -68,3 +71,67 @@ func (s *methodFaultGeneratorSuite) Test_Generate() {
 	f4 := gen.generate("")
 	s.Nil(f4)
 }
+
+func (s *methodFaultGeneratorSuite) Test_Generate_Latency() {
+	faults := []fault{
+		{
+			err:  errors.New("random error"),
+			rate: 0.5,
+		},
+	}
+	latency := newLatencyGenerator(&config.FaultInjectionLatencyConfig{
+		Rate:         0.5,
+		Distribution: config.FaultInjectionLatencyUniform,
+		Min:          time.Millisecond,
+		Max:          time.Second,
+	}, "")
+	gen1 := newMethodFaultGenerator(faults, latency, nil, 2208, clock.NewRealTimeSource())
+	gen2 := newMethodFaultGenerator(faults, latency, nil, 2208, clock.NewRealTimeSource())
+
+	var errs, delays int
+	for range 1000 {
+		f1 := gen1.generate("")
+		f2 := gen2.generate("")
+		s.Equal(f1, f2, "generators with the same seed should generate the same faults")
+		if f1 == nil {
+			continue
+		}
+		if f1.err != nil {
+			errs++
+		}
+		if f1.delay > 0 {
+			delays++
+			s.GreaterOrEqual(f1.delay, time.Millisecond)
+			s.LessOrEqual(f1.delay, time.Second)
+		}
+	}
+	s.InDelta(500, errs, 100)
+	s.InDelta(500, delays, 100)
+}
+
+func (s *methodFaultGeneratorSuite) Test_Generate_Windows() {
+	faults := []fault{
+		{
+			err:  errors.New("random error"),
+			rate: 1,
+		},
+	}
+	timeSource := clock.NewEventTimeSource().Update(time.Unix(0, 0))
+	gen := newMethodFaultGenerator(faults, nil, []config.FaultInjectionWindow{
+		{
+			Start:    time.Minute,
+			Duration: 10 * time.Second,
+			Period:   time.Minute,
+		},
+	}, 2208, timeSource)
+
+	s.Nil(gen.generate(""))
+	timeSource.Advance(time.Minute)
+	s.NotNil(gen.generate(""))
+	timeSource.Advance(10 * time.Second)
+	s.Nil(gen.generate(""))
+	timeSource.Advance(50 * time.Second)
+	s.NotNil(gen.generate(""))
+	timeSource.Advance(30 * time.Second)
+	s.Nil(gen.generate(""))
+}
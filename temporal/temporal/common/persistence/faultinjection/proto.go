@@ -0,0 +1,65 @@
+package faultinjection
+
+import (
+	"maps"
+
+	"go.temporal.io/server/api/adminservice/v1"
+	"go.temporal.io/server/common/config"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// RuleToProto converts a fault injection rule to its admin API representation.
+func RuleToProto(rule Rule) *adminservice.FaultInjectionRule {
+	result := &adminservice.FaultInjectionRule{
+		DataStore: string(rule.DataStore),
+		Method:    rule.Method,
+		Source:    string(rule.Source),
+		Errors:    maps.Clone(rule.Config.Errors),
+		Seed:      rule.Config.Seed,
+	}
+	if latency := rule.Config.Latency; latency != nil {
+		result.Latency = &adminservice.FaultInjectionLatency{
+			Rate:         latency.Rate,
+			Distribution: string(latency.Distribution),
+			Min:          durationpb.New(latency.Min),
+			Max:          durationpb.New(latency.Max),
+		}
+	}
+	for _, w := range rule.Config.Windows {
+		result.Windows = append(result.Windows, &adminservice.FaultInjectionWindow{
+			Start:    durationpb.New(w.Start),
+			Duration: durationpb.New(w.Duration),
+			Period:   durationpb.New(w.Period),
+		})
+	}
+	return result
+}
+
+// MethodConfigFromProto converts the admin API representation of a fault injection rule to the config of its method.
+func MethodConfigFromProto(rule *adminservice.FaultInjectionRule) config.FaultInjectionMethodConfig {
+	result := config.FaultInjectionMethodConfig{
+		Errors: maps.Clone(rule.GetErrors()),
+		Seed:   rule.GetSeed(),
+	}
+	if latency := rule.GetLatency(); latency != nil {
+		result.Latency = &config.FaultInjectionLatencyConfig{
+			Rate:         latency.GetRate(),
+			Distribution: config.FaultInjectionLatencyDistribution(latency.GetDistribution()),
+			Min:          latency.GetMin().AsDuration(),
+			Max:          latency.GetMax().AsDuration(),
+		}
+	}
+	for _, w := range rule.GetWindows() {
+		result.Windows = append(result.Windows, config.FaultInjectionWindow{
+			Start:    w.GetStart().AsDuration(),
+			Duration: w.GetDuration().AsDuration(),
+			Period:   w.GetPeriod().AsDuration(),
+		})
+	}
+	return result
+}
+
+// AddProto adds the fault injection rule of the admin API to the runtime rules.
+func (r *Rules) AddProto(rule *adminservice.FaultInjectionRule) error {
+	return r.Add(config.DataStoreName(rule.GetDataStore()), rule.GetMethod(), MethodConfigFromProto(rule))
+}
@@ -0,0 +1,302 @@
+package faultinjection
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"sync"
+
+	"go.temporal.io/server/common/config"
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/log/tag"
+	"go.temporal.io/server/common/persistence"
+)
+
+const (
+	// RuleSourceStatic is the source of rules from the static persistence config.
+	RuleSourceStatic RuleSource = "static"
+	// RuleSourceDynamicConfig is the source of rules from the DynamicTargets dynamic config.
+	RuleSourceDynamicConfig RuleSource = "dynamicConfig"
+	// RuleSourceRuntime is the source of rules added with Rules.Add, e.g. by the admin API.
+	RuleSourceRuntime RuleSource = "runtime"
+)
+
+var DynamicTargets = dynamicconfig.NewGlobalTypedSetting(
+	"system.persistenceFaultInjectionTargets",
+	config.FaultInjectionTargets{},
+	`DynamicTargets overrides the fault injection targets of the static persistence config, using the same structure.
+A method configured here replaces the static config of that method. Fault injection must be enabled in the static
+persistence config of the default store for this to take effect. Invalid method configs are ignored.`,
+)
+
+// dataStoreTypes are the data store interfaces which can be targeted by fault injection.
+var dataStoreTypes = map[config.DataStoreName]reflect.Type{
+	config.ShardStoreName:         reflect.TypeFor[persistence.ShardStore](),
+	config.TaskStoreName:          reflect.TypeFor[persistence.TaskStore](),
+	config.MetadataStoreName:      reflect.TypeFor[persistence.MetadataStore](),
+	config.ExecutionStoreName:     reflect.TypeFor[persistence.ExecutionStore](),
+	config.QueueName:              reflect.TypeFor[persistence.Queue](),
+	config.QueueV2Name:            reflect.TypeFor[persistence.QueueV2](),
+	config.ClusterMDStoreName:     reflect.TypeFor[persistence.ClusterMetadataStore](),
+	config.NexusEndpointStoreName: reflect.TypeFor[persistence.NexusEndpointStore](),
+}
+
+type (
+	// RuleSource is where a fault injection rule comes from.
+	RuleSource string
+
+	// Rule is the fault injection config of a single data store method.
+	Rule struct {
+		DataStore config.DataStoreName
+		Method    string
+		Source    RuleSource
+		Config    config.FaultInjectionMethodConfig
+	}
+
+	// Rules is the set of fault injection rules of a process. It merges the targets of the static config, of the
+	// DynamicTargets dynamic config and the rules added at runtime, in this order: a method which is configured by a
+	// later source replaces the config of that method from the earlier ones. Rules are shared by all the services of
+	// the process, and every change is pushed to the subscribed data store factories.
+	Rules struct {
+		logger log.Logger
+		static config.FaultInjectionTargets
+
+		sync.Mutex
+		dynamic       config.FaultInjectionTargets
+		runtime       config.FaultInjectionTargets
+		subscribers   map[int]func(config.FaultInjectionTargets)
+		nextID        int
+		cancelDynamic func()
+	}
+)
+
+// NewRules returns the fault injection rules for the provided static config. If dc is not nil, then the rules are
+// reloaded whenever the DynamicTargets dynamic config changes, until Stop is called.
+func NewRules(
+	cfg *config.FaultInjection,
+	dc *dynamicconfig.Collection,
+	logger log.Logger,
+) *Rules {
+	r := &Rules{
+		logger:      logger,
+		static:      cfg.Targets,
+		subscribers: make(map[int]func(config.FaultInjectionTargets)),
+	}
+	if dc != nil {
+		var dynamic config.FaultInjectionTargets
+		dynamic, r.cancelDynamic = DynamicTargets.Subscribe(dc)(r.setDynamic)
+		r.setDynamic(dynamic)
+	}
+	return r
+}
+
+// Stop stops reloading the rules from dynamic config.
+func (r *Rules) Stop() {
+	if r.cancelDynamic != nil {
+		r.cancelDynamic()
+	}
+}
+
+// List returns the rules of all sources, optionally filtered by data store. Rules of the same method are returned
+// in the order they are applied.
+func (r *Rules) List(dataStore config.DataStoreName) []Rule {
+	r.Lock()
+	defer r.Unlock()
+
+	var rules []Rule
+	for _, src := range []struct {
+		source  RuleSource
+		targets config.FaultInjectionTargets
+	}{
+		{source: RuleSourceStatic, targets: r.static},
+		{source: RuleSourceDynamicConfig, targets: r.dynamic},
+		{source: RuleSourceRuntime, targets: r.runtime},
+	} {
+		for storeName, storeConfig := range src.targets.DataStores {
+			if dataStore != "" && dataStore != storeName {
+				continue
+			}
+			for methodName, methodConfig := range storeConfig.Methods {
+				rules = append(rules, Rule{
+					DataStore: storeName,
+					Method:    methodName,
+					Source:    src.source,
+					Config:    methodConfig,
+				})
+			}
+		}
+	}
+	// stable sort keeps the sources in order
+	slices.SortStableFunc(rules, func(a, b Rule) int {
+		return cmp.Or(cmp.Compare(a.DataStore, b.DataStore), cmp.Compare(a.Method, b.Method))
+	})
+	return rules
+}
+
+// Add adds a runtime rule for a data store method, replacing the previous runtime rule of that method.
+func (r *Rules) Add(dataStore config.DataStoreName, method string, methodConfig config.FaultInjectionMethodConfig) error {
+	if err := ValidateRule(dataStore, method, methodConfig); err != nil {
+		return err
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	if r.runtime.DataStores == nil {
+		r.runtime.DataStores = make(map[config.DataStoreName]config.FaultInjectionDataStoreConfig)
+	}
+	storeConfig := r.runtime.DataStores[dataStore]
+	methods := maps.Clone(storeConfig.Methods)
+	if methods == nil {
+		methods = make(map[string]config.FaultInjectionMethodConfig)
+	}
+	methods[method] = methodConfig
+	r.runtime.DataStores[dataStore] = config.FaultInjectionDataStoreConfig{Methods: methods}
+	r.notifyLocked()
+	return nil
+}
+
+// Clear removes the runtime rules of a data store method. An empty method clears all the runtime rules of the data
+// store and an empty data store clears all the runtime rules. It returns the number of removed rules. Rules from the
+// static and dynamic config can't be cleared, but they can be overridden by adding a runtime rule without faults.
+func (r *Rules) Clear(dataStore config.DataStoreName, method string) int {
+	r.Lock()
+	defer r.Unlock()
+
+	cleared := 0
+	dataStores := make(map[config.DataStoreName]config.FaultInjectionDataStoreConfig, len(r.runtime.DataStores))
+	for storeName, storeConfig := range r.runtime.DataStores {
+		methods := make(map[string]config.FaultInjectionMethodConfig, len(storeConfig.Methods))
+		for methodName, methodConfig := range storeConfig.Methods {
+			if (dataStore == "" || dataStore == storeName) && (method == "" || method == methodName) {
+				cleared++
+				continue
+			}
+			methods[methodName] = methodConfig
+		}
+		if len(methods) > 0 {
+			dataStores[storeName] = config.FaultInjectionDataStoreConfig{Methods: methods}
+		}
+	}
+	if cleared > 0 {
+		r.runtime.DataStores = dataStores
+		r.notifyLocked()
+	}
+	return cleared
+}
+
+// Targets returns the merged targets of all sources.
+func (r *Rules) Targets() config.FaultInjectionTargets {
+	r.Lock()
+	defer r.Unlock()
+
+	return r.targetsLocked()
+}
+
+// subscribe calls callback with the current merged targets, and then again whenever the rules change. The callback
+// is called while holding the lock of the rules, so the calls are serialized and it must not call back into them.
+func (r *Rules) subscribe(callback func(config.FaultInjectionTargets)) func() {
+	r.Lock()
+	defer r.Unlock()
+
+	id := r.nextID
+	r.nextID++
+	r.subscribers[id] = callback
+	callback(r.targetsLocked())
+	return func() {
+		r.Lock()
+		defer r.Unlock()
+		delete(r.subscribers, id)
+	}
+}
+
+func (r *Rules) setDynamic(targets config.FaultInjectionTargets) {
+	// Invalid rules from dynamic config are dropped instead of failing the whole config, so that a typo in one
+	// rule doesn't disable the other ones.
+	valid := config.FaultInjectionTargets{
+		DataStores: make(map[config.DataStoreName]config.FaultInjectionDataStoreConfig, len(targets.DataStores)),
+	}
+	for storeName, storeConfig := range targets.DataStores {
+		methods := make(map[string]config.FaultInjectionMethodConfig, len(storeConfig.Methods))
+		for methodName, methodConfig := range storeConfig.Methods {
+			if err := ValidateRule(storeName, methodName, methodConfig); err != nil {
+				r.logger.Warn("Ignoring invalid fault injection rule from dynamic config.",
+					tag.Key(DynamicTargets.Key().String()),
+					tag.Error(err),
+				)
+				continue
+			}
+			methods[methodName] = methodConfig
+		}
+		valid.DataStores[storeName] = config.FaultInjectionDataStoreConfig{Methods: methods}
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	r.dynamic = valid
+	r.notifyLocked()
+}
+
+func (r *Rules) notifyLocked() {
+	targets := r.targetsLocked()
+	for _, callback := range r.subscribers {
+		callback(targets)
+	}
+}
+
+func (r *Rules) targetsLocked() config.FaultInjectionTargets {
+	targets := config.FaultInjectionTargets{
+		DataStores: make(map[config.DataStoreName]config.FaultInjectionDataStoreConfig),
+	}
+	for _, src := range []config.FaultInjectionTargets{r.static, r.dynamic, r.runtime} {
+		for storeName, storeConfig := range src.DataStores {
+			merged, ok := targets.DataStores[storeName]
+			if !ok {
+				merged = config.FaultInjectionDataStoreConfig{Methods: make(map[string]config.FaultInjectionMethodConfig)}
+			}
+			maps.Copy(merged.Methods, storeConfig.Methods)
+			targets.DataStores[storeName] = merged
+		}
+	}
+	return targets
+}
+
+// ValidateRule returns an error if the fault injection config of a data store method is invalid.
+func ValidateRule(dataStore config.DataStoreName, method string, methodConfig config.FaultInjectionMethodConfig) error {
+	storeType, ok := dataStoreTypes[dataStore]
+	if !ok {
+		return fmt.Errorf("unknown data store: %v", dataStore)
+	}
+	if method == "" {
+		return errors.New("method is not set")
+	}
+	if _, ok := storeType.MethodByName(method); !ok {
+		return fmt.Errorf("unknown method %v of data store %v", method, dataStore)
+	}
+	totalRate := 0.0
+	for errName, errRate := range methodConfig.Errors {
+		if err := validateFault(errName, errRate); err != nil {
+			return fmt.Errorf("%w at %v.%v", err, dataStore, method)
+		}
+		totalRate += errRate
+	}
+	if totalRate > 1 {
+		return fmt.Errorf("total error rate %v is greater than 1 at %v.%v", totalRate, dataStore, method)
+	}
+	if methodConfig.Latency != nil {
+		if err := validateLatencyConfig(methodConfig.Latency); err != nil {
+			return fmt.Errorf("%w at %v.%v", err, dataStore, method)
+		}
+	}
+	for _, w := range methodConfig.Windows {
+		if w.Start < 0 || w.Duration < 0 || w.Period < 0 {
+			return fmt.Errorf("negative window at %v.%v", dataStore, method)
+		}
+	}
+	return nil
+}
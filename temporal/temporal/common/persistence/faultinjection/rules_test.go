@@ -0,0 +1,194 @@
+package faultinjection
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/server/common/clock"
+	"go.temporal.io/server/common/config"
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/persistence/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRules_Precedence(t *testing.T) {
+	t.Parallel()
+
+	static := (&config.FaultInjection{}).
+		WithError(config.ShardStoreName, "UpdateShard", "Timeout", 1).
+		WithError(config.ShardStoreName, "GetOrCreateShard", "Timeout", 1)
+	rules := NewRules(static, nil, log.NewNoopLogger())
+
+	require.NoError(t, rules.Add(config.ShardStoreName, "UpdateShard", config.FaultInjectionMethodConfig{}))
+	targets := rules.Targets()
+	require.Empty(t, targets.DataStores[config.ShardStoreName].Methods["UpdateShard"].Errors, "runtime rule should replace static rule")
+	require.Equal(t, map[string]float64{"Timeout": 1}, targets.DataStores[config.ShardStoreName].Methods["GetOrCreateShard"].Errors)
+
+	listed := rules.List("")
+	require.Len(t, listed, 3)
+	require.Equal(t, "GetOrCreateShard", listed[0].Method)
+	require.Equal(t, RuleSourceStatic, listed[1].Source)
+	require.Equal(t, RuleSourceRuntime, listed[2].Source)
+	require.Empty(t, rules.List(config.ExecutionStoreName))
+
+	require.Equal(t, 1, rules.Clear(config.ShardStoreName, ""))
+	require.Zero(t, rules.Clear("", ""))
+	require.Equal(t, map[string]float64{"Timeout": 1}, rules.Targets().DataStores[config.ShardStoreName].Methods["UpdateShard"].Errors)
+}
+
+func TestRules_Clear(t *testing.T) {
+	t.Parallel()
+
+	rules := NewRules(&config.FaultInjection{}, nil, log.NewNoopLogger())
+	methodConfig := config.FaultInjectionMethodConfig{Errors: map[string]float64{"Unavailable": 0.5}}
+	require.NoError(t, rules.Add(config.ShardStoreName, "UpdateShard", methodConfig))
+	require.NoError(t, rules.Add(config.ShardStoreName, "GetOrCreateShard", methodConfig))
+	require.NoError(t, rules.Add(config.ExecutionStoreName, "GetWorkflowExecution", methodConfig))
+
+	require.Equal(t, 1, rules.Clear(config.ShardStoreName, "UpdateShard"))
+	require.Len(t, rules.List(""), 2)
+	require.Equal(t, 1, rules.Clear(config.ShardStoreName, ""))
+	require.Len(t, rules.List(""), 1)
+	require.Equal(t, 1, rules.Clear("", ""))
+	require.Empty(t, rules.List(""))
+}
+
+func TestRules_Invalid(t *testing.T) {
+	t.Parallel()
+
+	rules := NewRules(&config.FaultInjection{}, nil, log.NewNoopLogger())
+	for name, tc := range map[string]struct {
+		dataStore    config.DataStoreName
+		method       string
+		methodConfig config.FaultInjectionMethodConfig
+	}{
+		"unknown data store": {dataStore: "HistoryStore", method: "UpdateShard"},
+		"unknown method":     {dataStore: config.ShardStoreName, method: "UpdateWorkflowExecution"},
+		"missing method":     {dataStore: config.ShardStoreName},
+		"unknown error": {
+			dataStore:    config.ShardStoreName,
+			method:       "UpdateShard",
+			methodConfig: config.FaultInjectionMethodConfig{Errors: map[string]float64{"Oops": 0.1}},
+		},
+		"total rate": {
+			dataStore:    config.ShardStoreName,
+			method:       "UpdateShard",
+			methodConfig: config.FaultInjectionMethodConfig{Errors: map[string]float64{"Timeout": 0.6, "Unavailable": 0.6}},
+		},
+		"invalid latency": {
+			dataStore:    config.ShardStoreName,
+			method:       "UpdateShard",
+			methodConfig: config.FaultInjectionMethodConfig{Latency: &config.FaultInjectionLatencyConfig{Rate: 1, Distribution: "normal"}},
+		},
+		"negative window": {
+			dataStore:    config.ShardStoreName,
+			method:       "UpdateShard",
+			methodConfig: config.FaultInjectionMethodConfig{Windows: []config.FaultInjectionWindow{{Start: -time.Second}}},
+		},
+	} {
+		require.Error(t, rules.Add(tc.dataStore, tc.method, tc.methodConfig), name)
+	}
+	require.Empty(t, rules.List(""))
+}
+
+func TestRules_DynamicConfig(t *testing.T) {
+	t.Parallel()
+
+	dcClient := dynamicconfig.NewMemoryClient()
+	dc := dynamicconfig.NewCollection(dcClient, log.NewNoopLogger())
+	dc.Start()
+	defer dc.Stop()
+
+	rules := NewRules(&config.FaultInjection{}, dc, log.NewNoopLogger())
+	defer rules.Stop()
+	require.Empty(t, rules.List(""))
+
+	dcClient.OverrideSetting(DynamicTargets, map[string]any{
+		"dataStores": map[string]any{
+			"ShardStore": map[string]any{
+				"methods": map[string]any{
+					"UpdateShard": map[string]any{
+						"errors":  map[string]any{"Timeout": 0.5},
+						"latency": map[string]any{"rate": 1, "min": "10ms"},
+						"windows": []any{map[string]any{"start": "1m", "duration": "30s"}},
+					},
+					// invalid rules are ignored
+					"UpdateShardTypo": map[string]any{
+						"errors": map[string]any{"Timeout": 0.5},
+					},
+				},
+			},
+		},
+	})
+
+	// dynamic config dispatches updates asynchronously
+	require.Eventually(t, func() bool {
+		return len(rules.List(config.ShardStoreName)) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	listed := rules.List(config.ShardStoreName)
+	require.Len(t, listed, 1)
+	require.Equal(t, RuleSourceDynamicConfig, listed[0].Source)
+	require.Equal(t, config.FaultInjectionMethodConfig{
+		Errors:  map[string]float64{"Timeout": 0.5},
+		Latency: &config.FaultInjectionLatencyConfig{Rate: 1, Min: 10 * time.Millisecond},
+		Windows: []config.FaultInjectionWindow{{Start: time.Minute, Duration: 30 * time.Second}},
+	}, listed[0].Config)
+}
+
+func TestFaultInjection_Reload(t *testing.T) {
+	t.Parallel()
+
+	rules := NewRules(&config.FaultInjection{}, nil, log.NewNoopLogger())
+
+	ctrl := gomock.NewController(t)
+	baseFactory := mock.NewMockDataStoreFactory(ctrl)
+	factory := NewFaultInjectionDatastoreFactory(rules, baseFactory)
+	baseShardStore := mock.NewMockShardStore(ctrl)
+	baseFactory.EXPECT().NewShardStore().Return(baseShardStore, nil)
+
+	s, err := factory.NewShardStore()
+	require.NoError(t, err, "stores are wrapped even without rules, so that rules can be added later")
+
+	baseShardStore.EXPECT().UpdateShard(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	require.NoError(t, s.UpdateShard(context.Background(), nil))
+
+	require.NoError(t, rules.Add(config.ShardStoreName, "UpdateShard", config.FaultInjectionMethodConfig{
+		Errors: map[string]float64{"Timeout": 1},
+	}))
+	err = s.UpdateShard(context.Background(), nil)
+	var timeoutErr *persistence.TimeoutError
+	require.ErrorAs(t, err, &timeoutErr)
+
+	rules.Clear(config.ShardStoreName, "UpdateShard")
+	require.NoError(t, s.UpdateShard(context.Background(), nil))
+
+	baseFactory.EXPECT().Close()
+	factory.Close()
+	require.NoError(t, rules.Add(config.ShardStoreName, "UpdateShard", config.FaultInjectionMethodConfig{}))
+}
+
+func TestStoreFaultGenerator_UpdateKeepsUnchangedMethods(t *testing.T) {
+	t.Parallel()
+
+	methodConfig := config.FaultInjectionMethodConfig{Errors: map[string]float64{"Timeout": 0.5}, Seed: 1}
+	gen := newStoreFaultGenerator(&config.FaultInjectionDataStoreConfig{
+		Methods: map[string]config.FaultInjectionMethodConfig{"UpdateShard": methodConfig},
+	}, clock.NewRealTimeSource())
+	before := gen.methodFaultGenerators["UpdateShard"]
+
+	gen.update(&config.FaultInjectionDataStoreConfig{
+		Methods: map[string]config.FaultInjectionMethodConfig{
+			"UpdateShard":      methodConfig,
+			"GetOrCreateShard": methodConfig,
+		},
+	})
+	require.Same(t, before, gen.methodFaultGenerators["UpdateShard"])
+	require.Contains(t, gen.methodFaultGenerators, "GetOrCreateShard")
+
+	gen.update(nil)
+	require.Nil(t, gen.generate("UpdateShard"))
+}
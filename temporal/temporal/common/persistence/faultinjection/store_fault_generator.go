@@ -1,31 +1,67 @@
 package faultinjection
 
 import (
+	"reflect"
+	"sync"
+
+	"go.temporal.io/server/common/clock"
 	"go.temporal.io/server/common/config"
 )
 
 type (
 	// storeFaultGenerator is an implementation of faultGenerator that will inject errors into the persistence layer
-	// using a per-method configuration.
+	// using a per-method configuration. The configuration can be replaced at runtime with update.
 	storeFaultGenerator struct {
+		timeSource clock.TimeSource
+
+		sync.RWMutex
+		methodConfigs         map[string]config.FaultInjectionMethodConfig
 		methodFaultGenerators map[string]faultGenerator
 	}
 )
 
 // newStoreFaultGenerator returns a new instance of a data store error generator that will inject errors
 // into the persistence layer based on the provided configuration.
-func newStoreFaultGenerator(cfg *config.FaultInjectionDataStoreConfig) *storeFaultGenerator {
-	methodFaultGenerators := make(map[string]faultGenerator, len(cfg.Methods))
-	for methodName, methodConfig := range cfg.Methods {
+func newStoreFaultGenerator(cfg *config.FaultInjectionDataStoreConfig, timeSource clock.TimeSource) *storeFaultGenerator {
+	d := &storeFaultGenerator{
+		timeSource: timeSource,
+	}
+	d.update(cfg)
+	return d
+}
+
+// update replaces the configuration of the generator. Methods whose configuration didn't change keep their
+// generator, so that their random sequence and time windows are not reset. If the config is invalid, then this
+// method will panic.
+func (d *storeFaultGenerator) update(cfg *config.FaultInjectionDataStoreConfig) {
+	var methods map[string]config.FaultInjectionMethodConfig
+	if cfg != nil {
+		methods = cfg.Methods
+	}
+
+	d.Lock()
+	defer d.Unlock()
+
+	methodFaultGenerators := make(map[string]faultGenerator, len(methods))
+	for methodName, methodConfig := range methods {
+		if generator, ok := d.methodFaultGenerators[methodName]; ok && reflect.DeepEqual(d.methodConfigs[methodName], methodConfig) {
+			methodFaultGenerators[methodName] = generator
+			continue
+		}
 		var faults []fault
 		for errName, errRate := range methodConfig.Errors {
 			faults = append(faults, newFault(errName, errRate, methodName))
 		}
-		methodFaultGenerators[methodName] = newMethodFaultGenerator(faults, methodConfig.Seed)
-	}
-	return &storeFaultGenerator{
-		methodFaultGenerators: methodFaultGenerators,
+		methodFaultGenerators[methodName] = newMethodFaultGenerator(
+			faults,
+			newLatencyGenerator(methodConfig.Latency, methodName),
+			methodConfig.Windows,
+			methodConfig.Seed,
+			d.timeSource,
+		)
 	}
+	d.methodConfigs = methods
+	d.methodFaultGenerators = methodFaultGenerators
 }
 
 // Generate returns an error from the configured error types and rates for this method.
@@ -33,7 +69,9 @@ func newStoreFaultGenerator(cfg *config.FaultInjectionDataStoreConfig) *storeFau
 // but no error is sampled, then this method returns nil.
 // When this method returns nil, this causes the persistence layer to use the real implementation.
 func (d *storeFaultGenerator) generate(methodName string) *fault {
+	d.RLock()
 	methodGenerator, ok := d.methodFaultGenerators[methodName]
+	d.RUnlock()
 	if !ok {
 		return nil
 	}
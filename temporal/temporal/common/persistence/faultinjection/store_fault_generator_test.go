@@ -4,11 +4,13 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.temporal.io/api/serviceerror"
 	"go.temporal.io/server/common/config"
+	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/persistence"
 	"go.temporal.io/server/common/persistence/mock"
 	"go.uber.org/mock/gomock"
@@ -25,7 +27,7 @@ func TestFaultInjection_DataStoreFactory_CreateErr(t *testing.T) {
 	errCreate := errors.New("error creating QueueV2")
 	dataStoreFactory.EXPECT().NewQueueV2().Return(nil, errCreate)
 
-	factory := NewFaultInjectionDatastoreFactory(&config.FaultInjection{}, dataStoreFactory)
+	factory := NewFaultInjectionDatastoreFactory(NewRules(&config.FaultInjection{}, nil, log.NewNoopLogger()), dataStoreFactory)
 
 	_, err := factory.NewQueueV2()
 	assert.ErrorIs(t, err, errCreate)
@@ -91,7 +93,7 @@ func TestFaultInjection_Inject(t *testing.T) {
 
 			ctrl := gomock.NewController(t)
 			baseFactory := mock.NewMockDataStoreFactory(ctrl)
-			factory := NewFaultInjectionDatastoreFactory(faultInjectionConfig, baseFactory)
+			factory := NewFaultInjectionDatastoreFactory(NewRules(faultInjectionConfig, nil, log.NewNoopLogger()), baseFactory)
 			baseQueue := mock.NewMockQueueV2(ctrl)
 			baseFactory.EXPECT().NewQueueV2().Return(baseQueue, nil)
 
@@ -144,7 +146,7 @@ func TestFaultInjection_StoreNotConfigured(t *testing.T) {
 
 	ctrl := gomock.NewController(t)
 	baseFactory := mock.NewMockDataStoreFactory(ctrl)
-	factory := NewFaultInjectionDatastoreFactory(faultInjectionConfig, baseFactory)
+	factory := NewFaultInjectionDatastoreFactory(NewRules(faultInjectionConfig, nil, log.NewNoopLogger()), baseFactory)
 	baseQueue := mock.NewMockQueueV2(ctrl)
 	baseFactory.EXPECT().NewQueueV2().Return(baseQueue, nil)
 
@@ -166,3 +168,59 @@ func TestFaultInjection_StoreNotConfigured(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, resp2)
 }
+
+func TestFaultInjection_ExecuteAndDeadlineExceeded(t *testing.T) {
+	t.Parallel()
+
+	faultInjectionConfig := (&config.FaultInjection{}).
+		WithError(config.ShardStoreName, "UpdateShard", "ExecuteAndDeadlineExceeded", 1)
+
+	ctrl := gomock.NewController(t)
+	baseFactory := mock.NewMockDataStoreFactory(ctrl)
+	factory := NewFaultInjectionDatastoreFactory(NewRules(faultInjectionConfig, nil, log.NewNoopLogger()), baseFactory)
+	baseShardStore := mock.NewMockShardStore(ctrl)
+	baseFactory.EXPECT().NewShardStore().Return(baseShardStore, nil)
+
+	s, err := factory.NewShardStore()
+	require.NoError(t, err)
+
+	// the write lands, but the caller only sees the deadline
+	baseShardStore.EXPECT().UpdateShard(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	err = s.UpdateShard(context.Background(), nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, err.Error(), "fault injection error")
+}
+
+func TestFaultInjection_Latency(t *testing.T) {
+	t.Parallel()
+
+	faultInjectionConfig := (&config.FaultInjection{}).
+		WithMethodLatency(config.ShardStoreName, "UpdateShard", config.FaultInjectionLatencyConfig{
+			Rate: 1,
+			Min:  50 * time.Millisecond,
+		}).
+		WithMethodLatency(config.ShardStoreName, "GetOrCreateShard", config.FaultInjectionLatencyConfig{
+			Rate: 1,
+			Min:  time.Hour,
+		})
+
+	ctrl := gomock.NewController(t)
+	baseFactory := mock.NewMockDataStoreFactory(ctrl)
+	factory := NewFaultInjectionDatastoreFactory(NewRules(faultInjectionConfig, nil, log.NewNoopLogger()), baseFactory)
+	baseShardStore := mock.NewMockShardStore(ctrl)
+	baseFactory.EXPECT().NewShardStore().Return(baseShardStore, nil)
+
+	s, err := factory.NewShardStore()
+	require.NoError(t, err)
+
+	baseShardStore.EXPECT().UpdateShard(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	start := time.Now()
+	require.NoError(t, s.UpdateShard(context.Background(), nil))
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+
+	// the latency is cut short by the deadline of the caller, the call never reaches the base store
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = s.GetOrCreateShard(ctx, nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -12,6 +12,7 @@ import (
 	persistencespb "go.temporal.io/server/api/persistence/v1"
 	"go.temporal.io/server/common"
 	"go.temporal.io/server/common/log/tag"
+	"go.temporal.io/server/common/persistence/serialization"
 	"go.temporal.io/server/common/primitives/timestamp"
 	"go.temporal.io/server/common/softassert"
 )
@@ -247,10 +248,13 @@ func (m *executionManagerImpl) TrimHistoryBranch(
 			return nil, fmt.Errorf("unable to deserialize token: %w", err)
 		}
 
+		// only the metadata of the nodes is read, so the events don't need to be decrypted
 		nodes, token, err := m.readRawHistoryBranch(
 			ctx,
+			"",
 			request.BranchToken,
 			shardID,
+			treeID,
 			branchAncestors,
 			minNodeID,
 			maxNodeID,
@@ -329,6 +333,12 @@ func (m *executionManagerImpl) serializeAppendHistoryNodesRequest(
 		return nil, serviceerror.NewInvalidArgument(fmt.Sprintf("unable to parse branch token: %v", err))
 	}
 
+	if request.NamespaceID == "" {
+		return nil, &InvalidPersistenceRequestError{
+			Msg: "namespace ID cannot be empty",
+		}
+	}
+
 	if len(request.Events) == 0 {
 		return nil, &InvalidPersistenceRequestError{
 			Msg: "events to be appended cannot be empty",
@@ -418,6 +428,12 @@ func (m *executionManagerImpl) serializeAppendRawHistoryNodesRequest(
 		return nil, serviceerror.NewInvalidArgument(fmt.Sprintf("unable to parse branch token: %v", err))
 	}
 
+	if request.NamespaceID == "" {
+		return nil, &InvalidPersistenceRequestError{
+			Msg: "namespace ID cannot be empty",
+		}
+	}
+
 	if len(request.History.Data) == 0 {
 		return nil, &InvalidPersistenceRequestError{
 			Msg: "events to be appended cannot be empty",
@@ -489,10 +505,21 @@ func (m *executionManagerImpl) AppendHistoryNodes(
 		return nil, err
 	}
 
+	// history size is based on the plaintext events
+	size := len(req.Node.Events.Data)
+	req.Node.Events, err = m.encodeBlob(
+		historyNodeBlobContext(request.NamespaceID, req),
+		serialization.BlobCategoryHistory,
+		req.Node.Events,
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	err = m.persistence.AppendHistoryNodes(ctx, req)
 
 	return &AppendHistoryNodesResponse{
-		Size: len(req.Node.Events.Data),
+		Size: size,
 	}, err
 }
 
@@ -507,6 +534,15 @@ func (m *executionManagerImpl) AppendRawHistoryNodes(
 		return nil, err
 	}
 
+	req.Node.Events, err = m.encodeBlob(
+		historyNodeBlobContext(request.NamespaceID, req),
+		serialization.BlobCategoryHistory,
+		req.Node.Events,
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	err = m.persistence.AppendHistoryNodes(ctx, req)
 	return &AppendHistoryNodesResponse{
 		Size: len(request.History.Data),
@@ -551,6 +587,12 @@ func (m *executionManagerImpl) ReadRawHistoryBranch(
 	if err != nil {
 		return nil, err
 	}
+	// Raw history is returned to clients and remote clusters, which may not support compressed blobs.
+	for i, blob := range dataBlobs {
+		if dataBlobs[i], err = serialization.Decompress(blob); err != nil {
+			return nil, err
+		}
+	}
 
 	nextPageToken, err := m.serializeToken(token, false)
 	if err != nil {
@@ -607,8 +649,10 @@ func (m *executionManagerImpl) GetAllHistoryTreeBranches(
 
 func (m *executionManagerImpl) readRawHistoryBranch(
 	ctx context.Context,
+	namespaceID string,
 	branchToken []byte,
 	shardID int32,
+	treeID string,
 	branchAncestors []*persistencespb.HistoryBranchRange,
 	minNodeID int64,
 	maxNodeID int64,
@@ -660,11 +704,15 @@ func (m *executionManagerImpl) readRawHistoryBranch(
 		return nil, nil, err
 	}
 	token.StoreToken = resp.NextPageToken
+	if err := m.decryptHistoryNodes(namespaceID, treeID, branchID, resp.Nodes); err != nil {
+		return nil, nil, err
+	}
 	return resp.Nodes, token, nil
 }
 
 func (m *executionManagerImpl) readRawHistoryBranchReverse(
 	ctx context.Context,
+	namespaceID string,
 	branchToken []byte,
 	shardID int32,
 	treeID string,
@@ -721,9 +769,40 @@ func (m *executionManagerImpl) readRawHistoryBranchReverse(
 		return nil, nil, err
 	}
 	token.StoreToken = resp.NextPageToken
+	if err := m.decryptHistoryNodes(namespaceID, treeID, branchID, resp.Nodes); err != nil {
+		return nil, nil, err
+	}
 	return resp.Nodes, token, nil
 }
 
+// decryptHistoryNodes decrypts the encrypted events of the history nodes of a branch in place.
+func (m *executionManagerImpl) decryptHistoryNodes(
+	namespaceID string,
+	treeID string,
+	branchID string,
+	nodes []InternalHistoryNode,
+) error {
+	for i := range nodes {
+		if !serialization.IsEncrypted(nodes[i].Events) {
+			continue
+		}
+		if namespaceID == "" {
+			return &InvalidPersistenceRequestError{
+				Msg: "namespace ID is required to read encrypted history",
+			}
+		}
+		events, err := m.blobEncryptor.Decrypt(
+			serialization.HistoryNodeBlobContext(namespaceID, treeID, branchID, nodes[i].NodeID, nodes[i].TransactionID),
+			nodes[i].Events,
+		)
+		if err != nil {
+			return err
+		}
+		nodes[i].Events = events
+	}
+	return nil
+}
+
 func (m *executionManagerImpl) readRawHistoryBranchAndFilter(
 	ctx context.Context,
 	request *ReadHistoryBranchRequest,
@@ -763,8 +842,10 @@ func (m *executionManagerImpl) readRawHistoryBranchAndFilter(
 
 	nodes, token, err := m.readRawHistoryBranch(
 		ctx,
+		request.NamespaceID,
 		branchToken,
 		shardID,
+		branch.TreeId,
 		branchAncestors,
 		minNodeID,
 		maxNodeID,
@@ -855,6 +936,7 @@ func (m *executionManagerImpl) readRawHistoryBranchReverseAndFilter(
 
 	nodes, token, err := m.readRawHistoryBranchReverse(
 		ctx,
+		request.NamespaceID,
 		branchToken,
 		shardID,
 		treeID,
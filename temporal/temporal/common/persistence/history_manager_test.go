@@ -2,15 +2,25 @@ package persistence_test
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"slices"
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
+	enumspb "go.temporal.io/api/enums/v1"
+	historypb "go.temporal.io/api/history/v1"
 	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/server/common/clock"
 	"go.temporal.io/server/common/dynamicconfig"
 	"go.temporal.io/server/common/log"
 	p "go.temporal.io/server/common/persistence"
 	"go.temporal.io/server/common/persistence/mock"
 	"go.temporal.io/server/common/persistence/serialization"
+	"go.temporal.io/server/common/testing/protorequire"
 	"go.uber.org/mock/gomock"
 )
 
@@ -115,6 +125,8 @@ func TestHistoryManager_InvalidBranchToken_ReturnsInvalidArgument(t *testing.T)
 				log.NewNoopLogger(),
 				dynamicconfig.GetIntPropertyFn(1024*1024),
 				dynamicconfig.GetBoolPropertyFn(false),
+				nil,
+				nil,
 			)
 
 			tc.testFunc(t, em, invalidBranchToken)
@@ -122,6 +134,159 @@ func TestHistoryManager_InvalidBranchToken_ReturnsInvalidArgument(t *testing.T)
 	}
 }
 
+func TestHistoryManager_MixedEncryptedAndPlaintextHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	keyFile := filepath.Join(t.TempDir(), "keys.yaml")
+	require.NoError(t, os.WriteFile(keyFile, []byte("currentKeyId: key-1\nkeys:\n  key-1: "+base64.StdEncoding.EncodeToString(key)), 0o600))
+	keyProvider, err := serialization.NewFileKeyProvider(keyFile, log.NewNoopLogger(), clock.NewRealTimeSource())
+	require.NoError(t, err)
+	dcClient := dynamicconfig.NewMemoryClient()
+	encryptor := serialization.NewBlobEncryptor(keyProvider, dynamicconfig.NewCollection(dcClient, log.NewNoopLogger()), clock.NewRealTimeSource())
+
+	serializer := serialization.NewSerializer()
+	historyBranchUtil := p.NewHistoryBranchUtil(serializer)
+	var nodes []p.InternalHistoryNode
+	store := mock.NewMockExecutionStore(ctrl)
+	store.EXPECT().GetHistoryBranchUtil().AnyTimes().Return(historyBranchUtil)
+	store.EXPECT().AppendHistoryNodes(gomock.Any(), gomock.Any()).Times(3).DoAndReturn(
+		func(_ context.Context, request *p.InternalAppendHistoryNodesRequest) error {
+			nodes = append(nodes, request.Node)
+			return nil
+		},
+	)
+	store.EXPECT().ReadHistoryBranch(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+		func(_ context.Context, _ *p.InternalReadHistoryBranchRequest) (*p.InternalReadHistoryBranchResponse, error) {
+			return &p.InternalReadHistoryBranchResponse{Nodes: slices.Clone(nodes)}, nil
+		},
+	)
+
+	em := p.NewExecutionManager(
+		store,
+		serializer,
+		nil,
+		log.NewNoopLogger(),
+		dynamicconfig.GetIntPropertyFn(1024*1024),
+		dynamicconfig.GetBoolPropertyFn(false),
+		nil,
+		encryptor,
+	)
+
+	branchToken, err := historyBranchUtil.NewHistoryBranch("namespace-id", "workflow-id", "run-id", uuid.NewString(), nil, nil, 0, 0, 0)
+	require.NoError(t, err)
+	events := []*historypb.HistoryEvent{
+		{EventId: 1, EventType: enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED},
+		{EventId: 2, EventType: enumspb.EVENT_TYPE_WORKFLOW_TASK_SCHEDULED},
+		{EventId: 3, EventType: enumspb.EVENT_TYPE_WORKFLOW_TASK_STARTED},
+		{EventId: 4, EventType: enumspb.EVENT_TYPE_WORKFLOW_TASK_COMPLETED},
+	}
+
+	// the namespace decides whether the events are encrypted, so it is required
+	_, err = em.AppendHistoryNodes(context.Background(), &p.AppendHistoryNodesRequest{
+		ShardID:       1,
+		IsNewBranch:   true,
+		BranchToken:   branchToken,
+		Events:        events[:2],
+		TransactionID: 1,
+	})
+	require.ErrorAs(t, err, new(*p.InvalidPersistenceRequestError))
+
+	// the first batch is written before encryption is enabled for the namespace
+	_, err = em.AppendHistoryNodes(context.Background(), &p.AppendHistoryNodesRequest{
+		ShardID:       1,
+		IsNewBranch:   true,
+		NamespaceID:   "namespace-id",
+		BranchToken:   branchToken,
+		Events:        events[:2],
+		TransactionID: 1,
+	})
+	require.NoError(t, err)
+	dcClient.OverrideSetting(dynamicconfig.PersistenceBlobEncryptionEnabled, true)
+	_, err = em.AppendHistoryNodes(context.Background(), &p.AppendHistoryNodesRequest{
+		ShardID:           1,
+		NamespaceID:       "namespace-id",
+		BranchToken:       branchToken,
+		Events:            events[2:3],
+		PrevTransactionID: 1,
+		TransactionID:     2,
+	})
+	require.NoError(t, err)
+	rawBatch, err := serializer.SerializeEvents(events[3:])
+	require.NoError(t, err)
+	_, err = em.AppendRawHistoryNodes(context.Background(), &p.AppendRawHistoryNodesRequest{
+		ShardID:           1,
+		NamespaceID:       "namespace-id",
+		BranchToken:       branchToken,
+		History:           rawBatch,
+		PrevTransactionID: 2,
+		TransactionID:     3,
+		NodeID:            4,
+	})
+	require.NoError(t, err)
+	require.False(t, serialization.IsEncrypted(nodes[0].Events))
+	require.True(t, serialization.IsEncrypted(nodes[1].Events))
+	require.True(t, serialization.IsEncrypted(nodes[2].Events))
+
+	readRequest := &p.ReadHistoryBranchRequest{
+		ShardID:     1,
+		NamespaceID: "namespace-id",
+		BranchToken: branchToken,
+		MinEventID:  1,
+		MaxEventID:  5,
+		PageSize:    10,
+	}
+	history, err := em.ReadHistoryBranch(context.Background(), readRequest)
+	require.NoError(t, err)
+	protorequire.ProtoSliceEqual(t, events, history.HistoryEvents)
+
+	// the events are bound to the namespace, which is required to decrypt them
+	_, err = em.ReadHistoryBranch(context.Background(), &p.ReadHistoryBranchRequest{
+		ShardID:     1,
+		BranchToken: branchToken,
+		MinEventID:  1,
+		MaxEventID:  5,
+		PageSize:    10,
+	})
+	require.ErrorAs(t, err, new(*p.InvalidPersistenceRequestError))
+	_, err = em.ReadHistoryBranch(context.Background(), &p.ReadHistoryBranchRequest{
+		ShardID:     1,
+		NamespaceID: "other-namespace-id",
+		BranchToken: branchToken,
+		MinEventID:  1,
+		MaxEventID:  5,
+		PageSize:    10,
+	})
+	require.ErrorAs(t, err, new(*serialization.DeserializationError))
+
+	rawHistory, err := em.ReadRawHistoryBranch(context.Background(), readRequest)
+	require.NoError(t, err)
+	require.Len(t, rawHistory.HistoryEventBlobs, 3)
+	for _, blob := range rawHistory.HistoryEventBlobs {
+		require.False(t, serialization.IsEncrypted(blob), "raw history is returned decrypted")
+	}
+	rawEvents, err := serializer.DeserializeEvents(rawHistory.HistoryEventBlobs[1])
+	require.NoError(t, err)
+	protorequire.ProtoSliceEqual(t, events[2:3], rawEvents)
+
+	// without the key provider, the encrypted batch can't be read
+	emWithoutKeys := p.NewExecutionManager(
+		store,
+		serializer,
+		nil,
+		log.NewNoopLogger(),
+		dynamicconfig.GetIntPropertyFn(1024*1024),
+		dynamicconfig.GetBoolPropertyFn(false),
+		nil,
+		nil,
+	)
+	_, err = emWithoutKeys.ReadHistoryBranch(context.Background(), readRequest)
+	require.ErrorAs(t, err, new(*serialization.DeserializationError))
+}
+
 func requireInvalidArgumentError(t *testing.T, err error, operation string) {
 	t.Helper()
 	require.Error(t, err, "%s should return an error for invalid branch token", operation)
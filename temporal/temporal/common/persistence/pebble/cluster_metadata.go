@@ -0,0 +1,131 @@
+package pebble
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+
+	pebbledb "github.com/cockroachdb/pebble"
+	p "go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/persistence/sql/sqlplugin"
+)
+
+func clusterMetadataKey(clusterName string) key {
+	return newKey(tableClusterMetadata).String(clusterName)
+}
+
+func clusterMembershipKey(hostID []byte) key {
+	return newKey(tableClusterMembership).Bytes(hostID)
+}
+
+func (pdb *db) SaveClusterMetadata(
+	ctx context.Context,
+	row *sqlplugin.ClusterMetadataRow,
+) (sql.Result, error) {
+	saved := *row
+	saved.Version = row.Version + 1
+	if row.Version == 0 {
+		return pdb.insert(ctx, kv{key: clusterMetadataKey(row.ClusterName), value: encodeRow(&saved)})
+	}
+	return pdb.updateExisting(ctx, clusterMetadataKey(row.ClusterName), encodeRow(&saved))
+}
+
+func (pdb *db) ListClusterMetadata(
+	_ context.Context,
+	filter *sqlplugin.ClusterMetadataFilter,
+) ([]sqlplugin.ClusterMetadataRow, error) {
+	lower := newKey(tableClusterMetadata)
+	if len(filter.ClusterName) != 0 {
+		lower = clusterMetadataKey(filter.ClusterName).Next()
+	}
+	return selectRows[sqlplugin.ClusterMetadataRow](pdb.reader(), lower, nil, false, pageSize(filter.PageSize))
+}
+
+func (pdb *db) GetClusterMetadata(
+	_ context.Context,
+	filter *sqlplugin.ClusterMetadataFilter,
+) (*sqlplugin.ClusterMetadataRow, error) {
+	var row sqlplugin.ClusterMetadataRow
+	if err := getRow(pdb.reader(), clusterMetadataKey(filter.ClusterName), &row); err != nil {
+		return nil, err
+	}
+	return &row, nil
+}
+
+func (pdb *db) WriteLockGetClusterMetadata(
+	ctx context.Context,
+	filter *sqlplugin.ClusterMetadataFilter,
+) (*sqlplugin.ClusterMetadataRow, error) {
+	if err := pdb.lock(ctx, clusterMetadataKey(filter.ClusterName)); err != nil {
+		return nil, err
+	}
+	return pdb.GetClusterMetadata(ctx, filter)
+}
+
+func (pdb *db) DeleteClusterMetadata(
+	ctx context.Context,
+	filter *sqlplugin.ClusterMetadataFilter,
+) (sql.Result, error) {
+	return pdb.delete(ctx, clusterMetadataKey(filter.ClusterName))
+}
+
+func (pdb *db) UpsertClusterMembership(
+	ctx context.Context,
+	row *sqlplugin.ClusterMembershipRow,
+) (sql.Result, error) {
+	return pdb.replace(ctx, kv{key: clusterMembershipKey(row.HostID), value: encodeRow(row)})
+}
+
+func (pdb *db) GetClusterMembers(
+	_ context.Context,
+	filter *sqlplugin.ClusterMembershipFilter,
+) ([]sqlplugin.ClusterMembershipRow, error) {
+	lower := newKey(tableClusterMembership)
+	if filter.HostIDGreaterThan != nil {
+		lower = clusterMembershipKey(filter.HostIDGreaterThan).Next()
+	}
+
+	var rows []sqlplugin.ClusterMembershipRow
+	err := scan(pdb.reader(), lower, nil, false, 0, func(_ []byte, value []byte) (bool, error) {
+		var row sqlplugin.ClusterMembershipRow
+		if err := decodeRow(value, &row); err != nil {
+			return false, err
+		}
+		if matchClusterMember(filter, &row) {
+			row.InsertionOrder = 0
+			rows = append(rows, row)
+		}
+		return filter.MaxRecordCount <= 0 || len(rows) < filter.MaxRecordCount, nil
+	})
+	return rows, err
+}
+
+func matchClusterMember(filter *sqlplugin.ClusterMembershipFilter, row *sqlplugin.ClusterMembershipRow) bool {
+	switch {
+	case filter.HostIDEquals != nil && !bytes.Equal(row.HostID, filter.HostIDEquals):
+		return false
+	case filter.RPCAddressEquals != "" && row.RPCAddress != filter.RPCAddressEquals:
+		return false
+	case filter.RoleEquals != p.All && row.Role != filter.RoleEquals:
+		return false
+	case !filter.LastHeartbeatAfter.IsZero() && !row.LastHeartbeat.After(filter.LastHeartbeatAfter):
+		return false
+	case !filter.RecordExpiryAfter.IsZero() && !row.RecordExpiry.After(filter.RecordExpiryAfter):
+		return false
+	case !filter.SessionStartedAfter.IsZero() && row.SessionStart.Before(filter.SessionStartedAfter):
+		return false
+	default:
+		return true
+	}
+}
+
+func (pdb *db) PruneClusterMembership(
+	ctx context.Context,
+	filter *sqlplugin.PruneClusterMembershipFilter,
+) (sql.Result, error) {
+	return pdb.update(ctx, newKey(tableClusterMembership), func(b *pebbledb.Batch) (int64, error) {
+		return deleteRange(b, newKey(tableClusterMembership), nil, 0, func(row *sqlplugin.ClusterMembershipRow) bool {
+			return row.RecordExpiry.Before(filter.PruneRecordsBefore)
+		})
+	})
+}
@@ -0,0 +1,279 @@
+package pebble
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// table identifies the keyspace of a logical table. The values are persisted as the first byte of every key, so
+// new tables must only ever be appended.
+type table byte
+
+const (
+	tableShards table = iota + 1
+	tableExecutions
+	tableCurrentExecutions
+	tableBufferedEvents
+	tableActivityInfoMaps
+	tableTimerInfoMaps
+	tableChildExecutionInfoMaps
+	tableRequestCancelInfoMaps
+	tableSignalInfoMaps
+	tableSignalsRequestedSets
+	tableChasmNodeMaps
+	tableHistoryImmediateTasks
+	tableHistoryScheduledTasks
+	tableTransferTasks
+	tableTimerTasks
+	tableReplicationTasks
+	tableReplicationTasksDLQ
+	tableVisibilityTasks
+	tableHistoryNode
+	tableHistoryTree
+	tableNamespaces
+	tableNamespaceNames
+	tableNamespaceMetadata
+	tableQueue
+	tableQueueMetadata
+	tableQueueV2Messages
+	tableQueueV2Metadata
+	tableTaskQueues
+	tableTaskQueuesV2
+	tableTasks
+	tableTasksV2
+	tableTaskQueueUserData
+	tableBuildIDToTaskQueue
+	tableClusterMetadata
+	tableClusterMembership
+	tableNexusEndpoints
+	tableNexusEndpointsVersion
+	tableSequences
+)
+
+var errCorruptedValue = errors.New("pebble: corrupted value")
+
+// key is an order preserving encoding of a tuple of columns, prefixed by the table it belongs to.
+// Integers are encoded big-endian with the sign bit flipped and variable length columns are escaped
+// and terminated, so that the byte-wise order of two keys matches the order of their tuples.
+type key []byte
+
+const (
+	escapeByte     byte = 0x00
+	escapedZero    byte = 0xff
+	terminatorByte byte = 0x01
+)
+
+func newKey(t table) key {
+	return key{byte(t)}
+}
+
+// The append methods never modify the backing array of k, so that a single prefix can be extended into
+// multiple keys.
+
+func (k key) Int32(v int32) key {
+	return binary.BigEndian.AppendUint32(k[:len(k):len(k)], uint32(v)^(1<<31))
+}
+
+func (k key) Int64(v int64) key {
+	return binary.BigEndian.AppendUint64(k[:len(k):len(k)], uint64(v)^(1<<63))
+}
+
+func (k key) Uint32(v uint32) key {
+	return binary.BigEndian.AppendUint32(k[:len(k):len(k)], v)
+}
+
+func (k key) Time(t time.Time) key {
+	return k.Int64(t.Unix()).Uint32(uint32(t.Nanosecond()))
+}
+
+func (k key) String(s string) key {
+	return k.Bytes([]byte(s))
+}
+
+func (k key) Bytes(b []byte) key {
+	k = k[:len(k):len(k)]
+	for _, c := range b {
+		if c == escapeByte {
+			k = append(k, escapeByte, escapedZero)
+			continue
+		}
+		k = append(k, c)
+	}
+	return append(k, escapeByte, terminatorByte)
+}
+
+// PrefixEnd returns the smallest key that is greater than every key having k as a prefix.
+func (k key) PrefixEnd() key {
+	end := append(key(nil), k...)
+	for i := len(end) - 1; i >= 0; i-- {
+		end[i]++
+		if end[i] != 0 {
+			return end[:i+1]
+		}
+	}
+	// k consists of 0xff bytes only, there is no upper bound
+	return nil
+}
+
+// Next returns the immediate successor of k.
+func (k key) Next() key {
+	return append(append(key(nil), k...), 0)
+}
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// encodeRow serializes all exported fields of a row struct in declaration order. Fields are encoded without
+// names, so new fields must only be appended to the row structs; decodeRow leaves trailing fields that are
+// missing from older values at their zero value.
+func encodeRow(row any) []byte {
+	v := reflect.ValueOf(row)
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	return appendValue(nil, v)
+}
+
+// decodeRow is the inverse of encodeRow, row must be a pointer to a struct.
+func decodeRow(data []byte, row any) error {
+	r := &valueReader{buf: data}
+	r.readValue(reflect.ValueOf(row).Elem())
+	return r.err
+}
+
+func appendValue(buf []byte, v reflect.Value) []byte {
+	if v.Type() == timeType {
+		t := v.Interface().(time.Time) // nolint:revive
+		buf = binary.AppendVarint(buf, t.Unix())
+		return binary.AppendUvarint(buf, uint64(t.Nanosecond()))
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if !v.Type().Field(i).IsExported() {
+				continue
+			}
+			buf = appendValue(buf, v.Field(i))
+		}
+		return buf
+	case reflect.Pointer:
+		if v.IsNil() {
+			return append(buf, 0)
+		}
+		return appendValue(append(buf, 1), v.Elem())
+	case reflect.Bool:
+		if v.Bool() {
+			return append(buf, 1)
+		}
+		return append(buf, 0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return binary.AppendVarint(buf, v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return binary.AppendUvarint(buf, v.Uint())
+	case reflect.String:
+		buf = binary.AppendUvarint(buf, uint64(v.Len()))
+		return append(buf, v.String()...)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.Uint8 {
+			panic(fmt.Sprintf("pebble: unsupported row field type %v", v.Type()))
+		}
+		// length is shifted by one so that nil and empty slices round-trip the same way SQL NULL and '' do
+		if v.IsNil() {
+			return binary.AppendUvarint(buf, 0)
+		}
+		buf = binary.AppendUvarint(buf, uint64(v.Len())+1)
+		return append(buf, v.Bytes()...)
+	default:
+		panic(fmt.Sprintf("pebble: unsupported row field type %v", v.Type()))
+	}
+}
+
+type valueReader struct {
+	buf []byte
+	err error
+}
+
+func (r *valueReader) varint() int64 {
+	v, n := binary.Varint(r.buf)
+	if n <= 0 {
+		r.err = errCorruptedValue
+		return 0
+	}
+	r.buf = r.buf[n:]
+	return v
+}
+
+func (r *valueReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.buf)
+	if n <= 0 {
+		r.err = errCorruptedValue
+		return 0
+	}
+	r.buf = r.buf[n:]
+	return v
+}
+
+func (r *valueReader) bytes(n uint64) []byte {
+	if uint64(len(r.buf)) < n {
+		r.err = errCorruptedValue
+		return nil
+	}
+	b := r.buf[:n]
+	r.buf = r.buf[n:]
+	return b
+}
+
+func (r *valueReader) readValue(v reflect.Value) {
+	if r.err != nil || len(r.buf) == 0 {
+		// values written before a field was appended to the row struct end early
+		return
+	}
+	if v.Type() == timeType {
+		sec := r.varint()
+		nsec := r.uvarint()
+		v.Set(reflect.ValueOf(time.Unix(sec, int64(nsec)).UTC()))
+		return
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if !v.Type().Field(i).IsExported() {
+				continue
+			}
+			r.readValue(v.Field(i))
+		}
+	case reflect.Pointer:
+		present := r.bytes(1)
+		if r.err != nil || present[0] == 0 {
+			return
+		}
+		elem := reflect.New(v.Type().Elem())
+		r.readValue(elem.Elem())
+		v.Set(elem)
+	case reflect.Bool:
+		b := r.bytes(1)
+		if r.err == nil {
+			v.SetBool(b[0] != 0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(r.varint())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(r.uvarint())
+	case reflect.String:
+		v.SetString(string(r.bytes(r.uvarint())))
+	case reflect.Slice:
+		n := r.uvarint()
+		if n == 0 || r.err != nil {
+			return
+		}
+		b := r.bytes(n - 1)
+		if r.err != nil {
+			return
+		}
+		v.SetBytes(append(make([]byte, 0, len(b)), b...))
+	default:
+		r.err = fmt.Errorf("pebble: unsupported row field type %v", v.Type())
+	}
+}
@@ -0,0 +1,119 @@
+package pebble
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/server/common/persistence/sql/sqlplugin"
+	"go.temporal.io/server/common/primitives"
+)
+
+func TestKey_Order(t *testing.T) {
+	t.Parallel()
+
+	ordered := [][]key{
+		{
+			newKey(tableShards).Int64(math.MinInt64),
+			newKey(tableShards).Int64(-1),
+			newKey(tableShards).Int64(0),
+			newKey(tableShards).Int64(1),
+			newKey(tableShards).Int64(math.MaxInt64),
+		},
+		{
+			newKey(tableShards).Bytes(nil).Int32(math.MaxInt32),
+			newKey(tableShards).Bytes([]byte{0}).Int32(math.MinInt32),
+			newKey(tableShards).Bytes([]byte{0, 0}),
+			newKey(tableShards).Bytes([]byte{0, 1}),
+			newKey(tableShards).Bytes([]byte{1}),
+			newKey(tableShards).Bytes([]byte{0xff}),
+		},
+		{
+			newKey(tableShards).String("a").String("z"),
+			newKey(tableShards).String("aa").String(""),
+			newKey(tableShards).String("b"),
+		},
+		{
+			newKey(tableShards).Time(time.Unix(-1, 999)),
+			newKey(tableShards).Time(time.Unix(0, 0)),
+			newKey(tableShards).Time(time.Unix(0, 1)),
+			newKey(tableShards).Time(time.Unix(1, 0)),
+		},
+	}
+	for _, keys := range ordered {
+		for i := 1; i < len(keys); i++ {
+			require.Negative(t, bytes.Compare(keys[i-1], keys[i]), "key %d should be ordered before key %d", i-1, i)
+		}
+	}
+}
+
+func TestKey_PrefixEnd(t *testing.T) {
+	t.Parallel()
+
+	prefix := newKey(tableExecutions).Int32(1)
+	end := prefix.PrefixEnd()
+	require.Positive(t, bytes.Compare(end, prefix.String("any").Int64(math.MaxInt64)))
+	require.Equal(t, newKey(tableExecutions).Int32(2), end)
+	require.Nil(t, key{0xff, 0xff}.PrefixEnd())
+}
+
+func TestKey_SharedPrefix(t *testing.T) {
+	t.Parallel()
+
+	prefix := make(key, 0, 64)
+	prefix = append(prefix, byte(tableTasks))
+	first := prefix.Int64(1)
+	second := prefix.Int64(2)
+	require.Equal(t, newKey(tableTasks).Int64(1), first)
+	require.Equal(t, newKey(tableTasks).Int64(2), second)
+}
+
+func TestRow_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	row := sqlplugin.ExecutionsRow{
+		ShardID:          1,
+		NamespaceID:      primitives.NewUUID(),
+		WorkflowID:       "workflow",
+		RunID:            primitives.NewUUID(),
+		NextEventID:      -5,
+		LastWriteVersion: math.MaxInt64,
+		Data:             []byte{},
+		DataEncoding:     "proto3",
+		State:            nil,
+		StateEncoding:    "",
+		DBRecordVersion:  3,
+	}
+	var decoded sqlplugin.ExecutionsRow
+	require.NoError(t, decodeRow(encodeRow(&row), &decoded))
+	require.Equal(t, row, decoded)
+	require.NotNil(t, decoded.Data)
+	require.Nil(t, decoded.State)
+
+	now := time.Now()
+	member := sqlplugin.ClusterMembershipRow{
+		Role:          1,
+		HostID:        []byte("host"),
+		RPCAddress:    "127.0.0.1",
+		RPCPort:       7233,
+		SessionStart:  now,
+		LastHeartbeat: now,
+		RecordExpiry:  now.Add(time.Minute),
+	}
+	var decodedMember sqlplugin.ClusterMembershipRow
+	require.NoError(t, decodeRow(encodeRow(&member), &decodedMember))
+	require.True(t, member.SessionStart.Equal(decodedMember.SessionStart))
+	require.True(t, member.RecordExpiry.Equal(decodedMember.RecordExpiry))
+	require.Equal(t, member.HostID, decodedMember.HostID)
+	require.Equal(t, member.RPCPort, decodedMember.RPCPort)
+}
+
+func TestRow_Corrupted(t *testing.T) {
+	t.Parallel()
+
+	data := encodeRow(&sqlplugin.ShardsRow{ShardID: 1, RangeID: 2, Data: []byte("data"), DataEncoding: "proto3"})
+	var row sqlplugin.ShardsRow
+	require.ErrorIs(t, decodeRow(data[:len(data)-3], &row), errCorruptedValue)
+}
@@ -0,0 +1,202 @@
+package pebble
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	pebbledb "github.com/cockroachdb/pebble"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/persistence/sql/sqlplugin"
+)
+
+type (
+	// db represents a logical connection to a pebble database. A db created by BeginTx is a transaction, it
+	// holds the locks of the store it acquired until it is committed or rolled back.
+	db struct {
+		dbKind sqlplugin.DbKind
+		dbName string
+		store  *store
+		logger log.Logger
+
+		mu      sync.Mutex
+		onClose []func()
+
+		batch    *pebbledb.Batch
+		locks    []lockID
+		finished bool
+	}
+
+	result int64
+
+	dupEntryError struct {
+		table table
+	}
+)
+
+var _ sqlplugin.DB = (*db)(nil)
+var _ sqlplugin.Tx = (*db)(nil)
+
+var errTxFinished = errors.New("pebble: transaction has already been committed or rolled back")
+
+func (e *dupEntryError) Error() string {
+	return fmt.Sprintf("pebble: duplicate entry in table %d", e.table)
+}
+
+func (r result) LastInsertId() (int64, error) {
+	return 0, errors.New("pebble: LastInsertId is not supported")
+}
+
+func (r result) RowsAffected() (int64, error) {
+	return int64(r), nil
+}
+
+// newDB returns an instance of DB, which is a logical connection to the underlying pebble database
+func newDB(
+	dbKind sqlplugin.DbKind,
+	dbName string,
+	store *store,
+	batch *pebbledb.Batch,
+	logger log.Logger,
+) *db {
+	return &db{
+		dbKind: dbKind,
+		dbName: dbName,
+		store:  store,
+		batch:  batch,
+		logger: logger,
+	}
+}
+
+// BeginTx starts a new transaction and returns a reference to the Tx object
+func (pdb *db) BeginTx(ctx context.Context) (sqlplugin.Tx, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return newDB(pdb.dbKind, pdb.dbName, pdb.store, pdb.store.db.NewIndexedBatch(), pdb.logger), nil
+}
+
+// Commit commits a previously started transaction
+func (pdb *db) Commit() error {
+	if err := pdb.finish(); err != nil {
+		return err
+	}
+	defer pdb.unlock()
+	defer func() { _ = pdb.batch.Close() }()
+	return pdb.batch.Commit(pdb.store.writeOptions)
+}
+
+// Rollback triggers rollback of a previously started transaction
+func (pdb *db) Rollback() error {
+	if err := pdb.finish(); err != nil {
+		return err
+	}
+	defer pdb.unlock()
+	return pdb.batch.Close()
+}
+
+func (pdb *db) finish() error {
+	pdb.mu.Lock()
+	defer pdb.mu.Unlock()
+	if pdb.finished {
+		return errTxFinished
+	}
+	pdb.finished = true
+	return nil
+}
+
+// lock acquires the lock of the rows under k for the rest of the transaction, the same way a locking read or a
+// write of a SQL transaction locks the rows until it ends. It is a no-op outside a transaction.
+func (pdb *db) lock(ctx context.Context, k key) error {
+	if pdb.batch == nil {
+		return nil
+	}
+	id := lockOf(k)
+	if slices.Contains(pdb.locks, id) {
+		return nil
+	}
+	if err := pdb.store.lock(ctx, id); err != nil {
+		return err
+	}
+	pdb.locks = append(pdb.locks, id)
+	return nil
+}
+
+// unlock releases the locks acquired by the transaction.
+func (pdb *db) unlock() {
+	for _, id := range pdb.locks {
+		pdb.store.unlock(id)
+	}
+	pdb.locks = nil
+}
+
+func (pdb *db) OnClose(hook func()) {
+	pdb.mu.Lock()
+	pdb.onClose = append(pdb.onClose, hook)
+	pdb.mu.Unlock()
+}
+
+// Close closes the connection to the pebble db
+func (pdb *db) Close() error {
+	pdb.mu.Lock()
+	defer pdb.mu.Unlock()
+
+	for _, hook := range pdb.onClose {
+		// de-registers the database from the store pool
+		hook()
+	}
+	pdb.onClose = nil
+	return nil
+}
+
+// PluginName returns the name of the plugin
+func (pdb *db) PluginName() string {
+	return PluginName
+}
+
+// DbName returns the name of the database
+func (pdb *db) DbName() string {
+	return pdb.dbName
+}
+
+// IsDupEntryError verifies if the error is a duplicate entry error
+func (pdb *db) IsDupEntryError(err error) bool {
+	var dupErr *dupEntryError
+	return errors.As(err, &dupErr)
+}
+
+// HasReadReplica returns false, as pebble doesn't support read replicas
+func (pdb *db) HasReadReplica() bool {
+	return false
+}
+
+// reader returns the view of the data for reads: transactions read their own writes.
+func (pdb *db) reader() pebbledb.Reader {
+	if pdb.batch != nil {
+		return pdb.batch
+	}
+	return pdb.store.db
+}
+
+// update runs fn as a single statement which writes the rows under k, k may also be the prefix of the rows. Inside a
+// transaction the writes are buffered in the transaction batch, otherwise they are committed right away. fn must not
+// write anything if it returns an error, so that a failed statement doesn't leave partial results behind, the same
+// way a failed SQL statement doesn't.
+func (pdb *db) update(ctx context.Context, k key, fn func(b *pebbledb.Batch) (int64, error)) (result, error) {
+	if pdb.batch == nil {
+		return pdb.store.update(ctx, lockOf(k), fn)
+	}
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	if pdb.finished {
+		return 0, errTxFinished
+	}
+	if err := pdb.lock(ctx, k); err != nil {
+		return 0, err
+	}
+	n, err := fn(pdb.batch)
+	return result(n), err
+}
@@ -0,0 +1,149 @@
+package pebble
+
+import (
+	"context"
+	"database/sql"
+
+	pebbledb "github.com/cockroachdb/pebble"
+	"go.temporal.io/server/common/persistence/sql/sqlplugin"
+	"go.temporal.io/server/common/primitives"
+)
+
+// historyBranchKey is the prefix of all history_node rows of a branch. Like in the SQL plugins, the txn_id is
+// stored negated, so that the latest transaction of a node is ordered first.
+func historyBranchKey(shardID int32, treeID primitives.UUID, branchID primitives.UUID) key {
+	return newKey(tableHistoryNode).Int32(shardID).Bytes(treeID).Bytes(branchID)
+}
+
+func historyTreeKey(shardID int32, treeID primitives.UUID) key {
+	return newKey(tableHistoryTree).Int32(shardID).Bytes(treeID)
+}
+
+// For history_node table:
+
+// InsertIntoHistoryNode inserts a row into history_node table
+func (pdb *db) InsertIntoHistoryNode(
+	ctx context.Context,
+	row *sqlplugin.HistoryNodeRow,
+) (sql.Result, error) {
+	// NOTE: txn_id is *= -1 within DB
+	row.TxnID = -row.TxnID
+	return pdb.replace(ctx, kv{
+		key:   historyBranchKey(row.ShardID, row.TreeID, row.BranchID).Int64(row.NodeID).Int64(row.TxnID),
+		value: encodeRow(row),
+	})
+}
+
+// DeleteFromHistoryNode delete a row from history_node table
+func (pdb *db) DeleteFromHistoryNode(
+	ctx context.Context,
+	row *sqlplugin.HistoryNodeRow,
+) (sql.Result, error) {
+	// NOTE: txn_id is *= -1 within DB
+	row.TxnID = -row.TxnID
+	return pdb.delete(ctx,
+		historyBranchKey(row.ShardID, row.TreeID, row.BranchID).Int64(row.NodeID).Int64(row.TxnID),
+	)
+}
+
+// RangeSelectFromHistoryNode reads one or more rows from history_node table
+func (pdb *db) RangeSelectFromHistoryNode(
+	_ context.Context,
+	filter sqlplugin.HistoryNodeSelectFilter,
+) ([]sqlplugin.HistoryNodeRow, error) {
+	prefix := historyBranchKey(filter.ShardID, filter.TreeID, filter.BranchID)
+
+	var rows []sqlplugin.HistoryNodeRow
+	var err error
+	if filter.ReverseOrder {
+		// mirrors the predicate of the SQL plugins:
+		// node_id >= MinNodeID AND ((node_id = MaxTxnID AND txn_id < -MaxTxnID) OR node_id < MaxNodeID)
+		err = scan(pdb.reader(), prefix.Int64(filter.MinNodeID), prefix.PrefixEnd(), true, 0,
+			func(_ []byte, value []byte) (bool, error) {
+				var row sqlplugin.HistoryNodeRow
+				if err := decodeRow(value, &row); err != nil {
+					return false, err
+				}
+				if (row.NodeID == filter.MaxTxnID && row.TxnID < -filter.MaxTxnID) || row.NodeID < filter.MaxNodeID {
+					rows = append(rows, row)
+				}
+				return filter.PageSize <= 0 || len(rows) < filter.PageSize, nil
+			},
+		)
+	} else {
+		rows, err = selectRows[sqlplugin.HistoryNodeRow](
+			pdb.reader(),
+			prefix.Int64(filter.MinNodeID).Int64(-filter.MinTxnID).Next(), // NOTE: transaction ID is *= -1 when stored
+			prefix.Int64(filter.MaxNodeID),
+			false,
+			filter.PageSize,
+		)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range rows {
+		rows[i].TxnID = -rows[i].TxnID
+		if filter.MetadataOnly {
+			rows[i].Data = nil
+			rows[i].DataEncoding = ""
+		}
+	}
+	return rows, nil
+}
+
+// RangeDeleteFromHistoryNode deletes one or more rows from history_node table
+func (pdb *db) RangeDeleteFromHistoryNode(
+	ctx context.Context,
+	filter sqlplugin.HistoryNodeDeleteFilter,
+) (sql.Result, error) {
+	prefix := historyBranchKey(filter.ShardID, filter.TreeID, filter.BranchID)
+	return pdb.update(ctx, prefix, func(b *pebbledb.Batch) (int64, error) {
+		return deleteRange[struct{}](b, prefix.Int64(filter.MinNodeID), prefix.PrefixEnd(), 0, nil)
+	})
+}
+
+// For history_tree table:
+
+// InsertIntoHistoryTree inserts a row into history_tree table
+func (pdb *db) InsertIntoHistoryTree(
+	ctx context.Context,
+	row *sqlplugin.HistoryTreeRow,
+) (sql.Result, error) {
+	return pdb.replace(ctx, kv{
+		key:   historyTreeKey(row.ShardID, row.TreeID).Bytes(row.BranchID),
+		value: encodeRow(row),
+	})
+}
+
+// SelectFromHistoryTree reads one or more rows from history_tree table
+func (pdb *db) SelectFromHistoryTree(
+	_ context.Context,
+	filter sqlplugin.HistoryTreeSelectFilter,
+) ([]sqlplugin.HistoryTreeRow, error) {
+	return selectPrefix[sqlplugin.HistoryTreeRow](pdb.reader(), historyTreeKey(filter.ShardID, filter.TreeID))
+}
+
+// PaginateBranchesFromHistoryTree reads up to page.Limit rows from the history_tree table sorted by their primary
+// key, starting after the row identified by page.
+func (pdb *db) PaginateBranchesFromHistoryTree(
+	_ context.Context,
+	page sqlplugin.HistoryTreeBranchPage,
+) ([]sqlplugin.HistoryTreeRow, error) {
+	return selectRows[sqlplugin.HistoryTreeRow](
+		pdb.reader(),
+		historyTreeKey(page.ShardID, page.TreeID).Bytes(page.BranchID).Next(),
+		nil,
+		false,
+		page.Limit,
+	)
+}
+
+// DeleteFromHistoryTree deletes one or more rows from history_tree table
+func (pdb *db) DeleteFromHistoryTree(
+	ctx context.Context,
+	filter sqlplugin.HistoryTreeDeleteFilter,
+) (sql.Result, error) {
+	return pdb.delete(ctx, historyTreeKey(filter.ShardID, filter.TreeID).Bytes(filter.BranchID))
+}
@@ -0,0 +1,238 @@
+package pebble
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+
+	pebbledb "github.com/cockroachdb/pebble"
+	"go.temporal.io/server/chasm"
+	"go.temporal.io/server/common/persistence/sql/sqlplugin"
+	"go.temporal.io/server/common/primitives"
+	"go.temporal.io/server/common/softassert"
+)
+
+// executionKey is the key of a row in executions table, and the prefix of the keys of all rows which belong to
+// a single execution in the other execution tables.
+func executionKey(
+	t table,
+	shardID int32,
+	namespaceID primitives.UUID,
+	workflowID string,
+	runID primitives.UUID,
+) key {
+	return newKey(t).Int32(shardID).Bytes(namespaceID).String(workflowID).Bytes(runID)
+}
+
+func currentExecutionKey(
+	shardID int32,
+	namespaceID primitives.UUID,
+	workflowID string,
+	archetypeID chasm.ArchetypeID,
+) key {
+	return newKey(tableCurrentExecutions).Int32(shardID).Bytes(namespaceID).String(workflowID).Uint32(archetypeID)
+}
+
+// InsertIntoExecutions inserts a row into executions table
+func (pdb *db) InsertIntoExecutions(
+	ctx context.Context,
+	row *sqlplugin.ExecutionsRow,
+) (sql.Result, error) {
+	return pdb.insert(ctx, kv{
+		key:   executionKey(tableExecutions, row.ShardID, row.NamespaceID, row.WorkflowID, row.RunID),
+		value: encodeRow(row),
+	})
+}
+
+// UpdateExecutions updates a single row in executions table
+func (pdb *db) UpdateExecutions(
+	ctx context.Context,
+	row *sqlplugin.ExecutionsRow,
+) (sql.Result, error) {
+	return pdb.updateExisting(ctx,
+		executionKey(tableExecutions, row.ShardID, row.NamespaceID, row.WorkflowID, row.RunID),
+		encodeRow(row),
+	)
+}
+
+// SelectFromExecutions reads a single row from executions table
+func (pdb *db) SelectFromExecutions(
+	_ context.Context,
+	filter sqlplugin.ExecutionsFilter,
+) (*sqlplugin.ExecutionsRow, error) {
+	var row sqlplugin.ExecutionsRow
+	if err := getRow(
+		pdb.reader(),
+		executionKey(tableExecutions, filter.ShardID, filter.NamespaceID, filter.WorkflowID, filter.RunID),
+		&row,
+	); err != nil {
+		return nil, err
+	}
+	return &row, nil
+}
+
+// RangeSelectFromExecutions reads one or more rows from executions table
+func (pdb *db) RangeSelectFromExecutions(
+	_ context.Context,
+	filter sqlplugin.ExecutionsRangeFilter,
+) ([]sqlplugin.ExecutionsRow, error) {
+	prefix := newKey(tableExecutions).Int32(filter.ShardID)
+	lower := prefix
+	if len(filter.ExclusiveMinNamespaceID) > 0 {
+		lower = executionKey(
+			tableExecutions,
+			filter.ShardID,
+			filter.ExclusiveMinNamespaceID,
+			filter.ExclusiveMinWorkflowID,
+			filter.ExclusiveMinRunID,
+		).Next()
+	}
+	return selectRows[sqlplugin.ExecutionsRow](pdb.reader(), lower, prefix.PrefixEnd(), false, filter.PageSize)
+}
+
+// DeleteFromExecutions deletes a single row from executions table
+func (pdb *db) DeleteFromExecutions(
+	ctx context.Context,
+	filter sqlplugin.ExecutionsFilter,
+) (sql.Result, error) {
+	return pdb.delete(ctx,
+		executionKey(tableExecutions, filter.ShardID, filter.NamespaceID, filter.WorkflowID, filter.RunID),
+	)
+}
+
+// ReadLockExecutions acquires a write lock on a single row in executions table
+func (pdb *db) ReadLockExecutions(
+	ctx context.Context,
+	filter sqlplugin.ExecutionsFilter,
+) (int64, int64, error) {
+	if err := pdb.lock(ctx, shardKey(filter.ShardID)); err != nil {
+		return 0, 0, err
+	}
+	row, err := pdb.SelectFromExecutions(ctx, filter)
+	if err != nil {
+		return 0, 0, err
+	}
+	return row.DBRecordVersion, row.NextEventID, nil
+}
+
+// WriteLockExecutions acquires a write lock on a single row in executions table
+func (pdb *db) WriteLockExecutions(
+	ctx context.Context,
+	filter sqlplugin.ExecutionsFilter,
+) (int64, int64, error) {
+	return pdb.ReadLockExecutions(ctx, filter)
+}
+
+// InsertIntoCurrentExecutions inserts a single row into current_executions table
+func (pdb *db) InsertIntoCurrentExecutions(
+	ctx context.Context,
+	row *sqlplugin.CurrentExecutionsRow,
+) (sql.Result, error) {
+	if err := pdb.assertArchetypeIDSpecified(row.ArchetypeID); err != nil {
+		return nil, err
+	}
+	return pdb.insert(ctx, kv{
+		key:   currentExecutionKey(row.ShardID, row.NamespaceID, row.WorkflowID, row.ArchetypeID),
+		value: encodeRow(row),
+	})
+}
+
+// UpdateCurrentExecutions updates a single row in current_executions table
+func (pdb *db) UpdateCurrentExecutions(
+	ctx context.Context,
+	row *sqlplugin.CurrentExecutionsRow,
+) (sql.Result, error) {
+	if err := pdb.assertArchetypeIDSpecified(row.ArchetypeID); err != nil {
+		return nil, err
+	}
+	return pdb.updateExisting(ctx,
+		currentExecutionKey(row.ShardID, row.NamespaceID, row.WorkflowID, row.ArchetypeID),
+		encodeRow(row),
+	)
+}
+
+// SelectFromCurrentExecutions reads one or more rows from current_executions table
+func (pdb *db) SelectFromCurrentExecutions(
+	_ context.Context,
+	filter sqlplugin.CurrentExecutionsFilter,
+) (*sqlplugin.CurrentExecutionsRow, error) {
+	if err := pdb.assertArchetypeIDSpecified(filter.ArchetypeID); err != nil {
+		return nil, err
+	}
+	var row sqlplugin.CurrentExecutionsRow
+	if err := getRow(
+		pdb.reader(),
+		currentExecutionKey(filter.ShardID, filter.NamespaceID, filter.WorkflowID, filter.ArchetypeID),
+		&row,
+	); err != nil {
+		return nil, err
+	}
+	return &row, nil
+}
+
+// DeleteFromCurrentExecutions deletes a single row in current_executions table
+func (pdb *db) DeleteFromCurrentExecutions(
+	ctx context.Context,
+	filter sqlplugin.CurrentExecutionsFilter,
+) (sql.Result, error) {
+	if err := pdb.assertArchetypeIDSpecified(filter.ArchetypeID); err != nil {
+		return nil, err
+	}
+	k := currentExecutionKey(filter.ShardID, filter.NamespaceID, filter.WorkflowID, filter.ArchetypeID)
+	return pdb.update(ctx, k, func(b *pebbledb.Batch) (int64, error) {
+		var row sqlplugin.CurrentExecutionsRow
+		ok, err := get(b, k, &row)
+		if err != nil || !ok || !bytes.Equal(row.RunID, filter.RunID) {
+			return 0, err
+		}
+		return deleteKeys(b, k)
+	})
+}
+
+// LockCurrentExecutions acquires a write lock on a single row in current_executions table
+func (pdb *db) LockCurrentExecutions(
+	ctx context.Context,
+	filter sqlplugin.CurrentExecutionsFilter,
+) (*sqlplugin.CurrentExecutionsRow, error) {
+	if err := pdb.lock(ctx, shardKey(filter.ShardID)); err != nil {
+		return nil, err
+	}
+	return pdb.SelectFromCurrentExecutions(ctx, filter)
+}
+
+// LockCurrentExecutionsJoinExecutions joins a row in current_executions with executions table and acquires a
+// write lock on the result
+func (pdb *db) LockCurrentExecutionsJoinExecutions(
+	ctx context.Context,
+	filter sqlplugin.CurrentExecutionsFilter,
+) ([]sqlplugin.CurrentExecutionsRow, error) {
+	if err := pdb.lock(ctx, shardKey(filter.ShardID)); err != nil {
+		return nil, err
+	}
+	row, err := pdb.SelectFromCurrentExecutions(ctx, filter)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var execution sqlplugin.ExecutionsRow
+	ok, err := get(
+		pdb.reader(),
+		executionKey(tableExecutions, row.ShardID, row.NamespaceID, row.WorkflowID, row.RunID),
+		&execution,
+	)
+	if err != nil || !ok {
+		return nil, err
+	}
+	row.LastWriteVersion = execution.LastWriteVersion
+	return []sqlplugin.CurrentExecutionsRow{*row}, nil
+}
+
+func (pdb *db) assertArchetypeIDSpecified(archetypeID chasm.ArchetypeID) error {
+	if archetypeID == chasm.UnspecifiedArchetypeID {
+		return softassert.UnexpectedInternalErr(pdb.logger, "ArchetypeID not specified", nil)
+	}
+	return nil
+}
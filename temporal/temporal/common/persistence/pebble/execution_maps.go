@@ -0,0 +1,429 @@
+package pebble
+
+import (
+	"context"
+	"database/sql"
+
+	pebbledb "github.com/cockroachdb/pebble"
+	"go.temporal.io/server/common/persistence/sql/sqlplugin"
+)
+
+type sequenceValue struct {
+	Next int64
+}
+
+// nextSequence returns the next value of the sequence of table t in a shard, similar to an auto increment column.
+// The sequences are kept per shard, so that they are guarded by the lock of the shard. The sequence of a shard starts
+// from the sequence of the whole table, which was used before, so that the values used before are not returned again.
+func nextSequence(b *pebbledb.Batch, t table, shardID int32) (int64, error) {
+	tableKey := newKey(tableSequences).Uint32(uint32(t))
+	k := tableKey.Int32(shardID)
+	var seq sequenceValue
+	ok, err := get(b, k, &seq)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		if _, err := get(b, tableKey, &seq); err != nil {
+			return 0, err
+		}
+	}
+	seq.Next++
+	return seq.Next, b.Set(k, encodeRow(&seq), nil)
+}
+
+// InsertIntoBufferedEvents inserts one or more rows into buffered_events table
+func (pdb *db) InsertIntoBufferedEvents(
+	ctx context.Context,
+	rows []sqlplugin.BufferedEventsRow,
+) (sql.Result, error) {
+	var k key
+	if len(rows) > 0 {
+		k = shardTaskKey(tableBufferedEvents, rows[0].ShardID)
+	}
+	return pdb.update(ctx, k, func(b *pebbledb.Batch) (int64, error) {
+		for i := range rows {
+			row := &rows[i]
+			id, err := nextSequence(b, tableBufferedEvents, row.ShardID)
+			if err != nil {
+				return 0, err
+			}
+			k := executionKey(tableBufferedEvents, row.ShardID, row.NamespaceID, row.WorkflowID, row.RunID).Int64(id)
+			if err := b.Set(k, encodeRow(row), nil); err != nil {
+				return 0, err
+			}
+		}
+		return int64(len(rows)), nil
+	})
+}
+
+// SelectFromBufferedEvents reads one or more rows from buffered_events table
+func (pdb *db) SelectFromBufferedEvents(
+	_ context.Context,
+	filter sqlplugin.BufferedEventsFilter,
+) ([]sqlplugin.BufferedEventsRow, error) {
+	return selectPrefix[sqlplugin.BufferedEventsRow](
+		pdb.reader(),
+		executionKey(tableBufferedEvents, filter.ShardID, filter.NamespaceID, filter.WorkflowID, filter.RunID),
+	)
+}
+
+// DeleteFromBufferedEvents deletes one or more rows from buffered_events table
+func (pdb *db) DeleteFromBufferedEvents(
+	ctx context.Context,
+	filter sqlplugin.BufferedEventsFilter,
+) (sql.Result, error) {
+	return pdb.deletePrefix(ctx,
+		executionKey(tableBufferedEvents, filter.ShardID, filter.NamespaceID, filter.WorkflowID, filter.RunID),
+	)
+}
+
+// ReplaceIntoActivityInfoMaps replaces one or more rows in activity_info_maps table
+func (pdb *db) ReplaceIntoActivityInfoMaps(
+	ctx context.Context,
+	rows []sqlplugin.ActivityInfoMapsRow,
+) (sql.Result, error) {
+	kvs := make([]kv, len(rows))
+	for i := range rows {
+		row := &rows[i]
+		kvs[i] = kv{
+			key:   executionKey(tableActivityInfoMaps, row.ShardID, row.NamespaceID, row.WorkflowID, row.RunID).Int64(row.ScheduleID),
+			value: encodeRow(row),
+		}
+	}
+	return pdb.replace(ctx, kvs...)
+}
+
+// SelectAllFromActivityInfoMaps reads all rows from activity_info_maps table
+func (pdb *db) SelectAllFromActivityInfoMaps(
+	_ context.Context,
+	filter sqlplugin.ActivityInfoMapsAllFilter,
+) ([]sqlplugin.ActivityInfoMapsRow, error) {
+	return selectPrefix[sqlplugin.ActivityInfoMapsRow](
+		pdb.reader(),
+		executionKey(tableActivityInfoMaps, filter.ShardID, filter.NamespaceID, filter.WorkflowID, filter.RunID),
+	)
+}
+
+// DeleteFromActivityInfoMaps deletes one or more rows from activity_info_maps table
+func (pdb *db) DeleteFromActivityInfoMaps(
+	ctx context.Context,
+	filter sqlplugin.ActivityInfoMapsFilter,
+) (sql.Result, error) {
+	prefix := executionKey(tableActivityInfoMaps, filter.ShardID, filter.NamespaceID, filter.WorkflowID, filter.RunID)
+	keys := make([]key, len(filter.ScheduleIDs))
+	for i, id := range filter.ScheduleIDs {
+		keys[i] = prefix.Int64(id)
+	}
+	return pdb.delete(ctx, keys...)
+}
+
+// DeleteAllFromActivityInfoMaps deletes all rows from activity_info_maps table
+func (pdb *db) DeleteAllFromActivityInfoMaps(
+	ctx context.Context,
+	filter sqlplugin.ActivityInfoMapsAllFilter,
+) (sql.Result, error) {
+	return pdb.deletePrefix(ctx,
+		executionKey(tableActivityInfoMaps, filter.ShardID, filter.NamespaceID, filter.WorkflowID, filter.RunID),
+	)
+}
+
+// ReplaceIntoTimerInfoMaps replaces one or more rows in timer_info_maps table
+func (pdb *db) ReplaceIntoTimerInfoMaps(
+	ctx context.Context,
+	rows []sqlplugin.TimerInfoMapsRow,
+) (sql.Result, error) {
+	kvs := make([]kv, len(rows))
+	for i := range rows {
+		row := &rows[i]
+		kvs[i] = kv{
+			key:   executionKey(tableTimerInfoMaps, row.ShardID, row.NamespaceID, row.WorkflowID, row.RunID).String(row.TimerID),
+			value: encodeRow(row),
+		}
+	}
+	return pdb.replace(ctx, kvs...)
+}
+
+// SelectAllFromTimerInfoMaps reads all rows from timer_info_maps table
+func (pdb *db) SelectAllFromTimerInfoMaps(
+	_ context.Context,
+	filter sqlplugin.TimerInfoMapsAllFilter,
+) ([]sqlplugin.TimerInfoMapsRow, error) {
+	return selectPrefix[sqlplugin.TimerInfoMapsRow](
+		pdb.reader(),
+		executionKey(tableTimerInfoMaps, filter.ShardID, filter.NamespaceID, filter.WorkflowID, filter.RunID),
+	)
+}
+
+// DeleteFromTimerInfoMaps deletes one or more rows from timer_info_maps table
+func (pdb *db) DeleteFromTimerInfoMaps(
+	ctx context.Context,
+	filter sqlplugin.TimerInfoMapsFilter,
+) (sql.Result, error) {
+	prefix := executionKey(tableTimerInfoMaps, filter.ShardID, filter.NamespaceID, filter.WorkflowID, filter.RunID)
+	keys := make([]key, len(filter.TimerIDs))
+	for i, id := range filter.TimerIDs {
+		keys[i] = prefix.String(id)
+	}
+	return pdb.delete(ctx, keys...)
+}
+
+// DeleteAllFromTimerInfoMaps deletes all rows from timer_info_maps table
+func (pdb *db) DeleteAllFromTimerInfoMaps(
+	ctx context.Context,
+	filter sqlplugin.TimerInfoMapsAllFilter,
+) (sql.Result, error) {
+	return pdb.deletePrefix(ctx,
+		executionKey(tableTimerInfoMaps, filter.ShardID, filter.NamespaceID, filter.WorkflowID, filter.RunID),
+	)
+}
+
+// ReplaceIntoChildExecutionInfoMaps replaces one or more rows in child_execution_info_maps table
+func (pdb *db) ReplaceIntoChildExecutionInfoMaps(
+	ctx context.Context,
+	rows []sqlplugin.ChildExecutionInfoMapsRow,
+) (sql.Result, error) {
+	kvs := make([]kv, len(rows))
+	for i := range rows {
+		row := &rows[i]
+		kvs[i] = kv{
+			key:   executionKey(tableChildExecutionInfoMaps, row.ShardID, row.NamespaceID, row.WorkflowID, row.RunID).Int64(row.InitiatedID),
+			value: encodeRow(row),
+		}
+	}
+	return pdb.replace(ctx, kvs...)
+}
+
+// SelectAllFromChildExecutionInfoMaps reads all rows from child_execution_info_maps table
+func (pdb *db) SelectAllFromChildExecutionInfoMaps(
+	_ context.Context,
+	filter sqlplugin.ChildExecutionInfoMapsAllFilter,
+) ([]sqlplugin.ChildExecutionInfoMapsRow, error) {
+	return selectPrefix[sqlplugin.ChildExecutionInfoMapsRow](
+		pdb.reader(),
+		executionKey(tableChildExecutionInfoMaps, filter.ShardID, filter.NamespaceID, filter.WorkflowID, filter.RunID),
+	)
+}
+
+// DeleteFromChildExecutionInfoMaps deletes one or more rows from child_execution_info_maps table
+func (pdb *db) DeleteFromChildExecutionInfoMaps(
+	ctx context.Context,
+	filter sqlplugin.ChildExecutionInfoMapsFilter,
+) (sql.Result, error) {
+	prefix := executionKey(tableChildExecutionInfoMaps, filter.ShardID, filter.NamespaceID, filter.WorkflowID, filter.RunID)
+	keys := make([]key, len(filter.InitiatedIDs))
+	for i, id := range filter.InitiatedIDs {
+		keys[i] = prefix.Int64(id)
+	}
+	return pdb.delete(ctx, keys...)
+}
+
+// DeleteAllFromChildExecutionInfoMaps deletes all rows from child_execution_info_maps table
+func (pdb *db) DeleteAllFromChildExecutionInfoMaps(
+	ctx context.Context,
+	filter sqlplugin.ChildExecutionInfoMapsAllFilter,
+) (sql.Result, error) {
+	return pdb.deletePrefix(ctx,
+		executionKey(tableChildExecutionInfoMaps, filter.ShardID, filter.NamespaceID, filter.WorkflowID, filter.RunID),
+	)
+}
+
+// ReplaceIntoRequestCancelInfoMaps replaces one or more rows in request_cancel_info_maps table
+func (pdb *db) ReplaceIntoRequestCancelInfoMaps(
+	ctx context.Context,
+	rows []sqlplugin.RequestCancelInfoMapsRow,
+) (sql.Result, error) {
+	kvs := make([]kv, len(rows))
+	for i := range rows {
+		row := &rows[i]
+		kvs[i] = kv{
+			key:   executionKey(tableRequestCancelInfoMaps, row.ShardID, row.NamespaceID, row.WorkflowID, row.RunID).Int64(row.InitiatedID),
+			value: encodeRow(row),
+		}
+	}
+	return pdb.replace(ctx, kvs...)
+}
+
+// SelectAllFromRequestCancelInfoMaps reads all rows from request_cancel_info_maps table
+func (pdb *db) SelectAllFromRequestCancelInfoMaps(
+	_ context.Context,
+	filter sqlplugin.RequestCancelInfoMapsAllFilter,
+) ([]sqlplugin.RequestCancelInfoMapsRow, error) {
+	return selectPrefix[sqlplugin.RequestCancelInfoMapsRow](
+		pdb.reader(),
+		executionKey(tableRequestCancelInfoMaps, filter.ShardID, filter.NamespaceID, filter.WorkflowID, filter.RunID),
+	)
+}
+
+// DeleteFromRequestCancelInfoMaps deletes one or more rows from request_cancel_info_maps table
+func (pdb *db) DeleteFromRequestCancelInfoMaps(
+	ctx context.Context,
+	filter sqlplugin.RequestCancelInfoMapsFilter,
+) (sql.Result, error) {
+	prefix := executionKey(tableRequestCancelInfoMaps, filter.ShardID, filter.NamespaceID, filter.WorkflowID, filter.RunID)
+	keys := make([]key, len(filter.InitiatedIDs))
+	for i, id := range filter.InitiatedIDs {
+		keys[i] = prefix.Int64(id)
+	}
+	return pdb.delete(ctx, keys...)
+}
+
+// DeleteAllFromRequestCancelInfoMaps deletes all rows from request_cancel_info_maps table
+func (pdb *db) DeleteAllFromRequestCancelInfoMaps(
+	ctx context.Context,
+	filter sqlplugin.RequestCancelInfoMapsAllFilter,
+) (sql.Result, error) {
+	return pdb.deletePrefix(ctx,
+		executionKey(tableRequestCancelInfoMaps, filter.ShardID, filter.NamespaceID, filter.WorkflowID, filter.RunID),
+	)
+}
+
+// ReplaceIntoSignalInfoMaps replaces one or more rows in signal_info_maps table
+func (pdb *db) ReplaceIntoSignalInfoMaps(
+	ctx context.Context,
+	rows []sqlplugin.SignalInfoMapsRow,
+) (sql.Result, error) {
+	kvs := make([]kv, len(rows))
+	for i := range rows {
+		row := &rows[i]
+		kvs[i] = kv{
+			key:   executionKey(tableSignalInfoMaps, row.ShardID, row.NamespaceID, row.WorkflowID, row.RunID).Int64(row.InitiatedID),
+			value: encodeRow(row),
+		}
+	}
+	return pdb.replace(ctx, kvs...)
+}
+
+// SelectAllFromSignalInfoMaps reads all rows from signal_info_maps table
+func (pdb *db) SelectAllFromSignalInfoMaps(
+	_ context.Context,
+	filter sqlplugin.SignalInfoMapsAllFilter,
+) ([]sqlplugin.SignalInfoMapsRow, error) {
+	return selectPrefix[sqlplugin.SignalInfoMapsRow](
+		pdb.reader(),
+		executionKey(tableSignalInfoMaps, filter.ShardID, filter.NamespaceID, filter.WorkflowID, filter.RunID),
+	)
+}
+
+// DeleteFromSignalInfoMaps deletes one or more rows from signal_info_maps table
+func (pdb *db) DeleteFromSignalInfoMaps(
+	ctx context.Context,
+	filter sqlplugin.SignalInfoMapsFilter,
+) (sql.Result, error) {
+	prefix := executionKey(tableSignalInfoMaps, filter.ShardID, filter.NamespaceID, filter.WorkflowID, filter.RunID)
+	keys := make([]key, len(filter.InitiatedIDs))
+	for i, id := range filter.InitiatedIDs {
+		keys[i] = prefix.Int64(id)
+	}
+	return pdb.delete(ctx, keys...)
+}
+
+// DeleteAllFromSignalInfoMaps deletes all rows from signal_info_maps table
+func (pdb *db) DeleteAllFromSignalInfoMaps(
+	ctx context.Context,
+	filter sqlplugin.SignalInfoMapsAllFilter,
+) (sql.Result, error) {
+	return pdb.deletePrefix(ctx,
+		executionKey(tableSignalInfoMaps, filter.ShardID, filter.NamespaceID, filter.WorkflowID, filter.RunID),
+	)
+}
+
+// ReplaceIntoSignalsRequestedSets replaces one or more rows in signals_requested_sets table
+func (pdb *db) ReplaceIntoSignalsRequestedSets(
+	ctx context.Context,
+	rows []sqlplugin.SignalsRequestedSetsRow,
+) (sql.Result, error) {
+	kvs := make([]kv, len(rows))
+	for i := range rows {
+		row := &rows[i]
+		kvs[i] = kv{
+			key:   executionKey(tableSignalsRequestedSets, row.ShardID, row.NamespaceID, row.WorkflowID, row.RunID).String(row.SignalID),
+			value: encodeRow(row),
+		}
+	}
+	return pdb.replace(ctx, kvs...)
+}
+
+// SelectAllFromSignalsRequestedSets reads all rows from signals_requested_sets table
+func (pdb *db) SelectAllFromSignalsRequestedSets(
+	_ context.Context,
+	filter sqlplugin.SignalsRequestedSetsAllFilter,
+) ([]sqlplugin.SignalsRequestedSetsRow, error) {
+	return selectPrefix[sqlplugin.SignalsRequestedSetsRow](
+		pdb.reader(),
+		executionKey(tableSignalsRequestedSets, filter.ShardID, filter.NamespaceID, filter.WorkflowID, filter.RunID),
+	)
+}
+
+// DeleteFromSignalsRequestedSets deletes one or more rows from signals_requested_sets table
+func (pdb *db) DeleteFromSignalsRequestedSets(
+	ctx context.Context,
+	filter sqlplugin.SignalsRequestedSetsFilter,
+) (sql.Result, error) {
+	prefix := executionKey(tableSignalsRequestedSets, filter.ShardID, filter.NamespaceID, filter.WorkflowID, filter.RunID)
+	keys := make([]key, len(filter.SignalIDs))
+	for i, id := range filter.SignalIDs {
+		keys[i] = prefix.String(id)
+	}
+	return pdb.delete(ctx, keys...)
+}
+
+// DeleteAllFromSignalsRequestedSets deletes all rows from signals_requested_sets table
+func (pdb *db) DeleteAllFromSignalsRequestedSets(
+	ctx context.Context,
+	filter sqlplugin.SignalsRequestedSetsAllFilter,
+) (sql.Result, error) {
+	return pdb.deletePrefix(ctx,
+		executionKey(tableSignalsRequestedSets, filter.ShardID, filter.NamespaceID, filter.WorkflowID, filter.RunID),
+	)
+}
+
+// ReplaceIntoChasmNodeMaps replaces one or more rows in chasm_node_maps table
+func (pdb *db) ReplaceIntoChasmNodeMaps(
+	ctx context.Context,
+	rows []sqlplugin.ChasmNodeMapsRow,
+) (sql.Result, error) {
+	kvs := make([]kv, len(rows))
+	for i := range rows {
+		row := &rows[i]
+		kvs[i] = kv{
+			key:   executionKey(tableChasmNodeMaps, row.ShardID, row.NamespaceID, row.WorkflowID, row.RunID).String(row.ChasmPath),
+			value: encodeRow(row),
+		}
+	}
+	return pdb.replace(ctx, kvs...)
+}
+
+// SelectAllFromChasmNodeMaps reads all rows from chasm_node_maps table
+func (pdb *db) SelectAllFromChasmNodeMaps(
+	_ context.Context,
+	filter sqlplugin.ChasmNodeMapsAllFilter,
+) ([]sqlplugin.ChasmNodeMapsRow, error) {
+	return selectPrefix[sqlplugin.ChasmNodeMapsRow](
+		pdb.reader(),
+		executionKey(tableChasmNodeMaps, filter.ShardID, filter.NamespaceID, filter.WorkflowID, filter.RunID),
+	)
+}
+
+// DeleteFromChasmNodeMaps deletes one or more rows from chasm_node_maps table
+func (pdb *db) DeleteFromChasmNodeMaps(
+	ctx context.Context,
+	filter sqlplugin.ChasmNodeMapsFilter,
+) (sql.Result, error) {
+	prefix := executionKey(tableChasmNodeMaps, filter.ShardID, filter.NamespaceID, filter.WorkflowID, filter.RunID)
+	keys := make([]key, len(filter.ChasmPaths))
+	for i, path := range filter.ChasmPaths {
+		keys[i] = prefix.String(path)
+	}
+	return pdb.delete(ctx, keys...)
+}
+
+// DeleteAllFromChasmNodeMaps deletes all rows from chasm_node_maps table
+func (pdb *db) DeleteAllFromChasmNodeMaps(
+	ctx context.Context,
+	filter sqlplugin.ChasmNodeMapsAllFilter,
+) (sql.Result, error) {
+	return pdb.deletePrefix(ctx,
+		executionKey(tableChasmNodeMaps, filter.ShardID, filter.NamespaceID, filter.WorkflowID, filter.RunID),
+	)
+}
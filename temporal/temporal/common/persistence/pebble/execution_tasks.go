@@ -0,0 +1,399 @@
+package pebble
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	pebbledb "github.com/cockroachdb/pebble"
+	"go.temporal.io/server/common/persistence/sql/sqlplugin"
+)
+
+func immediateTaskKey(shardID int32, categoryID int32) key {
+	return newKey(tableHistoryImmediateTasks).Int32(shardID).Int32(categoryID)
+}
+
+func scheduledTaskKey(shardID int32, categoryID int32) key {
+	return newKey(tableHistoryScheduledTasks).Int32(shardID).Int32(categoryID)
+}
+
+func shardTaskKey(t table, shardID int32) key {
+	return newKey(t).Int32(shardID)
+}
+
+func replicationDLQTaskKey(sourceClusterName string, shardID int32) key {
+	return newKey(tableReplicationTasksDLQ).String(sourceClusterName).Int32(shardID)
+}
+
+// rangeDeleteScheduledTasks deletes all tasks under prefix with a visibility timestamp in [minTimestamp, maxTimestamp).
+func (pdb *db) rangeDeleteScheduledTasks(
+	ctx context.Context,
+	prefix key,
+	minTimestamp time.Time,
+	maxTimestamp time.Time,
+) (sql.Result, error) {
+	return pdb.update(ctx, prefix, func(b *pebbledb.Batch) (int64, error) {
+		return deleteRange[struct{}](b, prefix.Time(minTimestamp), prefix.Time(maxTimestamp), 0, nil)
+	})
+}
+
+// rangeDeleteTasks deletes all tasks under prefix with a task ID in [minTaskID, maxTaskID).
+func (pdb *db) rangeDeleteTasks(
+	ctx context.Context,
+	prefix key,
+	minTaskID int64,
+	maxTaskID int64,
+) (sql.Result, error) {
+	return pdb.update(ctx, prefix, func(b *pebbledb.Batch) (int64, error) {
+		return deleteRange[struct{}](b, prefix.Int64(minTaskID), prefix.Int64(maxTaskID), 0, nil)
+	})
+}
+
+// InsertIntoHistoryImmediateTasks inserts one or more rows into history_immediate_tasks table
+func (pdb *db) InsertIntoHistoryImmediateTasks(
+	ctx context.Context,
+	rows []sqlplugin.HistoryImmediateTasksRow,
+) (sql.Result, error) {
+	kvs := make([]kv, len(rows))
+	for i := range rows {
+		row := &rows[i]
+		kvs[i] = kv{key: immediateTaskKey(row.ShardID, row.CategoryID).Int64(row.TaskID), value: encodeRow(row)}
+	}
+	return pdb.insert(ctx, kvs...)
+}
+
+// RangeSelectFromHistoryImmediateTasks reads one or more rows from history_immediate_tasks table
+func (pdb *db) RangeSelectFromHistoryImmediateTasks(
+	_ context.Context,
+	filter sqlplugin.HistoryImmediateTasksRangeFilter,
+) ([]sqlplugin.HistoryImmediateTasksRow, error) {
+	prefix := immediateTaskKey(filter.ShardID, filter.CategoryID)
+	return selectRows[sqlplugin.HistoryImmediateTasksRow](
+		pdb.reader(),
+		prefix.Int64(filter.InclusiveMinTaskID),
+		prefix.Int64(filter.ExclusiveMaxTaskID),
+		false,
+		filter.PageSize,
+	)
+}
+
+// DeleteFromHistoryImmediateTasks deletes one or more rows from history_immediate_tasks table
+func (pdb *db) DeleteFromHistoryImmediateTasks(
+	ctx context.Context,
+	filter sqlplugin.HistoryImmediateTasksFilter,
+) (sql.Result, error) {
+	return pdb.delete(ctx, immediateTaskKey(filter.ShardID, filter.CategoryID).Int64(filter.TaskID))
+}
+
+// RangeDeleteFromHistoryImmediateTasks deletes one or more rows from history_immediate_tasks table
+func (pdb *db) RangeDeleteFromHistoryImmediateTasks(
+	ctx context.Context,
+	filter sqlplugin.HistoryImmediateTasksRangeFilter,
+) (sql.Result, error) {
+	return pdb.rangeDeleteTasks(ctx,
+		immediateTaskKey(filter.ShardID, filter.CategoryID),
+		filter.InclusiveMinTaskID,
+		filter.ExclusiveMaxTaskID,
+	)
+}
+
+// InsertIntoHistoryScheduledTasks inserts one or more rows into history_scheduled_tasks table
+func (pdb *db) InsertIntoHistoryScheduledTasks(
+	ctx context.Context,
+	rows []sqlplugin.HistoryScheduledTasksRow,
+) (sql.Result, error) {
+	kvs := make([]kv, len(rows))
+	for i := range rows {
+		row := &rows[i]
+		kvs[i] = kv{
+			key:   scheduledTaskKey(row.ShardID, row.CategoryID).Time(row.VisibilityTimestamp).Int64(row.TaskID),
+			value: encodeRow(row),
+		}
+	}
+	return pdb.insert(ctx, kvs...)
+}
+
+// RangeSelectFromHistoryScheduledTasks reads one or more rows from history_scheduled_tasks table
+func (pdb *db) RangeSelectFromHistoryScheduledTasks(
+	_ context.Context,
+	filter sqlplugin.HistoryScheduledTasksRangeFilter,
+) ([]sqlplugin.HistoryScheduledTasksRow, error) {
+	prefix := scheduledTaskKey(filter.ShardID, filter.CategoryID)
+	return selectRows[sqlplugin.HistoryScheduledTasksRow](
+		pdb.reader(),
+		prefix.Time(filter.InclusiveMinVisibilityTimestamp).Int64(filter.InclusiveMinTaskID),
+		prefix.Time(filter.ExclusiveMaxVisibilityTimestamp),
+		false,
+		filter.PageSize,
+	)
+}
+
+// DeleteFromHistoryScheduledTasks deletes one or more rows from history_scheduled_tasks table
+func (pdb *db) DeleteFromHistoryScheduledTasks(
+	ctx context.Context,
+	filter sqlplugin.HistoryScheduledTasksFilter,
+) (sql.Result, error) {
+	return pdb.delete(ctx,
+		scheduledTaskKey(filter.ShardID, filter.CategoryID).Time(filter.VisibilityTimestamp).Int64(filter.TaskID),
+	)
+}
+
+// RangeDeleteFromHistoryScheduledTasks deletes one or more rows from history_scheduled_tasks table
+func (pdb *db) RangeDeleteFromHistoryScheduledTasks(
+	ctx context.Context,
+	filter sqlplugin.HistoryScheduledTasksRangeFilter,
+) (sql.Result, error) {
+	return pdb.rangeDeleteScheduledTasks(ctx,
+		scheduledTaskKey(filter.ShardID, filter.CategoryID),
+		filter.InclusiveMinVisibilityTimestamp,
+		filter.ExclusiveMaxVisibilityTimestamp,
+	)
+}
+
+// InsertIntoTransferTasks inserts one or more rows into transfer_tasks table
+func (pdb *db) InsertIntoTransferTasks(
+	ctx context.Context,
+	rows []sqlplugin.TransferTasksRow,
+) (sql.Result, error) {
+	kvs := make([]kv, len(rows))
+	for i := range rows {
+		row := &rows[i]
+		kvs[i] = kv{key: shardTaskKey(tableTransferTasks, row.ShardID).Int64(row.TaskID), value: encodeRow(row)}
+	}
+	return pdb.insert(ctx, kvs...)
+}
+
+// RangeSelectFromTransferTasks reads one or more rows from transfer_tasks table
+func (pdb *db) RangeSelectFromTransferTasks(
+	_ context.Context,
+	filter sqlplugin.TransferTasksRangeFilter,
+) ([]sqlplugin.TransferTasksRow, error) {
+	prefix := shardTaskKey(tableTransferTasks, filter.ShardID)
+	return selectRows[sqlplugin.TransferTasksRow](
+		pdb.reader(),
+		prefix.Int64(filter.InclusiveMinTaskID),
+		prefix.Int64(filter.ExclusiveMaxTaskID),
+		false,
+		filter.PageSize,
+	)
+}
+
+// DeleteFromTransferTasks deletes one or more rows from transfer_tasks table
+func (pdb *db) DeleteFromTransferTasks(
+	ctx context.Context,
+	filter sqlplugin.TransferTasksFilter,
+) (sql.Result, error) {
+	return pdb.delete(ctx, shardTaskKey(tableTransferTasks, filter.ShardID).Int64(filter.TaskID))
+}
+
+// RangeDeleteFromTransferTasks deletes one or more rows from transfer_tasks table
+func (pdb *db) RangeDeleteFromTransferTasks(
+	ctx context.Context,
+	filter sqlplugin.TransferTasksRangeFilter,
+) (sql.Result, error) {
+	return pdb.rangeDeleteTasks(ctx,
+		shardTaskKey(tableTransferTasks, filter.ShardID),
+		filter.InclusiveMinTaskID,
+		filter.ExclusiveMaxTaskID,
+	)
+}
+
+// InsertIntoTimerTasks inserts one or more rows into timer_tasks table
+func (pdb *db) InsertIntoTimerTasks(
+	ctx context.Context,
+	rows []sqlplugin.TimerTasksRow,
+) (sql.Result, error) {
+	kvs := make([]kv, len(rows))
+	for i := range rows {
+		row := &rows[i]
+		kvs[i] = kv{
+			key:   shardTaskKey(tableTimerTasks, row.ShardID).Time(row.VisibilityTimestamp).Int64(row.TaskID),
+			value: encodeRow(row),
+		}
+	}
+	return pdb.insert(ctx, kvs...)
+}
+
+// RangeSelectFromTimerTasks reads one or more rows from timer_tasks table
+func (pdb *db) RangeSelectFromTimerTasks(
+	_ context.Context,
+	filter sqlplugin.TimerTasksRangeFilter,
+) ([]sqlplugin.TimerTasksRow, error) {
+	prefix := shardTaskKey(tableTimerTasks, filter.ShardID)
+	return selectRows[sqlplugin.TimerTasksRow](
+		pdb.reader(),
+		prefix.Time(filter.InclusiveMinVisibilityTimestamp).Int64(filter.InclusiveMinTaskID),
+		prefix.Time(filter.ExclusiveMaxVisibilityTimestamp),
+		false,
+		filter.PageSize,
+	)
+}
+
+// DeleteFromTimerTasks deletes one or more rows from timer_tasks table
+func (pdb *db) DeleteFromTimerTasks(
+	ctx context.Context,
+	filter sqlplugin.TimerTasksFilter,
+) (sql.Result, error) {
+	return pdb.delete(ctx,
+		shardTaskKey(tableTimerTasks, filter.ShardID).Time(filter.VisibilityTimestamp).Int64(filter.TaskID),
+	)
+}
+
+// RangeDeleteFromTimerTasks deletes one or more rows from timer_tasks table
+func (pdb *db) RangeDeleteFromTimerTasks(
+	ctx context.Context,
+	filter sqlplugin.TimerTasksRangeFilter,
+) (sql.Result, error) {
+	return pdb.rangeDeleteScheduledTasks(ctx,
+		shardTaskKey(tableTimerTasks, filter.ShardID),
+		filter.InclusiveMinVisibilityTimestamp,
+		filter.ExclusiveMaxVisibilityTimestamp,
+	)
+}
+
+// InsertIntoReplicationTasks inserts one or more rows into replication_tasks table
+func (pdb *db) InsertIntoReplicationTasks(
+	ctx context.Context,
+	rows []sqlplugin.ReplicationTasksRow,
+) (sql.Result, error) {
+	kvs := make([]kv, len(rows))
+	for i := range rows {
+		row := &rows[i]
+		kvs[i] = kv{key: shardTaskKey(tableReplicationTasks, row.ShardID).Int64(row.TaskID), value: encodeRow(row)}
+	}
+	return pdb.insert(ctx, kvs...)
+}
+
+// RangeSelectFromReplicationTasks reads one or more rows from replication_tasks table
+func (pdb *db) RangeSelectFromReplicationTasks(
+	_ context.Context,
+	filter sqlplugin.ReplicationTasksRangeFilter,
+) ([]sqlplugin.ReplicationTasksRow, error) {
+	prefix := shardTaskKey(tableReplicationTasks, filter.ShardID)
+	return selectRows[sqlplugin.ReplicationTasksRow](
+		pdb.reader(),
+		prefix.Int64(filter.InclusiveMinTaskID),
+		prefix.Int64(filter.ExclusiveMaxTaskID),
+		false,
+		filter.PageSize,
+	)
+}
+
+// DeleteFromReplicationTasks deletes one rows from replication_tasks table
+func (pdb *db) DeleteFromReplicationTasks(
+	ctx context.Context,
+	filter sqlplugin.ReplicationTasksFilter,
+) (sql.Result, error) {
+	return pdb.delete(ctx, shardTaskKey(tableReplicationTasks, filter.ShardID).Int64(filter.TaskID))
+}
+
+// RangeDeleteFromReplicationTasks deletes multi rows from replication_tasks table
+func (pdb *db) RangeDeleteFromReplicationTasks(
+	ctx context.Context,
+	filter sqlplugin.ReplicationTasksRangeFilter,
+) (sql.Result, error) {
+	return pdb.rangeDeleteTasks(ctx,
+		shardTaskKey(tableReplicationTasks, filter.ShardID),
+		filter.InclusiveMinTaskID,
+		filter.ExclusiveMaxTaskID,
+	)
+}
+
+// InsertIntoReplicationDLQTasks inserts one or more rows into replication_tasks_dlq table
+func (pdb *db) InsertIntoReplicationDLQTasks(
+	ctx context.Context,
+	rows []sqlplugin.ReplicationDLQTasksRow,
+) (sql.Result, error) {
+	kvs := make([]kv, len(rows))
+	for i := range rows {
+		row := &rows[i]
+		kvs[i] = kv{
+			key:   replicationDLQTaskKey(row.SourceClusterName, row.ShardID).Int64(row.TaskID),
+			value: encodeRow(row),
+		}
+	}
+	return pdb.insert(ctx, kvs...)
+}
+
+// RangeSelectFromReplicationDLQTasks reads one or more rows from replication_tasks_dlq table
+func (pdb *db) RangeSelectFromReplicationDLQTasks(
+	_ context.Context,
+	filter sqlplugin.ReplicationDLQTasksRangeFilter,
+) ([]sqlplugin.ReplicationDLQTasksRow, error) {
+	prefix := replicationDLQTaskKey(filter.SourceClusterName, filter.ShardID)
+	return selectRows[sqlplugin.ReplicationDLQTasksRow](
+		pdb.reader(),
+		prefix.Int64(filter.InclusiveMinTaskID),
+		prefix.Int64(filter.ExclusiveMaxTaskID),
+		false,
+		filter.PageSize,
+	)
+}
+
+// DeleteFromReplicationDLQTasks deletes one row from replication_tasks_dlq table
+func (pdb *db) DeleteFromReplicationDLQTasks(
+	ctx context.Context,
+	filter sqlplugin.ReplicationDLQTasksFilter,
+) (sql.Result, error) {
+	return pdb.delete(ctx, replicationDLQTaskKey(filter.SourceClusterName, filter.ShardID).Int64(filter.TaskID))
+}
+
+// RangeDeleteFromReplicationDLQTasks deletes one or more rows from replication_tasks_dlq table
+func (pdb *db) RangeDeleteFromReplicationDLQTasks(
+	ctx context.Context,
+	filter sqlplugin.ReplicationDLQTasksRangeFilter,
+) (sql.Result, error) {
+	return pdb.rangeDeleteTasks(ctx,
+		replicationDLQTaskKey(filter.SourceClusterName, filter.ShardID),
+		filter.InclusiveMinTaskID,
+		filter.ExclusiveMaxTaskID,
+	)
+}
+
+// InsertIntoVisibilityTasks inserts one or more rows into visibility_tasks table
+func (pdb *db) InsertIntoVisibilityTasks(
+	ctx context.Context,
+	rows []sqlplugin.VisibilityTasksRow,
+) (sql.Result, error) {
+	kvs := make([]kv, len(rows))
+	for i := range rows {
+		row := &rows[i]
+		kvs[i] = kv{key: shardTaskKey(tableVisibilityTasks, row.ShardID).Int64(row.TaskID), value: encodeRow(row)}
+	}
+	return pdb.insert(ctx, kvs...)
+}
+
+// RangeSelectFromVisibilityTasks reads one or more rows from visibility_tasks table
+func (pdb *db) RangeSelectFromVisibilityTasks(
+	_ context.Context,
+	filter sqlplugin.VisibilityTasksRangeFilter,
+) ([]sqlplugin.VisibilityTasksRow, error) {
+	prefix := shardTaskKey(tableVisibilityTasks, filter.ShardID)
+	return selectRows[sqlplugin.VisibilityTasksRow](
+		pdb.reader(),
+		prefix.Int64(filter.InclusiveMinTaskID),
+		prefix.Int64(filter.ExclusiveMaxTaskID),
+		false,
+		filter.PageSize,
+	)
+}
+
+// DeleteFromVisibilityTasks deletes one or more rows from visibility_tasks table
+func (pdb *db) DeleteFromVisibilityTasks(
+	ctx context.Context,
+	filter sqlplugin.VisibilityTasksFilter,
+) (sql.Result, error) {
+	return pdb.delete(ctx, shardTaskKey(tableVisibilityTasks, filter.ShardID).Int64(filter.TaskID))
+}
+
+// RangeDeleteFromVisibilityTasks deletes one or more rows from visibility_tasks table
+func (pdb *db) RangeDeleteFromVisibilityTasks(
+	ctx context.Context,
+	filter sqlplugin.VisibilityTasksRangeFilter,
+) (sql.Result, error) {
+	return pdb.rangeDeleteTasks(ctx,
+		shardTaskKey(tableVisibilityTasks, filter.ShardID),
+		filter.InclusiveMinTaskID,
+		filter.ExclusiveMaxTaskID,
+	)
+}
@@ -0,0 +1,98 @@
+package pebble
+
+import (
+	"fmt"
+	"strconv"
+
+	"go.temporal.io/server/common/config"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/log/tag"
+	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/persistence/client"
+	"go.temporal.io/server/common/persistence/serialization"
+	"go.temporal.io/server/common/persistence/sql"
+	"go.temporal.io/server/common/resolver"
+)
+
+const (
+	// OptionPath is the directory of the database files.
+	OptionPath = "path"
+	// OptionMode selects the storage mode, see ConnectAttributeMode.
+	OptionMode = "mode"
+	// OptionSync controls whether writes are synced to disk, see ConnectAttributeSync.
+	OptionSync = "sync"
+	// OptionTaskScanPartitions is the number of partitions to sequentially scan during ListTaskQueue operations.
+	OptionTaskScanPartitions = "taskScanPartitions"
+
+	defaultTaskScanPartitions = 1
+)
+
+type abstractDataStoreFactory struct{}
+
+var _ client.AbstractDataStoreFactory = (*abstractDataStoreFactory)(nil)
+
+// NewAbstractDataStoreFactory returns a factory for pebble backed data stores, to be registered with
+// temporal.WithCustomDataStoreFactory. The data stores are configured by the options of the custom datastore config:
+//
+//	persistence:
+//	  datastores:
+//	    default:
+//	      customDatastore:
+//	        name: pebble
+//	        options:
+//	          path: /var/lib/temporal/pebble
+func NewAbstractDataStoreFactory() client.AbstractDataStoreFactory {
+	return &abstractDataStoreFactory{}
+}
+
+// NewFactory returns a persistence.DataStoreFactory backed by pebble.
+func (f *abstractDataStoreFactory) NewFactory(
+	cfg config.CustomDatastoreConfig,
+	r resolver.ServiceResolver,
+	clusterName string,
+	logger log.Logger,
+	metricsHandler metrics.Handler,
+	serializer serialization.Serializer,
+) persistence.DataStoreFactory {
+	sqlCfg, err := SQLConfig(cfg)
+	if err != nil {
+		logger.Fatal("invalid pebble datastore options", tag.Error(err))
+	}
+	return sql.NewFactory(*sqlCfg, r, clusterName, logger, metricsHandler, serializer)
+}
+
+// SQLConfig converts a custom datastore config into the SQL config of the pebble plugin.
+func SQLConfig(cfg config.CustomDatastoreConfig) (*config.SQL, error) {
+	sqlCfg := &config.SQL{
+		PluginName:         PluginName,
+		DatabaseName:       optionString(cfg.Options, OptionPath),
+		ConnectAttributes:  make(map[string]string),
+		TaskScanPartitions: defaultTaskScanPartitions,
+	}
+	for _, option := range []string{OptionMode, OptionSync} {
+		if value := optionString(cfg.Options, option); value != "" {
+			sqlCfg.ConnectAttributes[option] = value
+		}
+	}
+	if sqlCfg.DatabaseName == "" && sqlCfg.ConnectAttributes[ConnectAttributeMode] == ModeMemory {
+		// in memory databases are identified by their name only
+		sqlCfg.DatabaseName = cfg.IndexName
+	}
+	if value := optionString(cfg.Options, OptionTaskScanPartitions); value != "" {
+		partitions, err := strconv.Atoi(value)
+		if err != nil || partitions <= 0 {
+			return nil, fmt.Errorf("pebble: invalid value %q for option %q", value, OptionTaskScanPartitions)
+		}
+		sqlCfg.TaskScanPartitions = partitions
+	}
+	return sqlCfg, nil
+}
+
+func optionString(options map[string]any, name string) string {
+	value, ok := options[name]
+	if !ok || value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,50 @@
+package pebble
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/server/common/config"
+)
+
+func TestSQLConfig(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := SQLConfig(config.CustomDatastoreConfig{
+		Name: PluginName,
+		Options: map[string]any{
+			OptionPath:               "/var/lib/temporal",
+			OptionSync:               false,
+			OptionTaskScanPartitions: 4,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, PluginName, cfg.PluginName)
+	require.Equal(t, "/var/lib/temporal", cfg.DatabaseName)
+	require.Equal(t, map[string]string{ConnectAttributeSync: "false"}, cfg.ConnectAttributes)
+	require.Equal(t, 4, cfg.TaskScanPartitions)
+}
+
+func TestSQLConfig_Memory(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := SQLConfig(config.CustomDatastoreConfig{
+		Name:      PluginName,
+		IndexName: "temporal",
+		Options:   map[string]any{OptionMode: ModeMemory},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "temporal", cfg.DatabaseName)
+	require.Equal(t, defaultTaskScanPartitions, cfg.TaskScanPartitions)
+}
+
+func TestSQLConfig_InvalidTaskScanPartitions(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := SQLConfig(config.CustomDatastoreConfig{
+		Name:    PluginName,
+		Options: map[string]any{OptionPath: "/tmp/pebble", OptionTaskScanPartitions: "none"},
+	})
+	require.Error(t, err)
+	require.Nil(t, cfg)
+}
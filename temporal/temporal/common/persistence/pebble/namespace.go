@@ -0,0 +1,185 @@
+package pebble
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	pebbledb "github.com/cockroachdb/pebble"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/server/common/persistence/sql/sqlplugin"
+	"go.temporal.io/server/common/primitives"
+)
+
+type namespaceNameValue struct {
+	ID primitives.UUID
+}
+
+var errMissingArgs = errors.New("missing one or more args for API")
+
+func namespaceKey(id primitives.UUID) key {
+	return newKey(tableNamespaces).Bytes(id)
+}
+
+func namespaceNameKey(name string) key {
+	return newKey(tableNamespaceNames).String(name)
+}
+
+func namespaceMetadataKey() key {
+	return newKey(tableNamespaceMetadata)
+}
+
+// InsertIntoNamespace inserts a single row into namespaces table
+func (pdb *db) InsertIntoNamespace(
+	ctx context.Context,
+	row *sqlplugin.NamespaceRow,
+) (sql.Result, error) {
+	return pdb.update(ctx, namespaceKey(row.ID), func(b *pebbledb.Batch) (int64, error) {
+		// the name index is not a row of its own, so only the namespace row is reported as affected
+		if _, err := insertRows(b,
+			kv{key: namespaceKey(row.ID), value: encodeRow(row)},
+			kv{key: namespaceNameKey(row.Name), value: encodeRow(&namespaceNameValue{ID: row.ID})},
+		); err != nil {
+			return 0, err
+		}
+		return 1, nil
+	})
+}
+
+// UpdateNamespace updates a single row in namespaces table
+func (pdb *db) UpdateNamespace(
+	ctx context.Context,
+	row *sqlplugin.NamespaceRow,
+) (sql.Result, error) {
+	return pdb.update(ctx, namespaceKey(row.ID), func(b *pebbledb.Batch) (int64, error) {
+		var current sqlplugin.NamespaceRow
+		ok, err := get(b, namespaceKey(row.ID), &current)
+		if err != nil || !ok {
+			return 0, err
+		}
+		if current.Name != row.Name {
+			// the name is unique, so it can only be changed to a name which isn't taken yet
+			if _, err := insertRows(b, kv{
+				key:   namespaceNameKey(row.Name),
+				value: encodeRow(&namespaceNameValue{ID: row.ID}),
+			}); err != nil {
+				return 0, err
+			}
+			if err := b.Delete(namespaceNameKey(current.Name), nil); err != nil {
+				return 0, err
+			}
+		}
+		return 1, b.Set(namespaceKey(row.ID), encodeRow(row), nil)
+	})
+}
+
+// SelectFromNamespace reads one or more rows from namespaces table
+func (pdb *db) SelectFromNamespace(
+	_ context.Context,
+	filter sqlplugin.NamespaceFilter,
+) ([]sqlplugin.NamespaceRow, error) {
+	switch {
+	case filter.ID != nil || filter.Name != nil:
+		if filter.ID != nil && filter.Name != nil {
+			return nil, serviceerror.NewInternal("only ID or name filter can be specified for selection")
+		}
+		id, err := pdb.resolveNamespaceID(pdb.reader(), filter)
+		if err != nil {
+			return nil, err
+		}
+		var row sqlplugin.NamespaceRow
+		if err := getRow(pdb.reader(), namespaceKey(id), &row); err != nil {
+			return nil, err
+		}
+		return []sqlplugin.NamespaceRow{row}, nil
+	case filter.PageSize != nil && *filter.PageSize > 0:
+		lower := newKey(tableNamespaces)
+		if filter.GreaterThanID != nil {
+			lower = namespaceKey(*filter.GreaterThanID).Next()
+		}
+		return selectRows[sqlplugin.NamespaceRow](pdb.reader(), lower, nil, false, *filter.PageSize)
+	default:
+		return nil, errMissingArgs
+	}
+}
+
+// DeleteFromNamespace deletes a single row in namespaces table
+func (pdb *db) DeleteFromNamespace(
+	ctx context.Context,
+	filter sqlplugin.NamespaceFilter,
+) (sql.Result, error) {
+	return pdb.update(ctx, newKey(tableNamespaces), func(b *pebbledb.Batch) (int64, error) {
+		id, err := pdb.resolveNamespaceID(b, filter)
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		var row sqlplugin.NamespaceRow
+		ok, err := get(b, namespaceKey(id), &row)
+		if err != nil || !ok {
+			return 0, err
+		}
+		if err := b.Delete(namespaceNameKey(row.Name), nil); err != nil {
+			return 0, err
+		}
+		return 1, b.Delete(namespaceKey(id), nil)
+	})
+}
+
+// resolveNamespaceID returns the ID of the namespace selected by either the ID or the name of filter.
+func (pdb *db) resolveNamespaceID(r pebbledb.Reader, filter sqlplugin.NamespaceFilter) (primitives.UUID, error) {
+	switch {
+	case filter.ID != nil:
+		return *filter.ID, nil
+	case filter.Name != nil:
+		var name namespaceNameValue
+		if err := getRow(r, namespaceNameKey(*filter.Name), &name); err != nil {
+			return nil, err
+		}
+		return name.ID, nil
+	default:
+		return nil, errMissingArgs
+	}
+}
+
+// LockNamespaceMetadata acquires a write lock on a single row in namespace_metadata table
+func (pdb *db) LockNamespaceMetadata(
+	ctx context.Context,
+) (*sqlplugin.NamespaceMetadataRow, error) {
+	if err := pdb.lock(ctx, namespaceMetadataKey()); err != nil {
+		return nil, err
+	}
+	return pdb.SelectFromNamespaceMetadata(ctx)
+}
+
+// SelectFromNamespaceMetadata reads a single row in namespace_metadata table
+func (pdb *db) SelectFromNamespaceMetadata(
+	_ context.Context,
+) (*sqlplugin.NamespaceMetadataRow, error) {
+	var row sqlplugin.NamespaceMetadataRow
+	if err := getRow(pdb.reader(), namespaceMetadataKey(), &row); err != nil {
+		return nil, err
+	}
+	return &row, nil
+}
+
+// UpdateNamespaceMetadata updates a single row in namespace_metadata table
+func (pdb *db) UpdateNamespaceMetadata(
+	ctx context.Context,
+	row *sqlplugin.NamespaceMetadataRow,
+) (sql.Result, error) {
+	return pdb.update(ctx, namespaceMetadataKey(), func(b *pebbledb.Batch) (int64, error) {
+		var current sqlplugin.NamespaceMetadataRow
+		ok, err := get(b, namespaceMetadataKey(), &current)
+		if err != nil || !ok || current.NotificationVersion != row.NotificationVersion {
+			return 0, err
+		}
+		return 1, b.Set(
+			namespaceMetadataKey(),
+			encodeRow(&sqlplugin.NamespaceMetadataRow{NotificationVersion: row.NotificationVersion + 1}),
+			nil,
+		)
+	})
+}
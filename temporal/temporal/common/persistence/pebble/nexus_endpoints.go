@@ -0,0 +1,110 @@
+package pebble
+
+import (
+	"context"
+	"database/sql"
+
+	pebbledb "github.com/cockroachdb/pebble"
+	"go.temporal.io/server/common/persistence/sql/sqlplugin"
+)
+
+type nexusEndpointsVersionValue struct {
+	Version int64
+}
+
+func nexusEndpointKey(id []byte) key {
+	return newKey(tableNexusEndpoints).Bytes(id)
+}
+
+func nexusEndpointsVersionKey() key {
+	return newKey(tableNexusEndpointsVersion)
+}
+
+func (pdb *db) InitializeNexusEndpointsTableVersion(ctx context.Context) (sql.Result, error) {
+	return pdb.insert(ctx, kv{
+		key:   nexusEndpointsVersionKey(),
+		value: encodeRow(&nexusEndpointsVersionValue{Version: 1}),
+	})
+}
+
+func (pdb *db) IncrementNexusEndpointsTableVersion(
+	ctx context.Context,
+	lastKnownTableVersion int64,
+) (sql.Result, error) {
+	return pdb.update(ctx, nexusEndpointsVersionKey(), func(b *pebbledb.Batch) (int64, error) {
+		var current nexusEndpointsVersionValue
+		ok, err := get(b, nexusEndpointsVersionKey(), &current)
+		if err != nil || !ok || current.Version != lastKnownTableVersion {
+			return 0, err
+		}
+		return 1, b.Set(
+			nexusEndpointsVersionKey(),
+			encodeRow(&nexusEndpointsVersionValue{Version: lastKnownTableVersion + 1}),
+			nil,
+		)
+	})
+}
+
+func (pdb *db) GetNexusEndpointsTableVersion(_ context.Context) (int64, error) {
+	var current nexusEndpointsVersionValue
+	if _, err := get(pdb.reader(), nexusEndpointsVersionKey(), &current); err != nil {
+		return 0, err
+	}
+	return current.Version, nil
+}
+
+func (pdb *db) InsertIntoNexusEndpoints(
+	ctx context.Context,
+	row *sqlplugin.NexusEndpointsRow,
+) (sql.Result, error) {
+	inserted := *row
+	inserted.Version = 1
+	return pdb.insert(ctx, kv{key: nexusEndpointKey(row.ID), value: encodeRow(&inserted)})
+}
+
+func (pdb *db) UpdateNexusEndpoint(
+	ctx context.Context,
+	row *sqlplugin.NexusEndpointsRow,
+) (sql.Result, error) {
+	return pdb.update(ctx, nexusEndpointKey(row.ID), func(b *pebbledb.Batch) (int64, error) {
+		var current sqlplugin.NexusEndpointsRow
+		ok, err := get(b, nexusEndpointKey(row.ID), &current)
+		if err != nil || !ok || current.Version != row.Version {
+			return 0, err
+		}
+		updated := *row
+		updated.Version++
+		return 1, b.Set(nexusEndpointKey(row.ID), encodeRow(&updated), nil)
+	})
+}
+
+func (pdb *db) DeleteFromNexusEndpoints(
+	ctx context.Context,
+	id []byte,
+) (sql.Result, error) {
+	return pdb.delete(ctx, nexusEndpointKey(id))
+}
+
+func (pdb *db) GetNexusEndpointByID(
+	_ context.Context,
+	id []byte,
+) (*sqlplugin.NexusEndpointsRow, error) {
+	var row sqlplugin.NexusEndpointsRow
+	if err := getRow(pdb.reader(), nexusEndpointKey(id), &row); err != nil {
+		return nil, err
+	}
+	return &row, nil
+}
+
+func (pdb *db) ListNexusEndpoints(
+	_ context.Context,
+	request *sqlplugin.ListNexusEndpointsRequest,
+) ([]sqlplugin.NexusEndpointsRow, error) {
+	return selectRows[sqlplugin.NexusEndpointsRow](
+		pdb.reader(),
+		nexusEndpointKey(request.LastID).Next(),
+		nil,
+		false,
+		request.Limit,
+	)
+}
@@ -0,0 +1,66 @@
+package pebble
+
+import (
+	"errors"
+
+	"go.temporal.io/server/common/config"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/log/tag"
+	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/common/persistence/sql"
+	"go.temporal.io/server/common/persistence/sql/sqlplugin"
+	"go.temporal.io/server/common/resolver"
+)
+
+const (
+	// PluginName is the name of the plugin
+	PluginName = "pebble"
+)
+
+// The pebble plugin doesn't implement the tables of the archival database, whose archived visibility records are
+// queried with indexed SQL.
+var errArchivalNotSupported = errors.New("pebble: archival store is not supported")
+
+type plugin struct {
+	storePool *storePool
+}
+
+var _ sqlplugin.Plugin = (*plugin)(nil)
+
+func init() {
+	sql.RegisterPlugin(PluginName, &plugin{
+		storePool: newStorePool(),
+	})
+}
+
+// GetVisibilityQueryConverter returns nil, as pebble doesn't support visibility.
+func (p *plugin) GetVisibilityQueryConverter() sqlplugin.VisibilityQueryConverter {
+	return nil
+}
+
+// CreateDB initialize the db object
+func (p *plugin) CreateDB(
+	dbKind sqlplugin.DbKind,
+	cfg *config.SQL,
+	_ resolver.ServiceResolver,
+	logger log.Logger,
+	_ metrics.Handler,
+) (sqlplugin.GenericDB, error) {
+	if dbKind == sqlplugin.DbKindVisibility {
+		return nil, errVisibilityNotSupported
+	}
+	if dbKind == sqlplugin.DbKindArchival {
+		return nil, errArchivalNotSupported
+	}
+	s, err := p.storePool.Allocate(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+	pdb := newDB(dbKind, cfg.DatabaseName, s, nil, logger)
+	pdb.OnClose(func() {
+		if err := p.storePool.Release(cfg); err != nil {
+			logger.Error("failed to close pebble database", tag.Error(err))
+		}
+	})
+	return pdb, nil
+}
@@ -0,0 +1,145 @@
+package pebble
+
+import (
+	"context"
+	"database/sql"
+
+	pebbledb "github.com/cockroachdb/pebble"
+	"go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/persistence/sql/sqlplugin"
+)
+
+func queueKey(queueType persistence.QueueType) key {
+	return newKey(tableQueue).Int32(int32(queueType))
+}
+
+func queueMetadataKey(queueType persistence.QueueType) key {
+	return newKey(tableQueueMetadata).Int32(int32(queueType))
+}
+
+// InsertIntoMessages inserts one or more rows into queue table
+func (pdb *db) InsertIntoMessages(
+	ctx context.Context,
+	rows []sqlplugin.QueueMessageRow,
+) (sql.Result, error) {
+	kvs := make([]kv, len(rows))
+	for i := range rows {
+		row := &rows[i]
+		kvs[i] = kv{key: queueKey(row.QueueType).Int64(row.MessageID), value: encodeRow(row)}
+	}
+	return pdb.insert(ctx, kvs...)
+}
+
+// SelectFromMessages reads a single row from queue table
+func (pdb *db) SelectFromMessages(
+	_ context.Context,
+	filter sqlplugin.QueueMessagesFilter,
+) ([]sqlplugin.QueueMessageRow, error) {
+	var row sqlplugin.QueueMessageRow
+	ok, err := get(pdb.reader(), queueKey(filter.QueueType).Int64(filter.MessageID), &row)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return []sqlplugin.QueueMessageRow{row}, nil
+}
+
+// RangeSelectFromMessages reads rows with a message ID in (MinMessageID, MaxMessageID] from queue table
+func (pdb *db) RangeSelectFromMessages(
+	_ context.Context,
+	filter sqlplugin.QueueMessagesRangeFilter,
+) ([]sqlplugin.QueueMessageRow, error) {
+	prefix := queueKey(filter.QueueType)
+	return selectRows[sqlplugin.QueueMessageRow](
+		pdb.reader(),
+		prefix.Int64(filter.MinMessageID).Next(),
+		prefix.Int64(filter.MaxMessageID).Next(),
+		false,
+		filter.PageSize,
+	)
+}
+
+// DeleteFromMessages deletes a single row from queue table
+func (pdb *db) DeleteFromMessages(
+	ctx context.Context,
+	filter sqlplugin.QueueMessagesFilter,
+) (sql.Result, error) {
+	return pdb.delete(ctx, queueKey(filter.QueueType).Int64(filter.MessageID))
+}
+
+// RangeDeleteFromMessages deletes rows with a message ID in (MinMessageID, MaxMessageID] from queue table
+func (pdb *db) RangeDeleteFromMessages(
+	ctx context.Context,
+	filter sqlplugin.QueueMessagesRangeFilter,
+) (sql.Result, error) {
+	prefix := queueKey(filter.QueueType)
+	return pdb.update(ctx, prefix, func(b *pebbledb.Batch) (int64, error) {
+		return deleteRange[struct{}](b, prefix.Int64(filter.MinMessageID).Next(), prefix.Int64(filter.MaxMessageID).Next(), 0, nil)
+	})
+}
+
+// GetLastEnqueuedMessageIDForUpdate returns the last enqueued message ID
+func (pdb *db) GetLastEnqueuedMessageIDForUpdate(
+	ctx context.Context,
+	queueType persistence.QueueType,
+) (int64, error) {
+	prefix := queueKey(queueType)
+	if err := pdb.lock(ctx, prefix); err != nil {
+		return 0, err
+	}
+	rows, err := selectRows[sqlplugin.QueueMessageRow](pdb.reader(), prefix, prefix.PrefixEnd(), true, 1)
+	if err != nil {
+		return 0, err
+	}
+	if len(rows) == 0 {
+		return 0, sql.ErrNoRows
+	}
+	return rows[0].MessageID, nil
+}
+
+// InsertIntoQueueMetadata inserts a single row into queue_metadata table
+func (pdb *db) InsertIntoQueueMetadata(
+	ctx context.Context,
+	row *sqlplugin.QueueMetadataRow,
+) (sql.Result, error) {
+	return pdb.insert(ctx, kv{key: queueMetadataKey(row.QueueType), value: encodeRow(row)})
+}
+
+// UpdateQueueMetadata updates a single row in queue_metadata table if its version matches
+func (pdb *db) UpdateQueueMetadata(
+	ctx context.Context,
+	row *sqlplugin.QueueMetadataRow,
+) (sql.Result, error) {
+	return pdb.update(ctx, queueMetadataKey(row.QueueType), func(b *pebbledb.Batch) (int64, error) {
+		var current sqlplugin.QueueMetadataRow
+		ok, err := get(b, queueMetadataKey(row.QueueType), &current)
+		if err != nil || !ok || current.Version != row.Version {
+			return 0, err
+		}
+		updated := *row
+		updated.Version++
+		return 1, b.Set(queueMetadataKey(row.QueueType), encodeRow(&updated), nil)
+	})
+}
+
+// SelectFromQueueMetadata reads a single row from queue_metadata table
+func (pdb *db) SelectFromQueueMetadata(
+	_ context.Context,
+	filter sqlplugin.QueueMetadataFilter,
+) (*sqlplugin.QueueMetadataRow, error) {
+	var row sqlplugin.QueueMetadataRow
+	if err := getRow(pdb.reader(), queueMetadataKey(filter.QueueType), &row); err != nil {
+		return nil, err
+	}
+	return &row, nil
+}
+
+// LockQueueMetadata acquires a write lock on a single row in queue_metadata table
+func (pdb *db) LockQueueMetadata(
+	ctx context.Context,
+	filter sqlplugin.QueueMetadataFilter,
+) (*sqlplugin.QueueMetadataRow, error) {
+	if err := pdb.lock(ctx, queueMetadataKey(filter.QueueType)); err != nil {
+		return nil, err
+	}
+	return pdb.SelectFromQueueMetadata(ctx, filter)
+}
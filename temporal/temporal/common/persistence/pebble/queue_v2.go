@@ -0,0 +1,142 @@
+package pebble
+
+import (
+	"context"
+	"database/sql"
+
+	pebbledb "github.com/cockroachdb/pebble"
+	"go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/persistence/sql/sqlplugin"
+)
+
+func queueV2MessageKey(queueType persistence.QueueV2Type, queueName string, partition int64) key {
+	return newKey(tableQueueV2Messages).Int32(int32(queueType)).String(queueName).Int64(partition)
+}
+
+func queueV2MetadataKey(queueType persistence.QueueV2Type) key {
+	return newKey(tableQueueV2Metadata).Int32(int32(queueType))
+}
+
+// InsertIntoQueueV2Messages inserts one or more rows into queue_messages table
+func (pdb *db) InsertIntoQueueV2Messages(
+	ctx context.Context,
+	rows []sqlplugin.QueueV2MessageRow,
+) (sql.Result, error) {
+	kvs := make([]kv, len(rows))
+	for i := range rows {
+		row := &rows[i]
+		kvs[i] = kv{
+			key:   queueV2MessageKey(row.QueueType, row.QueueName, row.QueuePartition).Int64(row.MessageID),
+			value: encodeRow(row),
+		}
+	}
+	return pdb.insert(ctx, kvs...)
+}
+
+// RangeSelectFromQueueV2Messages reads up to PageSize rows starting at MinMessageID from queue_messages table
+func (pdb *db) RangeSelectFromQueueV2Messages(
+	_ context.Context,
+	filter sqlplugin.QueueV2MessagesFilter,
+) ([]sqlplugin.QueueV2MessageRow, error) {
+	prefix := queueV2MessageKey(filter.QueueType, filter.QueueName, filter.Partition)
+	return selectRows[sqlplugin.QueueV2MessageRow](
+		pdb.reader(),
+		prefix.Int64(filter.MinMessageID),
+		prefix.PrefixEnd(),
+		false,
+		filter.PageSize,
+	)
+}
+
+// RangeDeleteFromQueueV2Messages deletes rows with a message ID in [MinMessageID, MaxMessageID] from
+// queue_messages table
+func (pdb *db) RangeDeleteFromQueueV2Messages(
+	ctx context.Context,
+	filter sqlplugin.QueueV2MessagesFilter,
+) (sql.Result, error) {
+	prefix := queueV2MessageKey(filter.QueueType, filter.QueueName, filter.Partition)
+	return pdb.update(ctx, prefix, func(b *pebbledb.Batch) (int64, error) {
+		return deleteRange[struct{}](b, prefix.Int64(filter.MinMessageID), prefix.Int64(filter.MaxMessageID).Next(), 0, nil)
+	})
+}
+
+// GetLastEnqueuedMessageIDForUpdateV2 returns the last enqueued message ID of a queue partition
+func (pdb *db) GetLastEnqueuedMessageIDForUpdateV2(
+	ctx context.Context,
+	filter sqlplugin.QueueV2Filter,
+) (int64, error) {
+	prefix := queueV2MessageKey(filter.QueueType, filter.QueueName, int64(filter.Partition))
+	if err := pdb.lock(ctx, prefix); err != nil {
+		return 0, err
+	}
+	rows, err := selectRows[sqlplugin.QueueV2MessageRow](pdb.reader(), prefix, prefix.PrefixEnd(), true, 1)
+	if err != nil {
+		return 0, err
+	}
+	if len(rows) == 0 {
+		return 0, sql.ErrNoRows
+	}
+	return rows[0].MessageID, nil
+}
+
+// InsertIntoQueueV2Metadata inserts a single row into queues table
+func (pdb *db) InsertIntoQueueV2Metadata(
+	ctx context.Context,
+	row *sqlplugin.QueueV2MetadataRow,
+) (sql.Result, error) {
+	return pdb.insert(ctx, kv{key: queueV2MetadataKey(row.QueueType).String(row.QueueName), value: encodeRow(row)})
+}
+
+// UpdateQueueV2Metadata updates a single row in queues table
+func (pdb *db) UpdateQueueV2Metadata(
+	ctx context.Context,
+	row *sqlplugin.QueueV2MetadataRow,
+) (sql.Result, error) {
+	return pdb.updateExisting(ctx, queueV2MetadataKey(row.QueueType).String(row.QueueName), encodeRow(row))
+}
+
+// SelectFromQueueV2Metadata reads a single row from queues table
+func (pdb *db) SelectFromQueueV2Metadata(
+	_ context.Context,
+	filter sqlplugin.QueueV2MetadataFilter,
+) (*sqlplugin.QueueV2MetadataRow, error) {
+	var row sqlplugin.QueueV2MetadataRow
+	if err := getRow(pdb.reader(), queueV2MetadataKey(filter.QueueType).String(filter.QueueName), &row); err != nil {
+		return nil, err
+	}
+	return &row, nil
+}
+
+// SelectFromQueueV2MetadataForUpdate acquires a write lock on a single row in queues table
+func (pdb *db) SelectFromQueueV2MetadataForUpdate(
+	ctx context.Context,
+	filter sqlplugin.QueueV2MetadataFilter,
+) (*sqlplugin.QueueV2MetadataRow, error) {
+	if err := pdb.lock(ctx, queueV2MetadataKey(filter.QueueType)); err != nil {
+		return nil, err
+	}
+	return pdb.SelectFromQueueV2Metadata(ctx, filter)
+}
+
+// SelectNameFromQueueV2Metadata reads a page of rows of a queue type from queues table
+func (pdb *db) SelectNameFromQueueV2Metadata(
+	_ context.Context,
+	filter sqlplugin.QueueV2MetadataTypeFilter,
+) ([]sqlplugin.QueueV2MetadataRow, error) {
+	var rows []sqlplugin.QueueV2MetadataRow
+	var offset int64
+	prefix := queueV2MetadataKey(filter.QueueType)
+	err := scan(pdb.reader(), prefix, prefix.PrefixEnd(), false, 0, func(_ []byte, value []byte) (bool, error) {
+		if offset < filter.PageOffset {
+			offset++
+			return true, nil
+		}
+		var row sqlplugin.QueueV2MetadataRow
+		if err := decodeRow(value, &row); err != nil {
+			return false, err
+		}
+		rows = append(rows, row)
+		return len(rows) < filter.PageSize, nil
+	})
+	return rows, err
+}
@@ -0,0 +1,247 @@
+package pebble
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	pebbledb "github.com/cockroachdb/pebble"
+)
+
+// kv is a single row to be written.
+type kv struct {
+	key   key
+	value []byte
+}
+
+// get decodes the row stored under k into row and reports whether it exists.
+func get(r pebbledb.Reader, k key, row any) (bool, error) {
+	value, closer, err := r.Get(k)
+	if errors.Is(err, pebbledb.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = closer.Close() }()
+	return true, decodeRow(value, row)
+}
+
+// getRow decodes the row stored under k into row and returns sql.ErrNoRows if it doesn't exist.
+func getRow(r pebbledb.Reader, k key, row any) error {
+	ok, err := get(r, k, row)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func exists(r pebbledb.Reader, k key) (bool, error) {
+	_, closer, err := r.Get(k)
+	if errors.Is(err, pebbledb.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, closer.Close()
+}
+
+// scan calls fn for every row with a key in [lower, upper), in key order or in reverse key order, until fn
+// returns false or limit rows were visited. A limit of zero or less means no limit and a nil upper bound means
+// the end of the table.
+func scan(
+	r pebbledb.Reader,
+	lower key,
+	upper key,
+	reverse bool,
+	limit int,
+	fn func(k []byte, value []byte) (bool, error),
+) (retErr error) {
+	if upper == nil {
+		upper = newKey(table(lower[0])).PrefixEnd()
+	}
+	iter, err := r.NewIter(&pebbledb.IterOptions{LowerBound: lower, UpperBound: upper})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := iter.Close(); err != nil && retErr == nil {
+			retErr = err
+		}
+	}()
+
+	first, next := iter.First, iter.Next
+	if reverse {
+		first, next = iter.Last, iter.Prev
+	}
+	count := 0
+	for valid := first(); valid; valid = next() {
+		more, err := fn(iter.Key(), iter.Value())
+		if err != nil {
+			return err
+		}
+		count++
+		if !more || (limit > 0 && count >= limit) {
+			break
+		}
+	}
+	return iter.Error()
+}
+
+// selectRows decodes up to limit rows with a key in [lower, upper).
+func selectRows[T any](r pebbledb.Reader, lower key, upper key, reverse bool, limit int) ([]T, error) {
+	var rows []T
+	err := scan(r, lower, upper, reverse, limit, func(_ []byte, value []byte) (bool, error) {
+		var row T
+		if err := decodeRow(value, &row); err != nil {
+			return false, err
+		}
+		rows = append(rows, row)
+		return true, nil
+	})
+	return rows, err
+}
+
+// selectPrefix decodes all rows with a key starting with prefix.
+func selectPrefix[T any](r pebbledb.Reader, prefix key) ([]T, error) {
+	return selectRows[T](r, prefix, prefix.PrefixEnd(), false, 0)
+}
+
+// scanKeys returns the keys of up to limit rows with a key in [lower, upper) for which match returns true.
+// A nil match selects all rows.
+func scanKeys[T any](r pebbledb.Reader, lower key, upper key, limit int, match func(*T) bool) ([]key, error) {
+	var keys []key
+	err := scan(r, lower, upper, false, 0, func(k []byte, value []byte) (bool, error) {
+		if match != nil {
+			var row T
+			if err := decodeRow(value, &row); err != nil {
+				return false, err
+			}
+			if !match(&row) {
+				return true, nil
+			}
+		}
+		keys = append(keys, append(key(nil), k...))
+		return limit <= 0 || len(keys) < limit, nil
+	})
+	return keys, err
+}
+
+// insertRows writes rows which must not exist yet. If any of them exists, nothing is written and a duplicate
+// entry error is returned.
+func insertRows(b *pebbledb.Batch, rows ...kv) (int64, error) {
+	seen := make(map[string]struct{}, len(rows))
+	for _, row := range rows {
+		if _, ok := seen[string(row.key)]; ok {
+			return 0, &dupEntryError{table: table(row.key[0])}
+		}
+		seen[string(row.key)] = struct{}{}
+		ok, err := exists(b, row.key)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			return 0, &dupEntryError{table: table(row.key[0])}
+		}
+	}
+	return replaceRows(b, rows...)
+}
+
+// replaceRows writes rows, overwriting existing ones.
+func replaceRows(b *pebbledb.Batch, rows ...kv) (int64, error) {
+	for _, row := range rows {
+		if err := b.Set(row.key, row.value, nil); err != nil {
+			return 0, err
+		}
+	}
+	return int64(len(rows)), nil
+}
+
+// updateRow overwrites the row under k if it exists.
+func updateRow(b *pebbledb.Batch, k key, value []byte) (int64, error) {
+	ok, err := exists(b, k)
+	if err != nil || !ok {
+		return 0, err
+	}
+	return 1, b.Set(k, value, nil)
+}
+
+// deleteKeys deletes the rows under keys which exist.
+func deleteKeys(b *pebbledb.Batch, keys ...key) (int64, error) {
+	var n int64
+	for _, k := range keys {
+		ok, err := exists(b, k)
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			continue
+		}
+		if err := b.Delete(k, nil); err != nil {
+			return 0, err
+		}
+		n++
+	}
+	return n, nil
+}
+
+// deleteRange deletes up to limit rows with a key in [lower, upper) for which match returns true.
+func deleteRange[T any](b *pebbledb.Batch, lower key, upper key, limit int, match func(*T) bool) (int64, error) {
+	keys, err := scanKeys(b, lower, upper, limit, match)
+	if err != nil {
+		return 0, err
+	}
+	// keys are collected before deleting, as the iterator must not observe its own mutations
+	return deleteKeys(b, keys...)
+}
+
+// firstKey returns the key of the first row, which selects the lock of a statement writing the rows.
+func firstKey(rows []kv) key {
+	if len(rows) == 0 {
+		return nil
+	}
+	return rows[0].key
+}
+
+// insert writes rows which must not exist yet, see insertRows.
+func (pdb *db) insert(ctx context.Context, rows ...kv) (sql.Result, error) {
+	return pdb.update(ctx, firstKey(rows), func(b *pebbledb.Batch) (int64, error) {
+		return insertRows(b, rows...)
+	})
+}
+
+// replace writes rows, overwriting existing ones.
+func (pdb *db) replace(ctx context.Context, rows ...kv) (sql.Result, error) {
+	return pdb.update(ctx, firstKey(rows), func(b *pebbledb.Batch) (int64, error) {
+		return replaceRows(b, rows...)
+	})
+}
+
+// updateExisting overwrites the row under k if it exists.
+func (pdb *db) updateExisting(ctx context.Context, k key, value []byte) (sql.Result, error) {
+	return pdb.update(ctx, k, func(b *pebbledb.Batch) (int64, error) {
+		return updateRow(b, k, value)
+	})
+}
+
+// delete deletes the rows under keys.
+func (pdb *db) delete(ctx context.Context, keys ...key) (sql.Result, error) {
+	var k key
+	if len(keys) > 0 {
+		k = keys[0]
+	}
+	return pdb.update(ctx, k, func(b *pebbledb.Batch) (int64, error) {
+		return deleteKeys(b, keys...)
+	})
+}
+
+// deletePrefix deletes all rows with a key starting with prefix.
+func (pdb *db) deletePrefix(ctx context.Context, prefix key) (sql.Result, error) {
+	return pdb.update(ctx, prefix, func(b *pebbledb.Batch) (int64, error) {
+		return deleteRange[struct{}](b, prefix, prefix.PrefixEnd(), 0, nil)
+	})
+}
@@ -0,0 +1,63 @@
+package pebble
+
+import (
+	"context"
+	"database/sql"
+
+	"go.temporal.io/server/common/persistence/sql/sqlplugin"
+)
+
+func shardKey(shardID int32) key {
+	return newKey(tableShards).Int32(shardID)
+}
+
+// InsertIntoShards inserts one or more rows into shards table
+func (pdb *db) InsertIntoShards(
+	ctx context.Context,
+	row *sqlplugin.ShardsRow,
+) (sql.Result, error) {
+	return pdb.insert(ctx, kv{key: shardKey(row.ShardID), value: encodeRow(row)})
+}
+
+// UpdateShards updates one or more rows into shards table
+func (pdb *db) UpdateShards(
+	ctx context.Context,
+	row *sqlplugin.ShardsRow,
+) (sql.Result, error) {
+	return pdb.updateExisting(ctx, shardKey(row.ShardID), encodeRow(row))
+}
+
+// SelectFromShards reads one or more rows from shards table
+func (pdb *db) SelectFromShards(
+	_ context.Context,
+	filter sqlplugin.ShardsFilter,
+) (*sqlplugin.ShardsRow, error) {
+	var row sqlplugin.ShardsRow
+	if err := getRow(pdb.reader(), shardKey(filter.ShardID), &row); err != nil {
+		return nil, err
+	}
+	return &row, nil
+}
+
+// ReadLockShards acquires a read lock on a single row in shards table
+func (pdb *db) ReadLockShards(
+	ctx context.Context,
+	filter sqlplugin.ShardsFilter,
+) (int64, error) {
+	if err := pdb.lock(ctx, shardKey(filter.ShardID)); err != nil {
+		return 0, err
+	}
+	row, err := pdb.SelectFromShards(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	return row.RangeID, nil
+}
+
+// WriteLockShards acquires a write lock on a single row in shards table
+func (pdb *db) WriteLockShards(
+	ctx context.Context,
+	filter sqlplugin.ShardsFilter,
+) (int64, error) {
+	return pdb.ReadLockShards(ctx, filter)
+}
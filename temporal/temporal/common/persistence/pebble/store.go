@@ -0,0 +1,259 @@
+package pebble
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	pebbledb "github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/vfs"
+	"go.temporal.io/server/common/config"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/log/tag"
+	"go.temporal.io/server/common/persistence/sql/sqlplugin"
+)
+
+const (
+	// ConnectAttributeMode selects the storage mode, set it to ModeMemory to keep all data in memory.
+	ConnectAttributeMode = "mode"
+	// ConnectAttributeSync controls whether writes are synced to disk before they are acknowledged, defaults to true.
+	ConnectAttributeSync = "sync"
+
+	// ModeMemory keeps all data in memory, the data is lost once the last reference to the store is closed.
+	ModeMemory = "memory"
+
+	unshardedLockID lockID = -1
+)
+
+type (
+	// store is the shared state of a single pebble database. All temporal services of a process share one store
+	// per database path, the same way they share a single connection to an embedded sqlite database.
+	//
+	// Pebble itself doesn't provide transactions with isolation, so the writes are serialized through locks. The
+	// rows of the history shard tables are guarded by one lock per shard, as temporal transactions never span
+	// shards, and the rows of all the other tables share a single lock. A transaction acquires the lock of the rows
+	// it writes or locks for update on first use, holds it until Commit or Rollback, and buffers its writes in an
+	// indexed batch, so that it can read its own writes. Reads outside a transaction are served directly from the
+	// database.
+	store struct {
+		db           *pebbledb.DB
+		writeOptions *pebbledb.WriteOptions
+
+		locksMu sync.Mutex
+		locks   map[lockID]chan struct{}
+	}
+
+	// lockID identifies a lock of the store: the shard ID for the rows of the history shard tables, and
+	// unshardedLockID for the rows of all the other tables.
+	lockID int64
+
+	storePool struct {
+		mu   sync.Mutex
+		pool map[string]*storeEntry
+	}
+
+	storeEntry struct {
+		store    *store
+		refCount int
+	}
+)
+
+func newStorePool() *storePool {
+	return &storePool{
+		pool: make(map[string]*storeEntry),
+	}
+}
+
+// Allocate returns the store for the database configured in cfg, opening it on first use. Each call counts as a
+// reference until Release.
+func (sp *storePool) Allocate(cfg *config.SQL, logger log.Logger) (*store, error) {
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+
+	id := storeID(cfg)
+	if e, ok := sp.pool[id]; ok {
+		e.refCount++
+		return e.store, nil
+	}
+
+	s, err := openStore(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+	sp.pool[id] = &storeEntry{store: s, refCount: 1}
+	return s, nil
+}
+
+// Release removes a reference to the store, the database is closed once no references are left. In memory
+// databases are never closed, as temporal services open and close their connections multiple times during the
+// lifetime of the process and closing the database would lose all data.
+func (sp *storePool) Release(cfg *config.SQL) error {
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+
+	id := storeID(cfg)
+	e, ok := sp.pool[id]
+	if !ok {
+		return nil
+	}
+	e.refCount--
+	if e.refCount > 0 || cfg.ConnectAttributes[ConnectAttributeMode] == ModeMemory {
+		return nil
+	}
+	delete(sp.pool, id)
+	return e.store.db.Close()
+}
+
+func storeID(cfg *config.SQL) string {
+	return cfg.ConnectAttributes[ConnectAttributeMode] + ":" + cfg.DatabaseName
+}
+
+func openStore(cfg *config.SQL, logger log.Logger) (*store, error) {
+	opts := &pebbledb.Options{
+		Logger: &pebbleLogger{logger: logger},
+	}
+	switch mode := cfg.ConnectAttributes[ConnectAttributeMode]; mode {
+	case ModeMemory:
+		opts.FS = vfs.NewMem()
+	case "":
+		if cfg.DatabaseName == "" {
+			return nil, errors.New("pebble: database path is required unless running in memory mode")
+		}
+	default:
+		return nil, fmt.Errorf("pebble: unknown mode %q", mode)
+	}
+
+	writeOptions := pebbledb.Sync
+	switch cfg.ConnectAttributes[ConnectAttributeSync] {
+	case "", "true":
+	case "false":
+		writeOptions = pebbledb.NoSync
+	default:
+		return nil, fmt.Errorf("pebble: invalid value %q for connect attribute %q",
+			cfg.ConnectAttributes[ConnectAttributeSync], ConnectAttributeSync)
+	}
+
+	pdb, err := pebbledb.Open(cfg.DatabaseName, opts)
+	if err != nil {
+		return nil, err
+	}
+	s := &store{
+		db:           pdb,
+		writeOptions: writeOptions,
+		locks:        make(map[lockID]chan struct{}),
+	}
+	if err := s.bootstrap(); err != nil {
+		_ = pdb.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// bootstrap creates the rows which the SQL schemas insert as part of the schema setup.
+func (s *store) bootstrap() error {
+	k := namespaceMetadataKey()
+	_, err := s.update(context.Background(), lockOf(k), func(b *pebbledb.Batch) (int64, error) {
+		ok, err := exists(b, k)
+		if err != nil || ok {
+			return 0, err
+		}
+		return 1, b.Set(k, encodeRow(&sqlplugin.NamespaceMetadataRow{NotificationVersion: 1}), nil)
+	})
+	return err
+}
+
+// lockOf returns the lock which guards the row under k, k may also be the prefix of a range of rows of a single
+// shard.
+func lockOf(k key) lockID {
+	if len(k) < 5 {
+		return unshardedLockID
+	}
+	switch table(k[0]) {
+	case tableShards,
+		tableExecutions,
+		tableCurrentExecutions,
+		tableBufferedEvents,
+		tableActivityInfoMaps,
+		tableTimerInfoMaps,
+		tableChildExecutionInfoMaps,
+		tableRequestCancelInfoMaps,
+		tableSignalInfoMaps,
+		tableSignalsRequestedSets,
+		tableChasmNodeMaps,
+		tableHistoryImmediateTasks,
+		tableHistoryScheduledTasks,
+		tableTransferTasks,
+		tableTimerTasks,
+		tableReplicationTasks,
+		tableVisibilityTasks,
+		tableHistoryNode,
+		tableHistoryTree:
+		// the shard ID is the first column of the keys of these tables, see key.Int32
+		return lockID(int32(binary.BigEndian.Uint32(k[1:5]) ^ (1 << 31)))
+	default:
+		return unshardedLockID
+	}
+}
+
+func (s *store) lock(ctx context.Context, id lockID) error {
+	s.locksMu.Lock()
+	l, ok := s.locks[id]
+	if !ok {
+		l = make(chan struct{}, 1)
+		s.locks[id] = l
+	}
+	s.locksMu.Unlock()
+
+	select {
+	case l <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *store) unlock(id lockID) {
+	s.locksMu.Lock()
+	l := s.locks[id]
+	s.locksMu.Unlock()
+	<-l
+}
+
+// update applies fn to a new batch and commits it atomically, holding the lock id.
+func (s *store) update(ctx context.Context, id lockID, fn func(b *pebbledb.Batch) (int64, error)) (result, error) {
+	if err := s.lock(ctx, id); err != nil {
+		return 0, err
+	}
+	defer s.unlock(id)
+
+	b := s.db.NewIndexedBatch()
+	defer func() { _ = b.Close() }()
+
+	n, err := fn(b)
+	if err != nil {
+		return 0, err
+	}
+	if err := b.Commit(s.writeOptions); err != nil {
+		return 0, err
+	}
+	return result(n), nil
+}
+
+// pebbleLogger forwards pebble's internal logging to the temporal logger.
+type pebbleLogger struct {
+	logger log.Logger
+}
+
+func (l *pebbleLogger) Infof(format string, args ...any) {
+	l.logger.Debug(fmt.Sprintf(format, args...), tag.ComponentPersistence)
+}
+
+func (l *pebbleLogger) Errorf(format string, args ...any) {
+	l.logger.Error(fmt.Sprintf(format, args...), tag.ComponentPersistence)
+}
+
+func (l *pebbleLogger) Fatalf(format string, args ...any) {
+	l.logger.Fatal(fmt.Sprintf(format, args...), tag.ComponentPersistence)
+}
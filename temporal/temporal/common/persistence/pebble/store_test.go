@@ -0,0 +1,77 @@
+package pebble
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/server/common/config"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/persistence/sql/sqlplugin"
+)
+
+func newTestDB(t *testing.T) *db {
+	s, err := openStore(&config.SQL{
+		DatabaseName:      t.Name(),
+		ConnectAttributes: map[string]string{ConnectAttributeMode: ModeMemory},
+	}, log.NewTestLogger())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = s.db.Close() })
+	return newDB(sqlplugin.DbKindMain, t.Name(), s, nil, log.NewTestLogger())
+}
+
+func TestLockOf(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, lockID(1), lockOf(shardKey(1)))
+	require.Equal(t, lockID(2), lockOf(shardTaskKey(tableTimerTasks, 2)))
+	require.Equal(t, lockID(3), lockOf(executionKey(tableExecutions, 3, []byte("namespace-id"), "workflow-id", []byte("run-id"))))
+	require.Equal(t, lockID(4), lockOf(historyTreeKey(4, []byte("tree-id"))))
+	require.Equal(t, unshardedLockID, lockOf(namespaceMetadataKey()))
+	require.Equal(t, unshardedLockID, lockOf(replicationDLQTaskKey("cluster", 1)))
+	require.Equal(t, unshardedLockID, lockOf(nil))
+}
+
+func TestTx_ShardLocks(t *testing.T) {
+	t.Parallel()
+
+	pdb := newTestDB(t)
+	ctx := context.Background()
+	for _, shardID := range []int32{1, 2} {
+		_, err := pdb.InsertIntoShards(ctx, &sqlplugin.ShardsRow{ShardID: shardID, RangeID: 1})
+		require.NoError(t, err)
+	}
+
+	tx1, err := pdb.BeginTx(ctx)
+	require.NoError(t, err)
+	_, err = tx1.WriteLockShards(ctx, sqlplugin.ShardsFilter{ShardID: 1})
+	require.NoError(t, err)
+
+	// the transactions of other shards are not blocked
+	tx2, err := pdb.BeginTx(ctx)
+	require.NoError(t, err)
+	_, err = tx2.WriteLockShards(ctx, sqlplugin.ShardsFilter{ShardID: 2})
+	require.NoError(t, err)
+	_, err = tx2.UpdateShards(ctx, &sqlplugin.ShardsRow{ShardID: 2, RangeID: 2})
+	require.NoError(t, err)
+	require.NoError(t, tx2.Commit())
+
+	// the transactions and statements of the same shard wait for the transaction to end
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = pdb.UpdateShards(timeoutCtx, &sqlplugin.ShardsRow{ShardID: 1, RangeID: 2})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	tx3, err := pdb.BeginTx(ctx)
+	require.NoError(t, err)
+	_, err = tx3.WriteLockShards(timeoutCtx, sqlplugin.ShardsFilter{ShardID: 1})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.NoError(t, tx3.Rollback())
+
+	_, err = tx1.UpdateShards(ctx, &sqlplugin.ShardsRow{ShardID: 1, RangeID: 2})
+	require.NoError(t, err)
+	require.NoError(t, tx1.Commit())
+	rangeID, err := pdb.ReadLockShards(ctx, sqlplugin.ShardsFilter{ShardID: 1})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), rangeID)
+}
@@ -0,0 +1,281 @@
+package pebble
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"slices"
+
+	pebbledb "github.com/cockroachdb/pebble"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/persistence/sql/sqlplugin"
+)
+
+type buildIDToTaskQueueValue struct {
+	TaskQueueName string
+}
+
+func taskQueueKey(v sqlplugin.MatchingTaskVersion, rangeHash uint32) key {
+	switch v {
+	case sqlplugin.MatchingTaskVersion1:
+		return newKey(tableTaskQueues).Uint32(rangeHash)
+	case sqlplugin.MatchingTaskVersion2:
+		return newKey(tableTaskQueuesV2).Uint32(rangeHash)
+	default:
+		panic("invalid task schema version") // nolint:forbidigo // hardcoded constants
+	}
+}
+
+func taskQueueUserDataKey(namespaceID []byte) key {
+	return newKey(tableTaskQueueUserData).Bytes(namespaceID)
+}
+
+func buildIDToTaskQueueKey(namespaceID []byte, buildID string) key {
+	return newKey(tableBuildIDToTaskQueue).Bytes(namespaceID).String(buildID)
+}
+
+// InsertIntoTaskQueues inserts one or more rows into task_queues[_v2] table
+func (pdb *db) InsertIntoTaskQueues(
+	ctx context.Context,
+	row *sqlplugin.TaskQueuesRow,
+	v sqlplugin.MatchingTaskVersion,
+) (sql.Result, error) {
+	return pdb.insert(ctx, kv{key: taskQueueKey(v, row.RangeHash).Bytes(row.TaskQueueID), value: encodeRow(row)})
+}
+
+// UpdateTaskQueues updates a row in task_queues[_v2] table
+func (pdb *db) UpdateTaskQueues(
+	ctx context.Context,
+	row *sqlplugin.TaskQueuesRow,
+	v sqlplugin.MatchingTaskVersion,
+) (sql.Result, error) {
+	return pdb.updateExisting(ctx, taskQueueKey(v, row.RangeHash).Bytes(row.TaskQueueID), encodeRow(row))
+}
+
+// SelectFromTaskQueues reads one or more rows from task_queues[_v2] table
+func (pdb *db) SelectFromTaskQueues(
+	_ context.Context,
+	filter sqlplugin.TaskQueuesFilter,
+	v sqlplugin.MatchingTaskVersion,
+) ([]sqlplugin.TaskQueuesRow, error) {
+	switch {
+	case filter.TaskQueueID != nil:
+		if filter.RangeHashLessThanEqualTo != 0 || filter.RangeHashGreaterThanEqualTo != 0 {
+			return nil, serviceerror.NewInternal("range of hashes not supported for specific selection")
+		}
+		var row sqlplugin.TaskQueuesRow
+		if err := getRow(pdb.reader(), taskQueueKey(v, filter.RangeHash).Bytes(filter.TaskQueueID), &row); err != nil {
+			return nil, err
+		}
+		return []sqlplugin.TaskQueuesRow{row}, nil
+	case filter.RangeHashLessThanEqualTo != 0 && filter.PageSize != nil:
+		if filter.RangeHashLessThanEqualTo < filter.RangeHashGreaterThanEqualTo {
+			return nil, serviceerror.NewInternal("range of hashes bound is invalid")
+		}
+		return pdb.rangeSelectFromTaskQueues(filter, v)
+	case filter.TaskQueueIDGreaterThan != nil && filter.PageSize != nil:
+		return pdb.rangeSelectFromTaskQueues(filter, v)
+	default:
+		return nil, serviceerror.NewInternal("invalid set of query filter params")
+	}
+}
+
+func (pdb *db) rangeSelectFromTaskQueues(
+	filter sqlplugin.TaskQueuesFilter,
+	v sqlplugin.MatchingTaskVersion,
+) ([]sqlplugin.TaskQueuesRow, error) {
+	if filter.RangeHashLessThanEqualTo == 0 {
+		prefix := taskQueueKey(v, filter.RangeHash)
+		return selectRows[sqlplugin.TaskQueuesRow](
+			pdb.reader(),
+			prefix.Bytes(filter.TaskQueueIDGreaterThan).Next(),
+			prefix.PrefixEnd(),
+			false,
+			*filter.PageSize,
+		)
+	}
+
+	// rows of a range of hashes are ordered by task queue ID, which doesn't match the key order
+	var rows []sqlplugin.TaskQueuesRow
+	err := scan(
+		pdb.reader(),
+		taskQueueKey(v, filter.RangeHashGreaterThanEqualTo),
+		taskQueueKey(v, filter.RangeHashLessThanEqualTo).PrefixEnd(),
+		false,
+		0,
+		func(_ []byte, value []byte) (bool, error) {
+			var row sqlplugin.TaskQueuesRow
+			if err := decodeRow(value, &row); err != nil {
+				return false, err
+			}
+			if bytes.Compare(row.TaskQueueID, filter.TaskQueueIDGreaterThan) > 0 {
+				rows = append(rows, row)
+			}
+			return true, nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(rows, func(a, b sqlplugin.TaskQueuesRow) int {
+		return bytes.Compare(a.TaskQueueID, b.TaskQueueID)
+	})
+	if len(rows) > *filter.PageSize {
+		rows = rows[:*filter.PageSize]
+	}
+	return rows, nil
+}
+
+// DeleteFromTaskQueues deletes a row from task_queues[_v2] table
+func (pdb *db) DeleteFromTaskQueues(
+	ctx context.Context,
+	filter sqlplugin.TaskQueuesFilter,
+	v sqlplugin.MatchingTaskVersion,
+) (sql.Result, error) {
+	k := taskQueueKey(v, filter.RangeHash).Bytes(filter.TaskQueueID)
+	return pdb.update(ctx, k, func(b *pebbledb.Batch) (int64, error) {
+		var row sqlplugin.TaskQueuesRow
+		ok, err := get(b, k, &row)
+		if err != nil || !ok || row.RangeID != *filter.RangeID {
+			return 0, err
+		}
+		return deleteKeys(b, k)
+	})
+}
+
+// LockTaskQueues locks a row in task_queues[_v2] table
+func (pdb *db) LockTaskQueues(
+	ctx context.Context,
+	filter sqlplugin.TaskQueuesFilter,
+	v sqlplugin.MatchingTaskVersion,
+) (int64, error) {
+	k := taskQueueKey(v, filter.RangeHash).Bytes(filter.TaskQueueID)
+	if err := pdb.lock(ctx, k); err != nil {
+		return 0, err
+	}
+	var row sqlplugin.TaskQueuesRow
+	if err := getRow(pdb.reader(), k, &row); err != nil {
+		return 0, err
+	}
+	return row.RangeID, nil
+}
+
+func (pdb *db) GetTaskQueueUserData(
+	_ context.Context,
+	request *sqlplugin.GetTaskQueueUserDataRequest,
+) (*sqlplugin.VersionedBlob, error) {
+	var row sqlplugin.TaskQueueUserDataEntry
+	if err := getRow(
+		pdb.reader(),
+		taskQueueUserDataKey(request.NamespaceID).String(request.TaskQueueName),
+		&row,
+	); err != nil {
+		return nil, err
+	}
+	return &row.VersionedBlob, nil
+}
+
+func (pdb *db) UpdateTaskQueueUserData(
+	ctx context.Context,
+	request *sqlplugin.UpdateTaskQueueDataRequest,
+) error {
+	k := taskQueueUserDataKey(request.NamespaceID).String(request.TaskQueueName)
+	row := &sqlplugin.TaskQueueUserDataEntry{
+		TaskQueueName: request.TaskQueueName,
+		VersionedBlob: sqlplugin.VersionedBlob{
+			Version:      request.Version + 1,
+			Data:         request.Data,
+			DataEncoding: request.DataEncoding,
+		},
+	}
+	if request.Version == 0 {
+		_, err := pdb.insert(ctx, kv{key: k, value: encodeRow(row)})
+		return err
+	}
+	result, err := pdb.update(ctx, k, func(b *pebbledb.Batch) (int64, error) {
+		var current sqlplugin.TaskQueueUserDataEntry
+		ok, err := get(b, k, &current)
+		if err != nil || !ok || current.Version != request.Version {
+			return 0, err
+		}
+		return 1, b.Set(k, encodeRow(row), nil)
+	})
+	if err != nil {
+		return err
+	}
+	if result != 1 {
+		return &persistence.ConditionFailedError{Msg: "Expected exactly one row to be updated"}
+	}
+	return nil
+}
+
+func (pdb *db) ListTaskQueueUserDataEntries(
+	_ context.Context,
+	request *sqlplugin.ListTaskQueueUserDataEntriesRequest,
+) ([]sqlplugin.TaskQueueUserDataEntry, error) {
+	prefix := taskQueueUserDataKey(request.NamespaceID)
+	return selectRows[sqlplugin.TaskQueueUserDataEntry](
+		pdb.reader(),
+		prefix.String(request.LastTaskQueueName).Next(),
+		prefix.PrefixEnd(),
+		false,
+		request.Limit,
+	)
+}
+
+func (pdb *db) AddToBuildIdToTaskQueueMapping(
+	ctx context.Context,
+	request sqlplugin.AddToBuildIdToTaskQueueMapping,
+) error {
+	kvs := make([]kv, len(request.BuildIds))
+	for i, buildID := range request.BuildIds {
+		kvs[i] = kv{
+			key:   buildIDToTaskQueueKey(request.NamespaceID, buildID).String(request.TaskQueueName),
+			value: encodeRow(&buildIDToTaskQueueValue{TaskQueueName: request.TaskQueueName}),
+		}
+	}
+	_, err := pdb.insert(ctx, kvs...)
+	return err
+}
+
+func (pdb *db) RemoveFromBuildIdToTaskQueueMapping(
+	ctx context.Context,
+	request sqlplugin.RemoveFromBuildIdToTaskQueueMapping,
+) error {
+	keys := make([]key, len(request.BuildIds))
+	for i, buildID := range request.BuildIds {
+		keys[i] = buildIDToTaskQueueKey(request.NamespaceID, buildID).String(request.TaskQueueName)
+	}
+	_, err := pdb.delete(ctx, keys...)
+	return err
+}
+
+func (pdb *db) GetTaskQueuesByBuildId(
+	_ context.Context,
+	request *sqlplugin.GetTaskQueuesByBuildIdRequest,
+) ([]string, error) {
+	rows, err := selectPrefix[buildIDToTaskQueueValue](
+		pdb.reader(),
+		buildIDToTaskQueueKey(request.NamespaceID, request.BuildID),
+	)
+	if err != nil {
+		return nil, err
+	}
+	taskQueues := make([]string, len(rows))
+	for i, row := range rows {
+		taskQueues[i] = row.TaskQueueName
+	}
+	return taskQueues, nil
+}
+
+func (pdb *db) CountTaskQueuesByBuildId(
+	ctx context.Context,
+	request *sqlplugin.CountTaskQueuesByBuildIdRequest,
+) (int, error) {
+	taskQueues, err := pdb.GetTaskQueuesByBuildId(ctx, &sqlplugin.GetTaskQueuesByBuildIdRequest{
+		NamespaceID: request.NamespaceID,
+		BuildID:     request.BuildID,
+	})
+	return len(taskQueues), err
+}
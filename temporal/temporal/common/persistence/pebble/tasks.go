@@ -0,0 +1,129 @@
+package pebble
+
+import (
+	"context"
+	"database/sql"
+
+	pebbledb "github.com/cockroachdb/pebble"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/server/common/persistence/sql/sqlplugin"
+)
+
+func taskKey(rangeHash uint32, taskQueueID []byte) key {
+	return newKey(tableTasks).Uint32(rangeHash).Bytes(taskQueueID)
+}
+
+func taskV2Key(rangeHash uint32, taskQueueID []byte) key {
+	return newKey(tableTasksV2).Uint32(rangeHash).Bytes(taskQueueID)
+}
+
+func (k key) fairLevel(level *sqlplugin.FairLevel) key {
+	return k.Int64(level.TaskPass).Int64(level.TaskID)
+}
+
+func pageSize(size *int) int {
+	if size == nil {
+		return 0
+	}
+	return *size
+}
+
+// InsertIntoTasks inserts one or more rows into tasks table
+func (pdb *db) InsertIntoTasks(
+	ctx context.Context,
+	rows []sqlplugin.TasksRow,
+) (sql.Result, error) {
+	kvs := make([]kv, len(rows))
+	for i := range rows {
+		row := &rows[i]
+		kvs[i] = kv{key: taskKey(row.RangeHash, row.TaskQueueID).Int64(row.TaskID), value: encodeRow(row)}
+	}
+	return pdb.insert(ctx, kvs...)
+}
+
+// SelectFromTasks reads one or more rows from tasks table
+func (pdb *db) SelectFromTasks(
+	_ context.Context,
+	filter sqlplugin.TasksFilter,
+) ([]sqlplugin.TasksRow, error) {
+	if filter.InclusiveMinTaskID == nil {
+		return nil, serviceerror.NewInternal("missing InclusiveMinTaskID parameter")
+	}
+	prefix := taskKey(filter.RangeHash, filter.TaskQueueID)
+	upper := prefix.PrefixEnd()
+	if filter.ExclusiveMaxTaskID != nil {
+		upper = prefix.Int64(*filter.ExclusiveMaxTaskID)
+	}
+	return selectRows[sqlplugin.TasksRow](
+		pdb.reader(),
+		prefix.Int64(*filter.InclusiveMinTaskID),
+		upper,
+		false,
+		pageSize(filter.PageSize),
+	)
+}
+
+// DeleteFromTasks deletes one or more rows from tasks table
+func (pdb *db) DeleteFromTasks(
+	ctx context.Context,
+	filter sqlplugin.TasksFilter,
+) (sql.Result, error) {
+	if filter.ExclusiveMaxTaskID == nil {
+		return nil, serviceerror.NewInternal("missing ExclusiveMaxTaskID parameter")
+	}
+	if filter.Limit == nil || *filter.Limit == 0 {
+		return nil, serviceerror.NewInternal("missing limit parameter")
+	}
+	prefix := taskKey(filter.RangeHash, filter.TaskQueueID)
+	return pdb.update(ctx, prefix, func(b *pebbledb.Batch) (int64, error) {
+		return deleteRange[struct{}](b, prefix, prefix.Int64(*filter.ExclusiveMaxTaskID), *filter.Limit, nil)
+	})
+}
+
+// InsertIntoTasksV2 inserts one or more rows into tasks_v2 table
+func (pdb *db) InsertIntoTasksV2(
+	ctx context.Context,
+	rows []sqlplugin.TasksRowV2,
+) (sql.Result, error) {
+	kvs := make([]kv, len(rows))
+	for i := range rows {
+		row := &rows[i]
+		kvs[i] = kv{key: taskV2Key(row.RangeHash, row.TaskQueueID).fairLevel(&row.FairLevel), value: encodeRow(row)}
+	}
+	return pdb.insert(ctx, kvs...)
+}
+
+// SelectFromTasksV2 reads one or more rows from tasks_v2 table
+func (pdb *db) SelectFromTasksV2(
+	_ context.Context,
+	filter sqlplugin.TasksFilterV2,
+) ([]sqlplugin.TasksRowV2, error) {
+	if filter.InclusiveMinLevel == nil {
+		return nil, serviceerror.NewInternal("missing InclusiveMinLevel")
+	}
+	prefix := taskV2Key(filter.RangeHash, filter.TaskQueueID)
+	return selectRows[sqlplugin.TasksRowV2](
+		pdb.reader(),
+		prefix.fairLevel(filter.InclusiveMinLevel),
+		prefix.PrefixEnd(),
+		false,
+		pageSize(filter.PageSize),
+	)
+}
+
+// DeleteFromTasksV2 deletes one or more rows from tasks_v2 table
+func (pdb *db) DeleteFromTasksV2(
+	ctx context.Context,
+	filter sqlplugin.TasksFilterV2,
+) (sql.Result, error) {
+	if filter.ExclusiveMaxLevel == nil {
+		return nil, serviceerror.NewInternal("missing ExclusiveMaxTaskLevel")
+	}
+	if filter.Limit == nil || *filter.Limit == 0 {
+		return nil, serviceerror.NewInternal("missing limit parameter")
+	}
+	prefix := taskV2Key(filter.RangeHash, filter.TaskQueueID)
+	return pdb.update(ctx, prefix, func(b *pebbledb.Batch) (int64, error) {
+		return deleteRange[struct{}](b, prefix, prefix.fairLevel(filter.ExclusiveMaxLevel), *filter.Limit, nil)
+	})
+}
@@ -0,0 +1,69 @@
+package pebble
+
+import (
+	"os"
+
+	"go.temporal.io/server/common/config"
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/log/tag"
+	"go.temporal.io/server/common/primitives"
+)
+
+// TestCluster allows executing pebble operations in testing.
+type TestCluster struct {
+	cfg            config.SQL
+	faultInjection *config.FaultInjection
+	logger         log.Logger
+}
+
+// NewTestCluster returns a new pebble test cluster. Databases in memory mode are identified by dbName, otherwise
+// dbName is the directory of the database files.
+func NewTestCluster(
+	dbName string,
+	connectAttributes map[string]string,
+	faultInjection *config.FaultInjection,
+	logger log.Logger,
+) *TestCluster {
+	return &TestCluster{
+		cfg: config.SQL{
+			PluginName:         PluginName,
+			DatabaseName:       dbName,
+			TaskScanPartitions: 4,
+			ConnectAttributes:  connectAttributes,
+		},
+		faultInjection: faultInjection,
+		logger:         logger,
+	}
+}
+
+// DatabaseName from PersistenceTestCluster interface
+func (s *TestCluster) DatabaseName() string {
+	return s.cfg.DatabaseName
+}
+
+// SetupTestDatabase from PersistenceTestCluster interface. Pebble creates the database on first use and has no
+// schema, so there is nothing to do.
+func (s *TestCluster) SetupTestDatabase() {}
+
+// Config returns the persistence config for connecting to this test cluster
+func (s *TestCluster) Config() config.Persistence {
+	cfg := s.cfg
+	return config.Persistence{
+		DefaultStore: "test",
+		DataStores: map[string]config.DataStore{
+			"test": {SQL: &cfg, FaultInjection: s.faultInjection},
+		},
+		TransactionSizeLimit: dynamicconfig.GetIntPropertyFn(primitives.DefaultTransactionSizeLimit),
+	}
+}
+
+// TearDownTestDatabase from PersistenceTestCluster interface
+func (s *TestCluster) TearDownTestDatabase() {
+	if s.cfg.ConnectAttributes[ConnectAttributeMode] == ModeMemory {
+		return
+	}
+	if err := os.RemoveAll(s.cfg.DatabaseName); err != nil {
+		s.logger.Error("failed to remove pebble database", tag.Error(err))
+	}
+}
@@ -0,0 +1,83 @@
+package pebble
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"go.temporal.io/server/common/persistence/sql/sqlplugin"
+)
+
+// The pebble plugin only implements the main persistence store. Visibility needs a query engine, so it has to be
+// backed by one of the SQL or Elasticsearch visibility stores.
+var errVisibilityNotSupported = errors.New("pebble: visibility store is not supported")
+
+func (pdb *db) InsertIntoVisibility(
+	_ context.Context,
+	_ *sqlplugin.VisibilityRow,
+) (sql.Result, error) {
+	return nil, errVisibilityNotSupported
+}
+
+func (pdb *db) ReplaceIntoVisibility(
+	_ context.Context,
+	_ *sqlplugin.VisibilityRow,
+) (sql.Result, error) {
+	return nil, errVisibilityNotSupported
+}
+
+func (pdb *db) DeleteFromVisibility(
+	_ context.Context,
+	_ sqlplugin.VisibilityDeleteFilter,
+) (sql.Result, error) {
+	return nil, errVisibilityNotSupported
+}
+
+func (pdb *db) SelectFromVisibility(
+	_ context.Context,
+	_ sqlplugin.VisibilitySelectFilter,
+) ([]sqlplugin.VisibilityRow, error) {
+	return nil, errVisibilityNotSupported
+}
+
+func (pdb *db) GetFromVisibility(
+	_ context.Context,
+	_ sqlplugin.VisibilityGetFilter,
+) (*sqlplugin.VisibilityRow, error) {
+	return nil, errVisibilityNotSupported
+}
+
+func (pdb *db) CountFromVisibility(
+	_ context.Context,
+	_ sqlplugin.VisibilitySelectFilter,
+) (int64, error) {
+	return 0, errVisibilityNotSupported
+}
+
+func (pdb *db) CountGroupByFromVisibility(
+	_ context.Context,
+	_ sqlplugin.VisibilitySelectFilter,
+) ([]sqlplugin.VisibilityCountRow, error) {
+	return nil, errVisibilityNotSupported
+}
+
+func (pdb *db) SelectValuesFromVisibility(
+	_ context.Context,
+	_ sqlplugin.VisibilitySelectFilter,
+) ([]sqlplugin.VisibilityValuesRow, error) {
+	return nil, errVisibilityNotSupported
+}
+
+func (pdb *db) AggregateFromVisibility(
+	_ context.Context,
+	_ sqlplugin.VisibilitySelectFilter,
+) ([]sqlplugin.VisibilityAggregateRow, error) {
+	return nil, errVisibilityNotSupported
+}
+
+func (pdb *db) ExplainFromVisibility(
+	_ context.Context,
+	_ sqlplugin.VisibilitySelectFilter,
+) (string, error) {
+	return "", errVisibilityNotSupported
+}
@@ -799,6 +799,7 @@ func (s *HistoryV2PersistenceSuite) append(branch []byte, events []*historypb.Hi
 		resp, err = s.ExecutionManager.AppendHistoryNodes(s.ctx, &p.AppendHistoryNodesRequest{
 			IsNewBranch:   isNewBranch,
 			Info:          branchInfo,
+			NamespaceID:   uuid.NewString(),
 			BranchToken:   branch,
 			Events:        events,
 			TransactionID: txnID,
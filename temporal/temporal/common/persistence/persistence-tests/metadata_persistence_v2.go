@@ -20,6 +20,7 @@ import (
 	"go.temporal.io/server/common/debug"
 	p "go.temporal.io/server/common/persistence"
 	"go.temporal.io/server/common/persistence/cassandra"
+	"go.temporal.io/server/common/persistence/pebble"
 	"go.temporal.io/server/common/persistence/sql"
 	"go.temporal.io/server/common/primitives/timestamp"
 	"go.temporal.io/server/common/testing/protorequire"
@@ -2119,7 +2120,7 @@ func (m *MetadataPersistenceSuiteV2) TestRenameNamespaceNotFound() {
 func (m *MetadataPersistenceSuiteV2) TestRenameNamespaceCassandra() {
 	// This test is for Cassandra
 	switch m.DefaultTestCluster.(type) {
-	case *sql.TestCluster:
+	case *sql.TestCluster, *pebble.TestCluster:
 		m.T().Skip()
 	default:
 	}
@@ -2243,7 +2244,7 @@ func (m *MetadataPersistenceSuiteV2) TestRenameNamespaceCassandra() {
 func (m *MetadataPersistenceSuiteV2) TestRenameNamespaceSQL() {
 	// This test is for SQL databases
 	switch m.DefaultTestCluster.(type) {
-	case *sql.TestCluster:
+	case *sql.TestCluster, *pebble.TestCluster:
 	default:
 		m.T().Skip()
 	}
@@ -211,6 +211,7 @@ func (s *TestBase) Setup(clusterMetadataConfig *cluster.Config) {
 		client.ClusterName(clusterName),
 		resolver.NewNoopResolver(),
 		&cfg,
+		nil,
 		s.AbstractDataStoreFactory,
 		s.Logger,
 		metrics.NoopMetricsHandler,
@@ -231,6 +232,8 @@ func (s *TestBase) Setup(clusterMetadataConfig *cluster.Config) {
 		s.PersistenceHealthSignals,
 		func() bool { return false },
 		func() bool { return false },
+		nil,
+		nil,
 	)
 
 	s.TaskMgr, err = factory.NewTaskManager()
@@ -411,6 +411,12 @@ type (
 		BufferedEvents      []*commonpb.DataBlob `json:",omitempty"`
 		Checksum            *commonpb.DataBlob   // persistencespb.Checksum
 		DBRecordVersion     int64
+		// NamespaceID, WorkflowID and RunID identify the execution of the mutable state, whose blobs can't be
+		// decrypted without them. They are only set by ListConcreteExecutions, since the other requests already
+		// identify the execution.
+		NamespaceID string `json:"-"`
+		WorkflowID  string `json:"-"`
+		RunID       string `json:"-"`
 	}
 
 	InternalHistoryTask struct {
@@ -0,0 +1,173 @@
+package serialization
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/common/namespace"
+)
+
+// BlobCategory is a category of persisted blobs which can be compressed with a BlobCompressor.
+type BlobCategory string
+
+const (
+	// BlobCategoryHistory is the category of history event batches, including buffered events.
+	BlobCategoryHistory BlobCategory = "history"
+	// BlobCategoryMutableState is the category of the workflow execution info and of the pending activity,
+	// timer, child execution, request cancel and signal infos of mutable state.
+	BlobCategoryMutableState BlobCategory = "mutableState"
+
+	// minCompressedBlobSize is the size below which blobs are not worth compressing.
+	minCompressedBlobSize = 256
+
+	blobCategoryTagName = "blob_category"
+)
+
+// compressedBlobHeader prefixes the data of compressed proto3 blobs. A valid proto3 message can't start with a zero
+// byte, since that would be a tag with the reserved field number 0, so compressed blobs can't be mistaken for
+// uncompressed ones. The second byte identifies the compression algorithm.
+var compressedBlobHeader = []byte{0x00, 0x01}
+
+var zstdDecoder = sync.OnceValue(func() *zstd.Decoder {
+	// DecodeAll is safe for concurrent use, and NewReader only fails on invalid options.
+	decoder, _ := zstd.NewReader(nil, zstd.WithDecoderConcurrency(0))
+	return decoder
+})
+
+type (
+	// BlobCompressor compresses proto3 blobs with zstd, for the namespaces and blob categories enabled by the
+	// PersistenceBlobCompressionCategories dynamic config. Compressed blobs keep the proto3 encoding type, and are
+	// decoded transparently by Decode and by all Decoders, so that compressed and uncompressed blobs can be read side
+	// by side. A nil BlobCompressor doesn't compress anything.
+	BlobCompressor struct {
+		categories     dynamicconfig.TypedPropertyFnWithNamespaceIDFilter[[]string]
+		level          dynamicconfig.IntPropertyFn
+		metricsHandler metrics.Handler
+
+		encodersLock sync.Mutex
+		encoders     map[zstd.EncoderLevel]*zstd.Encoder
+	}
+)
+
+// NewBlobCompressor returns a new BlobCompressor which reads its configuration from dc.
+func NewBlobCompressor(
+	dc *dynamicconfig.Collection,
+	metricsHandler metrics.Handler,
+) *BlobCompressor {
+	return &BlobCompressor{
+		categories:     dynamicconfig.PersistenceBlobCompressionCategories.Get(dc),
+		level:          dynamicconfig.PersistenceBlobCompressionLevel.Get(dc),
+		metricsHandler: metricsHandler,
+		encoders:       make(map[zstd.EncoderLevel]*zstd.Encoder),
+	}
+}
+
+// Enabled returns true if blobs of the category are compressed for the namespace.
+func (c *BlobCompressor) Enabled(namespaceID string, category BlobCategory) bool {
+	if c == nil {
+		return false
+	}
+	for _, enabled := range c.categories(namespace.ID(namespaceID)) {
+		if BlobCategory(enabled) == category {
+			return true
+		}
+	}
+	return false
+}
+
+// Compress returns the compressed blob if compression is enabled for the namespace and blob category. Blobs which
+// are not proto3 encoded, already compressed or encrypted, too small or which don't get smaller are returned
+// unchanged.
+func (c *BlobCompressor) Compress(
+	namespaceID string,
+	category BlobCategory,
+	blob *commonpb.DataBlob,
+) (*commonpb.DataBlob, error) {
+	if blob == nil ||
+		blob.EncodingType != enumspb.ENCODING_TYPE_PROTO3 ||
+		len(blob.Data) < minCompressedBlobSize ||
+		IsCompressed(blob) ||
+		IsEncrypted(blob) ||
+		!c.Enabled(namespaceID, category) {
+		return blob, nil
+	}
+
+	encoder, err := c.encoder()
+	if err != nil {
+		return nil, NewSerializationError(blob.EncodingType, err)
+	}
+	data := encoder.EncodeAll(blob.Data, append(make([]byte, 0, len(blob.Data)/2), compressedBlobHeader...))
+
+	tags := []metrics.Tag{metrics.StringTag(blobCategoryTagName, string(category))}
+	metrics.PersistenceBlobUncompressedSize.With(c.metricsHandler).Record(int64(len(blob.Data)), tags...)
+	metrics.PersistenceBlobCompressedSize.With(c.metricsHandler).Record(int64(len(data)), tags...)
+	metrics.PersistenceBlobCompressedPercent.With(c.metricsHandler).Record(int64(100*len(data)/len(blob.Data)), tags...)
+
+	if len(data) >= len(blob.Data) {
+		return blob, nil
+	}
+	return &commonpb.DataBlob{
+		EncodingType: blob.EncodingType,
+		Data:         data,
+	}, nil
+}
+
+func (c *BlobCompressor) encoder() (*zstd.Encoder, error) {
+	level := zstd.EncoderLevelFromZstd(c.level())
+
+	c.encodersLock.Lock()
+	defer c.encodersLock.Unlock()
+
+	if encoder, ok := c.encoders[level]; ok {
+		return encoder, nil
+	}
+	encoder, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(level))
+	if err != nil {
+		return nil, err
+	}
+	c.encoders[level] = encoder
+	return encoder, nil
+}
+
+// IsCompressed returns true if the blob was compressed by a BlobCompressor.
+func IsCompressed(blob *commonpb.DataBlob) bool {
+	return blob.GetEncodingType() == enumspb.ENCODING_TYPE_PROTO3 && bytes.HasPrefix(blob.GetData(), compressedBlobHeader)
+}
+
+// Decompress returns the uncompressed blob of a blob which was compressed by a BlobCompressor, and returns other
+// blobs unchanged. It is used where blobs leave the persistence layer without being decoded.
+func Decompress(blob *commonpb.DataBlob) (*commonpb.DataBlob, error) {
+	if !IsCompressed(blob) {
+		return blob, nil
+	}
+	data, err := decompress(blob.Data)
+	if err != nil {
+		return nil, err
+	}
+	return &commonpb.DataBlob{
+		EncodingType: blob.EncodingType,
+		Data:         data,
+	}, nil
+}
+
+// decompress returns the uncompressed proto3 data of possibly compressed proto3 data.
+func decompress(data []byte) ([]byte, error) {
+	if bytes.HasPrefix(data, encryptedBlobHeader) {
+		return nil, NewDeserializationError(enumspb.ENCODING_TYPE_PROTO3, errors.New("blob is encrypted and must be decrypted before it is decoded"))
+	}
+	if !bytes.HasPrefix(data, compressedBlobHeader) {
+		return data, nil
+	}
+	uncompressed, err := zstdDecoder().DecodeAll(data[len(compressedBlobHeader):], nil)
+	if err != nil {
+		return nil, NewDeserializationError(enumspb.ENCODING_TYPE_PROTO3, fmt.Errorf("unable to decompress blob: %w", err))
+	}
+	return uncompressed, nil
+}
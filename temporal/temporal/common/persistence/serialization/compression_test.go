@@ -0,0 +1,177 @@
+package serialization
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	historypb "go.temporal.io/api/history/v1"
+	taskqueuepb "go.temporal.io/api/taskqueue/v1"
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/common/metrics/metricstest"
+	"go.temporal.io/server/common/testing/protorequire"
+)
+
+const (
+	compressedNamespaceID   = "compressed-namespace-id"
+	uncompressedNamespaceID = "uncompressed-namespace-id"
+)
+
+func newTestBlobCompressor(metricsHandler metrics.Handler) *BlobCompressor {
+	dcClient := dynamicconfig.NewMemoryClient()
+	dcClient.OverrideSetting(dynamicconfig.PersistenceBlobCompressionCategories, []dynamicconfig.ConstrainedValue{
+		{
+			Constraints: dynamicconfig.Constraints{NamespaceID: compressedNamespaceID},
+			Value:       []string{string(BlobCategoryHistory), string(BlobCategoryMutableState)},
+		},
+	})
+	dc := dynamicconfig.NewCollection(dcClient, log.NewNoopLogger())
+	return NewBlobCompressor(dc, metricsHandler)
+}
+
+func newCompressibleExecutionInfo() *persistencespb.WorkflowExecutionInfo {
+	return &persistencespb.WorkflowExecutionInfo{
+		NamespaceId: compressedNamespaceID,
+		WorkflowId:  strings.Repeat("workflow-id-", 100),
+		TaskQueue:   strings.Repeat("task-queue-", 100),
+	}
+}
+
+func TestBlobCompressor_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	metricsHandler := metricstest.NewCaptureHandler()
+	capture := metricsHandler.StartCapture()
+	defer metricsHandler.StopCapture(capture)
+	compressor := newTestBlobCompressor(metricsHandler)
+
+	info := newCompressibleExecutionInfo()
+	blob, err := ProtoEncode(info)
+	require.NoError(t, err)
+
+	compressed, err := compressor.Compress(compressedNamespaceID, BlobCategoryMutableState, blob)
+	require.NoError(t, err)
+	require.True(t, IsCompressed(compressed))
+	require.Equal(t, enumspb.ENCODING_TYPE_PROTO3, compressed.EncodingType)
+	require.Less(t, len(compressed.Data), len(blob.Data))
+
+	var decoded persistencespb.WorkflowExecutionInfo
+	require.NoError(t, Decode(compressed, &decoded))
+	protorequire.ProtoEqual(t, info, &decoded)
+
+	// compressing twice is a no-op
+	again, err := compressor.Compress(compressedNamespaceID, BlobCategoryMutableState, compressed)
+	require.NoError(t, err)
+	require.Same(t, compressed, again)
+
+	decompressed, err := Decompress(compressed)
+	require.NoError(t, err)
+	require.Equal(t, blob.Data, decompressed.Data)
+
+	snapshot := capture.Snapshot()
+	require.Len(t, snapshot[metrics.PersistenceBlobCompressedSize.Name()], 1)
+	recording := snapshot[metrics.PersistenceBlobCompressedPercent.Name()]
+	require.Len(t, recording, 1)
+	require.Equal(t, string(BlobCategoryMutableState), recording[0].Tags[blobCategoryTagName])
+	require.Less(t, recording[0].Value.(int64), int64(100))
+}
+
+func TestBlobCompressor_SideBySide(t *testing.T) {
+	t.Parallel()
+
+	compressor := newTestBlobCompressor(metrics.NoopMetricsHandler)
+	serializer := NewSerializer()
+
+	events := []*historypb.HistoryEvent{
+		{EventId: 1, EventType: enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED},
+		{
+			EventId:   2,
+			EventType: enumspb.EVENT_TYPE_WORKFLOW_TASK_SCHEDULED,
+			Attributes: &historypb.HistoryEvent_WorkflowTaskScheduledEventAttributes{
+				WorkflowTaskScheduledEventAttributes: &historypb.WorkflowTaskScheduledEventAttributes{
+					TaskQueue: &taskqueuepb.TaskQueue{Name: strings.Repeat("task-queue-", 100)},
+				},
+			},
+		},
+	}
+	uncompressed, err := serializer.SerializeEvents(events)
+	require.NoError(t, err)
+	compressed, err := compressor.Compress(compressedNamespaceID, BlobCategoryHistory, uncompressed)
+	require.NoError(t, err)
+	require.True(t, IsCompressed(compressed))
+
+	for _, blob := range []*commonpb.DataBlob{uncompressed, compressed} {
+		deserialized, err := serializer.DeserializeEvents(blob)
+		require.NoError(t, err)
+		protorequire.ProtoSliceEqual(t, events, deserialized)
+
+		stripped, err := serializer.DeserializeStrippedEvents(blob)
+		require.NoError(t, err)
+		require.Len(t, stripped, len(events))
+		require.Equal(t, int64(2), stripped[1].EventId)
+	}
+}
+
+func TestBlobCompressor_NotCompressed(t *testing.T) {
+	t.Parallel()
+
+	compressor := newTestBlobCompressor(metrics.NoopMetricsHandler)
+	blob, err := ProtoEncode(newCompressibleExecutionInfo())
+	require.NoError(t, err)
+	jsonBlob := &commonpb.DataBlob{EncodingType: enumspb.ENCODING_TYPE_JSON, Data: blob.Data}
+	smallBlob, err := ProtoEncode(&persistencespb.WorkflowExecutionInfo{WorkflowId: "workflow-id"})
+	require.NoError(t, err)
+
+	for name, tc := range map[string]struct {
+		compressor  *BlobCompressor
+		namespaceID string
+		blob        *commonpb.DataBlob
+	}{
+		"nil compressor":         {compressor: nil, namespaceID: compressedNamespaceID, blob: blob},
+		"disabled namespace":     {compressor: compressor, namespaceID: uncompressedNamespaceID, blob: blob},
+		"not proto3 encoded":     {compressor: compressor, namespaceID: compressedNamespaceID, blob: jsonBlob},
+		"small blob":             {compressor: compressor, namespaceID: compressedNamespaceID, blob: smallBlob},
+		"nil blob":               {compressor: compressor, namespaceID: compressedNamespaceID, blob: nil},
+		"incompressible content": {compressor: compressor, namespaceID: compressedNamespaceID, blob: &commonpb.DataBlob{EncodingType: enumspb.ENCODING_TYPE_PROTO3, Data: incompressibleData(1024)}},
+	} {
+		result, err := tc.compressor.Compress(tc.namespaceID, BlobCategoryHistory, tc.blob)
+		require.NoError(t, err, name)
+		require.Same(t, tc.blob, result, name)
+	}
+
+	require.False(t, compressor.Enabled(uncompressedNamespaceID, BlobCategoryMutableState))
+	require.True(t, compressor.Enabled(compressedNamespaceID, BlobCategoryMutableState))
+	require.False(t, compressor.Enabled(compressedNamespaceID, "unknown"))
+}
+
+func TestDecompress_Corrupted(t *testing.T) {
+	t.Parallel()
+
+	blob := &commonpb.DataBlob{
+		EncodingType: enumspb.ENCODING_TYPE_PROTO3,
+		Data:         append(append([]byte{}, compressedBlobHeader...), 1, 2, 3),
+	}
+	_, err := Decompress(blob)
+	require.ErrorAs(t, err, new(*DeserializationError))
+
+	var result persistencespb.WorkflowExecutionInfo
+	require.ErrorAs(t, Decode(blob, &result), new(*DeserializationError))
+}
+
+// incompressibleData returns pseudo-random bytes which don't start with the compressed blob header.
+func incompressibleData(n int) []byte {
+	data := make([]byte, n)
+	x := uint32(2463534242)
+	for i := range data {
+		x ^= x << 13
+		x ^= x >> 17
+		x ^= x << 5
+		data[i] = byte(x) | 1
+	}
+	return data
+}
@@ -0,0 +1,315 @@
+package serialization
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/server/common/cache"
+	"go.temporal.io/server/common/clock"
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/namespace"
+)
+
+const (
+	// dataKeySize is the size of the AES-256 data keys.
+	dataKeySize = 32
+	// maxUnwrappedDataKeys is the number of unwrapped data keys which are cached for decryption.
+	maxUnwrappedDataKeys = 1024
+
+	// Fields of the blob contexts of mutable state blobs. The fields of the blobs of the pending info maps are
+	// followed by a slash and the key of the blob.
+	ExecutionInfoBlobField      = "execution_info"
+	ActivityInfoBlobField       = "activity_info"
+	TimerInfoBlobField          = "timer_info"
+	ChildExecutionInfoBlobField = "child_execution_info"
+	RequestCancelInfoBlobField  = "request_cancel_info"
+	SignalInfoBlobField         = "signal_info"
+	BufferedEventsBlobField     = "buffered_events"
+)
+
+// encryptedBlobHeader prefixes the data of encrypted proto3 blobs, like compressedBlobHeader. It is followed by the ID
+// of the key-encryption key, the wrapped data key, the nonce and the ciphertext of the proto3 data, which may itself
+// be compressed.
+var encryptedBlobHeader = []byte{0x00, 0x02}
+
+var errNoKeyProvider = errors.New("blob is encrypted, but no persistence key provider is configured")
+
+type (
+	// KeyProvider provides the key-encryption keys which wrap the data keys of a BlobEncryptor. Implementations must
+	// keep the keys they used to wrap data keys for as long as blobs encrypted with those data keys exist, since
+	// encrypted blobs are only re-encrypted with the current key when they are written again.
+	KeyProvider interface {
+		// CurrentKeyID returns the ID of the key-encryption key which wraps new data keys.
+		CurrentKeyID() (string, error)
+		// WrapKey encrypts a data key with the key-encryption key of the ID.
+		WrapKey(keyID string, dataKey []byte) ([]byte, error)
+		// UnwrapKey decrypts a data key which was wrapped with the key-encryption key of the ID.
+		UnwrapKey(keyID string, wrappedKey []byte) ([]byte, error)
+	}
+
+	// BlobEncryptor encrypts proto3 blobs with AES-GCM for the namespaces enabled by the
+	// PersistenceBlobEncryptionEnabled dynamic config. Each namespace has its own data key, which is wrapped by the
+	// current key-encryption key of the KeyProvider and stored in the blob next to the ciphertext. Data keys are
+	// rotated when the current key-encryption key changes and after PersistenceBlobEncryptionDataKeyRotationInterval,
+	// so blobs are re-encrypted with the new keys the next time they are written. Encrypted blobs keep the proto3
+	// encoding type and must be decrypted with Decrypt before they are decoded. A nil BlobEncryptor doesn't encrypt
+	// anything and fails to decrypt encrypted blobs.
+	BlobEncryptor struct {
+		keyProvider             KeyProvider
+		enabled                 dynamicconfig.BoolPropertyFnWithNamespaceIDFilter
+		dataKeyRotationInterval dynamicconfig.DurationPropertyFn
+		timeSource              clock.TimeSource
+
+		dataKeysLock sync.Mutex
+		dataKeys     map[string]*dataKey
+		// unwrappedKeys caches the data key ciphers by encrypted blob header.
+		unwrappedKeys cache.Cache
+	}
+
+	// BlobContext identifies where an encrypted blob is persisted. It is authenticated together with the ciphertext,
+	// so that a blob which is copied to another row, execution or namespace fails to decrypt. The context isn't
+	// stored in the blob: the same context must be provided to decrypt it.
+	BlobContext struct {
+		NamespaceID string
+		// Row identifies the row of the blob in the namespace, see MutableStateBlobContext and
+		// HistoryNodeBlobContext.
+		Row string
+	}
+
+	dataKey struct {
+		keyID      string
+		header     []byte
+		aead       cipher.AEAD
+		createTime time.Time
+	}
+)
+
+// NewBlobEncryptor returns a new BlobEncryptor which wraps its data keys with the keys of keyProvider.
+func NewBlobEncryptor(
+	keyProvider KeyProvider,
+	dc *dynamicconfig.Collection,
+	timeSource clock.TimeSource,
+) *BlobEncryptor {
+	return &BlobEncryptor{
+		keyProvider:             keyProvider,
+		enabled:                 dynamicconfig.PersistenceBlobEncryptionEnabled.Get(dc),
+		dataKeyRotationInterval: dynamicconfig.PersistenceBlobEncryptionDataKeyRotationInterval.Get(dc),
+		timeSource:              timeSource,
+		dataKeys:                make(map[string]*dataKey),
+		unwrappedKeys:           cache.New(maxUnwrappedDataKeys, nil),
+	}
+}
+
+// Enabled returns true if blobs are encrypted for the namespace.
+func (e *BlobEncryptor) Enabled(namespaceID string) bool {
+	return e != nil && e.enabled(namespace.ID(namespaceID))
+}
+
+// MutableStateBlobContext returns the context of a mutable state blob of an execution. The field names the blob, and
+// is followed by the key of the blob for the pending info maps, e.g. "execution_info" or "activity_info/5".
+func MutableStateBlobContext(namespaceID string, workflowID string, runID string, field string) BlobContext {
+	return BlobContext{
+		NamespaceID: namespaceID,
+		Row:         "execution " + strconv.Quote(workflowID) + " " + strconv.Quote(runID) + " " + strconv.Quote(field),
+	}
+}
+
+// HistoryNodeBlobContext returns the context of the events of a history node. The branch is the branch the node was
+// appended to, which is an ancestor of the branches forked after the node.
+func HistoryNodeBlobContext(
+	namespaceID string,
+	treeID string,
+	branchID string,
+	nodeID int64,
+	transactionID int64,
+) BlobContext {
+	return BlobContext{
+		NamespaceID: namespaceID,
+		Row:         fmt.Sprintf("history_node %q %q %d %d", treeID, branchID, nodeID, transactionID),
+	}
+}
+
+// additionalData returns the additional authenticated data of a blob encrypted with the header: the header, followed
+// by the length prefixed namespace ID and the row.
+func (c BlobContext) additionalData(header []byte) []byte {
+	data := make([]byte, 0, len(header)+binary.MaxVarintLen64+len(c.NamespaceID)+len(c.Row))
+	data = append(data, header...)
+	data = binary.AppendUvarint(data, uint64(len(c.NamespaceID)))
+	data = append(data, c.NamespaceID...)
+	return append(data, c.Row...)
+}
+
+// Encrypt returns the encrypted blob if encryption is enabled for the namespace of the blob context. Blobs which are
+// not proto3 encoded or already encrypted are returned unchanged.
+func (e *BlobEncryptor) Encrypt(
+	blobContext BlobContext,
+	blob *commonpb.DataBlob,
+) (*commonpb.DataBlob, error) {
+	if blob == nil ||
+		blob.EncodingType != enumspb.ENCODING_TYPE_PROTO3 ||
+		IsEncrypted(blob) ||
+		!e.Enabled(blobContext.NamespaceID) {
+		return blob, nil
+	}
+
+	key, err := e.currentDataKey(blobContext.NamespaceID)
+	if err != nil {
+		return nil, NewSerializationError(blob.EncodingType, err)
+	}
+	nonceSize := key.aead.NonceSize()
+	data := make([]byte, len(key.header)+nonceSize, len(key.header)+nonceSize+len(blob.Data)+key.aead.Overhead())
+	copy(data, key.header)
+	nonce := data[len(key.header):]
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, NewSerializationError(blob.EncodingType, err)
+	}
+	return &commonpb.DataBlob{
+		EncodingType: blob.EncodingType,
+		Data:         key.aead.Seal(data, nonce, blob.Data, blobContext.additionalData(key.header)),
+	}, nil
+}
+
+// Decrypt returns the plaintext blob of a blob which was encrypted by a BlobEncryptor with the same blob context, and
+// returns other blobs unchanged.
+func (e *BlobEncryptor) Decrypt(blobContext BlobContext, blob *commonpb.DataBlob) (*commonpb.DataBlob, error) {
+	if !IsEncrypted(blob) {
+		return blob, nil
+	}
+	if e == nil {
+		return nil, NewDeserializationError(blob.EncodingType, errNoKeyProvider)
+	}
+
+	header, keyID, wrappedKey, err := parseEncryptedBlobHeader(blob.Data)
+	if err != nil {
+		return nil, NewDeserializationError(blob.EncodingType, err)
+	}
+	aead, err := e.dataKeyCipher(header, keyID, wrappedKey)
+	if err != nil {
+		return nil, NewDeserializationError(blob.EncodingType, err)
+	}
+	if len(blob.Data) < len(header)+aead.NonceSize() {
+		return nil, NewDeserializationError(blob.EncodingType, errors.New("encrypted blob is truncated"))
+	}
+	nonce := blob.Data[len(header) : len(header)+aead.NonceSize()]
+	data, err := aead.Open(nil, nonce, blob.Data[len(header)+aead.NonceSize():], blobContext.additionalData(header))
+	if err != nil {
+		return nil, NewDeserializationError(blob.EncodingType, fmt.Errorf("unable to decrypt blob: %w", err))
+	}
+	return &commonpb.DataBlob{
+		EncodingType: blob.EncodingType,
+		Data:         data,
+	}, nil
+}
+
+func (e *BlobEncryptor) currentDataKey(namespaceID string) (*dataKey, error) {
+	keyID, err := e.keyProvider.CurrentKeyID()
+	if err != nil {
+		return nil, err
+	}
+	now := e.timeSource.Now()
+
+	e.dataKeysLock.Lock()
+	defer e.dataKeysLock.Unlock()
+
+	if key, ok := e.dataKeys[namespaceID]; ok && key.keyID == keyID && now.Sub(key.createTime) < e.dataKeyRotationInterval() {
+		return key, nil
+	}
+
+	plaintextKey := make([]byte, dataKeySize)
+	if _, err := rand.Read(plaintextKey); err != nil {
+		return nil, err
+	}
+	wrappedKey, err := e.keyProvider.WrapKey(keyID, plaintextKey)
+	if err != nil {
+		return nil, fmt.Errorf("unable to wrap data key with key %q: %w", keyID, err)
+	}
+	if len(keyID) > math.MaxUint8 || len(wrappedKey) > math.MaxUint16 {
+		return nil, fmt.Errorf("key ID %q or wrapped data key is too long", keyID)
+	}
+	aead, err := newAEAD(plaintextKey)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, 0, len(encryptedBlobHeader)+1+len(keyID)+2+len(wrappedKey))
+	header = append(header, encryptedBlobHeader...)
+	header = append(header, byte(len(keyID)))
+	header = append(header, keyID...)
+	header = binary.BigEndian.AppendUint16(header, uint16(len(wrappedKey)))
+	header = append(header, wrappedKey...)
+
+	key := &dataKey{
+		keyID:      keyID,
+		header:     header,
+		aead:       aead,
+		createTime: now,
+	}
+	e.dataKeys[namespaceID] = key
+	e.unwrappedKeys.Put(string(header), aead)
+	return key, nil
+}
+
+func (e *BlobEncryptor) dataKeyCipher(header []byte, keyID string, wrappedKey []byte) (cipher.AEAD, error) {
+	if aead, ok := e.unwrappedKeys.Get(string(header)).(cipher.AEAD); ok {
+		return aead, nil
+	}
+	plaintextKey, err := e.keyProvider.UnwrapKey(keyID, wrappedKey)
+	if err != nil {
+		return nil, fmt.Errorf("unable to unwrap data key with key %q: %w", keyID, err)
+	}
+	aead, err := newAEAD(plaintextKey)
+	if err != nil {
+		return nil, err
+	}
+	e.unwrappedKeys.Put(string(header), aead)
+	return aead, nil
+}
+
+// IsEncrypted returns true if the blob was encrypted by a BlobEncryptor.
+func IsEncrypted(blob *commonpb.DataBlob) bool {
+	return blob.GetEncodingType() == enumspb.ENCODING_TYPE_PROTO3 && bytes.HasPrefix(blob.GetData(), encryptedBlobHeader)
+}
+
+// parseEncryptedBlobHeader returns the header of encrypted blob data, with the key-encryption key ID and the wrapped
+// data key it contains.
+func parseEncryptedBlobHeader(data []byte) (header []byte, keyID string, wrappedKey []byte, err error) {
+	errTruncated := errors.New("encrypted blob header is truncated")
+	offset := len(encryptedBlobHeader)
+	if len(data) < offset+1 {
+		return nil, "", nil, errTruncated
+	}
+	keyIDLen := int(data[offset])
+	offset++
+	if len(data) < offset+keyIDLen+2 {
+		return nil, "", nil, errTruncated
+	}
+	keyID = string(data[offset : offset+keyIDLen])
+	offset += keyIDLen
+	wrappedKeyLen := int(binary.BigEndian.Uint16(data[offset:]))
+	offset += 2
+	if len(data) < offset+wrappedKeyLen {
+		return nil, "", nil, errTruncated
+	}
+	wrappedKey = data[offset : offset+wrappedKeyLen]
+	offset += wrappedKeyLen
+	return data[:offset], keyID, wrappedKey, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
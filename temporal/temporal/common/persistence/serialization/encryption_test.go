@@ -0,0 +1,282 @@
+package serialization
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common/clock"
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/common/testing/protorequire"
+)
+
+const (
+	encryptedNamespaceID = "encrypted-namespace-id"
+	plaintextNamespaceID = "plaintext-namespace-id"
+)
+
+var (
+	encryptedBlobContext = MutableStateBlobContext(encryptedNamespaceID, "workflow-id", "run-id", ExecutionInfoBlobField)
+	plaintextBlobContext = MutableStateBlobContext(plaintextNamespaceID, "workflow-id", "run-id", ExecutionInfoBlobField)
+)
+
+func newTestKey(t *testing.T) []byte {
+	key := make([]byte, dataKeySize)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	return key
+}
+
+func writeTestKeyFile(t *testing.T, path string, currentKeyID string, keys map[string][]byte) {
+	var content strings.Builder
+	content.WriteString("currentKeyId: " + currentKeyID + "\nkeys:\n")
+	for keyID, key := range keys {
+		content.WriteString("  " + keyID + ": " + base64.StdEncoding.EncodeToString(key) + "\n")
+	}
+	require.NoError(t, os.WriteFile(path, []byte(content.String()), 0o600))
+}
+
+func newTestBlobEncryptor(keyProvider KeyProvider, timeSource clock.TimeSource) *BlobEncryptor {
+	dcClient := dynamicconfig.NewMemoryClient()
+	dcClient.OverrideSetting(dynamicconfig.PersistenceBlobEncryptionEnabled, []dynamicconfig.ConstrainedValue{
+		{Constraints: dynamicconfig.Constraints{NamespaceID: encryptedNamespaceID}, Value: true},
+	})
+	dcClient.OverrideSetting(dynamicconfig.PersistenceBlobEncryptionDataKeyRotationInterval, time.Hour)
+	return NewBlobEncryptor(keyProvider, dynamicconfig.NewCollection(dcClient, log.NewNoopLogger()), timeSource)
+}
+
+func newTestFileKeyProvider(t *testing.T, timeSource clock.TimeSource) *FileKeyProvider {
+	path := filepath.Join(t.TempDir(), "keys.yaml")
+	writeTestKeyFile(t, path, "key-1", map[string][]byte{"key-1": newTestKey(t)})
+	keyProvider, err := NewFileKeyProvider(path, log.NewNoopLogger(), timeSource)
+	require.NoError(t, err)
+	return keyProvider
+}
+
+func TestBlobEncryptor_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	timeSource := clock.NewRealTimeSource()
+	encryptor := newTestBlobEncryptor(newTestFileKeyProvider(t, timeSource), timeSource)
+
+	info := newCompressibleExecutionInfo()
+	blob, err := ProtoEncode(info)
+	require.NoError(t, err)
+
+	encrypted, err := encryptor.Encrypt(encryptedBlobContext, blob)
+	require.NoError(t, err)
+	require.True(t, IsEncrypted(encrypted))
+	require.Equal(t, enumspb.ENCODING_TYPE_PROTO3, encrypted.EncodingType)
+	require.NotContains(t, string(encrypted.Data), info.WorkflowId)
+
+	// encrypted blobs must be decrypted before they are decoded
+	var decoded persistencespb.WorkflowExecutionInfo
+	require.ErrorAs(t, Decode(encrypted, &decoded), new(*DeserializationError))
+
+	decrypted, err := encryptor.Decrypt(encryptedBlobContext, encrypted)
+	require.NoError(t, err)
+	require.NoError(t, Decode(decrypted, &decoded))
+	protorequire.ProtoEqual(t, info, &decoded)
+
+	// encrypting twice is a no-op
+	again, err := encryptor.Encrypt(encryptedBlobContext, encrypted)
+	require.NoError(t, err)
+	require.Same(t, encrypted, again)
+}
+
+func TestBlobEncryptor_CompressedAndEncrypted(t *testing.T) {
+	t.Parallel()
+
+	timeSource := clock.NewRealTimeSource()
+	encryptor := newTestBlobEncryptor(newTestFileKeyProvider(t, timeSource), timeSource)
+	compressor := newTestBlobCompressor(metrics.NoopMetricsHandler)
+
+	info := newCompressibleExecutionInfo()
+	blob, err := ProtoEncode(info)
+	require.NoError(t, err)
+	compressed, err := compressor.Compress(compressedNamespaceID, BlobCategoryMutableState, blob)
+	require.NoError(t, err)
+	encrypted, err := encryptor.Encrypt(encryptedBlobContext, compressed)
+	require.NoError(t, err)
+	require.True(t, IsEncrypted(encrypted))
+
+	// encrypted blobs are not compressed again
+	notCompressed, err := compressor.Compress(compressedNamespaceID, BlobCategoryMutableState, encrypted)
+	require.NoError(t, err)
+	require.Same(t, encrypted, notCompressed)
+
+	decrypted, err := encryptor.Decrypt(encryptedBlobContext, encrypted)
+	require.NoError(t, err)
+	require.True(t, IsCompressed(decrypted))
+	var decoded persistencespb.WorkflowExecutionInfo
+	require.NoError(t, Decode(decrypted, &decoded))
+	protorequire.ProtoEqual(t, info, &decoded)
+}
+
+func TestBlobEncryptor_NotEncrypted(t *testing.T) {
+	t.Parallel()
+
+	timeSource := clock.NewRealTimeSource()
+	encryptor := newTestBlobEncryptor(newTestFileKeyProvider(t, timeSource), timeSource)
+	blob, err := ProtoEncode(newCompressibleExecutionInfo())
+	require.NoError(t, err)
+	jsonBlob := &commonpb.DataBlob{EncodingType: enumspb.ENCODING_TYPE_JSON, Data: []byte("{}")}
+
+	for name, tc := range map[string]struct {
+		encryptor   *BlobEncryptor
+		blobContext BlobContext
+		blob        *commonpb.DataBlob
+	}{
+		"nil encryptor":      {encryptor: nil, blobContext: encryptedBlobContext, blob: blob},
+		"disabled namespace": {encryptor: encryptor, blobContext: plaintextBlobContext, blob: blob},
+		"not proto3 encoded": {encryptor: encryptor, blobContext: encryptedBlobContext, blob: jsonBlob},
+		"nil blob":           {encryptor: encryptor, blobContext: encryptedBlobContext, blob: nil},
+	} {
+		result, err := tc.encryptor.Encrypt(tc.blobContext, tc.blob)
+		require.NoError(t, err, name)
+		require.Same(t, tc.blob, result, name)
+
+		// plaintext blobs are returned unchanged
+		result, err = tc.encryptor.Decrypt(tc.blobContext, tc.blob)
+		require.NoError(t, err, name)
+		require.Same(t, tc.blob, result, name)
+	}
+}
+
+func TestBlobEncryptor_DecryptErrors(t *testing.T) {
+	t.Parallel()
+
+	timeSource := clock.NewRealTimeSource()
+	encryptor := newTestBlobEncryptor(newTestFileKeyProvider(t, timeSource), timeSource)
+	blob, err := ProtoEncode(newCompressibleExecutionInfo())
+	require.NoError(t, err)
+	encrypted, err := encryptor.Encrypt(encryptedBlobContext, blob)
+	require.NoError(t, err)
+
+	var nilEncryptor *BlobEncryptor
+	_, err = nilEncryptor.Decrypt(encryptedBlobContext, encrypted)
+	require.ErrorIs(t, err, errNoKeyProvider)
+
+	otherEncryptor := newTestBlobEncryptor(newTestFileKeyProvider(t, timeSource), timeSource)
+	_, err = otherEncryptor.Decrypt(encryptedBlobContext, encrypted)
+	require.ErrorAs(t, err, new(*DeserializationError), "data key can't be unwrapped with another key with the same ID")
+
+	tampered := &commonpb.DataBlob{EncodingType: encrypted.EncodingType, Data: append([]byte{}, encrypted.Data...)}
+	tampered.Data[len(tampered.Data)-1] ^= 1
+	_, err = encryptor.Decrypt(encryptedBlobContext, tampered)
+	require.ErrorAs(t, err, new(*DeserializationError))
+
+	truncated := &commonpb.DataBlob{EncodingType: encrypted.EncodingType, Data: encrypted.Data[:5]}
+	_, err = encryptor.Decrypt(encryptedBlobContext, truncated)
+	require.ErrorAs(t, err, new(*DeserializationError))
+}
+
+func TestBlobEncryptor_BlobContext(t *testing.T) {
+	t.Parallel()
+
+	timeSource := clock.NewRealTimeSource()
+	encryptor := newTestBlobEncryptor(newTestFileKeyProvider(t, timeSource), timeSource)
+	blob, err := ProtoEncode(newCompressibleExecutionInfo())
+	require.NoError(t, err)
+	encrypted, err := encryptor.Encrypt(encryptedBlobContext, blob)
+	require.NoError(t, err)
+
+	// an encrypted blob can't be moved to another row, execution or namespace
+	for name, blobContext := range map[string]BlobContext{
+		"other field":     MutableStateBlobContext(encryptedNamespaceID, "workflow-id", "run-id", ActivityInfoBlobField+"/5"),
+		"other run":       MutableStateBlobContext(encryptedNamespaceID, "workflow-id", "other-run-id", ExecutionInfoBlobField),
+		"other workflow":  MutableStateBlobContext(encryptedNamespaceID, "other-workflow-id", "run-id", ExecutionInfoBlobField),
+		"other namespace": MutableStateBlobContext(plaintextNamespaceID, "workflow-id", "run-id", ExecutionInfoBlobField),
+		"history node":    HistoryNodeBlobContext(encryptedNamespaceID, "workflow-id", "run-id", 1, 1),
+		"ambiguous split": MutableStateBlobContext(encryptedNamespaceID, "workflow-id\" \"run-id", "", ExecutionInfoBlobField),
+	} {
+		_, err := encryptor.Decrypt(blobContext, encrypted)
+		require.ErrorAs(t, err, new(*DeserializationError), name)
+	}
+
+	decrypted, err := encryptor.Decrypt(encryptedBlobContext, encrypted)
+	require.NoError(t, err)
+	require.Equal(t, blob.Data, decrypted.Data)
+}
+
+func TestBlobEncryptor_KeyRotation(t *testing.T) {
+	t.Parallel()
+
+	timeSource := clock.NewEventTimeSource().Update(time.Now())
+	keyFile := filepath.Join(t.TempDir(), "keys.yaml")
+	keys := map[string][]byte{"key-1": newTestKey(t)}
+	writeTestKeyFile(t, keyFile, "key-1", keys)
+	keyProvider, err := NewFileKeyProvider(keyFile, log.NewNoopLogger(), timeSource)
+	require.NoError(t, err)
+	encryptor := newTestBlobEncryptor(keyProvider, timeSource)
+
+	blob, err := ProtoEncode(newCompressibleExecutionInfo())
+	require.NoError(t, err)
+	encrypt := func() *commonpb.DataBlob {
+		encrypted, err := encryptor.Encrypt(encryptedBlobContext, blob)
+		require.NoError(t, err)
+		return encrypted
+	}
+	header := func(encrypted *commonpb.DataBlob) string {
+		header, _, _, err := parseEncryptedBlobHeader(encrypted.Data)
+		require.NoError(t, err)
+		return string(header)
+	}
+
+	first := encrypt()
+	require.Equal(t, header(first), header(encrypt()), "data key is reused")
+
+	timeSource.Update(timeSource.Now().Add(2 * time.Hour))
+	second := encrypt()
+	require.NotEqual(t, header(first), header(second), "data key is rotated after the rotation interval")
+
+	// Rotate the key-encryption key, keeping the previous one. The key file is only reloaded after the refresh
+	// interval, and when its modification time changed.
+	keys["key-2"] = newTestKey(t)
+	writeTestKeyFile(t, keyFile, "key-2", keys)
+	require.NoError(t, os.Chtimes(keyFile, time.Now(), time.Now().Add(time.Minute)))
+	timeSource.Update(timeSource.Now().Add(keyFileRefreshInterval))
+
+	third := encrypt()
+	_, keyID, _, err := parseEncryptedBlobHeader(third.Data)
+	require.NoError(t, err)
+	require.Equal(t, "key-2", keyID)
+
+	// blobs encrypted with all the keys can still be read, also without the cached data keys
+	reader := newTestBlobEncryptor(keyProvider, timeSource)
+	for _, encrypted := range []*commonpb.DataBlob{first, second, third} {
+		decrypted, err := reader.Decrypt(encryptedBlobContext, encrypted)
+		require.NoError(t, err)
+		require.Equal(t, blob.Data, decrypted.Data)
+	}
+}
+
+func TestFileKeyProvider_Invalid(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"invalid yaml":        "currentKeyId: [",
+		"unknown current key": "currentKeyId: key-2\nkeys:\n  key-1: " + base64.StdEncoding.EncodeToString(make([]byte, dataKeySize)),
+		"invalid base64":      "currentKeyId: key-1\nkeys:\n  key-1: '%%%'",
+		"invalid key size":    "currentKeyId: key-1\nkeys:\n  key-1: " + base64.StdEncoding.EncodeToString(make([]byte, 16)),
+	} {
+		path := filepath.Join(dir, strings.ReplaceAll(name, " ", "-")+".yaml")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		_, err := NewFileKeyProvider(path, log.NewNoopLogger(), clock.NewRealTimeSource())
+		require.Error(t, err, name)
+	}
+
+	_, err := NewFileKeyProvider(filepath.Join(dir, "missing.yaml"), log.NewNoopLogger(), clock.NewRealTimeSource())
+	require.Error(t, err)
+}
@@ -0,0 +1,201 @@
+package serialization
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.temporal.io/server/common/clock"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/log/tag"
+	"gopkg.in/yaml.v3"
+)
+
+// keyFileRefreshInterval is how often the key file is checked for changes.
+const keyFileRefreshInterval = 10 * time.Second
+
+type (
+	// FileKeyProvider is a KeyProvider which reads its key-encryption keys from a local YAML file, and wraps data
+	// keys with AES-256-GCM. The file contains base64 encoded 32 byte keys by ID, and the ID of the current key:
+	//
+	//	currentKeyId: key-2
+	//	keys:
+	//	  key-1: <base64 encoded key>
+	//	  key-2: <base64 encoded key>
+	//
+	// The file is reloaded when it changes, so keys are rotated by adding a new key and making it the current key.
+	// Previous keys must be kept as long as there are blobs encrypted with data keys they wrapped.
+	FileKeyProvider struct {
+		path       string
+		logger     log.Logger
+		timeSource clock.TimeSource
+
+		sync.RWMutex
+		keys         *fileKeys
+		modTime      time.Time
+		lastRefresh  time.Time
+		refreshMutex sync.Mutex
+	}
+
+	fileKeys struct {
+		currentKeyID string
+		keys         map[string]cipher.AEAD
+	}
+
+	keyFile struct {
+		CurrentKeyID string            `yaml:"currentKeyId"`
+		Keys         map[string]string `yaml:"keys"`
+	}
+)
+
+var _ KeyProvider = (*FileKeyProvider)(nil)
+
+// NewFileKeyProvider returns a FileKeyProvider for the key file at path. It returns an error if the file can't be
+// read or is invalid.
+func NewFileKeyProvider(
+	path string,
+	logger log.Logger,
+	timeSource clock.TimeSource,
+) (*FileKeyProvider, error) {
+	p := &FileKeyProvider{
+		path:       path,
+		logger:     logger,
+		timeSource: timeSource,
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := loadKeyFile(path)
+	if err != nil {
+		return nil, err
+	}
+	p.keys = keys
+	p.modTime = info.ModTime()
+	p.lastRefresh = timeSource.Now()
+	return p, nil
+}
+
+// CurrentKeyID implements KeyProvider.
+func (p *FileKeyProvider) CurrentKeyID() (string, error) {
+	return p.currentKeys().currentKeyID, nil
+}
+
+// WrapKey implements KeyProvider.
+func (p *FileKeyProvider) WrapKey(keyID string, dataKey []byte) ([]byte, error) {
+	aead, err := p.key(keyID)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(dataKey)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, dataKey, []byte(keyID)), nil
+}
+
+// UnwrapKey implements KeyProvider.
+func (p *FileKeyProvider) UnwrapKey(keyID string, wrappedKey []byte) ([]byte, error) {
+	aead, err := p.key(keyID)
+	if err != nil {
+		return nil, err
+	}
+	if len(wrappedKey) < aead.NonceSize() {
+		return nil, errors.New("wrapped key is truncated")
+	}
+	return aead.Open(nil, wrappedKey[:aead.NonceSize()], wrappedKey[aead.NonceSize():], []byte(keyID))
+}
+
+func (p *FileKeyProvider) key(keyID string) (cipher.AEAD, error) {
+	aead, ok := p.currentKeys().keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("unknown key %q in key file %s", keyID, p.path)
+	}
+	return aead, nil
+}
+
+func (p *FileKeyProvider) currentKeys() *fileKeys {
+	p.refresh()
+
+	p.RLock()
+	defer p.RUnlock()
+	return p.keys
+}
+
+// refresh reloads the key file if it changed since it was last loaded. If the new file is invalid, then the previous
+// keys are kept.
+func (p *FileKeyProvider) refresh() {
+	if !p.refreshMutex.TryLock() {
+		// another goroutine is already refreshing
+		return
+	}
+	defer p.refreshMutex.Unlock()
+
+	now := p.timeSource.Now()
+	p.RLock()
+	refreshDue := now.Sub(p.lastRefresh) >= keyFileRefreshInterval
+	modTime := p.modTime
+	p.RUnlock()
+	if !refreshDue {
+		return
+	}
+
+	info, err := os.Stat(p.path)
+	if err == nil && info.ModTime().Equal(modTime) {
+		p.Lock()
+		p.lastRefresh = now
+		p.Unlock()
+		return
+	}
+	var keys *fileKeys
+	if err == nil {
+		keys, err = loadKeyFile(p.path)
+	}
+
+	p.Lock()
+	defer p.Unlock()
+	p.lastRefresh = now
+	if err != nil {
+		p.logger.Error("Unable to reload persistence key file, keeping the previous keys.", tag.Error(err))
+		return
+	}
+	p.keys = keys
+	p.modTime = info.ModTime()
+}
+
+func loadKeyFile(path string) (*fileKeys, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var file keyFile
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("unable to parse key file %s: %w", path, err)
+	}
+	if _, ok := file.Keys[file.CurrentKeyID]; !ok {
+		return nil, fmt.Errorf("current key %q is not in key file %s", file.CurrentKeyID, path)
+	}
+
+	keys := &fileKeys{
+		currentKeyID: file.CurrentKeyID,
+		keys:         make(map[string]cipher.AEAD, len(file.Keys)),
+	}
+	for keyID, encodedKey := range file.Keys {
+		key, err := base64.StdEncoding.DecodeString(encodedKey)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode key %q in key file %s: %w", keyID, path, err)
+		}
+		if len(key) != dataKeySize {
+			return nil, fmt.Errorf("key %q in key file %s must be %d bytes, but is %d bytes", keyID, path, dataKeySize, len(key))
+		}
+		if keys.keys[keyID], err = newAEAD(key); err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
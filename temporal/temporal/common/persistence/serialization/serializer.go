@@ -222,11 +222,15 @@ func (t *serializerImpl) DeserializeStrippedEvents(data *commonpb.DataBlob) ([]*
 	var err error
 	switch data.EncodingType {
 	case enumspb.ENCODING_TYPE_PROTO3:
+		var payload []byte
+		if payload, err = decompress(data.Data); err != nil {
+			return nil, err
+		}
 		// Discard unknown fields to improve performance. StrippedHistoryEvents is usually deserialized from HistoryEvent
 		// which has extra fields that are not needed for this message.
 		err = proto.UnmarshalOptions{
 			DiscardUnknown: true,
-		}.Unmarshal(data.Data, events)
+		}.Unmarshal(payload, events)
 	case enumspb.ENCODING_TYPE_JSON:
 		err = temporalproto.CustomJSONUnmarshalOptions{
 			DiscardUnknown: true,
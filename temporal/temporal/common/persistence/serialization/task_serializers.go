@@ -1246,6 +1246,11 @@ func archiveExecutionTaskToProto(
 		TaskId:         archiveExecutionTask.TaskID,
 		Version:        archiveExecutionTask.Version,
 		VisibilityTime: timestamppb.New(archiveExecutionTask.VisibilityTimestamp),
+		TaskDetails: &persistencespb.ArchivalTaskInfo_ChasmTaskInfo{
+			ChasmTaskInfo: &persistencespb.ChasmTaskInfo{
+				ArchetypeId: archiveExecutionTask.ArchetypeID,
+			},
+		},
 	}
 }
 
@@ -1265,6 +1270,7 @@ func archiveExecutionTaskFromProto(
 		VisibilityTimestamp: visibilityTimestamp,
 		TaskID:              archivalTaskInfo.TaskId,
 		Version:             archivalTaskInfo.Version,
+		ArchetypeID:         archivalTaskInfo.GetChasmTaskInfo().GetArchetypeId(),
 	}
 }
 
@@ -428,6 +428,7 @@ func (s *taskSerializerSuite) TestArchiveExecutionTask() {
 		VisibilityTimestamp: time.Unix(0, 0).UTC(), // go == compare for location as well which is striped during marshaling/unmarshaling
 		TaskID:              rand.Int63(),
 		Version:             rand.Int63(),
+		ArchetypeID:         rand.Uint32(),
 	}
 	s.Assert().Equal(tasks.CategoryArchival, task.GetCategory())
 	s.Assert().Equal(enumsspb.TASK_TYPE_ARCHIVAL_ARCHIVE_EXECUTION, task.GetType())
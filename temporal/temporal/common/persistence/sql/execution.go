@@ -733,11 +733,61 @@ func (m *sqlExecutionStore) setWorkflowExecutionTx(
 	)
 }
 
+// executionPageToken is the primary key of the last execution of a page of ListConcreteExecutions.
+type executionPageToken struct {
+	NamespaceID primitives.UUID
+	WorkflowID  string
+	RunID       primitives.UUID
+}
+
 func (m *sqlExecutionStore) ListConcreteExecutions(
-	_ context.Context,
-	_ *p.ListConcreteExecutionsRequest,
+	ctx context.Context,
+	request *p.ListConcreteExecutionsRequest,
 ) (*p.InternalListConcreteExecutionsResponse, error) {
-	return nil, serviceerror.NewUnimplemented("ListConcreteExecutions is not implemented")
+	filter := sqlplugin.ExecutionsRangeFilter{
+		ShardID:                 request.ShardID,
+		ExclusiveMinNamespaceID: primitives.UUID{},
+		ExclusiveMinRunID:       primitives.UUID{},
+		PageSize:                request.PageSize,
+	}
+	if len(request.PageToken) > 0 {
+		token, err := deserializePageTokenJson[executionPageToken](request.PageToken)
+		if err != nil {
+			return nil, serviceerror.NewInternalf("ListConcreteExecutions: failed to deserialize page token. Error: %v", err)
+		}
+		filter.ExclusiveMinNamespaceID = token.NamespaceID
+		filter.ExclusiveMinWorkflowID = token.WorkflowID
+		filter.ExclusiveMinRunID = token.RunID
+	}
+
+	rows, err := m.DB.RangeSelectFromExecutions(ctx, filter)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, serviceerror.NewUnavailablef("ListConcreteExecutions: failed. Error: %v", err)
+	}
+
+	resp := &p.InternalListConcreteExecutionsResponse{}
+	for _, row := range rows {
+		resp.States = append(resp.States, &p.InternalWorkflowMutableState{
+			ExecutionInfo:  p.NewDataBlob(row.Data, row.DataEncoding),
+			ExecutionState: p.NewDataBlob(row.State, row.StateEncoding),
+			NextEventID:    row.NextEventID,
+			NamespaceID:    row.NamespaceID.String(),
+			WorkflowID:     row.WorkflowID,
+			RunID:          row.RunID.String(),
+		})
+	}
+	if len(rows) == request.PageSize {
+		lastRow := rows[len(rows)-1]
+		resp.NextPageToken, err = serializePageTokenJson(&executionPageToken{
+			NamespaceID: lastRow.NamespaceID,
+			WorkflowID:  lastRow.WorkflowID,
+			RunID:       lastRow.RunID,
+		})
+		if err != nil {
+			return nil, serviceerror.NewInternalf("ListConcreteExecutions: failed to serialize page token. Error: %v", err)
+		}
+	}
+	return resp, nil
 }
 
 func (m *sqlExecutionStore) GetHistoryBranchUtil() p.HistoryBranchUtil {
@@ -194,7 +194,7 @@ func (m *sqlExecutionStore) ReadHistoryBranch(
 		minTxnId = token.LastTxnID
 	}
 
-	rows, err := m.DB.RangeSelectFromHistoryNode(ctx, sqlplugin.HistoryNodeSelectFilter{
+	filter := sqlplugin.HistoryNodeSelectFilter{
 		ShardID:      request.ShardID,
 		TreeID:       treeIDBytes,
 		BranchID:     branchIDBytes,
@@ -205,7 +205,18 @@ func (m *sqlExecutionStore) ReadHistoryBranch(
 		PageSize:     request.PageSize,
 		MetadataOnly: request.MetadataOnly,
 		ReverseOrder: request.ReverseOrder,
-	})
+	}
+	// History nodes are appended in order, so a lagging read replica can only miss the nodes at the end of a branch.
+	// Pages read in order are served by the replica, and the last page is re-read from the primary database.
+	staleRead := !request.ReverseOrder && m.DB.HasReadReplica()
+	readCtx := ctx
+	if staleRead {
+		readCtx = p.WithStaleReadsAllowed(ctx)
+	}
+	rows, err := m.DB.RangeSelectFromHistoryNode(readCtx, filter)
+	if staleRead && len(rows) < request.PageSize && (err == nil || err == sql.ErrNoRows) {
+		rows, err = m.DB.RangeSelectFromHistoryNode(ctx, filter)
+	}
 	switch err {
 	case nil:
 		// noop
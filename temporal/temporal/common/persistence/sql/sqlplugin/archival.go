@@ -0,0 +1,134 @@
+package sqlplugin
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+type (
+	// HistoryArchiveRow represents a row in history_archive table
+	HistoryArchiveRow struct {
+		NamespaceID          string
+		WorkflowID           string
+		RunID                string
+		CloseFailoverVersion int64
+		BatchIdx             int64
+		Data                 []byte
+		DataEncoding         string
+	}
+
+	// HistoryArchiveFilter contains the column names within history_archive table that
+	// can be used to filter results through a WHERE clause
+	HistoryArchiveFilter struct {
+		NamespaceID          string
+		WorkflowID           string
+		RunID                string
+		CloseFailoverVersion int64
+		MinBatchIdx          int64
+		PageSize             int
+	}
+
+	// HistoryArchiveVersionsFilter contains the column names within history_archive table that
+	// can be used to filter results through a WHERE clause
+	HistoryArchiveVersionsFilter struct {
+		NamespaceID string
+		WorkflowID  string
+		RunID       string
+	}
+
+	// HistoryArchiveManifestsRow represents a row in history_archive_manifests table
+	HistoryArchiveManifestsRow struct {
+		NamespaceID          string
+		WorkflowID           string
+		RunID                string
+		CloseFailoverVersion int64
+		Data                 []byte
+		DataEncoding         string
+	}
+
+	// HistoryArchiveManifestsFilter contains the column names within history_archive_manifests table that
+	// can be used to filter results through a WHERE clause
+	HistoryArchiveManifestsFilter struct {
+		NamespaceID          string
+		WorkflowID           string
+		RunID                string
+		CloseFailoverVersion int64
+	}
+
+	// ChasmExecutionArchiveRow represents a row in chasm_execution_archive table
+	ChasmExecutionArchiveRow struct {
+		NamespaceID          string
+		BusinessID           string
+		RunID                string
+		CloseFailoverVersion int64
+		Data                 []byte
+		DataEncoding         string
+	}
+
+	// ChasmExecutionArchiveFilter contains the column names within chasm_execution_archive table that
+	// can be used to filter results through a WHERE clause. The row of the highest close failover version is returned
+	// if the close failover version is not set.
+	ChasmExecutionArchiveFilter struct {
+		NamespaceID          string
+		BusinessID           string
+		RunID                string
+		CloseFailoverVersion *int64
+	}
+
+	// VisibilityArchiveRow represents a row in visibility_archive table
+	VisibilityArchiveRow struct {
+		NamespaceID      string
+		RunID            string
+		WorkflowID       string
+		WorkflowTypeName string
+		CloseTime        time.Time
+		Data             []byte
+		DataEncoding     string
+	}
+
+	// VisibilityArchiveFilter contains the column names within visibility_archive table that
+	// can be used to filter results through a WHERE clause. The rows are returned by descending close time and run
+	// ID, starting after the last close time and run ID if they are set.
+	VisibilityArchiveFilter struct {
+		NamespaceID       string
+		WorkflowID        *string
+		WorkflowTypeName  *string
+		RunID             *string
+		EarliestCloseTime *time.Time
+		LatestCloseTime   *time.Time
+		LastCloseTime     *time.Time
+		LastRunID         *string
+		PageSize          int
+	}
+
+	// HistoryArchive is the SQL persistence interface for archived histories
+	HistoryArchive interface {
+		// ReplaceIntoHistoryArchive replaces the rows of the archived history batches, so that an archival which is
+		// retried overwrites the batches of the previous attempt.
+		ReplaceIntoHistoryArchive(ctx context.Context, rows []HistoryArchiveRow) (sql.Result, error)
+		// SelectFromHistoryArchive returns the batches of an archived history version by increasing batch index
+		SelectFromHistoryArchive(ctx context.Context, filter HistoryArchiveFilter) ([]HistoryArchiveRow, error)
+		// SelectHistoryArchiveVersions returns the close failover versions of an archived history by descending
+		// version
+		SelectHistoryArchiveVersions(ctx context.Context, filter HistoryArchiveVersionsFilter) ([]int64, error)
+		// DeleteFromHistoryArchive deletes the batches of an archived history version, starting from the min batch
+		// index of the filter
+		DeleteFromHistoryArchive(ctx context.Context, filter HistoryArchiveFilter) (sql.Result, error)
+
+		ReplaceIntoHistoryArchiveManifests(ctx context.Context, row *HistoryArchiveManifestsRow) (sql.Result, error)
+		SelectFromHistoryArchiveManifests(ctx context.Context, filter HistoryArchiveManifestsFilter) (*HistoryArchiveManifestsRow, error)
+	}
+
+	// ChasmExecutionArchive is the SQL persistence interface for archived CHASM executions
+	ChasmExecutionArchive interface {
+		ReplaceIntoChasmExecutionArchive(ctx context.Context, row *ChasmExecutionArchiveRow) (sql.Result, error)
+		SelectFromChasmExecutionArchive(ctx context.Context, filter ChasmExecutionArchiveFilter) (*ChasmExecutionArchiveRow, error)
+	}
+
+	// VisibilityArchive is the SQL persistence interface for archived visibility records
+	VisibilityArchive interface {
+		ReplaceIntoVisibilityArchive(ctx context.Context, row *VisibilityArchiveRow) (sql.Result, error)
+		SelectFromVisibilityArchive(ctx context.Context, filter VisibilityArchiveFilter) ([]VisibilityArchiveRow, error)
+	}
+)
@@ -17,6 +17,7 @@ import (
 	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/log/tag"
 	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/common/persistence"
 )
 
 const (
@@ -38,6 +39,7 @@ type DatabaseHandle struct {
 	timeSource  clock.TimeSource
 
 	reporter *DBMetricsReporter
+	replica  *ReadReplica
 
 	// Ensures only one refresh call happens at a time
 	sync.Mutex
@@ -53,6 +55,19 @@ func NewDatabaseHandle(
 	logger log.Logger,
 	metricsHandler metrics.Handler,
 	timeSource clock.TimeSource,
+) *DatabaseHandle {
+	return newDatabaseHandle(dbKind, DBPoolPrimary, connect, needsRefresh, nil, logger, metricsHandler, timeSource)
+}
+
+func newDatabaseHandle(
+	dbKind DbKind,
+	pool string,
+	connect func() (*sqlx.DB, error),
+	needsRefresh func(error) bool,
+	replicationLag func() (time.Duration, bool),
+	logger log.Logger,
+	metricsHandler metrics.Handler,
+	timeSource clock.TimeSource,
 ) *DatabaseHandle {
 	handle := &DatabaseHandle{
 		running:      true,
@@ -62,7 +77,7 @@ func NewDatabaseHandle(
 		logger:       logger,
 		timeSource:   timeSource,
 	}
-	handle.reporter = newDBMetricReporter(dbKind, handle)
+	handle.reporter = newDBMetricReporter(dbKind, pool, handle, replicationLag)
 	handle.reporter.Start()
 	handle.reconnect(true)
 	return handle
@@ -125,6 +140,9 @@ func (h *DatabaseHandle) Close() {
 		if h.reporter != nil {
 			h.reporter.Stop()
 		}
+		if h.replica != nil {
+			h.replica.Close()
+		}
 		db := h.db.Swap(nil)
 		if db != nil {
 			db.Close()
@@ -132,6 +150,31 @@ func (h *DatabaseHandle) Close() {
 	}
 }
 
+// SetReadReplica sets the read replica which serves the reads which tolerate stale data. It must be called before
+// the handle is used, and the replica is closed with the handle.
+func (h *DatabaseHandle) SetReadReplica(replica *ReadReplica) {
+	h.replica = replica
+}
+
+// HasReadReplica returns true if the handle has a read replica.
+func (h *DatabaseHandle) HasReadReplica() bool {
+	return h.replica != nil
+}
+
+// ReadHandle returns the handle which serves a read outside of a transaction. It is the handle of the read replica
+// if the read tolerates stale data and the replica is usable, and h otherwise.
+func (h *DatabaseHandle) ReadHandle(ctx context.Context) *DatabaseHandle {
+	if h.replica == nil || !persistence.StaleReadsAllowed(ctx) {
+		return h
+	}
+	if h.replica.usable() {
+		recordReplicaRead(h.metrics, DBPoolReplica)
+		return h.replica.handle
+	}
+	recordReplicaRead(h.metrics, DBPoolPrimary)
+	return h
+}
+
 func (h *DatabaseHandle) DB() (*sqlx.DB, error) {
 	if db := h.db.Load(); db != nil {
 		return db, nil
@@ -10,11 +10,12 @@ import (
 )
 
 type DBMetricsReporter struct {
-	interval time.Duration
-	handle   *DatabaseHandle
-	metrics  metrics.Handler
-	quit     chan struct{}
-	wg       sync.WaitGroup
+	interval       time.Duration
+	handle         *DatabaseHandle
+	replicationLag func() (time.Duration, bool)
+	metrics        metrics.Handler
+	quit           chan struct{}
+	wg             sync.WaitGroup
 
 	started atomic.Bool
 	stopped atomic.Bool
@@ -22,13 +23,24 @@ type DBMetricsReporter struct {
 	logger log.Logger
 }
 
-func newDBMetricReporter(dbKind DbKind, handle *DatabaseHandle) *DBMetricsReporter {
+// newDBMetricReporter returns a reporter of the connection pool metrics of the handle, tagged with the pool. The
+// replication lag is also reported for the pools of read replicas.
+func newDBMetricReporter(
+	dbKind DbKind,
+	pool string,
+	handle *DatabaseHandle,
+	replicationLag func() (time.Duration, bool),
+) *DBMetricsReporter {
 	reporter := &DBMetricsReporter{
-		interval: time.Minute,
-		handle:   handle,
-		metrics:  handle.metrics.WithTags(metrics.PersistenceDBKindTag(dbKind.String())),
-		quit:     make(chan struct{}),
-		logger:   handle.logger,
+		interval:       time.Minute,
+		handle:         handle,
+		replicationLag: replicationLag,
+		metrics: handle.metrics.WithTags(
+			metrics.PersistenceDBKindTag(dbKind.String()),
+			metrics.PersistenceDBPoolTag(pool),
+		),
+		quit:   make(chan struct{}),
+		logger: handle.logger,
 	}
 	reporter.started.Store(false)
 	reporter.stopped.Store(false)
@@ -74,6 +86,16 @@ func (r *DBMetricsReporter) report() {
 	metrics.PersistenceSQLOpenConn.With(r.metrics).Record(float64(s.OpenConnections))
 	metrics.PersistenceSQLIdleConn.With(r.metrics).Record(float64(s.Idle))
 	metrics.PersistenceSQLInUse.With(r.metrics).Record(float64(s.InUse))
+	if r.replicationLag != nil {
+		if lag, ok := r.replicationLag(); ok {
+			metrics.PersistenceSQLReplicationLag.With(r.metrics).Record(lag.Seconds())
+		}
+	}
+}
+
+// recordReplicaRead counts a read which tolerates stale data by the pool which served it.
+func recordReplicaRead(handler metrics.Handler, pool string) {
+	metrics.PersistenceSQLReplicaReads.With(handler).Record(1, metrics.PersistenceDBPoolTag(pool))
 }
 
 // Stop signal background reporter to stop
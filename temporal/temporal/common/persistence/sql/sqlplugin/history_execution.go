@@ -36,6 +36,16 @@ type (
 		RunID       primitives.UUID
 	}
 
+	// ExecutionsRangeFilter contains the column names within executions table that
+	// can be used to page through the executions of a shard, in the order of their primary key
+	ExecutionsRangeFilter struct {
+		ShardID                 int32
+		ExclusiveMinNamespaceID primitives.UUID
+		ExclusiveMinWorkflowID  string
+		ExclusiveMinRunID       primitives.UUID
+		PageSize                int
+	}
+
 	// CurrentExecutionsRow represents a row in current_executions table
 	CurrentExecutionsRow struct {
 		ShardID          int32
@@ -73,6 +83,7 @@ type (
 		InsertIntoExecutions(ctx context.Context, row *ExecutionsRow) (sql.Result, error)
 		UpdateExecutions(ctx context.Context, row *ExecutionsRow) (sql.Result, error)
 		SelectFromExecutions(ctx context.Context, filter ExecutionsFilter) (*ExecutionsRow, error)
+		RangeSelectFromExecutions(ctx context.Context, filter ExecutionsRangeFilter) ([]ExecutionsRow, error)
 		DeleteFromExecutions(ctx context.Context, filter ExecutionsFilter) (sql.Result, error)
 		ReadLockExecutions(ctx context.Context, filter ExecutionsFilter) (int64, int64, error)
 		WriteLockExecutions(ctx context.Context, filter ExecutionsFilter) (int64, int64, error)
@@ -19,6 +19,7 @@ const (
 	DbKindUnknown DbKind = iota
 	DbKindMain
 	DbKindVisibility
+	DbKindArchival
 )
 
 type VersionedBlob struct {
@@ -87,7 +88,18 @@ type (
 		DropAllTables(database string) error
 		CreateDatabase(database string) error
 		DropDatabase(database string) error
+		// DatabaseExists returns true if the database exists
+		DatabaseExists(database string) (bool, error)
 		Exec(stmt string, args ...any) error
+		// DescribeSchema returns the columns and the index columns of the tables in the database
+		DescribeSchema(database string) ([]SchemaColumn, []SchemaIndexColumn, error)
+	}
+
+	// ArchivalCRUD defines the API for interacting with the tables of the archival database
+	ArchivalCRUD interface {
+		HistoryArchive
+		ChasmExecutionArchive
+		VisibilityArchive
 	}
 
 	// Tx defines the API for a SQL transaction
@@ -103,6 +115,23 @@ type (
 		GenericDB
 		BeginTx(ctx context.Context) (Tx, error)
 		IsDupEntryError(err error) bool
+		// HasReadReplica returns true if reads which tolerate stale data may be served by a read replica.
+		HasReadReplica() bool
+	}
+
+	// ArchivalTx defines the API for a SQL transaction on the archival database
+	ArchivalTx interface {
+		ArchivalCRUD
+		Commit() error
+		Rollback() error
+	}
+
+	// ArchivalDB defines the API for the SQL operations of the archivers which store the archived histories and
+	// visibility records in a separate SQL database
+	ArchivalDB interface {
+		ArchivalCRUD
+		GenericDB
+		BeginArchivalTx(ctx context.Context) (ArchivalTx, error)
 	}
 
 	// AdminDB defines the API for admin SQL operations for CLI and testing suites
@@ -136,6 +165,8 @@ func (k DbKind) String() string {
 		return "main"
 	case DbKindVisibility:
 		return "visibility"
+	case DbKindArchival:
+		return "archival"
 	default:
 		return "unknown"
 	}
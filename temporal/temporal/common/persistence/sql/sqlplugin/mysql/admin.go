@@ -3,6 +3,8 @@ package mysql
 import (
 	"fmt"
 	"time"
+
+	"go.temporal.io/server/common/persistence/sql/sqlplugin"
 )
 
 const (
@@ -38,9 +40,19 @@ const (
 
 	dropDatabaseQuery = "DROP DATABASE IF EXISTS %v"
 
+	databaseExistsQuery = "SELECT COUNT(*) FROM information_schema.schemata WHERE schema_name = ?"
+
 	listTablesQuery = "SHOW TABLES FROM %v"
 
 	dropTableQuery = "DROP TABLE %v"
+
+	describeColumnsQuery = `SELECT table_name AS table_name, column_name AS column_name, column_type AS column_type, ` +
+		`is_nullable = 'YES' AS nullable FROM information_schema.columns WHERE table_schema = ? ` +
+		`ORDER BY table_name, ordinal_position`
+
+	describeIndexColumnsQuery = `SELECT table_name AS table_name, index_name AS index_name, non_unique = 0 AS is_unique, ` +
+		`COALESCE(column_name, expression) AS column_name FROM information_schema.statistics WHERE table_schema = ? ` +
+		`ORDER BY table_name, index_name, seq_in_index`
 )
 
 // CreateSchemaVersionTables sets up the schema version tables
@@ -122,3 +134,33 @@ func (mdb *db) CreateDatabase(name string) error {
 func (mdb *db) DropDatabase(name string) error {
 	return mdb.Exec(fmt.Sprintf(dropDatabaseQuery, name))
 }
+
+// DatabaseExists returns true if the database exists
+func (mdb *db) DatabaseExists(name string) (bool, error) {
+	db, err := mdb.handle.DB()
+	if err != nil {
+		return false, err
+	}
+	var count int
+	if err := db.Get(&count, databaseExistsQuery, name); err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// DescribeSchema returns the columns and the index columns of the tables in the database
+func (mdb *db) DescribeSchema(database string) ([]sqlplugin.SchemaColumn, []sqlplugin.SchemaIndexColumn, error) {
+	db, err := mdb.handle.DB()
+	if err != nil {
+		return nil, nil, err
+	}
+	var columns []sqlplugin.SchemaColumn
+	if err := db.Select(&columns, describeColumnsQuery, database); err != nil {
+		return nil, nil, mdb.handle.ConvertError(err)
+	}
+	var indexColumns []sqlplugin.SchemaIndexColumn
+	if err := db.Select(&indexColumns, describeIndexColumnsQuery, database); err != nil {
+		return nil, nil, mdb.handle.ConvertError(err)
+	}
+	return columns, indexColumns, nil
+}
@@ -0,0 +1,252 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"go.temporal.io/server/common/persistence/sql/sqlplugin"
+)
+
+const (
+	// below are templates for history_archive table
+	replaceHistoryArchiveQuery = `INSERT INTO history_archive (` +
+		`namespace_id, workflow_id, run_id, close_failover_version, batch_idx, data, data_encoding) ` +
+		`VALUES (:namespace_id, :workflow_id, :run_id, :close_failover_version, :batch_idx, :data, :data_encoding) ` +
+		`ON DUPLICATE KEY UPDATE data=VALUES(data), data_encoding=VALUES(data_encoding)`
+
+	getHistoryArchiveQuery = `SELECT namespace_id, workflow_id, run_id, close_failover_version, batch_idx, data, data_encoding ` +
+		`FROM history_archive ` +
+		`WHERE namespace_id = ? AND workflow_id = ? AND run_id = ? AND close_failover_version = ? AND batch_idx >= ? ` +
+		`ORDER BY batch_idx LIMIT ?`
+
+	getHistoryArchiveVersionsQuery = `SELECT DISTINCT close_failover_version FROM history_archive ` +
+		`WHERE namespace_id = ? AND workflow_id = ? AND run_id = ? ` +
+		`ORDER BY close_failover_version DESC`
+
+	deleteHistoryArchiveQuery = `DELETE FROM history_archive ` +
+		`WHERE namespace_id = ? AND workflow_id = ? AND run_id = ? AND close_failover_version = ? AND batch_idx >= ?`
+
+	// below are templates for history_archive_manifests table
+	replaceHistoryArchiveManifestsQuery = `INSERT INTO history_archive_manifests (` +
+		`namespace_id, workflow_id, run_id, close_failover_version, data, data_encoding) ` +
+		`VALUES (:namespace_id, :workflow_id, :run_id, :close_failover_version, :data, :data_encoding) ` +
+		`ON DUPLICATE KEY UPDATE data=VALUES(data), data_encoding=VALUES(data_encoding)`
+
+	getHistoryArchiveManifestsQuery = `SELECT namespace_id, workflow_id, run_id, close_failover_version, data, data_encoding ` +
+		`FROM history_archive_manifests ` +
+		`WHERE namespace_id = ? AND workflow_id = ? AND run_id = ? AND close_failover_version = ?`
+
+	// below are templates for chasm_execution_archive table
+	replaceChasmExecutionArchiveQuery = `INSERT INTO chasm_execution_archive (` +
+		`namespace_id, business_id, run_id, close_failover_version, data, data_encoding) ` +
+		`VALUES (:namespace_id, :business_id, :run_id, :close_failover_version, :data, :data_encoding) ` +
+		`ON DUPLICATE KEY UPDATE data=VALUES(data), data_encoding=VALUES(data_encoding)`
+
+	getChasmExecutionArchiveQuery = `SELECT namespace_id, business_id, run_id, close_failover_version, data, data_encoding ` +
+		`FROM chasm_execution_archive ` +
+		`WHERE namespace_id = ? AND business_id = ? AND run_id = ? AND close_failover_version = ?`
+
+	getLatestChasmExecutionArchiveQuery = `SELECT namespace_id, business_id, run_id, close_failover_version, data, data_encoding ` +
+		`FROM chasm_execution_archive ` +
+		`WHERE namespace_id = ? AND business_id = ? AND run_id = ? ` +
+		`ORDER BY close_failover_version DESC LIMIT 1`
+
+	// below are templates for visibility_archive table
+	replaceVisibilityArchiveQuery = `INSERT INTO visibility_archive (` +
+		`namespace_id, run_id, workflow_id, workflow_type_name, close_time, data, data_encoding) ` +
+		`VALUES (:namespace_id, :run_id, :workflow_id, :workflow_type_name, :close_time, :data, :data_encoding) ` +
+		`ON DUPLICATE KEY UPDATE workflow_id=VALUES(workflow_id), workflow_type_name=VALUES(workflow_type_name), ` +
+		`close_time=VALUES(close_time), data=VALUES(data), data_encoding=VALUES(data_encoding)`
+
+	getVisibilityArchiveQueryPrefix = `SELECT namespace_id, run_id, workflow_id, workflow_type_name, close_time, data, data_encoding ` +
+		`FROM visibility_archive WHERE namespace_id = ?`
+)
+
+// For history_archive table:
+
+// ReplaceIntoHistoryArchive replaces one or more rows in history_archive table
+func (mdb *db) ReplaceIntoHistoryArchive(
+	ctx context.Context,
+	rows []sqlplugin.HistoryArchiveRow,
+) (sql.Result, error) {
+	return mdb.NamedExecContext(ctx,
+		replaceHistoryArchiveQuery,
+		rows,
+	)
+}
+
+// SelectFromHistoryArchive reads one or more rows from history_archive table
+func (mdb *db) SelectFromHistoryArchive(
+	ctx context.Context,
+	filter sqlplugin.HistoryArchiveFilter,
+) ([]sqlplugin.HistoryArchiveRow, error) {
+	var rows []sqlplugin.HistoryArchiveRow
+	err := mdb.SelectContext(ctx,
+		&rows,
+		getHistoryArchiveQuery,
+		filter.NamespaceID,
+		filter.WorkflowID,
+		filter.RunID,
+		filter.CloseFailoverVersion,
+		filter.MinBatchIdx,
+		filter.PageSize,
+	)
+	return rows, err
+}
+
+// SelectHistoryArchiveVersions reads the close failover versions of an archived history from history_archive table
+func (mdb *db) SelectHistoryArchiveVersions(
+	ctx context.Context,
+	filter sqlplugin.HistoryArchiveVersionsFilter,
+) ([]int64, error) {
+	var versions []int64
+	err := mdb.SelectContext(ctx,
+		&versions,
+		getHistoryArchiveVersionsQuery,
+		filter.NamespaceID,
+		filter.WorkflowID,
+		filter.RunID,
+	)
+	return versions, err
+}
+
+// DeleteFromHistoryArchive deletes one or more rows from history_archive table
+func (mdb *db) DeleteFromHistoryArchive(
+	ctx context.Context,
+	filter sqlplugin.HistoryArchiveFilter,
+) (sql.Result, error) {
+	return mdb.ExecContext(ctx,
+		deleteHistoryArchiveQuery,
+		filter.NamespaceID,
+		filter.WorkflowID,
+		filter.RunID,
+		filter.CloseFailoverVersion,
+		filter.MinBatchIdx,
+	)
+}
+
+// For history_archive_manifests table:
+
+// ReplaceIntoHistoryArchiveManifests replaces a row in history_archive_manifests table
+func (mdb *db) ReplaceIntoHistoryArchiveManifests(
+	ctx context.Context,
+	row *sqlplugin.HistoryArchiveManifestsRow,
+) (sql.Result, error) {
+	return mdb.NamedExecContext(ctx,
+		replaceHistoryArchiveManifestsQuery,
+		row,
+	)
+}
+
+// SelectFromHistoryArchiveManifests reads a row from history_archive_manifests table
+func (mdb *db) SelectFromHistoryArchiveManifests(
+	ctx context.Context,
+	filter sqlplugin.HistoryArchiveManifestsFilter,
+) (*sqlplugin.HistoryArchiveManifestsRow, error) {
+	var row sqlplugin.HistoryArchiveManifestsRow
+	err := mdb.GetContext(ctx,
+		&row,
+		getHistoryArchiveManifestsQuery,
+		filter.NamespaceID,
+		filter.WorkflowID,
+		filter.RunID,
+		filter.CloseFailoverVersion,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &row, nil
+}
+
+// For chasm_execution_archive table:
+
+// ReplaceIntoChasmExecutionArchive replaces a row in chasm_execution_archive table
+func (mdb *db) ReplaceIntoChasmExecutionArchive(
+	ctx context.Context,
+	row *sqlplugin.ChasmExecutionArchiveRow,
+) (sql.Result, error) {
+	return mdb.NamedExecContext(ctx,
+		replaceChasmExecutionArchiveQuery,
+		row,
+	)
+}
+
+// SelectFromChasmExecutionArchive reads a row from chasm_execution_archive table
+func (mdb *db) SelectFromChasmExecutionArchive(
+	ctx context.Context,
+	filter sqlplugin.ChasmExecutionArchiveFilter,
+) (*sqlplugin.ChasmExecutionArchiveRow, error) {
+	query := getLatestChasmExecutionArchiveQuery
+	args := []any{filter.NamespaceID, filter.BusinessID, filter.RunID}
+	if filter.CloseFailoverVersion != nil {
+		query = getChasmExecutionArchiveQuery
+		args = append(args, *filter.CloseFailoverVersion)
+	}
+	var row sqlplugin.ChasmExecutionArchiveRow
+	if err := mdb.GetContext(ctx, &row, query, args...); err != nil {
+		return nil, err
+	}
+	return &row, nil
+}
+
+// For visibility_archive table:
+
+// ReplaceIntoVisibilityArchive replaces a row in visibility_archive table
+func (mdb *db) ReplaceIntoVisibilityArchive(
+	ctx context.Context,
+	row *sqlplugin.VisibilityArchiveRow,
+) (sql.Result, error) {
+	finalRow := *row
+	finalRow.CloseTime = mdb.converter.ToMySQLDateTime(row.CloseTime)
+	return mdb.NamedExecContext(ctx,
+		replaceVisibilityArchiveQuery,
+		&finalRow,
+	)
+}
+
+// SelectFromVisibilityArchive reads one or more rows from visibility_archive table
+func (mdb *db) SelectFromVisibilityArchive(
+	ctx context.Context,
+	filter sqlplugin.VisibilityArchiveFilter,
+) ([]sqlplugin.VisibilityArchiveRow, error) {
+	var queryBuilder strings.Builder
+	queryBuilder.WriteString(getVisibilityArchiveQueryPrefix)
+	args := []any{filter.NamespaceID}
+	if filter.WorkflowID != nil {
+		queryBuilder.WriteString(" AND workflow_id = ?")
+		args = append(args, *filter.WorkflowID)
+	}
+	if filter.WorkflowTypeName != nil {
+		queryBuilder.WriteString(" AND workflow_type_name = ?")
+		args = append(args, *filter.WorkflowTypeName)
+	}
+	if filter.RunID != nil {
+		queryBuilder.WriteString(" AND run_id = ?")
+		args = append(args, *filter.RunID)
+	}
+	if filter.EarliestCloseTime != nil {
+		queryBuilder.WriteString(" AND close_time >= ?")
+		args = append(args, mdb.converter.ToMySQLDateTime(*filter.EarliestCloseTime))
+	}
+	if filter.LatestCloseTime != nil {
+		queryBuilder.WriteString(" AND close_time <= ?")
+		args = append(args, mdb.converter.ToMySQLDateTime(*filter.LatestCloseTime))
+	}
+	if filter.LastCloseTime != nil && filter.LastRunID != nil {
+		lastCloseTime := mdb.converter.ToMySQLDateTime(*filter.LastCloseTime)
+		queryBuilder.WriteString(" AND (close_time < ? OR (close_time = ? AND run_id < ?))")
+		args = append(args, lastCloseTime, lastCloseTime, *filter.LastRunID)
+	}
+	queryBuilder.WriteString(" ORDER BY close_time DESC, run_id DESC LIMIT ?")
+	args = append(args, filter.PageSize)
+
+	var rows []sqlplugin.VisibilityArchiveRow
+	if err := mdb.SelectContext(ctx, &rows, queryBuilder.String(), args...); err != nil {
+		return nil, err
+	}
+	for i := range rows {
+		rows[i].CloseTime = mdb.converter.FromMySQLDateTime(rows[i].CloseTime)
+	}
+	return rows, nil
+}
@@ -41,6 +41,8 @@ type db struct {
 var _ sqlplugin.AdminDB = (*db)(nil)
 var _ sqlplugin.DB = (*db)(nil)
 var _ sqlplugin.Tx = (*db)(nil)
+var _ sqlplugin.ArchivalDB = (*db)(nil)
+var _ sqlplugin.ArchivalTx = (*db)(nil)
 
 func isConnNeedsRefreshError(err error) bool {
 	myErr, ok := err.(*mysql.MySQLError)
@@ -82,6 +84,21 @@ func (mdb *db) conn() sqlplugin.Conn {
 	return mdb.handle.Conn()
 }
 
+// readConn returns the connection which serves a read, and its handle. Reads outside of a transaction are served by
+// the read replica if the context allows stale reads.
+func (mdb *db) readConn(ctx context.Context) (sqlplugin.Conn, *sqlplugin.DatabaseHandle) {
+	if mdb.tx != nil {
+		return mdb.tx, mdb.handle
+	}
+	handle := mdb.handle.ReadHandle(ctx)
+	return handle.Conn(), handle
+}
+
+// HasReadReplica returns true if reads which tolerate stale data may be served by a read replica
+func (mdb *db) HasReadReplica() bool {
+	return mdb.handle.HasReadReplica()
+}
+
 // BeginTx starts a new transaction and returns a reference to the Tx object
 func (mdb *db) BeginTx(ctx context.Context) (sqlplugin.Tx, error) {
 	db, err := mdb.handle.DB()
@@ -95,6 +112,19 @@ func (mdb *db) BeginTx(ctx context.Context) (sqlplugin.Tx, error) {
 	return newDB(mdb.dbKind, mdb.dbName, mdb.handle, xtx, mdb.logger), nil
 }
 
+// BeginArchivalTx starts a new transaction on the archival database and returns a reference to the Tx object
+func (mdb *db) BeginArchivalTx(ctx context.Context) (sqlplugin.ArchivalTx, error) {
+	db, err := mdb.handle.DB()
+	if err != nil {
+		return nil, err
+	}
+	xtx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, mdb.handle.ConvertError(err)
+	}
+	return newDB(mdb.dbKind, mdb.dbName, mdb.handle, xtx, mdb.logger), nil
+}
+
 // Commit commits a previously started transaction
 func (mdb *db) Commit() error {
 	return mdb.tx.Commit()
@@ -128,6 +158,8 @@ func (mdb *db) ExpectedVersion() string {
 		return mysqlschemaV8.Version
 	case sqlplugin.DbKindVisibility:
 		return mysqlschemaV8.VisibilityVersion
+	case sqlplugin.DbKindArchival:
+		return mysqlschemaV8.ArchivalVersion
 	default:
 		panic(fmt.Sprintf("unknown db kind %v", mdb.dbKind))
 	}
@@ -146,13 +178,15 @@ func (mdb *db) ExecContext(ctx context.Context, stmt string, args ...any) (sql.R
 }
 
 func (mdb *db) GetContext(ctx context.Context, dest any, query string, args ...any) error {
-	err := mdb.conn().GetContext(ctx, dest, query, args...)
-	return mdb.handle.ConvertError(err)
+	conn, handle := mdb.readConn(ctx)
+	err := conn.GetContext(ctx, dest, query, args...)
+	return handle.ConvertError(err)
 }
 
 func (mdb *db) SelectContext(ctx context.Context, dest any, query string, args ...any) error {
-	err := mdb.conn().SelectContext(ctx, dest, query, args...)
-	return mdb.handle.ConvertError(err)
+	conn, handle := mdb.readConn(ctx)
+	err := conn.SelectContext(ctx, dest, query, args...)
+	return handle.ConvertError(err)
 }
 
 func (mdb *db) NamedExecContext(ctx context.Context, query string, arg any) (sql.Result, error) {
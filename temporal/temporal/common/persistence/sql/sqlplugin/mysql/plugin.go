@@ -48,6 +48,24 @@ func (p *plugin) CreateDB(
 		return p.createDBConnection(dbKind, cfg, r)
 	}
 	handle := sqlplugin.NewDatabaseHandle(dbKind, connect, isConnNeedsRefreshError, logger, metricsHandler, clock.NewRealTimeSource())
+	if replicaCfg := cfg.ReadReplicaConfig(); replicaCfg != nil {
+		connectReplica := func() (*sqlx.DB, error) {
+			if cfg.Connect != nil {
+				return cfg.Connect(replicaCfg)
+			}
+			return p.createDBConnection(dbKind, replicaCfg, r)
+		}
+		handle.SetReadReplica(sqlplugin.NewReadReplica(
+			dbKind,
+			cfg.ReadReplica,
+			connectReplica,
+			isConnNeedsRefreshError,
+			replicationLag,
+			logger,
+			metricsHandler,
+			clock.NewRealTimeSource(),
+		))
+	}
 	db := newDB(dbKind, cfg.DatabaseName, handle, nil, logger)
 	return db, nil
 }
@@ -113,14 +113,20 @@ func (c *queryConverter) ConvertKeywordListComparisonExpr(
 func (c *queryConverter) ConvertTextComparisonExpr(
 	operator string,
 	col *query.SAColumn,
-	value sqlparser.Expr,
+	textQuery *query.TextQuery,
 ) (sqlparser.Expr, error) {
 	// build the following expression:
 	// `match ({col}) against ({value} in natural language mode)`
+	// or, if the text query has phrases, prefixes, required or excluded terms:
+	// `match ({col}) against ({value} in boolean mode)`
+	value, option, err := buildMatchAgainstValue(textQuery)
+	if err != nil {
+		return nil, err
+	}
 	var newExpr sqlparser.Expr = &sqlparser.MatchExpr{
 		Columns: []sqlparser.SelectExpr{&sqlparser.AliasedExpr{Expr: col}},
-		Expr:    value,
-		Option:  sqlparser.NaturalLanguageModeStr,
+		Expr:    query.NewUnsafeSQLString(value),
+		Option:  option,
 	}
 	if operator == sqlparser.NotEqualStr {
 		newExpr = &sqlparser.NotExpr{Expr: newExpr}
@@ -234,6 +240,71 @@ func (c *queryConverter) BuildCountStmt(
 	), nil
 }
 
+func (c *queryConverter) BuildValuesStmt(
+	queryParams *query.QueryParams[sqlparser.Expr],
+	fields []string,
+) (string, []any) {
+	whereString := ""
+	if queryParams.QueryExpr != nil {
+		whereString = sqlparser.String(queryParams.QueryExpr)
+		if whereString != "" {
+			whereString = " WHERE " + whereString
+		}
+	}
+
+	columns := make([]string, 0, len(queryParams.GroupBy)+len(fields))
+	for _, field := range queryParams.GroupBy {
+		columns = append(columns, sadefs.GetSqlDbColName(field.FieldName))
+	}
+	for _, field := range fields {
+		columns = append(columns, sadefs.GetSqlDbColName(field))
+	}
+
+	return fmt.Sprintf(
+		"SELECT %s FROM executions_visibility ev "+
+			"LEFT JOIN custom_search_attributes USING (%s, %s) "+
+			"LEFT JOIN chasm_search_attributes USING (%s, %s)"+
+			"%s",
+		strings.Join(columns, ", "),
+		sadefs.GetSqlDbColName(sadefs.NamespaceID),
+		sadefs.GetSqlDbColName(sadefs.RunID),
+		sadefs.GetSqlDbColName(sadefs.NamespaceID),
+		sadefs.GetSqlDbColName(sadefs.RunID),
+		whereString,
+	), nil
+}
+
+func (c *queryConverter) BuildAggregateStmt(
+	queryParams *query.QueryParams[sqlparser.Expr],
+	aggregations []*query.Aggregation,
+) (string, []any) {
+	whereString := ""
+	if queryParams.QueryExpr != nil {
+		whereString = sqlparser.String(queryParams.QueryExpr)
+		if whereString != "" {
+			whereString = " WHERE " + whereString
+		}
+	}
+
+	columns := sqlplugin.BuildAggregateColumns(queryParams, aggregations, func(col string) string {
+		// DATETIME columns have no time zone, so the difference is independent of the session time zone.
+		return fmt.Sprintf("TIMESTAMPDIFF(MICROSECOND, '1970-01-01 00:00:00', %s)", col)
+	})
+	return fmt.Sprintf(
+		"SELECT %s FROM executions_visibility ev "+
+			"LEFT JOIN custom_search_attributes USING (%s, %s) "+
+			"LEFT JOIN chasm_search_attributes USING (%s, %s)"+
+			"%s%s",
+		strings.Join(columns, ", "),
+		sadefs.GetSqlDbColName(sadefs.NamespaceID),
+		sadefs.GetSqlDbColName(sadefs.RunID),
+		sadefs.GetSqlDbColName(sadefs.NamespaceID),
+		sadefs.GetSqlDbColName(sadefs.RunID),
+		whereString,
+		sqlplugin.BuildGroupByClause(queryParams),
+	), nil
+}
+
 func (c *queryConverter) buildJSONOverlapsExpr(
 	col *query.SAColumn,
 	value sqlparser.Expr,
@@ -262,3 +333,42 @@ func (c *queryConverter) buildJSONOverlapsExpr(
 		},
 	}, nil
 }
+
+// buildMatchAgainstValue builds the full-text search of a text query and its search modifier. The tokens only have
+// letters and digits, so they don't need to be escaped.
+func buildMatchAgainstValue(textQuery *query.TextQuery) (string, string, error) {
+	if textQuery.IsPlain() {
+		words := make([]string, len(textQuery.Optional))
+		for i, term := range textQuery.Optional {
+			words[i] = term.Tokens[0]
+		}
+		return strings.Join(words, " "), sqlparser.NaturalLanguageModeStr, nil
+	}
+
+	var words []string
+	for _, terms := range []struct {
+		operator string
+		terms    []query.TextTerm
+	}{
+		{operator: "+", terms: textQuery.Required},
+		{operator: "", terms: textQuery.Optional},
+		{operator: "-", terms: textQuery.Excluded},
+	} {
+		for _, term := range terms.terms {
+			word := term.Tokens[0]
+			if len(term.Tokens) > 1 {
+				if term.Prefix {
+					return "", "", query.NewConverterError(
+						"%s: prefix phrases are not supported for Text type search attributes by MySQL",
+						query.NotSupportedErrMessage,
+					)
+				}
+				word = fmt.Sprintf(`"%s"`, strings.Join(term.Tokens, " "))
+			} else if term.Prefix {
+				word += "*"
+			}
+			words = append(words, terms.operator+word)
+		}
+	}
+	return strings.Join(words, " "), sqlparser.BooleanModeStr, nil
+}
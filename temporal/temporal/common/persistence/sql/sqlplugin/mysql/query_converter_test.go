@@ -129,30 +129,53 @@ func TestQueryConverter_ConvertTextComparisonExpr(t *testing.T) {
 		name     string
 		operator string
 		col      *query.SAColumn
-		value    sqlparser.Expr
+		value    string
 		out      string
+		err      string
 	}{
 		{
 			name:     "valid equal expression",
 			operator: sqlparser.EqualStr,
 			col:      textCol,
-			value:    query.NewUnsafeSQLString("foo bar"),
+			value:    "foo bar",
 			out:      "match(Text01) against ('foo bar' in natural language mode)",
 		},
 		{
 			name:     "valid not equal expression",
 			operator: sqlparser.NotEqualStr,
 			col:      textCol,
-			value:    query.NewUnsafeSQLString("foo bar"),
+			value:    "foo bar",
 			out:      "not match(Text01) against ('foo bar' in natural language mode)",
 		},
+		{
+			name:     "boolean mode expression",
+			operator: sqlparser.EqualStr,
+			col:      textCol,
+			value:    `-qux "Quick brown" +foo fo*`,
+			out:      `match(Text01) against ('+foo "quick brown" fo* -qux' in boolean mode)`,
+		},
+		{
+			name:     "prefix phrase expression",
+			operator: sqlparser.EqualStr,
+			col:      textCol,
+			value:    `"quick bro"*`,
+			err:      "prefix phrases are not supported",
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			r := require.New(t)
 			qc := &queryConverter{}
-			out, err := qc.ConvertTextComparisonExpr(tc.operator, tc.col, tc.value)
+			textQuery, err := query.ParseTextQuery(tc.value)
+			r.NoError(err)
+			out, err := qc.ConvertTextComparisonExpr(tc.operator, tc.col, textQuery)
+			if tc.err != "" {
+				r.ErrorContains(err, tc.err)
+				var expectedErr *query.ConverterError
+				r.ErrorAs(err, &expectedErr)
+				return
+			}
 			r.NoError(err)
 			r.Equal(tc.out, sqlparser.String(out))
 		})
@@ -305,3 +328,64 @@ func TestQueryConverter_BuildCountStmt(t *testing.T) {
 		})
 	}
 }
+
+func TestQueryConverter_BuildAggregateStmt(t *testing.T) {
+	keywordCol := query.NewSAColumn(
+		"AliasForKeyword01",
+		"Keyword01",
+		enumspb.INDEXED_VALUE_TYPE_KEYWORD,
+	)
+	aggregations := []*query.Aggregation{
+		{
+			Function: query.AggregationAvg,
+			Column:   query.NewSAColumn(sadefs.ExecutionDuration, sadefs.ExecutionDuration, enumspb.INDEXED_VALUE_TYPE_INT),
+		},
+		{
+			Function: query.AggregationMax,
+			Column:   query.NewSAColumn(sadefs.CloseTime, sadefs.CloseTime, enumspb.INDEXED_VALUE_TYPE_DATETIME),
+		},
+	}
+
+	tests := []struct {
+		name      string
+		queryExpr sqlparser.Expr
+		groupBy   []*query.SAColumn
+		stmt      string
+	}{
+		{
+			name: "no group by",
+			queryExpr: &sqlparser.ComparisonExpr{
+				Operator: sqlparser.EqualStr,
+				Left:     keywordCol,
+				Right:    query.NewUnsafeSQLString("foo"),
+			},
+			stmt: "SELECT COUNT(*), AVG(execution_duration), MAX(TIMESTAMPDIFF(MICROSECOND, '1970-01-01 00:00:00', close_time)) FROM executions_visibility ev LEFT JOIN custom_search_attributes USING (namespace_id, run_id) LEFT JOIN chasm_search_attributes USING (namespace_id, run_id) WHERE Keyword01 = 'foo'",
+		},
+		{
+			name: "group by",
+			queryExpr: &sqlparser.ComparisonExpr{
+				Operator: sqlparser.EqualStr,
+				Left:     keywordCol,
+				Right:    query.NewUnsafeSQLString("foo"),
+			},
+			groupBy: []*query.SAColumn{
+				query.NewSAColumn(sadefs.ExecutionStatus, sadefs.ExecutionStatus, enumspb.INDEXED_VALUE_TYPE_KEYWORD),
+			},
+			stmt: "SELECT status, COUNT(*), AVG(execution_duration), MAX(TIMESTAMPDIFF(MICROSECOND, '1970-01-01 00:00:00', close_time)) FROM executions_visibility ev LEFT JOIN custom_search_attributes USING (namespace_id, run_id) LEFT JOIN chasm_search_attributes USING (namespace_id, run_id) WHERE Keyword01 = 'foo' GROUP BY status",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := require.New(t)
+			qc := &queryConverter{}
+			qp := &query.QueryParams[sqlparser.Expr]{
+				QueryExpr: tc.queryExpr,
+				GroupBy:   tc.groupBy,
+			}
+			stmt, queryArgs := qc.BuildAggregateStmt(qp, aggregations)
+			r.Equal(tc.stmt, stmt)
+			r.Nil(queryArgs)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const showReplicaStatusQuery = `SHOW REPLICA STATUS`
+
+// replicationLag returns the replication lag of a MySQL replica. A server which doesn't replicate has no lag.
+func replicationLag(ctx context.Context, db *sqlx.DB) (time.Duration, error) {
+	rows, err := db.QueryxContext(ctx, showReplicaStatusQuery)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	if !rows.Next() {
+		return 0, rows.Err()
+	}
+	status := make(map[string]any)
+	if err := rows.MapScan(status); err != nil {
+		return 0, err
+	}
+	for _, column := range []string{"Seconds_Behind_Source", "Seconds_Behind_Master"} {
+		value, ok := status[column]
+		if !ok {
+			continue
+		}
+		return parseSecondsBehindSource(value)
+	}
+	return 0, errors.New("replica status has no seconds behind source")
+}
+
+func parseSecondsBehindSource(value any) (time.Duration, error) {
+	var seconds string
+	switch v := value.(type) {
+	case nil:
+		// NULL while the replication threads are not running
+		return 0, errors.New("replication is not running")
+	case []byte:
+		seconds = string(v)
+	case string:
+		seconds = v
+	case int64:
+		return time.Duration(v) * time.Second, nil
+	default:
+		return 0, fmt.Errorf("unexpected seconds behind source type %T", value)
+	}
+	n, err := strconv.ParseInt(seconds, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid seconds behind source %q: %w", seconds, err)
+	}
+	return time.Duration(n) * time.Second, nil
+}
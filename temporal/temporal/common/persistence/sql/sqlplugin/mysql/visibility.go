@@ -280,10 +280,11 @@ func (mdb *db) CountGroupByFromVisibility(
 	ctx context.Context,
 	filter sqlplugin.VisibilitySelectFilter,
 ) (_ []sqlplugin.VisibilityCountRow, retError error) {
+	handle := mdb.handle.ReadHandle(ctx)
 	defer func() {
-		retError = mdb.handle.ConvertError(retError)
+		retError = handle.ConvertError(retError)
 	}()
-	db, err := mdb.handle.DB()
+	db, err := handle.DB()
 	if err != nil {
 		return nil, err
 	}
@@ -295,6 +296,59 @@ func (mdb *db) CountGroupByFromVisibility(
 	return sqlplugin.ParseCountGroupByRows(rows, filter.GroupBy)
 }
 
+func (mdb *db) SelectValuesFromVisibility(
+	ctx context.Context,
+	filter sqlplugin.VisibilitySelectFilter,
+) (_ []sqlplugin.VisibilityValuesRow, retError error) {
+	handle := mdb.handle.ReadHandle(ctx)
+	defer func() {
+		retError = handle.ConvertError(retError)
+	}()
+	db, err := handle.DB()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.QueryContext(ctx, filter.Query, filter.QueryArgs...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return sqlplugin.ParseValuesRows(rows, filter.GroupBy, filter.Fields)
+}
+
+func (mdb *db) AggregateFromVisibility(
+	ctx context.Context,
+	filter sqlplugin.VisibilitySelectFilter,
+) (_ []sqlplugin.VisibilityAggregateRow, retError error) {
+	handle := mdb.handle.ReadHandle(ctx)
+	defer func() {
+		retError = handle.ConvertError(retError)
+	}()
+	db, err := handle.DB()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.QueryContext(ctx, filter.Query, filter.QueryArgs...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return sqlplugin.ParseAggregateRows(rows, filter.GroupBy, filter.Fields)
+}
+
+// ExplainFromVisibility returns the plan of EXPLAIN in tree format
+func (mdb *db) ExplainFromVisibility(
+	ctx context.Context,
+	filter sqlplugin.VisibilitySelectFilter,
+) (string, error) {
+	var plan string
+	err := mdb.GetContext(ctx, &plan, "EXPLAIN FORMAT=TREE "+filter.Query, filter.QueryArgs...)
+	if err != nil {
+		return "", err
+	}
+	return plan, nil
+}
+
 func (mdb *db) prepareRowForDB(row *sqlplugin.VisibilityRow) *sqlplugin.VisibilityRow {
 	if row == nil {
 		return nil
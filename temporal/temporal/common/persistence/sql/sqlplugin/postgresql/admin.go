@@ -3,6 +3,8 @@ package postgresql
 import (
 	"fmt"
 	"time"
+
+	"go.temporal.io/server/common/persistence/sql/sqlplugin"
 )
 
 const (
@@ -42,9 +44,30 @@ const (
 
 	dropDatabaseQuery = "DROP DATABASE IF EXISTS %v"
 
-	listTablesQuery = "select table_name from information_schema.tables where table_schema='public'"
+	databaseExistsQuery = "SELECT COUNT(*) FROM pg_database WHERE datname = $1"
+
+	// partitions are not listed, as they are dropped with their partitioned tables
+	listTablesQuery = "select table_name from information_schema.tables t where table_schema='public' and not exists " +
+		"(select 1 from pg_class c join pg_namespace n on n.oid = c.relnamespace " +
+		"where n.nspname = t.table_schema and c.relname = t.table_name and c.relispartition)"
 
 	dropTableQuery = "DROP TABLE %v"
+
+	// partitions are described by their partitioned tables
+	describeColumnsQuery = `SELECT t.relname AS table_name, a.attname AS column_name, ` +
+		`format_type(a.atttypid, a.atttypmod) AS column_type, NOT a.attnotnull AS nullable ` +
+		`FROM pg_attribute a JOIN pg_class t ON t.oid = a.attrelid JOIN pg_namespace n ON n.oid = t.relnamespace ` +
+		`WHERE n.nspname = 'public' AND t.relkind IN ('r', 'p') AND NOT t.relispartition AND a.attnum > 0 AND NOT a.attisdropped ` +
+		`ORDER BY t.relname, a.attnum`
+
+	describeIndexColumnsQuery = `SELECT t.relname AS table_name, i.relname AS index_name, ix.indisunique AS is_unique, ` +
+		`COALESCE(a.attname, pg_get_indexdef(ix.indexrelid, k.ord::int, true)) AS column_name ` +
+		`FROM pg_index ix JOIN pg_class t ON t.oid = ix.indrelid JOIN pg_class i ON i.oid = ix.indexrelid ` +
+		`JOIN pg_namespace n ON n.oid = t.relnamespace ` +
+		`CROSS JOIN LATERAL unnest(ix.indkey) WITH ORDINALITY AS k(attnum, ord) ` +
+		`LEFT JOIN pg_attribute a ON a.attrelid = t.oid AND a.attnum = k.attnum AND k.attnum > 0 ` +
+		`WHERE n.nspname = 'public' AND NOT t.relispartition ` +
+		`ORDER BY t.relname, i.relname, k.ord`
 )
 
 // Exec executes a sql statement
@@ -130,3 +153,29 @@ func (pdb *db) CreateDatabase(name string) error {
 func (pdb *db) DropDatabase(name string) error {
 	return pdb.Exec(fmt.Sprintf(dropDatabaseQuery, name))
 }
+
+// DatabaseExists returns true if the database exists
+func (pdb *db) DatabaseExists(name string) (bool, error) {
+	db, err := pdb.handle.DB()
+	if err != nil {
+		return false, err
+	}
+	var count int
+	if err := db.Get(&count, databaseExistsQuery, name); err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// DescribeSchema returns the columns and the index columns of the tables in the database
+func (pdb *db) DescribeSchema(_ string) ([]sqlplugin.SchemaColumn, []sqlplugin.SchemaIndexColumn, error) {
+	var columns []sqlplugin.SchemaColumn
+	if err := pdb.Select(&columns, describeColumnsQuery); err != nil {
+		return nil, nil, err
+	}
+	var indexColumns []sqlplugin.SchemaIndexColumn
+	if err := pdb.Select(&indexColumns, describeIndexColumnsQuery); err != nil {
+		return nil, nil, err
+	}
+	return columns, indexColumns, nil
+}
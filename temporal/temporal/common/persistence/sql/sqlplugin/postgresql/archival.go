@@ -0,0 +1,255 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"go.temporal.io/server/common/persistence/sql/sqlplugin"
+)
+
+const (
+	// below are templates for history_archive table
+	replaceHistoryArchiveQuery = `INSERT INTO history_archive (` +
+		`namespace_id, workflow_id, run_id, close_failover_version, batch_idx, data, data_encoding) ` +
+		`VALUES (:namespace_id, :workflow_id, :run_id, :close_failover_version, :batch_idx, :data, :data_encoding) ` +
+		`ON CONFLICT (namespace_id, workflow_id, run_id, close_failover_version, batch_idx) DO UPDATE ` +
+		`SET data = excluded.data, data_encoding = excluded.data_encoding`
+
+	getHistoryArchiveQuery = `SELECT namespace_id, workflow_id, run_id, close_failover_version, batch_idx, data, data_encoding ` +
+		`FROM history_archive ` +
+		`WHERE namespace_id = $1 AND workflow_id = $2 AND run_id = $3 AND close_failover_version = $4 AND batch_idx >= $5 ` +
+		`ORDER BY batch_idx LIMIT $6`
+
+	getHistoryArchiveVersionsQuery = `SELECT DISTINCT close_failover_version FROM history_archive ` +
+		`WHERE namespace_id = $1 AND workflow_id = $2 AND run_id = $3 ` +
+		`ORDER BY close_failover_version DESC`
+
+	deleteHistoryArchiveQuery = `DELETE FROM history_archive ` +
+		`WHERE namespace_id = $1 AND workflow_id = $2 AND run_id = $3 AND close_failover_version = $4 AND batch_idx >= $5`
+
+	// below are templates for history_archive_manifests table
+	replaceHistoryArchiveManifestsQuery = `INSERT INTO history_archive_manifests (` +
+		`namespace_id, workflow_id, run_id, close_failover_version, data, data_encoding) ` +
+		`VALUES (:namespace_id, :workflow_id, :run_id, :close_failover_version, :data, :data_encoding) ` +
+		`ON CONFLICT (namespace_id, workflow_id, run_id, close_failover_version) DO UPDATE ` +
+		`SET data = excluded.data, data_encoding = excluded.data_encoding`
+
+	getHistoryArchiveManifestsQuery = `SELECT namespace_id, workflow_id, run_id, close_failover_version, data, data_encoding ` +
+		`FROM history_archive_manifests ` +
+		`WHERE namespace_id = $1 AND workflow_id = $2 AND run_id = $3 AND close_failover_version = $4`
+
+	// below are templates for chasm_execution_archive table
+	replaceChasmExecutionArchiveQuery = `INSERT INTO chasm_execution_archive (` +
+		`namespace_id, business_id, run_id, close_failover_version, data, data_encoding) ` +
+		`VALUES (:namespace_id, :business_id, :run_id, :close_failover_version, :data, :data_encoding) ` +
+		`ON CONFLICT (namespace_id, business_id, run_id, close_failover_version) DO UPDATE ` +
+		`SET data = excluded.data, data_encoding = excluded.data_encoding`
+
+	getChasmExecutionArchiveQuery = `SELECT namespace_id, business_id, run_id, close_failover_version, data, data_encoding ` +
+		`FROM chasm_execution_archive ` +
+		`WHERE namespace_id = $1 AND business_id = $2 AND run_id = $3 AND close_failover_version = $4`
+
+	getLatestChasmExecutionArchiveQuery = `SELECT namespace_id, business_id, run_id, close_failover_version, data, data_encoding ` +
+		`FROM chasm_execution_archive ` +
+		`WHERE namespace_id = $1 AND business_id = $2 AND run_id = $3 ` +
+		`ORDER BY close_failover_version DESC LIMIT 1`
+
+	// below are templates for visibility_archive table
+	replaceVisibilityArchiveQuery = `INSERT INTO visibility_archive (` +
+		`namespace_id, run_id, workflow_id, workflow_type_name, close_time, data, data_encoding) ` +
+		`VALUES (:namespace_id, :run_id, :workflow_id, :workflow_type_name, :close_time, :data, :data_encoding) ` +
+		`ON CONFLICT (namespace_id, run_id) DO UPDATE ` +
+		`SET workflow_id = excluded.workflow_id, workflow_type_name = excluded.workflow_type_name, ` +
+		`close_time = excluded.close_time, data = excluded.data, data_encoding = excluded.data_encoding`
+
+	getVisibilityArchiveQueryPrefix = `SELECT namespace_id, run_id, workflow_id, workflow_type_name, close_time, data, data_encoding ` +
+		`FROM visibility_archive WHERE namespace_id = $1`
+)
+
+// For history_archive table:
+
+// ReplaceIntoHistoryArchive replaces one or more rows in history_archive table
+func (pdb *db) ReplaceIntoHistoryArchive(
+	ctx context.Context,
+	rows []sqlplugin.HistoryArchiveRow,
+) (sql.Result, error) {
+	return pdb.NamedExecContext(ctx,
+		replaceHistoryArchiveQuery,
+		rows,
+	)
+}
+
+// SelectFromHistoryArchive reads one or more rows from history_archive table
+func (pdb *db) SelectFromHistoryArchive(
+	ctx context.Context,
+	filter sqlplugin.HistoryArchiveFilter,
+) ([]sqlplugin.HistoryArchiveRow, error) {
+	var rows []sqlplugin.HistoryArchiveRow
+	err := pdb.SelectContext(ctx,
+		&rows,
+		getHistoryArchiveQuery,
+		filter.NamespaceID,
+		filter.WorkflowID,
+		filter.RunID,
+		filter.CloseFailoverVersion,
+		filter.MinBatchIdx,
+		filter.PageSize,
+	)
+	return rows, err
+}
+
+// SelectHistoryArchiveVersions reads the close failover versions of an archived history from history_archive table
+func (pdb *db) SelectHistoryArchiveVersions(
+	ctx context.Context,
+	filter sqlplugin.HistoryArchiveVersionsFilter,
+) ([]int64, error) {
+	var versions []int64
+	err := pdb.SelectContext(ctx,
+		&versions,
+		getHistoryArchiveVersionsQuery,
+		filter.NamespaceID,
+		filter.WorkflowID,
+		filter.RunID,
+	)
+	return versions, err
+}
+
+// DeleteFromHistoryArchive deletes one or more rows from history_archive table
+func (pdb *db) DeleteFromHistoryArchive(
+	ctx context.Context,
+	filter sqlplugin.HistoryArchiveFilter,
+) (sql.Result, error) {
+	return pdb.ExecContext(ctx,
+		deleteHistoryArchiveQuery,
+		filter.NamespaceID,
+		filter.WorkflowID,
+		filter.RunID,
+		filter.CloseFailoverVersion,
+		filter.MinBatchIdx,
+	)
+}
+
+// For history_archive_manifests table:
+
+// ReplaceIntoHistoryArchiveManifests replaces a row in history_archive_manifests table
+func (pdb *db) ReplaceIntoHistoryArchiveManifests(
+	ctx context.Context,
+	row *sqlplugin.HistoryArchiveManifestsRow,
+) (sql.Result, error) {
+	return pdb.NamedExecContext(ctx,
+		replaceHistoryArchiveManifestsQuery,
+		row,
+	)
+}
+
+// SelectFromHistoryArchiveManifests reads a row from history_archive_manifests table
+func (pdb *db) SelectFromHistoryArchiveManifests(
+	ctx context.Context,
+	filter sqlplugin.HistoryArchiveManifestsFilter,
+) (*sqlplugin.HistoryArchiveManifestsRow, error) {
+	var row sqlplugin.HistoryArchiveManifestsRow
+	err := pdb.GetContext(ctx,
+		&row,
+		getHistoryArchiveManifestsQuery,
+		filter.NamespaceID,
+		filter.WorkflowID,
+		filter.RunID,
+		filter.CloseFailoverVersion,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &row, nil
+}
+
+// For chasm_execution_archive table:
+
+// ReplaceIntoChasmExecutionArchive replaces a row in chasm_execution_archive table
+func (pdb *db) ReplaceIntoChasmExecutionArchive(
+	ctx context.Context,
+	row *sqlplugin.ChasmExecutionArchiveRow,
+) (sql.Result, error) {
+	return pdb.NamedExecContext(ctx,
+		replaceChasmExecutionArchiveQuery,
+		row,
+	)
+}
+
+// SelectFromChasmExecutionArchive reads a row from chasm_execution_archive table
+func (pdb *db) SelectFromChasmExecutionArchive(
+	ctx context.Context,
+	filter sqlplugin.ChasmExecutionArchiveFilter,
+) (*sqlplugin.ChasmExecutionArchiveRow, error) {
+	query := getLatestChasmExecutionArchiveQuery
+	args := []any{filter.NamespaceID, filter.BusinessID, filter.RunID}
+	if filter.CloseFailoverVersion != nil {
+		query = getChasmExecutionArchiveQuery
+		args = append(args, *filter.CloseFailoverVersion)
+	}
+	var row sqlplugin.ChasmExecutionArchiveRow
+	if err := pdb.GetContext(ctx, &row, query, args...); err != nil {
+		return nil, err
+	}
+	return &row, nil
+}
+
+// For visibility_archive table:
+
+// ReplaceIntoVisibilityArchive replaces a row in visibility_archive table
+func (pdb *db) ReplaceIntoVisibilityArchive(
+	ctx context.Context,
+	row *sqlplugin.VisibilityArchiveRow,
+) (sql.Result, error) {
+	finalRow := *row
+	finalRow.CloseTime = pdb.converter.ToPostgreSQLDateTime(row.CloseTime)
+	return pdb.NamedExecContext(ctx,
+		replaceVisibilityArchiveQuery,
+		&finalRow,
+	)
+}
+
+// SelectFromVisibilityArchive reads one or more rows from visibility_archive table
+func (pdb *db) SelectFromVisibilityArchive(
+	ctx context.Context,
+	filter sqlplugin.VisibilityArchiveFilter,
+) ([]sqlplugin.VisibilityArchiveRow, error) {
+	var queryBuilder strings.Builder
+	queryBuilder.WriteString(getVisibilityArchiveQueryPrefix)
+	args := []any{filter.NamespaceID}
+	// param appends the argument and returns its positional parameter
+	param := func(arg any) string {
+		args = append(args, arg)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	if filter.WorkflowID != nil {
+		queryBuilder.WriteString(" AND workflow_id = " + param(*filter.WorkflowID))
+	}
+	if filter.WorkflowTypeName != nil {
+		queryBuilder.WriteString(" AND workflow_type_name = " + param(*filter.WorkflowTypeName))
+	}
+	if filter.RunID != nil {
+		queryBuilder.WriteString(" AND run_id = " + param(*filter.RunID))
+	}
+	if filter.EarliestCloseTime != nil {
+		queryBuilder.WriteString(" AND close_time >= " + param(pdb.converter.ToPostgreSQLDateTime(*filter.EarliestCloseTime)))
+	}
+	if filter.LatestCloseTime != nil {
+		queryBuilder.WriteString(" AND close_time <= " + param(pdb.converter.ToPostgreSQLDateTime(*filter.LatestCloseTime)))
+	}
+	if filter.LastCloseTime != nil && filter.LastRunID != nil {
+		lastCloseTime := param(pdb.converter.ToPostgreSQLDateTime(*filter.LastCloseTime))
+		queryBuilder.WriteString(fmt.Sprintf(" AND (close_time < %[1]s OR (close_time = %[1]s AND run_id < %[2]s))", lastCloseTime, param(*filter.LastRunID)))
+	}
+	queryBuilder.WriteString(" ORDER BY close_time DESC, run_id DESC LIMIT " + param(filter.PageSize))
+
+	var rows []sqlplugin.VisibilityArchiveRow
+	if err := pdb.SelectContext(ctx, &rows, queryBuilder.String(), args...); err != nil {
+		return nil, err
+	}
+	for i := range rows {
+		rows[i].CloseTime = pdb.converter.FromPostgreSQLDateTime(rows[i].CloseTime)
+	}
+	return rows, nil
+}
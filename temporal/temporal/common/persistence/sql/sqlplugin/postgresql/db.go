@@ -40,6 +40,7 @@ type db struct {
 }
 
 var _ sqlplugin.DB = (*db)(nil)
+var _ sqlplugin.ArchivalDB = (*db)(nil)
 
 // newDB returns an instance of DB, which is a logical
 // connection to the underlying postgresql database
@@ -70,6 +71,21 @@ func (pdb *db) conn() sqlplugin.Conn {
 	return pdb.handle.Conn()
 }
 
+// readConn returns the connection which serves a read, and its handle. Reads outside of a transaction are served by
+// the read replica if the context allows stale reads.
+func (pdb *db) readConn(ctx context.Context) (sqlplugin.Conn, *sqlplugin.DatabaseHandle) {
+	if pdb.tx != nil {
+		return pdb.tx, pdb.handle
+	}
+	handle := pdb.handle.ReadHandle(ctx)
+	return handle.Conn(), handle
+}
+
+// HasReadReplica returns true if reads which tolerate stale data may be served by a read replica
+func (pdb *db) HasReadReplica() bool {
+	return pdb.handle.HasReadReplica()
+}
+
 // BeginTx starts a new transaction and returns a reference to the Tx object
 func (pdb *db) BeginTx(ctx context.Context) (sqlplugin.Tx, error) {
 	db, err := pdb.handle.DB()
@@ -84,6 +100,20 @@ func (pdb *db) BeginTx(ctx context.Context) (sqlplugin.Tx, error) {
 	return newDB(pdb.dbKind, pdb.dbName, pdb.dbDriver, pdb.handle, tx, pdb.logger), nil
 }
 
+// BeginArchivalTx starts a new transaction on the archival database and returns a reference to the Tx object
+func (pdb *db) BeginArchivalTx(ctx context.Context) (sqlplugin.ArchivalTx, error) {
+	db, err := pdb.handle.DB()
+	if err != nil {
+		// This error needs no conversion
+		return nil, err
+	}
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, pdb.handle.ConvertError(err)
+	}
+	return newDB(pdb.dbKind, pdb.dbName, pdb.dbDriver, pdb.handle, tx, pdb.logger), nil
+}
+
 // Close closes the connection to the mysql db
 func (pdb *db) Close() error {
 	pdb.handle.Close()
@@ -107,6 +137,8 @@ func (pdb *db) ExpectedVersion() string {
 		return postgresqlschemaV12.Version
 	case sqlplugin.DbKindVisibility:
 		return postgresqlschemaV12.VisibilityVersion
+	case sqlplugin.DbKindArchival:
+		return postgresqlschemaV12.ArchivalVersion
 	default:
 		panic(fmt.Sprintf("unknown db kind %v", pdb.dbKind))
 	}
@@ -135,8 +167,9 @@ func (pdb *db) ExecContext(ctx context.Context, stmt string, args ...any) (sql.R
 }
 
 func (pdb *db) GetContext(ctx context.Context, dest any, query string, args ...any) error {
-	err := pdb.conn().GetContext(ctx, dest, query, args...)
-	return pdb.handle.ConvertError(err)
+	conn, handle := pdb.readConn(ctx)
+	err := conn.GetContext(ctx, dest, query, args...)
+	return handle.ConvertError(err)
 }
 
 func (pdb *db) Select(dest any, query string, args ...any) error {
@@ -149,8 +182,9 @@ func (pdb *db) Select(dest any, query string, args ...any) error {
 }
 
 func (pdb *db) SelectContext(ctx context.Context, dest any, query string, args ...any) error {
-	err := pdb.conn().SelectContext(ctx, dest, query, args...)
-	return pdb.handle.ConvertError(err)
+	conn, handle := pdb.readConn(ctx)
+	err := conn.SelectContext(ctx, dest, query, args...)
+	return handle.ConvertError(err)
 }
 
 func (pdb *db) NamedExecContext(ctx context.Context, query string, arg any) (sql.Result, error) {
@@ -164,12 +198,13 @@ func (pdb *db) PrepareNamedContext(ctx context.Context, query string) (*sqlx.Nam
 }
 
 func (pdb *db) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
-	db, err := pdb.handle.DB()
+	handle := pdb.handle.ReadHandle(ctx)
+	db, err := handle.DB()
 	if err != nil {
 		return nil, err
 	}
 	rows, err := db.QueryContext(ctx, query, args...)
-	return rows, pdb.handle.ConvertError(err)
+	return rows, handle.ConvertError(err)
 }
 
 func (pdb *db) Rebind(query string) string {
@@ -8,7 +8,8 @@ import (
 )
 
 const (
-	// below are templates for history_node table
+	// below are templates for history_node table, which is partitioned by shard_id range, so every statement binds
+	// shard_id with an equality to be pruned to a single partition
 	addHistoryNodesQuery = `INSERT INTO history_node (` +
 		`shard_id, tree_id, branch_id, node_id, prev_txn_id, txn_id, data, data_encoding) ` +
 		`VALUES (:shard_id, :tree_id, :branch_id, :node_id, :prev_txn_id, :txn_id, :data, :data_encoding) ` +
@@ -0,0 +1,52 @@
+package postgresql
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestPartitionedTableQueries_BindPartitionKeys makes sure that the statements on the tables partitioned by shard_id
+// can be pruned to a single partition, so they don't scan every partition of a partitioned table.
+func TestPartitionedTableQueries_BindPartitionKeys(t *testing.T) {
+	t.Parallel()
+
+	shardIDEquality := regexp.MustCompile(`(?s)WHERE.*\b(e\.)?shard_id = (\$1|:shard_id)\b`)
+	treeIDEquality := regexp.MustCompile(`(?s)WHERE.*\btree_id = \$2\b`)
+
+	queries := map[string]string{
+		"updateExecutionQuery":                         updateExecutionQuery,
+		"getExecutionQuery":                            getExecutionQuery,
+		"deleteExecutionQuery":                         deleteExecutionQuery,
+		"writeLockExecutionQuery":                      writeLockExecutionQuery,
+		"readLockExecutionQuery":                       readLockExecutionQuery,
+		"lockCurrentExecutionJoinExecutionsQuery":      lockCurrentExecutionJoinExecutionsQuery,
+		"lockCurrentChasmExecutionJoinExecutionsQuery": lockCurrentChasmExecutionJoinExecutionsQuery,
+		"getTransferTasksQuery":                        getTransferTasksQuery,
+		"deleteTransferTaskQuery":                      deleteTransferTaskQuery,
+		"rangeDeleteTransferTaskQuery":                 rangeDeleteTransferTaskQuery,
+		"getTimerTasksQuery":                           getTimerTasksQuery,
+		"deleteTimerTaskQuery":                         deleteTimerTaskQuery,
+		"rangeDeleteTimerTaskQuery":                    rangeDeleteTimerTaskQuery,
+	}
+	for name, query := range queries {
+		require.Regexp(t, shardIDEquality, query, name)
+	}
+
+	historyNodeQueries := map[string]string{
+		"getHistoryNodesQuery":        getHistoryNodesQuery,
+		"getHistoryNodesReverseQuery": getHistoryNodesReverseQuery,
+		"getHistoryNodeMetadataQuery": getHistoryNodeMetadataQuery,
+		"deleteHistoryNodeQuery":      deleteHistoryNodeQuery,
+		"deleteHistoryNodesQuery":     deleteHistoryNodesQuery,
+	}
+	for name, query := range historyNodeQueries {
+		require.Regexp(t, shardIDEquality, query, name)
+		require.Regexp(t, treeIDEquality, query, name)
+	}
+
+	// the join is pruned on executions itself, not only through the join condition
+	require.Contains(t, lockCurrentExecutionJoinExecutionsQuery, "e.shard_id = $1")
+	require.Contains(t, lockCurrentChasmExecutionJoinExecutionsQuery, "e.shard_id = $1")
+}
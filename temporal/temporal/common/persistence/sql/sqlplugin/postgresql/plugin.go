@@ -66,6 +66,24 @@ func (p *plugin) CreateDB(
 	}
 	needsRefresh := p.driver.IsConnNeedsRefreshError
 	handle := sqlplugin.NewDatabaseHandle(dbKind, connect, needsRefresh, logger, metricsHandler, clock.NewRealTimeSource())
+	if replicaCfg := cfg.ReadReplicaConfig(); replicaCfg != nil {
+		connectReplica := func() (*sqlx.DB, error) {
+			if cfg.Connect != nil {
+				return cfg.Connect(replicaCfg)
+			}
+			return p.createDBConnection(replicaCfg, r)
+		}
+		handle.SetReadReplica(sqlplugin.NewReadReplica(
+			dbKind,
+			cfg.ReadReplica,
+			connectReplica,
+			needsRefresh,
+			replicationLag,
+			logger,
+			metricsHandler,
+			clock.NewRealTimeSource(),
+		))
+	}
 	db := newDB(dbKind, cfg.DatabaseName, p.driver, handle, nil, logger)
 	return db, nil
 }
@@ -92,29 +92,13 @@ func (c *queryConverter) ConvertKeywordListComparisonExpr(
 func (c *queryConverter) ConvertTextComparisonExpr(
 	operator string,
 	col *query.SAColumn,
-	value sqlparser.Expr,
+	textQuery *query.TextQuery,
 ) (sqlparser.Expr, error) {
-	valueExpr, ok := value.(*query.UnsafeSQLString)
-	if !ok {
-		return nil, query.NewConverterError(
-			"%s: unexpected value type (expected string, got %s)",
-			query.InvalidExpressionErrMessage,
-			sqlparser.String(value),
-		)
-	}
-	tokens := query.TokenizeTextQueryString(valueExpr.Val)
-	if len(tokens) == 0 {
-		return nil, query.NewConverterError(
-			"%s: unexpected value for Text type search attribute (no tokens found)",
-			query.InvalidExpressionErrMessage,
-		)
-	}
-	valueExpr.Val = strings.Join(tokens, " | ")
 	var newExpr sqlparser.Expr = &sqlparser.ComparisonExpr{
 		Operator: ftsMatchOp,
 		Left:     col,
 		Right: &pgCastExpr{
-			Value: value,
+			Value: query.NewUnsafeSQLString(buildTSQueryString(textQuery)),
 			Type:  convertTypeTSQuery,
 		},
 	}
@@ -209,6 +193,56 @@ func (c *queryConverter) BuildCountStmt(
 	), nil
 }
 
+func (c *queryConverter) BuildValuesStmt(
+	queryParams *query.QueryParams[sqlparser.Expr],
+	fields []string,
+) (string, []any) {
+	whereString := ""
+	if queryParams.QueryExpr != nil {
+		whereString = sqlparser.String(queryParams.QueryExpr)
+		if whereString != "" {
+			whereString = " WHERE " + whereString
+		}
+	}
+
+	columns := make([]string, 0, len(queryParams.GroupBy)+len(fields))
+	for _, field := range queryParams.GroupBy {
+		columns = append(columns, sadefs.GetSqlDbColName(field.FieldName))
+	}
+	for _, field := range fields {
+		columns = append(columns, sadefs.GetSqlDbColName(field))
+	}
+
+	return fmt.Sprintf(
+		"SELECT %s FROM executions_visibility%s",
+		strings.Join(columns, ", "),
+		whereString,
+	), nil
+}
+
+func (c *queryConverter) BuildAggregateStmt(
+	queryParams *query.QueryParams[sqlparser.Expr],
+	aggregations []*query.Aggregation,
+) (string, []any) {
+	whereString := ""
+	if queryParams.QueryExpr != nil {
+		whereString = sqlparser.String(queryParams.QueryExpr)
+		if whereString != "" {
+			whereString = " WHERE " + whereString
+		}
+	}
+
+	columns := sqlplugin.BuildAggregateColumns(queryParams, aggregations, func(col string) string {
+		return fmt.Sprintf("EXTRACT(EPOCH FROM %s) * 1000000", col)
+	})
+	return fmt.Sprintf(
+		"SELECT %s FROM executions_visibility%s%s",
+		strings.Join(columns, ", "),
+		whereString,
+		sqlplugin.BuildGroupByClause(queryParams),
+	), nil
+}
+
 func (c *queryConverter) convertInExpr(
 	leftExpr sqlparser.Expr,
 	values sqlparser.ValTuple,
@@ -244,3 +278,43 @@ func newJSONContainsExpr(
 		Right:    query.NewFuncExpr(jsonBuildArrayFuncName, valueExpr),
 	}
 }
+
+// buildTSQueryString builds the tsquery of a text query, for example:
+//
+//	(token1 & (token2 <-> token3)) & !prefix:*
+//
+// The tokens only have letters and digits, so they don't need to be quoted. They are lowercase like the lexemes
+// of the Text columns, which are normalized with the simple text search configuration.
+func buildTSQueryString(textQuery *query.TextQuery) string {
+	terms, all := textQuery.Positive()
+	phrases := make([]string, len(terms))
+	for i, term := range terms {
+		phrases[i] = buildTSQueryPhrase(term)
+	}
+	separator := " | "
+	if all {
+		separator = " & "
+	}
+	expr := strings.Join(phrases, separator)
+	if len(textQuery.Excluded) == 0 {
+		return expr
+	}
+	if len(phrases) > 1 {
+		expr = fmt.Sprintf("(%s)", expr)
+	}
+	for _, term := range textQuery.Excluded {
+		expr = fmt.Sprintf("%s & !%s", expr, buildTSQueryPhrase(term))
+	}
+	return expr
+}
+
+func buildTSQueryPhrase(term query.TextTerm) string {
+	phrase := strings.Join(term.Tokens, " <-> ")
+	if term.Prefix {
+		phrase += ":*"
+	}
+	if len(term.Tokens) > 1 {
+		phrase = fmt.Sprintf("(%s)", phrase)
+	}
+	return phrase
+}
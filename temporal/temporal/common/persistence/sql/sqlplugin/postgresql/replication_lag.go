@@ -0,0 +1,30 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// replicationLagQuery returns 0 for a primary and for a standby which replayed all the WAL it received, as the
+// timestamp of the last replayed transaction doesn't advance while the primary is idle.
+const replicationLagQuery = `SELECT CASE
+	WHEN NOT pg_is_in_recovery() OR pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+	ELSE EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp())
+END`
+
+// replicationLag returns the replication lag of a PostgreSQL standby.
+func replicationLag(ctx context.Context, db *sqlx.DB) (time.Duration, error) {
+	var seconds sql.NullFloat64
+	if err := db.GetContext(ctx, &seconds, replicationLagQuery); err != nil {
+		return 0, err
+	}
+	if !seconds.Valid {
+		return 0, errors.New("standby has not replayed any transaction")
+	}
+	return time.Duration(math.Max(seconds.Float64, 0) * float64(time.Second)), nil
+}
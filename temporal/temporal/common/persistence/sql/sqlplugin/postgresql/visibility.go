@@ -92,7 +92,7 @@ func (pdb *db) SelectFromVisibility(
 	}
 
 	// Rebind will replace default placeholder `?` with the right placeholder for PostgreSQL.
-	db, err := pdb.handle.DB()
+	db, err := pdb.handle.ReadHandle(ctx).DB()
 	if err != nil {
 		return nil, err
 	}
@@ -158,6 +158,45 @@ func (pdb *db) CountGroupByFromVisibility(
 	return sqlplugin.ParseCountGroupByRows(rows, filter.GroupBy)
 }
 
+func (pdb *db) SelectValuesFromVisibility(
+	ctx context.Context,
+	filter sqlplugin.VisibilitySelectFilter,
+) ([]sqlplugin.VisibilityValuesRow, error) {
+	filter.Query = pdb.Rebind(filter.Query)
+	rows, err := pdb.QueryContext(ctx, filter.Query, filter.QueryArgs...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return sqlplugin.ParseValuesRows(rows, filter.GroupBy, filter.Fields)
+}
+
+func (pdb *db) AggregateFromVisibility(
+	ctx context.Context,
+	filter sqlplugin.VisibilitySelectFilter,
+) ([]sqlplugin.VisibilityAggregateRow, error) {
+	filter.Query = pdb.Rebind(filter.Query)
+	rows, err := pdb.QueryContext(ctx, filter.Query, filter.QueryArgs...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return sqlplugin.ParseAggregateRows(rows, filter.GroupBy, filter.Fields)
+}
+
+// ExplainFromVisibility returns the lines of the plan of EXPLAIN
+func (pdb *db) ExplainFromVisibility(
+	ctx context.Context,
+	filter sqlplugin.VisibilitySelectFilter,
+) (string, error) {
+	var lines []string
+	err := pdb.SelectContext(ctx, &lines, "EXPLAIN "+pdb.Rebind(filter.Query), filter.QueryArgs...)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(lines, "\n") + "\n", nil
+}
+
 func (pdb *db) prepareRowForDB(row *sqlplugin.VisibilityRow) *sqlplugin.VisibilityRow {
 	if row == nil {
 		return nil
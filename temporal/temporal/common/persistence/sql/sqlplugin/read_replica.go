@@ -0,0 +1,133 @@
+package sqlplugin
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"go.temporal.io/server/common/clock"
+	"go.temporal.io/server/common/config"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/log/tag"
+	"go.temporal.io/server/common/metrics"
+)
+
+const (
+	// DBPoolPrimary is the db_pool metric tag value of the connections to the primary database.
+	DBPoolPrimary = "primary"
+	// DBPoolReplica is the db_pool metric tag value of the connections to the read replica.
+	DBPoolReplica = "replica"
+
+	minLagCheckInterval = 100 * time.Millisecond
+)
+
+type (
+	// ReplicationLagFn returns the replication lag of the database, or an error if it is unknown, e.g. because
+	// replication is stopped.
+	ReplicationLagFn func(ctx context.Context, db *sqlx.DB) (time.Duration, error)
+
+	// ReadReplica is the connection pool of a read replica, whose replication lag is checked periodically. Reads are
+	// only served by the replica while its lag is known and no more than the max replication lag.
+	ReadReplica struct {
+		handle         *DatabaseHandle
+		maxLag         time.Duration
+		checkInterval  time.Duration
+		replicationLag ReplicationLagFn
+		logger         log.Logger
+
+		// lag is the replication lag in nanoseconds, or negative if it is unknown
+		lag     atomic.Int64
+		quit    chan struct{}
+		wg      sync.WaitGroup
+		stopped atomic.Bool
+	}
+)
+
+// NewReadReplica returns the ReadReplica of the config, and starts checking its replication lag.
+func NewReadReplica(
+	dbKind DbKind,
+	cfg *config.SQLReadReplica,
+	connect func() (*sqlx.DB, error),
+	needsRefresh func(error) bool,
+	replicationLag ReplicationLagFn,
+	logger log.Logger,
+	metricsHandler metrics.Handler,
+	timeSource clock.TimeSource,
+) *ReadReplica {
+	logger = log.With(logger, tag.NewStringTag("db-pool", DBPoolReplica))
+	r := &ReadReplica{
+		maxLag:         cfg.MaxReplicationLag,
+		checkInterval:  max(cfg.LagCheckInterval, minLagCheckInterval),
+		replicationLag: replicationLag,
+		logger:         logger,
+		quit:           make(chan struct{}),
+	}
+	r.lag.Store(-1)
+	r.handle = newDatabaseHandle(dbKind, DBPoolReplica, connect, needsRefresh, r.Lag, logger, metricsHandler, timeSource)
+	r.checkLag()
+	r.wg.Add(1)
+	go r.run()
+	return r
+}
+
+// Lag returns the replication lag as of the last check, and false if it is unknown.
+func (r *ReadReplica) Lag() (time.Duration, bool) {
+	lag := r.lag.Load()
+	return time.Duration(lag), lag >= 0
+}
+
+// usable returns true if the replica is connected and its replication lag is acceptable.
+func (r *ReadReplica) usable() bool {
+	lag, ok := r.Lag()
+	return ok && lag <= r.maxLag && r.handle.db.Load() != nil
+}
+
+func (r *ReadReplica) run() {
+	defer r.wg.Done()
+	ticker := time.NewTicker(r.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.quit:
+			return
+		case <-ticker.C:
+			r.checkLag()
+		}
+	}
+}
+
+func (r *ReadReplica) checkLag() {
+	db, err := r.handle.DB()
+	if err != nil {
+		r.lag.Store(-1)
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), r.checkInterval)
+	defer cancel()
+	lag, err := r.replicationLag(ctx, db)
+	if err != nil {
+		if r.lag.Swap(-1) >= 0 {
+			r.logger.Warn("sql read replica: unable to get replication lag, reads fall back to the primary database", tag.Error(err))
+		}
+		r.handle.ConvertError(err)
+		return
+	}
+	if prevLag := time.Duration(r.lag.Swap(int64(lag))); lag > r.maxLag && (prevLag < 0 || prevLag <= r.maxLag) {
+		r.logger.Warn("sql read replica: replication lag exceeds the max replication lag, reads fall back to the primary database",
+			tag.NewDurationTag("replication-lag", lag),
+			tag.NewDurationTag("max-replication-lag", r.maxLag),
+		)
+	}
+}
+
+// Close stops checking the replication lag and closes the connections to the replica.
+func (r *ReadReplica) Close() {
+	if r.stopped.CompareAndSwap(false, true) {
+		close(r.quit)
+	}
+	r.wg.Wait()
+	r.handle.Close()
+}
@@ -0,0 +1,78 @@
+package sqlplugin
+
+import (
+	"context"
+	"database/sql"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/server/common/clock"
+	"go.temporal.io/server/common/config"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/common/persistence"
+	_ "modernc.org/sqlite"
+)
+
+func TestDatabaseHandle_ReadHandle(t *testing.T) {
+	connect := func() (*sqlx.DB, error) {
+		db, err := sql.Open("sqlite", ":memory:")
+		if err != nil {
+			return nil, err
+		}
+		return sqlx.NewDb(db, "sqlite"), nil
+	}
+	needsRefresh := func(error) bool { return false }
+
+	var lag atomic.Int64
+	var lagErr atomic.Pointer[error]
+	replicationLag := func(context.Context, *sqlx.DB) (time.Duration, error) {
+		if err := lagErr.Load(); err != nil {
+			return 0, *err
+		}
+		return time.Duration(lag.Load()), nil
+	}
+	lag.Store(int64(time.Second))
+
+	handle := NewDatabaseHandle(DbKindMain, connect, needsRefresh, log.NewNoopLogger(), metrics.NoopMetricsHandler, clock.NewRealTimeSource())
+	defer handle.Close()
+	require.False(t, handle.HasReadReplica())
+	require.Same(t, handle, handle.ReadHandle(persistence.WithStaleReadsAllowed(context.Background())))
+
+	replica := NewReadReplica(
+		DbKindMain,
+		&config.SQLReadReplica{MaxReplicationLag: 5 * time.Second, LagCheckInterval: time.Hour},
+		connect,
+		needsRefresh,
+		replicationLag,
+		log.NewNoopLogger(),
+		metrics.NoopMetricsHandler,
+		clock.NewRealTimeSource(),
+	)
+	handle.SetReadReplica(replica)
+	require.True(t, handle.HasReadReplica())
+
+	staleCtx := persistence.WithStaleReadsAllowed(context.Background())
+	require.Same(t, handle, handle.ReadHandle(context.Background()))
+	require.Same(t, replica.handle, handle.ReadHandle(staleCtx))
+
+	// reads fall back to the primary database while the replica lags too far behind
+	lag.Store(int64(10 * time.Second))
+	replica.checkLag()
+	require.Same(t, handle, handle.ReadHandle(staleCtx))
+
+	lag.Store(0)
+	replica.checkLag()
+	require.Same(t, replica.handle, handle.ReadHandle(staleCtx))
+
+	// and while its lag is unknown
+	err := errTest
+	lagErr.Store(&err)
+	replica.checkLag()
+	_, ok := replica.Lag()
+	require.False(t, ok)
+	require.Same(t, handle, handle.ReadHandle(staleCtx))
+}
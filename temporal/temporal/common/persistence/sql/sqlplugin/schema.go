@@ -0,0 +1,20 @@
+package sqlplugin
+
+type (
+	// SchemaColumn is a column of a table in the live database schema
+	SchemaColumn struct {
+		TableName  string `db:"table_name"`
+		ColumnName string `db:"column_name"`
+		ColumnType string `db:"column_type"`
+		Nullable   bool   `db:"nullable"`
+	}
+
+	// SchemaIndexColumn is a column of an index in the live database schema. The columns of an index are returned in
+	// the order of the index key.
+	SchemaIndexColumn struct {
+		TableName  string `db:"table_name"`
+		IndexName  string `db:"index_name"`
+		Unique     bool   `db:"is_unique"`
+		ColumnName string `db:"column_name"`
+	}
+)
@@ -1,8 +1,14 @@
 package sqlite
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io/fs"
+	"os"
 	"time"
+
+	"go.temporal.io/server/common/persistence/sql/sqlplugin"
 )
 
 const (
@@ -33,6 +39,15 @@ const (
 	listTablesQuery = "SELECT name FROM sqlite_master WHERE type='table'"
 
 	dropTableQuery = "DROP TABLE %v"
+
+	describeColumnsQuery = `SELECT m.name AS table_name, c.name AS column_name, c.type AS column_type, ` +
+		`NOT c."notnull" AS nullable FROM sqlite_master m JOIN pragma_table_info(m.name) c ` +
+		`WHERE m.type = 'table' AND m.name NOT LIKE 'sqlite_%' ORDER BY m.name, c.cid`
+
+	describeIndexColumnsQuery = `SELECT m.name AS table_name, il.name AS index_name, il."unique" AS is_unique, ` +
+		`COALESCE(ic.name, '') AS column_name FROM sqlite_master m JOIN pragma_index_list(m.name) il ` +
+		`JOIN pragma_index_info(il.name) ic WHERE m.type = 'table' AND m.name NOT LIKE 'sqlite_%' ` +
+		`ORDER BY m.name, il.name, ic.seqno`
 )
 
 // CreateSchemaVersionTables sets up the schema version tables
@@ -63,7 +78,7 @@ func (mdb *db) WriteSchemaUpdateLog(oldVersion string, newVersion string, manife
 
 // Exec executes a sql statement
 func (mdb *db) Exec(stmt string, args ...any) error {
-	_, err := mdb.db.Exec(stmt, args...)
+	_, err := mdb.conn.ExecContext(context.Background(), stmt, args...)
 	return err
 }
 
@@ -104,3 +119,25 @@ func (mdb *db) DropDatabase(name string) error {
 	// // SQLite does not need to drop database
 	return nil
 }
+
+// DatabaseExists returns true if the database file exists. In memory databases don't exist until they are connected to.
+func (mdb *db) DatabaseExists(name string) (bool, error) {
+	_, err := os.Stat(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// DescribeSchema returns the columns and the index columns of the tables in the database
+func (mdb *db) DescribeSchema(_ string) ([]sqlplugin.SchemaColumn, []sqlplugin.SchemaIndexColumn, error) {
+	var columns []sqlplugin.SchemaColumn
+	if err := mdb.db.Select(&columns, describeColumnsQuery); err != nil {
+		return nil, nil, err
+	}
+	var indexColumns []sqlplugin.SchemaIndexColumn
+	if err := mdb.db.Select(&indexColumns, describeIndexColumnsQuery); err != nil {
+		return nil, nil, err
+	}
+	return columns, indexColumns, nil
+}
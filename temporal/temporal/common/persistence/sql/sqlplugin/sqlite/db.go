@@ -29,6 +29,8 @@ type db struct {
 var _ sqlplugin.AdminDB = (*db)(nil)
 var _ sqlplugin.DB = (*db)(nil)
 var _ sqlplugin.Tx = (*db)(nil)
+var _ sqlplugin.ArchivalDB = (*db)(nil)
+var _ sqlplugin.ArchivalTx = (*db)(nil)
 
 // newDB returns an instance of DB, which is a logical
 // connection to the underlying sqlite database
@@ -64,6 +66,15 @@ func (mdb *db) BeginTx(ctx context.Context) (sqlplugin.Tx, error) {
 	return newDB(mdb.dbKind, mdb.dbName, mdb.db, xtx, mdb.logger), nil
 }
 
+// BeginArchivalTx starts a new transaction on the archival database and returns a reference to the Tx object
+func (mdb *db) BeginArchivalTx(ctx context.Context) (sqlplugin.ArchivalTx, error) {
+	xtx, err := mdb.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return newDB(mdb.dbKind, mdb.dbName, mdb.db, xtx, mdb.logger), nil
+}
+
 // Commit commits a previously started transaction
 func (mdb *db) Commit() error {
 	return mdb.tx.Commit()
@@ -104,6 +115,11 @@ func (mdb *db) DbName() string {
 	return mdb.dbName
 }
 
+// HasReadReplica returns false, as sqlite doesn't support read replicas
+func (mdb *db) HasReadReplica() bool {
+	return false
+}
+
 // ExpectedVersion returns expected version.
 func (mdb *db) ExpectedVersion() string {
 	switch mdb.dbKind {
@@ -111,6 +127,8 @@ func (mdb *db) ExpectedVersion() string {
 		return sqliteschema.Version
 	case sqlplugin.DbKindVisibility:
 		return sqliteschema.VisibilityVersion
+	case sqlplugin.DbKindArchival:
+		return sqliteschema.ArchivalVersion
 	default:
 		panic(fmt.Sprintf("unknown db kind %v", mdb.dbKind))
 	}
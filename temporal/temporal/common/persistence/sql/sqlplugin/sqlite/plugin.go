@@ -1,6 +1,8 @@
 package sqlite
 
 import (
+	gosql "database/sql"
+	"errors"
 	"fmt"
 	"net/url"
 	"sort"
@@ -21,6 +23,8 @@ import (
 const (
 	// PluginName is the name of the plugin
 	PluginName = "sqlite"
+
+	textSearchIndexSchemaQuery = `SELECT sql FROM sqlite_master WHERE type = 'table' AND name = 'executions_visibility_fts_text'`
 )
 
 // List of non-pragma parameters
@@ -116,6 +120,13 @@ func (p *plugin) createDBConnection(
 		}
 	}
 
+	if cfg.ConnectAttributes["mode"] != "memory" {
+		if err := p.upgradeSQLiteDatabase(cfg, db, logger); err != nil {
+			_ = db.Close()
+			return nil, err
+		}
+	}
+
 	return db, nil
 }
 
@@ -132,6 +143,36 @@ func (p *plugin) setupSQLiteDatabase(cfg *config.SQL, conn *sqlx.DB, logger log.
 	return sqliteschema.SetupSchemaOnDB(db)
 }
 
+// upgradeSQLiteDatabase upgrades the parts of the schema of an existing database file which changed without a new
+// schema version. The full-text index of the Text search attributes removed diacritics before, so it is rebuilt with
+// the tokenizer of the current schema.
+func (p *plugin) upgradeSQLiteDatabase(cfg *config.SQL, conn *sqlx.DB, logger log.Logger) error {
+	var textSearchIndexSQL string
+	err := conn.Get(&textSearchIndexSQL, textSearchIndexSchemaQuery)
+	if errors.Is(err, gosql.ErrNoRows) {
+		// visibility schema isn't set up in this database
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error reading text search index schema: %w", err)
+	}
+	if !strings.Contains(textSearchIndexSQL, "remove_diacritics 2") {
+		return nil
+	}
+
+	logger.Info("Rebuilding the SQLite text search index of the visibility records with the current tokenizer.")
+	tx, err := conn.Beginx()
+	if err != nil {
+		return err
+	}
+	db := newDB(sqlplugin.DbKindVisibility, cfg.DatabaseName, conn, tx, logger)
+	if err := sqliteschema.RebuildTextSearchIndexOnDB(db); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
 func buildDSN(cfg *config.SQL) (string, error) {
 	if cfg.ConnectAttributes == nil {
 		cfg.ConnectAttributes = make(map[string]string)
@@ -104,24 +104,8 @@ func (c *queryConverter) ConvertKeywordListComparisonExpr(
 func (c *queryConverter) ConvertTextComparisonExpr(
 	operator string,
 	col *query.SAColumn,
-	value sqlparser.Expr,
+	textQuery *query.TextQuery,
 ) (sqlparser.Expr, error) {
-	valueExpr, ok := value.(*query.UnsafeSQLString)
-	if !ok {
-		return nil, query.NewConverterError(
-			"%s: unexpected value type (expected string, got %s)",
-			query.InvalidExpressionErrMessage,
-			sqlparser.String(value),
-		)
-	}
-	tokens := query.TokenizeTextQueryString(valueExpr.Val)
-	if len(tokens) == 0 {
-		return nil, query.NewConverterError(
-			"%s: unexpected value for Text type search attribute (no tokens found)",
-			query.InvalidExpressionErrMessage,
-		)
-	}
-
 	var oper string
 	switch operator {
 	case sqlparser.EqualStr:
@@ -137,7 +121,7 @@ func (c *queryConverter) ConvertTextComparisonExpr(
 		)
 	}
 
-	ftsQuery := buildFtsQueryString(col.FieldName, tokens...)
+	ftsQuery := buildFtsTextQueryString(col.FieldName, textQuery)
 	newExpr := sqlparser.ComparisonExpr{
 		Operator: oper,
 		Left:     query.NewColName("rowid"),
@@ -233,6 +217,56 @@ func (c *queryConverter) BuildCountStmt(
 	), nil
 }
 
+func (c *queryConverter) BuildValuesStmt(
+	queryParams *query.QueryParams[sqlparser.Expr],
+	fields []string,
+) (string, []any) {
+	whereString := ""
+	if queryParams.QueryExpr != nil {
+		whereString = sqlparser.String(queryParams.QueryExpr)
+		if whereString != "" {
+			whereString = " WHERE " + whereString
+		}
+	}
+
+	columns := make([]string, 0, len(queryParams.GroupBy)+len(fields))
+	for _, field := range queryParams.GroupBy {
+		columns = append(columns, sadefs.GetSqlDbColName(field.FieldName))
+	}
+	for _, field := range fields {
+		columns = append(columns, sadefs.GetSqlDbColName(field))
+	}
+
+	return fmt.Sprintf(
+		"SELECT %s FROM executions_visibility%s",
+		strings.Join(columns, ", "),
+		whereString,
+	), nil
+}
+
+func (c *queryConverter) BuildAggregateStmt(
+	queryParams *query.QueryParams[sqlparser.Expr],
+	aggregations []*query.Aggregation,
+) (string, []any) {
+	whereString := ""
+	if queryParams.QueryExpr != nil {
+		whereString = sqlparser.String(queryParams.QueryExpr)
+		if whereString != "" {
+			whereString = " WHERE " + whereString
+		}
+	}
+
+	columns := sqlplugin.BuildAggregateColumns(queryParams, aggregations, func(col string) string {
+		return fmt.Sprintf("UNIXEPOCH(%s, 'subsec') * 1000000", col)
+	})
+	return fmt.Sprintf(
+		"SELECT %s FROM executions_visibility%s%s",
+		strings.Join(columns, ", "),
+		whereString,
+		sqlplugin.BuildGroupByClause(queryParams),
+	), nil
+}
+
 // buildFtsSelectStmt builds the following statement for querying FTS:
 //
 //	SELECT rowid FROM tableName WHERE tableName = '%s'
@@ -269,3 +303,34 @@ func buildFtsQueryString(fieldName string, values ...string) string {
 	colName := sadefs.GetSqlDbColName(fieldName)
 	return fmt.Sprintf(`%s : ("%s")`, colName, strings.Join(values, `" OR "`))
 }
+
+// buildFtsTextQueryString builds the FTS query of a text query, for example:
+//
+//	colName : (("token1" AND "token2 token3") NOT "prefix"*)
+func buildFtsTextQueryString(fieldName string, textQuery *query.TextQuery) string {
+	terms, all := textQuery.Positive()
+	phrases := make([]string, len(terms))
+	for i, term := range terms {
+		phrases[i] = buildFtsPhrase(term)
+	}
+	separator := " OR "
+	if all {
+		separator = " AND "
+	}
+	expr := fmt.Sprintf("(%s)", strings.Join(phrases, separator))
+	for _, term := range textQuery.Excluded {
+		expr = fmt.Sprintf("(%s NOT %s)", expr, buildFtsPhrase(term))
+	}
+	colName := sadefs.GetSqlDbColName(fieldName)
+	return fmt.Sprintf("%s : %s", colName, expr)
+}
+
+// buildFtsPhrase builds the FTS phrase of a text term. The tokens only have letters and digits, so they don't need
+// to be escaped.
+func buildFtsPhrase(term query.TextTerm) string {
+	phrase := fmt.Sprintf(`"%s"`, strings.Join(term.Tokens, " "))
+	if term.Prefix {
+		phrase += "*"
+	}
+	return phrase
+}
@@ -134,7 +134,7 @@ func TestQueryConverter_ConvertTextComparisonExpr(t *testing.T) {
 		name     string
 		operator string
 		col      *query.SAColumn
-		value    sqlparser.Expr
+		value    string
 		out      string
 		err      string
 	}{
@@ -142,31 +142,35 @@ func TestQueryConverter_ConvertTextComparisonExpr(t *testing.T) {
 			name:     "valid equal expression",
 			operator: sqlparser.EqualStr,
 			col:      textCol,
-			value:    query.NewUnsafeSQLString("foo bar"),
+			value:    "foo bar",
 			out:      `rowid in (select rowid from executions_visibility_fts_text where executions_visibility_fts_text = 'Text01 : ("foo" OR "bar")')`,
 		},
 		{
 			name:     "valid not equal expression",
 			operator: sqlparser.NotEqualStr,
 			col:      textCol,
-			value:    query.NewUnsafeSQLString("foo bar"),
+			value:    "foo bar",
 			out:      `rowid not in (select rowid from executions_visibility_fts_text where executions_visibility_fts_text = 'Text01 : ("foo" OR "bar")')`,
 		},
 		{
-			name:     "invalid value type",
+			name:     "phrase and prefix expression",
 			operator: sqlparser.EqualStr,
 			col:      textCol,
-			value:    sqlparser.NewIntVal([]byte("123")),
-			err: fmt.Sprintf(
-				"%s: unexpected value type (expected string, got 123)",
-				query.InvalidExpressionErrMessage,
-			),
+			value:    `"Quick brown" fo*`,
+			out:      `rowid in (select rowid from executions_visibility_fts_text where executions_visibility_fts_text = 'Text01 : ("quick brown" OR "fo"*)')`,
+		},
+		{
+			name:     "required and excluded expression",
+			operator: sqlparser.EqualStr,
+			col:      textCol,
+			value:    `+foo +bar baz -qux -"foo-bar"*`,
+			out:      `rowid in (select rowid from executions_visibility_fts_text where executions_visibility_fts_text = 'Text01 : ((("foo" AND "bar") NOT "qux") NOT "foo bar"*)')`,
 		},
 		{
 			name:     "invalid no tokens expression",
 			operator: sqlparser.EqualStr,
 			col:      textCol,
-			value:    query.NewUnsafeSQLString(""),
+			value:    "",
 			err: fmt.Sprintf(
 				"%s: unexpected value for Text type search attribute (no tokens found)",
 				query.InvalidExpressionErrMessage,
@@ -176,7 +180,7 @@ func TestQueryConverter_ConvertTextComparisonExpr(t *testing.T) {
 			name:     "invalid operator",
 			operator: sqlparser.LessThanStr,
 			col:      textCol,
-			value:    query.NewUnsafeSQLString("foo"),
+			value:    "foo",
 			err: fmt.Sprintf(
 				"%s: operator '<' not supported for Text type",
 				query.InvalidExpressionErrMessage,
@@ -188,7 +192,11 @@ func TestQueryConverter_ConvertTextComparisonExpr(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			r := require.New(t)
 			qc := &queryConverter{}
-			out, err := qc.ConvertTextComparisonExpr(tc.operator, tc.col, tc.value)
+			textQuery, err := query.ParseTextQuery(tc.value)
+			var out sqlparser.Expr
+			if err == nil {
+				out, err = qc.ConvertTextComparisonExpr(tc.operator, tc.col, textQuery)
+			}
 			if tc.err != "" {
 				r.Error(err)
 				r.ErrorContains(err, tc.err)
@@ -340,3 +348,64 @@ func TestQueryConverter_BuildCountStmt(t *testing.T) {
 		})
 	}
 }
+
+func TestQueryConverter_BuildAggregateStmt(t *testing.T) {
+	keywordCol := query.NewSAColumn(
+		"AliasForKeyword01",
+		"Keyword01",
+		enumspb.INDEXED_VALUE_TYPE_KEYWORD,
+	)
+	aggregations := []*query.Aggregation{
+		{
+			Function: query.AggregationAvg,
+			Column:   query.NewSAColumn(sadefs.ExecutionDuration, sadefs.ExecutionDuration, enumspb.INDEXED_VALUE_TYPE_INT),
+		},
+		{
+			Function: query.AggregationMax,
+			Column:   query.NewSAColumn(sadefs.CloseTime, sadefs.CloseTime, enumspb.INDEXED_VALUE_TYPE_DATETIME),
+		},
+	}
+
+	tests := []struct {
+		name      string
+		queryExpr sqlparser.Expr
+		groupBy   []*query.SAColumn
+		stmt      string
+	}{
+		{
+			name: "no group by",
+			queryExpr: &sqlparser.ComparisonExpr{
+				Operator: sqlparser.EqualStr,
+				Left:     keywordCol,
+				Right:    query.NewUnsafeSQLString("foo"),
+			},
+			stmt: "SELECT COUNT(*), AVG(execution_duration), MAX(UNIXEPOCH(close_time, 'subsec') * 1000000) FROM executions_visibility WHERE Keyword01 = 'foo'",
+		},
+		{
+			name: "group by",
+			queryExpr: &sqlparser.ComparisonExpr{
+				Operator: sqlparser.EqualStr,
+				Left:     keywordCol,
+				Right:    query.NewUnsafeSQLString("foo"),
+			},
+			groupBy: []*query.SAColumn{
+				query.NewSAColumn(sadefs.ExecutionStatus, sadefs.ExecutionStatus, enumspb.INDEXED_VALUE_TYPE_KEYWORD),
+			},
+			stmt: "SELECT status, COUNT(*), AVG(execution_duration), MAX(UNIXEPOCH(close_time, 'subsec') * 1000000) FROM executions_visibility WHERE Keyword01 = 'foo' GROUP BY status",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := require.New(t)
+			qc := &queryConverter{}
+			qp := &query.QueryParams[sqlparser.Expr]{
+				QueryExpr: tc.queryExpr,
+				GroupBy:   tc.groupBy,
+			}
+			stmt, queryArgs := qc.BuildAggregateStmt(qp, aggregations)
+			r.Equal(tc.stmt, stmt)
+			r.Nil(queryArgs)
+		})
+	}
+}
@@ -152,6 +152,61 @@ func (mdb *db) CountGroupByFromVisibility(
 	return sqlplugin.ParseCountGroupByRows(rows, filter.GroupBy)
 }
 
+func (mdb *db) SelectValuesFromVisibility(
+	ctx context.Context,
+	filter sqlplugin.VisibilitySelectFilter,
+) ([]sqlplugin.VisibilityValuesRow, error) {
+	rows, err := mdb.db.QueryContext(ctx, filter.Query, filter.QueryArgs...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return sqlplugin.ParseValuesRows(rows, filter.GroupBy, filter.Fields)
+}
+
+func (mdb *db) AggregateFromVisibility(
+	ctx context.Context,
+	filter sqlplugin.VisibilitySelectFilter,
+) ([]sqlplugin.VisibilityAggregateRow, error) {
+	rows, err := mdb.db.QueryContext(ctx, filter.Query, filter.QueryArgs...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return sqlplugin.ParseAggregateRows(rows, filter.GroupBy, filter.Fields)
+}
+
+// ExplainFromVisibility returns the steps of EXPLAIN QUERY PLAN, indented by depth
+func (mdb *db) ExplainFromVisibility(
+	ctx context.Context,
+	filter sqlplugin.VisibilitySelectFilter,
+) (string, error) {
+	var steps []struct {
+		ID      int64  `db:"id"`
+		Parent  int64  `db:"parent"`
+		NotUsed int64  `db:"notused"`
+		Detail  string `db:"detail"`
+	}
+	err := mdb.conn.SelectContext(ctx, &steps, "EXPLAIN QUERY PLAN "+filter.Query, filter.QueryArgs...)
+	if err != nil {
+		return "", err
+	}
+	// The steps are returned in depth-first order, and the parent of a top level step is 0.
+	depths := make(map[int64]int, len(steps))
+	var sb strings.Builder
+	for _, step := range steps {
+		depth := 0
+		if parentDepth, ok := depths[step.Parent]; ok {
+			depth = parentDepth + 1
+		}
+		depths[step.ID] = depth
+		sb.WriteString(strings.Repeat("  ", depth))
+		sb.WriteString(step.Detail)
+		sb.WriteString("\n")
+	}
+	return sb.String(), nil
+}
+
 func (mdb *db) prepareRowForDB(row *sqlplugin.VisibilityRow) *sqlplugin.VisibilityRow {
 	if row == nil {
 		return nil
@@ -120,6 +120,54 @@ func (s *historyExecutionSuite) TestInsertSelect() {
 	s.Equal(&execution, row)
 }
 
+func (s *historyExecutionSuite) TestInsertRangeSelect() {
+	shardID := rand.Int31()
+	numExecutions := 5
+	pageSize := 2
+
+	executions := map[string]sqlplugin.ExecutionsRow{}
+	for i := 0; i < numExecutions; i++ {
+		execution := s.newRandomExecutionRow(
+			shardID,
+			primitives.NewUUID(),
+			shuffle.String(testHistoryExecutionWorkflowID),
+			primitives.NewUUID(),
+			rand.Int63(),
+			rand.Int63(),
+		)
+		result, err := s.store.InsertIntoExecutions(newExecutionContext(), &execution)
+		s.NoError(err)
+		rowsAffected, err := result.RowsAffected()
+		s.NoError(err)
+		s.Equal(1, int(rowsAffected))
+		executions[execution.RunID.String()] = execution
+	}
+
+	filter := sqlplugin.ExecutionsRangeFilter{
+		ShardID:                 shardID,
+		ExclusiveMinNamespaceID: primitives.UUID{},
+		ExclusiveMinRunID:       primitives.UUID{},
+		PageSize:                pageSize,
+	}
+	var rows []sqlplugin.ExecutionsRow
+	for {
+		page, err := s.store.RangeSelectFromExecutions(newExecutionContext(), filter)
+		s.NoError(err)
+		rows = append(rows, page...)
+		if len(page) < pageSize {
+			break
+		}
+		lastRow := page[len(page)-1]
+		filter.ExclusiveMinNamespaceID = lastRow.NamespaceID
+		filter.ExclusiveMinWorkflowID = lastRow.WorkflowID
+		filter.ExclusiveMinRunID = lastRow.RunID
+	}
+	s.Len(rows, numExecutions)
+	for _, row := range rows {
+		s.Equal(executions[row.RunID.String()], row)
+	}
+}
+
 func (s *historyExecutionSuite) TestInsertUpdate_Success() {
 	shardID := rand.Int31()
 	namespaceID := primitives.NewUUID()
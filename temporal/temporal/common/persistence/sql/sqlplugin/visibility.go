@@ -71,6 +71,8 @@ type (
 		Query     string
 		QueryArgs []any
 		GroupBy   []string
+		// Fields are the fields whose values are selected after the GROUP BY fields by SelectValuesFromVisibility.
+		Fields []string
 	}
 
 	VisibilityGetFilter struct {
@@ -88,6 +90,20 @@ type (
 		Count       int64
 	}
 
+	// VisibilityAggregateRow holds the values of the GROUP BY fields, the count and the values of the aggregations
+	// of a group.
+	VisibilityAggregateRow struct {
+		GroupValues []any
+		Count       int64
+		Values      []any
+	}
+
+	// VisibilityValuesRow holds the values of the GROUP BY fields and of the selected fields of a row.
+	VisibilityValuesRow struct {
+		GroupValues []any
+		Values      []any
+	}
+
 	Visibility interface {
 		// InsertIntoVisibility inserts a row into visibility table. If a row already exist,
 		// no changes will be made by this API
@@ -107,6 +123,16 @@ type (
 		DeleteFromVisibility(ctx context.Context, filter VisibilityDeleteFilter) (sql.Result, error)
 		CountFromVisibility(ctx context.Context, filter VisibilitySelectFilter) (int64, error)
 		CountGroupByFromVisibility(ctx context.Context, filter VisibilitySelectFilter) ([]VisibilityCountRow, error)
+		// SelectValuesFromVisibility returns the values of the GROUP BY fields and of the selected fields of all the
+		// rows which match the filter.
+		SelectValuesFromVisibility(ctx context.Context, filter VisibilitySelectFilter) ([]VisibilityValuesRow, error)
+		// AggregateFromVisibility returns the values of the GROUP BY fields, the count and the values of the
+		// aggregations of the groups of the statement built by VisibilityQueryConverter.BuildAggregateStmt. The
+		// Fields of the filter are the fields of the aggregations.
+		AggregateFromVisibility(ctx context.Context, filter VisibilitySelectFilter) ([]VisibilityAggregateRow, error)
+		// ExplainFromVisibility returns the plan of the query of the filter, as returned by the EXPLAIN statement of
+		// the database, one line per step. The query is not executed.
+		ExplainFromVisibility(ctx context.Context, filter VisibilitySelectFilter) (string, error)
 	}
 )
 
@@ -166,21 +192,9 @@ func ParseCountGroupByRows(rows dbRowsIf, groupBy []string) ([]VisibilityCountRo
 				return nil, err
 			}
 		}
-		var countTyped int64
-		countValue := reflect.ValueOf(*(rowValues[len(rowValues)-1].(*any)))
-		if countValue.CanInt() {
-			countTyped = countValue.Int()
-		} else if countValue.CanUint() {
-			countTyped = int64(countValue.Uint())
-		} else {
-			// This should never happen.
-			return nil, serviceerror.NewInternal(
-				fmt.Sprintf(
-					"Unable to parse count value from DB (got: %v of type: %T, expected type: integer)",
-					countValue,
-					countValue,
-				),
-			)
+		countTyped, err := parseCountValue(*(rowValues[len(rowValues)-1].(*any)))
+		if err != nil {
+			return nil, err
 		}
 		res = append(res, VisibilityCountRow{
 			GroupValues: groupValues,
@@ -190,6 +204,104 @@ func ParseCountGroupByRows(rows dbRowsIf, groupBy []string) ([]VisibilityCountRo
 	return res, nil
 }
 
+func parseCountValue(value any) (int64, error) {
+	countValue := reflect.ValueOf(value)
+	if countValue.CanInt() {
+		return countValue.Int(), nil
+	}
+	if countValue.CanUint() {
+		return int64(countValue.Uint()), nil
+	}
+	// This should never happen.
+	return 0, serviceerror.NewInternal(
+		fmt.Sprintf(
+			"Unable to parse count value from DB (got: %v of type: %T, expected type: integer)",
+			value,
+			value,
+		),
+	)
+}
+
+// ParseAggregateRows parses the rows of a statement built by VisibilityQueryConverter.BuildAggregateStmt. The
+// values of the aggregations are returned as scanned, except for strings returned as []byte by the driver.
+func ParseAggregateRows(rows dbRowsIf, groupBy []string, fields []string) ([]VisibilityAggregateRow, error) {
+	// Number of columns is number of group by fields plus the count column plus the aggregation columns.
+	rowValues := make([]any, len(groupBy)+1+len(fields))
+	for i := range rowValues {
+		rowValues[i] = new(any)
+	}
+
+	var res []VisibilityAggregateRow
+	for rows.Next() {
+		if err := rows.Scan(rowValues...); err != nil {
+			return nil, err
+		}
+		groupValues := make([]any, len(groupBy))
+		for i := range groupBy {
+			var err error
+			groupValues[i], err = parseCountGroupByGroupValue(groupBy[i], *(rowValues[i].(*any)))
+			if err != nil {
+				return nil, err
+			}
+		}
+		count, err := parseCountValue(*(rowValues[len(groupBy)].(*any)))
+		if err != nil {
+			return nil, err
+		}
+		values := make([]any, len(fields))
+		for i := range fields {
+			value := *(rowValues[len(groupBy)+1+i].(*any))
+			if bs, ok := value.([]byte); ok {
+				value = string(bs)
+			}
+			values[i] = value
+		}
+		res = append(res, VisibilityAggregateRow{
+			GroupValues: groupValues,
+			Count:       count,
+			Values:      values,
+		})
+	}
+	return res, nil
+}
+
+// ParseValuesRows parses the rows of a statement built by VisibilityQueryConverter.BuildValuesStmt. The values of
+// the selected fields are returned as scanned, except for strings returned as []byte by the driver.
+func ParseValuesRows(rows dbRowsIf, groupBy []string, fields []string) ([]VisibilityValuesRow, error) {
+	rowValues := make([]any, len(groupBy)+len(fields))
+	for i := range rowValues {
+		rowValues[i] = new(any)
+	}
+
+	var res []VisibilityValuesRow
+	for rows.Next() {
+		if err := rows.Scan(rowValues...); err != nil {
+			return nil, err
+		}
+		groupValues := make([]any, len(groupBy))
+		for i := range groupBy {
+			var err error
+			groupValues[i], err = parseCountGroupByGroupValue(groupBy[i], *(rowValues[i].(*any)))
+			if err != nil {
+				return nil, err
+			}
+		}
+		values := make([]any, len(fields))
+		for i := range fields {
+			value := *(rowValues[len(groupBy)+i].(*any))
+			if bs, ok := value.([]byte); ok {
+				value = string(bs)
+			}
+			values[i] = value
+		}
+		res = append(res, VisibilityValuesRow{
+			GroupValues: groupValues,
+			Values:      values,
+		})
+	}
+	return res, nil
+}
+
 func parseCountGroupByGroupValue(fieldName string, value any) (any, error) {
 	switch fieldName {
 	case sadefs.ExecutionStatus:
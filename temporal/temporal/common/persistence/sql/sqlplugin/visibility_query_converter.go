@@ -4,10 +4,13 @@ package sqlplugin
 
 import (
 	"encoding/json"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/temporalio/sqlparser"
 	"go.temporal.io/server/common/persistence/visibility/store/query"
+	"go.temporal.io/server/common/searchattribute/sadefs"
 )
 
 type (
@@ -41,10 +44,12 @@ type VisibilityQueryConverter interface {
 		value sqlparser.Expr,
 	) (sqlparser.Expr, error)
 
+	// ConvertTextComparisonExpr converts the comparison of a Text search attribute with a text query, which must
+	// have the semantics documented in query.ParseTextQuery.
 	ConvertTextComparisonExpr(
 		operator string,
 		col *query.SAColumn,
-		value sqlparser.Expr,
+		textQuery *query.TextQuery,
 	) (sqlparser.Expr, error)
 
 	BuildSelectStmt(
@@ -56,4 +61,55 @@ type VisibilityQueryConverter interface {
 	BuildCountStmt(
 		queryExpr *query.QueryParams[sqlparser.Expr],
 	) (string, []any)
+
+	// BuildValuesStmt builds the statement which selects the GROUP BY fields and the given fields of the
+	// rows which match the query.
+	BuildValuesStmt(
+		queryExpr *query.QueryParams[sqlparser.Expr],
+		fields []string,
+	) (string, []any)
+
+	// BuildAggregateStmt builds the statement which selects the GROUP BY fields, the count and the aggregations
+	// of the rows which match the query, grouped by the GROUP BY fields. The aggregations can't be percentiles,
+	// which not all the databases support, and the aggregations of Datetime search attributes are computed over
+	// Unix microseconds.
+	BuildAggregateStmt(
+		queryExpr *query.QueryParams[sqlparser.Expr],
+		aggregations []*query.Aggregation,
+	) (string, []any)
+}
+
+// BuildAggregateColumns returns the GROUP BY columns, the count column and the aggregation columns of
+// BuildAggregateStmt. unixMicrosExpr returns the expression which converts a Datetime column to Unix microseconds.
+func BuildAggregateColumns(
+	queryParams *query.QueryParams[sqlparser.Expr],
+	aggregations []*query.Aggregation,
+	unixMicrosExpr func(col string) string,
+) []string {
+	columns := make([]string, 0, len(queryParams.GroupBy)+1+len(aggregations))
+	for _, field := range queryParams.GroupBy {
+		columns = append(columns, sadefs.GetSqlDbColName(field.FieldName))
+	}
+	columns = append(columns, "COUNT(*)")
+	for _, agg := range aggregations {
+		col := sadefs.GetSqlDbColName(agg.Column.FieldName)
+		if agg.IsDatetime() {
+			col = unixMicrosExpr(col)
+		}
+		columns = append(columns, fmt.Sprintf("%s(%s)", strings.ToUpper(string(agg.Function)), col))
+	}
+	return columns
+}
+
+// BuildGroupByClause returns the GROUP BY clause of the GROUP BY fields, with a leading space, or an empty string
+// if there are no GROUP BY fields.
+func BuildGroupByClause(queryParams *query.QueryParams[sqlparser.Expr]) string {
+	if len(queryParams.GroupBy) == 0 {
+		return ""
+	}
+	groupBy := make([]string, 0, len(queryParams.GroupBy))
+	for _, field := range queryParams.GroupBy {
+		groupBy = append(groupBy, sadefs.GetSqlDbColName(field.FieldName))
+	}
+	return fmt.Sprintf(" GROUP BY %s", strings.Join(groupBy, ", "))
 }
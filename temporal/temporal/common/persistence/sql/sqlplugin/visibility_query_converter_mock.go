@@ -41,6 +41,21 @@ func (m *MockVisibilityQueryConverter) EXPECT() *MockVisibilityQueryConverterMoc
 	return m.recorder
 }
 
+// BuildAggregateStmt mocks base method.
+func (m *MockVisibilityQueryConverter) BuildAggregateStmt(queryExpr *query.QueryParams[sqlparser.Expr], aggregations []*query.Aggregation) (string, []any) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BuildAggregateStmt", queryExpr, aggregations)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].([]any)
+	return ret0, ret1
+}
+
+// BuildAggregateStmt indicates an expected call of BuildAggregateStmt.
+func (mr *MockVisibilityQueryConverterMockRecorder) BuildAggregateStmt(queryExpr, aggregations any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuildAggregateStmt", reflect.TypeOf((*MockVisibilityQueryConverter)(nil).BuildAggregateStmt), queryExpr, aggregations)
+}
+
 // BuildCountStmt mocks base method.
 func (m *MockVisibilityQueryConverter) BuildCountStmt(queryExpr *query.QueryParams[sqlparser.Expr]) (string, []any) {
 	m.ctrl.T.Helper()
@@ -71,6 +86,21 @@ func (mr *MockVisibilityQueryConverterMockRecorder) BuildSelectStmt(queryExpr, p
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuildSelectStmt", reflect.TypeOf((*MockVisibilityQueryConverter)(nil).BuildSelectStmt), queryExpr, pageSize, pageToken)
 }
 
+// BuildValuesStmt mocks base method.
+func (m *MockVisibilityQueryConverter) BuildValuesStmt(queryExpr *query.QueryParams[sqlparser.Expr], fields []string) (string, []any) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BuildValuesStmt", queryExpr, fields)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].([]any)
+	return ret0, ret1
+}
+
+// BuildValuesStmt indicates an expected call of BuildValuesStmt.
+func (mr *MockVisibilityQueryConverterMockRecorder) BuildValuesStmt(queryExpr, fields any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuildValuesStmt", reflect.TypeOf((*MockVisibilityQueryConverter)(nil).BuildValuesStmt), queryExpr, fields)
+}
+
 // ConvertKeywordListComparisonExpr mocks base method.
 func (m *MockVisibilityQueryConverter) ConvertKeywordListComparisonExpr(operator string, col *query.SAColumn, value sqlparser.Expr) (sqlparser.Expr, error) {
 	m.ctrl.T.Helper()
@@ -87,18 +117,18 @@ func (mr *MockVisibilityQueryConverterMockRecorder) ConvertKeywordListComparison
 }
 
 // ConvertTextComparisonExpr mocks base method.
-func (m *MockVisibilityQueryConverter) ConvertTextComparisonExpr(operator string, col *query.SAColumn, value sqlparser.Expr) (sqlparser.Expr, error) {
+func (m *MockVisibilityQueryConverter) ConvertTextComparisonExpr(operator string, col *query.SAColumn, textQuery *query.TextQuery) (sqlparser.Expr, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ConvertTextComparisonExpr", operator, col, value)
+	ret := m.ctrl.Call(m, "ConvertTextComparisonExpr", operator, col, textQuery)
 	ret0, _ := ret[0].(sqlparser.Expr)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ConvertTextComparisonExpr indicates an expected call of ConvertTextComparisonExpr.
-func (mr *MockVisibilityQueryConverterMockRecorder) ConvertTextComparisonExpr(operator, col, value any) *gomock.Call {
+func (mr *MockVisibilityQueryConverterMockRecorder) ConvertTextComparisonExpr(operator, col, textQuery any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConvertTextComparisonExpr", reflect.TypeOf((*MockVisibilityQueryConverter)(nil).ConvertTextComparisonExpr), operator, col, value)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConvertTextComparisonExpr", reflect.TypeOf((*MockVisibilityQueryConverter)(nil).ConvertTextComparisonExpr), operator, col, textQuery)
 }
 
 // GetDatetimeFormat mocks base method.
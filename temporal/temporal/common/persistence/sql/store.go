@@ -50,6 +50,17 @@ func NewSQLAdminDB(
 	return createDB[sqlplugin.AdminDB](dbKind, cfg, r, logger, mh)
 }
 
+// NewSQLArchivalDB returns an ArchivalDB, which is a logical connection to the SQL database of the archived
+// histories and visibility records.
+func NewSQLArchivalDB(
+	cfg *config.SQL,
+	r resolver.ServiceResolver,
+	logger log.Logger,
+	mh metrics.Handler,
+) (sqlplugin.ArchivalDB, error) {
+	return createDB[sqlplugin.ArchivalDB](sqlplugin.DbKindArchival, cfg, r, logger, mh)
+}
+
 func createDB[T any](
 	dbKind sqlplugin.DbKind,
 	cfg *config.SQL,
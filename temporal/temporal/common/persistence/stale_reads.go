@@ -0,0 +1,24 @@
+package persistence
+
+import (
+	"context"
+)
+
+type staleReadsAllowedKey struct{}
+
+// WithStaleReadsAllowed marks ctx as the context of reads which tolerate stale data. Such reads may be served by a
+// read replica of the database, if the datastore has one.
+//
+// The marked reads are the visibility queries, the in-order pages of history branches, ListConcreteExecutions of the
+// visibility scanner, and GetAllHistoryTreeBranches of the history scavenger. Reads whose results are used to decide
+// what to write must not be marked, like ListConcreteExecutions of the executions scanner, which deletes the
+// executions whose retention has passed, or reading the history tree of a branch before deleting it.
+func WithStaleReadsAllowed(ctx context.Context) context.Context {
+	return context.WithValue(ctx, staleReadsAllowedKey{}, true)
+}
+
+// StaleReadsAllowed returns true if ctx was marked by WithStaleReadsAllowed.
+func StaleReadsAllowed(ctx context.Context) bool {
+	allowed, _ := ctx.Value(staleReadsAllowedKey{}).(bool)
+	return allowed
+}
@@ -6,7 +6,6 @@ import (
 	"maps"
 	"math"
 	"math/rand"
-	"strings"
 	"testing"
 	"time"
 
@@ -74,6 +73,8 @@ func NewExecutionMutableStateSuite(
 			logger,
 			dynamicconfig.GetIntPropertyFn(4*1024*1024),
 			dynamicconfig.GetBoolPropertyFn(false),
+			nil,
+			nil,
 		),
 		HistoryBranchUtil: p.NewHistoryBranchUtil(serializer),
 		Logger:            logger,
@@ -2372,10 +2373,6 @@ func (s *ExecutionMutableStateSuite) TestDelete_NotExists() {
 }
 
 func (s *ExecutionMutableStateSuite) TestListConcreteExecutions() {
-	if !strings.HasPrefix(s.T().Name(), "TestCassandra") {
-		s.T().Skip("ListConcreteExecutions is only implemented by cassandra persistence")
-	}
-
 	_, workflowSnapshot, _ := s.CreateWorkflow(
 		rand.Int63(),
 		enumsspb.WORKFLOW_EXECUTION_STATE_CREATED,
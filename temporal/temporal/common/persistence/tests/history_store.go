@@ -66,6 +66,8 @@ func NewHistoryEventsSuite(
 			logger,
 			dynamicconfig.GetIntPropertyFn(4*1024*1024),
 			dynamicconfig.GetBoolPropertyFn(false),
+			nil,
+			nil,
 		),
 		serializer: serializer,
 		logger:     logger,
@@ -733,6 +735,7 @@ func (s *HistoryEventsSuite) appendHistoryEvents(
 ) {
 	_, err := s.store.AppendHistoryNodes(s.Ctx, &p.AppendHistoryNodesRequest{
 		ShardID:           shardID,
+		NamespaceID:       uuid.NewString(),
 		BranchToken:       branchToken,
 		Events:            packet.events,
 		TransactionID:     packet.transactionID,
@@ -752,6 +755,7 @@ func (s *HistoryEventsSuite) appendRawHistoryBatches(
 	s.NoError(err)
 	_, err = s.store.AppendRawHistoryNodes(s.Ctx, &p.AppendRawHistoryNodesRequest{
 		ShardID:           shardID,
+		NamespaceID:       uuid.NewString(),
 		BranchToken:       branchToken,
 		NodeID:            packet.nodeID,
 		TransactionID:     packet.transactionID,
@@ -0,0 +1,19 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"go.temporal.io/server/common/log"
+	persistencetests "go.temporal.io/server/common/persistence/persistence-tests"
+	"go.temporal.io/server/common/persistence/visibility/store/memory"
+)
+
+func TestMemoryVisibilityPersistenceSuite(t *testing.T) {
+	t.Parallel()
+	s := new(VisibilityPersistenceSuite)
+	s.TestBase = persistencetests.NewTestBaseForCluster(memory.NewTestCluster(uuid.NewString()), log.NewTestLogger())
+	s.CustomVisibilityStoreFactory = memory.NewVisibilityStoreFactory()
+	suite.Run(t, s)
+}
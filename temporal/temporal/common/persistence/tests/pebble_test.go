@@ -0,0 +1,724 @@
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"go.temporal.io/server/chasm"
+	"go.temporal.io/server/common/config"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/common/persistence/pebble"
+	persistencetests "go.temporal.io/server/common/persistence/persistence-tests"
+	"go.temporal.io/server/common/persistence/serialization"
+	"go.temporal.io/server/common/persistence/sql"
+	"go.temporal.io/server/common/persistence/sql/sqlplugin"
+	sqltests "go.temporal.io/server/common/persistence/sql/sqlplugin/tests"
+	"go.temporal.io/server/common/resolver"
+)
+
+const (
+	testPebbleClusterName = "temporal_pebble_cluster"
+)
+
+// NewPebbleMemoryConfig returns a new pebble config for test
+func NewPebbleMemoryConfig() *config.SQL {
+	return &config.SQL{
+		PluginName:        pebble.PluginName,
+		DatabaseName:      uuid.NewString(),
+		ConnectAttributes: map[string]string{pebble.ConnectAttributeMode: pebble.ModeMemory},
+	}
+}
+
+// NewPebbleFileConfig returns a new pebble config for test, storing the database files in a temporary directory
+func NewPebbleFileConfig(t *testing.T) *config.SQL {
+	return &config.SQL{
+		PluginName:        pebble.PluginName,
+		DatabaseName:      t.TempDir(),
+		ConnectAttributes: map[string]string{pebble.ConnectAttributeSync: "false"},
+	}
+}
+
+func newPebbleTestBase(cfg *config.SQL) *persistencetests.TestBase {
+	logger := log.NewTestLogger()
+	testCluster := pebble.NewTestCluster(cfg.DatabaseName, cfg.ConnectAttributes, nil, logger)
+	return persistencetests.NewTestBaseForCluster(testCluster, logger)
+}
+
+func TestPebbleExecutionMutableStateStoreSuite(t *testing.T) {
+	t.Parallel()
+	cfg := NewPebbleMemoryConfig()
+	logger := log.NewNoopLogger()
+	factory := sql.NewFactory(
+		*cfg,
+		resolver.NewNoopResolver(),
+		testPebbleClusterName,
+		logger,
+		metrics.NoopMetricsHandler,
+		serialization.NewSerializer(),
+	)
+	shardStore, err := factory.NewShardStore()
+	if err != nil {
+		t.Fatalf("unable to create pebble DB: %v", err)
+	}
+	executionStore, err := factory.NewExecutionStore()
+	if err != nil {
+		t.Fatalf("unable to create pebble DB: %v", err)
+	}
+	defer func() {
+		factory.Close()
+	}()
+
+	s := NewExecutionMutableStateSuite(
+		t,
+		shardStore,
+		executionStore,
+		serialization.NewSerializer(),
+		logger,
+	)
+	suite.Run(t, s)
+}
+
+func TestPebbleExecutionMutableStateTaskStoreSuite(t *testing.T) {
+	t.Parallel()
+	cfg := NewPebbleMemoryConfig()
+	logger := log.NewNoopLogger()
+	factory := sql.NewFactory(
+		*cfg,
+		resolver.NewNoopResolver(),
+		testPebbleClusterName,
+		logger,
+		metrics.NoopMetricsHandler,
+		serialization.NewSerializer(),
+	)
+	shardStore, err := factory.NewShardStore()
+	if err != nil {
+		t.Fatalf("unable to create pebble DB: %v", err)
+	}
+	executionStore, err := factory.NewExecutionStore()
+	if err != nil {
+		t.Fatalf("unable to create pebble DB: %v", err)
+	}
+	defer func() {
+		factory.Close()
+	}()
+
+	s := NewExecutionMutableStateTaskSuite(
+		t,
+		shardStore,
+		executionStore,
+		serialization.NewSerializer(),
+		logger,
+	)
+	suite.Run(t, s)
+}
+
+func TestPebbleHistoryStoreSuite(t *testing.T) {
+	t.Parallel()
+	cfg := NewPebbleMemoryConfig()
+	logger := log.NewNoopLogger()
+	factory := sql.NewFactory(
+		*cfg,
+		resolver.NewNoopResolver(),
+		testPebbleClusterName,
+		logger,
+		metrics.NoopMetricsHandler,
+		serialization.NewSerializer(),
+	)
+	store, err := factory.NewExecutionStore()
+	if err != nil {
+		t.Fatalf("unable to create pebble DB: %v", err)
+	}
+	defer func() {
+		factory.Close()
+	}()
+
+	s := NewHistoryEventsSuite(t, store, logger)
+	suite.Run(t, s)
+}
+
+func TestPebbleTaskQueueSuite(t *testing.T) {
+	t.Parallel()
+	cfg := NewPebbleMemoryConfig()
+	logger := log.NewNoopLogger()
+	factory := sql.NewFactory(
+		*cfg,
+		resolver.NewNoopResolver(),
+		testPebbleClusterName,
+		logger,
+		metrics.NoopMetricsHandler,
+		serialization.NewSerializer(),
+	)
+	taskQueueStore, err := factory.NewTaskStore()
+	if err != nil {
+		t.Fatalf("unable to create pebble DB: %v", err)
+	}
+	defer func() {
+		factory.Close()
+	}()
+
+	s := NewTaskQueueSuite(t, taskQueueStore, logger)
+	suite.Run(t, s)
+}
+
+func TestPebbleFairTaskQueueSuite(t *testing.T) {
+	t.Parallel()
+	cfg := NewPebbleMemoryConfig()
+	logger := log.NewNoopLogger()
+	factory := sql.NewFactory(
+		*cfg,
+		resolver.NewNoopResolver(),
+		testPebbleClusterName,
+		logger,
+		metrics.NoopMetricsHandler,
+		serialization.NewSerializer(),
+	)
+	taskQueueStore, err := factory.NewFairTaskStore()
+	if err != nil {
+		t.Fatalf("unable to create pebble DB: %v", err)
+	}
+	defer func() {
+		factory.Close()
+	}()
+
+	s := NewTaskQueueSuite(t, taskQueueStore, logger) // same suite, different store
+	suite.Run(t, s)
+}
+
+func TestPebbleTaskQueueTaskSuite(t *testing.T) {
+	t.Parallel()
+	cfg := NewPebbleMemoryConfig()
+	logger := log.NewNoopLogger()
+	factory := sql.NewFactory(
+		*cfg,
+		resolver.NewNoopResolver(),
+		testPebbleClusterName,
+		logger,
+		metrics.NoopMetricsHandler,
+		serialization.NewSerializer(),
+	)
+	taskQueueStore, err := factory.NewTaskStore()
+	if err != nil {
+		t.Fatalf("unable to create pebble DB: %v", err)
+	}
+	defer func() {
+		factory.Close()
+	}()
+
+	s := NewTaskQueueTaskSuite(t, taskQueueStore, logger)
+	suite.Run(t, s)
+}
+
+func TestPebbleTaskQueueFairTaskSuite(t *testing.T) {
+	t.Parallel()
+	cfg := NewPebbleMemoryConfig()
+	logger := log.NewNoopLogger()
+	factory := sql.NewFactory(
+		*cfg,
+		resolver.NewNoopResolver(),
+		testPebbleClusterName,
+		logger,
+		metrics.NoopMetricsHandler,
+		serialization.NewSerializer(),
+	)
+	taskQueueStore, err := factory.NewFairTaskStore()
+	if err != nil {
+		t.Fatalf("unable to create pebble DB: %v", err)
+	}
+	defer func() {
+		factory.Close()
+	}()
+
+	s := NewTaskQueueFairTaskSuite(t, taskQueueStore, logger)
+	suite.Run(t, s)
+}
+
+func TestPebbleTaskQueueUserDataSuite(t *testing.T) {
+	t.Parallel()
+	cfg := NewPebbleMemoryConfig()
+	logger := log.NewNoopLogger()
+	factory := sql.NewFactory(
+		*cfg,
+		resolver.NewNoopResolver(),
+		testPebbleClusterName,
+		logger,
+		metrics.NoopMetricsHandler,
+		serialization.NewSerializer(),
+	)
+	taskQueueStore, err := factory.NewTaskStore()
+	if err != nil {
+		t.Fatalf("unable to create pebble DB: %v", err)
+	}
+	defer func() {
+		factory.Close()
+	}()
+
+	s := NewTaskQueueUserDataSuite(t, taskQueueStore, logger)
+	suite.Run(t, s)
+}
+
+func TestPebbleHistoryV2PersistenceSuite(t *testing.T) {
+	t.Parallel()
+	s := new(persistencetests.HistoryV2PersistenceSuite)
+	s.TestBase = newPebbleTestBase(NewPebbleMemoryConfig())
+	s.TestBase.Setup(nil)
+	suite.Run(t, s)
+}
+
+func TestPebbleMetadataPersistenceSuiteV2(t *testing.T) {
+	t.Parallel()
+	s := new(persistencetests.MetadataPersistenceSuiteV2)
+	s.TestBase = newPebbleTestBase(NewPebbleMemoryConfig())
+	s.TestBase.Setup(nil)
+	suite.Run(t, s)
+}
+
+func TestPebbleClusterMetadataPersistence(t *testing.T) {
+	t.Parallel()
+	s := new(persistencetests.ClusterMetadataManagerSuite)
+	s.TestBase = newPebbleTestBase(NewPebbleMemoryConfig())
+	s.TestBase.Setup(nil)
+	suite.Run(t, s)
+}
+
+func TestPebbleQueuePersistence(t *testing.T) {
+	t.Parallel()
+	s := new(persistencetests.QueuePersistenceSuite)
+	s.TestBase = newPebbleTestBase(NewPebbleMemoryConfig())
+	s.TestBase.Setup(nil)
+	suite.Run(t, s)
+}
+
+func TestPebbleFileMetadataPersistenceSuiteV2(t *testing.T) {
+	t.Parallel()
+	s := new(persistencetests.MetadataPersistenceSuiteV2)
+	s.TestBase = newPebbleTestBase(NewPebbleFileConfig(t))
+	s.TestBase.Setup(nil)
+	suite.Run(t, s)
+}
+
+// SQL store tests
+
+func TestPebbleNamespaceSuite(t *testing.T) {
+	t.Parallel()
+	cfg := NewPebbleMemoryConfig()
+	store, err := sql.NewSQLDB(sqlplugin.DbKindMain, cfg, resolver.NewNoopResolver(), log.NewTestLogger(), metrics.NoopMetricsHandler)
+	if err != nil {
+		t.Fatalf("unable to create pebble DB: %v", err)
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+
+	s := sqltests.NewNamespaceSuite(t, store)
+	suite.Run(t, s)
+}
+
+func TestPebbleQueueMessageSuite(t *testing.T) {
+	t.Parallel()
+	cfg := NewPebbleMemoryConfig()
+	store, err := sql.NewSQLDB(sqlplugin.DbKindMain, cfg, resolver.NewNoopResolver(), log.NewTestLogger(), metrics.NoopMetricsHandler)
+	if err != nil {
+		t.Fatalf("unable to create pebble DB: %v", err)
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+
+	s := sqltests.NewQueueMessageSuite(t, store)
+	suite.Run(t, s)
+}
+
+func TestPebbleQueueMetadataSuite(t *testing.T) {
+	t.Parallel()
+	cfg := NewPebbleMemoryConfig()
+	store, err := sql.NewSQLDB(sqlplugin.DbKindMain, cfg, resolver.NewNoopResolver(), log.NewTestLogger(), metrics.NoopMetricsHandler)
+	if err != nil {
+		t.Fatalf("unable to create pebble DB: %v", err)
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+
+	s := sqltests.NewQueueMetadataSuite(t, store)
+	suite.Run(t, s)
+}
+
+func TestPebbleMatchingTaskSuite(t *testing.T) {
+	t.Parallel()
+	cfg := NewPebbleMemoryConfig()
+	store, err := sql.NewSQLDB(sqlplugin.DbKindMain, cfg, resolver.NewNoopResolver(), log.NewTestLogger(), metrics.NoopMetricsHandler)
+	if err != nil {
+		t.Fatalf("unable to create pebble DB: %v", err)
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+
+	s := sqltests.NewMatchingTaskSuite(t, store)
+	suite.Run(t, s)
+}
+
+func TestPebbleMatchingTaskV2Suite(t *testing.T) {
+	t.Parallel()
+	cfg := NewPebbleMemoryConfig()
+	store, err := sql.NewSQLDB(sqlplugin.DbKindMain, cfg, resolver.NewNoopResolver(), log.NewTestLogger(), metrics.NoopMetricsHandler)
+	if err != nil {
+		t.Fatalf("unable to create pebble DB: %v", err)
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+
+	s := sqltests.NewMatchingTaskV2Suite(t, store)
+	suite.Run(t, s)
+}
+
+func TestPebbleMatchingTaskQueueSuite(t *testing.T) {
+	t.Parallel()
+	cfg := NewPebbleMemoryConfig()
+	store, err := sql.NewSQLDB(sqlplugin.DbKindMain, cfg, resolver.NewNoopResolver(), log.NewTestLogger(), metrics.NoopMetricsHandler)
+	if err != nil {
+		t.Fatalf("unable to create pebble DB: %v", err)
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+
+	s := sqltests.NewMatchingTaskQueueSuite(t, store, sqlplugin.MatchingTaskVersion1)
+	suite.Run(t, s)
+}
+
+func TestPebbleMatchingTaskQueueV2Suite(t *testing.T) {
+	t.Parallel()
+	cfg := NewPebbleMemoryConfig()
+	store, err := sql.NewSQLDB(sqlplugin.DbKindMain, cfg, resolver.NewNoopResolver(), log.NewTestLogger(), metrics.NoopMetricsHandler)
+	if err != nil {
+		t.Fatalf("unable to create pebble DB: %v", err)
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+
+	s := sqltests.NewMatchingTaskQueueSuite(t, store, sqlplugin.MatchingTaskVersion2)
+	suite.Run(t, s)
+}
+
+func TestPebbleHistoryShardSuite(t *testing.T) {
+	t.Parallel()
+	cfg := NewPebbleMemoryConfig()
+	store, err := sql.NewSQLDB(sqlplugin.DbKindMain, cfg, resolver.NewNoopResolver(), log.NewTestLogger(), metrics.NoopMetricsHandler)
+	if err != nil {
+		t.Fatalf("unable to create pebble DB: %v", err)
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+
+	s := sqltests.NewHistoryShardSuite(t, store)
+	suite.Run(t, s)
+}
+
+func TestPebbleHistoryNodeSuite(t *testing.T) {
+	t.Parallel()
+	cfg := NewPebbleMemoryConfig()
+	store, err := sql.NewSQLDB(sqlplugin.DbKindMain, cfg, resolver.NewNoopResolver(), log.NewTestLogger(), metrics.NoopMetricsHandler)
+	if err != nil {
+		t.Fatalf("unable to create pebble DB: %v", err)
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+
+	s := sqltests.NewHistoryNodeSuite(t, store)
+	suite.Run(t, s)
+}
+
+func TestPebbleHistoryTreeSuite(t *testing.T) {
+	t.Parallel()
+	cfg := NewPebbleMemoryConfig()
+	store, err := sql.NewSQLDB(sqlplugin.DbKindMain, cfg, resolver.NewNoopResolver(), log.NewTestLogger(), metrics.NoopMetricsHandler)
+	if err != nil {
+		t.Fatalf("unable to create pebble DB: %v", err)
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+
+	s := sqltests.NewHistoryTreeSuite(t, store)
+	suite.Run(t, s)
+}
+
+func TestPebbleHistoryCurrentExecutionSuite(t *testing.T) {
+	t.Parallel()
+	cfg := NewPebbleMemoryConfig()
+	store, err := sql.NewSQLDB(sqlplugin.DbKindMain, cfg, resolver.NewNoopResolver(), log.NewTestLogger(), metrics.NoopMetricsHandler)
+	if err != nil {
+		t.Fatalf("unable to create pebble DB: %v", err)
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+
+	s := sqltests.NewHistoryCurrentExecutionSuite(t, store, chasm.WorkflowArchetypeID)
+	suite.Run(t, s)
+}
+
+func TestPebbleHistoryCurrentChasmExecutionSuite(t *testing.T) {
+	t.Parallel()
+	cfg := NewPebbleMemoryConfig()
+	store, err := sql.NewSQLDB(sqlplugin.DbKindMain, cfg, resolver.NewNoopResolver(), log.NewTestLogger(), metrics.NoopMetricsHandler)
+	if err != nil {
+		t.Fatalf("unable to create pebble DB: %v", err)
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+
+	s := sqltests.NewHistoryCurrentExecutionSuite(t, store, math.MaxUint32)
+	suite.Run(t, s)
+}
+
+func TestPebbleHistoryExecutionSuite(t *testing.T) {
+	t.Parallel()
+	cfg := NewPebbleMemoryConfig()
+	store, err := sql.NewSQLDB(sqlplugin.DbKindMain, cfg, resolver.NewNoopResolver(), log.NewTestLogger(), metrics.NoopMetricsHandler)
+	if err != nil {
+		t.Fatalf("unable to create pebble DB: %v", err)
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+
+	s := sqltests.NewHistoryExecutionSuite(t, store)
+	suite.Run(t, s)
+}
+
+func TestPebbleHistoryTransferTaskSuite(t *testing.T) {
+	t.Parallel()
+	cfg := NewPebbleMemoryConfig()
+	store, err := sql.NewSQLDB(sqlplugin.DbKindMain, cfg, resolver.NewNoopResolver(), log.NewTestLogger(), metrics.NoopMetricsHandler)
+	if err != nil {
+		t.Fatalf("unable to create pebble DB: %v", err)
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+
+	s := sqltests.NewHistoryTransferTaskSuite(t, store)
+	suite.Run(t, s)
+}
+
+func TestPebbleHistoryTimerTaskSuite(t *testing.T) {
+	t.Parallel()
+	cfg := NewPebbleMemoryConfig()
+	store, err := sql.NewSQLDB(sqlplugin.DbKindMain, cfg, resolver.NewNoopResolver(), log.NewTestLogger(), metrics.NoopMetricsHandler)
+	if err != nil {
+		t.Fatalf("unable to create pebble DB: %v", err)
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+
+	s := sqltests.NewHistoryTimerTaskSuite(t, store)
+	suite.Run(t, s)
+}
+
+func TestPebbleHistoryReplicationTaskSuite(t *testing.T) {
+	t.Parallel()
+	cfg := NewPebbleMemoryConfig()
+	store, err := sql.NewSQLDB(sqlplugin.DbKindMain, cfg, resolver.NewNoopResolver(), log.NewTestLogger(), metrics.NoopMetricsHandler)
+	if err != nil {
+		t.Fatalf("unable to create pebble DB: %v", err)
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+
+	s := sqltests.NewHistoryReplicationTaskSuite(t, store)
+	suite.Run(t, s)
+}
+
+func TestPebbleHistoryVisibilityTaskSuite(t *testing.T) {
+	t.Parallel()
+	cfg := NewPebbleMemoryConfig()
+	store, err := sql.NewSQLDB(sqlplugin.DbKindMain, cfg, resolver.NewNoopResolver(), log.NewTestLogger(), metrics.NoopMetricsHandler)
+	if err != nil {
+		t.Fatalf("unable to create pebble DB: %v", err)
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+
+	s := sqltests.NewHistoryVisibilityTaskSuite(t, store)
+	suite.Run(t, s)
+}
+
+func TestPebbleHistoryReplicationDLQTaskSuite(t *testing.T) {
+	t.Parallel()
+	cfg := NewPebbleMemoryConfig()
+	store, err := sql.NewSQLDB(sqlplugin.DbKindMain, cfg, resolver.NewNoopResolver(), log.NewTestLogger(), metrics.NoopMetricsHandler)
+	if err != nil {
+		t.Fatalf("unable to create pebble DB: %v", err)
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+
+	s := sqltests.NewHistoryReplicationDLQTaskSuite(t, store)
+	suite.Run(t, s)
+}
+
+func TestPebbleHistoryExecutionBufferSuite(t *testing.T) {
+	t.Parallel()
+	cfg := NewPebbleMemoryConfig()
+	store, err := sql.NewSQLDB(sqlplugin.DbKindMain, cfg, resolver.NewNoopResolver(), log.NewTestLogger(), metrics.NoopMetricsHandler)
+	if err != nil {
+		t.Fatalf("unable to create pebble DB: %v", err)
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+
+	s := sqltests.NewHistoryExecutionBufferSuite(t, store)
+	suite.Run(t, s)
+}
+
+func TestPebbleHistoryExecutionActivitySuite(t *testing.T) {
+	t.Parallel()
+	cfg := NewPebbleMemoryConfig()
+	store, err := sql.NewSQLDB(sqlplugin.DbKindMain, cfg, resolver.NewNoopResolver(), log.NewTestLogger(), metrics.NoopMetricsHandler)
+	if err != nil {
+		t.Fatalf("unable to create pebble DB: %v", err)
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+
+	s := sqltests.NewHistoryExecutionActivitySuite(t, store)
+	suite.Run(t, s)
+}
+
+func TestPebbleHistoryExecutionChildWorkflowSuite(t *testing.T) {
+	t.Parallel()
+	cfg := NewPebbleMemoryConfig()
+	store, err := sql.NewSQLDB(sqlplugin.DbKindMain, cfg, resolver.NewNoopResolver(), log.NewTestLogger(), metrics.NoopMetricsHandler)
+	if err != nil {
+		t.Fatalf("unable to create pebble DB: %v", err)
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+
+	s := sqltests.NewHistoryExecutionChildWorkflowSuite(t, store)
+	suite.Run(t, s)
+}
+
+func TestPebbleHistoryExecutionTimerSuite(t *testing.T) {
+	t.Parallel()
+	cfg := NewPebbleMemoryConfig()
+	store, err := sql.NewSQLDB(sqlplugin.DbKindMain, cfg, resolver.NewNoopResolver(), log.NewTestLogger(), metrics.NoopMetricsHandler)
+	if err != nil {
+		t.Fatalf("unable to create pebble DB: %v", err)
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+
+	s := sqltests.NewHistoryExecutionTimerSuite(t, store)
+	suite.Run(t, s)
+}
+
+func TestPebbleHistoryExecutionChasmSuite(t *testing.T) {
+	t.Parallel()
+	cfg := NewPebbleMemoryConfig()
+	store, err := sql.NewSQLDB(sqlplugin.DbKindMain, cfg, resolver.NewNoopResolver(), log.NewTestLogger(), metrics.NoopMetricsHandler)
+	if err != nil {
+		t.Fatalf("unable to create pebble DB: %v", err)
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+
+	s := sqltests.NewHistoryExecutionChasmSuite(t, store)
+	suite.Run(t, s)
+}
+
+func TestPebbleHistoryExecutionRequestCancelSuite(t *testing.T) {
+	t.Parallel()
+	cfg := NewPebbleMemoryConfig()
+	store, err := sql.NewSQLDB(sqlplugin.DbKindMain, cfg, resolver.NewNoopResolver(), log.NewTestLogger(), metrics.NoopMetricsHandler)
+	if err != nil {
+		t.Fatalf("unable to create pebble DB: %v", err)
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+
+	s := sqltests.NewHistoryExecutionRequestCancelSuite(t, store)
+	suite.Run(t, s)
+}
+
+func TestPebbleHistoryExecutionSignalSuite(t *testing.T) {
+	t.Parallel()
+	cfg := NewPebbleMemoryConfig()
+	store, err := sql.NewSQLDB(sqlplugin.DbKindMain, cfg, resolver.NewNoopResolver(), log.NewTestLogger(), metrics.NoopMetricsHandler)
+	if err != nil {
+		t.Fatalf("unable to create pebble DB: %v", err)
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+
+	s := sqltests.NewHistoryExecutionSignalSuite(t, store)
+	suite.Run(t, s)
+}
+
+func TestPebbleHistoryExecutionSignalRequestSuite(t *testing.T) {
+	t.Parallel()
+	cfg := NewPebbleMemoryConfig()
+	store, err := sql.NewSQLDB(sqlplugin.DbKindMain, cfg, resolver.NewNoopResolver(), log.NewTestLogger(), metrics.NoopMetricsHandler)
+	if err != nil {
+		t.Fatalf("unable to create pebble DB: %v", err)
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+
+	s := sqltests.NewHistoryExecutionSignalRequestSuite(t, store)
+	suite.Run(t, s)
+}
+
+func TestPebbleQueueV2(t *testing.T) {
+	t.Parallel()
+	cfg := NewPebbleMemoryConfig()
+	logger := log.NewNoopLogger()
+	factory := sql.NewFactory(
+		*cfg,
+		resolver.NewNoopResolver(),
+		testPebbleClusterName,
+		logger,
+		metrics.NoopMetricsHandler,
+		serialization.NewSerializer(),
+	)
+	t.Cleanup(factory.Close)
+	RunQueueV2TestSuiteForSQL(t, factory)
+}
+
+func TestPebbleNexusEndpointPersistence(t *testing.T) {
+	t.Parallel()
+	cfg := NewPebbleMemoryConfig()
+	logger := log.NewNoopLogger()
+	factory := sql.NewFactory(
+		*cfg,
+		resolver.NewNoopResolver(),
+		testPebbleClusterName,
+		logger,
+		metrics.NoopMetricsHandler,
+		serialization.NewSerializer(),
+	)
+	t.Cleanup(factory.Close)
+	RunNexusEndpointTestSuiteForSQL(t, factory)
+}
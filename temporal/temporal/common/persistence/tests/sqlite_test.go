@@ -10,6 +10,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"go.temporal.io/server/chasm"
 	"go.temporal.io/server/common/config"
@@ -616,6 +617,46 @@ func TestSQLiteFileQueuePersistence(t *testing.T) {
 	suite.Run(t, s)
 }
 
+func TestSQLiteFileTextSearchIndexUpgrade(t *testing.T) {
+	cfg := NewSQLiteFileConfig()
+	defer func() {
+		assert.NoError(t, os.Remove(cfg.DatabaseName))
+	}()
+
+	// Set up the database like the schema did before the text search index stopped removing diacritics. The database
+	// isn't opened with the SQLite plugin, which keeps its connections open, so that the next one upgrades it.
+	conn, err := gosql.Open("sqlite", "file:"+cfg.DatabaseName)
+	require.NoError(t, err)
+	statements, err := persistence.LoadAndSplitQuery([]string{path.Join(testSQLiteSchemaDir, "visibility", "schema.sql")})
+	require.NoError(t, err)
+	statements = append(statements,
+		`DROP TABLE executions_visibility_fts_text`,
+		`CREATE VIRTUAL TABLE executions_visibility_fts_text USING fts5 (Text01, Text02, Text03, content='executions_visibility', tokenize="unicode61 remove_diacritics 2")`,
+		`INSERT INTO executions_visibility (namespace_id, run_id, start_time, execution_time, workflow_id, workflow_type_name, status, encoding, search_attributes)
+		VALUES ('namespace-id', 'run-id', '2020-01-01 00:00:00+00:00', '2020-01-01 00:00:00+00:00', 'workflow-id', 'workflow-type', 1, 'Proto3', '{"Text01": "café"}')`,
+	)
+	for _, stmt := range statements {
+		_, err = conn.Exec(stmt)
+		require.NoError(t, err)
+	}
+	require.NoError(t, conn.Close())
+
+	db, err := sql.NewSQLDB(sqlplugin.DbKindVisibility, cfg, resolver.NewNoopResolver(), log.NewTestLogger(), metrics.NoopMetricsHandler)
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	count := func(match string) int64 {
+		count, err := db.CountFromVisibility(context.Background(), sqlplugin.VisibilitySelectFilter{
+			Query:     `SELECT COUNT(*) FROM executions_visibility_fts_text WHERE executions_visibility_fts_text MATCH ?`,
+			QueryArgs: []any{match},
+		})
+		require.NoError(t, err)
+		return count
+	}
+	require.Equal(t, int64(1), count("café"))
+	require.Equal(t, int64(0), count("cafe"))
+}
+
 // SQL store tests
 
 func TestSQLiteNamespaceSuite(t *testing.T) {
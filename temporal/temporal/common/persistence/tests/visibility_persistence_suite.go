@@ -3,12 +3,14 @@ package tests
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 	commonpb "go.temporal.io/api/common/v1"
 	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
 	workflowpb "go.temporal.io/api/workflow/v1"
 	"go.temporal.io/api/workflowservice/v1"
 	"go.temporal.io/server/chasm"
@@ -21,8 +23,11 @@ import (
 	"go.temporal.io/server/common/persistence"
 	persistencetests "go.temporal.io/server/common/persistence/persistence-tests"
 	"go.temporal.io/server/common/persistence/serialization"
+	"go.temporal.io/server/common/persistence/sql/sqlplugin/mysql"
+	"go.temporal.io/server/common/persistence/sql/sqlplugin/sqlite"
 	"go.temporal.io/server/common/persistence/visibility"
 	"go.temporal.io/server/common/persistence/visibility/manager"
+	"go.temporal.io/server/common/persistence/visibility/store/memory"
 	"go.temporal.io/server/common/primitives/timestamp"
 	"go.temporal.io/server/common/resolver"
 	"go.temporal.io/server/common/searchattribute"
@@ -41,6 +46,8 @@ type (
 		// TaskID is monotonic increasing every time a request is created using createOpenWorkflowRecord
 		// and createClosedWorkflowRecord. It tries to simulate the real task ID.
 		taskID int64
+		// countGroupByMaxGroups is the max number of groups of a GROUP BY query.
+		countGroupByMaxGroups int
 
 		*persistencetests.TestBase
 		NamespaceRegistry              namespace.Registry
@@ -64,6 +71,7 @@ func (s *VisibilityPersistenceSuite) SetupSuite() {
 	s.SearchAttributesProvider = searchattribute.NewTestProvider()
 	s.SearchAttributesMapperProvider = searchattribute.NewTestMapperProvider(nil)
 	s.NamespaceRegistry = namespace.NewMockRegistry(s.controller)
+	s.countGroupByMaxGroups = 1000
 	s.VisibilityMgr, err = visibility.NewManager(
 		cfg,
 		resolver.NewNoopResolver(),
@@ -83,6 +91,7 @@ func (s *VisibilityPersistenceSuite) SetupSuite() {
 		dynamicconfig.GetBoolPropertyFnFilteredByNamespace(false),
 		dynamicconfig.GetBoolPropertyFnFilteredByNamespace(true),
 		dynamicconfig.GetBoolPropertyFn(true),
+		func(string) int { return s.countGroupByMaxGroups },
 		metrics.NoopMetricsHandler,
 		s.Logger,
 		serialization.NewSerializer(),
@@ -1054,6 +1063,242 @@ func (s *VisibilityPersistenceSuite) TestCountGroupByWorkflowExecutions() {
 	)
 	s.NoError(err)
 	s.Equal(int64(5), resp.Count)
+	s.False(resp.Truncated)
+
+	s.countGroupByMaxGroups = 1
+	defer func() { s.countGroupByMaxGroups = 1000 }()
+	resp, err = s.VisibilityMgr.CountWorkflowExecutions(
+		s.ctx,
+		&manager.CountWorkflowExecutionsRequest{
+			NamespaceID: testNamespaceUUID,
+			Query:       "GROUP BY ExecutionStatus",
+		},
+	)
+	s.NoError(err)
+	s.True(resp.Truncated)
+	s.Equal(int64(5), resp.Count)
+	s.Equal(
+		[]*workflowservice.CountWorkflowExecutionsResponse_AggregationGroup{
+			{
+				GroupValues: []*commonpb.Payload{runningStatusPayload},
+				Count:       int64(3),
+			},
+		},
+		resp.Groups,
+	)
+}
+
+func (s *VisibilityPersistenceSuite) TestAggregateWorkflowExecutions() {
+	testNamespaceUUID := namespace.ID(uuid.NewString())
+	closeTime := time.Now().UTC().Truncate(time.Millisecond)
+
+	for i := range 3 {
+		startTime := closeTime.Add(-time.Duration(i+1) * time.Second)
+		startReq := s.createOpenWorkflowRecord(
+			testNamespaceUUID,
+			"visibility-workflow-test",
+			"visibility-workflow",
+			startTime,
+			startTime,
+			"test-queue",
+		)
+		s.createClosedWorkflowRecord(startReq, closeTime.Add(-time.Duration(i)*time.Millisecond), enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED)
+	}
+	s.createOpenWorkflowRecord(
+		testNamespaceUUID,
+		"visibility-workflow-test",
+		"visibility-workflow",
+		closeTime,
+		closeTime,
+		"test-queue",
+	)
+
+	resp, err := s.VisibilityMgr.AggregateWorkflowExecutions(
+		s.ctx,
+		&manager.AggregateWorkflowExecutionsRequest{
+			NamespaceID:  testNamespaceUUID,
+			Query:        "GROUP BY ExecutionStatus",
+			Aggregations: []string{"avg(ExecutionDuration)", "percentile(ExecutionDuration, 50)", "max(CloseTime)"},
+		},
+	)
+	s.NoError(err)
+	s.Equal(int64(4), resp.Count)
+	s.Len(resp.Groups, 2)
+
+	decode := func(p *commonpb.Payload, valueType enumspb.IndexedValueType) any {
+		value, err := searchattribute.DecodeValue(p, valueType, false)
+		s.NoError(err)
+		return value
+	}
+	completed := resp.Groups[0]
+	s.Equal(int64(3), completed.Count)
+	s.Equal(enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED.String(), decode(completed.GroupValues[0], enumspb.INDEXED_VALUE_TYPE_KEYWORD))
+	s.InDelta(float64(2*time.Second-time.Millisecond), decode(completed.Values[0], enumspb.INDEXED_VALUE_TYPE_DOUBLE), float64(time.Millisecond))
+	s.InDelta(float64(2*time.Second-time.Millisecond), decode(completed.Values[1], enumspb.INDEXED_VALUE_TYPE_DOUBLE), float64(time.Millisecond))
+	s.WithinDuration(closeTime, decode(completed.Values[2], enumspb.INDEXED_VALUE_TYPE_DATETIME).(time.Time), time.Millisecond)
+
+	running := resp.Groups[1]
+	s.Equal(int64(1), running.Count)
+	s.Equal(enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING.String(), decode(running.GroupValues[0], enumspb.INDEXED_VALUE_TYPE_KEYWORD))
+	s.Nil(decode(running.Values[2], enumspb.INDEXED_VALUE_TYPE_DATETIME))
+
+	s.countGroupByMaxGroups = 1
+	resp, err = s.VisibilityMgr.AggregateWorkflowExecutions(
+		s.ctx,
+		&manager.AggregateWorkflowExecutionsRequest{
+			NamespaceID:  testNamespaceUUID,
+			Query:        "GROUP BY ExecutionStatus",
+			Aggregations: []string{"avg(ExecutionDuration)"},
+		},
+	)
+	s.countGroupByMaxGroups = 1000
+	s.NoError(err)
+	s.True(resp.Truncated)
+	s.Equal(int64(4), resp.Count)
+	s.Len(resp.Groups, 1)
+	s.Equal(int64(3), resp.Groups[0].Count)
+
+	resp, err = s.VisibilityMgr.AggregateWorkflowExecutions(
+		s.ctx,
+		&manager.AggregateWorkflowExecutionsRequest{
+			NamespaceID:  testNamespaceUUID,
+			Query:        "ExecutionStatus = 'Completed'",
+			Aggregations: []string{"min(ExecutionDuration)", "sum(ExecutionDuration)", "min(StartTime)"},
+		},
+	)
+	s.NoError(err)
+	s.Equal(int64(3), resp.Count)
+	s.Len(resp.Groups, 1)
+	s.Empty(resp.Groups[0].GroupValues)
+	s.InDelta(float64(time.Second), decode(resp.Groups[0].Values[0], enumspb.INDEXED_VALUE_TYPE_DOUBLE), float64(time.Millisecond))
+	s.InDelta(float64(6*time.Second-3*time.Millisecond), decode(resp.Groups[0].Values[1], enumspb.INDEXED_VALUE_TYPE_DOUBLE), float64(time.Millisecond))
+	s.WithinDuration(closeTime.Add(-3*time.Second), decode(resp.Groups[0].Values[2], enumspb.INDEXED_VALUE_TYPE_DATETIME).(time.Time), time.Millisecond)
+}
+
+// TestTextSearch tests that the visibility stores match Text search attributes like Elasticsearch, with the text
+// query semantics of query.ParseTextQuery.
+func (s *VisibilityPersistenceSuite) TestTextSearch() {
+	testNamespaceUUID := namespace.ID(uuid.NewString())
+	startTime := time.Now().UTC().Truncate(time.Millisecond)
+
+	texts := map[string]string{
+		"quick":   "Quick brown fox jumps",
+		"lazy":    "Lazy brown dogs sleeping",
+		"order":   "order-12345 shipped",
+		"invoice": "Invoice paid",
+	}
+	for workflowID, text := range texts {
+		textPayload, err := searchattribute.EncodeValue(text, enumspb.INDEXED_VALUE_TYPE_TEXT)
+		s.NoError(err)
+		s.taskID++
+		s.NoError(s.VisibilityMgr.RecordWorkflowExecutionStarted(s.ctx, &manager.RecordWorkflowExecutionStartedRequest{
+			VisibilityRequestBase: &manager.VisibilityRequestBase{
+				NamespaceID:      testNamespaceUUID,
+				Execution:        &commonpb.WorkflowExecution{WorkflowId: workflowID, RunId: uuid.NewString()},
+				WorkflowTypeName: "visibility-workflow",
+				StartTime:        startTime,
+				ExecutionTime:    startTime,
+				Status:           enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING,
+				TaskID:           s.taskID,
+				SearchAttributes: &commonpb.SearchAttributes{
+					IndexedFields: map[string]*commonpb.Payload{"Text01": textPayload},
+				},
+			},
+		}))
+	}
+
+	testCases := []struct {
+		query    string
+		expected []string
+		// skipMySQL skips the queries which MySQL full-text search doesn't support
+		skipMySQL bool
+	}{
+		{query: "Text01 = 'quick'", expected: []string{"quick"}},
+		{query: "Text01 = 'QUICK'", expected: []string{"quick"}},
+		{query: "Text01 = 'fox dogs'", expected: []string{"lazy", "quick"}},
+		{query: `Text01 = '"brown fox"'`, expected: []string{"quick"}},
+		{query: `Text01 = '"fox brown"'`, expected: nil},
+		{query: "Text01 = 'bro*'", expected: []string{"lazy", "quick"}},
+		{query: `Text01 = '"lazy bro"*'`, expected: []string{"lazy"}, skipMySQL: true},
+		{query: "Text01 = '+brown +dogs'", expected: []string{"lazy"}},
+		{query: "Text01 = '+brown invoice'", expected: []string{"lazy", "quick"}},
+		{query: "Text01 = 'brown -dogs'", expected: []string{"quick"}},
+		{query: "Text01 = 'order-12345'", expected: []string{"order"}},
+		{query: "Text01 = '12345'", expected: []string{"order"}},
+		{query: "Text01 != 'brown'", expected: []string{"invoice", "order"}},
+	}
+	for _, tc := range testCases {
+		if tc.skipMySQL && s.VisibilityMgr.HasStoreName(mysql.PluginName) {
+			continue
+		}
+		resp, err := s.VisibilityMgr.ListWorkflowExecutions(s.ctx, &manager.ListWorkflowExecutionsRequestV2{
+			NamespaceID: testNamespaceUUID,
+			PageSize:    10,
+			Query:       tc.query,
+		})
+		s.NoError(err, tc.query)
+		var workflowIDs []string
+		for _, execution := range resp.Executions {
+			workflowIDs = append(workflowIDs, execution.GetExecution().GetWorkflowId())
+		}
+		slices.Sort(workflowIDs)
+		s.Equal(tc.expected, workflowIDs, tc.query)
+	}
+}
+
+func (s *VisibilityPersistenceSuite) TestExplainQuery() {
+	testNamespaceUUID := namespace.ID(uuid.NewString())
+	startTime := time.Now().UTC().Truncate(time.Millisecond)
+	for i := 0; i < 3; i++ {
+		s.taskID++
+		s.NoError(s.VisibilityMgr.RecordWorkflowExecutionStarted(s.ctx, &manager.RecordWorkflowExecutionStartedRequest{
+			VisibilityRequestBase: &manager.VisibilityRequestBase{
+				NamespaceID:      testNamespaceUUID,
+				Execution:        &commonpb.WorkflowExecution{WorkflowId: fmt.Sprintf("explain-%d", i), RunId: uuid.NewString()},
+				WorkflowTypeName: "visibility-workflow",
+				StartTime:        startTime,
+				ExecutionTime:    startTime,
+				Status:           enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING,
+				TaskID:           s.taskID,
+			},
+		}))
+	}
+
+	request := &manager.ExplainQueryRequest{
+		NamespaceID: testNamespaceUUID,
+		Query:       "WorkflowType = 'visibility-workflow'",
+		PageSize:    2,
+	}
+	resp, err := s.VisibilityMgr.ExplainQuery(s.ctx, request)
+	s.NoError(err)
+	s.Contains(resp.AST, "Column WorkflowType")
+	s.Equal("WorkflowType", resp.SearchAttributes[0].Name)
+	s.Equal(enumspb.INDEXED_VALUE_TYPE_KEYWORD, resp.SearchAttributes[0].Type)
+	s.Equal(s.VisibilityMgr.GetStoreNames()[0], resp.StoreName)
+	if resp.StoreName != memory.PersistenceName {
+		s.NotEmpty(resp.StoreQuery)
+	}
+	s.Empty(resp.Plan)
+	s.Zero(resp.Count)
+
+	request.Execute = true
+	resp, err = s.VisibilityMgr.ExplainQuery(s.ctx, request)
+	s.NoError(err)
+	s.Equal(int64(2), resp.Count)
+	if resp.StoreName == sqlite.PluginName {
+		s.NotEmpty(resp.Plan)
+	}
+
+	request.Query = "GROUP BY ExecutionStatus"
+	resp, err = s.VisibilityMgr.ExplainQuery(s.ctx, request)
+	s.NoError(err)
+	s.Equal("GroupBy\n  Column ExecutionStatus\n", resp.AST)
+	s.Equal(int64(1), resp.Count)
+
+	request.Query = "Unknown = 'foo'"
+	_, err = s.VisibilityMgr.ExplainQuery(s.ctx, request)
+	var invalidArgumentErr *serviceerror.InvalidArgument
+	s.ErrorAs(err, &invalidArgumentErr)
 }
 
 func (s *VisibilityPersistenceSuite) listWithPagination(namespaceID namespace.ID, pageSize int) []*workflowpb.WorkflowExecutionInfo {
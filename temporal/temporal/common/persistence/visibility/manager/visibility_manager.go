@@ -37,10 +37,12 @@ type (
 		ListChasmExecutions(ctx context.Context, request *ListChasmExecutionsRequest) (*chasm.ListExecutionsResponse[*commonpb.Payload], error)
 		CountWorkflowExecutions(ctx context.Context, request *CountWorkflowExecutionsRequest) (*CountWorkflowExecutionsResponse, error)
 		CountChasmExecutions(ctx context.Context, request *CountChasmExecutionsRequest) (*chasm.CountExecutionsResponse, error)
+		AggregateWorkflowExecutions(ctx context.Context, request *AggregateWorkflowExecutionsRequest) (*AggregateWorkflowExecutionsResponse, error)
 		GetWorkflowExecution(ctx context.Context, request *GetWorkflowExecutionRequest) (*GetWorkflowExecutionResponse, error)
 
 		// Admin APIs
 		AddSearchAttributes(ctx context.Context, request *AddSearchAttributesRequest) error
+		ExplainQuery(ctx context.Context, request *ExplainQueryRequest) (*ExplainQueryResponse, error)
 	}
 
 	VisibilityRequestBase struct {
@@ -140,8 +142,82 @@ type (
 
 	// CountWorkflowExecutionsResponse is response to CountWorkflowExecutions
 	CountWorkflowExecutionsResponse struct {
-		Count  int64 // sum of counts in Groups
+		Count  int64 // count of all the matching executions, which is the sum of counts in Groups unless Truncated
 		Groups []*workflowservice.CountWorkflowExecutionsResponse_AggregationGroup
+		// Truncated is true if only the groups with the highest counts are returned, because there are more
+		// groups than the max number of groups of the namespace.
+		Truncated bool
+	}
+
+	// AggregateWorkflowExecutionsRequest is request from AggregateWorkflowExecutions
+	AggregateWorkflowExecutionsRequest struct {
+		NamespaceID namespace.ID
+		Namespace   namespace.Name // namespace.Name is not persisted.
+		Query       string
+		// Aggregations are the aggregate functions over Int, Double and Datetime search attributes, for example
+		// avg(ExecutionDuration) or percentile(ExecutionDuration, 95).
+		Aggregations []string
+	}
+
+	// AggregateWorkflowExecutionsResponse is response to AggregateWorkflowExecutions
+	AggregateWorkflowExecutionsResponse struct {
+		Count  int64 // count of all the matching executions, which is the sum of counts in Groups unless Truncated
+		Groups []*AggregationGroup
+		// Truncated is true if only the groups with the highest counts are returned, because there are more
+		// groups than the max number of groups of the namespace.
+		Truncated bool
+	}
+
+	// AggregationGroup holds the aggregations of the executions which have the same values of the GROUP BY fields
+	AggregationGroup struct {
+		GroupValues []*commonpb.Payload
+		Count       int64
+		// Values are the values of the aggregations in the order of the request. They are Datetime search
+		// attribute payloads for the aggregations of Datetime search attributes and Double otherwise, and null
+		// payloads if no execution of the group has a value.
+		Values []*commonpb.Payload
+	}
+
+	// ExplainQueryRequest is request from ExplainQuery
+	ExplainQueryRequest struct {
+		NamespaceID namespace.ID
+		Namespace   namespace.Name // namespace.Name is not persisted.
+		Query       string
+		// PageSize is the page size of the list statement which is explained.
+		PageSize int
+		// Execute runs the query, to return its duration and the plan of the store.
+		Execute bool
+	}
+
+	// ExplainQueryResponse is response to ExplainQuery
+	ExplainQueryResponse struct {
+		// AST is the parsed query, one node per line.
+		AST string
+		// SearchAttributes are the search attributes of the query, resolved to the field names of the store.
+		SearchAttributes []*ExplainedSearchAttribute
+		// StoreName is the name of the store which explained the query.
+		StoreName string
+		// StoreQuery is the SQL statement or Elasticsearch request which the store runs for the query. It counts
+		// the executions if the query has a GROUP BY clause, and lists them otherwise. It is empty for the stores
+		// which evaluate the queries in memory.
+		StoreQuery string
+		// Plan is the plan of the query from EXPLAIN or from the Elasticsearch profile API. It is set only if the
+		// query is executed.
+		Plan string
+		// Duration is the time the store took to run the query. It is set only if the query is executed.
+		Duration time.Duration
+		// Count is the number of rows or documents returned by the query, which is limited by the page size for
+		// list statements. It is set only if the query is executed.
+		Count int64
+	}
+
+	// ExplainedSearchAttribute is a search attribute of an explained query
+	ExplainedSearchAttribute struct {
+		// Name is the name of the search attribute in the query.
+		Name string
+		// FieldName is the name of the search attribute in the store, after alias mapping.
+		FieldName string
+		Type      enumspb.IndexedValueType
 	}
 
 	// VisibilityDeleteWorkflowExecutionRequest contains the request params for DeleteWorkflowExecution call
@@ -42,6 +42,21 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 	return m.recorder
 }
 
+// Aggregate mocks base method.
+func (m *MockClient) Aggregate(ctx context.Context, index string, query elastic.Query, aggs map[string]elastic.Aggregation) (*elastic.SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Aggregate", ctx, index, query, aggs)
+	ret0, _ := ret[0].(*elastic.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Aggregate indicates an expected call of Aggregate.
+func (mr *MockClientMockRecorder) Aggregate(ctx, index, query, aggs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Aggregate", reflect.TypeOf((*MockClient)(nil).Aggregate), ctx, index, query, aggs)
+}
+
 // CatIndices mocks base method.
 func (m *MockClient) CatIndices(ctx context.Context, target string) (elastic.CatIndicesResponse, error) {
 	m.ctrl.T.Helper()
@@ -162,6 +177,21 @@ func (mr *MockClientMockRecorder) IndexExists(ctx, indexName any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexExists", reflect.TypeOf((*MockClient)(nil).IndexExists), ctx, indexName)
 }
 
+// Profile mocks base method.
+func (m *MockClient) Profile(ctx context.Context, index string, searchSource *elastic.SearchSource) (*elastic.SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Profile", ctx, index, searchSource)
+	ret0, _ := ret[0].(*elastic.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Profile indicates an expected call of Profile.
+func (mr *MockClientMockRecorder) Profile(ctx, index, searchSource any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Profile", reflect.TypeOf((*MockClient)(nil).Profile), ctx, index, searchSource)
+}
+
 // PutMapping mocks base method.
 func (m *MockClient) PutMapping(ctx context.Context, index string, mapping map[string]enums.IndexedValueType) (bool, error) {
 	m.ctrl.T.Helper()
@@ -246,6 +276,21 @@ func (m *MockCLIClient) EXPECT() *MockCLIClientMockRecorder {
 	return m.recorder
 }
 
+// Aggregate mocks base method.
+func (m *MockCLIClient) Aggregate(ctx context.Context, index string, query elastic.Query, aggs map[string]elastic.Aggregation) (*elastic.SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Aggregate", ctx, index, query, aggs)
+	ret0, _ := ret[0].(*elastic.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Aggregate indicates an expected call of Aggregate.
+func (mr *MockCLIClientMockRecorder) Aggregate(ctx, index, query, aggs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Aggregate", reflect.TypeOf((*MockCLIClient)(nil).Aggregate), ctx, index, query, aggs)
+}
+
 // CatIndices mocks base method.
 func (m *MockCLIClient) CatIndices(ctx context.Context, target string) (elastic.CatIndicesResponse, error) {
 	m.ctrl.T.Helper()
@@ -439,6 +484,21 @@ func (mr *MockCLIClientMockRecorder) Ping(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockCLIClient)(nil).Ping), ctx)
 }
 
+// Profile mocks base method.
+func (m *MockCLIClient) Profile(ctx context.Context, index string, searchSource *elastic.SearchSource) (*elastic.SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Profile", ctx, index, searchSource)
+	ret0, _ := ret[0].(*elastic.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Profile indicates an expected call of Profile.
+func (mr *MockCLIClientMockRecorder) Profile(ctx, index, searchSource any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Profile", reflect.TypeOf((*MockCLIClient)(nil).Profile), ctx, index, searchSource)
+}
+
 // PutMapping mocks base method.
 func (m *MockCLIClient) PutMapping(ctx context.Context, index string, mapping map[string]enums.IndexedValueType) (bool, error) {
 	m.ctrl.T.Helper()
@@ -523,6 +583,21 @@ func (m *MockIntegrationTestsClient) EXPECT() *MockIntegrationTestsClientMockRec
 	return m.recorder
 }
 
+// Aggregate mocks base method.
+func (m *MockIntegrationTestsClient) Aggregate(ctx context.Context, index string, query elastic.Query, aggs map[string]elastic.Aggregation) (*elastic.SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Aggregate", ctx, index, query, aggs)
+	ret0, _ := ret[0].(*elastic.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Aggregate indicates an expected call of Aggregate.
+func (mr *MockIntegrationTestsClientMockRecorder) Aggregate(ctx, index, query, aggs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Aggregate", reflect.TypeOf((*MockIntegrationTestsClient)(nil).Aggregate), ctx, index, query, aggs)
+}
+
 // CatIndices mocks base method.
 func (m *MockIntegrationTestsClient) CatIndices(ctx context.Context, target string) (elastic.CatIndicesResponse, error) {
 	m.ctrl.T.Helper()
@@ -702,6 +777,21 @@ func (mr *MockIntegrationTestsClientMockRecorder) Ping(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockIntegrationTestsClient)(nil).Ping), ctx)
 }
 
+// Profile mocks base method.
+func (m *MockIntegrationTestsClient) Profile(ctx context.Context, index string, searchSource *elastic.SearchSource) (*elastic.SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Profile", ctx, index, searchSource)
+	ret0, _ := ret[0].(*elastic.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Profile indicates an expected call of Profile.
+func (mr *MockIntegrationTestsClientMockRecorder) Profile(ctx, index, searchSource any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Profile", reflect.TypeOf((*MockIntegrationTestsClient)(nil).Profile), ctx, index, searchSource)
+}
+
 // PutMapping mocks base method.
 func (m *MockIntegrationTestsClient) PutMapping(ctx context.Context, index string, mapping map[string]enums.IndexedValueType) (bool, error) {
 	m.ctrl.T.Helper()
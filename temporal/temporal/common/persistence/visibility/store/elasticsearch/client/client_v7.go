@@ -123,6 +123,11 @@ func (c *clientImpl) Get(ctx context.Context, index string, docID string) (*elas
 }
 
 func (c *clientImpl) Search(ctx context.Context, p *SearchParameters) (*elastic.SearchResult, error) {
+	return c.esClient.Search(p.Index).SearchSource(NewSearchSource(p)).Do(ctx)
+}
+
+// NewSearchSource returns the search request which Search sends for the parameters.
+func NewSearchSource(p *SearchParameters) *elastic.SearchSource {
 	searchSource := elastic.NewSearchSource().
 		Query(p.Query).
 		SortBy(p.Sorter...).
@@ -136,7 +141,7 @@ func (c *clientImpl) Search(ctx context.Context, p *SearchParameters) (*elastic.
 		searchSource.SearchAfter(p.SearchAfter...)
 	}
 
-	return c.esClient.Search(p.Index).SearchSource(searchSource).Do(ctx)
+	return searchSource
 }
 
 func (c *clientImpl) Count(ctx context.Context, index string, query elastic.Query) (int64, error) {
@@ -150,14 +155,44 @@ func (c *clientImpl) CountGroupBy(
 	aggName string,
 	agg elastic.Aggregation,
 ) (*elastic.SearchResult, error) {
-	searchSource := elastic.NewSearchSource().
+	return c.esClient.Search(index).SearchSource(NewCountGroupBySearchSource(query, aggName, agg)).Do(ctx)
+}
+
+// NewCountGroupBySearchSource returns the search request which CountGroupBy sends for the parameters.
+func NewCountGroupBySearchSource(query elastic.Query, aggName string, agg elastic.Aggregation) *elastic.SearchSource {
+	return elastic.NewSearchSource().
 		Query(query).
 		Size(0).
 		TrackTotalHits(false).
 		Aggregation(aggName, agg)
+}
+
+// Aggregate runs the aggregations over the documents which match the query. The total number of matching documents
+// is tracked exactly.
+func (c *clientImpl) Aggregate(
+	ctx context.Context,
+	index string,
+	query elastic.Query,
+	aggs map[string]elastic.Aggregation,
+) (*elastic.SearchResult, error) {
+	searchSource := elastic.NewSearchSource().
+		Query(query).
+		Size(0).
+		TrackTotalHits(true)
+	for name, agg := range aggs {
+		searchSource = searchSource.Aggregation(name, agg)
+	}
 	return c.esClient.Search(index).SearchSource(searchSource).Do(ctx)
 }
 
+func (c *clientImpl) Profile(
+	ctx context.Context,
+	index string,
+	searchSource *elastic.SearchSource,
+) (*elastic.SearchResult, error) {
+	return c.esClient.Search(index).SearchSource(searchSource).Profile(true).Do(ctx)
+}
+
 func (c *clientImpl) RunBulkProcessor(ctx context.Context, p *BulkProcessorParameters) (BulkProcessor, error) {
 	esBulkProcessor, err := c.esClient.BulkProcessor().
 		Name(p.Name).
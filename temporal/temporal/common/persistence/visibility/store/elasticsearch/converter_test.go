@@ -22,7 +22,6 @@ var errorCases = map[string]string{
 	"select * from a where 1 = 1":            query.InvalidExpressionErrMessage,
 	"select * from a where 1=a":              query.InvalidExpressionErrMessage,
 	"select * from a where zz(k=2)":          query.NotSupportedErrMessage,
-	"select * from a group by k, m":          query.NotSupportedErrMessage,
 	"select * from a group by k order by id": query.NotSupportedErrMessage,
 	"select * from a where a like '%a%'":     "operator 'like' not allowed in comparison expression",
 	"select * from a where a not like '%a%'": "operator 'not like' not allowed in comparison expression",
@@ -102,6 +101,10 @@ var supportedWhereGroupByCases = map[string]struct {
 		query:   `{"bool":{"filter":{"term":{"id":1}}}}`,
 		groupBy: []string{"status"},
 	},
+	"group by status, process_id": {
+		query:   ``,
+		groupBy: []string{"status", "process_id"},
+	},
 }
 
 var testNameTypeMap = searchattribute.NewNameTypeMapStub(
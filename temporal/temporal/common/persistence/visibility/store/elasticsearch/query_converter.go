@@ -165,17 +165,74 @@ func (c *queryConverter) ConvertTextComparisonExpr(
 	col *query.SAColumn,
 	value any,
 ) (elastic.Query, error) {
-	colName := col.FieldName
+	text, ok := value.(string)
+	if !ok {
+		return nil, query.NewConverterError(
+			"%s: unexpected value type (expected string, got %v)",
+			query.InvalidExpressionErrMessage,
+			value,
+		)
+	}
+	textQuery, err := query.ParseTextQuery(text)
+	if err != nil {
+		return nil, err
+	}
+	textExpr := newTextQuery(col.FieldName, textQuery)
 	switch operator {
 	case sqlparser.EqualStr:
-		return elastic.NewMatchQuery(colName, value), nil
+		return textExpr, nil
 	case sqlparser.NotEqualStr:
-		return newBoolQuery().MustNot(elastic.NewMatchQuery(colName, value)), nil
+		return newBoolQuery().MustNot(textExpr), nil
 	default:
 		return nil, query.NewOperatorNotSupportedError(col.Alias, col.ValueType, operator)
 	}
 }
 
+// newTextQuery builds the query of a text query. A plain text query is a match query, which matches the documents
+// which contain any of its words.
+func newTextQuery(colName string, textQuery *query.TextQuery) elastic.Query {
+	if textQuery.IsPlain() {
+		words := make([]string, len(textQuery.Optional))
+		for i, term := range textQuery.Optional {
+			words[i] = term.Tokens[0]
+		}
+		return elastic.NewMatchQuery(colName, strings.Join(words, " "))
+	}
+
+	terms, all := textQuery.Positive()
+	if len(terms) == 1 && len(textQuery.Excluded) == 0 {
+		return newTextTermQuery(colName, terms[0])
+	}
+	termQueries := make([]elastic.Query, len(terms))
+	for i, term := range terms {
+		termQueries[i] = newTextTermQuery(colName, term)
+	}
+	q := newBoolQuery()
+	if all {
+		q.Filter(termQueries...)
+	} else {
+		q.Should(termQueries...).MinimumNumberShouldMatch(1)
+	}
+	for _, term := range textQuery.Excluded {
+		q.MustNot(newTextTermQuery(colName, term))
+	}
+	return q
+}
+
+func newTextTermQuery(colName string, term query.TextTerm) elastic.Query {
+	if len(term.Tokens) == 1 {
+		if term.Prefix {
+			return elastic.NewPrefixQuery(colName, term.Tokens[0])
+		}
+		return elastic.NewMatchQuery(colName, term.Tokens[0])
+	}
+	phrase := strings.Join(term.Tokens, " ")
+	if term.Prefix {
+		return elastic.NewMatchPhrasePrefixQuery(colName, phrase)
+	}
+	return elastic.NewMatchPhraseQuery(colName, phrase)
+}
+
 func (c *queryConverter) ConvertRangeExpr(
 	operator string,
 	col *query.SAColumn,
@@ -602,6 +602,50 @@ func TestQueryConverter_ConvertTextComparisonExpr(t *testing.T) {
 			value:    "foo",
 			out:      newBoolQuery().MustNot(elastic.NewMatchQuery(textCol.FieldName, "foo")),
 		},
+		{
+			name:     "plain words",
+			operator: sqlparser.EqualStr,
+			col:      textCol,
+			value:    "Foo  bar",
+			out:      elastic.NewMatchQuery(textCol.FieldName, "foo bar"),
+		},
+		{
+			name:     "phrase",
+			operator: sqlparser.EqualStr,
+			col:      textCol,
+			value:    `"Quick brown"`,
+			out:      elastic.NewMatchPhraseQuery(textCol.FieldName, "quick brown"),
+		},
+		{
+			name:     "phrase and prefixes",
+			operator: sqlparser.EqualStr,
+			col:      textCol,
+			value:    `"quick brown" fo* "lazy do"*`,
+			out: newBoolQuery().Should(
+				elastic.NewMatchPhraseQuery(textCol.FieldName, "quick brown"),
+				elastic.NewPrefixQuery(textCol.FieldName, "fo"),
+				elastic.NewMatchPhrasePrefixQuery(textCol.FieldName, "lazy do"),
+			).MinimumNumberShouldMatch(1),
+		},
+		{
+			name:     "required and excluded",
+			operator: sqlparser.EqualStr,
+			col:      textCol,
+			value:    "+foo +bar baz -qux",
+			out: newBoolQuery().Filter(
+				elastic.NewMatchQuery(textCol.FieldName, "foo"),
+				elastic.NewMatchQuery(textCol.FieldName, "bar"),
+			).MustNot(
+				elastic.NewMatchQuery(textCol.FieldName, "qux"),
+			),
+		},
+		{
+			name:     "no tokens",
+			operator: sqlparser.EqualStr,
+			col:      textCol,
+			value:    "!!",
+			err:      "no tokens found",
+		},
 		{
 			name:     "invalid operator",
 			operator: sqlparser.LikeStr,
@@ -104,11 +104,8 @@ func (ni *nameInterceptor) Name(name string, usage query.FieldNameUsage) (string
 			)
 		}
 	case query.FieldNameGroupBy:
-		if !query.IsGroupByFieldAllowed(fieldName) {
-			return "", query.NewConverterError(
-				"%s: 'GROUP BY' clause is only supported for ExecutionStatus",
-				query.NotSupportedErrMessage,
-			)
+		if !query.IsGroupByFieldAllowed(fieldName, fieldType) {
+			return "", query.NewGroupByFieldNotAllowedError(name)
 		}
 	}
 
@@ -37,6 +37,11 @@ const (
 	PersistenceName = "elasticsearch"
 
 	delimiter = "~"
+
+	// uncappedGroupByTermsSize is the number of buckets of each GROUP BY terms aggregation when the number of groups
+	// isn't capped, since Elasticsearch returns only 10 buckets if the size isn't set. It is the default max number
+	// of buckets of a search request of the older Elasticsearch versions.
+	uncappedGroupByTermsSize = 10000
 )
 
 type (
@@ -51,6 +56,7 @@ type (
 		disableOrderByClause           dynamicconfig.BoolPropertyFnWithNamespaceFilter
 		enableManualPagination         dynamicconfig.BoolPropertyFnWithNamespaceFilter
 		enableUnifiedQueryConverter    dynamicconfig.BoolPropertyFn
+		countGroupByMaxGroups          dynamicconfig.IntPropertyFnWithNamespaceFilter
 		metricsHandler                 metrics.Handler
 		logger                         log.Logger
 	}
@@ -131,6 +137,7 @@ func NewVisibilityStore(
 	disableOrderByClause dynamicconfig.BoolPropertyFnWithNamespaceFilter,
 	enableManualPagination dynamicconfig.BoolPropertyFnWithNamespaceFilter,
 	enableUnifiedQueryConverter dynamicconfig.BoolPropertyFn,
+	countGroupByMaxGroups dynamicconfig.IntPropertyFnWithNamespaceFilter,
 	metricsHandler metrics.Handler,
 	logger log.Logger,
 ) (*VisibilityStore, error) {
@@ -167,6 +174,7 @@ func NewVisibilityStore(
 		disableOrderByClause:           disableOrderByClause,
 		enableManualPagination:         enableManualPagination,
 		enableUnifiedQueryConverter:    enableUnifiedQueryConverter,
+		countGroupByMaxGroups:          countGroupByMaxGroups,
 		metricsHandler:                 metricsHandler.WithTags(metrics.OperationTag(metrics.ElasticsearchVisibility)),
 		logger:                         logger,
 	}, nil
@@ -437,7 +445,7 @@ func (s *VisibilityStore) CountChasmExecutions(
 	}
 
 	if len(queryParams.GroupBy) > 0 {
-		return s.countGroupByExecutions(ctx, queryParams, mapper)
+		return s.countGroupByExecutions(ctx, request.Namespace, queryParams, mapper)
 	}
 
 	count, err := s.esClient.Count(ctx, s.index, queryParams.Query)
@@ -468,7 +476,7 @@ func (s *VisibilityStore) CountWorkflowExecutions(
 	}
 
 	if len(queryParams.GroupBy) > 0 {
-		return s.countGroupByExecutions(ctx, queryParams, nil)
+		return s.countGroupByExecutions(ctx, request.Namespace, queryParams, nil)
 	}
 
 	count, err := s.esClient.Count(ctx, s.index, queryParams.Query)
@@ -481,10 +489,12 @@ func (s *VisibilityStore) CountWorkflowExecutions(
 
 func (s *VisibilityStore) countGroupByExecutions(
 	ctx context.Context,
+	namespaceName namespace.Name,
 	queryParams *esQueryParams,
 	chasmMapper *chasm.VisibilitySearchAttributesMapper,
 ) (*store.InternalCountExecutionsResponse, error) {
 	groupByFields := queryParams.GroupBy
+	maxGroups := s.countGroupByMaxGroups(namespaceName.String())
 
 	// Elasticsearch aggregation is nested. so need to loop backwards to build it.
 	// Example: when grouping by (field1, field2), the object looks like
@@ -504,12 +514,9 @@ func (s *VisibilityStore) countGroupByExecutions(
 	//     }
 	//   }
 	// }
-	termsAgg := elastic.NewTermsAggregation().Field(groupByFields[len(groupByFields)-1])
-	for i := len(groupByFields) - 2; i >= 0; i-- {
-		termsAgg = elastic.NewTermsAggregation().
-			Field(groupByFields[i]).
-			SubAggregation(groupByFields[i+1], termsAgg)
-	}
+	// Each level returns at most maxGroups buckets to bound the size of the response, and the flattened
+	// groups are limited to maxGroups as well. The response is truncated if any level leaves buckets out.
+	termsAgg := newGroupByAggregation(groupByFields, maxGroups, nil)
 	esResponse, err := s.esClient.CountGroupBy(
 		ctx,
 		s.index,
@@ -520,7 +527,235 @@ func (s *VisibilityStore) countGroupByExecutions(
 	if err != nil {
 		return nil, ConvertElasticsearchClientError("CountWorkflowExecutions failed", err)
 	}
-	return s.parseCountGroupByResponse(esResponse, groupByFields, chasmMapper)
+	resp, err := s.parseCountGroupByResponse(esResponse, groupByFields, chasmMapper)
+	if err != nil {
+		return nil, err
+	}
+	var truncated bool
+	resp.Groups, truncated = store.LimitAggregationGroups(resp.Groups, maxGroups)
+	if truncated || resp.Truncated {
+		// The buckets which are left out are not counted, so the total count is read separately.
+		resp.Truncated = true
+		resp.Count, err = s.esClient.Count(ctx, s.index, queryParams.Query)
+		if err != nil {
+			return nil, ConvertElasticsearchClientError("CountWorkflowExecutions failed", err)
+		}
+	}
+	return resp, nil
+}
+
+// newGroupByAggregation returns the nested terms aggregations of the GROUP BY fields, where each level returns at
+// most maxGroups buckets, or uncappedGroupByTermsSize buckets if maxGroups is not positive. The metric aggregations
+// are computed in the innermost buckets.
+func newGroupByAggregation(
+	groupByFields []string,
+	maxGroups int,
+	metricAggs map[string]elastic.Aggregation,
+) *elastic.TermsAggregation {
+	size := maxGroups
+	if size <= 0 {
+		size = uncappedGroupByTermsSize
+	}
+	newTermsAgg := func(field string) *elastic.TermsAggregation {
+		return elastic.NewTermsAggregation().Field(field).Size(size)
+	}
+	termsAgg := newTermsAgg(groupByFields[len(groupByFields)-1])
+	for name, metricAgg := range metricAggs {
+		termsAgg = termsAgg.SubAggregation(name, metricAgg)
+	}
+	for i := len(groupByFields) - 2; i >= 0; i-- {
+		termsAgg = newTermsAgg(groupByFields[i]).
+			SubAggregation(groupByFields[i+1], termsAgg)
+	}
+	return termsAgg
+}
+
+func (s *VisibilityStore) AggregateWorkflowExecutions(
+	ctx context.Context,
+	request *manager.AggregateWorkflowExecutionsRequest,
+) (*store.InternalAggregateExecutionsResponse, error) {
+	queryParams, err := s.convertQuery(request.Namespace, request.NamespaceID, request.Query, nil, chasm.UnspecifiedArchetypeID)
+	if err != nil {
+		return nil, err
+	}
+	aggregations, err := s.convertAggregations(request.Namespace, request.Aggregations)
+	if err != nil {
+		return nil, err
+	}
+
+	metricAggs := make(map[string]elastic.Aggregation, len(aggregations))
+	for i, agg := range aggregations {
+		metricAggs[aggregationName(i)] = newMetricAggregation(agg)
+	}
+	maxGroups := s.countGroupByMaxGroups(request.Namespace.String())
+	aggs := metricAggs
+	if len(queryParams.GroupBy) > 0 {
+		aggs = map[string]elastic.Aggregation{
+			queryParams.GroupBy[0]: newGroupByAggregation(queryParams.GroupBy, maxGroups, metricAggs),
+		}
+	}
+
+	esResponse, err := s.esClient.Aggregate(ctx, s.index, queryParams.Query, aggs)
+	if err != nil {
+		return nil, ConvertElasticsearchClientError("AggregateWorkflowExecutions failed", err)
+	}
+	resp, err := s.parseAggregateResponse(esResponse, queryParams.GroupBy, aggregations)
+	if err != nil {
+		return nil, err
+	}
+	var truncated bool
+	resp.Groups, truncated = store.LimitAggregationGroups(resp.Groups, maxGroups)
+	if truncated || resp.Truncated {
+		// The total number of matching documents is tracked exactly, including the buckets which are left out.
+		resp.Truncated = true
+		resp.Count = esResponse.TotalHits()
+	}
+	return resp, nil
+}
+
+func (s *VisibilityStore) convertAggregations(
+	namespaceName namespace.Name,
+	aggregationStrings []string,
+) (_ []*query.Aggregation, err error) {
+	defer func() {
+		var converterErr *query.ConverterError
+		if errors.As(err, &converterErr) {
+			err = converterErr.ToInvalidArgument()
+		}
+	}()
+
+	saTypeMap, err := s.searchAttributesProvider.GetSearchAttributes(s.index, false)
+	if err != nil {
+		return nil, serviceerror.NewUnavailablef("unable to read search attribute types: %v", err)
+	}
+
+	saMapper, err := s.searchAttributesMapperProvider.GetMapper(namespaceName)
+	if err != nil {
+		return nil, err
+	}
+
+	return query.NewQueryConverter(&queryConverter{}, namespaceName, saTypeMap, saMapper).
+		ConvertAggregations(aggregationStrings)
+}
+
+func aggregationName(i int) string {
+	return fmt.Sprintf("aggregation_%d", i)
+}
+
+func newMetricAggregation(agg *query.Aggregation) elastic.Aggregation {
+	field := agg.Column.FieldName
+	switch agg.Function {
+	case query.AggregationMin:
+		return elastic.NewMinAggregation().Field(field)
+	case query.AggregationMax:
+		return elastic.NewMaxAggregation().Field(field)
+	case query.AggregationAvg:
+		return elastic.NewAvgAggregation().Field(field)
+	case query.AggregationSum:
+		return elastic.NewSumAggregation().Field(field)
+	default:
+		return elastic.NewPercentilesAggregation().Field(field).Percentiles(agg.Percentile)
+	}
+}
+
+func (s *VisibilityStore) ExplainQuery(
+	ctx context.Context,
+	request *manager.ExplainQueryRequest,
+) (*manager.ExplainQueryResponse, error) {
+	saTypeMap, err := s.searchAttributesProvider.GetSearchAttributes(s.index, false)
+	if err != nil {
+		return nil, serviceerror.NewUnavailablef("unable to read search attribute types: %v", err)
+	}
+	saMapper, err := s.searchAttributesMapperProvider.GetMapper(request.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	explanation, err := query.ExplainQuery(request.Query, request.Namespace, saTypeMap, saMapper)
+	if err != nil {
+		var converterErr *query.ConverterError
+		if errors.As(err, &converterErr) {
+			return nil, converterErr.ToInvalidArgument()
+		}
+		return nil, err
+	}
+
+	searchSource, groupByFields, err := s.buildExplainSearchSource(request)
+	if err != nil {
+		return nil, err
+	}
+	source, err := searchSource.Source()
+	if err != nil {
+		return nil, serviceerror.NewInternalf("unable to build search request: %v", err)
+	}
+	storeQuery, err := json.MarshalIndent(source, "", "  ")
+	if err != nil {
+		return nil, serviceerror.NewInternalf("unable to marshal search request: %v", err)
+	}
+	response := query.NewExplainQueryResponse(s.GetName(), explanation)
+	response.StoreQuery = string(storeQuery)
+	if !request.Execute {
+		return response, nil
+	}
+
+	startTime := time.Now()
+	searchResult, err := s.esClient.Profile(ctx, s.index, searchSource)
+	if err != nil {
+		return nil, ConvertElasticsearchClientError("ExplainQuery failed", err)
+	}
+	response.Duration = time.Since(startTime)
+
+	plan, err := json.MarshalIndent(searchResult.Profile, "", "  ")
+	if err != nil {
+		return nil, serviceerror.NewInternalf("unable to marshal search profile: %v", err)
+	}
+	response.Plan = string(plan)
+	if len(groupByFields) > 0 {
+		resp, err := s.parseCountGroupByResponse(searchResult, groupByFields, nil)
+		if err != nil {
+			return nil, err
+		}
+		response.Count = int64(len(resp.Groups))
+	} else if searchResult.Hits != nil {
+		response.Count = int64(len(searchResult.Hits.Hits))
+	}
+	return response, nil
+}
+
+// buildExplainSearchSource builds the search request which counts the executions if the query has a GROUP BY
+// clause, and which lists the first page of executions otherwise. It returns the GROUP BY fields of the query.
+func (s *VisibilityStore) buildExplainSearchSource(
+	request *manager.ExplainQueryRequest,
+) (*elastic.SearchSource, []string, error) {
+	var queryParams *esQueryParams
+	var err error
+	if s.enableUnifiedQueryConverter() {
+		queryParams, err = s.convertQuery(request.Namespace, request.NamespaceID, request.Query, nil, chasm.UnspecifiedArchetypeID)
+		if err != nil {
+			return nil, nil, err
+		}
+	} else {
+		queryParamsLegacy, err := s.convertQueryLegacy(request.Namespace, request.NamespaceID, request.Query, nil, chasm.UnspecifiedArchetypeID)
+		if err != nil {
+			return nil, nil, err
+		}
+		queryParams = (*esQueryParams)(queryParamsLegacy)
+	}
+
+	if len(queryParams.GroupBy) > 0 {
+		termsAgg := newGroupByAggregation(queryParams.GroupBy, s.countGroupByMaxGroups(request.Namespace.String()), nil)
+		return client.NewCountGroupBySearchSource(queryParams.Query, queryParams.GroupBy[0], termsAgg), queryParams.GroupBy, nil
+	}
+
+	p, err := s.BuildSearchParametersV2(&manager.ListWorkflowExecutionsRequestV2{
+		NamespaceID: request.NamespaceID,
+		Namespace:   request.Namespace,
+		Query:       request.Query,
+		PageSize:    request.PageSize,
+	}, s.GetListFieldSorter)
+	if err != nil {
+		return nil, nil, err
+	}
+	return client.NewSearchSource(p), nil, nil
 }
 
 func (s *VisibilityStore) GetWorkflowExecution(
@@ -1183,7 +1418,11 @@ func (s *VisibilityStore) parseCountGroupByResponse(
 
 		index := len(bucketValues)
 		fieldName := groupByFields[index]
-		buckets := aggs[fieldName].(map[string]any)["buckets"].([]any)
+		termsAgg := aggs[fieldName].(map[string]any)
+		if otherDocCount, err := parseJsonNumber(termsAgg["sum_other_doc_count"]); err == nil && otherDocCount > 0 {
+			response.Truncated = true
+		}
+		buckets := termsAgg["buckets"].([]any)
 		for i := range buckets {
 			bucket := buckets[i].(map[string]any)
 			value, err := finishParseJSONValue(bucket["key"], groupByTypes[index])
@@ -1214,6 +1453,148 @@ func (s *VisibilityStore) parseCountGroupByResponse(
 	return response, nil
 }
 
+// parseAggregateResponse parses the aggregations of AggregateWorkflowExecutions. Without GROUP BY fields, the
+// response has a single group of all the matching documents.
+func (s *VisibilityStore) parseAggregateResponse(
+	searchResult *elastic.SearchResult,
+	groupByFields []string,
+	aggregations []*query.Aggregation,
+) (*store.InternalAggregateExecutionsResponse, error) {
+	response := &store.InternalAggregateExecutionsResponse{}
+	saTypeMap, err := s.searchAttributesProvider.GetSearchAttributes(s.index, false)
+	if err != nil {
+		return nil, serviceerror.NewUnavailablef(
+			"unable to read search attribute types: %v", err,
+		)
+	}
+	groupByTypes := make([]enumspb.IndexedValueType, len(groupByFields))
+	for i, saName := range groupByFields {
+		groupByTypes[i], err = saTypeMap.GetType(saName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	parseGroup := func(aggs map[string]any, groupValues []*commonpb.Payload, count int64) error {
+		values := make([]*commonpb.Payload, len(aggregations))
+		for i, agg := range aggregations {
+			value, err := parseMetricAggregationValue(aggs[aggregationName(i)], agg)
+			if err != nil {
+				return fmt.Errorf("unable to parse value of %s: %w", agg, err)
+			}
+			values[i], err = agg.EncodeValue(value)
+			if err != nil {
+				return err
+			}
+		}
+		response.Groups = append(response.Groups, store.InternalAggregationGroup{
+			GroupValues: groupValues,
+			Count:       count,
+			Values:      values,
+		})
+		response.Count += count
+		return nil
+	}
+
+	var parseInternal func(map[string]any, []*commonpb.Payload) error
+	parseInternal = func(aggs map[string]any, bucketValues []*commonpb.Payload) error {
+		if len(bucketValues) == len(groupByFields) {
+			numberVal, isNumber := aggs["doc_count"].(json.Number)
+			if !isNumber {
+				return fmt.Errorf("unable to parse 'doc_count' field: %w: expected json.Number, got %T", errUnexpectedJSONFieldType, aggs["doc_count"])
+			}
+			cnt, err := numberVal.Int64()
+			if err != nil {
+				return fmt.Errorf("unable to parse 'doc_count' field: %w", err)
+			}
+			groupValues := make([]*commonpb.Payload, len(groupByFields))
+			copy(groupValues, bucketValues)
+			return parseGroup(aggs, groupValues, cnt)
+		}
+
+		index := len(bucketValues)
+		fieldName := groupByFields[index]
+		termsAgg, _ := aggs[fieldName].(map[string]any)
+		if otherDocCount, ok := termsAgg["sum_other_doc_count"].(json.Number); ok {
+			if cnt, err := otherDocCount.Int64(); err == nil && cnt > 0 {
+				response.Truncated = true
+			}
+		}
+		buckets, _ := termsAgg["buckets"].([]any)
+		for i := range buckets {
+			bucket, _ := buckets[i].(map[string]any)
+			value, err := finishParseJSONValue(bucket["key"], groupByTypes[index])
+			if err != nil {
+				return fmt.Errorf("unable to parse value %v: %w", bucket["key"], err)
+			}
+			payload, err := searchattribute.EncodeValue(value, groupByTypes[index])
+			if err != nil {
+				return fmt.Errorf("unable to encode value %v: %w", value, err)
+			}
+			if err := parseInternal(bucket, append(bucketValues, payload)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	aggsJson := make(map[string]any, len(searchResult.Aggregations))
+	for name, raw := range searchResult.Aggregations {
+		var aggJson map[string]any
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		dec.UseNumber()
+		if err := dec.Decode(&aggJson); err != nil {
+			return nil, serviceerror.NewInternalf("unable to unmarshal json response: %v", err)
+		}
+		aggsJson[name] = aggJson
+	}
+	if len(groupByFields) == 0 {
+		err = parseGroup(aggsJson, nil, searchResult.TotalHits())
+	} else {
+		err = parseInternal(aggsJson, nil)
+	}
+	if err != nil {
+		return nil, serviceerror.NewInternalf("unable to parse aggregations: %v", err)
+	}
+	return response, nil
+}
+
+// parseMetricAggregationValue returns the value of a metric aggregation, converting the Unix milliseconds of
+// Datetime search attributes to nanoseconds. The value is nil if there are no values to aggregate.
+func parseMetricAggregationValue(aggJson any, agg *query.Aggregation) (*float64, error) {
+	aggMap, ok := aggJson.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: expected object, got %T", errUnexpectedJSONFieldType, aggJson)
+	}
+	value := aggMap["value"]
+	if agg.Function == query.AggregationPercentile {
+		// Percentiles are keyed by their string representation, like "95.0".
+		values, _ := aggMap["values"].(map[string]any)
+		value = nil
+		for key, v := range values {
+			if p, err := strconv.ParseFloat(key, 64); err == nil && p == agg.Percentile {
+				value = v
+				break
+			}
+		}
+	}
+	if value == nil {
+		return nil, nil
+	}
+	numberVal, isNumber := value.(json.Number)
+	if !isNumber {
+		return nil, fmt.Errorf("%w: expected json.Number, got %T", errUnexpectedJSONFieldType, value)
+	}
+	v, err := numberVal.Float64()
+	if err != nil {
+		return nil, err
+	}
+	if agg.IsDatetime() {
+		v *= float64(time.Millisecond)
+	}
+	return &v, nil
+}
+
 // finishParseJSONValue finishes JSON parsing after json.Decode.
 // json.Decode returns:
 //
@@ -66,6 +66,7 @@ var (
 	testWorkflowID   = "test-wid"
 	testRunID        = "test-rid"
 	testStatus       = enumspb.WORKFLOW_EXECUTION_STATUS_FAILED
+	testMaxGroups    = 1000
 
 	testSearchResult = &elastic.SearchResult{
 		Hits: &elastic.SearchHits{},
@@ -130,6 +131,7 @@ func (s *ESVisibilitySuite) SetupTest() {
 	visibilityDisableOrderByClause := dynamicconfig.GetBoolPropertyFnFilteredByNamespace(false)
 	visibilityEnableManualPagination := dynamicconfig.GetBoolPropertyFnFilteredByNamespace(true)
 	visibilityEnableUnifiedQueryConverter := dynamicconfig.GetBoolPropertyFn(true)
+	visibilityCountGroupByMaxGroups := dynamicconfig.GetIntPropertyFnFilteredByNamespace(testMaxGroups)
 
 	s.controller = gomock.NewController(s.T())
 	s.mockMetricsHandler = metrics.NewMockHandler(s.controller)
@@ -171,6 +173,7 @@ func (s *ESVisibilitySuite) SetupTest() {
 		disableOrderByClause:           visibilityDisableOrderByClause,
 		enableManualPagination:         visibilityEnableManualPagination,
 		enableUnifiedQueryConverter:    visibilityEnableUnifiedQueryConverter,
+		countGroupByMaxGroups:          visibilityCountGroupByMaxGroups,
 		metricsHandler:                 s.mockMetricsHandler,
 		logger:                         log.NewNoopLogger(),
 	}
@@ -1047,7 +1050,7 @@ func (s *ESVisibilitySuite) TestCountWorkflowExecutions_GroupBy() {
 					namespaceDivisionIsNull,
 				),
 			sadefs.ExecutionStatus,
-			elastic.NewTermsAggregation().Field(sadefs.ExecutionStatus),
+			elastic.NewTermsAggregation().Field(sadefs.ExecutionStatus).Size(testMaxGroups),
 		).
 		Return(
 			&elastic.SearchResult{
@@ -1080,19 +1083,147 @@ func (s *ESVisibilitySuite) TestCountWorkflowExecutions_GroupBy() {
 	}
 	s.True(temporalproto.DeepEqual(expectedResp, resp))
 
-	// test only allowed to group by a single field
-	request.Query = "GROUP BY ExecutionStatus, WorkflowType"
+	// test not allowed to group by fields which are unique per execution
+	request.Query = "GROUP BY ExecutionStatus, WorkflowId"
 	resp, err = s.visibilityStore.CountWorkflowExecutions(context.Background(), request)
-	s.ErrorContains(err, "'GROUP BY' clause supports only a single field")
+	s.ErrorContains(err, "'GROUP BY' clause is only supported for ExecutionStatus and Keyword search attributes")
 	s.Nil(resp)
 
-	// test only allowed to group by ExecutionStatus and LowCardinalityKeyword fields
-	request.Query = "GROUP BY WorkflowType"
+	// test only allowed to group by Keyword fields
+	request.Query = "GROUP BY StartTime"
 	resp, err = s.visibilityStore.CountWorkflowExecutions(context.Background(), request)
-	s.ErrorContains(err, "'GROUP BY' clause is only supported for ExecutionStatus")
+	s.ErrorContains(err, "'GROUP BY' clause is only supported for ExecutionStatus and Keyword search attributes")
 	s.Nil(resp)
 }
 
+func (s *ESVisibilitySuite) TestCountWorkflowExecutions_GroupByMaxGroups() {
+	s.visibilityStore.countGroupByMaxGroups = dynamicconfig.GetIntPropertyFnFilteredByNamespace(2)
+	request := &manager.CountWorkflowExecutionsRequest{
+		NamespaceID: testNamespaceID,
+		Namespace:   testNamespace,
+		Query:       "GROUP BY ExecutionStatus, WorkflowType",
+	}
+	s.mockESClient.EXPECT().
+		CountGroupBy(
+			gomock.Any(),
+			testIndex,
+			elastic.NewBoolQuery().
+				Filter(
+					elastic.NewTermQuery(sadefs.NamespaceID, testNamespaceID.String()),
+					namespaceDivisionIsNull,
+				),
+			sadefs.ExecutionStatus,
+			elastic.NewTermsAggregation().Field(sadefs.ExecutionStatus).Size(2).SubAggregation(
+				sadefs.WorkflowType,
+				elastic.NewTermsAggregation().Field(sadefs.WorkflowType).Size(2),
+			),
+		).
+		Return(
+			&elastic.SearchResult{
+				Aggregations: map[string]json.RawMessage{
+					sadefs.ExecutionStatus: json.RawMessage(
+						`{"buckets":[` +
+							`{"key":"Completed","doc_count":100,"WorkflowType":{"buckets":[{"key":"wf-type-1","doc_count":60},{"key":"wf-type-2","doc_count":40}]}},` +
+							`{"key":"Running","doc_count":70,"WorkflowType":{"buckets":[{"key":"wf-type-1","doc_count":70}]}}` +
+							`]}`,
+					),
+				},
+			},
+			nil,
+		)
+	s.mockESClient.EXPECT().
+		Count(
+			gomock.Any(),
+			testIndex,
+			elastic.NewBoolQuery().
+				Filter(
+					elastic.NewTermQuery(sadefs.NamespaceID, testNamespaceID.String()),
+					namespaceDivisionIsNull,
+				),
+		).
+		Return(int64(170), nil)
+	resp, err := s.visibilityStore.CountWorkflowExecutions(context.Background(), request)
+	s.NoError(err)
+	expectedResp := &store.InternalCountExecutionsResponse{
+		Count: 170,
+		Groups: []store.InternalAggregationGroup{
+			{
+				GroupValues: []*commonpb.Payload{
+					mustEncodeValue(enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING, enumspb.INDEXED_VALUE_TYPE_KEYWORD),
+					mustEncodeValue("wf-type-1", enumspb.INDEXED_VALUE_TYPE_KEYWORD),
+				},
+				Count: 70,
+			},
+			{
+				GroupValues: []*commonpb.Payload{
+					mustEncodeValue(enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED, enumspb.INDEXED_VALUE_TYPE_KEYWORD),
+					mustEncodeValue("wf-type-1", enumspb.INDEXED_VALUE_TYPE_KEYWORD),
+				},
+				Count: 60,
+			},
+		},
+		Truncated: true,
+	}
+	s.True(temporalproto.DeepEqual(expectedResp, resp))
+}
+
+func (s *ESVisibilitySuite) TestCountWorkflowExecutions_GroupByUncapped() {
+	s.visibilityStore.countGroupByMaxGroups = dynamicconfig.GetIntPropertyFnFilteredByNamespace(0)
+	request := &manager.CountWorkflowExecutionsRequest{
+		NamespaceID: testNamespaceID,
+		Namespace:   testNamespace,
+		Query:       "GROUP BY ExecutionStatus",
+	}
+	s.mockESClient.EXPECT().
+		CountGroupBy(
+			gomock.Any(),
+			testIndex,
+			elastic.NewBoolQuery().
+				Filter(
+					elastic.NewTermQuery(sadefs.NamespaceID, testNamespaceID.String()),
+					namespaceDivisionIsNull,
+				),
+			sadefs.ExecutionStatus,
+			elastic.NewTermsAggregation().Field(sadefs.ExecutionStatus).Size(uncappedGroupByTermsSize),
+		).
+		Return(
+			&elastic.SearchResult{
+				Aggregations: map[string]json.RawMessage{
+					sadefs.ExecutionStatus: json.RawMessage(
+						`{"sum_other_doc_count":5,"buckets":[{"key":"Completed","doc_count":100}]}`,
+					),
+				},
+			},
+			nil,
+		)
+	s.mockESClient.EXPECT().
+		Count(
+			gomock.Any(),
+			testIndex,
+			elastic.NewBoolQuery().
+				Filter(
+					elastic.NewTermQuery(sadefs.NamespaceID, testNamespaceID.String()),
+					namespaceDivisionIsNull,
+				),
+		).
+		Return(int64(105), nil)
+	resp, err := s.visibilityStore.CountWorkflowExecutions(context.Background(), request)
+	s.NoError(err)
+	expectedResp := &store.InternalCountExecutionsResponse{
+		Count: 105,
+		Groups: []store.InternalAggregationGroup{
+			{
+				GroupValues: []*commonpb.Payload{
+					mustEncodeValue(enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED, enumspb.INDEXED_VALUE_TYPE_KEYWORD),
+				},
+				Count: 100,
+			},
+		},
+		Truncated: true,
+	}
+	s.True(temporalproto.DeepEqual(expectedResp, resp))
+}
+
 func (s *ESVisibilitySuite) TestCountGroupByWorkflowExecutions() {
 	testCases := []struct {
 		name         string
@@ -1106,7 +1237,7 @@ func (s *ESVisibilitySuite) TestCountGroupByWorkflowExecutions() {
 			name:    "group by one field",
 			groupBy: []string{sadefs.ExecutionStatus},
 			aggName: sadefs.ExecutionStatus,
-			agg:     elastic.NewTermsAggregation().Field(sadefs.ExecutionStatus),
+			agg:     elastic.NewTermsAggregation().Field(sadefs.ExecutionStatus).Size(testMaxGroups),
 			mockResponse: &elastic.SearchResult{
 				Aggregations: map[string]json.RawMessage{
 					sadefs.ExecutionStatus: json.RawMessage(
@@ -1148,9 +1279,9 @@ func (s *ESVisibilitySuite) TestCountGroupByWorkflowExecutions() {
 			name:    "group by two fields",
 			groupBy: []string{sadefs.ExecutionStatus, sadefs.WorkflowType},
 			aggName: sadefs.ExecutionStatus,
-			agg: elastic.NewTermsAggregation().Field(sadefs.ExecutionStatus).SubAggregation(
+			agg: elastic.NewTermsAggregation().Field(sadefs.ExecutionStatus).Size(testMaxGroups).SubAggregation(
 				sadefs.WorkflowType,
-				elastic.NewTermsAggregation().Field(sadefs.WorkflowType),
+				elastic.NewTermsAggregation().Field(sadefs.WorkflowType).Size(testMaxGroups),
 			),
 			mockResponse: &elastic.SearchResult{
 				Aggregations: map[string]json.RawMessage{
@@ -1237,11 +1368,11 @@ func (s *ESVisibilitySuite) TestCountGroupByWorkflowExecutions() {
 				sadefs.WorkflowID,
 			},
 			aggName: sadefs.ExecutionStatus,
-			agg: elastic.NewTermsAggregation().Field(sadefs.ExecutionStatus).SubAggregation(
+			agg: elastic.NewTermsAggregation().Field(sadefs.ExecutionStatus).Size(testMaxGroups).SubAggregation(
 				sadefs.WorkflowType,
-				elastic.NewTermsAggregation().Field(sadefs.WorkflowType).SubAggregation(
+				elastic.NewTermsAggregation().Field(sadefs.WorkflowType).Size(testMaxGroups).SubAggregation(
 					sadefs.WorkflowID,
-					elastic.NewTermsAggregation().Field(sadefs.WorkflowID),
+					elastic.NewTermsAggregation().Field(sadefs.WorkflowID).Size(testMaxGroups),
 				),
 			),
 			mockResponse: &elastic.SearchResult{
@@ -1393,7 +1524,7 @@ func (s *ESVisibilitySuite) TestCountGroupByWorkflowExecutions() {
 					tc.agg,
 				).
 				Return(tc.mockResponse, nil)
-			resp, err := s.visibilityStore.countGroupByExecutions(context.Background(), searchParams, nil)
+			resp, err := s.visibilityStore.countGroupByExecutions(context.Background(), testNamespace, searchParams, nil)
 			s.NoError(err)
 			s.True(temporalproto.DeepEqual(tc.response, resp))
 		})
@@ -2339,3 +2470,123 @@ func (s *ESVisibilitySuite) TestValuesInterceptor_ChasmMapper() {
 	var converterErr *query.ConverterError
 	s.ErrorAs(err, &converterErr)
 }
+
+func (s *ESVisibilitySuite) TestAggregateWorkflowExecutions() {
+	request := &manager.AggregateWorkflowExecutionsRequest{
+		NamespaceID:  testNamespaceID,
+		Namespace:    testNamespace,
+		Query:        "GROUP BY ExecutionStatus",
+		Aggregations: []string{"avg(ExecutionDuration)", "percentile(ExecutionDuration, 95)", "max(StartTime)"},
+	}
+	s.mockESClient.EXPECT().
+		Aggregate(
+			gomock.Any(),
+			testIndex,
+			elastic.NewBoolQuery().
+				Filter(
+					elastic.NewTermQuery(sadefs.NamespaceID, testNamespaceID.String()),
+					namespaceDivisionIsNull,
+				),
+			map[string]elastic.Aggregation{
+				sadefs.ExecutionStatus: elastic.NewTermsAggregation().Field(sadefs.ExecutionStatus).Size(testMaxGroups).
+					SubAggregation("aggregation_0", elastic.NewAvgAggregation().Field(sadefs.ExecutionDuration)).
+					SubAggregation("aggregation_1", elastic.NewPercentilesAggregation().Field(sadefs.ExecutionDuration).Percentiles(95)).
+					SubAggregation("aggregation_2", elastic.NewMaxAggregation().Field(sadefs.StartTime)),
+			},
+		).
+		Return(
+			&elastic.SearchResult{
+				Aggregations: map[string]json.RawMessage{
+					sadefs.ExecutionStatus: json.RawMessage(
+						`{"buckets":[` +
+							`{"key":"Completed","doc_count":100,"aggregation_0":{"value":1.5},"aggregation_1":{"values":{"95.0":2.5}},` +
+							`"aggregation_2":{"value":1767323045000,"value_as_string":"2026-01-02T03:04:05.000Z"}},` +
+							`{"key":"Running","doc_count":10,"aggregation_0":{"value":null},"aggregation_1":{"values":{"95.0":null}},` +
+							`"aggregation_2":{"value":null}}` +
+							`]}`,
+					),
+				},
+			},
+			nil,
+		)
+	resp, err := s.visibilityStore.AggregateWorkflowExecutions(context.Background(), request)
+	s.NoError(err)
+	nullValue := mustEncodeValue(nil, enumspb.INDEXED_VALUE_TYPE_DOUBLE)
+	expectedResp := &store.InternalAggregateExecutionsResponse{
+		Count: 110,
+		Groups: []store.InternalAggregationGroup{
+			{
+				GroupValues: []*commonpb.Payload{
+					mustEncodeValue(enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED, enumspb.INDEXED_VALUE_TYPE_KEYWORD),
+				},
+				Count: 100,
+				Values: []*commonpb.Payload{
+					mustEncodeValue(1.5, enumspb.INDEXED_VALUE_TYPE_DOUBLE),
+					mustEncodeValue(2.5, enumspb.INDEXED_VALUE_TYPE_DOUBLE),
+					mustEncodeValue(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), enumspb.INDEXED_VALUE_TYPE_DATETIME),
+				},
+			},
+			{
+				GroupValues: []*commonpb.Payload{
+					mustEncodeValue(enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING, enumspb.INDEXED_VALUE_TYPE_KEYWORD),
+				},
+				Count:  10,
+				Values: []*commonpb.Payload{nullValue, nullValue, nullValue},
+			},
+		},
+	}
+	s.True(temporalproto.DeepEqual(expectedResp, resp))
+}
+
+func (s *ESVisibilitySuite) TestAggregateWorkflowExecutions_NoGroupBy() {
+	request := &manager.AggregateWorkflowExecutionsRequest{
+		NamespaceID:  testNamespaceID,
+		Namespace:    testNamespace,
+		Aggregations: []string{"sum(ExecutionDuration)"},
+	}
+	s.mockESClient.EXPECT().
+		Aggregate(
+			gomock.Any(),
+			testIndex,
+			elastic.NewBoolQuery().
+				Filter(
+					elastic.NewTermQuery(sadefs.NamespaceID, testNamespaceID.String()),
+					namespaceDivisionIsNull,
+				),
+			map[string]elastic.Aggregation{
+				"aggregation_0": elastic.NewSumAggregation().Field(sadefs.ExecutionDuration),
+			},
+		).
+		Return(
+			&elastic.SearchResult{
+				Hits: &elastic.SearchHits{TotalHits: &elastic.TotalHits{Value: 3}},
+				Aggregations: map[string]json.RawMessage{
+					"aggregation_0": json.RawMessage(`{"value":42}`),
+				},
+			},
+			nil,
+		)
+	resp, err := s.visibilityStore.AggregateWorkflowExecutions(context.Background(), request)
+	s.NoError(err)
+	expectedResp := &store.InternalAggregateExecutionsResponse{
+		Count: 3,
+		Groups: []store.InternalAggregationGroup{
+			{
+				Count:  3,
+				Values: []*commonpb.Payload{mustEncodeValue(42.0, enumspb.INDEXED_VALUE_TYPE_DOUBLE)},
+			},
+		},
+	}
+	s.True(temporalproto.DeepEqual(expectedResp, resp))
+}
+
+func (s *ESVisibilitySuite) TestAggregateWorkflowExecutions_InvalidAggregation() {
+	_, err := s.visibilityStore.AggregateWorkflowExecutions(context.Background(), &manager.AggregateWorkflowExecutionsRequest{
+		NamespaceID:  testNamespaceID,
+		Namespace:    testNamespace,
+		Aggregations: []string{"sum(StartTime)"},
+	})
+	var invalidArgument *serviceerror.InvalidArgument
+	s.ErrorAs(err, &invalidArgument)
+	s.ErrorContains(err, "aggregate function 'sum' not supported for Datetime type search attribute 'StartTime'")
+}
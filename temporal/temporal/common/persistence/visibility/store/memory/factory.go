@@ -0,0 +1,59 @@
+package memory
+
+import (
+	"sync"
+
+	"go.temporal.io/server/chasm"
+	"go.temporal.io/server/common/config"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/common/namespace"
+	"go.temporal.io/server/common/persistence/visibility"
+	"go.temporal.io/server/common/persistence/visibility/store"
+	"go.temporal.io/server/common/resolver"
+	"go.temporal.io/server/common/searchattribute"
+)
+
+type (
+	// VisibilityStoreFactory is a visibility.VisibilityStoreFactory which creates in-memory visibility stores. The
+	// records are kept by the factory, so that all the stores created with the same index name share them, e.g.
+	// the stores of the services of a server running in a single process.
+	VisibilityStoreFactory struct {
+		mu      sync.Mutex
+		indexes map[string]*index
+	}
+)
+
+var _ visibility.VisibilityStoreFactory = (*VisibilityStoreFactory)(nil)
+
+// NewVisibilityStoreFactory returns a new VisibilityStoreFactory
+func NewVisibilityStoreFactory() *VisibilityStoreFactory {
+	return &VisibilityStoreFactory{
+		indexes: make(map[string]*index),
+	}
+}
+
+// NewVisibilityStore returns an in-memory visibility store of the index of the config.
+func (f *VisibilityStoreFactory) NewVisibilityStore(
+	cfg config.CustomDatastoreConfig,
+	saProvider searchattribute.Provider,
+	saMapperProvider searchattribute.MapperProvider,
+	_ namespace.Registry,
+	chasmRegistry *chasm.Registry,
+	_ resolver.ServiceResolver,
+	logger log.Logger,
+	_ metrics.Handler,
+) (store.VisibilityStore, error) {
+	return newVisibilityStore(cfg.IndexName, f.index(cfg.IndexName), saProvider, saMapperProvider, chasmRegistry, logger), nil
+}
+
+func (f *VisibilityStoreFactory) index(name string) *index {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	idx, ok := f.indexes[name]
+	if !ok {
+		idx = newIndex()
+		f.indexes[name] = idx
+	}
+	return idx
+}
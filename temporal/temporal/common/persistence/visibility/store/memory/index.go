@@ -0,0 +1,129 @@
+package memory
+
+import (
+	"maps"
+	"sync"
+
+	"go.temporal.io/server/common/namespace"
+	"go.temporal.io/server/common/persistence/visibility/store"
+	"go.temporal.io/server/common/persistence/visibility/store/query"
+	"go.temporal.io/server/common/searchattribute/sadefs"
+)
+
+type (
+	// index holds the visibility records of the executions by namespace and run ID.
+	index struct {
+		mu         sync.RWMutex
+		namespaces map[namespace.ID]map[string]*record
+	}
+
+	// record is the visibility record of an execution. Records are immutable, they are replaced on update.
+	record struct {
+		// info is the execution info, without search attributes.
+		info *store.InternalExecutionInfo
+		// searchAttributes are the decoded custom, predefined and CHASM search attributes by field name.
+		searchAttributes map[string]any
+		// values are the values of the search attributes by field name, including the system search attributes,
+		// against which the queries are evaluated. Search attributes without a value are absent.
+		values map[string]any
+		// version is the task ID of the visibility task which wrote the record. Writes of older tasks are ignored.
+		version int64
+	}
+)
+
+func newIndex() *index {
+	return &index{
+		namespaces: make(map[namespace.ID]map[string]*record),
+	}
+}
+
+func newRecord(info *store.InternalExecutionInfo, searchAttributes map[string]any, version int64) *record {
+	values := maps.Clone(searchAttributes)
+	if values == nil {
+		values = make(map[string]any)
+	}
+	values[sadefs.WorkflowID] = info.WorkflowID
+	values[sadefs.RunID] = info.RunID
+	values[sadefs.WorkflowType] = info.TypeName
+	values[sadefs.StartTime] = info.StartTime
+	values[sadefs.ExecutionTime] = info.ExecutionTime
+	values[sadefs.ExecutionStatus] = info.Status.String()
+	values[sadefs.TaskQueue] = info.TaskQueue
+	values[sadefs.RootWorkflowID] = info.RootWorkflowID
+	values[sadefs.RootRunID] = info.RootRunID
+	if info.ParentWorkflowID != "" {
+		values[sadefs.ParentWorkflowID] = info.ParentWorkflowID
+	}
+	if info.ParentRunID != "" {
+		values[sadefs.ParentRunID] = info.ParentRunID
+	}
+	if !info.CloseTime.IsZero() {
+		values[sadefs.CloseTime] = info.CloseTime
+		values[sadefs.ExecutionDuration] = info.ExecutionDuration.Nanoseconds()
+		values[sadefs.HistoryLength] = info.HistoryLength
+		values[sadefs.HistorySizeBytes] = info.HistorySizeBytes
+		values[sadefs.StateTransitionCount] = info.StateTransitionCount
+	}
+	return &record{
+		info:             info,
+		searchAttributes: searchAttributes,
+		values:           values,
+		version:          version,
+	}
+}
+
+// insert adds the record if there is no record of the run yet.
+func (idx *index) insert(namespaceID namespace.ID, r *record) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	records := idx.records(namespaceID)
+	if _, ok := records[r.info.RunID]; !ok {
+		records[r.info.RunID] = r
+	}
+}
+
+// replace adds the record, or replaces the record of the run if it was written by an older task.
+func (idx *index) replace(namespaceID namespace.ID, r *record) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	records := idx.records(namespaceID)
+	if existing, ok := records[r.info.RunID]; !ok || existing.version < r.version {
+		records[r.info.RunID] = r
+	}
+}
+
+func (idx *index) delete(namespaceID namespace.ID, runID string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	delete(idx.namespaces[namespaceID], runID)
+}
+
+func (idx *index) get(namespaceID namespace.ID, runID string) (*record, bool) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	r, ok := idx.namespaces[namespaceID][runID]
+	return r, ok
+}
+
+// filter returns the records of the namespace which match the predicate, in no particular order.
+func (idx *index) filter(namespaceID namespace.ID, p query.ValuesPredicate) []*record {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	var res []*record
+	for _, r := range idx.namespaces[namespaceID] {
+		if p == nil || p(r.values) {
+			res = append(res, r)
+		}
+	}
+	return res
+}
+
+// records returns the records of the namespace, and must be called with the lock held for writing.
+func (idx *index) records(namespaceID namespace.ID) map[string]*record {
+	records, ok := idx.namespaces[namespaceID]
+	if !ok {
+		records = make(map[string]*record)
+		idx.namespaces[namespaceID] = records
+	}
+	return records
+}
@@ -0,0 +1,41 @@
+package memory
+
+import (
+	"go.temporal.io/server/common/config"
+)
+
+// TestCluster is a persistence test cluster whose visibility store is an in-memory visibility store. It has no
+// default store, so it can only be used to test visibility, with a VisibilityStoreFactory.
+type TestCluster struct {
+	indexName string
+}
+
+// NewTestCluster returns a new in-memory visibility test cluster. The records of the visibility stores are
+// identified by indexName.
+func NewTestCluster(indexName string) *TestCluster {
+	return &TestCluster{
+		indexName: indexName,
+	}
+}
+
+// SetupTestDatabase from PersistenceTestCluster interface. The records are created on first use, so there is
+// nothing to do.
+func (s *TestCluster) SetupTestDatabase() {}
+
+// TearDownTestDatabase from PersistenceTestCluster interface
+func (s *TestCluster) TearDownTestDatabase() {}
+
+// Config returns the persistence config of the in-memory visibility store
+func (s *TestCluster) Config() config.Persistence {
+	return config.Persistence{
+		VisibilityStore: "test",
+		DataStores: map[string]config.DataStore{
+			"test": {
+				CustomDataStoreConfig: &config.CustomDatastoreConfig{
+					Name:      PersistenceName,
+					IndexName: s.indexName,
+				},
+			},
+		},
+	}
+}
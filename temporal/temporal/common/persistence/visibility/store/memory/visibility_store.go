@@ -0,0 +1,521 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"time"
+
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/server/chasm"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/log/tag"
+	"go.temporal.io/server/common/namespace"
+	"go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/persistence/visibility/manager"
+	"go.temporal.io/server/common/persistence/visibility/store"
+	"go.temporal.io/server/common/persistence/visibility/store/query"
+	"go.temporal.io/server/common/searchattribute"
+)
+
+const (
+	// PersistenceName is the name of the in-memory visibility store.
+	PersistenceName = "memory"
+)
+
+type (
+	// VisibilityStore is a visibility store which keeps the records in memory, and evaluates the queries against
+	// them. It supports the whole query language, including ORDER BY.
+	VisibilityStore struct {
+		indexName                      string
+		index                          *index
+		searchAttributesProvider       searchattribute.Provider
+		searchAttributesMapperProvider searchattribute.MapperProvider
+		chasmRegistry                  *chasm.Registry
+		logger                         log.Logger
+	}
+
+	listExecutionsRequest struct {
+		NamespaceID   namespace.ID
+		Namespace     namespace.Name
+		Query         string
+		PageSize      int
+		NextPageToken []byte
+		ArchetypeID   chasm.ArchetypeID
+		ChasmMapper   *chasm.VisibilitySearchAttributesMapper
+	}
+)
+
+var _ store.VisibilityStore = (*VisibilityStore)(nil)
+
+func newVisibilityStore(
+	indexName string,
+	index *index,
+	searchAttributesProvider searchattribute.Provider,
+	searchAttributesMapperProvider searchattribute.MapperProvider,
+	chasmRegistry *chasm.Registry,
+	logger log.Logger,
+) *VisibilityStore {
+	return &VisibilityStore{
+		indexName:                      indexName,
+		index:                          index,
+		searchAttributesProvider:       searchAttributesProvider,
+		searchAttributesMapperProvider: searchAttributesMapperProvider,
+		chasmRegistry:                  chasmRegistry,
+		logger:                         logger,
+	}
+}
+
+// Close is a no-op: the records are kept by the factory, so that they outlive the store.
+func (s *VisibilityStore) Close() {}
+
+func (s *VisibilityStore) GetName() string {
+	return PersistenceName
+}
+
+func (s *VisibilityStore) GetIndexName() string {
+	return s.indexName
+}
+
+func (s *VisibilityStore) ValidateCustomSearchAttributes(
+	searchAttributes map[string]any,
+) (map[string]any, error) {
+	return searchAttributes, nil
+}
+
+func (s *VisibilityStore) RecordWorkflowExecutionStarted(
+	_ context.Context,
+	request *store.InternalRecordWorkflowExecutionStartedRequest,
+) error {
+	r, err := s.newRecord(request.InternalVisibilityRequestBase, nil)
+	if err != nil {
+		return err
+	}
+	s.index.insert(namespace.ID(request.NamespaceID), r)
+	return nil
+}
+
+func (s *VisibilityStore) RecordWorkflowExecutionClosed(
+	_ context.Context,
+	request *store.InternalRecordWorkflowExecutionClosedRequest,
+) error {
+	r, err := s.newRecord(request.InternalVisibilityRequestBase, func(info *store.InternalExecutionInfo) {
+		info.CloseTime = request.CloseTime
+		info.HistoryLength = request.HistoryLength
+		info.HistorySizeBytes = request.HistorySizeBytes
+		info.ExecutionDuration = request.ExecutionDuration
+		info.StateTransitionCount = request.StateTransitionCount
+	})
+	if err != nil {
+		return err
+	}
+	s.index.replace(namespace.ID(request.NamespaceID), r)
+	return nil
+}
+
+func (s *VisibilityStore) UpsertWorkflowExecution(
+	_ context.Context,
+	request *store.InternalUpsertWorkflowExecutionRequest,
+) error {
+	r, err := s.newRecord(request.InternalVisibilityRequestBase, nil)
+	if err != nil {
+		return err
+	}
+	s.index.replace(namespace.ID(request.NamespaceID), r)
+	return nil
+}
+
+func (s *VisibilityStore) DeleteWorkflowExecution(
+	_ context.Context,
+	request *manager.VisibilityDeleteWorkflowExecutionRequest,
+) error {
+	s.index.delete(request.NamespaceID, request.RunID)
+	return nil
+}
+
+func (s *VisibilityStore) ListWorkflowExecutions(
+	_ context.Context,
+	request *manager.ListWorkflowExecutionsRequestV2,
+) (*store.InternalListExecutionsResponse, error) {
+	return s.listExecutions(&listExecutionsRequest{
+		NamespaceID:   request.NamespaceID,
+		Namespace:     request.Namespace,
+		Query:         request.Query,
+		PageSize:      request.PageSize,
+		NextPageToken: request.NextPageToken,
+	})
+}
+
+func (s *VisibilityStore) ListChasmExecutions(
+	_ context.Context,
+	request *manager.ListChasmExecutionsRequest,
+) (*store.InternalListExecutionsResponse, error) {
+	mapper, err := s.chasmMapper(request.ArchetypeID)
+	if err != nil {
+		return nil, err
+	}
+	return s.listExecutions(&listExecutionsRequest{
+		NamespaceID:   request.NamespaceID,
+		Namespace:     request.Namespace,
+		Query:         request.Query,
+		PageSize:      request.PageSize,
+		NextPageToken: request.NextPageToken,
+		ArchetypeID:   request.ArchetypeID,
+		ChasmMapper:   mapper,
+	})
+}
+
+func (s *VisibilityStore) CountWorkflowExecutions(
+	_ context.Context,
+	request *manager.CountWorkflowExecutionsRequest,
+) (*store.InternalCountExecutionsResponse, error) {
+	return s.countExecutions(request.NamespaceID, request.Namespace, request.Query, nil, chasm.UnspecifiedArchetypeID)
+}
+
+func (s *VisibilityStore) CountChasmExecutions(
+	_ context.Context,
+	request *manager.CountChasmExecutionsRequest,
+) (*store.InternalCountExecutionsResponse, error) {
+	mapper, err := s.chasmMapper(request.ArchetypeID)
+	if err != nil {
+		return nil, err
+	}
+	return s.countExecutions(request.NamespaceID, request.Namespace, request.Query, mapper, request.ArchetypeID)
+}
+
+func (s *VisibilityStore) AggregateWorkflowExecutions(
+	_ context.Context,
+	request *manager.AggregateWorkflowExecutionsRequest,
+) (*store.InternalAggregateExecutionsResponse, error) {
+	converter, err := s.newQueryConverter(request.Namespace, nil, chasm.UnspecifiedArchetypeID)
+	if err != nil {
+		return nil, err
+	}
+	queryParams, err := converter.Convert(request.Query)
+	if err != nil {
+		return nil, convertQueryError(err)
+	}
+	aggregations, err := converter.ConvertAggregations(request.Aggregations)
+	if err != nil {
+		return nil, convertQueryError(err)
+	}
+
+	aggregator := query.NewAggregator(aggregations, groupByTypes(queryParams.GroupBy))
+	for _, r := range s.index.filter(request.NamespaceID, queryParams.QueryExpr) {
+		values := make([]any, len(aggregations))
+		for i, agg := range aggregations {
+			values[i] = r.values[agg.Column.FieldName]
+		}
+		if err := aggregator.Add(groupValues(r, queryParams.GroupBy), values); err != nil {
+			return nil, serviceerror.NewInternal(err.Error())
+		}
+	}
+	resp, err := aggregator.Response()
+	if err != nil {
+		return nil, serviceerror.NewInternal(err.Error())
+	}
+	return resp, nil
+}
+
+func (s *VisibilityStore) GetWorkflowExecution(
+	_ context.Context,
+	request *manager.GetWorkflowExecutionRequest,
+) (*store.InternalGetWorkflowExecutionResponse, error) {
+	r, ok := s.index.get(request.NamespaceID, request.RunID)
+	if !ok {
+		return nil, serviceerror.NewNotFoundf("workflow execution with run ID %s not found", request.RunID)
+	}
+	info, err := s.recordToInfo(r, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &store.InternalGetWorkflowExecutionResponse{
+		Execution: info,
+	}, nil
+}
+
+// AddSearchAttributes is a no-op: the records can hold the values of any search attribute.
+func (s *VisibilityStore) AddSearchAttributes(
+	_ context.Context,
+	_ *manager.AddSearchAttributesRequest,
+) error {
+	return nil
+}
+
+// ExplainQuery explains a query without store query nor plan, since the store evaluates the queries in memory.
+func (s *VisibilityStore) ExplainQuery(
+	_ context.Context,
+	request *manager.ExplainQueryRequest,
+) (*manager.ExplainQueryResponse, error) {
+	saTypeMap, err := s.searchAttributesProvider.GetSearchAttributes(s.indexName, false)
+	if err != nil {
+		return nil, err
+	}
+	saMapper, err := s.searchAttributesMapperProvider.GetMapper(request.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	explanation, err := query.ExplainQuery(request.Query, request.Namespace, saTypeMap, saMapper)
+	if err != nil {
+		return nil, convertQueryError(err)
+	}
+	response := query.NewExplainQueryResponse(s.GetName(), explanation)
+	if !request.Execute {
+		return response, nil
+	}
+
+	converter, err := s.newQueryConverter(request.Namespace, nil, chasm.UnspecifiedArchetypeID)
+	if err != nil {
+		return nil, err
+	}
+	queryParams, err := converter.Convert(request.Query)
+	if err != nil {
+		return nil, convertQueryError(err)
+	}
+
+	startTime := time.Now()
+	if len(queryParams.GroupBy) > 0 {
+		resp, err := s.countExecutions(request.NamespaceID, request.Namespace, request.Query, nil, chasm.UnspecifiedArchetypeID)
+		if err != nil {
+			return nil, err
+		}
+		response.Count = int64(len(resp.Groups))
+	} else {
+		resp, err := s.listExecutions(&listExecutionsRequest{
+			NamespaceID: request.NamespaceID,
+			Namespace:   request.Namespace,
+			Query:       request.Query,
+			PageSize:    request.PageSize,
+		})
+		if err != nil {
+			return nil, err
+		}
+		response.Count = int64(len(resp.Executions))
+	}
+	response.Duration = time.Since(startTime)
+	return response, nil
+}
+
+func (s *VisibilityStore) listExecutions(
+	request *listExecutionsRequest,
+) (*store.InternalListExecutionsResponse, error) {
+	converter, err := s.newQueryConverter(request.Namespace, request.ChasmMapper, request.ArchetypeID)
+	if err != nil {
+		return nil, err
+	}
+	queryParams, err := converter.Convert(request.Query)
+	if err != nil {
+		return nil, convertQueryError(err)
+	}
+	if len(queryParams.GroupBy) > 0 {
+		return nil, serviceerror.NewInvalidArgument("GROUP BY clause is not supported")
+	}
+
+	records, nextPageToken, err := query.SortPage(
+		s.index.filter(request.NamespaceID, queryParams.QueryExpr),
+		func(r *record) map[string]any { return r.values },
+		query.NewSortFields(queryParams.OrderBy),
+		request.PageSize,
+		request.NextPageToken,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &store.InternalListExecutionsResponse{NextPageToken: nextPageToken}
+	for _, r := range records {
+		info, err := s.recordToInfo(r, request.ChasmMapper)
+		if err != nil {
+			return nil, err
+		}
+		resp.Executions = append(resp.Executions, info)
+	}
+	return resp, nil
+}
+
+func (s *VisibilityStore) countExecutions(
+	namespaceID namespace.ID,
+	namespaceName namespace.Name,
+	queryString string,
+	chasmMapper *chasm.VisibilitySearchAttributesMapper,
+	archetypeID chasm.ArchetypeID,
+) (*store.InternalCountExecutionsResponse, error) {
+	converter, err := s.newQueryConverter(namespaceName, chasmMapper, archetypeID)
+	if err != nil {
+		return nil, err
+	}
+	queryParams, err := converter.Convert(queryString)
+	if err != nil {
+		return nil, convertQueryError(err)
+	}
+
+	records := s.index.filter(namespaceID, queryParams.QueryExpr)
+	if len(queryParams.GroupBy) == 0 {
+		return &store.InternalCountExecutionsResponse{Count: int64(len(records))}, nil
+	}
+
+	aggregator := query.NewAggregator(nil, groupByTypes(queryParams.GroupBy))
+	for _, r := range records {
+		if err := aggregator.Add(groupValues(r, queryParams.GroupBy), nil); err != nil {
+			return nil, serviceerror.NewInternal(err.Error())
+		}
+	}
+	resp, err := aggregator.Response()
+	if err != nil {
+		return nil, serviceerror.NewInternal(err.Error())
+	}
+	return &store.InternalCountExecutionsResponse{
+		Count:  resp.Count,
+		Groups: resp.Groups,
+	}, nil
+}
+
+func (s *VisibilityStore) newQueryConverter(
+	namespaceName namespace.Name,
+	chasmMapper *chasm.VisibilitySearchAttributesMapper,
+	archetypeID chasm.ArchetypeID,
+) (*query.QueryConverter[query.ValuesPredicate], error) {
+	saTypeMap, err := s.searchAttributesProvider.GetSearchAttributes(s.indexName, false)
+	if err != nil {
+		return nil, err
+	}
+	saMapper, err := s.searchAttributesMapperProvider.GetMapper(namespaceName)
+	if err != nil {
+		return nil, err
+	}
+	return query.NewQueryConverter[query.ValuesPredicate](&query.ValuesConverter{}, namespaceName, saTypeMap, saMapper).
+		WithChasmMapper(chasmMapper).
+		WithArchetypeID(archetypeID), nil
+}
+
+func (s *VisibilityStore) chasmMapper(archetypeID chasm.ArchetypeID) (*chasm.VisibilitySearchAttributesMapper, error) {
+	rc, ok := s.chasmRegistry.ComponentByID(archetypeID)
+	if !ok {
+		return nil, serviceerror.NewInvalidArgument(fmt.Sprintf("unknown archetype ID: %d", archetypeID))
+	}
+	return rc.SearchAttributesMapper(), nil
+}
+
+// newRecord returns the record of the request. The setClosed function, if not nil, sets the fields of the closed
+// executions.
+func (s *VisibilityStore) newRecord(
+	request *store.InternalVisibilityRequestBase,
+	setClosed func(info *store.InternalExecutionInfo),
+) (*record, error) {
+	searchAttributes, err := s.decodeSearchAttributes(request.SearchAttributes)
+	if err != nil {
+		return nil, err
+	}
+	info := &store.InternalExecutionInfo{
+		WorkflowID:     request.WorkflowID,
+		RunID:          request.RunID,
+		TypeName:       request.WorkflowTypeName,
+		StartTime:      request.StartTime,
+		ExecutionTime:  request.ExecutionTime,
+		Status:         request.Status,
+		TaskQueue:      request.TaskQueue,
+		RootWorkflowID: request.RootWorkflowID,
+		RootRunID:      request.RootRunID,
+		Memo:           persistence.NewDataBlob(request.Memo.GetData(), request.Memo.GetEncodingType().String()),
+	}
+	if info.ExecutionTime.IsZero() {
+		info.ExecutionTime = info.StartTime
+	}
+	if request.ParentWorkflowID != nil {
+		info.ParentWorkflowID = *request.ParentWorkflowID
+	}
+	if request.ParentRunID != nil {
+		info.ParentRunID = *request.ParentRunID
+	}
+	if setClosed != nil {
+		setClosed(info)
+	}
+	return newRecord(info, searchAttributes, request.TaskID), nil
+}
+
+func (s *VisibilityStore) decodeSearchAttributes(
+	searchAttributes *commonpb.SearchAttributes,
+) (map[string]any, error) {
+	if searchAttributes == nil {
+		return nil, nil
+	}
+	saTypeMap, err := s.searchAttributesProvider.GetSearchAttributes(s.indexName, false)
+	if err != nil {
+		return nil, serviceerror.NewUnavailable(
+			fmt.Sprintf("Unable to read search attributes types: %v", err))
+	}
+	decoded, err := searchattribute.Decode(searchAttributes, &saTypeMap, false)
+	if err != nil {
+		return nil, err
+	}
+	for name := range searchAttributes.GetIndexedFields() {
+		if _, ok := decoded[name]; !ok {
+			s.logger.Warn("Skipping unknown search attribute while generating visibility record", tag.String("search-attribute", name))
+		}
+	}
+	maps.DeleteFunc(decoded, func(_ string, value any) bool {
+		return value == nil
+	})
+	return decoded, nil
+}
+
+func (s *VisibilityStore) recordToInfo(
+	r *record,
+	chasmMapper *chasm.VisibilitySearchAttributesMapper,
+) (*store.InternalExecutionInfo, error) {
+	info := *r.info
+	if len(r.searchAttributes) == 0 {
+		return &info, nil
+	}
+
+	saTypeMap, err := s.searchAttributesProvider.GetSearchAttributes(s.indexName, false)
+	if err != nil {
+		return nil, serviceerror.NewUnavailable(
+			fmt.Sprintf("Unable to read search attributes types: %v", err))
+	}
+	combinedTypeMap := store.CombineTypeMaps(saTypeMap, chasmMapper)
+	registeredSearchAttributes := make(map[string]any, len(r.searchAttributes))
+	for name, value := range r.searchAttributes {
+		if _, err := combinedTypeMap.GetType(name); err != nil {
+			if errors.Is(err, searchattribute.ErrInvalidName) {
+				continue
+			}
+			return nil, err
+		}
+		registeredSearchAttributes[name] = value
+	}
+	info.SearchAttributes, err = searchattribute.Encode(registeredSearchAttributes, &combinedTypeMap)
+	if err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+func groupByTypes(groupBy []*query.SAColumn) []enumspb.IndexedValueType {
+	types := make([]enumspb.IndexedValueType, len(groupBy))
+	for i, col := range groupBy {
+		types[i] = col.ValueType
+	}
+	return types
+}
+
+func groupValues(r *record, groupBy []*query.SAColumn) []any {
+	values := make([]any, len(groupBy))
+	for i, col := range groupBy {
+		values[i] = r.values[col.FieldName]
+	}
+	return values
+}
+
+// convertQueryError converts ConverterError to InvalidArgument and passes through all other errors (which should be
+// only mapper errors).
+func convertQueryError(err error) error {
+	var converterErr *query.ConverterError
+	if errors.As(err, &converterErr) {
+		return converterErr.ToInvalidArgument()
+	}
+	return err
+}
@@ -0,0 +1,208 @@
+package query
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/temporalio/sqlparser"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/server/common/searchattribute"
+)
+
+type (
+	// AggregationFunction is an aggregate function computed over the values of a search attribute.
+	AggregationFunction string
+
+	// Aggregation is an aggregate function over a search attribute, for example avg(ExecutionDuration) or
+	// percentile(ExecutionDuration, 95).
+	Aggregation struct {
+		Function AggregationFunction
+		Column   *SAColumn
+		// Percentile is the argument of the percentile function, between 0 and 100.
+		Percentile float64
+	}
+)
+
+const (
+	AggregationMin        AggregationFunction = "min"
+	AggregationMax        AggregationFunction = "max"
+	AggregationAvg        AggregationFunction = "avg"
+	AggregationSum        AggregationFunction = "sum"
+	AggregationPercentile AggregationFunction = "percentile"
+)
+
+var (
+	supportedAggregationFunctions = []AggregationFunction{
+		AggregationMin,
+		AggregationMax,
+		AggregationAvg,
+		AggregationSum,
+		AggregationPercentile,
+	}
+
+	supportedTypesAggregation = []enumspb.IndexedValueType{
+		enumspb.INDEXED_VALUE_TYPE_INT,
+		enumspb.INDEXED_VALUE_TYPE_DOUBLE,
+		enumspb.INDEXED_VALUE_TYPE_DATETIME,
+	}
+)
+
+// ConvertAggregations converts the aggregate function expressions, which are either
+// <function>(<search attribute>) with function one of min, max, avg and sum, or
+// percentile(<search attribute>, <percentile>). Only Int, Double and Datetime search attributes
+// can be aggregated, and Datetime search attributes can't be summed.
+func (c *QueryConverter[ExprT]) ConvertAggregations(aggregations []string) ([]*Aggregation, error) {
+	if len(aggregations) == 0 {
+		return nil, NewConverterError("%s: at least one aggregation is required", InvalidExpressionErrMessage)
+	}
+	res := make([]*Aggregation, 0, len(aggregations))
+	for _, aggregation := range aggregations {
+		agg, err := c.convertAggregation(aggregation)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, agg)
+	}
+	return res, nil
+}
+
+func (c *QueryConverter[ExprT]) convertAggregation(aggregation string) (*Aggregation, error) {
+	// sqlparser can't parse just an expression but instead accepts only valid SQL statement.
+	stmt, err := sqlparser.Parse("select " + aggregation + " from table1")
+	if err != nil {
+		return nil, NewConverterError("%s: %v", MalformedSqlQueryErrMessage, err)
+	}
+	sel, ok := stmt.(*sqlparser.Select)
+	if !ok || len(sel.SelectExprs) != 1 || sel.Where != nil || sel.GroupBy != nil || sel.OrderBy != nil ||
+		sel.Limit != nil || sel.Having != nil {
+		return nil, NewConverterError("%s: aggregation '%s'", MalformedSqlQueryErrMessage, aggregation)
+	}
+	aliasedExpr, ok := sel.SelectExprs[0].(*sqlparser.AliasedExpr)
+	if !ok || !aliasedExpr.As.IsEmpty() {
+		return nil, NewConverterError("%s: aggregation '%s'", InvalidExpressionErrMessage, aggregation)
+	}
+	funcExpr, ok := aliasedExpr.Expr.(*sqlparser.FuncExpr)
+	if !ok || funcExpr.Distinct || !funcExpr.Qualifier.IsEmpty() {
+		return nil, NewConverterError(
+			"%s: aggregation '%s' must be a function of a search attribute",
+			InvalidExpressionErrMessage,
+			aggregation,
+		)
+	}
+
+	function := AggregationFunction(funcExpr.Name.Lowered())
+	if !slices.Contains(supportedAggregationFunctions, function) {
+		return nil, NewConverterError(
+			"%s: aggregate function '%s', supported functions are %v",
+			NotSupportedErrMessage,
+			funcExpr.Name.String(),
+			supportedAggregationFunctions,
+		)
+	}
+	numArgs := 1
+	if function == AggregationPercentile {
+		numArgs = 2
+	}
+	if len(funcExpr.Exprs) != numArgs {
+		return nil, NewConverterError(
+			"%s: aggregate function '%s' takes %d arguments, got %d",
+			InvalidExpressionErrMessage,
+			function,
+			numArgs,
+			len(funcExpr.Exprs),
+		)
+	}
+	args := make([]sqlparser.Expr, numArgs)
+	for i, selectExpr := range funcExpr.Exprs {
+		argExpr, ok := selectExpr.(*sqlparser.AliasedExpr)
+		if !ok {
+			return nil, NewConverterError("%s: aggregation '%s'", InvalidExpressionErrMessage, aggregation)
+		}
+		args[i] = argExpr.Expr
+	}
+
+	colName, err := c.convertColName(args[0])
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(supportedTypesAggregation, colName.ValueType) ||
+		(function == AggregationSum && colName.ValueType == enumspb.INDEXED_VALUE_TYPE_DATETIME) {
+		return nil, NewConverterError(
+			"%s: aggregate function '%s' not supported for %s type search attribute '%s'",
+			NotSupportedErrMessage,
+			function,
+			colName.ValueType.String(),
+			colName.Alias,
+		)
+	}
+
+	res := &Aggregation{
+		Function: function,
+		Column:   colName,
+	}
+	if function == AggregationPercentile {
+		res.Percentile, err = parsePercentile(args[1])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func parsePercentile(expr sqlparser.Expr) (float64, error) {
+	val, ok := expr.(*sqlparser.SQLVal)
+	if ok && (val.Type == sqlparser.IntVal || val.Type == sqlparser.FloatVal) {
+		percentile, err := strconv.ParseFloat(string(val.Val), 64)
+		if err == nil && percentile >= 0 && percentile <= 100 {
+			return percentile, nil
+		}
+	}
+	return 0, NewConverterError(
+		"%s: percentile must be a number between 0 and 100, got '%s'",
+		InvalidExpressionErrMessage,
+		sqlparser.String(expr),
+	)
+}
+
+// String returns the expression of the aggregation, for example percentile(ExecutionDuration, 95).
+func (a *Aggregation) String() string {
+	if a.Function == AggregationPercentile {
+		return fmt.Sprintf("%s(%s, %s)", a.Function, a.Column.Alias, strconv.FormatFloat(a.Percentile, 'f', -1, 64))
+	}
+	return fmt.Sprintf("%s(%s)", a.Function, a.Column.Alias)
+}
+
+// IsDatetime returns true if the values of the aggregation are datetimes.
+func (a *Aggregation) IsDatetime() bool {
+	return a.Column.ValueType == enumspb.INDEXED_VALUE_TYPE_DATETIME
+}
+
+// ValueType returns the search attribute type of the values of the aggregation, which is Datetime for
+// aggregations of Datetime search attributes and Double otherwise.
+func (a *Aggregation) ValueType() enumspb.IndexedValueType {
+	if a.IsDatetime() {
+		return enumspb.INDEXED_VALUE_TYPE_DATETIME
+	}
+	return enumspb.INDEXED_VALUE_TYPE_DOUBLE
+}
+
+// EncodeValue encodes the value of the aggregation, which is a number of Unix nanoseconds for the aggregations of
+// Datetime search attributes, or nil if there are no values to aggregate.
+func (a *Aggregation) EncodeValue(value *float64) (*commonpb.Payload, error) {
+	var v any
+	if value != nil {
+		v = *value
+		if a.IsDatetime() {
+			v = time.Unix(0, int64(math.Round(*value))).UTC()
+		}
+	}
+	payload, err := searchattribute.EncodeValue(v, a.ValueType())
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode value of %s: %w", a, err)
+	}
+	return payload, nil
+}
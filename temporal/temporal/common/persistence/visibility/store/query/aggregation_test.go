@@ -0,0 +1,177 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/temporalio/sqlparser"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/server/common/searchattribute"
+	"go.temporal.io/server/common/searchattribute/sadefs"
+	"go.uber.org/mock/gomock"
+)
+
+func TestQueryConverter_ConvertAggregations(t *testing.T) {
+	executionDurationCol := NewSAColumn(sadefs.ExecutionDuration, sadefs.ExecutionDuration, enumspb.INDEXED_VALUE_TYPE_INT)
+	startTimeCol := NewSAColumn(sadefs.StartTime, sadefs.StartTime, enumspb.INDEXED_VALUE_TYPE_DATETIME)
+	doubleCol := NewSAColumn("AliasForDouble01", "Double01", enumspb.INDEXED_VALUE_TYPE_DOUBLE)
+
+	testCases := []struct {
+		name string
+		in   []string
+		out  []*Aggregation
+		err  string
+	}{
+		{
+			name: "success",
+			in: []string{
+				"min(ExecutionDuration)",
+				"MAX(StartTime)",
+				"avg(AliasForDouble01)",
+				"sum(ExecutionDuration)",
+				"percentile(ExecutionDuration, 99.9)",
+				"percentile(`StartTime`, 50)",
+			},
+			out: []*Aggregation{
+				{Function: AggregationMin, Column: executionDurationCol},
+				{Function: AggregationMax, Column: startTimeCol},
+				{Function: AggregationAvg, Column: doubleCol},
+				{Function: AggregationSum, Column: executionDurationCol},
+				{Function: AggregationPercentile, Column: executionDurationCol, Percentile: 99.9},
+				{Function: AggregationPercentile, Column: startTimeCol, Percentile: 50},
+			},
+		},
+		{
+			name: "fail no aggregations",
+			in:   nil,
+			err:  "at least one aggregation is required",
+		},
+		{
+			name: "fail malformed",
+			in:   []string{"avg(ExecutionDuration"},
+			err:  MalformedSqlQueryErrMessage,
+		},
+		{
+			name: "fail multiple expressions",
+			in:   []string{"min(ExecutionDuration), max(ExecutionDuration)"},
+			err:  MalformedSqlQueryErrMessage,
+		},
+		{
+			name: "fail not a function",
+			in:   []string{"ExecutionDuration"},
+			err:  "must be a function of a search attribute",
+		},
+		{
+			name: "fail unknown function",
+			in:   []string{"count(ExecutionDuration)"},
+			err:  "aggregate function 'count'",
+		},
+		{
+			name: "fail distinct",
+			in:   []string{"sum(distinct ExecutionDuration)"},
+			err:  "must be a function of a search attribute",
+		},
+		{
+			name: "fail missing percentile",
+			in:   []string{"percentile(ExecutionDuration)"},
+			err:  "takes 2 arguments, got 1",
+		},
+		{
+			name: "fail too many arguments",
+			in:   []string{"avg(ExecutionDuration, 1)"},
+			err:  "takes 1 arguments, got 2",
+		},
+		{
+			name: "fail invalid percentile",
+			in:   []string{"percentile(ExecutionDuration, 101)"},
+			err:  "percentile must be a number between 0 and 100, got '101'",
+		},
+		{
+			name: "fail percentile not a number",
+			in:   []string{"percentile(ExecutionDuration, '95')"},
+			err:  "percentile must be a number between 0 and 100",
+		},
+		{
+			name: "fail argument not a column",
+			in:   []string{"avg(1)"},
+			err:  "must be a column name",
+		},
+		{
+			name: "fail unknown search attribute",
+			in:   []string{"avg(Unknown)"},
+			err:  "column name 'Unknown' is not a valid search attribute",
+		},
+		{
+			name: "fail keyword",
+			in:   []string{"max(AliasForKeyword01)"},
+			err:  "aggregate function 'max' not supported for Keyword type search attribute 'AliasForKeyword01'",
+		},
+		{
+			name: "fail sum of datetime",
+			in:   []string{"sum(StartTime)"},
+			err:  "aggregate function 'sum' not supported for Datetime type search attribute 'StartTime'",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := require.New(t)
+			ctrl := gomock.NewController(t)
+			queryConverter := NewQueryConverter(
+				NewMockStoreQueryConverter[sqlparser.Expr](ctrl),
+				testNamespaceName,
+				searchattribute.TestNameTypeMap(),
+				&searchattribute.TestMapper{},
+			)
+			out, err := queryConverter.ConvertAggregations(tc.in)
+			if tc.err != "" {
+				var converterErr *ConverterError
+				r.ErrorAs(err, &converterErr)
+				r.ErrorContains(err, tc.err)
+				return
+			}
+			r.NoError(err)
+			r.Equal(tc.out, out)
+		})
+	}
+}
+
+func TestAggregation_EncodeValue(t *testing.T) {
+	r := require.New(t)
+
+	agg := &Aggregation{
+		Function:   AggregationPercentile,
+		Column:     NewSAColumn(sadefs.ExecutionDuration, sadefs.ExecutionDuration, enumspb.INDEXED_VALUE_TYPE_INT),
+		Percentile: 99.5,
+	}
+	r.Equal("percentile(ExecutionDuration, 99.5)", agg.String())
+	r.Equal(enumspb.INDEXED_VALUE_TYPE_DOUBLE, agg.ValueType())
+	value := 1.5
+	payload, err := agg.EncodeValue(&value)
+	r.NoError(err)
+	decoded, err := searchattribute.DecodeValue(payload, enumspb.INDEXED_VALUE_TYPE_DOUBLE, false)
+	r.NoError(err)
+	r.Equal(1.5, decoded)
+
+	agg = &Aggregation{
+		Function: AggregationMax,
+		Column:   NewSAColumn(sadefs.StartTime, sadefs.StartTime, enumspb.INDEXED_VALUE_TYPE_DATETIME),
+	}
+	r.Equal("max(StartTime)", agg.String())
+	r.Equal(enumspb.INDEXED_VALUE_TYPE_DATETIME, agg.ValueType())
+	startTime := time.Date(2026, 1, 2, 3, 4, 5, 6, time.UTC)
+	value = float64(startTime.UnixNano())
+	payload, err = agg.EncodeValue(&value)
+	r.NoError(err)
+	decoded, err = searchattribute.DecodeValue(payload, enumspb.INDEXED_VALUE_TYPE_DATETIME, false)
+	r.NoError(err)
+	// Unix nanoseconds as float64 are only precise to the microsecond.
+	r.WithinDuration(startTime, decoded.(time.Time), time.Microsecond)
+
+	payload, err = agg.EncodeValue(nil)
+	r.NoError(err)
+	decoded, err = searchattribute.DecodeValue(payload, enumspb.INDEXED_VALUE_TYPE_DATETIME, false)
+	r.NoError(err)
+	r.Nil(decoded)
+}
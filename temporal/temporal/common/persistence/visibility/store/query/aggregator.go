@@ -0,0 +1,198 @@
+package query
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/server/common/persistence/visibility/store"
+	"go.temporal.io/server/common/searchattribute"
+)
+
+type (
+	// Aggregator computes the aggregations of AggregateWorkflowExecutions from the values of the executions, for
+	// the visibility stores which can't compute them natively.
+	Aggregator struct {
+		aggregations []*Aggregation
+		groupByTypes []enumspb.IndexedValueType
+		groups       map[string]*aggregatorGroup
+		// keys are the keys of the groups in the order in which they were added.
+		keys []string
+	}
+
+	aggregatorGroup struct {
+		groupValues []any
+		count       int64
+		values      []aggregatorValues
+	}
+
+	// aggregatorValues accumulates the values of a search attribute. Datetime values are accumulated as Unix
+	// nanoseconds.
+	aggregatorValues struct {
+		count int64
+		sum   float64
+		min   float64
+		max   float64
+		// all holds all the values if they are needed to compute a percentile.
+		all []float64
+	}
+)
+
+// NewAggregator returns an Aggregator of the aggregations, grouped by the GROUP BY fields of the groupByTypes.
+// Without GROUP BY fields, all the executions are aggregated in a single group, which exists even if there are
+// no executions.
+func NewAggregator(aggregations []*Aggregation, groupByTypes []enumspb.IndexedValueType) *Aggregator {
+	a := &Aggregator{
+		aggregations: aggregations,
+		groupByTypes: groupByTypes,
+		groups:       make(map[string]*aggregatorGroup),
+	}
+	if len(groupByTypes) == 0 {
+		a.group([]any{})
+	}
+	return a
+}
+
+// Add adds an execution to the aggregations. The groupValues are the values of the GROUP BY fields of the execution,
+// and the values are the values of the search attributes of the aggregations, in the same order. Values must be
+// int64, float64 or time.Time, or nil if the execution has no value.
+func (a *Aggregator) Add(groupValues []any, values []any) error {
+	if len(groupValues) != len(a.groupByTypes) || len(values) != len(a.aggregations) {
+		return fmt.Errorf(
+			"expected %d group values and %d values, got %d and %d",
+			len(a.groupByTypes),
+			len(a.aggregations),
+			len(groupValues),
+			len(values),
+		)
+	}
+
+	numbers := make([]*float64, len(values))
+	for i, value := range values {
+		var v float64
+		switch typedValue := value.(type) {
+		case nil:
+			continue
+		case int64:
+			v = float64(typedValue)
+		case float64:
+			v = typedValue
+		case time.Time:
+			v = float64(typedValue.UnixNano())
+		default:
+			return fmt.Errorf("unexpected value type %T of %s", value, a.aggregations[i])
+		}
+		numbers[i] = &v
+	}
+
+	group := a.group(groupValues)
+	group.count++
+	for i, v := range numbers {
+		if v != nil {
+			group.values[i].add(*v, a.aggregations[i].Function == AggregationPercentile)
+		}
+	}
+	return nil
+}
+
+func (a *Aggregator) group(groupValues []any) *aggregatorGroup {
+	key := fmt.Sprintf("%#v", groupValues)
+	group, ok := a.groups[key]
+	if !ok {
+		group = &aggregatorGroup{
+			groupValues: groupValues,
+			values:      make([]aggregatorValues, len(a.aggregations)),
+		}
+		a.groups[key] = group
+		a.keys = append(a.keys, key)
+	}
+	return group
+}
+
+// Response returns the aggregations of the groups, in descending order of count.
+func (a *Aggregator) Response() (*store.InternalAggregateExecutionsResponse, error) {
+	resp := &store.InternalAggregateExecutionsResponse{
+		Groups: make([]store.InternalAggregationGroup, 0, len(a.keys)),
+	}
+	for _, key := range a.keys {
+		group := a.groups[key]
+		groupValues := make([]*commonpb.Payload, len(group.groupValues))
+		for i, value := range group.groupValues {
+			var err error
+			groupValues[i], err = searchattribute.EncodeValue(value, a.groupByTypes[i])
+			if err != nil {
+				return nil, fmt.Errorf("unable to encode value %v: %w", value, err)
+			}
+		}
+		values := make([]*commonpb.Payload, len(a.aggregations))
+		for i, agg := range a.aggregations {
+			var err error
+			values[i], err = agg.EncodeValue(group.values[i].result(agg))
+			if err != nil {
+				return nil, err
+			}
+		}
+		resp.Groups = append(resp.Groups, store.InternalAggregationGroup{
+			GroupValues: groupValues,
+			Count:       group.count,
+			Values:      values,
+		})
+		resp.Count += group.count
+	}
+	slices.SortStableFunc(resp.Groups, func(a, b store.InternalAggregationGroup) int {
+		return cmp.Compare(b.Count, a.Count)
+	})
+	return resp, nil
+}
+
+func (v *aggregatorValues) add(value float64, keepAll bool) {
+	if v.count == 0 || value < v.min {
+		v.min = value
+	}
+	if v.count == 0 || value > v.max {
+		v.max = value
+	}
+	v.count++
+	v.sum += value
+	if keepAll {
+		v.all = append(v.all, value)
+	}
+}
+
+// result returns the value of the aggregation, which is nil if there are no values, except for sum which is 0.
+func (v *aggregatorValues) result(agg *Aggregation) *float64 {
+	var res float64
+	switch agg.Function {
+	case AggregationSum:
+		return &v.sum
+	case AggregationMin:
+		res = v.min
+	case AggregationMax:
+		res = v.max
+	case AggregationAvg:
+		res = v.sum / float64(v.count)
+	case AggregationPercentile:
+		if len(v.all) == 0 {
+			return nil
+		}
+		res = Percentile(v.all, agg.Percentile)
+	}
+	if v.count == 0 {
+		return nil
+	}
+	return &res
+}
+
+// Percentile returns the percentile of the values, interpolated linearly between the closest ranks. The values are
+// sorted in place.
+func Percentile(values []float64, p float64) float64 {
+	slices.Sort(values)
+	rank := p / 100 * float64(len(values)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	return values[lower] + (rank-float64(lower))*(values[upper]-values[lower])
+}
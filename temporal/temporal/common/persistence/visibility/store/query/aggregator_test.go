@@ -0,0 +1,95 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/server/common/searchattribute"
+	"go.temporal.io/server/common/searchattribute/sadefs"
+)
+
+func TestAggregator(t *testing.T) {
+	t.Parallel()
+	r := require.New(t)
+
+	durationCol := NewSAColumn(sadefs.ExecutionDuration, sadefs.ExecutionDuration, enumspb.INDEXED_VALUE_TYPE_INT)
+	closeTimeCol := NewSAColumn(sadefs.CloseTime, sadefs.CloseTime, enumspb.INDEXED_VALUE_TYPE_DATETIME)
+	aggregations := []*Aggregation{
+		{Function: AggregationMin, Column: durationCol},
+		{Function: AggregationMax, Column: durationCol},
+		{Function: AggregationAvg, Column: durationCol},
+		{Function: AggregationSum, Column: durationCol},
+		{Function: AggregationPercentile, Column: durationCol, Percentile: 75},
+		{Function: AggregationMax, Column: closeTimeCol},
+	}
+	a := NewAggregator(aggregations, []enumspb.IndexedValueType{enumspb.INDEXED_VALUE_TYPE_KEYWORD})
+
+	closeTime := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	r.NoError(a.Add([]any{"Running"}, []any{nil, nil, nil, nil, nil, nil}))
+	for i, duration := range []int64{40, 10, 30, 20} {
+		r.NoError(a.Add([]any{"Completed"}, []any{duration, duration, duration, duration, duration, closeTime.Add(time.Duration(i) * time.Second)}))
+	}
+	r.ErrorContains(a.Add([]any{"Completed"}, []any{1}), "expected 1 group values and 6 values, got 1 and 1")
+	r.ErrorContains(a.Add([]any{"Completed"}, []any{1, 1, 1, 1, 1, 1}), "unexpected value type int of min(ExecutionDuration)")
+
+	resp, err := a.Response()
+	r.NoError(err)
+	r.Equal(int64(5), resp.Count)
+	r.Len(resp.Groups, 2)
+
+	decode := func(payloads []*commonpb.Payload, valueTypes ...enumspb.IndexedValueType) []any {
+		values := make([]any, len(payloads))
+		for i, p := range payloads {
+			var err error
+			values[i], err = searchattribute.DecodeValue(p, valueTypes[i], false)
+			r.NoError(err)
+		}
+		return values
+	}
+	double, datetime := enumspb.INDEXED_VALUE_TYPE_DOUBLE, enumspb.INDEXED_VALUE_TYPE_DATETIME
+
+	// groups are sorted by count, and the percentile is interpolated between 30 and 40
+	r.Equal(int64(4), resp.Groups[0].Count)
+	r.Equal([]any{"Completed"}, decode(resp.Groups[0].GroupValues, enumspb.INDEXED_VALUE_TYPE_KEYWORD))
+	r.Equal(
+		[]any{10.0, 40.0, 25.0, 100.0, 32.5, closeTime.Add(3 * time.Second)},
+		decode(resp.Groups[0].Values, double, double, double, double, double, datetime),
+	)
+
+	// aggregations of a group without values are null, except for sum
+	r.Equal(int64(1), resp.Groups[1].Count)
+	r.Equal([]any{"Running"}, decode(resp.Groups[1].GroupValues, enumspb.INDEXED_VALUE_TYPE_KEYWORD))
+	r.Equal(
+		[]any{nil, nil, nil, 0.0, nil, nil},
+		decode(resp.Groups[1].Values, double, double, double, double, double, datetime),
+	)
+}
+
+func TestAggregator_NoGroupBy(t *testing.T) {
+	t.Parallel()
+	r := require.New(t)
+
+	durationCol := NewSAColumn(sadefs.ExecutionDuration, sadefs.ExecutionDuration, enumspb.INDEXED_VALUE_TYPE_INT)
+	a := NewAggregator([]*Aggregation{{Function: AggregationAvg, Column: durationCol}}, nil)
+
+	// there is a single group even if there are no executions
+	resp, err := a.Response()
+	r.NoError(err)
+	r.Equal(int64(0), resp.Count)
+	r.Len(resp.Groups, 1)
+	r.Empty(resp.Groups[0].GroupValues)
+	r.Equal(int64(0), resp.Groups[0].Count)
+
+	r.NoError(a.Add([]any{}, []any{int64(1)}))
+	r.NoError(a.Add([]any{}, []any{2.0}))
+	resp, err = a.Response()
+	r.NoError(err)
+	r.Equal(int64(2), resp.Count)
+	r.Len(resp.Groups, 1)
+	avg, err := searchattribute.DecodeValue(resp.Groups[0].Values[0], enumspb.INDEXED_VALUE_TYPE_DOUBLE, false)
+	r.NoError(err)
+	r.Equal(1.5, avg)
+}
@@ -85,6 +85,18 @@ var (
 		"TemporalLowCardinalityKeyword",
 	}
 
+	// groupByFieldDenylist are the Keyword search attributes which are unique per execution, and thus not allowed
+	// in the GROUP BY clause.
+	groupByFieldDenylist = []string{
+		sadefs.WorkflowID,
+		sadefs.RunID,
+		sadefs.ParentWorkflowID,
+		sadefs.ParentRunID,
+		sadefs.RootWorkflowID,
+		sadefs.RootRunID,
+		sadefs.TemporalNamespaceDivision,
+	}
+
 	supportedComparisonOperators = []string{
 		sqlparser.EqualStr,
 		sqlparser.NotEqualStr,
@@ -222,6 +234,16 @@ func (c *QueryConverter[ExprT]) Convert(
 }
 
 func (c *QueryConverter[ExprT]) convertWhereString(queryString string) (*QueryParams[ExprT], error) {
+	selectStmt, err := parseQueryString(queryString)
+	if err != nil {
+		return nil, err
+	}
+	return c.convertSelectStmt(selectStmt)
+}
+
+// parseQueryString parses a visibility query, which is the WHERE, GROUP BY and ORDER BY clauses of a SELECT
+// statement.
+func parseQueryString(queryString string) (*sqlparser.Select, error) {
 	where := strings.TrimSpace(queryString)
 	if where != "" &&
 		!strings.HasPrefix(strings.ToLower(where), "order by") &&
@@ -237,7 +259,7 @@ func (c *QueryConverter[ExprT]) convertWhereString(queryString string) (*QueryPa
 
 	//nolint:revive // type cast is guaranteed to be a *sqlparser.Select
 	selectStmt, _ := stmt.(*sqlparser.Select)
-	return c.convertSelectStmt(selectStmt)
+	return selectStmt, nil
 }
 
 func (c *QueryConverter[ExprT]) convertSelectStmt(
@@ -269,22 +291,13 @@ func (c *QueryConverter[ExprT]) convertSelectStmt(
 		res.QueryExpr = queryExpr
 	}
 
-	if len(sel.GroupBy) > 1 {
-		return nil, NewConverterError(
-			"%s: 'GROUP BY' clause supports only a single field",
-			NotSupportedErrMessage,
-		)
-	}
 	for k := range sel.GroupBy {
 		colName, err := c.convertColName(sel.GroupBy[k])
 		if err != nil {
 			return nil, err
 		}
-		if !IsGroupByFieldAllowed(colName.FieldName) {
-			return nil, NewConverterError(
-				"%s: 'GROUP BY' clause is only supported for ExecutionStatus",
-				NotSupportedErrMessage,
-			)
+		if !IsGroupByFieldAllowed(colName.FieldName, colName.ValueType) {
+			return nil, NewGroupByFieldNotAllowedError(colName.Alias)
 		}
 		res.GroupBy = append(res.GroupBy, colName)
 	}
@@ -717,18 +730,18 @@ func (c *QueryConverter[ExprT]) validateValueType(
 	}
 }
 
-func IsGroupByFieldAllowed(fieldName string) bool {
-	for _, allowedField := range groupByFieldAllowlist {
-		if fieldName == allowedField {
-			return true
-		}
+// IsGroupByFieldAllowed returns true if the search attribute can be used in the GROUP BY clause, which is the case for
+// ExecutionStatus and the Keyword search attributes which aren't unique per execution.
+func IsGroupByFieldAllowed(fieldName string, fieldType enumspb.IndexedValueType) bool {
+	if slices.Contains(groupByFieldAllowlist, fieldName) {
+		return true
 	}
 	for _, allowedPrefix := range groupByFieldPrefixAllowlist {
 		if strings.HasPrefix(fieldName, allowedPrefix) {
 			return true
 		}
 	}
-	return false
+	return fieldType == enumspb.INDEXED_VALUE_TYPE_KEYWORD && !slices.Contains(groupByFieldDenylist, fieldName)
 }
 
 func parseExecutionStatusValue(value any) (string, error) {
@@ -228,9 +228,6 @@ func (c *ConverterLegacy) convertSelect(sel *sqlparser.Select) (*QueryParamsLega
 		queryParams.Query = query
 	}
 
-	if len(sel.GroupBy) > 1 {
-		return nil, NewConverterError("%s: 'group by' clause supports only a single field", NotSupportedErrMessage)
-	}
 	for _, groupByExpr := range sel.GroupBy {
 		_, colName, err := convertColName(c.fnInterceptor, groupByExpr, FieldNameGroupBy)
 		if err != nil {
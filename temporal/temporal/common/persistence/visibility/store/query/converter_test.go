@@ -485,21 +485,39 @@ func TestQueryConverter_ConvertSelectStmt(t *testing.T) {
 		},
 
 		{
-			name: "fail multiple group by",
+			name: "success group by multiple fields",
+			in:   "select * from t group by ExecutionStatus, WorkflowType, AliasForKeyword01",
+			out: &QueryParams[sqlparser.Expr]{
+				GroupBy: []*SAColumn{
+					NewSAColumn(
+						sadefs.ExecutionStatus,
+						sadefs.ExecutionStatus,
+						enumspb.INDEXED_VALUE_TYPE_KEYWORD,
+					),
+					NewSAColumn(
+						sadefs.WorkflowType,
+						sadefs.WorkflowType,
+						enumspb.INDEXED_VALUE_TYPE_KEYWORD,
+					),
+					NewSAColumn(
+						"AliasForKeyword01",
+						"Keyword01",
+						enumspb.INDEXED_VALUE_TYPE_KEYWORD,
+					),
+				},
+			},
+		},
+
+		{
+			name: "fail not supported group by unique field",
 			in:   "select * from t group by ExecutionStatus, RunId",
-			err: fmt.Sprintf(
-				"%s: 'GROUP BY' clause supports only a single field",
-				NotSupportedErrMessage,
-			),
+			err:  NewGroupByFieldNotAllowedError(sadefs.RunID).Error(),
 		},
 
 		{
-			name: "fail not supported group by field",
-			in:   "select * from t group by RunId",
-			err: fmt.Sprintf(
-				"%s: 'GROUP BY' clause is only supported for ExecutionStatus",
-				NotSupportedErrMessage,
-			),
+			name: "fail not supported group by non keyword field",
+			in:   "select * from t group by AliasForInt01",
+			err:  NewGroupByFieldNotAllowedError("AliasForInt01").Error(),
 		},
 
 		{
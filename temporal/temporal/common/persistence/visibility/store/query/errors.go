@@ -55,3 +55,11 @@ func wrapConverterError(message string, err error) error {
 	}
 	return err
 }
+
+func NewGroupByFieldNotAllowedError(saName string) error {
+	return NewConverterError(
+		"%s: 'GROUP BY' clause is only supported for ExecutionStatus and Keyword search attributes which are not unique per execution, got '%s'",
+		NotSupportedErrMessage,
+		saName,
+	)
+}
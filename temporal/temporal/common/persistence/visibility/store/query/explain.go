@@ -0,0 +1,173 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/temporalio/sqlparser"
+	"go.temporal.io/server/common/namespace"
+	"go.temporal.io/server/common/persistence/visibility/manager"
+	"go.temporal.io/server/common/searchattribute"
+)
+
+type (
+	// QueryExplanation is the store independent part of the explanation of a visibility query.
+	QueryExplanation struct {
+		// AST is the parsed query, one node per line, indented by depth.
+		AST string
+		// SearchAttributes are the search attributes of the query, in order of appearance, resolved to the
+		// field names of the store. They include the search attributes added by the converter, such as
+		// TemporalNamespaceDivision.
+		SearchAttributes []*SAColumn
+	}
+
+	// SearchAttributeRecorder is a SearchAttributeInterceptor which records the resolved search attributes of a
+	// query, after they are intercepted by the next interceptor.
+	SearchAttributeRecorder struct {
+		next    SearchAttributeInterceptor
+		columns []*SAColumn
+	}
+)
+
+var _ SearchAttributeInterceptor = (*SearchAttributeRecorder)(nil)
+
+// NewSearchAttributeRecorder returns a new SearchAttributeRecorder which calls next before recording the search
+// attributes. next can be nil.
+func NewSearchAttributeRecorder(next SearchAttributeInterceptor) *SearchAttributeRecorder {
+	if next == nil {
+		next = nopSearchAttributeInterceptor
+	}
+	return &SearchAttributeRecorder{
+		next: next,
+	}
+}
+
+func (r *SearchAttributeRecorder) Intercept(col *SAColumn) error {
+	if err := r.next.Intercept(col); err != nil {
+		return err
+	}
+	for _, seen := range r.columns {
+		if seen.Alias == col.Alias && seen.FieldName == col.FieldName {
+			return nil
+		}
+	}
+	r.columns = append(r.columns, NewSAColumn(col.Alias, col.FieldName, col.ValueType))
+	return nil
+}
+
+// Columns returns the distinct search attributes which were intercepted, in order of appearance.
+func (r *SearchAttributeRecorder) Columns() []*SAColumn {
+	return r.columns
+}
+
+// ExplainQuery parses a visibility query and resolves its search attributes with the same rules as the query
+// converter of the stores.
+func ExplainQuery(
+	queryString string,
+	namespaceName namespace.Name,
+	saTypeMap searchattribute.NameTypeMap,
+	saMapper searchattribute.Mapper,
+) (*QueryExplanation, error) {
+	sel, err := parseQueryString(queryString)
+	if err != nil {
+		return nil, err
+	}
+	ast := formatSelectAST(sel)
+
+	recorder := NewSearchAttributeRecorder(nil)
+	_, err = NewNilQueryConverter(namespaceName, saTypeMap, saMapper).
+		WithSearchAttributeInterceptor(recorder).
+		Convert(queryString)
+	if err != nil {
+		return nil, err
+	}
+	return &QueryExplanation{
+		AST:              ast,
+		SearchAttributes: recorder.Columns(),
+	}, nil
+}
+
+// NewExplainQueryResponse returns a response of ExplainQuery with the store independent part of the explanation.
+func NewExplainQueryResponse(storeName string, explanation *QueryExplanation) *manager.ExplainQueryResponse {
+	searchAttributes := make([]*manager.ExplainedSearchAttribute, 0, len(explanation.SearchAttributes))
+	for _, col := range explanation.SearchAttributes {
+		searchAttributes = append(searchAttributes, &manager.ExplainedSearchAttribute{
+			Name:      col.Alias,
+			FieldName: col.FieldName,
+			Type:      col.ValueType,
+		})
+	}
+	return &manager.ExplainQueryResponse{
+		AST:              explanation.AST,
+		SearchAttributes: searchAttributes,
+		StoreName:        storeName,
+	}
+}
+
+func formatSelectAST(sel *sqlparser.Select) string {
+	var sb strings.Builder
+	if sel.Where != nil && sel.Where.Expr != nil {
+		sb.WriteString("Where\n")
+		formatExprAST(&sb, sel.Where.Expr, 1)
+	}
+	if len(sel.GroupBy) > 0 {
+		sb.WriteString("GroupBy\n")
+		for _, expr := range sel.GroupBy {
+			formatExprAST(&sb, expr, 1)
+		}
+	}
+	if len(sel.OrderBy) > 0 {
+		sb.WriteString("OrderBy\n")
+		for _, order := range sel.OrderBy {
+			writeASTNode(&sb, 1, "Order %s", order.Direction)
+			formatExprAST(&sb, order.Expr, 2)
+		}
+	}
+	return sb.String()
+}
+
+func formatExprAST(sb *strings.Builder, expr sqlparser.Expr, depth int) {
+	switch e := expr.(type) {
+	case *sqlparser.ParenExpr:
+		writeASTNode(sb, depth, "Paren")
+		formatExprAST(sb, e.Expr, depth+1)
+	case *sqlparser.NotExpr:
+		writeASTNode(sb, depth, "Not")
+		formatExprAST(sb, e.Expr, depth+1)
+	case *sqlparser.AndExpr:
+		writeASTNode(sb, depth, "And")
+		formatExprAST(sb, e.Left, depth+1)
+		formatExprAST(sb, e.Right, depth+1)
+	case *sqlparser.OrExpr:
+		writeASTNode(sb, depth, "Or")
+		formatExprAST(sb, e.Left, depth+1)
+		formatExprAST(sb, e.Right, depth+1)
+	case *sqlparser.ComparisonExpr:
+		writeASTNode(sb, depth, "Comparison %s", e.Operator)
+		formatExprAST(sb, e.Left, depth+1)
+		formatExprAST(sb, e.Right, depth+1)
+	case *sqlparser.RangeCond:
+		writeASTNode(sb, depth, "Range %s", e.Operator)
+		formatExprAST(sb, e.Left, depth+1)
+		formatExprAST(sb, e.From, depth+1)
+		formatExprAST(sb, e.To, depth+1)
+	case *sqlparser.IsExpr:
+		writeASTNode(sb, depth, "Is %s", strings.TrimPrefix(e.Operator, "is "))
+		formatExprAST(sb, e.Expr, depth+1)
+	case *sqlparser.ColName:
+		writeASTNode(sb, depth, "Column %s", strings.ReplaceAll(sqlparser.String(e), "`", ""))
+	case sqlparser.ValTuple:
+		writeASTNode(sb, depth, "Tuple")
+		for _, item := range e {
+			formatExprAST(sb, item, depth+1)
+		}
+	default:
+		writeASTNode(sb, depth, "Value %s", sqlparser.String(e))
+	}
+}
+
+func writeASTNode(sb *strings.Builder, depth int, format string, args ...any) {
+	sb.WriteString(strings.Repeat("  ", depth))
+	_, _ = fmt.Fprintf(sb, format, args...)
+	sb.WriteString("\n")
+}
@@ -0,0 +1,80 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/server/common/searchattribute"
+)
+
+func TestExplainQuery(t *testing.T) {
+	t.Parallel()
+
+	explanation, err := ExplainQuery(
+		"(AliasForKeyword01 = 'foo' OR WorkflowId IN ('wid-1', 'wid-2')) AND NOT StartTime BETWEEN '2024-01-01T00:00:00Z' AND '2024-01-02T00:00:00Z' AND CloseTime IS NULL ORDER BY StartTime DESC",
+		"test-namespace",
+		searchattribute.TestNameTypeMap(),
+		&searchattribute.TestMapper{},
+	)
+	require.NoError(t, err)
+	require.Equal(t, `Where
+  And
+    And
+      Paren
+        Or
+          Comparison =
+            Column AliasForKeyword01
+            Value 'foo'
+          Comparison in
+            Column WorkflowId
+            Tuple
+              Value 'wid-1'
+              Value 'wid-2'
+      Not
+        Range between
+          Column StartTime
+          Value '2024-01-01T00:00:00Z'
+          Value '2024-01-02T00:00:00Z'
+    Is null
+      Column CloseTime
+OrderBy
+  Order desc
+    Column StartTime
+`, explanation.AST)
+	require.Equal(t, []*SAColumn{
+		NewSAColumn("AliasForKeyword01", "Keyword01", enumspb.INDEXED_VALUE_TYPE_KEYWORD),
+		NewSAColumn("WorkflowId", "WorkflowId", enumspb.INDEXED_VALUE_TYPE_KEYWORD),
+		NewSAColumn("StartTime", "StartTime", enumspb.INDEXED_VALUE_TYPE_DATETIME),
+		NewSAColumn("CloseTime", "CloseTime", enumspb.INDEXED_VALUE_TYPE_DATETIME),
+		NewSAColumn("TemporalNamespaceDivision", "TemporalNamespaceDivision", enumspb.INDEXED_VALUE_TYPE_KEYWORD),
+	}, explanation.SearchAttributes)
+
+	explanation, err = ExplainQuery("GROUP BY ExecutionStatus", "test-namespace", searchattribute.TestNameTypeMap(), &searchattribute.TestMapper{})
+	require.NoError(t, err)
+	require.Equal(t, "GroupBy\n  Column ExecutionStatus\n", explanation.AST)
+
+	_, err = ExplainQuery("Unknown = 'foo'", "test-namespace", searchattribute.TestNameTypeMap(), &searchattribute.TestMapper{})
+	var converterErr *ConverterError
+	require.ErrorAs(t, err, &converterErr)
+	_, err = ExplainQuery("WorkflowId = ", "test-namespace", searchattribute.TestNameTypeMap(), &searchattribute.TestMapper{})
+	require.ErrorAs(t, err, &converterErr)
+}
+
+func TestSearchAttributeRecorder(t *testing.T) {
+	t.Parallel()
+
+	next := &testSearchAttributeInterceptor{}
+	recorder := NewSearchAttributeRecorder(next)
+	_, err := NewNilQueryConverter("", searchattribute.TestNameTypeMap(), &searchattribute.TestMapper{}).
+		WithSearchAttributeInterceptor(recorder).
+		Convert("ExecutionStatus = 'Running' AND ExecutionStatus != 'Completed'")
+	require.NoError(t, err)
+	require.Equal(t, []string{"ExecutionStatus", "ExecutionStatus", "TemporalNamespaceDivision"}, next.seenFields)
+	require.Len(t, recorder.Columns(), 2)
+
+	_, err = NewNilQueryConverter("", searchattribute.TestNameTypeMap(), &searchattribute.TestMapper{}).
+		WithSearchAttributeInterceptor(NewSearchAttributeRecorder(next)).
+		Convert("AliasForKeyword01 = 'foo'")
+	require.ErrorContains(t, err, "interceptor error")
+}
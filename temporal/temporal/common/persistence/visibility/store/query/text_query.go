@@ -0,0 +1,169 @@
+package query
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type (
+	// TextQuery is the parsed value of a comparison of a Text search attribute. A text matches the query if it
+	// matches all the required terms and none of the excluded terms, and, if there is no required term, at least one
+	// of the optional terms.
+	TextQuery struct {
+		Required []TextTerm
+		Optional []TextTerm
+		Excluded []TextTerm
+	}
+
+	// TextTerm is a word or a phrase of a text query.
+	TextTerm struct {
+		// Tokens are the lowercase words of the term. A text matches the term if it contains them in this order.
+		Tokens []string
+		// Prefix makes the last token match the words which start with it.
+		Prefix bool
+	}
+)
+
+// ParseTextQuery parses the value of a comparison of a Text search attribute. The value is a list of terms separated
+// by whitespace, with the same semantics in all the visibility stores:
+//   - a word matches the texts which contain it, case-insensitively;
+//   - a quoted phrase, such as "quick brown fox", matches the texts which contain its words in this order;
+//   - a trailing * makes the last word of a term a prefix, such as qui*;
+//   - a leading + makes a term required, and a leading - excludes the texts which match the term.
+//
+// Words are split on the characters which are neither letters nor digits, so a word such as foo-bar is the phrase
+// "foo bar".
+func ParseTextQuery(s string) (*TextQuery, error) {
+	textQuery := &TextQuery{}
+	for s != "" {
+		r, size := utf8.DecodeRuneInString(s)
+		if unicode.IsSpace(r) {
+			s = s[size:]
+			continue
+		}
+
+		terms := &textQuery.Optional
+		switch r {
+		case '+':
+			terms = &textQuery.Required
+			s = s[size:]
+		case '-':
+			terms = &textQuery.Excluded
+			s = s[size:]
+		}
+
+		var text string
+		if strings.HasPrefix(s, `"`) {
+			end := strings.Index(s[1:], `"`)
+			if end < 0 {
+				return nil, NewConverterError(
+					"%s: unterminated phrase in Text query",
+					InvalidExpressionErrMessage,
+				)
+			}
+			text, s = s[1:end+1], s[end+2:]
+		} else {
+			end := strings.IndexFunc(s, unicode.IsSpace)
+			if end < 0 {
+				end = len(s)
+			}
+			text, s = s[:end], s[end:]
+		}
+
+		prefix := strings.HasPrefix(s, "*")
+		if prefix {
+			s = s[1:]
+		} else if strings.HasSuffix(text, "*") {
+			text, prefix = strings.TrimSuffix(text, "*"), true
+		}
+
+		tokens := TokenizeText(text)
+		if len(tokens) == 0 {
+			continue
+		}
+		*terms = append(*terms, TextTerm{Tokens: tokens, Prefix: prefix})
+	}
+
+	if len(textQuery.Required) == 0 && len(textQuery.Optional) == 0 {
+		if len(textQuery.Excluded) > 0 {
+			return nil, NewConverterError(
+				"%s: Text query must have a term which is not excluded",
+				InvalidExpressionErrMessage,
+			)
+		}
+		return nil, NewConverterError(
+			"%s: unexpected value for Text type search attribute (no tokens found)",
+			InvalidExpressionErrMessage,
+		)
+	}
+	return textQuery, nil
+}
+
+// TokenizeText splits a text into lowercase words, which are the sequences of letters and digits of the text.
+func TokenizeText(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// Positive returns the terms of which the texts must match all, if all is true, or at least one, otherwise.
+func (q *TextQuery) Positive() (terms []TextTerm, all bool) {
+	if len(q.Required) > 0 {
+		return q.Required, true
+	}
+	return q.Optional, false
+}
+
+// IsPlain returns true if the query is a list of optional words, without phrases, prefixes or excluded terms.
+func (q *TextQuery) IsPlain() bool {
+	if len(q.Required) > 0 || len(q.Excluded) > 0 {
+		return false
+	}
+	for _, term := range q.Optional {
+		if len(term.Tokens) > 1 || term.Prefix {
+			return false
+		}
+	}
+	return true
+}
+
+// Matches returns true if the text matches the query.
+func (q *TextQuery) Matches(text string) bool {
+	tokens := TokenizeText(text)
+	for _, term := range q.Excluded {
+		if term.Matches(tokens) {
+			return false
+		}
+	}
+	terms, all := q.Positive()
+	for _, term := range terms {
+		if term.Matches(tokens) != all {
+			return !all
+		}
+	}
+	return all
+}
+
+// Matches returns true if the tokens of a text contain the tokens of the term.
+func (t TextTerm) Matches(tokens []string) bool {
+	for start := 0; start+len(t.Tokens) <= len(tokens); start++ {
+		if t.matchesAt(tokens[start:]) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t TextTerm) matchesAt(tokens []string) bool {
+	last := len(t.Tokens) - 1
+	for i, token := range t.Tokens {
+		if i == last && t.Prefix {
+			return strings.HasPrefix(tokens[i], token)
+		}
+		if tokens[i] != token {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,142 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTextQuery(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name  string
+		input string
+		want  *TextQuery
+		err   string
+	}{
+		{
+			name:  "words",
+			input: "  Foo   BAR ",
+			want: &TextQuery{
+				Optional: []TextTerm{{Tokens: []string{"foo"}}, {Tokens: []string{"bar"}}},
+			},
+		},
+		{
+			name:  "phrase",
+			input: `"quick  Brown fox"`,
+			want: &TextQuery{
+				Optional: []TextTerm{{Tokens: []string{"quick", "brown", "fox"}}},
+			},
+		},
+		{
+			name:  "word with punctuation",
+			input: "foo-bar",
+			want: &TextQuery{
+				Optional: []TextTerm{{Tokens: []string{"foo", "bar"}}},
+			},
+		},
+		{
+			name:  "prefix",
+			input: `qui* "quick bro"*`,
+			want: &TextQuery{
+				Optional: []TextTerm{
+					{Tokens: []string{"qui"}, Prefix: true},
+					{Tokens: []string{"quick", "bro"}, Prefix: true},
+				},
+			},
+		},
+		{
+			name:  "required and excluded",
+			input: `+foo -"bar baz" qux`,
+			want: &TextQuery{
+				Required: []TextTerm{{Tokens: []string{"foo"}}},
+				Optional: []TextTerm{{Tokens: []string{"qux"}}},
+				Excluded: []TextTerm{{Tokens: []string{"bar", "baz"}}},
+			},
+		},
+		{
+			name:  "empty terms are ignored",
+			input: `foo + - "" !!`,
+			want: &TextQuery{
+				Optional: []TextTerm{{Tokens: []string{"foo"}}},
+			},
+		},
+		{
+			name:  "no tokens",
+			input: " !! ",
+			err:   "no tokens found",
+		},
+		{
+			name:  "only excluded",
+			input: "-foo",
+			err:   "must have a term which is not excluded",
+		},
+		{
+			name:  "unterminated phrase",
+			input: `"foo bar`,
+			err:   "unterminated phrase",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseTextQuery(tc.input)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				var converterErr *ConverterError
+				require.ErrorAs(t, err, &converterErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestTextQuery_Matches(t *testing.T) {
+	t.Parallel()
+	text := "The quick brown fox jumps over the lazy dog"
+	testCases := []struct {
+		query string
+		want  bool
+	}{
+		{query: "FOX", want: true},
+		{query: "cat", want: false},
+		{query: "cat dog", want: true},
+		{query: `"quick brown"`, want: true},
+		{query: `"brown quick"`, want: false},
+		{query: "qui*", want: true},
+		{query: "ick*", want: false},
+		{query: `"lazy do"*`, want: true},
+		{query: "+fox +cat", want: false},
+		{query: "+fox cat", want: true},
+		{query: "fox -dog", want: false},
+		{query: "cat -dog", want: false},
+		{query: "fox -cat", want: true},
+		{query: "over-the-lazy", want: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			textQuery, err := ParseTextQuery(tc.query)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, textQuery.Matches(text))
+		})
+	}
+}
+
+func TestTextQuery_IsPlain(t *testing.T) {
+	t.Parallel()
+	for query, want := range map[string]bool{
+		"foo bar":   true,
+		"foo-bar":   false,
+		"foo*":      false,
+		"+foo":      false,
+		"foo -bar":  false,
+		`"foo" bar`: true,
+	} {
+		textQuery, err := ParseTextQuery(query)
+		require.NoError(t, err)
+		require.Equal(t, want, textQuery.IsPlain(), query)
+	}
+}
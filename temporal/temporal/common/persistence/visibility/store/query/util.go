@@ -2,7 +2,6 @@ package query
 
 import (
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/temporalio/sqlparser"
@@ -104,19 +103,6 @@ func NewFuncExpr(name string, exprs ...sqlparser.Expr) *sqlparser.FuncExpr {
 	}
 }
 
-// TokenizeTextQueryString tokenizes the string by spaces
-// It's a temporary solution as it doesn't cover tokenizer used by PostgreSQL or SQLite.
-func TokenizeTextQueryString(s string) []string {
-	tokens := strings.Split(s, " ")
-	nonEmptyTokens := make([]string, 0, len(tokens))
-	for _, token := range tokens {
-		if token != "" {
-			nonEmptyTokens = append(nonEmptyTokens, token)
-		}
-	}
-	return nonEmptyTokens
-}
-
 func GetUnsafeStringTupleValues(valTuple sqlparser.ValTuple) ([]string, error) {
 	values := make([]string, len(valTuple))
 	for i, val := range valTuple {
@@ -81,63 +81,6 @@ func TestNewFuncExpr(t *testing.T) {
 	}
 }
 
-func TestTokenizeTextQueryString(t *testing.T) {
-	t.Parallel()
-	testCases := []struct {
-		name  string
-		input string
-		want  []string
-	}{
-		{
-			name:  "empty",
-			input: "",
-			want:  []string{},
-		},
-		{
-			name:  "single token",
-			input: "foo",
-			want:  []string{"foo"},
-		},
-		{
-			name:  "two tokens",
-			input: "foo bar",
-			want:  []string{"foo", "bar"},
-		},
-		{
-			name:  "multiple spaces collapsed",
-			input: "a  b   c",
-			want:  []string{"a", "b", "c"},
-		},
-		{
-			name:  "leading and trailing spaces",
-			input: "  foo   bar  ",
-			want:  []string{"foo", "bar"},
-		},
-		{
-			name:  "spaces only",
-			input: "    ",
-			want:  []string{},
-		},
-		{
-			name:  "tabs are not separators",
-			input: "foo\tbar baz",
-			want:  []string{"foo\tbar", "baz"},
-		},
-		{
-			name:  "newlines are not separators",
-			input: "foo\nbar baz",
-			want:  []string{"foo\nbar", "baz"},
-		},
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			got := TokenizeTextQueryString(tc.input)
-			require.Equal(t, tc.want, got)
-		})
-	}
-}
-
 func TestGetUnsafeStringTupleValues(t *testing.T) {
 	t.Parallel()
 	testCases := []struct {
@@ -0,0 +1,366 @@
+package query
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/temporalio/sqlparser"
+	enumspb "go.temporal.io/api/enums/v1"
+)
+
+type (
+	// ValuesPredicate is the expression built by ValuesConverter, which returns true if the values of the search
+	// attributes of an execution, by field name, match the query. A nil ValuesPredicate matches all the executions.
+	ValuesPredicate func(values map[string]any) bool
+
+	// ValuesConverter builds predicates which evaluate queries against the values of the search attributes of the
+	// executions. The values are int64, float64, bool, time.Time, string or []string, and the ExecutionStatus is the
+	// name of the status. Comparisons only match the executions which have a value for the search attribute, except
+	// for the negated operators (!=, NOT IN, NOT STARTS_WITH and NOT BETWEEN) which also match the executions without
+	// a value.
+	ValuesConverter struct{}
+)
+
+var _ StoreQueryConverter[ValuesPredicate] = (*ValuesConverter)(nil)
+
+var comparisonOperators = map[string]func(res int) bool{
+	sqlparser.EqualStr:        func(res int) bool { return res == 0 },
+	sqlparser.LessThanStr:     func(res int) bool { return res < 0 },
+	sqlparser.GreaterThanStr:  func(res int) bool { return res > 0 },
+	sqlparser.LessEqualStr:    func(res int) bool { return res <= 0 },
+	sqlparser.GreaterEqualStr: func(res int) bool { return res >= 0 },
+}
+
+func (c *ValuesConverter) GetDatetimeFormat() string {
+	return time.RFC3339Nano
+}
+
+func (c *ValuesConverter) BuildParenExpr(expr ValuesPredicate) (ValuesPredicate, error) {
+	return expr, nil
+}
+
+func (c *ValuesConverter) BuildNotExpr(expr ValuesPredicate) (ValuesPredicate, error) {
+	return negate(expr), nil
+}
+
+func (c *ValuesConverter) BuildAndExpr(exprs ...ValuesPredicate) (ValuesPredicate, error) {
+	exprs = nonNil(exprs)
+	if len(exprs) <= 1 {
+		return first(exprs), nil
+	}
+	return func(values map[string]any) bool {
+		for _, expr := range exprs {
+			if !expr(values) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+func (c *ValuesConverter) BuildOrExpr(exprs ...ValuesPredicate) (ValuesPredicate, error) {
+	exprs = nonNil(exprs)
+	if len(exprs) <= 1 {
+		return first(exprs), nil
+	}
+	return func(values map[string]any) bool {
+		for _, expr := range exprs {
+			if expr(values) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+func (c *ValuesConverter) ConvertComparisonExpr(
+	operator string,
+	col *SAColumn,
+	value any,
+) (ValuesPredicate, error) {
+	value, err := parseValue(col, value)
+	if err != nil {
+		return nil, err
+	}
+
+	switch operator {
+	case sqlparser.InStr, sqlparser.NotInStr:
+		values, ok := value.([]any)
+		if !ok {
+			return nil, NewConverterError(
+				"%s: right-hand side of '%s' operator must be a tuple (got: %v)",
+				InvalidExpressionErrMessage,
+				operator,
+				value,
+			)
+		}
+		p := hasValue(col, func(v any) bool {
+			return slices.ContainsFunc(values, func(value any) bool {
+				res, ok := CompareValues(v, value)
+				return ok && res == 0
+			})
+		})
+		if operator == sqlparser.NotInStr {
+			return negate(p), nil
+		}
+		return p, nil
+	case sqlparser.NotEqualStr:
+		p, err := c.ConvertComparisonExpr(sqlparser.EqualStr, col, value)
+		if err != nil {
+			return nil, err
+		}
+		return negate(p), nil
+	}
+
+	matchComparison, ok := comparisonOperators[operator]
+	if !ok {
+		return nil, NewOperatorNotSupportedError(col.Alias, col.ValueType, operator)
+	}
+	return hasValue(col, func(v any) bool {
+		res, ok := CompareValues(v, value)
+		return ok && matchComparison(res)
+	}), nil
+}
+
+func (c *ValuesConverter) ConvertKeywordComparisonExpr(
+	operator string,
+	col *SAColumn,
+	value any,
+) (ValuesPredicate, error) {
+	switch operator {
+	case sqlparser.StartsWithStr, sqlparser.NotStartsWithStr:
+		prefix, ok := value.(string)
+		if !ok {
+			return nil, NewConverterError(
+				"%s: right-hand side of %q operator must be a literal string (got: %v)",
+				InvalidExpressionErrMessage,
+				operator,
+				value,
+			)
+		}
+		p := hasValue(col, func(v any) bool {
+			s, ok := v.(string)
+			return ok && strings.HasPrefix(s, prefix)
+		})
+		if operator == sqlparser.NotStartsWithStr {
+			return negate(p), nil
+		}
+		return p, nil
+	default:
+		return c.ConvertComparisonExpr(operator, col, value)
+	}
+}
+
+func (c *ValuesConverter) ConvertKeywordListComparisonExpr(
+	operator string,
+	col *SAColumn,
+	value any,
+) (ValuesPredicate, error) {
+	var values []any
+	switch operator {
+	case sqlparser.EqualStr, sqlparser.NotEqualStr:
+		values = []any{value}
+	case sqlparser.InStr, sqlparser.NotInStr:
+		var ok bool
+		values, ok = value.([]any)
+		if !ok {
+			return nil, NewConverterError(
+				"%s: unexpected value type (expected tuple of strings, got %v)",
+				InvalidExpressionErrMessage,
+				value,
+			)
+		}
+	default:
+		// this should never happen since isSupportedKeywordListOperator should already fail
+		return nil, NewOperatorNotSupportedError(col.Alias, col.ValueType, operator)
+	}
+
+	// A KeywordList matches if it contains any of the values.
+	p := hasValue(col, func(v any) bool {
+		list, ok := v.([]string)
+		return ok && slices.ContainsFunc(list, func(item string) bool {
+			return slices.Contains(values, any(item))
+		})
+	})
+	if operator == sqlparser.NotEqualStr || operator == sqlparser.NotInStr {
+		return negate(p), nil
+	}
+	return p, nil
+}
+
+func (c *ValuesConverter) ConvertTextComparisonExpr(
+	operator string,
+	col *SAColumn,
+	value any,
+) (ValuesPredicate, error) {
+	text, ok := value.(string)
+	if !ok {
+		return nil, NewConverterError(
+			"%s: unexpected value type (expected string, got %v)",
+			InvalidExpressionErrMessage,
+			value,
+		)
+	}
+	textQuery, err := ParseTextQuery(text)
+	if err != nil {
+		return nil, err
+	}
+
+	p := hasValue(col, func(v any) bool {
+		s, ok := v.(string)
+		return ok && textQuery.Matches(s)
+	})
+	switch operator {
+	case sqlparser.EqualStr:
+		return p, nil
+	case sqlparser.NotEqualStr:
+		return negate(p), nil
+	default:
+		// this should never happen since isSupportedTextOperator should already fail
+		return nil, NewOperatorNotSupportedError(col.Alias, col.ValueType, operator)
+	}
+}
+
+func (c *ValuesConverter) ConvertRangeExpr(
+	operator string,
+	col *SAColumn,
+	from, to any,
+) (ValuesPredicate, error) {
+	from, err := parseValue(col, from)
+	if err != nil {
+		return nil, err
+	}
+	to, err = parseValue(col, to)
+	if err != nil {
+		return nil, err
+	}
+	p := hasValue(col, func(v any) bool {
+		fromRes, fromOK := CompareValues(v, from)
+		toRes, toOK := CompareValues(v, to)
+		return fromOK && toOK && fromRes >= 0 && toRes <= 0
+	})
+	switch operator {
+	case sqlparser.BetweenStr:
+		return p, nil
+	case sqlparser.NotBetweenStr:
+		return negate(p), nil
+	default:
+		return nil, NewOperatorNotSupportedError(col.Alias, col.ValueType, operator)
+	}
+}
+
+func (c *ValuesConverter) ConvertIsExpr(
+	operator string,
+	col *SAColumn,
+) (ValuesPredicate, error) {
+	p := hasValue(col, func(any) bool { return true })
+	switch operator {
+	case sqlparser.IsNotNullStr:
+		return p, nil
+	case sqlparser.IsNullStr:
+		return negate(p), nil
+	default:
+		return nil, NewOperatorNotSupportedError(col.Alias, col.ValueType, operator)
+	}
+}
+
+// hasValue returns a ValuesPredicate which is true if there is a value for the column which matches.
+func hasValue(col *SAColumn, match func(v any) bool) ValuesPredicate {
+	return func(values map[string]any) bool {
+		v, ok := values[col.FieldName]
+		return ok && v != nil && match(v)
+	}
+}
+
+func negate(p ValuesPredicate) ValuesPredicate {
+	if p == nil {
+		return func(map[string]any) bool { return false }
+	}
+	return func(values map[string]any) bool { return !p(values) }
+}
+
+func nonNil(exprs []ValuesPredicate) []ValuesPredicate {
+	return slices.DeleteFunc(slices.Clone(exprs), func(expr ValuesPredicate) bool { return expr == nil })
+}
+
+func first(exprs []ValuesPredicate) ValuesPredicate {
+	if len(exprs) == 0 {
+		return nil
+	}
+	return exprs[0]
+}
+
+// parseValue parses the values of Datetime search attributes, which the query converter formats as strings, so that
+// they can be compared with the values of the executions.
+func parseValue(col *SAColumn, value any) (any, error) {
+	if col.ValueType != enumspb.INDEXED_VALUE_TYPE_DATETIME {
+		return value, nil
+	}
+	switch v := value.(type) {
+	case string:
+		t, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return nil, NewConverterError(
+				"%s: unable to parse datetime '%s'",
+				InvalidExpressionErrMessage,
+				v,
+			)
+		}
+		return t, nil
+	case []any:
+		values := make([]any, len(v))
+		for i, item := range v {
+			var err error
+			values[i], err = parseValue(col, item)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return values, nil
+	default:
+		return value, nil
+	}
+}
+
+// CompareValues compares two values of a search attribute, as evaluated by ValuesConverter, and returns false if they
+// aren't comparable. Int and
+// Double values are comparable with each other.
+func CompareValues(a, b any) (int, bool) {
+	switch a := a.(type) {
+	case string:
+		b, ok := b.(string)
+		return strings.Compare(a, b), ok
+	case int64:
+		switch b := b.(type) {
+		case int64:
+			return cmp.Compare(a, b), true
+		case float64:
+			return cmp.Compare(float64(a), b), true
+		}
+	case float64:
+		switch b := b.(type) {
+		case int64:
+			return cmp.Compare(a, float64(b)), true
+		case float64:
+			return cmp.Compare(a, b), true
+		}
+	case bool:
+		b, ok := b.(bool)
+		if !ok || a == b {
+			return 0, ok
+		}
+		if a {
+			return 1, true
+		}
+		return -1, true
+	case time.Time:
+		b, ok := b.(time.Time)
+		return a.Compare(b), ok
+	case []string:
+		b, ok := b.([]string)
+		return slices.Compare(a, b), ok
+	}
+	return 0, false
+}
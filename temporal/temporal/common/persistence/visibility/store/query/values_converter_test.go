@@ -0,0 +1,119 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/server/common/searchattribute"
+	"go.temporal.io/server/common/searchattribute/sadefs"
+)
+
+func TestValuesConverter(t *testing.T) {
+	startTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	executions := map[string]map[string]any{
+		"running": {
+			sadefs.WorkflowID:      "wid-1",
+			sadefs.RunID:           "running",
+			sadefs.WorkflowType:    "type-a",
+			sadefs.StartTime:       startTime,
+			sadefs.ExecutionTime:   startTime,
+			sadefs.ExecutionStatus: enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING.String(),
+			"Keyword01":            "order-123",
+			"Int01":                int64(10),
+			"Text01":               "The quick brown fox",
+			"KeywordList01":        []string{"red", "green"},
+		},
+		"completed": {
+			sadefs.WorkflowID:        "wid-2",
+			sadefs.RunID:             "completed",
+			sadefs.WorkflowType:      "type-b",
+			sadefs.StartTime:         startTime.Add(time.Hour),
+			sadefs.ExecutionTime:     startTime.Add(time.Hour),
+			sadefs.CloseTime:         startTime.Add(2 * time.Hour),
+			sadefs.ExecutionDuration: int64(time.Hour),
+			sadefs.ExecutionStatus:   enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED.String(),
+			"Keyword01":              "invoice-456",
+			"Double01":               float64(2.5),
+			"Text01":                 "Lazy dogs",
+			"KeywordList01":          []string{"blue"},
+		},
+		"chasm": {
+			sadefs.WorkflowID:           "wid-3",
+			sadefs.RunID:                "chasm",
+			sadefs.WorkflowType:         "",
+			sadefs.StartTime:            startTime,
+			sadefs.ExecutionTime:        startTime,
+			sadefs.ExecutionStatus:      enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING.String(),
+			"TemporalNamespaceDivision": "1",
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		query    string
+		expected []string
+		err      string
+	}{
+		{name: "empty", query: "", expected: []string{"running", "completed"}},
+		{name: "equal", query: "WorkflowId = 'wid-1'", expected: []string{"running"}},
+		{name: "not equal", query: "WorkflowType != 'type-a'", expected: []string{"completed"}},
+		{name: "status", query: "ExecutionStatus = 'Completed'", expected: []string{"completed"}},
+		{name: "int", query: "Int01 >= 10", expected: []string{"running"}},
+		{name: "int against double", query: "Double01 > 2", expected: []string{"completed"}},
+		{name: "missing value", query: "Int01 < 10", expected: nil},
+		{name: "negated missing value", query: "Int01 != 5", expected: []string{"running", "completed"}},
+		{name: "in", query: "WorkflowId IN ('wid-1', 'wid-2')", expected: []string{"running", "completed"}},
+		{name: "not in", query: "WorkflowId NOT IN ('wid-1')", expected: []string{"completed"}},
+		{name: "starts with", query: "Keyword01 STARTS_WITH 'order-'", expected: []string{"running"}},
+		{name: "not starts with", query: "Keyword01 NOT STARTS_WITH 'order-'", expected: []string{"completed"}},
+		{name: "text token", query: "Text01 = 'QUICK'", expected: []string{"running"}},
+		{name: "text any token", query: "Text01 = 'fox dogs'", expected: []string{"running", "completed"}},
+		{name: "text phrase", query: `Text01 = '"quick brown"'`, expected: []string{"running"}},
+		{name: "text prefix and excluded", query: "Text01 = 'qui* la* -dogs'", expected: []string{"running"}},
+		{name: "text no tokens", query: "Text01 = '!!'", err: "no tokens found"},
+		{name: "keyword list contains", query: "KeywordList01 = 'green'", expected: []string{"running"}},
+		{name: "keyword list in", query: "KeywordList01 IN ('blue', 'yellow')", expected: []string{"completed"}},
+		{name: "keyword list not equal", query: "KeywordList01 != 'red'", expected: []string{"completed"}},
+		{
+			name:     "datetime between",
+			query:    "StartTime BETWEEN '2024-01-01T00:30:00Z' AND '2024-01-01T01:30:00Z'",
+			expected: []string{"completed"},
+		},
+		{name: "duration", query: "ExecutionDuration = '1h'", expected: []string{"completed"}},
+		{name: "is null", query: "CloseTime IS NULL", expected: []string{"running"}},
+		{name: "is not null", query: "CloseTime IS NOT NULL", expected: []string{"completed"}},
+		{
+			name:     "and or not",
+			query:    "(WorkflowId = 'wid-1' OR WorkflowId = 'wid-2') AND NOT (ExecutionStatus = 'Running')",
+			expected: []string{"completed"},
+		},
+		{name: "namespace division", query: "TemporalNamespaceDivision = '1'", expected: []string{"chasm"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			converter := NewQueryConverter[ValuesPredicate](
+				&ValuesConverter{},
+				"test-namespace",
+				searchattribute.TestNameTypeMap(),
+				searchattribute.NewNoopMapper(),
+			)
+			queryParams, err := converter.Convert(tc.query)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+
+			var matched []string
+			for _, runID := range []string{"running", "completed", "chasm"} {
+				if queryParams.QueryExpr == nil || queryParams.QueryExpr(executions[runID]) {
+					matched = append(matched, runID)
+				}
+			}
+			require.Equal(t, tc.expected, matched)
+		})
+	}
+}
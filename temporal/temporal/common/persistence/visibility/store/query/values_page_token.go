@@ -0,0 +1,84 @@
+package query
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+)
+
+type (
+	// pageToken is the token of the next page of SortPage, which starts after the last execution of the previous page.
+	pageToken struct {
+		// SortValues are the values of the sort fields of the last execution of the previous page.
+		SortValues []json.RawMessage
+	}
+)
+
+func serializePageToken(values []any) ([]byte, error) {
+	token := &pageToken{SortValues: make([]json.RawMessage, len(values))}
+	for i, value := range values {
+		var err error
+		token.SortValues[i], err = json.Marshal(value)
+		if err != nil {
+			return nil, serviceerror.NewInternalf("unable to serialize page token: %v", err)
+		}
+	}
+	return json.Marshal(token)
+}
+
+// deserializePageToken returns the sort values of the page token, or nil if there is no page token.
+func deserializePageToken(data []byte, fields []SortField) ([]any, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var token pageToken
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, serviceerror.NewInvalidArgumentf("unable to deserialize page token: %v", err)
+	}
+	if len(token.SortValues) != len(fields) {
+		return nil, serviceerror.NewInvalidArgument("invalid page token: the query of the page token is different")
+	}
+	values := make([]any, len(fields))
+	for i, field := range fields {
+		var err error
+		values[i], err = decodeSortValue(token.SortValues[i], field.ValueType)
+		if err != nil {
+			return nil, serviceerror.NewInvalidArgumentf("unable to deserialize page token: %v", err)
+		}
+	}
+	return values, nil
+}
+
+func decodeSortValue(data json.RawMessage, valueType enumspb.IndexedValueType) (any, error) {
+	if bytes.Equal(data, []byte("null")) {
+		return nil, nil
+	}
+	switch valueType {
+	case enumspb.INDEXED_VALUE_TYPE_INT:
+		return unmarshal[int64](data)
+	case enumspb.INDEXED_VALUE_TYPE_DOUBLE:
+		return unmarshal[float64](data)
+	case enumspb.INDEXED_VALUE_TYPE_BOOL:
+		return unmarshal[bool](data)
+	case enumspb.INDEXED_VALUE_TYPE_DATETIME:
+		return unmarshal[time.Time](data)
+	case enumspb.INDEXED_VALUE_TYPE_KEYWORD, enumspb.INDEXED_VALUE_TYPE_TEXT:
+		return unmarshal[string](data)
+	case enumspb.INDEXED_VALUE_TYPE_KEYWORD_LIST:
+		return unmarshal[[]string](data)
+	default:
+		return nil, fmt.Errorf("unknown search attribute type %v", valueType)
+	}
+}
+
+func unmarshal[T any](data json.RawMessage) (any, error) {
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
@@ -0,0 +1,28 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageToken(t *testing.T) {
+	fields := NewSortFields(nil)
+	values := []any{nil, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), "run-id"}
+
+	data, err := serializePageToken(values)
+	require.NoError(t, err)
+	decoded, err := deserializePageToken(data, fields)
+	require.NoError(t, err)
+	require.Equal(t, values, decoded)
+
+	_, err = deserializePageToken(data, fields[1:])
+	require.ErrorContains(t, err, "invalid page token")
+	_, err = deserializePageToken([]byte("invalid"), fields)
+	require.ErrorContains(t, err, "unable to deserialize page token")
+
+	decoded, err = deserializePageToken(nil, fields)
+	require.NoError(t, err)
+	require.Nil(t, decoded)
+}
@@ -0,0 +1,138 @@
+package query
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/temporalio/sqlparser"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/server/common/searchattribute/sadefs"
+)
+
+type (
+	// SortField is a search attribute by which the executions are sorted, when they are evaluated with
+	// ValuesConverter.
+	SortField struct {
+		FieldName string
+		ValueType enumspb.IndexedValueType
+		Desc      bool
+		// MissingFirst sorts the executions without a value first instead of last.
+		MissingFirst bool
+	}
+)
+
+// defaultSortFields sort the executions like the SQL visibility stores: open executions first, then by descending
+// close time and descending start time.
+var defaultSortFields = []SortField{
+	{FieldName: sadefs.CloseTime, ValueType: enumspb.INDEXED_VALUE_TYPE_DATETIME, Desc: true, MissingFirst: true},
+	{FieldName: sadefs.StartTime, ValueType: enumspb.INDEXED_VALUE_TYPE_DATETIME, Desc: true},
+}
+
+// runIDSortField is always the last sort field, so that the order of the executions is total, which pagination
+// requires.
+var runIDSortField = SortField{FieldName: sadefs.RunID, ValueType: enumspb.INDEXED_VALUE_TYPE_KEYWORD}
+
+// NewSortFields returns the sort fields of the ORDER BY clause of a converted query, or the default sort fields if
+// there is none. The run ID is always the last sort field.
+func NewSortFields(orderBy sqlparser.OrderBy) []SortField {
+	if len(orderBy) == 0 {
+		return append(slices.Clone(defaultSortFields), runIDSortField)
+	}
+	fields := make([]SortField, 0, len(orderBy)+1)
+	for _, order := range orderBy {
+		// the query converter replaces the ORDER BY expressions with search attribute columns
+		col, ok := order.Expr.(*SAColumn)
+		if !ok {
+			continue
+		}
+		fields = append(fields, SortField{
+			FieldName: col.FieldName,
+			ValueType: col.ValueType,
+			Desc:      strings.EqualFold(order.Direction, sqlparser.DescScr),
+		})
+	}
+	return append(fields, runIDSortField)
+}
+
+// SortPage sorts the executions by the sort fields, and returns the page of the executions which starts after the
+// page token, with at most pageSize executions unless pageSize isn't positive, and the token of the next page if there
+// are more executions. The values function returns the values of the search attributes of an execution by field name.
+func SortPage[T any](
+	executions []T,
+	values func(T) map[string]any,
+	fields []SortField,
+	pageSize int,
+	pageToken []byte,
+) ([]T, []byte, error) {
+	after, err := deserializePageToken(pageToken, fields)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	type sortedExecution struct {
+		execution  T
+		sortValues []any
+	}
+	sorted := make([]sortedExecution, len(executions))
+	for i, execution := range executions {
+		sorted[i] = sortedExecution{execution: execution, sortValues: sortValues(values(execution), fields)}
+	}
+	slices.SortFunc(sorted, func(a, b sortedExecution) int {
+		return compareSortValues(a.sortValues, b.sortValues, fields)
+	})
+	if after != nil {
+		start, _ := slices.BinarySearchFunc(sorted, after, func(e sortedExecution, after []any) int {
+			if compareSortValues(e.sortValues, after, fields) <= 0 {
+				return -1
+			}
+			return 1
+		})
+		sorted = sorted[start:]
+	}
+
+	var nextPageToken []byte
+	if pageSize > 0 && len(sorted) > pageSize {
+		sorted = sorted[:pageSize]
+		nextPageToken, err = serializePageToken(sorted[len(sorted)-1].sortValues)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	page := make([]T, len(sorted))
+	for i, e := range sorted {
+		page[i] = e.execution
+	}
+	return page, nextPageToken, nil
+}
+
+// sortValues returns the values of the sort fields of an execution, which are nil if the execution has no value.
+func sortValues(values map[string]any, fields []SortField) []any {
+	sortValues := make([]any, len(fields))
+	for i, field := range fields {
+		sortValues[i] = values[field.FieldName]
+	}
+	return sortValues
+}
+
+// compareSortValues compares the sort values of two executions.
+func compareSortValues(a, b []any, fields []SortField) int {
+	for i, field := range fields {
+		switch {
+		case a[i] == nil && b[i] == nil:
+			continue
+		case a[i] == nil || b[i] == nil:
+			if (a[i] == nil) == field.MissingFirst {
+				return -1
+			}
+			return 1
+		}
+		res, _ := CompareValues(a[i], b[i])
+		if field.Desc {
+			res = -res
+		}
+		if res != 0 {
+			return res
+		}
+	}
+	return 0
+}
@@ -0,0 +1,60 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/temporalio/sqlparser"
+	enumspb "go.temporal.io/api/enums/v1"
+)
+
+func TestCompareSortValues(t *testing.T) {
+	fields := NewSortFields(nil)
+	open := []any{nil, time.Unix(1, 0), "b"}
+	closedEarly := []any{time.Unix(2, 0), time.Unix(1, 0), "a"}
+	closedLate := []any{time.Unix(3, 0), time.Unix(1, 0), "c"}
+
+	require.Negative(t, compareSortValues(open, closedLate, fields))
+	require.Negative(t, compareSortValues(closedLate, closedEarly, fields))
+	require.Zero(t, compareSortValues(closedEarly, closedEarly, fields))
+	require.Positive(t, compareSortValues(closedEarly, open, fields))
+}
+
+func TestSortPage(t *testing.T) {
+	executions := []map[string]any{
+		{"RunId": "a", "Int01": int64(2)},
+		{"RunId": "b", "Int01": int64(1)},
+		{"RunId": "c"},
+		{"RunId": "d", "Int01": int64(2)},
+	}
+	fields := NewSortFields(sqlparser.OrderBy{
+		{
+			Expr:      &SAColumn{FieldName: "Int01", ValueType: enumspb.INDEXED_VALUE_TYPE_INT},
+			Direction: sqlparser.DescScr,
+		},
+	})
+	values := func(execution map[string]any) map[string]any { return execution }
+	runIDs := func(page []map[string]any) []string {
+		var res []string
+		for _, execution := range page {
+			res = append(res, execution["RunId"].(string))
+		}
+		return res
+	}
+
+	page, token, err := SortPage(executions, values, fields, 2, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "d"}, runIDs(page))
+	require.NotNil(t, token)
+
+	page, token, err = SortPage(executions, values, fields, 2, token)
+	require.NoError(t, err)
+	require.Equal(t, []string{"b", "c"}, runIDs(page))
+	require.Nil(t, token)
+
+	page, token, err = SortPage(executions, values, fields, 0, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "d", "b", "c"}, runIDs(page))
+	require.Nil(t, token)
+}
@@ -218,11 +218,19 @@ func (c *SQLQueryConverter) ConvertTextComparisonExpr(
 	col *query.SAColumn,
 	value any,
 ) (sqlparser.Expr, error) {
-	valueExpr, err := c.buildValueExpr(col.FieldName, value)
+	text, ok := value.(string)
+	if !ok {
+		return nil, query.NewConverterError(
+			"%s: unexpected value type (expected string, got %v)",
+			query.InvalidExpressionErrMessage,
+			value,
+		)
+	}
+	textQuery, err := query.ParseTextQuery(text)
 	if err != nil {
 		return nil, err
 	}
-	return c.VisibilityQueryConverter.ConvertTextComparisonExpr(operator, col, valueExpr)
+	return c.VisibilityQueryConverter.ConvertTextComparisonExpr(operator, col, textQuery)
 }
 
 func (c *SQLQueryConverter) ConvertRangeExpr(
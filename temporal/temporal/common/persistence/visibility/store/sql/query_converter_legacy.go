@@ -161,6 +161,20 @@ func (c *QueryConverterLegacy) BuildCountStmt() (*sqlplugin.VisibilitySelectFilt
 	}, nil
 }
 
+// BuildTotalCountStmt builds the statement which counts all the executions which match the query, regardless of its
+// GROUP BY fields.
+func (c *QueryConverterLegacy) BuildTotalCountStmt() (*sqlplugin.VisibilitySelectFilter, error) {
+	qp, err := c.convertWhereString(c.queryString)
+	if err != nil {
+		return nil, err
+	}
+	queryString, queryArgs := c.buildCountStmt(c.namespaceID, qp.queryString, nil)
+	return &sqlplugin.VisibilitySelectFilter{
+		Query:     queryString,
+		QueryArgs: queryArgs,
+	}, nil
+}
+
 func (c *QueryConverterLegacy) convertWhereString(queryString string) (*queryParamsLegacy, error) {
 	where := strings.TrimSpace(queryString)
 	if where != "" &&
@@ -259,22 +273,13 @@ func (c *QueryConverterLegacy) convertSelectStmt(sel *sqlparser.Select) error {
 		}
 	}
 
-	if len(sel.GroupBy) > 1 {
-		return query.NewConverterError(
-			"%s: 'GROUP BY' clause supports only a single field",
-			query.NotSupportedErrMessage,
-		)
-	}
 	for k := range sel.GroupBy {
 		colName, err := c.convertColName(&sel.GroupBy[k])
 		if err != nil {
 			return err
 		}
-		if !query.IsGroupByFieldAllowed(colName.fieldName) {
-			return query.NewConverterError(
-				"%s: 'GROUP BY' clause is only supported for ExecutionStatus",
-				query.NotSupportedErrMessage,
-			)
+		if !query.IsGroupByFieldAllowed(colName.fieldName, colName.valueType) {
+			return query.NewGroupByFieldNotAllowedError(colName.alias)
 		}
 	}
 
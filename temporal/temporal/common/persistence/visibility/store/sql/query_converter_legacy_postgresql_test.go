@@ -114,6 +114,12 @@ func (s *postgresqlQueryConverterSuite) TestConvertTextComparisonExpr() {
 			output: "not Text01 @@ 'foo | bar'::tsquery",
 			err:    nil,
 		},
+		{
+			name:   "valid expression with punctuation",
+			input:  "AliasForText01 = 'Foo-Bar'",
+			output: "Text01 @@ 'foo | bar'::tsquery",
+			err:    nil,
+		},
 	}
 
 	for _, tc := range tests {
@@ -171,7 +171,7 @@ func (c *sqliteQueryConverter) convertTextComparisonExpr(
 			sqlparser.String(expr.Right),
 		)
 	}
-	tokens := tokenizeTextQueryString(valueExpr.Val)
+	tokens := query.TokenizeText(valueExpr.Val)
 	if len(tokens) == 0 {
 		return nil, query.NewConverterError(
 			"%s: unexpected value for Text type search attribute (no tokens found in %s)",
@@ -108,22 +108,25 @@ func (s *queryConverterSuite) TestConvertWhereString() {
 			err: nil,
 		},
 		{
-			name:   "group by two fields not supported",
-			input:  "GROUP BY ExecutionStatus, WorkflowType",
+			name:  "group by multiple fields",
+			input: "GROUP BY ExecutionStatus, WorkflowType, AliasForKeyword01",
+			output: &queryParamsLegacy{
+				queryString: "TemporalNamespaceDivision is null",
+				groupBy:     []string{sadefs.ExecutionStatus, sadefs.WorkflowType, "Keyword01"},
+			},
+			err: nil,
+		},
+		{
+			name:   "group by unique field not supported",
+			input:  "GROUP BY WorkflowId",
 			output: nil,
-			err: query.NewConverterError(
-				"%s: 'GROUP BY' clause supports only a single field",
-				query.NotSupportedErrMessage,
-			),
+			err:    query.NewGroupByFieldNotAllowedError(sadefs.WorkflowID),
 		},
 		{
-			name:   "group by non ExecutionStatus",
-			input:  "GROUP BY WorkflowType",
+			name:   "group by non keyword field not supported",
+			input:  "GROUP BY AliasForInt01",
 			output: nil,
-			err: query.NewConverterError(
-				"%s: 'GROUP BY' clause is only supported for ExecutionStatus",
-				query.NotSupportedErrMessage,
-			),
+			err:    query.NewGroupByFieldNotAllowedError("AliasForInt01"),
 		},
 		{
 			name:   "order by not supported",
@@ -65,7 +65,7 @@ func (c *dummyVisQC) ConvertKeywordListComparisonExpr(
 func (c *dummyVisQC) ConvertTextComparisonExpr(
 	operator string,
 	col *query.SAColumn,
-	value sqlparser.Expr,
+	textQuery *query.TextQuery,
 ) (sqlparser.Expr, error) {
 	c.convertTextComparisonExprCalls++
 	return nil, c.err
@@ -104,6 +104,20 @@ func (c *dummyVisQC) BuildCountStmt(
 	return "", nil
 }
 
+func (c *dummyVisQC) BuildValuesStmt(
+	queryExpr *query.QueryParams[sqlparser.Expr],
+	fields []string,
+) (string, []any) {
+	return "", nil
+}
+
+func (c *dummyVisQC) BuildAggregateStmt(
+	queryExpr *query.QueryParams[sqlparser.Expr],
+	aggregations []*query.Aggregation,
+) (string, []any) {
+	return "", nil
+}
+
 func TestSQLQueryConverter_GetDatetimeFormat(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
@@ -638,37 +652,51 @@ func TestSQLQueryConverter_ConvertTextComparisonExpr(t *testing.T) {
 		operator  string
 		col       *query.SAColumn
 		value     any
-		valueExpr sqlparser.Expr
+		textQuery *query.TextQuery
 		mockErr   error
 		errString string
 	}{
 		{
-			name:      "a = 'foo'",
-			operator:  sqlparser.EqualStr,
-			col:       query.NewSAColumn("a", "a", enumspb.INDEXED_VALUE_TYPE_TEXT),
-			value:     "foo",
-			valueExpr: query.NewUnsafeSQLString("foo"),
+			name:     "a = 'foo'",
+			operator: sqlparser.EqualStr,
+			col:      query.NewSAColumn("a", "a", enumspb.INDEXED_VALUE_TYPE_TEXT),
+			value:    "foo",
+			textQuery: &query.TextQuery{
+				Optional: []query.TextTerm{{Tokens: []string{"foo"}}},
+			},
 		},
 		{
-			name:      "a = 123",
-			operator:  sqlparser.EqualStr,
-			col:       query.NewSAColumn("a", "a", enumspb.INDEXED_VALUE_TYPE_TEXT),
-			value:     int64(123),
-			valueExpr: sqlparser.NewIntVal([]byte("123")),
+			name:     "a = '+\"foo bar\" -baz*'",
+			operator: sqlparser.EqualStr,
+			col:      query.NewSAColumn("a", "a", enumspb.INDEXED_VALUE_TYPE_TEXT),
+			value:    `+"foo bar" -baz*`,
+			textQuery: &query.TextQuery{
+				Required: []query.TextTerm{{Tokens: []string{"foo", "bar"}}},
+				Excluded: []query.TextTerm{{Tokens: []string{"baz"}, Prefix: true}},
+			},
 		},
 		{
 			name:      "unexpected type a = 123",
 			operator:  sqlparser.EqualStr,
 			col:       query.NewSAColumn("a", "a", enumspb.INDEXED_VALUE_TYPE_TEXT),
-			value:     123,
+			value:     int64(123),
 			errString: query.InvalidExpressionErrMessage,
 		},
 		{
-			name:      "mock error",
+			name:      "no tokens a = '!!'",
 			operator:  sqlparser.EqualStr,
 			col:       query.NewSAColumn("a", "a", enumspb.INDEXED_VALUE_TYPE_TEXT),
-			value:     "foo",
-			valueExpr: query.NewUnsafeSQLString("foo"),
+			value:     "!!",
+			errString: "no tokens found",
+		},
+		{
+			name:     "mock error",
+			operator: sqlparser.EqualStr,
+			col:      query.NewSAColumn("a", "a", enumspb.INDEXED_VALUE_TYPE_TEXT),
+			value:    "foo",
+			textQuery: &query.TextQuery{
+				Optional: []query.TextTerm{{Tokens: []string{"foo"}}},
+			},
 			mockErr:   query.NewConverterError("%s", query.InvalidExpressionErrMessage),
 			errString: query.InvalidExpressionErrMessage,
 		},
@@ -683,8 +711,8 @@ func TestSQLQueryConverter_ConvertTextComparisonExpr(t *testing.T) {
 				VisibilityQueryConverter: pluginVisQCMock,
 			}
 
-			if tc.valueExpr != nil {
-				pluginVisQCMock.EXPECT().ConvertTextComparisonExpr(tc.operator, tc.col, tc.valueExpr).
+			if tc.textQuery != nil {
+				pluginVisQCMock.EXPECT().ConvertTextComparisonExpr(tc.operator, tc.col, tc.textQuery).
 					Return(nil, tc.mockErr)
 			}
 			_, err := queryConverter.ConvertTextComparisonExpr(tc.operator, tc.col, tc.value)
@@ -693,6 +721,8 @@ func TestSQLQueryConverter_ConvertTextComparisonExpr(t *testing.T) {
 				var expectedErr *query.ConverterError
 				r.ErrorAs(err, &expectedErr)
 				r.ErrorContains(err, tc.errString)
+			} else {
+				r.NoError(err)
 			}
 		})
 	}
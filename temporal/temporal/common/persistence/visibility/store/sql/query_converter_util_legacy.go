@@ -1,7 +1,6 @@
 package sql
 
 import (
-	"strings"
 	"time"
 
 	"github.com/temporalio/sqlparser"
@@ -119,18 +118,6 @@ func formatComparisonExprStringForError(expr sqlparser.ComparisonExpr) string {
 	return sqlparser.String(&expr)
 }
 
-// Simple tokenizer by spaces. It's a temporary solution as it doesn't cover tokenizer used by
-// PostgreSQL or SQLite.
-func tokenizeTextQueryString(s string) []string {
-	nonEmptyTokens := make([]string, 0, strings.Count(s, " ")+1)
-	for token := range strings.SplitSeq(s, " ") {
-		if token != "" {
-			nonEmptyTokens = append(nonEmptyTokens, token)
-		}
-	}
-	return nonEmptyTokens
-}
-
 func getUnsafeStringTupleValues(valTuple sqlparser.ValTuple) ([]string, error) {
 	values := make([]string, len(valTuple))
 	for i, val := range valTuple {
@@ -1,9 +1,12 @@
 package sql
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strconv"
 	"time"
 
 	"github.com/temporalio/sqlparser"
@@ -38,6 +41,7 @@ type (
 		logger                         log.Logger
 
 		enableUnifiedQueryConverter dynamicconfig.BoolPropertyFn
+		countGroupByMaxGroups       dynamicconfig.IntPropertyFnWithNamespaceFilter
 	}
 
 	listExecutionsRequestInternal struct {
@@ -55,6 +59,10 @@ var _ store.VisibilityStore = (*VisibilityStore)(nil)
 
 var maxDatetime, _ = time.Parse(time.RFC3339, "9999-12-31T23:59:59Z")
 
+// maxPercentileExecutions is the max number of executions whose values are read to compute percentile aggregations,
+// which are computed in memory.
+const maxPercentileExecutions = 10000
+
 // NewSQLVisibilityStore creates an instance of VisibilityStore
 func NewSQLVisibilityStore(
 	cfg config.SQL,
@@ -63,6 +71,7 @@ func NewSQLVisibilityStore(
 	searchAttributesMapperProvider searchattribute.MapperProvider,
 	chasmRegistry *chasm.Registry,
 	enableUnifiedQueryConverter dynamicconfig.BoolPropertyFn,
+	countGroupByMaxGroups dynamicconfig.IntPropertyFnWithNamespaceFilter,
 	logger log.Logger,
 	metricsHandler metrics.Handler,
 	serializer serialization.Serializer,
@@ -81,6 +90,7 @@ func NewSQLVisibilityStore(
 		logger:                         logger,
 
 		enableUnifiedQueryConverter: enableUnifiedQueryConverter,
+		countGroupByMaxGroups:       countGroupByMaxGroups,
 	}, nil
 }
 
@@ -195,6 +205,8 @@ func (s *VisibilityStore) ListWorkflowExecutions(
 	ctx context.Context,
 	request *manager.ListWorkflowExecutionsRequestV2,
 ) (*store.InternalListExecutionsResponse, error) {
+	// visibility is eventually consistent, so listing may be served by a read replica
+	ctx = persistence.WithStaleReadsAllowed(ctx)
 	if s.enableUnifiedQueryConverter() {
 		return s.listWorkflowExecutions(ctx, request)
 	}
@@ -205,6 +217,7 @@ func (s *VisibilityStore) ListChasmExecutions(
 	ctx context.Context,
 	request *manager.ListChasmExecutionsRequest,
 ) (*store.InternalListExecutionsResponse, error) {
+	ctx = persistence.WithStaleReadsAllowed(ctx)
 	rc, ok := s.chasmRegistry.ComponentByID(request.ArchetypeID)
 	if !ok {
 		return nil, serviceerror.NewInvalidArgument(fmt.Sprintf("unknown archetype ID: %d", request.ArchetypeID))
@@ -232,6 +245,7 @@ func (s *VisibilityStore) CountChasmExecutions(
 	ctx context.Context,
 	request *manager.CountChasmExecutionsRequest,
 ) (*store.InternalCountExecutionsResponse, error) {
+	ctx = persistence.WithStaleReadsAllowed(ctx)
 	rc, ok := s.chasmRegistry.ComponentByID(request.ArchetypeID)
 	if !ok {
 		return nil, serviceerror.NewInvalidArgument(fmt.Sprintf("unknown archetype ID: %d", request.ArchetypeID))
@@ -285,7 +299,8 @@ func (s *VisibilityStore) countChasmExecutions(
 	selectFilter := s.buildSelectFilterFromQueryParams(queryParams, sqlQC)
 
 	if len(selectFilter.GroupBy) > 0 {
-		return s.countGroupByExecutions(ctx, selectFilter, mapper)
+		countFilter := s.buildCountFilterFromQueryParams(queryParams, sqlQC)
+		return s.countGroupByExecutions(ctx, request.Namespace, selectFilter, countFilter, mapper)
 	}
 
 	count, err := s.sqlStore.DB.CountFromVisibility(ctx, *selectFilter)
@@ -332,7 +347,11 @@ func (s *VisibilityStore) countChasmExecutionsLegacy(
 	}
 
 	if len(selectFilter.GroupBy) > 0 {
-		return s.countGroupByExecutions(ctx, selectFilter, mapper)
+		countFilter, err := converter.BuildTotalCountStmt()
+		if err != nil {
+			return nil, err
+		}
+		return s.countGroupByExecutions(ctx, request.Namespace, selectFilter, countFilter, mapper)
 	}
 
 	count, err := s.sqlStore.DB.CountFromVisibility(ctx, *selectFilter)
@@ -540,6 +559,7 @@ func (s *VisibilityStore) CountWorkflowExecutions(
 	ctx context.Context,
 	request *manager.CountWorkflowExecutionsRequest,
 ) (*store.InternalCountExecutionsResponse, error) {
+	ctx = persistence.WithStaleReadsAllowed(ctx)
 	if s.enableUnifiedQueryConverter() {
 		return s.countWorkflowExecutions(ctx, request)
 	}
@@ -581,7 +601,11 @@ func (s *VisibilityStore) countWorkflowExecutionsLegacy(
 	}
 
 	if len(selectFilter.GroupBy) > 0 {
-		return s.countGroupByExecutions(ctx, selectFilter, nil)
+		countFilter, err := converter.BuildTotalCountStmt()
+		if err != nil {
+			return nil, err
+		}
+		return s.countGroupByExecutions(ctx, request.Namespace, selectFilter, countFilter, nil)
 	}
 
 	count, err := s.sqlStore.DB.CountFromVisibility(ctx, *selectFilter)
@@ -634,7 +658,8 @@ func (s *VisibilityStore) countWorkflowExecutions(
 	selectFilter := s.buildSelectFilterFromQueryParams(queryParams, sqlQC)
 
 	if len(selectFilter.GroupBy) > 0 {
-		return s.countGroupByExecutions(ctx, selectFilter, nil)
+		countFilter := s.buildCountFilterFromQueryParams(queryParams, sqlQC)
+		return s.countGroupByExecutions(ctx, request.Namespace, selectFilter, countFilter, nil)
 	}
 
 	count, err := s.sqlStore.DB.CountFromVisibility(ctx, *selectFilter)
@@ -689,15 +714,44 @@ func (s *VisibilityStore) buildSelectFilterFromQueryParams(
 	}
 }
 
+// buildCountFilterFromQueryParams returns the filter which counts all the executions which match the query,
+// regardless of its GROUP BY fields.
+func (s *VisibilityStore) buildCountFilterFromQueryParams(
+	queryParams *query.QueryParams[sqlparser.Expr],
+	sqlQC *SQLQueryConverter,
+) *sqlplugin.VisibilitySelectFilter {
+	countParams := *queryParams
+	countParams.GroupBy = nil
+	queryString, queryArgs := sqlQC.BuildCountStmt(&countParams)
+	return &sqlplugin.VisibilitySelectFilter{
+		Query:     queryString,
+		QueryArgs: queryArgs,
+	}
+}
+
+// countGroupByExecutions counts the executions of the groups of selectFilter. If the groups are truncated, the total
+// count is read with countFilter, since it is more than the sum of the returned groups.
 func (s *VisibilityStore) countGroupByExecutions(
 	ctx context.Context,
+	namespaceName namespace.Name,
 	selectFilter *sqlplugin.VisibilitySelectFilter,
+	countFilter *sqlplugin.VisibilitySelectFilter,
 	chasmMapper *chasm.VisibilitySearchAttributesMapper,
 ) (*store.InternalCountExecutionsResponse, error) {
-	rows, err := s.sqlStore.DB.CountGroupByFromVisibility(ctx, *selectFilter)
+	maxGroups := s.countGroupByMaxGroups(namespaceName.String())
+	filter := *selectFilter
+	if maxGroups > 0 {
+		// Only the groups with the highest counts are read. One more group is read to know if any is left out.
+		filter.Query += fmt.Sprintf(" ORDER BY COUNT(*) DESC LIMIT %d", maxGroups+1)
+	}
+	rows, err := s.sqlStore.DB.CountGroupByFromVisibility(ctx, filter)
 	if err != nil {
 		return nil, convertSQLError("CountExecutions operation failed.", err)
 	}
+	truncated := maxGroups > 0 && len(rows) > maxGroups
+	if truncated {
+		rows = rows[:maxGroups]
+	}
 
 	groupByTypes, err := s.getGroupByFieldTypes(selectFilter.GroupBy, chasmMapper)
 	if err != nil {
@@ -705,8 +759,9 @@ func (s *VisibilityStore) countGroupByExecutions(
 	}
 
 	resp := &store.InternalCountExecutionsResponse{
-		Count:  0,
-		Groups: make([]store.InternalAggregationGroup, 0, len(rows)),
+		Count:     0,
+		Groups:    make([]store.InternalAggregationGroup, 0, len(rows)),
+		Truncated: truncated,
 	}
 	for _, row := range rows {
 		groupValues := make([]*commonpb.Payload, len(row.GroupValues))
@@ -725,9 +780,234 @@ func (s *VisibilityStore) countGroupByExecutions(
 		)
 		resp.Count += row.Count
 	}
+	if truncated {
+		resp.Count, err = s.sqlStore.DB.CountFromVisibility(ctx, *countFilter)
+		if err != nil {
+			return nil, convertSQLError("CountExecutions operation failed.", err)
+		}
+	}
 	return resp, nil
 }
 
+func (s *VisibilityStore) AggregateWorkflowExecutions(
+	ctx context.Context,
+	request *manager.AggregateWorkflowExecutionsRequest,
+) (*store.InternalAggregateExecutionsResponse, error) {
+	ctx = persistence.WithStaleReadsAllowed(ctx)
+	sqlQC, err := NewSQLQueryConverter(s.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	saTypeMap, err := s.searchAttributesProvider.GetSearchAttributes(s.GetIndexName(), false)
+	if err != nil {
+		return nil, err
+	}
+
+	saMapper, err := s.searchAttributesMapperProvider.GetMapper(request.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	queryParams, aggregations, err := buildAggregationQueryParams(
+		request.NamespaceID,
+		request.Namespace,
+		request.Query,
+		request.Aggregations,
+		sqlQC,
+		saTypeMap,
+		saMapper,
+	)
+	if err != nil {
+		// Convert ConverterError to InvalidArgument and pass through all other errors (which should be
+		// only mapper errors).
+		var converterErr *query.ConverterError
+		if errors.As(err, &converterErr) {
+			return nil, converterErr.ToInvalidArgument()
+		}
+		return nil, err
+	}
+
+	// Not all the databases support percentiles, so they are computed from the values of the matching executions,
+	// and the other aggregations are computed by the database.
+	var dbAggregations, percentileAggregations []*query.Aggregation
+	for _, agg := range aggregations {
+		if agg.Function == query.AggregationPercentile {
+			percentileAggregations = append(percentileAggregations, agg)
+		} else {
+			dbAggregations = append(dbAggregations, agg)
+		}
+	}
+	aggregateFilter := &sqlplugin.VisibilitySelectFilter{
+		GroupBy: make([]string, 0, len(queryParams.GroupBy)),
+		Fields:  make([]string, 0, len(dbAggregations)),
+	}
+	for _, field := range queryParams.GroupBy {
+		aggregateFilter.GroupBy = append(aggregateFilter.GroupBy, field.FieldName)
+	}
+	for _, agg := range dbAggregations {
+		aggregateFilter.Fields = append(aggregateFilter.Fields, agg.Column.FieldName)
+	}
+	aggregateFilter.Query, aggregateFilter.QueryArgs = sqlQC.BuildAggregateStmt(queryParams, dbAggregations)
+	maxGroups := s.countGroupByMaxGroups(request.Namespace.String())
+	if maxGroups > 0 && len(queryParams.GroupBy) > 0 {
+		// Only the groups with the highest counts are read. One more group is read to know if any is left out.
+		aggregateFilter.Query += fmt.Sprintf(" ORDER BY COUNT(*) DESC LIMIT %d", maxGroups+1)
+	}
+
+	rows, err := s.sqlStore.DB.AggregateFromVisibility(ctx, *aggregateFilter)
+	if err != nil {
+		return nil, convertSQLError("AggregateWorkflowExecutions operation failed.", err)
+	}
+	truncated := maxGroups > 0 && len(rows) > maxGroups
+	if truncated {
+		rows = rows[:maxGroups]
+	}
+
+	resp := &store.InternalAggregateExecutionsResponse{
+		Groups:    make([]store.InternalAggregationGroup, 0, len(rows)),
+		Truncated: truncated,
+	}
+	for _, row := range rows {
+		resp.Count += row.Count
+	}
+	if truncated {
+		countFilter := s.buildCountFilterFromQueryParams(queryParams, sqlQC)
+		resp.Count, err = s.sqlStore.DB.CountFromVisibility(ctx, *countFilter)
+		if err != nil {
+			return nil, convertSQLError("AggregateWorkflowExecutions operation failed.", err)
+		}
+	}
+
+	var percentileValues map[string][][]float64
+	if len(percentileAggregations) > 0 {
+		percentileValues, err = s.selectPercentileValues(ctx, queryParams, sqlQC, percentileAggregations, resp.Count)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	groupByTypes, err := s.getGroupByFieldTypes(aggregateFilter.GroupBy, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		groupValues := make([]*commonpb.Payload, len(row.GroupValues))
+		for i, val := range row.GroupValues {
+			groupValues[i], err = searchattribute.EncodeValue(val, groupByTypes[i])
+			if err != nil {
+				return nil, err
+			}
+		}
+		groupPercentileValues := percentileValues[aggregationGroupKey(row.GroupValues)]
+		values := make([]*commonpb.Payload, len(aggregations))
+		var dbIndex, percentileIndex int
+		for i, agg := range aggregations {
+			var value *float64
+			if agg.Function == query.AggregationPercentile {
+				if groupPercentileValues != nil && len(groupPercentileValues[percentileIndex]) > 0 {
+					v := query.Percentile(groupPercentileValues[percentileIndex], agg.Percentile)
+					value = &v
+				}
+				percentileIndex++
+			} else {
+				value, err = parseAggregateResult(row.Values[dbIndex], agg)
+				if err != nil {
+					return nil, serviceerror.NewInternalf("unable to parse value of %s: %v", agg, err)
+				}
+				dbIndex++
+			}
+			values[i], err = agg.EncodeValue(value)
+			if err != nil {
+				return nil, serviceerror.NewInternal(err.Error())
+			}
+		}
+		resp.Groups = append(resp.Groups, store.InternalAggregationGroup{
+			GroupValues: groupValues,
+			Count:       row.Count,
+			Values:      values,
+		})
+	}
+	slices.SortStableFunc(resp.Groups, func(a, b store.InternalAggregationGroup) int {
+		return cmp.Compare(b.Count, a.Count)
+	})
+	return resp, nil
+}
+
+// selectPercentileValues reads the values of the search attributes of the percentile aggregations of the matching
+// executions, by group. The values of a group are in the order of the aggregations. The query is rejected if it
+// matches more than maxPercentileExecutions executions, since all their values are held in memory.
+func (s *VisibilityStore) selectPercentileValues(
+	ctx context.Context,
+	queryParams *query.QueryParams[sqlparser.Expr],
+	sqlQC *SQLQueryConverter,
+	aggregations []*query.Aggregation,
+	count int64,
+) (map[string][][]float64, error) {
+	if count > maxPercentileExecutions {
+		return nil, serviceerror.NewInvalidArgumentf(
+			"percentile aggregations are limited to queries which match at most %d executions, got %d",
+			maxPercentileExecutions,
+			count,
+		)
+	}
+
+	selectFilter := &sqlplugin.VisibilitySelectFilter{
+		GroupBy: make([]string, 0, len(queryParams.GroupBy)),
+		Fields:  make([]string, 0, len(aggregations)),
+	}
+	for _, field := range queryParams.GroupBy {
+		selectFilter.GroupBy = append(selectFilter.GroupBy, field.FieldName)
+	}
+	for _, agg := range aggregations {
+		selectFilter.Fields = append(selectFilter.Fields, agg.Column.FieldName)
+	}
+	selectFilter.Query, selectFilter.QueryArgs = sqlQC.BuildValuesStmt(queryParams, selectFilter.Fields)
+	// The executions may have changed since they were counted.
+	selectFilter.Query += fmt.Sprintf(" LIMIT %d", maxPercentileExecutions+1)
+
+	rows, err := s.sqlStore.DB.SelectValuesFromVisibility(ctx, *selectFilter)
+	if err != nil {
+		return nil, convertSQLError("AggregateWorkflowExecutions operation failed.", err)
+	}
+	if len(rows) > maxPercentileExecutions {
+		return nil, serviceerror.NewInvalidArgumentf(
+			"percentile aggregations are limited to queries which match at most %d executions",
+			maxPercentileExecutions,
+		)
+	}
+
+	res := make(map[string][][]float64)
+	for _, row := range rows {
+		key := aggregationGroupKey(row.GroupValues)
+		groupValues, ok := res[key]
+		if !ok {
+			groupValues = make([][]float64, len(aggregations))
+			res[key] = groupValues
+		}
+		for i, agg := range aggregations {
+			value, err := parseAggregationValue(row.Values[i], agg.Column.ValueType)
+			if err != nil {
+				return nil, serviceerror.NewInternalf("unable to parse value of %s: %v", agg, err)
+			}
+			switch v := value.(type) {
+			case int64:
+				groupValues[i] = append(groupValues[i], float64(v))
+			case float64:
+				groupValues[i] = append(groupValues[i], v)
+			case time.Time:
+				groupValues[i] = append(groupValues[i], float64(v.UnixNano()))
+			}
+		}
+	}
+	return res, nil
+}
+
+// aggregationGroupKey returns the key of the group of the values of the GROUP BY fields.
+func aggregationGroupKey(groupValues []any) string {
+	return fmt.Sprintf("%#v", groupValues)
+}
+
 func (s *VisibilityStore) GetWorkflowExecution(
 	ctx context.Context,
 	request *manager.GetWorkflowExecutionRequest,
@@ -924,6 +1204,235 @@ func (s *VisibilityStore) AddSearchAttributes(
 	return serviceerror.NewUnimplemented("AddSearchAttributes operation not supported in SQL visibility")
 }
 
+func (s *VisibilityStore) ExplainQuery(
+	ctx context.Context,
+	request *manager.ExplainQueryRequest,
+) (*manager.ExplainQueryResponse, error) {
+	saTypeMap, err := s.searchAttributesProvider.GetSearchAttributes(s.GetIndexName(), false)
+	if err != nil {
+		return nil, err
+	}
+
+	saMapper, err := s.searchAttributesMapperProvider.GetMapper(request.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	explanation, err := query.ExplainQuery(request.Query, request.Namespace, saTypeMap, saMapper)
+	if err != nil {
+		// Convert ConverterError to InvalidArgument and pass through all other errors (which should be
+		// only mapper errors).
+		var converterErr *query.ConverterError
+		if errors.As(err, &converterErr) {
+			return nil, converterErr.ToInvalidArgument()
+		}
+		return nil, err
+	}
+
+	var selectFilter *sqlplugin.VisibilitySelectFilter
+	if s.enableUnifiedQueryConverter() {
+		selectFilter, err = s.buildExplainSelectFilter(request, saTypeMap, saMapper)
+	} else {
+		selectFilter, err = s.buildExplainSelectFilterLegacy(request, saTypeMap, saMapper)
+	}
+	if err != nil {
+		var converterErr *query.ConverterError
+		if errors.As(err, &converterErr) {
+			return nil, converterErr.ToInvalidArgument()
+		}
+		return nil, err
+	}
+	return s.explainSelectFilter(ctx, request, explanation, selectFilter)
+}
+
+// buildExplainSelectFilter builds the statement which counts the executions if the query has a GROUP BY clause,
+// and which lists the first page of executions otherwise.
+func (s *VisibilityStore) buildExplainSelectFilter(
+	request *manager.ExplainQueryRequest,
+	saTypeMap searchattribute.NameTypeMap,
+	saMapper searchattribute.Mapper,
+) (*sqlplugin.VisibilitySelectFilter, error) {
+	sqlQC, err := NewSQLQueryConverter(s.GetName())
+	if err != nil {
+		return nil, err
+	}
+	queryParams, err := buildQueryParams(
+		request.NamespaceID,
+		request.Namespace,
+		request.Query,
+		sqlQC,
+		saTypeMap,
+		saMapper,
+		nil,
+		chasm.UnspecifiedArchetypeID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(queryParams.GroupBy) > 0 {
+		return s.buildSelectFilterFromQueryParams(queryParams, sqlQC), nil
+	}
+	sqlQueryString, queryArgs := sqlQC.BuildSelectStmt(queryParams, request.PageSize, nil)
+	return &sqlplugin.VisibilitySelectFilter{
+		Query:     sqlQueryString,
+		QueryArgs: queryArgs,
+	}, nil
+}
+
+func (s *VisibilityStore) buildExplainSelectFilterLegacy(
+	request *manager.ExplainQueryRequest,
+	saTypeMap searchattribute.NameTypeMap,
+	saMapper searchattribute.Mapper,
+) (*sqlplugin.VisibilitySelectFilter, error) {
+	newConverter := func() *QueryConverterLegacy {
+		return NewQueryConverterLegacy(
+			s.GetName(),
+			request.Namespace,
+			request.NamespaceID,
+			saTypeMap,
+			saMapper,
+			request.Query,
+			nil,
+			chasm.UnspecifiedArchetypeID,
+		)
+	}
+	selectFilter, err := newConverter().BuildCountStmt()
+	if err != nil || len(selectFilter.GroupBy) > 0 {
+		return selectFilter, err
+	}
+	return newConverter().BuildSelectStmt(request.PageSize, nil)
+}
+
+func (s *VisibilityStore) explainSelectFilter(
+	ctx context.Context,
+	request *manager.ExplainQueryRequest,
+	explanation *query.QueryExplanation,
+	selectFilter *sqlplugin.VisibilitySelectFilter,
+) (*manager.ExplainQueryResponse, error) {
+	response := query.NewExplainQueryResponse(s.GetName(), explanation)
+	response.StoreQuery = formatSQLStatement(selectFilter.Query, selectFilter.QueryArgs)
+	if !request.Execute {
+		return response, nil
+	}
+
+	plan, err := s.sqlStore.DB.ExplainFromVisibility(ctx, *selectFilter)
+	if err != nil {
+		return nil, convertSQLError("ExplainQuery operation failed.", err)
+	}
+	response.Plan = plan
+
+	startTime := time.Now()
+	if len(selectFilter.GroupBy) > 0 {
+		rows, err := s.sqlStore.DB.CountGroupByFromVisibility(ctx, *selectFilter)
+		if err != nil {
+			return nil, convertSQLError("ExplainQuery operation failed.", err)
+		}
+		response.Count = int64(len(rows))
+	} else {
+		rows, err := s.sqlStore.DB.SelectFromVisibility(ctx, *selectFilter)
+		if err != nil {
+			return nil, convertSQLError("ExplainQuery operation failed.", err)
+		}
+		response.Count = int64(len(rows))
+	}
+	response.Duration = time.Since(startTime)
+	return response, nil
+}
+
+// formatSQLStatement returns a SQL statement followed by a comment with its arguments, which are bound to the
+// placeholders of the statement in order.
+func formatSQLStatement(statement string, args []any) string {
+	if len(args) == 0 {
+		return statement
+	}
+	return fmt.Sprintf("%s\n-- args: %v", statement, args)
+}
+
+func buildAggregationQueryParams(
+	namespaceID namespace.ID,
+	namespaceName namespace.Name,
+	queryString string,
+	aggregationStrings []string,
+	sqlQC *SQLQueryConverter,
+	saTypeMap searchattribute.NameTypeMap,
+	saMapper searchattribute.Mapper,
+) (*query.QueryParams[sqlparser.Expr], []*query.Aggregation, error) {
+	queryParams, err := buildQueryParams(
+		namespaceID,
+		namespaceName,
+		queryString,
+		sqlQC,
+		saTypeMap,
+		saMapper,
+		nil,
+		chasm.UnspecifiedArchetypeID,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	aggregations, err := query.NewQueryConverter(sqlQC, namespaceName, saTypeMap, saMapper).
+		ConvertAggregations(aggregationStrings)
+	if err != nil {
+		return nil, nil, err
+	}
+	return queryParams, aggregations, nil
+}
+
+// parseAggregationValue parses a value of an aggregated search attribute as returned by the database driver, which
+// is either typed, or a string for decimals and for datetimes in SQLite.
+// parseAggregateResult parses the value of an aggregation computed by the database, which is nil if there are no
+// values to aggregate, except for sum which is 0. The aggregations of Datetime search attributes are computed over
+// Unix microseconds, and are returned as Unix nanoseconds.
+func parseAggregateResult(value any, agg *query.Aggregation) (*float64, error) {
+	var res float64
+	switch v := value.(type) {
+	case nil:
+		if agg.Function != query.AggregationSum {
+			return nil, nil
+		}
+	case int64:
+		res = float64(v)
+	case float64:
+		res = v
+	case string:
+		var err error
+		res, err = strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unexpected value %v of type %T", value, value)
+	}
+	if agg.IsDatetime() {
+		res *= float64(time.Microsecond)
+	}
+	return &res, nil
+}
+
+func parseAggregationValue(value any, valueType enumspb.IndexedValueType) (any, error) {
+	switch v := value.(type) {
+	case nil, int64, float64, time.Time:
+		return v, nil
+	case int32:
+		return int64(v), nil
+	case string:
+		switch valueType {
+		case enumspb.INDEXED_VALUE_TYPE_INT:
+			return strconv.ParseInt(v, 10, 64)
+		case enumspb.INDEXED_VALUE_TYPE_DOUBLE:
+			return strconv.ParseFloat(v, 64)
+		case enumspb.INDEXED_VALUE_TYPE_DATETIME:
+			for _, layout := range []string{time.RFC3339Nano, "2006-01-02 15:04:05.999999999Z07:00", "2006-01-02 15:04:05.999999999"} {
+				if t, err := time.Parse(layout, v); err == nil {
+					return t, nil
+				}
+			}
+		default:
+		}
+	}
+	return nil, fmt.Errorf("unexpected value %v of type %T for search attribute type %v", value, value, valueType)
+}
+
 func buildQueryParams(
 	namespaceID namespace.ID,
 	namespaceName namespace.Name,
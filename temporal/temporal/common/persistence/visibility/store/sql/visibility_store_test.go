@@ -3,14 +3,19 @@ package sql
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/temporalio/sqlparser"
+	enumspb "go.temporal.io/api/enums/v1"
 	"go.temporal.io/server/chasm"
 	"go.temporal.io/server/common/persistence/sql/sqlplugin/mysql"
 	"go.temporal.io/server/common/persistence/sql/sqlplugin/postgresql"
 	"go.temporal.io/server/common/persistence/sql/sqlplugin/sqlite"
+	"go.temporal.io/server/common/persistence/visibility/store/query"
 	"go.temporal.io/server/common/searchattribute"
+	"go.temporal.io/server/common/searchattribute/sadefs"
+	"go.temporal.io/server/common/util"
 )
 
 var pluginNames = []string{
@@ -89,3 +94,104 @@ func TestBuildQueryParams(t *testing.T) {
 		}
 	}
 }
+
+func TestParseAggregateResult(t *testing.T) {
+	t.Parallel()
+
+	intCol := query.NewSAColumn(sadefs.ExecutionDuration, sadefs.ExecutionDuration, enumspb.INDEXED_VALUE_TYPE_INT)
+	datetimeCol := query.NewSAColumn(sadefs.CloseTime, sadefs.CloseTime, enumspb.INDEXED_VALUE_TYPE_DATETIME)
+	testCases := []struct {
+		name  string
+		value any
+		agg   *query.Aggregation
+		out   *float64
+		err   string
+	}{
+		{name: "nil", value: nil, agg: &query.Aggregation{Function: query.AggregationAvg, Column: intCol}, out: nil},
+		{name: "nil sum", value: nil, agg: &query.Aggregation{Function: query.AggregationSum, Column: intCol}, out: util.Ptr(0.0)},
+		{name: "int64", value: int64(42), agg: &query.Aggregation{Function: query.AggregationMax, Column: intCol}, out: util.Ptr(42.0)},
+		{name: "float64", value: 1.5, agg: &query.Aggregation{Function: query.AggregationAvg, Column: intCol}, out: util.Ptr(1.5)},
+		{name: "decimal string", value: "1.5", agg: &query.Aggregation{Function: query.AggregationAvg, Column: intCol}, out: util.Ptr(1.5)},
+		{
+			name:  "datetime micros",
+			value: int64(1_700_000_000_123_456),
+			agg:   &query.Aggregation{Function: query.AggregationMin, Column: datetimeCol},
+			out:   util.Ptr(1_700_000_000_123_456_000.0),
+		},
+		{
+			name:  "unexpected type",
+			value: true,
+			agg:   &query.Aggregation{Function: query.AggregationMin, Column: intCol},
+			err:   "unexpected value true of type bool",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			out, err := parseAggregateResult(tc.value, tc.agg)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.out, out)
+		})
+	}
+}
+
+func TestParseAggregationValue(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name      string
+		value     any
+		valueType enumspb.IndexedValueType
+		out       any
+		err       string
+	}{
+		{name: "nil", value: nil, valueType: enumspb.INDEXED_VALUE_TYPE_INT, out: nil},
+		{name: "int64", value: int64(42), valueType: enumspb.INDEXED_VALUE_TYPE_INT, out: int64(42)},
+		{name: "int32", value: int32(42), valueType: enumspb.INDEXED_VALUE_TYPE_INT, out: int64(42)},
+		{name: "float64", value: 1.5, valueType: enumspb.INDEXED_VALUE_TYPE_DOUBLE, out: 1.5},
+		{name: "int string", value: "42", valueType: enumspb.INDEXED_VALUE_TYPE_INT, out: int64(42)},
+		{name: "decimal string", value: "1.5", valueType: enumspb.INDEXED_VALUE_TYPE_DOUBLE, out: 1.5},
+		{
+			name:      "RFC3339 datetime string",
+			value:     "2026-01-02T03:04:05.123Z",
+			valueType: enumspb.INDEXED_VALUE_TYPE_DATETIME,
+			out:       time.Date(2026, 1, 2, 3, 4, 5, 123000000, time.UTC),
+		},
+		{
+			name:      "SQL datetime string",
+			value:     "2026-01-02 03:04:05.123456",
+			valueType: enumspb.INDEXED_VALUE_TYPE_DATETIME,
+			out:       time.Date(2026, 1, 2, 3, 4, 5, 123456000, time.UTC),
+		},
+		{
+			name:      "invalid datetime string",
+			value:     "yesterday",
+			valueType: enumspb.INDEXED_VALUE_TYPE_DATETIME,
+			err:       "unexpected value yesterday of type string for search attribute type Datetime",
+		},
+		{
+			name:      "unexpected type",
+			value:     true,
+			valueType: enumspb.INDEXED_VALUE_TYPE_INT,
+			err:       "unexpected value true of type bool for search attribute type Int",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			out, err := parseAggregationValue(tc.value, tc.valueType)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.out, out)
+		})
+	}
+}
@@ -1,7 +1,9 @@
 package store
 
 import (
+	"cmp"
 	"maps"
+	"slices"
 
 	enumspb "go.temporal.io/api/enums/v1"
 	"go.temporal.io/server/chasm"
@@ -14,3 +16,16 @@ func CombineTypeMaps(customTypeMap searchattribute.NameTypeMap, chasmTypeMap *ch
 	maps.Copy(combinedTypeMap, chasmTypeMap.SATypeMap())
 	return searchattribute.NewNameTypeMap(combinedTypeMap)
 }
+
+// LimitAggregationGroups returns the groups as is if there are no more than maxGroups of them, or if maxGroups is not
+// positive. Otherwise, it returns the maxGroups groups with the highest counts, in descending order of count, and true
+// to flag that the other groups were left out.
+func LimitAggregationGroups(groups []InternalAggregationGroup, maxGroups int) ([]InternalAggregationGroup, bool) {
+	if maxGroups <= 0 || len(groups) <= maxGroups {
+		return groups, false
+	}
+	slices.SortStableFunc(groups, func(a, b InternalAggregationGroup) int {
+		return cmp.Compare(b.Count, a.Count)
+	})
+	return groups[:maxGroups], true
+}
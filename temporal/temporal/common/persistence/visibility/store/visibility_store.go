@@ -36,6 +36,7 @@ type (
 		ListChasmExecutions(ctx context.Context, request *manager.ListChasmExecutionsRequest) (*InternalListExecutionsResponse, error)
 		CountWorkflowExecutions(ctx context.Context, request *manager.CountWorkflowExecutionsRequest) (*InternalCountExecutionsResponse, error)
 		CountChasmExecutions(ctx context.Context, request *manager.CountChasmExecutionsRequest) (*InternalCountExecutionsResponse, error)
+		AggregateWorkflowExecutions(ctx context.Context, request *manager.AggregateWorkflowExecutionsRequest) (*InternalAggregateExecutionsResponse, error)
 		GetWorkflowExecution(ctx context.Context, request *manager.GetWorkflowExecutionRequest) (*InternalGetWorkflowExecutionResponse, error)
 
 		// Admin APIs
@@ -44,6 +45,9 @@ type (
 		// idempotent, ie., if a search attribute already exists, this function must be no-op, and must
 		// not return any error.
 		AddSearchAttributes(ctx context.Context, request *manager.AddSearchAttributesRequest) error
+
+		// ExplainQuery explains how the store runs a visibility query, and optionally runs it.
+		ExplainQuery(ctx context.Context, request *manager.ExplainQueryRequest) (*manager.ExplainQueryResponse, error)
 	}
 
 	// InternalExecutionInfo is internal visibility info for workflow execution
@@ -80,12 +84,27 @@ type (
 	InternalCountExecutionsResponse struct {
 		Count  int64
 		Groups []InternalAggregationGroup
+		// Truncated is true if only the groups with the highest counts are returned, because there are more groups
+		// than the max number of groups of the namespace. Count is still the count of all the matching executions.
+		Truncated bool
+	}
+
+	// InternalAggregateExecutionsResponse is response from AggregateWorkflowExecutions
+	InternalAggregateExecutionsResponse struct {
+		Count  int64
+		Groups []InternalAggregationGroup
+		// Truncated is true if only the groups with the highest counts are returned, like for
+		// InternalCountExecutionsResponse.
+		Truncated bool
 	}
 
 	// InternalAggregationGroup represents a GROUP BY aggregation result
 	InternalAggregationGroup struct {
 		GroupValues []*commonpb.Payload
 		Count       int64
+		// Values are the values of the aggregate functions of AggregateWorkflowExecutions, in the order of the
+		// request.
+		Values []*commonpb.Payload
 	}
 
 	// InternalGetWorkflowExecutionResponse is response from GetWorkflowExecution
@@ -200,6 +200,19 @@ func (v *VisibilityManagerDual) CountChasmExecutions(
 	)
 }
 
+func (v *VisibilityManagerDual) AggregateWorkflowExecutions(
+	ctx context.Context,
+	request *manager.AggregateWorkflowExecutionsRequest,
+) (*manager.AggregateWorkflowExecutionsResponse, error) {
+	return dualReadWrapper(
+		ctx,
+		v,
+		request,
+		request.Namespace,
+		manager.VisibilityManager.AggregateWorkflowExecutions,
+	)
+}
+
 func (v *VisibilityManagerDual) GetWorkflowExecution(
 	ctx context.Context,
 	request *manager.GetWorkflowExecutionRequest,
@@ -223,6 +236,15 @@ func (v *VisibilityManagerDual) AddSearchAttributes(
 	return v.secondaryVisibilityManager.AddSearchAttributes(ctx, request)
 }
 
+// ExplainQuery explains the query with the store which the namespace reads from. Unlike the read APIs, it doesn't
+// shadow the request to the other store, so that the query runs once if it's executed.
+func (v *VisibilityManagerDual) ExplainQuery(
+	ctx context.Context,
+	request *manager.ExplainQueryRequest,
+) (*manager.ExplainQueryResponse, error) {
+	return v.managerSelector.readManager(request.Namespace).ExplainQuery(ctx, request)
+}
+
 func dualWriteWrapper[RequestT any](
 	ctx context.Context,
 	v *VisibilityManagerDual,
@@ -296,6 +296,30 @@ func (p *visibilityManagerImpl) CountWorkflowExecutions(
 	return p.convertToCountWorkflowExecutionsResponse(internalResp)
 }
 
+func (p *visibilityManagerImpl) AggregateWorkflowExecutions(
+	ctx context.Context,
+	request *manager.AggregateWorkflowExecutionsRequest,
+) (*manager.AggregateWorkflowExecutionsResponse, error) {
+	internalResp, err := p.store.AggregateWorkflowExecutions(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &manager.AggregateWorkflowExecutionsResponse{
+		Count:     internalResp.Count,
+		Groups:    make([]*manager.AggregationGroup, 0, len(internalResp.Groups)),
+		Truncated: internalResp.Truncated,
+	}
+	for _, group := range internalResp.Groups {
+		response.Groups = append(response.Groups, &manager.AggregationGroup{
+			GroupValues: group.GroupValues,
+			Count:       group.Count,
+			Values:      group.Values,
+		})
+	}
+	return response, nil
+}
+
 func (p *visibilityManagerImpl) GetWorkflowExecution(
 	ctx context.Context,
 	request *manager.GetWorkflowExecutionRequest,
@@ -318,11 +342,19 @@ func (p *visibilityManagerImpl) AddSearchAttributes(
 	return p.store.AddSearchAttributes(ctx, request)
 }
 
+func (p *visibilityManagerImpl) ExplainQuery(
+	ctx context.Context,
+	request *manager.ExplainQueryRequest,
+) (*manager.ExplainQueryResponse, error) {
+	return p.store.ExplainQuery(ctx, request)
+}
+
 func (p *visibilityManagerImpl) convertToCountWorkflowExecutionsResponse(
 	internal *store.InternalCountExecutionsResponse,
 ) (*manager.CountWorkflowExecutionsResponse, error) {
 	response := &manager.CountWorkflowExecutionsResponse{
-		Count: internal.Count,
+		Count:     internal.Count,
+		Truncated: internal.Truncated,
 	}
 
 	if len(internal.Groups) > 0 {
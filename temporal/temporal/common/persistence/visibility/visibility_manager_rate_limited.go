@@ -148,6 +148,16 @@ func (m *visibilityManagerRateLimited) CountChasmExecutions(
 	return m.delegate.CountChasmExecutions(ctx, request)
 }
 
+func (m *visibilityManagerRateLimited) AggregateWorkflowExecutions(
+	ctx context.Context,
+	request *manager.AggregateWorkflowExecutionsRequest,
+) (*manager.AggregateWorkflowExecutionsResponse, error) {
+	if ok := allow(ctx, "AggregateWorkflowExecutions", m.readRateLimiter); !ok {
+		return nil, persistence.ErrPersistenceSystemLimitExceeded
+	}
+	return m.delegate.AggregateWorkflowExecutions(ctx, request)
+}
+
 func (m *visibilityManagerRateLimited) GetWorkflowExecution(
 	ctx context.Context,
 	request *manager.GetWorkflowExecutionRequest,
@@ -168,6 +178,16 @@ func (m *visibilityManagerRateLimited) AddSearchAttributes(
 	return m.delegate.AddSearchAttributes(ctx, request)
 }
 
+func (m *visibilityManagerRateLimited) ExplainQuery(
+	ctx context.Context,
+	request *manager.ExplainQueryRequest,
+) (*manager.ExplainQueryResponse, error) {
+	if ok := allow(ctx, "ExplainQuery", m.readRateLimiter); !ok {
+		return nil, persistence.ErrPersistenceSystemLimitExceeded
+	}
+	return m.delegate.ExplainQuery(ctx, request)
+}
+
 func allow(
 	ctx context.Context,
 	api string,
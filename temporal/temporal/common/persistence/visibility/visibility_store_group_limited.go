@@ -0,0 +1,76 @@
+package visibility
+
+import (
+	"context"
+
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/persistence/visibility/manager"
+	"go.temporal.io/server/common/persistence/visibility/store"
+)
+
+var _ store.VisibilityStore = (*visibilityStoreGroupLimited)(nil)
+
+// visibilityStoreGroupLimited limits the groups of the GROUP BY queries of a custom visibility store to the max number
+// of groups of the namespace, like the SQL and Elasticsearch stores do. The custom store factories are not given the
+// dynamic config, so the stores are expected to return all the groups with the count of all the matching executions.
+type visibilityStoreGroupLimited struct {
+	store.VisibilityStore
+	countGroupByMaxGroups dynamicconfig.IntPropertyFnWithNamespaceFilter
+}
+
+func newVisibilityStoreGroupLimited(
+	delegate store.VisibilityStore,
+	countGroupByMaxGroups dynamicconfig.IntPropertyFnWithNamespaceFilter,
+) *visibilityStoreGroupLimited {
+	return &visibilityStoreGroupLimited{
+		VisibilityStore:       delegate,
+		countGroupByMaxGroups: countGroupByMaxGroups,
+	}
+}
+
+func (s *visibilityStoreGroupLimited) CountWorkflowExecutions(
+	ctx context.Context,
+	request *manager.CountWorkflowExecutionsRequest,
+) (*store.InternalCountExecutionsResponse, error) {
+	resp, err := s.VisibilityStore.CountWorkflowExecutions(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	s.limitCountGroups(request.Namespace.String(), resp)
+	return resp, nil
+}
+
+func (s *visibilityStoreGroupLimited) CountChasmExecutions(
+	ctx context.Context,
+	request *manager.CountChasmExecutionsRequest,
+) (*store.InternalCountExecutionsResponse, error) {
+	resp, err := s.VisibilityStore.CountChasmExecutions(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	s.limitCountGroups(request.Namespace.String(), resp)
+	return resp, nil
+}
+
+func (s *visibilityStoreGroupLimited) AggregateWorkflowExecutions(
+	ctx context.Context,
+	request *manager.AggregateWorkflowExecutionsRequest,
+) (*store.InternalAggregateExecutionsResponse, error) {
+	resp, err := s.VisibilityStore.AggregateWorkflowExecutions(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	var truncated bool
+	resp.Groups, truncated = store.LimitAggregationGroups(resp.Groups, s.countGroupByMaxGroups(request.Namespace.String()))
+	resp.Truncated = resp.Truncated || truncated
+	return resp, nil
+}
+
+func (s *visibilityStoreGroupLimited) limitCountGroups(
+	namespaceName string,
+	resp *store.InternalCountExecutionsResponse,
+) {
+	var truncated bool
+	resp.Groups, truncated = store.LimitAggregationGroups(resp.Groups, s.countGroupByMaxGroups(namespaceName))
+	resp.Truncated = resp.Truncated || truncated
+}
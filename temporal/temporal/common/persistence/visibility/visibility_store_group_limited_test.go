@@ -0,0 +1,50 @@
+package visibility
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	commonpb "go.temporal.io/api/common/v1"
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/payload"
+	"go.temporal.io/server/common/persistence/visibility/manager"
+	"go.temporal.io/server/common/persistence/visibility/store"
+	"go.uber.org/mock/gomock"
+)
+
+func TestVisibilityStoreGroupLimited_CountWorkflowExecutions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	delegate := store.NewMockVisibilityStore(ctrl)
+	limited := newVisibilityStoreGroupLimited(delegate, dynamicconfig.GetIntPropertyFnFilteredByNamespace(2))
+
+	request := &manager.CountWorkflowExecutionsRequest{
+		NamespaceID: testNamespaceUUID,
+		Namespace:   testNamespace,
+		Query:       "GROUP BY WorkflowType",
+	}
+	group := func(workflowType string, count int64) store.InternalAggregationGroup {
+		return store.InternalAggregationGroup{
+			GroupValues: []*commonpb.Payload{payload.EncodeString(workflowType)},
+			Count:       count,
+		}
+	}
+	delegate.EXPECT().CountWorkflowExecutions(gomock.Any(), request).Return(&store.InternalCountExecutionsResponse{
+		Count:  10,
+		Groups: []store.InternalAggregationGroup{group("a", 1), group("b", 6), group("c", 3)},
+	}, nil)
+	resp, err := limited.CountWorkflowExecutions(context.Background(), request)
+	require.NoError(t, err)
+	require.True(t, resp.Truncated)
+	require.Equal(t, int64(10), resp.Count)
+	require.Equal(t, []store.InternalAggregationGroup{group("b", 6), group("c", 3)}, resp.Groups)
+
+	delegate.EXPECT().CountWorkflowExecutions(gomock.Any(), request).Return(&store.InternalCountExecutionsResponse{
+		Count:  4,
+		Groups: []store.InternalAggregationGroup{group("a", 1), group("b", 3)},
+	}, nil)
+	resp, err = limited.CountWorkflowExecutions(context.Background(), request)
+	require.NoError(t, err)
+	require.False(t, resp.Truncated)
+	require.Len(t, resp.Groups, 2)
+}
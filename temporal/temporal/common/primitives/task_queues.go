@@ -13,6 +13,9 @@ const (
 	AddSearchAttributesActivityTQ = "temporal-sys-add-search-attributes-activity-tq"
 	DeleteNamespaceActivityTQ     = "temporal-sys-delete-namespace-activity-tq"
 	DLQActivityTQ                 = "temporal-sys-dlq-activity-tq"
+
+	DualWriteVerificationActivityTQ = "temporal-sys-dual-write-verification-activity-tq"
+	VisibilityRebuildActivityTQ     = "temporal-sys-visibility-rebuild-activity-tq"
 )
 
 func IsInternalPerNsTaskQueue(taskQueue string) bool {
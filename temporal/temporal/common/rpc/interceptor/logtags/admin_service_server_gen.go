@@ -9,6 +9,10 @@ import (
 
 func (wt *WorkflowTags) extractFromAdminServiceServerMessage(message any) []tag.Tag {
 	switch r := message.(type) {
+	case *adminservice.AddFaultInjectionRuleRequest:
+		return nil
+	case *adminservice.AddFaultInjectionRuleResponse:
+		return nil
 	case *adminservice.AddOrUpdateRemoteClusterRequest:
 		return nil
 	case *adminservice.AddOrUpdateRemoteClusterResponse:
@@ -21,10 +25,22 @@ func (wt *WorkflowTags) extractFromAdminServiceServerMessage(message any) []tag.
 		return nil
 	case *adminservice.AddTasksResponse:
 		return nil
+	case *adminservice.AdvanceNamespaceTimeRequest:
+		return nil
+	case *adminservice.AdvanceNamespaceTimeResponse:
+		return nil
+	case *adminservice.AggregateWorkflowExecutionsRequest:
+		return nil
+	case *adminservice.AggregateWorkflowExecutionsResponse:
+		return nil
 	case *adminservice.CancelDLQJobRequest:
 		return nil
 	case *adminservice.CancelDLQJobResponse:
 		return nil
+	case *adminservice.ClearFaultInjectionRulesRequest:
+		return nil
+	case *adminservice.ClearFaultInjectionRulesResponse:
+		return nil
 	case *adminservice.CloseShardRequest:
 		return nil
 	case *adminservice.CloseShardResponse:
@@ -66,6 +82,10 @@ func (wt *WorkflowTags) extractFromAdminServiceServerMessage(message any) []tag.
 		return nil
 	case *adminservice.DescribeTaskQueuePartitionResponse:
 		return nil
+	case *adminservice.ExplainVisibilityQueryRequest:
+		return nil
+	case *adminservice.ExplainVisibilityQueryResponse:
+		return nil
 	case *adminservice.ForceUnloadTaskQueuePartitionRequest:
 		return nil
 	case *adminservice.ForceUnloadTaskQueuePartitionResponse:
@@ -77,6 +97,12 @@ func (wt *WorkflowTags) extractFromAdminServiceServerMessage(message any) []tag.
 		}
 	case *adminservice.GenerateLastHistoryReplicationTasksResponse:
 		return nil
+	case *adminservice.GetArchivedChasmExecutionRequest:
+		return []tag.Tag{
+			tag.WorkflowRunID(r.GetRunId()),
+		}
+	case *adminservice.GetArchivedChasmExecutionResponse:
+		return nil
 	case *adminservice.GetDLQMessagesRequest:
 		return nil
 	case *adminservice.GetDLQMessagesResponse:
@@ -142,6 +168,10 @@ func (wt *WorkflowTags) extractFromAdminServiceServerMessage(message any) []tag.
 		return nil
 	case *adminservice.ListClustersResponse:
 		return nil
+	case *adminservice.ListFaultInjectionRulesRequest:
+		return nil
+	case *adminservice.ListFaultInjectionRulesResponse:
+		return nil
 	case *adminservice.ListHistoryTasksRequest:
 		return nil
 	case *adminservice.ListHistoryTasksResponse:
@@ -191,6 +221,13 @@ func (wt *WorkflowTags) extractFromAdminServiceServerMessage(message any) []tag.
 		}
 	case *adminservice.RefreshWorkflowTasksResponse:
 		return nil
+	case *adminservice.RehydrateWorkflowExecutionRequest:
+		return []tag.Tag{
+			tag.WorkflowID(r.GetExecution().GetWorkflowId()),
+			tag.WorkflowRunID(r.GetExecution().GetRunId()),
+		}
+	case *adminservice.RehydrateWorkflowExecutionResponse:
+		return nil
 	case *adminservice.RemoveRemoteClusterRequest:
 		return nil
 	case *adminservice.RemoveRemoteClusterResponse:
@@ -221,6 +258,13 @@ func (wt *WorkflowTags) extractFromAdminServiceServerMessage(message any) []tag.
 		}
 	case *adminservice.SyncWorkflowStateResponse:
 		return nil
+	case *adminservice.VerifyArchivedHistoryRequest:
+		return []tag.Tag{
+			tag.WorkflowID(r.GetExecution().GetWorkflowId()),
+			tag.WorkflowRunID(r.GetExecution().GetRunId()),
+		}
+	case *adminservice.VerifyArchivedHistoryResponse:
+		return nil
 	default:
 		return nil
 	}
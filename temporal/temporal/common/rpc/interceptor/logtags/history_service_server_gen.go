@@ -9,14 +9,30 @@ import (
 
 func (wt *WorkflowTags) extractFromHistoryServiceServerMessage(message any) []tag.Tag {
 	switch r := message.(type) {
+	case *historyservice.AddFaultInjectionRuleRequest:
+		return nil
+	case *historyservice.AddFaultInjectionRuleResponse:
+		return nil
 	case *historyservice.AddTasksRequest:
 		return nil
 	case *historyservice.AddTasksResponse:
 		return nil
+	case *historyservice.AdvanceNamespaceTimeRequest:
+		return nil
+	case *historyservice.AdvanceNamespaceTimeResponse:
+		return nil
+	case *historyservice.AllocateTaskIdRequest:
+		return nil
+	case *historyservice.AllocateTaskIdResponse:
+		return nil
 	case *historyservice.CancelNexusOperationRequest:
 		return nil
 	case *historyservice.CancelNexusOperationResponse:
 		return nil
+	case *historyservice.ClearFaultInjectionRulesRequest:
+		return nil
+	case *historyservice.ClearFaultInjectionRulesResponse:
+		return nil
 	case *historyservice.CloseShardRequest:
 		return nil
 	case *historyservice.CloseShardResponse:
@@ -71,6 +87,10 @@ func (wt *WorkflowTags) extractFromHistoryServiceServerMessage(message any) []ta
 		}
 	case *historyservice.DescribeMutableStateResponse:
 		return nil
+	case *historyservice.DescribeNamespaceTimeRequest:
+		return nil
+	case *historyservice.DescribeNamespaceTimeResponse:
+		return nil
 	case *historyservice.DescribeWorkflowExecutionRequest:
 		return []tag.Tag{
 			tag.WorkflowID(r.GetRequest().GetExecution().GetWorkflowId()),
@@ -443,6 +463,10 @@ func (wt *WorkflowTags) extractFromHistoryServiceServerMessage(message any) []ta
 		}
 	case *historyservice.UpdateActivityOptionsResponse:
 		return nil
+	case *historyservice.UpdateNamespaceTimeRequest:
+		return nil
+	case *historyservice.UpdateNamespaceTimeResponse:
+		return nil
 	case *historyservice.UpdateWorkflowExecutionRequest:
 		return []tag.Tag{
 			tag.WorkflowID(r.GetRequest().GetWorkflowExecution().GetWorkflowId()),
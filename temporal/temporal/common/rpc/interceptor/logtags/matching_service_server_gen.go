@@ -16,6 +16,10 @@ func (wt *WorkflowTags) extractFromMatchingServiceServerMessage(message any) []t
 		}
 	case *matchingservice.AddActivityTaskResponse:
 		return nil
+	case *matchingservice.AddFaultInjectionRuleRequest:
+		return nil
+	case *matchingservice.AddFaultInjectionRuleResponse:
+		return nil
 	case *matchingservice.AddWorkflowTaskRequest:
 		return []tag.Tag{
 			tag.WorkflowID(r.GetExecution().GetWorkflowId()),
@@ -43,6 +47,10 @@ func (wt *WorkflowTags) extractFromMatchingServiceServerMessage(message any) []t
 		return nil
 	case *matchingservice.CheckTaskQueueVersionMembershipResponse:
 		return nil
+	case *matchingservice.ClearFaultInjectionRulesRequest:
+		return nil
+	case *matchingservice.ClearFaultInjectionRulesResponse:
+		return nil
 	case *matchingservice.CreateNexusEndpointRequest:
 		return nil
 	case *matchingservice.CreateNexusEndpointResponse:
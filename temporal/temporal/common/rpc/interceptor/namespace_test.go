@@ -42,6 +42,7 @@ var (
 	historyAPIExcluded = map[string]struct{}{
 		"CloseShard":                  {},
 		"GetShard":                    {},
+		"AllocateTaskId":              {},
 		"GetDLQMessages":              {},
 		"GetDLQReplicationMessages":   {},
 		"GetReplicationMessages":      {},
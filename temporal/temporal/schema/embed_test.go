@@ -18,12 +18,14 @@ func TestSchemaDirs(t *testing.T) {
 	requireContains(t, []string{
 		"mysql/v8/temporal",
 		"mysql/v8/visibility",
+		"mysql/v8/archival",
 	}, dirs)
 
 	dirs = PathsByDir("postgresql")
 	requireContains(t, []string{
 		"postgresql/v12/temporal",
 		"postgresql/v12/visibility",
+		"postgresql/v12/archival",
 	}, dirs)
 }
 
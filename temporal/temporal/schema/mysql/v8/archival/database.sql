@@ -0,0 +1 @@
+CREATE DATABASE temporal_archival CHARACTER SET utf8mb4;
@@ -7,3 +7,6 @@ const Version = "1.19"
 
 // VisibilityVersion is the MySQL visibility database release version
 const VisibilityVersion = "1.14"
+
+// ArchivalVersion is the MySQL archival database release version
+const ArchivalVersion = "1.0"
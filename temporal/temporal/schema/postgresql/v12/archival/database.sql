@@ -0,0 +1 @@
+CREATE DATABASE temporal_archival;
\ No newline at end of file
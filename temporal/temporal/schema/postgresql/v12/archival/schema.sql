@@ -0,0 +1,50 @@
+-- Stores the history batches archived by the sqlstore history archiver
+CREATE TABLE history_archive (
+  namespace_id           CHAR(64)      NOT NULL,
+  workflow_id            VARCHAR(255)  NOT NULL,
+  run_id                 CHAR(64)      NOT NULL,
+  close_failover_version BIGINT        NOT NULL,
+  batch_idx              BIGINT        NOT NULL,
+  data                   BYTEA         NOT NULL, -- temporal.api.history.v1.History
+  data_encoding          VARCHAR(16)   NOT NULL,
+  PRIMARY KEY (namespace_id, workflow_id, run_id, close_failover_version, batch_idx)
+);
+
+-- Stores the manifests of the archived histories, which are written in the same transaction as the history batches
+CREATE TABLE history_archive_manifests (
+  namespace_id           CHAR(64)      NOT NULL,
+  workflow_id            VARCHAR(255)  NOT NULL,
+  run_id                 CHAR(64)      NOT NULL,
+  close_failover_version BIGINT        NOT NULL,
+  data                   BYTEA         NOT NULL, -- temporal.server.api.archiver.v1.HistoryManifest
+  data_encoding          VARCHAR(16)   NOT NULL,
+  PRIMARY KEY (namespace_id, workflow_id, run_id, close_failover_version)
+);
+
+-- Stores the component trees of the CHASM executions archived by the sqlstore history archiver
+CREATE TABLE chasm_execution_archive (
+  namespace_id           CHAR(64)      NOT NULL,
+  business_id            VARCHAR(255)  NOT NULL,
+  run_id                 CHAR(64)      NOT NULL,
+  close_failover_version BIGINT        NOT NULL,
+  data                   BYTEA         NOT NULL, -- temporal.server.api.archiver.v1.ChasmExecution
+  data_encoding          VARCHAR(16)   NOT NULL,
+  PRIMARY KEY (namespace_id, business_id, run_id, close_failover_version)
+);
+
+-- Stores the visibility records archived by the sqlstore visibility archiver. The columns which can narrow a visibility
+-- query are indexed, the other fields of the record are only in the data.
+CREATE TABLE visibility_archive (
+  namespace_id       CHAR(64)      NOT NULL,
+  run_id             CHAR(64)      NOT NULL,
+  workflow_id        VARCHAR(255)  NOT NULL,
+  workflow_type_name VARCHAR(255)  NOT NULL,
+  close_time         TIMESTAMP     NOT NULL,
+  data               BYTEA         NOT NULL, -- temporal.server.api.archiver.v1.VisibilityRecord
+  data_encoding      VARCHAR(16)   NOT NULL,
+  PRIMARY KEY (namespace_id, run_id)
+);
+
+CREATE INDEX archive_by_close_time         ON visibility_archive (namespace_id, close_time DESC, run_id DESC);
+CREATE INDEX archive_by_workflow_id        ON visibility_archive (namespace_id, workflow_id, close_time DESC, run_id DESC);
+CREATE INDEX archive_by_workflow_type_name ON visibility_archive (namespace_id, workflow_type_name, close_time DESC, run_id DESC);
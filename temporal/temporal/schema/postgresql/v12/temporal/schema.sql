@@ -27,6 +27,8 @@ CREATE TABLE shards (
   PRIMARY KEY (shard_id)
 );
 
+-- executions, transfer_tasks, timer_tasks and history_node are partitioned by shard_id range, which every query on them
+-- binds. A namespace spans all the shards, so its data is deleted row by row, not by dropping partitions.
 CREATE TABLE executions(
   shard_id INTEGER NOT NULL,
   namespace_id BYTEA NOT NULL,
@@ -41,7 +43,8 @@ CREATE TABLE executions(
   state_encoding VARCHAR(16) NOT NULL,
   db_record_version BIGINT NOT NULL DEFAULT 0,
   PRIMARY KEY (shard_id, namespace_id, workflow_id, run_id)
-);
+) PARTITION BY RANGE (shard_id);
+CREATE TABLE executions_default PARTITION OF executions DEFAULT;
 
 CREATE TABLE current_executions(
   shard_id INTEGER NOT NULL,
@@ -183,7 +186,8 @@ CREATE TABLE transfer_tasks(
   data BYTEA NOT NULL,
   data_encoding VARCHAR(16) NOT NULL,
   PRIMARY KEY (shard_id, task_id)
-);
+) PARTITION BY RANGE (shard_id);
+CREATE TABLE transfer_tasks_default PARTITION OF transfer_tasks DEFAULT;
 
 CREATE TABLE timer_tasks (
   shard_id INTEGER NOT NULL,
@@ -193,7 +197,8 @@ CREATE TABLE timer_tasks (
   data BYTEA NOT NULL,
   data_encoding VARCHAR(16) NOT NULL,
   PRIMARY KEY (shard_id, visibility_timestamp, task_id)
-);
+) PARTITION BY RANGE (shard_id);
+CREATE TABLE timer_tasks_default PARTITION OF timer_tasks DEFAULT;
 
 CREATE TABLE replication_tasks (
   shard_id INTEGER NOT NULL,
@@ -320,7 +325,8 @@ CREATE TABLE history_node (
   data           BYTEA NOT NULL,
   data_encoding  VARCHAR(16) NOT NULL,
   PRIMARY KEY (shard_id, tree_id, branch_id, node_id, txn_id)
-);
+) PARTITION BY RANGE (shard_id);
+CREATE TABLE history_node_default PARTITION OF history_node DEFAULT;
 
 -- history eventsV2: history_tree stores branch metadata
 CREATE TABLE history_tree (
@@ -0,0 +1,24 @@
+-- The existing tables are attached as the default partitions of the new partitioned tables, which doesn't copy any
+-- row. Shard ranges are then moved out of the default partitions with `temporal-sql-tool create-shard-partitions`.
+-- The tables are partitioned by shard_id, not namespace_id, because the task and history tables have no namespace_id
+-- column and every query binds shard_id.
+
+ALTER TABLE executions RENAME TO executions_default;
+ALTER INDEX executions_pkey RENAME TO executions_default_pkey;
+CREATE TABLE executions (LIKE executions_default INCLUDING ALL) PARTITION BY RANGE (shard_id);
+ALTER TABLE executions ATTACH PARTITION executions_default DEFAULT;
+
+ALTER TABLE transfer_tasks RENAME TO transfer_tasks_default;
+ALTER INDEX transfer_tasks_pkey RENAME TO transfer_tasks_default_pkey;
+CREATE TABLE transfer_tasks (LIKE transfer_tasks_default INCLUDING ALL) PARTITION BY RANGE (shard_id);
+ALTER TABLE transfer_tasks ATTACH PARTITION transfer_tasks_default DEFAULT;
+
+ALTER TABLE timer_tasks RENAME TO timer_tasks_default;
+ALTER INDEX timer_tasks_pkey RENAME TO timer_tasks_default_pkey;
+CREATE TABLE timer_tasks (LIKE timer_tasks_default INCLUDING ALL) PARTITION BY RANGE (shard_id);
+ALTER TABLE timer_tasks ATTACH PARTITION timer_tasks_default DEFAULT;
+
+ALTER TABLE history_node RENAME TO history_node_default;
+ALTER INDEX history_node_pkey RENAME TO history_node_default_pkey;
+CREATE TABLE history_node (LIKE history_node_default INCLUDING ALL) PARTITION BY RANGE (shard_id);
+ALTER TABLE history_node ATTACH PARTITION history_node_default DEFAULT;
@@ -4,8 +4,11 @@ package v12
 
 // Version is the Postgres database release version
 // Temporal supports both MySQL and Postgres officially, so upgrade should be performed for both MySQL and Postgres
-const Version = "1.19"
+const Version = "1.20"
 
 // VisibilityVersion is the Postgres visibility database release version
 // Temporal supports both MySQL and Postgres officially, so upgrade should be performed for both MySQL and Postgres
-const VisibilityVersion = "1.14"
+const VisibilityVersion = "1.15"
+
+// ArchivalVersion is the Postgres archival database release version
+const ArchivalVersion = "1.0"
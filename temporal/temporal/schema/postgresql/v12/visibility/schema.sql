@@ -82,9 +82,9 @@ CREATE TABLE executions_visibility (
   Keyword08       VARCHAR(255)    GENERATED ALWAYS AS (search_attributes->>'Keyword08')               STORED,
   Keyword09       VARCHAR(255)    GENERATED ALWAYS AS (search_attributes->>'Keyword09')               STORED,
   Keyword10       VARCHAR(255)    GENERATED ALWAYS AS (search_attributes->>'Keyword10')               STORED,
-  Text01          TSVECTOR        GENERATED ALWAYS AS ((search_attributes->>'Text01')::tsvector)      STORED,
-  Text02          TSVECTOR        GENERATED ALWAYS AS ((search_attributes->>'Text02')::tsvector)      STORED,
-  Text03          TSVECTOR        GENERATED ALWAYS AS ((search_attributes->>'Text03')::tsvector)      STORED,
+  Text01          TSVECTOR        GENERATED ALWAYS AS (to_tsvector('simple', search_attributes->>'Text01')) STORED,
+  Text02          TSVECTOR        GENERATED ALWAYS AS (to_tsvector('simple', search_attributes->>'Text02')) STORED,
+  Text03          TSVECTOR        GENERATED ALWAYS AS (to_tsvector('simple', search_attributes->>'Text03')) STORED,
   KeywordList01   JSONB           GENERATED ALWAYS AS (search_attributes->'KeywordList01')            STORED,
   KeywordList02   JSONB           GENERATED ALWAYS AS (search_attributes->'KeywordList02')            STORED,
   KeywordList03   JSONB           GENERATED ALWAYS AS (search_attributes->'KeywordList03')            STORED,
@@ -0,0 +1,17 @@
+DROP INDEX by_text_01;
+DROP INDEX by_text_02;
+DROP INDEX by_text_03;
+
+ALTER TABLE executions_visibility
+  DROP COLUMN Text01,
+  DROP COLUMN Text02,
+  DROP COLUMN Text03;
+
+ALTER TABLE executions_visibility
+  ADD COLUMN Text01 TSVECTOR GENERATED ALWAYS AS ((search_attributes->>'Text01')::tsvector) STORED,
+  ADD COLUMN Text02 TSVECTOR GENERATED ALWAYS AS ((search_attributes->>'Text02')::tsvector) STORED,
+  ADD COLUMN Text03 TSVECTOR GENERATED ALWAYS AS ((search_attributes->>'Text03')::tsvector) STORED;
+
+CREATE INDEX by_text_01 ON executions_visibility USING GIN (namespace_id, Text01);
+CREATE INDEX by_text_02 ON executions_visibility USING GIN (namespace_id, Text02);
+CREATE INDEX by_text_03 ON executions_visibility USING GIN (namespace_id, Text03);
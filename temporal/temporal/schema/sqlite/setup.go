@@ -28,6 +28,10 @@ var (
 	executionSchema []byte
 	//go:embed v3/visibility/schema.sql
 	visibilitySchema []byte
+	//go:embed v3/visibility/rebuild_fts_text.sql
+	rebuildTextSearchIndexSchema []byte
+	//go:embed v3/archival/schema.sql
+	archivalSchema []byte
 )
 
 // SetupSchema initializes the SQLite schema in an empty database.
@@ -69,6 +73,37 @@ func SetupSchemaOnDB(db sqlplugin.AdminDB) error {
 		}
 	}
 
+	statements, err = p.LoadAndSplitQueryFromReaders([]io.Reader{bytes.NewBuffer(archivalSchema)})
+	if err != nil {
+		return fmt.Errorf("error loading archival schema: %w", err)
+	}
+
+	for _, stmt := range statements {
+		if err = db.Exec(stmt); err != nil {
+			return fmt.Errorf("error executing statement %q: %w", stmt, err)
+		}
+	}
+
+	return nil
+}
+
+// RebuildTextSearchIndexOnDB recreates the full-text index of the Text search attributes in a database which was set up
+// with an older visibility schema, so that it uses the tokenizer of the current schema, and indexes the existing
+// visibility records again.
+//
+// Note: this function may receive breaking changes or be removed in the future.
+func RebuildTextSearchIndexOnDB(db sqlplugin.AdminDB) error {
+	statements, err := p.LoadAndSplitQueryFromReaders([]io.Reader{bytes.NewBuffer(rebuildTextSearchIndexSchema)})
+	if err != nil {
+		return fmt.Errorf("error loading text search index schema: %w", err)
+	}
+
+	for _, stmt := range statements {
+		if err = db.Exec(stmt); err != nil {
+			return fmt.Errorf("error executing statement %q: %w", stmt, err)
+		}
+	}
+
 	return nil
 }
 
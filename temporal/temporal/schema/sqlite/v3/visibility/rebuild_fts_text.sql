@@ -0,0 +1,13 @@
+-- Recreates the full-text index of the Text search attributes with the tokenizer of schema.sql, and indexes the
+-- visibility records again. The triggers of executions_visibility keep updating the recreated table.
+DROP TABLE executions_visibility_fts_text;
+
+CREATE VIRTUAL TABLE executions_visibility_fts_text USING fts5 (
+  Text01,
+  Text02,
+  Text03,
+  content='executions_visibility',
+  tokenize="unicode61 remove_diacritics 0"
+);
+
+INSERT INTO executions_visibility_fts_text(executions_visibility_fts_text) VALUES ('rebuild');
@@ -154,12 +154,17 @@ CREATE INDEX by_temporal_keyword_03                 ON executions_visibility (na
 CREATE INDEX by_temporal_keyword_04                 ON executions_visibility (namespace_id, TemporalKeyword04,               (COALESCE(close_time, '9999-12-31 23:59:59+00:00')) DESC, start_time DESC, run_id);
 CREATE INDEX by_temporal_low_cardinality_keyword_01 ON executions_visibility (namespace_id, TemporalLowCardinalityKeyword01, (COALESCE(close_time, '9999-12-31 23:59:59+00:00')) DESC, start_time DESC, run_id);
 
+-- tokenize args:
+-- `unicode61`: letters and digits are tokens, which are case-folded
+-- `remove_diacritics 0`: don't remove diacritics, like the text query tokens and the other visibility stores
+-- Databases created with `remove_diacritics 2` are upgraded by rebuild_fts_text.sql when the SQLite plugin opens them.
+-- Keep both files in sync.
 CREATE VIRTUAL TABLE executions_visibility_fts_text USING fts5 (
   Text01,
   Text02,
   Text03,
   content='executions_visibility',
-  tokenize="unicode61 remove_diacritics 2"
+  tokenize="unicode61 remove_diacritics 0"
 );
 
 -- tokenize args:
@@ -5,3 +5,6 @@ const Version = "0.11"
 
 // VisibilityVersion is the SQLite visibility database release version
 const VisibilityVersion = "0.1"
+
+// ArchivalVersion is the SQLite archival database release version
+const ArchivalVersion = "0.1"
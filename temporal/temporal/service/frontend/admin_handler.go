@@ -15,6 +15,7 @@ import (
 	"github.com/google/uuid"
 	commonpb "go.temporal.io/api/common/v1"
 	enumspb "go.temporal.io/api/enums/v1"
+	historypb "go.temporal.io/api/history/v1"
 	namespacepb "go.temporal.io/api/namespace/v1"
 	replicationpb "go.temporal.io/api/replication/v1"
 	"go.temporal.io/api/serviceerror"
@@ -28,6 +29,7 @@ import (
 	commonspb "go.temporal.io/server/api/common/v1"
 	enumsspb "go.temporal.io/server/api/enums/v1"
 	healthspb "go.temporal.io/server/api/health/v1"
+	historyspb "go.temporal.io/server/api/history/v1"
 	"go.temporal.io/server/api/historyservice/v1"
 	"go.temporal.io/server/api/matchingservice/v1"
 	persistencespb "go.temporal.io/server/api/persistence/v1"
@@ -38,6 +40,8 @@ import (
 	"go.temporal.io/server/client/frontend"
 	"go.temporal.io/server/client/history"
 	"go.temporal.io/server/common"
+	"go.temporal.io/server/common/archiver"
+	"go.temporal.io/server/common/archiver/provider"
 	"go.temporal.io/server/common/channel"
 	"go.temporal.io/server/common/clock"
 	"go.temporal.io/server/common/cluster"
@@ -53,7 +57,9 @@ import (
 	"go.temporal.io/server/common/payload"
 	"go.temporal.io/server/common/payloads"
 	"go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/persistence/faultinjection"
 	"go.temporal.io/server/common/persistence/serialization"
+	"go.temporal.io/server/common/persistence/versionhistory"
 	"go.temporal.io/server/common/persistence/visibility"
 	"go.temporal.io/server/common/persistence/visibility/manager"
 	"go.temporal.io/server/common/persistence/visibility/store/elasticsearch"
@@ -64,11 +70,13 @@ import (
 	serviceerrors "go.temporal.io/server/common/serviceerror"
 	"go.temporal.io/server/common/util"
 	"go.temporal.io/server/service/history/tasks"
+	"go.temporal.io/server/service/history/timeskipping"
 	"go.temporal.io/server/service/worker/addsearchattributes"
 	"go.temporal.io/server/service/worker/batcher"
 	"go.temporal.io/server/service/worker/dlq"
 	"google.golang.org/grpc/health"
 	grpchealthspb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -76,6 +84,9 @@ const (
 	getNamespaceReplicationMessageBatchSize = 100
 	defaultLastMessageID                    = -1
 	listClustersPageSize                    = 100
+	rehydrationArchivedHistoryPageSize      = 1000
+	rehydrationImportBlobSize               = 256 * 1024 // 256K
+	rehydrationImportPageSize               = 16
 )
 
 type (
@@ -113,6 +124,9 @@ type (
 		healthServer               *health.Server
 		historyHealthChecker       HealthChecker
 		chasmRegistry              *chasm.Registry
+		faultInjectionRules        *faultinjection.Rules
+		archiverProvider           provider.ArchiverProvider
+		timeSource                 clock.TimeSource
 
 		// DEPRECATED: only history service on server side is supposed to
 		// use the following components.
@@ -148,6 +162,8 @@ type (
 		EventSerializer                     serialization.Serializer
 		TimeSource                          clock.TimeSource
 		ChasmRegistry                       *chasm.Registry
+		FaultInjectionRules                 *faultinjection.Rules
+		ArchiverProvider                    provider.ArchiverProvider
 
 		// DEPRECATED: only history service on server side is supposed to
 		// use the following components.
@@ -229,6 +245,9 @@ func NewAdminHandler(
 		taskCategoryRegistry: args.CategoryRegistry,
 		matchingClient:       args.matchingClient,
 		chasmRegistry:        args.ChasmRegistry,
+		faultInjectionRules:  args.FaultInjectionRules,
+		archiverProvider:     args.ArchiverProvider,
+		timeSource:           args.TimeSource,
 	}
 }
 
@@ -721,6 +740,431 @@ func (adh *AdminHandler) ImportWorkflowExecution(
 	}, nil
 }
 
+// RehydrateWorkflowExecution imports the archived history of a closed workflow execution, which has passed the
+// retention of its namespace, back into the primary persistence. The rehydrated execution is deleted again once the
+// requested retention expires, and is not archived again.
+func (adh *AdminHandler) RehydrateWorkflowExecution(
+	ctx context.Context,
+	request *adminservice.RehydrateWorkflowExecutionRequest,
+) (_ *adminservice.RehydrateWorkflowExecutionResponse, retError error) {
+	defer log.CapturePanic(adh.logger, &retError)
+
+	if request == nil {
+		return nil, errRequestNotSet
+	}
+
+	if err := validateExecution(request.Execution); err != nil {
+		return nil, err
+	}
+	if request.Execution.GetRunId() == "" {
+		return nil, errRunIDNotSet
+	}
+
+	retention := adh.config.RehydratedWorkflowRetention(request.GetNamespace())
+	if request.Retention != nil {
+		retention = request.Retention.AsDuration()
+	}
+	if retention <= 0 {
+		return nil, serviceerror.NewInvalidArgument("Retention must be positive.")
+	}
+
+	namespaceEntry, err := adh.namespaceRegistry.GetNamespace(namespace.Name(request.GetNamespace()))
+	if err != nil {
+		return nil, err
+	}
+	namespaceID := namespaceEntry.ID()
+
+	URIString := namespaceEntry.HistoryArchivalState().URI
+	if URIString == "" {
+		return nil, errNamespaceIsNotConfiguredForHistoryArchival
+	}
+	URI, err := archiver.NewURI(URIString)
+	if err != nil {
+		return nil, err
+	}
+	historyArchiver, err := adh.archiverProvider.GetHistoryArchiver(URI.Scheme())
+	if err != nil {
+		return nil, err
+	}
+
+	// the history of an execution which is still in the primary persistence must not be overwritten
+	_, err = adh.historyClient.DescribeMutableState(ctx, &historyservice.DescribeMutableStateRequest{
+		NamespaceId: namespaceID.String(),
+		Execution:   request.Execution,
+		ArchetypeId: chasm.WorkflowArchetypeID,
+	})
+	switch err.(type) {
+	case nil:
+		return nil, serviceerror.NewAlreadyExists("Workflow execution still exists, it does not need to be rehydrated.")
+	case *serviceerror.NotFound:
+	default:
+		return nil, err
+	}
+
+	getRequest := &archiver.GetHistoryRequest{
+		NamespaceID: namespaceID.String(),
+		WorkflowID:  request.Execution.GetWorkflowId(),
+		RunID:       request.Execution.GetRunId(),
+		PageSize:    rehydrationArchivedHistoryPageSize,
+	}
+	versionHistory, err := adh.getArchivedVersionHistory(ctx, historyArchiver, URI, getRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	var token []byte
+	var blobs []*commonpb.DataBlob
+	blobSize := 0
+	importedBatches := 0
+	importBlobs := func() error {
+		resp, err := adh.historyClient.ImportWorkflowExecution(ctx, &historyservice.ImportWorkflowExecutionRequest{
+			NamespaceId:    namespaceID.String(),
+			Execution:      request.Execution,
+			HistoryBatches: blobs,
+			VersionHistory: versionHistory,
+			Token:          token,
+		})
+		if err != nil {
+			return err
+		}
+		token = resp.Token
+		importedBatches += len(blobs)
+		blobs = nil
+		blobSize = 0
+		return nil
+	}
+	// the pages are imported as they are read, so that the whole history is never buffered
+	if err := forEachArchivedHistoryPage(ctx, historyArchiver, URI, getRequest, func(historyBatches []*historypb.History) error {
+		for _, historyBatch := range historyBatches {
+			blob, err := adh.eventSerializer.SerializeEvents(historyBatch.Events)
+			if err != nil {
+				return err
+			}
+			blobSize += len(blob.Data)
+			blobs = append(blobs, blob)
+			if blobSize < rehydrationImportBlobSize && len(blobs) < rehydrationImportPageSize {
+				continue
+			}
+			if err := importBlobs(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if len(blobs) != 0 {
+		if err := importBlobs(); err != nil {
+			return nil, err
+		}
+	}
+	if importedBatches == 0 {
+		return nil, errHistoryNotFound
+	}
+
+	// call with empty history to commit
+	expirationTime := timestamppb.New(adh.timeSource.Now().UTC().Add(retention))
+	resp, err := adh.historyClient.ImportWorkflowExecution(ctx, &historyservice.ImportWorkflowExecutionRequest{
+		NamespaceId:               namespaceID.String(),
+		Execution:                 request.Execution,
+		VersionHistory:            versionHistory,
+		Token:                     token,
+		RehydrationExpirationTime: expirationTime,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Token) != 0 {
+		return nil, serviceerror.NewInternal("Rehydrated workflow execution is not committed.")
+	}
+	return &adminservice.RehydrateWorkflowExecutionResponse{
+		ExpirationTime: expirationTime,
+	}, nil
+}
+
+// getArchivedVersionHistory returns the version history of the archived history of the request. It is read from the
+// manifest of the history if the history archiver writes one, and the request is then pinned to the close failover
+// version of the manifest. Otherwise, or for the manifests which have no version history, it is computed from a first
+// pass over the archived history.
+func (adh *AdminHandler) getArchivedVersionHistory(
+	ctx context.Context,
+	historyArchiver archiver.HistoryArchiver,
+	URI archiver.URI,
+	request *archiver.GetHistoryRequest,
+) (*historyspb.VersionHistory, error) {
+	if manifestReader, ok := historyArchiver.(archiver.HistoryManifestReader); ok {
+		manifest, err := manifestReader.GetManifest(ctx, URI, request)
+		switch err.(type) {
+		case nil:
+			if manifest.GetVersionHistory() != nil {
+				closeFailoverVersion := manifest.GetCloseFailoverVersion()
+				request.CloseFailoverVersion = &closeFailoverVersion
+				return manifest.GetVersionHistory(), nil
+			}
+		case *serviceerror.NotFound:
+		default:
+			return nil, err
+		}
+	}
+
+	versionHistory := versionhistory.NewVersionHistory(nil, nil)
+	if err := forEachArchivedHistoryPage(ctx, historyArchiver, URI, request, func(historyBatches []*historypb.History) error {
+		for _, historyBatch := range historyBatches {
+			for _, event := range historyBatch.Events {
+				item := versionhistory.NewVersionHistoryItem(event.EventId, event.Version)
+				if err := versionhistory.AddOrUpdateVersionHistoryItem(versionHistory, item); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if len(versionHistory.Items) == 0 {
+		return nil, errHistoryNotFound
+	}
+	return versionHistory, nil
+}
+
+// forEachArchivedHistoryPage calls fn with the history batches of each page of the archived history of the request.
+func forEachArchivedHistoryPage(
+	ctx context.Context,
+	historyArchiver archiver.HistoryArchiver,
+	URI archiver.URI,
+	request *archiver.GetHistoryRequest,
+	fn func([]*historypb.History) error,
+) error {
+	pageRequest := *request
+	pageRequest.NextPageToken = nil
+	for {
+		resp, err := historyArchiver.Get(ctx, URI, &pageRequest)
+		if err != nil {
+			return err
+		}
+		if err := fn(resp.HistoryBatches); err != nil {
+			return err
+		}
+		pageRequest.NextPageToken = resp.NextPageToken
+		if len(pageRequest.NextPageToken) == 0 {
+			return nil
+		}
+	}
+}
+
+// VerifyArchivedHistory reads back the archived histories of one run, or of the runs matching a visibility archival
+// query, and verifies them against their manifests.
+func (adh *AdminHandler) VerifyArchivedHistory(
+	ctx context.Context,
+	request *adminservice.VerifyArchivedHistoryRequest,
+) (_ *adminservice.VerifyArchivedHistoryResponse, retError error) {
+	defer log.CapturePanic(adh.logger, &retError)
+
+	if request == nil {
+		return nil, errRequestNotSet
+	}
+
+	if request.Execution != nil {
+		if err := validateExecution(request.Execution); err != nil {
+			return nil, err
+		}
+		if request.Execution.GetRunId() == "" {
+			return nil, errRunIDNotSet
+		}
+	}
+
+	maxPageSize := int32(adh.config.VisibilityArchivalQueryMaxPageSize())
+	if request.GetPageSize() <= 0 {
+		request.PageSize = maxPageSize
+	} else if request.GetPageSize() > maxPageSize {
+		return nil, serviceerror.NewInvalidArgumentf(errPageSizeTooBigMessage, maxPageSize)
+	}
+
+	namespaceEntry, err := adh.namespaceRegistry.GetNamespace(namespace.Name(request.GetNamespace()))
+	if err != nil {
+		return nil, err
+	}
+
+	URIString := namespaceEntry.HistoryArchivalState().URI
+	if URIString == "" {
+		return nil, errNamespaceIsNotConfiguredForHistoryArchival
+	}
+	URI, err := archiver.NewURI(URIString)
+	if err != nil {
+		return nil, err
+	}
+	historyArchiver, err := adh.archiverProvider.GetHistoryArchiver(URI.Scheme())
+	if err != nil {
+		return nil, err
+	}
+
+	executions := []*commonpb.WorkflowExecution{request.Execution}
+	var nextPageToken []byte
+	if request.Execution == nil {
+		executions, nextPageToken, err = adh.queryArchivedExecutions(ctx, namespaceEntry, request)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	response := &adminservice.VerifyArchivedHistoryResponse{
+		NextPageToken: nextPageToken,
+	}
+	for _, execution := range executions {
+		verification, err := archiver.VerifyHistory(ctx, historyArchiver, URI, &archiver.VerifyHistoryRequest{
+			NamespaceID: namespaceEntry.ID().String(),
+			WorkflowID:  execution.GetWorkflowId(),
+			RunID:       execution.GetRunId(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		response.VerifiedCount++
+		if request.GetFailuresOnly() && verification.Status == enumsspb.ARCHIVAL_INTEGRITY_STATUS_OK {
+			continue
+		}
+		response.Results = append(response.Results, &adminservice.ArchivedHistoryVerification{
+			Execution:            execution,
+			CloseFailoverVersion: verification.CloseFailoverVersion,
+			Status:               verification.Status,
+			Details:              verification.Details,
+		})
+	}
+	return response, nil
+}
+
+func (adh *AdminHandler) queryArchivedExecutions(
+	ctx context.Context,
+	namespaceEntry *namespace.Namespace,
+	request *adminservice.VerifyArchivedHistoryRequest,
+) ([]*commonpb.WorkflowExecution, []byte, error) {
+	URIString := namespaceEntry.VisibilityArchivalState().URI
+	if URIString == "" {
+		return nil, nil, errNamespaceIsNotConfiguredForVisibilityArchival
+	}
+	URI, err := archiver.NewURI(URIString)
+	if err != nil {
+		return nil, nil, err
+	}
+	visibilityArchiver, err := adh.archiverProvider.GetVisibilityArchiver(URI.Scheme())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	saMapper, err := adh.saMapperProvider.GetMapper(namespaceEntry.Name())
+	if err != nil {
+		return nil, nil, err
+	}
+	searchAttributes, err := adh.saProvider.GetSearchAttributes(adh.visibilityMgr.GetIndexName(), false)
+	if err != nil {
+		return nil, nil, serviceerror.NewUnavailablef(errUnableToGetSearchAttributesMessage, err)
+	}
+
+	resp, err := visibilityArchiver.Query(ctx, URI, &archiver.QueryVisibilityRequest{
+		NamespaceID:            namespaceEntry.ID().String(),
+		PageSize:               int(request.GetPageSize()),
+		NextPageToken:          request.GetNextPageToken(),
+		Query:                  request.GetQuery(),
+		Namespace:              namespaceEntry.Name().String(),
+		SearchAttributesMapper: saMapper,
+	}, searchAttributes)
+	if err != nil {
+		return nil, nil, err
+	}
+	executions := make([]*commonpb.WorkflowExecution, 0, len(resp.Executions))
+	for _, execution := range resp.Executions {
+		executions = append(executions, execution.GetExecution())
+	}
+	return executions, resp.NextPageToken, nil
+}
+
+// GetArchivedChasmExecution returns the archived component tree of a closed CHASM execution.
+func (adh *AdminHandler) GetArchivedChasmExecution(
+	ctx context.Context,
+	request *adminservice.GetArchivedChasmExecutionRequest,
+) (_ *adminservice.GetArchivedChasmExecutionResponse, retError error) {
+	defer log.CapturePanic(adh.logger, &retError)
+
+	if request == nil {
+		return nil, errRequestNotSet
+	}
+	if request.GetNamespace() == "" {
+		return nil, errNamespaceNotSet
+	}
+	if request.GetBusinessId() == "" {
+		return nil, errBusinessIDNotSet
+	}
+	if request.GetRunId() == "" {
+		return nil, errRunIDNotSet
+	}
+	if err := uuid.Validate(request.GetRunId()); err != nil {
+		return nil, errInvalidRunID
+	}
+
+	namespaceEntry, err := adh.namespaceRegistry.GetNamespace(namespace.Name(request.GetNamespace()))
+	if err != nil {
+		return nil, err
+	}
+
+	URIString := namespaceEntry.HistoryArchivalState().URI
+	if URIString == "" {
+		return nil, errNamespaceIsNotConfiguredForHistoryArchival
+	}
+	URI, err := archiver.NewURI(URIString)
+	if err != nil {
+		return nil, err
+	}
+	historyArchiver, err := adh.archiverProvider.GetHistoryArchiver(URI.Scheme())
+	if err != nil {
+		return nil, err
+	}
+	chasmArchiver, ok := historyArchiver.(archiver.ChasmExecutionArchiver)
+	if !ok {
+		return nil, serviceerror.NewUnimplementedf("History archiver of scheme %q does not support CHASM executions.", URI.Scheme())
+	}
+
+	execution, err := chasmArchiver.GetChasmExecution(ctx, URI, &archiver.GetChasmExecutionRequest{
+		NamespaceID: namespaceEntry.ID().String(),
+		BusinessID:  request.GetBusinessId(),
+		RunID:       request.GetRunId(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &adminservice.GetArchivedChasmExecutionResponse{Execution: execution}, nil
+}
+
+// AdvanceNamespaceTime moves the virtual time of a namespace with time skipping enabled forward. The virtual time is
+// advanced by the host of the coordinator shard of the namespace, which persists it and sends it to every history host.
+func (adh *AdminHandler) AdvanceNamespaceTime(
+	ctx context.Context,
+	request *adminservice.AdvanceNamespaceTimeRequest,
+) (_ *adminservice.AdvanceNamespaceTimeResponse, retError error) {
+	defer log.CapturePanic(adh.logger, &retError)
+
+	if request == nil {
+		return nil, errRequestNotSet
+	}
+	if request.GetNamespace() == "" {
+		return nil, errNamespaceNotSet
+	}
+
+	namespaceID, err := adh.namespaceRegistry.GetNamespaceID(namespace.Name(request.GetNamespace()))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := adh.historyClient.AdvanceNamespaceTime(ctx, &historyservice.AdvanceNamespaceTimeRequest{
+		NamespaceId: namespaceID.String(),
+		ShardId:     timeskipping.CoordinatorShardID(namespaceID, adh.numberOfHistoryShards),
+		Duration:    request.GetDuration(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &adminservice.AdvanceNamespaceTimeResponse{Time: resp.GetTime()}, nil
+}
+
 func (adh *AdminHandler) unaliasAndValidateSearchAttributes(historyBatches []*commonpb.DataBlob, nsName namespace.Name) ([]*commonpb.DataBlob, error) {
 	var unaliasedBatches []*commonpb.DataBlob
 	for _, historyBatch := range historyBatches {
@@ -1868,6 +2312,269 @@ func (adh *AdminHandler) DeleteWorkflowExecution(
 	return response.Response, nil
 }
 
+// ListFaultInjectionRules lists the persistence fault injection rules of this host. Unlike AddFaultInjectionRule and
+// ClearFaultInjectionRules, it doesn't fan out to the history and matching hosts, whose rules may differ if they were
+// changed through dynamic config or by another frontend host.
+func (adh *AdminHandler) ListFaultInjectionRules(
+	_ context.Context,
+	request *adminservice.ListFaultInjectionRulesRequest,
+) (_ *adminservice.ListFaultInjectionRulesResponse, err error) {
+	defer log.CapturePanic(adh.logger, &err)
+
+	if request == nil {
+		return nil, errRequestNotSet
+	}
+	if adh.faultInjectionRules == nil {
+		return nil, errFaultInjectionNotEnabled
+	}
+
+	rules := adh.faultInjectionRules.List(config.DataStoreName(request.GetDataStore()))
+	resp := &adminservice.ListFaultInjectionRulesResponse{
+		Rules: make([]*adminservice.FaultInjectionRule, 0, len(rules)),
+	}
+	for _, rule := range rules {
+		resp.Rules = append(resp.Rules, faultinjection.RuleToProto(rule))
+	}
+	return resp, nil
+}
+
+// AddFaultInjectionRule adds a persistence fault injection rule to all services of this host, and to every history and
+// matching host of the cluster. Rules of the other frontend hosts and of the worker hosts are not changed: use the
+// system.persistenceFaultInjectionTargets dynamic config to target them.
+func (adh *AdminHandler) AddFaultInjectionRule(
+	ctx context.Context,
+	request *adminservice.AddFaultInjectionRuleRequest,
+) (_ *adminservice.AddFaultInjectionRuleResponse, err error) {
+	defer log.CapturePanic(adh.logger, &err)
+
+	if request == nil {
+		return nil, errRequestNotSet
+	}
+	if request.GetRule() == nil {
+		return nil, errFaultInjectionRuleNotSet
+	}
+	if adh.faultInjectionRules == nil {
+		return nil, errFaultInjectionNotEnabled
+	}
+
+	rule := request.GetRule()
+	if err := adh.faultInjectionRules.AddProto(rule); err != nil {
+		return nil, serviceerror.NewInvalidArgument(err.Error())
+	}
+	adh.logger.Info("Added persistence fault injection rule.",
+		tag.NewStringTag("data-store", rule.GetDataStore()),
+		tag.NewStringTag("method", rule.GetMethod()),
+	)
+
+	hosts, err := adh.forEachFaultInjectionHost(ctx, func(ctx context.Context, service primitives.ServiceName, address string) error {
+		var err error
+		switch service {
+		case primitives.HistoryService:
+			_, err = adh.historyClient.AddFaultInjectionRule(ctx, &historyservice.AddFaultInjectionRuleRequest{
+				HostAddress: address,
+				Rule:        rule,
+			})
+		case primitives.MatchingService:
+			_, err = adh.matchingClient.AddFaultInjectionRule(ctx, &matchingservice.AddFaultInjectionRuleRequest{
+				HostAddress: address,
+				Rule:        rule,
+			})
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &adminservice.AddFaultInjectionRuleResponse{
+		Hosts: hosts,
+	}, nil
+}
+
+// ClearFaultInjectionRules removes the persistence fault injection rules which were added with AddFaultInjectionRule
+// from this host and from every history and matching host of the cluster.
+func (adh *AdminHandler) ClearFaultInjectionRules(
+	ctx context.Context,
+	request *adminservice.ClearFaultInjectionRulesRequest,
+) (_ *adminservice.ClearFaultInjectionRulesResponse, err error) {
+	defer log.CapturePanic(adh.logger, &err)
+
+	if request == nil {
+		return nil, errRequestNotSet
+	}
+	if adh.faultInjectionRules == nil {
+		return nil, errFaultInjectionNotEnabled
+	}
+
+	cleared := adh.faultInjectionRules.Clear(config.DataStoreName(request.GetDataStore()), request.GetMethod())
+	adh.logger.Info("Cleared persistence fault injection rules.",
+		tag.NewStringTag("data-store", request.GetDataStore()),
+		tag.NewStringTag("method", request.GetMethod()),
+		tag.Counter(cleared),
+	)
+
+	hosts, err := adh.forEachFaultInjectionHost(ctx, func(ctx context.Context, service primitives.ServiceName, address string) error {
+		var err error
+		switch service {
+		case primitives.HistoryService:
+			_, err = adh.historyClient.ClearFaultInjectionRules(ctx, &historyservice.ClearFaultInjectionRulesRequest{
+				HostAddress: address,
+				DataStore:   request.GetDataStore(),
+				Method:      request.GetMethod(),
+			})
+		case primitives.MatchingService:
+			_, err = adh.matchingClient.ClearFaultInjectionRules(ctx, &matchingservice.ClearFaultInjectionRulesRequest{
+				HostAddress: address,
+				DataStore:   request.GetDataStore(),
+				Method:      request.GetMethod(),
+			})
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &adminservice.ClearFaultInjectionRulesResponse{
+		ClearedCount: int32(cleared),
+		Hosts:        hosts,
+	}, nil
+}
+
+// forEachFaultInjectionHost calls fn for every history and matching host of the cluster, and returns the addresses of
+// the hosts on which fn succeeded. The fault injection rules are per process, so the hosts are called one by one
+// rather than through the regular routing of the clients.
+func (adh *AdminHandler) forEachFaultInjectionHost(
+	ctx context.Context,
+	fn func(ctx context.Context, service primitives.ServiceName, address string) error,
+) ([]string, error) {
+	var hosts []string
+	var errs []error
+	for _, service := range []primitives.ServiceName{primitives.HistoryService, primitives.MatchingService} {
+		resolver, err := adh.membershipMonitor.GetResolver(service)
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range resolver.Members() {
+			if err := fn(ctx, service, member.GetAddress()); err != nil {
+				errs = append(errs, fmt.Errorf("%s host %s: %w", service, member.GetAddress(), err))
+				continue
+			}
+			hosts = append(hosts, member.GetAddress())
+		}
+	}
+	if len(errs) > 0 {
+		return nil, serviceerror.NewUnavailablef("failed to change the fault injection rules of some hosts: %v", errors.Join(errs...))
+	}
+	return hosts, nil
+}
+
+// AggregateWorkflowExecutions computes aggregate functions over the search attributes of the workflow executions
+// which match a visibility query.
+func (adh *AdminHandler) AggregateWorkflowExecutions(
+	ctx context.Context,
+	request *adminservice.AggregateWorkflowExecutionsRequest,
+) (_ *adminservice.AggregateWorkflowExecutionsResponse, err error) {
+	defer log.CapturePanic(adh.logger, &err)
+
+	if request == nil {
+		return nil, errRequestNotSet
+	}
+	if request.GetNamespace() == "" {
+		return nil, errNamespaceNotSet
+	}
+	if len(request.GetAggregations()) == 0 {
+		return nil, errAggregationsNotSet
+	}
+
+	nsName := namespace.Name(request.GetNamespace())
+	namespaceID, err := adh.namespaceRegistry.GetNamespaceID(nsName)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := adh.visibilityMgr.AggregateWorkflowExecutions(ctx, &manager.AggregateWorkflowExecutionsRequest{
+		NamespaceID:  namespaceID,
+		Namespace:    nsName,
+		Query:        request.GetQuery(),
+		Aggregations: request.GetAggregations(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]*adminservice.AggregationGroup, 0, len(resp.Groups))
+	for _, group := range resp.Groups {
+		groups = append(groups, &adminservice.AggregationGroup{
+			GroupValues: group.GroupValues,
+			Count:       group.Count,
+			Values:      group.Values,
+		})
+	}
+	return &adminservice.AggregateWorkflowExecutionsResponse{
+		Count:     resp.Count,
+		Groups:    groups,
+		Truncated: resp.Truncated,
+	}, nil
+}
+
+// ExplainVisibilityQuery explains how the visibility store runs a query, and optionally runs it.
+func (adh *AdminHandler) ExplainVisibilityQuery(
+	ctx context.Context,
+	request *adminservice.ExplainVisibilityQueryRequest,
+) (_ *adminservice.ExplainVisibilityQueryResponse, err error) {
+	defer log.CapturePanic(adh.logger, &err)
+
+	if request == nil {
+		return nil, errRequestNotSet
+	}
+	if request.GetNamespace() == "" {
+		return nil, errNamespaceNotSet
+	}
+
+	nsName := namespace.Name(request.GetNamespace())
+	namespaceID, err := adh.namespaceRegistry.GetNamespaceID(nsName)
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := request.GetPageSize()
+	maxPageSize := int32(adh.config.VisibilityMaxPageSize(request.GetNamespace()))
+	if pageSize <= 0 || pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	resp, err := adh.visibilityMgr.ExplainQuery(ctx, &manager.ExplainQueryRequest{
+		NamespaceID: namespaceID,
+		Namespace:   nsName,
+		Query:       request.GetQuery(),
+		PageSize:    int(pageSize),
+		Execute:     request.GetExecute(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	searchAttributes := make([]*adminservice.ExplainedSearchAttribute, 0, len(resp.SearchAttributes))
+	for _, sa := range resp.SearchAttributes {
+		searchAttributes = append(searchAttributes, &adminservice.ExplainedSearchAttribute{
+			Name:      sa.Name,
+			FieldName: sa.FieldName,
+			Type:      sa.Type,
+		})
+	}
+	response := &adminservice.ExplainVisibilityQueryResponse{
+		Ast:              resp.AST,
+		SearchAttributes: searchAttributes,
+		StoreName:        resp.StoreName,
+		StoreQuery:       resp.StoreQuery,
+		Plan:             resp.Plan,
+		Count:            resp.Count,
+	}
+	if request.GetExecute() {
+		response.Duration = durationpb.New(resp.Duration)
+	}
+	return response, nil
+}
+
 func (adh *AdminHandler) validateRemoteClusterMetadata(metadata *adminservice.DescribeClusterResponse) error {
 	// Verify remote cluster config
 	currentClusterInfo := adh.clusterMetadata
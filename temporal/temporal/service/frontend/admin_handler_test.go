@@ -8,6 +8,7 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -23,8 +24,10 @@ import (
 	"go.temporal.io/api/workflowservice/v1"
 	"go.temporal.io/server/api/adminservice/v1"
 	"go.temporal.io/server/api/adminservicemock/v1"
+	archiverspb "go.temporal.io/server/api/archiver/v1"
 	commonspb "go.temporal.io/server/api/common/v1"
 	enumsspb "go.temporal.io/server/api/enums/v1"
+	historyspb "go.temporal.io/server/api/history/v1"
 	"go.temporal.io/server/api/historyservice/v1"
 	"go.temporal.io/server/api/historyservicemock/v1"
 	"go.temporal.io/server/api/matchingservice/v1"
@@ -35,13 +38,17 @@ import (
 	chasmworkflow "go.temporal.io/server/chasm/lib/workflow"
 	clientmocks "go.temporal.io/server/client"
 	historyclient "go.temporal.io/server/client/history"
+	"go.temporal.io/server/common"
+	"go.temporal.io/server/common/archiver"
 	"go.temporal.io/server/common/clock"
 	"go.temporal.io/server/common/cluster"
 	"go.temporal.io/server/common/config"
 	"go.temporal.io/server/common/dynamicconfig"
 	"go.temporal.io/server/common/membership"
 	"go.temporal.io/server/common/namespace"
+	"go.temporal.io/server/common/payload"
 	"go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/persistence/faultinjection"
 	"go.temporal.io/server/common/persistence/serialization"
 	"go.temporal.io/server/common/persistence/visibility/manager"
 	"go.temporal.io/server/common/persistence/visibility/store/elasticsearch"
@@ -55,13 +62,17 @@ import (
 	"go.temporal.io/server/common/testing/mocksdk"
 	"go.temporal.io/server/common/testing/protorequire"
 	"go.temporal.io/server/common/testing/testvars"
+	"go.temporal.io/server/common/util"
 	"go.temporal.io/server/service/history/tasks"
+	"go.temporal.io/server/service/history/timeskipping"
 	"go.temporal.io/server/service/worker/dlq"
 	"go.uber.org/mock/gomock"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type (
@@ -146,6 +157,9 @@ func (s *adminHandlerSuite) SetupTest() {
 		SearchAttributesTotalSizeLimit:        dynamicconfig.GetIntPropertyFnFilteredByNamespace(10),
 		VisibilityAllowList:                   dynamicconfig.GetBoolPropertyFnFilteredByNamespace(false),
 		SuppressErrorSetSystemSearchAttribute: dynamicconfig.GetBoolPropertyFnFilteredByNamespace(false),
+		VisibilityMaxPageSize:                 dynamicconfig.GetIntPropertyFnFilteredByNamespace(1000),
+		RehydratedWorkflowRetention:           dynamicconfig.GetDurationPropertyFnFilteredByNamespace(7 * 24 * time.Hour),
+		VisibilityArchivalQueryMaxPageSize:    dynamicconfig.GetIntPropertyFn(10000),
 	}
 
 	chasmRegistry := chasm.NewRegistry(s.mockResource.GetLogger())
@@ -180,6 +194,8 @@ func (s *adminHandlerSuite) SetupTest() {
 		serialization.NewSerializer(),
 		clock.NewRealTimeSource(),
 		chasmRegistry,
+		faultinjection.NewRules(&config.FaultInjection{}, nil, s.mockResource.GetLogger()),
+		s.mockResource.GetArchiverProvider(),
 		tasks.NewDefaultTaskCategoryRegistry(),
 		s.mockResource.GetMatchingClient(),
 	}
@@ -2094,3 +2110,579 @@ func (s *adminHandlerSuite) validatePhysicalTaskQueueInfo(expectedPhysicalTaskQu
 	s.Equal(expectedPhysicalTaskQueueInfo.GetTaskQueueStats(), responsePhysicalTaskQueueInfo.GetTaskQueueStats())
 	s.Equal(expectedPhysicalTaskQueueInfo.GetInternalTaskQueueStatus(), responsePhysicalTaskQueueInfo.GetInternalTaskQueueStatus())
 }
+
+func (s *adminHandlerSuite) TestRehydrateWorkflowExecution_InvalidRequest() {
+	tv := testvars.New(s.T()).WithNamespaceName(s.namespace).WithNamespaceID(s.namespaceID).WithRunID(uuid.NewString())
+
+	_, err := s.handler.RehydrateWorkflowExecution(context.Background(), &adminservice.RehydrateWorkflowExecutionRequest{
+		Namespace: tv.NamespaceName().String(),
+		Execution: &commonpb.WorkflowExecution{WorkflowId: tv.WorkflowID()},
+	})
+	s.ErrorIs(err, errRunIDNotSet)
+
+	_, err = s.handler.RehydrateWorkflowExecution(context.Background(), &adminservice.RehydrateWorkflowExecutionRequest{
+		Namespace: tv.NamespaceName().String(),
+		Execution: tv.WorkflowExecution(),
+		Retention: durationpb.New(-time.Hour),
+	})
+	var invalidArgument *serviceerror.InvalidArgument
+	s.ErrorAs(err, &invalidArgument)
+}
+
+func (s *adminHandlerSuite) TestRehydrateWorkflowExecution_NamespaceNotArchived() {
+	tv := testvars.New(s.T()).WithNamespaceName(s.namespace).WithNamespaceID(s.namespaceID).WithRunID(uuid.NewString())
+
+	s.mockNamespaceCache.EXPECT().GetNamespace(tv.NamespaceName()).Return(s.namespaceEntry, nil)
+
+	_, err := s.handler.RehydrateWorkflowExecution(context.Background(), &adminservice.RehydrateWorkflowExecutionRequest{
+		Namespace: tv.NamespaceName().String(),
+		Execution: tv.WorkflowExecution(),
+	})
+	s.ErrorIs(err, errNamespaceIsNotConfiguredForHistoryArchival)
+}
+
+func (s *adminHandlerSuite) TestRehydrateWorkflowExecution_ExecutionStillExists() {
+	tv := testvars.New(s.T()).WithNamespaceName(s.namespace).WithNamespaceID(s.namespaceID).WithRunID(uuid.NewString())
+
+	s.mockNamespaceCache.EXPECT().GetNamespace(tv.NamespaceName()).Return(s.newArchivedNamespaceEntry(), nil)
+	s.mockResource.ArchiverProvider.EXPECT().GetHistoryArchiver("test").Return(archiver.NewMockHistoryArchiver(s.controller), nil)
+	s.mockHistoryClient.EXPECT().DescribeMutableState(gomock.Any(), gomock.Any()).Return(&historyservice.DescribeMutableStateResponse{}, nil)
+
+	_, err := s.handler.RehydrateWorkflowExecution(context.Background(), &adminservice.RehydrateWorkflowExecutionRequest{
+		Namespace: tv.NamespaceName().String(),
+		Execution: tv.WorkflowExecution(),
+	})
+	var alreadyExists *serviceerror.AlreadyExists
+	s.ErrorAs(err, &alreadyExists)
+}
+
+func (s *adminHandlerSuite) TestRehydrateWorkflowExecution_WithoutManifest() {
+	tv := testvars.New(s.T()).WithNamespaceName(s.namespace).WithNamespaceID(s.namespaceID).WithRunID(uuid.NewString())
+
+	historyBatches := []*historypb.History{
+		{Events: []*historypb.HistoryEvent{{EventId: 1, Version: 100}, {EventId: 2, Version: 100}}},
+		{Events: []*historypb.HistoryEvent{{EventId: 3, Version: 100}}},
+		{Events: []*historypb.HistoryEvent{{EventId: 4, Version: 200}}},
+	}
+
+	mockHistoryArchiver := archiver.NewMockHistoryArchiver(s.controller)
+	s.mockNamespaceCache.EXPECT().GetNamespace(tv.NamespaceName()).Return(s.newArchivedNamespaceEntry(), nil)
+	s.mockResource.ArchiverProvider.EXPECT().GetHistoryArchiver("test").Return(mockHistoryArchiver, nil)
+	s.mockHistoryClient.EXPECT().DescribeMutableState(gomock.Any(), &historyservice.DescribeMutableStateRequest{
+		NamespaceId: tv.NamespaceID().String(),
+		Execution:   tv.WorkflowExecution(),
+		ArchetypeId: chasm.WorkflowArchetypeID,
+	}).Return(nil, serviceerror.NewNotFound("workflow not found"))
+	// the history is read once to compute the version history, and once more to import it
+	mockHistoryArchiver.EXPECT().Get(gomock.Any(), gomock.Any(), &archiver.GetHistoryRequest{
+		NamespaceID: tv.NamespaceID().String(),
+		WorkflowID:  tv.WorkflowID(),
+		RunID:       tv.RunID(),
+		PageSize:    rehydrationArchivedHistoryPageSize,
+	}).Return(&archiver.GetHistoryResponse{
+		HistoryBatches: historyBatches[:2],
+		NextPageToken:  []byte("next"),
+	}, nil).Times(2)
+	mockHistoryArchiver.EXPECT().Get(gomock.Any(), gomock.Any(), &archiver.GetHistoryRequest{
+		NamespaceID:   tv.NamespaceID().String(),
+		WorkflowID:    tv.WorkflowID(),
+		RunID:         tv.RunID(),
+		NextPageToken: []byte("next"),
+		PageSize:      rehydrationArchivedHistoryPageSize,
+	}).Return(&archiver.GetHistoryResponse{
+		HistoryBatches: historyBatches[2:],
+	}, nil).Times(2)
+
+	expectedVersionHistory := &historyspb.VersionHistory{
+		Items: []*historyspb.VersionHistoryItem{
+			{EventId: 3, Version: 100},
+			{EventId: 4, Version: 200},
+		},
+	}
+	importedBatches, expirationTime := s.expectRehydrationImports(tv, expectedVersionHistory)
+
+	before := time.Now()
+	resp, err := s.handler.RehydrateWorkflowExecution(context.Background(), &adminservice.RehydrateWorkflowExecutionRequest{
+		Namespace: tv.NamespaceName().String(),
+		Execution: tv.WorkflowExecution(),
+		Retention: durationpb.New(time.Hour),
+	})
+	s.NoError(err)
+	s.Equal(len(historyBatches), *importedBatches)
+	s.NotNil(*expirationTime)
+	s.ProtoEqual(*expirationTime, resp.ExpirationTime)
+	s.False((*expirationTime).AsTime().Before(before.Add(time.Hour)))
+	s.False((*expirationTime).AsTime().After(time.Now().Add(time.Hour)))
+}
+
+func (s *adminHandlerSuite) TestRehydrateWorkflowExecution_WithManifest() {
+	tv := testvars.New(s.T()).WithNamespaceName(s.namespace).WithNamespaceID(s.namespaceID).WithRunID(uuid.NewString())
+
+	timeSource := clock.NewEventTimeSource()
+	timeSource.Update(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	s.handler.timeSource = timeSource
+
+	historyBatches := []*historypb.History{
+		{Events: []*historypb.HistoryEvent{{EventId: 1, Version: 100}, {EventId: 2, Version: 100}}},
+		{Events: []*historypb.HistoryEvent{{EventId: 3, Version: 200}}},
+	}
+	expectedVersionHistory := &historyspb.VersionHistory{
+		Items: []*historyspb.VersionHistoryItem{
+			{EventId: 2, Version: 100},
+			{EventId: 3, Version: 200},
+		},
+	}
+
+	mockHistoryArchiver := archiver.NewMockHistoryArchiver(s.controller)
+	manifestReader := archiver.NewMockHistoryManifestReader(s.controller)
+	s.mockNamespaceCache.EXPECT().GetNamespace(tv.NamespaceName()).Return(s.newArchivedNamespaceEntry(), nil)
+	s.mockResource.ArchiverProvider.EXPECT().GetHistoryArchiver("test").Return(struct {
+		*archiver.MockHistoryArchiver
+		*archiver.MockHistoryManifestReader
+	}{mockHistoryArchiver, manifestReader}, nil)
+	s.mockHistoryClient.EXPECT().DescribeMutableState(gomock.Any(), gomock.Any()).Return(nil, serviceerror.NewNotFound("workflow not found"))
+	manifestReader.EXPECT().GetManifest(gomock.Any(), gomock.Any(), &archiver.GetHistoryRequest{
+		NamespaceID: tv.NamespaceID().String(),
+		WorkflowID:  tv.WorkflowID(),
+		RunID:       tv.RunID(),
+		PageSize:    rehydrationArchivedHistoryPageSize,
+	}).Return(&archiverspb.HistoryManifest{
+		CloseFailoverVersion: 200,
+		VersionHistory:       expectedVersionHistory,
+	}, nil)
+	// the history is read only once, at the close failover version of the manifest
+	mockHistoryArchiver.EXPECT().Get(gomock.Any(), gomock.Any(), &archiver.GetHistoryRequest{
+		NamespaceID:          tv.NamespaceID().String(),
+		WorkflowID:           tv.WorkflowID(),
+		RunID:                tv.RunID(),
+		CloseFailoverVersion: util.Ptr(int64(200)),
+		PageSize:             rehydrationArchivedHistoryPageSize,
+	}).Return(&archiver.GetHistoryResponse{
+		HistoryBatches: historyBatches,
+	}, nil)
+
+	importedBatches, expirationTime := s.expectRehydrationImports(tv, expectedVersionHistory)
+
+	resp, err := s.handler.RehydrateWorkflowExecution(context.Background(), &adminservice.RehydrateWorkflowExecutionRequest{
+		Namespace: tv.NamespaceName().String(),
+		Execution: tv.WorkflowExecution(),
+		Retention: durationpb.New(time.Hour),
+	})
+	s.NoError(err)
+	s.Equal(len(historyBatches), *importedBatches)
+	s.ProtoEqual(timestamppb.New(timeSource.Now().Add(time.Hour)), resp.ExpirationTime)
+	s.ProtoEqual(*expirationTime, resp.ExpirationTime)
+}
+
+// expectRehydrationImports expects one import of the history batches and one commit, and returns the number of
+// imported history batches and the expiration time of the commit.
+func (s *adminHandlerSuite) expectRehydrationImports(
+	tv *testvars.TestVars,
+	expectedVersionHistory *historyspb.VersionHistory,
+) (*int, **timestamppb.Timestamp) {
+	var importedBatches int
+	var expirationTime *timestamppb.Timestamp
+	s.mockHistoryClient.EXPECT().ImportWorkflowExecution(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, request *historyservice.ImportWorkflowExecutionRequest, opts ...grpc.CallOption) (*historyservice.ImportWorkflowExecutionResponse, error) {
+		s.Equal(tv.NamespaceID().String(), request.NamespaceId)
+		s.ProtoEqual(expectedVersionHistory, request.VersionHistory)
+		if len(request.HistoryBatches) > 0 {
+			s.Nil(request.RehydrationExpirationTime)
+			importedBatches += len(request.HistoryBatches)
+			return &historyservice.ImportWorkflowExecutionResponse{Token: []byte("token")}, nil
+		}
+		s.Equal([]byte("token"), request.Token)
+		expirationTime = request.RehydrationExpirationTime
+		return &historyservice.ImportWorkflowExecutionResponse{}, nil
+	}).Times(2)
+	return &importedBatches, &expirationTime
+}
+
+func (s *adminHandlerSuite) newArchivedNamespaceEntry() *namespace.Namespace {
+	return namespace.NewLocalNamespaceForTest(
+		&persistencespb.NamespaceInfo{
+			Name: s.namespace.String(),
+			Id:   s.namespaceID.String(),
+		},
+		&persistencespb.NamespaceConfig{
+			HistoryArchivalState:    enumspb.ARCHIVAL_STATE_ENABLED,
+			HistoryArchivalUri:      "test:///history/archival",
+			VisibilityArchivalState: enumspb.ARCHIVAL_STATE_ENABLED,
+			VisibilityArchivalUri:   "test:///visibility/archival",
+		},
+		"",
+	)
+}
+
+func (s *adminHandlerSuite) TestVerifyArchivedHistory_InvalidRequest() {
+	_, err := s.handler.VerifyArchivedHistory(context.Background(), nil)
+	s.Equal(errRequestNotSet, err)
+
+	_, err = s.handler.VerifyArchivedHistory(context.Background(), &adminservice.VerifyArchivedHistoryRequest{
+		Namespace: s.namespace.String(),
+		Execution: &commonpb.WorkflowExecution{WorkflowId: "workflow-id"},
+	})
+	s.Equal(errRunIDNotSet, err)
+
+	_, err = s.handler.VerifyArchivedHistory(context.Background(), &adminservice.VerifyArchivedHistoryRequest{
+		Namespace: s.namespace.String(),
+		PageSize:  10001,
+	})
+	s.IsType(&serviceerror.InvalidArgument{}, err)
+}
+
+func (s *adminHandlerSuite) TestVerifyArchivedHistory_Execution() {
+	tv := testvars.New(s.T()).WithNamespaceName(s.namespace).WithNamespaceID(s.namespaceID).WithRunID(uuid.NewString())
+
+	mockHistoryArchiver := archiver.NewMockHistoryArchiver(s.controller)
+	s.mockNamespaceCache.EXPECT().GetNamespace(tv.NamespaceName()).Return(s.newArchivedNamespaceEntry(), nil)
+	s.mockResource.ArchiverProvider.EXPECT().GetHistoryArchiver("test").Return(mockHistoryArchiver, nil)
+	mockHistoryArchiver.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(&archiver.GetHistoryResponse{
+		HistoryBatches: []*historypb.History{{Events: []*historypb.HistoryEvent{{EventId: 1, Version: 100}}}},
+	}, nil)
+
+	resp, err := s.handler.VerifyArchivedHistory(context.Background(), &adminservice.VerifyArchivedHistoryRequest{
+		Namespace: tv.NamespaceName().String(),
+		Execution: tv.WorkflowExecution(),
+	})
+	s.NoError(err)
+	s.Equal(int32(1), resp.VerifiedCount)
+	s.Nil(resp.NextPageToken)
+	s.Len(resp.Results, 1)
+	s.ProtoEqual(tv.WorkflowExecution(), resp.Results[0].Execution)
+	s.Equal(enumsspb.ARCHIVAL_INTEGRITY_STATUS_UNVERIFIED, resp.Results[0].Status)
+}
+
+func (s *adminHandlerSuite) TestVerifyArchivedHistory_Query() {
+	executions := []*commonpb.WorkflowExecution{
+		{WorkflowId: "workflow-id-1", RunId: uuid.NewString()},
+		{WorkflowId: "workflow-id-2", RunId: uuid.NewString()},
+	}
+
+	mockHistoryArchiver := archiver.NewMockHistoryArchiver(s.controller)
+	mockVisibilityArchiver := archiver.NewMockVisibilityArchiver(s.controller)
+	s.mockNamespaceCache.EXPECT().GetNamespace(s.namespace).Return(s.newArchivedNamespaceEntry(), nil)
+	s.mockResource.ArchiverProvider.EXPECT().GetHistoryArchiver("test").Return(mockHistoryArchiver, nil)
+	s.mockResource.ArchiverProvider.EXPECT().GetVisibilityArchiver("test").Return(mockVisibilityArchiver, nil)
+	s.mockVisibilityMgr.EXPECT().GetIndexName().Return("")
+	s.mockResource.SearchAttributesProvider.EXPECT().GetSearchAttributes("", false).Return(searchattribute.TestEsNameTypeMap(), nil)
+	mockVisibilityArchiver.EXPECT().Query(gomock.Any(), gomock.Any(), &archiver.QueryVisibilityRequest{
+		NamespaceID:            s.namespaceID.String(),
+		PageSize:               2,
+		NextPageToken:          []byte("token"),
+		Query:                  "WorkflowType = 'type'",
+		Namespace:              s.namespace.String(),
+		SearchAttributesMapper: s.mockSaMapper,
+	}, gomock.Any()).Return(&archiver.QueryVisibilityResponse{
+		Executions: []*workflowpb.WorkflowExecutionInfo{
+			{Execution: executions[0]},
+			{Execution: executions[1]},
+		},
+		NextPageToken: []byte("next"),
+	}, nil)
+	mockHistoryArchiver.EXPECT().Get(gomock.Any(), gomock.Any(), &archiver.GetHistoryRequest{
+		NamespaceID: s.namespaceID.String(),
+		WorkflowID:  executions[0].WorkflowId,
+		RunID:       executions[0].RunId,
+		PageSize:    1000,
+	}).Return(&archiver.GetHistoryResponse{
+		HistoryBatches: []*historypb.History{{Events: []*historypb.HistoryEvent{{EventId: 1, Version: 100}}}},
+	}, nil)
+	mockHistoryArchiver.EXPECT().Get(gomock.Any(), gomock.Any(), &archiver.GetHistoryRequest{
+		NamespaceID: s.namespaceID.String(),
+		WorkflowID:  executions[1].WorkflowId,
+		RunID:       executions[1].RunId,
+		PageSize:    1000,
+	}).Return(nil, serviceerror.NewNotFound(archiver.ErrHistoryNotExist.Error()))
+
+	resp, err := s.handler.VerifyArchivedHistory(context.Background(), &adminservice.VerifyArchivedHistoryRequest{
+		Namespace:     s.namespace.String(),
+		Query:         "WorkflowType = 'type'",
+		PageSize:      2,
+		NextPageToken: []byte("token"),
+		FailuresOnly:  true,
+	})
+	s.NoError(err)
+	s.Equal(int32(2), resp.VerifiedCount)
+	s.Equal([]byte("next"), resp.NextPageToken)
+	s.Len(resp.Results, 2)
+	s.Equal(enumsspb.ARCHIVAL_INTEGRITY_STATUS_UNVERIFIED, resp.Results[0].Status)
+	s.Equal(enumsspb.ARCHIVAL_INTEGRITY_STATUS_MISSING, resp.Results[1].Status)
+}
+
+func (s *adminHandlerSuite) TestGetArchivedChasmExecution_InvalidRequest() {
+	_, err := s.handler.GetArchivedChasmExecution(context.Background(), nil)
+	s.Equal(errRequestNotSet, err)
+
+	_, err = s.handler.GetArchivedChasmExecution(context.Background(), &adminservice.GetArchivedChasmExecutionRequest{
+		Namespace: s.namespace.String(),
+		RunId:     uuid.NewString(),
+	})
+	s.Equal(errBusinessIDNotSet, err)
+
+	_, err = s.handler.GetArchivedChasmExecution(context.Background(), &adminservice.GetArchivedChasmExecutionRequest{
+		Namespace:  s.namespace.String(),
+		BusinessId: "business-id",
+		RunId:      "invalid-run-id",
+	})
+	s.Equal(errInvalidRunID, err)
+}
+
+func (s *adminHandlerSuite) TestGetArchivedChasmExecution_NotSupported() {
+	s.mockNamespaceCache.EXPECT().GetNamespace(s.namespace).Return(s.newArchivedNamespaceEntry(), nil)
+	s.mockResource.ArchiverProvider.EXPECT().GetHistoryArchiver("test").Return(archiver.NewMockHistoryArchiver(s.controller), nil)
+
+	_, err := s.handler.GetArchivedChasmExecution(context.Background(), &adminservice.GetArchivedChasmExecutionRequest{
+		Namespace:  s.namespace.String(),
+		BusinessId: "business-id",
+		RunId:      uuid.NewString(),
+	})
+	s.IsType(&serviceerror.Unimplemented{}, err)
+}
+
+func (s *adminHandlerSuite) TestGetArchivedChasmExecution() {
+	runID := uuid.NewString()
+	execution := &archiverspb.ChasmExecution{
+		NamespaceId: s.namespaceID.String(),
+		Namespace:   s.namespace.String(),
+		BusinessId:  "business-id",
+		RunId:       runID,
+		ArchetypeId: 1,
+		Archetype:   "test.archetype",
+	}
+
+	chasmArchiver := archiver.NewMockChasmExecutionArchiver(s.controller)
+	s.mockNamespaceCache.EXPECT().GetNamespace(s.namespace).Return(s.newArchivedNamespaceEntry(), nil)
+	s.mockResource.ArchiverProvider.EXPECT().GetHistoryArchiver("test").Return(struct {
+		*archiver.MockHistoryArchiver
+		*archiver.MockChasmExecutionArchiver
+	}{archiver.NewMockHistoryArchiver(s.controller), chasmArchiver}, nil)
+	chasmArchiver.EXPECT().GetChasmExecution(gomock.Any(), gomock.Any(), &archiver.GetChasmExecutionRequest{
+		NamespaceID: s.namespaceID.String(),
+		BusinessID:  "business-id",
+		RunID:       runID,
+	}).Return(execution, nil)
+
+	resp, err := s.handler.GetArchivedChasmExecution(context.Background(), &adminservice.GetArchivedChasmExecutionRequest{
+		Namespace:  s.namespace.String(),
+		BusinessId: "business-id",
+		RunId:      runID,
+	})
+	s.NoError(err)
+	s.ProtoEqual(execution, resp.Execution)
+}
+
+func (s *adminHandlerSuite) TestAdvanceNamespaceTime() {
+	s.handler.numberOfHistoryShards = 3
+	virtualTime := timestamppb.New(time.Now().Add(time.Hour))
+
+	s.mockNamespaceCache.EXPECT().GetNamespaceID(s.namespace).Return(s.namespaceID, nil)
+	s.mockHistoryClient.EXPECT().AdvanceNamespaceTime(gomock.Any(), &historyservice.AdvanceNamespaceTimeRequest{
+		NamespaceId: s.namespaceID.String(),
+		ShardId:     timeskipping.CoordinatorShardID(s.namespaceID, 3),
+		Duration:    durationpb.New(time.Hour),
+	}).Return(&historyservice.AdvanceNamespaceTimeResponse{Time: virtualTime}, nil)
+
+	resp, err := s.handler.AdvanceNamespaceTime(context.Background(), &adminservice.AdvanceNamespaceTimeRequest{
+		Namespace: s.namespace.String(),
+		Duration:  durationpb.New(time.Hour),
+	})
+	s.NoError(err)
+	s.ProtoEqual(virtualTime, resp.Time)
+
+	_, err = s.handler.AdvanceNamespaceTime(context.Background(), &adminservice.AdvanceNamespaceTimeRequest{})
+	s.Equal(errNamespaceNotSet, err)
+}
+
+func (s *adminHandlerSuite) TestFaultInjectionRules() {
+	ctx := context.Background()
+
+	_, err := s.handler.AddFaultInjectionRule(ctx, &adminservice.AddFaultInjectionRuleRequest{})
+	s.ErrorIs(err, errFaultInjectionRuleNotSet)
+
+	_, err = s.handler.AddFaultInjectionRule(ctx, &adminservice.AddFaultInjectionRuleRequest{
+		Rule: &adminservice.FaultInjectionRule{
+			DataStore: string(config.ShardStoreName),
+			Method:    "UpdateShard",
+			Errors:    map[string]float64{"Timeout": 2},
+		},
+	})
+	var invalidArgument *serviceerror.InvalidArgument
+	s.ErrorAs(err, &invalidArgument)
+
+	rule := &adminservice.FaultInjectionRule{
+		DataStore: string(config.ShardStoreName),
+		Method:    "UpdateShard",
+		Errors:    map[string]float64{"Timeout": 0.5},
+		Latency: &adminservice.FaultInjectionLatency{
+			Rate:         1,
+			Distribution: string(config.FaultInjectionLatencyUniform),
+			Min:          durationpb.New(time.Millisecond),
+			Max:          durationpb.New(time.Second),
+		},
+		Windows: []*adminservice.FaultInjectionWindow{{
+			Start:    durationpb.New(time.Minute),
+			Duration: durationpb.New(time.Second),
+			Period:   durationpb.New(time.Hour),
+		}},
+		Seed: 42,
+	}
+	historyHost := membership.NewHostInfoFromAddress("1.1.1.1:7234")
+	matchingHost := membership.NewHostInfoFromAddress("2.2.2.2:7235")
+	s.mockResource.HistoryServiceResolver.EXPECT().Members().Return([]membership.HostInfo{historyHost}).Times(2)
+	s.mockResource.MatchingServiceResolver.EXPECT().Members().Return([]membership.HostInfo{matchingHost}).Times(2)
+	s.mockHistoryClient.EXPECT().AddFaultInjectionRule(gomock.Any(), &historyservice.AddFaultInjectionRuleRequest{
+		HostAddress: historyHost.GetAddress(),
+		Rule:        rule,
+	}).Return(&historyservice.AddFaultInjectionRuleResponse{}, nil)
+	s.mockMatchingClient.EXPECT().AddFaultInjectionRule(gomock.Any(), &matchingservice.AddFaultInjectionRuleRequest{
+		HostAddress: matchingHost.GetAddress(),
+		Rule:        rule,
+	}).Return(&matchingservice.AddFaultInjectionRuleResponse{}, nil)
+	addResp, err := s.handler.AddFaultInjectionRule(ctx, &adminservice.AddFaultInjectionRuleRequest{Rule: rule})
+	s.NoError(err)
+	s.Equal([]string{historyHost.GetAddress(), matchingHost.GetAddress()}, addResp.Hosts)
+
+	listResp, err := s.handler.ListFaultInjectionRules(ctx, &adminservice.ListFaultInjectionRulesRequest{})
+	s.NoError(err)
+	expected := common.CloneProto(rule)
+	expected.Source = string(faultinjection.RuleSourceRuntime)
+	s.Len(listResp.Rules, 1)
+	s.ProtoEqual(expected, listResp.Rules[0])
+
+	listResp, err = s.handler.ListFaultInjectionRules(ctx, &adminservice.ListFaultInjectionRulesRequest{
+		DataStore: string(config.ExecutionStoreName),
+	})
+	s.NoError(err)
+	s.Empty(listResp.Rules)
+
+	s.mockHistoryClient.EXPECT().ClearFaultInjectionRules(gomock.Any(), &historyservice.ClearFaultInjectionRulesRequest{
+		HostAddress: historyHost.GetAddress(),
+		DataStore:   string(config.ShardStoreName),
+	}).Return(&historyservice.ClearFaultInjectionRulesResponse{ClearedCount: 1}, nil)
+	s.mockMatchingClient.EXPECT().ClearFaultInjectionRules(gomock.Any(), &matchingservice.ClearFaultInjectionRulesRequest{
+		HostAddress: matchingHost.GetAddress(),
+		DataStore:   string(config.ShardStoreName),
+	}).Return(nil, serviceerror.NewUnavailable("host is unreachable"))
+	_, err = s.handler.ClearFaultInjectionRules(ctx, &adminservice.ClearFaultInjectionRulesRequest{
+		DataStore: string(config.ShardStoreName),
+	})
+	var unavailable *serviceerror.Unavailable
+	s.ErrorAs(err, &unavailable)
+	s.Contains(err.Error(), matchingHost.GetAddress())
+	// the rules of this host and of the reachable hosts are cleared nonetheless
+	s.Empty(s.handler.faultInjectionRules.List(config.ShardStoreName))
+
+	s.handler.faultInjectionRules = nil
+	_, err = s.handler.ListFaultInjectionRules(ctx, &adminservice.ListFaultInjectionRulesRequest{})
+	s.ErrorIs(err, errFaultInjectionNotEnabled)
+}
+
+func (s *adminHandlerSuite) TestAggregateWorkflowExecutions() {
+	ctx := context.Background()
+
+	_, err := s.handler.AggregateWorkflowExecutions(ctx, &adminservice.AggregateWorkflowExecutionsRequest{
+		Namespace: s.namespace.String(),
+	})
+	s.ErrorIs(err, errAggregationsNotSet)
+
+	groupValue := payload.EncodeString("Running")
+	value := payload.EncodeString("1.5")
+	s.mockNamespaceCache.EXPECT().GetNamespaceID(s.namespace).Return(s.namespaceID, nil)
+	s.mockVisibilityMgr.EXPECT().AggregateWorkflowExecutions(gomock.Any(), &manager.AggregateWorkflowExecutionsRequest{
+		NamespaceID:  s.namespaceID,
+		Namespace:    s.namespace,
+		Query:        "GROUP BY ExecutionStatus",
+		Aggregations: []string{"avg(ExecutionDuration)"},
+	}).Return(&manager.AggregateWorkflowExecutionsResponse{
+		Count: 3,
+		Groups: []*manager.AggregationGroup{{
+			GroupValues: []*commonpb.Payload{groupValue},
+			Count:       3,
+			Values:      []*commonpb.Payload{value},
+		}},
+	}, nil)
+
+	resp, err := s.handler.AggregateWorkflowExecutions(ctx, &adminservice.AggregateWorkflowExecutionsRequest{
+		Namespace:    s.namespace.String(),
+		Query:        "GROUP BY ExecutionStatus",
+		Aggregations: []string{"avg(ExecutionDuration)"},
+	})
+	s.NoError(err)
+	s.ProtoEqual(&adminservice.AggregateWorkflowExecutionsResponse{
+		Count: 3,
+		Groups: []*adminservice.AggregationGroup{{
+			GroupValues: []*commonpb.Payload{groupValue},
+			Count:       3,
+			Values:      []*commonpb.Payload{value},
+		}},
+	}, resp)
+}
+
+func (s *adminHandlerSuite) TestExplainVisibilityQuery() {
+	ctx := context.Background()
+
+	_, err := s.handler.ExplainVisibilityQuery(ctx, &adminservice.ExplainVisibilityQueryRequest{})
+	s.ErrorIs(err, errNamespaceNotSet)
+
+	s.mockNamespaceCache.EXPECT().GetNamespaceID(s.namespace).Return(s.namespaceID, nil).Times(2)
+	s.mockVisibilityMgr.EXPECT().ExplainQuery(gomock.Any(), &manager.ExplainQueryRequest{
+		NamespaceID: s.namespaceID,
+		Namespace:   s.namespace,
+		Query:       "WorkflowType = 'test'",
+		PageSize:    1000,
+	}).Return(&manager.ExplainQueryResponse{
+		AST: "Where\n  Comparison =\n    Column WorkflowType\n    Value 'test'\n",
+		SearchAttributes: []*manager.ExplainedSearchAttribute{{
+			Name:      "WorkflowType",
+			FieldName: "WorkflowType",
+			Type:      enumspb.INDEXED_VALUE_TYPE_KEYWORD,
+		}},
+		StoreName:  "sqlite",
+		StoreQuery: "SELECT 1",
+	}, nil)
+	resp, err := s.handler.ExplainVisibilityQuery(ctx, &adminservice.ExplainVisibilityQueryRequest{
+		Namespace: s.namespace.String(),
+		Query:     "WorkflowType = 'test'",
+		PageSize:  5000,
+	})
+	s.NoError(err)
+	s.ProtoEqual(&adminservice.ExplainVisibilityQueryResponse{
+		Ast: "Where\n  Comparison =\n    Column WorkflowType\n    Value 'test'\n",
+		SearchAttributes: []*adminservice.ExplainedSearchAttribute{{
+			Name:      "WorkflowType",
+			FieldName: "WorkflowType",
+			Type:      enumspb.INDEXED_VALUE_TYPE_KEYWORD,
+		}},
+		StoreName:  "sqlite",
+		StoreQuery: "SELECT 1",
+	}, resp)
+
+	s.mockVisibilityMgr.EXPECT().ExplainQuery(gomock.Any(), &manager.ExplainQueryRequest{
+		NamespaceID: s.namespaceID,
+		Namespace:   s.namespace,
+		Query:       "WorkflowType = 'test'",
+		PageSize:    10,
+		Execute:     true,
+	}).Return(&manager.ExplainQueryResponse{
+		StoreName:  "sqlite",
+		StoreQuery: "SELECT 1",
+		Plan:       "SCAN executions_visibility\n",
+		Duration:   time.Second,
+		Count:      3,
+	}, nil)
+	resp, err = s.handler.ExplainVisibilityQuery(ctx, &adminservice.ExplainVisibilityQueryRequest{
+		Namespace: s.namespace.String(),
+		Query:     "WorkflowType = 'test'",
+		PageSize:  10,
+		Execute:   true,
+	})
+	s.NoError(err)
+	s.ProtoEqual(&adminservice.ExplainVisibilityQueryResponse{
+		SearchAttributes: []*adminservice.ExplainedSearchAttribute{},
+		StoreName:        "sqlite",
+		StoreQuery:       "SELECT 1",
+		Plan:             "SCAN executions_visibility\n",
+		Duration:         durationpb.New(time.Second),
+		Count:            3,
+	}, resp)
+}
@@ -28,6 +28,7 @@ import (
 	workerpb "go.temporal.io/api/worker/v1"
 	workflowpb "go.temporal.io/api/workflow/v1"
 	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/converter"
 	batchspb "go.temporal.io/server/api/batch/v1"
 	deploymentspb "go.temporal.io/server/api/deployment/v1"
 	"go.temporal.io/server/api/historyservice/v1"
@@ -80,9 +81,11 @@ import (
 	"go.temporal.io/server/service/worker/batcher"
 	"go.temporal.io/server/service/worker/scheduler"
 	"go.temporal.io/server/service/worker/workerdeployment"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/health"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/protowire"
 	"google.golang.org/protobuf/proto"
@@ -106,6 +109,14 @@ const (
 	maxReasonLength              = 1000 // Maximum length for the reason field in RateLimitUpdate configurations.
 	defaultUserTerminateReason   = "terminated by user via frontend"
 	defaultUserTerminateIdentity = "frontend-service"
+
+	// countGroupsTruncatedHeader is the response header of CountWorkflowExecutions which flags that only the groups
+	// with the highest counts are returned, because there are more groups than the max number of groups.
+	countGroupsTruncatedHeader = "temporal-count-groups-truncated"
+	// countOtherGroupsMetadataKey is the payload metadata key of the group values of the group which
+	// CountWorkflowExecutions appends to a truncated response. The group counts the executions of the groups which
+	// are left out, so that the counts of the groups still add up to the count.
+	countOtherGroupsMetadataKey = "temporal-other-groups"
 )
 
 type (
@@ -2632,11 +2643,18 @@ func (wh *WorkflowHandler) ListArchivedWorkflowExecutions(ctx context.Context, r
 		return nil, err
 	}
 
+	saMapper, err := wh.saMapperProvider.GetMapper(entry.Name())
+	if err != nil {
+		return nil, err
+	}
+
 	archiverRequest := &archiver.QueryVisibilityRequest{
-		NamespaceID:   entry.ID().String(),
-		PageSize:      int(request.GetPageSize()),
-		NextPageToken: request.NextPageToken,
-		Query:         request.GetQuery(),
+		NamespaceID:            entry.ID().String(),
+		PageSize:               int(request.GetPageSize()),
+		NextPageToken:          request.NextPageToken,
+		Query:                  request.GetQuery(),
+		Namespace:              entry.Name().String(),
+		SearchAttributesMapper: saMapper,
 	}
 
 	searchAttributes, err := wh.saProvider.GetSearchAttributes(wh.visibilityMgr.GetIndexName(), false)
@@ -2711,14 +2729,48 @@ func (wh *WorkflowHandler) CountWorkflowExecutions(ctx context.Context, request
 	if err != nil {
 		return nil, err
 	}
+	if persistenceResp.Truncated {
+		if err := grpc.SetHeader(ctx, metadata.Pairs(countGroupsTruncatedHeader, "true")); err != nil {
+			wh.logger.Warn("Failed to add the truncated groups header to the response.", tag.WorkflowNamespace(namespaceName.String()), tag.Error(err))
+		}
+	}
 
 	resp := &workflowservice.CountWorkflowExecutionsResponse{
 		Count:  persistenceResp.Count,
 		Groups: persistenceResp.Groups,
 	}
+	if persistenceResp.Truncated {
+		resp.Groups = append(resp.Groups, newCountOtherGroupsGroup(persistenceResp))
+	}
 	return resp, nil
 }
 
+// newCountOtherGroupsGroup returns the group of the executions of the groups which are left out of a truncated count.
+// Its group values are null payloads which are flagged with countOtherGroupsMetadataKey.
+func newCountOtherGroupsGroup(
+	persistenceResp *manager.CountWorkflowExecutionsResponse,
+) *workflowservice.CountWorkflowExecutionsResponse_AggregationGroup {
+	otherCount := persistenceResp.Count
+	var groupValuesLen int
+	for _, group := range persistenceResp.Groups {
+		otherCount -= group.GetCount()
+		groupValuesLen = len(group.GetGroupValues())
+	}
+	groupValues := make([]*commonpb.Payload, groupValuesLen)
+	for i := range groupValues {
+		groupValues[i] = &commonpb.Payload{
+			Metadata: map[string][]byte{
+				converter.MetadataEncoding:  []byte(converter.MetadataEncodingNil),
+				countOtherGroupsMetadataKey: []byte("true"),
+			},
+		}
+	}
+	return &workflowservice.CountWorkflowExecutionsResponse_AggregationGroup{
+		GroupValues: groupValues,
+		Count:       otherCount,
+	}
+}
+
 // GetSearchAttributes is a visibility API to get all legal keys that could be used in list APIs
 func (wh *WorkflowHandler) GetSearchAttributes(ctx context.Context, _ *workflowservice.GetSearchAttributesRequest) (_ *workflowservice.GetSearchAttributesResponse, retError error) {
 	defer log.CapturePanic(wh.logger, &retError)
@@ -2016,7 +2016,14 @@ func (s *WorkflowHandlerSuite) TestListArchivedVisibility_Success() {
 		"",
 	), nil).AnyTimes()
 	s.mockArchivalMetadata.EXPECT().GetVisibilityConfig().Return(archiver.NewArchivalConfig("enabled", dc.GetStringPropertyFn("enabled"), dc.GetBoolPropertyFn(true), "disabled", "random URI")).Times(2)
-	s.mockVisibilityArchiver.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&archiver.QueryVisibilityResponse{}, nil)
+	saMapper := &searchattribute.TestMapper{}
+	s.mockSearchAttributesMapperProvider.EXPECT().GetMapper(namespace.Name("test-namespace")).Return(saMapper, nil)
+	s.mockVisibilityArchiver.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ archiver.URI, request *archiver.QueryVisibilityRequest, _ searchattribute.NameTypeMap) (*archiver.QueryVisibilityResponse, error) {
+			s.Equal("test-namespace", request.Namespace)
+			s.Equal(saMapper, request.SearchAttributesMapper)
+			return &archiver.QueryVisibilityResponse{}, nil
+		})
 	s.mockArchiverProvider.EXPECT().GetVisibilityArchiver(gomock.Any()).Return(s.mockVisibilityArchiver, nil)
 	s.mockSearchAttributesProvider.EXPECT().GetSearchAttributes("", false)
 
@@ -2189,6 +2196,34 @@ func (s *WorkflowHandlerSuite) TestCountWorkflowExecutions() {
 	s.Equal(int64(5), resp.Count)
 }
 
+func (s *WorkflowHandlerSuite) TestCountWorkflowExecutions_GroupsTruncated() {
+	wh := s.getWorkflowHandler(s.newConfig())
+
+	runningPayload := payload.EncodeString(enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING.String())
+	s.mockNamespaceCache.EXPECT().GetNamespaceID(gomock.Any()).Return(s.testNamespaceID, nil).AnyTimes()
+	s.mockVisibilityMgr.EXPECT().CountWorkflowExecutions(gomock.Any(), gomock.Any()).Return(&manager.CountWorkflowExecutionsResponse{
+		Count: 5,
+		Groups: []*workflowservice.CountWorkflowExecutionsResponse_AggregationGroup{
+			{GroupValues: []*commonpb.Payload{runningPayload}, Count: 3},
+		},
+		Truncated: true,
+	}, nil)
+
+	resp, err := wh.CountWorkflowExecutions(context.Background(), &workflowservice.CountWorkflowExecutionsRequest{
+		Namespace: s.testNamespace.String(),
+		Query:     "GROUP BY ExecutionStatus",
+	})
+	s.NoError(err)
+	s.Equal(int64(5), resp.Count)
+	s.Len(resp.Groups, 2)
+	s.Equal(int64(3), resp.Groups[0].Count)
+	otherGroup := resp.Groups[1]
+	s.Equal(int64(2), otherGroup.Count)
+	s.Len(otherGroup.GroupValues, 1)
+	s.Equal([]byte("true"), otherGroup.GroupValues[0].GetMetadata()[countOtherGroupsMetadataKey])
+	s.Equal([]byte("binary/null"), otherGroup.GroupValues[0].GetMetadata()["encoding"])
+}
+
 func (s *WorkflowHandlerSuite) TestVerifyHistoryIsComplete() {
 	logger := log.NewTestLogger()
 	events := make([]*historyspb.StrippedHistoryEvent, 50)
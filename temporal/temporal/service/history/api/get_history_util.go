@@ -61,6 +61,7 @@ func GetRawHistory(
 	rawHistory, size, nextToken, err := persistence.ReadFullPageRawEvents(
 		ctx, shardContext.GetExecutionManager(),
 		&persistence.ReadHistoryBranchRequest{
+			NamespaceID:   namespaceID.String(),
 			BranchToken:   branchToken,
 			MinEventID:    firstEventID,
 			MaxEventID:    nextEventID,
@@ -189,6 +190,7 @@ func GetHistory(
 	var err error
 	var historyEvents []*historypb.HistoryEvent
 	historyEvents, size, nextPageToken, err = persistence.ReadFullPageEvents(ctx, shardContext.GetExecutionManager(), &persistence.ReadHistoryBranchRequest{
+		NamespaceID:   namespaceID.String(),
 		BranchToken:   branchToken,
 		MinEventID:    firstEventID,
 		MaxEventID:    nextEventID,
@@ -309,6 +311,7 @@ func GetHistoryReverse(
 	var historyEvents []*historypb.HistoryEvent
 
 	historyEvents, size, nextPageToken, err = persistence.ReadFullPageEventsReverse(ctx, shardContext.GetExecutionManager(), &persistence.ReadHistoryBranchReverseRequest{
+		NamespaceID:            namespaceID.String(),
 		BranchToken:            branchToken,
 		MaxEventID:             nextEventID,
 		LastFirstTransactionID: lastFirstTxnID,
@@ -98,6 +98,7 @@ func Invoke(
 		shardContext.GetConfig().NumberOfShards,
 	)
 	rawHistoryResponse, err := shardContext.GetExecutionManager().ReadRawHistoryBranch(ctx, &persistence.ReadHistoryBranchRequest{
+		NamespaceID: ns.ID().String(),
 		BranchToken: targetVersionHistory.GetBranchToken(),
 		// GetWorkflowExecutionRawHistory is inclusive/inclusive.
 		// ReadRawHistoryBranch is inclusive/exclusive.
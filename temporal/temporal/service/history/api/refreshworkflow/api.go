@@ -15,6 +15,7 @@ func Invoke(
 	ctx context.Context,
 	workflowKey definition.WorkflowKey,
 	archetypeID chasm.ArchetypeID,
+	visibilityOnly bool,
 	shardContext historyi.ShardContext,
 	workflowConsistencyChecker api.WorkflowConsistencyChecker,
 ) (retError error) {
@@ -39,5 +40,8 @@ func Invoke(
 	}
 	defer func() { chasmLease.GetReleaseFn()(retError) }()
 
+	if visibilityOnly {
+		return chasmLease.GetContext().RefreshVisibilityTasks(ctx, shardContext)
+	}
 	return chasmLease.GetContext().RefreshTasks(ctx, shardContext)
 }
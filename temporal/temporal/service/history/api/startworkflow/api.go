@@ -638,6 +638,7 @@ func (s *Starter) getWorkflowHistory(ctx context.Context, mutableState *mutableS
 	for {
 		response, err := s.shardContext.GetExecutionManager().ReadHistoryBranch(ctx, &persistence.ReadHistoryBranchRequest{
 			ShardID:     s.shardContext.GetShardID(),
+			NamespaceID: s.namespace.ID().String(),
 			BranchToken: mutableState.branchToken,
 			MinEventID:  1,
 			MaxEventID:  mutableState.lastEventID,
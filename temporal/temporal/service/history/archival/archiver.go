@@ -13,6 +13,7 @@ import (
 	enumspb "go.temporal.io/api/enums/v1"
 	"go.temporal.io/api/serviceerror"
 	archiverspb "go.temporal.io/server/api/archiver/v1"
+	persistencespb "go.temporal.io/server/api/persistence/v1"
 	carchiver "go.temporal.io/server/common/archiver"
 	"go.temporal.io/server/common/archiver/provider"
 	"go.temporal.io/server/common/log"
@@ -54,7 +55,14 @@ type (
 		// VisibilityURI is the URI of the visibility archival backend.
 		VisibilityURI carchiver.URI
 
-		// archival targets: history and/or visibility
+		// CHASM execution archival
+		ArchetypeID uint32
+		Archetype   string
+		// ChasmNodes are the serialized nodes of the final component tree of a CHASM execution, which are archived
+		// into the HistoryURI in place of a workflow history.
+		ChasmNodes map[string]*persistencespb.ChasmNode
+
+		// archival targets: history or CHASM execution, and/or visibility
 		Targets       []Target
 		CallerService string
 	}
@@ -81,8 +89,9 @@ type (
 )
 
 const (
-	TargetHistory    Target = "history"
-	TargetVisibility Target = "visibility"
+	TargetHistory        Target = "history"
+	TargetVisibility     Target = "visibility"
+	TargetChasmExecution Target = "chasm_execution"
 )
 
 // NewArchiver creates a new Archiver
@@ -165,6 +174,12 @@ func (a *archiver) Archive(ctx context.Context, request *Request) (res *Response
 
 				errs[i] = a.archiveVisibility(ctx, request, logger)
 			}()
+		case TargetChasmExecution:
+			go func() {
+				defer wg.Done()
+
+				errs[i] = a.archiveChasmExecution(ctx, request, logger)
+			}()
 		default:
 			return nil, fmt.Errorf("unknown archival target: %s", target)
 		}
@@ -201,6 +216,38 @@ func (a *archiver) archiveHistory(ctx context.Context, request *Request, logger
 	})
 }
 
+func (a *archiver) archiveChasmExecution(ctx context.Context, request *Request, logger log.Logger) (err error) {
+	logger = log.With(
+		logger,
+		tag.ArchivalRequestCloseFailoverVersion(request.CloseFailoverVersion),
+		tag.ArchivalURI(request.HistoryURI.String()),
+	)
+	defer a.recordArchiveTargetResult(logger, time.Now(), TargetChasmExecution, &err)
+
+	historyArchiver, err := a.archiverProvider.GetHistoryArchiver(request.HistoryURI.Scheme())
+	if err != nil {
+		return err
+	}
+	chasmArchiver, ok := historyArchiver.(carchiver.ChasmExecutionArchiver)
+	if !ok {
+		// The execution must not be deleted at retention without its component tree being archived, so the task
+		// fails until the history archiver of the scheme supports CHASM executions.
+		return fmt.Errorf("history archiver of scheme %q does not support CHASM executions", request.HistoryURI.Scheme())
+	}
+
+	return chasmArchiver.ArchiveChasmExecution(ctx, request.HistoryURI, &archiverspb.ChasmExecution{
+		NamespaceId:          request.NamespaceID,
+		Namespace:            request.Namespace,
+		BusinessId:           request.WorkflowID,
+		RunId:                request.RunID,
+		ArchetypeId:          request.ArchetypeID,
+		Archetype:            request.Archetype,
+		CloseFailoverVersion: request.CloseFailoverVersion,
+		CloseTime:            request.CloseTime,
+		Nodes:                request.ChasmNodes,
+	})
+}
+
 func (a *archiver) archiveVisibility(ctx context.Context, request *Request, logger log.Logger) (err error) {
 	logger = log.With(
 		logger,
@@ -245,7 +292,7 @@ func (a *archiver) archiveVisibility(ctx context.Context, request *Request, logg
 		Memo:               request.Memo,
 		SearchAttributes:   searchAttributes,
 		HistoryArchivalUri: historyArchivalUri,
-	})
+	}, carchiver.GetSearchAttributeTypesOption(saTypeMap))
 }
 
 // recordArchiveTargetResult takes an error pointer as an argument so that it isn't passed-by-value when used in a defer
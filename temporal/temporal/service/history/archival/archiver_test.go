@@ -8,6 +8,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	commonpb "go.temporal.io/api/common/v1"
+	archiverspb "go.temporal.io/server/api/archiver/v1"
+	persistencespb "go.temporal.io/server/api/persistence/v1"
 	carchiver "go.temporal.io/server/common/archiver"
 	"go.temporal.io/server/common/archiver/provider"
 	"go.temporal.io/server/common/log"
@@ -182,7 +184,7 @@ func TestArchiver(t *testing.T) {
 				Return(visibilityArchiver, nil).AnyTimes()
 
 			if c.ExpectArchiveVisibility {
-				visibilityArchiver.EXPECT().Archive(gomock.Any(), visibilityURI, gomock.Any()).
+				visibilityArchiver.EXPECT().Archive(gomock.Any(), visibilityURI, gomock.Any(), gomock.Any()).
 					Return(c.ArchiveVisibilityErr)
 			}
 
@@ -254,3 +256,96 @@ func TestArchiver(t *testing.T) {
 		})
 	}
 }
+
+type chasmHistoryArchiver struct {
+	*carchiver.MockHistoryArchiver
+	*carchiver.MockChasmExecutionArchiver
+}
+
+func TestArchiver_ChasmExecution(t *testing.T) {
+	for _, c := range []struct {
+		Name                 string
+		SupportedByArchiver  bool
+		ArchiveErr           error
+		ExpectedReturnErrors []string
+	}{
+		{
+			Name:                "CHASM execution archival succeeds",
+			SupportedByArchiver: true,
+		},
+		{
+			Name:                 "CHASM execution archival fails",
+			SupportedByArchiver:  true,
+			ArchiveErr:           errors.New("example archive CHASM execution error"),
+			ExpectedReturnErrors: []string{"example archive CHASM execution error"},
+		},
+		{
+			Name:                 "CHASM execution archival fails with unsupported archiver",
+			SupportedByArchiver:  false,
+			ExpectedReturnErrors: []string{`history archiver of scheme "test" does not support CHASM executions`},
+		},
+	} {
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			controller := gomock.NewController(t)
+			historyURI, err := carchiver.NewURI("test:///history/archival")
+			require.NoError(t, err)
+			nodes := map[string]*persistencespb.ChasmNode{
+				"": {Data: &commonpb.DataBlob{Data: []byte("root")}},
+			}
+
+			archiverProvider := provider.NewMockArchiverProvider(controller)
+			historyArchiver := carchiver.NewMockHistoryArchiver(controller)
+			if c.SupportedByArchiver {
+				chasmArchiver := carchiver.NewMockChasmExecutionArchiver(controller)
+				chasmArchiver.EXPECT().ArchiveChasmExecution(gomock.Any(), historyURI, gomock.Any()).DoAndReturn(
+					func(_ context.Context, _ carchiver.URI, request *archiverspb.ChasmExecution, _ ...carchiver.ArchiveOption) error {
+						assert.Equal(t, "business-id", request.GetBusinessId())
+						assert.Equal(t, uint32(1234), request.GetArchetypeId())
+						assert.Equal(t, "scheduler.scheduler", request.GetArchetype())
+						assert.Equal(t, nodes, request.GetNodes())
+						return c.ArchiveErr
+					},
+				)
+				archiverProvider.EXPECT().GetHistoryArchiver(gomock.Any()).
+					Return(&chasmHistoryArchiver{historyArchiver, chasmArchiver}, nil)
+			} else {
+				archiverProvider.EXPECT().GetHistoryArchiver(gomock.Any()).Return(historyArchiver, nil)
+			}
+
+			rateLimiter := quotas.NewMockRateLimiter(controller)
+			rateLimiter.EXPECT().WaitN(gomock.Any(), 1).Return(nil)
+
+			archiver := NewArchiver(
+				archiverProvider,
+				log.NewNoopLogger(),
+				metrics.NoopMetricsHandler,
+				rateLimiter,
+				searchattribute.NewMockProvider(controller),
+				manager.NewMockVisibilityManager(controller),
+			)
+			_, err = archiver.Archive(ctx, &Request{
+				NamespaceID: "namespace-id",
+				Namespace:   "namespace",
+				WorkflowID:  "business-id",
+				RunID:       "run-id",
+				HistoryURI:  historyURI,
+				ArchetypeID: 1234,
+				Archetype:   "scheduler.scheduler",
+				ChasmNodes:  nodes,
+				Targets:     []Target{TargetChasmExecution},
+			})
+
+			if len(c.ExpectedReturnErrors) > 0 {
+				require.Error(t, err)
+				for _, e := range c.ExpectedReturnErrors {
+					assert.Contains(t, err.Error(), e)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -4,16 +4,22 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
+	commonpb "go.temporal.io/api/common/v1"
 	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
 	"go.temporal.io/server/chasm"
+	"go.temporal.io/server/common"
 	carchiver "go.temporal.io/server/common/archiver"
 	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/log/tag"
 	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/common/payload"
 	"go.temporal.io/server/common/persistence"
 	"go.temporal.io/server/common/primitives"
+	"go.temporal.io/server/common/searchattribute/sadefs"
 	"go.temporal.io/server/service/history/archival"
 	historyi "go.temporal.io/server/service/history/interfaces"
 	"go.temporal.io/server/service/history/queues"
@@ -185,10 +191,19 @@ func (e *archivalQueueTaskExecutor) getArchiveTaskRequest(
 			)
 			return nil, fmt.Errorf("failed to parse history URI for archival task: %w", err)
 		}
-		targets = append(targets, archival.TargetHistory)
+		if mutableState.IsWorkflow() {
+			targets = append(targets, archival.TargetHistory)
+		} else {
+			targets = append(targets, archival.TargetChasmExecution)
+		}
 	}
 
-	workflowAttributes, err := e.relocatableAttributesFetcher.Fetch(ctx, mutableState)
+	var workflowAttributes *workflow.RelocatableAttributes
+	if mutableState.IsWorkflow() {
+		workflowAttributes, err = e.relocatableAttributesFetcher.Fetch(ctx, mutableState)
+	} else {
+		workflowAttributes, err = e.getChasmVisibilityAttributes(ctx, mutableState)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -216,9 +231,74 @@ func (e *archivalQueueTaskExecutor) getArchiveTaskRequest(
 		Targets:              targets,
 		CallerService:        string(primitives.HistoryService),
 	}
+	if !mutableState.IsWorkflow() {
+		tree := mutableState.ChasmTree()
+		archetype, ok := e.shardContext.ChasmRegistry().ComponentFqnByID(tree.ArchetypeID())
+		if !ok {
+			return nil, serviceerror.NewInternalf("unknown CHASM archetype ID: %d", tree.ArchetypeID())
+		}
+		// The archetype takes the place of the workflow type in the archived visibility record, and the final
+		// component tree takes the place of the history. The nodes are cloned as they are archived after the mutable
+		// state is released.
+		request.WorkflowTypeName = archetype
+		request.HistoryLength = 0
+		request.ArchetypeID = tree.ArchetypeID()
+		request.Archetype = archetype
+		request.ChasmNodes = common.CloneProtoMap(tree.Snapshot(nil).Nodes)
+	}
 	return request, nil
 }
 
+// getChasmVisibilityAttributes returns the memo and the search attributes of the archived visibility record of a CHASM
+// execution, which are provided by its root component like in the visibility queue.
+func (e *archivalQueueTaskExecutor) getChasmVisibilityAttributes(
+	ctx context.Context,
+	mutableState historyi.MutableState,
+) (*workflow.RelocatableAttributes, error) {
+	tree := mutableState.ChasmTree()
+	chasmNode, ok := tree.(*chasm.Node)
+	if !ok {
+		return nil, serviceerror.NewInternalf(
+			"CHASM tree implementation not properly wired up, encountered type: %T, expected type: %T",
+			tree,
+			&chasm.Node{},
+		)
+	}
+
+	chasmContext := chasm.NewContext(ctx, chasmNode)
+	rootComponent, err := tree.ComponentByPath(chasmContext, nil)
+	if err != nil {
+		return nil, err
+	}
+	chasmContext = chasm.AugmentContextForComponent(chasmContext, rootComponent, e.shardContext.ChasmRegistry())
+
+	searchAttributes := map[string]*commonpb.Payload{
+		// The ArchetypeID is stored in the TemporalNamespaceDivision search attribute, like in the visibility store.
+		sadefs.TemporalNamespaceDivision: payload.EncodeString(strconv.FormatUint(uint64(tree.ArchetypeID()), 10)),
+	}
+	if saProvider, ok := rootComponent.(chasm.VisibilitySearchAttributesProvider); ok {
+		for _, chasmSA := range saProvider.SearchAttributes(chasmContext) {
+			searchAttributes[chasmSA.Field] = chasmSA.Value.MustEncode()
+		}
+	}
+
+	memo := make(map[string]*commonpb.Payload, 1)
+	if memoProvider, ok := rootComponent.(chasm.VisibilityMemoProvider); ok {
+		if chasmMemo := memoProvider.Memo(chasmContext); chasmMemo != nil {
+			chasmMemoPayload, err := payload.Encode(chasmMemo)
+			if err != nil {
+				return nil, err
+			}
+			memo[chasm.ChasmMemoKey] = chasmMemoPayload
+		}
+	}
+
+	return &workflow.RelocatableAttributes{
+		Memo:             &commonpb.Memo{Fields: memo},
+		SearchAttributes: &commonpb.SearchAttributes{IndexedFields: searchAttributes},
+	}, nil
+}
+
 // addDeletionTask adds a task to delete workflow history events from primary storage.
 func (e *archivalQueueTaskExecutor) addDeletionTask(
 	ctx context.Context,
@@ -249,7 +329,7 @@ func (e *archivalQueueTaskExecutor) addDeletionTask(
 		ShardID:     e.shardContext.GetShardID(),
 		NamespaceID: task.GetNamespaceID(),
 		WorkflowID:  task.WorkflowID,
-		ArchetypeID: chasm.WorkflowArchetypeID, // Archival is only supported for workflow now.
+		ArchetypeID: getTaskArchetypeID(task),
 		Tasks:       mutableState.PopTasks(),
 	})
 	return err
@@ -378,6 +378,7 @@ func TestArchivalQueueTaskExecutor(t *testing.T) {
 				mutableState := historyi.NewMockMutableState(p.Controller)
 				mutableState.EXPECT().IsWorkflowExecutionRunning().Return(p.IsWorkflowExecutionRunning).AnyTimes()
 				mutableState.EXPECT().GetWorkflowKey().Return(p.WorkflowKey).AnyTimes()
+				mutableState.EXPECT().IsWorkflow().Return(true).AnyTimes()
 				workflowContext.EXPECT().LoadMutableState(gomock.Any(), shardContext).Return(
 					mutableState,
 					p.LoadMutableStateError,
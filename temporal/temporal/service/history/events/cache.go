@@ -179,6 +179,7 @@ func (e *CacheImpl) getHistoryEventFromStore(
 	defer func() { metrics.CacheLatency.With(handler).Record(time.Since(startTime)) }()
 
 	response, err := e.executionManager.ReadHistoryBranch(ctx, &persistence.ReadHistoryBranchRequest{
+		NamespaceID:   key.NamespaceID.String(),
 		BranchToken:   branchToken,
 		MinEventID:    firstEventID,
 		MaxEventID:    key.EventID + 1,
@@ -136,6 +136,7 @@ func (s *eventsCacheSuite) TestEventsCacheMissMultiEventsBatchV2Success() {
 		PageSize:      1,
 		NextPageToken: nil,
 		ShardID:       shardID,
+		NamespaceID:   namespaceID.String(),
 	}).Return(&persistence.ReadHistoryBranchResponse{
 		HistoryEvents: []*historypb.HistoryEvent{event1, event2, event3, event4, event5, event6},
 		NextPageToken: nil,
@@ -167,6 +168,7 @@ func (s *eventsCacheSuite) TestEventsCacheMissV2Failure() {
 		PageSize:      1,
 		NextPageToken: nil,
 		ShardID:       shardID,
+		NamespaceID:   namespaceID.String(),
 	}).Return(nil, expectedErr)
 
 	actualEvent, err := s.cache.GetEvent(
@@ -201,6 +203,7 @@ func (s *eventsCacheSuite) TestEventsCacheDisableSuccess() {
 		PageSize:      1,
 		NextPageToken: nil,
 		ShardID:       shardID,
+		NamespaceID:   namespaceID.String(),
 	}).Return(&persistence.ReadHistoryBranchResponse{
 		HistoryEvents: []*historypb.HistoryEvent{event2},
 		NextPageToken: nil,
@@ -240,6 +243,7 @@ func (s *eventsCacheSuite) TestEventsCacheGetCachesResult() {
 		PageSize:      1,
 		NextPageToken: nil,
 		ShardID:       shardID,
+		NamespaceID:   namespaceID.String(),
 	}).Return(&persistence.ReadHistoryBranchResponse{
 		HistoryEvents: []*historypb.HistoryEvent{event1},
 		NextPageToken: nil,
@@ -277,6 +281,7 @@ func (s *eventsCacheSuite) TestEventsCacheInvalidKey() {
 		PageSize:      1,
 		NextPageToken: nil,
 		ShardID:       shardID,
+		NamespaceID:   namespaceID.String(),
 	}).Return(&persistence.ReadHistoryBranchResponse{
 		HistoryEvents: []*historypb.HistoryEvent{event1},
 		NextPageToken: nil,
@@ -18,6 +18,7 @@ import (
 	"go.temporal.io/server/common/membership"
 	"go.temporal.io/server/common/metrics"
 	"go.temporal.io/server/common/namespace"
+	"go.temporal.io/server/common/persistence"
 	persistenceClient "go.temporal.io/server/common/persistence/client"
 	"go.temporal.io/server/common/persistence/serialization"
 	"go.temporal.io/server/common/persistence/visibility"
@@ -41,8 +42,10 @@ import (
 	"go.temporal.io/server/service/history/consts"
 	"go.temporal.io/server/service/history/events"
 	"go.temporal.io/server/service/history/hsm"
+	"go.temporal.io/server/service/history/payloadoffload"
 	"go.temporal.io/server/service/history/replication"
 	"go.temporal.io/server/service/history/shard"
+	"go.temporal.io/server/service/history/timeskipping"
 	"go.temporal.io/server/service/history/workflow"
 	"go.temporal.io/server/service/history/workflow/cache"
 	"go.temporal.io/server/service/worker/workerdeployment"
@@ -59,8 +62,11 @@ var Module = fx.Options(
 	events.Module,
 	cache.Module,
 	archival.Module,
+	payloadoffload.Module,
 	ChasmEngineModule,
 	fx.Provide(ConfigProvider), // might be worth just using provider for configs.Config directly
+	fx.Provide(TimeSkippingClockProvider),
+	fx.Provide(TimeSkippingCoordinatorProvider),
 	fx.Provide(workflow.NewCommandHandlerRegistry),
 	fx.Provide(RetryableInterceptorProvider),
 	fx.Provide(ErrorHandlerProvider),
@@ -144,6 +150,9 @@ func HandlerProvider(args NewHandlerArgs) (*Handler, error) {
 		dlqMetricsEmitter:            args.DLQMetricsEmitter,
 		chasmEngine:                  args.ChasmEngine,
 		chasmRegistry:                args.ChasmRegistry,
+		faultInjectionRules:          args.FaultInjectionRules,
+		timeSkippingClock:            args.TimeSkippingClock,
+		timeSkippingCoordinator:      args.TimeSkippingCoordinator,
 
 		replicationTaskFetcherFactory:    args.ReplicationTaskFetcherFactory,
 		replicationTaskConverterProvider: args.ReplicationTaskConverterFactory,
@@ -187,6 +196,41 @@ func ConfigProvider(
 	)
 }
 
+func TimeSkippingClockProvider(
+	lc fx.Lifecycle,
+	serviceConfig *configs.Config,
+	timeSource clock.TimeSource,
+	namespaceRegistry namespace.Registry,
+) *timeskipping.Clock {
+	c := timeskipping.NewClock(serviceConfig.TimeSkippingEnabled, timeSource, namespaceRegistry)
+	lc.Append(fx.StartStopHook(c.Start, c.Stop))
+	return c
+}
+
+func TimeSkippingCoordinatorProvider(
+	lc fx.Lifecycle,
+	serviceConfig *configs.Config,
+	timeSkippingClock *timeskipping.Clock,
+	metadataManager persistence.MetadataManager,
+	historyClient resource.HistoryClient,
+	historyServiceResolver membership.ServiceResolver,
+	timeSource clock.TimeSource,
+	logger log.Logger,
+) *timeskipping.Coordinator {
+	c := timeskipping.NewCoordinator(
+		timeSkippingClock,
+		metadataManager,
+		historyClient,
+		historyServiceResolver,
+		serviceConfig.NumberOfShards,
+		serviceConfig.TimeSkippingAutoAdvanceInterval,
+		timeSource,
+		logger,
+	)
+	lc.Append(fx.StartStopHook(c.Start, c.Stop))
+	return c
+}
+
 func ThrottledLoggerRpsFnProvider(serviceConfig *configs.Config) resource.ThrottledLoggerRpsFn {
 	return func() float64 { return float64(serviceConfig.ThrottledLogRPS()) }
 }
@@ -358,6 +402,7 @@ func VisibilityManagerProvider(
 		serviceConfig.VisibilityDisableOrderByClause,
 		serviceConfig.VisibilityEnableManualPagination,
 		serviceConfig.VisibilityEnableUnifiedQueryConverter,
+		serviceConfig.VisibilityCountGroupByMaxGroups,
 		metricsHandler,
 		logger,
 		serializer,
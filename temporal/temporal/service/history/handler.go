@@ -32,6 +32,7 @@ import (
 	"go.temporal.io/server/common/archiver"
 	"go.temporal.io/server/common/clock"
 	"go.temporal.io/server/common/cluster"
+	"go.temporal.io/server/common/config"
 	"go.temporal.io/server/common/convert"
 	"go.temporal.io/server/common/definition"
 	"go.temporal.io/server/common/headers"
@@ -45,6 +46,7 @@ import (
 	"go.temporal.io/server/common/nexus/nexusrpc"
 	"go.temporal.io/server/common/payloads"
 	"go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/persistence/faultinjection"
 	"go.temporal.io/server/common/persistence/serialization"
 	"go.temporal.io/server/common/persistence/visibility/manager"
 	"go.temporal.io/server/common/primitives/timestamp"
@@ -65,9 +67,11 @@ import (
 	"go.temporal.io/server/service/history/replication"
 	"go.temporal.io/server/service/history/shard"
 	"go.temporal.io/server/service/history/tasks"
+	"go.temporal.io/server/service/history/timeskipping"
 	"go.uber.org/fx"
 	"google.golang.org/grpc/health"
 	grpchealthspb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type (
@@ -106,6 +110,9 @@ type (
 		chasmEngine                  chasm.Engine
 		chasmRegistry                *chasm.Registry
 		nexusHandler                 nexus.Handler
+		faultInjectionRules          *faultinjection.Rules
+		timeSkippingClock            *timeskipping.Clock
+		timeSkippingCoordinator      *timeskipping.Coordinator
 
 		replicationTaskFetcherFactory    replication.TaskFetcherFactory
 		replicationTaskConverterProvider replication.SourceTaskConverterProvider
@@ -142,6 +149,9 @@ type (
 		DLQMetricsEmitter            *persistence.DLQMetricsEmitter
 		ChasmEngine                  chasm.Engine
 		ChasmRegistry                *chasm.Registry
+		FaultInjectionRules          *faultinjection.Rules
+		TimeSkippingClock            *timeskipping.Clock
+		TimeSkippingCoordinator      *timeskipping.Coordinator
 
 		ReplicationTaskFetcherFactory   replication.TaskFetcherFactory
 		ReplicationTaskConverterFactory replication.SourceTaskConverterProvider
@@ -166,6 +176,9 @@ var (
 	errSourceClusterNotSet     = serviceerror.NewInvalidArgument("Source Cluster not set on request.")
 	errShardIDNotSet           = serviceerror.NewInvalidArgument("ShardId not set on request.")
 	errTimestampNotSet         = serviceerror.NewInvalidArgument("Timestamp not set on request.")
+
+	errFaultInjectionNotEnabled = serviceerror.NewFailedPrecondition("Persistence fault injection is not enabled in the config of the default store.")
+	errFaultInjectionRuleNotSet = serviceerror.NewInvalidArgument("Rule is not set on request.")
 )
 
 // Start starts the handler
@@ -913,6 +926,25 @@ func (h *Handler) GetShard(ctx context.Context, request *historyservice.GetShard
 	return &historyservice.GetShardResponse{ShardInfo: resp.ShardInfo}, nil
 }
 
+// AllocateTaskId allocates a task ID from a shard hosted by this instance, so that writes outside of the shard can be
+// ordered with the tasks of the shard.
+func (h *Handler) AllocateTaskId(
+	_ context.Context,
+	request *historyservice.AllocateTaskIdRequest,
+) (_ *historyservice.AllocateTaskIdResponse, retError error) {
+	defer log.CapturePanic(h.logger, &retError)
+
+	shardContext, err := h.controller.GetShardByID(request.GetShardId())
+	if err != nil {
+		return nil, h.convertError(err)
+	}
+	taskID, err := shardContext.GenerateTaskID()
+	if err != nil {
+		return nil, h.convertError(err)
+	}
+	return &historyservice.AllocateTaskIdResponse{TaskId: taskID}, nil
+}
+
 // RebuildMutableState attempts to rebuild mutable state according to persisted history events
 func (h *Handler) RebuildMutableState(ctx context.Context, request *historyservice.RebuildMutableStateRequest) (*historyservice.RebuildMutableStateResponse, error) {
 	namespaceID := namespace.ID(request.GetNamespaceId())
@@ -1859,6 +1891,7 @@ func (h *Handler) RefreshWorkflowTasks(ctx context.Context, request *historyserv
 		namespaceID,
 		execution,
 		request.GetArchetypeId(),
+		request.GetRequest().GetVisibilityOnly(),
 	)
 
 	if err != nil {
@@ -2681,6 +2714,110 @@ func (h *Handler) CancelNexusOperation(
 	}, nil
 }
 
+func (h *Handler) AdvanceNamespaceTime(
+	ctx context.Context,
+	request *historyservice.AdvanceNamespaceTimeRequest,
+) (_ *historyservice.AdvanceNamespaceTimeResponse, retError error) {
+	defer log.CapturePanic(h.logger, &retError)
+
+	// the host of the coordinator shard of the namespace advances its time
+	if _, err := h.controller.GetShardByID(request.GetShardId()); err != nil {
+		return nil, h.convertError(err)
+	}
+	namespaceEntry, err := h.namespaceRegistry.GetNamespaceByID(namespace.ID(request.GetNamespaceId()))
+	if err != nil {
+		return nil, h.convertError(err)
+	}
+
+	virtualTime, err := h.timeSkippingCoordinator.Advance(ctx, namespaceEntry, request.GetDuration().AsDuration())
+	if err != nil {
+		return nil, h.convertError(err)
+	}
+	return &historyservice.AdvanceNamespaceTimeResponse{
+		Time: timestamppb.New(virtualTime),
+	}, nil
+}
+
+// UpdateNamespaceTime moves the virtual time of the namespace on this host forward to the skipped duration persisted
+// by the coordinator, ahead of the refresh of the namespace registry.
+func (h *Handler) UpdateNamespaceTime(
+	_ context.Context,
+	request *historyservice.UpdateNamespaceTimeRequest,
+) (_ *historyservice.UpdateNamespaceTimeResponse, retError error) {
+	defer log.CapturePanic(h.logger, &retError)
+
+	namespaceEntry, err := h.namespaceRegistry.GetNamespaceByID(namespace.ID(request.GetNamespaceId()))
+	if err != nil {
+		return nil, h.convertError(err)
+	}
+	h.timeSkippingClock.SetSkipped(namespaceEntry, request.GetSkipped().AsDuration())
+	return &historyservice.UpdateNamespaceTimeResponse{}, nil
+}
+
+// DescribeNamespaceTime returns whether the executions of the namespace on the shards of this host are idle, and the
+// fire time of its next timer, for the coordinator to auto-advance the virtual time of the namespace.
+func (h *Handler) DescribeNamespaceTime(
+	_ context.Context,
+	request *historyservice.DescribeNamespaceTimeRequest,
+) (_ *historyservice.DescribeNamespaceTimeResponse, retError error) {
+	defer log.CapturePanic(h.logger, &retError)
+
+	state := h.timeSkippingClock.Describe(namespace.ID(request.GetNamespaceId()))
+	resp := &historyservice.DescribeNamespaceTimeResponse{
+		ShardIds: state.ShardIDs,
+		Idle:     state.Idle,
+	}
+	if !state.NextFireTime.IsZero() {
+		resp.NextFireTime = timestamppb.New(state.NextFireTime)
+	}
+	return resp, nil
+}
+
+// AddFaultInjectionRule adds a persistence fault injection rule to all services of this host.
+func (h *Handler) AddFaultInjectionRule(
+	_ context.Context,
+	request *historyservice.AddFaultInjectionRuleRequest,
+) (_ *historyservice.AddFaultInjectionRuleResponse, retError error) {
+	defer log.CapturePanic(h.logger, &retError)
+
+	if request.GetRule() == nil {
+		return nil, errFaultInjectionRuleNotSet
+	}
+	if h.faultInjectionRules == nil {
+		return nil, errFaultInjectionNotEnabled
+	}
+	if err := h.faultInjectionRules.AddProto(request.GetRule()); err != nil {
+		return nil, serviceerror.NewInvalidArgument(err.Error())
+	}
+	h.logger.Info("Added persistence fault injection rule.",
+		tag.NewStringTag("data-store", request.GetRule().GetDataStore()),
+		tag.NewStringTag("method", request.GetRule().GetMethod()),
+	)
+	return &historyservice.AddFaultInjectionRuleResponse{}, nil
+}
+
+// ClearFaultInjectionRules removes the persistence fault injection rules which were added with AddFaultInjectionRule
+// from this host.
+func (h *Handler) ClearFaultInjectionRules(
+	_ context.Context,
+	request *historyservice.ClearFaultInjectionRulesRequest,
+) (_ *historyservice.ClearFaultInjectionRulesResponse, retError error) {
+	defer log.CapturePanic(h.logger, &retError)
+
+	if h.faultInjectionRules == nil {
+		return nil, errFaultInjectionNotEnabled
+	}
+	cleared := h.faultInjectionRules.Clear(config.DataStoreName(request.GetDataStore()), request.GetMethod())
+	h.logger.Info("Cleared persistence fault injection rules.",
+		tag.NewStringTag("data-store", request.GetDataStore()),
+		tag.NewStringTag("method", request.GetMethod()),
+		tag.Counter(cleared),
+	)
+	return &historyservice.ClearFaultInjectionRulesResponse{
+		ClearedCount: int32(cleared),
+	}, nil
+}
+
 func createLazyValueFromPayload(payload *commonpb.Payload) *nexus.LazyValue {
 	// Create a serializer that wraps the payload.
 	// When Deserialize is called, it will directly unmarshal the payload.
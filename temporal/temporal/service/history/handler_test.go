@@ -4,19 +4,30 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/server/api/adminservice/v1"
 	"go.temporal.io/server/api/historyservice/v1"
+	"go.temporal.io/server/api/historyservicemock/v1"
 	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common/clock"
+	"go.temporal.io/server/common/config"
+	"go.temporal.io/server/common/dynamicconfig"
 	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/membership"
 	"go.temporal.io/server/common/metrics"
 	"go.temporal.io/server/common/namespace"
-	"go.temporal.io/server/common/serviceerror"
+	"go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/persistence/faultinjection"
+	serviceerrors "go.temporal.io/server/common/serviceerror"
 	"go.temporal.io/server/service/history/configs"
 	"go.temporal.io/server/service/history/shard"
 	"go.temporal.io/server/service/history/tests"
+	"go.temporal.io/server/service/history/timeskipping"
 	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 func TestDescribeHistoryHost(t *testing.T) {
@@ -44,13 +55,13 @@ func TestDescribeHistoryHost(t *testing.T) {
 		},
 		tests.NewDynamicConfig(),
 	)
-	controller.EXPECT().GetShardByID(int32(1)).Return(mockShard1, serviceerror.NewShardOwnershipLost("", ""))
+	controller.EXPECT().GetShardByID(int32(1)).Return(mockShard1, serviceerrors.NewShardOwnershipLost("", ""))
 
 	_, err := h.DescribeHistoryHost(context.Background(), &historyservice.DescribeHistoryHostRequest{
 		ShardId: 1,
 	})
 	assert.Error(t, err)
-	var sol *serviceerror.ShardOwnershipLost
+	var sol *serviceerrors.ShardOwnershipLost
 	assert.True(t, errors.As(err, &sol))
 
 	mockShard2 := shard.NewTestContext(
@@ -70,3 +81,170 @@ func TestDescribeHistoryHost(t *testing.T) {
 	})
 	assert.NoError(t, err)
 }
+
+func TestAllocateTaskId(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	controller := shard.NewMockController(ctrl)
+	h := Handler{
+		metricsHandler: metrics.NoopMetricsHandler,
+		logger:         log.NewNoopLogger(),
+		controller:     controller,
+	}
+
+	mockShard := shard.NewTestContext(
+		ctrl,
+		&persistencespb.ShardInfo{
+			ShardId: 1,
+			RangeId: 1,
+		},
+		tests.NewDynamicConfig(),
+	)
+	controller.EXPECT().GetShardByID(int32(1)).Return(mockShard, nil).AnyTimes()
+
+	taskID, err := mockShard.GenerateTaskID()
+	assert.NoError(t, err)
+	resp, err := h.AllocateTaskId(context.Background(), &historyservice.AllocateTaskIdRequest{ShardId: 1})
+	assert.NoError(t, err)
+	assert.Greater(t, resp.GetTaskId(), taskID)
+	nextTaskID, err := mockShard.GenerateTaskID()
+	assert.NoError(t, err)
+	assert.Greater(t, nextTaskID, resp.GetTaskId())
+}
+
+func TestAdvanceNamespaceTime(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	controller := shard.NewMockController(ctrl)
+	namespaceRegistry := namespace.NewMockRegistry(ctrl)
+	metadataManager := persistence.NewMockMetadataManager(ctrl)
+	historyServiceResolver := membership.NewMockServiceResolver(ctrl)
+	timeSource := clock.NewEventTimeSource().Update(time.Now())
+	timeSkippingClock := timeskipping.NewClock(dynamicconfig.GetBoolPropertyFnFilteredByNamespace(true), timeSource, namespaceRegistry)
+	h := Handler{
+		metricsHandler:    metrics.NoopMetricsHandler,
+		logger:            log.NewNoopLogger(),
+		controller:        controller,
+		namespaceRegistry: namespaceRegistry,
+		timeSkippingClock: timeSkippingClock,
+		timeSkippingCoordinator: timeskipping.NewCoordinator(
+			timeSkippingClock,
+			metadataManager,
+			historyservicemock.NewMockHistoryServiceClient(ctrl),
+			historyServiceResolver,
+			1,
+			dynamicconfig.GetDurationPropertyFn(time.Second),
+			timeSource,
+			log.NewNoopLogger(),
+		),
+	}
+
+	namespaceRegistry.EXPECT().GetNamespaceByID(tests.NamespaceID).Return(tests.GlobalNamespaceEntry, nil).AnyTimes()
+	historyServiceResolver.EXPECT().Members().Return(nil).AnyTimes()
+	controller.EXPECT().GetShardByID(int32(1)).Return(nil, nil).AnyTimes()
+	controller.EXPECT().GetShardByID(int32(2)).Return(nil, serviceerrors.NewShardOwnershipLost("", "")).AnyTimes()
+	metadataManager.EXPECT().GetMetadata(gomock.Any()).Return(&persistence.GetMetadataResponse{}, nil)
+	metadataManager.EXPECT().GetNamespace(gomock.Any(), &persistence.GetNamespaceRequest{ID: tests.NamespaceID.String()}).
+		Return(&persistence.GetNamespaceResponse{
+			Namespace: &persistencespb.NamespaceDetail{
+				Info:   &persistencespb.NamespaceInfo{Id: tests.NamespaceID.String()},
+				Config: &persistencespb.NamespaceConfig{},
+			},
+		}, nil)
+	metadataManager.EXPECT().UpdateNamespace(gomock.Any(), gomock.Any()).Return(nil)
+
+	resp, err := h.AdvanceNamespaceTime(context.Background(), &historyservice.AdvanceNamespaceTimeRequest{
+		NamespaceId: tests.NamespaceID.String(),
+		ShardId:     1,
+		Duration:    durationpb.New(time.Hour),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, timeSource.Now().Add(time.Hour).UTC(), resp.GetTime().AsTime())
+
+	// the time is advanced by the host of the coordinator shard
+	_, err = h.AdvanceNamespaceTime(context.Background(), &historyservice.AdvanceNamespaceTimeRequest{
+		NamespaceId: tests.NamespaceID.String(),
+		ShardId:     2,
+		Duration:    durationpb.New(time.Hour),
+	})
+	var shardOwnershipLost *serviceerrors.ShardOwnershipLost
+	assert.ErrorAs(t, err, &shardOwnershipLost)
+
+	_, err = h.AdvanceNamespaceTime(context.Background(), &historyservice.AdvanceNamespaceTimeRequest{
+		NamespaceId: tests.NamespaceID.String(),
+		ShardId:     1,
+		Duration:    durationpb.New(-time.Minute),
+	})
+	var invalidArgument *serviceerror.InvalidArgument
+	assert.ErrorAs(t, err, &invalidArgument)
+}
+
+func TestUpdateNamespaceTime(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	namespaceRegistry := namespace.NewMockRegistry(ctrl)
+	timeSource := clock.NewEventTimeSource().Update(time.Now())
+	h := Handler{
+		logger:            log.NewNoopLogger(),
+		namespaceRegistry: namespaceRegistry,
+		timeSkippingClock: timeskipping.NewClock(dynamicconfig.GetBoolPropertyFnFilteredByNamespace(true), timeSource, namespaceRegistry),
+	}
+	namespaceRegistry.EXPECT().GetNamespaceByID(tests.NamespaceID).Return(tests.GlobalNamespaceEntry, nil).AnyTimes()
+
+	_, err := h.UpdateNamespaceTime(context.Background(), &historyservice.UpdateNamespaceTimeRequest{
+		NamespaceId: tests.NamespaceID.String(),
+		Skipped:     durationpb.New(time.Hour),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, timeSource.Now().Add(time.Hour), h.timeSkippingClock.Now(tests.NamespaceID))
+
+	tracker := h.timeSkippingClock.RegisterShard(1, func(namespace.ID) bool { return false })
+	tracker.SetRecovered()
+	tracker.AddTimer(tests.NamespaceID, timeSource.Now().Add(2*time.Hour))
+	resp, err := h.DescribeNamespaceTime(context.Background(), &historyservice.DescribeNamespaceTimeRequest{
+		NamespaceId: tests.NamespaceID.String(),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int32{1}, resp.GetShardIds())
+	assert.True(t, resp.GetIdle())
+	assert.Equal(t, timeSource.Now().Add(2*time.Hour).UTC(), resp.GetNextFireTime().AsTime())
+}
+
+func TestFaultInjectionRules(t *testing.T) {
+	h := Handler{
+		logger: log.NewNoopLogger(),
+	}
+	_, err := h.AddFaultInjectionRule(context.Background(), &historyservice.AddFaultInjectionRuleRequest{
+		Rule: &adminservice.FaultInjectionRule{},
+	})
+	assert.ErrorIs(t, err, errFaultInjectionNotEnabled)
+
+	h.faultInjectionRules = faultinjection.NewRules(&config.FaultInjection{}, nil, log.NewNoopLogger())
+	_, err = h.AddFaultInjectionRule(context.Background(), &historyservice.AddFaultInjectionRuleRequest{})
+	assert.ErrorIs(t, err, errFaultInjectionRuleNotSet)
+
+	_, err = h.AddFaultInjectionRule(context.Background(), &historyservice.AddFaultInjectionRuleRequest{
+		Rule: &adminservice.FaultInjectionRule{
+			DataStore: string(config.ExecutionStoreName),
+			Method:    "NotAMethod",
+			Errors:    map[string]float64{"Timeout": 0.5},
+		},
+	})
+	var invalidArgument *serviceerror.InvalidArgument
+	assert.ErrorAs(t, err, &invalidArgument)
+
+	_, err = h.AddFaultInjectionRule(context.Background(), &historyservice.AddFaultInjectionRuleRequest{
+		Rule: &adminservice.FaultInjectionRule{
+			DataStore: string(config.ExecutionStoreName),
+			Method:    "GetWorkflowExecution",
+			Errors:    map[string]float64{"Timeout": 0.5},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, h.faultInjectionRules.List(config.ExecutionStoreName), 1)
+
+	resp, err := h.ClearFaultInjectionRules(context.Background(), &historyservice.ClearFaultInjectionRulesRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), resp.GetClearedCount())
+	assert.Empty(t, h.faultInjectionRules.List(config.ExecutionStoreName))
+}
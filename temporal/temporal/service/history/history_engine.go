@@ -819,6 +819,7 @@ func (e *historyEngineImpl) ImportWorkflowExecution(
 		request.VersionHistory.Items,
 		historyEvents,
 		request.Token,
+		request.GetRehydrationExpirationTime(),
 	)
 	if err != nil {
 		return nil, err
@@ -1006,11 +1007,13 @@ func (e *historyEngineImpl) RefreshWorkflowTasks(
 	namespaceUUID namespace.ID,
 	execution *commonpb.WorkflowExecution,
 	archetypeID chasm.ArchetypeID,
+	visibilityOnly bool,
 ) (retError error) {
 	return refreshworkflow.Invoke(
 		ctx,
 		definition.NewWorkflowKey(namespaceUUID.String(), execution.WorkflowId, execution.RunId),
 		archetypeID,
+		visibilityOnly,
 		e.shardContext,
 		e.workflowConsistencyChecker,
 	)
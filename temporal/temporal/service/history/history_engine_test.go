@@ -5564,6 +5564,7 @@ func (s *engineSuite) TestGetHistory() {
 		PageSize:      2,
 		NextPageToken: []byte{},
 		ShardID:       1,
+		NamespaceID:   tests.NamespaceID.String(),
 	}
 	s.mockExecutionMgr.EXPECT().ReadHistoryBranch(gomock.Any(), req).Return(&persistence.ReadHistoryBranchResponse{
 		HistoryEvents: []*historypb.HistoryEvent{
@@ -5675,6 +5676,7 @@ func (s *engineSuite) TestGetWorkflowExecutionHistory() {
 		PageSize:      10,
 		NextPageToken: nil,
 		ShardID:       1,
+		NamespaceID:   tests.NamespaceID.String(),
 	}).Return(&persistence.ReadHistoryBranchResponse{
 		HistoryEvents: []*historypb.HistoryEvent{
 			{
@@ -5813,6 +5815,7 @@ func (s *engineSuite) TestGetWorkflowExecutionHistoryWhenInternalRawHistoryIsEna
 		PageSize:      10,
 		NextPageToken: nil,
 		ShardID:       1,
+		NamespaceID:   tests.NamespaceID.String(),
 	}).Return(&persistence.ReadRawHistoryBranchResponse{
 		HistoryEventBlobs: []*commonpb.DataBlob{
 			{
@@ -5936,6 +5939,7 @@ func (s *engineSuite) TestGetWorkflowExecutionHistory_RawHistoryWithTransientDec
 		PageSize:      10,
 		NextPageToken: persistenceToken,
 		ShardID:       1,
+		NamespaceID:   tests.NamespaceID.String(),
 	}).Return(&persistence.ReadRawHistoryBranchResponse{
 		HistoryEventBlobs: []*commonpb.DataBlob{historyBlob1, historyBlob2},
 		NextPageToken:     []byte{},
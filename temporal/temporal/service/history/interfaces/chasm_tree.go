@@ -26,6 +26,7 @@ type ChasmTree interface {
 	Terminate(chasm.TerminateComponentRequest) error
 	Archetype() (chasm.Archetype, error)
 	ArchetypeID() chasm.ArchetypeID
+	IsArchivable() bool
 	EachPureTask(
 		deadline time.Time,
 		callback func(executor chasm.NodePureTask, taskAttributes chasm.TaskAttributes, task any) (bool, error),
@@ -174,6 +174,20 @@ func (mr *MockChasmTreeMockRecorder) ExecuteSideEffectTask(ctx, registry, execut
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteSideEffectTask", reflect.TypeOf((*MockChasmTree)(nil).ExecuteSideEffectTask), ctx, registry, executionKey, task, validate)
 }
 
+// IsArchivable mocks base method.
+func (m *MockChasmTree) IsArchivable() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsArchivable")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsArchivable indicates an expected call of IsArchivable.
+func (mr *MockChasmTreeMockRecorder) IsArchivable() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsArchivable", reflect.TypeOf((*MockChasmTree)(nil).IsArchivable))
+}
+
 // IsDirty mocks base method.
 func (m *MockChasmTree) IsDirty() bool {
 	m.ctrl.T.Helper()
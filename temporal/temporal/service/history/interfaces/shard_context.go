@@ -27,6 +27,7 @@ import (
 	"go.temporal.io/server/service/history/events"
 	"go.temporal.io/server/service/history/hsm"
 	"go.temporal.io/server/service/history/tasks"
+	"go.temporal.io/server/service/history/timeskipping"
 )
 
 //go:generate mockgen -package $GOPACKAGE -source $GOFILE -destination shard_context_mock.go
@@ -106,6 +107,8 @@ type (
 		GetFinalizer() *finalizer.Finalizer
 
 		ChasmRegistry() *chasm.Registry
+
+		TimeSkippingClock() *timeskipping.Clock
 	}
 
 	// A ControllableContext is a Context plus other methods needed by
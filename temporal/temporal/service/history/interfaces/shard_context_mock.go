@@ -37,6 +37,7 @@ import (
 	events "go.temporal.io/server/service/history/events"
 	hsm "go.temporal.io/server/service/history/hsm"
 	tasks "go.temporal.io/server/service/history/tasks"
+	timeskipping "go.temporal.io/server/service/history/timeskipping"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -724,6 +725,20 @@ func (mr *MockShardContextMockRecorder) StateMachineRegistry() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateMachineRegistry", reflect.TypeOf((*MockShardContext)(nil).StateMachineRegistry))
 }
 
+// TimeSkippingClock mocks base method.
+func (m *MockShardContext) TimeSkippingClock() *timeskipping.Clock {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TimeSkippingClock")
+	ret0, _ := ret[0].(*timeskipping.Clock)
+	return ret0
+}
+
+// TimeSkippingClock indicates an expected call of TimeSkippingClock.
+func (mr *MockShardContextMockRecorder) TimeSkippingClock() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TimeSkippingClock", reflect.TypeOf((*MockShardContext)(nil).TimeSkippingClock))
+}
+
 // UnloadForOwnershipLost mocks base method.
 func (m *MockShardContext) UnloadForOwnershipLost() {
 	m.ctrl.T.Helper()
@@ -1541,6 +1556,20 @@ func (mr *MockControllableContextMockRecorder) StateMachineRegistry() *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateMachineRegistry", reflect.TypeOf((*MockControllableContext)(nil).StateMachineRegistry))
 }
 
+// TimeSkippingClock mocks base method.
+func (m *MockControllableContext) TimeSkippingClock() *timeskipping.Clock {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TimeSkippingClock")
+	ret0, _ := ret[0].(*timeskipping.Clock)
+	return ret0
+}
+
+// TimeSkippingClock indicates an expected call of TimeSkippingClock.
+func (mr *MockControllableContextMockRecorder) TimeSkippingClock() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TimeSkippingClock", reflect.TypeOf((*MockControllableContext)(nil).TimeSkippingClock))
+}
+
 // UnloadForOwnershipLost mocks base method.
 func (m *MockControllableContext) UnloadForOwnershipLost() {
 	m.ctrl.T.Helper()
@@ -32,6 +32,7 @@ type (
 		IsDirty() bool
 
 		RefreshTasks(ctx context.Context, shardContext ShardContext) error
+		RefreshVisibilityTasks(ctx context.Context, shardContext ShardContext) error
 
 		ReapplyEvents(
 			ctx context.Context,
@@ -205,6 +205,20 @@ func (mr *MockWorkflowContextMockRecorder) RefreshTasks(ctx, shardContext any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshTasks", reflect.TypeOf((*MockWorkflowContext)(nil).RefreshTasks), ctx, shardContext)
 }
 
+// RefreshVisibilityTasks mocks base method.
+func (m *MockWorkflowContext) RefreshVisibilityTasks(ctx context.Context, shardContext ShardContext) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshVisibilityTasks", ctx, shardContext)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RefreshVisibilityTasks indicates an expected call of RefreshVisibilityTasks.
+func (mr *MockWorkflowContextMockRecorder) RefreshVisibilityTasks(ctx, shardContext any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshVisibilityTasks", reflect.TypeOf((*MockWorkflowContext)(nil).RefreshVisibilityTasks), ctx, shardContext)
+}
+
 // SetWorkflowExecution mocks base method.
 func (m *MockWorkflowContext) SetWorkflowExecution(ctx context.Context, shardContext ShardContext) error {
 	m.ctrl.T.Helper()
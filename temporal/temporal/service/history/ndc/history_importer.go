@@ -18,6 +18,7 @@ import (
 	historyi "go.temporal.io/server/service/history/interfaces"
 	"go.temporal.io/server/service/history/workflow"
 	wcache "go.temporal.io/server/service/history/workflow/cache"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type (
@@ -28,6 +29,7 @@ type (
 			versionHistoryItems []*historyspb.VersionHistoryItem,
 			events [][]*historypb.HistoryEvent,
 			token []byte,
+			rehydrationExpirationTime *timestamppb.Timestamp,
 		) ([]byte, bool, error)
 	}
 
@@ -107,6 +109,7 @@ func (r *HistoryImporterImpl) ImportWorkflow(
 	versionHistoryItems []*historyspb.VersionHistoryItem,
 	eventsSlice [][]*historypb.HistoryEvent,
 	token []byte,
+	rehydrationExpirationTime *timestamppb.Timestamp,
 ) (_ []byte, _ bool, retError error) {
 	if len(eventsSlice) == 0 && len(token) == 0 {
 		return nil, false, serviceerror.NewInvalidArgument("ImportWorkflowExecution cannot import empty history events")
@@ -139,6 +142,7 @@ func (r *HistoryImporterImpl) ImportWorkflow(
 		ctx,
 		ndcWorkflow,
 		mutableStateSpec,
+		rehydrationExpirationTime,
 	); err != nil {
 		return nil, false, err
 	}
@@ -319,12 +323,17 @@ func (r *HistoryImporterImpl) commit(
 	ctx context.Context,
 	memNDCWorkflow Workflow,
 	mutableStateSpec MutableStateInitializationSpec,
+	rehydrationExpirationTime *timestamppb.Timestamp,
 ) (retError error) {
 	if mutableStateSpec.IsBrandNew {
 		return serviceerror.NewInvalidArgument("HistoryImporter::commit cannot create workflow without events")
 	}
 
 	if !mutableStateSpec.ExistsInDB {
+		// the expiration time must be set before the refresh so that the close tasks honor it
+		if rehydrationExpirationTime != nil {
+			memNDCWorkflow.GetMutableState().GetExecutionInfo().RehydrationExpirationTime = rehydrationExpirationTime
+		}
 		// refresh tasks to be generated
 		if err := r.taskRefresher.Refresh(
 			ctx,
@@ -242,6 +242,7 @@ func (r *StateRebuilderImpl) buildMutableStateFromEvent(
 		common.FirstEventID,
 		baseLastEventID+1,
 		baseBranchToken,
+		baseWorkflowIdentifier.NamespaceID,
 		namespaceEntry.Name().String(),
 	))
 
@@ -358,6 +359,7 @@ func (r *StateRebuilderImpl) getPaginationFn(
 	firstEventID int64,
 	nextEventID int64,
 	branchToken []byte,
+	namespaceID string,
 	namespaceName string,
 ) collection.PaginationFn[HistoryBlobsPaginationItem] {
 	return func(paginationToken []byte) ([]HistoryBlobsPaginationItem, []byte, error) {
@@ -368,6 +370,7 @@ func (r *StateRebuilderImpl) getPaginationFn(
 			PageSize:      defaultPageSize,
 			NextPageToken: paginationToken,
 			ShardID:       r.shard.GetShardID(),
+			NamespaceID:   namespaceID,
 		})
 		if err != nil {
 			return nil, nil, err
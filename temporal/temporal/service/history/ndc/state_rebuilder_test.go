@@ -203,6 +203,7 @@ func (s *stateRebuilderSuite) TestPagination() {
 		PageSize:      defaultPageSize,
 		NextPageToken: nil,
 		ShardID:       shardID,
+		NamespaceID:   tests.NamespaceID.String(),
 	}).Return(&persistence.ReadHistoryBranchByBatchResponse{
 		History:        history1,
 		TransactionIDs: []int64{transactionID1},
@@ -216,6 +217,7 @@ func (s *stateRebuilderSuite) TestPagination() {
 		PageSize:      defaultPageSize,
 		NextPageToken: pageToken,
 		ShardID:       shardID,
+		NamespaceID:   tests.NamespaceID.String(),
 	}).Return(&persistence.ReadHistoryBranchByBatchResponse{
 		History:        history2,
 		TransactionIDs: []int64{transactionID2},
@@ -223,7 +225,7 @@ func (s *stateRebuilderSuite) TestPagination() {
 		Size:           67890,
 	}, nil)
 
-	paginationFn := s.nDCStateRebuilder.getPaginationFn(context.Background(), firstEventID, nextEventID, branchToken, tests.Namespace.String())
+	paginationFn := s.nDCStateRebuilder.getPaginationFn(context.Background(), firstEventID, nextEventID, branchToken, tests.NamespaceID.String(), tests.Namespace.String())
 	iter := collection.NewPagingIterator(paginationFn)
 
 	var result []HistoryBlobsPaginationItem
@@ -307,6 +309,7 @@ func (s *stateRebuilderSuite) TestRebuild() {
 		PageSize:      defaultPageSize,
 		NextPageToken: nil,
 		ShardID:       shardID,
+		NamespaceID:   s.namespaceID.String(),
 	}).Return(&persistence.ReadHistoryBranchByBatchResponse{
 		History:        history1,
 		TransactionIDs: []int64{10},
@@ -321,6 +324,7 @@ func (s *stateRebuilderSuite) TestRebuild() {
 		PageSize:      defaultPageSize,
 		NextPageToken: pageToken,
 		ShardID:       shardID,
+		NamespaceID:   s.namespaceID.String(),
 	}).Return(&persistence.ReadHistoryBranchByBatchResponse{
 		History:        history2,
 		TransactionIDs: []int64{expectedLastFirstTransactionID},
@@ -424,6 +428,7 @@ func (s *stateRebuilderSuite) TestRebuildWithCurrentMutableState() {
 		PageSize:      defaultPageSize,
 		NextPageToken: nil,
 		ShardID:       shardID,
+		NamespaceID:   s.namespaceID.String(),
 	}).Return(&persistence.ReadHistoryBranchByBatchResponse{
 		History:        history1,
 		TransactionIDs: []int64{10},
@@ -438,6 +443,7 @@ func (s *stateRebuilderSuite) TestRebuildWithCurrentMutableState() {
 		PageSize:      defaultPageSize,
 		NextPageToken: pageToken,
 		ShardID:       shardID,
+		NamespaceID:   s.namespaceID.String(),
 	}).Return(&persistence.ReadHistoryBranchByBatchResponse{
 		History:        history2,
 		TransactionIDs: []int64{expectedLastFirstTransactionID},
@@ -665,6 +665,7 @@ func (r *workflowResetterImpl) reapplyContinueAsNewWorkflowEvents(
 	// First, special handling of remaining events for base workflow
 	nextRunID, err := r.reapplyEventsFromBranch(
 		ctx,
+		namespaceID,
 		resetMutableState,
 		baseRebuildNextEventID,
 		baseNextEventID,
@@ -732,6 +733,7 @@ func (r *workflowResetterImpl) reapplyContinueAsNewWorkflowEvents(
 
 		nextRunID, err = r.reapplyEventsFromBranch(
 			ctx,
+			namespaceID,
 			resetMutableState,
 			common.FirstEventID,
 			nextWorkflowNextEventID,
@@ -759,6 +761,7 @@ func (r *workflowResetterImpl) reapplyContinueAsNewWorkflowEvents(
 
 func (r *workflowResetterImpl) reapplyEventsFromBranch(
 	ctx context.Context,
+	namespaceID namespace.ID,
 	mutableState historyi.MutableState,
 	firstEventID int64,
 	nextEventID int64,
@@ -774,6 +777,7 @@ func (r *workflowResetterImpl) reapplyEventsFromBranch(
 
 	iter := collection.NewPagingIterator(r.getPaginationFn(
 		ctx,
+		namespaceID.String(),
 		firstEventID,
 		nextEventID,
 		branchToken,
@@ -1128,6 +1132,7 @@ func reapplyChildEvents(mutableState historyi.MutableState, event *historypb.His
 
 func (r *workflowResetterImpl) getPaginationFn(
 	ctx context.Context,
+	namespaceID string,
 	firstEventID int64,
 	nextEventID int64,
 	branchToken []byte,
@@ -1142,6 +1147,7 @@ func (r *workflowResetterImpl) getPaginationFn(
 			PageSize:      defaultPageSize,
 			NextPageToken: paginationToken,
 			ShardID:       r.shardContext.GetShardID(),
+			NamespaceID:   namespaceID,
 		})
 		if err != nil {
 			return nil, nil, err
@@ -602,6 +602,7 @@ func (s *workflowResetterSuite) TestReapplyContinueAsNewWorkflowEvents_WithOutCo
 		PageSize:      defaultPageSize,
 		NextPageToken: nil,
 		ShardID:       shardID,
+		NamespaceID:   s.namespaceID.String(),
 	}).Return(&persistence.ReadHistoryBranchByBatchResponse{
 		History:       []*historypb.History{{Events: baseEvents}},
 		NextPageToken: nil,
@@ -701,6 +702,7 @@ func (s *workflowResetterSuite) TestReapplyContinueAsNewWorkflowEvents_WithConti
 		PageSize:      defaultPageSize,
 		NextPageToken: nil,
 		ShardID:       shardID,
+		NamespaceID:   s.namespaceID.String(),
 	}).Return(&persistence.ReadHistoryBranchByBatchResponse{
 		History:       []*historypb.History{{Events: baseEvents}},
 		NextPageToken: nil,
@@ -714,6 +716,7 @@ func (s *workflowResetterSuite) TestReapplyContinueAsNewWorkflowEvents_WithConti
 		PageSize:      defaultPageSize,
 		NextPageToken: nil,
 		ShardID:       shardID,
+		NamespaceID:   s.namespaceID.String(),
 	}).Return(&persistence.ReadHistoryBranchByBatchResponse{
 		History:       []*historypb.History{{Events: newEvents}},
 		NextPageToken: nil,
@@ -804,6 +807,7 @@ func (s *workflowResetterSuite) TestReapplyWorkflowEvents() {
 		PageSize:      defaultPageSize,
 		NextPageToken: nil,
 		ShardID:       shardID,
+		NamespaceID:   s.namespaceID.String(),
 	}).Return(&persistence.ReadHistoryBranchByBatchResponse{
 		History:       []*historypb.History{{Events: events}},
 		NextPageToken: nil,
@@ -818,6 +822,7 @@ func (s *workflowResetterSuite) TestReapplyWorkflowEvents() {
 
 	nextRunID, err := s.workflowResetter.reapplyEventsFromBranch(
 		context.Background(),
+		s.namespaceID,
 		mutableState,
 		firstEventID,
 		nextEventID,
@@ -1440,6 +1445,7 @@ func (s *workflowResetterSuite) TestPagination() {
 		PageSize:      defaultPageSize,
 		NextPageToken: nil,
 		ShardID:       shardID,
+		NamespaceID:   s.namespaceID.String(),
 	}).Return(&persistence.ReadHistoryBranchByBatchResponse{
 		History:       history1,
 		NextPageToken: pageToken,
@@ -1452,13 +1458,14 @@ func (s *workflowResetterSuite) TestPagination() {
 		PageSize:      defaultPageSize,
 		NextPageToken: pageToken,
 		ShardID:       shardID,
+		NamespaceID:   s.namespaceID.String(),
 	}).Return(&persistence.ReadHistoryBranchByBatchResponse{
 		History:       history2,
 		NextPageToken: nil,
 		Size:          67890,
 	}, nil)
 
-	paginationFn := s.workflowResetter.getPaginationFn(context.Background(), firstEventID, nextEventID, branchToken)
+	paginationFn := s.workflowResetter.getPaginationFn(context.Background(), s.namespaceID.String(), firstEventID, nextEventID, branchToken)
 	iter := collection.NewPagingIterator(paginationFn)
 
 	var result []*historypb.History
@@ -1279,6 +1279,7 @@ func (r *WorkflowStateReplicatorImpl) bringLocalEventsUpToSourceCurrentBranch(
 			_, err = r.executionMgr.AppendRawHistoryNodes(ctx, &persistence.AppendRawHistoryNodesRequest{
 				ShardID:           r.shardContext.GetShardID(),
 				IsNewBranch:       isNewBranch,
+				NamespaceID:       namespaceID.String(),
 				BranchToken:       versionHistoryToAppend.BranchToken,
 				History:           historyBlob.rawHistory,
 				PrevTransactionID: prevTxnID,
@@ -1349,6 +1350,7 @@ func (r *WorkflowStateReplicatorImpl) bringLocalEventsUpToSourceCurrentBranch(
 		_, err = r.executionMgr.AppendRawHistoryNodes(ctx, &persistence.AppendRawHistoryNodesRequest{
 			ShardID:           r.shardContext.GetShardID(),
 			IsNewBranch:       isNewBranch,
+			NamespaceID:       namespaceID.String(),
 			BranchToken:       versionHistoryToAppend.BranchToken,
 			History:           eventBlobs[i],
 			PrevTransactionID: prevTxnID,
@@ -1686,6 +1688,7 @@ func (r *WorkflowStateReplicatorImpl) backfillHistory(
 	// Get the last batch node id to check if the history data is already in DB.
 	localHistoryIterator := collection.NewPagingIterator(r.getHistoryFromLocalPaginationFn(
 		ctx,
+		namespaceID.String(),
 		backfillBranchToken,
 		lastEventItem.EventId,
 	))
@@ -1828,6 +1831,7 @@ BackfillLoop:
 		_, err = r.executionMgr.AppendRawHistoryNodes(ctx, &persistence.AppendRawHistoryNodesRequest{
 			ShardID:           r.shardContext.GetShardID(),
 			IsNewBranch:       prevBranchID != branchID,
+			NamespaceID:       namespaceID.String(),
 			BranchToken:       filteredHistoryBranch,
 			History:           historyBlob.rawHistory,
 			PrevTransactionID: prevTxnID,
@@ -1852,6 +1856,7 @@ BackfillLoop:
 
 func (r *WorkflowStateReplicatorImpl) getHistoryFromLocalPaginationFn(
 	ctx context.Context,
+	namespaceID string,
 	branchToken []byte,
 	lastEventID int64,
 ) collection.PaginationFn[*historypb.History] {
@@ -1859,6 +1864,7 @@ func (r *WorkflowStateReplicatorImpl) getHistoryFromLocalPaginationFn(
 	return func(paginationToken []byte) ([]*historypb.History, []byte, error) {
 		response, err := r.executionMgr.ReadHistoryBranchByBatch(ctx, &persistence.ReadHistoryBranchRequest{
 			ShardID:       r.shardContext.GetShardID(),
+			NamespaceID:   namespaceID,
 			BranchToken:   branchToken,
 			MinEventID:    common.FirstEventID,
 			MaxEventID:    lastEventID + 1,
@@ -1201,6 +1201,7 @@ func (s *workflowReplicatorSuite) Test_bringLocalEventsUpToSourceCurrentBranch_W
 	s.mockExecutionManager.EXPECT().AppendRawHistoryNodes(gomock.Any(), &persistence.AppendRawHistoryNodesRequest{
 		ShardID:           mockShard.GetShardID(),
 		IsNewBranch:       false,
+		NamespaceID:       namespaceID,
 		BranchToken:       localVersionHistoryies.Histories[0].BranchToken,
 		History:           gapBlobs,
 		PrevTransactionID: 0,
@@ -1211,6 +1212,7 @@ func (s *workflowReplicatorSuite) Test_bringLocalEventsUpToSourceCurrentBranch_W
 	s.mockExecutionManager.EXPECT().AppendRawHistoryNodes(gomock.Any(), &persistence.AppendRawHistoryNodesRequest{
 		ShardID:           mockShard.GetShardID(),
 		IsNewBranch:       false,
+		NamespaceID:       namespaceID,
 		BranchToken:       localVersionHistoryies.Histories[0].BranchToken,
 		History:           blobs,
 		PrevTransactionID: taskId1,
@@ -1221,6 +1223,7 @@ func (s *workflowReplicatorSuite) Test_bringLocalEventsUpToSourceCurrentBranch_W
 	s.mockExecutionManager.EXPECT().AppendRawHistoryNodes(gomock.Any(), &persistence.AppendRawHistoryNodesRequest{
 		ShardID:           mockShard.GetShardID(),
 		IsNewBranch:       false,
+		NamespaceID:       namespaceID,
 		BranchToken:       localVersionHistoryies.Histories[0].BranchToken,
 		History:           tailBlobs,
 		PrevTransactionID: taskId2,
@@ -1364,6 +1367,7 @@ func (s *workflowReplicatorSuite) Test_bringLocalEventsUpToSourceCurrentBranch_W
 	s.mockExecutionManager.EXPECT().AppendRawHistoryNodes(gomock.Any(), &persistence.AppendRawHistoryNodesRequest{
 		ShardID:           mockShard.GetShardID(),
 		IsNewBranch:       true,
+		NamespaceID:       namespaceID,
 		BranchToken:       localVersionHistories.Histories[0].BranchToken,
 		History:           gapBlobs,
 		PrevTransactionID: 0,
@@ -1374,6 +1378,7 @@ func (s *workflowReplicatorSuite) Test_bringLocalEventsUpToSourceCurrentBranch_W
 	s.mockExecutionManager.EXPECT().AppendRawHistoryNodes(gomock.Any(), &persistence.AppendRawHistoryNodesRequest{
 		ShardID:           mockShard.GetShardID(),
 		IsNewBranch:       false,
+		NamespaceID:       namespaceID,
 		BranchToken:       localVersionHistories.Histories[0].BranchToken,
 		History:           blobs,
 		PrevTransactionID: taskId1,
@@ -1384,6 +1389,7 @@ func (s *workflowReplicatorSuite) Test_bringLocalEventsUpToSourceCurrentBranch_W
 	s.mockExecutionManager.EXPECT().AppendRawHistoryNodes(gomock.Any(), &persistence.AppendRawHistoryNodesRequest{
 		ShardID:           mockShard.GetShardID(),
 		IsNewBranch:       false,
+		NamespaceID:       namespaceID,
 		BranchToken:       localVersionHistories.Histories[0].BranchToken,
 		History:           tailBlobs,
 		PrevTransactionID: taskId2,
@@ -1552,6 +1558,7 @@ func (s *workflowReplicatorSuite) Test_bringLocalEventsUpToSourceCurrentBranch_W
 	s.mockExecutionManager.EXPECT().AppendRawHistoryNodes(gomock.Any(), &persistence.AppendRawHistoryNodesRequest{
 		ShardID:           mockShard.GetShardID(),
 		IsNewBranch:       false,
+		NamespaceID:       namespaceID,
 		BranchToken:       localVersionHistoryies.Histories[0].BranchToken,
 		History:           gapBlobs,
 		PrevTransactionID: 0,
@@ -1562,6 +1569,7 @@ func (s *workflowReplicatorSuite) Test_bringLocalEventsUpToSourceCurrentBranch_W
 	s.mockExecutionManager.EXPECT().AppendRawHistoryNodes(gomock.Any(), &persistence.AppendRawHistoryNodesRequest{
 		ShardID:           mockShard.GetShardID(),
 		IsNewBranch:       false,
+		NamespaceID:       namespaceID,
 		BranchToken:       localVersionHistoryies.Histories[0].BranchToken,
 		History:           blobs,
 		PrevTransactionID: taskId1,
@@ -0,0 +1,51 @@
+package payloadoffload
+
+import (
+	"context"
+	"errors"
+
+	"go.temporal.io/server/common/config"
+)
+
+type (
+	// BlobStore stores the offloaded payloads and their references. The keys are slash separated paths.
+	BlobStore interface {
+		// Put creates or overwrites the blob of the key.
+		Put(ctx context.Context, key string, data []byte) error
+		// Get returns the blob of the key, or ErrBlobNotFound.
+		Get(ctx context.Context, key string) ([]byte, error)
+		// Delete deletes the blob of the key. Deleting a blob which doesn't exist is not an error.
+		Delete(ctx context.Context, key string) error
+		// Generation returns an opaque version of the blob of the key, which changes whenever the content of the blob
+		// changes, or ErrBlobNotFound.
+		Generation(ctx context.Context, key string) (string, error)
+		// DeleteIfGeneration deletes the blob of the key if it still has the generation, or returns ErrBlobModified.
+		// Deleting a blob which doesn't exist is not an error.
+		DeleteIfGeneration(ctx context.Context, key string, generation string) error
+		// List returns the names of the blobs directly under the dir, without the dir.
+		List(ctx context.Context, dir string) ([]string, error)
+	}
+)
+
+var (
+	// ErrBlobNotFound is returned by BlobStore.Get if the blob doesn't exist.
+	ErrBlobNotFound = errors.New("blob not found")
+	// ErrBlobModified is returned by BlobStore.DeleteIfGeneration if the blob has another generation.
+	ErrBlobModified = errors.New("blob was modified")
+
+	errMultipleBlobStores = errors.New("only one of the filestore and s3store of payloadOffload can be configured")
+)
+
+// NewBlobStore creates the blob store of the config, or returns nil if no blob store is configured.
+func NewBlobStore(cfg config.PayloadOffload) (BlobStore, error) {
+	switch {
+	case cfg.Filestore != nil && cfg.S3store != nil:
+		return nil, errMultipleBlobStores
+	case cfg.Filestore != nil:
+		return NewFilestore(cfg.Filestore)
+	case cfg.S3store != nil:
+		return NewS3store(cfg.S3store)
+	default:
+		return nil, nil
+	}
+}
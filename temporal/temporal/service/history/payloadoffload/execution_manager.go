@@ -0,0 +1,273 @@
+package payloadoffload
+
+import (
+	"bytes"
+	"context"
+
+	commonpb "go.temporal.io/api/common/v1"
+	historypb "go.temporal.io/api/history/v1"
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/log/tag"
+	"go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/persistence/serialization"
+)
+
+type (
+	// executionManager offloads the large payloads of the history events which are written to the history branches,
+	// and rehydrates them when the history branches are read. The raw history batches are rehydrated too, since
+	// they are replicated to remote clusters, which don't necessarily share the blob store.
+	executionManager struct {
+		persistence.ExecutionManager
+
+		offloader  *offloader
+		serializer serialization.Serializer
+		logger     log.Logger
+	}
+)
+
+// NewExecutionManager wraps the execution manager to offload the history event payloads which are larger than the
+// threshold to the blob store.
+func NewExecutionManager(
+	delegate persistence.ExecutionManager,
+	blobStore BlobStore,
+	thresholdBytes dynamicconfig.IntPropertyFn,
+	logger log.Logger,
+) persistence.ExecutionManager {
+	return &executionManager{
+		ExecutionManager: delegate,
+		offloader: &offloader{
+			blobStore:         blobStore,
+			historyBranchUtil: delegate.GetHistoryBranchUtil(),
+			thresholdBytes:    thresholdBytes,
+		},
+		serializer: serialization.NewSerializer(),
+		logger:     logger,
+	}
+}
+
+func (m *executionManager) CreateWorkflowExecution(
+	ctx context.Context,
+	request *persistence.CreateWorkflowExecutionRequest,
+) (*persistence.CreateWorkflowExecutionResponse, error) {
+	newWorkflowEvents, err := m.offloadWorkflowEvents(ctx, request.NewWorkflowEvents)
+	if err != nil {
+		return nil, err
+	}
+	offloadedRequest := *request
+	offloadedRequest.NewWorkflowEvents = newWorkflowEvents
+	return m.ExecutionManager.CreateWorkflowExecution(ctx, &offloadedRequest)
+}
+
+func (m *executionManager) UpdateWorkflowExecution(
+	ctx context.Context,
+	request *persistence.UpdateWorkflowExecutionRequest,
+) (*persistence.UpdateWorkflowExecutionResponse, error) {
+	updateWorkflowEvents, err := m.offloadWorkflowEvents(ctx, request.UpdateWorkflowEvents)
+	if err != nil {
+		return nil, err
+	}
+	newWorkflowEvents, err := m.offloadWorkflowEvents(ctx, request.NewWorkflowEvents)
+	if err != nil {
+		return nil, err
+	}
+	offloadedRequest := *request
+	offloadedRequest.UpdateWorkflowEvents = updateWorkflowEvents
+	offloadedRequest.NewWorkflowEvents = newWorkflowEvents
+	return m.ExecutionManager.UpdateWorkflowExecution(ctx, &offloadedRequest)
+}
+
+func (m *executionManager) ConflictResolveWorkflowExecution(
+	ctx context.Context,
+	request *persistence.ConflictResolveWorkflowExecutionRequest,
+) (*persistence.ConflictResolveWorkflowExecutionResponse, error) {
+	resetWorkflowEvents, err := m.offloadWorkflowEvents(ctx, request.ResetWorkflowEvents)
+	if err != nil {
+		return nil, err
+	}
+	newWorkflowEvents, err := m.offloadWorkflowEvents(ctx, request.NewWorkflowEvents)
+	if err != nil {
+		return nil, err
+	}
+	currentWorkflowEvents, err := m.offloadWorkflowEvents(ctx, request.CurrentWorkflowEvents)
+	if err != nil {
+		return nil, err
+	}
+	offloadedRequest := *request
+	offloadedRequest.ResetWorkflowEvents = resetWorkflowEvents
+	offloadedRequest.NewWorkflowEvents = newWorkflowEvents
+	offloadedRequest.CurrentWorkflowEvents = currentWorkflowEvents
+	return m.ExecutionManager.ConflictResolveWorkflowExecution(ctx, &offloadedRequest)
+}
+
+func (m *executionManager) AppendHistoryNodes(
+	ctx context.Context,
+	request *persistence.AppendHistoryNodesRequest,
+) (*persistence.AppendHistoryNodesResponse, error) {
+	events, err := m.offloadEvents(ctx, request.BranchToken, request.Events)
+	if err != nil {
+		return nil, err
+	}
+	offloadedRequest := *request
+	offloadedRequest.Events = events
+	return m.ExecutionManager.AppendHistoryNodes(ctx, &offloadedRequest)
+}
+
+func (m *executionManager) ReadHistoryBranch(
+	ctx context.Context,
+	request *persistence.ReadHistoryBranchRequest,
+) (*persistence.ReadHistoryBranchResponse, error) {
+	resp, err := m.ExecutionManager.ReadHistoryBranch(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.offloader.rehydrateEvents(ctx, resp.HistoryEvents); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (m *executionManager) ReadHistoryBranchByBatch(
+	ctx context.Context,
+	request *persistence.ReadHistoryBranchRequest,
+) (*persistence.ReadHistoryBranchByBatchResponse, error) {
+	resp, err := m.ExecutionManager.ReadHistoryBranchByBatch(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	for _, batch := range resp.History {
+		if err := m.offloader.rehydrateEvents(ctx, batch.GetEvents()); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+func (m *executionManager) ReadRawHistoryBranch(
+	ctx context.Context,
+	request *persistence.ReadHistoryBranchRequest,
+) (*persistence.ReadRawHistoryBranchResponse, error) {
+	resp, err := m.ExecutionManager.ReadRawHistoryBranch(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	for i, blob := range resp.HistoryEventBlobs {
+		if resp.HistoryEventBlobs[i], err = m.rehydrateBlob(ctx, blob); err != nil {
+			return nil, err
+		}
+		resp.Size += len(resp.HistoryEventBlobs[i].GetData()) - len(blob.GetData())
+	}
+	return resp, nil
+}
+
+func (m *executionManager) ReadHistoryBranchReverse(
+	ctx context.Context,
+	request *persistence.ReadHistoryBranchReverseRequest,
+) (*persistence.ReadHistoryBranchReverseResponse, error) {
+	resp, err := m.ExecutionManager.ReadHistoryBranchReverse(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.offloader.rehydrateEvents(ctx, resp.HistoryEvents); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (m *executionManager) ForkHistoryBranch(
+	ctx context.Context,
+	request *persistence.ForkHistoryBranchRequest,
+) (*persistence.ForkHistoryBranchResponse, error) {
+	resp, err := m.ExecutionManager.ForkHistoryBranch(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	// The forked branch shares the events before the fork point with the branch it was forked from, so it must keep
+	// their payloads alive after the branch it was forked from is deleted.
+	if err := m.offloader.copyReferences(ctx, request.ForkBranchToken, resp.NewBranchToken); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (m *executionManager) DeleteHistoryBranch(
+	ctx context.Context,
+	request *persistence.DeleteHistoryBranchRequest,
+) error {
+	if err := m.ExecutionManager.DeleteHistoryBranch(ctx, request); err != nil {
+		return err
+	}
+	// The history branch is already deleted, so failing to release its payloads only leaks them.
+	if err := m.offloader.removeReferences(ctx, request.BranchToken); err != nil {
+		m.logger.Warn("Unable to remove the offloaded payload references of the deleted history branch",
+			tag.ShardID(request.ShardID),
+			tag.Error(err),
+		)
+	}
+	return nil
+}
+
+// rehydrateBlob returns the raw history batch with the reference payloads of its events replaced by the offloaded
+// payloads. The batches without reference payloads are returned as is, without being decoded.
+func (m *executionManager) rehydrateBlob(ctx context.Context, blob *commonpb.DataBlob) (*commonpb.DataBlob, error) {
+	if !bytes.Contains(blob.GetData(), []byte(MetadataEncodingOffloaded)) {
+		return blob, nil
+	}
+	events, err := m.serializer.DeserializeEvents(blob)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.offloader.rehydrateEvents(ctx, events); err != nil {
+		return nil, err
+	}
+	return m.serializer.SerializeEvents(events)
+}
+
+func (m *executionManager) offloadWorkflowEvents(
+	ctx context.Context,
+	workflowEvents []*persistence.WorkflowEvents,
+) ([]*persistence.WorkflowEvents, error) {
+	var offloadedWorkflowEvents []*persistence.WorkflowEvents
+	for i, workflowEvent := range workflowEvents {
+		events, blobs, err := m.offloader.offloadEvents(workflowEvent.Events)
+		if err != nil {
+			return nil, err
+		}
+		if len(blobs) == 0 {
+			continue
+		}
+		if err := m.offloader.storeBlobs(ctx, workflowEvent.BranchToken, blobs); err != nil {
+			return nil, err
+		}
+
+		if offloadedWorkflowEvents == nil {
+			offloadedWorkflowEvents = make([]*persistence.WorkflowEvents, len(workflowEvents))
+			copy(offloadedWorkflowEvents, workflowEvents)
+		}
+		offloadedWorkflowEvent := *workflowEvent
+		offloadedWorkflowEvent.Events = events
+		offloadedWorkflowEvents[i] = &offloadedWorkflowEvent
+	}
+
+	if offloadedWorkflowEvents == nil {
+		return workflowEvents, nil
+	}
+	return offloadedWorkflowEvents, nil
+}
+
+// offloadEvents offloads the large payloads of the events which are written to the history branch, and returns the
+// events to persist instead.
+func (m *executionManager) offloadEvents(
+	ctx context.Context,
+	branchToken []byte,
+	events []*historypb.HistoryEvent,
+) ([]*historypb.HistoryEvent, error) {
+	offloadedEvents, blobs, err := m.offloader.offloadEvents(events)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.offloader.storeBlobs(ctx, branchToken, blobs); err != nil {
+		return nil, err
+	}
+	return offloadedEvents, nil
+}
@@ -0,0 +1,442 @@
+package payloadoffload
+
+import (
+	"bytes"
+	"context"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	historypb "go.temporal.io/api/history/v1"
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/server/common/config"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/persistence/serialization"
+	"go.temporal.io/server/common/testing/protorequire"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/proto"
+)
+
+const testThresholdBytes = 1024
+
+type (
+	executionManagerSuite struct {
+		suite.Suite
+		*require.Assertions
+		protorequire.ProtoAssertions
+
+		controller        *gomock.Controller
+		mockDelegate      *persistence.MockExecutionManager
+		historyBranchUtil persistence.HistoryBranchUtil
+		blobStore         BlobStore
+		thresholdBytes    int
+
+		executionManager persistence.ExecutionManager
+	}
+)
+
+func TestExecutionManagerSuite(t *testing.T) {
+	suite.Run(t, new(executionManagerSuite))
+}
+
+func (s *executionManagerSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.ProtoAssertions = protorequire.New(s.T())
+
+	s.controller = gomock.NewController(s.T())
+	s.mockDelegate = persistence.NewMockExecutionManager(s.controller)
+	s.historyBranchUtil = persistence.NewHistoryBranchUtil(serialization.NewSerializer())
+	s.mockDelegate.EXPECT().GetHistoryBranchUtil().Return(s.historyBranchUtil).AnyTimes()
+
+	var err error
+	s.blobStore, err = NewFilestore(&config.FilestorePayloadStore{DirPath: s.T().TempDir()})
+	s.NoError(err)
+	s.thresholdBytes = testThresholdBytes
+
+	s.executionManager = NewExecutionManager(
+		s.mockDelegate,
+		s.blobStore,
+		func() int { return s.thresholdBytes },
+		log.NewTestLogger(),
+	)
+}
+
+func (s *executionManagerSuite) TestAppendHistoryNodes_OffloadAndRehydrate() {
+	ctx := context.Background()
+	branchToken := s.newBranchToken("tree", "branch")
+	largePayload := newTestPayload(2 * testThresholdBytes)
+	smallPayload := newTestPayload(10)
+	events := []*historypb.HistoryEvent{
+		newStartedEvent(1, largePayload),
+		newActivityScheduledEvent(2, smallPayload),
+		newActivityScheduledEvent(3, largePayload),
+	}
+	originalEvents := cloneEvents(events)
+
+	var persistedEvents []*historypb.HistoryEvent
+	s.mockDelegate.EXPECT().AppendHistoryNodes(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *persistence.AppendHistoryNodesRequest) (*persistence.AppendHistoryNodesResponse, error) {
+			persistedEvents = cloneEvents(request.Events)
+			return &persistence.AppendHistoryNodesResponse{}, nil
+		},
+	)
+	_, err := s.executionManager.AppendHistoryNodes(ctx, &persistence.AppendHistoryNodesRequest{
+		BranchToken: branchToken,
+		Events:      events,
+	})
+	s.NoError(err)
+
+	// The events of the caller are not modified.
+	s.ProtoElementsMatch(originalEvents, events)
+
+	s.Len(persistedEvents, 3)
+	startedInput := persistedEvents[0].GetWorkflowExecutionStartedEventAttributes().GetInput().GetPayloads()[0]
+	s.Equal(MetadataEncodingOffloaded, string(startedInput.GetMetadata()[converter.MetadataEncoding]))
+	s.Empty(startedInput.GetData())
+	s.ProtoEqual(events[1], persistedEvents[1])
+	scheduledInput := persistedEvents[2].GetActivityTaskScheduledEventAttributes().GetInput().GetPayloads()[0]
+	s.ProtoEqual(startedInput, scheduledInput)
+
+	// The same payload is stored once.
+	keys, err := s.blobStore.List(ctx, path.Join(branchesDir, "tree_branch"))
+	s.NoError(err)
+	s.Len(keys, 1)
+
+	s.mockDelegate.EXPECT().ReadHistoryBranch(gomock.Any(), gomock.Any()).Return(
+		&persistence.ReadHistoryBranchResponse{HistoryEvents: cloneEvents(persistedEvents)}, nil,
+	)
+	readResp, err := s.executionManager.ReadHistoryBranch(ctx, &persistence.ReadHistoryBranchRequest{
+		BranchToken: branchToken,
+	})
+	s.NoError(err)
+	s.ProtoElementsMatch(originalEvents, readResp.HistoryEvents)
+
+	s.mockDelegate.EXPECT().ReadHistoryBranchByBatch(gomock.Any(), gomock.Any()).Return(
+		&persistence.ReadHistoryBranchByBatchResponse{History: []*historypb.History{
+			{Events: cloneEvents(persistedEvents[:1])},
+			{Events: cloneEvents(persistedEvents[1:])},
+		}}, nil,
+	)
+	readByBatchResp, err := s.executionManager.ReadHistoryBranchByBatch(ctx, &persistence.ReadHistoryBranchRequest{
+		BranchToken: branchToken,
+	})
+	s.NoError(err)
+	s.ProtoElementsMatch(originalEvents[:1], readByBatchResp.History[0].Events)
+	s.ProtoElementsMatch(originalEvents[1:], readByBatchResp.History[1].Events)
+}
+
+func (s *executionManagerSuite) TestReadRawHistoryBranch_Rehydrate() {
+	ctx := context.Background()
+	branchToken := s.newBranchToken("tree", "branch")
+	events := []*historypb.HistoryEvent{
+		newStartedEvent(1, newTestPayload(2*testThresholdBytes)),
+		newActivityScheduledEvent(2, newTestPayload(10)),
+	}
+	originalEvents := cloneEvents(events)
+
+	var persistedEvents []*historypb.HistoryEvent
+	s.mockDelegate.EXPECT().AppendHistoryNodes(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *persistence.AppendHistoryNodesRequest) (*persistence.AppendHistoryNodesResponse, error) {
+			persistedEvents = cloneEvents(request.Events)
+			return &persistence.AppendHistoryNodesResponse{}, nil
+		},
+	)
+	_, err := s.executionManager.AppendHistoryNodes(ctx, &persistence.AppendHistoryNodesRequest{
+		BranchToken: branchToken,
+		Events:      events,
+	})
+	s.NoError(err)
+
+	serializer := serialization.NewSerializer()
+	offloadedBlob, err := serializer.SerializeEvents(persistedEvents[:1])
+	s.NoError(err)
+	s.True(bytes.Contains(offloadedBlob.GetData(), []byte(MetadataEncodingOffloaded)))
+	plainBlob, err := serializer.SerializeEvents(persistedEvents[1:])
+	s.NoError(err)
+	s.mockDelegate.EXPECT().ReadRawHistoryBranch(gomock.Any(), gomock.Any()).Return(
+		&persistence.ReadRawHistoryBranchResponse{
+			HistoryEventBlobs: []*commonpb.DataBlob{offloadedBlob, plainBlob},
+			NodeIDs:           []int64{1, 2},
+			Size:              len(offloadedBlob.GetData()) + len(plainBlob.GetData()),
+		}, nil,
+	)
+	resp, err := s.executionManager.ReadRawHistoryBranch(ctx, &persistence.ReadHistoryBranchRequest{
+		BranchToken: branchToken,
+	})
+	s.NoError(err)
+
+	// The raw batches are replicated to clusters which may not have access to the blob store, so they don't contain
+	// any reference payload.
+	s.Len(resp.HistoryEventBlobs, 2)
+	s.False(bytes.Contains(resp.HistoryEventBlobs[0].GetData(), []byte(MetadataEncodingOffloaded)))
+	rehydratedEvents, err := serializer.DeserializeEvents(resp.HistoryEventBlobs[0])
+	s.NoError(err)
+	s.ProtoElementsMatch(originalEvents[:1], rehydratedEvents)
+	s.Same(plainBlob, resp.HistoryEventBlobs[1])
+	s.Equal([]int64{1, 2}, resp.NodeIDs)
+	s.Equal(len(resp.HistoryEventBlobs[0].GetData())+len(plainBlob.GetData()), resp.Size)
+}
+
+func (s *executionManagerSuite) TestAppendHistoryNodes_Disabled() {
+	s.thresholdBytes = 0
+	events := []*historypb.HistoryEvent{
+		newStartedEvent(1, newTestPayload(2*testThresholdBytes)),
+	}
+	request := &persistence.AppendHistoryNodesRequest{
+		BranchToken: s.newBranchToken("tree", "branch"),
+		Events:      events,
+	}
+
+	s.mockDelegate.EXPECT().AppendHistoryNodes(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, offloadedRequest *persistence.AppendHistoryNodesRequest) (*persistence.AppendHistoryNodesResponse, error) {
+			s.Equal(events, offloadedRequest.Events)
+			return &persistence.AppendHistoryNodesResponse{}, nil
+		},
+	)
+	_, err := s.executionManager.AppendHistoryNodes(context.Background(), request)
+	s.NoError(err)
+
+	payloads, err := s.blobStore.List(context.Background(), payloadsDir)
+	s.NoError(err)
+	s.Empty(payloads)
+}
+
+func (s *executionManagerSuite) TestUpdateWorkflowExecution() {
+	largePayload := newTestPayload(2 * testThresholdBytes)
+	updateWorkflowEvents := &persistence.WorkflowEvents{
+		BranchToken: s.newBranchToken("tree", "branch"),
+		Events:      []*historypb.HistoryEvent{newActivityScheduledEvent(5, newTestPayload(10))},
+	}
+	newWorkflowEvents := &persistence.WorkflowEvents{
+		BranchToken: s.newBranchToken("new-tree", "new-branch"),
+		Events:      []*historypb.HistoryEvent{newStartedEvent(1, largePayload)},
+	}
+	request := &persistence.UpdateWorkflowExecutionRequest{
+		UpdateWorkflowEvents: []*persistence.WorkflowEvents{updateWorkflowEvents},
+		NewWorkflowEvents:    []*persistence.WorkflowEvents{newWorkflowEvents},
+	}
+
+	s.mockDelegate.EXPECT().UpdateWorkflowExecution(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, offloadedRequest *persistence.UpdateWorkflowExecutionRequest) (*persistence.UpdateWorkflowExecutionResponse, error) {
+			s.Same(updateWorkflowEvents, offloadedRequest.UpdateWorkflowEvents[0])
+			s.NotSame(newWorkflowEvents, offloadedRequest.NewWorkflowEvents[0])
+			input := offloadedRequest.NewWorkflowEvents[0].Events[0].GetWorkflowExecutionStartedEventAttributes().GetInput()
+			s.Equal(MetadataEncodingOffloaded, string(input.GetPayloads()[0].GetMetadata()[converter.MetadataEncoding]))
+			return &persistence.UpdateWorkflowExecutionResponse{}, nil
+		},
+	)
+	_, err := s.executionManager.UpdateWorkflowExecution(context.Background(), request)
+	s.NoError(err)
+
+	s.Same(newWorkflowEvents, request.NewWorkflowEvents[0])
+	s.ProtoEqual(largePayload, newWorkflowEvents.Events[0].GetWorkflowExecutionStartedEventAttributes().GetInput().GetPayloads()[0])
+	keys, err := s.blobStore.List(context.Background(), path.Join(branchesDir, "new-tree_new-branch"))
+	s.NoError(err)
+	s.Len(keys, 1)
+}
+
+func (s *executionManagerSuite) TestForkAndDeleteHistoryBranch() {
+	ctx := context.Background()
+	branchToken := s.newBranchToken("tree", "branch")
+	forkedBranchToken := s.newBranchToken("tree", "forked-branch")
+
+	s.mockDelegate.EXPECT().AppendHistoryNodes(gomock.Any(), gomock.Any()).Return(&persistence.AppendHistoryNodesResponse{}, nil)
+	_, err := s.executionManager.AppendHistoryNodes(ctx, &persistence.AppendHistoryNodesRequest{
+		BranchToken: branchToken,
+		Events:      []*historypb.HistoryEvent{newStartedEvent(1, newTestPayload(2*testThresholdBytes))},
+	})
+	s.NoError(err)
+	keys, err := s.blobStore.List(ctx, payloadsDir)
+	s.NoError(err)
+	s.Len(keys, 1)
+	payloadPath := path.Join(payloadsDir, keys[0])
+
+	s.mockDelegate.EXPECT().ForkHistoryBranch(gomock.Any(), gomock.Any()).Return(
+		&persistence.ForkHistoryBranchResponse{NewBranchToken: forkedBranchToken}, nil,
+	)
+	_, err = s.executionManager.ForkHistoryBranch(ctx, &persistence.ForkHistoryBranchRequest{
+		ForkBranchToken: branchToken,
+		ForkNodeID:      2,
+	})
+	s.NoError(err)
+
+	// The payload is kept until the last branch which references it is deleted.
+	s.mockDelegate.EXPECT().DeleteHistoryBranch(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	s.NoError(s.executionManager.DeleteHistoryBranch(ctx, &persistence.DeleteHistoryBranchRequest{BranchToken: branchToken}))
+	_, err = s.blobStore.Get(ctx, payloadPath)
+	s.NoError(err)
+
+	s.NoError(s.executionManager.DeleteHistoryBranch(ctx, &persistence.DeleteHistoryBranchRequest{BranchToken: forkedBranchToken}))
+	_, err = s.blobStore.Get(ctx, payloadPath)
+	s.ErrorIs(err, ErrBlobNotFound)
+	refs, err := s.blobStore.List(ctx, path.Join(refsDir, keys[0]))
+	s.NoError(err)
+	s.Empty(refs)
+}
+
+func (s *executionManagerSuite) TestDeleteHistoryBranch_ConcurrentAppend() {
+	ctx := context.Background()
+	branchToken := s.newBranchToken("tree", "branch")
+	otherBranchToken := s.newBranchToken("other-tree", "branch")
+	largePayload := newTestPayload(2 * testThresholdBytes)
+
+	// The other branch appends the same payload after the references were listed by the deletion of the branch,
+	// and before the payload is deleted.
+	blobStore := &listHookBlobStore{BlobStore: s.blobStore}
+	executionManager := NewExecutionManager(s.mockDelegate, blobStore, func() int { return s.thresholdBytes }, log.NewTestLogger())
+	var otherEvents []*historypb.HistoryEvent
+	s.mockDelegate.EXPECT().AppendHistoryNodes(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *persistence.AppendHistoryNodesRequest) (*persistence.AppendHistoryNodesResponse, error) {
+			if bytes.Equal(request.BranchToken, otherBranchToken) {
+				otherEvents = cloneEvents(request.Events)
+			}
+			return &persistence.AppendHistoryNodesResponse{}, nil
+		},
+	).Times(2)
+	_, err := executionManager.AppendHistoryNodes(ctx, &persistence.AppendHistoryNodesRequest{
+		BranchToken: branchToken,
+		Events:      []*historypb.HistoryEvent{newStartedEvent(1, largePayload)},
+	})
+	s.NoError(err)
+
+	blobStore.afterList = func(dir string) {
+		if !strings.HasPrefix(dir, refsDir) {
+			return
+		}
+		blobStore.afterList = nil
+		_, err := executionManager.AppendHistoryNodes(ctx, &persistence.AppendHistoryNodesRequest{
+			BranchToken: otherBranchToken,
+			Events:      []*historypb.HistoryEvent{newStartedEvent(1, largePayload)},
+		})
+		s.NoError(err)
+	}
+	s.mockDelegate.EXPECT().DeleteHistoryBranch(gomock.Any(), gomock.Any()).Return(nil)
+	s.NoError(executionManager.DeleteHistoryBranch(ctx, &persistence.DeleteHistoryBranchRequest{BranchToken: branchToken}))
+
+	s.mockDelegate.EXPECT().ReadHistoryBranch(gomock.Any(), gomock.Any()).Return(
+		&persistence.ReadHistoryBranchResponse{HistoryEvents: otherEvents}, nil,
+	)
+	readResp, err := executionManager.ReadHistoryBranch(ctx, &persistence.ReadHistoryBranchRequest{
+		BranchToken: otherBranchToken,
+	})
+	s.NoError(err)
+	s.ProtoEqual(largePayload, readResp.HistoryEvents[0].GetWorkflowExecutionStartedEventAttributes().GetInput().GetPayloads()[0])
+}
+
+func (s *executionManagerSuite) TestDeleteHistoryBranch_ConcurrentAppends() {
+	ctx := context.Background()
+	largePayload := newTestPayload(2 * testThresholdBytes)
+	s.mockDelegate.EXPECT().AppendHistoryNodes(gomock.Any(), gomock.Any()).Return(&persistence.AppendHistoryNodesResponse{}, nil).AnyTimes()
+	s.mockDelegate.EXPECT().DeleteHistoryBranch(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	// Each branch appends the payload while the previous branch is deleted, so the payload must never be deleted.
+	previousBranchToken := s.newBranchToken("tree", "branch-0")
+	_, err := s.executionManager.AppendHistoryNodes(ctx, &persistence.AppendHistoryNodesRequest{
+		BranchToken: previousBranchToken,
+		Events:      []*historypb.HistoryEvent{newStartedEvent(1, largePayload)},
+	})
+	s.NoError(err)
+	for i := 1; i <= 50; i++ {
+		branchToken := s.newBranchToken("tree", "branch-"+strconv.Itoa(i))
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, err := s.executionManager.AppendHistoryNodes(ctx, &persistence.AppendHistoryNodesRequest{
+				BranchToken: branchToken,
+				Events:      []*historypb.HistoryEvent{newStartedEvent(1, largePayload)},
+			})
+			s.NoError(err)
+		}()
+		go func() {
+			defer wg.Done()
+			s.NoError(s.executionManager.DeleteHistoryBranch(ctx, &persistence.DeleteHistoryBranchRequest{
+				BranchToken: previousBranchToken,
+			}))
+		}()
+		wg.Wait()
+
+		keys, err := s.blobStore.List(ctx, payloadsDir)
+		s.NoError(err)
+		s.Len(keys, 1)
+		previousBranchToken = branchToken
+	}
+}
+
+func (s *executionManagerSuite) TestReadHistoryBranch_MissingPayload() {
+	s.mockDelegate.EXPECT().ReadHistoryBranch(gomock.Any(), gomock.Any()).Return(
+		&persistence.ReadHistoryBranchResponse{HistoryEvents: []*historypb.HistoryEvent{
+			newStartedEvent(1, newReferencePayload("missing", 10)),
+		}}, nil,
+	)
+	_, err := s.executionManager.ReadHistoryBranch(context.Background(), &persistence.ReadHistoryBranchRequest{
+		BranchToken: s.newBranchToken("tree", "branch"),
+	})
+	s.ErrorIs(err, ErrBlobNotFound)
+}
+
+func (s *executionManagerSuite) newBranchToken(treeID string, branchID string) []byte {
+	branchToken, err := s.historyBranchUtil.NewHistoryBranch("", "", "", treeID, &branchID, nil, 0, 0, 0)
+	s.NoError(err)
+	return branchToken
+}
+
+// listHookBlobStore calls afterList after each List.
+type listHookBlobStore struct {
+	BlobStore
+	afterList func(dir string)
+}
+
+func (b *listHookBlobStore) List(ctx context.Context, dir string) ([]string, error) {
+	names, err := b.BlobStore.List(ctx, dir)
+	if b.afterList != nil {
+		b.afterList(dir)
+	}
+	return names, err
+}
+
+func newTestPayload(size int) *commonpb.Payload {
+	return &commonpb.Payload{
+		Metadata: map[string][]byte{converter.MetadataEncoding: []byte(converter.MetadataEncodingBinary)},
+		Data:     make([]byte, size),
+	}
+}
+
+func newStartedEvent(eventID int64, input *commonpb.Payload) *historypb.HistoryEvent {
+	return &historypb.HistoryEvent{
+		EventId:   eventID,
+		EventType: enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED,
+		Attributes: &historypb.HistoryEvent_WorkflowExecutionStartedEventAttributes{
+			WorkflowExecutionStartedEventAttributes: &historypb.WorkflowExecutionStartedEventAttributes{
+				Input: &commonpb.Payloads{Payloads: []*commonpb.Payload{input}},
+			},
+		},
+	}
+}
+
+func newActivityScheduledEvent(eventID int64, input *commonpb.Payload) *historypb.HistoryEvent {
+	return &historypb.HistoryEvent{
+		EventId:   eventID,
+		EventType: enumspb.EVENT_TYPE_ACTIVITY_TASK_SCHEDULED,
+		Attributes: &historypb.HistoryEvent_ActivityTaskScheduledEventAttributes{
+			ActivityTaskScheduledEventAttributes: &historypb.ActivityTaskScheduledEventAttributes{
+				Input: &commonpb.Payloads{Payloads: []*commonpb.Payload{input}},
+			},
+		},
+	}
+}
+
+func cloneEvents(events []*historypb.HistoryEvent) []*historypb.HistoryEvent {
+	cloned := make([]*historypb.HistoryEvent, len(events))
+	for i, event := range events {
+		cloned[i] = proto.Clone(event).(*historypb.HistoryEvent)
+	}
+	return cloned
+}
@@ -0,0 +1,172 @@
+package payloadoffload
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"go.temporal.io/server/common/config"
+)
+
+const (
+	defaultFileMode = 0o644
+	defaultDirMode  = 0o755
+)
+
+type (
+	filestore struct {
+		dirPath  string
+		fileMode os.FileMode
+		dirMode  os.FileMode
+	}
+)
+
+var (
+	errEmptyDirPath    = errors.New("dirPath of the payload filestore is empty")
+	errInvalidFileMode = errors.New("invalid file mode")
+	errInvalidDirMode  = errors.New("invalid directory mode")
+)
+
+// NewFilestore creates a blob store which stores the blobs as files of a local directory, such as a mounted network
+// file system.
+func NewFilestore(cfg *config.FilestorePayloadStore) (BlobStore, error) {
+	if cfg.DirPath == "" {
+		return nil, errEmptyDirPath
+	}
+	fileMode, err := parseMode(cfg.FileMode, defaultFileMode)
+	if err != nil {
+		return nil, errInvalidFileMode
+	}
+	dirMode, err := parseMode(cfg.DirMode, defaultDirMode)
+	if err != nil {
+		return nil, errInvalidDirMode
+	}
+	return &filestore{
+		dirPath:  cfg.DirPath,
+		fileMode: fileMode,
+		dirMode:  dirMode,
+	}, nil
+}
+
+func parseMode(mode string, defaultMode os.FileMode) (os.FileMode, error) {
+	if mode == "" {
+		return defaultMode, nil
+	}
+	parsed, err := strconv.ParseUint(mode, 0, 32)
+	if err != nil {
+		return 0, err
+	}
+	return os.FileMode(parsed), nil
+}
+
+func (f *filestore) Put(_ context.Context, key string, data []byte) error {
+	path := f.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), f.dirMode); err != nil {
+		return err
+	}
+	// Write to a temporary file first, so that readers never see a partially written blob.
+	tmpFile, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmpFile.Name()) }()
+	if _, err := tmpFile.Write(data); err != nil {
+		_ = tmpFile.Close()
+		return err
+	}
+	if err := tmpFile.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpFile.Name(), f.fileMode); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile.Name(), path)
+}
+
+func (f *filestore) Get(_ context.Context, key string) ([]byte, error) {
+	data, err := os.ReadFile(f.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrBlobNotFound
+	}
+	return data, err
+}
+
+func (f *filestore) Delete(_ context.Context, key string) error {
+	path := f.path(key)
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	// Remove the directory if it is now empty, which fails if it isn't.
+	_ = os.Remove(filepath.Dir(path))
+	return nil
+}
+
+func (f *filestore) Generation(_ context.Context, key string) (string, error) {
+	return fileGeneration(f.path(key))
+}
+
+func (f *filestore) DeleteIfGeneration(_ context.Context, key string, generation string) error {
+	path := f.path(key)
+	// The file is moved out of the way first, so that a concurrent Put isn't lost between the check and the removal.
+	tmpPath := filepath.Join(filepath.Dir(path), "."+filepath.Base(path)+".deleted"+strconv.FormatInt(rand.Int64(), 36))
+	if err := os.Rename(path, tmpPath); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	defer func() { _ = os.Remove(tmpPath) }()
+	tmpGeneration, err := fileGeneration(tmpPath)
+	if err != nil {
+		return err
+	}
+	if tmpGeneration != generation {
+		// Put the file back, unless a newer Put already replaced it.
+		if err := os.Link(tmpPath, path); err != nil && !errors.Is(err, os.ErrExist) {
+			return err
+		}
+		return ErrBlobModified
+	}
+	// Remove the directory if it is now empty, which fails if it isn't.
+	_ = os.Remove(tmpPath)
+	_ = os.Remove(filepath.Dir(path))
+	return nil
+}
+
+func fileGeneration(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", ErrBlobNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func (f *filestore) List(_ context.Context, dir string) ([]string, error) {
+	entries, err := os.ReadDir(f.path(dir))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Type().IsRegular() && entry.Name()[0] != '.' {
+			names = append(names, entry.Name())
+		}
+	}
+	return names, nil
+}
+
+func (f *filestore) path(key string) string {
+	return filepath.Join(f.dirPath, filepath.FromSlash(key))
+}
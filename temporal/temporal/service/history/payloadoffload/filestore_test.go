@@ -0,0 +1,113 @@
+package payloadoffload
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/server/common/config"
+)
+
+func TestNewFilestore_InvalidConfig(t *testing.T) {
+	_, err := NewFilestore(&config.FilestorePayloadStore{})
+	require.ErrorIs(t, err, errEmptyDirPath)
+
+	_, err = NewFilestore(&config.FilestorePayloadStore{DirPath: t.TempDir(), FileMode: "rw"})
+	require.ErrorIs(t, err, errInvalidFileMode)
+
+	_, err = NewFilestore(&config.FilestorePayloadStore{DirPath: t.TempDir(), DirMode: "0o888"})
+	require.ErrorIs(t, err, errInvalidDirMode)
+}
+
+func TestNewBlobStore(t *testing.T) {
+	blobStore, err := NewBlobStore(config.PayloadOffload{})
+	require.NoError(t, err)
+	require.Nil(t, blobStore)
+
+	_, err = NewBlobStore(config.PayloadOffload{
+		Filestore: &config.FilestorePayloadStore{DirPath: t.TempDir()},
+		S3store:   &config.S3PayloadStore{Bucket: "bucket"},
+	})
+	require.ErrorIs(t, err, errMultipleBlobStores)
+
+	blobStore, err = NewBlobStore(config.PayloadOffload{
+		Filestore: &config.FilestorePayloadStore{DirPath: t.TempDir()},
+	})
+	require.NoError(t, err)
+	require.IsType(t, &filestore{}, blobStore)
+}
+
+func TestFilestore(t *testing.T) {
+	ctx := context.Background()
+	dirPath := t.TempDir()
+	blobStore, err := NewFilestore(&config.FilestorePayloadStore{DirPath: dirPath, FileMode: "0600"})
+	require.NoError(t, err)
+
+	_, err = blobStore.Get(ctx, "dir/blob")
+	require.ErrorIs(t, err, ErrBlobNotFound)
+	names, err := blobStore.List(ctx, "dir")
+	require.NoError(t, err)
+	require.Empty(t, names)
+
+	require.NoError(t, blobStore.Put(ctx, "dir/blob", []byte("data")))
+	require.NoError(t, blobStore.Put(ctx, "dir/other-blob", nil))
+	require.NoError(t, blobStore.Put(ctx, "dir/sub-dir/blob", []byte("data")))
+
+	data, err := blobStore.Get(ctx, "dir/blob")
+	require.NoError(t, err)
+	require.Equal(t, []byte("data"), data)
+	info, err := os.Stat(filepath.Join(dirPath, "dir", "blob"))
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	require.NoError(t, blobStore.Put(ctx, "dir/blob", []byte("new data")))
+	data, err = blobStore.Get(ctx, "dir/blob")
+	require.NoError(t, err)
+	require.Equal(t, []byte("new data"), data)
+
+	names, err = blobStore.List(ctx, "dir")
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"blob", "other-blob"}, names)
+
+	require.NoError(t, blobStore.Delete(ctx, "dir/sub-dir/blob"))
+	require.NoError(t, blobStore.Delete(ctx, "dir/sub-dir/blob"))
+	_, err = os.Stat(filepath.Join(dirPath, "dir", "sub-dir"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	require.NoError(t, blobStore.Delete(ctx, "dir/blob"))
+	_, err = blobStore.Get(ctx, "dir/blob")
+	require.ErrorIs(t, err, ErrBlobNotFound)
+	names, err = blobStore.List(ctx, "dir")
+	require.NoError(t, err)
+	require.Equal(t, []string{"other-blob"}, names)
+}
+
+func TestFilestore_DeleteIfGeneration(t *testing.T) {
+	ctx := context.Background()
+	blobStore, err := NewFilestore(&config.FilestorePayloadStore{DirPath: t.TempDir()})
+	require.NoError(t, err)
+
+	_, err = blobStore.Generation(ctx, "dir/blob")
+	require.ErrorIs(t, err, ErrBlobNotFound)
+	require.NoError(t, blobStore.DeleteIfGeneration(ctx, "dir/blob", "generation"))
+
+	require.NoError(t, blobStore.Put(ctx, "dir/blob", []byte("data")))
+	generation, err := blobStore.Generation(ctx, "dir/blob")
+	require.NoError(t, err)
+	require.NoError(t, blobStore.Put(ctx, "dir/blob", []byte("new data")))
+	require.ErrorIs(t, blobStore.DeleteIfGeneration(ctx, "dir/blob", generation), ErrBlobModified)
+	data, err := blobStore.Get(ctx, "dir/blob")
+	require.NoError(t, err)
+	require.Equal(t, []byte("new data"), data)
+	names, err := blobStore.List(ctx, "dir")
+	require.NoError(t, err)
+	require.Equal(t, []string{"blob"}, names)
+
+	generation, err = blobStore.Generation(ctx, "dir/blob")
+	require.NoError(t, err)
+	require.NoError(t, blobStore.DeleteIfGeneration(ctx, "dir/blob", generation))
+	_, err = blobStore.Get(ctx, "dir/blob")
+	require.ErrorIs(t, err, ErrBlobNotFound)
+}
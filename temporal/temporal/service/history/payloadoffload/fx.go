@@ -0,0 +1,31 @@
+package payloadoffload
+
+import (
+	"go.temporal.io/server/common/config"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/service/history/configs"
+	"go.uber.org/fx"
+)
+
+var Module = fx.Options(
+	fx.Decorate(ExecutionManagerDecorator),
+)
+
+// ExecutionManagerDecorator wraps the execution manager of the history service to offload the large history event
+// payloads, if a payload offload blob store is configured.
+func ExecutionManagerDecorator(
+	executionManager persistence.ExecutionManager,
+	cfg *config.Config,
+	historyConfig *configs.Config,
+	logger log.Logger,
+) (persistence.ExecutionManager, error) {
+	blobStore, err := NewBlobStore(cfg.PayloadOffload)
+	if err != nil {
+		return nil, err
+	}
+	if blobStore == nil {
+		return executionManager, nil
+	}
+	return NewExecutionManager(executionManager, blobStore, historyConfig.PayloadOffloadThresholdBytes, logger), nil
+}
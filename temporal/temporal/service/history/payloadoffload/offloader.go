@@ -0,0 +1,285 @@
+package payloadoffload
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+
+	commonpb "go.temporal.io/api/common/v1"
+	historypb "go.temporal.io/api/history/v1"
+	"go.temporal.io/api/proxy"
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/persistence"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// MetadataEncodingOffloaded is the encoding of the reference payloads which replace the offloaded payloads in the
+	// persisted history events.
+	MetadataEncodingOffloaded = "binary/temporal-offloaded"
+	// MetadataOffloadKey is the metadata of the reference payloads which contains the key of the offloaded payload.
+	MetadataOffloadKey = "temporal-offload-key"
+	// MetadataOffloadSize is the metadata of the reference payloads which contains the size of the offloaded payload.
+	MetadataOffloadSize = "temporal-offload-size"
+
+	// The offloaded payloads are stored under payloadsDir/<key>. They are content addressed, so that the same payload
+	// is stored once, and reference counted by the history branches which reference them: a marker is stored under
+	// refsDir/<key>/<branch> and branchesDir/<branch>/<key> for each reference.
+	payloadsDir = "payloads"
+	refsDir     = "refs"
+	branchesDir = "branches"
+
+	// The stored payloads are prefixed by a random write ID, so that the generation of the blob changes every time
+	// it is written, even if the payload is the same.
+	writeIDSize = 16
+)
+
+type (
+	offloader struct {
+		blobStore         BlobStore
+		historyBranchUtil persistence.HistoryBranchUtil
+		thresholdBytes    dynamicconfig.IntPropertyFn
+	}
+)
+
+// offloadEvents replaces the payloads of the events which are larger than the threshold by reference payloads. The
+// events are not modified: the events which have offloaded payloads are cloned. It returns the offloaded payloads by
+// key, which must be stored before the returned events are persisted.
+func (o *offloader) offloadEvents(
+	events []*historypb.HistoryEvent,
+) ([]*historypb.HistoryEvent, map[string][]byte, error) {
+	threshold := o.thresholdBytes()
+	if threshold <= 0 {
+		return events, nil, nil
+	}
+
+	var offloadedEvents []*historypb.HistoryEvent
+	blobs := make(map[string][]byte)
+	for i, event := range events {
+		// The events are only visited if they may have large payloads, and on a clone, since the events are shared
+		// with the events cache.
+		if proto.Size(event) <= threshold {
+			continue
+		}
+		offloaded := false
+		offloadedEvent := proto.Clone(event).(*historypb.HistoryEvent)
+		if err := visitPayloads(context.Background(), offloadedEvent, func(payload *commonpb.Payload) (*commonpb.Payload, error) {
+			if proto.Size(payload) <= threshold {
+				return payload, nil
+			}
+			data, err := proto.MarshalOptions{Deterministic: true}.Marshal(payload)
+			if err != nil {
+				return nil, err
+			}
+			key := payloadKey(data)
+			blobs[key] = data
+			offloaded = true
+			return newReferencePayload(key, len(data)), nil
+		}); err != nil {
+			return nil, nil, err
+		}
+		if !offloaded {
+			continue
+		}
+
+		if offloadedEvents == nil {
+			offloadedEvents = make([]*historypb.HistoryEvent, len(events))
+			copy(offloadedEvents, events)
+		}
+		offloadedEvents[i] = offloadedEvent
+	}
+
+	if offloadedEvents == nil {
+		return events, nil, nil
+	}
+	return offloadedEvents, blobs, nil
+}
+
+// storeBlobs stores the offloaded payloads and their references from the history branch.
+func (o *offloader) storeBlobs(ctx context.Context, branchToken []byte, blobs map[string][]byte) error {
+	if len(blobs) == 0 {
+		return nil
+	}
+	branch, err := o.branchName(branchToken)
+	if err != nil {
+		return err
+	}
+	for key, data := range blobs {
+		// The references are stored before the payload, and the payload is always written even if it already
+		// exists, so that a concurrent removal of the last other reference can't leave a dangling reference.
+		if err := o.addReference(ctx, key, branch); err != nil {
+			return err
+		}
+		if err := o.blobStore.Put(ctx, path.Join(payloadsDir, key), append(newWriteID(), data...)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyReferences adds references from the forked branch to the payloads which are referenced by the branch it was
+// forked from. Some of them may only be referenced by events after the fork point, and are then released with the
+// forked branch only.
+func (o *offloader) copyReferences(ctx context.Context, fromBranchToken []byte, toBranchToken []byte) error {
+	fromBranch, err := o.branchName(fromBranchToken)
+	if err != nil {
+		return err
+	}
+	toBranch, err := o.branchName(toBranchToken)
+	if err != nil {
+		return err
+	}
+	keys, err := o.blobStore.List(ctx, path.Join(branchesDir, fromBranch))
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if err := o.addReference(ctx, key, toBranch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeReferences removes the references from the deleted history branch, and deletes the payloads which are not
+// referenced anymore. A payload is only deleted if it wasn't written since its references were listed: the branches
+// which store it concurrently add their reference before they write it, so the payload is either still referenced
+// when the references are listed, or its generation changed.
+func (o *offloader) removeReferences(ctx context.Context, branchToken []byte) error {
+	branch, err := o.branchName(branchToken)
+	if err != nil {
+		return err
+	}
+	keys, err := o.blobStore.List(ctx, path.Join(branchesDir, branch))
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if err := o.blobStore.Delete(ctx, path.Join(refsDir, key, branch)); err != nil {
+			return err
+		}
+		if err := o.deleteIfUnreferenced(ctx, key); err != nil {
+			return err
+		}
+		if err := o.blobStore.Delete(ctx, path.Join(branchesDir, branch, key)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *offloader) deleteIfUnreferenced(ctx context.Context, key string) error {
+	payloadPath := path.Join(payloadsDir, key)
+	// The generation must be read before the references are listed.
+	generation, err := o.blobStore.Generation(ctx, payloadPath)
+	if errors.Is(err, ErrBlobNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	branches, err := o.blobStore.List(ctx, path.Join(refsDir, key))
+	if err != nil || len(branches) > 0 {
+		return err
+	}
+	err = o.blobStore.DeleteIfGeneration(ctx, payloadPath, generation)
+	if errors.Is(err, ErrBlobModified) {
+		// The payload was stored again by a branch which references it.
+		return nil
+	}
+	return err
+}
+
+func (o *offloader) addReference(ctx context.Context, key string, branch string) error {
+	if err := o.blobStore.Put(ctx, path.Join(refsDir, key, branch), nil); err != nil {
+		return err
+	}
+	return o.blobStore.Put(ctx, path.Join(branchesDir, branch, key), nil)
+}
+
+// rehydrateEvents replaces the reference payloads of the events by the offloaded payloads, in place.
+func (o *offloader) rehydrateEvents(ctx context.Context, events []*historypb.HistoryEvent) error {
+	rehydrated := make(map[string]*commonpb.Payload)
+	for _, event := range events {
+		if err := visitPayloads(ctx, event, func(payload *commonpb.Payload) (*commonpb.Payload, error) {
+			if string(payload.GetMetadata()[converter.MetadataEncoding]) != MetadataEncodingOffloaded {
+				return payload, nil
+			}
+			key := string(payload.GetMetadata()[MetadataOffloadKey])
+			if offloadedPayload, ok := rehydrated[key]; ok {
+				return offloadedPayload, nil
+			}
+			data, err := o.blobStore.Get(ctx, path.Join(payloadsDir, key))
+			if err != nil {
+				return nil, fmt.Errorf("unable to get offloaded payload %s: %w", key, err)
+			}
+			if len(data) < writeIDSize {
+				return nil, fmt.Errorf("offloaded payload %s is truncated", key)
+			}
+			offloadedPayload := &commonpb.Payload{}
+			if err := proto.Unmarshal(data[writeIDSize:], offloadedPayload); err != nil {
+				return nil, err
+			}
+			rehydrated[key] = offloadedPayload
+			return offloadedPayload, nil
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *offloader) branchName(branchToken []byte) (string, error) {
+	branch, err := o.historyBranchUtil.ParseHistoryBranchInfo(branchToken)
+	if err != nil {
+		return "", err
+	}
+	return branch.GetTreeId() + "_" + branch.GetBranchId(), nil
+}
+
+func visitPayloads(
+	ctx context.Context,
+	event *historypb.HistoryEvent,
+	visitor func(payload *commonpb.Payload) (*commonpb.Payload, error),
+) error {
+	return proxy.VisitPayloads(ctx, event, proxy.VisitPayloadsOptions{
+		Visitor: func(_ *proxy.VisitPayloadsContext, payloads []*commonpb.Payload) ([]*commonpb.Payload, error) {
+			for i, payload := range payloads {
+				var err error
+				if payloads[i], err = visitor(payload); err != nil {
+					return nil, err
+				}
+			}
+			return payloads, nil
+		},
+		// The search attributes are indexed by the visibility store.
+		SkipSearchAttributes: true,
+	})
+}
+
+func payloadKey(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func newWriteID() []byte {
+	writeID := make([]byte, writeIDSize)
+	_, _ = rand.Read(writeID)
+	return writeID
+}
+
+func newReferencePayload(key string, size int) *commonpb.Payload {
+	return &commonpb.Payload{
+		Metadata: map[string][]byte{
+			converter.MetadataEncoding: []byte(MetadataEncodingOffloaded),
+			MetadataOffloadKey:         []byte(key),
+			MetadataOffloadSize:        []byte(strconv.Itoa(size)),
+		},
+	}
+}
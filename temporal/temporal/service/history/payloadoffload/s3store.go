@@ -0,0 +1,148 @@
+package payloadoffload
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"go.temporal.io/server/common/config"
+)
+
+type (
+	s3store struct {
+		s3cli  s3iface.S3API
+		bucket string
+		prefix string
+	}
+)
+
+var errEmptyBucket = errors.New("bucket of the payload s3store is empty")
+
+// NewS3store creates a blob store which stores the blobs as objects of an S3-compatible bucket.
+func NewS3store(cfg *config.S3PayloadStore) (BlobStore, error) {
+	if cfg.Bucket == "" {
+		return nil, errEmptyBucket
+	}
+	sess, err := session.NewSession(&aws.Config{
+		Endpoint:         cfg.Endpoint,
+		Region:           aws.String(cfg.Region),
+		S3ForcePathStyle: aws.Bool(cfg.S3ForcePathStyle),
+		LogLevel:         (*aws.LogLevelType)(&cfg.LogLevel),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newS3store(s3.New(sess), cfg.Bucket, cfg.Prefix), nil
+}
+
+func newS3store(s3cli s3iface.S3API, bucket string, prefix string) *s3store {
+	prefix = strings.Trim(prefix, "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+	return &s3store{
+		s3cli:  s3cli,
+		bucket: bucket,
+		prefix: prefix,
+	}
+}
+
+func (s *s3store) Put(ctx context.Context, key string, data []byte) error {
+	_, err := s.s3cli.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.prefix + key),
+		Body:   bytes.NewReader(data),
+	})
+	return err
+}
+
+func (s *s3store) Get(ctx context.Context, key string) ([]byte, error) {
+	result, err := s.s3cli.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.prefix + key),
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return nil, ErrBlobNotFound
+		}
+		return nil, err
+	}
+	defer func() { _ = result.Body.Close() }()
+	return io.ReadAll(result.Body)
+}
+
+func (s *s3store) Delete(ctx context.Context, key string) error {
+	// Deleting an object which doesn't exist succeeds.
+	_, err := s.s3cli.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.prefix + key),
+	})
+	return err
+}
+
+func (s *s3store) Generation(ctx context.Context, key string) (string, error) {
+	result, err := s.s3cli.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.prefix + key),
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return "", ErrBlobNotFound
+		}
+		return "", err
+	}
+	return aws.StringValue(result.ETag), nil
+}
+
+func (s *s3store) DeleteIfGeneration(ctx context.Context, key string, generation string) error {
+	// The SDK doesn't model the conditional deletes of S3 yet, so the If-Match header is set on the request.
+	req, _ := s.s3cli.DeleteObjectRequest(&s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.prefix + key),
+	})
+	req.SetContext(ctx)
+	req.HTTPRequest.Header.Set("If-Match", generation)
+	err := req.Send()
+	var requestFailure awserr.RequestFailure
+	switch {
+	case err == nil, isNotFound(err):
+		return nil
+	case errors.As(err, &requestFailure) && requestFailure.StatusCode() == http.StatusPreconditionFailed:
+		return ErrBlobModified
+	default:
+		return err
+	}
+}
+
+func isNotFound(err error) bool {
+	var awsErr awserr.Error
+	if !errors.As(err, &awsErr) {
+		return false
+	}
+	// HeadObject has no response body, so its error code is the HTTP status text.
+	return awsErr.Code() == s3.ErrCodeNoSuchKey || awsErr.Code() == "NotFound"
+}
+
+func (s *s3store) List(ctx context.Context, dir string) ([]string, error) {
+	dirPrefix := s.prefix + strings.TrimSuffix(dir, "/") + "/"
+	var names []string
+	err := s.s3cli.ListObjectsV2PagesWithContext(ctx, &s3.ListObjectsV2Input{
+		Bucket:    aws.String(s.bucket),
+		Prefix:    aws.String(dirPrefix),
+		Delimiter: aws.String("/"),
+	}, func(page *s3.ListObjectsV2Output, _ bool) bool {
+		for _, object := range page.Contents {
+			names = append(names, strings.TrimPrefix(aws.StringValue(object.Key), dirPrefix))
+		}
+		return true
+	})
+	return names, err
+}
@@ -98,6 +98,10 @@ type (
 		MaxReaderCount                      dynamicconfig.IntPropertyFn
 		MoveGroupTaskCountBase              dynamicconfig.IntPropertyFn
 		MoveGroupTaskCountMultiplier        dynamicconfig.FloatPropertyFn
+
+		// TimeSkippingAutoAdvanceInterval enables time skipping for the namespaces with
+		// dynamicconfig.TimeSkippingEnabled on a scheduled queue when it is set.
+		TimeSkippingAutoAdvanceInterval dynamicconfig.DurationPropertyFn
 	}
 )
 
@@ -33,6 +33,13 @@ type (
 
 		lookAheadCh               chan struct{}
 		lookAheadRateLimitRequest quotas.Request
+
+		// timeSkipper is nil if time skipping is not enabled for the queue.
+		timeSkipper         *timeSkipper
+		timeSkippingCh      <-chan struct{}
+		cancelTimeSkipping  func()
+		timeSkippingTimer   *time.Timer
+		timeSkippingTimerCh <-chan time.Time
 	}
 )
 
@@ -98,7 +105,7 @@ func NewScheduledQueue(
 		}
 	}
 
-	return &scheduledQueue{
+	queue := &scheduledQueue{
 		queueBase: newQueueBase(
 			shard,
 			category,
@@ -120,6 +127,17 @@ func NewScheduledQueue(
 		lookAheadCh:               lookAheadCh,
 		lookAheadRateLimitRequest: newReaderRequest(DefaultReaderId),
 	}
+	if options.TimeSkippingAutoAdvanceInterval != nil {
+		queue.timeSkipper = newTimeSkipper(
+			shard,
+			category,
+			executableFactory,
+			scheduler,
+			options.BatchSize,
+			queue.logger,
+		)
+	}
+	return queue
 }
 
 func (p *scheduledQueue) Start() {
@@ -132,6 +150,13 @@ func (p *scheduledQueue) Start() {
 
 	p.queueBase.Start()
 
+	if p.timeSkipper != nil {
+		p.timeSkipper.start()
+		p.timeSkippingCh, p.cancelTimeSkipping = p.timeSkipper.clock.Subscribe()
+		p.timeSkippingTimer = time.NewTimer(p.options.TimeSkippingAutoAdvanceInterval())
+		p.timeSkippingTimerCh = p.timeSkippingTimer.C
+	}
+
 	p.shutdownWG.Add(1)
 	go p.processEventLoop()
 
@@ -148,6 +173,11 @@ func (p *scheduledQueue) Stop() {
 
 	close(p.shutdownCh)
 	p.timerGate.Close()
+	if p.timeSkipper != nil {
+		p.cancelTimeSkipping()
+		p.timeSkippingTimer.Stop()
+		p.timeSkipper.stop()
+	}
 
 	if success := common.AwaitWaitGroup(&p.shutdownWG, time.Minute); !success {
 		p.logger.Warn("", tag.LifeCycleStopTimedout)
@@ -169,6 +199,10 @@ func (p *scheduledQueue) NotifyNewTasks(tasks []tasks.Task) {
 		}
 	}
 
+	if p.timeSkipper != nil {
+		p.timeSkipper.notifyNewTasks(tasks)
+	}
+
 	p.notify(newTime)
 }
 
@@ -194,6 +228,10 @@ func (p *scheduledQueue) processEventLoop() {
 			p.processNewRange()
 		case <-p.checkpointTimer.C:
 			p.checkpoint()
+		case <-p.timeSkippingCh:
+			p.timeSkipper.skipTime(p.nonReadableScope.Range.InclusiveMin)
+		case <-p.timeSkippingTimerCh:
+			p.processTimeSkipping()
 		case alert := <-p.alertCh:
 			p.handleAlert(alert)
 		}
@@ -224,6 +262,13 @@ func (p *scheduledQueue) processNewTime() {
 	p.timerGate.Update(newTime)
 }
 
+func (p *scheduledQueue) processTimeSkipping() {
+	// skipping time again retries the tasks which failed to be loaded
+	p.timeSkipper.skipTime(p.nonReadableScope.Range.InclusiveMin)
+	p.timeSkipper.recover()
+	p.timeSkippingTimer.Reset(p.options.TimeSkippingAutoAdvanceInterval())
+}
+
 func (p *scheduledQueue) lookAheadTask() {
 	rateLimitCtx, rateLimitCancel := context.WithTimeout(context.Background(), lookAheadRateLimitDelay)
 	rateLimitErr := p.readerRateLimiter.Wait(rateLimitCtx, p.lookAheadRateLimitRequest)
@@ -0,0 +1,301 @@
+package queues
+
+import (
+	"errors"
+	"sync"
+
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/server/chasm"
+	"go.temporal.io/server/common/clock"
+	"go.temporal.io/server/common/definition"
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/log/tag"
+	"go.temporal.io/server/common/namespace"
+	"go.temporal.io/server/common/persistence"
+	ctasks "go.temporal.io/server/common/tasks"
+	historyi "go.temporal.io/server/service/history/interfaces"
+	"go.temporal.io/server/service/history/tasks"
+	"go.temporal.io/server/service/history/timeskipping"
+)
+
+type (
+	// timeSkipper submits the tasks of the namespaces with time skipping enabled once they are due on the virtual time
+	// of their namespace, which is ahead of the real time the readers of the scheduled queue load the tasks at. The
+	// tasks are still loaded by the readers once they are due on the real time, and their executor drops them as they
+	// are already executed.
+	//
+	// timeSkipper also keeps the tracker of the shard in the clock, which the coordinator of a namespace checks before
+	// auto-advancing its virtual time to the fire time of its next task.
+	timeSkipper struct {
+		shard             historyi.ShardContext
+		category          tasks.Category
+		clock             *timeskipping.Clock
+		executableFactory ExecutableFactory
+		scheduler         Scheduler
+		timeSource        clock.TimeSource
+		batchSize         dynamicconfig.IntPropertyFn
+		logger            log.Logger
+
+		// readLevels is the exclusive max key of the tasks already submitted for each namespace, it is only accessed
+		// by the event loop of the queue.
+		readLevels map[namespace.ID]tasks.Key
+
+		tracker *timeskipping.ShardTracker
+		// the recovery of the tracker is only accessed by the event loop of the queue.
+		executionsPageToken []byte
+		executionsRecovered bool
+		timersMinKey        tasks.Key
+		timersPageToken     []byte
+		timersRecovered     bool
+
+		sync.Mutex
+		// pendingExecutables are the submitted executables of each namespace which are not completed yet, the virtual
+		// time of a namespace is not auto-advanced until the tasks of its previous advance are executed.
+		pendingExecutables map[namespace.ID][]Executable
+	}
+)
+
+func newTimeSkipper(
+	shard historyi.ShardContext,
+	category tasks.Category,
+	executableFactory ExecutableFactory,
+	scheduler Scheduler,
+	batchSize dynamicconfig.IntPropertyFn,
+	logger log.Logger,
+) *timeSkipper {
+	return &timeSkipper{
+		shard:              shard,
+		category:           category,
+		clock:              shard.TimeSkippingClock(),
+		executableFactory:  executableFactory,
+		scheduler:          scheduler,
+		timeSource:         shard.GetTimeSource(),
+		batchSize:          batchSize,
+		logger:             logger,
+		readLevels:         make(map[namespace.ID]tasks.Key),
+		pendingExecutables: make(map[namespace.ID][]Executable),
+	}
+}
+
+func (s *timeSkipper) start() {
+	// the timers before are loaded by the readers of the queue
+	s.timersMinKey = tasks.NewKey(s.timeSource.Now(), 0)
+	s.tracker = s.clock.RegisterShard(s.shard.GetShardID(), s.hasPendingExecutables)
+}
+
+func (s *timeSkipper) stop() {
+	s.clock.UnregisterShard(s.tracker)
+}
+
+// skipTime submits the tasks of the namespaces which are due on their virtual time. The tasks before
+// inclusiveMinTaskKey are loaded by the readers of the queue.
+func (s *timeSkipper) skipTime(inclusiveMinTaskKey tasks.Key) {
+	for _, namespaceID := range s.clock.Namespaces() {
+		if s.clock.Skipped(namespaceID) <= 0 {
+			continue
+		}
+
+		minKey := inclusiveMinTaskKey
+		if readLevel, ok := s.readLevels[namespaceID]; ok && readLevel.CompareTo(minKey) > 0 {
+			minKey = readLevel
+		}
+		maxKey := tasks.NewKey(s.clock.Now(namespaceID), 0)
+		if minKey.CompareTo(maxKey) >= 0 {
+			continue
+		}
+
+		var nextPageToken []byte
+		for {
+			resp, err := s.getTasks(minKey, maxKey, nextPageToken)
+			if err != nil {
+				// the tasks are read again on the next attempt
+				s.logger.Error("Failed to load tasks of time skipping namespace", tag.WorkflowNamespaceID(namespaceID.String()), tag.Error(err))
+				return
+			}
+			for _, task := range resp.Tasks {
+				if task.GetNamespaceID() == namespaceID.String() && isTimeSkippable(task) {
+					s.submit(namespaceID, task)
+				}
+			}
+			nextPageToken = resp.NextPageToken
+			if len(nextPageToken) == 0 {
+				break
+			}
+		}
+		s.readLevels[namespaceID] = maxKey
+	}
+}
+
+// recover loads a page of the executions and then of the timer tasks persisted before the shard was loaded into the
+// tracker of the shard, until the tracker is recovered. The recovery only starts once time skipping is enabled for a
+// namespace, so the namespaces need to have time skipping enabled when their shards are loaded.
+func (s *timeSkipper) recover() {
+	if s.tracker.Recovered() || len(s.clock.Namespaces()) == 0 {
+		return
+	}
+
+	if !s.executionsRecovered {
+		if err := s.recoverExecutions(); err != nil {
+			s.logger.Error("Failed to recover executions of time skipping namespaces", tag.Error(err))
+		}
+		return
+	}
+	if err := s.recoverTimers(); err != nil {
+		s.logger.Error("Failed to recover timers of time skipping namespaces", tag.Error(err))
+		return
+	}
+	if s.timersRecovered {
+		s.tracker.SetRecovered()
+	}
+}
+
+func (s *timeSkipper) recoverExecutions() error {
+	ctx, cancel := newQueueIOContext()
+	defer cancel()
+
+	resp, err := s.shard.GetExecutionManager().ListConcreteExecutions(ctx, &persistence.ListConcreteExecutionsRequest{
+		ShardID:   s.shard.GetShardID(),
+		PageSize:  s.batchSize(),
+		PageToken: s.executionsPageToken,
+	})
+	if err != nil {
+		return err
+	}
+	for _, state := range resp.States {
+		namespaceID := namespace.ID(state.GetExecutionInfo().GetNamespaceId())
+		if !s.enabled(namespaceID) || !timeskipping.IsExecutionRunning(state) {
+			continue
+		}
+		workflowKey := definition.NewWorkflowKey(
+			namespaceID.String(),
+			state.GetExecutionInfo().GetWorkflowId(),
+			state.GetExecutionState().GetRunId(),
+		)
+		// the listed executions only have their execution info and state
+		executionResp, err := s.shard.GetWorkflowExecution(ctx, &persistence.GetWorkflowExecutionRequest{
+			ShardID:     s.shard.GetShardID(),
+			NamespaceID: workflowKey.NamespaceID,
+			WorkflowID:  workflowKey.WorkflowID,
+			RunID:       workflowKey.RunID,
+			ArchetypeID: chasm.WorkflowArchetypeID,
+		})
+		if err != nil {
+			var notFound *serviceerror.NotFound
+			if errors.As(err, &notFound) {
+				continue
+			}
+			return err
+		}
+		s.tracker.RecoverExecution(workflowKey, timeskipping.IsExecutionBusy(executionResp.State, s.clock.Now(namespaceID)))
+	}
+	s.executionsPageToken = resp.PageToken
+	s.executionsRecovered = len(resp.PageToken) == 0
+	return nil
+}
+
+func (s *timeSkipper) recoverTimers() error {
+	resp, err := s.getTasks(s.timersMinKey, tasks.NewKey(tasks.MaximumKey.FireTime, 0), s.timersPageToken)
+	if err != nil {
+		return err
+	}
+	for _, task := range resp.Tasks {
+		namespaceID := namespace.ID(task.GetNamespaceID())
+		if isTimeSkippable(task) && s.enabled(namespaceID) {
+			s.tracker.AddTimer(namespaceID, task.GetKey().FireTime)
+		}
+	}
+	s.timersPageToken = resp.NextPageToken
+	s.timersRecovered = len(resp.NextPageToken) == 0
+	return nil
+}
+
+func (s *timeSkipper) enabled(namespaceID namespace.ID) bool {
+	namespaceEntry, err := s.shard.GetNamespaceRegistry().GetNamespaceByID(namespaceID)
+	return err == nil && s.clock.Enabled(namespaceEntry.Name())
+}
+
+// notifyNewTasks submits the new tasks which are already due on the virtual time of their namespace, and records the
+// fire times of the others in the tracker of the shard.
+func (s *timeSkipper) notifyNewTasks(newTasks []tasks.Task) {
+	for _, task := range newTasks {
+		namespaceID := namespace.ID(task.GetNamespaceID())
+		if !isTimeSkippable(task) || !s.clock.EnabledByID(namespaceID) {
+			continue
+		}
+		if !task.GetKey().FireTime.Before(s.clock.Now(namespaceID)) {
+			s.tracker.AddTimer(namespaceID, task.GetKey().FireTime)
+		} else if s.clock.Skipped(namespaceID) > 0 {
+			s.submit(namespaceID, task)
+		}
+	}
+}
+
+// isTimeSkippable returns whether the task waits for time to pass, like a user timer or the backoff of a retry, and
+// so can be executed once it is due on the virtual time of its namespace. The timeouts of workflow tasks and activities
+// are not skippable, as they bound the real time workers take to process the tasks.
+func isTimeSkippable(task tasks.Task) bool {
+	switch task.(type) {
+	case *tasks.UserTimerTask,
+		*tasks.ActivityRetryTimerTask,
+		*tasks.WorkflowBackoffTimerTask,
+		*tasks.WorkflowRunTimeoutTask,
+		*tasks.WorkflowExecutionTimeoutTask:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *timeSkipper) getTasks(
+	inclusiveMinTaskKey tasks.Key,
+	exclusiveMaxTaskKey tasks.Key,
+	nextPageToken []byte,
+) (*persistence.GetHistoryTasksResponse, error) {
+	ctx, cancel := newQueueIOContext()
+	defer cancel()
+
+	return s.shard.GetHistoryTasks(ctx, &persistence.GetHistoryTasksRequest{
+		ShardID:             s.shard.GetShardID(),
+		TaskCategory:        s.category,
+		InclusiveMinTaskKey: inclusiveMinTaskKey,
+		ExclusiveMaxTaskKey: exclusiveMaxTaskKey,
+		BatchSize:           s.batchSize(),
+		NextPageToken:       nextPageToken,
+	})
+}
+
+func (s *timeSkipper) submit(
+	namespaceID namespace.ID,
+	task tasks.Task,
+) {
+	executable := s.executableFactory.NewExecutable(task, DefaultReaderId)
+
+	s.Lock()
+	s.pendingExecutables[namespaceID] = append(s.pendingExecutables[namespaceID], executable)
+	s.Unlock()
+
+	executable.SetScheduledTime(s.timeSource.Now())
+	if !s.scheduler.TrySubmit(executable) {
+		executable.Reschedule()
+	}
+}
+
+func (s *timeSkipper) hasPendingExecutables(namespaceID namespace.ID) bool {
+	s.Lock()
+	defer s.Unlock()
+
+	executables := s.pendingExecutables[namespaceID]
+	pending := executables[:0]
+	for _, executable := range executables {
+		if executable.State() == ctasks.TaskStatePending {
+			pending = append(pending, executable)
+		}
+	}
+	if len(pending) == 0 {
+		delete(s.pendingExecutables, namespaceID)
+		return false
+	}
+	s.pendingExecutables[namespaceID] = pending
+	return true
+}
@@ -0,0 +1,267 @@
+package queues
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.temporal.io/api/serviceerror"
+	enumsspb "go.temporal.io/server/api/enums/v1"
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/chasm"
+	"go.temporal.io/server/common/clock"
+	"go.temporal.io/server/common/definition"
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/persistence"
+	ctasks "go.temporal.io/server/common/tasks"
+	"go.temporal.io/server/service/history/shard"
+	"go.temporal.io/server/service/history/tasks"
+	"go.temporal.io/server/service/history/tests"
+	"go.temporal.io/server/service/history/timeskipping"
+	"go.uber.org/mock/gomock"
+)
+
+type (
+	timeSkipperSuite struct {
+		suite.Suite
+		*require.Assertions
+
+		controller           *gomock.Controller
+		mockShard            *shard.ContextTest
+		mockExecutionManager *persistence.MockExecutionManager
+		mockScheduler        *MockScheduler
+		timeSource           *clock.EventTimeSource
+
+		submitted   []tasks.Task
+		timeSkipper *timeSkipper
+	}
+)
+
+func TestTimeSkipperSuite(t *testing.T) {
+	s := new(timeSkipperSuite)
+	suite.Run(t, s)
+}
+
+func (s *timeSkipperSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+
+	s.controller = gomock.NewController(s.T())
+	s.timeSource = clock.NewEventTimeSource().Update(time.Now())
+	config := tests.NewDynamicConfig()
+	config.TimeSkippingEnabled = dynamicconfig.GetBoolPropertyFnFilteredByNamespace(true)
+	s.mockShard = shard.NewTestContextWithTimeSource(
+		s.controller,
+		&persistencespb.ShardInfo{
+			ShardId: 1,
+			RangeId: 1,
+		},
+		config,
+		s.timeSource,
+	)
+	s.mockExecutionManager = s.mockShard.Resource.ExecutionMgr
+	s.mockShard.Resource.NamespaceCache.EXPECT().GetNamespaceByID(tests.NamespaceID).Return(tests.GlobalNamespaceEntry, nil).AnyTimes()
+	s.mockScheduler = NewMockScheduler(s.controller)
+	s.mockScheduler.EXPECT().TrySubmit(gomock.Any()).Return(true).AnyTimes()
+
+	s.submitted = nil
+	executableFactory := ExecutableFactoryFn(func(_ int64, task tasks.Task) Executable {
+		s.submitted = append(s.submitted, task)
+		executable := NewMockExecutable(s.controller)
+		executable.EXPECT().SetScheduledTime(gomock.Any()).AnyTimes()
+		executable.EXPECT().State().Return(ctasks.TaskStateAcked).AnyTimes()
+		return executable
+	})
+	s.timeSkipper = newTimeSkipper(
+		s.mockShard,
+		tasks.CategoryTimer,
+		executableFactory,
+		s.mockScheduler,
+		func() int { return 100 },
+		log.NewTestLogger(),
+	)
+	s.timeSkipper.start()
+}
+
+func (s *timeSkipperSuite) TearDownTest() {
+	s.timeSkipper.stop()
+	s.controller.Finish()
+	s.mockShard.StopForTest()
+}
+
+func (s *timeSkipperSuite) TestSkipTime() {
+	now := s.timeSource.Now()
+	s.timeSkipper.clock.SetSkipped(tests.GlobalNamespaceEntry, time.Hour)
+
+	dueTask := s.newUserTimerTask(tests.NamespaceID.String(), now.Add(30*time.Minute))
+	otherNamespaceTask := s.newUserTimerTask(tests.ParentNamespaceID.String(), now.Add(30*time.Minute))
+	minKey := tasks.NewKey(now, 0)
+	s.mockExecutionManager.EXPECT().GetHistoryTasks(gomock.Any(), &persistence.GetHistoryTasksRequest{
+		ShardID:             1,
+		TaskCategory:        tasks.CategoryTimer,
+		InclusiveMinTaskKey: minKey,
+		ExclusiveMaxTaskKey: tasks.NewKey(now.Add(time.Hour), 0),
+		BatchSize:           100,
+	}).Return(&persistence.GetHistoryTasksResponse{
+		Tasks: []tasks.Task{dueTask, otherNamespaceTask},
+	}, nil)
+
+	s.timeSkipper.skipTime(minKey)
+	s.Equal([]tasks.Task{dueTask}, s.submitted)
+
+	// the tasks already submitted are not read again
+	s.timeSkipper.skipTime(minKey)
+	s.Len(s.submitted, 1)
+
+	s.timeSource.Advance(time.Minute)
+	s.mockExecutionManager.EXPECT().GetHistoryTasks(gomock.Any(), &persistence.GetHistoryTasksRequest{
+		ShardID:             1,
+		TaskCategory:        tasks.CategoryTimer,
+		InclusiveMinTaskKey: tasks.NewKey(now.Add(time.Hour), 0),
+		ExclusiveMaxTaskKey: tasks.NewKey(now.Add(time.Hour+time.Minute), 0),
+		BatchSize:           100,
+	}).Return(&persistence.GetHistoryTasksResponse{}, nil)
+	s.timeSkipper.skipTime(minKey)
+	s.Len(s.submitted, 1)
+}
+
+func (s *timeSkipperSuite) TestSkipTime_NotSkipped() {
+	s.timeSkipper.clock.SetExecutionBusy(
+		1,
+		tests.GlobalNamespaceEntry,
+		definition.NewWorkflowKey(tests.NamespaceID.String(), tests.WorkflowID, tests.RunID),
+		false,
+	)
+
+	s.timeSkipper.skipTime(tasks.NewKey(s.timeSource.Now(), 0))
+	s.Empty(s.submitted)
+}
+
+func (s *timeSkipperSuite) TestRecover() {
+	now := s.timeSource.Now()
+	s.mockShard.Resource.NamespaceCache.EXPECT().GetNamespaceByID(tests.ParentNamespaceID).
+		Return(nil, serviceerror.NewNamespaceNotFound("")).AnyTimes()
+
+	// the recovery starts once time skipping is enabled for a namespace
+	s.timeSkipper.recover()
+	s.timeSkipper.clock.SetSkipped(tests.GlobalNamespaceEntry, time.Minute)
+
+	s.mockExecutionManager.EXPECT().ListConcreteExecutions(gomock.Any(), &persistence.ListConcreteExecutionsRequest{
+		ShardID:  1,
+		PageSize: 100,
+	}).Return(&persistence.ListConcreteExecutionsResponse{
+		States: []*persistencespb.WorkflowMutableState{
+			s.newMutableState(tests.NamespaceID.String(), "workflow-id-1", 0),
+			s.newMutableState(tests.NamespaceID.String(), "workflow-id-2", 5),
+			s.newMutableState(tests.ParentNamespaceID.String(), "workflow-id-3", 5),
+			s.newCompletedMutableState(tests.NamespaceID.String(), "workflow-id-4"),
+		},
+		PageToken: []byte("token"),
+	}, nil)
+	for _, state := range []*persistencespb.WorkflowMutableState{
+		s.newMutableState(tests.NamespaceID.String(), "workflow-id-1", 0),
+		s.newMutableState(tests.NamespaceID.String(), "workflow-id-2", 5),
+	} {
+		s.mockExecutionManager.EXPECT().GetWorkflowExecution(gomock.Any(), &persistence.GetWorkflowExecutionRequest{
+			ShardID:     1,
+			NamespaceID: tests.NamespaceID.String(),
+			WorkflowID:  state.ExecutionInfo.WorkflowId,
+			RunID:       tests.RunID,
+			ArchetypeID: chasm.WorkflowArchetypeID,
+		}).Return(&persistence.GetWorkflowExecutionResponse{State: state}, nil)
+	}
+	s.timeSkipper.recover()
+	s.False(s.timeSkipper.tracker.Recovered())
+
+	s.mockExecutionManager.EXPECT().ListConcreteExecutions(gomock.Any(), &persistence.ListConcreteExecutionsRequest{
+		ShardID:   1,
+		PageSize:  100,
+		PageToken: []byte("token"),
+	}).Return(&persistence.ListConcreteExecutionsResponse{}, nil)
+	s.timeSkipper.recover()
+	s.False(s.timeSkipper.tracker.Recovered())
+
+	nextFireTime := now.Add(time.Hour)
+	s.mockExecutionManager.EXPECT().GetHistoryTasks(gomock.Any(), &persistence.GetHistoryTasksRequest{
+		ShardID:             1,
+		TaskCategory:        tasks.CategoryTimer,
+		InclusiveMinTaskKey: tasks.NewKey(now, 0),
+		ExclusiveMaxTaskKey: tasks.NewKey(tasks.MaximumKey.FireTime, 0),
+		BatchSize:           100,
+	}).Return(&persistence.GetHistoryTasksResponse{
+		Tasks: []tasks.Task{
+			&tasks.DeleteHistoryEventTask{
+				WorkflowKey:         definition.NewWorkflowKey(tests.NamespaceID.String(), tests.WorkflowID, tests.RunID),
+				VisibilityTimestamp: now.Add(30 * time.Minute),
+			},
+			s.newUserTimerTask(tests.ParentNamespaceID.String(), now.Add(30*time.Minute)),
+			s.newUserTimerTask(tests.NamespaceID.String(), nextFireTime),
+		},
+	}, nil)
+	s.timeSkipper.recover()
+	s.True(s.timeSkipper.tracker.Recovered())
+
+	s.Equal(timeskipping.HostState{
+		ShardIDs:     []int32{1},
+		Idle:         false,
+		NextFireTime: nextFireTime,
+	}, s.timeSkipper.clock.Describe(tests.NamespaceID))
+
+	s.timeSkipper.clock.SetExecutionBusy(
+		1,
+		tests.GlobalNamespaceEntry,
+		definition.NewWorkflowKey(tests.NamespaceID.String(), "workflow-id-2", tests.RunID),
+		false,
+	)
+	s.True(s.timeSkipper.clock.Describe(tests.NamespaceID).Idle)
+}
+
+func (s *timeSkipperSuite) TestNotifyNewTasks() {
+	now := s.timeSource.Now()
+	s.timeSkipper.clock.SetSkipped(tests.GlobalNamespaceEntry, time.Hour)
+	s.timeSkipper.tracker.SetRecovered()
+
+	dueTask := s.newUserTimerTask(tests.NamespaceID.String(), now.Add(30*time.Minute))
+	s.timeSkipper.notifyNewTasks([]tasks.Task{
+		dueTask,
+		s.newUserTimerTask(tests.NamespaceID.String(), now.Add(2*time.Hour)),
+		s.newUserTimerTask(tests.ParentNamespaceID.String(), now.Add(30*time.Minute)),
+		// the timeout of a workflow task is not skipped
+		&tasks.WorkflowTaskTimeoutTask{
+			WorkflowKey:         definition.NewWorkflowKey(tests.NamespaceID.String(), tests.WorkflowID, tests.RunID),
+			VisibilityTimestamp: now.Add(10 * time.Second),
+		},
+	})
+	s.Equal([]tasks.Task{dueTask}, s.submitted)
+	// the fire times of the other tasks are recorded for the coordinator to auto-advance the virtual time
+	s.Equal(now.Add(2*time.Hour), s.timeSkipper.clock.Describe(tests.NamespaceID).NextFireTime)
+}
+
+func (s *timeSkipperSuite) newMutableState(namespaceID string, workflowID string, workflowTaskScheduledEventID int64) *persistencespb.WorkflowMutableState {
+	return &persistencespb.WorkflowMutableState{
+		ExecutionInfo: &persistencespb.WorkflowExecutionInfo{
+			NamespaceId:                  namespaceID,
+			WorkflowId:                   workflowID,
+			WorkflowTaskScheduledEventId: workflowTaskScheduledEventID,
+		},
+		ExecutionState: &persistencespb.WorkflowExecutionState{
+			RunId: tests.RunID,
+			State: enumsspb.WORKFLOW_EXECUTION_STATE_RUNNING,
+		},
+	}
+}
+
+func (s *timeSkipperSuite) newCompletedMutableState(namespaceID string, workflowID string) *persistencespb.WorkflowMutableState {
+	state := s.newMutableState(namespaceID, workflowID, 0)
+	state.ExecutionState.State = enumsspb.WORKFLOW_EXECUTION_STATE_COMPLETED
+	return state
+}
+
+func (s *timeSkipperSuite) newUserTimerTask(namespaceID string, fireTime time.Time) tasks.Task {
+	return &tasks.UserTimerTask{
+		WorkflowKey:         definition.NewWorkflowKey(namespaceID, tests.WorkflowID, tests.RunID),
+		VisibilityTimestamp: fireTime,
+		TaskID:              1,
+	}
+}
@@ -455,7 +455,7 @@ func getVersionHistoryAndEvents(
 	if versionHistory == nil {
 		return nil, nil, nil, nil
 	}
-	eventBatches, err := getEventsBlob(ctx, shardID, branchToken, firstEventID, nextEventID, executionManager)
+	eventBatches, err := getEventsBlob(ctx, shardID, workflowKey.NamespaceID, branchToken, firstEventID, nextEventID, executionManager)
 	if err != nil {
 		return nil, nil, nil, convertGetHistoryError(workflowKey, logger, err)
 	}
@@ -565,6 +565,7 @@ func getBranchToken(
 func getEventsBlob(
 	ctx context.Context,
 	shardID int32,
+	namespaceID string,
 	branchToken []byte,
 	firstEventID int64,
 	nextEventID int64,
@@ -573,6 +574,7 @@ func getEventsBlob(
 	var eventBatchBlobs []*commonpb.DataBlob
 	var pageToken []byte
 	req := &persistence.ReadHistoryBranchRequest{
+		NamespaceID:   namespaceID,
 		BranchToken:   branchToken,
 		MinEventID:    firstEventID,
 		MaxEventID:    nextEventID,
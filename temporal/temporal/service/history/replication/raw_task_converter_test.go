@@ -696,6 +696,7 @@ func (s *rawTaskConverterSuite) TestConvertHistoryReplicationTask_WithNewRun() {
 		PageSize:      1,
 		NextPageToken: nil,
 		ShardID:       shardID,
+		NamespaceID:   s.namespaceID,
 	}).Return(&persistence.ReadRawHistoryBranchResponse{
 		HistoryEventBlobs: []*commonpb.DataBlob{events},
 		NextPageToken:     nil,
@@ -743,6 +744,7 @@ func (s *rawTaskConverterSuite) TestConvertHistoryReplicationTask_WithNewRun() {
 		PageSize:      1,
 		NextPageToken: nil,
 		ShardID:       shardID,
+		NamespaceID:   s.namespaceID,
 	}).Return(&persistence.ReadRawHistoryBranchResponse{
 		HistoryEventBlobs: []*commonpb.DataBlob{newEvents},
 		NextPageToken:     nil,
@@ -860,6 +862,7 @@ func (s *rawTaskConverterSuite) TestConvertHistoryReplicationTask_WithoutNewRun(
 		PageSize:      1,
 		NextPageToken: nil,
 		ShardID:       shardID,
+		NamespaceID:   s.namespaceID,
 	}).Return(&persistence.ReadRawHistoryBranchResponse{
 		HistoryEventBlobs: []*commonpb.DataBlob{events},
 		NextPageToken:     nil,
@@ -1168,6 +1171,7 @@ func (s *rawTaskConverterSuite) TestConvertSyncVersionedTransitionTask_Backfill(
 		PageSize:      1,
 		NextPageToken: nil,
 		ShardID:       shardID,
+		NamespaceID:   s.namespaceID,
 	}).Return(&persistence.ReadRawHistoryBranchResponse{
 		HistoryEventBlobs: []*commonpb.DataBlob{events},
 		NextPageToken:     nil,
@@ -1216,6 +1220,7 @@ func (s *rawTaskConverterSuite) TestConvertSyncVersionedTransitionTask_Backfill(
 		PageSize:      1,
 		NextPageToken: nil,
 		ShardID:       shardID,
+		NamespaceID:   s.namespaceID,
 	}).Return(&persistence.ReadRawHistoryBranchResponse{
 		HistoryEventBlobs: []*commonpb.DataBlob{newEvents},
 		NextPageToken:     nil,
@@ -483,6 +483,7 @@ func (s *SyncStateRetrieverImpl) getEventsBlob(
 	}
 
 	rawHistoryResponse, err := s.shardContext.GetExecutionManager().ReadRawHistoryBranch(ctx, &persistence.ReadHistoryBranchRequest{
+		NamespaceID: workflowKey.NamespaceID,
 		BranchToken: versionHistory.BranchToken,
 		MinEventID:  startEventId,
 		MaxEventID:  endEventId,
@@ -334,6 +334,7 @@ func (s *syncWorkflowStateSuite) TestGetSyncStateRetrieverForNewWorkflow_WithEve
 		MinEventID:  1,
 		MaxEventID:  versionHistories.Histories[0].Items[0].GetEventId() + 1,
 		ShardID:     s.mockShard.GetShardID(),
+		NamespaceID: s.workflowKey.NamespaceID,
 		PageSize:    defaultPageSize,
 	}).Return(&persistence.ReadRawHistoryBranchResponse{HistoryEventBlobs: s.getEventBlobs(1, 10)}, nil)
 
@@ -646,6 +647,7 @@ func (s *syncWorkflowStateSuite) TestGetNewRunInfo() {
 		MinEventID:  1,
 		MaxEventID:  2,
 		ShardID:     s.mockShard.GetShardID(),
+		NamespaceID: s.workflowKey.NamespaceID,
 		PageSize:    defaultPageSize,
 	}).Return(&persistence.ReadRawHistoryBranchResponse{
 		HistoryEventBlobs: []*commonpb.DataBlob{
@@ -795,6 +797,7 @@ func (s *syncWorkflowStateSuite) TestGetSyncStateEvents() {
 		MinEventID:  19,
 		MaxEventID:  31,
 		ShardID:     s.mockShard.GetShardID(),
+		NamespaceID: s.workflowKey.NamespaceID,
 		PageSize:    defaultPageSize,
 	}).Return(&persistence.ReadRawHistoryBranchResponse{HistoryEventBlobs: s.getEventBlobs(19, 31)}, nil)
 
@@ -808,6 +811,7 @@ func (s *syncWorkflowStateSuite) TestGetSyncStateEvents() {
 		MinEventID:  21,
 		MaxEventID:  31,
 		ShardID:     s.mockShard.GetShardID(),
+		NamespaceID: s.workflowKey.NamespaceID,
 		PageSize:    defaultPageSize,
 	}).Return(&persistence.ReadRawHistoryBranchResponse{HistoryEventBlobs: s.getEventBlobs(21, 31)}, nil)
 
@@ -837,6 +841,7 @@ func (s *syncWorkflowStateSuite) TestGetEventsBlob_NewRun() {
 		MinEventID:  common.FirstEventID,
 		MaxEventID:  common.FirstEventID + 1,
 		ShardID:     s.mockShard.GetShardID(),
+		NamespaceID: s.workflowKey.NamespaceID,
 		PageSize:    defaultPageSize,
 	}).Return(&persistence.ReadRawHistoryBranchResponse{HistoryEventBlobs: s.getEventBlobs(1, 4)}, nil)
 	events, err := s.syncStateRetriever.getEventsBlob(context.Background(), s.workflowKey, versionHistory, common.FirstEventID, common.FirstEventID+1, true)
@@ -20,6 +20,7 @@ import (
 	"go.temporal.io/server/service/history/hsm"
 	historyi "go.temporal.io/server/service/history/interfaces"
 	"go.temporal.io/server/service/history/tasks"
+	"go.temporal.io/server/service/history/timeskipping"
 	"go.uber.org/fx"
 )
 
@@ -59,6 +60,7 @@ type (
 
 		StateMachineRegistry *hsm.Registry
 		ChasmRegistry        *chasm.Registry
+		TimeSkippingClock    *timeskipping.Clock
 	}
 
 	contextFactoryImpl struct {
@@ -101,6 +103,7 @@ func (c *contextFactoryImpl) CreateContext(
 		c.EventsCache,
 		c.StateMachineRegistry,
 		c.ChasmRegistry,
+		c.TimeSkippingClock,
 	)
 	if err != nil {
 		return nil, err
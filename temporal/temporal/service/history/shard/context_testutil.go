@@ -23,6 +23,7 @@ import (
 	"go.temporal.io/server/service/history/hsm"
 	historyi "go.temporal.io/server/service/history/interfaces"
 	"go.temporal.io/server/service/history/tasks"
+	"go.temporal.io/server/service/history/timeskipping"
 	"go.uber.org/mock/gomock"
 )
 
@@ -45,6 +46,7 @@ func NewTestContextWithTimeSource(
 	result := NewTestContext(ctrl, shardInfo, config)
 	result.timeSource = timeSource
 	result.taskKeyManager.generator.timeSource = timeSource
+	result.timeSkippingClock = timeskipping.NewClock(config.TimeSkippingEnabled, timeSource, result.namespaceRegistry)
 	result.Resource.TimeSource = timeSource
 	return result
 }
@@ -150,6 +152,7 @@ func newTestContext(t *resourcetest.Test, eventsCache events.Cache, config Conte
 		namespaceRegistry:       registry,
 		stateMachineRegistry:    hsm.NewRegistry(),
 		chasmRegistry:           chasm.NewRegistry(t.GetLogger()),
+		timeSkippingClock:       timeskipping.NewClock(config.Config.TimeSkippingEnabled, t.TimeSource, registry),
 		persistenceShardManager: t.GetShardManager(),
 		clientBean:              t.GetClientBean(),
 		saProvider:              t.GetSearchAttributesProvider(),
@@ -8,15 +8,17 @@ import (
 )
 
 var _ Task = (*ArchiveExecutionTask)(nil)
+var _ HasArchetypeID = (*ArchiveExecutionTask)(nil)
 
 type (
-	// ArchiveExecutionTask is the task which archives both the history and visibility of a workflow execution and then
-	// produces a retention timer task to delete the data.
+	// ArchiveExecutionTask is the task which archives both the history and visibility of a workflow execution, or the
+	// component tree and visibility of a CHASM execution, and then produces a retention timer task to delete the data.
 	ArchiveExecutionTask struct {
 		definition.WorkflowKey
 		VisibilityTimestamp time.Time
 		TaskID              int64
 		Version             int64
+		ArchetypeID         uint32
 	}
 )
 
@@ -51,3 +53,7 @@ func (a *ArchiveExecutionTask) SetTaskID(id int64) {
 func (a *ArchiveExecutionTask) SetVisibilityTime(timestamp time.Time) {
 	a.VisibilityTimestamp = timestamp
 }
+
+func (a *ArchiveExecutionTask) GetArchetypeID() uint32 {
+	return a.ArchetypeID
+}
@@ -200,6 +200,7 @@ func (f *timerQueueFactory) CreateQueue(
 			MaxReaderCount:                      f.Config.TimerQueueMaxReaderCount,
 			MoveGroupTaskCountBase:              f.Config.QueueMoveGroupTaskCountBase,
 			MoveGroupTaskCountMultiplier:        f.Config.QueueMoveGroupTaskCountMultiplier,
+			TimeSkippingAutoAdvanceInterval:     f.Config.TimeSkippingAutoAdvanceInterval,
 		},
 		f.HostReaderRateLimiter,
 		logger,
@@ -159,6 +159,12 @@ func (t *timerQueueTaskExecutorBase) deleteHistoryBranch(
 	return nil
 }
 
+// virtualNow returns the current time on the virtual clock of the namespace of the task, which is ahead of the real
+// time if the namespace has time skipping enabled.
+func (t *timerQueueTaskExecutorBase) virtualNow(task tasks.Task) time.Time {
+	return t.Now().Add(t.shardContext.TimeSkippingClock().Skipped(namespace.ID(task.GetNamespaceID())))
+}
+
 func (t *timerQueueTaskExecutorBase) isValidExpirationTime(
 	mutableState historyi.MutableState,
 	task tasks.Task,
@@ -0,0 +1,287 @@
+// Package timeskipping implements the virtual clock of the namespaces with time skipping enabled, which lets test
+// suites run long-running workflows against a real server without waiting for their timers.
+package timeskipping
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"go.temporal.io/server/common/clock"
+	"go.temporal.io/server/common/definition"
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/namespace"
+)
+
+type (
+	// Clock keeps the virtual time of the namespaces with time skipping enabled on a history host, which is the real
+	// time plus the duration the namespace skipped. The timer queue executes the timer tasks of a namespace once they
+	// are due on its virtual time.
+	//
+	// The skipped duration is persisted in the namespace metadata by the Coordinator, which then sends it to every
+	// history host, so that the hosts don't have to wait for the refresh of their namespace registry.
+	//
+	// Clock also holds the ShardTracker of each shard of the host, which tracks the executions of these namespaces
+	// which have pending work and their timers.
+	Clock struct {
+		enabled    dynamicconfig.BoolPropertyFnWithNamespaceFilter
+		timeSource clock.TimeSource
+		registry   namespace.Registry
+
+		sync.Mutex
+		namespaces       map[namespace.ID]*namespaceClock
+		shards           map[int32]*ShardTracker
+		subscribers      map[int64]chan struct{}
+		nextSubscriberID int64
+	}
+
+	namespaceClock struct {
+		name namespace.Name
+		// skipped is the skipped duration received by the host, which may be ahead of the one of the namespace
+		// registry.
+		skipped time.Duration
+	}
+
+	// HostState is the time skipping state of a namespace on the shards of a history host.
+	HostState struct {
+		// ShardIDs are the shards of which the ShardTracker is recovered.
+		ShardIDs []int32
+		// Idle is whether none of the executions of the namespace on these shards has pending work.
+		Idle bool
+		// NextFireTime is the fire time of the next timer of the namespace on these shards, it is zero if there is
+		// none.
+		NextFireTime time.Time
+	}
+)
+
+// NewClock creates a new Clock.
+func NewClock(
+	enabled dynamicconfig.BoolPropertyFnWithNamespaceFilter,
+	timeSource clock.TimeSource,
+	registry namespace.Registry,
+) *Clock {
+	return &Clock{
+		enabled:     enabled,
+		timeSource:  timeSource,
+		registry:    registry,
+		namespaces:  make(map[namespace.ID]*namespaceClock),
+		shards:      make(map[int32]*ShardTracker),
+		subscribers: make(map[int64]chan struct{}),
+	}
+}
+
+// Start registers the clock to the namespace registry, so that the coordinator knows the namespaces of which no
+// execution is loaded by the host.
+func (c *Clock) Start() {
+	c.registry.RegisterStateChangeCallback(c, func(ns *namespace.Namespace, deletedFromDb bool) {
+		c.Lock()
+		defer c.Unlock()
+
+		if deletedFromDb {
+			delete(c.namespaces, ns.ID())
+			return
+		}
+		c.getOrCreateLocked(ns).name = ns.Name()
+	})
+}
+
+// Stop unregisters the clock from the namespace registry.
+func (c *Clock) Stop() {
+	c.registry.UnregisterStateChangeCallback(c)
+}
+
+// Enabled returns whether time skipping is enabled for the namespace.
+func (c *Clock) Enabled(namespaceName namespace.Name) bool {
+	return c.enabled(namespaceName.String())
+}
+
+// EnabledByID returns whether time skipping is enabled for the namespace, if the namespace is known by the clock.
+func (c *Clock) EnabledByID(namespaceID namespace.ID) bool {
+	c.Lock()
+	defer c.Unlock()
+
+	ns, ok := c.namespaces[namespaceID]
+	return ok && c.enabled(ns.name.String())
+}
+
+// Now returns the virtual time of the namespace, which is the real time if the namespace never skipped time.
+func (c *Clock) Now(namespaceID namespace.ID) time.Time {
+	return c.timeSource.Now().Add(c.Skipped(namespaceID))
+}
+
+// Skipped returns the duration the virtual time of the namespace is ahead of the real time.
+func (c *Clock) Skipped(namespaceID namespace.ID) time.Duration {
+	c.Lock()
+	ns, ok := c.namespaces[namespaceID]
+	if !ok || !c.enabled(ns.name.String()) {
+		c.Unlock()
+		return 0
+	}
+	skipped := ns.skipped
+	c.Unlock()
+
+	if namespaceEntry, err := c.registry.GetNamespaceByID(namespaceID); err == nil {
+		skipped = max(skipped, namespaceEntry.TimeSkipped())
+	}
+	return skipped
+}
+
+// Namespaces returns the IDs of the namespaces with time skipping enabled known by the clock.
+func (c *Clock) Namespaces() []namespace.ID {
+	c.Lock()
+	defer c.Unlock()
+
+	namespaceIDs := make([]namespace.ID, 0, len(c.namespaces))
+	for namespaceID, ns := range c.namespaces {
+		if c.enabled(ns.name.String()) {
+			namespaceIDs = append(namespaceIDs, namespaceID)
+		}
+	}
+	return namespaceIDs
+}
+
+// SetSkipped moves the virtual time of the namespace forward to the real time plus the skipped duration, ahead of the
+// refresh of the namespace registry. It is a no-op if the virtual time is already ahead, or if time skipping is not
+// enabled for the namespace.
+func (c *Clock) SetSkipped(
+	namespaceEntry *namespace.Namespace,
+	skipped time.Duration,
+) {
+	if !c.Enabled(namespaceEntry.Name()) {
+		return
+	}
+
+	c.Lock()
+	ns := c.getOrCreateLocked(namespaceEntry)
+	if skipped <= ns.skipped {
+		c.Unlock()
+		return
+	}
+	ns.skipped = skipped
+	subscribers := make([]chan struct{}, 0, len(c.subscribers))
+	for _, ch := range c.subscribers {
+		subscribers = append(subscribers, ch)
+	}
+	c.Unlock()
+
+	for _, ch := range subscribers {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// SetExecutionBusy records whether the execution has pending work in the tracker of its shard. It is a no-op if time
+// skipping is not enabled for the namespace of the execution.
+func (c *Clock) SetExecutionBusy(
+	shardID int32,
+	namespaceEntry *namespace.Namespace,
+	workflowKey definition.WorkflowKey,
+	busy bool,
+) {
+	if !c.Enabled(namespaceEntry.Name()) {
+		return
+	}
+
+	c.Lock()
+	c.getOrCreateLocked(namespaceEntry)
+	tracker, ok := c.shards[shardID]
+	c.Unlock()
+
+	if ok {
+		tracker.setExecutionBusy(workflowKey, busy)
+	}
+}
+
+// RegisterShard creates the tracker of a shard loaded by the host. hasPendingTasks returns whether the tasks the
+// shard submitted for a namespace after its virtual time advanced are not executed yet.
+func (c *Clock) RegisterShard(
+	shardID int32,
+	hasPendingTasks func(namespace.ID) bool,
+) *ShardTracker {
+	tracker := newShardTracker(shardID, hasPendingTasks)
+
+	c.Lock()
+	defer c.Unlock()
+
+	c.shards[shardID] = tracker
+	return tracker
+}
+
+// UnregisterShard removes the tracker of a shard unloaded by the host.
+func (c *Clock) UnregisterShard(tracker *ShardTracker) {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.shards[tracker.shardID] == tracker {
+		delete(c.shards, tracker.shardID)
+	}
+}
+
+// HasShard returns whether the shard is loaded by the host.
+func (c *Clock) HasShard(shardID int32) bool {
+	c.Lock()
+	defer c.Unlock()
+
+	_, ok := c.shards[shardID]
+	return ok
+}
+
+// Describe returns the time skipping state of the namespace on the shards of the host.
+func (c *Clock) Describe(namespaceID namespace.ID) HostState {
+	c.Lock()
+	trackers := make([]*ShardTracker, 0, len(c.shards))
+	for _, tracker := range c.shards {
+		trackers = append(trackers, tracker)
+	}
+	c.Unlock()
+
+	virtualNow := c.Now(namespaceID)
+	state := HostState{Idle: true}
+	for _, tracker := range trackers {
+		recovered, idle, nextFireTime := tracker.state(namespaceID, virtualNow)
+		if !recovered {
+			continue
+		}
+		state.ShardIDs = append(state.ShardIDs, tracker.shardID)
+		if !idle || tracker.hasPendingTasks(namespaceID) {
+			state.Idle = false
+		}
+		if !nextFireTime.IsZero() && (state.NextFireTime.IsZero() || nextFireTime.Before(state.NextFireTime)) {
+			state.NextFireTime = nextFireTime
+		}
+	}
+	slices.Sort(state.ShardIDs)
+	return state
+}
+
+// Subscribe returns a channel which is signaled when the virtual time of a namespace advances, and a function to
+// cancel the subscription.
+func (c *Clock) Subscribe() (<-chan struct{}, func()) {
+	c.Lock()
+	defer c.Unlock()
+
+	id := c.nextSubscriberID
+	c.nextSubscriberID++
+	ch := make(chan struct{}, 1)
+	c.subscribers[id] = ch
+
+	return ch, func() {
+		c.Lock()
+		defer c.Unlock()
+
+		delete(c.subscribers, id)
+	}
+}
+
+func (c *Clock) getOrCreateLocked(namespaceEntry *namespace.Namespace) *namespaceClock {
+	ns, ok := c.namespaces[namespaceEntry.ID()]
+	if !ok {
+		ns = &namespaceClock{
+			name: namespaceEntry.Name(),
+		}
+		c.namespaces[namespaceEntry.ID()] = ns
+	}
+	return ns
+}
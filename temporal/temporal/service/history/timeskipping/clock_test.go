@@ -0,0 +1,137 @@
+package timeskipping
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common/clock"
+	"go.temporal.io/server/common/definition"
+	"go.temporal.io/server/common/namespace"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func newTestClock(timeSource clock.TimeSource, registry namespace.Registry) *Clock {
+	return NewClock(func(namespaceName string) bool {
+		return namespaceName == "time-skipping"
+	}, timeSource, registry)
+}
+
+func newTestNamespace(name string, skipped time.Duration) *namespace.Namespace {
+	return namespace.NewLocalNamespaceForTest(
+		&persistencespb.NamespaceInfo{Id: name + "-id", Name: name},
+		&persistencespb.NamespaceConfig{TimeSkipped: durationpb.New(skipped)},
+		"",
+	)
+}
+
+func TestClock_SetSkipped(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	registry := namespace.NewMockRegistry(ctrl)
+	timeSource := clock.NewEventTimeSource().Update(time.Unix(1000, 0))
+	c := newTestClock(timeSource, registry)
+	ns := newTestNamespace("time-skipping", 0)
+	registry.EXPECT().GetNamespaceByID(ns.ID()).Return(ns, nil).AnyTimes()
+	updates, cancel := c.Subscribe()
+	defer cancel()
+
+	require.Equal(t, timeSource.Now(), c.Now(ns.ID()))
+
+	c.SetSkipped(ns, time.Hour)
+	require.Equal(t, timeSource.Now().Add(time.Hour), c.Now(ns.ID()))
+	require.Equal(t, time.Hour, c.Skipped(ns.ID()))
+	require.Len(t, updates, 1)
+	<-updates
+
+	timeSource.Advance(time.Minute)
+	require.Equal(t, timeSource.Now().Add(time.Hour), c.Now(ns.ID()))
+
+	// the virtual time never moves backward
+	c.SetSkipped(ns, time.Minute)
+	require.Equal(t, time.Hour, c.Skipped(ns.ID()))
+	require.Empty(t, updates)
+	require.Equal(t, []namespace.ID{ns.ID()}, c.Namespaces())
+}
+
+func TestClock_SkippedFromRegistry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	registry := namespace.NewMockRegistry(ctrl)
+	c := newTestClock(clock.NewRealTimeSource(), registry)
+	ns := newTestNamespace("time-skipping", 2*time.Hour)
+	registry.EXPECT().GetNamespaceByID(ns.ID()).Return(ns, nil).AnyTimes()
+
+	// the skipped duration persisted in the namespace metadata is restored on restart
+	c.SetSkipped(ns, time.Hour)
+	require.Equal(t, 2*time.Hour, c.Skipped(ns.ID()))
+
+	c.SetSkipped(ns, 3*time.Hour)
+	require.Equal(t, 3*time.Hour, c.Skipped(ns.ID()))
+}
+
+func TestClock_NotEnabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	registry := namespace.NewMockRegistry(ctrl)
+	timeSource := clock.NewEventTimeSource().Update(time.Unix(1000, 0))
+	c := newTestClock(timeSource, registry)
+	ns := newTestNamespace("other", time.Hour)
+	tracker := c.RegisterShard(1, func(namespace.ID) bool { return false })
+	tracker.SetRecovered()
+
+	c.SetSkipped(ns, time.Hour)
+	require.Equal(t, timeSource.Now(), c.Now(ns.ID()))
+
+	c.SetExecutionBusy(1, ns, definition.NewWorkflowKey(ns.ID().String(), "workflow-id", "run-id"), true)
+	require.True(t, c.Describe(ns.ID()).Idle)
+	require.Empty(t, c.Namespaces())
+}
+
+func TestClock_Describe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	registry := namespace.NewMockRegistry(ctrl)
+	timeSource := clock.NewEventTimeSource().Update(time.Unix(1000, 0))
+	c := newTestClock(timeSource, registry)
+	ns := newTestNamespace("time-skipping", 0)
+	registry.EXPECT().GetNamespaceByID(ns.ID()).Return(ns, nil).AnyTimes()
+	key := definition.NewWorkflowKey(ns.ID().String(), "workflow-id", "run-id")
+
+	pendingTasks := false
+	tracker1 := c.RegisterShard(1, func(namespace.ID) bool { return pendingTasks })
+	tracker2 := c.RegisterShard(2, func(namespace.ID) bool { return false })
+	c.RegisterShard(3, func(namespace.ID) bool { return false })
+	tracker1.SetRecovered()
+	tracker2.SetRecovered()
+	require.True(t, c.HasShard(3))
+
+	// the shards of which the tracker is not recovered yet are not reported
+	require.Equal(t, HostState{ShardIDs: []int32{1, 2}, Idle: true}, c.Describe(ns.ID()))
+
+	tracker1.AddTimer(ns.ID(), timeSource.Now().Add(2*time.Hour))
+	tracker2.AddTimer(ns.ID(), timeSource.Now().Add(time.Hour))
+	c.SetExecutionBusy(2, ns, key, true)
+	require.Equal(t, HostState{
+		ShardIDs:     []int32{1, 2},
+		Idle:         false,
+		NextFireTime: timeSource.Now().Add(time.Hour),
+	}, c.Describe(ns.ID()))
+
+	c.SetExecutionBusy(2, ns, key, false)
+	pendingTasks = true
+	require.False(t, c.Describe(ns.ID()).Idle)
+
+	pendingTasks = false
+	c.SetSkipped(ns, time.Hour)
+	require.Equal(t, HostState{
+		ShardIDs:     []int32{1, 2},
+		Idle:         true,
+		NextFireTime: timeSource.Now().Add(2 * time.Hour),
+	}, c.Describe(ns.ID()))
+
+	// the tracker of a shard loaded again is not removed with the previous one
+	c.RegisterShard(1, func(namespace.ID) bool { return false })
+	c.UnregisterShard(tracker1)
+	require.True(t, c.HasShard(1))
+	c.UnregisterShard(tracker2)
+	require.False(t, c.HasShard(2))
+}
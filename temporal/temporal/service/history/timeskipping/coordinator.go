@@ -0,0 +1,272 @@
+package timeskipping
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/server/api/historyservice/v1"
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common"
+	"go.temporal.io/server/common/clock"
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/log/tag"
+	"go.temporal.io/server/common/membership"
+	"go.temporal.io/server/common/namespace"
+	"go.temporal.io/server/common/persistence"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+type (
+	// Coordinator advances the virtual time of the namespaces with time skipping enabled. The skipped duration of a
+	// namespace is persisted in its metadata, and then sent to every history host with one UpdateNamespaceTime call per
+	// host. A host which misses the call catches up once its namespace registry is refreshed.
+	//
+	// The virtual time of a namespace is auto-advanced to its next timer by the host of its coordinator shard, once
+	// every history host reports that the executions of the namespace on its shards are idle.
+	Coordinator struct {
+		status          int32
+		clock           *Clock
+		metadataManager persistence.MetadataManager
+		historyClient   historyservice.HistoryServiceClient
+		historyResolver membership.ServiceResolver
+		numberOfShards  int32
+		interval        dynamicconfig.DurationPropertyFn
+		timeSource      clock.TimeSource
+		logger          log.Logger
+
+		shutdownCh chan struct{}
+		shutdownWG sync.WaitGroup
+	}
+)
+
+const (
+	autoAdvanceTimeout = 10 * time.Second
+)
+
+// CoordinatorShardID returns the shard of which the host auto-advances the virtual time of the namespace.
+func CoordinatorShardID(namespaceID namespace.ID, numberOfShards int32) int32 {
+	return common.WorkflowIDToHistoryShard(namespaceID.String(), namespaceID.String(), numberOfShards)
+}
+
+// NewCoordinator creates a new Coordinator.
+func NewCoordinator(
+	clock *Clock,
+	metadataManager persistence.MetadataManager,
+	historyClient historyservice.HistoryServiceClient,
+	historyResolver membership.ServiceResolver,
+	numberOfShards int32,
+	interval dynamicconfig.DurationPropertyFn,
+	timeSource clock.TimeSource,
+	logger log.Logger,
+) *Coordinator {
+	return &Coordinator{
+		status:          common.DaemonStatusInitialized,
+		clock:           clock,
+		metadataManager: metadataManager,
+		historyClient:   historyClient,
+		historyResolver: historyResolver,
+		numberOfShards:  numberOfShards,
+		interval:        interval,
+		timeSource:      timeSource,
+		logger:          logger,
+		shutdownCh:      make(chan struct{}),
+	}
+}
+
+// Start starts the auto-advance loop of the coordinator.
+func (c *Coordinator) Start() {
+	if !atomic.CompareAndSwapInt32(&c.status, common.DaemonStatusInitialized, common.DaemonStatusStarted) {
+		return
+	}
+
+	c.shutdownWG.Add(1)
+	go c.autoAdvanceLoop()
+}
+
+// Stop stops the auto-advance loop of the coordinator.
+func (c *Coordinator) Stop() {
+	if !atomic.CompareAndSwapInt32(&c.status, common.DaemonStatusStarted, common.DaemonStatusStopped) {
+		return
+	}
+
+	close(c.shutdownCh)
+	c.shutdownWG.Wait()
+}
+
+// Advance moves the virtual time of the namespace forward by the duration, and returns the new virtual time.
+func (c *Coordinator) Advance(
+	ctx context.Context,
+	namespaceEntry *namespace.Namespace,
+	duration time.Duration,
+) (time.Time, error) {
+	if !c.clock.Enabled(namespaceEntry.Name()) {
+		return time.Time{}, serviceerror.NewFailedPreconditionf(
+			"time skipping is not enabled for namespace %v",
+			namespaceEntry.Name(),
+		)
+	}
+	if duration < 0 {
+		return time.Time{}, serviceerror.NewInvalidArgumentf("cannot move the virtual time backward by %v", duration)
+	}
+
+	skipped, err := c.advance(ctx, namespaceEntry.ID(), func(skipped time.Duration) time.Duration {
+		return skipped + duration
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+	return c.timeSource.Now().Add(skipped), nil
+}
+
+func (c *Coordinator) autoAdvanceLoop() {
+	defer c.shutdownWG.Done()
+
+	timer := time.NewTimer(c.interval())
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-c.shutdownCh:
+			return
+		case <-timer.C:
+			for _, namespaceID := range c.clock.Namespaces() {
+				if !c.clock.HasShard(CoordinatorShardID(namespaceID, c.numberOfShards)) {
+					continue
+				}
+				ctx, cancel := context.WithTimeout(context.Background(), autoAdvanceTimeout)
+				if err := c.autoAdvance(ctx, namespaceID); err != nil {
+					c.logger.Warn("Failed to auto-advance virtual time", tag.WorkflowNamespaceID(namespaceID.String()), tag.Error(err))
+				}
+				cancel()
+			}
+			timer.Reset(c.interval())
+		}
+	}
+}
+
+// autoAdvance advances the virtual time of the namespace to the fire time of its next timer, if the executions of the
+// namespace are idle on every shard.
+func (c *Coordinator) autoAdvance(
+	ctx context.Context,
+	namespaceID namespace.ID,
+) error {
+	var lock sync.Mutex
+	shardIDs := make(map[int32]struct{})
+	idle := true
+	var nextFireTime time.Time
+	if err := c.forEachHost(ctx, func(ctx context.Context, address string) error {
+		resp, err := c.historyClient.DescribeNamespaceTime(ctx, &historyservice.DescribeNamespaceTimeRequest{
+			HostAddress: address,
+			NamespaceId: namespaceID.String(),
+		})
+		if err != nil {
+			return err
+		}
+
+		lock.Lock()
+		defer lock.Unlock()
+
+		for _, shardID := range resp.GetShardIds() {
+			shardIDs[shardID] = struct{}{}
+		}
+		idle = idle && resp.GetIdle()
+		if fireTime := resp.GetNextFireTime(); fireTime != nil && (nextFireTime.IsZero() || fireTime.AsTime().Before(nextFireTime)) {
+			nextFireTime = fireTime.AsTime()
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// the executions of the shards which are being loaded may have pending work
+	if !idle || len(shardIDs) < int(c.numberOfShards) || nextFireTime.IsZero() {
+		return nil
+	}
+
+	// Persistence layer may lose precision when persisting the task, so the virtual time is advanced past the fire
+	// time of the task for it to be due.
+	virtualTime := nextFireTime.Add(common.ScheduledTaskMinPrecision)
+	_, err := c.advance(ctx, namespaceID, func(skipped time.Duration) time.Duration {
+		return max(skipped, virtualTime.Sub(c.timeSource.Now()))
+	})
+	return err
+}
+
+// advance persists the skipped duration of the namespace returned by newSkippedFn in the namespace metadata, sends it
+// to every history host, and returns it.
+func (c *Coordinator) advance(
+	ctx context.Context,
+	namespaceID namespace.ID,
+	newSkippedFn func(skipped time.Duration) time.Duration,
+) (time.Duration, error) {
+	// must get the metadata (notificationVersion) first, it is the lock of the namespace update
+	metadata, err := c.metadataManager.GetMetadata(ctx)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := c.metadataManager.GetNamespace(ctx, &persistence.GetNamespaceRequest{ID: namespaceID.String()})
+	if err != nil {
+		return 0, err
+	}
+
+	ns := resp.Namespace
+	skipped := ns.GetConfig().GetTimeSkipped().AsDuration()
+	if newSkipped := newSkippedFn(skipped); newSkipped > skipped {
+		skipped = newSkipped
+		ns.Config.TimeSkipped = durationpb.New(skipped)
+		if err := c.metadataManager.UpdateNamespace(ctx, &persistence.UpdateNamespaceRequest{
+			Namespace: &persistencespb.NamespaceDetail{
+				Info:                        ns.Info,
+				Config:                      ns.Config,
+				ReplicationConfig:           ns.ReplicationConfig,
+				ConfigVersion:               ns.ConfigVersion + 1,
+				FailoverVersion:             ns.FailoverVersion,
+				FailoverNotificationVersion: ns.FailoverNotificationVersion,
+			},
+			IsGlobalNamespace:   resp.IsGlobalNamespace,
+			NotificationVersion: metadata.NotificationVersion,
+		}); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := c.forEachHost(ctx, func(ctx context.Context, address string) error {
+		_, err := c.historyClient.UpdateNamespaceTime(ctx, &historyservice.UpdateNamespaceTimeRequest{
+			HostAddress: address,
+			NamespaceId: namespaceID.String(),
+			Skipped:     durationpb.New(skipped),
+		})
+		return err
+	}); err != nil {
+		// the hosts catch up with the persisted skipped duration once their namespace registry is refreshed
+		c.logger.Warn("Failed to update virtual time of some history hosts", tag.WorkflowNamespaceID(namespaceID.String()), tag.Error(err))
+	}
+	return skipped, nil
+}
+
+// forEachHost calls fn for every history host concurrently.
+func (c *Coordinator) forEachHost(
+	ctx context.Context,
+	fn func(ctx context.Context, address string) error,
+) error {
+	members := c.historyResolver.Members()
+	errs := make([]error, len(members))
+	var wg sync.WaitGroup
+	for i, member := range members {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := fn(ctx, member.GetAddress()); err != nil {
+				errs[i] = fmt.Errorf("history host %s: %w", member.GetAddress(), err)
+			}
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
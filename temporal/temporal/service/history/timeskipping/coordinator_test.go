@@ -0,0 +1,177 @@
+package timeskipping
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/server/api/historyservice/v1"
+	"go.temporal.io/server/api/historyservicemock/v1"
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common"
+	"go.temporal.io/server/common/clock"
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/membership"
+	"go.temporal.io/server/common/namespace"
+	"go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/testing/protomock"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type testCoordinator struct {
+	*Coordinator
+	t               *testing.T
+	metadataManager *persistence.MockMetadataManager
+	historyClient   *historyservicemock.MockHistoryServiceClient
+	timeSource      *clock.EventTimeSource
+}
+
+func newTestCoordinator(t *testing.T) *testCoordinator {
+	ctrl := gomock.NewController(t)
+	registry := namespace.NewMockRegistry(ctrl)
+	registry.EXPECT().GetNamespaceByID(gomock.Any()).Return(nil, serviceerror.NewNamespaceNotFound("")).AnyTimes()
+	resolver := membership.NewMockServiceResolver(ctrl)
+	resolver.EXPECT().Members().Return([]membership.HostInfo{
+		membership.NewHostInfoFromAddress("host-1"),
+		membership.NewHostInfoFromAddress("host-2"),
+	}).AnyTimes()
+	timeSource := clock.NewEventTimeSource().Update(time.Unix(1000, 0))
+	metadataManager := persistence.NewMockMetadataManager(ctrl)
+	historyClient := historyservicemock.NewMockHistoryServiceClient(ctrl)
+	return &testCoordinator{
+		Coordinator: NewCoordinator(
+			newTestClock(timeSource, registry),
+			metadataManager,
+			historyClient,
+			resolver,
+			4,
+			dynamicconfig.GetDurationPropertyFn(time.Second),
+			timeSource,
+			log.NewTestLogger(),
+		),
+		t:               t,
+		metadataManager: metadataManager,
+		historyClient:   historyClient,
+		timeSource:      timeSource,
+	}
+}
+
+func (c *testCoordinator) expectUpdateNamespace(ns *namespace.Namespace, skipped time.Duration, newSkipped time.Duration) {
+	c.metadataManager.EXPECT().GetMetadata(gomock.Any()).Return(&persistence.GetMetadataResponse{NotificationVersion: 5}, nil)
+	c.metadataManager.EXPECT().GetNamespace(gomock.Any(), &persistence.GetNamespaceRequest{ID: ns.ID().String()}).
+		Return(&persistence.GetNamespaceResponse{
+			Namespace: &persistencespb.NamespaceDetail{
+				Info:          &persistencespb.NamespaceInfo{Id: ns.ID().String(), Name: ns.Name().String()},
+				Config:        &persistencespb.NamespaceConfig{TimeSkipped: durationpb.New(skipped)},
+				ConfigVersion: 2,
+			},
+		}, nil)
+	if newSkipped > skipped {
+		c.metadataManager.EXPECT().UpdateNamespace(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, request *persistence.UpdateNamespaceRequest) error {
+				require.Equal(c.t, int64(5), request.NotificationVersion)
+				require.Equal(c.t, int64(3), request.Namespace.ConfigVersion)
+				require.Equal(c.t, newSkipped, request.Namespace.Config.TimeSkipped.AsDuration())
+				return nil
+			})
+	}
+}
+
+func (c *testCoordinator) expectUpdateNamespaceTime(ns *namespace.Namespace, skipped time.Duration, err error) {
+	for _, address := range []string{"host-1", "host-2"} {
+		c.historyClient.EXPECT().UpdateNamespaceTime(gomock.Any(), protomock.Eq(&historyservice.UpdateNamespaceTimeRequest{
+			HostAddress: address,
+			NamespaceId: ns.ID().String(),
+			Skipped:     durationpb.New(skipped),
+		})).Return(&historyservice.UpdateNamespaceTimeResponse{}, err)
+	}
+}
+
+func (c *testCoordinator) expectDescribeNamespaceTime(ns *namespace.Namespace, resps map[string]*historyservice.DescribeNamespaceTimeResponse) {
+	for address, resp := range resps {
+		c.historyClient.EXPECT().DescribeNamespaceTime(gomock.Any(), protomock.Eq(&historyservice.DescribeNamespaceTimeRequest{
+			HostAddress: address,
+			NamespaceId: ns.ID().String(),
+		})).Return(resp, nil)
+	}
+}
+
+func TestCoordinatorShardID(t *testing.T) {
+	shardID := CoordinatorShardID("namespace-id", 4)
+	require.Equal(t, shardID, CoordinatorShardID("namespace-id", 4))
+	require.True(t, shardID >= 1 && shardID <= 4)
+}
+
+func TestCoordinator_Advance(t *testing.T) {
+	c := newTestCoordinator(t)
+	ns := newTestNamespace("time-skipping", 0)
+
+	c.expectUpdateNamespace(ns, time.Hour, 2*time.Hour)
+	c.expectUpdateNamespaceTime(ns, 2*time.Hour, nil)
+	virtualTime, err := c.Advance(context.Background(), ns, time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, c.timeSource.Now().Add(2*time.Hour), virtualTime)
+
+	// the hosts which fail to update catch up once their namespace registry is refreshed
+	c.expectUpdateNamespace(ns, 2*time.Hour, 2*time.Hour)
+	c.expectUpdateNamespaceTime(ns, 2*time.Hour, errors.New("unavailable"))
+	virtualTime, err = c.Advance(context.Background(), ns, 0)
+	require.NoError(t, err)
+	require.Equal(t, c.timeSource.Now().Add(2*time.Hour), virtualTime)
+
+	_, err = c.Advance(context.Background(), ns, -time.Minute)
+	require.IsType(t, &serviceerror.InvalidArgument{}, err)
+}
+
+func TestCoordinator_Advance_NotEnabled(t *testing.T) {
+	c := newTestCoordinator(t)
+
+	_, err := c.Advance(context.Background(), newTestNamespace("other", 0), time.Hour)
+	require.IsType(t, &serviceerror.FailedPrecondition{}, err)
+}
+
+func TestCoordinator_AutoAdvance(t *testing.T) {
+	c := newTestCoordinator(t)
+	ns := newTestNamespace("time-skipping", 0)
+	nextFireTime := c.timeSource.Now().Add(time.Hour)
+
+	c.expectDescribeNamespaceTime(ns, map[string]*historyservice.DescribeNamespaceTimeResponse{
+		"host-1": {ShardIds: []int32{1, 2}, Idle: true, NextFireTime: timestamppb.New(nextFireTime.Add(time.Hour))},
+		"host-2": {ShardIds: []int32{3, 4}, Idle: true, NextFireTime: timestamppb.New(nextFireTime)},
+	})
+	skipped := time.Hour + common.ScheduledTaskMinPrecision
+	c.expectUpdateNamespace(ns, 0, skipped)
+	c.expectUpdateNamespaceTime(ns, skipped, nil)
+	require.NoError(t, c.autoAdvance(context.Background(), ns.ID()))
+}
+
+func TestCoordinator_AutoAdvance_NotIdle(t *testing.T) {
+	c := newTestCoordinator(t)
+	ns := newTestNamespace("time-skipping", 0)
+	nextFireTime := timestamppb.New(c.timeSource.Now().Add(time.Hour))
+
+	c.expectDescribeNamespaceTime(ns, map[string]*historyservice.DescribeNamespaceTimeResponse{
+		"host-1": {ShardIds: []int32{1, 2}, Idle: false, NextFireTime: nextFireTime},
+		"host-2": {ShardIds: []int32{3, 4}, Idle: true},
+	})
+	require.NoError(t, c.autoAdvance(context.Background(), ns.ID()))
+
+	// the executions of the shards which are not recovered yet may have pending work
+	c.expectDescribeNamespaceTime(ns, map[string]*historyservice.DescribeNamespaceTimeResponse{
+		"host-1": {ShardIds: []int32{1, 2}, Idle: true, NextFireTime: nextFireTime},
+		"host-2": {ShardIds: []int32{3}, Idle: true},
+	})
+	require.NoError(t, c.autoAdvance(context.Background(), ns.ID()))
+
+	c.expectDescribeNamespaceTime(ns, map[string]*historyservice.DescribeNamespaceTimeResponse{
+		"host-1": {ShardIds: []int32{1, 2}, Idle: true},
+		"host-2": {ShardIds: []int32{3, 4}, Idle: true},
+	})
+	require.NoError(t, c.autoAdvance(context.Background(), ns.ID()))
+}
@@ -0,0 +1,56 @@
+package timeskipping
+
+import (
+	"time"
+
+	enumsspb "go.temporal.io/server/api/enums/v1"
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common"
+)
+
+// IsActivityBusy returns whether the activity is running or waits for a worker, rather than for the timer of its next
+// attempt.
+func IsActivityBusy(
+	activityInfo *persistencespb.ActivityInfo,
+	virtualNow time.Time,
+) bool {
+	if activityInfo.GetPaused() {
+		return false
+	}
+	// the activity waits for the timer of its next attempt
+	return activityInfo.GetStartedEventId() != common.EmptyEventID || !activityInfo.GetScheduledTime().AsTime().After(virtualNow)
+}
+
+// IsExecutionBusy returns whether the persisted workflow execution waits for work which takes real time, like a
+// workflow task or a running activity, rather than for a timer. The mutable state tracks the executions it changes the
+// same way, this is used for the executions which were persisted before their shard was loaded.
+func IsExecutionBusy(
+	state *persistencespb.WorkflowMutableState,
+	virtualNow time.Time,
+) bool {
+	if !IsExecutionRunning(state) {
+		return false
+	}
+	if state.GetExecutionInfo().GetWorkflowTaskScheduledEventId() != common.EmptyEventID ||
+		len(state.GetRequestCancelInfos()) > 0 ||
+		len(state.GetSignalInfos()) > 0 {
+		return true
+	}
+	for _, activityInfo := range state.GetActivityInfos() {
+		if IsActivityBusy(activityInfo, virtualNow) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsExecutionRunning returns whether the persisted workflow execution is not completed yet, only the running executions
+// can have pending work.
+func IsExecutionRunning(state *persistencespb.WorkflowMutableState) bool {
+	switch state.GetExecutionState().GetState() {
+	case enumsspb.WORKFLOW_EXECUTION_STATE_CREATED, enumsspb.WORKFLOW_EXECUTION_STATE_RUNNING:
+		return true
+	default:
+		return false
+	}
+}
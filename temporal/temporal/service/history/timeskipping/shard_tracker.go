@@ -0,0 +1,155 @@
+package timeskipping
+
+import (
+	"sync"
+	"time"
+
+	"go.temporal.io/server/common/collection"
+	"go.temporal.io/server/common/definition"
+	"go.temporal.io/server/common/namespace"
+)
+
+type (
+	// ShardTracker tracks the executions of the namespaces with time skipping enabled on a shard which have pending
+	// work, like a workflow task or a running activity, and the fire times of their timers. The virtual time of a
+	// namespace is only auto-advanced to its next timer when the executions of the namespace are idle on every shard.
+	//
+	// The tracker is recovered from the persisted executions and timer tasks of the shard when the shard is loaded,
+	// and the executions changed afterwards are tracked by their mutable state. The state of the tracker is not
+	// reported until it is recovered.
+	ShardTracker struct {
+		shardID         int32
+		hasPendingTasks func(namespace.ID) bool
+
+		sync.Mutex
+		recovered bool
+		// executions maps the tracked executions to whether they have pending work. The idle executions are only kept
+		// until the tracker is recovered, so that the recovery doesn't override the executions changed since the shard
+		// was loaded with their older persisted state.
+		executions     map[definition.WorkflowKey]bool
+		busyExecutions map[namespace.ID]int
+		fireTimes      map[namespace.ID]collection.Queue[time.Time]
+	}
+)
+
+func newShardTracker(
+	shardID int32,
+	hasPendingTasks func(namespace.ID) bool,
+) *ShardTracker {
+	return &ShardTracker{
+		shardID:         shardID,
+		hasPendingTasks: hasPendingTasks,
+		executions:      make(map[definition.WorkflowKey]bool),
+		busyExecutions:  make(map[namespace.ID]int),
+		fireTimes:       make(map[namespace.ID]collection.Queue[time.Time]),
+	}
+}
+
+// Recovered returns whether the persisted executions and timers of the shard are loaded into the tracker.
+func (t *ShardTracker) Recovered() bool {
+	t.Lock()
+	defer t.Unlock()
+
+	return t.recovered
+}
+
+// SetRecovered marks the persisted executions and timers of the shard as loaded into the tracker.
+func (t *ShardTracker) SetRecovered() {
+	t.Lock()
+	defer t.Unlock()
+
+	for workflowKey, busy := range t.executions {
+		if !busy {
+			delete(t.executions, workflowKey)
+		}
+	}
+	t.recovered = true
+}
+
+// RecoverExecution records whether an execution persisted before the shard was loaded has pending work, unless the
+// execution changed since.
+func (t *ShardTracker) RecoverExecution(
+	workflowKey definition.WorkflowKey,
+	busy bool,
+) {
+	t.Lock()
+	defer t.Unlock()
+
+	if _, ok := t.executions[workflowKey]; ok || t.recovered {
+		return
+	}
+	t.setExecutionBusyLocked(workflowKey, busy)
+}
+
+// AddTimer records the fire time of a timer task of the namespace.
+func (t *ShardTracker) AddTimer(
+	namespaceID namespace.ID,
+	fireTime time.Time,
+) {
+	t.Lock()
+	defer t.Unlock()
+
+	fireTimes, ok := t.fireTimes[namespaceID]
+	if !ok {
+		fireTimes = collection.NewPriorityQueue(time.Time.Before)
+		t.fireTimes[namespaceID] = fireTimes
+	}
+	fireTimes.Add(fireTime)
+}
+
+func (t *ShardTracker) setExecutionBusy(
+	workflowKey definition.WorkflowKey,
+	busy bool,
+) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.setExecutionBusyLocked(workflowKey, busy)
+}
+
+func (t *ShardTracker) setExecutionBusyLocked(
+	workflowKey definition.WorkflowKey,
+	busy bool,
+) {
+	namespaceID := namespace.ID(workflowKey.NamespaceID)
+	wasBusy := t.executions[workflowKey]
+	switch {
+	case busy && !wasBusy:
+		t.busyExecutions[namespaceID]++
+	case !busy && wasBusy:
+		t.busyExecutions[namespaceID]--
+		if t.busyExecutions[namespaceID] == 0 {
+			delete(t.busyExecutions, namespaceID)
+		}
+	}
+
+	if busy || !t.recovered {
+		t.executions[workflowKey] = busy
+	} else {
+		delete(t.executions, workflowKey)
+	}
+}
+
+// state returns whether the tracker is recovered, whether none of the executions of the namespace has pending work,
+// and the fire time of the next timer of the namespace after the virtual time, which is zero if there is none.
+func (t *ShardTracker) state(
+	namespaceID namespace.ID,
+	virtualNow time.Time,
+) (bool, bool, time.Time) {
+	t.Lock()
+	defer t.Unlock()
+
+	var nextFireTime time.Time
+	if fireTimes, ok := t.fireTimes[namespaceID]; ok {
+		// the timers which are due on the virtual time are already submitted by the timer queue
+		for !fireTimes.IsEmpty() && !fireTimes.Peek().After(virtualNow) {
+			fireTimes.Remove()
+		}
+		if !fireTimes.IsEmpty() {
+			nextFireTime = fireTimes.Peek()
+		} else {
+			delete(t.fireTimes, namespaceID)
+		}
+	}
+	return t.recovered, t.busyExecutions[namespaceID] == 0, nextFireTime
+}
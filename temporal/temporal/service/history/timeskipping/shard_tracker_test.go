@@ -0,0 +1,67 @@
+package timeskipping
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/server/common/definition"
+	"go.temporal.io/server/common/namespace"
+)
+
+func TestShardTracker_Recover(t *testing.T) {
+	tracker := newShardTracker(1, func(namespace.ID) bool { return false })
+	namespaceID := namespace.ID("namespace-id")
+	key1 := definition.NewWorkflowKey(namespaceID.String(), "workflow-id-1", "run-id")
+	key2 := definition.NewWorkflowKey(namespaceID.String(), "workflow-id-2", "run-id")
+	key3 := definition.NewWorkflowKey(namespaceID.String(), "workflow-id-3", "run-id")
+	now := time.Unix(1000, 0)
+
+	// the executions changed since the shard was loaded are not overridden by their persisted state
+	tracker.setExecutionBusy(key1, false)
+	tracker.setExecutionBusy(key2, true)
+	tracker.RecoverExecution(key1, true)
+	tracker.RecoverExecution(key2, false)
+	tracker.RecoverExecution(key3, true)
+	recovered, idle, _ := tracker.state(namespaceID, now)
+	require.False(t, recovered)
+	require.False(t, idle)
+
+	tracker.SetRecovered()
+	require.True(t, tracker.Recovered())
+	tracker.setExecutionBusy(key2, false)
+	recovered, idle, _ = tracker.state(namespaceID, now)
+	require.True(t, recovered)
+	require.False(t, idle)
+
+	tracker.setExecutionBusy(key3, false)
+	_, idle, _ = tracker.state(namespaceID, now)
+	require.True(t, idle)
+
+	// the executions are not recovered once the tracker is recovered
+	tracker.RecoverExecution(key1, true)
+	_, idle, _ = tracker.state(namespaceID, now)
+	require.True(t, idle)
+}
+
+func TestShardTracker_NextFireTime(t *testing.T) {
+	tracker := newShardTracker(1, func(namespace.ID) bool { return false })
+	namespaceID := namespace.ID("namespace-id")
+	now := time.Unix(1000, 0)
+
+	_, _, nextFireTime := tracker.state(namespaceID, now)
+	require.True(t, nextFireTime.IsZero())
+
+	tracker.AddTimer(namespaceID, now.Add(2*time.Hour))
+	tracker.AddTimer(namespaceID, now.Add(time.Hour))
+	tracker.AddTimer(namespace.ID("other-namespace-id"), now.Add(time.Minute))
+	_, _, nextFireTime = tracker.state(namespaceID, now)
+	require.Equal(t, now.Add(time.Hour), nextFireTime)
+
+	// the timers which are due on the virtual time are dropped
+	_, _, nextFireTime = tracker.state(namespaceID, now.Add(time.Hour))
+	require.Equal(t, now.Add(2*time.Hour), nextFireTime)
+
+	_, _, nextFireTime = tracker.state(namespaceID, now.Add(2*time.Hour))
+	require.True(t, nextFireTime.IsZero())
+}
@@ -958,6 +958,35 @@ func (c *ContextImpl) RefreshTasks(
 	return c.UpdateWorkflowExecutionAsPassive(ctx, shardContext)
 }
 
+// RefreshVisibilityTasks only refreshes the tasks which rebuild the visibility record of the execution. The tasks of
+// CHASM executions are all refreshed, as their visibility tasks are generated by the CHASM tree.
+func (c *ContextImpl) RefreshVisibilityTasks(
+	ctx context.Context,
+	shardContext historyi.ShardContext,
+) error {
+	mutableState, err := c.LoadMutableState(ctx, shardContext)
+	if err != nil {
+		return err
+	}
+	if !mutableState.IsWorkflow() {
+		return c.RefreshTasks(ctx, shardContext)
+	}
+
+	if err := NewTaskRefresher(shardContext).RefreshVisibilityTasks(ctx, mutableState); err != nil {
+		return err
+	}
+
+	if c.config.EnableUpdateWorkflowModeIgnoreCurrent() {
+		return c.UpdateWorkflowExecutionAsPassive(ctx, shardContext)
+	}
+
+	// TODO: remove following code once EnableUpdateWorkflowModeIgnoreCurrent config is deprecated.
+	if !mutableState.IsWorkflowExecutionRunning() {
+		return c.SubmitClosedWorkflowSnapshot(ctx, shardContext, historyi.TransactionPolicyPassive)
+	}
+	return c.UpdateWorkflowExecutionAsPassive(ctx, shardContext)
+}
+
 func (c *ContextImpl) UpdateRegistry(ctx context.Context) update.Registry {
 	if c.updateRegistry != nil && c.updateRegistry.FailoverVersion() != c.MutableState.GetCurrentVersion() {
 		c.updateRegistry.Clear()
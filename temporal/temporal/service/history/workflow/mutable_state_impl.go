@@ -69,6 +69,7 @@ import (
 	"go.temporal.io/server/service/history/hsm"
 	historyi "go.temporal.io/server/service/history/interfaces"
 	"go.temporal.io/server/service/history/tasks"
+	"go.temporal.io/server/service/history/timeskipping"
 	"go.temporal.io/server/service/history/workflow/update"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/durationpb"
@@ -1762,6 +1763,7 @@ func (ms *MutableStateImpl) GetCompletionEvent(
 			_, txID := ms.GetLastFirstEventIDTxnID()
 			resp, err := ms.shard.GetExecutionManager().ReadHistoryBranchReverse(ctx, &persistence.ReadHistoryBranchReverseRequest{
 				ShardID:                ms.shard.GetShardID(),
+				NamespaceID:            ms.executionInfo.NamespaceId,
 				BranchToken:            currentBranchToken,
 				MaxEventID:             nextEventID, // looking for an event in the most recent batch
 				PageSize:               1,
@@ -7100,6 +7102,8 @@ func (ms *MutableStateImpl) closeTransaction(
 
 	ms.closeTransactionTrackTombstones(transactionPolicy, chasmNodesMutation)
 
+	ms.closeTransactionTrackTimeSkipping(transactionPolicy)
+
 	if err := ms.closeTransactionPrepareTasks(
 		transactionPolicy,
 		eventBatches,
@@ -7374,6 +7378,38 @@ func (ms *MutableStateImpl) closeTransactionUpdateLastRunningClock(
 	ms.executionInfo.LastRunningClock = ms.shard.CurrentVectorClock().GetClock()
 }
 
+func (ms *MutableStateImpl) closeTransactionTrackTimeSkipping(
+	transactionPolicy historyi.TransactionPolicy,
+) {
+	if transactionPolicy != historyi.TransactionPolicyActive ||
+		!ms.IsWorkflow() ||
+		!ms.config.TimeSkippingEnabled(ms.namespaceEntry.Name().String()) {
+		return
+	}
+
+	ms.shard.TimeSkippingClock().SetExecutionBusy(ms.shard.GetShardID(), ms.namespaceEntry, ms.GetWorkflowKey(), ms.hasPendingWork())
+}
+
+// hasPendingWork returns whether the workflow waits for work which takes real time, like a workflow task or a running
+// activity, rather than for a timer. The virtual clock of a namespace with time skipping enabled is only auto-advanced
+// to its next timer when none of its workflows has pending work.
+func (ms *MutableStateImpl) hasPendingWork() bool {
+	if !ms.IsWorkflowExecutionRunning() {
+		return false
+	}
+	if ms.HasPendingWorkflowTask() || len(ms.pendingRequestCancelInfoIDs) > 0 || len(ms.pendingSignalInfoIDs) > 0 {
+		return true
+	}
+
+	now := ms.shard.TimeSkippingClock().Now(ms.namespaceEntry.ID())
+	for _, ai := range ms.pendingActivityInfoIDs {
+		if timeskipping.IsActivityBusy(ai, now) {
+			return true
+		}
+	}
+	return false
+}
+
 func (ms *MutableStateImpl) closeTransactionTrackTombstones(
 	transactionPolicy historyi.TransactionPolicy,
 	chasmNodesMutation chasm.NodesMutation,
@@ -7554,7 +7590,7 @@ func (ms *MutableStateImpl) closeTransactionGenerateChasmRetentionTask(
 		// If passive cluster, the CloseTime field should already be populated by the replication stack.
 	}
 
-	return ms.taskGenerator.GenerateDeleteHistoryEventTask(ms.executionInfo.CloseTime.AsTime())
+	return ms.taskGenerator.GenerateChasmCloseTasks(ms.executionInfo.CloseTime.AsTime())
 }
 
 func (ms *MutableStateImpl) closeTransactionPrepareReplicationTasks(
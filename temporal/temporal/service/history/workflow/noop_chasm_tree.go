@@ -57,6 +57,10 @@ func (*noopChasmTree) ArchetypeID() chasm.ArchetypeID {
 	return chasm.WorkflowArchetypeID
 }
 
+func (*noopChasmTree) IsArchivable() bool {
+	return false
+}
+
 func (*noopChasmTree) EachPureTask(
 	deadline time.Time,
 	callback func(executor chasm.NodePureTask, taskAttributes chasm.TaskAttributes, task any) (bool, error),
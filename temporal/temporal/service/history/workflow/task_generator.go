@@ -43,6 +43,9 @@ type (
 		// GenerateDeleteHistoryEventTask adds a tasks.DeleteHistoryEventTask to the mutable state.
 		// This task is used to delete the history events of the workflow execution after the retention period expires.
 		GenerateDeleteHistoryEventTask(closeTime time.Time) error
+		// GenerateChasmCloseTasks adds the archival task of a closed CHASM execution, or its
+		// tasks.DeleteHistoryEventTask if the execution is not archived.
+		GenerateChasmCloseTasks(closedTime time.Time) error
 		GenerateDeleteExecutionTask() (*tasks.DeleteExecutionTask, error)
 		GenerateRecordWorkflowStartedTasks(
 			startEvent *historypb.HistoryEvent,
@@ -238,27 +241,12 @@ func (r *TaskGeneratorImpl) GenerateWorkflowCloseTasks(
 				Version:     closeVersion,
 			},
 		)
-		if r.archivalEnabled() {
-			retention, err := r.getRetention()
+		// A rehydrated execution is already archived, so it is not archived again.
+		if r.archivalEnabled() && r.mutableState.GetExecutionInfo().GetRehydrationExpirationTime() == nil {
+			task, err := r.newArchiveExecutionTask(closedTime, closeVersion)
 			if err != nil {
 				return err
 			}
-			// We schedule the archival task for a random time in the near future to avoid sending a surge of tasks
-			// to the archival system at the same time
-
-			delay := backoff.FullJitter(r.config.ArchivalProcessorArchiveDelay())
-			if delay > retention {
-				delay = retention
-			}
-			// archiveTime is the time when the archival queue recognizes the ArchiveExecutionTask as ready-to-process
-			archiveTime := closedTime.Add(delay)
-
-			task := &tasks.ArchiveExecutionTask{
-				// TaskID is set by the shard
-				WorkflowKey:         r.mutableState.GetWorkflowKey(),
-				VisibilityTimestamp: archiveTime,
-				Version:             closeVersion,
-			}
 			closeTasks = append(closeTasks, task)
 		} else if err := r.GenerateDeleteHistoryEventTask(closedTime); err != nil {
 			return err
@@ -270,6 +258,52 @@ func (r *TaskGeneratorImpl) GenerateWorkflowCloseTasks(
 	return nil
 }
 
+// GenerateChasmCloseTasks adds the tasks of a CHASM execution which is closed. The execution is archived first if its
+// archetype is registered with archival and archival is enabled, and is deleted after its retention.
+func (r *TaskGeneratorImpl) GenerateChasmCloseTasks(closedTime time.Time) error {
+	if !r.mutableState.ChasmTree().IsArchivable() || !r.archivalEnabled() {
+		return r.GenerateDeleteHistoryEventTask(closedTime)
+	}
+
+	closeVersion, err := r.mutableState.GetCloseVersion()
+	if err != nil {
+		return err
+	}
+	task, err := r.newArchiveExecutionTask(closedTime, closeVersion)
+	if err != nil {
+		return err
+	}
+	task.ArchetypeID = r.mutableState.ChasmTree().ArchetypeID()
+	r.mutableState.AddTasks(task)
+	return nil
+}
+
+func (r *TaskGeneratorImpl) newArchiveExecutionTask(
+	closedTime time.Time,
+	closeVersion int64,
+) (*tasks.ArchiveExecutionTask, error) {
+	retention, err := r.getRetention()
+	if err != nil {
+		return nil, err
+	}
+	// We schedule the archival task for a random time in the near future to avoid sending a surge of tasks
+	// to the archival system at the same time
+
+	delay := backoff.FullJitter(r.config.ArchivalProcessorArchiveDelay())
+	if delay > retention {
+		delay = retention
+	}
+	// archiveTime is the time when the archival queue recognizes the ArchiveExecutionTask as ready-to-process
+	archiveTime := closedTime.Add(delay)
+
+	return &tasks.ArchiveExecutionTask{
+		// TaskID is set by the shard
+		WorkflowKey:         r.mutableState.GetWorkflowKey(),
+		VisibilityTimestamp: archiveTime,
+		Version:             closeVersion,
+	}, nil
+}
+
 // getRetention returns the retention period for this task generator's workflow execution.
 // The retention period represents how long the workflow data should exist in primary storage after the workflow closes.
 // If the workflow namespace is not found, the default retention period is returned.
@@ -356,6 +390,10 @@ func (r *TaskGeneratorImpl) GenerateDeleteHistoryEventTask(closeTime time.Time)
 
 	retentionJitterDuration := backoff.FullJitter(r.config.RetentionTimerJitterDuration())
 	deleteTime := closeTime.Add(retention).Add(retentionJitterDuration)
+	// A rehydrated execution has usually passed the retention already, and is deleted at its own expiration time.
+	if expirationTime := r.mutableState.GetExecutionInfo().GetRehydrationExpirationTime(); expirationTime != nil {
+		deleteTime = expirationTime.AsTime().Add(retentionJitterDuration)
+	}
 	r.mutableState.AddTasks(&tasks.DeleteHistoryEventTask{
 		// TaskID is set by shard
 		WorkflowKey:         r.mutableState.GetWorkflowKey(),
@@ -87,6 +87,20 @@ func (mr *MockTaskGeneratorMockRecorder) GenerateActivityTimerTasks() *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateActivityTimerTasks", reflect.TypeOf((*MockTaskGenerator)(nil).GenerateActivityTimerTasks))
 }
 
+// GenerateChasmCloseTasks mocks base method.
+func (m *MockTaskGenerator) GenerateChasmCloseTasks(closedTime time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateChasmCloseTasks", closedTime)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GenerateChasmCloseTasks indicates an expected call of GenerateChasmCloseTasks.
+func (mr *MockTaskGeneratorMockRecorder) GenerateChasmCloseTasks(closedTime any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateChasmCloseTasks", reflect.TypeOf((*MockTaskGenerator)(nil).GenerateChasmCloseTasks), closedTime)
+}
+
 // GenerateChildWorkflowTasks mocks base method.
 func (m *MockTaskGenerator) GenerateChildWorkflowTasks(childInitiatedEventId int64) error {
 	m.ctrl.T.Helper()
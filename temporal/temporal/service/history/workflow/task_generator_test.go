@@ -53,6 +53,7 @@ type testParams struct {
 	HistoryArchivalEnabledInNamespace    bool
 	VisibilityArchivalEnabledForCluster  bool
 	VisibilityArchivalEnabledInNamespace bool
+	RehydrationExpirationTime            time.Time
 
 	ExpectCloseExecutionVisibilityTask              bool
 	ExpectArchiveExecutionTask                      bool
@@ -152,6 +153,16 @@ func TestTaskGeneratorImpl_GenerateWorkflowCloseTasks(t *testing.T) {
 				p.HistoryArchivalEnabledInNamespace = false
 				p.VisibilityArchivalEnabledInNamespace = false
 
+				p.ExpectCloseExecutionVisibilityTask = true
+				p.ExpectDeleteHistoryEventTask = true
+				p.ExpectArchiveExecutionTask = false
+			},
+		},
+		{
+			Name: "rehydrated execution skips archival",
+			ConfigFn: func(p *testParams) {
+				p.RehydrationExpirationTime = time.Unix(0, 0).Add(90 * 24 * time.Hour)
+
 				p.ExpectCloseExecutionVisibilityTask = true
 				p.ExpectDeleteHistoryEventTask = true
 				p.ExpectArchiveExecutionTask = false
@@ -222,9 +233,13 @@ func TestTaskGeneratorImpl_GenerateWorkflowCloseTasks(t *testing.T) {
 			mutableState.EXPECT().GetNamespaceEntry().Return(namespaceEntry).AnyTimes()
 			mutableState.EXPECT().GetCloseVersion().Return(int64(0), nil).AnyTimes()
 			mutableState.EXPECT().GetExecutionInfo().DoAndReturn(func() *persistencespb.WorkflowExecutionInfo {
-				return &persistencespb.WorkflowExecutionInfo{
+				executionInfo := &persistencespb.WorkflowExecutionInfo{
 					NamespaceId: namespaceEntry.ID().String(),
 				}
+				if !p.RehydrationExpirationTime.IsZero() {
+					executionInfo.RehydrationExpirationTime = timestamppb.New(p.RehydrationExpirationTime)
+				}
+				return executionInfo
 			}).AnyTimes()
 			mutableState.EXPECT().GetWorkflowKey().Return(definition.NewWorkflowKey(
 				namespaceEntry.ID().String(), tests.WorkflowID, tests.RunID,
@@ -303,9 +318,13 @@ func TestTaskGeneratorImpl_GenerateWorkflowCloseTasks(t *testing.T) {
 				assert.Equal(t, deleteHistoryEventTask.NamespaceID, namespaceEntry.ID().String())
 				assert.Equal(t, deleteHistoryEventTask.WorkflowID, tests.WorkflowID)
 				assert.Equal(t, deleteHistoryEventTask.RunID, tests.RunID)
-				assert.GreaterOrEqual(t, deleteHistoryEventTask.VisibilityTimestamp, closeTime.Add(p.Retention))
+				expectedDeleteTime := closeTime.Add(p.Retention)
+				if !p.RehydrationExpirationTime.IsZero() {
+					expectedDeleteTime = p.RehydrationExpirationTime
+				}
+				assert.GreaterOrEqual(t, deleteHistoryEventTask.VisibilityTimestamp, expectedDeleteTime)
 				assert.LessOrEqual(t, deleteHistoryEventTask.VisibilityTimestamp,
-					closeTime.Add(p.Retention).Add(retentionTimerDelay*2))
+					expectedDeleteTime.Add(retentionTimerDelay*2))
 			} else {
 				assert.Nil(t, deleteHistoryEventTask)
 			}
@@ -1066,3 +1085,108 @@ func TestTaskGeneratorImpl_GenerateDeleteHistoryEventTask_ActivityRetention(t *t
 		})
 	}
 }
+
+func TestTaskGeneratorImpl_GenerateChasmCloseTasks(t *testing.T) {
+	t.Parallel()
+
+	closeTime := time.Unix(0, 0)
+	testCases := []struct {
+		name                  string
+		archivable            bool
+		archivalEnabled       bool
+		expectArchiveTask     bool
+		expectDeleteEventTask bool
+	}{
+		{
+			name:              "archivable archetype is archived",
+			archivable:        true,
+			archivalEnabled:   true,
+			expectArchiveTask: true,
+		},
+		{
+			name:                  "archivable archetype is deleted if archival is disabled",
+			archivable:            true,
+			archivalEnabled:       false,
+			expectDeleteEventTask: true,
+		},
+		{
+			name:                  "archetype without archival is deleted",
+			archivable:            false,
+			archivalEnabled:       true,
+			expectDeleteEventTask: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			archivalState := enumspb.ARCHIVAL_STATE_DISABLED
+			if tc.archivalEnabled {
+				archivalState = enumspb.ARCHIVAL_STATE_ENABLED
+			}
+			namespaceEntry := namespace.NewGlobalNamespaceForTest(
+				&persistencespb.NamespaceInfo{Id: tests.NamespaceID.String(), Name: tests.Namespace.String()},
+				&persistencespb.NamespaceConfig{
+					Retention:            durationpb.New(7 * 24 * time.Hour),
+					HistoryArchivalState: archivalState,
+					HistoryArchivalUri:   "test:///history/archival/",
+				},
+				&persistencespb.NamespaceReplicationConfig{
+					ActiveClusterName: cluster.TestCurrentClusterName,
+					Clusters:          []string{cluster.TestCurrentClusterName},
+				},
+				tests.Version,
+			)
+			namespaceRegistry := namespace.NewMockRegistry(ctrl)
+			namespaceRegistry.EXPECT().GetNamespaceByID(namespaceEntry.ID()).Return(namespaceEntry, nil).AnyTimes()
+
+			mockChasmTree := historyi.NewMockChasmTree(ctrl)
+			mockChasmTree.EXPECT().ArchetypeID().Return(chasm.SchedulerArchetypeID).AnyTimes()
+			mockChasmTree.EXPECT().IsArchivable().Return(tc.archivable).AnyTimes()
+
+			mutableState := historyi.NewMockMutableState(ctrl)
+			mutableState.EXPECT().GetNamespaceEntry().Return(namespaceEntry).AnyTimes()
+			mutableState.EXPECT().GetExecutionInfo().Return(&persistencespb.WorkflowExecutionInfo{
+				NamespaceId: namespaceEntry.ID().String(),
+			}).AnyTimes()
+			mutableState.EXPECT().GetWorkflowKey().Return(definition.NewWorkflowKey(
+				namespaceEntry.ID().String(), tests.WorkflowID, tests.RunID,
+			)).AnyTimes()
+			mutableState.EXPECT().GetCloseVersion().Return(int64(0), nil).AnyTimes()
+			mutableState.EXPECT().GetCurrentBranchToken().Return(nil, nil).AnyTimes()
+			mutableState.EXPECT().ChasmTree().Return(mockChasmTree).AnyTimes()
+			var allTasks []tasks.Task
+			mutableState.EXPECT().AddTasks(gomock.Any()).Do(func(ts ...tasks.Task) {
+				allTasks = append(allTasks, ts...)
+			}).AnyTimes()
+
+			archivalConfig := archiver.NewMockArchivalConfig(ctrl)
+			archivalConfig.EXPECT().ClusterConfiguredForArchival().Return(true).AnyTimes()
+			archivalMetadata := archiver.NewMockArchivalMetadata(ctrl)
+			archivalMetadata.EXPECT().GetHistoryConfig().Return(archivalConfig).AnyTimes()
+			archivalMetadata.EXPECT().GetVisibilityConfig().Return(archivalConfig).AnyTimes()
+
+			cfg := &configs.Config{
+				RetentionTimerJitterDuration:  func() time.Duration { return time.Second },
+				ArchivalProcessorArchiveDelay: func() time.Duration { return time.Minute },
+			}
+			taskGenerator := NewTaskGenerator(namespaceRegistry, mutableState, cfg, archivalMetadata, log.NewTestLogger())
+			require.NoError(t, taskGenerator.GenerateChasmCloseTasks(closeTime))
+
+			require.Len(t, allTasks, 1)
+			if tc.expectArchiveTask {
+				archiveExecutionTask, ok := allTasks[0].(*tasks.ArchiveExecutionTask)
+				require.True(t, ok)
+				assert.Equal(t, chasm.SchedulerArchetypeID, archiveExecutionTask.ArchetypeID)
+				assert.False(t, archiveExecutionTask.VisibilityTimestamp.After(closeTime.Add(time.Minute)))
+			}
+			if tc.expectDeleteEventTask {
+				deleteHistoryEventTask, ok := allTasks[0].(*tasks.DeleteHistoryEventTask)
+				require.True(t, ok)
+				assert.Equal(t, chasm.SchedulerArchetypeID, deleteHistoryEventTask.ArchetypeID)
+			}
+		})
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"go.temporal.io/server/common/primitives/timestamp"
 	"go.temporal.io/server/service/history/hsm"
 	historyi "go.temporal.io/server/service/history/interfaces"
+	"go.temporal.io/server/service/history/tasks"
 )
 
 type (
@@ -41,6 +42,12 @@ type (
 			previousPendingChildIds map[int64]struct{},
 			shouldSkipGeneratingCloseTransferTask bool,
 		) error
+		// RefreshVisibilityTasks refreshes the tasks which rebuild the visibility record of a workflow: an upsert
+		// visibility task if it is running or paused, and a close visibility task otherwise.
+		RefreshVisibilityTasks(
+			ctx context.Context,
+			mutableState historyi.MutableState,
+		) error
 	}
 
 	TaskRefresherImpl struct {
@@ -90,7 +97,7 @@ func (r *TaskRefresherImpl) Refresh(
 			r.shard,
 			mutableState,
 		)
-		return taskGenerator.GenerateDeleteHistoryEventTask(closeTime)
+		return taskGenerator.GenerateChasmCloseTasks(closeTime)
 	}
 
 	return nil
@@ -208,6 +215,31 @@ func (r *TaskRefresherImpl) PartialRefresh(
 	)
 }
 
+func (r *TaskRefresherImpl) RefreshVisibilityTasks(
+	_ context.Context,
+	mutableState historyi.MutableState,
+) error {
+	executionState := mutableState.GetExecutionState()
+	if executionState.Status == enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING || executionState.Status == enumspb.WORKFLOW_EXECUTION_STATUS_PAUSED {
+		taskGenerator := r.taskGeneratorProvider.NewTaskGenerator(
+			r.shard,
+			mutableState,
+		)
+		return taskGenerator.GenerateUpsertVisibilityTask()
+	}
+
+	closeVersion, err := mutableState.GetCloseVersion()
+	if err != nil {
+		return err
+	}
+	mutableState.AddTasks(&tasks.CloseExecutionVisibilityTask{
+		// TaskID, VisibilityTimestamp is set by shard
+		WorkflowKey: mutableState.GetWorkflowKey(),
+		Version:     closeVersion,
+	})
+	return nil
+}
+
 func RefreshTasksForWorkflowStart(
 	ctx context.Context,
 	mutableState historyi.MutableState,
@@ -69,3 +69,17 @@ func (mr *MockTaskRefresherMockRecorder) Refresh(ctx, mutableState, shouldSkipGe
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockTaskRefresher)(nil).Refresh), ctx, mutableState, shouldSkipGeneratingCloseTransferTask)
 }
+
+// RefreshVisibilityTasks mocks base method.
+func (m *MockTaskRefresher) RefreshVisibilityTasks(ctx context.Context, mutableState interfaces.MutableState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshVisibilityTasks", ctx, mutableState)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RefreshVisibilityTasks indicates an expected call of RefreshVisibilityTasks.
+func (mr *MockTaskRefresherMockRecorder) RefreshVisibilityTasks(ctx, mutableState any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshVisibilityTasks", reflect.TypeOf((*MockTaskRefresher)(nil).RefreshVisibilityTasks), ctx, mutableState)
+}
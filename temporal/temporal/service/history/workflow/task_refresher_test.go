@@ -1359,6 +1359,62 @@ func (s *taskRefresherSuite) TestRefreshWorkflowSearchAttributesTasks() {
 	s.NoError(err)
 }
 
+func (s *taskRefresherSuite) TestRefreshVisibilityTasks() {
+	mutableStateRecord := &persistencespb.WorkflowMutableState{
+		ExecutionInfo: &persistencespb.WorkflowExecutionInfo{
+			NamespaceId: tests.NamespaceID.String(),
+			WorkflowId:  tests.WorkflowID,
+			VersionHistories: &historyspb.VersionHistories{
+				Histories: []*historyspb.VersionHistory{
+					{
+						BranchToken: []byte("branchToken"),
+						Items: []*historyspb.VersionHistoryItem{
+							{EventId: 2, Version: common.EmptyVersion},
+						},
+					},
+				},
+			},
+		},
+		ExecutionState: &persistencespb.WorkflowExecutionState{
+			RunId:  tests.RunID,
+			State:  enumsspb.WORKFLOW_EXECUTION_STATE_RUNNING,
+			Status: enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING,
+		},
+		NextEventId: int64(3),
+	}
+	mutableState, err := NewMutableStateFromDB(
+		s.mockShard,
+		s.mockShard.GetEventsCache(),
+		log.NewTestLogger(),
+		tests.LocalNamespaceEntry,
+		mutableStateRecord,
+		2,
+	)
+	s.NoError(err)
+
+	s.mockTaskGenerator.EXPECT().GenerateUpsertVisibilityTask().Return(nil).Times(1)
+	err = s.taskRefresher.RefreshVisibilityTasks(context.Background(), mutableState)
+	s.NoError(err)
+
+	mutableStateRecord.ExecutionState.State = enumsspb.WORKFLOW_EXECUTION_STATE_COMPLETED
+	mutableStateRecord.ExecutionState.Status = enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED
+	mutableState, err = NewMutableStateFromDB(
+		s.mockShard,
+		s.mockShard.GetEventsCache(),
+		log.NewTestLogger(),
+		tests.LocalNamespaceEntry,
+		mutableStateRecord,
+		2,
+	)
+	s.NoError(err)
+
+	err = s.taskRefresher.RefreshVisibilityTasks(context.Background(), mutableState)
+	s.NoError(err)
+	visibilityTasks := mutableState.PopTasks()[tasks.CategoryVisibility]
+	s.Len(visibilityTasks, 1)
+	s.Equal(enumsspb.TASK_TYPE_VISIBILITY_CLOSE_EXECUTION, visibilityTasks[0].GetType())
+}
+
 func (s *taskRefresherSuite) TestRefreshSubStateMachineTasks() {
 
 	stateMachineDef := hsmtest.NewDefinition("test")
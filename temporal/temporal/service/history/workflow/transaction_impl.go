@@ -298,6 +298,7 @@ func persistFirstWorkflowEvents(
 		&persistence.AppendHistoryNodesRequest{
 			IsNewBranch:       true,
 			Info:              persistence.BuildHistoryGarbageCleanupInfo(namespaceID.String(), workflowID, runID),
+			NamespaceID:       namespaceID.String(),
 			BranchToken:       branchToken,
 			Events:            events,
 			PrevTransactionID: prevTxnID,
@@ -334,6 +335,7 @@ func persistNonFirstWorkflowEvents(
 		&execution,
 		&persistence.AppendHistoryNodesRequest{
 			IsNewBranch:       false,
+			NamespaceID:       namespaceID.String(),
 			BranchToken:       branchToken,
 			Events:            events,
 			PrevTransactionID: prevTxnID,
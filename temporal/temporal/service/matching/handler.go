@@ -6,17 +6,21 @@ import (
 	"time"
 
 	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
 	taskqueuepb "go.temporal.io/api/taskqueue/v1"
 	workerpb "go.temporal.io/api/worker/v1"
 	"go.temporal.io/api/workflowservice/v1"
 	"go.temporal.io/server/api/matchingservice/v1"
 	"go.temporal.io/server/common"
 	"go.temporal.io/server/common/cluster"
+	"go.temporal.io/server/common/config"
 	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/log/tag"
 	"go.temporal.io/server/common/membership"
 	"go.temporal.io/server/common/metrics"
 	"go.temporal.io/server/common/namespace"
 	"go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/persistence/faultinjection"
 	"go.temporal.io/server/common/persistence/serialization"
 	"go.temporal.io/server/common/persistence/visibility/manager"
 	"go.temporal.io/server/common/resource"
@@ -43,6 +47,7 @@ type (
 		throttledLogger   log.Logger
 		namespaceRegistry namespace.Registry
 		workersRegistry   workers.Registry
+		faultInjection    *faultinjection.Rules
 	}
 
 	HandlerParams struct {
@@ -70,6 +75,7 @@ type (
 		RateLimiter                   TaskDispatchRateLimiter `optional:"true"`
 		WorkersRegistry               workers.Registry
 		Serializer                    serialization.Serializer
+		FaultInjectionRules           *faultinjection.Rules
 	}
 )
 
@@ -79,6 +85,9 @@ const (
 
 var (
 	_ matchingservice.MatchingServiceServer = (*Handler)(nil)
+
+	errFaultInjectionNotEnabled = serviceerror.NewFailedPrecondition("Persistence fault injection is not enabled in the config of the default store.")
+	errFaultInjectionRuleNotSet = serviceerror.NewInvalidArgument("Rule is not set on request.")
 )
 
 // NewHandler creates a gRPC handler for the matchingservice
@@ -115,6 +124,7 @@ func NewHandler(
 		),
 		namespaceRegistry: params.NamespaceRegistry,
 		workersRegistry:   params.WorkersRegistry,
+		faultInjection:    params.FaultInjectionRules,
 	}
 
 	// prevent from serving requests before matching engine is started and ready
@@ -644,3 +654,42 @@ func (h *Handler) DescribeWorker(
 		},
 	}, nil
 }
+
+// AddFaultInjectionRule adds a persistence fault injection rule to all services of this host.
+func (h *Handler) AddFaultInjectionRule(
+	_ context.Context, request *matchingservice.AddFaultInjectionRuleRequest,
+) (*matchingservice.AddFaultInjectionRuleResponse, error) {
+	if request.GetRule() == nil {
+		return nil, errFaultInjectionRuleNotSet
+	}
+	if h.faultInjection == nil {
+		return nil, errFaultInjectionNotEnabled
+	}
+	if err := h.faultInjection.AddProto(request.GetRule()); err != nil {
+		return nil, serviceerror.NewInvalidArgument(err.Error())
+	}
+	h.logger.Info("Added persistence fault injection rule.",
+		tag.NewStringTag("data-store", request.GetRule().GetDataStore()),
+		tag.NewStringTag("method", request.GetRule().GetMethod()),
+	)
+	return &matchingservice.AddFaultInjectionRuleResponse{}, nil
+}
+
+// ClearFaultInjectionRules removes the persistence fault injection rules which were added with AddFaultInjectionRule
+// from this host.
+func (h *Handler) ClearFaultInjectionRules(
+	_ context.Context, request *matchingservice.ClearFaultInjectionRulesRequest,
+) (*matchingservice.ClearFaultInjectionRulesResponse, error) {
+	if h.faultInjection == nil {
+		return nil, errFaultInjectionNotEnabled
+	}
+	cleared := h.faultInjection.Clear(config.DataStoreName(request.GetDataStore()), request.GetMethod())
+	h.logger.Info("Cleared persistence fault injection rules.",
+		tag.NewStringTag("data-store", request.GetDataStore()),
+		tag.NewStringTag("method", request.GetMethod()),
+		tag.Counter(cleared),
+	)
+	return &matchingservice.ClearFaultInjectionRulesResponse{
+		ClearedCount: int32(cleared),
+	}, nil
+}
@@ -0,0 +1,85 @@
+package dualwriteverification
+
+import (
+	"context"
+	"errors"
+
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/log/tag"
+	"go.temporal.io/server/common/persistence/dualwrite"
+)
+
+type (
+	activities struct {
+		numHistoryShards int32
+		verifier         func() (*dualwrite.ShardVerifier, error)
+		logger           log.Logger
+	}
+
+	// verifyShardProgress is recorded in the heartbeats of VerifyShardActivity, so that a retried activity resumes
+	// from the last verified page.
+	verifyShardProgress struct {
+		Result        VerifyShardResult
+		NextPageToken []byte
+	}
+)
+
+var (
+	errSecondaryStoreNotConfigured = errors.New("no secondary store is configured in the persistence config")
+)
+
+func (a *activities) GetShardIDsActivity(_ context.Context) ([]int32, error) {
+	shardIDs := make([]int32, 0, a.numHistoryShards)
+	for shardID := int32(1); shardID <= a.numHistoryShards; shardID++ {
+		shardIDs = append(shardIDs, shardID)
+	}
+	return shardIDs, nil
+}
+
+func (a *activities) VerifyShardActivity(ctx context.Context, params VerifyShardParams) (VerifyShardResult, error) {
+	verifier, err := a.verifier()
+	if err != nil {
+		return VerifyShardResult{}, temporal.NewNonRetryableApplicationError(err.Error(), "", err)
+	}
+
+	progress := verifyShardProgress{Result: VerifyShardResult{ShardID: params.ShardID}}
+	if activity.HasHeartbeatDetails(ctx) {
+		if err := activity.GetHeartbeatDetails(ctx, &progress); err != nil {
+			a.logger.Warn("Unable to get heartbeat details, verifying the shard from the start.", tag.ShardID(params.ShardID), tag.Error(err))
+			progress = verifyShardProgress{Result: VerifyShardResult{ShardID: params.ShardID}}
+		}
+	}
+
+	if len(progress.NextPageToken) == 0 {
+		progress.Result.ShardInfoDivergence, err = verifier.VerifyShardInfo(ctx, params.ShardID)
+		if err != nil {
+			return VerifyShardResult{}, err
+		}
+	}
+
+	for {
+		verification, err := verifier.VerifyExecutions(ctx, params.ShardID, params.PageSize, progress.NextPageToken)
+		if errors.Is(err, dualwrite.ErrExecutionsNotListable) {
+			return VerifyShardResult{}, temporal.NewNonRetryableApplicationError(err.Error(), "", err)
+		}
+		if err != nil {
+			return VerifyShardResult{}, err
+		}
+
+		result := &progress.Result
+		result.VerifiedExecutions += verification.Verified
+		result.DivergentExecutions += verification.Divergent
+		for _, divergence := range verification.Divergences {
+			if len(result.Divergences) < dualwrite.MaxReportedDivergences {
+				result.Divergences = append(result.Divergences, divergence)
+			}
+		}
+		progress.NextPageToken = verification.NextPageToken
+		if len(progress.NextPageToken) == 0 {
+			return progress.Result, nil
+		}
+		activity.RecordHeartbeat(ctx, progress)
+	}
+}
@@ -0,0 +1,80 @@
+package dualwriteverification
+
+import (
+	"context"
+	"sync"
+
+	sdkworker "go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
+	"go.temporal.io/server/common/config"
+	"go.temporal.io/server/common/headers"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/persistence/dualwrite"
+	"go.temporal.io/server/common/persistence/serialization"
+	"go.temporal.io/server/common/primitives"
+	workercommon "go.temporal.io/server/service/worker/common"
+	"go.uber.org/fx"
+)
+
+type (
+	// dualWriteVerification represent background work needed for verifying that the shards of the dual-written
+	// stores are in sync.
+	dualWriteVerification struct {
+		numHistoryShards int32
+		verifier         func() (*dualwrite.ShardVerifier, error)
+		logger           log.Logger
+	}
+
+	componentParams struct {
+		fx.In
+		DualWriteDataStoreFactory *dualwrite.DataStoreFactory
+		BlobEncryptor             *serialization.BlobEncryptor
+		PersistenceConfig         *config.Persistence
+		Logger                    log.Logger
+	}
+)
+
+var Module = workercommon.AnnotateWorkerComponentProvider(newComponent)
+
+func newComponent(params componentParams) workercommon.WorkerComponent {
+	return &dualWriteVerification{
+		numHistoryShards: params.PersistenceConfig.NumHistoryShards,
+		verifier: sync.OnceValues(func() (*dualwrite.ShardVerifier, error) {
+			if params.DualWriteDataStoreFactory == nil {
+				return nil, errSecondaryStoreNotConfigured
+			}
+			return params.DualWriteDataStoreFactory.NewShardVerifier(params.BlobEncryptor)
+		}),
+		logger: params.Logger,
+	}
+}
+
+func (wc *dualWriteVerification) RegisterWorkflow(registry sdkworker.Registry) {
+	registry.RegisterWorkflowWithOptions(DualWriteVerificationWorkflow, workflow.RegisterOptions{Name: WorkflowName})
+}
+
+func (wc *dualWriteVerification) DedicatedWorkflowWorkerOptions() *workercommon.DedicatedWorkerOptions {
+	// use default worker
+	return nil
+}
+
+func (wc *dualWriteVerification) RegisterActivities(registry sdkworker.Registry) {
+	registry.RegisterActivity(wc.activities())
+}
+
+func (wc *dualWriteVerification) DedicatedActivityWorkerOptions() *workercommon.DedicatedWorkerOptions {
+	return &workercommon.DedicatedWorkerOptions{
+		TaskQueue: primitives.DualWriteVerificationActivityTQ,
+		Options: sdkworker.Options{
+			BackgroundActivityContext: headers.SetCallerType(context.Background(), headers.CallerTypePreemptable),
+		},
+	}
+}
+
+func (wc *dualWriteVerification) activities() *activities {
+	return &activities{
+		numHistoryShards: wc.numHistoryShards,
+		verifier:         wc.verifier,
+		logger:           wc.logger,
+	}
+}
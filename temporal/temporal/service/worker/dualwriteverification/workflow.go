@@ -0,0 +1,140 @@
+package dualwriteverification
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+	"go.temporal.io/server/common/log/tag"
+	"go.temporal.io/server/common/primitives"
+)
+
+const (
+	// WorkflowName is the name of the system workflow which verifies that the shards of the dual-written stores are in
+	// sync.
+	WorkflowName = "temporal-sys-dual-write-verification-workflow"
+
+	defaultPageSize    = 100
+	defaultConcurrency = 10
+)
+
+type (
+	// WorkflowParams is the parameters for the dual write verification workflow.
+	WorkflowParams struct {
+		// ShardIDs are the shards to verify. All the shards are verified if it is empty.
+		ShardIDs []int32
+		// PageSize is the number of executions which are verified per page. Defaults to 100.
+		PageSize int
+		// Concurrency is the number of shards which are verified concurrently. Defaults to 10.
+		Concurrency int
+	}
+
+	// WorkflowResult is the result of the dual write verification workflow.
+	WorkflowResult struct {
+		// InSyncShards are the shards which are the same in the primary and the secondary store.
+		InSyncShards []int32
+		// OutOfSyncShards are the shards which are different in the primary and the secondary store.
+		OutOfSyncShards []VerifyShardResult
+	}
+
+	// VerifyShardParams is the parameters for VerifyShardActivity.
+	VerifyShardParams struct {
+		ShardID  int32
+		PageSize int
+	}
+
+	// VerifyShardResult is the result of the verification of a shard.
+	VerifyShardResult struct {
+		ShardID int32
+		// ShardInfoDivergence describes the difference of the shard info, and is empty if it is the same.
+		ShardInfoDivergence string
+		// VerifiedExecutions is the number of executions which were verified.
+		VerifiedExecutions int
+		// DivergentExecutions is the number of executions which are different in the stores.
+		DivergentExecutions int
+		// Divergences describes some of the divergent executions.
+		Divergences []string
+	}
+)
+
+var (
+	getShardIDsActivityOptions = workflow.ActivityOptions{
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval: 1 * time.Second,
+		},
+		StartToCloseTimeout:    10 * time.Second,
+		ScheduleToCloseTimeout: 1 * time.Minute,
+	}
+
+	verifyShardActivityOptions = workflow.ActivityOptions{
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    1 * time.Second,
+			MaximumInterval:    1 * time.Minute,
+			BackoffCoefficient: 2,
+		},
+		StartToCloseTimeout: 1 * time.Hour,
+		HeartbeatTimeout:    1 * time.Minute,
+	}
+)
+
+// InSync returns true if the shard is the same in the primary and the secondary store.
+func (r VerifyShardResult) InSync() bool {
+	return r.ShardInfoDivergence == "" && r.DivergentExecutions == 0
+}
+
+// DualWriteVerificationWorkflow compares the shards of the primary and the secondary store of the dual write data
+// store factory, and reports which shards are in sync.
+func DualWriteVerificationWorkflow(ctx workflow.Context, params WorkflowParams) (WorkflowResult, error) {
+	logger := workflow.GetLogger(ctx)
+	logger.Info("Workflow started.", tag.WorkflowType(WorkflowName))
+
+	if params.PageSize <= 0 {
+		params.PageSize = defaultPageSize
+	}
+	if params.Concurrency <= 0 {
+		params.Concurrency = defaultConcurrency
+	}
+
+	ctx = workflow.WithTaskQueue(ctx, primitives.DualWriteVerificationActivityTQ)
+	var a *activities
+
+	shardIDs := params.ShardIDs
+	if len(shardIDs) == 0 {
+		ctx1 := workflow.WithActivityOptions(ctx, getShardIDsActivityOptions)
+		if err := workflow.ExecuteActivity(ctx1, a.GetShardIDsActivity).Get(ctx, &shardIDs); err != nil {
+			return WorkflowResult{}, fmt.Errorf("GetShardIDsActivity: %w", err)
+		}
+	}
+
+	var result WorkflowResult
+	ctx2 := workflow.WithActivityOptions(ctx, verifyShardActivityOptions)
+	for batch := range slices.Chunk(shardIDs, params.Concurrency) {
+		futures := make([]workflow.Future, 0, len(batch))
+		for _, shardID := range batch {
+			futures = append(futures, workflow.ExecuteActivity(ctx2, a.VerifyShardActivity, VerifyShardParams{
+				ShardID:  shardID,
+				PageSize: params.PageSize,
+			}))
+		}
+		for i, future := range futures {
+			var shardResult VerifyShardResult
+			if err := future.Get(ctx, &shardResult); err != nil {
+				return WorkflowResult{}, fmt.Errorf("VerifyShardActivity for shard %d: %w", batch[i], err)
+			}
+			if shardResult.InSync() {
+				result.InSyncShards = append(result.InSyncShards, shardResult.ShardID)
+			} else {
+				result.OutOfSyncShards = append(result.OutOfSyncShards, shardResult)
+			}
+		}
+	}
+
+	logger.Info("Workflow finished successfully.",
+		tag.WorkflowType(WorkflowName),
+		tag.NewInt("in-sync-shards", len(result.InSyncShards)),
+		tag.NewInt("out-of-sync-shards", len(result.OutOfSyncShards)),
+	)
+	return result, nil
+}
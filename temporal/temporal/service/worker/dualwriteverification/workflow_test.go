@@ -0,0 +1,58 @@
+package dualwriteverification
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/server/common/log"
+)
+
+func Test_DualWriteVerificationWorkflow(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	testSuite.SetLogger(log.NewSdkLogger(log.NewTestLogger()))
+	env := testSuite.NewTestWorkflowEnvironment()
+	var a *activities
+
+	env.OnActivity(a.GetShardIDsActivity, mock.Anything).Return([]int32{1, 2, 3}, nil).Once()
+	env.OnActivity(a.VerifyShardActivity, mock.Anything, VerifyShardParams{ShardID: 1, PageSize: 50}).
+		Return(VerifyShardResult{ShardID: 1, VerifiedExecutions: 10}, nil).Once()
+	env.OnActivity(a.VerifyShardActivity, mock.Anything, VerifyShardParams{ShardID: 2, PageSize: 50}).
+		Return(VerifyShardResult{ShardID: 2, VerifiedExecutions: 10, DivergentExecutions: 1, Divergences: []string{"divergence"}}, nil).Once()
+	env.OnActivity(a.VerifyShardActivity, mock.Anything, VerifyShardParams{ShardID: 3, PageSize: 50}).
+		Return(VerifyShardResult{ShardID: 3, ShardInfoDivergence: "shard only exists in the primary store"}, nil).Once()
+
+	env.ExecuteWorkflow(DualWriteVerificationWorkflow, WorkflowParams{PageSize: 50, Concurrency: 2})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	var result WorkflowResult
+	require.NoError(t, env.GetWorkflowResult(&result))
+	require.Equal(t, []int32{1}, result.InSyncShards)
+	require.Len(t, result.OutOfSyncShards, 2)
+	require.Equal(t, int32(2), result.OutOfSyncShards[0].ShardID)
+	require.Equal(t, []string{"divergence"}, result.OutOfSyncShards[0].Divergences)
+	require.Equal(t, int32(3), result.OutOfSyncShards[1].ShardID)
+	env.AssertExpectations(t)
+}
+
+func Test_DualWriteVerificationWorkflow_ShardIDs(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	testSuite.SetLogger(log.NewSdkLogger(log.NewTestLogger()))
+	env := testSuite.NewTestWorkflowEnvironment()
+	var a *activities
+
+	env.OnActivity(a.VerifyShardActivity, mock.Anything, VerifyShardParams{ShardID: 7, PageSize: defaultPageSize}).
+		Return(VerifyShardResult{ShardID: 7}, nil).Once()
+
+	env.ExecuteWorkflow(DualWriteVerificationWorkflow, WorkflowParams{ShardIDs: []int32{7}})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	var result WorkflowResult
+	require.NoError(t, env.GetWorkflowResult(&result))
+	require.Equal(t, []int32{7}, result.InSyncShards)
+	require.Empty(t, result.OutOfSyncShards)
+	env.AssertExpectations(t)
+}
@@ -0,0 +1,198 @@
+package archival
+
+import (
+	"context"
+	"time"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+	"go.temporal.io/server/common/archiver"
+	"go.temporal.io/server/common/archiver/provider"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/log/tag"
+	"go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/searchattribute"
+)
+
+const (
+	VisibilityCompactorWorkflowName = "temporal-sys-visibility-archive-compactor-workflow"
+	VisibilityCompactorActivityName = "temporal-sys-visibility-archive-compactor-activity"
+
+	VisibilityCompactorWFID          = "temporal-sys-visibility-archive-compactor"
+	VisibilityCompactorTaskQueueName = "temporal-sys-visibility-archive-compactor-taskqueue-0"
+)
+
+var (
+	VisibilityCompactorWFStartOptions = client.StartWorkflowOptions{
+		ID:                    VisibilityCompactorWFID,
+		TaskQueue:             VisibilityCompactorTaskQueueName,
+		WorkflowIDReusePolicy: enumspb.WORKFLOW_ID_REUSE_POLICY_ALLOW_DUPLICATE,
+		CronSchedule:          "15 * * * *",
+	}
+)
+
+type (
+	// CompactionReport counts the namespaces of which the archived visibility records were compacted.
+	CompactionReport struct {
+		CompactedNamespaces int
+		// SkippedNamespaces is the number of namespaces of which the archived visibility records couldn't be
+		// compacted.
+		SkippedNamespaces int
+	}
+
+	CompactorActivities struct {
+		metadataManager  persistence.MetadataManager
+		archiverProvider provider.ArchiverProvider
+		saProvider       searchattribute.Provider
+		visibilityIndex  string
+		logger           log.Logger
+	}
+
+	compactedNamespace struct {
+		ID   string
+		Name string
+		URI  string
+	}
+
+	compactorHeartbeatDetails struct {
+		Namespaces     []compactedNamespace
+		NamespaceIndex int
+		Report         CompactionReport
+	}
+)
+
+func NewCompactorActivities(
+	metadataManager persistence.MetadataManager,
+	archiverProvider provider.ArchiverProvider,
+	saProvider searchattribute.Provider,
+	visibilityIndex string,
+	logger log.Logger,
+) *CompactorActivities {
+	return &CompactorActivities{
+		metadataManager:  metadataManager,
+		archiverProvider: archiverProvider,
+		saProvider:       saProvider,
+		visibilityIndex:  visibilityIndex,
+		logger:           logger,
+	}
+}
+
+// VisibilityCompactorWorkflow compacts the archived visibility records which are staged by the visibility archivers,
+// such as the ones of the Parquet format. This workflow is a wrapper around the long running
+// CompactVisibilityArchives activity.
+func VisibilityCompactorWorkflow(ctx workflow.Context) (CompactionReport, error) {
+	activityCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: time.Hour,
+		HeartbeatTimeout:    5 * time.Minute,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    10 * time.Second,
+			BackoffCoefficient: 1.7,
+			MaximumInterval:    5 * time.Minute,
+			MaximumAttempts:    5,
+		},
+	})
+	var report CompactionReport
+	err := workflow.ExecuteActivity(activityCtx, VisibilityCompactorActivityName).Get(ctx, &report)
+	return report, err
+}
+
+// CompactVisibilityArchives compacts the archived visibility records of the namespaces with visibility archival
+// enabled, with the visibility archivers which implement archiver.VisibilityCompactor.
+func (a *CompactorActivities) CompactVisibilityArchives(ctx context.Context) (CompactionReport, error) {
+	var heartbeat compactorHeartbeatDetails
+	if activity.HasHeartbeatDetails(ctx) {
+		if err := activity.GetHeartbeatDetails(ctx, &heartbeat); err != nil {
+			return CompactionReport{}, temporal.NewNonRetryableApplicationError("failed to load previous heartbeat details", "TypeError", err)
+		}
+	} else {
+		namespaces, err := a.listVisibilityArchivedNamespaces(ctx)
+		if err != nil {
+			return CompactionReport{}, err
+		}
+		heartbeat.Namespaces = namespaces
+	}
+	report := &heartbeat.Report
+
+	for heartbeat.NamespaceIndex < len(heartbeat.Namespaces) {
+		ns := heartbeat.Namespaces[heartbeat.NamespaceIndex]
+		compacted, err := a.compactNamespace(ctx, ns)
+		if err != nil {
+			if ctx.Err() != nil {
+				return CompactionReport{}, ctx.Err()
+			}
+			// skip the namespace, so that a namespace with a misconfigured archival doesn't block the others
+			report.SkippedNamespaces++
+			a.logger.Warn("unable to compact archived visibility records of namespace",
+				tag.WorkflowNamespace(ns.Name),
+				tag.ArchivalURI(ns.URI),
+				tag.Error(err),
+			)
+		} else if compacted {
+			report.CompactedNamespaces++
+		}
+		heartbeat.NamespaceIndex++
+		activity.RecordHeartbeat(ctx, heartbeat)
+	}
+
+	a.logger.Info("visibility archive compactor finished",
+		tag.NewInt("compacted-namespaces", report.CompactedNamespaces),
+		tag.NewInt("skipped-namespaces", report.SkippedNamespaces),
+	)
+	return *report, nil
+}
+
+// compactNamespace compacts the archived visibility records of the namespace, and returns whether its visibility
+// archiver implements archiver.VisibilityCompactor.
+func (a *CompactorActivities) compactNamespace(ctx context.Context, ns compactedNamespace) (bool, error) {
+	uri, err := archiver.NewURI(ns.URI)
+	if err != nil {
+		return false, err
+	}
+	visibilityArchiver, err := a.archiverProvider.GetVisibilityArchiver(uri.Scheme())
+	if err != nil {
+		return false, err
+	}
+	compactor, ok := visibilityArchiver.(archiver.VisibilityCompactor)
+	if !ok {
+		return false, nil
+	}
+	saTypeMap, err := a.saProvider.GetSearchAttributes(a.visibilityIndex, false)
+	if err != nil {
+		return false, err
+	}
+	return true, compactor.CompactVisibility(ctx, uri, ns.ID, saTypeMap)
+}
+
+// listVisibilityArchivedNamespaces returns the namespaces with visibility archival enabled, in the order in which
+// they are listed.
+func (a *CompactorActivities) listVisibilityArchivedNamespaces(ctx context.Context) ([]compactedNamespace, error) {
+	var namespaces []compactedNamespace
+	var nextPageToken []byte
+	for {
+		resp, err := a.metadataManager.ListNamespaces(ctx, &persistence.ListNamespacesRequest{
+			PageSize:      listNamespacesPageSize,
+			NextPageToken: nextPageToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, ns := range resp.Namespaces {
+			config := ns.Namespace.GetConfig()
+			if config.GetVisibilityArchivalState() == enumspb.ARCHIVAL_STATE_ENABLED && config.GetVisibilityArchivalUri() != "" {
+				namespaces = append(namespaces, compactedNamespace{
+					ID:   ns.Namespace.GetInfo().GetId(),
+					Name: ns.Namespace.GetInfo().GetName(),
+					URI:  config.GetVisibilityArchivalUri(),
+				})
+			}
+		}
+		nextPageToken = resp.NextPageToken
+		if len(nextPageToken) == 0 {
+			break
+		}
+	}
+	return namespaces, nil
+}
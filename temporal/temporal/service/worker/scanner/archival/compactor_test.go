@@ -0,0 +1,93 @@
+package archival
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/testsuite"
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common/archiver"
+	"go.temporal.io/server/common/archiver/provider"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/searchattribute"
+	"go.uber.org/mock/gomock"
+)
+
+type compactingVisibilityArchiver struct {
+	*archiver.MockVisibilityArchiver
+	*archiver.MockVisibilityCompactor
+}
+
+func newTestArchivedNamespace(id string, visibilityState enumspb.ArchivalState, uri string) *persistence.GetNamespaceResponse {
+	return &persistence.GetNamespaceResponse{
+		Namespace: &persistencespb.NamespaceDetail{
+			Info: &persistencespb.NamespaceInfo{Id: id, Name: id + "-name"},
+			Config: &persistencespb.NamespaceConfig{
+				VisibilityArchivalState: visibilityState,
+				VisibilityArchivalUri:   uri,
+			},
+		},
+	}
+}
+
+func Test_CompactVisibilityArchives(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestActivityEnvironment()
+
+	ctrl := gomock.NewController(t)
+	metadataManager := persistence.NewMockMetadataManager(ctrl)
+	archiverProvider := provider.NewMockArchiverProvider(ctrl)
+	saProvider := searchattribute.NewMockProvider(ctrl)
+
+	metadataManager.EXPECT().ListNamespaces(gomock.Any(), &persistence.ListNamespacesRequest{
+		PageSize: listNamespacesPageSize,
+	}).Return(&persistence.ListNamespacesResponse{
+		Namespaces: []*persistence.GetNamespaceResponse{
+			newTestArchivedNamespace("compacted", enumspb.ARCHIVAL_STATE_ENABLED, "file:///tmp/archive"),
+			newTestArchivedNamespace("not-archived", enumspb.ARCHIVAL_STATE_DISABLED, "file:///tmp/archive"),
+			newTestArchivedNamespace("not-compacted", enumspb.ARCHIVAL_STATE_ENABLED, "gs://bucket/archive"),
+		},
+		NextPageToken: []byte("token"),
+	}, nil)
+	metadataManager.EXPECT().ListNamespaces(gomock.Any(), &persistence.ListNamespacesRequest{
+		PageSize:      listNamespacesPageSize,
+		NextPageToken: []byte("token"),
+	}).Return(&persistence.ListNamespacesResponse{
+		Namespaces: []*persistence.GetNamespaceResponse{
+			newTestArchivedNamespace("failed", enumspb.ARCHIVAL_STATE_ENABLED, "file:///tmp/failed"),
+			newTestArchivedNamespace("unknown-scheme", enumspb.ARCHIVAL_STATE_ENABLED, "unknown:///tmp/archive"),
+		},
+	}, nil)
+
+	saTypeMap := searchattribute.TestNameTypeMap()
+	saProvider.EXPECT().GetSearchAttributes("index-name", false).Return(saTypeMap, nil).Times(2)
+	compactor := archiver.NewMockVisibilityCompactor(ctrl)
+	compactor.EXPECT().CompactVisibility(gomock.Any(), gomock.Any(), "compacted", saTypeMap).Return(nil)
+	compactor.EXPECT().CompactVisibility(gomock.Any(), gomock.Any(), "failed", saTypeMap).Return(errors.New("failed"))
+	fileArchiver := &compactingVisibilityArchiver{
+		MockVisibilityArchiver:  archiver.NewMockVisibilityArchiver(ctrl),
+		MockVisibilityCompactor: compactor,
+	}
+	archiverProvider.EXPECT().GetVisibilityArchiver("file").Return(fileArchiver, nil).Times(2)
+	archiverProvider.EXPECT().GetVisibilityArchiver("gs").Return(archiver.NewMockVisibilityArchiver(ctrl), nil)
+	archiverProvider.EXPECT().GetVisibilityArchiver("unknown").Return(nil, provider.ErrUnknownScheme)
+
+	a := NewCompactorActivities(
+		metadataManager,
+		archiverProvider,
+		saProvider,
+		"index-name",
+		log.NewTestLogger(),
+	)
+	env.RegisterActivityWithOptions(a.CompactVisibilityArchives, activity.RegisterOptions{Name: VisibilityCompactorActivityName})
+
+	val, err := env.ExecuteActivity(VisibilityCompactorActivityName)
+	require.NoError(t, err)
+	var report CompactionReport
+	require.NoError(t, val.Get(&report))
+	require.Equal(t, CompactionReport{CompactedNamespaces: 1, SkippedNamespaces: 2}, report)
+}
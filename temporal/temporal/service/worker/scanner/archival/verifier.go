@@ -0,0 +1,271 @@
+package archival
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+	"go.temporal.io/server/api/adminservice/v1"
+	enumsspb "go.temporal.io/server/api/enums/v1"
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/log/tag"
+	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/quotas"
+)
+
+const (
+	ArchivalVerifierWorkflowName = "temporal-sys-archival-verifier-workflow"
+	ArchivalVerifierActivityName = "temporal-sys-archival-verifier-activity"
+
+	ArchivalVerifierWFID          = "temporal-sys-archival-verifier"
+	ArchivalVerifierTaskQueueName = "temporal-sys-archival-verifier-taskqueue-0"
+
+	defaultPageSize        = 100
+	listNamespacesPageSize = 100
+
+	// MaxFailuresPerNamespace is the maximum number of failed runs which are reported for each namespace.
+	MaxFailuresPerNamespace = 10
+)
+
+var (
+	ArchivalVerifierWFStartOptions = client.StartWorkflowOptions{
+		ID:                    ArchivalVerifierWFID,
+		TaskQueue:             ArchivalVerifierTaskQueueName,
+		WorkflowIDReusePolicy: enumspb.WORKFLOW_ID_REUSE_POLICY_ALLOW_DUPLICATE,
+		CronSchedule:          "0 6 * * *",
+	}
+)
+
+type (
+	ArchivalVerifierInput struct {
+		// Namespace is the namespace of which the archived histories are verified. The archived histories of all the
+		// namespaces with history and visibility archival enabled are verified if it is empty.
+		Namespace string
+		// Query is the visibility archival query of the runs which are verified. All the archived runs are verified
+		// if it is empty.
+		Query string
+		// PageSize is the number of runs which are verified per request. Defaults to 100.
+		PageSize int
+	}
+
+	// VerificationReport counts the archived runs by the result of their verification, and lists the runs which
+	// failed verification.
+	VerificationReport struct {
+		VerifiedRuns   int
+		CorruptedRuns  int
+		TruncatedRuns  int
+		MissingRuns    int
+		UnverifiedRuns int
+		// SkippedNamespaces is the number of namespaces of which the archived histories couldn't be listed.
+		SkippedNamespaces int
+		// Failures are the first runs of each namespace which failed verification, by namespace.
+		Failures map[string][]FailedRun
+	}
+
+	FailedRun struct {
+		WorkflowID string
+		RunID      string
+		Status     string
+		Details    string
+	}
+
+	Activities struct {
+		metadataManager persistence.MetadataManager
+		adminClient     adminservice.AdminServiceClient
+		verifierRPS     dynamicconfig.FloatPropertyFn
+		metricsHandler  metrics.Handler
+		logger          log.Logger
+	}
+
+	heartbeatDetails struct {
+		Namespaces     []string
+		NamespaceIndex int
+		PageToken      []byte
+		Report         VerificationReport
+	}
+)
+
+func NewActivities(
+	metadataManager persistence.MetadataManager,
+	adminClient adminservice.AdminServiceClient,
+	verifierRPS dynamicconfig.FloatPropertyFn,
+	metricsHandler metrics.Handler,
+	logger log.Logger,
+) *Activities {
+	return &Activities{
+		metadataManager: metadataManager,
+		adminClient:     adminClient,
+		verifierRPS:     verifierRPS,
+		metricsHandler:  metricsHandler.WithTags(metrics.OperationTag(metrics.ArchivalVerifierScope)),
+		logger:          logger,
+	}
+}
+
+// ArchivalVerifierWorkflow re-reads the archived histories and verifies them against their integrity manifests, and
+// returns the verification report. This workflow is a wrapper around the long running VerifyArchives activity.
+func ArchivalVerifierWorkflow(ctx workflow.Context, input ArchivalVerifierInput) (VerificationReport, error) {
+	activityCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		// Give the activity enough time to read back all the archives
+		StartToCloseTimeout: 12 * time.Hour,
+		HeartbeatTimeout:    5 * time.Minute,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    10 * time.Second,
+			BackoffCoefficient: 1.7,
+			MaximumInterval:    5 * time.Minute,
+		},
+	})
+	var report VerificationReport
+	err := workflow.ExecuteActivity(activityCtx, ArchivalVerifierActivityName, input).Get(ctx, &report)
+	return report, err
+}
+
+// VerifyArchives pages through the archived runs of the namespaces and verifies their histories with the
+// VerifyArchivedHistory admin API.
+func (a *Activities) VerifyArchives(ctx context.Context, input ArchivalVerifierInput) (VerificationReport, error) {
+	if input.PageSize <= 0 {
+		input.PageSize = defaultPageSize
+	}
+
+	var heartbeat heartbeatDetails
+	if activity.HasHeartbeatDetails(ctx) {
+		if err := activity.GetHeartbeatDetails(ctx, &heartbeat); err != nil {
+			return VerificationReport{}, temporal.NewNonRetryableApplicationError("failed to load previous heartbeat details", "TypeError", err)
+		}
+	} else if input.Namespace != "" {
+		heartbeat.Namespaces = []string{input.Namespace}
+	} else {
+		namespaces, err := a.listArchivedNamespaces(ctx)
+		if err != nil {
+			return VerificationReport{}, err
+		}
+		heartbeat.Namespaces = namespaces
+	}
+	rateLimiter := quotas.NewDefaultOutgoingRateLimiter(quotas.RateFn(a.verifierRPS))
+	report := &heartbeat.Report
+
+	for heartbeat.NamespaceIndex < len(heartbeat.Namespaces) {
+		nsName := heartbeat.Namespaces[heartbeat.NamespaceIndex]
+		if err := rateLimiter.Wait(ctx); err != nil {
+			return VerificationReport{}, err
+		}
+		resp, err := a.adminClient.VerifyArchivedHistory(ctx, &adminservice.VerifyArchivedHistoryRequest{
+			Namespace:     nsName,
+			Query:         input.Query,
+			PageSize:      int32(input.PageSize),
+			NextPageToken: heartbeat.PageToken,
+			FailuresOnly:  true,
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return VerificationReport{}, ctx.Err()
+			}
+			// skip the namespace, so that a namespace with a misconfigured archival doesn't block the others
+			report.SkippedNamespaces++
+			metrics.ScavengerValidationSkipsCount.With(a.metricsHandler).Record(1)
+			a.logger.Warn("unable to verify archived histories of namespace",
+				tag.WorkflowNamespace(nsName),
+				tag.Error(err),
+			)
+			heartbeat.PageToken = nil
+			heartbeat.NamespaceIndex++
+			activity.RecordHeartbeat(ctx, heartbeat)
+			continue
+		}
+
+		report.VerifiedRuns += int(resp.GetVerifiedCount())
+		metrics.ScavengerValidationRequestsCount.With(a.metricsHandler).Record(int64(resp.GetVerifiedCount()))
+		for _, result := range resp.GetResults() {
+			report.addFailure(nsName, result)
+			metrics.ScavengerValidationFailuresCount.With(a.metricsHandler).Record(1, metrics.FailureTag(result.GetStatus().String()))
+			a.logger.Warn("archived history failed verification.",
+				tag.WorkflowNamespace(nsName),
+				tag.WorkflowID(result.GetExecution().GetWorkflowId()),
+				tag.WorkflowRunID(result.GetExecution().GetRunId()),
+				tag.NewStringTag("integrity-status", result.GetStatus().String()),
+				tag.Value(result.GetDetails()),
+			)
+		}
+
+		heartbeat.PageToken = resp.GetNextPageToken()
+		if len(heartbeat.PageToken) == 0 {
+			heartbeat.NamespaceIndex++
+		}
+		activity.RecordHeartbeat(ctx, heartbeat)
+	}
+
+	a.logger.Info("archival verifier finished",
+		tag.NewInt("verified-runs", report.VerifiedRuns),
+		tag.NewInt("corrupted-runs", report.CorruptedRuns),
+		tag.NewInt("truncated-runs", report.TruncatedRuns),
+		tag.NewInt("missing-runs", report.MissingRuns),
+		tag.NewInt("unverified-runs", report.UnverifiedRuns),
+		tag.NewInt("skipped-namespaces", report.SkippedNamespaces),
+	)
+	return *report, nil
+}
+
+// listArchivedNamespaces returns the names of the namespaces of which the archived runs can be listed, which are the
+// ones with both history and visibility archival enabled.
+func (a *Activities) listArchivedNamespaces(ctx context.Context) ([]string, error) {
+	var namespaces []string
+	var nextPageToken []byte
+	for {
+		resp, err := a.metadataManager.ListNamespaces(ctx, &persistence.ListNamespacesRequest{
+			PageSize:      listNamespacesPageSize,
+			NextPageToken: nextPageToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, ns := range resp.Namespaces {
+			config := ns.Namespace.GetConfig()
+			if config.GetHistoryArchivalState() == enumspb.ARCHIVAL_STATE_ENABLED &&
+				config.GetVisibilityArchivalState() == enumspb.ARCHIVAL_STATE_ENABLED {
+				namespaces = append(namespaces, ns.Namespace.GetInfo().GetName())
+			}
+		}
+		nextPageToken = resp.NextPageToken
+		if len(nextPageToken) == 0 {
+			break
+		}
+	}
+	sort.Strings(namespaces)
+	return namespaces, nil
+}
+
+func (r *VerificationReport) addFailure(nsName string, result *adminservice.ArchivedHistoryVerification) {
+	switch result.GetStatus() {
+	case enumsspb.ARCHIVAL_INTEGRITY_STATUS_OK:
+		return
+	case enumsspb.ARCHIVAL_INTEGRITY_STATUS_TRUNCATED:
+		r.TruncatedRuns++
+	case enumsspb.ARCHIVAL_INTEGRITY_STATUS_MISSING:
+		r.MissingRuns++
+	case enumsspb.ARCHIVAL_INTEGRITY_STATUS_UNVERIFIED:
+		r.UnverifiedRuns++
+	default:
+		r.CorruptedRuns++
+	}
+	if r.Failures == nil {
+		r.Failures = make(map[string][]FailedRun)
+	}
+	if len(r.Failures[nsName]) < MaxFailuresPerNamespace {
+		r.Failures[nsName] = append(r.Failures[nsName], newFailedRun(result))
+	}
+}
+
+func newFailedRun(result *adminservice.ArchivedHistoryVerification) FailedRun {
+	return FailedRun{
+		WorkflowID: result.GetExecution().GetWorkflowId(),
+		RunID:      result.GetExecution().GetRunId(),
+		Status:     result.GetStatus().String(),
+		Details:    result.GetDetails(),
+	}
+}
@@ -0,0 +1,178 @@
+package archival
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/server/api/adminservice/v1"
+	"go.temporal.io/server/api/adminservicemock/v1"
+	enumsspb "go.temporal.io/server/api/enums/v1"
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/common/persistence"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestNamespace(name string, historyState, visibilityState enumspb.ArchivalState) *persistence.GetNamespaceResponse {
+	return &persistence.GetNamespaceResponse{
+		Namespace: &persistencespb.NamespaceDetail{
+			Info: &persistencespb.NamespaceInfo{Name: name},
+			Config: &persistencespb.NamespaceConfig{
+				HistoryArchivalState:    historyState,
+				VisibilityArchivalState: visibilityState,
+			},
+		},
+	}
+}
+
+func newTestVerification(runID string, status enumsspb.ArchivalIntegrityStatus) *adminservice.ArchivedHistoryVerification {
+	return &adminservice.ArchivedHistoryVerification{
+		Execution: &commonpb.WorkflowExecution{WorkflowId: "workflow-id", RunId: runID},
+		Status:    status,
+		Details:   "details",
+	}
+}
+
+func Test_VerificationReport_addFailure(t *testing.T) {
+	var report VerificationReport
+	for i := 0; i < MaxFailuresPerNamespace+2; i++ {
+		report.addFailure("test-namespace", newTestVerification("run-id", enumsspb.ARCHIVAL_INTEGRITY_STATUS_CORRUPTED))
+	}
+	report.addFailure("test-namespace", newTestVerification("run-id", enumsspb.ARCHIVAL_INTEGRITY_STATUS_OK))
+
+	require.Equal(t, MaxFailuresPerNamespace+2, report.CorruptedRuns)
+	require.Len(t, report.Failures["test-namespace"], MaxFailuresPerNamespace)
+	require.Equal(t, enumsspb.ARCHIVAL_INTEGRITY_STATUS_CORRUPTED.String(), report.Failures["test-namespace"][0].Status)
+}
+
+func Test_VerifyArchives(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestActivityEnvironment()
+
+	ctrl := gomock.NewController(t)
+	metadataManager := persistence.NewMockMetadataManager(ctrl)
+	adminClient := adminservicemock.NewMockAdminServiceClient(ctrl)
+
+	metadataManager.EXPECT().ListNamespaces(gomock.Any(), &persistence.ListNamespacesRequest{
+		PageSize: listNamespacesPageSize,
+	}).Return(&persistence.ListNamespacesResponse{
+		Namespaces: []*persistence.GetNamespaceResponse{
+			newTestNamespace("namespace-b", enumspb.ARCHIVAL_STATE_ENABLED, enumspb.ARCHIVAL_STATE_ENABLED),
+			newTestNamespace("not-archived", enumspb.ARCHIVAL_STATE_DISABLED, enumspb.ARCHIVAL_STATE_ENABLED),
+		},
+		NextPageToken: []byte("token"),
+	}, nil)
+	metadataManager.EXPECT().ListNamespaces(gomock.Any(), &persistence.ListNamespacesRequest{
+		PageSize:      listNamespacesPageSize,
+		NextPageToken: []byte("token"),
+	}).Return(&persistence.ListNamespacesResponse{
+		Namespaces: []*persistence.GetNamespaceResponse{
+			newTestNamespace("namespace-a", enumspb.ARCHIVAL_STATE_ENABLED, enumspb.ARCHIVAL_STATE_ENABLED),
+			newTestNamespace("namespace-c", enumspb.ARCHIVAL_STATE_ENABLED, enumspb.ARCHIVAL_STATE_ENABLED),
+		},
+	}, nil)
+
+	var verifiedNamespaces []string
+	adminClient.EXPECT().VerifyArchivedHistory(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *adminservice.VerifyArchivedHistoryRequest, _ ...any) (*adminservice.VerifyArchivedHistoryResponse, error) {
+			require.True(t, request.GetFailuresOnly())
+			require.Equal(t, int32(defaultPageSize), request.GetPageSize())
+			verifiedNamespaces = append(verifiedNamespaces, request.GetNamespace())
+			switch {
+			case request.GetNamespace() == "namespace-a" && request.GetNextPageToken() == nil:
+				return &adminservice.VerifyArchivedHistoryResponse{
+					Results: []*adminservice.ArchivedHistoryVerification{
+						newTestVerification("corrupted", enumsspb.ARCHIVAL_INTEGRITY_STATUS_CORRUPTED),
+						newTestVerification("missing", enumsspb.ARCHIVAL_INTEGRITY_STATUS_MISSING),
+					},
+					VerifiedCount: 5,
+					NextPageToken: []byte("token"),
+				}, nil
+			case request.GetNamespace() == "namespace-a":
+				return &adminservice.VerifyArchivedHistoryResponse{
+					Results: []*adminservice.ArchivedHistoryVerification{
+						newTestVerification("truncated", enumsspb.ARCHIVAL_INTEGRITY_STATUS_TRUNCATED),
+					},
+					VerifiedCount: 2,
+				}, nil
+			case request.GetNamespace() == "namespace-b":
+				return nil, serviceerror.NewInvalidArgument("namespace is not configured for visibility archival")
+			default:
+				return &adminservice.VerifyArchivedHistoryResponse{
+					Results: []*adminservice.ArchivedHistoryVerification{
+						newTestVerification("unverified", enumsspb.ARCHIVAL_INTEGRITY_STATUS_UNVERIFIED),
+					},
+					VerifiedCount: 3,
+				}, nil
+			}
+		},
+	).Times(4)
+
+	a := NewActivities(
+		metadataManager,
+		adminClient,
+		dynamicconfig.GetFloatPropertyFn(1000),
+		metrics.NoopMetricsHandler,
+		log.NewTestLogger(),
+	)
+	env.RegisterActivityWithOptions(a.VerifyArchives, activity.RegisterOptions{Name: ArchivalVerifierActivityName})
+
+	val, err := env.ExecuteActivity(ArchivalVerifierActivityName, ArchivalVerifierInput{})
+	require.NoError(t, err)
+	var report VerificationReport
+	require.NoError(t, val.Get(&report))
+
+	require.Equal(t, []string{"namespace-a", "namespace-a", "namespace-b", "namespace-c"}, verifiedNamespaces)
+	require.Equal(t, 10, report.VerifiedRuns)
+	require.Equal(t, 1, report.CorruptedRuns)
+	require.Equal(t, 1, report.TruncatedRuns)
+	require.Equal(t, 1, report.MissingRuns)
+	require.Equal(t, 1, report.UnverifiedRuns)
+	require.Equal(t, 1, report.SkippedNamespaces)
+	require.Len(t, report.Failures["namespace-a"], 3)
+	require.Equal(t, "unverified", report.Failures["namespace-c"][0].RunID)
+}
+
+func Test_VerifyArchives_Namespace(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestActivityEnvironment()
+
+	ctrl := gomock.NewController(t)
+	adminClient := adminservicemock.NewMockAdminServiceClient(ctrl)
+	adminClient.EXPECT().VerifyArchivedHistory(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *adminservice.VerifyArchivedHistoryRequest, _ ...any) (*adminservice.VerifyArchivedHistoryResponse, error) {
+			require.Equal(t, "test-namespace", request.GetNamespace())
+			require.Equal(t, "WorkflowType = 'test'", request.GetQuery())
+			require.Equal(t, int32(10), request.GetPageSize())
+			return &adminservice.VerifyArchivedHistoryResponse{VerifiedCount: 4}, nil
+		},
+	)
+
+	a := NewActivities(
+		persistence.NewMockMetadataManager(ctrl),
+		adminClient,
+		dynamicconfig.GetFloatPropertyFn(1000),
+		metrics.NoopMetricsHandler,
+		log.NewTestLogger(),
+	)
+	env.RegisterActivityWithOptions(a.VerifyArchives, activity.RegisterOptions{Name: ArchivalVerifierActivityName})
+
+	val, err := env.ExecuteActivity(ArchivalVerifierActivityName, ArchivalVerifierInput{
+		Namespace: "test-namespace",
+		Query:     "WorkflowType = 'test'",
+		PageSize:  10,
+	})
+	require.NoError(t, err)
+	var report VerificationReport
+	require.NoError(t, val.Get(&report))
+	require.Equal(t, 4, report.VerifiedRuns)
+	require.Empty(t, report.Failures)
+}
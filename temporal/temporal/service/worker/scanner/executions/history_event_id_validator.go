@@ -59,6 +59,7 @@ func (v *historyEventIDValidator) Validate(
 		MaxEventID:    common.FirstEventID + 1,
 		BranchToken:   currentVersionHistory.BranchToken,
 		ShardID:       v.shardID,
+		NamespaceID:   mutableState.GetExecutionInfo().GetNamespaceId(),
 		PageSize:      1,
 		NextPageToken: nil,
 	})
@@ -193,6 +193,7 @@ func (t *task) getPaginationFn() collection.PaginationFn[*persistencespb.Workflo
 			PageSize:  executionsPageSize,
 			PageToken: paginationToken,
 		}
+		// The scanned executions are deleted by handleFailures, so the scan must not be served by a read replica.
 		resp, err := t.executionManager.ListConcreteExecutions(t.ctx, req)
 		if err != nil {
 			return nil, nil, err
@@ -311,7 +311,9 @@ func (s *Scavenger) getPaginationFn(
 			PageSize:      pageSize,
 			NextPageToken: paginationToken,
 		}
-		resp, err := s.db.GetAllHistoryTreeBranches(ctx, req)
+		// branches are verified against mutable state before they are deleted, so listing them may be served by a
+		// read replica
+		resp, err := s.db.GetAllHistoryTreeBranches(persistence.WithStaleReadsAllowed(ctx), req)
 		if err != nil {
 			return nil, nil, err
 		}
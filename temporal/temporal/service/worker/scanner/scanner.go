@@ -14,6 +14,7 @@ import (
 	"go.temporal.io/server/api/historyservice/v1"
 	"go.temporal.io/server/api/matchingservice/v1"
 	"go.temporal.io/server/common"
+	"go.temporal.io/server/common/archiver/provider"
 	"go.temporal.io/server/common/backoff"
 	"go.temporal.io/server/common/config"
 	"go.temporal.io/server/common/dynamicconfig"
@@ -27,7 +28,10 @@ import (
 	"go.temporal.io/server/common/persistence/serialization"
 	"go.temporal.io/server/common/persistence/visibility/manager"
 	"go.temporal.io/server/common/sdk"
+	"go.temporal.io/server/common/searchattribute"
+	"go.temporal.io/server/service/worker/scanner/archival"
 	"go.temporal.io/server/service/worker/scanner/build_ids"
+	"go.temporal.io/server/service/worker/scanner/visibility"
 )
 
 type (
@@ -71,6 +75,24 @@ type (
 		RemovableBuildIdDurationSinceDefault dynamicconfig.DurationPropertyFn
 		// BuildIdScavengerVisibilityRPS is the rate limit for visibility calls from the build ID scavenger
 		BuildIdScavengerVisibilityRPS dynamicconfig.FloatPropertyFn
+
+		// VisibilityScannerEnabled indicates if the visibility scanner should be started as part of scanner
+		VisibilityScannerEnabled dynamicconfig.BoolPropertyFn
+		// VisibilityScannerSampleRate is the fraction of the executions which are checked by the visibility scanner
+		VisibilityScannerSampleRate dynamicconfig.FloatPropertyFn
+		// VisibilityScannerRPS is the rate limit for visibility calls from the visibility scanner
+		VisibilityScannerRPS dynamicconfig.FloatPropertyFn
+		// VisibilityScannerAutoRepair indicates if the visibility scanner should regenerate the visibility tasks of
+		// the drifted executions
+		VisibilityScannerAutoRepair dynamicconfig.BoolPropertyFn
+
+		// ArchivalVerifierEnabled indicates if the archival verifier should be started as part of scanner
+		ArchivalVerifierEnabled dynamicconfig.BoolPropertyFn
+		// ArchivalVerifierRPS is the rate limit for archival verification calls from the archival verifier
+		ArchivalVerifierRPS dynamicconfig.FloatPropertyFn
+		// VisibilityArchiveCompactorEnabled indicates if the visibility archive compactor should be started as part of
+		// scanner
+		VisibilityArchiveCompactorEnabled dynamicconfig.BoolPropertyFn
 	}
 
 	// scannerContext is the context object that gets
@@ -83,6 +105,9 @@ type (
 		executionManager   persistence.ExecutionManager
 		taskManager        persistence.TaskManager
 		visibilityManager  manager.VisibilityManager
+		saMapperProvider   searchattribute.MapperProvider
+		saProvider         searchattribute.Provider
+		archiverProvider   provider.ArchiverProvider
 		metadataManager    persistence.MetadataManager
 		historyClient      historyservice.HistoryServiceClient
 		matchingClient     matchingservice.MatchingServiceClient
@@ -116,6 +141,9 @@ func New(
 	executionManager persistence.ExecutionManager,
 	metadataManager persistence.MetadataManager,
 	visibilityManager manager.VisibilityManager,
+	saMapperProvider searchattribute.MapperProvider,
+	saProvider searchattribute.Provider,
+	archiverProvider provider.ArchiverProvider,
 	taskManager persistence.TaskManager,
 	historyClient historyservice.HistoryServiceClient,
 	adminClient adminservice.AdminServiceClient,
@@ -134,6 +162,9 @@ func New(
 			executionManager:   executionManager,
 			taskManager:        taskManager,
 			visibilityManager:  visibilityManager,
+			saMapperProvider:   saMapperProvider,
+			saProvider:         saProvider,
+			archiverProvider:   archiverProvider,
 			metadataManager:    metadataManager,
 			historyClient:      historyClient,
 			matchingClient:     matchingClient,
@@ -209,6 +240,78 @@ func (s *Scanner) Start() error {
 		}
 	}
 
+	if s.context.cfg.VisibilityScannerEnabled() {
+		s.wg.Add(1)
+		go s.startWorkflowWithRetry(ctx, visibility.VisibilityScannerWFStartOptions, visibility.VisibilityScannerWorkflowName)
+
+		visibilityActivities := visibility.NewActivities(
+			s.context.cfg.Persistence.NumHistoryShards,
+			s.context.executionManager,
+			s.context.visibilityManager,
+			s.context.namespaceRegistry,
+			s.context.historyClient,
+			s.context.saMapperProvider,
+			s.context.cfg.VisibilityScannerSampleRate,
+			s.context.cfg.VisibilityScannerRPS,
+			s.context.cfg.VisibilityScannerAutoRepair,
+			s.context.metricsHandler,
+			s.context.logger,
+		)
+
+		work := s.context.sdkClientFactory.NewWorker(s.context.sdkClientFactory.GetSystemClient(), visibility.VisibilityScannerTaskQueueName, workerOpts)
+		work.RegisterWorkflowWithOptions(visibility.VisibilityScannerWorkflow, workflow.RegisterOptions{Name: visibility.VisibilityScannerWorkflowName})
+		work.RegisterActivityWithOptions(visibilityActivities.ScanVisibility, activity.RegisterOptions{Name: visibility.VisibilityScannerActivityName})
+
+		// TODO: Nothing is gracefully stopping these workers or listening for fatal errors.
+		if err := work.Start(); err != nil {
+			return err
+		}
+	}
+
+	if s.context.cfg.ArchivalVerifierEnabled() {
+		s.wg.Add(1)
+		go s.startWorkflowWithRetry(ctx, archival.ArchivalVerifierWFStartOptions, archival.ArchivalVerifierWorkflowName)
+
+		archivalActivities := archival.NewActivities(
+			s.context.metadataManager,
+			s.context.adminClient,
+			s.context.cfg.ArchivalVerifierRPS,
+			s.context.metricsHandler,
+			s.context.logger,
+		)
+
+		work := s.context.sdkClientFactory.NewWorker(s.context.sdkClientFactory.GetSystemClient(), archival.ArchivalVerifierTaskQueueName, workerOpts)
+		work.RegisterWorkflowWithOptions(archival.ArchivalVerifierWorkflow, workflow.RegisterOptions{Name: archival.ArchivalVerifierWorkflowName})
+		work.RegisterActivityWithOptions(archivalActivities.VerifyArchives, activity.RegisterOptions{Name: archival.ArchivalVerifierActivityName})
+
+		// TODO: Nothing is gracefully stopping these workers or listening for fatal errors.
+		if err := work.Start(); err != nil {
+			return err
+		}
+	}
+
+	if s.context.cfg.VisibilityArchiveCompactorEnabled() {
+		s.wg.Add(1)
+		go s.startWorkflowWithRetry(ctx, archival.VisibilityCompactorWFStartOptions, archival.VisibilityCompactorWorkflowName)
+
+		compactorActivities := archival.NewCompactorActivities(
+			s.context.metadataManager,
+			s.context.archiverProvider,
+			s.context.saProvider,
+			s.context.visibilityManager.GetIndexName(),
+			s.context.logger,
+		)
+
+		work := s.context.sdkClientFactory.NewWorker(s.context.sdkClientFactory.GetSystemClient(), archival.VisibilityCompactorTaskQueueName, workerOpts)
+		work.RegisterWorkflowWithOptions(archival.VisibilityCompactorWorkflow, workflow.RegisterOptions{Name: archival.VisibilityCompactorWorkflowName})
+		work.RegisterActivityWithOptions(compactorActivities.CompactVisibilityArchives, activity.RegisterOptions{Name: archival.VisibilityCompactorActivityName})
+
+		// TODO: Nothing is gracefully stopping these workers or listening for fatal errors.
+		if err := work.Start(); err != nil {
+			return err
+		}
+	}
+
 	// TODO: There's no reason to register all activities and workflows on every task queue.
 	for _, tl := range workerTaskQueueNames {
 		work := s.context.sdkClientFactory.NewWorker(s.context.sdkClientFactory.GetSystemClient(), tl, workerOpts)
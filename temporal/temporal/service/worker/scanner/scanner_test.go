@@ -17,9 +17,12 @@ import (
 	"go.temporal.io/server/common/namespace"
 	p "go.temporal.io/server/common/persistence"
 	"go.temporal.io/server/common/persistence/serialization"
+	"go.temporal.io/server/common/persistence/visibility/manager"
 	"go.temporal.io/server/common/sdk"
 	"go.temporal.io/server/common/testing/mocksdk"
+	"go.temporal.io/server/service/worker/scanner/archival"
 	"go.temporal.io/server/service/worker/scanner/build_ids"
+	"go.temporal.io/server/service/worker/scanner/visibility"
 	"go.uber.org/mock/gomock"
 )
 
@@ -53,6 +56,18 @@ func (s *scannerTestSuite) TestScannerEnabled() {
 		WFTypeName:    build_ids.BuildIdScavangerWorkflowName,
 		TaskQueueName: build_ids.BuildIdScavengerTaskQueueName,
 	}
+	visibilityScanner := expectedScanner{
+		WFTypeName:    visibility.VisibilityScannerWorkflowName,
+		TaskQueueName: visibility.VisibilityScannerTaskQueueName,
+	}
+	archivalVerifier := expectedScanner{
+		WFTypeName:    archival.ArchivalVerifierWorkflowName,
+		TaskQueueName: archival.ArchivalVerifierTaskQueueName,
+	}
+	visibilityCompactor := expectedScanner{
+		WFTypeName:    archival.VisibilityCompactorWorkflowName,
+		TaskQueueName: archival.VisibilityCompactorTaskQueueName,
+	}
 
 	type testCase struct {
 		Name                     string
@@ -60,6 +75,9 @@ func (s *scannerTestSuite) TestScannerEnabled() {
 		TaskQueueScannerEnabled  bool
 		HistoryScannerEnabled    bool
 		BuildIdScavengerEnabled  bool
+		VisibilityScannerEnabled bool
+		ArchivalVerifierEnabled  bool
+		CompactorEnabled         bool
 		DefaultStore             string
 		ExpectedScanners         []expectedScanner
 	}
@@ -155,14 +173,53 @@ func (s *scannerTestSuite) TestScannerEnabled() {
 			DefaultStore:             config.StoreTypeSQL,
 			ExpectedScanners:         []expectedScanner{buildIdScavenger},
 		},
+		{
+			Name:                     "VisibilityScannerNoSQL",
+			VisibilityScannerEnabled: true,
+			DefaultStore:             config.StoreTypeNoSQL,
+			ExpectedScanners:         []expectedScanner{visibilityScanner},
+		},
+		{
+			Name:                     "VisibilityScannerSQL",
+			VisibilityScannerEnabled: true,
+			DefaultStore:             config.StoreTypeSQL,
+			ExpectedScanners:         []expectedScanner{visibilityScanner},
+		},
+		{
+			Name:                    "ArchivalVerifierNoSQL",
+			ArchivalVerifierEnabled: true,
+			DefaultStore:            config.StoreTypeNoSQL,
+			ExpectedScanners:        []expectedScanner{archivalVerifier},
+		},
+		{
+			Name:                    "ArchivalVerifierSQL",
+			ArchivalVerifierEnabled: true,
+			DefaultStore:            config.StoreTypeSQL,
+			ExpectedScanners:        []expectedScanner{archivalVerifier},
+		},
+		{
+			Name:             "VisibilityArchiveCompactorNoSQL",
+			CompactorEnabled: true,
+			DefaultStore:     config.StoreTypeNoSQL,
+			ExpectedScanners: []expectedScanner{visibilityCompactor},
+		},
+		{
+			Name:             "VisibilityArchiveCompactorSQL",
+			CompactorEnabled: true,
+			DefaultStore:     config.StoreTypeSQL,
+			ExpectedScanners: []expectedScanner{visibilityCompactor},
+		},
 		{
 			Name:                     "AllScannersSQL",
 			ExecutionsScannerEnabled: true,
 			TaskQueueScannerEnabled:  true,
 			HistoryScannerEnabled:    true,
 			BuildIdScavengerEnabled:  true,
+			VisibilityScannerEnabled: true,
+			ArchivalVerifierEnabled:  true,
+			CompactorEnabled:         true,
 			DefaultStore:             config.StoreTypeSQL,
-			ExpectedScanners:         []expectedScanner{historyScanner, taskQueueScanner, buildIdScavenger}, // ExecutionsScanner is not supported for SQL store
+			ExpectedScanners:         []expectedScanner{historyScanner, taskQueueScanner, buildIdScavenger, visibilityScanner, archivalVerifier, visibilityCompactor}, // ExecutionsScanner is not supported for SQL store
 		},
 		{
 			Name:                     "AllScannersNoSQL",
@@ -170,8 +227,11 @@ func (s *scannerTestSuite) TestScannerEnabled() {
 			TaskQueueScannerEnabled:  true,
 			HistoryScannerEnabled:    true,
 			BuildIdScavengerEnabled:  true,
+			VisibilityScannerEnabled: true,
+			ArchivalVerifierEnabled:  true,
+			CompactorEnabled:         true,
 			DefaultStore:             config.StoreTypeNoSQL,
-			ExpectedScanners:         []expectedScanner{historyScanner, executionScanner, buildIdScavenger}, // TaskQueueScanner is only supported for SQL store
+			ExpectedScanners:         []expectedScanner{historyScanner, executionScanner, buildIdScavenger, visibilityScanner, archivalVerifier, visibilityCompactor}, // TaskQueueScanner is only supported for SQL store
 		},
 	} {
 		s.Run(c.Name, func() {
@@ -180,6 +240,8 @@ func (s *scannerTestSuite) TestScannerEnabled() {
 			mockSdkClient := mocksdk.NewMockClient(ctrl)
 			mockNamespaceRegistry := namespace.NewMockRegistry(ctrl)
 			mockAdminClient := adminservicemock.NewMockAdminServiceClient(ctrl)
+			mockVisibilityManager := manager.NewMockVisibilityManager(ctrl)
+			mockVisibilityManager.EXPECT().GetIndexName().Return("index-name").AnyTimes()
 			scanner := New(
 				log.NewNoopLogger(),
 				&Config{
@@ -191,6 +253,9 @@ func (s *scannerTestSuite) TestScannerEnabled() {
 					BuildIdScavengerEnabled:                dynamicconfig.GetBoolPropertyFn(c.BuildIdScavengerEnabled),
 					ExecutionsScannerEnabled:               dynamicconfig.GetBoolPropertyFn(c.ExecutionsScannerEnabled),
 					TaskQueueScannerEnabled:                dynamicconfig.GetBoolPropertyFn(c.TaskQueueScannerEnabled),
+					VisibilityScannerEnabled:               dynamicconfig.GetBoolPropertyFn(c.VisibilityScannerEnabled),
+					ArchivalVerifierEnabled:                dynamicconfig.GetBoolPropertyFn(c.ArchivalVerifierEnabled),
+					VisibilityArchiveCompactorEnabled:      dynamicconfig.GetBoolPropertyFn(c.CompactorEnabled),
 					Persistence: &config.Persistence{
 						DefaultStore: c.DefaultStore,
 						DataStores: map[string]config.DataStore{
@@ -204,7 +269,10 @@ func (s *scannerTestSuite) TestScannerEnabled() {
 				mockSdkClientFactory,
 				metrics.NoopMetricsHandler,
 				p.NewMockExecutionManager(ctrl),
-				// These nils are irrelevant since they're only used by the build ID scavenger which is not tested here.
+				// These nils are irrelevant since they're only used by the activities of the scanners which are not tested here.
+				nil,
+				mockVisibilityManager,
+				nil,
 				nil,
 				nil,
 				p.NewMockTaskManager(ctrl),
@@ -271,6 +339,9 @@ func (s *scannerTestSuite) TestScannerShutdown() {
 			ExecutionsScannerEnabled:               dynamicconfig.GetBoolPropertyFn(false),
 			TaskQueueScannerEnabled:                dynamicconfig.GetBoolPropertyFn(false),
 			BuildIdScavengerEnabled:                dynamicconfig.GetBoolPropertyFn(false),
+			VisibilityScannerEnabled:               dynamicconfig.GetBoolPropertyFn(false),
+			ArchivalVerifierEnabled:                dynamicconfig.GetBoolPropertyFn(false),
+			VisibilityArchiveCompactorEnabled:      dynamicconfig.GetBoolPropertyFn(false),
 			Persistence: &config.Persistence{
 				DefaultStore: config.StoreTypeNoSQL,
 				DataStores: map[string]config.DataStore{
@@ -281,7 +352,10 @@ func (s *scannerTestSuite) TestScannerShutdown() {
 		mockSdkClientFactory,
 		metrics.NoopMetricsHandler,
 		p.NewMockExecutionManager(ctrl),
-		// These nils are irrelevant since they're only used by the build ID scavenger which is not tested here.
+		// These nils are irrelevant since they're only used by the build ID scavenger and visibility scanner which are not tested here.
+		nil,
+		nil,
+		nil,
 		nil,
 		nil,
 		p.NewMockTaskManager(ctrl),
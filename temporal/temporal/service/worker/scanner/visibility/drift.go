@@ -0,0 +1,205 @@
+package visibility
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
+
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common/searchattribute"
+)
+
+const (
+	// DriftCategoryMissingRecord is the drift of the executions which have no visibility record.
+	DriftCategoryMissingRecord DriftCategory = "missing-record"
+	// DriftCategoryStatus is the drift of the executions of which the visibility record has another status, such as
+	// open executions which visibility reports as closed.
+	DriftCategoryStatus DriftCategory = "status"
+	// DriftCategoryCloseTime is the drift of the closed executions of which the visibility record has another close
+	// time.
+	DriftCategoryCloseTime DriftCategory = "close-time"
+	// DriftCategorySearchAttributes is the drift of the executions of which the visibility record is missing search
+	// attributes, or has other values for them.
+	DriftCategorySearchAttributes DriftCategory = "search-attributes"
+
+	// MaxExamplesPerCategory is the maximum number of drifted executions which are reported per category.
+	MaxExamplesPerCategory = 10
+
+	// The visibility stores don't keep the timestamps with a greater precision.
+	timePrecision = time.Millisecond
+)
+
+type (
+	// DriftCategory is the kind of difference between the mutable state of an execution and its visibility record.
+	DriftCategory string
+
+	// DriftReport is the result of the visibility scanner.
+	DriftReport struct {
+		// ScannedExecutions is the number of executions which were listed.
+		ScannedExecutions int
+		// CheckedExecutions is the number of executions which were compared with their visibility record. The others
+		// were either not sampled, not workflows, or updated too recently.
+		CheckedExecutions int
+		// DriftedExecutions is the number of executions of which the visibility record drifted in at least one
+		// category.
+		DriftedExecutions int
+		// RepairedExecutions is the number of drifted executions of which the visibility tasks were regenerated.
+		RepairedExecutions int
+		// FailedRepairs is the number of drifted executions of which the visibility tasks couldn't be regenerated.
+		FailedRepairs int
+		// Categories is the drift per category.
+		Categories map[DriftCategory]CategoryReport
+	}
+
+	// CategoryReport is the drift of a category.
+	CategoryReport struct {
+		// Count is the number of executions which drifted in this category.
+		Count int
+		// Examples are some of the executions which drifted in this category.
+		Examples []DriftExample
+	}
+
+	// DriftExample identifies a drifted execution.
+	DriftExample struct {
+		NamespaceID string
+		WorkflowID  string
+		RunID       string
+		Details     string
+	}
+
+	drift struct {
+		category DriftCategory
+		details  string
+	}
+)
+
+func (r *DriftReport) addDrifts(execution *commonpb.WorkflowExecution, namespaceID string, drifts []drift) {
+	r.DriftedExecutions++
+	if r.Categories == nil {
+		r.Categories = make(map[DriftCategory]CategoryReport)
+	}
+	for _, d := range drifts {
+		categoryReport := r.Categories[d.category]
+		categoryReport.Count++
+		if len(categoryReport.Examples) < MaxExamplesPerCategory {
+			categoryReport.Examples = append(categoryReport.Examples, DriftExample{
+				NamespaceID: namespaceID,
+				WorkflowID:  execution.GetWorkflowId(),
+				RunID:       execution.GetRunId(),
+				Details:     d.details,
+			})
+		}
+		r.Categories[d.category] = categoryReport
+	}
+}
+
+// compareRecord compares the mutable state of an execution with its visibility record. The search attributes of
+// the record must be unaliased.
+func compareRecord(
+	state *persistencespb.WorkflowMutableState,
+	record *workflowpb.WorkflowExecutionInfo,
+) []drift {
+	var drifts []drift
+	executionInfo := state.GetExecutionInfo()
+	status := state.GetExecutionState().GetStatus()
+
+	if status != record.GetStatus() {
+		drifts = append(drifts, drift{
+			category: DriftCategoryStatus,
+			details:  fmt.Sprintf("mutable state status is %v, visibility status is %v", status, record.GetStatus()),
+		})
+	} else if isClosed(status) {
+		msCloseTime := executionInfo.GetCloseTime().AsTime().Truncate(timePrecision)
+		recordCloseTime := record.GetCloseTime().AsTime().Truncate(timePrecision)
+		if !msCloseTime.Equal(recordCloseTime) {
+			drifts = append(drifts, drift{
+				category: DriftCategoryCloseTime,
+				details: fmt.Sprintf("mutable state close time is %v, visibility close time is %v",
+					msCloseTime.UTC(), recordCloseTime.UTC()),
+			})
+		}
+	}
+
+	// The search attributes of the executions which relocated them are only in the history.
+	if !executionInfo.GetRelocatableAttributesRemoved() {
+		if details := compareSearchAttributes(
+			executionInfo.GetSearchAttributes(),
+			record.GetSearchAttributes().GetIndexedFields(),
+		); details != "" {
+			drifts = append(drifts, drift{
+				category: DriftCategorySearchAttributes,
+				details:  details,
+			})
+		}
+	}
+	return drifts
+}
+
+// compareSearchAttributes checks that the visibility record has the search attributes of the mutable state, and
+// returns the names of the ones which are missing or have another value. The record can have more search attributes,
+// such as the ones which are computed by the visibility task executor.
+func compareSearchAttributes(
+	msSearchAttributes map[string]*commonpb.Payload,
+	recordSearchAttributes map[string]*commonpb.Payload,
+) string {
+	var drifted []string
+	for name, msValue := range msSearchAttributes {
+		recordValue, ok := recordSearchAttributes[name]
+		if !ok {
+			drifted = append(drifted, name+" (missing)")
+			continue
+		}
+		equal, err := searchAttributeValuesEqual(msValue, recordValue)
+		if err != nil {
+			drifted = append(drifted, fmt.Sprintf("%s (%v)", name, err))
+		} else if !equal {
+			drifted = append(drifted, name)
+		}
+	}
+	if len(drifted) == 0 {
+		return ""
+	}
+	slices.Sort(drifted)
+	return fmt.Sprintf("search attributes differ: %v", drifted)
+}
+
+func searchAttributeValuesEqual(msValue *commonpb.Payload, recordValue *commonpb.Payload) (bool, error) {
+	// The visibility stores always return the type of the search attributes, which isn't always set in the mutable
+	// state.
+	recordDecoded, err := searchattribute.DecodeValue(recordValue, enumspb.INDEXED_VALUE_TYPE_UNSPECIFIED, true)
+	if err != nil {
+		return false, err
+	}
+	saType, err := enumspb.IndexedValueTypeFromString(string(recordValue.GetMetadata()[searchattribute.MetadataType]))
+	if err != nil {
+		return false, err
+	}
+	msDecoded, err := searchattribute.DecodeValue(msValue, saType, true)
+	if err != nil {
+		return false, err
+	}
+	return reflect.DeepEqual(normalizeValue(msDecoded), normalizeValue(recordDecoded)), nil
+}
+
+func normalizeValue(value any) any {
+	switch v := value.(type) {
+	case time.Time:
+		return v.UTC().Truncate(timePrecision)
+	case []time.Time:
+		normalized := make([]time.Time, len(v))
+		for i, t := range v {
+			normalized[i] = t.UTC().Truncate(timePrecision)
+		}
+		return normalized
+	default:
+		return value
+	}
+}
+
+func isClosed(status enumspb.WorkflowExecutionStatus) bool {
+	return status != enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING && status != enumspb.WORKFLOW_EXECUTION_STATUS_PAUSED
+}
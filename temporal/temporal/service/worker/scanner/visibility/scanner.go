@@ -0,0 +1,342 @@
+package visibility
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+	"go.temporal.io/server/api/adminservice/v1"
+	"go.temporal.io/server/api/historyservice/v1"
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/chasm"
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/log/tag"
+	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/common/namespace"
+	"go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/persistence/visibility/manager"
+	"go.temporal.io/server/common/quotas"
+	"go.temporal.io/server/common/searchattribute"
+)
+
+const (
+	VisibilityScannerWorkflowName = "temporal-sys-visibility-scanner-workflow"
+	VisibilityScannerActivityName = "temporal-sys-visibility-scanner-activity"
+
+	VisibilityScannerWFID          = "temporal-sys-visibility-scanner"
+	VisibilityScannerTaskQueueName = "temporal-sys-visibility-scanner-taskqueue-0"
+
+	defaultPageSize = 100
+	// defaultMinExecutionAge leaves time to the visibility task processor to write the last transitions of the
+	// executions, which would otherwise be reported as drift.
+	defaultMinExecutionAge = 10 * time.Minute
+)
+
+var (
+	// errExecutionUpdated is returned when the execution was updated or deleted since it was listed, so it can't be
+	// checked until the visibility task processor caught up.
+	errExecutionUpdated = errors.New("execution was updated since it was listed")
+
+	VisibilityScannerWFStartOptions = client.StartWorkflowOptions{
+		ID:                    VisibilityScannerWFID,
+		TaskQueue:             VisibilityScannerTaskQueueName,
+		WorkflowIDReusePolicy: enumspb.WORKFLOW_ID_REUSE_POLICY_ALLOW_DUPLICATE,
+		CronSchedule:          "0 */12 * * *",
+	}
+)
+
+type (
+	VisibilityScannerInput struct {
+		// SampleRate is the fraction of the executions which are checked, between 0 and 1. The value of
+		// worker.visibilityScannerSampleRate is used if it is 0.
+		SampleRate float64
+		// AutoRepair regenerates the visibility tasks of the drifted executions, even if
+		// worker.visibilityScannerAutoRepair is disabled.
+		AutoRepair bool
+		// MinExecutionAge skips the executions which were updated more recently than this. Defaults to 10 minutes.
+		MinExecutionAge time.Duration
+		// PageSize is the number of executions which are listed per page. Defaults to 100.
+		PageSize int
+	}
+
+	Activities struct {
+		numHistoryShards  int32
+		executionManager  persistence.ExecutionManager
+		visibilityManager manager.VisibilityManager
+		namespaceRegistry namespace.Registry
+		historyClient     historyservice.HistoryServiceClient
+		saMapperProvider  searchattribute.MapperProvider
+		sampleRate        dynamicconfig.FloatPropertyFn
+		visibilityRPS     dynamicconfig.FloatPropertyFn
+		autoRepair        dynamicconfig.BoolPropertyFn
+		metricsHandler    metrics.Handler
+		logger            log.Logger
+	}
+
+	heartbeatDetails struct {
+		ShardID   int32
+		PageToken []byte
+		Report    DriftReport
+	}
+)
+
+func NewActivities(
+	numHistoryShards int32,
+	executionManager persistence.ExecutionManager,
+	visibilityManager manager.VisibilityManager,
+	namespaceRegistry namespace.Registry,
+	historyClient historyservice.HistoryServiceClient,
+	saMapperProvider searchattribute.MapperProvider,
+	sampleRate dynamicconfig.FloatPropertyFn,
+	visibilityRPS dynamicconfig.FloatPropertyFn,
+	autoRepair dynamicconfig.BoolPropertyFn,
+	metricsHandler metrics.Handler,
+	logger log.Logger,
+) *Activities {
+	return &Activities{
+		numHistoryShards:  numHistoryShards,
+		executionManager:  executionManager,
+		visibilityManager: visibilityManager,
+		namespaceRegistry: namespaceRegistry,
+		historyClient:     historyClient,
+		saMapperProvider:  saMapperProvider,
+		sampleRate:        sampleRate,
+		visibilityRPS:     visibilityRPS,
+		autoRepair:        autoRepair,
+		metricsHandler:    metricsHandler.WithTags(metrics.OperationTag(metrics.VisibilityScannerScope)),
+		logger:            logger,
+	}
+}
+
+// VisibilityScannerWorkflow compares the mutable state of the executions with their visibility records and returns
+// the drift report. This workflow is a wrapper around the long running ScanVisibility activity.
+func VisibilityScannerWorkflow(ctx workflow.Context, input VisibilityScannerInput) (DriftReport, error) {
+	activityCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		// Give the activity enough time to scan all the shards
+		StartToCloseTimeout: 12 * time.Hour,
+		HeartbeatTimeout:    5 * time.Minute,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    10 * time.Second,
+			BackoffCoefficient: 1.7,
+			MaximumInterval:    5 * time.Minute,
+		},
+	})
+	var report DriftReport
+	err := workflow.ExecuteActivity(activityCtx, VisibilityScannerActivityName, input).Get(ctx, &report)
+	return report, err
+}
+
+func (a *Activities) setDefaults(input *VisibilityScannerInput) {
+	if input.SampleRate <= 0 {
+		input.SampleRate = a.sampleRate()
+	}
+	input.SampleRate = min(input.SampleRate, 1)
+	if input.MinExecutionAge <= 0 {
+		input.MinExecutionAge = defaultMinExecutionAge
+	}
+	if input.PageSize <= 0 {
+		input.PageSize = defaultPageSize
+	}
+}
+
+// ScanVisibility scans the executions of all the shards, compares a sample of them with their visibility records
+// and optionally regenerates the visibility tasks of the drifted ones.
+func (a *Activities) ScanVisibility(ctx context.Context, input VisibilityScannerInput) (DriftReport, error) {
+	a.setDefaults(&input)
+
+	heartbeat := heartbeatDetails{ShardID: 1}
+	if activity.HasHeartbeatDetails(ctx) {
+		if err := activity.GetHeartbeatDetails(ctx, &heartbeat); err != nil {
+			return DriftReport{}, temporal.NewNonRetryableApplicationError("failed to load previous heartbeat details", "TypeError", err)
+		}
+	}
+	rateLimiter := quotas.NewDefaultOutgoingRateLimiter(quotas.RateFn(a.visibilityRPS))
+	report := &heartbeat.Report
+
+	for heartbeat.ShardID <= a.numHistoryShards {
+		// The listing can be served by a read replica, as the sampled executions are read again from the primary
+		// before they are checked.
+		resp, err := a.executionManager.ListConcreteExecutions(persistence.WithStaleReadsAllowed(ctx), &persistence.ListConcreteExecutionsRequest{
+			ShardID:   heartbeat.ShardID,
+			PageSize:  input.PageSize,
+			PageToken: heartbeat.PageToken,
+		})
+		if err != nil {
+			return DriftReport{}, err
+		}
+
+		minUpdateTime := time.Now().Add(-input.MinExecutionAge)
+		for _, state := range resp.States {
+			report.ScannedExecutions++
+			if !shouldCheck(state, input.SampleRate, minUpdateTime) {
+				continue
+			}
+			if err := rateLimiter.Wait(ctx); err != nil {
+				return DriftReport{}, err
+			}
+			if err := a.checkExecution(ctx, input, heartbeat.ShardID, minUpdateTime, state, report); err != nil {
+				if ctx.Err() != nil {
+					return DriftReport{}, ctx.Err()
+				}
+				if errors.Is(err, errExecutionUpdated) {
+					continue
+				}
+				metrics.VisibilityScannerSkippedExecutions.With(a.metricsHandler).Record(1)
+				a.logger.Warn("unable to check visibility record of execution",
+					tag.ShardID(heartbeat.ShardID),
+					tag.WorkflowNamespaceID(state.GetExecutionInfo().GetNamespaceId()),
+					tag.WorkflowID(state.GetExecutionInfo().GetWorkflowId()),
+					tag.WorkflowRunID(state.GetExecutionState().GetRunId()),
+					tag.Error(err),
+				)
+			}
+		}
+
+		heartbeat.PageToken = resp.PageToken
+		if len(heartbeat.PageToken) == 0 {
+			heartbeat.ShardID++
+		}
+		activity.RecordHeartbeat(ctx, heartbeat)
+	}
+
+	a.logger.Info("visibility scanner finished",
+		tag.NewInt("checked-executions", report.CheckedExecutions),
+		tag.NewInt("drifted-executions", report.DriftedExecutions),
+		tag.NewInt("repaired-executions", report.RepairedExecutions),
+	)
+	return *report, nil
+}
+
+// checkExecution compares the visibility record of a listed execution with its mutable state. The listed state may be
+// stale, so the mutable state is read again from the primary after the visibility record. The execution isn't checked
+// if it was updated since minUpdateTime, as the visibility task of the update may not have been processed yet.
+func (a *Activities) checkExecution(
+	ctx context.Context,
+	input VisibilityScannerInput,
+	shardID int32,
+	minUpdateTime time.Time,
+	listedState *persistencespb.WorkflowMutableState,
+	report *DriftReport,
+) error {
+	executionInfo := listedState.GetExecutionInfo()
+	execution := &commonpb.WorkflowExecution{
+		WorkflowId: executionInfo.GetWorkflowId(),
+		RunId:      listedState.GetExecutionState().GetRunId(),
+	}
+	nsEntry, err := a.namespaceRegistry.GetNamespaceByID(namespace.ID(executionInfo.GetNamespaceId()))
+	if err != nil {
+		return err
+	}
+
+	resp, err := a.visibilityManager.GetWorkflowExecution(ctx, &manager.GetWorkflowExecutionRequest{
+		NamespaceID: nsEntry.ID(),
+		Namespace:   nsEntry.Name(),
+		WorkflowID:  execution.GetWorkflowId(),
+		RunID:       execution.GetRunId(),
+	})
+	var notFound *serviceerror.NotFound
+	var record *workflowpb.WorkflowExecutionInfo
+	switch {
+	case errors.As(err, &notFound):
+	case err != nil:
+		return err
+	default:
+		record = resp.Execution
+		record.SearchAttributes, err = searchattribute.UnaliasFields(a.saMapperProvider, record.GetSearchAttributes(), nsEntry.Name().String())
+		if err != nil {
+			return err
+		}
+	}
+
+	stateResp, err := a.executionManager.GetWorkflowExecution(ctx, &persistence.GetWorkflowExecutionRequest{
+		ShardID:     shardID,
+		NamespaceID: nsEntry.ID().String(),
+		WorkflowID:  execution.GetWorkflowId(),
+		RunID:       execution.GetRunId(),
+		ArchetypeID: chasm.WorkflowArchetypeID,
+	})
+	if errors.As(err, &notFound) {
+		return errExecutionUpdated
+	}
+	if err != nil {
+		return err
+	}
+	state := stateResp.State
+	if !state.GetExecutionInfo().GetLastUpdateTime().AsTime().Before(minUpdateTime) {
+		return errExecutionUpdated
+	}
+
+	drifts := []drift{{category: DriftCategoryMissingRecord, details: "visibility record not found"}}
+	if record != nil {
+		drifts = compareRecord(state, record)
+	}
+
+	report.CheckedExecutions++
+	metrics.VisibilityScannerCheckedExecutions.With(a.metricsHandler).Record(1)
+	if len(drifts) == 0 {
+		return nil
+	}
+
+	report.addDrifts(execution, nsEntry.ID().String(), drifts)
+	for _, d := range drifts {
+		metrics.VisibilityScannerDriftedExecutions.With(a.metricsHandler).Record(1, metrics.FailureTag(string(d.category)))
+		a.logger.Info("visibility record drifted from mutable state.",
+			tag.WorkflowNamespaceID(nsEntry.ID().String()),
+			tag.WorkflowID(execution.GetWorkflowId()),
+			tag.WorkflowRunID(execution.GetRunId()),
+			tag.Value(d.details),
+		)
+	}
+
+	if !input.AutoRepair && !a.autoRepair() {
+		return nil
+	}
+	_, err = a.historyClient.RefreshWorkflowTasks(ctx, &historyservice.RefreshWorkflowTasksRequest{
+		NamespaceId: nsEntry.ID().String(),
+		ArchetypeId: chasm.WorkflowArchetypeID,
+		Request: &adminservice.RefreshWorkflowTasksRequest{
+			NamespaceId:    nsEntry.ID().String(),
+			Execution:      execution,
+			VisibilityOnly: true,
+		},
+	})
+	if err != nil {
+		report.FailedRepairs++
+		metrics.VisibilityScannerFailedRepairs.With(a.metricsHandler).Record(1)
+		a.logger.Warn("unable to regenerate visibility tasks of execution",
+			tag.WorkflowNamespaceID(nsEntry.ID().String()),
+			tag.WorkflowID(execution.GetWorkflowId()),
+			tag.WorkflowRunID(execution.GetRunId()),
+			tag.Error(err),
+		)
+		return nil
+	}
+	report.RepairedExecutions++
+	metrics.VisibilityScannerRepairedExecutions.With(a.metricsHandler).Record(1)
+	return nil
+}
+
+// shouldCheck samples the workflow executions which weren't updated recently. CHASM executions are skipped, as
+// their visibility record is built by their CHASM tree.
+func shouldCheck(state *persistencespb.WorkflowMutableState, sampleRate float64, minUpdateTime time.Time) bool {
+	if rootNode, ok := state.GetChasmNodes()[""]; ok {
+		if componentAttrs := rootNode.GetMetadata().GetComponentAttributes(); componentAttrs != nil &&
+			chasm.ArchetypeID(componentAttrs.GetTypeId()) != chasm.WorkflowArchetypeID {
+			return false
+		}
+	}
+	if !state.GetExecutionInfo().GetLastUpdateTime().AsTime().Before(minUpdateTime) {
+		return false
+	}
+	return sampleRate >= 1 || rand.Float64() < sampleRate
+}
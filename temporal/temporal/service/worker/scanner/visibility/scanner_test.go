@@ -0,0 +1,404 @@
+package visibility
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/testsuite"
+	enumsspb "go.temporal.io/server/api/enums/v1"
+	"go.temporal.io/server/api/historyservice/v1"
+	"go.temporal.io/server/api/historyservicemock/v1"
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/chasm"
+	"go.temporal.io/server/common"
+	"go.temporal.io/server/common/config"
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/common/namespace"
+	"go.temporal.io/server/common/payload"
+	"go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/persistence/serialization"
+	"go.temporal.io/server/common/persistence/sql"
+	_ "go.temporal.io/server/common/persistence/sql/sqlplugin/sqlite"
+	persistencetests "go.temporal.io/server/common/persistence/tests"
+	"go.temporal.io/server/common/persistence/visibility/manager"
+	"go.temporal.io/server/common/resolver"
+	"go.temporal.io/server/common/searchattribute"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	testNamespaceID = "test-namespace-id"
+	testNamespace   = "test-namespace"
+)
+
+func newTestMutableState(runID string, status enumspb.WorkflowExecutionStatus) *persistencespb.WorkflowMutableState {
+	state := &persistencespb.WorkflowMutableState{
+		ExecutionInfo: &persistencespb.WorkflowExecutionInfo{
+			NamespaceId:    testNamespaceID,
+			WorkflowId:     "workflow-id",
+			LastUpdateTime: timestamppb.New(time.Now().Add(-time.Hour)),
+			SearchAttributes: map[string]*commonpb.Payload{
+				"CustomKeywordField": payload.EncodeString("value"),
+			},
+		},
+		ExecutionState: &persistencespb.WorkflowExecutionState{
+			RunId:  runID,
+			Status: status,
+		},
+	}
+	if isClosed(status) {
+		state.ExecutionInfo.CloseTime = timestamppb.New(time.Unix(1000, 123456789))
+	}
+	return state
+}
+
+func newTestRecord(state *persistencespb.WorkflowMutableState) *workflowpb.WorkflowExecutionInfo {
+	value := payload.EncodeString("value")
+	value.Metadata[searchattribute.MetadataType] = []byte(enumspb.INDEXED_VALUE_TYPE_KEYWORD.String())
+	return &workflowpb.WorkflowExecutionInfo{
+		Status:    state.GetExecutionState().GetStatus(),
+		CloseTime: state.GetExecutionInfo().GetCloseTime(),
+		SearchAttributes: &commonpb.SearchAttributes{IndexedFields: map[string]*commonpb.Payload{
+			"CustomKeywordField": value,
+		}},
+	}
+}
+
+func Test_compareRecord(t *testing.T) {
+	testCases := []struct {
+		name               string
+		status             enumspb.WorkflowExecutionStatus
+		modifyRecord       func(record *workflowpb.WorkflowExecutionInfo)
+		expectedCategories []DriftCategory
+	}{
+		{
+			name:   "in sync",
+			status: enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING,
+		},
+		{
+			name:   "close time precision",
+			status: enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED,
+			modifyRecord: func(record *workflowpb.WorkflowExecutionInfo) {
+				record.CloseTime = timestamppb.New(time.Unix(1000, 123000000))
+			},
+		},
+		{
+			name:   "open execution reported as closed",
+			status: enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING,
+			modifyRecord: func(record *workflowpb.WorkflowExecutionInfo) {
+				record.Status = enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED
+				record.CloseTime = timestamppb.New(time.Unix(1000, 0))
+			},
+			expectedCategories: []DriftCategory{DriftCategoryStatus},
+		},
+		{
+			name:   "close time",
+			status: enumspb.WORKFLOW_EXECUTION_STATUS_FAILED,
+			modifyRecord: func(record *workflowpb.WorkflowExecutionInfo) {
+				record.CloseTime = timestamppb.New(time.Unix(2000, 0))
+			},
+			expectedCategories: []DriftCategory{DriftCategoryCloseTime},
+		},
+		{
+			name:   "search attribute value",
+			status: enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING,
+			modifyRecord: func(record *workflowpb.WorkflowExecutionInfo) {
+				value := payload.EncodeString("other-value")
+				value.Metadata[searchattribute.MetadataType] = []byte(enumspb.INDEXED_VALUE_TYPE_KEYWORD.String())
+				record.SearchAttributes.IndexedFields["CustomKeywordField"] = value
+			},
+			expectedCategories: []DriftCategory{DriftCategorySearchAttributes},
+		},
+		{
+			name:   "missing search attribute and status",
+			status: enumspb.WORKFLOW_EXECUTION_STATUS_TERMINATED,
+			modifyRecord: func(record *workflowpb.WorkflowExecutionInfo) {
+				record.Status = enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING
+				record.SearchAttributes = nil
+			},
+			expectedCategories: []DriftCategory{DriftCategoryStatus, DriftCategorySearchAttributes},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			state := newTestMutableState("run-id", tc.status)
+			record := newTestRecord(state)
+			if tc.modifyRecord != nil {
+				tc.modifyRecord(record)
+			}
+
+			var categories []DriftCategory
+			for _, d := range compareRecord(state, record) {
+				categories = append(categories, d.category)
+			}
+			require.Equal(t, tc.expectedCategories, categories)
+		})
+	}
+}
+
+func Test_DriftReport_addDrifts(t *testing.T) {
+	var report DriftReport
+	for i := 0; i < MaxExamplesPerCategory+2; i++ {
+		report.addDrifts(
+			&commonpb.WorkflowExecution{WorkflowId: "workflow-id", RunId: "run-id"},
+			testNamespaceID,
+			[]drift{{category: DriftCategoryStatus}, {category: DriftCategoryCloseTime}},
+		)
+	}
+
+	require.Equal(t, MaxExamplesPerCategory+2, report.DriftedExecutions)
+	require.Len(t, report.Categories, 2)
+	require.Equal(t, MaxExamplesPerCategory+2, report.Categories[DriftCategoryStatus].Count)
+	require.Len(t, report.Categories[DriftCategoryStatus].Examples, MaxExamplesPerCategory)
+	require.Equal(t, "run-id", report.Categories[DriftCategoryCloseTime].Examples[0].RunID)
+}
+
+func Test_ScanVisibility(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestActivityEnvironment()
+
+	ctrl := gomock.NewController(t)
+	executionManager := persistence.NewMockExecutionManager(ctrl)
+	visibilityManager := manager.NewMockVisibilityManager(ctrl)
+	namespaceRegistry := namespace.NewMockRegistry(ctrl)
+	historyClient := historyservicemock.NewMockHistoryServiceClient(ctrl)
+
+	inSync := newTestMutableState("in-sync", enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING)
+	missing := newTestMutableState("missing", enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING)
+	drifted := newTestMutableState("drifted", enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING)
+	recent := newTestMutableState("recent", enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING)
+	recent.ExecutionInfo.LastUpdateTime = timestamppb.Now()
+	// The listing was served by a stale replica, on which the execution is still running. The primary has the close
+	// transition, which visibility already has too.
+	staleListed := newTestMutableState("stale", enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING)
+	stale := newTestMutableState("stale", enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED)
+	// The execution was updated on the primary since it was listed.
+	updatedListed := newTestMutableState("updated", enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING)
+	updated := newTestMutableState("updated", enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED)
+	updated.ExecutionInfo.LastUpdateTime = timestamppb.Now()
+	primaryStates := map[string]*persistencespb.WorkflowMutableState{
+		"in-sync": inSync,
+		"missing": missing,
+		"drifted": drifted,
+		"stale":   stale,
+		"updated": updated,
+	}
+
+	executionManager.EXPECT().ListConcreteExecutions(gomock.Any(), &persistence.ListConcreteExecutionsRequest{
+		ShardID:  1,
+		PageSize: defaultPageSize,
+	}).Return(&persistence.ListConcreteExecutionsResponse{
+		States:    []*persistencespb.WorkflowMutableState{inSync, missing},
+		PageToken: []byte("token"),
+	}, nil)
+	executionManager.EXPECT().ListConcreteExecutions(gomock.Any(), &persistence.ListConcreteExecutionsRequest{
+		ShardID:   1,
+		PageSize:  defaultPageSize,
+		PageToken: []byte("token"),
+	}).Return(&persistence.ListConcreteExecutionsResponse{
+		States: []*persistencespb.WorkflowMutableState{drifted},
+	}, nil)
+	executionManager.EXPECT().ListConcreteExecutions(gomock.Any(), &persistence.ListConcreteExecutionsRequest{
+		ShardID:  2,
+		PageSize: defaultPageSize,
+	}).Return(&persistence.ListConcreteExecutionsResponse{
+		States: []*persistencespb.WorkflowMutableState{recent, staleListed, updatedListed},
+	}, nil)
+	executionManager.EXPECT().GetWorkflowExecution(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *persistence.GetWorkflowExecutionRequest) (*persistence.GetWorkflowExecutionResponse, error) {
+			return &persistence.GetWorkflowExecutionResponse{State: primaryStates[request.RunID]}, nil
+		},
+	).Times(5)
+
+	namespaceRegistry.EXPECT().GetNamespaceByID(namespace.ID(testNamespaceID)).Return(namespace.NewLocalNamespaceForTest(
+		&persistencespb.NamespaceInfo{Id: testNamespaceID, Name: testNamespace}, nil, "active",
+	), nil).Times(5)
+	visibilityManager.EXPECT().GetWorkflowExecution(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *manager.GetWorkflowExecutionRequest) (*manager.GetWorkflowExecutionResponse, error) {
+			switch request.RunID {
+			case "in-sync", "stale":
+				return &manager.GetWorkflowExecutionResponse{Execution: newTestRecord(primaryStates[request.RunID])}, nil
+			case "updated":
+				// The visibility task of the close transition wasn't processed yet.
+				return &manager.GetWorkflowExecutionResponse{Execution: newTestRecord(updatedListed)}, nil
+			case "drifted":
+				record := newTestRecord(drifted)
+				record.Status = enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED
+				return &manager.GetWorkflowExecutionResponse{Execution: record}, nil
+			default:
+				return nil, serviceerror.NewNotFound("not found")
+			}
+		},
+	).Times(5)
+	var refreshedRunIDs []string
+	historyClient.EXPECT().RefreshWorkflowTasks(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *historyservice.RefreshWorkflowTasksRequest, _ ...any) (*historyservice.RefreshWorkflowTasksResponse, error) {
+			require.True(t, request.GetRequest().GetVisibilityOnly())
+			refreshedRunIDs = append(refreshedRunIDs, request.GetRequest().GetExecution().GetRunId())
+			if request.GetRequest().GetExecution().GetRunId() == "missing" {
+				return nil, errors.New("refresh failed")
+			}
+			return &historyservice.RefreshWorkflowTasksResponse{}, nil
+		},
+	).Times(2)
+
+	a := NewActivities(
+		2,
+		executionManager,
+		visibilityManager,
+		namespaceRegistry,
+		historyClient,
+		searchattribute.NewTestMapperProvider(nil),
+		dynamicconfig.GetFloatPropertyFn(1),
+		dynamicconfig.GetFloatPropertyFn(1000),
+		dynamicconfig.GetBoolPropertyFn(true),
+		metrics.NoopMetricsHandler,
+		log.NewTestLogger(),
+	)
+	env.RegisterActivityWithOptions(a.ScanVisibility, activity.RegisterOptions{Name: VisibilityScannerActivityName})
+
+	val, err := env.ExecuteActivity(VisibilityScannerActivityName, VisibilityScannerInput{})
+	require.NoError(t, err)
+	var report DriftReport
+	require.NoError(t, val.Get(&report))
+
+	require.Equal(t, 6, report.ScannedExecutions)
+	require.Equal(t, 4, report.CheckedExecutions)
+	require.Equal(t, 2, report.DriftedExecutions)
+	require.Equal(t, 1, report.RepairedExecutions)
+	require.Equal(t, 1, report.FailedRepairs)
+	require.Equal(t, 1, report.Categories[DriftCategoryMissingRecord].Count)
+	require.Equal(t, "missing", report.Categories[DriftCategoryMissingRecord].Examples[0].RunID)
+	require.Equal(t, 1, report.Categories[DriftCategoryStatus].Count)
+	require.Equal(t, "drifted", report.Categories[DriftCategoryStatus].Examples[0].RunID)
+	require.Equal(t, []string{"missing", "drifted"}, refreshedRunIDs)
+}
+
+func Test_ScanVisibility_SQLite(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestActivityEnvironment()
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	visibilityManager := manager.NewMockVisibilityManager(ctrl)
+	namespaceRegistry := namespace.NewMockRegistry(ctrl)
+	historyClient := historyservicemock.NewMockHistoryServiceClient(ctrl)
+
+	factory := sql.NewFactory(
+		config.SQL{
+			PluginName:        "sqlite",
+			DatabaseName:      uuid.NewString(),
+			ConnectAttributes: map[string]string{"mode": "memory", "cache": "private"},
+		},
+		resolver.NewNoopResolver(),
+		"test-cluster",
+		log.NewNoopLogger(),
+		metrics.NoopMetricsHandler,
+		serialization.NewSerializer(),
+	)
+	defer factory.Close()
+	shardStore, err := factory.NewShardStore()
+	require.NoError(t, err)
+	executionStore, err := factory.NewExecutionStore()
+	require.NoError(t, err)
+	shardManager := persistence.NewShardManager(shardStore, serialization.NewSerializer())
+	executionManager := persistence.NewExecutionManager(
+		executionStore,
+		serialization.NewSerializer(),
+		nil,
+		log.NewNoopLogger(),
+		dynamicconfig.GetIntPropertyFn(4*1024*1024),
+		dynamicconfig.GetBoolPropertyFn(false),
+		nil,
+		nil,
+	)
+
+	// The SQL stores require UUIDs for the IDs of the executions.
+	namespaceID := uuid.NewString()
+	inSyncRunID := uuid.NewString()
+	missingRunID := uuid.NewString()
+	for _, runID := range []string{inSyncRunID, missingRunID} {
+		shardResp, err := shardManager.GetOrCreateShard(ctx, &persistence.GetOrCreateShardRequest{
+			ShardID:          1,
+			InitialShardInfo: &persistencespb.ShardInfo{ShardId: 1, RangeId: 1},
+		})
+		require.NoError(t, err)
+		expected := newTestMutableState(runID, enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING)
+		snapshot, _ := persistencetests.RandomSnapshot(
+			t,
+			namespaceID,
+			"workflow-id-"+runID,
+			runID,
+			common.FirstEventID,
+			1,
+			enumsspb.WORKFLOW_EXECUTION_STATE_RUNNING,
+			enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING,
+			1,
+			nil,
+		)
+		snapshot.ExecutionInfo.LastUpdateTime = expected.ExecutionInfo.LastUpdateTime
+		snapshot.ExecutionInfo.SearchAttributes = expected.ExecutionInfo.SearchAttributes
+		snapshot.ExecutionInfo.CloseTime = nil
+		snapshot.ChasmNodes = nil
+		_, err = executionManager.CreateWorkflowExecution(ctx, &persistence.CreateWorkflowExecutionRequest{
+			ShardID:             1,
+			RangeID:             shardResp.ShardInfo.RangeId,
+			Mode:                persistence.CreateWorkflowModeBrandNew,
+			ArchetypeID:         chasm.WorkflowArchetypeID,
+			NewWorkflowSnapshot: *snapshot,
+		})
+		require.NoError(t, err)
+	}
+
+	namespaceRegistry.EXPECT().GetNamespaceByID(namespace.ID(namespaceID)).Return(namespace.NewLocalNamespaceForTest(
+		&persistencespb.NamespaceInfo{Id: namespaceID, Name: testNamespace}, nil, "active",
+	), nil).Times(2)
+	visibilityManager.EXPECT().GetWorkflowExecution(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *manager.GetWorkflowExecutionRequest) (*manager.GetWorkflowExecutionResponse, error) {
+			if request.RunID == missingRunID {
+				return nil, serviceerror.NewNotFound("not found")
+			}
+			state := newTestMutableState(request.RunID, enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING)
+			return &manager.GetWorkflowExecutionResponse{Execution: newTestRecord(state)}, nil
+		},
+	).Times(2)
+
+	a := NewActivities(
+		1,
+		executionManager,
+		visibilityManager,
+		namespaceRegistry,
+		historyClient,
+		searchattribute.NewTestMapperProvider(nil),
+		dynamicconfig.GetFloatPropertyFn(1),
+		dynamicconfig.GetFloatPropertyFn(1000),
+		dynamicconfig.GetBoolPropertyFn(false),
+		metrics.NoopMetricsHandler,
+		log.NewTestLogger(),
+	)
+	env.RegisterActivityWithOptions(a.ScanVisibility, activity.RegisterOptions{Name: VisibilityScannerActivityName})
+
+	// The executions are listed from the store in pages of one execution.
+	val, err := env.ExecuteActivity(VisibilityScannerActivityName, VisibilityScannerInput{PageSize: 1})
+	require.NoError(t, err)
+	var report DriftReport
+	require.NoError(t, val.Get(&report))
+
+	require.Equal(t, 2, report.ScannedExecutions)
+	require.Equal(t, 2, report.CheckedExecutions)
+	require.Equal(t, 1, report.DriftedExecutions)
+	require.Equal(t, missingRunID, report.Categories[DriftCategoryMissingRecord].Examples[0].RunID)
+}
@@ -8,6 +8,7 @@ import (
 	sdkworker "go.temporal.io/sdk/worker"
 	"go.temporal.io/server/api/matchingservice/v1"
 	"go.temporal.io/server/client"
+	"go.temporal.io/server/common/archiver/provider"
 	"go.temporal.io/server/common/cluster"
 	"go.temporal.io/server/common/config"
 	"go.temporal.io/server/common/dynamicconfig"
@@ -23,6 +24,7 @@ import (
 	"go.temporal.io/server/common/primitives"
 	"go.temporal.io/server/common/resource"
 	"go.temporal.io/server/common/sdk"
+	"go.temporal.io/server/common/searchattribute"
 	"go.temporal.io/server/service/worker/parentclosepolicy"
 	"go.temporal.io/server/service/worker/replicator"
 	"go.temporal.io/server/service/worker/scanner"
@@ -53,6 +55,9 @@ type (
 		namespaceRegistry      namespace.Registry
 		workerServiceResolver  membership.ServiceResolver
 		visibilityManager      manager.VisibilityManager
+		saMapperProvider       searchattribute.MapperProvider
+		saProvider             searchattribute.Provider
+		archiverProvider       provider.ArchiverProvider
 
 		namespaceReplicationQueue persistence.NamespaceReplicationQueue
 
@@ -101,6 +106,7 @@ type (
 		VisibilityDisableOrderByClause          dynamicconfig.BoolPropertyFnWithNamespaceFilter
 		VisibilityEnableManualPagination        dynamicconfig.BoolPropertyFnWithNamespaceFilter
 		VisibilityEnableUnifiedQueryConverter   dynamicconfig.BoolPropertyFn
+		VisibilityCountGroupByMaxGroups         dynamicconfig.IntPropertyFnWithNamespaceFilter
 	}
 )
 
@@ -123,6 +129,9 @@ func NewService(
 	workerManager *workerManager,
 	perNamespaceWorkerManager *PerNamespaceWorkerManager,
 	visibilityManager manager.VisibilityManager,
+	saMapperProvider searchattribute.MapperProvider,
+	saProvider searchattribute.Provider,
+	archiverProvider provider.ArchiverProvider,
 	matchingClient resource.MatchingClient,
 	namespaceReplicationTaskExecutor nsreplication.TaskExecutor,
 	serializer serialization.Serializer,
@@ -153,6 +162,9 @@ func NewService(
 		taskManager:               taskManager,
 		historyClient:             historyClient,
 		visibilityManager:         visibilityManager,
+		saMapperProvider:          saMapperProvider,
+		saProvider:                saProvider,
+		archiverProvider:          archiverProvider,
 
 		workerManager:                    workerManager,
 		perNamespaceWorkerManager:        perNamespaceWorkerManager,
@@ -203,6 +215,13 @@ func NewConfig(
 			ExecutionScannerHistoryEventIdValidator: dynamicconfig.ExecutionScannerHistoryEventIdValidator.Get(dc),
 			RemovableBuildIdDurationSinceDefault:    dynamicconfig.RemovableBuildIdDurationSinceDefault.Get(dc),
 			BuildIdScavengerVisibilityRPS:           dynamicconfig.BuildIdScavengerVisibilityRPS.Get(dc),
+			VisibilityScannerEnabled:                dynamicconfig.VisibilityScannerEnabled.Get(dc),
+			VisibilityScannerSampleRate:             dynamicconfig.VisibilityScannerSampleRate.Get(dc),
+			VisibilityScannerRPS:                    dynamicconfig.VisibilityScannerRPS.Get(dc),
+			VisibilityScannerAutoRepair:             dynamicconfig.VisibilityScannerAutoRepair.Get(dc),
+			ArchivalVerifierEnabled:                 dynamicconfig.ArchivalVerifierEnabled.Get(dc),
+			ArchivalVerifierRPS:                     dynamicconfig.ArchivalVerifierRPS.Get(dc),
+			VisibilityArchiveCompactorEnabled:       dynamicconfig.VisibilityArchiveCompactorEnabled.Get(dc),
 		},
 		BatcherRPS:                           dynamicconfig.BatcherRPS.Get(dc),
 		BatcherConcurrency:                   dynamicconfig.BatcherConcurrency.Get(dc),
@@ -228,6 +247,7 @@ func NewConfig(
 		VisibilityDisableOrderByClause:          dynamicconfig.VisibilityDisableOrderByClause.Get(dc),
 		VisibilityEnableManualPagination:        dynamicconfig.VisibilityEnableManualPagination.Get(dc),
 		VisibilityEnableUnifiedQueryConverter:   dynamicconfig.VisibilityEnableUnifiedQueryConverter.Get(dc),
+		VisibilityCountGroupByMaxGroups:         dynamicconfig.VisibilityCountGroupByMaxGroups.Get(dc),
 	}
 	return config
 }
@@ -330,6 +350,9 @@ func (s *Service) initScanner(serializer serialization.Serializer) error {
 		s.executionManager,
 		s.metadataManager,
 		s.visibilityManager,
+		s.saMapperProvider,
+		s.saProvider,
+		s.archiverProvider,
 		s.taskManager,
 		s.historyClient,
 		adminClient,
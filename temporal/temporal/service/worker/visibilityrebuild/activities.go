@@ -0,0 +1,316 @@
+package visibilityrebuild
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/server/api/adminservice/v1"
+	"go.temporal.io/server/api/historyservice/v1"
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/chasm"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/log/tag"
+	"go.temporal.io/server/common/namespace"
+	"go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/persistence/visibility"
+	"go.temporal.io/server/common/persistence/visibility/manager"
+	"go.temporal.io/server/common/quotas"
+)
+
+type (
+	activities struct {
+		numHistoryShards  int32
+		executionManager  persistence.ExecutionManager
+		namespaceRegistry namespace.Registry
+		historyClient     historyservice.HistoryServiceClient
+		visibilityManager func() (manager.VisibilityManager, error)
+		logger            log.Logger
+	}
+
+	// rebuildShardProgress is recorded in the heartbeats of RebuildShardActivity, so that a retried activity resumes
+	// from the last rebuilt execution.
+	rebuildShardProgress struct {
+		Result RebuildShardResult
+		// PageToken is the token of the page which is being rebuilt, and Index is the number of executions of this
+		// page which were already rebuilt.
+		PageToken []byte
+		Index     int
+	}
+)
+
+var (
+	errSecondaryStoreNotConfigured = errors.New("no secondary visibility store is configured in the persistence config")
+	errExecutionSkipped            = errors.New("execution skipped")
+)
+
+func (a *activities) GetRebuildShardIDsActivity(_ context.Context) ([]int32, error) {
+	shardIDs := make([]int32, 0, a.numHistoryShards)
+	for shardID := int32(1); shardID <= a.numHistoryShards; shardID++ {
+		shardIDs = append(shardIDs, shardID)
+	}
+	return shardIDs, nil
+}
+
+func (a *activities) GetNamespaceIDActivity(_ context.Context, nsName string) (string, error) {
+	nsID, err := a.namespaceRegistry.GetNamespaceID(namespace.Name(nsName))
+	if err != nil {
+		var notFound *serviceerror.NamespaceNotFound
+		if errors.As(err, &notFound) {
+			return "", temporal.NewNonRetryableApplicationError(err.Error(), "", err)
+		}
+		return "", err
+	}
+	return nsID.String(), nil
+}
+
+func (a *activities) RebuildShardActivity(ctx context.Context, params RebuildShardParams) (RebuildShardResult, error) {
+	var visibilityManager manager.VisibilityManager
+	if params.Mode == ModeWriteDirect {
+		var err error
+		visibilityManager, err = a.targetVisibilityManager(params.TargetStore)
+		if err != nil {
+			return RebuildShardResult{}, temporal.NewNonRetryableApplicationError(err.Error(), "", err)
+		}
+	}
+
+	progress := rebuildShardProgress{Result: RebuildShardResult{ShardID: params.ShardID}}
+	if activity.HasHeartbeatDetails(ctx) {
+		if err := activity.GetHeartbeatDetails(ctx, &progress); err != nil {
+			a.logger.Warn("Unable to get heartbeat details, rebuilding the shard from the start.", tag.ShardID(params.ShardID), tag.Error(err))
+			progress = rebuildShardProgress{Result: RebuildShardResult{ShardID: params.ShardID}}
+		}
+	}
+
+	rateLimiter := quotas.NewRateLimiter(params.RPS, int(math.Ceil(params.RPS)))
+	result := &progress.Result
+	for {
+		var taskID int64
+		if params.Mode == ModeWriteDirect {
+			// The records are written with a task ID of the shard which is allocated before the executions are read,
+			// so that they overwrite the records written by the visibility tasks of the older transitions, and are
+			// overwritten by the ones of the newer transitions.
+			allocateResp, err := a.historyClient.AllocateTaskId(ctx, &historyservice.AllocateTaskIdRequest{
+				ShardId: params.ShardID,
+			})
+			if err != nil {
+				return RebuildShardResult{}, err
+			}
+			taskID = allocateResp.GetTaskId()
+		}
+
+		resp, err := a.executionManager.ListConcreteExecutions(ctx, &persistence.ListConcreteExecutionsRequest{
+			ShardID:   params.ShardID,
+			PageSize:  params.PageSize,
+			PageToken: progress.PageToken,
+		})
+		if err != nil {
+			return RebuildShardResult{}, err
+		}
+
+		for progress.Index < len(resp.States) {
+			state := resp.States[progress.Index]
+			progress.Index++
+			result.ScannedExecutions++
+			if !params.matches(state) {
+				continue
+			}
+			if err := rateLimiter.Wait(ctx); err != nil {
+				return RebuildShardResult{}, err
+			}
+
+			err := a.rebuildExecution(ctx, params, visibilityManager, taskID, state)
+			var notFound *serviceerror.NotFound
+			var nsNotFound *serviceerror.NamespaceNotFound
+			switch {
+			case err == nil:
+				result.RebuiltExecutions++
+			case errors.Is(err, errExecutionSkipped), errors.As(err, &notFound), errors.As(err, &nsNotFound):
+				result.SkippedExecutions++
+			case ctx.Err() != nil:
+				return RebuildShardResult{}, ctx.Err()
+			default:
+				result.FailedExecutions++
+				if len(result.Failures) < MaxReportedFailures {
+					result.Failures = append(result.Failures, fmt.Sprintf("%s/%s/%s: %v",
+						state.GetExecutionInfo().GetNamespaceId(),
+						state.GetExecutionInfo().GetWorkflowId(),
+						state.GetExecutionState().GetRunId(),
+						err,
+					))
+				}
+			}
+			activity.RecordHeartbeat(ctx, progress)
+		}
+
+		if len(resp.PageToken) == 0 {
+			return progress.Result, nil
+		}
+		progress.PageToken = resp.PageToken
+		progress.Index = 0
+		activity.RecordHeartbeat(ctx, progress)
+	}
+}
+
+func (a *activities) targetVisibilityManager(targetStore TargetStore) (manager.VisibilityManager, error) {
+	visibilityManager, err := a.visibilityManager()
+	if err != nil {
+		return nil, err
+	}
+	dualVisibilityManager, isDual := visibilityManager.(*visibility.VisibilityManagerDual)
+	switch targetStore {
+	case TargetStorePrimary:
+		if isDual {
+			return dualVisibilityManager.GetPrimaryVisibility(), nil
+		}
+		return visibilityManager, nil
+	case TargetStoreSecondary:
+		if isDual {
+			return dualVisibilityManager.GetSecondaryVisibility(), nil
+		}
+		return nil, errSecondaryStoreNotConfigured
+	default:
+		return nil, fmt.Errorf("unknown target store %q", targetStore)
+	}
+}
+
+func (a *activities) rebuildExecution(
+	ctx context.Context,
+	params RebuildShardParams,
+	visibilityManager manager.VisibilityManager,
+	taskID int64,
+	state *persistencespb.WorkflowMutableState,
+) error {
+	executionInfo := state.GetExecutionInfo()
+	execution := &commonpb.WorkflowExecution{
+		WorkflowId: executionInfo.GetWorkflowId(),
+		RunId:      state.GetExecutionState().GetRunId(),
+	}
+
+	if params.Mode == ModeRefreshTasks {
+		_, err := a.historyClient.RefreshWorkflowTasks(ctx, &historyservice.RefreshWorkflowTasksRequest{
+			NamespaceId: executionInfo.GetNamespaceId(),
+			ArchetypeId: archetypeID(state),
+			Request: &adminservice.RefreshWorkflowTasksRequest{
+				NamespaceId:    executionInfo.GetNamespaceId(),
+				Execution:      execution,
+				VisibilityOnly: true,
+			},
+		})
+		return err
+	}
+
+	// The visibility record of CHASM executions is built by the CHASM tree, and the memo and search attributes of
+	// the executions which relocated them are only in the history.
+	if archetypeID(state) != chasm.WorkflowArchetypeID || executionInfo.GetRelocatableAttributesRemoved() {
+		return errExecutionSkipped
+	}
+	nsEntry, err := a.namespaceRegistry.GetNamespaceByID(namespace.ID(executionInfo.GetNamespaceId()))
+	if err != nil {
+		return err
+	}
+
+	base := newVisibilityRequestBase(params.ShardID, taskID, nsEntry, execution, state)
+	switch state.GetExecutionState().GetStatus() {
+	case enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING, enumspb.WORKFLOW_EXECUTION_STATUS_PAUSED:
+		return visibilityManager.UpsertWorkflowExecution(ctx, &manager.UpsertWorkflowExecutionRequest{
+			VisibilityRequestBase: base,
+		})
+	default:
+		if executionInfo.GetCloseTime() == nil {
+			return errExecutionSkipped
+		}
+		closeTime := executionInfo.GetCloseTime().AsTime()
+		var executionDuration time.Duration
+		if executionInfo.GetExecutionTime() != nil {
+			executionDuration = closeTime.Sub(executionInfo.GetExecutionTime().AsTime())
+		}
+		return visibilityManager.RecordWorkflowExecutionClosed(ctx, &manager.RecordWorkflowExecutionClosedRequest{
+			VisibilityRequestBase: base,
+			CloseTime:             closeTime,
+			ExecutionDuration:     executionDuration,
+			HistoryLength:         state.GetNextEventId() - 1,
+			HistorySizeBytes:      executionInfo.GetExecutionStats().GetHistorySize(),
+			StateTransitionCount:  executionInfo.GetStateTransitionCount(),
+		})
+	}
+}
+
+// newVisibilityRequestBase builds the visibility record of a workflow from its mutable state, like the visibility
+// task executor of the history service.
+func newVisibilityRequestBase(
+	shardID int32,
+	taskID int64,
+	nsEntry *namespace.Namespace,
+	execution *commonpb.WorkflowExecution,
+	state *persistencespb.WorkflowMutableState,
+) *manager.VisibilityRequestBase {
+	executionInfo := state.GetExecutionInfo()
+
+	var parentExecution *commonpb.WorkflowExecution
+	if executionInfo.GetParentWorkflowId() != "" && executionInfo.GetParentRunId() != "" {
+		parentExecution = &commonpb.WorkflowExecution{
+			WorkflowId: executionInfo.GetParentWorkflowId(),
+			RunId:      executionInfo.GetParentRunId(),
+		}
+	}
+	var memo *commonpb.Memo
+	if executionInfo.GetMemo() != nil {
+		memo = &commonpb.Memo{Fields: executionInfo.GetMemo()}
+	}
+	var searchAttributes *commonpb.SearchAttributes
+	if executionInfo.GetSearchAttributes() != nil {
+		searchAttributes = &commonpb.SearchAttributes{IndexedFields: executionInfo.GetSearchAttributes()}
+	}
+
+	return &manager.VisibilityRequestBase{
+		NamespaceID:      nsEntry.ID(),
+		Namespace:        nsEntry.Name(),
+		Execution:        execution,
+		WorkflowTypeName: executionInfo.GetWorkflowTypeName(),
+		StartTime:        state.GetExecutionState().GetStartTime().AsTime(),
+		Status:           state.GetExecutionState().GetStatus(),
+		ExecutionTime:    executionInfo.GetExecutionTime().AsTime(),
+		TaskID:           taskID,
+		ShardID:          shardID,
+		Memo:             memo,
+		TaskQueue:        executionInfo.GetTaskQueue(),
+		SearchAttributes: searchAttributes,
+		ParentExecution:  parentExecution,
+		RootExecution: &commonpb.WorkflowExecution{
+			WorkflowId: executionInfo.GetRootWorkflowId(),
+			RunId:      executionInfo.GetRootRunId(),
+		},
+	}
+}
+
+func (p RebuildShardParams) matches(state *persistencespb.WorkflowMutableState) bool {
+	executionInfo := state.GetExecutionInfo()
+	if p.NamespaceID != "" && executionInfo.GetNamespaceId() != p.NamespaceID {
+		return false
+	}
+	lastUpdateTime := executionInfo.GetLastUpdateTime().AsTime()
+	if !p.UpdatedAfter.IsZero() && lastUpdateTime.Before(p.UpdatedAfter) {
+		return false
+	}
+	if !p.UpdatedBefore.IsZero() && !lastUpdateTime.Before(p.UpdatedBefore) {
+		return false
+	}
+	return true
+}
+
+func archetypeID(state *persistencespb.WorkflowMutableState) chasm.ArchetypeID {
+	if rootNode, ok := state.GetChasmNodes()[""]; ok {
+		if componentAttrs := rootNode.GetMetadata().GetComponentAttributes(); componentAttrs != nil {
+			return chasm.ArchetypeID(componentAttrs.GetTypeId())
+		}
+	}
+	return chasm.WorkflowArchetypeID
+}
@@ -0,0 +1,148 @@
+package visibilityrebuild
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/server/api/historyservice/v1"
+	"go.temporal.io/server/api/historyservicemock/v1"
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/chasm"
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/common/namespace"
+	"go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/persistence/serialization"
+	"go.temporal.io/server/common/persistence/visibility"
+	"go.temporal.io/server/common/persistence/visibility/manager"
+	"go.temporal.io/server/common/persistence/visibility/store/memory"
+	"go.temporal.io/server/common/resolver"
+	"go.temporal.io/server/common/searchattribute"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestRebuildShardActivity_WriteDirectOverwritesStaleRecord(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	executionManager := persistence.NewMockExecutionManager(ctrl)
+	historyClient := historyservicemock.NewMockHistoryServiceClient(ctrl)
+	namespaceRegistry := namespace.NewMockRegistry(ctrl)
+	nsEntry := namespace.NewLocalNamespaceForTest(
+		&persistencespb.NamespaceInfo{Id: uuid.NewString(), Name: "test-namespace"},
+		nil,
+		"active",
+	)
+	namespaceRegistry.EXPECT().GetNamespaceByID(nsEntry.ID()).Return(nsEntry, nil).AnyTimes()
+
+	visibilityManager, err := visibility.NewManager(
+		memory.NewTestCluster(uuid.NewString()).Config(),
+		resolver.NewNoopResolver(),
+		memory.NewVisibilityStoreFactory(),
+		nil,
+		searchattribute.NewTestProvider(),
+		searchattribute.NewTestMapperProvider(nil),
+		namespaceRegistry,
+		chasm.NewRegistry(nil),
+		dynamicconfig.GetIntPropertyFn(1000),
+		dynamicconfig.GetIntPropertyFn(1000),
+		dynamicconfig.GetFloatPropertyFn(0.2),
+		dynamicconfig.GetDurationPropertyFn(time.Second),
+		dynamicconfig.GetBoolPropertyFnFilteredByNamespace(false),
+		dynamicconfig.GetBoolPropertyFn(false),
+		dynamicconfig.GetStringPropertyFn(visibility.SecondaryVisibilityWritingModeOff),
+		dynamicconfig.GetBoolPropertyFnFilteredByNamespace(false),
+		dynamicconfig.GetBoolPropertyFnFilteredByNamespace(true),
+		dynamicconfig.GetBoolPropertyFn(true),
+		dynamicconfig.GetIntPropertyFnFilteredByNamespace(1000),
+		metrics.NoopMetricsHandler,
+		log.NewTestLogger(),
+		serialization.NewSerializer(),
+	)
+	require.NoError(t, err)
+	defer visibilityManager.Close()
+
+	startTime := time.Unix(1000, 0).UTC()
+	closeTime := startTime.Add(time.Minute)
+	execution := &commonpb.WorkflowExecution{WorkflowId: "workflow-id", RunId: uuid.NewString()}
+
+	// The record of the execution is running, because the visibility task of the close transition was lost. The
+	// record was written by a visibility task with a greater task ID than the first event of the last transaction.
+	err = visibilityManager.UpsertWorkflowExecution(context.Background(), &manager.UpsertWorkflowExecutionRequest{
+		VisibilityRequestBase: &manager.VisibilityRequestBase{
+			NamespaceID:      nsEntry.ID(),
+			Namespace:        nsEntry.Name(),
+			Execution:        execution,
+			WorkflowTypeName: "workflow-type",
+			StartTime:        startTime,
+			ExecutionTime:    startTime,
+			Status:           enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING,
+			TaskID:           100,
+			ShardID:          1,
+		},
+	})
+	require.NoError(t, err)
+
+	historyClient.EXPECT().AllocateTaskId(gomock.Any(), &historyservice.AllocateTaskIdRequest{ShardId: 1}).
+		Return(&historyservice.AllocateTaskIdResponse{TaskId: 200}, nil)
+	executionManager.EXPECT().ListConcreteExecutions(gomock.Any(), gomock.Any()).Return(&persistence.ListConcreteExecutionsResponse{
+		States: []*persistencespb.WorkflowMutableState{
+			{
+				ExecutionInfo: &persistencespb.WorkflowExecutionInfo{
+					NamespaceId:         nsEntry.ID().String(),
+					WorkflowId:          execution.GetWorkflowId(),
+					WorkflowTypeName:    "workflow-type",
+					ExecutionTime:       timestamppb.New(startTime),
+					CloseTime:           timestamppb.New(closeTime),
+					LastFirstEventTxnId: 50,
+				},
+				ExecutionState: &persistencespb.WorkflowExecutionState{
+					RunId:     execution.GetRunId(),
+					StartTime: timestamppb.New(startTime),
+					Status:    enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED,
+				},
+				NextEventId: 5,
+			},
+		},
+	}, nil)
+
+	a := &activities{
+		executionManager:  executionManager,
+		namespaceRegistry: namespaceRegistry,
+		historyClient:     historyClient,
+		visibilityManager: func() (manager.VisibilityManager, error) { return visibilityManager, nil },
+		logger:            log.NewTestLogger(),
+	}
+	testSuite := &testsuite.WorkflowTestSuite{}
+	testSuite.SetLogger(log.NewSdkLogger(log.NewTestLogger()))
+	env := testSuite.NewTestActivityEnvironment()
+	env.RegisterActivity(a)
+
+	value, err := env.ExecuteActivity(a.RebuildShardActivity, RebuildShardParams{
+		ShardID:     1,
+		Mode:        ModeWriteDirect,
+		TargetStore: TargetStorePrimary,
+		PageSize:    defaultPageSize,
+		RPS:         defaultRPS,
+	})
+	require.NoError(t, err)
+	var result RebuildShardResult
+	require.NoError(t, value.Get(&result))
+	require.Equal(t, RebuildShardResult{ShardID: 1, ScannedExecutions: 1, RebuiltExecutions: 1}, result)
+
+	resp, err := visibilityManager.GetWorkflowExecution(context.Background(), &manager.GetWorkflowExecutionRequest{
+		NamespaceID: nsEntry.ID(),
+		Namespace:   nsEntry.Name(),
+		WorkflowID:  execution.GetWorkflowId(),
+		RunID:       execution.GetRunId(),
+	})
+	require.NoError(t, err)
+	require.Equal(t, enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED, resp.Execution.GetStatus())
+	require.Equal(t, closeTime, resp.Execution.GetCloseTime().AsTime())
+}
@@ -0,0 +1,142 @@
+package visibilityrebuild
+
+import (
+	"context"
+	"sync"
+
+	sdkworker "go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
+	"go.temporal.io/server/chasm"
+	"go.temporal.io/server/common/config"
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/headers"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/common/namespace"
+	"go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/persistence/serialization"
+	"go.temporal.io/server/common/persistence/visibility"
+	"go.temporal.io/server/common/persistence/visibility/manager"
+	"go.temporal.io/server/common/persistence/visibility/store/elasticsearch"
+	"go.temporal.io/server/common/primitives"
+	"go.temporal.io/server/common/resolver"
+	"go.temporal.io/server/common/resource"
+	"go.temporal.io/server/common/searchattribute"
+	workercommon "go.temporal.io/server/service/worker/common"
+	"go.uber.org/fx"
+)
+
+type (
+	// visibilityRebuild represent background work needed for rebuilding the visibility records of the executions.
+	visibilityRebuild struct {
+		numHistoryShards  int32
+		executionManager  persistence.ExecutionManager
+		namespaceRegistry namespace.Registry
+		historyClient     resource.HistoryClient
+		visibilityManager func() (manager.VisibilityManager, error)
+		logger            log.Logger
+	}
+
+	componentParams struct {
+		fx.In
+		PersistenceConfig              *config.Persistence
+		PersistenceServiceResolver     resolver.ServiceResolver
+		CustomVisibilityStoreFactory   visibility.VisibilityStoreFactory
+		ExecutionManager               persistence.ExecutionManager
+		NamespaceRegistry              namespace.Registry
+		HistoryClient                  resource.HistoryClient
+		SearchAttributesProvider       searchattribute.Provider
+		SearchAttributesMapperProvider searchattribute.MapperProvider
+		ChasmRegistry                  *chasm.Registry
+		Serializer                     serialization.Serializer
+		DynamicCollection              *dynamicconfig.Collection
+		MetricsHandler                 metrics.Handler
+		Logger                         log.Logger
+	}
+)
+
+var Module = workercommon.AnnotateWorkerComponentProvider(newComponent)
+
+func newComponent(params componentParams) workercommon.WorkerComponent {
+	return &visibilityRebuild{
+		numHistoryShards:  params.PersistenceConfig.NumHistoryShards,
+		executionManager:  params.ExecutionManager,
+		namespaceRegistry: params.NamespaceRegistry,
+		historyClient:     params.HistoryClient,
+		// The visibility manager of the worker service can't write, so the records written directly by the rebuild
+		// go through a dedicated visibility manager, which is only created when a rebuild needs it.
+		visibilityManager: sync.OnceValues(func() (manager.VisibilityManager, error) {
+			return newVisibilityManager(params)
+		}),
+		logger: params.Logger,
+	}
+}
+
+func (wc *visibilityRebuild) RegisterWorkflow(registry sdkworker.Registry) {
+	registry.RegisterWorkflowWithOptions(VisibilityRebuildWorkflow, workflow.RegisterOptions{Name: WorkflowName})
+}
+
+func (wc *visibilityRebuild) DedicatedWorkflowWorkerOptions() *workercommon.DedicatedWorkerOptions {
+	// use default worker
+	return nil
+}
+
+func (wc *visibilityRebuild) RegisterActivities(registry sdkworker.Registry) {
+	registry.RegisterActivity(wc.activities())
+}
+
+func (wc *visibilityRebuild) DedicatedActivityWorkerOptions() *workercommon.DedicatedWorkerOptions {
+	return &workercommon.DedicatedWorkerOptions{
+		TaskQueue: primitives.VisibilityRebuildActivityTQ,
+		Options: sdkworker.Options{
+			BackgroundActivityContext: headers.SetCallerType(context.Background(), headers.CallerTypePreemptable),
+		},
+	}
+}
+
+func (wc *visibilityRebuild) activities() *activities {
+	return &activities{
+		numHistoryShards:  wc.numHistoryShards,
+		executionManager:  wc.executionManager,
+		namespaceRegistry: wc.namespaceRegistry,
+		historyClient:     wc.historyClient,
+		visibilityManager: wc.visibilityManager,
+		logger:            wc.logger,
+	}
+}
+
+func newVisibilityManager(params componentParams) (manager.VisibilityManager, error) {
+	dc := params.DynamicCollection
+	return visibility.NewManager(
+		*params.PersistenceConfig,
+		params.PersistenceServiceResolver,
+		params.CustomVisibilityStoreFactory,
+		&elasticsearch.ProcessorConfig{
+			IndexerConcurrency:       dynamicconfig.WorkerIndexerConcurrency.Get(dc),
+			ESProcessorNumOfWorkers:  dynamicconfig.WorkerESProcessorNumOfWorkers.Get(dc),
+			ESProcessorBulkActions:   dynamicconfig.WorkerESProcessorBulkActions.Get(dc),
+			ESProcessorBulkSize:      dynamicconfig.WorkerESProcessorBulkSize.Get(dc),
+			ESProcessorFlushInterval: dynamicconfig.WorkerESProcessorFlushInterval.Get(dc),
+			ESProcessorAckTimeout:    dynamicconfig.WorkerESProcessorAckTimeout.Get(dc),
+		},
+		params.SearchAttributesProvider,
+		params.SearchAttributesMapperProvider,
+		params.NamespaceRegistry,
+		params.ChasmRegistry,
+		dynamicconfig.VisibilityPersistenceMaxReadQPS.Get(dc),
+		dynamicconfig.VisibilityPersistenceMaxWriteQPS.Get(dc),
+		dynamicconfig.OperatorRPSRatio.Get(dc),
+		dynamicconfig.VisibilityPersistenceSlowQueryThreshold.Get(dc),
+		dynamicconfig.EnableReadFromSecondaryVisibility.Get(dc),
+		dynamicconfig.VisibilityEnableShadowReadMode.Get(dc),
+		// the rebuild writes to the primary and the secondary visibility managers directly
+		dynamicconfig.GetStringPropertyFn(visibility.SecondaryVisibilityWritingModeOff),
+		dynamicconfig.VisibilityDisableOrderByClause.Get(dc),
+		dynamicconfig.VisibilityEnableManualPagination.Get(dc),
+		dynamicconfig.VisibilityEnableUnifiedQueryConverter.Get(dc),
+		dynamicconfig.VisibilityCountGroupByMaxGroups.Get(dc),
+		params.MetricsHandler,
+		params.Logger,
+		params.Serializer,
+	)
+}
@@ -0,0 +1,265 @@
+package visibilityrebuild
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+	"go.temporal.io/server/common/log/tag"
+	"go.temporal.io/server/common/primitives"
+)
+
+const (
+	// WorkflowName is the name of the system workflow which rebuilds the visibility records of the executions from
+	// their mutable state.
+	WorkflowName = "temporal-sys-visibility-rebuild-workflow"
+
+	// ModeRefreshTasks regenerates the upsert or close visibility task of the executions, which the history service
+	// then processes into all the visibility stores, exactly like the visibility tasks of a new transition.
+	ModeRefreshTasks Mode = "refresh-tasks"
+	// ModeWriteDirect builds the visibility records from the mutable state of the executions and writes them directly
+	// into the target store, without going through the visibility tasks of the history service. The records are
+	// versioned with a task ID allocated from the shard of the executions, so that they replace the diverged records.
+	// The executions of which the record can't be built from the mutable state only, such as CHASM executions, are
+	// skipped.
+	ModeWriteDirect Mode = "write-direct"
+
+	// TargetStorePrimary is the visibility store of the persistence config.
+	TargetStorePrimary TargetStore = "primary"
+	// TargetStoreSecondary is the secondary visibility store of the persistence config.
+	TargetStoreSecondary TargetStore = "secondary"
+
+	// MaxReportedFailures is the maximum number of failures which are reported in the results.
+	MaxReportedFailures = 10
+
+	defaultPageSize    = 100
+	defaultConcurrency = 10
+	defaultRPS         = 100
+)
+
+type (
+	// Mode is how the visibility records are rebuilt.
+	Mode string
+
+	// TargetStore is the visibility store which the records are written to in ModeWriteDirect.
+	TargetStore string
+
+	// WorkflowParams is the parameters for the visibility rebuild workflow.
+	WorkflowParams struct {
+		// Mode is how the visibility records are rebuilt. Defaults to ModeRefreshTasks.
+		Mode Mode
+		// TargetStore is the visibility store which the records are written to in ModeWriteDirect. Defaults to
+		// TargetStorePrimary.
+		TargetStore TargetStore
+		// Namespace restricts the rebuild to the executions of a namespace. All the namespaces are rebuilt if it is
+		// empty.
+		Namespace string
+		// UpdatedAfter and UpdatedBefore restrict the rebuild to the executions which were last updated in this time
+		// range, such as during an outage of the visibility store. A zero time doesn't bound the range.
+		UpdatedAfter  time.Time
+		UpdatedBefore time.Time
+		// ShardIDs are the shards to rebuild. All the shards are rebuilt if it is empty.
+		ShardIDs []int32
+		// PageSize is the number of executions which are listed per page. Defaults to 100.
+		PageSize int
+		// Concurrency is the number of shards which are rebuilt concurrently. Defaults to 10.
+		Concurrency int
+		// RPS is the maximum number of executions which are rebuilt per second, across all the shards. Defaults to
+		// 100.
+		RPS float64
+
+		// PreviousResult is the result of the runs before the last continue-as-new.
+		PreviousResult WorkflowResult
+		// ContinueAsNewCount is the number of times the workflow continued as new.
+		ContinueAsNewCount int
+	}
+
+	// WorkflowResult is the result of the visibility rebuild workflow.
+	WorkflowResult struct {
+		// RebuiltShards is the number of shards which were rebuilt.
+		RebuiltShards int
+		// ScannedExecutions is the number of executions which were listed, including the ones which are out of the
+		// namespace or the time range.
+		ScannedExecutions int
+		// RebuiltExecutions is the number of executions of which the visibility record was rebuilt.
+		RebuiltExecutions int
+		// SkippedExecutions is the number of executions which were deleted during the rebuild, or of which the
+		// record can't be written directly.
+		SkippedExecutions int
+		// FailedExecutions is the number of executions of which the visibility record couldn't be rebuilt.
+		FailedExecutions int
+		// Failures describes some of the failed executions.
+		Failures []string
+	}
+
+	// RebuildShardParams is the parameters for RebuildShardActivity.
+	RebuildShardParams struct {
+		ShardID       int32
+		Mode          Mode
+		TargetStore   TargetStore
+		NamespaceID   string
+		UpdatedAfter  time.Time
+		UpdatedBefore time.Time
+		PageSize      int
+		RPS           float64
+	}
+
+	// RebuildShardResult is the result of the rebuild of a shard.
+	RebuildShardResult struct {
+		ShardID           int32
+		ScannedExecutions int
+		RebuiltExecutions int
+		SkippedExecutions int
+		FailedExecutions  int
+		Failures          []string
+	}
+)
+
+var (
+	getShardIDsActivityOptions = workflow.ActivityOptions{
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval: 1 * time.Second,
+		},
+		StartToCloseTimeout:    10 * time.Second,
+		ScheduleToCloseTimeout: 1 * time.Minute,
+	}
+
+	rebuildShardActivityOptions = workflow.ActivityOptions{
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    1 * time.Second,
+			MaximumInterval:    1 * time.Minute,
+			BackoffCoefficient: 2,
+		},
+		StartToCloseTimeout: 24 * time.Hour,
+		HeartbeatTimeout:    1 * time.Minute,
+	}
+)
+
+func (p *WorkflowParams) applyDefaults() {
+	if p.Mode == "" {
+		p.Mode = ModeRefreshTasks
+	}
+	if p.TargetStore == "" {
+		p.TargetStore = TargetStorePrimary
+	}
+	if p.PageSize <= 0 {
+		p.PageSize = defaultPageSize
+	}
+	if p.Concurrency <= 0 {
+		p.Concurrency = defaultConcurrency
+	}
+	if p.RPS <= 0 {
+		p.RPS = defaultRPS
+	}
+}
+
+func (p *WorkflowParams) validate() error {
+	switch p.Mode {
+	case ModeRefreshTasks, ModeWriteDirect:
+	default:
+		return temporal.NewNonRetryableApplicationError(fmt.Sprintf("unknown mode %q", p.Mode), "", nil)
+	}
+	switch p.TargetStore {
+	case TargetStorePrimary, TargetStoreSecondary:
+	default:
+		return temporal.NewNonRetryableApplicationError(fmt.Sprintf("unknown target store %q", p.TargetStore), "", nil)
+	}
+	if !p.UpdatedAfter.IsZero() && !p.UpdatedBefore.IsZero() && !p.UpdatedAfter.Before(p.UpdatedBefore) {
+		return temporal.NewNonRetryableApplicationError("UpdatedAfter must be before UpdatedBefore", "", nil)
+	}
+	return nil
+}
+
+func (r *WorkflowResult) add(shardResult RebuildShardResult) {
+	r.RebuiltShards++
+	r.ScannedExecutions += shardResult.ScannedExecutions
+	r.RebuiltExecutions += shardResult.RebuiltExecutions
+	r.SkippedExecutions += shardResult.SkippedExecutions
+	r.FailedExecutions += shardResult.FailedExecutions
+	for _, failure := range shardResult.Failures {
+		if len(r.Failures) < MaxReportedFailures {
+			r.Failures = append(r.Failures, failure)
+		}
+	}
+}
+
+// VisibilityRebuildWorkflow scans the executions of the shards and rebuilds their visibility records, either by
+// regenerating their visibility tasks or by writing the records directly into a visibility store. The shards are
+// rebuilt by activities which checkpoint their progress in heartbeats, and the workflow continues as new with the
+// remaining shards when its history grows too large.
+func VisibilityRebuildWorkflow(ctx workflow.Context, params WorkflowParams) (WorkflowResult, error) {
+	logger := workflow.GetLogger(ctx)
+	logger.Info("Workflow started.", tag.WorkflowType(WorkflowName), tag.Counter(params.ContinueAsNewCount))
+
+	params.applyDefaults()
+	if err := params.validate(); err != nil {
+		return WorkflowResult{}, err
+	}
+
+	ctx = workflow.WithTaskQueue(ctx, primitives.VisibilityRebuildActivityTQ)
+	var a *activities
+
+	ctx1 := workflow.WithActivityOptions(ctx, getShardIDsActivityOptions)
+	var namespaceID string
+	if params.Namespace != "" {
+		if err := workflow.ExecuteActivity(ctx1, a.GetNamespaceIDActivity, params.Namespace).Get(ctx, &namespaceID); err != nil {
+			return WorkflowResult{}, fmt.Errorf("GetNamespaceIDActivity: %w", err)
+		}
+	}
+	shardIDs := params.ShardIDs
+	if len(shardIDs) == 0 {
+		if err := workflow.ExecuteActivity(ctx1, a.GetRebuildShardIDsActivity).Get(ctx, &shardIDs); err != nil {
+			return WorkflowResult{}, fmt.Errorf("GetRebuildShardIDsActivity: %w", err)
+		}
+	}
+
+	result := params.PreviousResult
+	ctx2 := workflow.WithActivityOptions(ctx, rebuildShardActivityOptions)
+	for len(shardIDs) > 0 {
+		batch := shardIDs[:min(params.Concurrency, len(shardIDs))]
+		futures := make([]workflow.Future, 0, len(batch))
+		for _, shardID := range batch {
+			futures = append(futures, workflow.ExecuteActivity(ctx2, a.RebuildShardActivity, RebuildShardParams{
+				ShardID:       shardID,
+				Mode:          params.Mode,
+				TargetStore:   params.TargetStore,
+				NamespaceID:   namespaceID,
+				UpdatedAfter:  params.UpdatedAfter,
+				UpdatedBefore: params.UpdatedBefore,
+				PageSize:      params.PageSize,
+				// The RPS is shared by the shards which are rebuilt concurrently.
+				RPS: params.RPS / float64(len(batch)),
+			}))
+		}
+		for i, future := range futures {
+			var shardResult RebuildShardResult
+			if err := future.Get(ctx, &shardResult); err != nil {
+				return result, fmt.Errorf("RebuildShardActivity for shard %d: %w", batch[i], err)
+			}
+			result.add(shardResult)
+		}
+		shardIDs = shardIDs[len(batch):]
+
+		if len(shardIDs) > 0 && workflow.GetInfo(ctx).GetContinueAsNewSuggested() {
+			params.ShardIDs = slices.Clone(shardIDs)
+			params.PreviousResult = result
+			params.ContinueAsNewCount++
+			logger.Info("There are more shards to rebuild. Continuing workflow as new.",
+				tag.WorkflowType(WorkflowName),
+				tag.NewInt("remaining-shards", len(shardIDs)),
+			)
+			return result, workflow.NewContinueAsNewError(ctx, VisibilityRebuildWorkflow, params)
+		}
+	}
+
+	logger.Info("Workflow finished successfully.",
+		tag.WorkflowType(WorkflowName),
+		tag.NewInt("rebuilt-shards", result.RebuiltShards),
+		tag.NewInt("rebuilt-executions", result.RebuiltExecutions),
+		tag.NewInt("skipped-executions", result.SkippedExecutions),
+		tag.NewInt("failed-executions", result.FailedExecutions),
+	)
+	return result, nil
+}
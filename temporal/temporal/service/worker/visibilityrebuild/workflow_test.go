@@ -0,0 +1,152 @@
+package visibilityrebuild
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/testsuite"
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/chasm"
+	"go.temporal.io/server/common/log"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func Test_VisibilityRebuildWorkflow(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	testSuite.SetLogger(log.NewSdkLogger(log.NewTestLogger()))
+	env := testSuite.NewTestWorkflowEnvironment()
+	var a *activities
+
+	env.OnActivity(a.GetNamespaceIDActivity, mock.Anything, "test-namespace").Return("test-namespace-id", nil).Once()
+	env.OnActivity(a.GetRebuildShardIDsActivity, mock.Anything).Return([]int32{1, 2, 3}, nil).Once()
+	shardParams := func(shardID int32, rps float64) RebuildShardParams {
+		return RebuildShardParams{
+			ShardID:     shardID,
+			Mode:        ModeWriteDirect,
+			TargetStore: TargetStoreSecondary,
+			NamespaceID: "test-namespace-id",
+			PageSize:    defaultPageSize,
+			RPS:         rps,
+		}
+	}
+	env.OnActivity(a.RebuildShardActivity, mock.Anything, shardParams(1, 50)).
+		Return(RebuildShardResult{ShardID: 1, ScannedExecutions: 10, RebuiltExecutions: 8, SkippedExecutions: 2}, nil).Once()
+	env.OnActivity(a.RebuildShardActivity, mock.Anything, shardParams(2, 50)).
+		Return(RebuildShardResult{ShardID: 2, ScannedExecutions: 5, RebuiltExecutions: 4, FailedExecutions: 1, Failures: []string{"failure"}}, nil).Once()
+	env.OnActivity(a.RebuildShardActivity, mock.Anything, shardParams(3, 100)).
+		Return(RebuildShardResult{ShardID: 3, ScannedExecutions: 1, RebuiltExecutions: 1}, nil).Once()
+
+	env.ExecuteWorkflow(VisibilityRebuildWorkflow, WorkflowParams{
+		Mode:        ModeWriteDirect,
+		TargetStore: TargetStoreSecondary,
+		Namespace:   "test-namespace",
+		Concurrency: 2,
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	var result WorkflowResult
+	require.NoError(t, env.GetWorkflowResult(&result))
+	require.Equal(t, WorkflowResult{
+		RebuiltShards:     3,
+		ScannedExecutions: 16,
+		RebuiltExecutions: 13,
+		SkippedExecutions: 2,
+		FailedExecutions:  1,
+		Failures:          []string{"failure"},
+	}, result)
+	env.AssertExpectations(t)
+}
+
+func Test_VisibilityRebuildWorkflow_ShardIDs(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	testSuite.SetLogger(log.NewSdkLogger(log.NewTestLogger()))
+	env := testSuite.NewTestWorkflowEnvironment()
+	var a *activities
+
+	env.OnActivity(a.RebuildShardActivity, mock.Anything, RebuildShardParams{
+		ShardID:     7,
+		Mode:        ModeRefreshTasks,
+		TargetStore: TargetStorePrimary,
+		PageSize:    defaultPageSize,
+		RPS:         defaultRPS,
+	}).Return(RebuildShardResult{ShardID: 7, ScannedExecutions: 3, RebuiltExecutions: 3}, nil).Once()
+
+	env.ExecuteWorkflow(VisibilityRebuildWorkflow, WorkflowParams{ShardIDs: []int32{7}})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	var result WorkflowResult
+	require.NoError(t, env.GetWorkflowResult(&result))
+	require.Equal(t, 1, result.RebuiltShards)
+	require.Equal(t, 3, result.RebuiltExecutions)
+	env.AssertExpectations(t)
+}
+
+func Test_VisibilityRebuildWorkflow_InvalidParams(t *testing.T) {
+	testCases := []struct {
+		name   string
+		params WorkflowParams
+	}{
+		{
+			name:   "unknown mode",
+			params: WorkflowParams{Mode: "unknown"},
+		},
+		{
+			name:   "unknown target store",
+			params: WorkflowParams{TargetStore: "unknown"},
+		},
+		{
+			name: "empty time range",
+			params: WorkflowParams{
+				UpdatedAfter:  time.Unix(2000, 0),
+				UpdatedBefore: time.Unix(1000, 0),
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			testSuite := &testsuite.WorkflowTestSuite{}
+			testSuite.SetLogger(log.NewSdkLogger(log.NewTestLogger()))
+			env := testSuite.NewTestWorkflowEnvironment()
+
+			env.ExecuteWorkflow(VisibilityRebuildWorkflow, tc.params)
+
+			require.True(t, env.IsWorkflowCompleted())
+			require.Error(t, env.GetWorkflowError())
+		})
+	}
+}
+
+func TestRebuildShardParams_Matches(t *testing.T) {
+	state := &persistencespb.WorkflowMutableState{
+		ExecutionInfo: &persistencespb.WorkflowExecutionInfo{
+			NamespaceId:    "namespace-id",
+			LastUpdateTime: timestamppb.New(time.Unix(1000, 0)),
+		},
+	}
+
+	require.True(t, RebuildShardParams{}.matches(state))
+	require.True(t, RebuildShardParams{NamespaceID: "namespace-id"}.matches(state))
+	require.False(t, RebuildShardParams{NamespaceID: "other-namespace-id"}.matches(state))
+	require.True(t, RebuildShardParams{UpdatedAfter: time.Unix(1000, 0), UpdatedBefore: time.Unix(1001, 0)}.matches(state))
+	require.False(t, RebuildShardParams{UpdatedAfter: time.Unix(1001, 0)}.matches(state))
+	require.False(t, RebuildShardParams{UpdatedBefore: time.Unix(1000, 0)}.matches(state))
+}
+
+func TestArchetypeID(t *testing.T) {
+	require.Equal(t, chasm.WorkflowArchetypeID, archetypeID(&persistencespb.WorkflowMutableState{}))
+	require.Equal(t, chasm.ArchetypeID(42), archetypeID(&persistencespb.WorkflowMutableState{
+		ChasmNodes: map[string]*persistencespb.ChasmNode{
+			"": {
+				Metadata: &persistencespb.ChasmNodeMetadata{
+					Attributes: &persistencespb.ChasmNodeMetadata_ComponentAttributes{
+						ComponentAttributes: &persistencespb.ChasmComponentAttributes{TypeId: 42},
+					},
+				},
+			},
+		},
+	}))
+}
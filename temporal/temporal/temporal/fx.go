@@ -39,6 +39,7 @@ import (
 	"go.temporal.io/server/common/persistence"
 	"go.temporal.io/server/common/persistence/cassandra"
 	persistenceClient "go.temporal.io/server/common/persistence/client"
+	"go.temporal.io/server/common/persistence/faultinjection"
 	"go.temporal.io/server/common/persistence/serialization"
 	"go.temporal.io/server/common/persistence/sql"
 	"go.temporal.io/server/common/persistence/visibility"
@@ -108,6 +109,7 @@ type (
 		ServiceResolver        resolver.ServiceResolver
 		CustomDataStoreFactory persistenceClient.AbstractDataStoreFactory
 		CustomVisibilityStore  visibility.VisibilityStoreFactory
+		PersistenceKeyProvider serialization.KeyProvider
 
 		SearchAttributesMapper     searchattribute.Mapper
 		CustomFrontendInterceptors []grpc.UnaryServerInterceptor
@@ -133,6 +135,7 @@ var (
 			ServerOptionsProvider,
 			resource.ArchivalMetadataProvider,
 			TaskCategoryRegistryProvider,
+			FaultInjectionRulesProvider,
 			PersistenceFactoryProvider,
 			HistoryServiceProvider,
 			MatchingServiceProvider,
@@ -292,6 +295,7 @@ func ServerOptionsProvider(opts []ServerOption) (serverOptionsProvider, error) {
 		ServiceResolver:        so.persistenceServiceResolver,
 		CustomDataStoreFactory: so.customDataStoreFactory,
 		CustomVisibilityStore:  so.customVisibilityStoreFactory,
+		PersistenceKeyProvider: so.persistenceKeyProvider,
 
 		SearchAttributesMapper:     so.searchAttributesMapper,
 		CustomFrontendInterceptors: so.customFrontendInterceptors,
@@ -364,10 +368,12 @@ type (
 		ClaimMapper                authorization.ClaimMapper
 		DataStoreFactory           persistenceClient.AbstractDataStoreFactory
 		VisibilityStoreFactory     visibility.VisibilityStoreFactory
+		PersistenceKeyProvider     serialization.KeyProvider
 		SpanExporters              []otelsdktrace.SpanExporter
 		InstanceID                 resource.InstanceID                     `optional:"true"`
 		StaticServiceHosts         map[primitives.ServiceName]static.Hosts `optional:"true"`
 		TaskCategoryRegistry       tasks.TaskCategoryRegistry
+		FaultInjectionRules        *faultinjection.Rules
 	}
 )
 
@@ -401,6 +407,9 @@ func (params ServiceProviderParamsCommon) GetCommonServiceOptions(serviceName pr
 			func() visibility.VisibilityStoreFactory {
 				return params.VisibilityStoreFactory
 			},
+			func() serialization.KeyProvider {
+				return params.PersistenceKeyProvider
+			},
 			func() client.FactoryProvider {
 				return params.ClientFactoryProvider
 			},
@@ -440,6 +449,9 @@ func (params ServiceProviderParamsCommon) GetCommonServiceOptions(serviceName pr
 			func() tasks.TaskCategoryRegistry {
 				return params.TaskCategoryRegistry
 			},
+			func() *faultinjection.Rules {
+				return params.FaultInjectionRules
+			},
 		),
 		ServiceTracingModule,
 		resource.DefaultOptions,
@@ -464,6 +476,24 @@ func TaskCategoryRegistryProvider(archivalMetadata archiver.ArchivalMetadata) ta
 	return registry
 }
 
+// FaultInjectionRulesProvider provides the persistence fault injection rules to the server, or nil if fault injection
+// is not enabled for the default store. Like the TaskCategoryRegistry, the rules are shared by each service, so that
+// a rule which is added through the admin API of the frontend also applies to the other services of the process.
+func FaultInjectionRulesProvider(
+	cfg *config.Config,
+	dc *dynamicconfig.Collection,
+	logger log.Logger,
+	lc fx.Lifecycle,
+) *faultinjection.Rules {
+	fiConfig := cfg.Persistence.DataStores[cfg.Persistence.DefaultStore].FaultInjection
+	if fiConfig == nil {
+		return nil
+	}
+	rules := faultinjection.NewRules(fiConfig, dc, logger)
+	lc.Append(fx.StopHook(rules.Stop))
+	return rules
+}
+
 func NewService(app *fx.App, serviceName primitives.ServiceName, logger log.Logger) ServicesGroupOut {
 	return ServicesGroupOut{
 		Services: &ServicesMetadata{
@@ -602,6 +632,7 @@ func ApplyClusterMetadataConfigProvider(
 		clusterName,
 		persistenceServiceResolver,
 		&svc.Persistence,
+		nil,
 		customDataStoreFactory,
 		logger,
 		metricsHandler,
@@ -162,6 +162,7 @@ func initSystemNamespaces(
 		clusterName,
 		persistenceServiceResolver,
 		cfg,
+		nil,
 		customDataStoreFactory,
 		logger,
 		metricsHandler,
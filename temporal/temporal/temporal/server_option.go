@@ -11,6 +11,7 @@ import (
 	"go.temporal.io/server/common/membership/static"
 	"go.temporal.io/server/common/metrics"
 	persistenceclient "go.temporal.io/server/common/persistence/client"
+	"go.temporal.io/server/common/persistence/serialization"
 	"go.temporal.io/server/common/persistence/visibility"
 	"go.temporal.io/server/common/primitives"
 	"go.temporal.io/server/common/resolver"
@@ -154,6 +155,15 @@ func WithCustomVisibilityStoreFactory(customFactory visibility.VisibilityStoreFa
 	})
 }
 
+// WithPersistenceKeyProvider sets the key provider of the encryption at rest of history and mutable state blobs,
+// instead of the file key provider of the persistence config.
+// NOTE: this option is experimental and may be changed or removed in future release.
+func WithPersistenceKeyProvider(keyProvider serialization.KeyProvider) ServerOption {
+	return applyFunc(func(s *serverOptions) {
+		s.persistenceKeyProvider = keyProvider
+	})
+}
+
 // WithClientFactoryProvider sets a custom ClientFactoryProvider
 // NOTE: this option is experimental and may be changed or removed in future release.
 func WithClientFactoryProvider(clientFactoryProvider client.FactoryProvider) ServerOption {
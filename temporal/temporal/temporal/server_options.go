@@ -14,6 +14,7 @@ import (
 	"go.temporal.io/server/common/membership/static"
 	"go.temporal.io/server/common/metrics"
 	persistenceClient "go.temporal.io/server/common/persistence/client"
+	"go.temporal.io/server/common/persistence/serialization"
 	"go.temporal.io/server/common/persistence/visibility"
 	"go.temporal.io/server/common/primitives"
 	"go.temporal.io/server/common/resolver"
@@ -51,6 +52,7 @@ type (
 		dynamicConfigClient          dynamicconfig.Client
 		customDataStoreFactory       persistenceClient.AbstractDataStoreFactory
 		customVisibilityStoreFactory visibility.VisibilityStoreFactory
+		persistenceKeyProvider       serialization.KeyProvider
 		clientFactoryProvider        client.FactoryProvider
 		searchAttributesMapper       searchattribute.Mapper
 		customFrontendInterceptors   []grpc.UnaryServerInterceptor
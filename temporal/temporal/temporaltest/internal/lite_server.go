@@ -22,6 +22,7 @@ import (
 	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/metrics"
 	sqliteplugin "go.temporal.io/server/common/persistence/sql/sqlplugin/sqlite"
+	"go.temporal.io/server/common/persistence/visibility/store/memory"
 	"go.temporal.io/server/common/testing/freeport"
 	"go.temporal.io/server/schema/sqlite"
 	"go.temporal.io/server/temporal"
@@ -69,6 +70,9 @@ type LiteServerConfig struct {
 	DynamicConfig dynamicconfig.StaticClient
 	// SearchAttributes adds custom search attributes to all namespaces created on Temporal start.
 	SearchAttributes map[string]enumspb.IndexedValueType
+	// InMemoryVisibility stores visibility records in memory instead of SQLite.
+	// Visibility records are lost on each process restart, even if Ephemeral is false.
+	InMemoryVisibility bool
 }
 
 func (cfg *LiteServerConfig) apply(serverConfig *config.Config) {
@@ -118,6 +122,15 @@ func (cfg *LiteServerConfig) apply(serverConfig *config.Config) {
 			sqliteplugin.PluginName: {SQL: &sqliteConfig},
 		},
 	}
+	if cfg.InMemoryVisibility {
+		serverConfig.Persistence.VisibilityStore = memory.PersistenceName
+		serverConfig.Persistence.DataStores[memory.PersistenceName] = config.DataStore{
+			CustomDataStoreConfig: &config.CustomDatastoreConfig{
+				Name:      memory.PersistenceName,
+				IndexName: memory.PersistenceName,
+			},
+		}
+	}
 	serverConfig.ClusterMetadata = &cluster.Config{
 		EnableGlobalNamespace:    false,
 		FailoverVersionIncrement: 10,
@@ -276,6 +289,10 @@ func NewLiteServer(liteConfig *LiteServerConfig, opts ...temporal.ServerOption)
 		}),
 	}
 
+	if liteConfig.InMemoryVisibility {
+		serverOpts = append(serverOpts, temporal.WithCustomVisibilityStoreFactory(memory.NewVisibilityStoreFactory()))
+	}
+
 	if len(liteConfig.DynamicConfig) > 0 {
 		// To prevent having to code fall-through semantics right now, we currently
 		// eagerly fail if dynamic config is being configured in two ways
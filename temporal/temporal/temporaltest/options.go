@@ -50,3 +50,26 @@ func WithBaseServerOptions(options ...temporal.ServerOption) TestServerOption {
 		server.serverOptions = append(server.serverOptions, options...)
 	})
 }
+
+// WithInMemoryVisibility stores visibility records in memory instead of SQLite.
+func WithInMemoryVisibility() TestServerOption {
+	return applyFunc(func(server *TestServer) {
+		server.inMemoryVisibility = true
+	})
+}
+
+// WithTimeSkipping enables time skipping for the test namespace, so that its timers run against a virtual clock
+// instead of the real time.
+//
+// The virtual clock is automatically advanced to the next timer of the namespace when none of its workflows has
+// pending work, such as a workflow task or a running activity. It can also be advanced explicitly with
+// TestServer.AdvanceTime.
+//
+// Only the timers which wait for time to pass are skipped, such as workflow.Sleep, retry backoffs and workflow
+// timeouts. Workflow task and activity timeouts still run on the real time, and so does the time returned by
+// workflow.Now.
+func WithTimeSkipping() TestServerOption {
+	return applyFunc(func(server *TestServer) {
+		server.timeSkipping = true
+	})
+}
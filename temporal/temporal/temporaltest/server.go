@@ -8,12 +8,18 @@ import (
 	"testing"
 	"time"
 
+	"go.temporal.io/api/serviceerror"
 	"go.temporal.io/sdk/client"
 	"go.temporal.io/sdk/worker"
+	"go.temporal.io/server/api/adminservice/v1"
 	"go.temporal.io/server/common/dynamicconfig"
 	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/temporal"
 	temporalite "go.temporal.io/server/temporaltest/internal"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 // A TestServer is a Temporal server listening on a system-chosen port on the
@@ -30,6 +36,9 @@ type TestServer struct {
 	defaultClientOptions client.Options
 	defaultWorkerOptions worker.Options
 	serverOptions        []temporal.ServerOption
+	inMemoryVisibility   bool
+	timeSkipping         bool
+	adminConn            *grpc.ClientConn
 }
 
 func (ts *TestServer) fatal(err error) {
@@ -110,6 +119,29 @@ func (ts *TestServer) NewClientWithOptions(opts client.Options) client.Client {
 	return c
 }
 
+// AdvanceTime moves the virtual time of the test namespace forward by the duration, and returns the new virtual time.
+// The timers of the namespace which are due on the new virtual time fire right away.
+//
+// The WithTimeSkipping option must be specified to advance time.
+func (ts *TestServer) AdvanceTime(ctx context.Context, d time.Duration) (time.Time, error) {
+	if ts.adminConn == nil {
+		conn, err := grpc.NewClient(ts.GetFrontendHostPort(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			return time.Time{}, err
+		}
+		ts.adminConn = conn
+	}
+
+	resp, err := adminservice.NewAdminServiceClient(ts.adminConn).AdvanceNamespaceTime(ctx, &adminservice.AdvanceNamespaceTimeRequest{
+		Namespace: ts.defaultTestNamespace,
+		Duration:  durationpb.New(d),
+	})
+	if err != nil {
+		return time.Time{}, serviceerror.FromStatus(status.Convert(err))
+	}
+	return resp.GetTime().AsTime(), nil
+}
+
 // Stop closes test clients and shuts down the server.
 func (ts *TestServer) Stop() {
 	for _, w := range ts.workers {
@@ -118,6 +150,9 @@ func (ts *TestServer) Stop() {
 	for _, c := range ts.clients {
 		c.Close()
 	}
+	if ts.adminConn != nil {
+		_ = ts.adminConn.Close()
+	}
 	if err := ts.server.Stop(); err != nil {
 		// Log instead of throwing error because there's no need to fail the test
 		// if it already succeeded.
@@ -145,15 +180,24 @@ func NewServer(opts ...TestServerOption) *TestServer {
 		ts.t.Cleanup(ts.Stop)
 	}
 
+	dynamicConfig := dynamicconfig.StaticClient{
+		dynamicconfig.ForceSearchAttributesCacheRefreshOnRead.Key(): []dynamicconfig.ConstrainedValue{{Value: true}},
+	}
+	if ts.timeSkipping {
+		dynamicConfig[dynamicconfig.TimeSkippingEnabled.Key()] = []dynamicconfig.ConstrainedValue{{
+			Constraints: dynamicconfig.Constraints{Namespace: ts.defaultTestNamespace},
+			Value:       true,
+		}}
+	}
+
 	s, err := temporalite.NewLiteServer(&temporalite.LiteServerConfig{
-		Namespaces: []string{ts.defaultTestNamespace},
-		Ephemeral:  true,
-		Logger:     log.NewNoopLogger(),
-		DynamicConfig: dynamicconfig.StaticClient{
-			dynamicconfig.ForceSearchAttributesCacheRefreshOnRead.Key(): []dynamicconfig.ConstrainedValue{{Value: true}},
-		},
+		Namespaces:    []string{ts.defaultTestNamespace},
+		Ephemeral:     true,
+		Logger:        log.NewNoopLogger(),
+		DynamicConfig: dynamicConfig,
 		// Disable "accept incoming network connections?" prompt on macOS
-		FrontendIP: "127.0.0.1",
+		FrontendIP:         "127.0.0.1",
+		InMemoryVisibility: ts.inMemoryVisibility,
 	}, ts.serverOptions...)
 	if err != nil {
 		ts.fatal(fmt.Errorf("error creating server: %w", err))
@@ -261,9 +261,18 @@ func TestClientWithCustomInterceptor(t *testing.T) {
 
 func TestSearchAttributeRegistration(t *testing.T) {
 	t.Parallel()
+	testSearchAttributeRegistration(t)
+}
+
+func TestSearchAttributeRegistration_InMemoryVisibility(t *testing.T) {
+	t.Parallel()
+	testSearchAttributeRegistration(t, temporaltest.WithInMemoryVisibility())
+}
+
+func testSearchAttributeRegistration(t *testing.T, opts ...temporaltest.TestServerOption) {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
-	ts := temporaltest.NewServer(temporaltest.WithT(t))
+	ts := temporaltest.NewServer(append(opts, temporaltest.WithT(t))...)
 	c := ts.GetDefaultClient()
 
 	testSearchAttr := "MySearchAttr"
@@ -339,6 +348,59 @@ func TestSearchAttributeRegistration(t *testing.T) {
 	}, 30*time.Second, 100*time.Millisecond)
 }
 
+func TestTimeSkipping(t *testing.T) {
+	t.Parallel()
+	ts := temporaltest.NewServer(temporaltest.WithT(t), temporaltest.WithTimeSkipping())
+
+	ts.NewWorker("sleep", func(registry worker.Registry) {
+		registry.RegisterWorkflow(SleepWorkflow)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// The timer of the workflow fires once the virtual time is auto-advanced.
+	wfr, err := ts.GetDefaultClient().ExecuteWorkflow(
+		ctx,
+		client.StartWorkflowOptions{TaskQueue: "sleep"},
+		SleepWorkflow,
+		24*time.Hour,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := wfr.Get(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAdvanceTime(t *testing.T) {
+	t.Parallel()
+	ts := temporaltest.NewServer(temporaltest.WithT(t), temporaltest.WithTimeSkipping())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	virtualTime, err := ts.AdvanceTime(ctx, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.False(t, virtualTime.Before(now.Add(time.Hour)))
+}
+
+func TestAdvanceTime_NotEnabled(t *testing.T) {
+	t.Parallel()
+	ts := temporaltest.NewServer(temporaltest.WithT(t))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	_, err := ts.AdvanceTime(ctx, time.Hour)
+	var failedPrecondition *serviceerror.FailedPrecondition
+	assert.ErrorAs(t, err, &failedPrecondition)
+}
+
 func BenchmarkRunWorkflow(b *testing.B) {
 	ts := temporaltest.NewServer()
 	defer ts.Stop()
@@ -376,6 +438,10 @@ func SearchAttrWorkflow(ctx workflow.Context, searchAttr string) error {
 	})
 }
 
+func SleepWorkflow(ctx workflow.Context, d time.Duration) error {
+	return workflow.Sleep(ctx, d)
+}
+
 // Example workflow/activity
 
 // Greet implements a Temporal workflow that returns a salutation for a given subject.
@@ -986,17 +986,24 @@ func (s *AdvancedVisibilitySuite) TestCountGroupByWorkflow() {
 		resp.Groups[1],
 	)
 
-	query = `GROUP BY WorkflowType`
+	query = fmt.Sprintf(`WorkflowType = %q GROUP BY ExecutionStatus, WorkflowType`, wt)
+	countRequest.Query = query
+	resp, err = s.FrontendClient().CountWorkflowExecutions(testcore.NewContext(), countRequest)
+	s.NoError(err)
+	s.Equal(int64(numWorkflows), resp.GetCount())
+	s.Len(resp.Groups, 2)
+
+	query = `GROUP BY WorkflowId`
 	countRequest.Query = query
 	_, err = s.FrontendClient().CountWorkflowExecutions(testcore.NewContext(), countRequest)
 	s.Error(err)
 	s.Contains(strings.ToLower(err.Error()), "'group by' clause is only supported for")
 
-	query = `GROUP BY ExecutionStatus, WorkflowType`
+	query = `GROUP BY StartTime`
 	countRequest.Query = query
 	_, err = s.FrontendClient().CountWorkflowExecutions(testcore.NewContext(), countRequest)
 	s.Error(err)
-	s.Contains(strings.ToLower(err.Error()), "'group by' clause supports only a single field")
+	s.Contains(strings.ToLower(err.Error()), "'group by' clause is only supported for")
 }
 
 func (s *AdvancedVisibilitySuite) createStartWorkflowExecutionRequest(id, wt, tl string) *workflowservice.StartWorkflowExecutionRequest {
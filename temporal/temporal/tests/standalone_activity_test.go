@@ -3342,7 +3342,7 @@ func (s *standaloneActivityTestSuite) TestCountActivityExecutions() {
 	t.Run("GroupByUnsupportedField", func(t *testing.T) {
 		_, err := s.FrontendClient().CountActivityExecutions(ctx, &workflowservice.CountActivityExecutionsRequest{
 			Namespace: s.Namespace().String(),
-			Query:     "GROUP BY ActivityType",
+			Query:     "GROUP BY StartTime",
 		})
 		s.ErrorAs(err, new(*serviceerror.InvalidArgument))
 		s.Contains(err.Error(), "'GROUP BY' clause is only supported for ExecutionStatus")
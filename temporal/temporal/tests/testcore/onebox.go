@@ -40,6 +40,7 @@ import (
 	"go.temporal.io/server/common/namespace/nsreplication"
 	"go.temporal.io/server/common/persistence"
 	persistenceClient "go.temporal.io/server/common/persistence/client"
+	"go.temporal.io/server/common/persistence/faultinjection"
 	"go.temporal.io/server/common/persistence/visibility"
 	esclient "go.temporal.io/server/common/persistence/visibility/store/elasticsearch/client"
 	"go.temporal.io/server/common/primitives"
@@ -114,6 +115,8 @@ type (
 		callbackLock              sync.RWMutex // Must be used for above callbacks
 		serviceFxOptions          map[primitives.ServiceName][]fx.Option
 		taskCategoryRegistry      tasks.TaskCategoryRegistry
+		faultInjectionRules       *faultinjection.Rules
+		faultInjectionDCCol       *dynamicconfig.Collection
 		chasmRegistry             *chasm.Registry
 		grpcClientInterceptor     *grpcinject.Interceptor
 		replicationStreamRecorder *ReplicationStreamRecorder
@@ -245,6 +248,12 @@ func (c *TemporalImpl) Start() error {
 	if err := c.createSystemNamespace(); err != nil {
 		return err
 	}
+	if fiConfig := c.persistenceConfig.DataStores[c.persistenceConfig.DefaultStore].FaultInjection; fiConfig != nil {
+		// the rules are shared by all services, like they are in a single binary server
+		c.faultInjectionDCCol = dynamicconfig.NewCollection(c.dcClient, c.logger)
+		c.faultInjectionDCCol.Start()
+		c.faultInjectionRules = faultinjection.NewRules(fiConfig, c.faultInjectionDCCol, c.logger)
+	}
 	c.startMatching()
 	c.startHistory()
 	c.startFrontend()
@@ -262,6 +271,10 @@ func (c *TemporalImpl) Stop() error {
 	for _, app := range c.fxApps {
 		errs = append(errs, app.Stop(ctx))
 	}
+	if c.faultInjectionRules != nil {
+		c.faultInjectionRules.Stop()
+		c.faultInjectionDCCol.Stop()
+	}
 
 	return multierr.Combine(errs...)
 }
@@ -410,6 +423,7 @@ func (c *TemporalImpl) startFrontend() {
 			fx.Provide(func() esclient.Client { return c.esClient }),
 			fx.Provide(c.GetTLSConfigProvider),
 			fx.Provide(c.GetTaskCategoryRegistry),
+			fx.Provide(c.GetFaultInjectionRules),
 			temporal.TraceExportModule,
 			temporal.ServiceTracingModule,
 			frontend.Module,
@@ -505,6 +519,7 @@ func (c *TemporalImpl) startHistory() {
 			fx.Provide(func() esclient.Client { return c.esClient }),
 			fx.Provide(c.GetTLSConfigProvider),
 			fx.Provide(c.GetTaskCategoryRegistry),
+			fx.Provide(c.GetFaultInjectionRules),
 			temporal.TraceExportModule,
 			temporal.ServiceTracingModule,
 			history.QueueModule,
@@ -567,6 +582,7 @@ func (c *TemporalImpl) startMatching() {
 			fx.Provide(c.GetTLSConfigProvider),
 			fx.Provide(resource.DefaultSnTaggedLoggerProvider),
 			fx.Provide(c.GetTaskCategoryRegistry),
+			fx.Provide(c.GetFaultInjectionRules),
 			temporal.TraceExportModule,
 			temporal.ServiceTracingModule,
 			matching.Module,
@@ -634,6 +650,7 @@ func (c *TemporalImpl) startWorker() {
 			fx.Provide(func() esclient.Client { return c.esClient }),
 			fx.Provide(c.GetTLSConfigProvider),
 			fx.Provide(c.GetTaskCategoryRegistry),
+			fx.Provide(c.GetFaultInjectionRules),
 			temporal.TraceExportModule,
 			temporal.ServiceTracingModule,
 			worker.Module,
@@ -699,6 +716,12 @@ func (c *TemporalImpl) GetTaskCategoryRegistry() tasks.TaskCategoryRegistry {
 	return c.taskCategoryRegistry
 }
 
+// GetFaultInjectionRules returns the persistence fault injection rules shared by all services, or nil if fault
+// injection is not enabled.
+func (c *TemporalImpl) GetFaultInjectionRules() *faultinjection.Rules {
+	return c.faultInjectionRules
+}
+
 func (c *TemporalImpl) GetCHASMRegistry() *chasm.Registry {
 	return c.chasmRegistry
 }
@@ -3,6 +3,7 @@ package cassandra
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/gocql/gocql"
@@ -53,6 +54,10 @@ const (
 	readSchemaVersionCQL        = `SELECT curr_version from schema_version where keyspace_name=?`
 	listTablesCQL               = `SELECT table_name from system_schema.tables where keyspace_name=?`
 	listTypesCQL                = `SELECT type_name from system_schema.types where keyspace_name=?`
+	keyspaceExistsCQL           = `SELECT keyspace_name from system_schema.keyspaces where keyspace_name=?`
+	describeColumnsCQL          = `SELECT table_name, column_name, kind, position, clustering_order, type from system_schema.columns where keyspace_name=?`
+	describeIndexesCQL          = `SELECT table_name, index_name, kind, options from system_schema.indexes where keyspace_name=?`
+	describeTypesCQL            = `SELECT type_name, field_names, field_types from system_schema.types where keyspace_name=?`
 	writeSchemaVersionCQL       = `INSERT into schema_version(keyspace_name, creation_time, curr_version, min_compatible_version) VALUES (?,?,?,?)`
 	writeSchemaUpdateHistoryCQL = `INSERT into schema_update_history(year, month, update_time, old_version, new_version, manifest_md5, description) VALUES(?,?,?,?,?,?,?)`
 
@@ -78,7 +83,7 @@ const (
 		`WITH replication = { 'class' : 'NetworkTopologyStrategy', '%v' : %v};`
 )
 
-var _ schema.DB = (*cqlClient)(nil)
+var _ schema.DescribableDB = (*cqlClient)(nil)
 
 // newCQLClient returns a new instance of CQLClient
 func newCQLClient(cfg *CQLClientConfig, logger log.Logger) (*cqlClient, error) {
@@ -156,6 +161,17 @@ func (client *cqlClient) dropKeyspace(name string) error {
 	return client.Exec(fmt.Sprintf("DROP KEYSPACE IF EXISTS %v", name))
 }
 
+// keyspaceExists returns true if the Keyspace exists
+func (client *cqlClient) keyspaceExists(name string) (bool, error) {
+	iter := client.session.Query(keyspaceExistsCQL, name).Iter()
+	var keyspace string
+	exists := iter.Scan(&keyspace)
+	if err := iter.Close(); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
 func (client *cqlClient) DropAllTables() error {
 	return client.dropAllTablesTypes()
 }
@@ -229,6 +245,64 @@ func (client *cqlClient) ListTables() ([]string, error) {
 	return names, nil
 }
 
+// DescribeSchema returns the schema of the Keyspace
+func (client *cqlClient) DescribeSchema() (*schema.Description, error) {
+	description := &schema.Description{
+		Tables: make(map[string]*schema.TableDescription),
+		Types:  make(map[string]string),
+	}
+	table := func(name string) *schema.TableDescription {
+		t, ok := description.Tables[name]
+		if !ok {
+			t = &schema.TableDescription{Columns: make(map[string]string), Indexes: make(map[string]string)}
+			description.Tables[name] = t
+		}
+		return t
+	}
+
+	iter := client.session.Query(describeColumnsCQL, client.keyspace).Iter()
+	var tableName, columnName, kind, clusteringOrder, columnType string
+	var position int
+	for iter.Scan(&tableName, &columnName, &kind, &position, &clusteringOrder, &columnType) {
+		definition := columnType
+		if kind != "regular" {
+			definition += fmt.Sprintf(" %s(%d)", kind, position)
+		}
+		if clusteringOrder != "none" {
+			definition += " " + clusteringOrder
+		}
+		table(tableName).Columns[columnName] = definition
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+
+	iter = client.session.Query(describeIndexesCQL, client.keyspace).Iter()
+	var indexName string
+	var options map[string]string
+	for iter.Scan(&tableName, &indexName, &kind, &options) {
+		table(tableName).Indexes[indexName] = fmt.Sprintf("%s (%s)", kind, options["target"])
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+
+	iter = client.session.Query(describeTypesCQL, client.keyspace).Iter()
+	var typeName string
+	var fieldNames, fieldTypes []string
+	for iter.Scan(&typeName, &fieldNames, &fieldTypes) {
+		fields := make([]string, len(fieldNames))
+		for i, name := range fieldNames {
+			fields[i] = name + " " + fieldTypes[i]
+		}
+		description.Types[typeName] = "(" + strings.Join(fields, ", ") + ")"
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	return description, nil
+}
+
 // listTypes lists the User defined types in a Keyspace
 func (client *cqlClient) listTypes() ([]string, error) {
 	qry := client.session.Query(listTypesCQL, client.keyspace)
@@ -13,7 +13,14 @@ import (
 	"go.temporal.io/server/tools/common/schema"
 )
 
-const defaultNumReplicas = 1
+const (
+	defaultNumReplicas = 1
+
+	// cliOptScratchKeyspace is the cli option for the scratch keyspace of verify-schema
+	cliOptScratchKeyspace = "scratch-keyspace"
+
+	defaultScratchKeyspaceSuffix = "_verify_schema"
+)
 
 // SetupSchemaConfig contains the configuration params needed to setup schema tables
 type SetupSchemaConfig struct {
@@ -64,6 +71,90 @@ func updateSchema(cli *cli.Context, logger log.Logger) error {
 	return nil
 }
 
+// downgradeSchema downgrades the schema of the keyspace by executing the rollback files of the versioned schema
+func downgradeSchema(cli *cli.Context, logger log.Logger) error {
+	config, err := newCQLClientConfig(cli)
+	if err != nil {
+		logger.Error("Unable to read config.", tag.Error(schema.NewConfigError(err.Error())))
+		return err
+	}
+	client, err := newCQLClient(config, logger)
+	if err != nil {
+		logger.Error("Unable to establish CQL session.", tag.Error(err))
+		return err
+	}
+	defer client.Close()
+	if err := schema.Downgrade(cli, client, logger); err != nil {
+		logger.Error("Unable to downgrade CQL schema.", tag.Error(err))
+		return err
+	}
+	return nil
+}
+
+// verifySchema diffs the live schema of the keyspace against the expected schema of its recorded version, which is
+// set up in a scratch keyspace created and dropped by this run
+func verifySchema(cli *cli.Context, logger log.Logger) error {
+	config, err := newCQLClientConfig(cli)
+	if err != nil {
+		logger.Error("Unable to read config.", tag.Error(schema.NewConfigError(err.Error())))
+		return err
+	}
+	scratchConfig := *config
+	scratchConfig.Keyspace = cli.String(cliOptScratchKeyspace)
+	if scratchConfig.Keyspace == "" {
+		scratchConfig.Keyspace = config.Keyspace + defaultScratchKeyspaceSuffix
+	}
+	if scratchConfig.Keyspace == config.Keyspace {
+		err := schema.NewConfigError(flag(cliOptScratchKeyspace) + " must be different from the verified keyspace")
+		logger.Error("Unable to read config.", tag.Error(err))
+		return err
+	}
+
+	client, err := newCQLClient(config, logger)
+	if err != nil {
+		logger.Error("Unable to establish CQL session.", tag.Error(err))
+		return err
+	}
+	defer client.Close()
+
+	// the scratch keyspace is dropped afterwards, so it must be created by this run
+	existsConfig := scratchConfig
+	exists, err := doKeyspaceExists(&existsConfig, scratchConfig.Keyspace, logger)
+	if err != nil {
+		logger.Error("Unable to check for scratch keyspace.", tag.Error(err))
+		return err
+	}
+	if exists {
+		err := schema.NewConfigError(fmt.Sprintf("scratch keyspace %q already exists, drop it or choose another one with %s",
+			scratchConfig.Keyspace, flag(cliOptScratchKeyspace)))
+		logger.Error("Unable to create scratch keyspace.", tag.Error(err))
+		return err
+	}
+	createConfig := scratchConfig
+	if err := doCreateKeyspace(&createConfig, scratchConfig.Keyspace, logger); err != nil {
+		logger.Error("Unable to create scratch keyspace.", tag.Error(err))
+		return err
+	}
+	defer func() {
+		dropConfig := scratchConfig
+		if err := doDropKeyspace(&dropConfig, scratchConfig.Keyspace, logger); err != nil {
+			logger.Warn("Unable to drop scratch keyspace.", tag.Error(err))
+		}
+	}()
+	scratchClient, err := newCQLClient(&scratchConfig, logger)
+	if err != nil {
+		logger.Error("Unable to establish CQL session to scratch keyspace.", tag.Error(err))
+		return err
+	}
+	defer scratchClient.Close()
+
+	if err := schema.Verify(cli, client, scratchClient, logger); err != nil {
+		logger.Error("Unable to verify CQL schema.", tag.Error(err))
+		return err
+	}
+	return nil
+}
+
 func createKeyspace(cli *cli.Context, logger log.Logger) error {
 	config, err := newCQLClientConfig(cli)
 	if err != nil {
@@ -133,6 +224,16 @@ func doCreateKeyspace(cfg *CQLClientConfig, name string, logger log.Logger) erro
 	return client.createKeyspace(name)
 }
 
+func doKeyspaceExists(cfg *CQLClientConfig, name string, logger log.Logger) (bool, error) {
+	cfg.Keyspace = systemKeyspace
+	client, err := newCQLClient(cfg, logger)
+	if err != nil {
+		return false, err
+	}
+	defer client.Close()
+	return client.keyspaceExists(name)
+}
+
 func doDropKeyspace(cfg *CQLClientConfig, name string, logger log.Logger) error {
 	cfg.Keyspace = systemKeyspace
 	client, err := newCQLClient(cfg, logger)
@@ -190,11 +190,79 @@ func buildCLIOptions() *cli.App {
 					Usage: fmt.Sprintf("name of embedded versioned schema, one of: %v",
 						dbschemas.PathsByDB("cassandra")),
 				},
+				cli.BoolFlag{
+					Name:  schema.CLIOptDryRun,
+					Usage: "print the statements of the schema update in order without executing them",
+				},
 			},
 			Action: func(c *cli.Context) {
 				cliHandler(c, updateSchema, logger)
 			},
 		},
+		{
+			Name:    "downgrade-schema",
+			Aliases: []string{"downgrade"},
+			Usage:   "downgrade cassandra schema to a specific version by executing the rollback files of the newer versions",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  schema.CLIFlagTargetVersion,
+					Usage: "target version for the schema downgrade",
+				},
+				cli.StringFlag{
+					Name:  schema.CLIFlagSchemaDir,
+					Usage: "path to directory containing versioned schema",
+				},
+				cli.StringFlag{
+					Name: schema.CLIFlagSchemaName,
+					Usage: fmt.Sprintf("name of embedded versioned schema, one of: %v",
+						dbschemas.PathsByDB("cassandra")),
+				},
+				cli.BoolFlag{
+					Name:  schema.CLIOptDryRun,
+					Usage: "print the statements of the schema downgrade in order without executing them",
+				},
+			},
+			Action: func(c *cli.Context) {
+				cliHandler(c, downgradeSchema, logger)
+			},
+		},
+		{
+			Name:  "verify-schema",
+			Usage: "diff the live cassandra schema against the expected schema of its recorded version",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  schema.CLIFlagSchemaDir,
+					Usage: "path to directory containing versioned schema",
+				},
+				cli.StringFlag{
+					Name: schema.CLIFlagSchemaName,
+					Usage: fmt.Sprintf("name of embedded versioned schema, one of: %v",
+						dbschemas.PathsByDB("cassandra")),
+				},
+				cli.StringFlag{
+					Name:  cliOptScratchKeyspace,
+					Usage: "scratch keyspace in which the expected schema is set up, which must not exist, defaults to the keyspace name with a " + defaultScratchKeyspaceSuffix + " suffix",
+				},
+				cli.IntFlag{
+					Name:  schema.CLIFlagReplicationFactor,
+					Value: 1,
+					Usage: "replication factor for the scratch keyspace",
+				},
+				cli.StringFlag{
+					Name:  schema.CLIFlagDatacenter,
+					Value: "",
+					Usage: "enable NetworkTopologyStrategy for the scratch keyspace by providing datacenter name",
+				},
+				cli.StringFlag{
+					Name:  schema.CLIOptOutputFormat,
+					Value: schema.OutputFormatText,
+					Usage: fmt.Sprintf("output format, one of: %v", []string{schema.OutputFormatText, schema.OutputFormatJSON}),
+				},
+			},
+			Action: func(c *cli.Context) {
+				cliHandler(c, verifySchema, logger)
+			},
+		},
 		{
 			Name:    "create-keyspace",
 			Aliases: []string{"create", "create-Keyspace"},
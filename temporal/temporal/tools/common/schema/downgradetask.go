@@ -0,0 +1,183 @@
+package schema
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"slices"
+
+	"github.com/blang/semver/v4"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/log/tag"
+)
+
+type (
+	// DowngradeTask represents a task that downgrades the schema by executing
+	// the rollback files of the schema versions in reverse order
+	DowngradeTask struct {
+		db     DB
+		config *DowngradeConfig
+		out    io.Writer
+		logger log.Logger
+	}
+
+	// rollbackSet represents all the changes which
+	// revert the schema updates of a single schema version
+	rollbackSet struct {
+		fromVersion          string
+		toVersion            string
+		minCompatibleVersion string
+		manifest             *manifest
+		cqlStmts             []string
+	}
+)
+
+// NewDowngradeSchemaTask returns a new instance of DowngradeTask
+func NewDowngradeSchemaTask(db DB, config *DowngradeConfig, logger log.Logger) *DowngradeTask {
+	return &DowngradeTask{
+		db:     db,
+		config: config,
+		out:    os.Stdout,
+		logger: logger,
+	}
+}
+
+// Run executes the task
+func (task *DowngradeTask) Run() error {
+	config := task.config
+
+	task.logger.Info("DowngradeSchemaTask started", tag.Any("config", config))
+
+	currVer, err := task.db.ReadSchemaVersion()
+	if err != nil {
+		return fmt.Errorf("error reading current schema version:%v", err.Error())
+	}
+	currVer, err = normalizeVersionString(currVer)
+	if err != nil {
+		return fmt.Errorf("invalid current schema version:%v", err.Error())
+	}
+
+	rollbacks, err := task.buildRollbackSet(currVer)
+	if err != nil {
+		return err
+	}
+
+	if config.IsDryRun {
+		return writeDowngradePlan(task.out, rollbacks)
+	}
+
+	for _, rs := range rollbacks {
+		if err := execStmts(task.db, rs.fromVersion, rs.cqlStmts, task.logger); err != nil {
+			return err
+		}
+		if err := task.db.UpdateSchemaVersion(rs.toVersion, rs.minCompatibleVersion); err != nil {
+			return fmt.Errorf("failed to update schema_version table, err=%v", err.Error())
+		}
+		if err := task.db.WriteSchemaUpdateLog(rs.fromVersion, rs.toVersion, rs.manifest.md5, "rollback: "+rs.manifest.Description); err != nil {
+			return fmt.Errorf("failed to add entry to schema_update_history, err=%v", err.Error())
+		}
+		task.logger.Debug(fmt.Sprintf("Schema downgraded from %v to %v", rs.fromVersion, rs.toVersion))
+	}
+
+	task.logger.Info("DowngradeSchemaTask done")
+
+	return nil
+}
+
+// buildRollbackSet returns the rollbacks from the current version down to the target version, in the order in
+// which they are executed. It fails if any of the versions has no rollback files, so that the schema is either
+// downgraded to the target version or left untouched.
+func (task *DowngradeTask) buildRollbackSet(currVer string) ([]rollbackSet, error) {
+	config := task.config
+	fsys, dir := versionedSchemaDir(config.SchemaDir, config.SchemaName)
+
+	targetVer, err := semver.ParseTolerant(config.TargetVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid target version:%v", err.Error())
+	}
+	curr, err := semver.ParseTolerant(currVer)
+	if err != nil {
+		return nil, fmt.Errorf("invalid current schema version:%v", err.Error())
+	}
+	if targetVer.Compare(curr) >= 0 {
+		return nil, fmt.Errorf("target version '%s' must be less than current version '%s'", config.TargetVersion, currVer)
+	}
+
+	// the min compatible version of the target version is restored along with the version
+	targetMinCompatibleVersion := config.TargetVersion
+	if targetVer.Compare(semver.Version{}) > 0 {
+		targetDirs, err := readSchemaDir(fsys, dir, "0.0", config.TargetVersion, task.logger)
+		if err != nil {
+			return nil, fmt.Errorf("error listing schema dir:%v", err.Error())
+		}
+		m, err := readManifest(fsys, path.Join(dir, targetDirs[len(targetDirs)-1]))
+		if err != nil {
+			return nil, fmt.Errorf("error processing manifest for version %v:%v", config.TargetVersion, err.Error())
+		}
+		targetMinCompatibleVersion = m.MinCompatibleVersion
+	}
+
+	verDirs, err := readSchemaDir(fsys, dir, config.TargetVersion, currVer, task.logger)
+	if err != nil {
+		return nil, fmt.Errorf("error listing schema dir:%v", err.Error())
+	}
+
+	task.logger.Debug(fmt.Sprintf("Schema Dirs: %s", verDirs))
+
+	var result []rollbackSet
+	toVersion, minCompatibleVersion := config.TargetVersion, targetMinCompatibleVersion
+	for _, vd := range verDirs {
+		dirPath := path.Join(dir, vd)
+
+		m, err := readManifest(fsys, dirPath)
+		if err != nil {
+			return nil, fmt.Errorf("error processing manifest for version %v:%v", vd, err.Error())
+		}
+		if m.CurrVersion != dirToVersion(vd) {
+			return nil, fmt.Errorf(
+				"manifest version doesn't match with dirname, dir=%v,manifest.version=%v",
+				vd, m.CurrVersion,
+			)
+		}
+		if len(m.SchemaRollbackCqlFiles) == 0 && len(m.SchemaUpdateCqlFiles) > 0 {
+			return nil, fmt.Errorf("version %v has no SchemaRollbackCqlFiles, unable to downgrade below it", m.CurrVersion)
+		}
+
+		stmts, err := parseSQLStmts(fsys, dirPath, m.SchemaRollbackCqlFiles, task.logger)
+		if err != nil {
+			return nil, err
+		}
+		if err := validateCQLStmts(stmts); err != nil {
+			return nil, fmt.Errorf("error processing version %v:%v", vd, err.Error())
+		}
+
+		result = append(result, rollbackSet{
+			fromVersion:          m.CurrVersion,
+			toVersion:            toVersion,
+			minCompatibleVersion: minCompatibleVersion,
+			manifest:             m,
+			cqlStmts:             stmts,
+		})
+		toVersion, minCompatibleVersion = m.CurrVersion, m.MinCompatibleVersion
+	}
+
+	slices.Reverse(result)
+	return result, nil
+}
+
+// writeDowngradePlan writes the statements which the rollbacks execute, in order
+func writeDowngradePlan(out io.Writer, rollbacks []rollbackSet) error {
+	for _, rs := range rollbacks {
+		if _, err := fmt.Fprintf(out, "-- downgrade from version %v to version %v: rollback of %v\n", rs.fromVersion, rs.toVersion, rs.manifest.Description); err != nil {
+			return err
+		}
+		if err := writeStmts(out, rs.cqlStmts); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(out, "-- set schema version to %v, min compatible version %v\n", rs.toVersion, rs.minCompatibleVersion); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package schema
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/server/common/log"
+)
+
+type (
+	// versionDB is a DB which records the executed statements and the schema version updates
+	versionDB struct {
+		mockSQLDB
+		version              string
+		minCompatibleVersion string
+		stmts                []string
+		updateLog            []string
+	}
+)
+
+func (db *versionDB) Exec(stmt string, _ ...any) error {
+	db.stmts = append(db.stmts, stmt)
+	return nil
+}
+
+func (db *versionDB) ReadSchemaVersion() (string, error) {
+	return db.version, nil
+}
+
+func (db *versionDB) UpdateSchemaVersion(newVersion string, minCompatibleVersion string) error {
+	db.version, db.minCompatibleVersion = newVersion, minCompatibleVersion
+	return nil
+}
+
+func (db *versionDB) WriteSchemaUpdateLog(oldVersion string, newVersion string, _ string, desc string) error {
+	db.updateLog = append(db.updateLog, fmt.Sprintf("%v -> %v: %v", oldVersion, newVersion, desc))
+	return nil
+}
+
+// writeTestVersions writes the versioned schema of versions 0.1 to 0.3, whose update creates the table t<version>,
+// and whose rollback drops it. Version 0.1 has no rollback file.
+func writeTestVersions(t *testing.T) string {
+	dir := t.TempDir()
+	for i, version := range []string{"0.1", "0.2", "0.3"} {
+		versionDir := filepath.Join(dir, "v"+version)
+		table := fmt.Sprintf("t%d", i+1)
+		rollback := `, "SchemaRollbackCqlFiles": ["rollback.sql"]`
+		if i == 0 {
+			rollback = ""
+		}
+		require.NoError(t, os.Mkdir(versionDir, 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(versionDir, manifestFileName), []byte(fmt.Sprintf(
+			`{"CurrVersion": "%v", "MinCompatibleVersion": "0.%d", "Description": "create %v", "SchemaUpdateCqlFiles": ["update.sql"]%v}`,
+			version, i, table, rollback,
+		)), 0o644))
+		require.NoError(t, os.WriteFile(filepath.Join(versionDir, "update.sql"), []byte("CREATE TABLE "+table+" (id INTEGER);"), 0o644))
+		require.NoError(t, os.WriteFile(filepath.Join(versionDir, "rollback.sql"), []byte("DROP TABLE "+table+";"), 0o644))
+	}
+	return dir
+}
+
+func TestDowngradeTask(t *testing.T) {
+	dir := writeTestVersions(t)
+	db := &versionDB{version: "0.3", minCompatibleVersion: "0.2"}
+
+	var out bytes.Buffer
+	task := NewDowngradeSchemaTask(db, &DowngradeConfig{SchemaDir: dir, TargetVersion: "0.1", IsDryRun: true}, log.NewNoopLogger())
+	task.out = &out
+	require.NoError(t, task.Run())
+	require.Equal(t, `-- downgrade from version 0.3 to version 0.2: rollback of create t3
+DROP TABLE t3;
+-- set schema version to 0.2, min compatible version 0.1
+-- downgrade from version 0.2 to version 0.1: rollback of create t2
+DROP TABLE t2;
+-- set schema version to 0.1, min compatible version 0.0
+`, out.String())
+	require.Empty(t, db.stmts)
+	require.Equal(t, "0.3", db.version)
+
+	task = NewDowngradeSchemaTask(db, &DowngradeConfig{SchemaDir: dir, TargetVersion: "0.1"}, log.NewNoopLogger())
+	require.NoError(t, task.Run())
+	require.Equal(t, []string{"DROP TABLE t3;", "DROP TABLE t2;"}, db.stmts)
+	require.Equal(t, "0.1", db.version)
+	require.Equal(t, "0.0", db.minCompatibleVersion)
+	require.Equal(t, []string{"0.3 -> 0.2: rollback: create t3", "0.2 -> 0.1: rollback: create t2"}, db.updateLog)
+}
+
+func TestDowngradeTask_MissingRollback(t *testing.T) {
+	dir := writeTestVersions(t)
+	db := &versionDB{version: "0.3", minCompatibleVersion: "0.2"}
+
+	// the schema is left untouched if any of the versions can't be rolled back
+	task := NewDowngradeSchemaTask(db, &DowngradeConfig{SchemaDir: dir, TargetVersion: "0.0"}, log.NewNoopLogger())
+	require.ErrorContains(t, task.Run(), "version 0.1 has no SchemaRollbackCqlFiles")
+	require.Empty(t, db.stmts)
+	require.Equal(t, "0.3", db.version)
+
+	task = NewDowngradeSchemaTask(db, &DowngradeConfig{SchemaDir: dir, TargetVersion: "0.3"}, log.NewNoopLogger())
+	require.ErrorContains(t, task.Run(), "must be less than current version")
+}
+
+func TestUpdateTask_DryRun(t *testing.T) {
+	dir := writeTestVersions(t)
+	db := &versionDB{version: "0.1", minCompatibleVersion: "0.0"}
+
+	var out bytes.Buffer
+	task := NewUpdateSchemaTask(db, &UpdateConfig{SchemaDir: dir, IsDryRun: true}, log.NewNoopLogger())
+	task.out = &out
+	require.NoError(t, task.Run())
+	require.Equal(t, `-- update from version 0.1 to version 0.2: create t2
+CREATE TABLE t2 (id INTEGER);
+-- set schema version to 0.2, min compatible version 0.1
+-- update from version 0.2 to version 0.3: create t3
+CREATE TABLE t3 (id INTEGER);
+-- set schema version to 0.3, min compatible version 0.2
+`, out.String())
+	require.Empty(t, db.stmts)
+	require.Equal(t, "0.1", db.version)
+
+	out.Reset()
+	db.version = "0.3"
+	require.NoError(t, task.Run())
+	require.Equal(t, "-- schema is up to date at version 0.3\n", out.String())
+}
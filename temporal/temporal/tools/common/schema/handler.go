@@ -2,6 +2,7 @@ package schema
 
 import (
 	"fmt"
+	"os"
 	"slices"
 	"strings"
 
@@ -28,11 +29,31 @@ func Update(cli *cli.Context, db DB, logger log.Logger) error {
 	return NewUpdateSchemaTask(db, cfg, logger).Run()
 }
 
+// Downgrade downgrades the schema for the specified database by executing the rollback files of the versioned schema
+func Downgrade(cli *cli.Context, db DB, logger log.Logger) error {
+	cfg, err := newDowngradeConfig(cli, db)
+	if err != nil {
+		return err
+	}
+	return NewDowngradeSchemaTask(db, cfg, logger).Run()
+}
+
+// Verify verifies the live schema of the specified database against its expected schema, which is set up in the
+// scratch database
+func Verify(cli *cli.Context, db DescribableDB, scratch DescribableDB, logger log.Logger) error {
+	cfg, err := newVerifyConfig(cli, db)
+	if err != nil {
+		return err
+	}
+	return NewVerifySchemaTask(db, scratch, cfg, os.Stdout, logger).Run()
+}
+
 func newUpdateConfig(cli *cli.Context, db DB) (*UpdateConfig, error) {
 	config := new(UpdateConfig)
 	config.SchemaDir = cli.String(CLIOptSchemaDir)
 	config.SchemaName = cli.String(CLIOptSchemaName)
 	config.TargetVersion = cli.String(CLIOptTargetVersion)
+	config.IsDryRun = cli.Bool(CLIOptDryRun)
 
 	if err := validateUpdateConfig(config, db); err != nil {
 		return nil, err
@@ -40,6 +61,31 @@ func newUpdateConfig(cli *cli.Context, db DB) (*UpdateConfig, error) {
 	return config, nil
 }
 
+func newDowngradeConfig(cli *cli.Context, db DB) (*DowngradeConfig, error) {
+	config := new(DowngradeConfig)
+	config.SchemaDir = cli.String(CLIOptSchemaDir)
+	config.SchemaName = cli.String(CLIOptSchemaName)
+	config.TargetVersion = cli.String(CLIOptTargetVersion)
+	config.IsDryRun = cli.Bool(CLIOptDryRun)
+
+	if err := validateDowngradeConfig(config, db); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func newVerifyConfig(cli *cli.Context, db DB) (*VerifyConfig, error) {
+	config := new(VerifyConfig)
+	config.SchemaDir = cli.String(CLIOptSchemaDir)
+	config.SchemaName = cli.String(CLIOptSchemaName)
+	config.OutputFormat = cli.String(CLIOptOutputFormat)
+
+	if err := validateVerifyConfig(config, db); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
 func newSetupConfig(cli *cli.Context, db DB) (*SetupConfig, error) {
 	config := new(SetupConfig)
 	config.SchemaFilePath = cli.String(CLIOptSchemaFile)
@@ -108,6 +154,22 @@ func validateUpdateConfig(config *UpdateConfig, db DB) error {
 	return nil
 }
 
+func validateDowngradeConfig(config *DowngradeConfig, db DB) error {
+	if len(config.TargetVersion) == 0 {
+		return NewConfigError("missing argument; " + flag(CLIOptTargetVersion) + " must be specified")
+	}
+	updateConfig := &UpdateConfig{
+		SchemaDir:     config.SchemaDir,
+		SchemaName:    config.SchemaName,
+		TargetVersion: config.TargetVersion,
+	}
+	if err := validateUpdateConfig(updateConfig, db); err != nil {
+		return err
+	}
+	config.TargetVersion = updateConfig.TargetVersion
+	return nil
+}
+
 func flag(opt string) string {
 	return "(-" + opt + ")"
 }
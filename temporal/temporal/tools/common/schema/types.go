@@ -20,6 +20,14 @@ type (
 		SchemaName    string
 		IsDryRun      bool
 	}
+	// DowngradeConfig holds the config
+	// params for executing a DowngradeTask
+	DowngradeConfig struct {
+		TargetVersion string
+		SchemaDir     string
+		SchemaName    string
+		IsDryRun      bool
+	}
 	// SetupConfig holds the config
 	// params need by the SetupTask
 	SetupConfig struct {
@@ -103,6 +111,10 @@ const (
 	CLIOptQuiet = "quiet"
 	// CLIOptForce is the cli option for force mode
 	CLIOptForce = "force"
+	// CLIOptOutputFormat is the cli option for output format
+	CLIOptOutputFormat = "output"
+	// CLIOptDryRun is the cli option for dry run mode
+	CLIOptDryRun = "dry-run"
 
 	// CLIFlagEndpoint is the cli flag for endpoint
 	CLIFlagEndpoint = CLIOptEndpoint + ", ep"
@@ -30,6 +30,7 @@ type (
 	UpdateTask struct {
 		db     DB
 		config *UpdateConfig
+		out    io.Writer
 		logger log.Logger
 	}
 
@@ -41,6 +42,8 @@ type (
 		MinCompatibleVersion string
 		Description          string
 		SchemaUpdateCqlFiles []string
+		// SchemaRollbackCqlFiles are the optional files which revert the schema updates, used to downgrade the schema.
+		SchemaRollbackCqlFiles []string
 		// If set, the manifest is intentionally opting out of schema updates.
 		AllowNoCqlFiles bool
 		md5             string
@@ -69,6 +72,7 @@ func NewUpdateSchemaTask(db DB, config *UpdateConfig, logger log.Logger) *Update
 	return &UpdateTask{
 		db:     db,
 		config: config,
+		out:    os.Stdout,
 		logger: logger,
 	}
 }
@@ -79,12 +83,6 @@ func (task *UpdateTask) Run() error {
 
 	task.logger.Info("UpdateSchemaTask started", tag.Any("config", config))
 
-	if config.IsDryRun {
-		if err := task.setupDryRunDatabase(); err != nil {
-			return fmt.Errorf("error creating dryrun database:%v", err.Error())
-		}
-	}
-
 	currVer, err := task.db.ReadSchemaVersion()
 	if err != nil {
 		return fmt.Errorf("error reading current schema version:%v", err.Error())
@@ -95,6 +93,10 @@ func (task *UpdateTask) Run() error {
 		return err
 	}
 
+	if config.IsDryRun {
+		return writeUpdatePlan(task.out, currVer, updates)
+	}
+
 	err = task.executeUpdates(currVer, updates)
 	if err != nil {
 		return err
@@ -113,7 +115,7 @@ func (task *UpdateTask) executeUpdates(currVer string, updates []changeSet) erro
 
 	task.logger.Debug(fmt.Sprintf("running %v updates for current version %v", len(updates), currVer))
 	for _, cs := range updates {
-		err := task.execStmts(cs.version, cs.cqlStmts)
+		err := execStmts(task.db, cs.version, cs.cqlStmts, task.logger)
 		if err != nil {
 			return err
 		}
@@ -129,11 +131,11 @@ func (task *UpdateTask) executeUpdates(currVer string, updates []changeSet) erro
 	return nil
 }
 
-func (task *UpdateTask) execStmts(ver string, stmts []string) error {
-	task.logger.Debug(fmt.Sprintf("---- Executing updates for version %v ----", ver))
+func execStmts(db DB, ver string, stmts []string, logger log.Logger) error {
+	logger.Debug(fmt.Sprintf("---- Executing updates for version %v ----", ver))
 	for _, stmt := range stmts {
-		task.logger.Debug(rmspaceRegex.ReplaceAllString(stmt, " "))
-		err := task.db.Exec(stmt)
+		logger.Debug(rmspaceRegex.ReplaceAllString(stmt, " "))
+		err := db.Exec(stmt)
 		if err != nil {
 			// To make schema update idempotent, we need to handle error when retry on previous partially succeeded update attempt.
 			// There are 2 major cases that will be handled:
@@ -142,14 +144,14 @@ func (task *UpdateTask) execStmts(ver string, stmts []string) error {
 			alreadyExists := strings.Contains(err.Error(), "already exist")
 			notFound := strings.Contains(err.Error(), "not found")
 			if alreadyExists || notFound {
-				task.logger.Warn("Duplicate update, most likely due to previous partially succeeded update attempt. Ignoring it and continue.", tag.Error(err))
+				logger.Warn("Duplicate update, most likely due to previous partially succeeded update attempt. Ignoring it and continue.", tag.Error(err))
 				continue
 			}
 
 			return fmt.Errorf("error executing statement: %w", err)
 		}
 	}
-	task.logger.Debug("---- Done ----")
+	logger.Debug("---- Done ----")
 	return nil
 }
 
@@ -169,16 +171,7 @@ func (task *UpdateTask) updateSchemaVersion(oldVer string, cs *changeSet) error
 func (task *UpdateTask) buildChangeSet(currVer string) ([]changeSet, error) {
 
 	config := task.config
-
-	var fsys fs.FS
-	var dir string
-	if len(config.SchemaName) > 0 {
-		fsys = dbschemas.Assets()
-		dir = path.Join(config.SchemaName, "versioned")
-	} else {
-		fsys = os.DirFS(config.SchemaDir)
-		dir = "."
-	}
+	fsys, dir := versionedSchemaDir(config.SchemaDir, config.SchemaName)
 
 	verDirs, err := readSchemaDir(fsys, dir, currVer, config.TargetVersion, task.logger)
 	if err != nil {
@@ -204,10 +197,13 @@ func (task *UpdateTask) buildChangeSet(currVer string) ([]changeSet, error) {
 			)
 		}
 
-		stmts, e := task.parseSQLStmts(fsys, dirPath, m)
+		stmts, e := parseSQLStmts(fsys, dirPath, m.SchemaUpdateCqlFiles, task.logger)
 		if e != nil {
 			return nil, e
 		}
+		if len(stmts) == 0 && !m.AllowNoCqlFiles {
+			return nil, fmt.Errorf("found 0 updates in dir %v", dirPath)
+		}
 
 		e = validateCQLStmts(stmts)
 		if e != nil {
@@ -224,12 +220,12 @@ func (task *UpdateTask) buildChangeSet(currVer string) ([]changeSet, error) {
 	return result, nil
 }
 
-func (task *UpdateTask) parseSQLStmts(fsys fs.FS, dir string, manifest *manifest) ([]string, error) {
+func parseSQLStmts(fsys fs.FS, dir string, files []string, logger log.Logger) ([]string, error) {
 	result := make([]string, 0, 4)
 
-	for _, file := range manifest.SchemaUpdateCqlFiles {
+	for _, file := range files {
 		schemaPath := path.Join(dir, file)
-		task.logger.Info("Processing schema file: " + schemaPath)
+		logger.Info("Processing schema file: " + schemaPath)
 		schemaBuf, err := fs.ReadFile(fsys, schemaPath)
 		if err != nil {
 			return nil, fmt.Errorf("error reading file %s: %w", schemaPath, err)
@@ -241,10 +237,6 @@ func (task *UpdateTask) parseSQLStmts(fsys fs.FS, dir string, manifest *manifest
 		result = append(result, stmts...)
 	}
 
-	if len(result) == 0 && !manifest.AllowNoCqlFiles {
-		return nil, fmt.Errorf("found 0 updates in dir %v", dir)
-	}
-
 	return result, nil
 }
 
@@ -415,15 +407,43 @@ func readSchemaDir(fsys fs.FS, dir string, startVer string, endVer string, logge
 	return sortAndFilterVersions(dirNames, startVer, endVer, logger)
 }
 
-// sets up a temporary dryrun database for
-// executing the cassandra schema update
-func (task *UpdateTask) setupDryRunDatabase() error {
-	setupConfig := &SetupConfig{
-		Overwrite:      true,
-		InitialVersion: "0.0",
+// versionedSchemaDir returns the file system and the directory within it
+// which hold the versioned schema directories
+func versionedSchemaDir(schemaDir string, schemaName string) (fs.FS, string) {
+	if len(schemaName) > 0 {
+		return dbschemas.Assets(), path.Join(schemaName, "versioned")
+	}
+	return os.DirFS(schemaDir), "."
+}
+
+// writeUpdatePlan writes the statements which the updates execute, in order
+func writeUpdatePlan(out io.Writer, currVer string, updates []changeSet) error {
+	if len(updates) == 0 {
+		_, err := fmt.Fprintf(out, "-- schema is up to date at version %v\n", currVer)
+		return err
+	}
+	for _, cs := range updates {
+		if _, err := fmt.Fprintf(out, "-- update from version %v to version %v: %v\n", currVer, cs.version, cs.manifest.Description); err != nil {
+			return err
+		}
+		if err := writeStmts(out, cs.cqlStmts); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(out, "-- set schema version to %v, min compatible version %v\n", cs.version, cs.manifest.MinCompatibleVersion); err != nil {
+			return err
+		}
+		currVer = cs.version
+	}
+	return nil
+}
+
+func writeStmts(out io.Writer, stmts []string) error {
+	for _, stmt := range stmts {
+		if _, err := fmt.Fprintln(out, stmt); err != nil {
+			return err
+		}
 	}
-	setupTask := NewSetupSchemaTask(task.db, setupConfig, task.logger)
-	return setupTask.Run()
+	return nil
 }
 
 func dirToVersion(dir string) string {
@@ -0,0 +1,256 @@
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/log/tag"
+)
+
+type (
+	// VerifyConfig holds the config
+	// params for executing a VerifyTask
+	VerifyConfig struct {
+		SchemaDir    string
+		SchemaName   string
+		OutputFormat string
+	}
+
+	// Description is the schema of a live database
+	Description struct {
+		// Tables are the tables by name
+		Tables map[string]*TableDescription
+		// Types are the definitions of the user defined types by name
+		Types map[string]string
+	}
+
+	// TableDescription is the schema of a table of a live database
+	TableDescription struct {
+		// Columns are the definitions of the columns by name
+		Columns map[string]string
+		// Indexes are the definitions of the indexes by name
+		Indexes map[string]string
+	}
+
+	// DescribableDB is a DB whose live schema can be described, which is required to verify its schema
+	DescribableDB interface {
+		DB
+		// DescribeSchema returns the schema of the live database
+		DescribeSchema() (*Description, error)
+	}
+
+	// Drift is a difference between the schema of a live database and its expected schema
+	Drift struct {
+		Kind     DriftKind `json:"kind"`
+		Object   string    `json:"object"`
+		Name     string    `json:"name"`
+		Expected string    `json:"expected,omitempty"`
+		Actual   string    `json:"actual,omitempty"`
+	}
+
+	// DriftKind is the kind of a Drift
+	DriftKind string
+
+	// VerifyResult is the result of a VerifyTask
+	VerifyResult struct {
+		Version string  `json:"version"`
+		Drifts  []Drift `json:"drifts"`
+	}
+
+	// VerifyTask represents a task that diffs the schema of a live database against the schema which the versioned
+	// schema files define for the recorded schema version of the database. The expected schema is set up in a scratch
+	// database, which must be empty.
+	VerifyTask struct {
+		db      DescribableDB
+		scratch DescribableDB
+		config  *VerifyConfig
+		out     io.Writer
+		logger  log.Logger
+	}
+)
+
+const (
+	// DriftMissing is the kind of an object which is expected but doesn't exist
+	DriftMissing DriftKind = "missing"
+	// DriftExtra is the kind of an object which exists but isn't expected
+	DriftExtra DriftKind = "extra"
+	// DriftMismatched is the kind of an object whose definition is different from the expected one
+	DriftMismatched DriftKind = "mismatched"
+
+	// OutputFormatText is the human readable output format of verify-schema
+	OutputFormatText = "text"
+	// OutputFormatJSON is the JSON output format of verify-schema
+	OutputFormatJSON = "json"
+)
+
+// ErrSchemaDrift is returned by VerifyTask if the schema of the live database is different from the expected schema
+var ErrSchemaDrift = errors.New("schema of the database is different from its expected schema")
+
+// NewVerifySchemaTask returns a new instance of VerifyTask
+func NewVerifySchemaTask(db DescribableDB, scratch DescribableDB, config *VerifyConfig, out io.Writer, logger log.Logger) *VerifyTask {
+	return &VerifyTask{
+		db:      db,
+		scratch: scratch,
+		config:  config,
+		out:     out,
+		logger:  logger,
+	}
+}
+
+// Run executes the task
+func (task *VerifyTask) Run() error {
+	result, err := task.verify()
+	if err != nil {
+		return err
+	}
+	if err := writeVerifyResult(task.out, task.config.OutputFormat, result); err != nil {
+		return err
+	}
+	if len(result.Drifts) > 0 {
+		return ErrSchemaDrift
+	}
+	return nil
+}
+
+func (task *VerifyTask) verify() (*VerifyResult, error) {
+	version, err := task.db.ReadSchemaVersion()
+	if err != nil {
+		return nil, fmt.Errorf("error reading current schema version:%v", err.Error())
+	}
+	version, err = normalizeVersionString(version)
+	if err != nil {
+		return nil, fmt.Errorf("invalid current schema version:%v", err.Error())
+	}
+	task.logger.Info("VerifySchemaTask started", tag.String("version", version), tag.Any("config", task.config))
+
+	// the scratch database is created by the caller, so it is empty and is never overwritten
+	setupConfig := &SetupConfig{
+		InitialVersion: "0.0",
+	}
+	if err := NewSetupSchemaTask(task.scratch, setupConfig, task.logger).Run(); err != nil {
+		return nil, fmt.Errorf("error setting up scratch database:%v", err.Error())
+	}
+	updateConfig := &UpdateConfig{
+		SchemaDir:     task.config.SchemaDir,
+		SchemaName:    task.config.SchemaName,
+		TargetVersion: version,
+	}
+	if err := NewUpdateSchemaTask(task.scratch, updateConfig, task.logger).Run(); err != nil {
+		return nil, fmt.Errorf("error setting up expected schema version %v in scratch database:%v", version, err.Error())
+	}
+
+	expected, err := task.scratch.DescribeSchema()
+	if err != nil {
+		return nil, fmt.Errorf("error describing expected schema:%v", err.Error())
+	}
+	actual, err := task.db.DescribeSchema()
+	if err != nil {
+		return nil, fmt.Errorf("error describing schema:%v", err.Error())
+	}
+
+	task.logger.Info("VerifySchemaTask done")
+	return &VerifyResult{
+		Version: version,
+		Drifts:  DiffSchemas(expected, actual),
+	}, nil
+}
+
+// DiffSchemas returns the drifts of the actual schema from the expected schema, sorted by object name.
+func DiffSchemas(expected *Description, actual *Description) []Drift {
+	drifts := make([]Drift, 0)
+	for _, name := range sortedUnion(expected.Tables, actual.Tables) {
+		expectedTable, expectedOK := expected.Tables[name]
+		actualTable, actualOK := actual.Tables[name]
+		switch {
+		case !actualOK:
+			drifts = append(drifts, Drift{Kind: DriftMissing, Object: "table", Name: name})
+		case !expectedOK:
+			drifts = append(drifts, Drift{Kind: DriftExtra, Object: "table", Name: name})
+		default:
+			drifts = append(drifts, diffDefinitions("column", name+".", expectedTable.Columns, actualTable.Columns)...)
+			drifts = append(drifts, diffDefinitions("index", name+".", expectedTable.Indexes, actualTable.Indexes)...)
+		}
+	}
+	return append(drifts, diffDefinitions("type", "", expected.Types, actual.Types)...)
+}
+
+func diffDefinitions(object string, prefix string, expected map[string]string, actual map[string]string) []Drift {
+	var drifts []Drift
+	for _, name := range sortedUnion(expected, actual) {
+		expectedDef, expectedOK := expected[name]
+		actualDef, actualOK := actual[name]
+		switch {
+		case !actualOK:
+			drifts = append(drifts, Drift{Kind: DriftMissing, Object: object, Name: prefix + name, Expected: expectedDef})
+		case !expectedOK:
+			drifts = append(drifts, Drift{Kind: DriftExtra, Object: object, Name: prefix + name, Actual: actualDef})
+		case expectedDef != actualDef:
+			drifts = append(drifts, Drift{Kind: DriftMismatched, Object: object, Name: prefix + name, Expected: expectedDef, Actual: actualDef})
+		}
+	}
+	return drifts
+}
+
+func sortedUnion[V any](a map[string]V, b map[string]V) []string {
+	names := slices.Collect(maps.Keys(a))
+	for name := range b {
+		if _, ok := a[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+func writeVerifyResult(out io.Writer, format string, result *VerifyResult) error {
+	switch format {
+	case OutputFormatJSON:
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	case OutputFormatText, "":
+		if len(result.Drifts) == 0 {
+			_, err := fmt.Fprintf(out, "Schema matches the expected schema of version %v.\n", result.Version)
+			return err
+		}
+		if _, err := fmt.Fprintf(out, "Schema has %d differences from the expected schema of version %v:\n", len(result.Drifts), result.Version); err != nil {
+			return err
+		}
+		for _, drift := range result.Drifts {
+			line := fmt.Sprintf("  %-10s %-6s %s", drift.Kind, drift.Object, drift.Name)
+			switch drift.Kind {
+			case DriftMissing:
+				if drift.Expected != "" {
+					line += fmt.Sprintf(" (expected: %s)", drift.Expected)
+				}
+			case DriftExtra:
+				if drift.Actual != "" {
+					line += fmt.Sprintf(" (actual: %s)", drift.Actual)
+				}
+			case DriftMismatched:
+				line += fmt.Sprintf(" (expected: %s, actual: %s)", drift.Expected, drift.Actual)
+			}
+			if _, err := fmt.Fprintln(out, line); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return NewConfigError(fmt.Sprintf("unknown output format %q, one of: %v", format, []string{OutputFormatText, OutputFormatJSON}))
+	}
+}
+
+func validateVerifyConfig(config *VerifyConfig, db DB) error {
+	if err := validateUpdateConfig(&UpdateConfig{SchemaDir: config.SchemaDir, SchemaName: config.SchemaName}, db); err != nil {
+		return err
+	}
+	if !slices.Contains([]string{"", OutputFormatText, OutputFormatJSON}, config.OutputFormat) {
+		return NewConfigError(fmt.Sprintf("%s must be one of: %v", flag(CLIOptOutputFormat), []string{OutputFormatText, OutputFormatJSON}))
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffSchemas(t *testing.T) {
+	expected := &Description{
+		Tables: map[string]*TableDescription{
+			"executions": {
+				Columns: map[string]string{"shard_id": "integer NOT NULL", "data": "bytea NOT NULL"},
+				Indexes: map[string]string{"executions_pkey": "UNIQUE (shard_id)"},
+			},
+			"timer_tasks": {Columns: map[string]string{"shard_id": "integer NOT NULL"}},
+		},
+		Types: map[string]string{"serialized_event_batch": "(data blob)"},
+	}
+	actual := &Description{
+		Tables: map[string]*TableDescription{
+			"executions": {
+				Columns: map[string]string{"shard_id": "integer NOT NULL", "data": "bytea", "extra": "text"},
+				Indexes: map[string]string{"executions_by_data": "(data)"},
+			},
+			"tmp": {},
+		},
+	}
+
+	require.Equal(t, []Drift{
+		{Kind: DriftMismatched, Object: "column", Name: "executions.data", Expected: "bytea NOT NULL", Actual: "bytea"},
+		{Kind: DriftExtra, Object: "column", Name: "executions.extra", Actual: "text"},
+		{Kind: DriftExtra, Object: "index", Name: "executions.executions_by_data", Actual: "(data)"},
+		{Kind: DriftMissing, Object: "index", Name: "executions.executions_pkey", Expected: "UNIQUE (shard_id)"},
+		{Kind: DriftMissing, Object: "table", Name: "timer_tasks"},
+		{Kind: DriftExtra, Object: "table", Name: "tmp"},
+		{Kind: DriftMissing, Object: "type", Name: "serialized_event_batch", Expected: "(data blob)"},
+	}, DiffSchemas(expected, actual))
+	require.Empty(t, DiffSchemas(expected, expected))
+}
+
+func TestWriteVerifyResult(t *testing.T) {
+	result := &VerifyResult{
+		Version: "1.2",
+		Drifts: []Drift{
+			{Kind: DriftMissing, Object: "table", Name: "timer_tasks"},
+			{Kind: DriftMismatched, Object: "column", Name: "executions.data", Expected: "bytea NOT NULL", Actual: "bytea"},
+		},
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, writeVerifyResult(&out, OutputFormatText, result))
+	require.Equal(t, `Schema has 2 differences from the expected schema of version 1.2:
+  missing    table  timer_tasks
+  mismatched column executions.data (expected: bytea NOT NULL, actual: bytea)
+`, out.String())
+
+	out.Reset()
+	require.NoError(t, writeVerifyResult(&out, OutputFormatJSON, result))
+	var decoded VerifyResult
+	require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	require.Equal(t, *result, decoded)
+
+	out.Reset()
+	require.NoError(t, writeVerifyResult(&out, OutputFormatText, &VerifyResult{Version: "1.2"}))
+	require.Equal(t, "Schema matches the expected schema of version 1.2.\n", out.String())
+}
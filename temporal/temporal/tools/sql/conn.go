@@ -1,6 +1,8 @@
 package sql
 
 import (
+	"strings"
+
 	"go.temporal.io/server/common/config"
 	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/metrics"
@@ -20,7 +22,7 @@ type (
 
 const dbType = "sql"
 
-var _ schema.DB = (*Connection)(nil)
+var _ schema.DescribableDB = (*Connection)(nil)
 
 // NewConnection creates a new connection to database
 func NewConnection(cfg *config.SQL, logger log.Logger) (*Connection, error) {
@@ -89,11 +91,59 @@ func (c *Connection) CreateDatabase(name string) error {
 	return c.adminDb.CreateDatabase(name)
 }
 
+// DatabaseExists returns true if the database exists
+func (c *Connection) DatabaseExists(name string) (bool, error) {
+	return c.adminDb.DatabaseExists(name)
+}
+
 // DropDatabase drops a database
 func (c *Connection) DropDatabase(name string) error {
 	return c.adminDb.DropDatabase(name)
 }
 
+// DescribeSchema returns the schema of the live database
+func (c *Connection) DescribeSchema() (*schema.Description, error) {
+	columns, indexColumns, err := c.adminDb.DescribeSchema(c.dbName)
+	if err != nil {
+		return nil, err
+	}
+	description := &schema.Description{Tables: make(map[string]*schema.TableDescription)}
+	table := func(name string) *schema.TableDescription {
+		t, ok := description.Tables[name]
+		if !ok {
+			t = &schema.TableDescription{Columns: make(map[string]string), Indexes: make(map[string]string)}
+			description.Tables[name] = t
+		}
+		return t
+	}
+	for _, column := range columns {
+		definition := column.ColumnType
+		if !column.Nullable {
+			definition += " NOT NULL"
+		}
+		table(column.TableName).Columns[column.ColumnName] = definition
+	}
+	indexes := make(map[[2]string][]string)
+	unique := make(map[[2]string]bool)
+	var indexKeys [][2]string
+	for _, column := range indexColumns {
+		key := [2]string{column.TableName, column.IndexName}
+		if _, ok := indexes[key]; !ok {
+			indexKeys = append(indexKeys, key)
+		}
+		indexes[key] = append(indexes[key], column.ColumnName)
+		unique[key] = column.Unique
+	}
+	for _, key := range indexKeys {
+		definition := "(" + strings.Join(indexes[key], ", ") + ")"
+		if unique[key] {
+			definition = "UNIQUE " + definition
+		}
+		table(key[0]).Indexes[key[1]] = definition
+	}
+	return description, nil
+}
+
 // Close closes the sql client
 func (c *Connection) Close() {
 	if c.adminDb != nil {
@@ -13,6 +13,13 @@ import (
 	"go.temporal.io/server/tools/common/schema"
 )
 
+const (
+	// cliOptScratchDatabase is the cli option for the scratch database of verify-schema
+	cliOptScratchDatabase = "scratch-database"
+
+	defaultScratchDatabaseSuffix = "_verify_schema"
+)
+
 // setupSchema executes the setupSchemaTask
 // using the given command line arguments
 // as input
@@ -56,6 +63,91 @@ func updateSchema(cli *cli.Context, logger log.Logger) error {
 	return nil
 }
 
+// downgradeSchema downgrades the schema of the database by executing the rollback files of the versioned schema
+func downgradeSchema(cli *cli.Context, logger log.Logger) error {
+	cfg, err := parseConnectConfig(cli)
+	if err != nil {
+		logger.Error("Unable to read config.", tag.Error(schema.NewConfigError(err.Error())))
+		return err
+	}
+	conn, err := NewConnection(cfg, logger)
+	if err != nil {
+		logger.Error("Unable to connect to SQL database.", tag.Error(err))
+		return err
+	}
+	defer conn.Close()
+	if err := schema.Downgrade(cli, conn, logger); err != nil {
+		logger.Error("Unable to downgrade SQL schema.", tag.Error(err))
+		return err
+	}
+	return nil
+}
+
+// verifySchema diffs the live schema of the database against the expected schema of its recorded version, which is
+// set up in a scratch database created and dropped by this run
+func verifySchema(cli *cli.Context, logger log.Logger) error {
+	cfg, err := parseConnectConfig(cli)
+	if err != nil {
+		logger.Error("Unable to read config.", tag.Error(schema.NewConfigError(err.Error())))
+		return err
+	}
+	scratchCfg := *cfg
+	scratchCfg.DatabaseName = cli.String(cliOptScratchDatabase)
+	if scratchCfg.DatabaseName == "" {
+		scratchCfg.DatabaseName = cfg.DatabaseName + defaultScratchDatabaseSuffix
+	}
+	if scratchCfg.DatabaseName == cfg.DatabaseName {
+		err := schema.NewConfigError(flag(cliOptScratchDatabase) + " must be different from the verified database")
+		logger.Error("Unable to read config.", tag.Error(err))
+		return err
+	}
+
+	conn, err := NewConnection(cfg, logger)
+	if err != nil {
+		logger.Error("Unable to connect to SQL database.", tag.Error(err))
+		return err
+	}
+	defer conn.Close()
+
+	defaultDb := cli.String(schema.CLIOptDefaultDb)
+	// the scratch database is dropped afterwards, so it must be created by this run
+	existsCfg := scratchCfg
+	exists, err := doDatabaseExists(&existsCfg, defaultDb, logger)
+	if err != nil {
+		logger.Error("Unable to check for scratch SQL database.", tag.Error(err))
+		return err
+	}
+	if exists {
+		err := schema.NewConfigError(fmt.Sprintf("scratch database %q already exists, drop it or choose another one with %s",
+			scratchCfg.DatabaseName, flag(cliOptScratchDatabase)))
+		logger.Error("Unable to create scratch SQL database.", tag.Error(err))
+		return err
+	}
+	createCfg := scratchCfg
+	if err := DoCreateDatabase(&createCfg, defaultDb, logger); err != nil {
+		logger.Error("Unable to create scratch SQL database.", tag.Error(err))
+		return err
+	}
+	defer func() {
+		dropCfg := scratchCfg
+		if err := DoDropDatabase(&dropCfg, defaultDb, logger); err != nil {
+			logger.Warn("Unable to drop scratch SQL database.", tag.Error(err))
+		}
+	}()
+	scratchConn, err := NewConnection(&scratchCfg, logger)
+	if err != nil {
+		logger.Error("Unable to connect to scratch SQL database.", tag.Error(err))
+		return err
+	}
+	defer scratchConn.Close()
+
+	if err := schema.Verify(cli, conn, scratchConn, logger); err != nil {
+		logger.Error("Unable to verify SQL schema.", tag.Error(err))
+		return err
+	}
+	return nil
+}
+
 // createDatabase creates a sql database
 func createDatabase(cli *cli.Context, logger log.Logger) error {
 	cfg, err := parseConnectConfig(cli)
@@ -83,6 +175,17 @@ func DoCreateDatabase(cfg *config.SQL, defaultDb string, logger log.Logger) erro
 	return conn.CreateDatabase(dbToCreate)
 }
 
+func doDatabaseExists(cfg *config.SQL, defaultDb string, logger log.Logger) (bool, error) {
+	dbToCheck := cfg.DatabaseName
+	cfg.DatabaseName = defaultDb
+	conn, err := NewConnection(cfg, logger)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+	return conn.DatabaseExists(dbToCheck)
+}
+
 // dropDatabase drops a sql database
 func dropDatabase(cli *cli.Context, logger log.Logger) error {
 	cfg, err := parseConnectConfig(cli)
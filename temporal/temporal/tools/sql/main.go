@@ -167,11 +167,100 @@ func BuildCLIOptions() *cli.App {
 					Usage: fmt.Sprintf("name of embedded versioned schema, one of: %v",
 						dbschemas.PathsByDB("mysql")),
 				},
+				cli.BoolFlag{
+					Name:  schema.CLIOptDryRun,
+					Usage: "print the statements of the schema update in order without executing them",
+				},
 			},
 			Action: func(c *cli.Context) {
 				cliHandler(c, updateSchema, logger)
 			},
 		},
+		{
+			Name:    "downgrade-schema",
+			Aliases: []string{"downgrade"},
+			Usage:   "downgrade sql schema to a specific version by executing the rollback files of the newer versions",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  schema.CLIFlagTargetVersion,
+					Usage: "target version for the schema downgrade",
+				},
+				cli.StringFlag{
+					Name:  schema.CLIFlagSchemaDir,
+					Usage: "path to directory containing versioned schema",
+				},
+				cli.StringFlag{
+					Name: schema.CLIFlagSchemaName,
+					Usage: fmt.Sprintf("name of embedded versioned schema, one of: %v",
+						dbschemas.PathsByDB("mysql")),
+				},
+				cli.BoolFlag{
+					Name:  schema.CLIOptDryRun,
+					Usage: "print the statements of the schema downgrade in order without executing them",
+				},
+			},
+			Action: func(c *cli.Context) {
+				cliHandler(c, downgradeSchema, logger)
+			},
+		},
+		{
+			Name:  "verify-schema",
+			Usage: "diff the live sql schema against the expected schema of its recorded version",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  schema.CLIFlagSchemaDir,
+					Usage: "path to directory containing versioned schema",
+				},
+				cli.StringFlag{
+					Name: schema.CLIFlagSchemaName,
+					Usage: fmt.Sprintf("name of embedded versioned schema, one of: %v",
+						dbschemas.PathsByDB("sql")),
+				},
+				cli.StringFlag{
+					Name:  cliOptScratchDatabase,
+					Usage: "scratch database in which the expected schema is set up, which must not exist, defaults to the database name with a " + defaultScratchDatabaseSuffix + " suffix",
+				},
+				cli.StringFlag{
+					Name:  schema.CLIOptDefaultDb,
+					Usage: "optional default db to connect to, used to create and drop the scratch database",
+				},
+				cli.StringFlag{
+					Name:  schema.CLIOptOutputFormat,
+					Value: schema.OutputFormatText,
+					Usage: fmt.Sprintf("output format, one of: %v", []string{schema.OutputFormatText, schema.OutputFormatJSON}),
+				},
+			},
+			Action: func(c *cli.Context) {
+				cliHandler(c, verifySchema, logger)
+			},
+		},
+		{
+			Name:  "create-shard-partitions",
+			Usage: "move shard ranges of the postgresql tables partitioned by shard_id out of their default partitions",
+			Flags: []cli.Flag{
+				cli.IntFlag{
+					Name:  cliOptNumHistoryShards,
+					Usage: "number of history shards of the cluster",
+				},
+				cli.IntFlag{
+					Name:  cliOptShardsPerPartition,
+					Value: defaultShardsPerPartition,
+					Usage: "number of shards of each partition",
+				},
+				cli.IntFlag{
+					Name:  cliOptBatchSize,
+					Value: defaultBatchSize,
+					Usage: "number of rows copied by each transaction of the backfill of a partition",
+				},
+				cli.StringFlag{
+					Name:  cliOptTables,
+					Usage: fmt.Sprintf("comma separated tables to partition, defaults to all of: %v", shardPartitionedTableNames),
+				},
+			},
+			Action: func(c *cli.Context) {
+				cliHandler(c, createShardPartitions, logger)
+			},
+		},
 		{
 			Name:    "create-database",
 			Aliases: []string{"create"},
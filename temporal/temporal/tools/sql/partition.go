@@ -0,0 +1,258 @@
+package sql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/log/tag"
+	"go.temporal.io/server/common/persistence/sql/sqlplugin/postgresql"
+	"go.temporal.io/server/tools/common/schema"
+)
+
+const (
+	// cliOptNumHistoryShards is the cli option for the number of history shards of the cluster
+	cliOptNumHistoryShards = "num-history-shards"
+	// cliOptShardsPerPartition is the cli option for the number of shards of a partition
+	cliOptShardsPerPartition = "shards-per-partition"
+	// cliOptTables is the cli option for the tables to partition
+	cliOptTables = "tables"
+	// cliOptBatchSize is the cli option for the number of rows copied by each transaction of the backfill
+	cliOptBatchSize = "batch-size"
+
+	defaultShardsPerPartition = 256
+	defaultBatchSize          = 10000
+)
+
+// shardPartitionedTables are the tables which are partitioned by shard_id range in the PostgreSQL schema, and the
+// columns of their primary keys.
+var shardPartitionedTables = map[string][]string{
+	"executions":     {"shard_id", "namespace_id", "workflow_id", "run_id"},
+	"transfer_tasks": {"shard_id", "task_id"},
+	"timer_tasks":    {"shard_id", "visibility_timestamp", "task_id"},
+	"history_node":   {"shard_id", "tree_id", "branch_id", "node_id", "txn_id"},
+}
+
+// shardPartitionedTableNames are the names of shardPartitionedTables, in the order they are partitioned.
+var shardPartitionedTableNames = []string{"executions", "transfer_tasks", "timer_tasks", "history_node"}
+
+// shardPartitionedTable is a table partitioned by shard_id range, and the partitions its default partition is split
+// into.
+type shardPartitionedTable struct {
+	table              string
+	numShards          int32
+	shardsPerPartition int32
+	batchSize          int
+	partitions         []shardPartition
+}
+
+// shardPartition is the partition of a table which holds the rows of the shards in [firstShardID, lastShardID].
+type shardPartition struct {
+	table        string
+	firstShardID int32
+	lastShardID  int32
+}
+
+// createShardPartitions moves the shard ranges of the tables partitioned by shard_id out of their default partitions
+func createShardPartitions(cli *cli.Context, logger log.Logger) error {
+	cfg, err := parseConnectConfig(cli)
+	if err != nil {
+		logger.Error("Unable to read config.", tag.Error(schema.NewConfigError(err.Error())))
+		return err
+	}
+	if cfg.PluginName != postgresql.PluginName && cfg.PluginName != postgresql.PluginNamePGX {
+		err := schema.NewConfigError("shard partitions are only supported by the postgresql plugins")
+		logger.Error("Unable to create shard partitions.", tag.Error(err))
+		return err
+	}
+	tables, err := parseShardPartitionedTables(cli)
+	if err != nil {
+		logger.Error("Unable to read config.", tag.Error(err))
+		return err
+	}
+	conn, err := NewConnection(cfg, logger)
+	if err != nil {
+		logger.Error("Unable to connect to SQL database.", tag.Error(err))
+		return err
+	}
+	defer conn.Close()
+
+	for _, table := range tables {
+		logger.Info("Creating shard partitions.", tag.NewStringTag("table", table.table))
+		// statements are executed one at a time and not in a transaction, so that the batches of the backfill commit
+		// on their own
+		for _, stmt := range table.statements() {
+			if err := conn.Exec(stmt); err != nil {
+				logger.Error("Unable to create shard partitions.", tag.NewStringTag("table", table.table), tag.Error(err))
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func parseShardPartitionedTables(cli *cli.Context) ([]shardPartitionedTable, error) {
+	numShards := cli.Int(cliOptNumHistoryShards)
+	if numShards <= 0 {
+		return nil, schema.NewConfigError("missing " + flag(cliOptNumHistoryShards) + " argument")
+	}
+	shardsPerPartition := cli.Int(cliOptShardsPerPartition)
+	if shardsPerPartition <= 0 {
+		return nil, schema.NewConfigError(flag(cliOptShardsPerPartition) + " must be positive")
+	}
+	batchSize := cli.Int(cliOptBatchSize)
+	if batchSize <= 0 {
+		return nil, schema.NewConfigError(flag(cliOptBatchSize) + " must be positive")
+	}
+	names := shardPartitionedTableNames
+	if tablesOpt := cli.String(cliOptTables); tablesOpt != "" {
+		names = strings.Split(tablesOpt, ",")
+		for _, name := range names {
+			if _, ok := shardPartitionedTables[name]; !ok {
+				return nil, schema.NewConfigError(fmt.Sprintf("table %q is not partitioned by shard, one of: %v", name, shardPartitionedTableNames))
+			}
+		}
+	}
+	tables := make([]shardPartitionedTable, len(names))
+	for i, name := range names {
+		tables[i] = newShardPartitionedTable(name, int32(numShards), int32(shardsPerPartition), batchSize)
+	}
+	return tables, nil
+}
+
+func newShardPartitionedTable(table string, numShards int32, shardsPerPartition int32, batchSize int) shardPartitionedTable {
+	t := shardPartitionedTable{
+		table:              table,
+		numShards:          numShards,
+		shardsPerPartition: shardsPerPartition,
+		batchSize:          batchSize,
+	}
+	// shard IDs start from 1
+	for first := int32(1); first <= numShards; first += shardsPerPartition {
+		last := min(first+shardsPerPartition-1, numShards)
+		t.partitions = append(t.partitions, shardPartition{table: table, firstShardID: first, lastShardID: last})
+	}
+	return t
+}
+
+func (p shardPartition) name() string {
+	return fmt.Sprintf("%s_s%d_%d", p.table, p.firstShardID, p.lastShardID)
+}
+
+func (p shardPartition) shardRange() string {
+	return fmt.Sprintf("shard_id >= %d AND shard_id < %d", p.firstShardID, p.lastShardID+1)
+}
+
+// statements returns the statements which split the default partition of the table into its shard partitions. Rows
+// are never deleted from the default partition, and it is never scanned while writes are blocked:
+//   - the partitions are created as standalone tables, checked to only hold rows of their shards, and a trigger on the
+//     default partition mirrors every write of their shards into them;
+//   - each partition is backfilled from the default partition in batches of batchSize rows in primary key order, each
+//     in its own transaction;
+//   - a single short transaction then detaches the default partition, attaches the partitions, which their checks
+//     prove to hold only rows of their shards so they aren't scanned, and attaches a new empty default partition.
+//
+// The old default partition is left detached as <table>_default_detached, to be dropped once the partitions are
+// checked. Every statement is skipped once the partitions are attached, so the statements can be re-run after a
+// failure.
+func (t shardPartitionedTable) statements() []string {
+	defaultPartition := t.table + "_default"
+	mirror := t.table + "_shard_partitions_mirror"
+	columns := shardPartitionedTables[t.table]
+
+	var b strings.Builder
+	for _, p := range t.partitions {
+		fmt.Fprintf(&b, "CREATE TABLE IF NOT EXISTS %s (LIKE %s INCLUDING ALL, CONSTRAINT %s_shard_range CHECK (%s));\n",
+			p.name(), defaultPartition, p.name(), p.shardRange())
+	}
+	keyMatch := make([]string, len(columns))
+	for i, column := range columns {
+		keyMatch[i] = fmt.Sprintf("%s = ($1).%s", column, column)
+	}
+	deleteKey := fmt.Sprintf("'DELETE FROM %%I WHERE %s'", strings.Join(keyMatch, " AND "))
+	fmt.Fprintf(&b, "CREATE OR REPLACE FUNCTION %s() RETURNS trigger AS $mirror$\n", mirror)
+	b.WriteString("BEGIN\n")
+	fmt.Fprintf(&b, "IF TG_OP <> 'INSERT' AND OLD.shard_id BETWEEN 1 AND %d THEN\n", t.numShards)
+	fmt.Fprintf(&b, "EXECUTE format(%s, %s) USING OLD;\n", deleteKey, t.partitionName("OLD"))
+	b.WriteString("END IF;\n")
+	fmt.Fprintf(&b, "IF TG_OP <> 'DELETE' AND NEW.shard_id BETWEEN 1 AND %d THEN\n", t.numShards)
+	fmt.Fprintf(&b, "EXECUTE format(%s, %s) USING NEW;\n", deleteKey, t.partitionName("NEW"))
+	fmt.Fprintf(&b, "EXECUTE format('INSERT INTO %%I SELECT ($1).*', %s) USING NEW;\n", t.partitionName("NEW"))
+	b.WriteString("END IF;\n")
+	b.WriteString("RETURN NULL;\n")
+	b.WriteString("END\n")
+	b.WriteString("$mirror$ LANGUAGE plpgsql;\n")
+	fmt.Fprintf(&b, "DROP TRIGGER IF EXISTS %s ON %s;\n", mirror, defaultPartition)
+	fmt.Fprintf(&b, "CREATE TRIGGER %s AFTER INSERT OR UPDATE OR DELETE ON %s FOR EACH ROW EXECUTE FUNCTION %s();\n",
+		mirror, defaultPartition, mirror)
+	statements := []string{ifNotAttached(t.partitions[0], "", b.String())}
+
+	for _, p := range t.partitions {
+		statements = append(statements, t.backfill(p, defaultPartition, columns))
+	}
+
+	b.Reset()
+	fmt.Fprintf(&b, "LOCK TABLE %s IN ACCESS EXCLUSIVE MODE;\n", t.table)
+	// rows of shards outside of the partitions would be left behind in the detached default partition
+	fmt.Fprintf(&b, "IF EXISTS (SELECT 1 FROM %s WHERE shard_id < 1 OR shard_id > %d) THEN\n", defaultPartition, t.numShards)
+	fmt.Fprintf(&b, "RAISE EXCEPTION '%s has rows of shards outside of [1, %d]';\n", defaultPartition, t.numShards)
+	b.WriteString("END IF;\n")
+	fmt.Fprintf(&b, "ALTER TABLE %s DETACH PARTITION %s;\n", t.table, defaultPartition)
+	fmt.Fprintf(&b, "DROP TRIGGER %s ON %s;\n", mirror, defaultPartition)
+	fmt.Fprintf(&b, "DROP FUNCTION %s();\n", mirror)
+	fmt.Fprintf(&b, "ALTER TABLE %s RENAME TO %s_detached;\n", defaultPartition, defaultPartition)
+	fmt.Fprintf(&b, "ALTER INDEX %s_pkey RENAME TO %s_detached_pkey;\n", defaultPartition, defaultPartition)
+	for _, p := range t.partitions {
+		fmt.Fprintf(&b, "ALTER TABLE %s ATTACH PARTITION %s FOR VALUES FROM (%d) TO (%d);\n",
+			t.table, p.name(), p.firstShardID, p.lastShardID+1)
+		fmt.Fprintf(&b, "ALTER TABLE %s DROP CONSTRAINT %s_shard_range;\n", p.name(), p.name())
+	}
+	fmt.Fprintf(&b, "CREATE TABLE %s PARTITION OF %s DEFAULT;\n", defaultPartition, t.table)
+	return append(statements, ifNotAttached(t.partitions[0], "", b.String()))
+}
+
+// partitionName returns the expression of the name of the partition of the shard of the row.
+func (t shardPartitionedTable) partitionName(row string) string {
+	first := fmt.Sprintf("(%s.shard_id - 1) / %d * %d", row, t.shardsPerPartition, t.shardsPerPartition)
+	return fmt.Sprintf("format('%s_s%%s_%%s', %s + 1, least(%s + %d, %d))", t.table, first, first, t.shardsPerPartition, t.numShards)
+}
+
+// backfill returns the statements which copy the rows of the shards of the partition from the default partition, in
+// batches in primary key order which each commit on their own. The rows of a batch are locked until it commits, so
+// that the trigger mirrors their concurrent updates and deletes after they are copied. The primary key of the last
+// copied row starts just before the first shard, so that the first batch starts from the first row of the partition.
+func (t shardPartitionedTable) backfill(p shardPartition, defaultPartition string, columns []string) string {
+	lastColumns := make([]string, len(columns))
+	descColumns := make([]string, len(columns))
+	var declare strings.Builder
+	declare.WriteString("DECLARE\n")
+	for i, column := range columns {
+		lastColumns[i] = "last_" + column
+		descColumns[i] = column + " DESC"
+		fmt.Fprintf(&declare, "%s %s.%s%%TYPE", lastColumns[i], defaultPartition, column)
+		if column == "shard_id" {
+			fmt.Fprintf(&declare, " := %d", p.firstShardID-1)
+		}
+		declare.WriteString(";\n")
+	}
+	var b strings.Builder
+	b.WriteString("LOOP\n")
+	fmt.Fprintf(&b, "WITH batch AS (SELECT * FROM %s WHERE %s AND (%s) > (%s) ORDER BY %s LIMIT %d FOR SHARE),\n",
+		defaultPartition, p.shardRange(), strings.Join(columns, ", "), strings.Join(lastColumns, ", "),
+		strings.Join(columns, ", "), t.batchSize)
+	fmt.Fprintf(&b, "copied AS (INSERT INTO %s SELECT * FROM batch ON CONFLICT DO NOTHING)\n", p.name())
+	fmt.Fprintf(&b, "SELECT %s INTO %s FROM batch ORDER BY %s LIMIT 1;\n",
+		strings.Join(columns, ", "), strings.Join(lastColumns, ", "), strings.Join(descColumns, ", "))
+	b.WriteString("EXIT WHEN NOT FOUND;\n")
+	b.WriteString("COMMIT;\n")
+	b.WriteString("END LOOP;\n")
+	return ifNotAttached(p, declare.String(), b.String())
+}
+
+// ifNotAttached returns a DO block which runs body, with the variables declared by declare, unless the partition is
+// attached.
+func ifNotAttached(p shardPartition, declare string, body string) string {
+	return fmt.Sprintf("DO $$\n%sBEGIN\nIF NOT EXISTS (SELECT 1 FROM pg_class WHERE relname = '%s' AND relispartition) THEN\n%sEND IF;\nEND\n$$",
+		declare, p.name(), body)
+}
@@ -0,0 +1,103 @@
+package sql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewShardPartitionedTable(t *testing.T) {
+	table := newShardPartitionedTable("history_node", 10, 4, 100)
+	var names []string
+	for _, partition := range table.partitions {
+		names = append(names, partition.name())
+	}
+	require.Equal(t, []string{"history_node_s1_4", "history_node_s5_8", "history_node_s9_10"}, names)
+}
+
+func TestShardPartitionedTable_Statements(t *testing.T) {
+	table := newShardPartitionedTable("transfer_tasks", 4, 2, 100)
+	require.Equal(t, []string{
+		`DO $$
+BEGIN
+IF NOT EXISTS (SELECT 1 FROM pg_class WHERE relname = 'transfer_tasks_s1_2' AND relispartition) THEN
+CREATE TABLE IF NOT EXISTS transfer_tasks_s1_2 (LIKE transfer_tasks_default INCLUDING ALL, CONSTRAINT transfer_tasks_s1_2_shard_range CHECK (shard_id >= 1 AND shard_id < 3));
+CREATE TABLE IF NOT EXISTS transfer_tasks_s3_4 (LIKE transfer_tasks_default INCLUDING ALL, CONSTRAINT transfer_tasks_s3_4_shard_range CHECK (shard_id >= 3 AND shard_id < 5));
+CREATE OR REPLACE FUNCTION transfer_tasks_shard_partitions_mirror() RETURNS trigger AS $mirror$
+BEGIN
+IF TG_OP <> 'INSERT' AND OLD.shard_id BETWEEN 1 AND 4 THEN
+EXECUTE format('DELETE FROM %I WHERE shard_id = ($1).shard_id AND task_id = ($1).task_id', format('transfer_tasks_s%s_%s', (OLD.shard_id - 1) / 2 * 2 + 1, least((OLD.shard_id - 1) / 2 * 2 + 2, 4))) USING OLD;
+END IF;
+IF TG_OP <> 'DELETE' AND NEW.shard_id BETWEEN 1 AND 4 THEN
+EXECUTE format('DELETE FROM %I WHERE shard_id = ($1).shard_id AND task_id = ($1).task_id', format('transfer_tasks_s%s_%s', (NEW.shard_id - 1) / 2 * 2 + 1, least((NEW.shard_id - 1) / 2 * 2 + 2, 4))) USING NEW;
+EXECUTE format('INSERT INTO %I SELECT ($1).*', format('transfer_tasks_s%s_%s', (NEW.shard_id - 1) / 2 * 2 + 1, least((NEW.shard_id - 1) / 2 * 2 + 2, 4))) USING NEW;
+END IF;
+RETURN NULL;
+END
+$mirror$ LANGUAGE plpgsql;
+DROP TRIGGER IF EXISTS transfer_tasks_shard_partitions_mirror ON transfer_tasks_default;
+CREATE TRIGGER transfer_tasks_shard_partitions_mirror AFTER INSERT OR UPDATE OR DELETE ON transfer_tasks_default FOR EACH ROW EXECUTE FUNCTION transfer_tasks_shard_partitions_mirror();
+END IF;
+END
+$$`,
+		`DO $$
+DECLARE
+last_shard_id transfer_tasks_default.shard_id%TYPE := 0;
+last_task_id transfer_tasks_default.task_id%TYPE;
+BEGIN
+IF NOT EXISTS (SELECT 1 FROM pg_class WHERE relname = 'transfer_tasks_s1_2' AND relispartition) THEN
+LOOP
+WITH batch AS (SELECT * FROM transfer_tasks_default WHERE shard_id >= 1 AND shard_id < 3 AND (shard_id, task_id) > (last_shard_id, last_task_id) ORDER BY shard_id, task_id LIMIT 100 FOR SHARE),
+copied AS (INSERT INTO transfer_tasks_s1_2 SELECT * FROM batch ON CONFLICT DO NOTHING)
+SELECT shard_id, task_id INTO last_shard_id, last_task_id FROM batch ORDER BY shard_id DESC, task_id DESC LIMIT 1;
+EXIT WHEN NOT FOUND;
+COMMIT;
+END LOOP;
+END IF;
+END
+$$`,
+		`DO $$
+DECLARE
+last_shard_id transfer_tasks_default.shard_id%TYPE := 2;
+last_task_id transfer_tasks_default.task_id%TYPE;
+BEGIN
+IF NOT EXISTS (SELECT 1 FROM pg_class WHERE relname = 'transfer_tasks_s3_4' AND relispartition) THEN
+LOOP
+WITH batch AS (SELECT * FROM transfer_tasks_default WHERE shard_id >= 3 AND shard_id < 5 AND (shard_id, task_id) > (last_shard_id, last_task_id) ORDER BY shard_id, task_id LIMIT 100 FOR SHARE),
+copied AS (INSERT INTO transfer_tasks_s3_4 SELECT * FROM batch ON CONFLICT DO NOTHING)
+SELECT shard_id, task_id INTO last_shard_id, last_task_id FROM batch ORDER BY shard_id DESC, task_id DESC LIMIT 1;
+EXIT WHEN NOT FOUND;
+COMMIT;
+END LOOP;
+END IF;
+END
+$$`,
+		`DO $$
+BEGIN
+IF NOT EXISTS (SELECT 1 FROM pg_class WHERE relname = 'transfer_tasks_s1_2' AND relispartition) THEN
+LOCK TABLE transfer_tasks IN ACCESS EXCLUSIVE MODE;
+IF EXISTS (SELECT 1 FROM transfer_tasks_default WHERE shard_id < 1 OR shard_id > 4) THEN
+RAISE EXCEPTION 'transfer_tasks_default has rows of shards outside of [1, 4]';
+END IF;
+ALTER TABLE transfer_tasks DETACH PARTITION transfer_tasks_default;
+DROP TRIGGER transfer_tasks_shard_partitions_mirror ON transfer_tasks_default;
+DROP FUNCTION transfer_tasks_shard_partitions_mirror();
+ALTER TABLE transfer_tasks_default RENAME TO transfer_tasks_default_detached;
+ALTER INDEX transfer_tasks_default_pkey RENAME TO transfer_tasks_default_detached_pkey;
+ALTER TABLE transfer_tasks ATTACH PARTITION transfer_tasks_s1_2 FOR VALUES FROM (1) TO (3);
+ALTER TABLE transfer_tasks_s1_2 DROP CONSTRAINT transfer_tasks_s1_2_shard_range;
+ALTER TABLE transfer_tasks ATTACH PARTITION transfer_tasks_s3_4 FOR VALUES FROM (3) TO (5);
+ALTER TABLE transfer_tasks_s3_4 DROP CONSTRAINT transfer_tasks_s3_4_shard_range;
+CREATE TABLE transfer_tasks_default PARTITION OF transfer_tasks DEFAULT;
+END IF;
+END
+$$`,
+	}, table.statements())
+}
+
+func TestShardPartitionedTables(t *testing.T) {
+	require.Len(t, shardPartitionedTableNames, len(shardPartitionedTables))
+	for _, table := range shardPartitionedTableNames {
+		require.Equal(t, "shard_id", shardPartitionedTables[table][0], table)
+	}
+}
@@ -0,0 +1,86 @@
+package sql
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/server/common/config"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/persistence/sql/sqlplugin/sqlite"
+	"go.temporal.io/server/tools/common/schema"
+)
+
+func TestVerifySchema(t *testing.T) {
+	dir := t.TempDir()
+	schemaDir := filepath.Join(dir, "versioned")
+	writeVersion := func(version string, stmt string) {
+		versionDir := filepath.Join(schemaDir, "v"+version)
+		require.NoError(t, os.MkdirAll(versionDir, 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(versionDir, "manifest.json"), []byte(`{
+  "CurrVersion": "`+version+`",
+  "MinCompatibleVersion": "0.1",
+  "Description": "test",
+  "SchemaUpdateCqlFiles": ["update.sql"]
+}`), 0o644))
+		require.NoError(t, os.WriteFile(filepath.Join(versionDir, "update.sql"), []byte(stmt), 0o644))
+	}
+	writeVersion("0.1", "CREATE TABLE executions (shard_id INTEGER NOT NULL, data BLOB NOT NULL, PRIMARY KEY (shard_id));")
+	writeVersion("0.2", "CREATE INDEX executions_by_data ON executions (data);")
+	// the live database is verified against its recorded version
+	writeVersion("0.3", "CREATE TABLE timer_tasks (shard_id INTEGER NOT NULL);")
+
+	logger := log.NewNoopLogger()
+	newConnection := func(name string) *Connection {
+		conn, err := NewConnection(&config.SQL{
+			PluginName:   sqlite.PluginName,
+			DatabaseName: filepath.Join(dir, name),
+		}, logger)
+		require.NoError(t, err)
+		t.Cleanup(conn.Close)
+		return conn
+	}
+	db := newConnection("temporal.db")
+
+	require.NoError(t, schema.NewSetupSchemaTask(db, &schema.SetupConfig{InitialVersion: "0.0"}, logger).Run())
+	require.NoError(t, schema.NewUpdateSchemaTask(db, &schema.UpdateConfig{SchemaDir: schemaDir, TargetVersion: "0.2"}, logger).Run())
+
+	var out bytes.Buffer
+	verifyConfig := &schema.VerifyConfig{SchemaDir: schemaDir, OutputFormat: schema.OutputFormatText}
+	require.NoError(t, schema.NewVerifySchemaTask(db, newConnection("scratch.db"), verifyConfig, &out, logger).Run())
+	require.Equal(t, "Schema matches the expected schema of version 0.2.\n", out.String())
+
+	require.NoError(t, db.Exec("DROP INDEX executions_by_data"))
+	require.NoError(t, db.Exec("ALTER TABLE executions ADD COLUMN extra TEXT"))
+
+	out.Reset()
+	verifyConfig.OutputFormat = schema.OutputFormatJSON
+	err := schema.NewVerifySchemaTask(db, newConnection("scratch2.db"), verifyConfig, &out, logger).Run()
+	require.ErrorIs(t, err, schema.ErrSchemaDrift)
+	var result schema.VerifyResult
+	require.NoError(t, json.Unmarshal(out.Bytes(), &result))
+	require.Equal(t, schema.VerifyResult{
+		Version: "0.2",
+		Drifts: []schema.Drift{
+			{Kind: schema.DriftExtra, Object: "column", Name: "executions.extra", Actual: "TEXT"},
+			{Kind: schema.DriftMissing, Object: "index", Name: "executions.executions_by_data", Expected: "(data)"},
+		},
+	}, result)
+}
+
+func TestDoDatabaseExists(t *testing.T) {
+	dir := t.TempDir()
+	logger := log.NewNoopLogger()
+	exists := func(name string) bool {
+		cfg := &config.SQL{PluginName: sqlite.PluginName, DatabaseName: filepath.Join(dir, name)}
+		exists, err := doDatabaseExists(cfg, filepath.Join(dir, "default.db"), logger)
+		require.NoError(t, err)
+		return exists
+	}
+	require.False(t, exists("scratch.db"))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "scratch.db"), nil, 0o644))
+	require.True(t, exists("scratch.db"))
+}
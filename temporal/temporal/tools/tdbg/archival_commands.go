@@ -0,0 +1,73 @@
+package tdbg
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+	commonpb "go.temporal.io/api/common/v1"
+	"go.temporal.io/server/api/adminservice/v1"
+	enumsspb "go.temporal.io/server/api/enums/v1"
+)
+
+// AdminVerifyArchivedHistory verifies the archived histories of a run, or of the runs matching a visibility archival
+// query, against their integrity manifests
+func AdminVerifyArchivedHistory(c *cli.Context, clientFactory ClientFactory) error {
+	nsName, err := getRequiredOption(c, FlagNamespace)
+	if err != nil {
+		return err
+	}
+
+	request := &adminservice.VerifyArchivedHistoryRequest{
+		Namespace:    nsName,
+		Query:        c.String(FlagVisibilityQuery),
+		PageSize:     int32(c.Int(FlagPageSize)),
+		FailuresOnly: c.Bool(FlagFailuresOnly),
+	}
+	if c.IsSet(FlagWorkflowID) || c.IsSet(FlagRunID) {
+		wid, err := getRequiredOption(c, FlagWorkflowID)
+		if err != nil {
+			return err
+		}
+		rid, err := getRequiredOption(c, FlagRunID)
+		if err != nil {
+			return err
+		}
+		request.Execution = &commonpb.WorkflowExecution{
+			WorkflowId: wid,
+			RunId:      rid,
+		}
+	}
+
+	client := clientFactory.AdminClient(c)
+	var verifiedCount, failureCount int
+	for {
+		ctx, cancel := newContext(c)
+		resp, err := client.VerifyArchivedHistory(ctx, request)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("unable to verify archived history: %w", err)
+		}
+
+		verifiedCount += int(resp.GetVerifiedCount())
+		for _, result := range resp.GetResults() {
+			if result.GetStatus() != enumsspb.ARCHIVAL_INTEGRITY_STATUS_OK {
+				failureCount++
+			}
+			if c.Bool(FlagPrintJSON) {
+				prettyPrintJSONObject(c, result)
+				continue
+			}
+			// nolint:errcheck // assuming that write will succeed.
+			fmt.Fprintf(c.App.Writer, "%s\t%s\t%s\t%s\n", result.GetExecution().GetWorkflowId(), result.GetExecution().GetRunId(), result.GetStatus(), result.GetDetails())
+		}
+
+		if len(resp.GetNextPageToken()) == 0 || !c.Bool(FlagMore) {
+			break
+		}
+		request.NextPageToken = resp.GetNextPageToken()
+	}
+
+	// nolint:errcheck // assuming that write will succeed.
+	fmt.Fprintf(c.App.Writer, "Verified %d archived histories, %d failed verification.\n", verifiedCount, failureCount)
+	return nil
+}
@@ -0,0 +1,80 @@
+package tdbg
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+	commonpb "go.temporal.io/api/common/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/server/api/adminservice/v1"
+	enumsspb "go.temporal.io/server/api/enums/v1"
+	"google.golang.org/grpc"
+)
+
+type archivalTestClient struct {
+	adminservice.AdminServiceClient
+	requests []*adminservice.VerifyArchivedHistoryRequest
+}
+
+func (t *archivalTestClient) AdminClient(*cli.Context) adminservice.AdminServiceClient {
+	return t
+}
+
+func (t *archivalTestClient) WorkflowClient(*cli.Context) workflowservice.WorkflowServiceClient {
+	panic("unimplemented")
+}
+
+func (t *archivalTestClient) VerifyArchivedHistory(_ context.Context, request *adminservice.VerifyArchivedHistoryRequest, _ ...grpc.CallOption) (*adminservice.VerifyArchivedHistoryResponse, error) {
+	t.requests = append(t.requests, request)
+	resp := &adminservice.VerifyArchivedHistoryResponse{
+		VerifiedCount: 2,
+		Results: []*adminservice.ArchivedHistoryVerification{{
+			Execution: &commonpb.WorkflowExecution{WorkflowId: "wid", RunId: "rid"},
+			Status:    enumsspb.ARCHIVAL_INTEGRITY_STATUS_TRUNCATED,
+			Details:   "archived history ends at event 3, manifest ends at event 5",
+		}},
+	}
+	if len(request.GetNextPageToken()) == 0 {
+		resp.NextPageToken = []byte("next")
+	}
+	return resp, nil
+}
+
+func TestArchivalVerifyCommand(t *testing.T) {
+	client := &archivalTestClient{}
+	var out bytes.Buffer
+	app := NewCliApp(func(params *Params) {
+		params.ClientFactory = client
+		params.Writer = &out
+	})
+	app.ExitErrHandler = func(context *cli.Context, err error) {}
+
+	require.NoError(t, app.Run([]string{"tdbg", "--namespace", "test-namespace", "archival", "verify",
+		"--query", "WorkflowType = 'type'", "--pagesize", "10", "--failures-only",
+	}))
+	require.Len(t, client.requests, 1)
+	require.Equal(t, "test-namespace", client.requests[0].GetNamespace())
+	require.Equal(t, "WorkflowType = 'type'", client.requests[0].GetQuery())
+	require.Equal(t, int32(10), client.requests[0].GetPageSize())
+	require.True(t, client.requests[0].GetFailuresOnly())
+	require.Nil(t, client.requests[0].GetExecution())
+	require.Contains(t, out.String(), "wid\trid\tTruncated")
+	require.Contains(t, out.String(), "Verified 2 archived histories, 1 failed verification.")
+
+	out.Reset()
+	require.NoError(t, app.Run([]string{"tdbg", "--namespace", "test-namespace", "archival", "verify", "--more"}))
+	require.Len(t, client.requests, 3)
+	require.Equal(t, []byte("next"), client.requests[2].GetNextPageToken())
+	require.Contains(t, out.String(), "Verified 4 archived histories, 2 failed verification.")
+
+	require.NoError(t, app.Run([]string{"tdbg", "--namespace", "test-namespace", "archival", "verify", "--workflow-id", "wid", "--run-id", "rid"}))
+	require.Len(t, client.requests, 4)
+	require.Equal(t, "wid", client.requests[3].GetExecution().GetWorkflowId())
+	require.Equal(t, "rid", client.requests[3].GetExecution().GetRunId())
+
+	require.Error(t, app.Run([]string{"tdbg", "--namespace", "test-namespace", "archival", "verify", "--workflow-id", "wid"}))
+	require.Len(t, client.requests, 4)
+}
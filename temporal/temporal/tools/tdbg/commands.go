@@ -253,6 +253,45 @@ func AdminImportWorkflow(c *cli.Context, clientFactory ClientFactory) error {
 	return nil
 }
 
+// AdminRehydrateWorkflow restores the archived history of a workflow which passed the namespace retention
+func AdminRehydrateWorkflow(c *cli.Context, clientFactory ClientFactory) error {
+	nsName, err := getRequiredOption(c, FlagNamespace)
+	if err != nil {
+		return err
+	}
+	wid, err := getRequiredOption(c, FlagWorkflowID)
+	if err != nil {
+		return err
+	}
+	rid, err := getRequiredOption(c, FlagRunID)
+	if err != nil {
+		return err
+	}
+
+	request := &adminservice.RehydrateWorkflowExecutionRequest{
+		Namespace: nsName,
+		Execution: &commonpb.WorkflowExecution{
+			WorkflowId: wid,
+			RunId:      rid,
+		},
+	}
+	if c.IsSet(FlagRetention) {
+		request.Retention = durationpb.New(c.Duration(FlagRetention))
+	}
+
+	client := clientFactory.AdminClient(c)
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	resp, err := client.RehydrateWorkflowExecution(ctx, request)
+	if err != nil {
+		return fmt.Errorf("unable to rehydrate workflow: %s", err)
+	}
+	// nolint:errcheck // assuming that write will succeed.
+	fmt.Fprintf(c.App.Writer, "Rehydrate workflow succeeded, the workflow is kept until %s.\n", resp.GetExpirationTime().AsTime().Format(time.RFC3339))
+	return nil
+}
+
 // AdminDescribeExecution describes a Temporal execution (CHASM tree or workflow).
 func AdminDescribeExecution(c *cli.Context, clientFactory ClientFactory) error {
 	resp, err := describeMutableState(c, clientFactory)
@@ -1,12 +1,40 @@
 package tdbg
 
 import (
+	"bytes"
+	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/server/api/adminservice/v1"
 	"go.temporal.io/server/service/history/tasks"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+type rehydrateTestClient struct {
+	adminservice.AdminServiceClient
+	requests []*adminservice.RehydrateWorkflowExecutionRequest
+}
+
+func (t *rehydrateTestClient) AdminClient(*cli.Context) adminservice.AdminServiceClient {
+	return t
+}
+
+func (t *rehydrateTestClient) WorkflowClient(*cli.Context) workflowservice.WorkflowServiceClient {
+	panic("unimplemented")
+}
+
+func (t *rehydrateTestClient) RehydrateWorkflowExecution(_ context.Context, request *adminservice.RehydrateWorkflowExecutionRequest, _ ...grpc.CallOption) (*adminservice.RehydrateWorkflowExecutionResponse, error) {
+	t.requests = append(t.requests, request)
+	return &adminservice.RehydrateWorkflowExecutionResponse{
+		ExpirationTime: timestamppb.New(time.Date(2024, 5, 6, 12, 0, 0, 0, time.UTC)),
+	}, nil
+}
+
 func TestGetCategory(t *testing.T) {
 	cat101 := tasks.NewCategory(101, tasks.CategoryTypeImmediate, "CategoryName")
 
@@ -49,3 +77,32 @@ func TestGetCategory(t *testing.T) {
 		})
 	}
 }
+
+func TestAdminRehydrateWorkflow(t *testing.T) {
+	client := &rehydrateTestClient{}
+	var out bytes.Buffer
+	app := NewCliApp(func(params *Params) {
+		params.ClientFactory = client
+		params.Writer = &out
+	})
+	app.ExitErrHandler = func(context *cli.Context, err error) {}
+
+	require.NoError(t, app.Run([]string{"tdbg", "--namespace", "test-namespace", "workflow", "rehydrate",
+		"--workflow-id", "wid", "--run-id", "rid", "--retention", "48h",
+	}))
+	require.Len(t, client.requests, 1)
+	require.Equal(t, "test-namespace", client.requests[0].GetNamespace())
+	require.Equal(t, "wid", client.requests[0].GetExecution().GetWorkflowId())
+	require.Equal(t, "rid", client.requests[0].GetExecution().GetRunId())
+	require.Equal(t, 48*time.Hour, client.requests[0].GetRetention().AsDuration())
+	require.Contains(t, out.String(), "2024-05-06T12:00:00Z")
+
+	require.NoError(t, app.Run([]string{"tdbg", "--namespace", "test-namespace", "workflow", "rehydrate",
+		"--workflow-id", "wid", "--run-id", "rid",
+	}))
+	require.Len(t, client.requests, 2)
+	require.Nil(t, client.requests[1].GetRetention())
+
+	require.Error(t, app.Run([]string{"tdbg", "--namespace", "test-namespace", "workflow", "rehydrate", "--workflow-id", "wid"}))
+	require.Len(t, client.requests, 2)
+}
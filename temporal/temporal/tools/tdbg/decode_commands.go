@@ -8,7 +8,13 @@ import (
 	"strings"
 
 	"github.com/urfave/cli/v2"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/server/common/clock"
 	"go.temporal.io/server/common/codec"
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/persistence/serialization"
 	"google.golang.org/protobuf/encoding/prototext"
 )
 
@@ -57,6 +63,11 @@ func AdminDecodeProto(c *cli.Context) error {
 		return fmt.Errorf("missing required parameter data flag")
 	}
 
+	protoData, err = decodePersistedData(c, protoData)
+	if err != nil {
+		return err
+	}
+
 	message, err := unmarshalProtoByTypeName(protoType, protoData)
 	if err != nil {
 		return err
@@ -78,6 +89,37 @@ func AdminDecodeProto(c *cli.Context) error {
 	return nil
 }
 
+// decodePersistedData decrypts and decompresses proto data which was encrypted or compressed when it was persisted,
+// and returns other data unchanged. Encrypted data is bound to its namespace and row, which must be provided to
+// decrypt it.
+func decodePersistedData(c *cli.Context, data []byte) ([]byte, error) {
+	blob := &commonpb.DataBlob{EncodingType: enumspb.ENCODING_TYPE_PROTO3, Data: data}
+	if serialization.IsEncrypted(blob) {
+		keyFile := c.String(FlagKeyFile)
+		if keyFile == "" {
+			return nil, fmt.Errorf("data is encrypted, use the --%s flag to decrypt it", FlagKeyFile)
+		}
+		timeSource := clock.NewRealTimeSource()
+		keyProvider, err := serialization.NewFileKeyProvider(keyFile, log.NewNoopLogger(), timeSource)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load key file %s: %w", keyFile, err)
+		}
+		encryptor := serialization.NewBlobEncryptor(keyProvider, dynamicconfig.NewNoopCollection(), timeSource)
+		blobContext := serialization.BlobContext{
+			NamespaceID: c.String(FlagNamespaceID),
+			Row:         c.String(FlagEncryptionRow),
+		}
+		if blob, err = encryptor.Decrypt(blobContext, blob); err != nil {
+			return nil, err
+		}
+	}
+	blob, err := serialization.Decompress(blob)
+	if err != nil {
+		return nil, err
+	}
+	return blob.Data, nil
+}
+
 func AdminDecodeBase64(c *cli.Context) error {
 	base64Data := c.String(FlagBase64Data)
 	base64File := c.String(FlagBase64File)
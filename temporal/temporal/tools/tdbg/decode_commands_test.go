@@ -0,0 +1,65 @@
+package tdbg_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common/clock"
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/persistence/serialization"
+	"go.temporal.io/server/tools/tdbg"
+)
+
+func TestDecodeProto_Encrypted(t *testing.T) {
+	t.Parallel()
+
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	keyFile := filepath.Join(t.TempDir(), "keys.yaml")
+	require.NoError(t, os.WriteFile(keyFile, []byte("currentKeyId: key-1\nkeys:\n  key-1: "+base64.StdEncoding.EncodeToString(key)), 0o600))
+	keyProvider, err := serialization.NewFileKeyProvider(keyFile, log.NewNoopLogger(), clock.NewRealTimeSource())
+	require.NoError(t, err)
+	dcClient := dynamicconfig.NewMemoryClient()
+	dcClient.OverrideSetting(dynamicconfig.PersistenceBlobEncryptionEnabled, true)
+	encryptor := serialization.NewBlobEncryptor(keyProvider, dynamicconfig.NewCollection(dcClient, log.NewNoopLogger()), clock.NewRealTimeSource())
+
+	blob, err := serialization.ProtoEncode(&persistencespb.WorkflowExecutionInfo{WorkflowId: "encrypted-workflow-id"})
+	require.NoError(t, err)
+	blob, err = encryptor.Encrypt(
+		serialization.MutableStateBlobContext("namespace-id", "workflow-id", "run-id", serialization.ExecutionInfoBlobField),
+		blob,
+	)
+	require.NoError(t, err)
+	require.True(t, serialization.IsEncrypted(blob))
+
+	args := []string{
+		"tdbg", "decode", "proto",
+		"--" + tdbg.FlagProtoType, "temporal.server.api.persistence.v1.WorkflowExecutionInfo",
+		"--" + tdbg.FlagHexData, "0x" + hex.EncodeToString(blob.Data),
+	}
+	var output bytes.Buffer
+	app := tdbg.NewCliApp(func(params *tdbg.Params) {
+		params.Writer = &output
+	})
+	app.ExitErrHandler = func(context *cli.Context, err error) {}
+
+	err = app.Run(args)
+	require.ErrorContains(t, err, "data is encrypted")
+
+	args = append(args, "--"+tdbg.FlagKeyFile, keyFile, "--"+tdbg.FlagNamespaceID, "namespace-id")
+	err = app.Run(args)
+	require.ErrorContains(t, err, "unable to decrypt blob", "the row of the data is required")
+
+	require.NoError(t, app.Run(append(args, "--"+tdbg.FlagEncryptionRow, `execution "workflow-id" "run-id" "execution_info"`)))
+	require.Contains(t, output.String(), "encrypted-workflow-id")
+}
@@ -0,0 +1,97 @@
+package tdbg
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+	"go.temporal.io/server/api/adminservice/v1"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// AdminListFaultInjectionRules lists the persistence fault injection rules
+func AdminListFaultInjectionRules(c *cli.Context, clientFactory ClientFactory) error {
+	adminClient := clientFactory.AdminClient(c)
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	resp, err := adminClient.ListFaultInjectionRules(ctx, &adminservice.ListFaultInjectionRulesRequest{
+		DataStore: c.String(FlagDataStore),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to list fault injection rules: %w", err)
+	}
+	prettyPrintJSONObject(c, resp)
+	return nil
+}
+
+// AdminAddFaultInjectionRule adds a persistence fault injection rule
+func AdminAddFaultInjectionRule(c *cli.Context, clientFactory ClientFactory) error {
+	rule := &adminservice.FaultInjectionRule{
+		DataStore: c.String(FlagDataStore),
+		Method:    c.String(FlagMethod),
+		Seed:      c.Int64(FlagSeed),
+	}
+	for _, faultError := range c.StringSlice(FlagFaultError) {
+		name, rateStr, ok := strings.Cut(faultError, "=")
+		if !ok {
+			return fmt.Errorf("invalid %s %q, expected <name>=<rate>", FlagFaultError, faultError)
+		}
+		rate, err := strconv.ParseFloat(rateStr, 64)
+		if err != nil {
+			return fmt.Errorf("invalid rate of %s %q: %w", FlagFaultError, faultError, err)
+		}
+		if rule.Errors == nil {
+			rule.Errors = make(map[string]float64)
+		}
+		rule.Errors[name] = rate
+	}
+	if c.IsSet(FlagLatencyRate) {
+		rule.Latency = &adminservice.FaultInjectionLatency{
+			Rate:         c.Float64(FlagLatencyRate),
+			Distribution: c.String(FlagLatencyDistribution),
+			Min:          durationpb.New(c.Duration(FlagLatencyMin)),
+			Max:          durationpb.New(c.Duration(FlagLatencyMax)),
+		}
+	}
+	if c.IsSet(FlagWindowStart) || c.IsSet(FlagWindowDuration) || c.IsSet(FlagWindowPeriod) {
+		rule.Windows = append(rule.Windows, &adminservice.FaultInjectionWindow{
+			Start:    durationpb.New(c.Duration(FlagWindowStart)),
+			Duration: durationpb.New(c.Duration(FlagWindowDuration)),
+			Period:   durationpb.New(c.Duration(FlagWindowPeriod)),
+		})
+	}
+
+	adminClient := clientFactory.AdminClient(c)
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	resp, err := adminClient.AddFaultInjectionRule(ctx, &adminservice.AddFaultInjectionRuleRequest{
+		Rule: rule,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to add fault injection rule: %w", err)
+	}
+	fmt.Fprintf(c.App.Writer, "Added fault injection rule to %s.%s on the frontend host and %d history and matching hosts\n",
+		rule.DataStore, rule.Method, len(resp.GetHosts()))
+	return nil
+}
+
+// AdminClearFaultInjectionRules clears the persistence fault injection rules which were added at runtime
+func AdminClearFaultInjectionRules(c *cli.Context, clientFactory ClientFactory) error {
+	adminClient := clientFactory.AdminClient(c)
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	resp, err := adminClient.ClearFaultInjectionRules(ctx, &adminservice.ClearFaultInjectionRulesRequest{
+		DataStore: c.String(FlagDataStore),
+		Method:    c.String(FlagMethod),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to clear fault injection rules: %w", err)
+	}
+	fmt.Fprintf(c.App.Writer, "Cleared %d fault injection rules from the frontend host, and the rules of %d history and matching hosts\n",
+		resp.GetClearedCount(), len(resp.GetHosts()))
+	return nil
+}
@@ -0,0 +1,81 @@
+package tdbg
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/server/api/adminservice/v1"
+	"google.golang.org/grpc"
+)
+
+type faultInjectionTestClient struct {
+	adminservice.AdminServiceClient
+	added   []*adminservice.FaultInjectionRule
+	cleared []*adminservice.ClearFaultInjectionRulesRequest
+}
+
+func (t *faultInjectionTestClient) AdminClient(*cli.Context) adminservice.AdminServiceClient {
+	return t
+}
+
+func (t *faultInjectionTestClient) WorkflowClient(*cli.Context) workflowservice.WorkflowServiceClient {
+	panic("unimplemented")
+}
+
+func (t *faultInjectionTestClient) ListFaultInjectionRules(_ context.Context, request *adminservice.ListFaultInjectionRulesRequest, _ ...grpc.CallOption) (*adminservice.ListFaultInjectionRulesResponse, error) {
+	return &adminservice.ListFaultInjectionRulesResponse{Rules: t.added}, nil
+}
+
+func (t *faultInjectionTestClient) AddFaultInjectionRule(_ context.Context, request *adminservice.AddFaultInjectionRuleRequest, _ ...grpc.CallOption) (*adminservice.AddFaultInjectionRuleResponse, error) {
+	t.added = append(t.added, request.GetRule())
+	return &adminservice.AddFaultInjectionRuleResponse{}, nil
+}
+
+func (t *faultInjectionTestClient) ClearFaultInjectionRules(_ context.Context, request *adminservice.ClearFaultInjectionRulesRequest, _ ...grpc.CallOption) (*adminservice.ClearFaultInjectionRulesResponse, error) {
+	t.cleared = append(t.cleared, request)
+	return &adminservice.ClearFaultInjectionRulesResponse{ClearedCount: int32(len(t.added))}, nil
+}
+
+func TestFaultInjectionCommands(t *testing.T) {
+	client := &faultInjectionTestClient{}
+	var out bytes.Buffer
+	app := NewCliApp(func(params *Params) {
+		params.ClientFactory = client
+		params.Writer = &out
+	})
+	app.ExitErrHandler = func(context *cli.Context, err error) {}
+
+	require.NoError(t, app.Run([]string{"tdbg", "fault-injection", "add",
+		"--data-store", "ExecutionStore", "--method", "UpdateWorkflowExecution",
+		"--error", "Timeout=0.1", "--error", "Unavailable=0.2",
+		"--latency-rate", "0.5", "--latency-min", "10ms", "--latency-max", "1s",
+		"--window-start", "1m", "--window-duration", "10s",
+	}))
+	require.Len(t, client.added, 1)
+	rule := client.added[0]
+	require.Equal(t, "ExecutionStore", rule.GetDataStore())
+	require.Equal(t, "UpdateWorkflowExecution", rule.GetMethod())
+	require.Equal(t, map[string]float64{"Timeout": 0.1, "Unavailable": 0.2}, rule.GetErrors())
+	require.Equal(t, 0.5, rule.GetLatency().GetRate())
+	require.Equal(t, time.Second, rule.GetLatency().GetMax().AsDuration())
+	require.Len(t, rule.GetWindows(), 1)
+	require.Equal(t, time.Minute, rule.GetWindows()[0].GetStart().AsDuration())
+
+	require.NoError(t, app.Run([]string{"tdbg", "fi", "list"}))
+	require.Contains(t, out.String(), "UpdateWorkflowExecution")
+
+	require.NoError(t, app.Run([]string{"tdbg", "fi", "clear", "--data-store", "ExecutionStore"}))
+	require.Len(t, client.cleared, 1)
+	require.Equal(t, "ExecutionStore", client.cleared[0].GetDataStore())
+	require.Contains(t, out.String(), "Cleared 1 fault injection rules")
+
+	require.ErrorContains(t, app.Run([]string{"tdbg", "fi", "add",
+		"--data-store", "ExecutionStore", "--method", "UpdateWorkflowExecution", "--error", "Timeout",
+	}), "expected <name>=<rate>")
+	require.Len(t, client.added, 1)
+}
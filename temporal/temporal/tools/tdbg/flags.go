@@ -62,6 +62,8 @@ var (
 	FlagHexData                    = "hex-data"
 	FlagHexFile                    = "hex-file"
 	FlagBinaryFile                 = "binary-file"
+	FlagKeyFile                    = "key-file"
+	FlagEncryptionRow              = "encryption-row"
 	FlagBase64Data                 = "base64-data"
 	FlagBase64File                 = "base64-file"
 	FlagTaskCategoryID             = "task-category-id"
@@ -76,4 +78,19 @@ var (
 	FlagVisibilityQuery            = "query"
 	FlagJobID                      = "job-id"
 	FlagDecode                     = "decode"
+	FlagDataStore                  = "data-store"
+	FlagMethod                     = "method"
+	FlagFaultError                 = "error"
+	FlagLatencyRate                = "latency-rate"
+	FlagLatencyDistribution        = "latency-distribution"
+	FlagLatencyMin                 = "latency-min"
+	FlagLatencyMax                 = "latency-max"
+	FlagWindowStart                = "window-start"
+	FlagWindowDuration             = "window-duration"
+	FlagWindowPeriod               = "window-period"
+	FlagSeed                       = "seed"
+	FlagAggregation                = "aggregation"
+	FlagExecute                    = "execute"
+	FlagRetention                  = "retention"
+	FlagFailuresOnly               = "failures-only"
 )
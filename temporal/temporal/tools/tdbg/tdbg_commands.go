@@ -69,6 +69,24 @@ func getCommands(
 			Usage:       "Decode payload",
 			Subcommands: newDecodeCommands(taskBlobEncoder),
 		},
+		{
+			Name:        "fault-injection",
+			Aliases:     []string{"fi"},
+			Usage:       "Run admin operation on persistence fault injection rules",
+			Subcommands: newAdminFaultInjectionCommands(clientFactory),
+		},
+		{
+			Name:        "visibility",
+			Aliases:     []string{"vis"},
+			Usage:       "Run admin operation on visibility records",
+			Subcommands: newAdminVisibilityCommands(clientFactory),
+		},
+		{
+			Name:        "archival",
+			Aliases:     []string{"ar"},
+			Usage:       "Run admin operation on archived workflow histories",
+			Subcommands: newAdminArchivalCommands(clientFactory),
+		},
 	}
 }
 
@@ -96,6 +114,28 @@ func newAdminExecutionCommands(clientFactory ClientFactory, prompterFactory Prom
 				return AdminImportWorkflow(c, clientFactory)
 			},
 		},
+		{
+			Name:  "rehydrate",
+			Usage: "restore archived workflow history to database, after the workflow passed the namespace retention",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    FlagWorkflowID,
+					Aliases: FlagWorkflowIDAlias,
+					Usage:   "Workflow ID",
+				},
+				&cli.StringFlag{
+					Name:    FlagRunID,
+					Aliases: FlagRunIDAlias,
+					Usage:   "Run ID",
+				},
+				&cli.DurationFlag{
+					Name:  FlagRetention,
+					Usage: "How long the rehydrated workflow is kept before it is deleted again (default is frontend.rehydratedWorkflowRetention)",
+				}},
+			Action: func(c *cli.Context) error {
+				return AdminRehydrateWorkflow(c, clientFactory)
+			},
+		},
 		{
 			Name:  "show",
 			Usage: "show workflow history from database",
@@ -496,6 +536,198 @@ func newAdminHistoryHostCommands(clientFactory ClientFactory) []*cli.Command {
 	}
 }
 
+func newAdminFaultInjectionCommands(clientFactory ClientFactory) []*cli.Command {
+	return []*cli.Command{
+		{
+			Name:    "list",
+			Aliases: []string{"l"},
+			Usage:   "List the persistence fault injection rules of the frontend host; the rules of the history and matching hosts are not listed",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  FlagDataStore,
+					Usage: "Only list the rules of this data store, e.g. ExecutionStore",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				return AdminListFaultInjectionRules(c, clientFactory)
+			},
+		},
+		{
+			Name:    "add",
+			Aliases: []string{"a"},
+			Usage:   "Add a persistence fault injection rule to a data store method on the frontend, history and matching hosts, replacing the previous one added at runtime",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     FlagDataStore,
+					Usage:    "Data store, e.g. ExecutionStore",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:     FlagMethod,
+					Usage:    "Data store method, e.g. UpdateWorkflowExecution",
+					Required: true,
+				},
+				&cli.StringSliceFlag{
+					Name: FlagFaultError,
+					Usage: "Error to inject as <name>=<rate>, can be repeated. Names are ShardOwnershipLost, DeadlineExceeded, " +
+						"Timeout, ExecuteAndTimeout, ExecuteAndDeadlineExceeded, ResourceExhausted and Unavailable",
+				},
+				&cli.Float64Flag{
+					Name:  FlagLatencyRate,
+					Usage: "Rate of calls which are delayed",
+				},
+				&cli.StringFlag{
+					Name:  FlagLatencyDistribution,
+					Usage: "Distribution of the injected latency: fixed (default), uniform, longTail",
+				},
+				&cli.DurationFlag{
+					Name:  FlagLatencyMin,
+					Usage: "Minimum injected latency",
+				},
+				&cli.DurationFlag{
+					Name:  FlagLatencyMax,
+					Usage: "Maximum injected latency",
+				},
+				&cli.DurationFlag{
+					Name:  FlagWindowStart,
+					Usage: "Only inject faults after this delay from when the rule is applied",
+				},
+				&cli.DurationFlag{
+					Name:  FlagWindowDuration,
+					Usage: "Only inject faults for this duration from the window start",
+				},
+				&cli.DurationFlag{
+					Name:  FlagWindowPeriod,
+					Usage: "Repeat the window with this period",
+				},
+				&cli.Int64Flag{
+					Name:  FlagSeed,
+					Usage: "Seed of the random generator, random if not set",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				return AdminAddFaultInjectionRule(c, clientFactory)
+			},
+		},
+		{
+			Name:    "clear",
+			Aliases: []string{"c"},
+			Usage:   "Clear the persistence fault injection rules which were added at runtime",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  FlagDataStore,
+					Usage: "Only clear the rules of this data store",
+				},
+				&cli.StringFlag{
+					Name:  FlagMethod,
+					Usage: "Only clear the rules of this method",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				return AdminClearFaultInjectionRules(c, clientFactory)
+			},
+		},
+	}
+}
+
+func newAdminVisibilityCommands(clientFactory ClientFactory) []*cli.Command {
+	return []*cli.Command{
+		{
+			Name:    "aggregate",
+			Aliases: []string{"agg"},
+			Usage:   "Compute aggregate functions over the search attributes of the workflow executions which match a query",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    FlagVisibilityQuery,
+					Aliases: []string{"q"},
+					Usage:   "Visibility query of the executions to aggregate, which may have a GROUP BY clause",
+				},
+				&cli.GenericFlag{
+					Name:     FlagAggregation,
+					Usage:    "Aggregate function, one of min, max, avg, sum and percentile, e.g. \"avg(ExecutionDuration)\" or \"percentile(ExecutionDuration, 95)\", can be repeated",
+					Value:    &aggregationsValue{},
+					Required: true,
+				},
+			},
+			Action: func(c *cli.Context) error {
+				return AdminAggregateWorkflowExecutions(c, clientFactory)
+			},
+		},
+		{
+			Name:  "explain",
+			Usage: "Explain how a visibility query is parsed and translated to the query of the visibility store",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     FlagVisibilityQuery,
+					Aliases:  []string{"q"},
+					Usage:    "Visibility query to explain",
+					Required: true,
+				},
+				&cli.IntFlag{
+					Name:  FlagPageSize,
+					Usage: "Page size of the explained list query, default is the maximum page size of the namespace",
+				},
+				&cli.BoolFlag{
+					Name:  FlagExecute,
+					Usage: "Run the query to report its duration, number of results and the plan of the visibility store",
+				},
+				&cli.BoolFlag{
+					Name:  FlagPrintJSON,
+					Usage: "Print the response as JSON",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				return AdminExplainVisibilityQuery(c, clientFactory)
+			},
+		},
+	}
+}
+
+func newAdminArchivalCommands(clientFactory ClientFactory) []*cli.Command {
+	return []*cli.Command{
+		{
+			Name:  "verify",
+			Usage: "Verify archived workflow histories against their integrity manifests",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    FlagWorkflowID,
+					Aliases: FlagWorkflowIDAlias,
+					Usage:   "Workflow ID of the run to verify, verify the runs matching the query if not set",
+				},
+				&cli.StringFlag{
+					Name:    FlagRunID,
+					Aliases: FlagRunIDAlias,
+					Usage:   "Run ID of the run to verify",
+				},
+				&cli.StringFlag{
+					Name:    FlagVisibilityQuery,
+					Aliases: []string{"q"},
+					Usage:   "Visibility archival query of the runs to verify",
+				},
+				&cli.IntFlag{
+					Name:  FlagPageSize,
+					Usage: "Number of runs verified per request, default is the maximum page size of visibility archival queries",
+				},
+				&cli.BoolFlag{
+					Name:  FlagMore,
+					Usage: "Verify all the runs matching the query, default is to verify one page",
+				},
+				&cli.BoolFlag{
+					Name:  FlagFailuresOnly,
+					Usage: "Only print the runs which failed verification",
+				},
+				&cli.BoolFlag{
+					Name:  FlagPrintJSON,
+					Usage: "Print the results as JSON",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				return AdminVerifyArchivedHistory(c, clientFactory)
+			},
+		},
+	}
+}
+
 func newAdminTaskQueueCommands(clientFactory ClientFactory) []*cli.Command {
 	return []*cli.Command{
 		{
@@ -842,6 +1074,19 @@ func newDecodeCommands(
 					Name:  FlagBinaryFile,
 					Usage: "file with data in binary format.",
 				},
+				&cli.StringFlag{
+					Name:  FlagKeyFile,
+					Usage: "persistence key file to decrypt encrypted data with.",
+				},
+				&cli.StringFlag{
+					Name:  FlagNamespaceID,
+					Usage: "namespace ID of the encrypted data.",
+				},
+				&cli.StringFlag{
+					Name: FlagEncryptionRow,
+					Usage: "row of the encrypted data, i.e. 'execution \"<workflow ID>\" \"<run ID>\" \"activity_info/<scheduled event ID>\"' " +
+						"for mutable state or 'history_node \"<tree ID>\" \"<branch ID>\" <node ID> <transaction ID>' for history events.",
+				},
 			},
 			Action: func(c *cli.Context) error {
 				return AdminDecodeProto(c)
@@ -0,0 +1,164 @@
+package tdbg
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/urfave/cli/v2"
+	commonpb "go.temporal.io/api/common/v1"
+	"go.temporal.io/server/api/adminservice/v1"
+	"go.temporal.io/server/common/payload"
+)
+
+type (
+	// aggregationGroup is the printed form of adminservice.AggregationGroup, with decoded values
+	aggregationGroup struct {
+		GroupValues []any          `json:"groupValues,omitempty"`
+		Count       int64          `json:"count"`
+		Values      map[string]any `json:"values"`
+	}
+
+	aggregationResult struct {
+		Count     int64              `json:"count"`
+		Groups    []aggregationGroup `json:"groups"`
+		Truncated bool               `json:"truncated,omitempty"`
+	}
+
+	// aggregationsValue is the value of the repeatable aggregation flag. Unlike cli.StringSliceFlag, it doesn't split
+	// the values on commas, which separate the arguments of the percentile function.
+	aggregationsValue struct {
+		aggregations []string
+	}
+)
+
+func (v *aggregationsValue) Set(value string) error {
+	v.aggregations = append(v.aggregations, value)
+	return nil
+}
+
+func (v *aggregationsValue) String() string {
+	return strings.Join(v.aggregations, ", ")
+}
+
+// AdminAggregateWorkflowExecutions computes aggregate functions over the search attributes of workflow executions
+func AdminAggregateWorkflowExecutions(c *cli.Context, clientFactory ClientFactory) error {
+	nsName, err := getRequiredOption(c, FlagNamespace)
+	if err != nil {
+		return err
+	}
+	aggregations := c.Generic(FlagAggregation).(*aggregationsValue).aggregations
+
+	adminClient := clientFactory.AdminClient(c)
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	resp, err := adminClient.AggregateWorkflowExecutions(ctx, &adminservice.AggregateWorkflowExecutionsRequest{
+		Namespace:    nsName,
+		Query:        c.String(FlagVisibilityQuery),
+		Aggregations: aggregations,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to aggregate workflow executions: %w", err)
+	}
+
+	result := aggregationResult{
+		Count:     resp.GetCount(),
+		Groups:    make([]aggregationGroup, 0, len(resp.GetGroups())),
+		Truncated: resp.GetTruncated(),
+	}
+	for _, group := range resp.GetGroups() {
+		groupValues, err := decodePayloads(group.GetGroupValues())
+		if err != nil {
+			return err
+		}
+		values, err := decodePayloads(group.GetValues())
+		if err != nil {
+			return err
+		}
+		g := aggregationGroup{
+			GroupValues: groupValues,
+			Count:       group.GetCount(),
+			Values:      make(map[string]any, len(values)),
+		}
+		for i, value := range values {
+			if i < len(aggregations) {
+				g.Values[aggregations[i]] = value
+			}
+		}
+		result.Groups = append(result.Groups, g)
+	}
+	prettyPrintJSONObject(c, result)
+	return nil
+}
+
+// AdminExplainVisibilityQuery explains how a visibility query is parsed and translated to the query of the visibility store
+func AdminExplainVisibilityQuery(c *cli.Context, clientFactory ClientFactory) error {
+	nsName, err := getRequiredOption(c, FlagNamespace)
+	if err != nil {
+		return err
+	}
+
+	adminClient := clientFactory.AdminClient(c)
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	resp, err := adminClient.ExplainVisibilityQuery(ctx, &adminservice.ExplainVisibilityQueryRequest{
+		Namespace: nsName,
+		Query:     c.String(FlagVisibilityQuery),
+		PageSize:  int32(c.Int(FlagPageSize)),
+		Execute:   c.Bool(FlagExecute),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to explain visibility query: %w", err)
+	}
+
+	if c.Bool(FlagPrintJSON) {
+		prettyPrintJSONObject(c, resp)
+		return nil
+	}
+
+	w := c.App.Writer
+	// nolint:errcheck // assuming that write will succeed.
+	fmt.Fprintln(w, color.GreenString("AST:"))
+	// nolint:errcheck // assuming that write will succeed.
+	fmt.Fprint(w, resp.GetAst())
+	// nolint:errcheck // assuming that write will succeed.
+	fmt.Fprintln(w, color.GreenString("Search attributes:"))
+	for _, sa := range resp.GetSearchAttributes() {
+		// nolint:errcheck // assuming that write will succeed.
+		fmt.Fprintf(w, "  %s -> %s (%s)\n", sa.GetName(), sa.GetFieldName(), sa.GetType())
+	}
+	// nolint:errcheck // assuming that write will succeed.
+	fmt.Fprintf(w, "%s %s\n", color.GreenString("Store:"), resp.GetStoreName())
+	if resp.GetStoreQuery() != "" {
+		// nolint:errcheck // assuming that write will succeed.
+		fmt.Fprintln(w, color.GreenString("Store query:"))
+		// nolint:errcheck // assuming that write will succeed.
+		fmt.Fprintln(w, resp.GetStoreQuery())
+	}
+	if !c.Bool(FlagExecute) {
+		return nil
+	}
+	// nolint:errcheck // assuming that write will succeed.
+	fmt.Fprintf(w, "%s %v\n", color.GreenString("Duration:"), resp.GetDuration().AsDuration())
+	// nolint:errcheck // assuming that write will succeed.
+	fmt.Fprintf(w, "%s %d\n", color.GreenString("Count:"), resp.GetCount())
+	if resp.GetPlan() != "" {
+		// nolint:errcheck // assuming that write will succeed.
+		fmt.Fprintln(w, color.GreenString("Plan:"))
+		// nolint:errcheck // assuming that write will succeed.
+		fmt.Fprintln(w, resp.GetPlan())
+	}
+	return nil
+}
+
+func decodePayloads(payloads []*commonpb.Payload) ([]any, error) {
+	values := make([]any, len(payloads))
+	for i, p := range payloads {
+		if err := payload.Decode(p, &values[i]); err != nil {
+			return nil, fmt.Errorf("unable to decode value %v: %w", payload.ToString(p), err)
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,142 @@
+package tdbg
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/server/api/adminservice/v1"
+	"go.temporal.io/server/common/payload"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+type visibilityTestClient struct {
+	adminservice.AdminServiceClient
+	requests        []*adminservice.AggregateWorkflowExecutionsRequest
+	explainRequests []*adminservice.ExplainVisibilityQueryRequest
+}
+
+func (t *visibilityTestClient) AdminClient(*cli.Context) adminservice.AdminServiceClient {
+	return t
+}
+
+func (t *visibilityTestClient) WorkflowClient(*cli.Context) workflowservice.WorkflowServiceClient {
+	panic("unimplemented")
+}
+
+func (t *visibilityTestClient) AggregateWorkflowExecutions(_ context.Context, request *adminservice.AggregateWorkflowExecutionsRequest, _ ...grpc.CallOption) (*adminservice.AggregateWorkflowExecutionsResponse, error) {
+	t.requests = append(t.requests, request)
+	value, err := payload.Encode(1.5)
+	if err != nil {
+		return nil, err
+	}
+	nullValue, err := payload.Encode(nil)
+	if err != nil {
+		return nil, err
+	}
+	return &adminservice.AggregateWorkflowExecutionsResponse{
+		Count: 2,
+		Groups: []*adminservice.AggregationGroup{{
+			GroupValues: []*commonpb.Payload{payload.EncodeString("Running")},
+			Count:       2,
+			Values:      []*commonpb.Payload{value, nullValue},
+		}},
+	}, nil
+}
+
+func (t *visibilityTestClient) ExplainVisibilityQuery(_ context.Context, request *adminservice.ExplainVisibilityQueryRequest, _ ...grpc.CallOption) (*adminservice.ExplainVisibilityQueryResponse, error) {
+	t.explainRequests = append(t.explainRequests, request)
+	return &adminservice.ExplainVisibilityQueryResponse{
+		Ast: "Where\n  Comparison =\n    Column AliasForKeyword01\n    Value 'foo'\n",
+		SearchAttributes: []*adminservice.ExplainedSearchAttribute{{
+			Name:      "AliasForKeyword01",
+			FieldName: "Keyword01",
+			Type:      enumspb.INDEXED_VALUE_TYPE_KEYWORD,
+		}},
+		StoreName:  "sqlite",
+		StoreQuery: "SELECT * FROM executions_visibility WHERE Keyword01 = ?",
+		Plan:       "SCAN executions_visibility",
+		Duration:   durationpb.New(time.Millisecond),
+		Count:      3,
+	}, nil
+}
+
+func TestVisibilityAggregateCommand(t *testing.T) {
+	client := &visibilityTestClient{}
+	var out bytes.Buffer
+	app := NewCliApp(func(params *Params) {
+		params.ClientFactory = client
+		params.Writer = &out
+	})
+	app.ExitErrHandler = func(context *cli.Context, err error) {}
+
+	require.NoError(t, app.Run([]string{"tdbg", "--namespace", "test-namespace", "visibility", "aggregate",
+		"--query", "GROUP BY ExecutionStatus",
+		"--aggregation", "avg(ExecutionDuration)", "--aggregation", "percentile(ExecutionDuration, 95)",
+	}))
+	require.Len(t, client.requests, 1)
+	require.Equal(t, "test-namespace", client.requests[0].GetNamespace())
+	require.Equal(t, "GROUP BY ExecutionStatus", client.requests[0].GetQuery())
+	require.Equal(t, []string{"avg(ExecutionDuration)", "percentile(ExecutionDuration, 95)"}, client.requests[0].GetAggregations())
+
+	var result aggregationResult
+	require.NoError(t, json.Unmarshal(out.Bytes(), &result))
+	require.Equal(t, aggregationResult{
+		Count: 2,
+		Groups: []aggregationGroup{{
+			GroupValues: []any{"Running"},
+			Count:       2,
+			Values: map[string]any{
+				"avg(ExecutionDuration)":            1.5,
+				"percentile(ExecutionDuration, 95)": nil,
+			},
+		}},
+	}, result)
+
+	require.Error(t, app.Run([]string{"tdbg", "vis", "agg", "--query", "WorkflowType = 'test'"}))
+	require.Len(t, client.requests, 1)
+}
+
+func TestVisibilityExplainCommand(t *testing.T) {
+	client := &visibilityTestClient{}
+	var out bytes.Buffer
+	app := NewCliApp(func(params *Params) {
+		params.ClientFactory = client
+		params.Writer = &out
+	})
+	app.ExitErrHandler = func(context *cli.Context, err error) {}
+
+	require.NoError(t, app.Run([]string{"tdbg", "--namespace", "test-namespace", "visibility", "explain",
+		"--query", "AliasForKeyword01 = 'foo'",
+	}))
+	require.Len(t, client.explainRequests, 1)
+	require.Equal(t, "test-namespace", client.explainRequests[0].GetNamespace())
+	require.Equal(t, "AliasForKeyword01 = 'foo'", client.explainRequests[0].GetQuery())
+	require.False(t, client.explainRequests[0].GetExecute())
+	require.Contains(t, out.String(), "Column AliasForKeyword01")
+	require.Contains(t, out.String(), "AliasForKeyword01 -> Keyword01 (Keyword)")
+	require.Contains(t, out.String(), "SELECT * FROM executions_visibility WHERE Keyword01 = ?")
+	require.NotContains(t, out.String(), "SCAN executions_visibility")
+
+	out.Reset()
+	require.NoError(t, app.Run([]string{"tdbg", "--namespace", "test-namespace", "vis", "explain",
+		"-q", "AliasForKeyword01 = 'foo'", "--pagesize", "10", "--execute",
+	}))
+	require.Len(t, client.explainRequests, 2)
+	require.True(t, client.explainRequests[1].GetExecute())
+	require.Equal(t, int32(10), client.explainRequests[1].GetPageSize())
+	require.Contains(t, out.String(), "1ms")
+	require.Contains(t, out.String(), "3")
+	require.Contains(t, out.String(), "SCAN executions_visibility")
+
+	require.Error(t, app.Run([]string{"tdbg", "--namespace", "test-namespace", "vis", "explain"}))
+	require.Len(t, client.explainRequests, 2)
+}